@@ -0,0 +1,81 @@
+// Command index-migrate brings a membership index database up to the schema version of this
+// build. The server migrates its index when it starts; this tool migrates an index ahead of
+// an upgrade, or reports which migrations it is missing.
+//
+//	index-migrate -database ./data/pod-storage/membership.db
+//	index-migrate -driver postgres -host db.internal -database goro -user goro -status
+//
+// The PostgreSQL password is read from GORO_INDEX_DB_PASSWORD.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+)
+
+func main() {
+	driver := flag.String("driver", "sqlite3", "index database driver: sqlite3 or postgres")
+	database := flag.String("database", "", "SQLite index file, or PostgreSQL database name")
+	host := flag.String("host", "localhost", "PostgreSQL host")
+	port := flag.Int("port", 5432, "PostgreSQL port")
+	user := flag.String("user", "", "PostgreSQL user")
+	sslMode := flag.String("sslmode", "disable", "PostgreSQL SSL mode")
+	status := flag.Bool("status", false, "report the schema version and pending migrations without applying them")
+	flag.Parse()
+
+	if err := run(infrastructure.DatabaseConfig{
+		Driver:   *driver,
+		Host:     *host,
+		Port:     *port,
+		Database: *database,
+		Username: *user,
+		Password: os.Getenv("GORO_INDEX_DB_PASSWORD"),
+		SSLMode:  *sslMode,
+	}, *status); err != nil {
+		fmt.Fprintf(os.Stderr, "index-migrate: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run reports the index's schema version and, unless only the status is asked for, applies
+// its pending migrations
+func run(config infrastructure.DatabaseConfig, statusOnly bool) error {
+	if config.Database == "" {
+		return fmt.Errorf("-database is required")
+	}
+	// Opening a missing SQLite file would create an empty index; a mistyped path should not
+	if driver := strings.ToLower(config.Driver); driver == "sqlite3" || driver == "sqlite" {
+		if _, err := os.Stat(config.Database); err != nil {
+			return fmt.Errorf("index file %s: %w", config.Database, err)
+		}
+	}
+
+	db, err := infrastructure.NewDatabaseConnection(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	current, err := infrastructure.MembershipIndexSchemaVersion(db, config.Driver)
+	if err != nil {
+		return err
+	}
+	pending := infrastructure.PendingMigrations(current)
+	fmt.Printf("schema version %d, latest %d, %d pending\n", current, infrastructure.LatestSchemaVersion(), len(pending))
+	for _, migration := range pending {
+		fmt.Printf("  %d: %s\n", migration.Version, migration.Description)
+	}
+	if statusOnly || len(pending) == 0 {
+		return nil
+	}
+
+	if err := infrastructure.MigrateMembershipIndex(db, config.Driver); err != nil {
+		return err
+	}
+	fmt.Printf("migrated to schema version %d\n", infrastructure.LatestSchemaVersion())
+	return nil
+}
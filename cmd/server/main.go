@@ -32,6 +32,8 @@ var (
 	flagconf = flag.String("conf", "../../configs", "config path, eg: -conf config.yaml")
 )
 
+// newApp registers both transports for coordinated shutdown: on stop each closes its listener,
+// drains in-flight requests within the shutdown timeout and logs when it has finished
 func newApp(logger log.Logger, hs *http.Server, gs *grpc.Server, config *conf.Server) *kratos.App {
 	// Configure shutdown timeout from HTTP config
 	var shutdownTimeout time.Duration = 10 * time.Second // default
@@ -46,8 +48,8 @@ func newApp(logger log.Logger, hs *http.Server, gs *grpc.Server, config *conf.Se
 		kratos.Metadata(map[string]string{}),
 		kratos.Logger(logger),
 		kratos.Server(
-			hs,
-			gs,
+			newDrainingHTTPServer(hs, logger),
+			newDrainingGRPCServer(gs, logger),
 		),
 		kratos.StopTimeout(shutdownTimeout),
 	)
@@ -90,6 +92,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// Repositories are released only after app.Run returns, once both transports have drained
 	defer func() {
 		log.Info("Cleaning up resources...")
 		cleanup()
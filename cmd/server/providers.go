@@ -1,5 +1,21 @@
 package main
 
+import (
+	userDomain "github.com/akeemphilbert/goro/internal/user/domain"
+	userInfrastructure "github.com/akeemphilbert/goro/internal/user/infrastructure"
+	"gorm.io/gorm"
+)
+
 // This file previously contained manual dependency creation.
 // All dependencies are now handled through Wire dependency injection.
 // See wire.go for the Wire configuration.
+
+// NewAccountRepositoryProvider reads user accounts from the server database, migrating the
+// user tables into it first
+func NewAccountRepositoryProvider(db *gorm.DB) (userDomain.AccountRepository, error) {
+	userDB, err := userInfrastructure.ProvideUserDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	return userInfrastructure.ProvideAccountRepository(userDB)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// drainingServer wraps a transport so shutdown stops its listener, waits for in-flight requests
+// and streams to finish within the stop deadline, and then closes whatever is still open.
+// Kratos stops every registered server concurrently with the same deadline, so both transports
+// stop accepting together and drain in parallel; repositories are released only once app.Run
+// returns after both have drained.
+type drainingServer struct {
+	name   string
+	server transport.Server
+	// drain stops accepting new work and blocks until in-flight work completes
+	drain func(ctx context.Context) error
+	// force closes connections that are still open when the deadline passes
+	force func()
+	log   *log.Helper
+}
+
+// newDrainingHTTPServer wraps the HTTP server. Shutdown closes the listener and idle
+// connections, then waits for active requests; long-lived responses such as event streams
+// are cut off when the deadline passes.
+func newDrainingHTTPServer(hs *http.Server, logger log.Logger) *drainingServer {
+	return &drainingServer{
+		name:   "HTTP",
+		server: hs,
+		drain:  hs.Stop,
+		force: func() {
+			_ = hs.Close()
+		},
+		log: log.NewHelper(logger),
+	}
+}
+
+// newDrainingGRPCServer wraps the gRPC server. GracefulStop closes the listener and waits for
+// pending RPCs and open streams without regard to the deadline, so the hard stop bounds it.
+func newDrainingGRPCServer(gs *grpc.Server, logger log.Logger) *drainingServer {
+	return &drainingServer{
+		name:   "gRPC",
+		server: gs,
+		drain:  gs.Stop,
+		force:  gs.Server.Stop,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// Start starts the wrapped transport
+func (s *drainingServer) Start(ctx context.Context) error {
+	return s.server.Start(ctx)
+}
+
+// Stop drains the wrapped transport, forcing remaining connections closed at the deadline
+func (s *drainingServer) Stop(ctx context.Context) error {
+	started := time.Now()
+	s.log.Infof("[%s] stopping listener and draining in-flight requests", s.name)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.drain(ctx)
+	}()
+
+	var err error
+	drained := false
+	select {
+	case err = <-done:
+		drained = true
+		if err == nil {
+			s.log.Infof("[%s] drained in %v", s.name, time.Since(started))
+			return nil
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			s.log.Errorf("[%s] drain failed after %v: %v", s.name, time.Since(started), err)
+			return err
+		}
+	case <-ctx.Done():
+	}
+
+	s.log.Warnf("[%s] drain deadline reached after %v, closing remaining connections", s.name, time.Since(started))
+	s.force()
+	if !drained {
+		<-done
+	}
+	s.log.Infof("[%s] stopped", s.name)
+	return nil
+}
+
+// Endpoint returns the wrapped transport's endpoint so service registration still works
+func (s *drainingServer) Endpoint() (*url.URL, error) {
+	endpointer, ok := s.server.(transport.Endpointer)
+	if !ok {
+		return nil, errors.New("transport does not expose an endpoint")
+	}
+	return endpointer.Endpoint()
+}
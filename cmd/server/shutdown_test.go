@@ -1,6 +1,10 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"io"
+	stdhttp "net/http"
 	"os"
 	"syscall"
 	"testing"
@@ -8,6 +12,7 @@ import (
 
 	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -302,3 +307,104 @@ func TestKratosAppLifecycleManagement(t *testing.T) {
 		t.Fatal("App did not shutdown within timeout")
 	}
 }
+
+func TestDrainingServerStop(t *testing.T) {
+	logger := log.NewStdLogger(os.Stdout)
+
+	t.Run("returns once in-flight requests drain", func(t *testing.T) {
+		forced := false
+		srv := &drainingServer{
+			name:  "test",
+			drain: func(ctx context.Context) error { return nil },
+			force: func() { forced = true },
+			log:   log.NewHelper(logger),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, srv.Stop(ctx))
+		assert.False(t, forced, "drained transports should not be force closed")
+	})
+
+	t.Run("closes remaining connections at the deadline", func(t *testing.T) {
+		// A stream that never finishes on its own holds the drain open until forced
+		release := make(chan struct{})
+		srv := &drainingServer{
+			name: "test",
+			drain: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+			force: func() { close(release) },
+			log:   log.NewHelper(logger),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		started := time.Now()
+		assert.NoError(t, srv.Stop(ctx))
+		assert.Less(t, time.Since(started), time.Second)
+	})
+
+	t.Run("forces close when the drain itself reports the deadline", func(t *testing.T) {
+		forced := false
+		srv := &drainingServer{
+			name:  "test",
+			drain: func(ctx context.Context) error { return context.DeadlineExceeded },
+			force: func() { forced = true },
+			log:   log.NewHelper(logger),
+		}
+
+		assert.NoError(t, srv.Stop(context.Background()))
+		assert.True(t, forced)
+	})
+
+	t.Run("reports drain failures", func(t *testing.T) {
+		srv := &drainingServer{
+			name:  "test",
+			drain: func(ctx context.Context) error { return errors.New("listener close failed") },
+			force: func() {},
+			log:   log.NewHelper(logger),
+		}
+
+		assert.Error(t, srv.Stop(context.Background()))
+	})
+}
+
+func TestDrainingHTTPServerClosesStreamsAtDeadline(t *testing.T) {
+	streaming := make(chan struct{})
+	hs := http.NewServer(http.Address("127.0.0.1:0"))
+	hs.HandleFunc("/stream", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.WriteHeader(stdhttp.StatusOK)
+		w.(stdhttp.Flusher).Flush()
+		close(streaming)
+		<-r.Context().Done()
+	})
+
+	endpoint, err := hs.Endpoint()
+	require.NoError(t, err)
+	go func() {
+		_ = hs.Start(context.Background())
+	}()
+
+	go func() {
+		resp, err := stdhttp.Get(endpoint.String() + "/stream")
+		if err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	}()
+	select {
+	case <-streaming:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not start")
+	}
+
+	srv := newDrainingHTTPServer(hs, log.NewStdLogger(os.Stdout))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	assert.NoError(t, srv.Stop(ctx))
+	assert.Less(t, time.Since(started), 2*time.Second, "open streams should not hold shutdown past the deadline")
+}
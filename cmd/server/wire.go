@@ -20,6 +20,7 @@ import (
 	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/handlers"
 	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	userApplication "github.com/akeemphilbert/goro/internal/user/application"
 )
 
 // wireApp init kratos application.
@@ -28,7 +29,7 @@ func wireApp(*conf.Server, log.Logger) (*kratos.App, func(), error) {
 }
 
 // newAppWithCleanup creates both the app and cleanup function
-func newAppWithCleanup(logger log.Logger, hs *http.Server, gs *grpc.Server, config *conf.Server, initService *application.InitializationService) (*kratos.App, func()) {
+func newAppWithCleanup(logger log.Logger, hs *http.Server, gs *grpc.Server, config *conf.Server, initService *application.InitializationService, eventMirror *application.EventMirror) (*kratos.App, func()) {
 	// Initialize the system (create root container, etc.)
 	ctx := context.Background()
 	if err := initService.Initialize(ctx); err != nil {
@@ -38,8 +39,10 @@ func newAppWithCleanup(logger log.Logger, hs *http.Server, gs *grpc.Server, conf
 
 	app := newApp(logger, hs, gs, config)
 	cleanup := func() {
-		// Simple cleanup function for testing
-		// In production, this would handle resource cleanup
+		// Release the message broker connection events are mirrored over
+		if err := eventMirror.Close(); err != nil {
+			log.Errorf("Failed to close message broker connection: %v", err)
+		}
 	}
 	return app, cleanup
 }
@@ -58,7 +61,14 @@ var ProviderSet = wire.NewSet(
 
 	NewGRPCServer,
 	NewHTTPServerProvider,
-	wire.FieldsOf(new(*conf.Server), "HTTP", "GRPC", "Container"),
+
+	// Accounts opt in to read auditing and set storage quotas through their settings
+	NewAccountRepositoryProvider,
+	userApplication.NewAccountReadAuditPolicy,
+	wire.Bind(new(application.ReadAuditPolicy), new(*userApplication.AccountReadAuditPolicy)),
+	userApplication.NewAccountStorageQuotaPolicy,
+	wire.Bind(new(application.StorageQuotaPolicy), new(*userApplication.AccountStorageQuotaPolicy)),
+	wire.FieldsOf(new(*conf.Server), "HTTP", "GRPC", "Container", "Audit", "Auth"),
 )
 
 // NewGRPCServer creates a new gRPC server
@@ -83,8 +93,29 @@ func NewHTTPServerProvider(
 	requestResponseHandler *handlers.RequestResponseHandler,
 	resourceHandler *handlers.ResourceHandler,
 	containerHandler *handlers.ContainerHandler,
+	adminHandler *handlers.AdminHandler,
+	solidNotificationHandler *handlers.SolidNotificationHandler,
+	subscriptionHandler *handlers.ContainerSubscriptionHandler,
+	capabilitiesHandler *handlers.ServerCapabilitiesHandler,
+	accessControlHandler *handlers.WebAccessControlHandler,
+	auth *conf.Auth,
 	// userHandler *handlers.UserHandler,
 	// accountHandler *handlers.AccountHandler,
-) *http.Server {
-	return httpServer.NewHTTPServer(c, logger, healthHandler, requestResponseHandler, resourceHandler, containerHandler, nil, nil)
+) (*http.Server, error) {
+	srv := httpServer.NewHTTPServer(c, logger, healthHandler, requestResponseHandler, resourceHandler, containerHandler, nil, nil)
+	httpServer.RegisterAdminRoutes(srv, adminHandler)
+	httpServer.RegisterSolidNotificationRoutes(srv, solidNotificationHandler)
+	httpServer.RegisterContainerSubscriptionRoutes(srv, subscriptionHandler)
+	httpServer.RegisterWebAccessControl(srv, accessControlHandler)
+	if err := httpServer.RegisterAuthentication(srv, auth); err != nil {
+		return nil, err
+	}
+	if err := httpServer.RegisterDPoP(srv, auth); err != nil {
+		return nil, err
+	}
+	httpServer.RegisterServerCapabilities(srv, capabilitiesHandler)
+	if err := httpServer.RegisterHTTPSOnlyPaths(srv, auth, logger); err != nil {
+		return nil, err
+	}
+	return srv, nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
@@ -11,13 +12,13 @@ import (
 
 func TestWireComponents_InfrastructureLayer(t *testing.T) {
 	t.Run("Database provider", func(t *testing.T) {
-		db, err := infrastructure.DatabaseProvider()
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 		assert.NotNil(t, db)
 	})
 
 	t.Run("Event store provider", func(t *testing.T) {
-		db, err := infrastructure.DatabaseProvider()
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := infrastructure.EventStoreProvider(db)
@@ -43,7 +44,7 @@ func TestWireComponents_InfrastructureLayer(t *testing.T) {
 	})
 
 	t.Run("Unit of work factory provider", func(t *testing.T) {
-		db, err := infrastructure.DatabaseProvider()
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := infrastructure.EventStoreProvider(db)
@@ -69,7 +70,7 @@ func TestWireComponents_ApplicationLayer(t *testing.T) {
 
 		converter := infrastructure.NewRDFConverter()
 
-		db, err := infrastructure.DatabaseProvider()
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := infrastructure.EventStoreProvider(db)
@@ -81,7 +82,7 @@ func TestWireComponents_ApplicationLayer(t *testing.T) {
 		factory := infrastructure.NewUnitOfWorkFactory(eventStore, eventDispatcher)
 
 		// Create storage service
-		service, err := application.NewStorageServiceProvider(repo, converter, factory, eventDispatcher)
+		service, err := application.NewStorageServiceProvider(repo, converter, factory, eventDispatcher, nil, nil, nil, nil, nil, nil, nil, nil)
 		require.NoError(t, err)
 		assert.NotNil(t, service)
 	})
@@ -7,11 +7,13 @@
 package main
 
 import (
+	"context"
 	"github.com/akeemphilbert/goro/internal/conf"
 	http2 "github.com/akeemphilbert/goro/internal/infrastructure/transport/http"
 	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/handlers"
 	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	application2 "github.com/akeemphilbert/goro/internal/user/application"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
@@ -49,26 +51,88 @@ func wireApp(server *conf.Server, logger log.Logger) (*kratos.App, func(), error
 		return nil, nil, err
 	}
 	v := infrastructure.NewUnitOfWorkFactory(gormEventStore, eventDispatcher)
-	storageService, err := application.NewStorageServiceProvider(streamingResourceRepository, rdfConverter, v, eventDispatcher)
+	searchIndex := infrastructure.NewSearchIndexProvider()
+	container := server.Container
+	eventRetry := application.NewEventRetryProvider(container)
+	containerRepository, err := infrastructure.NewFileSystemContainerRepositoryProvider(container)
 	if err != nil {
 		return nil, nil, err
 	}
-	resourceHandler := handlers.NewResourceHandlerProvider(storageService, logger)
-	container := server.Container
-	containerRepository, err := infrastructure.NewFileSystemContainerRepositoryProvider(container)
+	storageUsageStore, err := infrastructure.NewStorageUsageStoreProvider(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	identifierIndex, err := infrastructure.NewIdentifierIndexProvider(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	accountRepository, err := NewAccountRepositoryProvider(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	accountStorageQuotaPolicy := application2.NewAccountStorageQuotaPolicy(accountRepository)
+	resourceVersionStore, err := infrastructure.NewResourceVersionStoreProvider(db, container)
+	if err != nil {
+		return nil, nil, err
+	}
+	storageService, err := application.NewStorageServiceProvider(streamingResourceRepository, rdfConverter, v, eventDispatcher, searchIndex, container, eventRetry, containerRepository, storageUsageStore, identifierIndex, accountStorageQuotaPolicy, resourceVersionStore)
+	if err != nil {
+		return nil, nil, err
+	}
+	audit := server.Audit
+	accountReadAuditPolicy := application2.NewAccountReadAuditPolicy(accountRepository)
+	readAuditor, err := application.NewReadAuditorProvider(audit, accountReadAuditPolicy, containerRepository)
+	if err != nil {
+		return nil, nil, err
+	}
+	containerRDFConverter, err := infrastructure.NewContainerRDFConverterProvider(container)
 	if err != nil {
 		return nil, nil, err
 	}
-	containerRDFConverter := infrastructure.NewContainerRDFConverter()
-	containerService, err := application.NewContainerServiceProvider(containerRepository, v, eventDispatcher, containerRDFConverter)
+	auth := server.Auth
+	resourceACLSource := infrastructure.NewResourceACLSourceProvider(streamingResourceRepository)
+	webAccessControl := application.NewWebAccessControlProvider(auth, resourceACLSource, containerRepository)
+	containerService, err := application.NewContainerServiceProvider(containerRepository, v, eventDispatcher, containerRDFConverter, container, searchIndex, eventRetry, streamingResourceRepository, identifierIndex, webAccessControl)
+	if err != nil {
+		return nil, nil, err
+	}
+	resourceAccessTracker := application.NewResourceAccessTrackerProvider(container, containerRepository)
+	uploadStore, err := infrastructure.NewUploadStoreProvider(container)
+	if err != nil {
+		return nil, nil, err
+	}
+	uploadService := application.NewUploadServiceProvider(container, storageService, uploadStore)
+	resourceHandler := handlers.NewResourceHandlerProvider(storageService, containerService, readAuditor, resourceAccessTracker, webAccessControl, uploadService, container, logger)
+	operationGate := application.NewOperationGateProvider(container)
+	containerHandler := handlers.NewContainerHandlerProvider(containerService, storageService, readAuditor, operationGate, webAccessControl, container, logger)
+	gormEventLogReader := infrastructure.NewGormEventLogReader(db)
+	eventLogExporter := application.NewEventLogExporter(gormEventLogReader)
+	retentionSweeper := application.NewRetentionSweeperProvider(container, containerService, storageService)
+	adminHandler := handlers.NewAdminHandlerProvider(auth, eventRetry, eventLogExporter, retentionSweeper, containerService, logger)
+	solidNotificationService, err := application.NewSolidNotificationServiceProvider(container, containerRepository, eventDispatcher, webAccessControl)
+	if err != nil {
+		return nil, nil, err
+	}
+	solidNotificationHandler := handlers.NewSolidNotificationHandlerProvider(solidNotificationService, logger)
+	containerChangeHub, err := application.NewContainerChangeHubProvider(container, containerRepository, streamingResourceRepository, eventDispatcher)
+	if err != nil {
+		return nil, nil, err
+	}
+	containerSubscriptionHandler := handlers.NewContainerSubscriptionHandlerProvider(containerChangeHub, webAccessControl, container, logger)
+	serverCapabilitiesHandler := handlers.NewServerCapabilitiesHandlerProvider(container, auth, logger)
+	webAccessControlHandler := handlers.NewWebAccessControlHandlerProvider(webAccessControl, logger)
+	httpServer, err := NewHTTPServerProvider(http, logger, healthHandler, requestResponseHandler, resourceHandler, containerHandler, adminHandler, solidNotificationHandler, containerSubscriptionHandler, serverCapabilitiesHandler, webAccessControlHandler, auth)
 	if err != nil {
 		return nil, nil, err
 	}
-	containerHandler := handlers.NewContainerHandlerProvider(containerService, storageService, logger)
-	httpServer := NewHTTPServerProvider(http, logger, healthHandler, requestResponseHandler, resourceHandler, containerHandler)
 	grpc := server.GRPC
 	grpcServer := NewGRPCServer(grpc, logger)
-	app, cleanup := newAppWithCleanup(logger, httpServer, grpcServer, server)
+	eventMirror, err := application.NewEventMirrorProvider(container, eventDispatcher, eventRetry)
+	if err != nil {
+		return nil, nil, err
+	}
+	initializationService := application.NewInitializationServiceProvider(containerRepository, storageUsageStore, logger)
+	app, cleanup := newAppWithCleanup(logger, httpServer, grpcServer, server, initializationService, eventMirror)
 	return app, func() {
 		cleanup()
 	}, nil
@@ -77,17 +141,27 @@ func wireApp(server *conf.Server, logger log.Logger) (*kratos.App, func(), error
 // wire.go:
 
 // newAppWithCleanup creates both the app and cleanup function
-func newAppWithCleanup(logger log.Logger, hs *http.Server, gs *grpc.Server, config *conf.Server) (*kratos.App, func()) {
+func newAppWithCleanup(logger log.Logger, hs *http.Server, gs *grpc.Server, config *conf.Server, initService *application.InitializationService, eventMirror *application.EventMirror) (*kratos.App, func()) {
+
+	ctx := context.Background()
+	if err := initService.Initialize(ctx); err != nil {
+		log.Errorf("Failed to initialize system: %v", err)
+		panic(err)
+	}
+
 	app := newApp(logger, hs, gs, config)
 	cleanup := func() {
 
+		if err := eventMirror.Close(); err != nil {
+			log.Errorf("Failed to close message broker connection: %v", err)
+		}
 	}
 	return app, cleanup
 }
 
 // ProviderSet is the provider set for Wire dependency injection
 var ProviderSet = wire.NewSet(handlers.NewHealthHandler, handlers.NewRequestResponseHandler, handlers.ProviderSet, application.ProviderSet, infrastructure.InfrastructureSet, NewGRPCServer,
-	NewHTTPServerProvider, wire.FieldsOf(new(*conf.Server), "HTTP", "GRPC", "Container"),
+	NewHTTPServerProvider, NewAccountRepositoryProvider, application2.NewAccountReadAuditPolicy, wire.Bind(new(application.ReadAuditPolicy), new(*application2.AccountReadAuditPolicy)), application2.NewAccountStorageQuotaPolicy, wire.Bind(new(application.StorageQuotaPolicy), new(*application2.AccountStorageQuotaPolicy)), wire.FieldsOf(new(*conf.Server), "HTTP", "GRPC", "Container", "Audit", "Auth"),
 )
 
 // NewGRPCServer creates a new gRPC server
@@ -109,6 +183,27 @@ func NewHTTPServerProvider(
 	requestResponseHandler *handlers.RequestResponseHandler,
 	resourceHandler *handlers.ResourceHandler,
 	containerHandler *handlers.ContainerHandler,
-) *http.Server {
-	return http2.NewHTTPServer(c, logger, healthHandler, requestResponseHandler, resourceHandler, containerHandler)
+	adminHandler *handlers.AdminHandler,
+	solidNotificationHandler *handlers.SolidNotificationHandler,
+	subscriptionHandler *handlers.ContainerSubscriptionHandler,
+	capabilitiesHandler *handlers.ServerCapabilitiesHandler,
+	accessControlHandler *handlers.WebAccessControlHandler,
+	auth *conf.Auth,
+) (*http.Server, error) {
+	srv := http2.NewHTTPServer(c, logger, healthHandler, requestResponseHandler, resourceHandler, containerHandler, nil, nil)
+	http2.RegisterAdminRoutes(srv, adminHandler)
+	http2.RegisterSolidNotificationRoutes(srv, solidNotificationHandler)
+	http2.RegisterContainerSubscriptionRoutes(srv, subscriptionHandler)
+	http2.RegisterWebAccessControl(srv, accessControlHandler)
+	if err := http2.RegisterAuthentication(srv, auth); err != nil {
+		return nil, err
+	}
+	if err := http2.RegisterDPoP(srv, auth); err != nil {
+		return nil, err
+	}
+	http2.RegisterServerCapabilities(srv, capabilitiesHandler)
+	if err := http2.RegisterHTTPSOnlyPaths(srv, auth, logger); err != nil {
+		return nil, err
+	}
+	return srv, nil
 }
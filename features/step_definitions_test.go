@@ -88,6 +88,31 @@ func (m *MockStorageService) StoreResourceStream(ctx context.Context, id string,
 	return m.StoreResource(ctx, id, data, contentType)
 }
 
+// PatchResource rejects every patch, since the mock stores resources without parsing them
+func (m *MockStorageService) PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error) {
+	if _, exists := m.resources[id]; !exists {
+		return nil, domain.ErrResourceNotFound
+	}
+	return nil, domain.ErrUnsupportedFormat
+}
+
+// QueryResourceTriples rejects every pattern, since the mock stores resources without parsing them
+func (m *MockStorageService) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	if _, exists := m.resources[id]; !exists {
+		return nil, domain.ErrResourceNotFound
+	}
+	return nil, domain.ErrUnsupportedFormat
+}
+
+// DiffResourceVersions reports versioning as disabled, since the mock keeps only the latest
+// content of each resource
+func (m *MockStorageService) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	if _, exists := m.resources[id]; !exists {
+		return domain.GraphDiff{}, domain.ErrResourceNotFound
+	}
+	return domain.GraphDiff{}, domain.ErrVersioningDisabled
+}
+
 func (m *MockStorageService) simulateFormatConversion(data []byte, fromFormat, toFormat string) []byte {
 	// Simple simulation of format conversion for BDD tests
 	dataStr := string(data)
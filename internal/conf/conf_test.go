@@ -227,3 +227,804 @@ func TestSetDefaults(t *testing.T) {
 		t.Errorf("Default TLS.Enabled = %v, want %v", config.TLS.Enabled, expectedDefaults.TLS.Enabled)
 	}
 }
+
+func TestAuditDefaults(t *testing.T) {
+	config := &Audit{}
+	config.SetDefaults()
+
+	if config.ReadEventsEnabled {
+		t.Error("Read audit events should be disabled by default")
+	}
+	if config.LogPath == "" {
+		t.Error("Default LogPath should not be empty")
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Default audit config should be valid, got %v", err)
+	}
+}
+
+func TestContainerNonContainerPostDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.NonContainerPost != NonContainerPostReject {
+		t.Errorf("Default NonContainerPost = %v, want %v", config.NonContainerPost, NonContainerPostReject)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Default container config should be valid, got %v", err)
+	}
+
+	config.NonContainerPost = "redirect"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown NonContainerPost behavior should be rejected")
+	}
+}
+
+func TestContainerEmptyContainerPutDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.EmptyContainerPut != EmptyContainerPutStrict {
+		t.Errorf("Default EmptyContainerPut = %v, want %v", config.EmptyContainerPut, EmptyContainerPutStrict)
+	}
+
+	config.EmptyContainerPut = EmptyContainerPutReset
+	if err := config.Validate(); err != nil {
+		t.Errorf("EmptyContainerPut reset should be valid, got %v", err)
+	}
+
+	config.EmptyContainerPut = "clear"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown EmptyContainerPut behavior should be rejected")
+	}
+}
+
+func TestContainerMissingContainerDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.MissingContainer != MissingContainerPlain {
+		t.Errorf("Default MissingContainer = %v, want %v", config.MissingContainer, MissingContainerPlain)
+	}
+
+	config.MissingContainer = MissingContainerHints
+	if err := config.Validate(); err != nil {
+		t.Errorf("MissingContainer hints should be valid, got %v", err)
+	}
+
+	config.MissingContainer = "verbose"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown MissingContainer behavior should be rejected")
+	}
+}
+
+func TestContainerETagsDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.ETags != ETagsStrong {
+		t.Errorf("Default ETags = %v, want %v", config.ETags, ETagsStrong)
+	}
+
+	config.ETags = ETagsWeak
+	if err := config.Validate(); err != nil {
+		t.Errorf("ETags weak should be valid, got %v", err)
+	}
+
+	config.ETags = "hashed"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown ETags scheme should be rejected")
+	}
+}
+
+func TestContainerTimestampFallbackDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.TimestampFallback != TimestampFallbackMTime {
+		t.Errorf("Default TimestampFallback = %v, want %v", config.TimestampFallback, TimestampFallbackMTime)
+	}
+
+	config.TimestampFallback = "ctime"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown TimestampFallback should be rejected")
+	}
+}
+
+func TestContainerDuplicateMemberDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.DuplicateMember != DuplicateMemberIgnore {
+		t.Errorf("Default DuplicateMember = %v, want %v", config.DuplicateMember, DuplicateMemberIgnore)
+	}
+
+	config.DuplicateMember = DuplicateMemberConflict
+	if err := config.Validate(); err != nil {
+		t.Errorf("DuplicateMember %q should be valid: %v", DuplicateMemberConflict, err)
+	}
+
+	config.DuplicateMember = "replace"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown DuplicateMember behavior should be rejected")
+	}
+}
+
+func TestContainerSizeAggregateDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.SizeAggregate != SizeAggregateLazy {
+		t.Errorf("Default SizeAggregate = %v, want %v", config.SizeAggregate, SizeAggregateLazy)
+	}
+
+	config.SizeAggregate = SizeAggregateEager
+	if err := config.Validate(); err != nil {
+		t.Errorf("SizeAggregate %q should be valid: %v", SizeAggregateEager, err)
+	}
+
+	config.SizeAggregate = "periodic"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown SizeAggregate mode should be rejected")
+	}
+}
+
+func TestContainerContentTransformersValidation(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if len(config.ContentTransformers) != 0 {
+		t.Errorf("Default ContentTransformers = %v, want none", config.ContentTransformers)
+	}
+
+	config.ContentTransformers = []string{ContentTransformStripEXIF, ContentTransformMinifyJSON}
+	if err := config.Validate(); err != nil {
+		t.Errorf("ContentTransformers %v should be valid: %v", config.ContentTransformers, err)
+	}
+
+	config.ContentTransformers = []string{"resize_images"}
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown content transformer should be rejected")
+	}
+}
+
+func TestContainerResourceNameCollisionDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.ResourceNameCollision != ResourceNameCollisionSuffix {
+		t.Errorf("Default ResourceNameCollision = %v, want %v", config.ResourceNameCollision, ResourceNameCollisionSuffix)
+	}
+
+	config.ResourceNameCollision = ResourceNameCollisionReject
+	if err := config.Validate(); err != nil {
+		t.Errorf("ResourceNameCollision %q should be valid: %v", ResourceNameCollisionReject, err)
+	}
+
+	config.ResourceNameCollision = "overwrite"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown ResourceNameCollision behavior should be rejected")
+	}
+}
+
+func TestContainerHeavyOperationsDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.HeavyOperations.MaxConcurrent != 4 || config.HeavyOperations.QueueTimeout != 0 {
+		t.Errorf("Default HeavyOperations = %+v, want 4 and no queueing", config.HeavyOperations)
+	}
+
+	config.HeavyOperations.QueueTimeout = Duration(-1)
+	if err := config.Validate(); err == nil {
+		t.Error("Negative HeavyOperations queue timeout should be rejected")
+	}
+}
+
+func TestContainerSlugDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.Slug.AllowedCharacters != "-_." || config.Slug.MaxLength != 128 {
+		t.Errorf("Default Slug = %+v, want \"-_.\" and 128", config.Slug)
+	}
+
+	config.Slug.AllowedCharacters = "-_.~+"
+	if err := config.Validate(); err != nil {
+		t.Errorf("Slug characters %q should be valid: %v", config.Slug.AllowedCharacters, err)
+	}
+
+	for _, unsafe := range []string{"/", "?", "#", "%", " ", "é"} {
+		config.Slug.AllowedCharacters = "-" + unsafe
+		if err := config.Validate(); err == nil {
+			t.Errorf("Slug character %q should be rejected", unsafe)
+		}
+	}
+
+	config.Slug.AllowedCharacters = "-"
+	config.Slug.MaxLength = 1000
+	if err := config.Validate(); err == nil {
+		t.Error("Slug max length beyond file name limits should be rejected")
+	}
+}
+
+func TestContainerMembershipResourceDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.MembershipResource != MembershipResourceEnforce {
+		t.Errorf("Default MembershipResource = %v, want %v", config.MembershipResource, MembershipResourceEnforce)
+	}
+
+	config.MembershipResource = MembershipResourceUnchecked
+	if err := config.Validate(); err != nil {
+		t.Errorf("MembershipResource %q should be valid: %v", MembershipResourceUnchecked, err)
+	}
+
+	config.MembershipResource = "warn"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown MembershipResource check should be rejected")
+	}
+}
+
+func TestContainerCrossPodMembershipDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.CrossPodMembership != CrossPodMembershipReject {
+		t.Errorf("Default CrossPodMembership = %v, want %v", config.CrossPodMembership, CrossPodMembershipReject)
+	}
+
+	config.CrossPodMembership = CrossPodMembershipAllow
+	if err := config.Validate(); err != nil {
+		t.Errorf("CrossPodMembership %q should be valid: %v", CrossPodMembershipAllow, err)
+	}
+
+	config.CrossPodMembership = "warn"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown CrossPodMembership check should be rejected")
+	}
+}
+
+func TestContainerDublinCoreDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.DublinCore.MaxTitleLength != 256 {
+		t.Errorf("Default DublinCore.MaxTitleLength = %v, want 256", config.DublinCore.MaxTitleLength)
+	}
+	if config.DublinCore.MaxDescriptionLength != 4096 {
+		t.Errorf("Default DublinCore.MaxDescriptionLength = %v, want 4096", config.DublinCore.MaxDescriptionLength)
+	}
+	if config.DublinCore.MaxFieldLength != 1024 {
+		t.Errorf("Default DublinCore.MaxFieldLength = %v, want 1024", config.DublinCore.MaxFieldLength)
+	}
+	if config.DublinCore.Overflow != DublinCoreOverflowReject {
+		t.Errorf("Default DublinCore.Overflow = %v, want %v", config.DublinCore.Overflow, DublinCoreOverflowReject)
+	}
+
+	config.DublinCore.Overflow = DublinCoreOverflowTruncate
+	if err := config.Validate(); err != nil {
+		t.Errorf("DublinCore.Overflow %q should be valid: %v", DublinCoreOverflowTruncate, err)
+	}
+
+	config.DublinCore.Overflow = "warn"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown DublinCore.Overflow should be rejected")
+	}
+
+	config.DublinCore.Overflow = DublinCoreOverflowReject
+	config.DublinCore.MaxTitleLength = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative DublinCore.MaxTitleLength should be rejected")
+	}
+}
+
+func TestContainerMoveRetentionDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.MoveRetention != Duration(7*24*time.Hour) {
+		t.Errorf("Default MoveRetention = %v, want %v", config.MoveRetention, 7*24*time.Hour)
+	}
+
+	config.MoveRetention = Duration(-time.Hour)
+	if err := config.Validate(); err == nil {
+		t.Error("Negative MoveRetention should be rejected")
+	}
+}
+
+func TestContainerIDPatternValidation(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.IDPattern != "" {
+		t.Errorf("Default IDPattern = %q, want the built-in pattern", config.IDPattern)
+	}
+
+	config.IDPattern = `[a-z0-9-]+`
+	if err := config.Validate(); err != nil {
+		t.Errorf("Valid IDPattern rejected: %v", err)
+	}
+
+	config.IDPattern = `[a-z`
+	if err := config.Validate(); err == nil {
+		t.Error("Malformed IDPattern should be rejected")
+	}
+}
+
+func TestContainerUploadsDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.Uploads.Path != "./data/uploads" {
+		t.Errorf("Default Uploads.Path = %q, want ./data/uploads", config.Uploads.Path)
+	}
+	if config.Uploads.TTL != Duration(24*time.Hour) {
+		t.Errorf("Default Uploads.TTL = %v, want %v", config.Uploads.TTL, 24*time.Hour)
+	}
+	if config.Uploads.SweepInterval != Duration(time.Hour) {
+		t.Errorf("Default Uploads.SweepInterval = %v, want %v", config.Uploads.SweepInterval, time.Hour)
+	}
+	if config.Uploads.MaxBytes != 5<<30 {
+		t.Errorf("Default Uploads.MaxBytes = %d, want %d", config.Uploads.MaxBytes, int64(5<<30))
+	}
+
+	config.Uploads.TTL = Duration(-time.Hour)
+	if err := config.Validate(); err == nil {
+		t.Error("Negative Uploads.TTL should be rejected")
+	}
+}
+
+func TestContainerJSONLDDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.JSONLD.Form != JSONLDFormCompacted {
+		t.Errorf("Default JSONLD.Form = %v, want %v", config.JSONLD.Form, JSONLDFormCompacted)
+	}
+
+	config.JSONLD.Form = JSONLDFormExpanded
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expanded JSONLD.Form should be accepted: %v", err)
+	}
+
+	config.JSONLD.Form = "flattened"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown JSONLD.Form should be rejected")
+	}
+}
+
+func TestContainerEventRetryDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.EventRetry.MaxAttempts != 5 {
+		t.Errorf("Default EventRetry.MaxAttempts = %v, want 5", config.EventRetry.MaxAttempts)
+	}
+	if config.EventRetry.InitialBackoff != Duration(100*time.Millisecond) {
+		t.Errorf("Default EventRetry.InitialBackoff = %v, want %v", config.EventRetry.InitialBackoff, 100*time.Millisecond)
+	}
+	if config.EventRetry.MaxBackoff != Duration(10*time.Second) {
+		t.Errorf("Default EventRetry.MaxBackoff = %v, want %v", config.EventRetry.MaxBackoff, 10*time.Second)
+	}
+	if config.EventRetry.DeadLetterCapacity != 10000 {
+		t.Errorf("Default EventRetry.DeadLetterCapacity = %v, want 10000", config.EventRetry.DeadLetterCapacity)
+	}
+
+	config.EventRetry.Handlers = map[string]EventRetryPolicy{"persistence": {MaxAttempts: 10}, "containment": {MaxAttempts: 3}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("EventRetry handler override should be valid: %v", err)
+	}
+
+	config.EventRetry.Handlers = map[string]EventRetryPolicy{"search": {MaxAttempts: 10}}
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown EventRetry handler should be rejected")
+	}
+
+	config.EventRetry.Handlers = map[string]EventRetryPolicy{"container": {InitialBackoff: Duration(time.Minute), MaxBackoff: Duration(time.Second)}}
+	if err := config.Validate(); err == nil {
+		t.Error("EventRetry initial backoff above max backoff should be rejected")
+	}
+
+	config.EventRetry.Handlers = nil
+	config.EventRetry.MaxAttempts = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative EventRetry.MaxAttempts should be rejected")
+	}
+}
+
+func TestContainerBrokerDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.Broker.Kind != BrokerNone {
+		t.Errorf("Default Broker.Kind = %v, want %v", config.Broker.Kind, BrokerNone)
+	}
+	if config.Broker.PublishTimeout != Duration(5*time.Second) {
+		t.Errorf("Default Broker.PublishTimeout = %v, want %v", config.Broker.PublishTimeout, 5*time.Second)
+	}
+	if config.Broker.DefaultTopic != "goro.events" {
+		t.Errorf("Default Broker.DefaultTopic = %v, want goro.events", config.Broker.DefaultTopic)
+	}
+
+	config.Broker.Kind = BrokerNATS
+	if err := config.Validate(); err == nil {
+		t.Error("NATS broker without a URL should be rejected")
+	}
+
+	config.Broker.URL = "nats://localhost:4222"
+	config.Broker.Topics = map[string]string{"container.member_added": "goro.members", "resource.updated": ""}
+	if err := config.Validate(); err != nil {
+		t.Errorf("NATS broker with a URL should be valid: %v", err)
+	}
+
+	config.Broker.Topics = map[string]string{"container.created": "goro containers"}
+	if err := config.Validate(); err == nil {
+		t.Error("Broker topic with whitespace should be rejected")
+	}
+
+	config.Broker.Topics = nil
+	config.Broker.Kind = "kafka"
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown Broker.Kind should be rejected")
+	}
+
+	config.Broker.Kind = BrokerNone
+	config.EventRetry.Handlers = map[string]EventRetryPolicy{"broker": {MaxAttempts: 20}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("EventRetry broker override should be valid: %v", err)
+	}
+}
+
+func TestContainerAccessTrackingDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.AccessTracking.Enabled {
+		t.Error("AccessTracking should be disabled by default")
+	}
+	if config.AccessTracking.Throttle != Duration(time.Hour) {
+		t.Errorf("Default AccessTracking.Throttle = %v, want %v", config.AccessTracking.Throttle, time.Hour)
+	}
+	if config.AccessTracking.FlushInterval != Duration(30*time.Second) {
+		t.Errorf("Default AccessTracking.FlushInterval = %v, want %v", config.AccessTracking.FlushInterval, 30*time.Second)
+	}
+	if config.AccessTracking.MaxPending != 1000 {
+		t.Errorf("Default AccessTracking.MaxPending = %v, want 1000", config.AccessTracking.MaxPending)
+	}
+
+	config.AccessTracking.Throttle = Duration(-time.Second)
+	if err := config.Validate(); err == nil {
+		t.Error("Negative AccessTracking.Throttle should be rejected")
+	}
+}
+
+func TestContainerNotificationsDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.Notifications.Enabled {
+		t.Error("Notifications should be disabled by default")
+	}
+	if config.Notifications.DeliveryTimeout != Duration(10*time.Second) {
+		t.Errorf("Default Notifications.DeliveryTimeout = %v, want %v", config.Notifications.DeliveryTimeout, 10*time.Second)
+	}
+	if config.Notifications.QueueSize != 1000 {
+		t.Errorf("Default Notifications.QueueSize = %v, want 1000", config.Notifications.QueueSize)
+	}
+	if config.Notifications.AllowPrivateTargets {
+		t.Error("Private webhook targets should be refused by default")
+	}
+
+	config.Notifications.QueueSize = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative Notifications.QueueSize should be rejected")
+	}
+}
+
+func TestContainerLiveNotificationsDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	live := config.Notifications.Live
+	if live.Enabled {
+		t.Error("Live notifications should be disabled by default")
+	}
+	if live.BufferSize != 64 {
+		t.Errorf("Default Live.BufferSize = %v, want 64", live.BufferSize)
+	}
+	if live.WriteTimeout != Duration(10*time.Second) {
+		t.Errorf("Default Live.WriteTimeout = %v, want %v", live.WriteTimeout, 10*time.Second)
+	}
+	if live.ReplaySize != 256 {
+		t.Errorf("Default Live.ReplaySize = %v, want 256", live.ReplaySize)
+	}
+
+	config.Notifications.Live.BufferSize = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative Live.BufferSize should be rejected")
+	}
+
+	config.Notifications.Live.BufferSize = 64
+	config.Notifications.Live.ReplaySize = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative Live.ReplaySize should be rejected")
+	}
+}
+
+func TestContainerStructureLimitDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.StructureMaxNodes != 1000 {
+		t.Errorf("Default StructureMaxNodes = %v, want 1000", config.StructureMaxNodes)
+	}
+	if config.StructureMaxBytes != 1<<20 {
+		t.Errorf("Default StructureMaxBytes = %v, want %v", config.StructureMaxBytes, 1<<20)
+	}
+
+	config.StructureMaxNodes = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative StructureMaxNodes should be rejected")
+	}
+}
+
+func TestContainerMaxTriplesDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.MaxTriples != 1000000 {
+		t.Errorf("Default MaxTriples = %v, want 1000000", config.MaxTriples)
+	}
+
+	config.MaxTriples = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative MaxTriples should be rejected")
+	}
+}
+
+func TestContainerMaxResourceVersionsDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.MaxResourceVersions != 20 {
+		t.Errorf("Default MaxResourceVersions = %v, want 20", config.MaxResourceVersions)
+	}
+
+	config.MaxResourceVersions = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Negative MaxResourceVersions should be rejected")
+	}
+}
+
+func TestContainerMediaTypesDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.MediaTypes.Strict {
+		t.Error("Strict media-type policy should be off by default")
+	}
+	if config.MediaTypes.Aliases["application/json"] != "application/ld+json" {
+		t.Errorf("Default alias for application/json = %v, want application/ld+json", config.MediaTypes.Aliases["application/json"])
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Default container config should be valid, got %v", err)
+	}
+
+	config.MediaTypes.Aliases["text/n3"] = "text/n3"
+	if err := config.Validate(); err == nil {
+		t.Error("Alias to an unsupported media type should be rejected")
+	}
+
+	delete(config.MediaTypes.Aliases, "text/n3")
+	config.MediaTypes.Rejected = append(config.MediaTypes.Rejected, "text/turtle")
+	if err := config.Validate(); err == nil {
+		t.Error("Rejecting a supported media type should be rejected")
+	}
+
+	empty := &Container{MediaTypes: MediaTypes{Rejected: []string{}}}
+	empty.SetDefaults()
+	if len(empty.MediaTypes.Rejected) != 0 {
+		t.Errorf("Explicitly empty rejected list = %v, want it kept empty", empty.MediaTypes.Rejected)
+	}
+}
+
+func TestAuthAdminTokensValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if len(config.AdminTokens) != 0 {
+		t.Errorf("Default AdminTokens = %v, want none", config.AdminTokens)
+	}
+
+	config.AdminTokens = []string{"secret", " "}
+	if err := config.Validate(); err == nil {
+		t.Error("Blank admin token should be rejected")
+	}
+}
+
+func TestAuthHTTPSOnlyPathsValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if len(config.HTTPSOnlyPaths) == 0 {
+		t.Error("Default HTTPSOnlyPaths should cover the auth endpoints")
+	}
+	if config.InsecureDev {
+		t.Error("Default InsecureDev should be false")
+	}
+
+	config.HTTPSOnlyPaths = []string{"login"}
+	if err := config.Validate(); err == nil {
+		t.Error("HTTPS-only path without a leading slash should be rejected")
+	}
+}
+
+func TestAuthTrustedProxiesValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if len(config.TrustedProxies) != 0 {
+		t.Error("Default TrustedProxies should trust no proxy")
+	}
+
+	config.TrustedProxies = []string{"127.0.0.1", "::1", "10.0.0.0/8"}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Valid trusted proxies should be accepted: %v", err)
+	}
+
+	config.TrustedProxies = []string{"proxy.internal"}
+	if err := config.Validate(); err == nil {
+		t.Error("Trusted proxy that is not an IP or CIDR range should be rejected")
+	}
+}
+
+func TestContainerCacheControlDefaults(t *testing.T) {
+	config := &Container{}
+	config.SetDefaults()
+
+	if config.CacheControl.Resources != "no-cache" || config.CacheControl.Containers != "no-cache" {
+		t.Errorf("Default resource and container Cache-Control = %q, %q, want no-cache", config.CacheControl.Resources, config.CacheControl.Containers)
+	}
+	if config.CacheControl.Versions != "public, max-age=31536000, immutable" {
+		t.Errorf("Default version Cache-Control = %q, want immutable", config.CacheControl.Versions)
+	}
+
+	config.CacheControl.ContentTypes = map[string]string{"image/*": "public, max-age=86400"}
+	if err := config.CacheControl.Validate(); err != nil {
+		t.Errorf("Wildcard content type should be valid: %v", err)
+	}
+	config.CacheControl.ContentTypes = map[string]string{"images": "max-age=60"}
+	if err := config.CacheControl.Validate(); err == nil {
+		t.Error("Content type without a subtype should be rejected")
+	}
+	config.CacheControl.ContentTypes = map[string]string{"image/png": "max-age=60\r\nX-Injected: 1"}
+	if err := config.CacheControl.Validate(); err == nil {
+		t.Error("Directives with line breaks should be rejected")
+	}
+}
+
+func TestAuthDPoPValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if config.DPoP.Enabled || config.DPoP.Required {
+		t.Error("DPoP should be off by default")
+	}
+	if len(config.DPoP.Paths) == 0 {
+		t.Error("Default DPoP paths should cover the resource and container endpoints")
+	}
+	if config.DPoP.ProofMaxAge != Duration(5*time.Minute) || config.DPoP.ReplayTTL != Duration(5*time.Minute) {
+		t.Errorf("Default DPoP ProofMaxAge = %v, ReplayTTL = %v, want 5m each", config.DPoP.ProofMaxAge, config.DPoP.ReplayTTL)
+	}
+
+	config.DPoP.Paths = []string{"resources"}
+	if err := config.Validate(); err == nil {
+		t.Error("DPoP path without a leading slash should be rejected")
+	}
+	config.DPoP.Paths = nil
+	config.DPoP.ReplayTTL = Duration(-time.Minute)
+	if err := config.Validate(); err == nil {
+		t.Error("Negative DPoP replay TTL should be rejected")
+	}
+}
+
+func TestAuthAccessTokensValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if len(config.AccessTokens.PublicKeyFiles) != 0 {
+		t.Error("Access tokens should not be verified by default")
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Default access token settings should be valid: %v", err)
+	}
+
+	config.AccessTokens.PublicKeyFiles = []string{"./keys/issuer.pem"}
+	if err := config.Validate(); err == nil {
+		t.Error("Public key files without an issuer should be rejected")
+	}
+	config.AccessTokens.Issuer = "https://idp.example"
+	if err := config.Validate(); err != nil {
+		t.Errorf("Issuer with public key files should be valid: %v", err)
+	}
+	config.AccessTokens.PublicKeyFiles = []string{" "}
+	if err := config.Validate(); err == nil {
+		t.Error("Empty public key file path should be rejected")
+	}
+}
+
+func TestAuthPasswordPolicyValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if config.PasswordPolicy.MinLength != 8 || config.PasswordPolicy.MaxLength != 128 {
+		t.Errorf("Default password lengths = %d-%d, want 8-128", config.PasswordPolicy.MinLength, config.PasswordPolicy.MaxLength)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Default password policy should be valid: %v", err)
+	}
+
+	config.PasswordPolicy.RequiredClasses = []string{"digit", "emoji"}
+	if err := config.Validate(); err == nil {
+		t.Error("Unknown character class should be rejected")
+	}
+	config.PasswordPolicy.RequiredClasses = nil
+	config.PasswordPolicy.MaxLength = 6
+	if err := config.Validate(); err == nil {
+		t.Error("Max length below min length should be rejected")
+	}
+	config.PasswordPolicy.MaxLength = 128
+	config.PasswordPolicy.MinStrength = 5
+	if err := config.Validate(); err == nil {
+		t.Error("Min strength above 4 should be rejected")
+	}
+}
+
+func TestAuthOAuthRedirectURIsValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if config.OAuthStateTTL != Duration(10*time.Minute) {
+		t.Errorf("Default OAuthStateTTL = %v, want %v", config.OAuthStateTTL, 10*time.Minute)
+	}
+	if config.SessionIdleTimeout != Duration(30*24*time.Hour) {
+		t.Errorf("Default SessionIdleTimeout = %v, want %v", config.SessionIdleTimeout, 30*24*time.Hour)
+	}
+
+	config.OAuthRedirectURIs = map[string][]string{"github": {"https://app.example.com/auth/callback"}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Absolute OAuth redirect URI should be valid: %v", err)
+	}
+
+	for _, redirectURI := range []string{"/auth/callback", "https://app.example.com/callback#token", "not a uri"} {
+		config.OAuthRedirectURIs = map[string][]string{"github": {redirectURI}}
+		if err := config.Validate(); err == nil {
+			t.Errorf("OAuth redirect URI %q should be rejected", redirectURI)
+		}
+	}
+}
+
+func TestAuthTOTPValidation(t *testing.T) {
+	config := &Auth{}
+	config.SetDefaults()
+
+	if config.TOTP.Issuer != "Goro" {
+		t.Errorf("Default TOTP issuer = %q, want Goro", config.TOTP.Issuer)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("An empty TOTP key should be valid: %v", err)
+	}
+
+	config.TOTP.Key = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	if err := config.Validate(); err != nil {
+		t.Errorf("A 32-byte TOTP key should be valid: %v", err)
+	}
+	for _, key := range []string{"c2hvcnQ=", "not base64!"} {
+		config.TOTP.Key = key
+		if err := config.Validate(); err == nil {
+			t.Errorf("TOTP key %q should be rejected", key)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Counter is an unlabeled, monotonically increasing counter
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Int64
+}
+
+// NewCounter creates a new counter
+func NewCounter(name, help string) *Counter {
+	return &Counter{name: name, help: help}
+}
+
+// Name returns the metric name
+func (c *Counter) Name() string {
+	return c.name
+}
+
+// Add increases the counter by delta; negative deltas are ignored
+func (c *Counter) Add(delta int64) {
+	if delta > 0 {
+		c.value.Add(delta)
+	}
+}
+
+// Inc increases the counter by one
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Value returns the current counter value
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// Write writes the counter in the Prometheus text exposition format
+func (c *Counter) Write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value())
+	return err
+}
+
+// Gauge is an unlabeled value that can go up and down
+type Gauge struct {
+	name  string
+	help  string
+	value atomic.Int64
+}
+
+// NewGauge creates a new gauge
+func NewGauge(name, help string) *Gauge {
+	return &Gauge{name: name, help: help}
+}
+
+// Name returns the metric name
+func (g *Gauge) Name() string {
+	return g.name
+}
+
+// Add changes the gauge by delta
+func (g *Gauge) Add(delta int64) {
+	g.value.Add(delta)
+}
+
+// Inc increases the gauge by one
+func (g *Gauge) Inc() {
+	g.value.Add(1)
+}
+
+// Dec decreases the gauge by one
+func (g *Gauge) Dec() {
+	g.value.Add(-1)
+}
+
+// Set replaces the gauge value
+func (g *Gauge) Set(value int64) {
+	g.value.Store(value)
+}
+
+// Value returns the current gauge value
+func (g *Gauge) Value() int64 {
+	return g.value.Load()
+}
+
+// Write writes the gauge in the Prometheus text exposition format
+func (g *Gauge) Write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n", g.name, g.help, g.name, g.name, g.Value())
+	return err
+}
+
+// RateMeter tracks events per second over a sliding window of one-second buckets
+type RateMeter struct {
+	buckets []int64
+	seconds []int64
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewRateMeter creates a rate meter averaging over the given window
+func NewRateMeter(window time.Duration) *RateMeter {
+	size := int(window / time.Second)
+	if size < 1 {
+		size = 1
+	}
+	return &RateMeter{
+		buckets: make([]int64, size),
+		seconds: make([]int64, size),
+		now:     time.Now,
+	}
+}
+
+// Mark records n events at the current time
+func (m *RateMeter) Mark(n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	second := m.now().Unix()
+	idx := int(second % int64(len(m.buckets)))
+	if m.seconds[idx] != second {
+		m.seconds[idx] = second
+		m.buckets[idx] = 0
+	}
+	m.buckets[idx] += n
+}
+
+// Rate returns the average number of events per second over the window
+func (m *RateMeter) Rate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now().Unix()
+	window := int64(len(m.buckets))
+	var total int64
+	for i, second := range m.seconds {
+		if now-second < window {
+			total += m.buckets[i]
+		}
+	}
+	return float64(total) / float64(window)
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterAndGauge_WritePrometheusTextFormat(t *testing.T) {
+	counter := NewCounter("test_events_total", "Test events.")
+	counter.Add(3)
+	counter.Add(-1)
+	counter.Inc()
+
+	gauge := NewGauge("test_items", "Test items.")
+	gauge.Add(5)
+	gauge.Dec()
+
+	var buf bytes.Buffer
+	require.NoError(t, counter.Write(&buf))
+	require.NoError(t, gauge.Write(&buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "# TYPE test_events_total counter\ntest_events_total 4\n")
+	assert.Contains(t, output, "# TYPE test_items gauge\ntest_items 4\n")
+}
+
+func TestRateMeter_AveragesOverWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	meter := NewRateMeter(10 * time.Second)
+	meter.now = func() time.Time { return now }
+
+	meter.Mark(10)
+	now = now.Add(time.Second)
+	meter.Mark(10)
+	assert.Equal(t, 2.0, meter.Rate())
+
+	// Events older than the window no longer count
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, 0.0, meter.Rate())
+
+	// A reused bucket is reset before it is incremented
+	meter.Mark(5)
+	assert.Equal(t, 0.5, meter.Rate())
+}
+
+func TestSnapshotServerStats_ComputesCacheHitRate(t *testing.T) {
+	before := SnapshotServerStats()
+
+	RecordCacheLookup(true)
+	RecordCacheLookup(true)
+	RecordCacheLookup(true)
+	RecordCacheLookup(false)
+	RecordEventsDispatched(2)
+
+	stats := SnapshotServerStats()
+	assert.Equal(t, before.CacheHits+3, stats.CacheHits)
+	assert.Equal(t, before.CacheMisses+1, stats.CacheMisses)
+	assert.Equal(t, before.EventsTotal+2, stats.EventsTotal)
+	assert.Greater(t, stats.EventsPerSecond, 0.0)
+	assert.InDelta(t, float64(stats.CacheHits)/float64(stats.CacheHits+stats.CacheMisses), stats.CacheHitRate, 1e-9)
+}
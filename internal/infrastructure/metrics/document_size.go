@@ -0,0 +1,56 @@
+package metrics
+
+import "strings"
+
+// Operation label values for RDF document size metrics
+const (
+	OperationContainerListing = "container_listing"
+	OperationResource         = "resource"
+)
+
+// DocumentSizeBuckets covers documents from 256 bytes to 64 MiB
+var DocumentSizeBuckets = ExponentialBuckets(256, 4, 10)
+
+var (
+	// RDFDocumentSizeBytes records the size of serialized RDF documents by format and operation
+	RDFDocumentSizeBytes = NewHistogram(HistogramOpts{
+		Name:    "goro_rdf_document_size_bytes",
+		Help:    "Size in bytes of serialized RDF documents by format and operation.",
+		Buckets: DocumentSizeBuckets,
+	}, "format", "operation")
+
+	// ResourceUploadSizeBytes records the size of uploaded resources by content type
+	ResourceUploadSizeBytes = NewHistogram(HistogramOpts{
+		Name:      "goro_resource_upload_size_bytes",
+		Help:      "Size in bytes of uploaded resources by content type.",
+		Buckets:   DocumentSizeBuckets,
+		MaxSeries: 64, // content types are client-supplied, so bound the cardinality
+	}, "content_type")
+)
+
+func init() {
+	DefaultRegistry.MustRegister(RDFDocumentSizeBytes)
+	DefaultRegistry.MustRegister(ResourceUploadSizeBytes)
+}
+
+// ObserveRDFDocumentSize records the size of a serialized RDF document
+func ObserveRDFDocumentSize(format, operation string, size int) {
+	RDFDocumentSizeBytes.Observe(float64(size), mediaType(format), operation)
+}
+
+// ObserveResourceUploadSize records the size of an uploaded resource
+func ObserveResourceUploadSize(contentType string, size int64) {
+	ResourceUploadSizeBytes.Observe(float64(size), mediaType(contentType))
+}
+
+// mediaType strips parameters from a content type and lowercases it
+func mediaType(contentType string) string {
+	if idx := strings.Index(contentType, ";"); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if contentType == "" {
+		return "unknown"
+	}
+	return contentType
+}
@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OverflowLabelValue replaces label values once a histogram reaches its series limit
+const OverflowLabelValue = "other"
+
+// HistogramOpts configures a histogram
+type HistogramOpts struct {
+	Name    string
+	Help    string
+	Buckets []float64
+	// MaxSeries bounds the number of label combinations; further combinations are
+	// recorded under OverflowLabelValue. Zero means unbounded.
+	MaxSeries int
+}
+
+// Histogram is a labeled histogram exposed in the Prometheus text format
+type Histogram struct {
+	opts       HistogramOpts
+	labelNames []string
+	series     map[string]*histogramSeries
+	mu         sync.Mutex
+}
+
+// histogramSeries holds the observations for one label combination
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// NewHistogram creates a new histogram with the given label names
+func NewHistogram(opts HistogramOpts, labelNames ...string) *Histogram {
+	buckets := append([]float64{}, opts.Buckets...)
+	sort.Float64s(buckets)
+	opts.Buckets = buckets
+
+	return &Histogram{
+		opts:       opts,
+		labelNames: labelNames,
+		series:     make(map[string]*histogramSeries),
+	}
+}
+
+// Name returns the metric name
+func (h *Histogram) Name() string {
+	return h.opts.Name
+}
+
+// Observe records a value for the given label values
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(h.labelNames) {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	series, exists := h.series[key]
+	if !exists {
+		if h.opts.MaxSeries > 0 && len(h.series) >= h.opts.MaxSeries {
+			labelValues = overflowLabels(len(labelValues))
+			key = strings.Join(labelValues, "\xff")
+			series, exists = h.series[key]
+		}
+		if !exists {
+			series = &histogramSeries{
+				labelValues: append([]string{}, labelValues...),
+				counts:      make([]uint64, len(h.opts.Buckets)),
+			}
+			h.series[key] = series
+		}
+	}
+
+	for i, upperBound := range h.opts.Buckets {
+		if value <= upperBound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+// Write writes the histogram in the Prometheus text exposition format
+func (h *Histogram) Write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.opts.Name, h.opts.Help, h.opts.Name); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		series := h.series[key]
+		labels := h.formatLabels(series.labelValues)
+
+		for i, upperBound := range h.opts.Buckets {
+			if _, err := fmt.Fprintf(w, "%s_bucket{%s} %d\n", h.opts.Name, joinLabels(labels, `le="`+formatFloat(upperBound)+`"`), series.counts[i]); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s_bucket{%s} %d\n", h.opts.Name, joinLabels(labels, `le="+Inf"`), series.count); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s_sum%s %s\n", h.opts.Name, wrapLabels(labels), formatFloat(series.sum)); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s_count%s %d\n", h.opts.Name, wrapLabels(labels), series.count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatLabels renders name="value" pairs for a series
+func (h *Histogram) formatLabels(values []string) string {
+	pairs := make([]string, len(h.labelNames))
+	for i, name := range h.labelNames {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, escapeLabelValue(values[i]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// ExponentialBuckets returns count buckets starting at start and multiplied by factor
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+// overflowLabels returns label values used once the series limit is reached
+func overflowLabels(n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = OverflowLabelValue
+	}
+	return values
+}
+
+// joinLabels appends an extra label pair to a rendered label set
+func joinLabels(labels, extra string) string {
+	if labels == "" {
+		return extra
+	}
+	return labels + "," + extra
+}
+
+// wrapLabels wraps a rendered label set in braces when it is not empty
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+// escapeLabelValue escapes a label value for the text exposition format
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat formats a float for the text exposition format
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram_WritesPrometheusTextFormat(t *testing.T) {
+	histogram := NewHistogram(HistogramOpts{
+		Name:    "test_size_bytes",
+		Help:    "Test sizes.",
+		Buckets: []float64{100, 1000},
+	}, "format")
+
+	histogram.Observe(50, "text/turtle")
+	histogram.Observe(500, "text/turtle")
+	histogram.Observe(5000, "text/turtle")
+
+	var buf bytes.Buffer
+	require.NoError(t, histogram.Write(&buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "# TYPE test_size_bytes histogram")
+	assert.Contains(t, output, `test_size_bytes_bucket{format="text/turtle",le="100"} 1`)
+	assert.Contains(t, output, `test_size_bytes_bucket{format="text/turtle",le="1000"} 2`)
+	assert.Contains(t, output, `test_size_bytes_bucket{format="text/turtle",le="+Inf"} 3`)
+	assert.Contains(t, output, `test_size_bytes_sum{format="text/turtle"} 5550`)
+	assert.Contains(t, output, `test_size_bytes_count{format="text/turtle"} 3`)
+}
+
+func TestHistogram_BoundsSeriesCardinality(t *testing.T) {
+	histogram := NewHistogram(HistogramOpts{
+		Name:      "test_upload_bytes",
+		Help:      "Test uploads.",
+		Buckets:   []float64{100},
+		MaxSeries: 1,
+	}, "content_type")
+
+	histogram.Observe(10, "text/turtle")
+	histogram.Observe(10, "application/x-one")
+	histogram.Observe(10, "application/x-two")
+
+	var buf bytes.Buffer
+	require.NoError(t, histogram.Write(&buf))
+	output := buf.String()
+
+	assert.Contains(t, output, `test_upload_bytes_count{content_type="text/turtle"} 1`)
+	assert.Contains(t, output, `test_upload_bytes_count{content_type="other"} 2`)
+	assert.NotContains(t, output, "application/x-one")
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	registry := NewRegistry()
+	histogram := NewHistogram(HistogramOpts{Name: "test_handler_bytes", Help: "Test.", Buckets: []float64{1}})
+	registry.MustRegister(histogram)
+	assert.Error(t, registry.Register(histogram), "duplicate names should be rejected")
+
+	histogram.Observe(1)
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain"))
+	assert.Contains(t, recorder.Body.String(), "test_handler_bytes_count 1")
+}
+
+func TestObserveRDFDocumentSize_StripsParameters(t *testing.T) {
+	ObserveRDFDocumentSize("Text/Turtle; charset=utf-8", OperationResource, 10)
+
+	var buf bytes.Buffer
+	require.NoError(t, RDFDocumentSizeBytes.Write(&buf))
+	assert.Contains(t, buf.String(), `format="text/turtle",operation="resource"`)
+}
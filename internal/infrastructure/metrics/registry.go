@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Collector is a metric that can write itself in the Prometheus text format
+type Collector interface {
+	Name() string
+	Write(w io.Writer) error
+}
+
+// Registry holds the collectors exposed on the metrics endpoint
+type Registry struct {
+	collectors map[string]Collector
+	mu         sync.RWMutex
+}
+
+// DefaultRegistry is the registry exposed by the server's /metrics endpoint
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates a new empty registry
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+// Register adds a collector to the registry
+func (r *Registry) Register(collector Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.collectors[collector.Name()]; exists {
+		return fmt.Errorf("collector %s is already registered", collector.Name())
+	}
+	r.collectors[collector.Name()] = collector
+	return nil
+}
+
+// MustRegister adds a collector to the registry and panics if the name is taken
+func (r *Registry) MustRegister(collector Collector) {
+	if err := r.Register(collector); err != nil {
+		panic(err)
+	}
+}
+
+// Write writes every registered collector, ordered by name
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := r.collectors[name].Write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler returns an HTTP handler serving the registry in the Prometheus text format
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var buf bytes.Buffer
+		if err := r.Write(&buf); err != nil {
+			http.Error(w, "failed to collect metrics", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+	})
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import "time"
+
+// EventRateWindow is the window events/sec is averaged over
+const EventRateWindow = time.Minute
+
+var (
+	// StoredContainers is seeded at startup from the membership index and then moved by each
+	// container created or deleted
+	StoredContainers = NewGauge("goro_stored_containers", "Number of stored containers.")
+
+	// StoredResources is seeded at startup from the membership index and then moved by each
+	// resource created or deleted
+	StoredResources = NewGauge("goro_stored_resources", "Number of stored resources.")
+
+	// StoredBytes is seeded at startup from the storage usage totals and then moved by each
+	// write and delete
+	StoredBytes = NewGauge("goro_stored_bytes", "Bytes of stored resource content.")
+
+	// ActiveSessions is recounted from the session repository whenever a session starts, is
+	// used or is revoked
+	ActiveSessions = NewGauge("goro_active_sessions", "Number of active sessions.")
+
+	// EventsDispatched counts domain events handed to the event dispatcher
+	EventsDispatched = NewCounter("goro_events_dispatched_total", "Domain events dispatched.")
+
+	// CacheHits counts lookups served from the container and resource caches
+	CacheHits = NewCounter("goro_cache_hits_total", "Container and resource cache hits.")
+
+	// CacheMisses counts lookups the container and resource caches could not serve
+	CacheMisses = NewCounter("goro_cache_misses_total", "Container and resource cache misses.")
+
+	eventRate = NewRateMeter(EventRateWindow)
+	startedAt = time.Now()
+)
+
+func init() {
+	DefaultRegistry.MustRegister(StoredContainers)
+	DefaultRegistry.MustRegister(StoredResources)
+	DefaultRegistry.MustRegister(StoredBytes)
+	DefaultRegistry.MustRegister(ActiveSessions)
+	DefaultRegistry.MustRegister(EventsDispatched)
+	DefaultRegistry.MustRegister(CacheHits)
+	DefaultRegistry.MustRegister(CacheMisses)
+}
+
+// ServerStats is a point-in-time view of the server-wide counters
+type ServerStats struct {
+	TotalContainers int64     `json:"totalContainers"`
+	TotalResources  int64     `json:"totalResources"`
+	TotalBytes      int64     `json:"totalBytes"`
+	ActiveSessions  int64     `json:"activeSessions"`
+	EventsPerSecond float64   `json:"eventsPerSecond"`
+	EventsTotal     int64     `json:"eventsTotal"`
+	CacheHitRate    float64   `json:"cacheHitRate"`
+	CacheHits       int64     `json:"cacheHits"`
+	CacheMisses     int64     `json:"cacheMisses"`
+	Since           time.Time `json:"since"`
+}
+
+// RecordEventsDispatched records n dispatched events
+func RecordEventsDispatched(n int) {
+	EventsDispatched.Add(int64(n))
+	eventRate.Mark(int64(n))
+}
+
+// RecordCacheLookup records a cache hit or miss
+func RecordCacheLookup(hit bool) {
+	if hit {
+		CacheHits.Inc()
+	} else {
+		CacheMisses.Inc()
+	}
+}
+
+// SnapshotServerStats reads the server-wide counters without touching storage
+func SnapshotServerStats() ServerStats {
+	stats := ServerStats{
+		TotalContainers: StoredContainers.Value(),
+		TotalResources:  StoredResources.Value(),
+		TotalBytes:      StoredBytes.Value(),
+		ActiveSessions:  ActiveSessions.Value(),
+		EventsPerSecond: eventRate.Rate(),
+		EventsTotal:     EventsDispatched.Value(),
+		CacheHits:       CacheHits.Value(),
+		CacheMisses:     CacheMisses.Value(),
+		Since:           startedAt,
+	}
+	if lookups := stats.CacheHits + stats.CacheMisses; lookups > 0 {
+		stats.CacheHitRate = float64(stats.CacheHits) / float64(lookups)
+	}
+	return stats
+}
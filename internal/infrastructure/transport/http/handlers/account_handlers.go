@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/akeemphilbert/goro/internal/user/application"
@@ -12,6 +14,13 @@ import (
 	khttp "github.com/go-kratos/kratos/v2/transport/http"
 )
 
+const (
+	// defaultMemberPageSize is used when a member listing does not specify a limit
+	defaultMemberPageSize = 50
+	// maxMemberPageSize bounds how many members a single listing page may hold
+	maxMemberPageSize = 1000
+)
+
 // AccountHandler handles HTTP requests for account management
 type AccountHandler struct {
 	accountService application.AccountService
@@ -51,6 +60,9 @@ type UpdateMemberRoleRequest struct {
 	RoleID string `json:"role_id"`
 }
 
+// PatchAccountSettingsRequest represents the HTTP request for partially updating account settings
+type PatchAccountSettingsRequest = domain.AccountSettingsPatch
+
 // AccountResponse represents the HTTP response for account data
 type AccountResponse struct {
 	ID          string                 `json:"id"`
@@ -72,6 +84,27 @@ type InvitationResponse struct {
 	InvitedBy string `json:"invited_by"`
 	ExpiresAt string `json:"expires_at"`
 	CreatedAt string `json:"created_at"`
+	// DeliveryFailed reports an invitation that was created but could not be sent; its
+	// delivery can be retried
+	DeliveryFailed bool `json:"delivery_failed,omitempty"`
+}
+
+// MemberResponse represents the HTTP response for account member data
+type MemberResponse struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	UserID    string `json:"user_id"`
+	RoleID    string `json:"role_id"`
+	InvitedBy string `json:"invited_by"`
+	JoinedAt  string `json:"joined_at"`
+}
+
+// MemberListResponse represents the HTTP response for one page of an account's members
+type MemberListResponse struct {
+	Members []MemberResponse `json:"members"`
+	Total   int              `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
 }
 
 // CreateAccount handles account creation requests
@@ -152,16 +185,85 @@ func (h *AccountHandler) InviteUser(ctx khttp.Context) error {
 
 	// Call service
 	invitation, err := h.accountService.InviteUser(ctx.Request().Context(), accountID, inviterID, req.Email, req.RoleID)
-	if err != nil {
+	deliveryFailed := errors.Is(err, domain.ErrInvitationDeliveryFailed) && invitation != nil
+	if err != nil && !deliveryFailed {
 		return h.handleServiceError(ctx, err)
 	}
 
 	// Build response
 	response := h.buildInvitationResponse(invitation)
+	response.DeliveryFailed = deliveryFailed
 
 	return ctx.JSON(http.StatusCreated, response)
 }
 
+// ResendInvitation handles extending a pending invitation and sending it again
+func (h *AccountHandler) ResendInvitation(ctx khttp.Context) error {
+	vars := ctx.Vars()
+	accountIDSlice, exists := vars["id"]
+	if !exists || len(accountIDSlice) == 0 || strings.TrimSpace(accountIDSlice[0]) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_ACCOUNT_ID", "Account ID is required")
+	}
+	invitationIDSlice, exists := vars["invitation_id"]
+	if !exists || len(invitationIDSlice) == 0 || strings.TrimSpace(invitationIDSlice[0]) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_INVITATION_ID", "Invitation ID is required")
+	}
+
+	resentByID := requestUserID(ctx.Request())
+	if resentByID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	var req application.ResendInvitationRequest
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&req); err != nil {
+		return h.handleError(ctx, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+	}
+	req.ResentByID = resentByID
+	req.AccountID = accountIDSlice[0]
+	req.InvitationID = invitationIDSlice[0]
+
+	invitation, err := h.accountService.ResendInvitation(ctx.Request().Context(), req)
+	deliveryFailed := errors.Is(err, domain.ErrInvitationDeliveryFailed) && invitation != nil
+	switch {
+	case errors.Is(err, domain.ErrInvitationNotPending):
+		return h.handleError(ctx, http.StatusConflict, "INVITATION_NOT_PENDING", err.Error())
+	case err != nil && !deliveryFailed:
+		return h.handleServiceError(ctx, err)
+	}
+
+	response := h.buildInvitationResponse(invitation)
+	response.DeliveryFailed = deliveryFailed
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// DeliverInvitation handles retrying the delivery of a pending invitation, as a verified user
+// who may invite users to the invitation's account
+func (h *AccountHandler) DeliverInvitation(ctx khttp.Context) error {
+	vars := ctx.Vars()
+	invitationIDSlice, exists := vars["id"]
+	if !exists || len(invitationIDSlice) == 0 || strings.TrimSpace(invitationIDSlice[0]) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_INVITATION_ID", "Invitation ID is required")
+	}
+
+	requesterID := requestUserID(ctx.Request())
+	if requesterID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	err := h.accountService.DeliverInvitation(ctx.Request().Context(), invitationIDSlice[0], requesterID)
+	switch {
+	case errors.Is(err, domain.ErrInvitationNotPending):
+		return h.handleError(ctx, http.StatusConflict, "INVITATION_NOT_PENDING", err.Error())
+	case errors.Is(err, domain.ErrInvitationDeliveryFailed):
+		return h.handleError(ctx, http.StatusBadGateway, "INVITATION_DELIVERY_FAILED", err.Error())
+	case err != nil:
+		return h.handleServiceError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "Invitation delivered successfully"})
+}
+
 // AcceptInvitation handles invitation acceptance requests
 func (h *AccountHandler) AcceptInvitation(ctx khttp.Context) error {
 	// Parse request body
@@ -188,6 +290,51 @@ func (h *AccountHandler) AcceptInvitation(ctx khttp.Context) error {
 	return ctx.JSON(http.StatusOK, map[string]string{"message": "Invitation accepted successfully"})
 }
 
+// ListMembers handles GET /api/v1/accounts/{id}/members. Supports limit, offset and role; the
+// response carries the total number of matching members so clients can page through them.
+func (h *AccountHandler) ListMembers(ctx khttp.Context) error {
+	vars := ctx.Vars()
+	accountIDSlice, exists := vars["id"]
+	if !exists || len(accountIDSlice) == 0 || strings.TrimSpace(accountIDSlice[0]) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_ACCOUNT_ID", "Account ID is required")
+	}
+
+	query := ctx.Request().URL.Query()
+	opts := domain.PaginationOptions{Limit: defaultMemberPageSize}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 || value > maxMemberPageSize {
+			return h.handleError(ctx, http.StatusBadRequest, "INVALID_LIMIT",
+				fmt.Sprintf("Limit must be an integer between 1 and %d", maxMemberPageSize))
+		}
+		opts.Limit = value
+	}
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return h.handleError(ctx, http.StatusBadRequest, "INVALID_OFFSET", "Offset must be a non-negative integer")
+		}
+		opts.Offset = value
+	}
+
+	members, total, err := h.accountService.ListMembers(ctx.Request().Context(), accountIDSlice[0], opts, strings.TrimSpace(query.Get("role")))
+	if err != nil {
+		return h.handleServiceError(ctx, err)
+	}
+
+	response := MemberListResponse{
+		Members: make([]MemberResponse, len(members)),
+		Total:   total,
+		Limit:   opts.Limit,
+		Offset:  opts.Offset,
+	}
+	for i, member := range members {
+		response.Members[i] = h.buildMemberResponse(member)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // UpdateMemberRole handles member role update requests
 func (h *AccountHandler) UpdateMemberRole(ctx khttp.Context) error {
 	// Extract account ID from path
@@ -236,6 +383,44 @@ func (h *AccountHandler) UpdateMemberRole(ctx khttp.Context) error {
 	return ctx.JSON(http.StatusOK, map[string]string{"message": "Member role updated successfully"})
 }
 
+// PatchAccountSettings handles partial account settings updates; omitted fields keep their current values
+func (h *AccountHandler) PatchAccountSettings(ctx khttp.Context) error {
+	// Extract account ID from path
+	vars := ctx.Vars()
+	accountIDSlice, exists := vars["id"]
+	if !exists || len(accountIDSlice) == 0 {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_ACCOUNT_ID", "Account ID is required")
+	}
+
+	accountID := accountIDSlice[0]
+	if strings.TrimSpace(accountID) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "INVALID_ACCOUNT_ID", "Account ID is required")
+	}
+
+	requesterID := requestUserID(ctx.Request())
+	if requesterID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	// Parse request body
+	var req PatchAccountSettingsRequest
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&req); err != nil {
+		return h.handleError(ctx, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+	}
+
+	if req.IsEmpty() {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_SETTINGS", "At least one setting is required")
+	}
+
+	// The service refuses requesters whose role cannot update the account
+	account, err := h.accountService.PatchAccountSettings(ctx.Request().Context(), accountID, requesterID, req)
+	if err != nil {
+		return h.handleServiceError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, h.buildAccountResponse(account))
+}
+
 // Helper methods
 
 func (h *AccountHandler) validateCreateAccountRequest(req CreateAccountRequest, ownerID string) error {
@@ -281,6 +466,11 @@ func (h *AccountHandler) validateInviteUserRequest(req InviteUserRequest, accoun
 }
 
 func (h *AccountHandler) buildAccountResponse(account *domain.Account) AccountResponse {
+	return newAccountResponse(account)
+}
+
+// newAccountResponse converts an account into its HTTP representation
+func newAccountResponse(account *domain.Account) AccountResponse {
 	return AccountResponse{
 		ID:          account.ID(),
 		OwnerID:     account.OwnerID,
@@ -305,6 +495,17 @@ func (h *AccountHandler) buildInvitationResponse(invitation *domain.Invitation)
 	}
 }
 
+func (h *AccountHandler) buildMemberResponse(member *domain.AccountMember) MemberResponse {
+	return MemberResponse{
+		ID:        member.ID(),
+		AccountID: member.AccountID,
+		UserID:    member.UserID,
+		RoleID:    member.RoleID,
+		InvitedBy: member.InvitedBy,
+		JoinedAt:  member.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
 func (h *AccountHandler) handleError(ctx khttp.Context, status int, code, message string) error {
 	response := map[string]interface{}{
 		"error":   code,
@@ -317,6 +518,10 @@ func (h *AccountHandler) handleServiceError(ctx khttp.Context, err error) error
 	errMsg := strings.ToLower(err.Error())
 
 	switch {
+	case errors.Is(err, domain.ErrTooManyPendingInvitations):
+		return h.handleError(ctx, http.StatusConflict, "TOO_MANY_PENDING_INVITATIONS", err.Error())
+	case errors.Is(err, domain.ErrInsufficientPermissions):
+		return h.handleError(ctx, http.StatusForbidden, "FORBIDDEN", "Insufficient permissions")
 	case strings.Contains(errMsg, "not found"):
 		return h.handleError(ctx, http.StatusNotFound, "NOT_FOUND", "Resource not found")
 	case strings.Contains(errMsg, "insufficient permissions"):
@@ -2,17 +2,21 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
 	"github.com/akeemphilbert/goro/internal/user/application"
 	"github.com/akeemphilbert/goro/internal/user/domain"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockAccountService is a mock implementation of AccountService for testing
@@ -46,6 +50,50 @@ func (m *MockAccountService) UpdateMemberRole(ctx context.Context, accountID, us
 	return args.Error(0)
 }
 
+func (m *MockAccountService) RemoveMember(ctx context.Context, accountID, userID, removedByID, reason string) error {
+	args := m.Called(ctx, accountID, userID, removedByID, reason)
+	return args.Error(0)
+}
+
+func (m *MockAccountService) TransferOwnership(ctx context.Context, accountID, currentOwnerID, newOwnerID string) error {
+	args := m.Called(ctx, accountID, currentOwnerID, newOwnerID)
+	return args.Error(0)
+}
+
+func (m *MockAccountService) LeaveAccount(ctx context.Context, accountID, userID, successorID string) error {
+	args := m.Called(ctx, accountID, userID, successorID)
+	return args.Error(0)
+}
+
+func (m *MockAccountService) DeliverInvitation(ctx context.Context, invitationID, requesterID string) error {
+	args := m.Called(ctx, invitationID, requesterID)
+	return args.Error(0)
+}
+
+func (m *MockAccountService) ResendInvitation(ctx context.Context, req application.ResendInvitationRequest) (*domain.Invitation, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockAccountService) PatchAccountSettings(ctx context.Context, accountID, requesterID string, patch domain.AccountSettingsPatch) (*domain.Account, error) {
+	args := m.Called(ctx, accountID, requesterID, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountService) ListMembers(ctx context.Context, accountID string, opts domain.PaginationOptions, roleFilter string) ([]*domain.AccountMember, int, error) {
+	args := m.Called(ctx, accountID, opts, roleFilter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.AccountMember), args.Int(1), args.Error(2)
+}
+
 // MockUserService for account handler tests
 type MockUserServiceForAccount struct {
 	mock.Mock
@@ -85,6 +133,19 @@ func (m *MockUserServiceForAccount) GetUserByWebID(ctx context.Context, webID st
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserServiceForAccount) UnlinkOAuthProvider(ctx context.Context, userID, provider string) error {
+	args := m.Called(ctx, userID, provider)
+	return args.Error(0)
+}
+
+func (m *MockUserServiceForAccount) GetOwnedAccounts(ctx context.Context, userID string) ([]*domain.Account, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Account), args.Error(1)
+}
+
 // Test data structures for account management
 type CreateAccountRequestHTTP struct {
 	Name        string `json:"name"`
@@ -605,6 +666,199 @@ func checkPermission(userRole, operation string) bool {
 	return false
 }
 
+// TestAccountHandlers_ListMembers tests paging through an account's members
+func TestAccountHandlers_ListMembers(t *testing.T) {
+	vars := map[string][]string{"id": {"account-1"}}
+
+	t.Run("should return the requested page with the total", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		members := []*domain.AccountMember{
+			{BasicEntity: pericarpdomain.NewEntity("member-3"), AccountID: "account-1", UserID: "user-3", RoleID: "admin", JoinedAt: time.Now()},
+		}
+		opts := domain.PaginationOptions{Limit: 2, Offset: 2}
+		mockAccountService.On("ListMembers", mock.Anything, "account-1", opts, "admin").Return(members, 3, nil)
+
+		ctx := createTestContext("GET", "/api/v1/accounts/account-1/members?limit=2&offset=2&role=admin", nil, vars)
+		require.NoError(t, handler.ListMembers(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var body MemberListResponse
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, 3, body.Total)
+		assert.Equal(t, 2, body.Limit)
+		assert.Equal(t, 2, body.Offset)
+		require.Len(t, body.Members, 1)
+		assert.Equal(t, "member-3", body.Members[0].ID)
+		assert.Equal(t, "admin", body.Members[0].RoleID)
+		mockAccountService.AssertExpectations(t)
+	})
+
+	t.Run("should apply the default page size", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		opts := domain.PaginationOptions{Limit: defaultMemberPageSize}
+		mockAccountService.On("ListMembers", mock.Anything, "account-1", opts, "").Return([]*domain.AccountMember{}, 0, nil)
+
+		ctx := createTestContext("GET", "/api/v1/accounts/account-1/members", nil, vars)
+		require.NoError(t, handler.ListMembers(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid pagination", func(t *testing.T) {
+		handler := NewAccountHandler(new(MockAccountService), new(MockUserServiceForAccount), log.DefaultLogger)
+
+		for _, query := range []string{"limit=0", "limit=1001", "limit=ten", "offset=-1"} {
+			ctx := createTestContext("GET", "/api/v1/accounts/account-1/members?"+query, nil, vars)
+			require.NoError(t, handler.ListMembers(ctx))
+			assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code, query)
+		}
+	})
+
+	t.Run("should answer 404 for an unknown account", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		mockAccountService.On("ListMembers", mock.Anything, "account-1", mock.Anything, "").
+			Return(nil, 0, fmt.Errorf("failed to get account: account not found: account-1"))
+
+		ctx := createTestContext("GET", "/api/v1/accounts/account-1/members", nil, vars)
+		require.NoError(t, handler.ListMembers(ctx))
+
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	})
+}
+
+func TestAccountHandlers_ResendInvitation(t *testing.T) {
+	vars := map[string][]string{"id": {"account-1"}, "invitation_id": {"invitation-1"}}
+
+	t.Run("should resend as the verified user, not one named in the body", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		invitation := &domain.Invitation{
+			BasicEntity: pericarpdomain.NewEntity("invitation-1"),
+			AccountID:   "account-1",
+			Status:      domain.InvitationStatusPending,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+		mockAccountService.On("ResendInvitation", mock.Anything, application.ResendInvitationRequest{
+			AccountID: "account-1", InvitationID: "invitation-1", ResentByID: "owner-1", RegenerateToken: true,
+		}).Return(invitation, nil)
+
+		body := []byte(`{"resent_by_id":"mallory","regenerate_token":true}`)
+		ctx := createTestContext("POST", "/api/v1/accounts/account-1/invitations/invitation-1/resend", body, vars)
+		authenticateAs(ctx, middleware.Identity{Subject: "owner-1"})
+		require.NoError(t, handler.ResendInvitation(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertExpectations(t)
+	})
+
+	t.Run("should require a verified identity", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		body := []byte(`{"resent_by_id":"owner-1"}`)
+		ctx := createTestContext("POST", "/api/v1/accounts/account-1/invitations/invitation-1/resend", body, vars)
+		require.NoError(t, handler.ResendInvitation(ctx))
+
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertNotCalled(t, "ResendInvitation", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountHandlers_DeliverInvitation(t *testing.T) {
+	vars := map[string][]string{"id": {"invitation-1"}}
+
+	t.Run("should deliver as the verified user", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+		mockAccountService.On("DeliverInvitation", mock.Anything, "invitation-1", "owner-1").Return(nil)
+
+		ctx := createTestContext("POST", "/api/v1/invitations/invitation-1/deliver", nil, vars)
+		authenticateAs(ctx, middleware.Identity{Subject: "owner-1"})
+		require.NoError(t, handler.DeliverInvitation(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertExpectations(t)
+	})
+
+	t.Run("should refuse users who may not invite to the account", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+		mockAccountService.On("DeliverInvitation", mock.Anything, "invitation-1", "mallory").Return(domain.ErrInsufficientPermissions)
+
+		ctx := createTestContext("POST", "/api/v1/invitations/invitation-1/deliver", nil, vars)
+		authenticateAs(ctx, middleware.Identity{Subject: "mallory"})
+		require.NoError(t, handler.DeliverInvitation(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should require a verified identity", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		ctx := createTestContext("POST", "/api/v1/invitations/invitation-1/deliver", nil, vars)
+		require.NoError(t, handler.DeliverInvitation(ctx))
+
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertNotCalled(t, "DeliverInvitation", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountHandlers_PatchAccountSettings(t *testing.T) {
+	vars := map[string][]string{"id": {"account-1"}}
+	body := []byte(`{"allow_invitations":false}`)
+
+	t.Run("should patch as the verified user", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		account := &domain.Account{BasicEntity: pericarpdomain.NewEntity("account-1"), OwnerID: "owner-1", Name: "Team"}
+		mockAccountService.On("PatchAccountSettings", mock.Anything, "account-1", "owner-1", mock.Anything).Return(account, nil)
+
+		ctx := createTestContext("PATCH", "/api/v1/accounts/account-1/settings", body, vars)
+		authenticateAs(ctx, middleware.Identity{Subject: "owner-1"})
+		require.NoError(t, handler.PatchAccountSettings(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertExpectations(t)
+	})
+
+	t.Run("should refuse users who cannot manage the account", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		mockAccountService.On("PatchAccountSettings", mock.Anything, "account-1", "viewer-1", mock.Anything).
+			Return(nil, fmt.Errorf("%w: account update on account account-1", domain.ErrInsufficientPermissions))
+
+		ctx := createTestContext("PATCH", "/api/v1/accounts/account-1/settings", body, vars)
+		authenticateAs(ctx, middleware.Identity{Subject: "viewer-1"})
+		require.NoError(t, handler.PatchAccountSettings(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should require a verified identity", func(t *testing.T) {
+		mockAccountService := new(MockAccountService)
+		handler := NewAccountHandler(mockAccountService, new(MockUserServiceForAccount), log.DefaultLogger)
+
+		ctx := createTestContext("PATCH", "/api/v1/accounts/account-1/settings", body, vars)
+		require.NoError(t, handler.PatchAccountSettings(ctx))
+
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+		mockAccountService.AssertNotCalled(t, "PatchAccountSettings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 // contains function for testing (reused from user_handlers_test.go)
 func containsForAccount(s, substr string) bool {
 	return strings.Contains(s, substr)
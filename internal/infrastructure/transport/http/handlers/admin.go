@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Admin authorization errors
+var (
+	ErrUnauthenticated = errors.Unauthorized("UNAUTHENTICATED", "admin credentials required")
+	ErrForbidden       = errors.Forbidden("FORBIDDEN", "admin privileges required")
+)
+
+// AdminHandler handles operator endpoints restricted to admin principals
+type AdminHandler struct {
+	adminTokens [][]byte
+	deadLetters DeadLetterManager
+	eventLog    EventLogStreamer
+	retention   RetentionSweepRunner
+	caches      CacheInvalidationRunner
+	logger      log.Logger
+}
+
+// NewAdminHandler creates a new admin handler; requests are only authorized
+// when they present one of the given bearer tokens
+func NewAdminHandler(adminTokens []string, logger log.Logger) *AdminHandler {
+	tokens := make([][]byte, 0, len(adminTokens))
+	for _, token := range adminTokens {
+		if token != "" {
+			tokens = append(tokens, []byte(token))
+		}
+	}
+
+	return &AdminHandler{
+		adminTokens: tokens,
+		logger:      logger,
+	}
+}
+
+// GetStats returns the server-wide statistics. Values are read from in-process
+// counters, so the request never touches storage.
+func (h *AdminHandler) GetStats(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, metrics.SnapshotServerStats())
+}
+
+// authorize checks that the request carries an admin bearer token
+func (h *AdminHandler) authorize(req *http.Request) error {
+	header := req.Header.Get("Authorization")
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || strings.TrimSpace(token) == "" {
+		return ErrUnauthenticated
+	}
+
+	for _, adminToken := range h.adminTokens {
+		if subtle.ConstantTimeCompare([]byte(token), adminToken) == 1 {
+			return nil
+		}
+	}
+	return ErrForbidden
+}
+
+// handleError writes an authorization error response
+func (h *AdminHandler) handleError(ctx khttp.Context, err error) error {
+	kratosErr := errors.FromError(err)
+	h.logger.Log(log.LevelWarn, "msg", "Admin request rejected", "path", ctx.Request().URL.Path, "reason", kratosErr.Reason)
+
+	if kratosErr.Code == http.StatusUnauthorized {
+		ctx.Response().Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+	}
+	return ctx.JSON(int(kratosErr.Code), map[string]interface{}{
+		"error":   kratosErr.Reason,
+		"message": kratosErr.Message,
+	})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetCacheInvalidationRunner enables the endpoint dropping cached container and resource data
+func (h *AdminHandler) SetCacheInvalidationRunner(runner CacheInvalidationRunner) {
+	h.caches = runner
+}
+
+// InvalidateCache handles POST /admin/cache/invalidate, dropping cached entries so they are
+// read from storage on next access. ?container=ID drops a container's entries and its
+// members'; ?all=true clears every cache.
+func (h *AdminHandler) InvalidateCache(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+	if h.caches == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "CACHE_INVALIDATION_DISABLED",
+			"message": "cache invalidation is not configured",
+		})
+	}
+
+	query := ctx.Request().URL.Query()
+	containerID := strings.TrimSpace(query.Get("container"))
+	all := query.Get("all") == "true"
+	if (containerID == "") == !all {
+		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "INVALID_REQUEST",
+			"message": "exactly one of container=ID or all=true is required",
+		})
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	if all {
+		invalidation := h.caches.InvalidateAllCaches(ctx.Request().Context())
+		h.logger.Log(log.LevelInfo, "msg", "Invalidated all caches", "entries", invalidation.Entries)
+		return ctx.JSON(http.StatusOK, invalidation)
+	}
+
+	invalidation, err := h.caches.InvalidateContainerCaches(ctx.Request().Context(), containerID)
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Cache invalidation failed", "container", containerID, "error", err)
+		status, code := http.StatusInternalServerError, "CACHE_INVALIDATION_FAILED"
+		if domain.IsInvalidID(err) {
+			status, code = http.StatusBadRequest, "INVALID_ID"
+		}
+		return ctx.JSON(status, map[string]interface{}{
+			"error":   code,
+			"message": "cache invalidation failed",
+		})
+	}
+
+	h.logger.Log(log.LevelInfo, "msg", "Invalidated container caches", "container", containerID, "entries", invalidation.Entries)
+	return ctx.JSON(http.StatusOK, invalidation)
+}
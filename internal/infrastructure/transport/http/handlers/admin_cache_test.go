@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCacheInvalidator records which invalidations were requested
+type recordingCacheInvalidator struct {
+	containers []string
+	cleared    int
+}
+
+func (r *recordingCacheInvalidator) InvalidateContainerCaches(ctx context.Context, containerID string) (application.CacheInvalidation, error) {
+	r.containers = append(r.containers, containerID)
+	return application.CacheInvalidation{ContainerID: containerID, Entries: 3}, nil
+}
+
+func (r *recordingCacheInvalidator) InvalidateAllCaches(ctx context.Context) application.CacheInvalidation {
+	r.cleared++
+	return application.CacheInvalidation{Entries: 10}
+}
+
+func newCacheAdminHandler() (*AdminHandler, *recordingCacheInvalidator) {
+	caches := &recordingCacheInvalidator{}
+	handler := NewAdminHandler([]string{"admin-secret"}, log.DefaultLogger)
+	handler.SetCacheInvalidationRunner(caches)
+	return handler, caches
+}
+
+func TestAdminHandler_InvalidateCache_Container(t *testing.T) {
+	handler, caches := newCacheAdminHandler()
+
+	ctx := createTestContext("POST", "/admin/cache/invalidate?container=photos", nil, nil)
+	ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+	require.NoError(t, handler.InvalidateCache(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var body application.CacheInvalidation
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "photos", body.ContainerID)
+	assert.Equal(t, 3, body.Entries)
+	assert.Equal(t, []string{"photos"}, caches.containers)
+	assert.Zero(t, caches.cleared)
+}
+
+func TestAdminHandler_InvalidateCache_All(t *testing.T) {
+	handler, caches := newCacheAdminHandler()
+
+	ctx := createTestContext("POST", "/admin/cache/invalidate?all=true", nil, nil)
+	ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+	require.NoError(t, handler.InvalidateCache(ctx))
+
+	require.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+	assert.Equal(t, 1, caches.cleared)
+	assert.Empty(t, caches.containers)
+}
+
+func TestAdminHandler_InvalidateCache_RequiresOneTarget(t *testing.T) {
+	handler, caches := newCacheAdminHandler()
+
+	for _, query := range []string{"", "?container=photos&all=true"} {
+		ctx := createTestContext("POST", "/admin/cache/invalidate"+query, nil, nil)
+		ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+		require.NoError(t, handler.InvalidateCache(ctx))
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code, query)
+	}
+	assert.Empty(t, caches.containers)
+	assert.Zero(t, caches.cleared)
+}
+
+func TestAdminHandler_InvalidateCache_RequiresAdmin(t *testing.T) {
+	handler, caches := newCacheAdminHandler()
+
+	ctx := createTestContext("POST", "/admin/cache/invalidate?all=true", nil, nil)
+	require.NoError(t, handler.InvalidateCache(ctx))
+
+	assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+	assert.Zero(t, caches.cleared)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetDeadLetterManager enables the endpoints inspecting and replaying dead-lettered events
+func (h *AdminHandler) SetDeadLetterManager(manager DeadLetterManager) {
+	h.deadLetters = manager
+}
+
+// ListDeadLetters returns the events that exhausted their handler's retry policy
+func (h *AdminHandler) ListDeadLetters(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+	if h.deadLetters == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "DEAD_LETTERS_DISABLED",
+			"message": "dead-lettering is not configured",
+		})
+	}
+
+	letters, err := h.deadLetters.ListDeadLetters(ctx.Request().Context())
+	if err != nil {
+		return h.handleDeadLetterError(ctx, "", err)
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"deadLetters": letters,
+		"count":       len(letters),
+	})
+}
+
+// ReplayDeadLetter hands a dead-lettered event to its handler again. The dead letter is
+// removed when the handler succeeds and kept, with the new error, when it fails.
+func (h *AdminHandler) ReplayDeadLetter(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+	if h.deadLetters == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "DEAD_LETTERS_DISABLED",
+			"message": "dead-lettering is not configured",
+		})
+	}
+
+	id := ctx.Vars().Get("id")
+	if err := h.deadLetters.ReplayDeadLetter(ctx.Request().Context(), id); err != nil {
+		return h.handleDeadLetterError(ctx, id, err)
+	}
+
+	h.logger.Log(log.LevelInfo, "msg", "Replayed dead-lettered event", "id", id)
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"id":     id,
+		"status": "replayed",
+	})
+}
+
+// handleDeadLetterError writes a dead-letter lookup or replay failure
+func (h *AdminHandler) handleDeadLetterError(ctx khttp.Context, id string, err error) error {
+	if domain.IsDeadLetterNotFound(err) {
+		return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "DEAD_LETTER_NOT_FOUND",
+			"message": "no dead-lettered event has this ID",
+		})
+	}
+
+	h.logger.Log(log.LevelError, "msg", "Dead letter request failed", "id", id, "error", err)
+	code := "DEAD_LETTER_FAILED"
+	if storageErr, ok := domain.GetStorageError(err); ok {
+		code = storageErr.Code
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
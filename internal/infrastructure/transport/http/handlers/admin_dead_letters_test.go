@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDeadLetters serves dead letters from a map and replays by removing them
+type memoryDeadLetters struct {
+	letters map[string]domain.DeadLetter
+}
+
+func (m *memoryDeadLetters) ListDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	letters := make([]domain.DeadLetter, 0, len(m.letters))
+	for _, letter := range m.letters {
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
+
+func (m *memoryDeadLetters) ReplayDeadLetter(ctx context.Context, id string) error {
+	if _, ok := m.letters[id]; !ok {
+		return domain.ErrDeadLetterNotFound
+	}
+	delete(m.letters, id)
+	return nil
+}
+
+func newDeadLetterAdminHandler() (*AdminHandler, *memoryDeadLetters) {
+	deadLetters := &memoryDeadLetters{letters: map[string]domain.DeadLetter{
+		"resource:event-1": {ID: "resource:event-1", Handler: "resource", EventType: "resource.created", Attempts: 5},
+	}}
+	handler := NewAdminHandler([]string{"admin-secret"}, log.DefaultLogger)
+	handler.SetDeadLetterManager(deadLetters)
+	return handler, deadLetters
+}
+
+func TestAdminHandler_ListDeadLetters(t *testing.T) {
+	handler, _ := newDeadLetterAdminHandler()
+
+	ctx := createTestContext("GET", "/admin/dead-letters", nil, nil)
+	ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+	require.NoError(t, handler.ListDeadLetters(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var body struct {
+		DeadLetters []domain.DeadLetter `json:"deadLetters"`
+		Count       int                 `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Count)
+	require.Len(t, body.DeadLetters, 1)
+	assert.Equal(t, "resource.created", body.DeadLetters[0].EventType)
+}
+
+func TestAdminHandler_ListDeadLetters_RequiresAdmin(t *testing.T) {
+	handler, _ := newDeadLetterAdminHandler()
+
+	ctx := createTestContext("GET", "/admin/dead-letters", nil, nil)
+	ctx.Request().Header.Set("Authorization", "Bearer someone-else")
+	require.NoError(t, handler.ListDeadLetters(ctx))
+
+	assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+}
+
+func TestAdminHandler_ReplayDeadLetter(t *testing.T) {
+	handler, deadLetters := newDeadLetterAdminHandler()
+
+	ctx := createTestContext("POST", "/admin/dead-letters/resource:event-1/replay", nil, map[string][]string{"id": {"resource:event-1"}})
+	ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+	require.NoError(t, handler.ReplayDeadLetter(ctx))
+
+	assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+	assert.Empty(t, deadLetters.letters)
+
+	ctx = createTestContext("POST", "/admin/dead-letters/resource:event-1/replay", nil, map[string][]string{"id": {"resource:event-1"}})
+	ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+	require.NoError(t, handler.ReplayDeadLetter(ctx))
+
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+}
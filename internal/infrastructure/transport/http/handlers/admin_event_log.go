@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetEventLogStreamer enables the endpoint exporting the event log for archival
+func (h *AdminHandler) SetEventLogStreamer(streamer EventLogStreamer) {
+	h.eventLog = streamer
+}
+
+// ExportEventLog streams the event log as newline-delimited JSON, one event per line in
+// sequence order. An archiver resumes with ?after=<last sequence it stored>, or selects events
+// by time with ?since=<RFC 3339 timestamp>. Entries are written as they are read, so the
+// response starts before the whole log has been read.
+func (h *AdminHandler) ExportEventLog(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+	if h.eventLog == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "EVENT_LOG_EXPORT_DISABLED",
+			"message": "event log export is not configured",
+		})
+	}
+
+	query := ctx.Request().URL.Query()
+	sinceParam, afterParam := query.Get("since"), query.Get("after")
+	if sinceParam != "" && afterParam != "" {
+		return h.invalidEventLogQuery(ctx, "use either since or after, not both")
+	}
+
+	var since time.Time
+	if sinceParam != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceParam)
+		if err != nil {
+			return h.invalidEventLogQuery(ctx, "since must be an RFC 3339 timestamp")
+		}
+		since = parsed
+	}
+
+	var after int64
+	if afterParam != "" {
+		parsed, err := strconv.ParseInt(afterParam, 10, 64)
+		if err != nil || parsed < 0 {
+			return h.invalidEventLogQuery(ctx, "after must be a non-negative sequence number")
+		}
+		after = parsed
+	}
+
+	w := ctx.Response()
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	var err error
+	if afterParam != "" {
+		err = h.eventLog.StreamEventLogFrom(ctx.Request().Context(), after, w)
+	} else {
+		err = h.eventLog.StreamEventLog(ctx.Request().Context(), since, w)
+	}
+	if err != nil {
+		// The status line is already sent; the archiver sees a truncated stream and resumes
+		// after the last complete line
+		h.logger.Log(log.LevelError, "msg", "Event log export failed", "error", err)
+	}
+	return nil
+}
+
+// invalidEventLogQuery writes a rejected export query
+func (h *AdminHandler) invalidEventLogQuery(ctx khttp.Context, message string) error {
+	return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
+		"error":   "INVALID_EVENT_LOG_QUERY",
+		"message": message,
+	})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEventLog writes one line per call and records the requested bounds
+type recordingEventLog struct {
+	since time.Time
+	after int64
+	from  bool
+}
+
+func (r *recordingEventLog) StreamEventLog(ctx context.Context, since time.Time, w io.Writer) error {
+	r.since = since
+	_, err := fmt.Fprintln(w, `{"sequence":1}`)
+	return err
+}
+
+func (r *recordingEventLog) StreamEventLogFrom(ctx context.Context, afterSequence int64, w io.Writer) error {
+	r.after, r.from = afterSequence, true
+	_, err := fmt.Fprintln(w, `{"sequence":8}`)
+	return err
+}
+
+func newEventLogAdminHandler() (*AdminHandler, *recordingEventLog) {
+	eventLog := &recordingEventLog{}
+	handler := NewAdminHandler([]string{"admin-secret"}, log.DefaultLogger)
+	handler.SetEventLogStreamer(eventLog)
+	return handler, eventLog
+}
+
+func TestAdminHandler_ExportEventLog(t *testing.T) {
+	t.Run("streams the log since a time", func(t *testing.T) {
+		handler, eventLog := newEventLogAdminHandler()
+
+		ctx := createTestContext("GET", "/admin/events?since=2025-03-01T12:00:00Z", nil, nil)
+		ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+		require.NoError(t, handler.ExportEventLog(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/x-ndjson", response.Header().Get("Content-Type"))
+		assert.Equal(t, `{"sequence":1}`, strings.TrimSpace(response.Body.String()))
+		assert.True(t, eventLog.since.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)))
+		assert.False(t, eventLog.from)
+	})
+
+	t.Run("resumes after a sequence number", func(t *testing.T) {
+		handler, eventLog := newEventLogAdminHandler()
+
+		ctx := createTestContext("GET", "/admin/events?after=7", nil, nil)
+		ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+		require.NoError(t, handler.ExportEventLog(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		assert.True(t, eventLog.from)
+		assert.Equal(t, int64(7), eventLog.after)
+	})
+
+	t.Run("rejects invalid bounds", func(t *testing.T) {
+		handler, _ := newEventLogAdminHandler()
+
+		for _, query := range []string{"since=yesterday", "after=-1", "after=1&since=2025-03-01T12:00:00Z"} {
+			ctx := createTestContext("GET", "/admin/events?"+query, nil, nil)
+			ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+			require.NoError(t, handler.ExportEventLog(ctx))
+			assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code, query)
+		}
+	})
+
+	t.Run("requires an admin token", func(t *testing.T) {
+		handler, _ := newEventLogAdminHandler()
+
+		ctx := createTestContext("GET", "/admin/events", nil, nil)
+		require.NoError(t, handler.ExportEventLog(ctx))
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetRetentionSweepRunner enables the endpoints running and reporting the retention sweep
+func (h *AdminHandler) SetRetentionSweepRunner(runner RetentionSweepRunner) {
+	h.retention = runner
+}
+
+// GetRetentionReport returns how many members the most recent retention sweep expired per
+// container; 204 No Content before the first sweep
+func (h *AdminHandler) GetRetentionReport(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+	if h.retention == nil {
+		return h.retentionDisabled(ctx)
+	}
+
+	report, ok := h.retention.LastReport()
+	if !ok {
+		ctx.Response().WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, report)
+}
+
+// SweepRetention runs the retention sweep now, without waiting for its schedule, and returns
+// its report
+func (h *AdminHandler) SweepRetention(ctx khttp.Context) error {
+	if err := h.authorize(ctx.Request()); err != nil {
+		return h.handleError(ctx, err)
+	}
+	if h.retention == nil {
+		return h.retentionDisabled(ctx)
+	}
+
+	report, err := h.retention.Sweep(ctx.Request().Context())
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Retention sweep failed", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"error":   "RETENTION_SWEEP_FAILED",
+			"message": "retention sweep failed",
+		})
+	}
+
+	h.logger.Log(log.LevelInfo, "msg", "Ran retention sweep", "expired", report.Expired, "failed", report.Failed)
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, report)
+}
+
+// retentionDisabled writes the response for retention endpoints when no sweeper is configured
+func (h *AdminHandler) retentionDisabled(ctx khttp.Context) error {
+	return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+		"error":   "RETENTION_DISABLED",
+		"message": "retention sweep is not configured",
+	})
+}
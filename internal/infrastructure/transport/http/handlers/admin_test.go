@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler_GetStats_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{"missing credentials", "", http.StatusUnauthorized},
+		{"non-bearer credentials", "Basic YWRtaW46c2VjcmV0", http.StatusUnauthorized},
+		{"non-admin token", "Bearer someone-else", http.StatusForbidden},
+		{"admin token", "Bearer admin-secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAdminHandler([]string{"admin-secret"}, log.DefaultLogger)
+
+			ctx := createTestContext("GET", "/admin/stats", nil, nil)
+			if tt.authorization != "" {
+				ctx.Request().Header.Set("Authorization", tt.authorization)
+			}
+			assert.NoError(t, handler.GetStats(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, tt.expectedStatus, response.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="admin"`, response.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetStats_NoTokensConfigured(t *testing.T) {
+	handler := NewAdminHandler(nil, log.DefaultLogger)
+
+	ctx := createTestContext("GET", "/admin/stats", nil, nil)
+	ctx.Request().Header.Set("Authorization", "Bearer anything")
+	assert.NoError(t, handler.GetStats(ctx))
+
+	assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+}
+
+func TestAdminHandler_GetStats_ReturnsCounters(t *testing.T) {
+	handler := NewAdminHandler([]string{"admin-secret"}, log.DefaultLogger)
+
+	ctx := createTestContext("GET", "/admin/stats", nil, nil)
+	ctx.Request().Header.Set("Authorization", "Bearer admin-secret")
+	require.NoError(t, handler.GetStats(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var stats map[string]interface{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &stats))
+	for _, field := range []string{"totalContainers", "totalResources", "totalBytes", "activeSessions", "eventsPerSecond", "cacheHitRate", "since"} {
+		assert.Contains(t, stats, field)
+	}
+}
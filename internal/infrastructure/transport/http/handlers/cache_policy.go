@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// CachePolicy decides the Cache-Control directives of successful reads. Resources and
+// containers change under their URLs, so they default to no-cache and are revalidated
+// against their ETags; content that never changes, such as version diffs, may be cached for
+// good. Directives in a container's or resource's "cacheControl" metadata override the
+// configured ones.
+type CachePolicy struct {
+	resources    string
+	contentTypes map[string]string
+	containers   string
+	versions     string
+}
+
+// defaultCachePolicy is used by handlers that were not given a policy
+var defaultCachePolicy = NewCachePolicy(conf.CacheControl{})
+
+// NewCachePolicy creates a cache policy from configuration, filling in the defaults of any
+// directives left empty
+func NewCachePolicy(config conf.CacheControl) *CachePolicy {
+	config.SetDefaults()
+	contentTypes := make(map[string]string, len(config.ContentTypes))
+	for contentType, directives := range config.ContentTypes {
+		contentTypes[baseMediaType(contentType)] = strings.TrimSpace(directives)
+	}
+	return &CachePolicy{
+		resources:    config.Resources,
+		contentTypes: contentTypes,
+		containers:   config.Containers,
+		versions:     config.Versions,
+	}
+}
+
+// ForResource returns the directives of a resource served as contentType: its metadata
+// override, else the rule for its exact media type, else the rule for its type wildcard,
+// else the resource default
+func (p *CachePolicy) ForResource(contentType string, metadata map[string]interface{}) string {
+	if directives, ok := domain.CacheControlOverride(metadata); ok {
+		return directives
+	}
+	mediaType := baseMediaType(contentType)
+	if directives, ok := p.contentTypes[mediaType]; ok {
+		return directives
+	}
+	if kind, _, ok := strings.Cut(mediaType, "/"); ok {
+		if directives, ok := p.contentTypes[kind+"/*"]; ok {
+			return directives
+		}
+	}
+	return p.resources
+}
+
+// ForContainer returns the directives of a container listing: the container's metadata
+// override, else the container default
+func (p *CachePolicy) ForContainer(metadata map[string]interface{}) string {
+	if directives, ok := domain.CacheControlOverride(metadata); ok {
+		return directives
+	}
+	return p.containers
+}
+
+// ForVersion returns the directives of responses about fixed stored versions
+func (p *CachePolicy) ForVersion() string {
+	return p.versions
+}
+
+// SetCachePolicy sets the policy deciding the Cache-Control directives of resource reads
+func (h *ResourceHandler) SetCachePolicy(policy *CachePolicy) {
+	h.cachePolicy = policy
+}
+
+// caching returns the handler's cache policy, falling back to the default policy
+func (h *ResourceHandler) caching() *CachePolicy {
+	if h.cachePolicy == nil {
+		return defaultCachePolicy
+	}
+	return h.cachePolicy
+}
+
+// SetResourceStreamer sets the source of resource streams carrying resource metadata, so
+// streamed resources get their metadata's Cache-Control override; without one, streamed
+// resources get the directives of their content type
+func (h *ResourceHandler) SetResourceStreamer(streamer ResourceMetadataStreamer) {
+	h.resourceStreamer = streamer
+}
+
+// SetCachePolicy sets the policy deciding the Cache-Control directives of container reads
+func (h *ContainerHandler) SetCachePolicy(policy *CachePolicy) {
+	h.cachePolicy = policy
+}
+
+// caching returns the handler's cache policy, falling back to the default policy
+func (h *ContainerHandler) caching() *CachePolicy {
+	if h.cachePolicy == nil {
+		return defaultCachePolicy
+	}
+	return h.cachePolicy
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachePolicy_ForResource(t *testing.T) {
+	policy := NewCachePolicy(conf.CacheControl{
+		ContentTypes: map[string]string{
+			"image/*":         "public, max-age=86400",
+			"Application/PDF": "public, max-age=3600",
+		},
+	})
+
+	assert.Equal(t, "no-cache", policy.ForResource("text/turtle", nil), "mutable resources revalidate by default")
+	assert.Equal(t, "public, max-age=86400", policy.ForResource("image/png", nil))
+	assert.Equal(t, "public, max-age=3600", policy.ForResource("application/pdf; version=1.7", nil))
+	assert.Equal(t, "private, max-age=60", policy.ForResource("image/png", map[string]interface{}{
+		domain.CacheControlKey: "private, max-age=60",
+	}), "metadata overrides the content type rule")
+	assert.Equal(t, "public, max-age=86400", policy.ForResource("image/png", map[string]interface{}{
+		domain.CacheControlKey: " ",
+	}), "a blank override is ignored")
+}
+
+func TestCachePolicy_ForContainerAndVersion(t *testing.T) {
+	policy := NewCachePolicy(conf.CacheControl{Containers: "private, no-cache"})
+
+	assert.Equal(t, "private, no-cache", policy.ForContainer(map[string]interface{}{}))
+	assert.Equal(t, "public, max-age=300", policy.ForContainer(map[string]interface{}{
+		domain.CacheControlKey: "public, max-age=300",
+	}))
+	assert.Equal(t, "public, max-age=31536000, immutable", policy.ForVersion())
+	assert.Equal(t, "no-cache", defaultCachePolicy.ForContainer(nil))
+}
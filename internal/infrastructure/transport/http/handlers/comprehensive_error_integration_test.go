@@ -431,6 +431,17 @@ func (m *MockErrorStorageService) StoreResource(ctx context.Context, id string,
 	return domain.NewResource(ctx, id, contentType, data), nil
 }
 
+func (m *MockErrorStorageService) StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	resource, err := m.StoreResource(ctx, id, data, contentType)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range metadata {
+		resource.SetMetadata(key, value)
+	}
+	return resource, nil
+}
+
 func (m *MockErrorStorageService) RetrieveResource(ctx context.Context, id string, acceptFormat string) (domain.Resource, error) {
 	if m.retrieveError != nil {
 		return nil, m.retrieveError
@@ -469,3 +480,15 @@ func (m *MockErrorStorageService) StoreResourceStream(ctx context.Context, id st
 	}
 	return domain.NewResource(ctx, id, contentType, data), nil
 }
+
+func (m *MockErrorStorageService) PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error) {
+	return m.RetrieveResource(ctx, id, "")
+}
+
+func (m *MockErrorStorageService) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	return m.RetrieveResource(ctx, id, acceptFormat)
+}
+
+func (m *MockErrorStorageService) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	return domain.GraphDiff{}, domain.ErrVersioningDisabled
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Writes are conditional on the entity tags clients read: If-Match makes a PUT, PATCH or
+// DELETE fail with 412 Precondition Failed unless its target is still at the version the client
+// saw, and If-None-Match: * makes a create fail with 412 when its target already exists.
+
+// ifMatchNames reports whether an If-Match header names version. A client may send the ETag
+// of any representation it read, so a tag naming the version in any format matches; "*"
+// matches any current version.
+func ifMatchNames(header, version string) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		opaque := strings.Trim(strings.TrimPrefix(strings.TrimSpace(candidate), "W/"), `"`)
+		if opaque == version || strings.HasPrefix(opaque, version+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// preconditionsFail evaluates If-Match and If-None-Match: * against a target that exists with
+// the given version, or does not exist when exists is false
+func preconditionsFail(req *http.Request, exists bool, version string) bool {
+	if ifMatch := strings.TrimSpace(req.Header.Get("If-Match")); ifMatch != "" {
+		if !exists || !ifMatchNames(ifMatch, version) {
+			return true
+		}
+	}
+	return exists && strings.TrimSpace(req.Header.Get("If-None-Match")) == "*"
+}
+
+// hasWritePreconditions reports whether a write carries preconditions that need its target's
+// current version
+func hasWritePreconditions(req *http.Request) bool {
+	return strings.TrimSpace(req.Header.Get("If-Match")) != "" || strings.TrimSpace(req.Header.Get("If-None-Match")) == "*"
+}
+
+// checkContainerPreconditions answers a container write whose If-Match or If-None-Match does
+// not hold with 412 Precondition Failed, reporting whether a response was written
+func (h *ContainerHandler) checkContainerPreconditions(ctx khttp.Context, id string) (bool, error) {
+	if !hasWritePreconditions(ctx.Request()) {
+		return false, nil
+	}
+
+	container, err := h.containerService.GetContainer(context.Background(), id)
+	if err != nil && !domain.IsResourceNotFound(err) {
+		return true, h.handleContainerError(ctx, err)
+	}
+
+	version := ""
+	if err == nil {
+		version = h.generateContainerETag(container)
+	}
+	if preconditionsFail(ctx.Request(), err == nil, version) {
+		return true, h.writeErrorResponse(ctx, http.StatusPreconditionFailed, "PRECONDITION_FAILED",
+			"The container does not match the request's If-Match or If-None-Match preconditions")
+	}
+	return false, nil
+}
+
+// checkPostedResourcePrecondition answers a POST carrying If-None-Match: * with 412
+// Precondition Failed when a resource already exists under the name its Slug asks for
+func (h *ContainerHandler) checkPostedResourcePrecondition(ctx khttp.Context) (bool, error) {
+	if strings.TrimSpace(ctx.Request().Header.Get("If-None-Match")) != "*" {
+		return false, nil
+	}
+	name := h.slugs().ResourceName(ctx.Request().Header.Get("Slug"))
+	if name == "" {
+		// A generated ID never names an existing resource
+		return false, nil
+	}
+
+	exists, err := h.storageService.ResourceExists(context.Background(), name)
+	if err != nil {
+		return true, h.handleStorageError(ctx, err)
+	}
+	if exists {
+		return true, h.writeErrorResponse(ctx, http.StatusPreconditionFailed, "PRECONDITION_FAILED",
+			"A resource named by the Slug header already exists")
+	}
+	return false, nil
+}
+
+// checkResourcePreconditions answers a resource write whose If-Match or If-None-Match does not
+// hold with 412 Precondition Failed, reporting whether a response was written
+func (h *ResourceHandler) checkResourcePreconditions(ctx khttp.Context, id string) (bool, error) {
+	if !hasWritePreconditions(ctx.Request()) {
+		return false, nil
+	}
+
+	resource, err := h.storageService.RetrieveResource(context.Background(), id, "")
+	if err != nil && !domain.IsResourceNotFound(err) {
+		return true, h.handleStorageError(ctx, err)
+	}
+
+	version := ""
+	if err == nil {
+		version = h.resourceVersion(resource)
+	}
+	if preconditionsFail(ctx.Request(), err == nil, version) {
+		return true, h.writeErrorResponse(ctx, http.StatusPreconditionFailed, "PRECONDITION_FAILED",
+			"The resource does not match the request's If-Match or If-None-Match preconditions")
+	}
+	return false, nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// preconditionContainerService mocks the container lookups and deletes preconditions need;
+// other container service methods are not expected
+type preconditionContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *preconditionContainerService) GetContainer(ctx context.Context, id string) (domain.ContainerResource, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.ContainerResource), args.Error(1)
+}
+
+func (m *preconditionContainerService) DeleteContainer(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestIfMatchNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected bool
+	}{
+		{"wildcard", "*", true},
+		{"exact tag", `"abc123"`, true},
+		{"tag of a negotiated format", `"abc123-turtle"`, true},
+		{"one of several tags", `"other", "abc123"`, true},
+		{"weak tag", `W/"abc123"`, true},
+		{"different tag", `"abc124"`, false},
+		{"tag sharing a prefix", `"abc1234"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ifMatchNames(tt.header, "abc123"))
+		})
+	}
+}
+
+func TestContainerHandler_ConditionalDelete(t *testing.T) {
+	container := domain.NewContainer(context.Background(), "docs", "", domain.BasicContainer)
+	container.Members = []string{"report"}
+	etag := `"` + domain.ContainerETag(container) + `"`
+
+	t.Run("should refuse a stale If-Match with 412", func(t *testing.T) {
+		service := new(preconditionContainerService)
+		service.On("GetContainer", mock.Anything, "docs").Return(container, nil)
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+
+		ctx := createTestContext("DELETE", "/containers/docs", nil, map[string][]string{"id": {"docs"}})
+		ctx.Request().Header.Set("If-Match", `"stale"`)
+		require.NoError(t, handler.DeleteContainer(ctx))
+
+		assert.Equal(t, http.StatusPreconditionFailed, ctx.(*mockHTTPContext).response.Code)
+		service.AssertNotCalled(t, "DeleteContainer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should delete when If-Match names the current ETag", func(t *testing.T) {
+		service := new(preconditionContainerService)
+		service.On("GetContainer", mock.Anything, "docs").Return(container, nil)
+		service.On("DeleteContainer", mock.Anything, "docs").Return(nil)
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+
+		ctx := createTestContext("DELETE", "/containers/docs", nil, map[string][]string{"id": {"docs"}})
+		ctx.Request().Header.Set("If-Match", etag)
+		require.NoError(t, handler.DeleteContainer(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("should refuse If-Match on a missing container with 412", func(t *testing.T) {
+		service := new(preconditionContainerService)
+		service.On("GetContainer", mock.Anything, "docs").Return(nil, domain.ErrResourceNotFound.WithOperation("GetContainer"))
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+
+		ctx := createTestContext("DELETE", "/containers/docs", nil, map[string][]string{"id": {"docs"}})
+		ctx.Request().Header.Set("If-Match", etag)
+		require.NoError(t, handler.DeleteContainer(ctx))
+
+		assert.Equal(t, http.StatusPreconditionFailed, ctx.(*mockHTTPContext).response.Code)
+	})
+}
+
+func TestContainerHandler_ConditionalPut(t *testing.T) {
+	t.Run("should refuse If-None-Match: * when the container exists", func(t *testing.T) {
+		container := domain.NewContainer(context.Background(), "docs", "", domain.BasicContainer)
+		service := new(preconditionContainerService)
+		service.On("GetContainer", mock.Anything, "docs").Return(container, nil)
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+
+		ctx := createTestContext("PUT", "/containers/docs", []byte(`{"title":"Docs"}`), map[string][]string{"id": {"docs"}})
+		ctx.Request().Header.Set("If-None-Match", "*")
+		require.NoError(t, handler.PutContainer(ctx))
+
+		assert.Equal(t, http.StatusPreconditionFailed, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/go-kratos/kratos/v2/log"
@@ -21,6 +23,27 @@ import (
 type ContainerHandler struct {
 	containerService ContainerServiceInterface
 	storageService   StorageServiceInterface
+	readAuditor      *application.ReadAuditor
+	readAuthorizer   application.ContainerReadAuthorizer
+	nonContainerPost string
+	emptyPut         string
+	missingContainer string
+	createAuthorizer ContainerCreateAuthorizer
+	mediaTypePolicy  *MediaTypePolicy
+	etagPolicy       *ETagPolicy
+	cachePolicy      *CachePolicy
+	feedsEnabled     bool
+	csvExporter      ContainerMemberExporter
+	recursiveDeleter RecursiveContainerDeleter
+	movedContainers  MovedContainerResolver
+	namedResources   NamedResourceCreator
+	slugPolicy       *SlugPolicy
+	operationGate    *application.OperationGate
+	contentTransform ContainerContentTransformer
+	jsonLDForm       string
+	rdfStreamer      ContainerRDFStreamer
+	podAccounts      PodAccountResolver
+	accessAuthorizer AccessAuthorizer
 	logger           log.Logger
 }
 
@@ -33,10 +56,52 @@ func NewContainerHandler(containerService ContainerServiceInterface, storageServ
 	}
 }
 
+// SetReadAuditor sets the auditor used to record container reads
+func (h *ContainerHandler) SetReadAuditor(auditor *application.ReadAuditor) {
+	h.readAuditor = auditor
+}
+
+// recordContainerRead records a container read for auditing; failures must not affect the response
+func (h *ContainerHandler) recordContainerRead(ctx khttp.Context, id string, format string) {
+	entry := newReadAuditEntry(ctx.Request(), format)
+	if err := h.readAuditor.RecordContainerRead(ctx.Request().Context(), id, entry); err != nil {
+		h.logger.Log(log.LevelWarn, "msg", "Failed to record container read audit event", "containerID", id, "error", err)
+	}
+}
+
+// SetReadAuthorizer sets the authorizer used to hide containers the caller cannot read
+func (h *ContainerHandler) SetReadAuthorizer(authorizer application.ContainerReadAuthorizer) {
+	h.readAuthorizer = authorizer
+}
+
 // ContainerMetadataUpdate represents the structure for container metadata updates
 type ContainerMetadataUpdate struct {
 	Title       string `json:"title,omitempty"`
 	Description string `json:"description,omitempty"`
+	// NormalizeRDF opts the container in to (or out of) canonical RDF normalization on write
+	NormalizeRDF *bool `json:"normalizeRdf,omitempty"`
+	// ContentTransforms opts the container out of (or back in to) write-time content transformers
+	ContentTransforms *bool `json:"contentTransforms,omitempty"`
+	// AllowExternalMembers lets the container hold members from other pods
+	AllowExternalMembers *bool `json:"allowExternalMembers,omitempty"`
+	// DefaultSort sets the member order used when a listing requests none; an empty sort clears it
+	DefaultSort *domain.SortOptions `json:"defaultSort,omitempty"`
+	// RetentionPolicy expires members older than its maxAge; an empty maxAge clears it
+	RetentionPolicy *domain.RetentionPolicy `json:"retentionPolicy,omitempty"`
+	// CacheControl sets the Cache-Control directives of the container's listing; empty clears it
+	CacheControl *string `json:"cacheControl,omitempty"`
+	// InheritableMetadata sets the metadata resources created in the container inherit
+	InheritableMetadata map[string]interface{} `json:"inheritableMetadata,omitempty"`
+	// InheritOnMove controls whether resources moved into the container re-inherit its metadata
+	InheritOnMove *bool `json:"inheritOnMove,omitempty"`
+	// Contains is the requested member set; members are added or removed to match it
+	Contains []string `json:"ldp:contains,omitempty"`
+	// MembershipResource sets a Direct or Indirect container's ldp:membershipResource; empty clears it
+	MembershipResource *string `json:"ldp:membershipResource,omitempty"`
+	// HasMemberRelation sets the predicate of a Direct or Indirect container's membership triples
+	HasMemberRelation *string `json:"ldp:hasMemberRelation,omitempty"`
+	// InsertedContentRelation sets the predicate an IndirectContainer's members name their content with
+	InsertedContentRelation *string `json:"ldp:insertedContentRelation,omitempty"`
 }
 
 // GetContainer handles GET requests for container retrieval with member listing
@@ -54,14 +119,55 @@ func (h *ContainerHandler) GetContainer(ctx khttp.Context) error {
 
 	// Get Accept header for content negotiation
 	acceptHeader := ctx.Request().Header.Get("Accept")
+	if feedType := h.negotiateFeedType(acceptHeader); feedType != "" {
+		return h.writeContainerFeed(ctx, id, feedType)
+	}
+	if h.negotiateCSV(acceptHeader) {
+		return h.writeContainerCSV(ctx, id)
+	}
+	if !h.mediaTypes().Acceptable(acceptHeader) {
+		return h.mediaTypes().writeNotAcceptable(ctx)
+	}
 	acceptFormat := h.negotiateContentType(acceptHeader)
 
+	// Large containers can be listed in the background when the client prefers it
+	if async, wait := parseAsyncPreference(ctx.Request().Header.Values("Prefer")); async {
+		return h.startAsyncListing(ctx, id, wait)
+	}
+
 	// Retrieve container
 	container, err := h.containerService.GetContainer(context.Background(), id)
 	if err != nil {
 		return h.handleContainerError(ctx, err)
 	}
 
+	// Members can be described inline when the client prefers a rich listing
+	if prefersInclude(ctx.Request().Header.Values("Prefer"), preferMemberMetadata) {
+		return h.writeMemberMetadataListing(ctx, container, h.getResponseContentType(acceptFormat))
+	}
+
+	// JSON-LD is compacted with the server's context unless expanded form is asked for
+	expanded := h.wantsExpandedJSONLD(acceptHeader, acceptFormat)
+
+	// Containment and membership triples are left out when the client prefers a minimal container
+	if preference := containerPreference(ctx.Request().Header.Values("Prefer")); !preference.IsZero() {
+		return h.writePreferredContainer(ctx, container, jsonLDContentType(h.getResponseContentType(acceptFormat), expanded), preference)
+	}
+
+	if expanded {
+		return h.writeExpandedContainer(ctx, container)
+	}
+
+	// Turtle and N-Triples carry the whole container, streamed as its members are read
+	if h.streamsContainer(ctx.Request(), acceptFormat) {
+		return h.writeStreamedContainer(ctx, container, acceptFormat)
+	}
+
+	// Answer a conditional GET before the members are listed
+	if h.answerConditional(ctx, container, h.etags().Tag(h.generateContainerETag(container), h.getResponseContentType(acceptFormat))) {
+		return nil
+	}
+
 	// Get container members with pagination
 	pagination := h.parsePaginationOptions(ctx.Request())
 	listing, err := h.containerService.ListContainerMembers(context.Background(), id, pagination)
@@ -71,13 +177,66 @@ func (h *ContainerHandler) GetContainer(ctx khttp.Context) error {
 
 	// Build container response
 	response := h.buildContainerResponse(container, listing, acceptFormat)
+	h.addPageTriples(ctx, response, listing)
+
+	// Select language-tagged Dublin Core literals based on Accept-Language
+	localizeDublinCore(ctx.Response().Header(), response, container.GetMetadata(), ctx.Request().Header.Get("Accept-Language"))
 
 	// Set LDP-specific headers
 	h.setLDPHeaders(ctx, container)
+	h.setPaginationLinks(ctx, listing)
 	ctx.Response().Header().Set("Content-Type", h.getResponseContentType(acceptFormat))
-	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateContainerETag(container)))
 
-	return ctx.JSON(http.StatusOK, response)
+	h.recordContainerRead(ctx, id, acceptFormat)
+
+	// ctx.JSON would replace the negotiated Content-Type with application/json
+	ctx.Response().WriteHeader(http.StatusOK)
+	return json.NewEncoder(ctx.Response()).Encode(response)
+}
+
+// GetBreadcrumbs handles GET requests for a container's ancestor chain
+func (h *ContainerHandler) GetBreadcrumbs(ctx khttp.Context) error {
+	// Extract container ID from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+
+	if id == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID is required")
+	}
+
+	breadcrumbs, err := h.containerService.GenerateAuthorizedBreadcrumbs(agentContext(ctx.Request()), id, h.readAuthorizer)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	return ctx.JSON(http.StatusOK, breadcrumbs)
+}
+
+// TouchContainer handles POST requests marking a container modified without changing it
+func (h *ContainerHandler) TouchContainer(ctx khttp.Context) error {
+	// Extract container ID from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+
+	if id == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID is required")
+	}
+
+	container, err := h.containerService.TouchContainer(agentContext(ctx.Request()), id)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateContainerETag(container)))
+	ctx.Response().WriteHeader(http.StatusNoContent)
+	return nil
 }
 
 // PostResource handles POST requests for resource creation in containers
@@ -100,7 +259,10 @@ func (h *ContainerHandler) PostResource(ctx khttp.Context) error {
 	}
 
 	if !exists {
-		return h.writeErrorResponse(ctx, http.StatusNotFound, "CONTAINER_NOT_FOUND", "Container not found")
+		return h.handlePostToMissingContainer(ctx, containerID)
+	}
+	if handled, err := h.checkPostedResourcePrecondition(ctx); handled {
+		return err
 	}
 
 	// Get content type from request
@@ -108,6 +270,10 @@ func (h *ContainerHandler) PostResource(ctx khttp.Context) error {
 	if contentType == "" {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "MISSING_CONTENT_TYPE", "Content-Type header is required")
 	}
+	if h.mediaTypes().Refuses(contentType) {
+		return h.mediaTypes().writeUnsupportedMediaType(ctx, contentType)
+	}
+	contentType = h.mediaTypes().Canonical(contentType)
 
 	// Read request body
 	body, err := io.ReadAll(ctx.Request().Body)
@@ -119,14 +285,30 @@ func (h *ContainerHandler) PostResource(ctx khttp.Context) error {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "EMPTY_BODY", "Request body cannot be empty")
 	}
 
-	// Generate resource ID
-	resourceID := h.generateResourceID()
+	// Canonicalize RDF when the container has opted in to normalization
+	body, contentType, err = h.containerService.NormalizeContainerRDF(context.Background(), containerID, body, contentType)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	// Rewrite the content with the write-time transformers the container has not opted out of
+	body, err = h.transformContent(ctx, containerID, body, contentType)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	// Resources inherit the container's sticky metadata at creation time
+	inherited, err := h.containerService.InheritedMetadata(context.Background(), containerID)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
 
-	// Store the resource
-	resource, err := h.storageService.StoreResource(context.Background(), resourceID, body, contentType)
+	// Store the resource under its Slug or a generated ID
+	resource, err := h.createPostedResource(ctx, containerID, body, contentType, inherited)
 	if err != nil {
 		return h.handleStorageError(ctx, err)
 	}
+	resourceID := resource.ID()
 
 	// Add resource to container
 	err = h.containerService.AddResource(context.Background(), containerID, resourceID, resource)
@@ -139,6 +321,19 @@ func (h *ContainerHandler) PostResource(ctx khttp.Context) error {
 		return h.handleContainerError(ctx, err)
 	}
 
+	// Direct and Indirect containers assert a membership triple on their membership resource
+	membershipTriple, err := h.applyMembershipTriple(containerID, resource)
+	if err != nil {
+		if removeErr := h.containerService.RemoveResource(context.Background(), containerID, resourceID); removeErr != nil {
+			h.logger.Log(log.LevelWarn, "msg", "Failed to remove member after membership triple failure",
+				"resourceID", resourceID, "error", removeErr.Error())
+		} else if deleteErr := h.storageService.DeleteResource(context.Background(), resourceID); deleteErr != nil {
+			h.logger.Log(log.LevelWarn, "msg", "Failed to cleanup resource after membership triple failure",
+				"resourceID", resourceID, "error", deleteErr.Error())
+		}
+		return h.handleContainerError(ctx, err)
+	}
+
 	// Set response headers
 	ctx.Response().Header().Set("Content-Type", "application/json")
 	ctx.Response().Header().Set("Location", fmt.Sprintf("/resources/%s", resource.ID()))
@@ -152,10 +347,26 @@ func (h *ContainerHandler) PostResource(ctx khttp.Context) error {
 		"containerID": containerID,
 		"message":     "Resource created in container successfully",
 	}
+	if membershipTriple != nil {
+		response["membershipTriple"] = membershipTriple
+	}
 
 	return ctx.JSON(http.StatusCreated, response)
 }
 
+// applyMembershipTriple asserts the membership triple of a new member when the container is a
+// Direct or Indirect container; Basic containers assert none and return nil
+func (h *ContainerHandler) applyMembershipTriple(containerID string, resource domain.Resource) (*domain.MembershipTriple, error) {
+	container, err := h.containerService.GetContainer(context.Background(), containerID)
+	if err != nil {
+		return nil, err
+	}
+	if !container.GetContainerType().HasMembershipTriples() {
+		return nil, nil
+	}
+	return h.containerService.ApplyMembershipTriple(context.Background(), containerID, resource)
+}
+
 // PutContainer handles PUT requests for container metadata updates
 func (h *ContainerHandler) PutContainer(ctx khttp.Context) error {
 	// Extract container ID from path parameters
@@ -169,17 +380,33 @@ func (h *ContainerHandler) PutContainer(ctx khttp.Context) error {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID is required")
 	}
 
+	if handled, err := h.checkContainerPreconditions(ctx, id); handled {
+		return err
+	}
+
 	// Read request body
 	body, err := io.ReadAll(ctx.Request().Body)
 	if err != nil {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_BODY", "Failed to read request body")
 	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return h.handleEmptyContainerPut(ctx, id)
+	}
 
 	// Parse metadata update
 	var update ContainerMetadataUpdate
 	if err := json.Unmarshal(body, &update); err != nil {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON in request body")
 	}
+	if update.DefaultSort != nil && *update.DefaultSort != (domain.SortOptions{}) && !update.DefaultSort.IsValid() {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_SORT",
+			"defaultSort needs a field of name, createdAt, updatedAt, size or type and a direction of asc or desc")
+	}
+	if update.CacheControl != nil {
+		if err := domain.ValidateCacheControl(*update.CacheControl); err != nil {
+			return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_CACHE_CONTROL", err.Error())
+		}
+	}
 
 	// Retrieve existing container
 	container, err := h.containerService.GetContainer(context.Background(), id)
@@ -187,6 +414,58 @@ func (h *ContainerHandler) PutContainer(ctx khttp.Context) error {
 		return h.handleContainerError(ctx, err)
 	}
 
+	if update.AllowExternalMembers != nil {
+		if allowed, err := h.authorizeMembershipPolicy(ctx, id); !allowed {
+			return err
+		}
+	}
+
+	// Validate requested membership changes before anything is modified
+	var membershipDiff *application.MembershipDiff
+	var addedMembers []domain.Resource
+	if update.Contains != nil {
+		membershipDiff, err = h.containerService.DiffContainerMembers(context.Background(), id, update.Contains)
+		if err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+		for _, memberID := range membershipDiff.Added {
+			if allowed, err := h.authorizeMemberLink(ctx, memberID); !allowed {
+				return err
+			}
+			member, err := h.storageService.RetrieveResource(context.Background(), memberID, "")
+			if err != nil {
+				if domain.IsResourceNotFound(err) {
+					return h.writeErrorResponse(ctx, http.StatusConflict, "MEMBER_NOT_FOUND",
+						fmt.Sprintf("Cannot contain resource %s because it does not exist", memberID))
+				}
+				return h.handleStorageError(ctx, err)
+			}
+			addedMembers = append(addedMembers, member)
+		}
+	}
+
+	// Check the membership resource before changing anything else
+	if update.MembershipResource != nil {
+		if err := h.containerService.SetContainerMembershipResource(context.Background(), id, *update.MembershipResource); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if update.HasMemberRelation != nil || update.InsertedContentRelation != nil {
+		// A relation left out of the update keeps its current value
+		hasMemberRelation := domain.HasMemberRelation(container.GetMetadata())
+		if update.HasMemberRelation != nil {
+			hasMemberRelation = *update.HasMemberRelation
+		}
+		insertedContentRelation := domain.InsertedContentRelation(container.GetMetadata())
+		if update.InsertedContentRelation != nil {
+			insertedContentRelation = *update.InsertedContentRelation
+		}
+		if err := h.containerService.SetContainerMembershipRelations(context.Background(), id, hasMemberRelation, insertedContentRelation); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
 	// Update container metadata
 	if update.Title != "" {
 		container.SetTitle(update.Title)
@@ -194,6 +473,9 @@ func (h *ContainerHandler) PutContainer(ctx khttp.Context) error {
 	if update.Description != "" {
 		container.SetDescription(update.Description)
 	}
+	if update.CacheControl != nil {
+		container.SetCacheControl(*update.CacheControl)
+	}
 
 	// Save updated container
 	err = h.containerService.UpdateContainer(context.Background(), container)
@@ -201,6 +483,70 @@ func (h *ContainerHandler) PutContainer(ctx khttp.Context) error {
 		return h.handleContainerError(ctx, err)
 	}
 
+	if update.NormalizeRDF != nil {
+		if err := h.containerService.SetContainerRDFNormalization(context.Background(), id, *update.NormalizeRDF); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if update.AllowExternalMembers != nil {
+		if err := h.containerService.SetContainerExternalMembers(context.Background(), id, *update.AllowExternalMembers); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if update.DefaultSort != nil {
+		if err := h.containerService.SetContainerDefaultSort(context.Background(), id, *update.DefaultSort); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if update.RetentionPolicy != nil {
+		if err := h.containerService.SetContainerRetentionPolicy(context.Background(), id, *update.RetentionPolicy); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if update.ContentTransforms != nil && h.contentTransform != nil {
+		if err := h.contentTransform.SetContainerContentTransforms(context.Background(), id, *update.ContentTransforms); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if update.InheritableMetadata != nil || update.InheritOnMove != nil {
+		// Fields left out of the update keep their current values
+		metadata := container.GetMetadata()
+		inheritable := domain.InheritableMetadata(metadata)
+		if update.InheritableMetadata != nil {
+			inheritable = update.InheritableMetadata
+		}
+		inheritOnMove := domain.InheritOnMoveEnabled(metadata)
+		if update.InheritOnMove != nil {
+			inheritOnMove = *update.InheritOnMove
+		}
+		if err := h.containerService.SetContainerInheritableMetadata(context.Background(), id, inheritable, inheritOnMove); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+	}
+
+	if membershipDiff != nil {
+		for _, member := range addedMembers {
+			if err := h.containerService.AddResource(context.Background(), id, member.ID(), member); err != nil {
+				return h.handleContainerError(ctx, err)
+			}
+		}
+		for _, memberID := range membershipDiff.Removed {
+			if err := h.containerService.RemoveResource(context.Background(), id, memberID); err != nil {
+				return h.handleContainerError(ctx, err)
+			}
+		}
+	}
+
+	// Reload the container so the ETag reflects the metadata and members just written
+	if updated, err := h.containerService.GetContainer(context.Background(), id); err == nil {
+		container = updated
+	}
+
 	// Set response headers
 	ctx.Response().Header().Set("Content-Type", "application/json")
 	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateContainerETag(container)))
@@ -212,10 +558,93 @@ func (h *ContainerHandler) PutContainer(ctx khttp.Context) error {
 		"description": container.GetDescription(),
 		"message":     "Container updated successfully",
 	}
+	if membershipDiff != nil {
+		response["membership"] = membershipDiff
+	}
 
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// ContainerBatchRequest represents the body of a batch container-creation request
+type ContainerBatchRequest struct {
+	Mode       application.BatchMode             `json:"mode,omitempty"`
+	Containers []application.CreateContainerSpec `json:"containers"`
+}
+
+// PostContainerBatch handles POST requests that create several containers in one operation
+func (h *ContainerHandler) PostContainerBatch(ctx khttp.Context) error {
+	// Read request body
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_BODY", "Failed to read request body")
+	}
+
+	// Parse batch request
+	var req ContainerBatchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON in request body")
+	}
+
+	if len(req.Containers) == 0 {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "At least one container is required")
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = application.BatchModeAtomic
+	}
+	if mode != application.BatchModeAtomic && mode != application.BatchModeBestEffort {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Mode must be atomic or best_effort")
+	}
+
+	if allowed, err := h.authorizeBatchParents(ctx, req.Containers); !allowed {
+		return err
+	}
+
+	// The batch is limited under the pod its first container is created in
+	target := req.Containers[0].ParentID
+	if target == "" {
+		target = req.Containers[0].ID
+	}
+	release, err := h.acquireHeavyOperation(ctx, target, application.OperationBatchCreate)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+	defer release()
+
+	results, err := h.containerService.CreateContainersWithOptions(context.Background(), req.Containers, application.BatchCreateOptions{Mode: mode})
+	if err != nil && results == nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	created := 0
+	for _, result := range results {
+		if result.Created {
+			created++
+		}
+	}
+
+	response := map[string]interface{}{
+		"mode":    mode,
+		"created": created,
+		"failed":  len(results) - created,
+		"results": results,
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+
+	switch {
+	case err != nil:
+		// Atomic batch rejected; nothing was created
+		h.logger.Log(log.LevelWarn, "msg", "Batch container creation rejected", "error", err)
+		return ctx.JSON(http.StatusUnprocessableEntity, response)
+	case created == len(results):
+		return ctx.JSON(http.StatusCreated, response)
+	default:
+		return ctx.JSON(http.StatusMultiStatus, response)
+	}
+}
+
 // DeleteContainer handles DELETE requests for container deletion with empty validation
 func (h *ContainerHandler) DeleteContainer(ctx khttp.Context) error {
 	// Extract container ID from path parameters
@@ -229,6 +658,14 @@ func (h *ContainerHandler) DeleteContainer(ctx khttp.Context) error {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID is required")
 	}
 
+	if handled, err := h.checkContainerPreconditions(ctx, id); handled {
+		return err
+	}
+
+	if ctx.Request().URL.Query().Get("recursive") == "true" {
+		return h.deleteContainerRecursive(ctx, id)
+	}
+
 	// Delete the container (service will validate it's empty)
 	err := h.containerService.DeleteContainer(context.Background(), id)
 	if err != nil {
@@ -260,14 +697,22 @@ func (h *ContainerHandler) HeadContainer(ctx khttp.Context) error {
 
 	// Get Accept header for content negotiation
 	acceptHeader := ctx.Request().Header.Get("Accept")
+	if !h.mediaTypes().Acceptable(acceptHeader) {
+		ctx.Response().WriteHeader(http.StatusNotAcceptable)
+		return nil
+	}
 	acceptFormat := h.negotiateContentType(acceptHeader)
 
 	// Retrieve container
 	container, err := h.containerService.GetContainer(context.Background(), id)
 	if err != nil {
 		if domain.IsResourceNotFound(err) {
-			ctx.Response().WriteHeader(http.StatusNotFound)
-			return nil
+			if toID, moved := h.resolveMovedContainer(ctx, id); moved {
+				ctx.Response().Header().Set("Location", movedContainerLocation(ctx.Request().URL, id, toID))
+				ctx.Response().WriteHeader(http.StatusPermanentRedirect)
+				return nil
+			}
+			return h.answerMissingContainer(ctx, id)
 		}
 		ctx.Response().WriteHeader(http.StatusInternalServerError)
 		return nil
@@ -275,8 +720,10 @@ func (h *ContainerHandler) HeadContainer(ctx khttp.Context) error {
 
 	// Set response headers (same as GET but no body)
 	h.setLDPHeaders(ctx, container)
+	if h.answerConditional(ctx, container, h.etags().Tag(h.generateContainerETag(container), h.getResponseContentType(acceptFormat))) {
+		return nil
+	}
 	ctx.Response().Header().Set("Content-Type", h.getResponseContentType(acceptFormat))
-	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateContainerETag(container)))
 
 	ctx.Response().WriteHeader(http.StatusOK)
 	return nil
@@ -284,9 +731,17 @@ func (h *ContainerHandler) HeadContainer(ctx khttp.Context) error {
 
 // OptionsContainer handles OPTIONS requests for container endpoints
 func (h *ContainerHandler) OptionsContainer(ctx khttp.Context) error {
+	// A missing container is only reported when creation hints are configured
+	if vars := ctx.Vars(); len(vars["id"]) > 0 && h.missingContainer == conf.MissingContainerHints {
+		exists, err := h.containerService.ContainerExists(ctx.Request().Context(), vars["id"][0])
+		if err == nil && !exists {
+			return h.answerMissingContainer(ctx, vars["id"][0])
+		}
+	}
+
 	// Set CORS headers
 	ctx.Response().Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, HEAD, OPTIONS")
-	ctx.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept, Authorization")
+	ctx.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept, Authorization, If-Match, If-None-Match")
 	ctx.Response().Header().Set("Access-Control-Max-Age", "86400")
 
 	// Set LDP headers
@@ -295,7 +750,7 @@ func (h *ContainerHandler) OptionsContainer(ctx khttp.Context) error {
 	// Return allowed methods and formats
 	response := map[string]interface{}{
 		"methods": []string{"GET", "POST", "PUT", "DELETE", "HEAD", "OPTIONS"},
-		"formats": []string{"application/ld+json", "text/turtle", "application/rdf+xml"},
+		"formats": h.mediaTypes().Supported(),
 		"ldpType": "BasicContainer",
 	}
 
@@ -324,14 +779,14 @@ func (h *ContainerHandler) parsePaginationOptions(req *http.Request) domain.Pagi
 }
 
 // buildContainerResponse builds the container response based on format
-func (h *ContainerHandler) buildContainerResponse(container *domain.Container, listing *application.ContainerListing, format string) map[string]interface{} {
+func (h *ContainerHandler) buildContainerResponse(container domain.ContainerResource, listing *application.ContainerListing, format string) map[string]interface{} {
 	response := map[string]interface{}{
 		"@context": map[string]interface{}{
 			"ldp":     "http://www.w3.org/ns/ldp#",
 			"dcterms": "http://purl.org/dc/terms/",
 		},
 		"@id":          container.ID(),
-		"@type":        []string{"ldp:BasicContainer", "ldp:Container"},
+		"@type":        []string{"ldp:" + container.GetContainerType().String(), "ldp:Container"},
 		"ldp:contains": listing.Members,
 	}
 
@@ -355,6 +810,17 @@ func (h *ContainerHandler) buildContainerResponse(container *domain.Container, l
 				response["dcterms:modified"] = t.Format(time.RFC3339)
 			}
 		}
+		if inheritable := domain.InheritableMetadata(metadata); len(inheritable) > 0 {
+			response["inheritableMetadata"] = inheritable
+			response["inheritOnMove"] = domain.InheritOnMoveEnabled(metadata)
+		}
+		if container.GetContainerType().HasMembershipTriples() {
+			response["ldp:membershipResource"] = domain.MembershipResource(metadata)
+			response["ldp:hasMemberRelation"] = domain.HasMemberRelation(metadata)
+			if container.GetContainerType() == domain.IndirectContainer {
+				response["ldp:insertedContentRelation"] = domain.InsertedContentRelation(metadata)
+			}
+		}
 	}
 
 	// Add member count
@@ -372,32 +838,17 @@ func (h *ContainerHandler) buildContainerResponse(container *domain.Container, l
 }
 
 // setLDPHeaders sets LDP-specific response headers
-func (h *ContainerHandler) setLDPHeaders(ctx khttp.Context, container *domain.Container) {
-	ctx.Response().Header().Set("Link", `<http://www.w3.org/ns/ldp#BasicContainer>; rel="type"`)
-	ctx.Response().Header().Set("Accept-Post", "text/turtle, application/ld+json, application/rdf+xml")
+func (h *ContainerHandler) setLDPHeaders(ctx khttp.Context, container domain.ContainerResource) {
+	ctx.Response().Header().Set("Link", fmt.Sprintf(`<http://www.w3.org/ns/ldp#%s>; rel="type"`, container.GetContainerType()))
+	ctx.Response().Header().Set("Accept-Post", strings.Join(h.mediaTypes().Supported(), ", "))
 	ctx.Response().Header().Set("Allow", "GET, POST, PUT, DELETE, HEAD, OPTIONS")
 }
 
-// negotiateContentType performs content negotiation for containers
+// negotiateContentType performs content negotiation for containers, defaulting to JSON-LD
 func (h *ContainerHandler) negotiateContentType(acceptHeader string) string {
-	if acceptHeader == "" {
-		return "application/ld+json" // Default for containers
+	if format := h.mediaTypes().Negotiate(acceptHeader); format != "" {
+		return format
 	}
-
-	// Simple content negotiation - prefer JSON-LD for containers
-	if strings.Contains(acceptHeader, "application/ld+json") || strings.Contains(acceptHeader, "application/json") {
-		return "application/ld+json"
-	}
-	if strings.Contains(acceptHeader, "text/turtle") {
-		return "text/turtle"
-	}
-	if strings.Contains(acceptHeader, "application/rdf+xml") {
-		return "application/rdf+xml"
-	}
-	if strings.Contains(acceptHeader, "*/*") {
-		return "application/ld+json"
-	}
-
 	return "application/ld+json"
 }
 
@@ -413,21 +864,13 @@ func (h *ContainerHandler) getResponseContentType(format string) string {
 	}
 }
 
-// generateContainerETag generates an ETag for a container
-func (h *ContainerHandler) generateContainerETag(container *domain.Container) string {
-	// Generate ETag based on container ID and updated timestamp
-	metadata := container.GetMetadata()
-	if updatedAt, exists := metadata["updatedAt"]; exists {
-		if t, ok := updatedAt.(time.Time); ok {
-			return fmt.Sprintf("%s-%d", container.ID(), t.Unix())
-		}
-	}
-	// Fallback to just container ID if no timestamp
-	return container.ID()
+// generateContainerETag generates a strong ETag for a container from its members and metadata
+func (h *ContainerHandler) generateContainerETag(container domain.ContainerResource) string {
+	return domain.ContainerETag(container)
 }
 
 // generateResourceETag generates an ETag for a resource
-func (h *ContainerHandler) generateResourceETag(resource *domain.Resource) string {
+func (h *ContainerHandler) generateResourceETag(resource domain.Resource) string {
 	return fmt.Sprintf("%s-%d", resource.ID(), len(resource.GetData()))
 }
 
@@ -444,22 +887,74 @@ func (h *ContainerHandler) handleContainerError(ctx khttp.Context, err error) er
 	// Log error with context
 	h.logError(err, storageErr)
 
-	// Handle specific container error types
-	if domain.IsResourceNotFound(err) {
+	// Handle specific container error types; a missing container is only redirected when a
+	// move was recorded for it, and is otherwise a plain 404
+	if domain.IsResourceNotFound(err) || domain.IsContainerNotFound(err) {
+		if vars := ctx.Vars(); len(vars["id"]) > 0 {
+			if redirected, err := h.redirectMovedContainer(ctx, vars["id"][0]); redirected {
+				return err
+			}
+		}
 		return h.writeDetailedErrorResponse(ctx, http.StatusNotFound, "CONTAINER_NOT_FOUND",
 			"The requested container could not be found", storageErr)
 	}
 
+	if domain.IsAccessDenied(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusForbidden, "ACCESS_DENIED",
+			"You do not have permission to modify this container", storageErr)
+	}
+
 	if domain.IsContainerNotEmpty(err) {
 		return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "CONTAINER_NOT_EMPTY",
 			"Container cannot be deleted because it contains resources", storageErr)
 	}
 
+	if domain.IsInvalidMembershipResource(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "INVALID_MEMBERSHIP_RESOURCE",
+			"The membership resource does not exist in the pod and is not an allowed external reference", storageErr)
+	}
+
+	if domain.IsMissingMembershipRelation(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "MISSING_MEMBERSHIP_RELATION",
+			"Direct and Indirect containers need ldp:membershipResource and ldp:hasMemberRelation, and Indirect containers ldp:insertedContentRelation", storageErr)
+	}
+
+	if domain.IsMissingInsertedContent(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusUnprocessableEntity, "MISSING_INSERTED_CONTENT",
+			"The member does not state the container's ldp:insertedContentRelation", storageErr)
+	}
+
+	if domain.IsCrossPodMembership(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "CROSS_POD_MEMBERSHIP",
+			"The member belongs to a different pod than the container", storageErr)
+	}
+
+	if domain.IsInvalidContainerType(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "INVALID_CONTAINER_TYPE",
+			"The operation is not supported for this container type", storageErr)
+	}
+
 	if domain.IsInvalidHierarchy(err) {
 		return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "INVALID_HIERARCHY",
 			"Invalid container hierarchy or circular reference detected", storageErr)
 	}
 
+	if domain.IsOperationLimitReached(err) {
+		ctx.Response().Header().Set("Retry-After", operationLimitRetryAfter)
+		return h.writeDetailedErrorResponse(ctx, http.StatusTooManyRequests, "OPERATION_LIMIT_REACHED",
+			"The account is already running as many heavy operations as it may at once", storageErr)
+	}
+
+	if domain.IsContentTransformFailed(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusUnprocessableEntity, "CONTENT_TRANSFORM_FAILED",
+			"The content could not be transformed for storage", storageErr)
+	}
+
+	if storageErr != nil && storageErr.Code == domain.ErrMembershipConflict.Code {
+		return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "MEMBERSHIP_CONFLICT",
+			"The requested membership change conflicts with server-managed containment", storageErr)
+	}
+
 	if storageErr != nil && storageErr.Code == domain.ErrResourceAlreadyExists.Code {
 		return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "CONTAINER_EXISTS",
 			"A container with this ID already exists", storageErr)
@@ -509,6 +1004,16 @@ func (h *ContainerHandler) handleStorageError(ctx khttp.Context, err error) erro
 			"Insufficient storage space available", storageErr)
 	}
 
+	if domain.IsResourceAlreadyExists(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "RESOURCE_NAME_TAKEN",
+			"A resource with the requested name already exists", storageErr)
+	}
+
+	if domain.IsGraphTooLarge(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusRequestEntityTooLarge, "GRAPH_TOO_LARGE",
+			"The RDF document holds more triples than a resource may", storageErr)
+	}
+
 	// Handle other storage error types
 	if isStorageErr {
 		switch storageErr.Code {
@@ -533,7 +1038,6 @@ func (h *ContainerHandler) writeErrorResponse(ctx khttp.Context, status int, cod
 
 // writeDetailedErrorResponse writes a comprehensive error response
 func (h *ContainerHandler) writeDetailedErrorResponse(ctx khttp.Context, status int, code, message string, storageErr *domain.StorageError) error {
-	ctx.Response().Header().Set("Content-Type", "application/json")
 	ctx.Response().Header().Set("Cache-Control", "no-cache")
 
 	// Build error response
@@ -565,11 +1069,7 @@ func (h *ContainerHandler) writeDetailedErrorResponse(ctx khttp.Context, status
 		}
 	}
 
-	response := map[string]interface{}{
-		"error": errorResponse,
-	}
-
-	return ctx.JSON(status, response)
+	return writeNegotiatedError(ctx, status, errorResponse)
 }
 
 // logError logs errors with appropriate context
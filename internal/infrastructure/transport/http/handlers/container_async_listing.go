@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// maxAsyncListingWait caps how long a Prefer: wait request holds the connection
+const maxAsyncListingWait = 10 * time.Second
+
+// asyncListingRetryAfter is the polling interval suggested while a listing is running
+const asyncListingRetryAfter = "1"
+
+// parseAsyncPreference reports whether Prefer headers ask for respond-async and the wait
+// preference in effect, per RFC 7240
+func parseAsyncPreference(values []string) (bool, time.Duration) {
+	async := false
+	var wait time.Duration
+	for _, value := range values {
+		for _, preference := range strings.Split(value, ",") {
+			name, param, _ := strings.Cut(strings.TrimSpace(strings.Split(preference, ";")[0]), "=")
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "respond-async":
+				async = true
+			case "wait":
+				if seconds, err := strconv.Atoi(strings.Trim(strings.TrimSpace(param), `"`)); err == nil && seconds > 0 {
+					wait = time.Duration(seconds) * time.Second
+				}
+			}
+		}
+	}
+	if wait > maxAsyncListingWait {
+		wait = maxAsyncListingWait
+	}
+	return async, wait
+}
+
+// startAsyncListing answers a container read that prefers respond-async. The listing runs in
+// the background, holding one of the account's heavy operation slots until it finishes, and
+// the client polls the returned Location page by page; when a wait preference is given and
+// the listing finishes in time, the first page is returned directly. The read is audited once,
+// when the listing starts, rather than on every poll.
+func (h *ContainerHandler) startAsyncListing(ctx khttp.Context, id string, wait time.Duration) error {
+	release, err := h.acquireHeavyOperation(ctx, id, application.OperationListing)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	result, err := h.containerService.StartAsyncListing(ctx.Request().Context(), id, release)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+	location := asyncListingLocation(id, result.ID)
+
+	h.recordContainerRead(ctx, id, "application/json")
+
+	if wait > 0 {
+		pagination := h.parsePaginationOptions(ctx.Request())
+		result, err = h.containerService.WaitAsyncListing(ctx.Request().Context(), id, result.ID, wait, pagination)
+		if err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+		if result.Status != application.ListingStatusRunning {
+			ctx.Response().Header().Set("Content-Location", location)
+			ctx.Response().Header().Set("Content-Type", "application/json")
+			return ctx.JSON(http.StatusOK, result)
+		}
+	}
+
+	ctx.Response().Header().Set("Location", location)
+	ctx.Response().Header().Set("Preference-Applied", "respond-async")
+	ctx.Response().Header().Set("Retry-After", asyncListingRetryAfter)
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	return ctx.JSON(http.StatusAccepted, result)
+}
+
+// GetAsyncListing handles GET requests polling an async container listing. Members are paged
+// with the limit and offset query parameters. A running listing is answered 202 with a page of
+// the members read so far; a finished one 200.
+func (h *ContainerHandler) GetAsyncListing(ctx khttp.Context) error {
+	// Extract container and listing IDs from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+	listingID := ""
+	if len(vars["listing_id"]) > 0 {
+		listingID = vars["listing_id"][0]
+	}
+
+	if id == "" || listingID == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID and listing ID are required")
+	}
+
+	pagination := h.parsePaginationOptions(ctx.Request())
+	result, err := h.containerService.GetAsyncListing(ctx.Request().Context(), id, listingID, pagination)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	if result.Status == application.ListingStatusRunning {
+		ctx.Response().Header().Set("Retry-After", asyncListingRetryAfter)
+		return ctx.JSON(http.StatusAccepted, result)
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// asyncListingLocation returns the path an async listing result is polled at
+func asyncListingLocation(containerID, listingID string) string {
+	return fmt.Sprintf("/containers/%s/listings/%s", containerID, listingID)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// asyncListingContainerService mocks the async listing methods, keeping the release of the
+// last listing started; other container service methods are not expected
+type asyncListingContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+	release func()
+}
+
+func (m *asyncListingContainerService) StartAsyncListing(ctx context.Context, containerID string, release func()) (*application.ListingResult, error) {
+	m.release = release
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ListingResult), args.Error(1)
+}
+
+func (m *asyncListingContainerService) GetAsyncListing(ctx context.Context, containerID, listingID string, pagination domain.PaginationOptions) (*application.ListingResult, error) {
+	args := m.Called(ctx, containerID, listingID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ListingResult), args.Error(1)
+}
+
+func (m *asyncListingContainerService) WaitAsyncListing(ctx context.Context, containerID, listingID string, timeout time.Duration, pagination domain.PaginationOptions) (*application.ListingResult, error) {
+	args := m.Called(ctx, containerID, listingID, timeout, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ListingResult), args.Error(1)
+}
+
+func TestParseAsyncPreference(t *testing.T) {
+	async, wait := parseAsyncPreference([]string{"respond-async, wait=5"})
+	assert.True(t, async)
+	assert.Equal(t, 5*time.Second, wait)
+
+	async, wait = parseAsyncPreference([]string{"return=minimal", "Respond-Async; foo=bar", "wait=600"})
+	assert.True(t, async)
+	assert.Equal(t, maxAsyncListingWait, wait)
+
+	async, _ = parseAsyncPreference([]string{"return=representation"})
+	assert.False(t, async)
+}
+
+func TestContainerHandler_GetContainer_RespondAsync(t *testing.T) {
+	running := &application.ListingResult{ID: "job1", ContainerID: "big", Status: application.ListingStatusRunning, Members: []string{}}
+
+	t.Run("should accept and point at the result", func(t *testing.T) {
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("StartAsyncListing", mock.Anything, "big").Return(running, nil)
+
+		ctx := createTestContext("GET", "/containers/big", nil, map[string][]string{"id": {"big"}})
+		ctx.Request().Header.Set("Prefer", "respond-async")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.Equal(t, "/containers/big/listings/job1", response.Header().Get("Location"))
+		assert.Equal(t, "respond-async", response.Header().Get("Preference-Applied"))
+	})
+
+	t.Run("should audit the read once, when the listing starts", func(t *testing.T) {
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		auditor, reads := newRecordingReadAuditor()
+		handler.SetReadAuditor(auditor)
+		mockService.On("StartAsyncListing", mock.Anything, "big").Return(running, nil)
+		mockService.On("GetAsyncListing", mock.Anything, "big", "job1", domain.GetDefaultPagination()).Return(running, nil)
+
+		ctx := createTestContext("GET", "/containers/big", nil, map[string][]string{"id": {"big"}})
+		ctx.Request().Header.Set("Prefer", "respond-async")
+		require.NoError(t, handler.GetContainer(ctx))
+		assert.Equal(t, []string{"big"}, reads.of())
+
+		ctx = createTestContext("GET", "/containers/big/listings/job1", nil, map[string][]string{"id": {"big"}, "listing_id": {"job1"}})
+		require.NoError(t, handler.GetAsyncListing(ctx))
+		assert.Equal(t, []string{"big"}, reads.of(), "polling the listing is not audited again")
+	})
+
+	t.Run("should not audit a listing that could not start", func(t *testing.T) {
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		auditor, reads := newRecordingReadAuditor()
+		handler.SetReadAuditor(auditor)
+		mockService.On("StartAsyncListing", mock.Anything, "gone").Return(nil, domain.ErrResourceNotFound)
+
+		ctx := createTestContext("GET", "/containers/gone", nil, map[string][]string{"id": {"gone"}})
+		ctx.Request().Header.Set("Prefer", "respond-async")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+		assert.Empty(t, reads.of())
+	})
+
+	t.Run("should return the result when it finishes within the wait", func(t *testing.T) {
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("StartAsyncListing", mock.Anything, "big").Return(running, nil)
+		mockService.On("WaitAsyncListing", mock.Anything, "big", "job1", 2*time.Second, domain.PaginationOptions{Limit: 10, Offset: 0}).Return(&application.ListingResult{
+			ID: "job1", ContainerID: "big", Status: application.ListingStatusComplete, Members: []string{"a"}, MemberCount: 1,
+		}, nil)
+
+		ctx := createTestContext("GET", "/containers/big?limit=10", nil, map[string][]string{"id": {"big"}})
+		ctx.Request().Header.Set("Prefer", "respond-async, wait=2")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "/containers/big/listings/job1", response.Header().Get("Content-Location"))
+	})
+
+	t.Run("should hold a heavy operation slot until the listing ends", func(t *testing.T) {
+		gate := application.NewOperationGate(1, 0)
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetOperationGate(gate)
+		handler.SetPodAccountResolver(podAccounts{"acct-1/big": "acct-1"})
+		mockService.On("StartAsyncListing", mock.Anything, "acct-1/big").Return(running, nil).Once()
+
+		ctx := createTestContext("GET", "/containers/acct-1/big", nil, map[string][]string{"id": {"acct-1/big"}})
+		ctx.Request().Header.Set("Prefer", "respond-async")
+		require.NoError(t, handler.GetContainer(ctx))
+		assert.Equal(t, http.StatusAccepted, ctx.(*mockHTTPContext).response.Code)
+		assert.Equal(t, 1, gate.Running("acct-1"))
+
+		ctx = createTestContext("GET", "/containers/acct-1/big", nil, map[string][]string{"id": {"acct-1/big"}})
+		ctx.Request().Header.Set("Prefer", "respond-async")
+		require.NoError(t, handler.GetContainer(ctx))
+		assert.Equal(t, http.StatusTooManyRequests, ctx.(*mockHTTPContext).response.Code)
+
+		require.NotNil(t, mockService.release)
+		mockService.release()
+		assert.Equal(t, 0, gate.Running("acct-1"))
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestContainerHandler_GetAsyncListing(t *testing.T) {
+	t.Run("should report a running listing as accepted", func(t *testing.T) {
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("GetAsyncListing", mock.Anything, "big", "job1", domain.PaginationOptions{Limit: 20, Offset: 40}).Return(&application.ListingResult{
+			ID: "job1", ContainerID: "big", Status: application.ListingStatusRunning,
+		}, nil)
+
+		ctx := createTestContext("GET", "/containers/big/listings/job1?limit=20&offset=40", nil, map[string][]string{"id": {"big"}, "listing_id": {"job1"}})
+		require.NoError(t, handler.GetAsyncListing(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusAccepted, response.Code)
+		assert.NotEmpty(t, response.Header().Get("Retry-After"))
+	})
+
+	t.Run("should return 404 for expired listings", func(t *testing.T) {
+		mockService := new(asyncListingContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("GetAsyncListing", mock.Anything, "big", "old", domain.GetDefaultPagination()).Return(nil, domain.ErrResourceNotFound)
+
+		ctx := createTestContext("GET", "/containers/big/listings/old", nil, map[string][]string{"id": {"big"}, "listing_id": {"old"}})
+		require.NoError(t, handler.GetAsyncListing(ctx))
+
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// authorizeBatchParents checks the caller holds Append on the parent of every container a batch
+// creates, and on the root container for those without a parent, before any is created. A
+// parent created earlier in the same batch is covered by the check on its own parent. A refusal
+// is answered and reported as false.
+func (h *ContainerHandler) authorizeBatchParents(ctx khttp.Context, specs []application.CreateContainerSpec) (bool, error) {
+	if h.accessAuthorizer == nil {
+		return true, nil
+	}
+
+	inBatch := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		inBatch[spec.ID] = true
+	}
+
+	checked := make(map[string]bool)
+	for _, spec := range specs {
+		parentID := spec.ParentID
+		if parentID == "" {
+			parentID = rootContainerID
+		}
+		if inBatch[parentID] || checked[parentID] {
+			continue
+		}
+		checked[parentID] = true
+
+		decision, err := h.accessAuthorizer.Authorize(ctx.Request().Context(), requestAgent(ctx.Request()), parentID, domain.AccessMode{Append: true})
+		if err != nil {
+			h.logger.Log(log.LevelError, "msg", "Failed to authorize batch container creation", "parent", parentID, "error", err)
+			return false, h.writeErrorResponse(ctx, http.StatusInternalServerError, "AUTHORIZATION_FAILED", "failed to check access to the parent container")
+		}
+		if !decision.Allowed() {
+			if !decision.Authenticated() {
+				return false, h.writeErrorResponse(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "authentication is required to create containers")
+			}
+			return false, h.writeErrorResponse(ctx, http.StatusForbidden, "ACCESS_DENIED", "the agent is not allowed to create containers in "+parentID)
+		}
+	}
+	return true, nil
+}
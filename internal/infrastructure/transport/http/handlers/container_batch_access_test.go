@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerHandler_PostContainerBatch_ParentAccess(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	handler := NewContainerHandler(batchContainerService{}, nil, log.NewStdLogger(io.Discard))
+	handler.SetAccessAuthorizer(memberAccess{"alice/docs": {granted: domain.AccessMode{Append: true}, ownACL: true}})
+
+	post := func(body string) int {
+		ctx := createTestContext("POST", "/containers/batch", []byte(body), nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.PostContainerBatch(ctx))
+		return ctx.(*mockHTTPContext).response.Code
+	}
+
+	t.Run("should create under a parent the caller can append to without Append on the root", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, post(`{"containers":[{"id":"reports","parentId":"alice/docs"},{"id":"q1","parentId":"reports"}]}`))
+	})
+
+	t.Run("should refuse when any parent is not appendable", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, post(`{"containers":[{"id":"reports","parentId":"alice/docs"},{"id":"notes","parentId":"bob/docs"}]}`))
+	})
+
+	t.Run("should check the root for containers without a parent", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, post(`{"containers":[{"id":"top"}]}`))
+	})
+}
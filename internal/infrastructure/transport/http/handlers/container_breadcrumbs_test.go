@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// breadcrumbContainerService mocks GenerateAuthorizedBreadcrumbs; other container service
+// methods are not expected
+type breadcrumbContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *breadcrumbContainerService) GenerateAuthorizedBreadcrumbs(ctx context.Context, containerID string, authorizer application.ContainerReadAuthorizer) ([]application.BreadcrumbItem, error) {
+	args := m.Called(ctx, containerID, authorizer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]application.BreadcrumbItem), args.Error(1)
+}
+
+// agentReads grants Read to a single agent
+type agentReads string
+
+func (a agentReads) CanReadContainer(ctx context.Context, containerID string) bool {
+	return domain.AgentFromContext(ctx) == string(a)
+}
+
+func TestContainerHandler_GetBreadcrumbs(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+
+	t.Run("should authorize as the verified agent", func(t *testing.T) {
+		mockService := new(breadcrumbContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetReadAuthorizer(agentReads(alice))
+
+		asAlice := mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.AgentFromContext(ctx) == alice
+		})
+		mockService.On("GenerateAuthorizedBreadcrumbs", asAlice, "photos", agentReads(alice)).
+			Return([]application.BreadcrumbItem{{ID: "photos", Title: "Photos"}}, nil)
+
+		ctx := createTestContext("GET", "/containers/photos/breadcrumbs", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("X-User-ID", "https://mallory.example/profile#me")
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.GetBreadcrumbs(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should not take the agent from headers", func(t *testing.T) {
+		mockService := new(breadcrumbContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetReadAuthorizer(agentReads(alice))
+
+		unauthenticated := mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.AgentFromContext(ctx) == ""
+		})
+		mockService.On("GenerateAuthorizedBreadcrumbs", unauthenticated, "photos", agentReads(alice)).
+			Return(nil, domain.ErrContainerNotFound)
+
+		ctx := createTestContext("GET", "/containers/photos/breadcrumbs", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("X-User-ID", alice)
+		require.NoError(t, handler.GetBreadcrumbs(ctx))
+
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+	})
+}
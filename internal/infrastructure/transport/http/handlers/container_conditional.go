@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// containerModified returns when a container last changed. Its updatedAt moves forward on
+// every metadata change and every member added or removed, so a poller can tell from it
+// whether the listing has changed.
+func containerModified(container domain.ContainerResource) (time.Time, bool) {
+	updatedAt, ok := container.GetMetadata()["updatedAt"].(time.Time)
+	if !ok || updatedAt.IsZero() {
+		return time.Time{}, false
+	}
+	return updatedAt, true
+}
+
+// answerConditional sets the ETag, Last-Modified and Cache-Control of a container
+// representation and reports whether the request's If-None-Match or If-Modified-Since already covers it, in
+// which case 304 Not Modified has been written
+func (h *ContainerHandler) answerConditional(ctx khttp.Context, container domain.ContainerResource, etag string) bool {
+	ctx.Response().Header().Set("Cache-Control", h.caching().ForContainer(container.GetMetadata()))
+	modified, hasModified := containerModified(container)
+	if hasModified {
+		ctx.Response().Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	if h.etags().apply(ctx, etag) {
+		return true
+	}
+	if !hasModified || !notModifiedSince(ctx.Request(), modified) {
+		return false
+	}
+	ctx.Response().WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// notModifiedSince reports whether a GET or HEAD carries an If-Modified-Since no earlier than
+// modified. HTTP dates have whole seconds, so modified is compared at that precision. The
+// header is ignored when If-None-Match is present, as entity tags are the stronger validator.
+func notModifiedSince(r *http.Request, modified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
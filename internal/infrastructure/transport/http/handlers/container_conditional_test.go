@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotModifiedSince(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 12, 0, 0, 500_000_000, time.UTC)
+
+	tests := []struct {
+		name        string
+		method      string
+		since       string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"same second", http.MethodGet, "Sat, 01 Mar 2025 12:00:00 GMT", "", true},
+		{"later", http.MethodHead, "Sat, 01 Mar 2025 13:00:00 GMT", "", true},
+		{"earlier", http.MethodGet, "Sat, 01 Mar 2025 11:59:59 GMT", "", false},
+		{"malformed", http.MethodGet, "yesterday", "", false},
+		{"absent", http.MethodGet, "", "", false},
+		{"If-None-Match takes precedence", http.MethodGet, "Sat, 01 Mar 2025 13:00:00 GMT", `"other"`, false},
+		{"not a read", http.MethodPut, "Sat, 01 Mar 2025 13:00:00 GMT", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest(tt.method, "/containers/photos", nil)
+			if tt.since != "" {
+				r.Header.Set("If-Modified-Since", tt.since)
+			}
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			assert.Equal(t, tt.want, notModifiedSince(r, modified))
+		})
+	}
+}
+
+func TestContainerHandler_IfModifiedSince(t *testing.T) {
+	updatedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	container.SetMetadata("updatedAt", updatedAt)
+
+	t.Run("unchanged container answers 304", func(t *testing.T) {
+		mockService := new(MockContainerService)
+		mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/containers/photos", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("If-Modified-Since", updatedAt.Format(http.TimeFormat))
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotModified, response.Code)
+		assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 GMT", response.Header().Get("Last-Modified"))
+		assert.Empty(t, response.Body.String())
+		mockService.AssertNotCalled(t, "ListContainerMembers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("changed container is served", func(t *testing.T) {
+		mockService := new(MockContainerService)
+		mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+		ctx := createTestContext("HEAD", "/containers/photos", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("If-Modified-Since", updatedAt.Add(-time.Minute).Format(http.TimeFormat))
+		require.NoError(t, handler.HeadContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 GMT", response.Header().Get("Last-Modified"))
+	})
+}
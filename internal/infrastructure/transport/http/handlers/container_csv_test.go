@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticMemberExporter exports fixed members, recording the requested page
+type staticMemberExporter struct {
+	members    []domain.IndexedMember
+	err        error
+	pagination domain.PaginationOptions
+}
+
+func (e *staticMemberExporter) ExportContainerMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions, emit func(domain.IndexedMember) error) error {
+	e.pagination = pagination
+	if e.err != nil {
+		return e.err
+	}
+	for _, member := range e.members {
+		if err := emit(member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestContainerHandler_GetContainer_CSV(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	newRequest := func() *mockHTTPContext {
+		ctx := createTestContext("GET", "/containers/photos?limit=2&offset=4", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("Accept", "text/csv")
+		return ctx.(*mockHTTPContext)
+	}
+
+	t.Run("should stream members as CSV rows", func(t *testing.T) {
+		exporter := &staticMemberExporter{members: []domain.IndexedMember{
+			{ID: "beach.jpg", Type: "Resource", ContentType: "image/jpeg", Size: 2048, CreatedAt: created, UpdatedAt: created.Add(time.Hour)},
+			{ID: "=2024", Type: "Container", ContentType: "application/ld+json", CreatedAt: created, UpdatedAt: created},
+		}}
+		handler := NewContainerHandler(missingContainerService{}, nil, log.DefaultLogger)
+		handler.SetCSVExporter(exporter)
+
+		ctx := newRequest()
+		require.NoError(t, handler.GetContainer(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.response.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", ctx.response.Header().Get("Content-Type"))
+		assert.Equal(t, domain.PaginationOptions{Limit: 2, Offset: 4}, exporter.pagination)
+
+		rows, err := csv.NewReader(strings.NewReader(ctx.response.Body.String())).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			csvHeader,
+			{"beach.jpg", "Resource", "image/jpeg", "2048", "2024-05-01T12:00:00Z", "2024-05-01T13:00:00Z"},
+			{"'=2024", "Container", "application/ld+json", "", "2024-05-01T12:00:00Z", "2024-05-01T12:00:00Z"},
+		}, rows)
+	})
+
+	t.Run("should answer 404 for a missing container", func(t *testing.T) {
+		handler := NewContainerHandler(missingContainerService{}, nil, log.DefaultLogger)
+		handler.SetCSVExporter(&staticMemberExporter{err: domain.ErrResourceNotFound})
+
+		ctx := newRequest()
+		require.NoError(t, handler.GetContainer(ctx))
+		assert.Equal(t, http.StatusNotFound, ctx.response.Code)
+	})
+
+	t.Run("should send the header row for an empty container", func(t *testing.T) {
+		handler := NewContainerHandler(missingContainerService{}, nil, log.DefaultLogger)
+		handler.SetCSVExporter(&staticMemberExporter{})
+
+		ctx := newRequest()
+		require.NoError(t, handler.GetContainer(ctx))
+		assert.Equal(t, http.StatusOK, ctx.response.Code)
+		assert.Equal(t, strings.Join(csvHeader, ",")+"\n", ctx.response.Body.String())
+	})
+}
+
+func TestContainerHandler_NegotiateCSV(t *testing.T) {
+	handler := NewContainerHandler(nil, nil, log.DefaultLogger)
+	assert.False(t, handler.negotiateCSV("text/csv"), "CSV is off unless configured")
+
+	handler.SetCSVExporter(&staticMemberExporter{})
+	assert.True(t, handler.negotiateCSV("text/csv"))
+	assert.True(t, handler.negotiateCSV("text/csv, text/turtle;q=0.5"))
+	assert.False(t, handler.negotiateCSV("text/turtle, text/csv;q=0.5"))
+	assert.False(t, handler.negotiateCSV("*/*"))
+}
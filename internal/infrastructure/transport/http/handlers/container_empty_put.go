@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetEmptyPutBehavior sets how a PUT with an empty body to an existing container is answered
+func (h *ContainerHandler) SetEmptyPutBehavior(behavior string) {
+	h.emptyPut = behavior
+}
+
+// handleEmptyContainerPut answers a PUT without a body. An empty body does not say whether the
+// client meant to clear the container's metadata or change nothing, so the configured behavior
+// decides; by default the request is refused rather than guessed at.
+func (h *ContainerHandler) handleEmptyContainerPut(ctx khttp.Context, id string) error {
+	if h.emptyPut != conf.EmptyContainerPutLenient && h.emptyPut != conf.EmptyContainerPutReset {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "EMPTY_BODY", "Request body cannot be empty")
+	}
+
+	container, err := h.containerService.GetContainer(context.Background(), id)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	message := "Container unchanged"
+	if h.emptyPut == conf.EmptyContainerPutReset {
+		container.SetTitle("")
+		container.SetDescription("")
+		if err := h.containerService.UpdateContainer(context.Background(), container); err != nil {
+			return h.handleContainerError(ctx, err)
+		}
+		message = "Container metadata reset"
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateContainerETag(container)))
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"id":          container.ID(),
+		"title":       container.GetTitle(),
+		"description": container.GetDescription(),
+		"message":     message,
+	})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// emptyPutContainerService mocks the container reads and writes an empty-body PUT makes; other
+// container service methods are not expected
+type emptyPutContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *emptyPutContainerService) GetContainer(ctx context.Context, id string) (domain.ContainerResource, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.ContainerResource), args.Error(1)
+}
+
+func (m *emptyPutContainerService) UpdateContainer(ctx context.Context, container domain.ContainerResource) error {
+	args := m.Called(ctx, container)
+	return args.Error(0)
+}
+
+func newTitledContainer() *domain.Container {
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	container.SetTitle("Photos")
+	container.SetDescription("Holiday photos")
+	container.MarkEventsAsCommitted()
+	return container
+}
+
+func TestContainerHandler_PutContainer_EmptyBodyStrict(t *testing.T) {
+	containerService := &emptyPutContainerService{}
+	handler := NewContainerHandler(containerService, nil, log.DefaultLogger)
+
+	ctx := createTestContext("PUT", "/containers/photos", []byte("  \n"), map[string][]string{"id": {"photos"}})
+	assert.NoError(t, handler.PutContainer(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), `"code":"EMPTY_BODY"`)
+	containerService.AssertNotCalled(t, "UpdateContainer", mock.Anything, mock.Anything)
+}
+
+func TestContainerHandler_PutContainer_EmptyBodyLenient(t *testing.T) {
+	containerService := &emptyPutContainerService{}
+	handler := NewContainerHandler(containerService, nil, log.DefaultLogger)
+	handler.SetEmptyPutBehavior(conf.EmptyContainerPutLenient)
+
+	containerService.On("GetContainer", mock.Anything, "photos").Return(newTitledContainer(), nil)
+
+	ctx := createTestContext("PUT", "/containers/photos", nil, map[string][]string{"id": {"photos"}})
+	assert.NoError(t, handler.PutContainer(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `"title":"Photos"`)
+	containerService.AssertNotCalled(t, "UpdateContainer", mock.Anything, mock.Anything)
+}
+
+func TestContainerHandler_PutContainer_EmptyBodyReset(t *testing.T) {
+	containerService := &emptyPutContainerService{}
+	handler := NewContainerHandler(containerService, nil, log.DefaultLogger)
+	handler.SetEmptyPutBehavior(conf.EmptyContainerPutReset)
+
+	containerService.On("GetContainer", mock.Anything, "photos").Return(newTitledContainer(), nil)
+	containerService.On("UpdateContainer", mock.Anything, mock.MatchedBy(func(container domain.ContainerResource) bool {
+		return container.GetTitle() == "" && container.GetDescription() == ""
+	})).Return(nil)
+
+	ctx := createTestContext("PUT", "/containers/photos", nil, map[string][]string{"id": {"photos"}})
+	assert.NoError(t, handler.PutContainer(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `"message":"Container metadata reset"`)
+	containerService.AssertExpectations(t)
+}
+
+func TestContainerHandler_PutContainer_EmptyBodyMissingContainer(t *testing.T) {
+	containerService := &emptyPutContainerService{}
+	handler := NewContainerHandler(containerService, nil, log.DefaultLogger)
+	handler.SetEmptyPutBehavior(conf.EmptyContainerPutLenient)
+
+	containerService.On("GetContainer", mock.Anything, "missing").Return(nil, domain.ErrResourceNotFound)
+
+	ctx := createTestContext("PUT", "/containers/missing", nil, map[string][]string{"id": {"missing"}})
+	assert.NoError(t, handler.PutContainer(ctx))
+
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	eventStreamMediaType = "text/event-stream"
+
+	// eventStreamHeartbeat is how often an idle event stream is sent a comment, so proxies
+	// do not close it
+	eventStreamHeartbeat = 30 * time.Second
+)
+
+// turtleIRIEscaper percent-encodes the characters Turtle does not allow in an IRI reference
+var turtleIRIEscaper = strings.NewReplacer(
+	"<", "%3C", ">", "%3E", "\"", "%22", " ", "%20", "{", "%7B", "}", "%7D",
+	"|", "%7C", "^", "%5E", "`", "%60", "\\", "%5C",
+)
+
+// acceptsEventStream reports whether an Accept header asks for a Server-Sent Events stream
+func acceptsEventStream(acceptHeader string) bool {
+	for _, accepted := range parseAcceptTypes(acceptHeader) {
+		if accepted.quality > 0 && baseMediaType(accepted.mediaType) == eventStreamMediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// eventStreamFormat returns the notification format an Accept header prefers: Turtle when it
+// ranks text/turtle above JSON-LD, and JSON-LD otherwise
+func eventStreamFormat(acceptHeader string) string {
+	for _, accepted := range parseAcceptTypes(acceptHeader) {
+		if accepted.quality <= 0 {
+			continue
+		}
+		switch baseMediaType(accepted.mediaType) {
+		case "text/turtle":
+			return "text/turtle"
+		case "application/ld+json":
+			return "application/ld+json"
+		}
+	}
+	return "application/ld+json"
+}
+
+// streamEvents serves the changes of one container or resource, named by the topic query
+// parameter, as Server-Sent Events. Each event carries a Solid notification in the format the
+// Accept header prefers, with the hub's sequence number as its ID; a client reconnecting with
+// Last-Event-ID receives the kept changes it missed before the live ones.
+func (h *ContainerSubscriptionHandler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	baseURL := requestBaseURL(r)
+	topic := r.URL.Query().Get("topic")
+	topicID, ok := topicContainerID(baseURL, topic)
+	if !ok {
+		topicID, ok = topicResourceID(baseURL, topic)
+	}
+	if !ok {
+		writeEventStreamError(w, http.StatusBadRequest, "INVALID_TOPIC", "topic must be the URI of a container or resource on this server")
+		return
+	}
+
+	// Checked before subscribing, as a Last-Event-ID replays the topic's kept history
+	ctx := agentContext(r)
+	if h.readAuthorizer != nil && !h.readAuthorizer.CanReadContainer(ctx, topicID) {
+		if requestAgent(r) == "" {
+			writeEventStreamError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "authentication is required to follow this topic")
+			return
+		}
+		writeEventStreamError(w, http.StatusForbidden, "FORBIDDEN", "following a topic requires Read access to it")
+		return
+	}
+
+	var subscription *application.ContainerChangeSubscription
+	var err error
+	if lastEventID := strings.TrimSpace(r.Header.Get("Last-Event-ID")); lastEventID != "" {
+		lastSequence, parseErr := strconv.ParseUint(lastEventID, 10, 64)
+		if parseErr != nil {
+			writeEventStreamError(w, http.StatusBadRequest, "INVALID_LAST_EVENT_ID", "Last-Event-ID must be the ID of an event from this stream")
+			return
+		}
+		subscription, err = h.subscriber.SubscribeFrom(ctx, topicID, lastSequence)
+	} else {
+		subscription, err = h.subscriber.Subscribe(ctx, topicID)
+	}
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			writeEventStreamError(w, http.StatusNotFound, "TOPIC_NOT_FOUND", "the topic does not exist")
+			return
+		}
+		h.logger.Log(log.LevelError, "msg", "Event stream subscription failed", "topic", topicID, "error", err)
+		writeEventStreamError(w, http.StatusInternalServerError, "SUBSCRIPTION_FAILED", "failed to subscribe to the topic")
+		return
+	}
+	defer subscription.Close()
+
+	format := eventStreamFormat(r.Header.Get("Accept"))
+	controller := http.NewResponseController(w)
+	write := func(data string) bool {
+		if h.writeTimeout > 0 {
+			controller.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+		}
+		if _, err := io.WriteString(w, data); err != nil {
+			return false
+		}
+		return controller.Flush() == nil
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", eventStreamMediaType+"; charset=utf-8")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if controller.Flush() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case notification, open := <-subscription.Notifications:
+			if !open {
+				if subscription.Slow() {
+					h.logger.Log(log.LevelWarn, "msg", "Closing event stream that fell behind", "topic", topicID)
+				}
+				return
+			}
+			data, err := renderSolidNotification(notification.SolidNotification(topic), format)
+			if err != nil {
+				h.logger.Log(log.LevelError, "msg", "Failed to render notification", "topic", topicID, "error", err)
+				continue
+			}
+			if !write(serverSentEvent(notification.Sequence, data)) {
+				return
+			}
+		case <-heartbeat.C:
+			if !write(": keep-alive\n\n") {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEventStreamError answers an event stream request that could not be subscribed
+func writeEventStreamError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   code,
+		"message": message,
+	})
+}
+
+// serverSentEvent frames data as one event with the given ID, one data field per line
+func serverSentEvent(id uint64, data string) string {
+	var event strings.Builder
+	fmt.Fprintf(&event, "id: %d\n", id)
+	for _, line := range strings.Split(strings.TrimRight(data, "\n"), "\n") {
+		event.WriteString("data: " + line + "\n")
+	}
+	event.WriteString("\n")
+	return event.String()
+}
+
+// renderSolidNotification serializes a Solid notification as JSON-LD or Turtle
+func renderSolidNotification(notification map[string]interface{}, format string) (string, error) {
+	if format != "text/turtle" {
+		data, err := json.Marshal(notification)
+		return string(data), err
+	}
+
+	iri := func(key string) string {
+		value, _ := notification[key].(string)
+		return "<" + turtleIRIEscaper.Replace(value) + ">"
+	}
+
+	var turtle strings.Builder
+	turtle.WriteString("@prefix as: <https://www.w3.org/ns/activitystreams#> .\n")
+	turtle.WriteString("@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .\n")
+	fmt.Fprintf(&turtle, "%s a as:%s ;\n", iri("id"), notification["type"])
+	fmt.Fprintf(&turtle, "    as:object %s ;\n", iri("object"))
+	if _, ok := notification["target"]; ok {
+		fmt.Fprintf(&turtle, "    as:target %s ;\n", iri("target"))
+	}
+	fmt.Fprintf(&turtle, "    as:published \"%s\"^^xsd:dateTime .\n", notification["published"])
+	return turtle.String(), nil
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// knownResources answers resource existence from a fixed set
+type knownResources struct {
+	domain.ResourceRepository
+	ids map[string]bool
+}
+
+func (k *knownResources) Exists(ctx context.Context, id string) (bool, error) {
+	return k.ids[id], nil
+}
+
+// openEventStream starts an event stream server on the hub and requests the topic's stream
+func openEventStream(t *testing.T, hub *application.ContainerChangeHub, topicPath string, header http.Header) (*http.Response, string) {
+	handler := NewContainerSubscriptionHandler(hub, time.Second, log.DefaultLogger)
+	server := httptest.NewServer(http.HandlerFunc(handler.streamEvents))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/subscribe?topic="+server.URL+topicPath, nil)
+	require.NoError(t, err)
+	request.Header = header
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+	return response, server.URL
+}
+
+// readEvent reads the next event from the stream, returning its ID and joined data lines
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	var id string
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && (id != "" || len(data) > 0):
+			return id, strings.Join(data, "\n")
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+func TestContainerSubscriptionHandler_StreamsSolidNotifications(t *testing.T) {
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{"photos": true}}, 8)
+	response, baseURL := openEventStream(t, hub, "/containers/photos", http.Header{"Accept": {"text/event-stream"}})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream; charset=utf-8", response.Header.Get("Content-Type"))
+	require.Eventually(t, func() bool { return hub.SubscriberCount("photos") == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	added := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": "beach.jpg"})
+	require.NoError(t, hub.Handle(context.Background(), &committedEnvelope{event: added}))
+
+	id, data := readEvent(t, bufio.NewReader(response.Body))
+	assert.Equal(t, "1", id)
+
+	var notification map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &notification))
+	assert.Equal(t, "Add", notification["type"])
+	assert.Equal(t, baseURL+"/resources/beach.jpg", notification["object"])
+	assert.Equal(t, baseURL+"/containers/photos", notification["target"])
+	assert.NotEmpty(t, notification["published"])
+	assert.NotEmpty(t, notification["@context"])
+}
+
+func TestContainerSubscriptionHandler_ResumesEventStreamInTurtle(t *testing.T) {
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{}}, 8)
+	hub.SetResourceRepository(&knownResources{ids: map[string]bool{"card": true}})
+	hub.SetReplaySize(16)
+
+	for _, event := range []*domain.EntityEvent{
+		domain.NewResourceUpdatedEvent("card", map[string]interface{}{}),
+		domain.NewResourceUpdatedEvent("card", map[string]interface{}{}),
+	} {
+		require.NoError(t, hub.Handle(context.Background(), &committedEnvelope{event: event}))
+	}
+
+	response, baseURL := openEventStream(t, hub, "/resources/card", http.Header{
+		"Accept":        {"text/event-stream, text/turtle"},
+		"Last-Event-ID": {"1"},
+	})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	// Only the change after the last delivered one is replayed
+	id, data := readEvent(t, bufio.NewReader(response.Body))
+	assert.Equal(t, "2", id)
+	assert.Contains(t, data, "@prefix as: <https://www.w3.org/ns/activitystreams#> .")
+	assert.Contains(t, data, "a as:Update ;")
+	assert.Contains(t, data, "as:object <"+baseURL+"/resources/card> ;")
+	assert.Contains(t, data, "^^xsd:dateTime .")
+}
+
+func TestContainerSubscriptionHandler_RejectsEventStreamRequests(t *testing.T) {
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{"photos": true}}, 8)
+	tests := []struct {
+		name      string
+		topicPath string
+		header    http.Header
+		status    int
+	}{
+		{"topic elsewhere", "/profile", http.Header{}, http.StatusBadRequest},
+		{"unknown topic", "/containers/missing", http.Header{}, http.StatusNotFound},
+		{"malformed Last-Event-ID", "/containers/photos", http.Header{"Last-Event-ID": {"latest"}}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := openEventStream(t, hub, tt.topicPath, tt.header)
+			assert.Equal(t, tt.status, response.StatusCode)
+		})
+	}
+}
+
+func TestAcceptsEventStream(t *testing.T) {
+	assert.True(t, acceptsEventStream("text/event-stream"))
+	assert.True(t, acceptsEventStream("text/turtle, text/event-stream;q=0.5"))
+	assert.False(t, acceptsEventStream("text/event-stream;q=0"))
+	assert.False(t, acceptsEventStream(""))
+
+	assert.Equal(t, "application/ld+json", eventStreamFormat("text/event-stream"))
+	assert.Equal(t, "text/turtle", eventStreamFormat("text/event-stream, text/turtle"))
+	assert.Equal(t, "application/ld+json", eventStreamFormat("text/turtle;q=0.5, application/ld+json"))
+}
+
+func TestContainerSubscriptionHandler_EventStreamRequiresRead(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{"photos": true}}, 8)
+	hub.SetReplaySize(16)
+	handler := NewContainerSubscriptionHandler(hub, time.Second, log.DefaultLogger)
+	handler.SetReadAuthorizer(agentReads(alice))
+
+	tests := []struct {
+		name     string
+		identity *middleware.Identity
+		status   int
+	}{
+		{"anonymous", nil, http.StatusUnauthorized},
+		{"agent without Read", &middleware.Identity{Subject: "bob", WebID: "https://bob.example/profile#me"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://pod.example/subscribe?topic=http://pod.example/containers/photos", nil)
+			request.Header.Set("Accept", "text/event-stream")
+			request.Header.Set("Last-Event-ID", "0")
+			if tt.identity != nil {
+				request = request.WithContext(middleware.WithIdentity(request.Context(), *tt.identity))
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.streamEvents(recorder, request)
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, 0, hub.SubscriberCount("photos"))
+		})
+	}
+}
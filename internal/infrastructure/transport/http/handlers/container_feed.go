@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Syndication feed media types a container can be served as
+const (
+	atomMediaType = "application/atom+xml"
+	rssMediaType  = "application/rss+xml"
+)
+
+// atomFeed is an Atom 1.0 feed document
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+}
+
+// rssFeed is an RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	GUID    string `xml:"guid"`
+	PubDate string `xml:"pubDate"`
+}
+
+// SetFeedsEnabled sets whether containers are served as Atom or RSS feeds when requested
+func (h *ContainerHandler) SetFeedsEnabled(enabled bool) {
+	h.feedsEnabled = enabled
+}
+
+// negotiateFeedType returns the feed media type an Accept header prefers over every RDF
+// format, or an empty string when feeds are disabled or an RDF format ranks first
+func (h *ContainerHandler) negotiateFeedType(acceptHeader string) string {
+	if !h.feedsEnabled {
+		return ""
+	}
+
+	for _, accepted := range parseAcceptTypes(acceptHeader) {
+		if accepted.quality <= 0 {
+			continue
+		}
+		mediaType := baseMediaType(accepted.mediaType)
+		if mediaType == atomMediaType || mediaType == rssMediaType {
+			return mediaType
+		}
+		if strings.Contains(mediaType, "*") || h.mediaTypes().IsSupported(h.mediaTypes().Canonical(mediaType)) {
+			return ""
+		}
+	}
+	return ""
+}
+
+// writeContainerFeed answers a container read with a feed of its members, newest first
+func (h *ContainerHandler) writeContainerFeed(ctx khttp.Context, id, feedType string) error {
+	feed, err := h.containerService.GetContainerFeed(ctx.Request().Context(), id)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	baseURL := requestBaseURL(ctx.Request())
+	var document interface{}
+	if feedType == atomMediaType {
+		document = buildAtomFeed(feed, baseURL)
+	} else {
+		document = buildRSSFeed(feed, baseURL)
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return h.writeErrorResponse(ctx, http.StatusInternalServerError, "FEED_ERROR", "Failed to render container feed")
+	}
+
+	h.recordContainerRead(ctx, id, feedType)
+
+	ctx.Response().Header().Set("Content-Type", feedType+"; charset=utf-8")
+	ctx.Response().Header().Add("Vary", "Accept")
+	ctx.Response().WriteHeader(http.StatusOK)
+	_, err = ctx.Response().Write(append([]byte(xml.Header), body...))
+	return err
+}
+
+// buildAtomFeed renders a container feed as Atom
+func buildAtomFeed(feed *application.ContainerFeed, baseURL string) atomFeed {
+	link := memberURL(baseURL, feed.ID, domain.SearchTypeContainer)
+	document := atomFeed{
+		ID:      link,
+		Title:   feed.Title,
+		Updated: feed.UpdatedAt.UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: link, Rel: "self"},
+		Entries: make([]atomEntry, 0, len(feed.Entries)),
+	}
+	for _, entry := range feed.Entries {
+		entryLink := memberURL(baseURL, entry.ID, entry.Type)
+		document.Entries = append(document.Entries, atomEntry{
+			ID:        entryLink,
+			Title:     entry.Title,
+			Link:      atomLink{Href: entryLink},
+			Published: entry.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   entry.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return document
+}
+
+// buildRSSFeed renders a container feed as RSS 2.0
+func buildRSSFeed(feed *application.ContainerFeed, baseURL string) rssFeed {
+	document := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          memberURL(baseURL, feed.ID, domain.SearchTypeContainer),
+			Description:   fmt.Sprintf("Members of container %s", feed.ID),
+			LastBuildDate: feed.UpdatedAt.UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(feed.Entries)),
+		},
+	}
+	for _, entry := range feed.Entries {
+		entryLink := memberURL(baseURL, entry.ID, entry.Type)
+		document.Channel.Items = append(document.Channel.Items, rssItem{
+			Title:   entry.Title,
+			Link:    entryLink,
+			GUID:    entryLink,
+			PubDate: entry.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return document
+}
+
+// memberURL returns the absolute URL a container or resource is served at
+func memberURL(baseURL, id, memberType string) string {
+	if memberType == domain.SearchTypeContainer {
+		return baseURL + "/containers/" + url.PathEscape(id)
+	}
+	return baseURL + "/resources/" + url.PathEscape(id)
+}
+
+// requestBaseURL returns the scheme and host a request was addressed to
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
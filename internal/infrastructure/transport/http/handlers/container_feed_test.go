@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// feedContainerService mocks GetContainerFeed; other container service methods are not expected
+type feedContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *feedContainerService) GetContainerFeed(ctx context.Context, containerID string) (*application.ContainerFeed, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ContainerFeed), args.Error(1)
+}
+
+func testContainerFeed() *application.ContainerFeed {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &application.ContainerFeed{
+		ID:        "trips",
+		Title:     "Trips",
+		UpdatedAt: created.Add(time.Hour),
+		Entries: []application.FeedEntry{
+			{ID: "porto", Type: "Resource", Title: "Porto", CreatedAt: created.Add(time.Hour), UpdatedAt: created.Add(time.Hour)},
+			{ID: "2024", Type: "Container", Title: "2024", CreatedAt: created, UpdatedAt: created},
+		},
+	}
+}
+
+func TestContainerHandler_GetContainer_Feed(t *testing.T) {
+	t.Run("should serve an Atom feed", func(t *testing.T) {
+		mockService := new(feedContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetFeedsEnabled(true)
+		mockService.On("GetContainerFeed", mock.Anything, "trips").Return(testContainerFeed(), nil)
+
+		ctx := createTestContext("GET", "/containers/trips", nil, map[string][]string{"id": {"trips"}})
+		ctx.Request().Header.Set("Accept", "application/atom+xml")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", response.Header().Get("Content-Type"))
+
+		var feed atomFeed
+		require.NoError(t, xml.Unmarshal(response.Body.Bytes(), &feed))
+		assert.Equal(t, "Trips", feed.Title)
+		require.Len(t, feed.Entries, 2)
+		assert.Equal(t, "Porto", feed.Entries[0].Title)
+		assert.Equal(t, "http://example.com/resources/porto", feed.Entries[0].Link.Href)
+		assert.Equal(t, "2024-05-01T13:00:00Z", feed.Entries[0].Published)
+		assert.Equal(t, "http://example.com/containers/2024", feed.Entries[1].Link.Href)
+	})
+
+	t.Run("should serve an RSS feed", func(t *testing.T) {
+		mockService := new(feedContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetFeedsEnabled(true)
+		mockService.On("GetContainerFeed", mock.Anything, "trips").Return(testContainerFeed(), nil)
+
+		ctx := createTestContext("GET", "/containers/trips", nil, map[string][]string{"id": {"trips"}})
+		ctx.Request().Header.Set("Accept", "application/rss+xml, application/ld+json;q=0.5")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var feed rssFeed
+		require.NoError(t, xml.Unmarshal(response.Body.Bytes(), &feed))
+		require.Len(t, feed.Channel.Items, 2)
+		assert.Equal(t, "http://example.com/resources/porto", feed.Channel.Items[0].Link)
+	})
+
+	t.Run("should prefer RDF when it ranks first", func(t *testing.T) {
+		handler := NewContainerHandler(new(feedContainerService), nil, log.DefaultLogger)
+		handler.SetFeedsEnabled(true)
+
+		assert.Empty(t, handler.negotiateFeedType("text/turtle, application/atom+xml;q=0.5"))
+		assert.Empty(t, handler.negotiateFeedType("*/*"))
+		assert.Equal(t, atomMediaType, handler.negotiateFeedType("application/atom+xml, */*;q=0.1"))
+	})
+
+	t.Run("should ignore feed requests when disabled", func(t *testing.T) {
+		handler := NewContainerHandler(new(feedContainerService), nil, log.DefaultLogger)
+
+		assert.Empty(t, handler.negotiateFeedType("application/atom+xml"))
+	})
+}
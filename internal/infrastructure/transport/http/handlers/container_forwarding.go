@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetMovedContainerResolver sets the lookup used to redirect requests for containers that moved
+func (h *ContainerHandler) SetMovedContainerResolver(resolver MovedContainerResolver) {
+	h.movedContainers = resolver
+}
+
+// resolveMovedContainer returns the current ID of a container moved away from id
+func (h *ContainerHandler) resolveMovedContainer(ctx khttp.Context, id string) (string, bool) {
+	if h.movedContainers == nil || id == "" {
+		return "", false
+	}
+	return h.movedContainers.ResolveMovedContainer(ctx.Request().Context(), id)
+}
+
+// redirectMovedContainer answers 308 Permanent Redirect when the container id was moved or
+// renamed within the retention period, so clients repeat the request, method and body
+// included, at the new location. It reports false, writing nothing, otherwise.
+func (h *ContainerHandler) redirectMovedContainer(ctx khttp.Context, id string) (bool, error) {
+	toID, moved := h.resolveMovedContainer(ctx, id)
+	if !moved {
+		return false, nil
+	}
+
+	location := movedContainerLocation(ctx.Request().URL, id, toID)
+	ctx.Response().Header().Set("Location", location)
+	return true, h.writeErrorResponse(ctx, http.StatusPermanentRedirect, "CONTAINER_MOVED", "Container has moved to "+location)
+}
+
+// movedContainerLocation rewrites the container segment of a request URL to the new container
+// ID, keeping any sub-path such as /members and the query string
+func movedContainerLocation(requestURL *url.URL, fromID, toID string) string {
+	segments := strings.Split(requestURL.Path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "containers" && segments[i] == url.PathEscape(fromID) {
+			segments[i] = url.PathEscape(toID)
+			break
+		}
+	}
+
+	location := strings.Join(segments, "/")
+	if requestURL.RawQuery != "" {
+		location += "?" + requestURL.RawQuery
+	}
+	return location
+}
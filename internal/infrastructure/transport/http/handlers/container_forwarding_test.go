@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// movedContainers resolves container moves from a fixed table
+type movedContainers map[string]string
+
+func (m movedContainers) ResolveMovedContainer(ctx context.Context, containerID string) (string, bool) {
+	toID, ok := m[containerID]
+	return toID, ok
+}
+
+// missingContainerService reports every container as not found
+type missingContainerService struct {
+	ContainerServiceInterface
+}
+
+func (missingContainerService) TouchContainer(ctx context.Context, containerID string) (*domain.Container, error) {
+	return nil, domain.ErrResourceNotFound
+}
+
+func (missingContainerService) GetContainer(ctx context.Context, containerID string) (domain.ContainerResource, error) {
+	return nil, domain.ErrResourceNotFound
+}
+
+func TestContainerHandler_RedirectsMovedContainers(t *testing.T) {
+	newHandler := func() *ContainerHandler {
+		handler := NewContainerHandler(missingContainerService{}, nil, log.DefaultLogger)
+		handler.SetMovedContainerResolver(movedContainers{"photos": "pictures"})
+		return handler
+	}
+
+	t.Run("should answer 308 with the new location", func(t *testing.T) {
+		ctx := createTestContext("POST", "/containers/photos/touch?source=sync", nil, map[string][]string{"id": {"photos"}})
+		require.NoError(t, newHandler().TouchContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusPermanentRedirect, response.Code)
+		assert.Equal(t, "/containers/pictures/touch?source=sync", response.Header().Get("Location"))
+	})
+
+	t.Run("should redirect HEAD requests", func(t *testing.T) {
+		ctx := createTestContext("HEAD", "/containers/photos", nil, map[string][]string{"id": {"photos"}})
+		require.NoError(t, newHandler().HeadContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusPermanentRedirect, response.Code)
+		assert.Equal(t, "/containers/pictures", response.Header().Get("Location"))
+	})
+
+	t.Run("should keep 404 for containers that did not move", func(t *testing.T) {
+		ctx := createTestContext("POST", "/containers/videos/touch", nil, map[string][]string{"id": {"videos"}})
+		require.NoError(t, newHandler().TouchContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Empty(t, response.Header().Get("Location"))
+	})
+
+	t.Run("should keep 404 without a move resolver", func(t *testing.T) {
+		ctx := createTestContext("POST", "/containers/photos/touch", nil, map[string][]string{"id": {"photos"}})
+		require.NoError(t, NewContainerHandler(missingContainerService{}, nil, log.DefaultLogger).TouchContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Empty(t, response.Header().Get("Location"))
+	})
+}
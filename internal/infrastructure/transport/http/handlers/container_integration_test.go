@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
@@ -29,7 +30,7 @@ func TestContainerHandlerIntegration(t *testing.T) {
 	converter := infrastructure.NewRDFConverter()
 
 	// Create database and event infrastructure
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	require.NoError(t, err)
 
 	eventStore, err := infrastructure.EventStoreProvider(db)
@@ -38,11 +39,6 @@ func TestContainerHandlerIntegration(t *testing.T) {
 	eventDispatcher, err := infrastructure.NewEventDispatcher()
 	require.NoError(t, err)
 
-	// Create unit of work factory using existing infrastructure
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return pericarpinfra.UnitOfWorkProvider(eventStore, eventDispatcher)
-	}
-
 	// Create membership indexer
 	membershipIndexer, err := infrastructure.NewSQLiteMembershipIndexer(tempDir + "/membership.db")
 	require.NoError(t, err)
@@ -52,9 +48,19 @@ func TestContainerHandlerIntegration(t *testing.T) {
 	containerRepo, err := infrastructure.NewFileSystemContainerRepository(tempDir, membershipIndexer)
 	require.NoError(t, err)
 
+	// Create unit of work factory using existing infrastructure, applying container events as they
+	// are committed so each step reads what the previous one wrote
+	containerEventHandler := application.NewContainerEventHandler(containerRepo)
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return &projectingUnitOfWork{
+			UnitOfWork: pericarpinfra.UnitOfWorkProvider(eventStore, eventDispatcher),
+			handler:    containerEventHandler,
+		}
+	}
+
 	// Create services
 	storageService := application.NewStorageService(repo, converter, unitOfWorkFactory)
-	containerService := application.NewContainerService(containerRepo, unitOfWorkFactory)
+	containerService := application.NewContainerService(containerRepo, unitOfWorkFactory, infrastructure.NewContainerRDFConverter())
 	logger := log.NewStdLogger(io.Discard)
 
 	// Create container handler
@@ -125,11 +131,11 @@ func TestContainerHandlerIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create a resource
-		_, err = storageService.StoreResource(ctx, "test-resource", []byte(`{"test": "data"}`), "application/json")
+		resource, err := storageService.StoreResource(ctx, "test-resource", []byte(`{"test": "data"}`), "application/json")
 		require.NoError(t, err)
 
 		// Add resource to container
-		err = containerService.AddResource(ctx, "container-with-resources", "test-resource")
+		err = containerService.AddResource(ctx, "container-with-resources", "test-resource", resource)
 		require.NoError(t, err)
 
 		// List container members
@@ -200,3 +206,23 @@ func TestContainerHandlerIntegration(t *testing.T) {
 		}
 	})
 }
+
+// projectingUnitOfWork hands committed events to a handler before returning, instead of leaving
+// them to the asynchronous dispatcher
+type projectingUnitOfWork struct {
+	pericarpdomain.UnitOfWork
+	handler pericarpdomain.EventHandler
+}
+
+func (u *projectingUnitOfWork) Commit(ctx context.Context) ([]pericarpdomain.Envelope, error) {
+	envelopes, err := u.UnitOfWork.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, envelope := range envelopes {
+		if err := u.handler.Handle(ctx, envelope); err != nil {
+			return nil, err
+		}
+	}
+	return envelopes, nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// jsonLDCompactedProfile is the profile a client names to insist on compacted JSON-LD
+const jsonLDCompactedProfile = "http://www.w3.org/ns/json-ld#compacted"
+
+// SetJSONLDForm sets the JSON-LD form, "compacted" or "expanded", served to clients whose
+// Accept header names no profile
+func (h *ContainerHandler) SetJSONLDForm(form string) {
+	h.jsonLDForm = form
+}
+
+// wantsExpandedJSONLD reports whether a container read negotiated to JSON-LD should be served
+// expanded: the Accept header asks for the expanded profile, or names no JSON-LD profile and
+// the server's default form is expanded
+func (h *ContainerHandler) wantsExpandedJSONLD(acceptHeader, format string) bool {
+	if format != "application/ld+json" {
+		return false
+	}
+	for _, part := range strings.Split(acceptHeader, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != "application/ld+json" {
+			continue
+		}
+		// The profile parameter may list several profiles separated by spaces
+		for _, profile := range strings.Fields(params["profile"]) {
+			switch profile {
+			case infrastructure.JSONLDExpandedProfile:
+				return true
+			case jsonLDCompactedProfile:
+				return false
+			}
+		}
+	}
+	return h.jsonLDForm == conf.JSONLDFormExpanded
+}
+
+// jsonLDContentType returns the Content-Type of a container representation, naming the
+// expanded profile when the JSON-LD is expanded
+func jsonLDContentType(format string, expanded bool) string {
+	if expanded {
+		return infrastructure.JSONLDExpandedFormat
+	}
+	return format
+}
+
+// writeExpandedContainer answers a container read with expanded JSON-LD: full IRIs, no
+// context, and every value in an array
+func (h *ContainerHandler) writeExpandedContainer(ctx khttp.Context, container domain.ContainerResource) error {
+	header := ctx.Response().Header()
+
+	// The expanded form differs from the compacted one, so it carries its own entity tag
+	if h.answerConditional(ctx, container, h.etags().Tag(h.generateContainerETag(container)+"-expanded", infrastructure.JSONLDExpandedFormat)) {
+		return nil
+	}
+
+	data, err := h.containerService.GetContainerWithPreference(ctx.Request().Context(), container.ID(), infrastructure.JSONLDExpandedFormat, requestBaseURL(ctx.Request())+"/", domain.ContainerPreference{})
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	h.recordContainerRead(ctx, container.ID(), infrastructure.JSONLDExpandedFormat)
+
+	h.setLDPHeaders(ctx, container)
+	header.Set("Content-Type", infrastructure.JSONLDExpandedFormat)
+	header.Set("Content-Length", strconv.Itoa(len(data)))
+	ctx.Response().WriteHeader(http.StatusOK)
+	_, err = ctx.Response().Write(data)
+	return err
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerHandler_WantsExpandedJSONLD(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		format string
+		form   string
+		want   bool
+	}{
+		{"no profile", "application/ld+json", "application/ld+json", conf.JSONLDFormCompacted, false},
+		{"expanded profile", `application/ld+json; profile="http://www.w3.org/ns/json-ld#expanded"`, "application/ld+json", conf.JSONLDFormCompacted, true},
+		{"among other profiles", `application/ld+json;profile="http://example.com/p http://www.w3.org/ns/json-ld#expanded"`, "application/ld+json", "", true},
+		{"expanded by default", "application/ld+json", "application/ld+json", conf.JSONLDFormExpanded, true},
+		{"compacted profile overrides the default", `application/ld+json; profile="http://www.w3.org/ns/json-ld#compacted"`, "application/ld+json", conf.JSONLDFormExpanded, false},
+		{"other formats", `text/turtle, application/ld+json; profile="http://www.w3.org/ns/json-ld#expanded"; q=0.5`, "text/turtle", conf.JSONLDFormExpanded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewContainerHandler(new(MockContainerService), nil, log.DefaultLogger)
+			handler.SetJSONLDForm(tt.form)
+			assert.Equal(t, tt.want, handler.wantsExpandedJSONLD(tt.accept, tt.format))
+		})
+	}
+}
+
+func TestContainerHandler_GetContainer_ExpandedJSONLD(t *testing.T) {
+	mockService := new(MockContainerService)
+	handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	expanded := []byte(`[{"@id": "http://example.com/photos"}]`)
+	mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+	mockService.On("GetContainerWithPreference", mock.Anything, "photos", infrastructure.JSONLDExpandedFormat, "http://example.com/", domain.ContainerPreference{}).Return(expanded, nil)
+
+	ctx := createTestContext("GET", "http://example.com/containers/photos", nil, map[string][]string{"id": {"photos"}})
+	ctx.Request().Header.Set("Accept", `application/ld+json; profile="http://www.w3.org/ns/json-ld#expanded"`)
+	require.NoError(t, handler.GetContainer(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, expanded, response.Body.Bytes())
+	assert.Equal(t, infrastructure.JSONLDExpandedFormat, response.Header().Get("Content-Type"))
+	assert.Equal(t, strconv.Itoa(len(expanded)), response.Header().Get("Content-Length"))
+	assert.Contains(t, response.Header().Values("Vary"), "Accept")
+	mockService.AssertNotCalled(t, "ListContainerMembers", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetAccessAuthorizer sets the authorizer checking the caller's access to the resources a
+// container update links in, to the container's membership policy and to the parents of a batch
+// of new containers; without one, as when Web Access Control is disabled, only the container
+// route's own checks apply
+func (h *ContainerHandler) SetAccessAuthorizer(authorizer AccessAuthorizer) {
+	h.accessAuthorizer = authorizer
+}
+
+// authorizeMemberLink checks the caller may link an existing resource into a container. Member
+// listings show a member's metadata, so the caller needs Read on it. A member without an ACL of
+// its own inherits one from a container holding it and can come to inherit this container's, so
+// linking it also needs Control. A refusal is answered and reported as false.
+func (h *ContainerHandler) authorizeMemberLink(ctx khttp.Context, memberID string) (bool, error) {
+	if h.accessAuthorizer == nil {
+		return true, nil
+	}
+
+	decision, err := h.accessAuthorizer.Authorize(ctx.Request().Context(), requestAgent(ctx.Request()), memberID, domain.AccessMode{Read: true})
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to authorize member link", "member", memberID, "error", err)
+		return false, h.writeErrorResponse(ctx, http.StatusInternalServerError, "AUTHORIZATION_FAILED", "failed to check access to the member")
+	}
+
+	required := domain.AccessMode{Read: true}
+	if decision.ACL == nil || decision.ACL.Inherited {
+		required.Control = true
+	}
+	if !decision.Granted.Covers(required) {
+		if !decision.Authenticated() {
+			return false, h.writeErrorResponse(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "authentication is required to link resources into a container")
+		}
+		return false, h.writeErrorResponse(ctx, http.StatusForbidden, "ACCESS_DENIED", "the agent is not allowed to link "+memberID+" into the container")
+	}
+	return true, nil
+}
+
+// authorizeMembershipPolicy checks the caller holds Control on a container before its membership
+// policy, such as whether it accepts members from other pods, is changed; Write on the container
+// alone is not enough. A refusal is answered and reported as false.
+func (h *ContainerHandler) authorizeMembershipPolicy(ctx khttp.Context, containerID string) (bool, error) {
+	if h.accessAuthorizer == nil {
+		return true, nil
+	}
+
+	decision, err := h.accessAuthorizer.Authorize(ctx.Request().Context(), requestAgent(ctx.Request()), containerID, domain.AccessMode{Control: true})
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to authorize membership policy change", "container", containerID, "error", err)
+		return false, h.writeErrorResponse(ctx, http.StatusInternalServerError, "AUTHORIZATION_FAILED", "failed to check access to the container")
+	}
+	if !decision.Allowed() {
+		if !decision.Authenticated() {
+			return false, h.writeErrorResponse(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "authentication is required to change the container's membership policy")
+		}
+		return false, h.writeErrorResponse(ctx, http.StatusForbidden, "ACCESS_DENIED", "changing the container's membership policy needs Control")
+	}
+	return true, nil
+}
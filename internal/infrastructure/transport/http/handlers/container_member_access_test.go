@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// resourceAccess is the access a caller holds on a resource, and whether the resource has an
+// ACL of its own or inherits one
+type resourceAccess struct {
+	granted domain.AccessMode
+	ownACL  bool
+}
+
+// memberAccess grants every caller the listed access per resource and nothing elsewhere
+type memberAccess map[string]resourceAccess
+
+func (m memberAccess) Authorize(ctx context.Context, userID, resourceID string, required domain.AccessMode) (*application.AccessDecision, error) {
+	access := m[resourceID]
+	return &application.AccessDecision{
+		Agent:    userID,
+		Required: required,
+		Granted:  access.granted,
+		ACL:      &domain.EffectiveACL{ResourceID: resourceID, Inherited: !access.ownACL},
+	}, nil
+}
+
+func TestContainerHandler_PutContainer_MemberAccess(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+
+	newRequest := func(body string) *mockHTTPContext {
+		ctx := createTestContext("PUT", "/containers/inbox", []byte(body), map[string][]string{"id": {"inbox"}}).(*mockHTTPContext)
+		ctx.request = ctx.request.WithContext(middleware.WithIdentity(ctx.request.Context(), middleware.Identity{Subject: "alice", WebID: alice}))
+		return ctx
+	}
+	newHandler := func(access memberAccess) (*ContainerHandler, *MockContainerService, *MockContainerStorageService) {
+		containers := new(MockContainerService)
+		storage := new(MockContainerStorageService)
+		containers.On("GetContainer", mock.Anything, "inbox").Return(domain.NewContainer(context.Background(), "inbox", "", domain.BasicContainer), nil)
+		handler := NewContainerHandler(containers, storage, log.DefaultLogger)
+		handler.SetAccessAuthorizer(access)
+		return handler, containers, storage
+	}
+
+	t.Run("should link a member the caller can read that has its own ACL", func(t *testing.T) {
+		handler, containers, storage := newHandler(memberAccess{"note": {granted: domain.AccessMode{Read: true}, ownACL: true}})
+		note := domain.NewResource(context.Background(), "note", "text/plain", []byte("hello"))
+		containers.On("DiffContainerMembers", mock.Anything, "inbox", []string{"note"}).Return(&application.MembershipDiff{Added: []string{"note"}}, nil)
+		storage.On("RetrieveResource", mock.Anything, "note", "").Return(note, nil)
+		containers.On("UpdateContainer", mock.Anything, mock.Anything).Return(nil)
+		containers.On("AddResource", mock.Anything, "inbox", "note", note).Return(nil)
+
+		ctx := newRequest(`{"ldp:contains":["note"]}`)
+		require.NoError(t, handler.PutContainer(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.response.Code)
+		containers.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a member the caller cannot read", func(t *testing.T) {
+		handler, containers, _ := newHandler(memberAccess{"diary": {ownACL: true}})
+		containers.On("DiffContainerMembers", mock.Anything, "inbox", []string{"diary"}).Return(&application.MembershipDiff{Added: []string{"diary"}}, nil)
+
+		ctx := newRequest(`{"ldp:contains":["diary"]}`)
+		require.NoError(t, handler.PutContainer(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.response.Code)
+		containers.AssertNotCalled(t, "AddResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should need Control on a member that inherits its ACL", func(t *testing.T) {
+		handler, containers, _ := newHandler(memberAccess{"photo": {granted: domain.AccessMode{Read: true}}})
+		containers.On("DiffContainerMembers", mock.Anything, "inbox", []string{"photo"}).Return(&application.MembershipDiff{Added: []string{"photo"}}, nil)
+
+		ctx := newRequest(`{"ldp:contains":["photo"]}`)
+		require.NoError(t, handler.PutContainer(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.response.Code, "linking would let the container's ACL govern the member")
+		containers.AssertNotCalled(t, "AddResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should need Control on the container to allow external members", func(t *testing.T) {
+		handler, containers, _ := newHandler(memberAccess{"inbox": {granted: domain.AccessMode{Read: true, Write: true}, ownACL: true}})
+
+		ctx := newRequest(`{"allowExternalMembers":true}`)
+		require.NoError(t, handler.PutContainer(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.response.Code)
+		containers.AssertNotCalled(t, "SetContainerExternalMembers", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
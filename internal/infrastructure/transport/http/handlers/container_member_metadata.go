@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// preferMemberMetadata is the Prefer include IRI asking a container listing to describe its
+// members inline
+const preferMemberMetadata = "urn:goro:PreferMemberMetadata"
+
+// prefersInclude reports whether Prefer headers ask for a representation including iri, as in
+// Prefer: return=representation; include="<iri> ..." per the LDP preferences
+func prefersInclude(values []string, iri string) bool {
+	for _, candidate := range representationPreferences(values, "include") {
+		if candidate == iri {
+			return true
+		}
+	}
+	return false
+}
+
+// representationPreferences returns the IRIs Prefer headers list in a return=representation
+// preference's include or omit parameter
+func representationPreferences(values []string, parameter string) []string {
+	var iris []string
+	for _, value := range values {
+		for _, preference := range strings.Split(value, ",") {
+			params := strings.Split(preference, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), "return=representation") {
+				continue
+			}
+			for _, param := range params[1:] {
+				name, listed, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(strings.TrimSpace(name), parameter) {
+					iris = append(iris, strings.Fields(strings.Trim(strings.TrimSpace(listed), `"`))...)
+				}
+			}
+		}
+	}
+	return iris
+}
+
+// writeMemberMetadataListing answers a container read that prefers member metadata with the
+// container's RDF listing, describing the requested page of members from the index
+func (h *ContainerHandler) writeMemberMetadataListing(ctx khttp.Context, container domain.ContainerResource, format string) error {
+	pagination := h.parsePaginationOptions(ctx.Request())
+	data, err := h.containerService.ListContainerMembersWithMetadata(ctx.Request().Context(), container.ID(), format, requestBaseURL(ctx.Request())+"/", pagination)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	h.setLDPHeaders(ctx, container)
+	header := ctx.Response().Header()
+	header.Set("Content-Type", format)
+	header.Set("Preference-Applied", "return=representation")
+	header.Add("Vary", "Prefer")
+
+	h.recordContainerRead(ctx, container.ID(), format)
+
+	ctx.Response().WriteHeader(http.StatusOK)
+	_, err = ctx.Response().Write(data)
+	return err
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefersInclude(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   bool
+	}{
+		{"no preference", nil, false},
+		{"included", []string{`return=representation; include="urn:goro:PreferMemberMetadata"`}, true},
+		{"among other IRIs", []string{`return=representation; include="http://www.w3.org/ns/ldp#PreferContainment urn:goro:PreferMemberMetadata"`}, true},
+		{"next to other preferences", []string{`respond-async, return=representation; include="urn:goro:PreferMemberMetadata"`}, true},
+		{"omitted", []string{`return=representation; omit="urn:goro:PreferMemberMetadata"`}, false},
+		{"minimal representation", []string{`return=minimal; include="urn:goro:PreferMemberMetadata"`}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, prefersInclude(tt.values, preferMemberMetadata))
+		})
+	}
+}
+
+func TestContainerHandler_GetContainer_MemberMetadata(t *testing.T) {
+	mockService := new(MockContainerService)
+	handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+	auditor, reads := newRecordingReadAuditor()
+	handler.SetReadAuditor(auditor)
+
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	listing := []byte("<http://example.com/m1> <http://purl.org/dc/terms/title> \"Beach\" .\n")
+	mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+	mockService.On("ListContainerMembersWithMetadata", mock.Anything, "photos", "text/turtle", "http://example.com/", domain.PaginationOptions{Limit: 10, Offset: 0}).Return(listing, nil)
+
+	ctx := createTestContext("GET", "http://example.com/containers/photos?limit=10", nil, map[string][]string{"id": {"photos"}})
+	ctx.Request().Header.Set("Accept", "text/turtle")
+	ctx.Request().Header.Set("Prefer", `return=representation; include="urn:goro:PreferMemberMetadata"`)
+	require.NoError(t, handler.GetContainer(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, listing, response.Body.Bytes())
+	assert.Equal(t, "text/turtle", response.Header().Get("Content-Type"))
+	assert.Equal(t, "return=representation", response.Header().Get("Preference-Applied"))
+	assert.Contains(t, response.Header().Values("Vary"), "Prefer")
+	mockService.AssertNotCalled(t, "ListContainerMembers", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, []string{"photos"}, reads.of(), "the listing is audited as a read of the container")
+}
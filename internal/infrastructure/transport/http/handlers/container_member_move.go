@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// MoveMemberRequest is the body of a request moving a member to another container
+type MoveMemberRequest struct {
+	// Target is the container the member is moved into
+	Target string `json:"target"`
+}
+
+// MoveMember handles POST requests moving a resource from the container in the path to the
+// target container named in the body. A target that inherits on move re-applies its
+// inheritable metadata to the resource, which is stored. The caller needs Write on both
+// containers: the source is checked by Web Access Control, the target by the service.
+func (h *ContainerHandler) MoveMember(ctx khttp.Context) error {
+	// Extract container and member IDs from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+	memberID := ""
+	if len(vars["member_id"]) > 0 {
+		memberID = vars["member_id"][0]
+	}
+
+	if id == "" || memberID == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID and member ID are required")
+	}
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_BODY", "Failed to read request body")
+	}
+
+	var req MoveMemberRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON in request body")
+	}
+	if req.Target == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Target container is required")
+	}
+
+	member, err := h.storageService.RetrieveResource(ctx.Request().Context(), memberID, "")
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return h.writeErrorResponse(ctx, http.StatusNotFound, "MEMBER_NOT_FOUND",
+				fmt.Sprintf("Cannot move resource %s because it does not exist", memberID))
+		}
+		return h.handleStorageError(ctx, err)
+	}
+
+	if err := h.containerService.MoveResource(agentContext(ctx.Request()), memberID, id, req.Target, member); err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"id":      memberID,
+		"from":    id,
+		"to":      req.Target,
+		"message": "Resource moved successfully",
+	})
+}
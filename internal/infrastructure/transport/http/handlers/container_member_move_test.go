@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// moveContainerService mocks MoveResource; other container service methods are not expected
+type moveContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *moveContainerService) MoveResource(ctx context.Context, resourceID, sourceContainerID, targetContainerID string, resource domain.Resource) error {
+	return m.Called(ctx, resourceID, sourceContainerID, targetContainerID, resource).Error(0)
+}
+
+func TestContainerHandler_MoveMember(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	ctx := context.Background()
+
+	t.Run("should move the stored resource as the verified agent", func(t *testing.T) {
+		mockService := new(moveContainerService)
+		storage := new(MockStorageService)
+		handler := NewContainerHandler(mockService, storage, log.DefaultLogger)
+
+		card := domain.NewResource(ctx, "card", "text/turtle", nil)
+		storage.On("RetrieveResource", mock.Anything, "card", "").Return(card, nil)
+		asAlice := mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.AgentFromContext(ctx) == alice
+		})
+		mockService.On("MoveResource", asAlice, "card", "inbox", "archive", card).Return(nil)
+
+		httpCtx := createTestContext("POST", "/containers/inbox/members/card/move", []byte(`{"target":"archive"}`),
+			map[string][]string{"id": {"inbox"}, "member_id": {"card"}})
+		authenticateAs(httpCtx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.MoveMember(httpCtx))
+
+		assert.Equal(t, http.StatusOK, httpCtx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a move into a container the agent cannot write", func(t *testing.T) {
+		mockService := new(moveContainerService)
+		storage := new(MockStorageService)
+		handler := NewContainerHandler(mockService, storage, log.DefaultLogger)
+
+		card := domain.NewResource(ctx, "card", "text/turtle", nil)
+		storage.On("RetrieveResource", mock.Anything, "card", "").Return(card, nil)
+		mockService.On("MoveResource", mock.Anything, "card", "inbox", "private", card).Return(domain.ErrAccessDenied)
+
+		httpCtx := createTestContext("POST", "/containers/inbox/members/card/move", []byte(`{"target":"private"}`),
+			map[string][]string{"id": {"inbox"}, "member_id": {"card"}})
+		require.NoError(t, handler.MoveMember(httpCtx))
+
+		assert.Equal(t, http.StatusForbidden, httpCtx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should reject requests without a target or member", func(t *testing.T) {
+		handler := NewContainerHandler(new(moveContainerService), new(MockStorageService), log.DefaultLogger)
+
+		httpCtx := createTestContext("POST", "/containers/inbox/members/card/move", []byte(`{}`),
+			map[string][]string{"id": {"inbox"}, "member_id": {"card"}})
+		require.NoError(t, handler.MoveMember(httpCtx))
+		assert.Equal(t, http.StatusBadRequest, httpCtx.(*mockHTTPContext).response.Code)
+
+		storage := new(MockStorageService)
+		storage.On("RetrieveResource", mock.Anything, "gone", "").Return(nil, domain.ErrResourceNotFound)
+		handler = NewContainerHandler(new(moveContainerService), storage, log.DefaultLogger)
+		httpCtx = createTestContext("POST", "/containers/inbox/members/gone/move", []byte(`{"target":"archive"}`),
+			map[string][]string{"id": {"inbox"}, "member_id": {"gone"}})
+		require.NoError(t, handler.MoveMember(httpCtx))
+		assert.Equal(t, http.StatusNotFound, httpCtx.(*mockHTTPContext).response.Code)
+	})
+}
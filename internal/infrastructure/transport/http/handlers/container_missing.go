@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetMissingContainerBehavior sets how HEAD and OPTIONS on a container that does not exist are
+// answered
+func (h *ContainerHandler) SetMissingContainerBehavior(behavior string) {
+	h.missingContainer = behavior
+}
+
+// SetCreateAuthorizer sets the authorizer deciding whether the caller may create a container,
+// consulted when a missing container is answered with creation hints
+func (h *ContainerHandler) SetCreateAuthorizer(authorizer ContainerCreateAuthorizer) {
+	h.createAuthorizer = authorizer
+}
+
+// answerMissingContainer answers a HEAD or OPTIONS request for a container that does not exist.
+// With hints enabled and an existing parent, a caller who may create the container gets 404
+// with the headers needed to do so: a Link to the parent to POST to and the formats it accepts.
+// A caller who may not gets 403, so "absent but creatable" and "forbidden" can be told apart.
+// Otherwise the answer is a bare 404.
+func (h *ContainerHandler) answerMissingContainer(ctx khttp.Context, id string) error {
+	if h.missingContainer != conf.MissingContainerHints {
+		ctx.Response().WriteHeader(http.StatusNotFound)
+		return nil
+	}
+
+	parentID := ""
+	if i := strings.LastIndex(id, "/"); i > 0 {
+		parentID = id[:i]
+		exists, err := h.containerService.ContainerExists(ctx.Request().Context(), parentID)
+		if err != nil || !exists {
+			ctx.Response().WriteHeader(http.StatusNotFound)
+			return nil
+		}
+	}
+
+	if h.createAuthorizer != nil && !h.createAuthorizer.CanCreateContainer(ctx.Request().Context(), parentID) {
+		ctx.Response().WriteHeader(http.StatusForbidden)
+		return nil
+	}
+
+	parentLocation := "/containers/"
+	if parentID != "" {
+		parentLocation += url.PathEscape(parentID)
+	}
+	header := ctx.Response().Header()
+	header.Set("Allow", "OPTIONS, HEAD")
+	header.Set("Link", "<"+parentLocation+`>; rel="up"`)
+	header.Set("Accept-Post", strings.Join(h.mediaTypes().Supported(), ", "))
+	ctx.Response().WriteHeader(http.StatusNotFound)
+	return nil
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// knownContainerService knows only the containers it lists as existing
+type knownContainerService struct {
+	preconditionContainerService
+	existing map[string]bool
+}
+
+func (m *knownContainerService) GetContainer(ctx context.Context, id string) (domain.ContainerResource, error) {
+	if m.existing[id] {
+		return domain.NewContainer(ctx, id, "", domain.BasicContainer), nil
+	}
+	return nil, domain.ErrResourceNotFound.WithOperation("GetContainer")
+}
+
+func (m *knownContainerService) ContainerExists(ctx context.Context, id string) (bool, error) {
+	return m.existing[id], nil
+}
+
+// fixedCreateAuthorizer allows creating containers in the listed parents only
+type fixedCreateAuthorizer map[string]bool
+
+func (f fixedCreateAuthorizer) CanCreateContainer(ctx context.Context, parentID string) bool {
+	return f[parentID]
+}
+
+func TestContainerHandler_HeadMissingContainer(t *testing.T) {
+	service := &knownContainerService{existing: map[string]bool{"alice": true}}
+
+	tests := []struct {
+		name           string
+		behavior       string
+		id             string
+		authorizer     ContainerCreateAuthorizer
+		expectedStatus int
+		expectHints    bool
+	}{
+		{name: "plain 404 by default", behavior: conf.MissingContainerPlain, id: "alice/notes", expectedStatus: http.StatusNotFound},
+		{name: "hints when the caller may create it", behavior: conf.MissingContainerHints, id: "alice/notes",
+			authorizer: fixedCreateAuthorizer{"alice": true}, expectedStatus: http.StatusNotFound, expectHints: true},
+		{name: "forbidden when the caller may not create it", behavior: conf.MissingContainerHints, id: "alice/notes",
+			authorizer: fixedCreateAuthorizer{}, expectedStatus: http.StatusForbidden},
+		{name: "plain 404 when the parent is missing", behavior: conf.MissingContainerHints, id: "bob/notes",
+			authorizer: fixedCreateAuthorizer{"bob": true}, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewContainerHandler(service, nil, log.DefaultLogger)
+			handler.SetMissingContainerBehavior(tt.behavior)
+			if tt.authorizer != nil {
+				handler.SetCreateAuthorizer(tt.authorizer)
+			}
+
+			ctx := createTestContext("HEAD", "/containers/notes", nil, map[string][]string{"id": {tt.id}})
+			require.NoError(t, handler.HeadContainer(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, tt.expectedStatus, response.Code)
+			if tt.expectHints {
+				assert.Equal(t, `</containers/alice>; rel="up"`, response.Header().Get("Link"))
+				assert.NotEmpty(t, response.Header().Get("Accept-Post"))
+			} else {
+				assert.Empty(t, response.Header().Get("Accept-Post"))
+			}
+		})
+	}
+}
+
+func TestContainerHandler_OptionsMissingContainer(t *testing.T) {
+	service := &knownContainerService{existing: map[string]bool{"alice": true}}
+	handler := NewContainerHandler(service, nil, log.DefaultLogger)
+	handler.SetMissingContainerBehavior(conf.MissingContainerHints)
+
+	ctx := createTestContext("OPTIONS", "/containers/notes", nil, map[string][]string{"id": {"alice/notes"}})
+	require.NoError(t, handler.OptionsContainer(ctx))
+
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	assert.Equal(t, "OPTIONS, HEAD", ctx.(*mockHTTPContext).response.Header().Get("Allow"))
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -22,15 +24,15 @@ func (m *MockContainerService) CreateContainer(ctx context.Context, id, parentID
 	return args.Get(0).(*domain.Container), args.Error(1)
 }
 
-func (m *MockContainerService) GetContainer(ctx context.Context, id string) (*domain.Container, error) {
+func (m *MockContainerService) GetContainer(ctx context.Context, id string) (domain.ContainerResource, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Container), args.Error(1)
+	return args.Get(0).(domain.ContainerResource), args.Error(1)
 }
 
-func (m *MockContainerService) UpdateContainer(ctx context.Context, container *domain.Container) error {
+func (m *MockContainerService) UpdateContainer(ctx context.Context, container domain.ContainerResource) error {
 	args := m.Called(ctx, container)
 	return args.Error(0)
 }
@@ -40,8 +42,8 @@ func (m *MockContainerService) DeleteContainer(ctx context.Context, id string) e
 	return args.Error(0)
 }
 
-func (m *MockContainerService) AddResource(ctx context.Context, containerID, resourceID string) error {
-	args := m.Called(ctx, containerID, resourceID)
+func (m *MockContainerService) AddResource(ctx context.Context, containerID, resourceID string, resource domain.Resource) error {
+	args := m.Called(ctx, containerID, resourceID, resource)
 	return args.Error(0)
 }
 
@@ -95,25 +97,201 @@ func (m *MockContainerService) ContainerExists(ctx context.Context, id string) (
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockContainerService) SetContainerRDFNormalization(ctx context.Context, containerID string, enabled bool) error {
+	args := m.Called(ctx, containerID, enabled)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) SetContainerExternalMembers(ctx context.Context, containerID string, allowed bool) error {
+	args := m.Called(ctx, containerID, allowed)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) SetContainerDefaultSort(ctx context.Context, containerID string, sort domain.SortOptions) error {
+	args := m.Called(ctx, containerID, sort)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) SetContainerRetentionPolicy(ctx context.Context, containerID string, policy domain.RetentionPolicy) error {
+	args := m.Called(ctx, containerID, policy)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) NormalizeContainerRDF(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, string, error) {
+	args := m.Called(ctx, containerID, data, contentType)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]byte), args.String(1), args.Error(2)
+}
+
+func (m *MockContainerService) SetContainerInheritableMetadata(ctx context.Context, containerID string, values map[string]interface{}, inheritOnMove bool) error {
+	args := m.Called(ctx, containerID, values, inheritOnMove)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) InheritedMetadata(ctx context.Context, containerID string) (map[string]interface{}, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (m *MockContainerService) GenerateAuthorizedBreadcrumbs(ctx context.Context, containerID string, authorizer application.ContainerReadAuthorizer) ([]application.BreadcrumbItem, error) {
+	args := m.Called(ctx, containerID, authorizer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]application.BreadcrumbItem), args.Error(1)
+}
+
+func (m *MockContainerService) TouchContainer(ctx context.Context, containerID string) (*domain.Container, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Container), args.Error(1)
+}
+
+func (m *MockContainerService) GetContainerFeed(ctx context.Context, containerID string) (*application.ContainerFeed, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ContainerFeed), args.Error(1)
+}
+
+func (m *MockContainerService) GenerateStructureInfo(ctx context.Context, containerID string, maxDepth int) (*application.ContainerStructureInfo, error) {
+	args := m.Called(ctx, containerID, maxDepth)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ContainerStructureInfo), args.Error(1)
+}
+
+func (m *MockContainerService) ContinueStructureInfo(ctx context.Context, containerID, token string) (*application.ContainerStructureInfo, error) {
+	args := m.Called(ctx, containerID, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ContainerStructureInfo), args.Error(1)
+}
+
+func (m *MockContainerService) SetContainerMembershipResource(ctx context.Context, containerID, reference string) error {
+	args := m.Called(ctx, containerID, reference)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) SetContainerMembershipRelations(ctx context.Context, containerID, hasMemberRelation, insertedContentRelation string) error {
+	args := m.Called(ctx, containerID, hasMemberRelation, insertedContentRelation)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) ApplyMembershipTriple(ctx context.Context, containerID string, member domain.Resource) (*domain.MembershipTriple, error) {
+	args := m.Called(ctx, containerID, member)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MembershipTriple), args.Error(1)
+}
+
+func (m *MockContainerService) MoveResource(ctx context.Context, resourceID, sourceContainerID, targetContainerID string, resource domain.Resource) error {
+	args := m.Called(ctx, resourceID, sourceContainerID, targetContainerID, resource)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) StartAsyncListing(ctx context.Context, containerID string, release func()) (*application.ListingResult, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ListingResult), args.Error(1)
+}
+
+func (m *MockContainerService) GetAsyncListing(ctx context.Context, containerID, listingID string, pagination domain.PaginationOptions) (*application.ListingResult, error) {
+	args := m.Called(ctx, containerID, listingID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ListingResult), args.Error(1)
+}
+
+func (m *MockContainerService) WaitAsyncListing(ctx context.Context, containerID, listingID string, timeout time.Duration, pagination domain.PaginationOptions) (*application.ListingResult, error) {
+	args := m.Called(ctx, containerID, listingID, timeout, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ListingResult), args.Error(1)
+}
+
+func (m *MockContainerService) ListContainerMembersWithMetadata(ctx context.Context, containerID, format, baseURI string, pagination domain.PaginationOptions) ([]byte, error) {
+	args := m.Called(ctx, containerID, format, baseURI, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockContainerService) GetContainerWithPreference(ctx context.Context, id, format, baseURI string, preference domain.ContainerPreference) ([]byte, error) {
+	args := m.Called(ctx, id, format, baseURI, preference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockContainerService) SearchPod(ctx context.Context, accountID string, query domain.SearchQuery, pagination domain.PaginationOptions) (*application.SearchResults, error) {
+	args := m.Called(ctx, accountID, query, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.SearchResults), args.Error(1)
+}
+
+func (m *MockContainerService) DiffContainerMembers(ctx context.Context, containerID string, desired []string) (*application.MembershipDiff, error) {
+	args := m.Called(ctx, containerID, desired)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.MembershipDiff), args.Error(1)
+}
+
+func (m *MockContainerService) CreateContainersWithOptions(ctx context.Context, specs []application.CreateContainerSpec, options application.BatchCreateOptions) ([]application.CreateContainerResult, error) {
+	args := m.Called(ctx, specs, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]application.CreateContainerResult), args.Error(1)
+}
+
 // MockContainerStorageService is a mock implementation of StorageServiceInterface for container tests
 type MockContainerStorageService struct {
 	mock.Mock
 }
 
-func (m *MockContainerStorageService) StoreResource(ctx context.Context, id string, data []byte, contentType string) (*domain.Resource, error) {
+func (m *MockContainerStorageService) StoreResource(ctx context.Context, id string, data []byte, contentType string) (domain.Resource, error) {
 	args := m.Called(ctx, id, data, contentType)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Resource), args.Error(1)
+	return args.Get(0).(domain.Resource), args.Error(1)
 }
 
-func (m *MockContainerStorageService) RetrieveResource(ctx context.Context, id string, acceptFormat string) (*domain.Resource, error) {
+func (m *MockContainerStorageService) StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	args := m.Called(ctx, id, data, contentType, metadata)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
+func (m *MockContainerStorageService) RetrieveResource(ctx context.Context, id string, acceptFormat string) (domain.Resource, error) {
 	args := m.Called(ctx, id, acceptFormat)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Resource), args.Error(1)
+	return args.Get(0).(domain.Resource), args.Error(1)
 }
 
 func (m *MockContainerStorageService) DeleteResource(ctx context.Context, id string) error {
@@ -134,10 +312,60 @@ func (m *MockContainerStorageService) StreamResource(ctx context.Context, id str
 	return args.Get(0).(io.ReadCloser), args.String(1), args.Error(2)
 }
 
-func (m *MockContainerStorageService) StoreResourceStream(ctx context.Context, id string, reader io.Reader, contentType string, size int64) (*domain.Resource, error) {
+func (m *MockContainerStorageService) StoreResourceStream(ctx context.Context, id string, reader io.Reader, contentType string, size int64) (domain.Resource, error) {
 	args := m.Called(ctx, id, reader, contentType, size)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Resource), args.Error(1)
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
+func (m *MockContainerStorageService) PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error) {
+	args := m.Called(ctx, id, mediaType, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
+func (m *MockContainerStorageService) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	args := m.Called(ctx, id, pattern, acceptFormat)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
+func (m *MockContainerStorageService) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	args := m.Called(ctx, id, fromVersion, toVersion)
+	return args.Get(0).(domain.GraphDiff), args.Error(1)
+}
+
+// recordedReads captures the read audit events handed to the audit projection
+type recordedReads struct {
+	envelopes []pericarpdomain.Envelope
+}
+
+func (r *recordedReads) EventTypes() []string {
+	return []string{}
+}
+
+func (r *recordedReads) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	r.envelopes = append(r.envelopes, envelope)
+	return nil
+}
+
+// of returns the IDs of the containers or resources read, in order
+func (r *recordedReads) of() []string {
+	ids := make([]string, 0, len(r.envelopes))
+	for _, envelope := range r.envelopes {
+		ids = append(ids, envelope.Event().AggregateID())
+	}
+	return ids
+}
+
+// newRecordingReadAuditor returns a read auditor that audits every read into reads
+func newRecordingReadAuditor() (*application.ReadAuditor, *recordedReads) {
+	reads := &recordedReads{}
+	return application.NewReadAuditor(true, reads, nil), reads
 }
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// setPaginationLinks adds RFC 5988 first, prev, next and last links for a member listing to
+// the response. Rels without a page in range are omitted. When the repository could not count
+// the container's members, next is inferred from a full page and last is left out.
+func (h *ContainerHandler) setPaginationLinks(ctx khttp.Context, listing *application.ContainerListing) {
+	limit := listing.Pagination.Limit
+	offset := listing.Pagination.Offset
+	if limit <= 0 {
+		return
+	}
+
+	total := listing.TotalCount
+	counted := membersCounted(listing)
+	lastOffset := 0
+	if counted && total > 0 {
+		lastOffset = (total - 1) / limit * limit
+	}
+
+	header := ctx.Response().Header()
+	link := func(rel string, pageOffset int) {
+		header.Add("Link", fmt.Sprintf(`<%s>; rel="%s"`, pageURL(ctx.Request().URL, pageOffset, limit), rel))
+	}
+
+	link("first", 0)
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		if counted && prev > lastOffset {
+			prev = lastOffset
+		}
+		link("prev", prev)
+	}
+	if next, ok := nextPageOffset(listing); ok {
+		link("next", next)
+	}
+	if counted {
+		link("last", lastOffset)
+	}
+}
+
+// membersCounted reports whether the listing's total is known. Repositories that cannot count
+// members leave the total at zero even for a non-empty page.
+func membersCounted(listing *application.ContainerListing) bool {
+	return listing.TotalCount > 0 || len(listing.Members) == 0
+}
+
+// nextPageOffset returns the offset of the page after the listing, if there is one. When the
+// members were not counted, a full page is taken to have one after it.
+func nextPageOffset(listing *application.ContainerListing) (int, bool) {
+	limit := listing.Pagination.Limit
+	if limit <= 0 {
+		return 0, false
+	}
+	next := listing.Pagination.Offset + limit
+	if membersCounted(listing) {
+		return next, next < listing.TotalCount
+	}
+	return next, len(listing.Members) == limit
+}
+
+// addPageTriples describes the page of members a response holds as an LDP Paging ldp:Page,
+// for a request that asked for a page with limit or offset. The page is ldp:pageOf the
+// container and names the following page with ldp:nextPage, or rdf:nil on the last page, so
+// clients reading only the graph can walk the pages without the Link headers.
+func (h *ContainerHandler) addPageTriples(ctx khttp.Context, response map[string]interface{}, listing *application.ContainerListing) {
+	requestURL := ctx.Request().URL
+	query := requestURL.Query()
+	if listing.Pagination.Limit <= 0 || (!query.Has("limit") && !query.Has("offset")) {
+		return
+	}
+
+	page := map[string]interface{}{
+		"@id":          pageURL(requestURL, listing.Pagination.Offset, listing.Pagination.Limit),
+		"@type":        "ldp:Page",
+		"ldp:nextPage": map[string]interface{}{"@id": rdfNil},
+	}
+	if next, ok := nextPageOffset(listing); ok {
+		page["ldp:nextPage"] = map[string]interface{}{"@id": pageURL(requestURL, next, listing.Pagination.Limit)}
+	}
+
+	// The page is the subject of ldp:pageOf, so it is attached to the container in reverse
+	response["@reverse"] = map[string]interface{}{"ldp:pageOf": page}
+}
+
+// rdfNil ends the chain of ldp:nextPage links on the last page
+const rdfNil = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+
+// pageURL returns the request's path and query with the page's offset and limit
+func pageURL(requestURL *url.URL, offset, limit int) string {
+	query := requestURL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	return (&url.URL{Path: requestURL.Path, RawQuery: query.Encode()}).String()
+}
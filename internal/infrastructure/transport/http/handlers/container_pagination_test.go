@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerHandler_SetPaginationLinks(t *testing.T) {
+	handler := NewContainerHandler(nil, nil, log.DefaultLogger)
+
+	links := func(path string, offset, limit, total int, members []string) []string {
+		ctx := createTestContext("GET", path, nil, map[string][]string{"id": {"docs"}})
+		handler.setPaginationLinks(ctx, &application.ContainerListing{
+			ContainerID: "docs",
+			Members:     members,
+			Pagination:  domain.PaginationOptions{Offset: offset, Limit: limit},
+			TotalCount:  total,
+		})
+		return ctx.(*mockHTTPContext).response.Header().Values("Link")
+	}
+
+	t.Run("should link every page around a middle page", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`</containers/docs?limit=10&offset=0>; rel="first"`,
+			`</containers/docs?limit=10&offset=0>; rel="prev"`,
+			`</containers/docs?limit=10&offset=20>; rel="next"`,
+			`</containers/docs?limit=10&offset=20>; rel="last"`,
+		}, links("/containers/docs?offset=10&limit=10", 10, 10, 25, make([]string, 10)))
+	})
+
+	t.Run("should omit prev on the first page and next on the last", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`</containers/docs?limit=10&offset=0>; rel="first"`,
+			`</containers/docs?limit=10&offset=10>; rel="next"`,
+			`</containers/docs?limit=10&offset=10>; rel="last"`,
+		}, links("/containers/docs", 0, 10, 20, make([]string, 10)))
+
+		assert.Equal(t, []string{
+			`</containers/docs?limit=10&offset=0>; rel="first"`,
+			`</containers/docs?limit=10&offset=0>; rel="prev"`,
+			`</containers/docs?limit=10&offset=10>; rel="last"`,
+		}, links("/containers/docs?offset=10", 10, 10, 20, make([]string, 10)))
+	})
+
+	t.Run("should point prev past the end at the last page", func(t *testing.T) {
+		assert.Contains(t, links("/containers/docs?offset=100", 100, 10, 25, nil),
+			`</containers/docs?limit=10&offset=20>; rel="prev"`)
+	})
+
+	t.Run("should keep other query parameters", func(t *testing.T) {
+		assert.Contains(t, links("/containers/docs?format=turtle", 0, 10, 5, make([]string, 5)),
+			`</containers/docs?format=turtle&limit=10&offset=0>; rel="first"`)
+	})
+
+	t.Run("should infer next from a full page when members were not counted", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`</containers/docs?limit=10&offset=0>; rel="first"`,
+			`</containers/docs?limit=10&offset=10>; rel="next"`,
+		}, links("/containers/docs", 0, 10, 0, make([]string, 10)))
+
+		assert.Equal(t, []string{
+			`</containers/docs?limit=10&offset=0>; rel="first"`,
+		}, links("/containers/docs", 0, 10, 0, make([]string, 4)))
+	})
+}
+
+func TestContainerHandler_AddPageTriples(t *testing.T) {
+	handler := NewContainerHandler(nil, nil, log.DefaultLogger)
+
+	page := func(path string, offset, limit, total int, members []string) map[string]interface{} {
+		ctx := createTestContext("GET", path, nil, map[string][]string{"id": {"docs"}})
+		response := map[string]interface{}{"@id": "docs"}
+		handler.addPageTriples(ctx, response, &application.ContainerListing{
+			ContainerID: "docs",
+			Members:     members,
+			Pagination:  domain.PaginationOptions{Offset: offset, Limit: limit},
+			TotalCount:  total,
+		})
+		reverse, ok := response["@reverse"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		return reverse["ldp:pageOf"].(map[string]interface{})
+	}
+
+	t.Run("should describe the first page as a page of the container linking the next", func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{
+			"@id":          "/containers/docs?limit=10&offset=0",
+			"@type":        "ldp:Page",
+			"ldp:nextPage": map[string]interface{}{"@id": "/containers/docs?limit=10&offset=10"},
+		}, page("/containers/docs?limit=10", 0, 10, 25, make([]string, 10)))
+	})
+
+	t.Run("should end the last page with rdf:nil", func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{"@id": rdfNil},
+			page("/containers/docs?offset=20&limit=10", 20, 10, 25, make([]string, 5))["ldp:nextPage"])
+	})
+
+	t.Run("should infer the next page from a full page when members were not counted", func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{"@id": "/containers/docs?limit=10&offset=10"},
+			page("/containers/docs?offset=0&limit=10", 0, 10, 0, make([]string, 10))["ldp:nextPage"])
+	})
+
+	t.Run("should leave unpaged reads alone", func(t *testing.T) {
+		assert.Nil(t, page("/containers/docs", 0, 10, 25, make([]string, 10)))
+	})
+}
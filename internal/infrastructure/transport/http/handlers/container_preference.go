@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// containerPreference reads the containment and membership triples Prefer headers ask a
+// container representation to leave out, as in
+// Prefer: return=representation; include="http://www.w3.org/ns/ldp#PreferMinimalContainer"
+func containerPreference(values []string) domain.ContainerPreference {
+	return domain.NewContainerPreference(representationPreferences(values, "include"), representationPreferences(values, "omit"))
+}
+
+// writePreferredContainer answers a container read whose Prefer header leaves out containment
+// or membership triples with the container's RDF representation, converted without them
+func (h *ContainerHandler) writePreferredContainer(ctx khttp.Context, container domain.ContainerResource, format string, preference domain.ContainerPreference) error {
+	header := ctx.Response().Header()
+	header.Add("Vary", "Prefer")
+
+	// The representation differs from the full one, so it carries its own entity tag
+	version := h.generateContainerETag(container) + "-" + preference.Token()
+	if format == infrastructure.JSONLDExpandedFormat {
+		version += "-expanded"
+	}
+	if h.answerConditional(ctx, container, h.etags().Tag(version, format)) {
+		return nil
+	}
+
+	data, err := h.containerService.GetContainerWithPreference(ctx.Request().Context(), container.ID(), format, requestBaseURL(ctx.Request())+"/", preference)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	h.setLDPHeaders(ctx, container)
+	header.Set("Content-Type", format)
+	header.Set("Content-Length", strconv.Itoa(len(data)))
+	header.Set("Preference-Applied", "return=representation")
+
+	h.recordContainerRead(ctx, container.ID(), format)
+
+	ctx.Response().WriteHeader(http.StatusOK)
+	_, err = ctx.Response().Write(data)
+	return err
+}
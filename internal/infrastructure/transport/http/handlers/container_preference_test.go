@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerHandler_GetContainer_MinimalContainer(t *testing.T) {
+	mockService := new(MockContainerService)
+	handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+	auditor, reads := newRecordingReadAuditor()
+	handler.SetReadAuditor(auditor)
+
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	minimal := []byte("<http://example.com/photos> a <http://www.w3.org/ns/ldp#BasicContainer> .\n")
+	mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+	mockService.On("GetContainerWithPreference", mock.Anything, "photos", "text/turtle", "http://example.com/", mock.Anything).Return(minimal, nil)
+
+	ctx := createTestContext("GET", "http://example.com/containers/photos", nil, map[string][]string{"id": {"photos"}})
+	ctx.Request().Header.Set("Accept", "text/turtle")
+	ctx.Request().Header.Set("Prefer", `return=representation; include="http://www.w3.org/ns/ldp#PreferMinimalContainer"`)
+	require.NoError(t, handler.GetContainer(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, minimal, response.Body.Bytes())
+	assert.Equal(t, "return=representation", response.Header().Get("Preference-Applied"))
+	assert.Contains(t, response.Header().Values("Vary"), "Prefer")
+	assert.Equal(t, []string{"photos"}, reads.of(), "the minimal container is audited as a read of it")
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetRecursiveDeleter enables DELETE ?recursive=true, deleting a container with everything
+// below it through the given deleter. LDP refuses to delete non-empty containers, so recursive
+// deletes are off unless configured.
+func (h *ContainerHandler) SetRecursiveDeleter(deleter RecursiveContainerDeleter) {
+	h.recursiveDeleter = deleter
+}
+
+// deleteContainerRecursive answers DELETE ?recursive=true by removing the container's whole tree
+func (h *ContainerHandler) deleteContainerRecursive(ctx khttp.Context, id string) error {
+	if h.recursiveDeleter == nil {
+		return h.writeErrorResponse(ctx, http.StatusForbidden, "RECURSIVE_DELETE_DISABLED", "Recursive container deletion is not enabled on this server")
+	}
+
+	deletion, err := h.recursiveDeleter.DeleteContainerRecursive(agentContext(ctx.Request()), id)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"id":         id,
+		"message":    "Container and its contents deleted successfully",
+		"containers": deletion.Containers,
+		"resources":  deletion.Resources,
+	})
+}
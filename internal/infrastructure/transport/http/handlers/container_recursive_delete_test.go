@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRecursiveDeleter records recursive deletes
+type mockRecursiveDeleter struct {
+	mock.Mock
+}
+
+func (m *mockRecursiveDeleter) DeleteContainerRecursive(ctx context.Context, id string) (*application.RecursiveDeletion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.RecursiveDeletion), args.Error(1)
+}
+
+func TestContainerHandler_DeleteContainerRecursive(t *testing.T) {
+	t.Run("should refuse recursive deletes unless enabled", func(t *testing.T) {
+		service := new(preconditionContainerService)
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+
+		ctx := createTestContext("DELETE", "/containers/photos?recursive=true", nil, map[string][]string{"id": {"photos"}})
+		require.NoError(t, handler.DeleteContainer(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+		service.AssertNotCalled(t, "DeleteContainer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should delete the whole tree when enabled", func(t *testing.T) {
+		service := new(preconditionContainerService)
+		deleter := new(mockRecursiveDeleter)
+		deleter.On("DeleteContainerRecursive", mock.Anything, "photos").Return(&application.RecursiveDeletion{
+			Containers: []string{"trips", "photos"},
+			Resources:  []string{"lisbon"},
+		}, nil)
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+		handler.SetRecursiveDeleter(deleter)
+
+		ctx := createTestContext("DELETE", "/containers/photos?recursive=true", nil, map[string][]string{"id": {"photos"}})
+		require.NoError(t, handler.DeleteContainer(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		assert.Contains(t, ctx.(*mockHTTPContext).response.Body.String(), "lisbon")
+		deleter.AssertExpectations(t)
+		service.AssertNotCalled(t, "DeleteContainer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should delete as the verified agent and refuse what it cannot write", func(t *testing.T) {
+		const alice = "https://alice.example/profile#me"
+		service := new(preconditionContainerService)
+		deleter := new(mockRecursiveDeleter)
+		deleter.On("DeleteContainerRecursive", mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.AgentFromContext(ctx) == alice
+		}), "photos").Return(nil, domain.ErrAccessDenied.WithOperation("DeleteContainerRecursive"))
+		handler := NewContainerHandler(service, nil, log.DefaultLogger)
+		handler.SetRecursiveDeleter(deleter)
+
+		ctx := createTestContext("DELETE", "/containers/photos?recursive=true", nil, map[string][]string{"id": {"photos"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.DeleteContainer(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+		deleter.AssertExpectations(t)
+	})
+}
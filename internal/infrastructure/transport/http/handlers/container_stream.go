@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bufio"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// streamBufferSize is how much streamed container output is gathered before it is sent as a chunk
+const streamBufferSize = 32 << 10
+
+// SetRDFStreamer sets the streamer whole containers are written through in Turtle and
+// N-Triples; without one they are listed a page at a time like other formats
+func (h *ContainerHandler) SetRDFStreamer(streamer ContainerRDFStreamer) {
+	h.rdfStreamer = streamer
+}
+
+// streamsContainer reports whether a container read is answered with the whole container
+// streamed: Turtle or N-Triples, with no page of the listing asked for
+func (h *ContainerHandler) streamsContainer(r *http.Request, format string) bool {
+	if h.rdfStreamer == nil {
+		return false
+	}
+	if format != "text/turtle" && format != "application/n-triples" {
+		return false
+	}
+	query := r.URL.Query()
+	return !query.Has("limit") && !query.Has("offset")
+}
+
+// startingWriter sends the response status and headers just before the first byte of the body
+type startingWriter struct {
+	response http.ResponseWriter
+	start    func()
+	started  bool
+}
+
+func (s *startingWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.start()
+	}
+	return s.response.Write(p)
+}
+
+// writeStreamedContainer answers a container read with the whole container in an RDF format,
+// sent with chunked transfer encoding as its membership triples are written. Output is
+// buffered, so a failure before the first chunk still gets an error response; a failure
+// after it leaves the body truncated.
+func (h *ContainerHandler) writeStreamedContainer(ctx khttp.Context, container domain.ContainerResource, format string) error {
+	if h.answerConditional(ctx, container, h.etags().Tag(h.generateContainerETag(container), format)) {
+		return nil
+	}
+
+	response := ctx.Response()
+	body := &startingWriter{response: response, start: func() {
+		h.setLDPHeaders(ctx, container)
+		response.Header().Set("Content-Type", format)
+		response.Header().Set("Transfer-Encoding", "chunked")
+		response.WriteHeader(http.StatusOK)
+	}}
+	buffered := bufio.NewWriterSize(body, streamBufferSize)
+
+	err := h.rdfStreamer.StreamContainerRDF(ctx.Request().Context(), container.ID(), format, requestBaseURL(ctx.Request())+"/", buffered)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		if !body.started {
+			return h.handleContainerError(ctx, err)
+		}
+		// The status is already sent; the truncated body is all the client can be given
+		h.logger.Log(log.LevelError, "msg", "Container streaming failed", "containerID", container.ID(), "error", err)
+		return nil
+	}
+
+	h.recordContainerRead(ctx, container.ID(), format)
+	return nil
+}
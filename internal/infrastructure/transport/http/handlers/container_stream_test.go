@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedStreamer writes a fixed document, or fails before writing anything
+type fixedStreamer struct {
+	document string
+	err      error
+}
+
+func (f *fixedStreamer) StreamContainerRDF(ctx context.Context, id, format, baseURI string, w io.Writer) error {
+	if f.err != nil {
+		return f.err
+	}
+	_, err := io.WriteString(w, f.document)
+	return err
+}
+
+func TestContainerHandler_GetContainer_Streamed(t *testing.T) {
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	document := "<http://example.com/photos> <http://www.w3.org/ns/ldp#contains> <http://example.com/m1> .\n"
+
+	t.Run("streams Turtle in chunks", func(t *testing.T) {
+		mockService := new(MockContainerService)
+		mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetRDFStreamer(&fixedStreamer{document: document})
+
+		ctx := createTestContext("GET", "http://example.com/containers/photos", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("Accept", "text/turtle")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, document, response.Body.String())
+		assert.Equal(t, "text/turtle", response.Header().Get("Content-Type"))
+		assert.Equal(t, "chunked", response.Header().Get("Transfer-Encoding"))
+		assert.Empty(t, response.Header().Get("Content-Length"))
+		mockService.AssertNotCalled(t, "ListContainerMembers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("answers a failure before writing with an error", func(t *testing.T) {
+		mockService := new(MockContainerService)
+		mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		handler.SetRDFStreamer(&fixedStreamer{err: domain.ErrResourceNotFound})
+
+		ctx := createTestContext("GET", "http://example.com/containers/photos", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("Accept", "text/turtle")
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Empty(t, response.Header().Get("Transfer-Encoding"))
+	})
+}
+
+func TestContainerHandler_StreamsContainer(t *testing.T) {
+	handler := NewContainerHandler(new(MockContainerService), nil, log.DefaultLogger)
+	whole, _ := http.NewRequest(http.MethodGet, "/containers/photos", nil)
+	paged, _ := http.NewRequest(http.MethodGet, "/containers/photos?limit=10", nil)
+
+	assert.False(t, handler.streamsContainer(whole, "text/turtle"), "no streamer")
+
+	handler.SetRDFStreamer(&fixedStreamer{})
+	assert.True(t, handler.streamsContainer(whole, "text/turtle"))
+	assert.True(t, handler.streamsContainer(whole, "application/n-triples"))
+	assert.False(t, handler.streamsContainer(whole, "application/ld+json"))
+	assert.False(t, handler.streamsContainer(paged, "text/turtle"))
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// defaultStructureDepth is used when a structure request does not specify a depth; the
+// service's structure limits bound the response size regardless
+const defaultStructureDepth = 10
+
+// GetContainerStructure handles GET requests for a container's hierarchical structure. Query
+// parameter depth limits the levels returned; continuation fetches the next page of a
+// structure the service truncated.
+func (h *ContainerHandler) GetContainerStructure(ctx khttp.Context) error {
+	// Extract container ID from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+
+	if id == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Container ID is required")
+	}
+
+	release, err := h.acquireHeavyOperation(ctx, id, application.OperationStructure)
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+	defer release()
+
+	params := ctx.Request().URL.Query()
+	var structure *application.ContainerStructureInfo
+	if token := params.Get("continuation"); token != "" {
+		structure, err = h.containerService.ContinueStructureInfo(ctx.Request().Context(), id, token)
+	} else {
+		depth := defaultStructureDepth
+		if depthStr := params.Get("depth"); depthStr != "" {
+			depth, err = strconv.Atoi(depthStr)
+			if err != nil || depth < 0 {
+				return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Depth must be a non-negative integer")
+			}
+		}
+		structure, err = h.containerService.GenerateStructureInfo(ctx.Request().Context(), id, depth)
+	}
+	if err != nil {
+		if storageErr, ok := domain.GetStorageError(err); ok && storageErr.Code == domain.ErrInvalidFormat.Code {
+			return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_CONTINUATION", "The continuation token is invalid for this container")
+		}
+		return h.handleContainerError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	return ctx.JSON(http.StatusOK, structure)
+}
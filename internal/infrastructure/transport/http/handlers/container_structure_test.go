@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// structureContainerService mocks the structure methods; other container service methods are not expected
+type structureContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *structureContainerService) GenerateStructureInfo(ctx context.Context, containerID string, maxDepth int) (*application.ContainerStructureInfo, error) {
+	args := m.Called(ctx, containerID, maxDepth)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ContainerStructureInfo), args.Error(1)
+}
+
+func (m *structureContainerService) ContinueStructureInfo(ctx context.Context, containerID, token string) (*application.ContainerStructureInfo, error) {
+	args := m.Called(ctx, containerID, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ContainerStructureInfo), args.Error(1)
+}
+
+func TestContainerHandler_GetContainerStructure(t *testing.T) {
+	t.Run("should return a truncated structure with its continuation token", func(t *testing.T) {
+		mockService := new(structureContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("GenerateStructureInfo", mock.Anything, "root", 2).Return(&application.ContainerStructureInfo{
+			Container:         application.ContainerInfo{ID: "root"},
+			Truncated:         true,
+			ContinuationToken: "next",
+		}, nil)
+
+		ctx := createTestContext("GET", "/containers/root/structure?depth=2", nil, map[string][]string{"id": {"root"}})
+		require.NoError(t, handler.GetContainerStructure(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, true, body["truncated"])
+		assert.Equal(t, "next", body["continuationToken"])
+	})
+
+	t.Run("should continue from a token", func(t *testing.T) {
+		mockService := new(structureContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("ContinueStructureInfo", mock.Anything, "root", "next").Return(&application.ContainerStructureInfo{
+			Container: application.ContainerInfo{ID: "root"},
+			Continued: true,
+		}, nil)
+
+		ctx := createTestContext("GET", "/containers/root/structure?continuation=next", nil, map[string][]string{"id": {"root"}})
+		require.NoError(t, handler.GetContainerStructure(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid continuation token", func(t *testing.T) {
+		mockService := new(structureContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("ContinueStructureInfo", mock.Anything, "root", "bogus").Return(nil,
+			domain.WrapStorageError(assert.AnError, domain.ErrInvalidFormat.Code, "invalid continuation token"))
+
+		ctx := createTestContext("GET", "/containers/root/structure?continuation=bogus", nil, map[string][]string{"id": {"root"}})
+		require.NoError(t, handler.GetContainerStructure(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should reject a negative depth", func(t *testing.T) {
+		handler := NewContainerHandler(new(structureContainerService), nil, log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/containers/root/structure?depth=-1", nil, map[string][]string{"id": {"root"}})
+		require.NoError(t, handler.GetContainerStructure(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/gorilla/websocket"
+)
+
+// subscriptionMessage is a control message written to a subscription connection, acknowledging
+// a subscription or reporting why one was refused
+type subscriptionMessage struct {
+	Type        string `json:"type"`
+	ContainerID string `json:"containerId,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// ContainerSubscriptionHandler serves live change notifications over WebSocket or, for
+// requests accepting text/event-stream, Server-Sent Events. A WebSocket client sends the URI
+// of a container as a text message and then receives a JSON notification for each change to
+// that container's membership or metadata; one connection may subscribe to several
+// containers. An event stream follows the one container or resource named by its topic.
+type ContainerSubscriptionHandler struct {
+	subscriber     ContainerChangeSubscriber
+	readAuthorizer application.ContainerReadAuthorizer
+	writeTimeout   time.Duration
+	upgrader       websocket.Upgrader
+	logger         log.Logger
+}
+
+// NewContainerSubscriptionHandler creates a new subscription handler; a nil subscriber answers
+// every request with 404, as when live notifications are disabled
+func NewContainerSubscriptionHandler(subscriber ContainerChangeSubscriber, writeTimeout time.Duration, logger log.Logger) *ContainerSubscriptionHandler {
+	return &ContainerSubscriptionHandler{
+		subscriber:   subscriber,
+		writeTimeout: writeTimeout,
+		logger:       logger,
+	}
+}
+
+// SetReadAuthorizer sets the authorizer consulted before each subscription; without one,
+// any client may follow any topic
+func (h *ContainerSubscriptionHandler) SetReadAuthorizer(authorizer application.ContainerReadAuthorizer) {
+	h.readAuthorizer = authorizer
+}
+
+// Subscribe streams Server-Sent Events when the request accepts them, and otherwise upgrades
+// the request to a WebSocket connection, serving either until the client disconnects
+func (h *ContainerSubscriptionHandler) Subscribe(ctx khttp.Context) error {
+	if h.subscriber == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"error":   "NOTIFICATIONS_DISABLED",
+			"message": "live notifications are not enabled",
+		})
+	}
+
+	if acceptsEventStream(ctx.Request().Header.Get("Accept")) {
+		h.streamEvents(ctx.Response(), ctx.Request())
+		return nil
+	}
+	h.serve(ctx.Response(), ctx.Request())
+	return nil
+}
+
+// serve runs one subscription connection. Only this goroutine writes to the connection: the
+// reader and every subscription hand it their messages, and a subscription blocked behind a
+// slow writer fills its hub buffer until the hub drops it.
+func (h *ContainerSubscriptionHandler) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Log(log.LevelWarn, "msg", "Failed to upgrade subscription connection", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	out := make(chan interface{})
+	done := make(chan struct{})
+	slow := make(chan string, 1)
+
+	var mu sync.Mutex
+	subscriptions := make(map[string]*application.ContainerChangeSubscription)
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, subscription := range subscriptions {
+			subscription.Close()
+		}
+	}()
+
+	send := func(message interface{}) {
+		select {
+		case out <- message:
+		case <-done:
+		}
+	}
+
+	forward := func(subscription *application.ContainerChangeSubscription) {
+		for notification := range subscription.Notifications {
+			send(notification)
+		}
+		if subscription.Slow() {
+			select {
+			case slow <- subscription.TopicID:
+			default:
+			}
+		}
+	}
+
+	go func() {
+		defer close(done)
+		baseURL := requestBaseURL(r)
+		agentCtx := agentContext(r)
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType != websocket.TextMessage {
+				continue
+			}
+
+			topic := strings.TrimSpace(string(data))
+			containerID, ok := topicContainerID(baseURL, topic)
+			if !ok {
+				send(subscriptionMessage{Type: "error", Error: "INVALID_TOPIC", Message: "send the URI of a container on this server"})
+				continue
+			}
+
+			mu.Lock()
+			_, subscribed := subscriptions[containerID]
+			mu.Unlock()
+			if subscribed {
+				send(subscriptionMessage{Type: "subscribed", ContainerID: containerID})
+				continue
+			}
+
+			if h.readAuthorizer != nil && !h.readAuthorizer.CanReadContainer(agentCtx, containerID) {
+				send(subscriptionMessage{Type: "error", ContainerID: containerID, Error: "FORBIDDEN", Message: "subscribing requires Read access to the container"})
+				continue
+			}
+
+			subscription, err := h.subscriber.Subscribe(agentCtx, containerID)
+			if err != nil {
+				if domain.IsResourceNotFound(err) {
+					send(subscriptionMessage{Type: "error", ContainerID: containerID, Error: "TOPIC_NOT_FOUND", Message: "the container does not exist"})
+				} else {
+					h.logger.Log(log.LevelError, "msg", "Container subscription failed", "container", containerID, "error", err)
+					send(subscriptionMessage{Type: "error", ContainerID: containerID, Error: "SUBSCRIPTION_FAILED", Message: "failed to subscribe to the container"})
+				}
+				continue
+			}
+
+			mu.Lock()
+			subscriptions[containerID] = subscription
+			mu.Unlock()
+			send(subscriptionMessage{Type: "subscribed", ContainerID: containerID})
+			go forward(subscription)
+		}
+	}()
+
+	for {
+		select {
+		case message := <-out:
+			if h.writeTimeout > 0 {
+				conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+			}
+			if err := conn.WriteJSON(message); err != nil {
+				return
+			}
+		case containerID := <-slow:
+			h.logger.Log(log.LevelWarn, "msg", "Closing subscription connection that fell behind", "container", containerID)
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow to keep up with notifications"),
+				time.Now().Add(time.Second))
+			return
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// knownContainers answers container existence from a fixed set
+type knownContainers struct {
+	domain.ContainerRepository
+	ids map[string]bool
+}
+
+func (k *knownContainers) ContainerExists(ctx context.Context, id string) (bool, error) {
+	return k.ids[id], nil
+}
+
+// committedEnvelope wraps an event as the unit of work hands it to subscribers
+type committedEnvelope struct {
+	event pericarpdomain.Event
+}
+
+func (e *committedEnvelope) Event() pericarpdomain.Event      { return e.event }
+func (e *committedEnvelope) Metadata() map[string]interface{} { return map[string]interface{}{} }
+func (e *committedEnvelope) EventID() string                  { return "test-event-id" }
+func (e *committedEnvelope) Timestamp() time.Time             { return time.Now() }
+
+// dialSubscriptions starts a subscription server on the hub and connects a client to it
+func dialSubscriptions(t *testing.T, hub *application.ContainerChangeHub) (*websocket.Conn, string) {
+	handler := NewContainerSubscriptionHandler(hub, time.Second, log.DefaultLogger)
+	server := httptest.NewServer(http.HandlerFunc(handler.serve))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/subscribe", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, server.URL
+}
+
+// readMessage reads the next JSON message from the connection
+func readMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	var message map[string]interface{}
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	require.NoError(t, conn.ReadJSON(&message))
+	return message
+}
+
+func TestContainerSubscriptionHandler_DeliversContainerChanges(t *testing.T) {
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{"photos": true}}, 8)
+	conn, baseURL := dialSubscriptions(t, hub)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(baseURL+"/containers/photos")))
+	ack := readMessage(t, conn)
+	assert.Equal(t, "subscribed", ack["type"])
+	assert.Equal(t, "photos", ack["containerId"])
+
+	added := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": "beach.jpg"})
+	unrelated := domain.NewMemberAddedEvent("notes", map[string]interface{}{"memberID": "todo.txt"})
+	updated := domain.NewContainerUpdatedEvent("photos", map[string]interface{}{"title": "Holidays"})
+	for _, event := range []*domain.EntityEvent{added, unrelated, updated} {
+		require.NoError(t, hub.Handle(context.Background(), &committedEnvelope{event: event}))
+	}
+
+	notification := readMessage(t, conn)
+	assert.Equal(t, domain.EventTypeMemberAdded, notification["type"])
+	assert.Equal(t, "photos", notification["containerId"])
+	assert.Equal(t, "beach.jpg", notification["memberId"])
+	assert.NotEmpty(t, notification["timestamp"])
+
+	notification = readMessage(t, conn)
+	assert.Equal(t, domain.EventTypeContainerUpdated, notification["type"])
+}
+
+func TestContainerSubscriptionHandler_RejectsUnknownTopics(t *testing.T) {
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{}}, 8)
+	conn, baseURL := dialSubscriptions(t, hub)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("https://elsewhere.example/containers/photos")))
+	assert.Equal(t, "INVALID_TOPIC", readMessage(t, conn)["error"])
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(baseURL+"/containers/missing")))
+	assert.Equal(t, "TOPIC_NOT_FOUND", readMessage(t, conn)["error"])
+}
+
+func TestContainerSubscriptionHandler_RequiresRead(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{"photos": true}}, 8)
+	handler := NewContainerSubscriptionHandler(hub, time.Second, log.DefaultLogger)
+	handler.SetReadAuthorizer(agentReads(alice))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("as") == "alice" {
+			r = r.WithContext(middleware.WithIdentity(r.Context(), middleware.Identity{Subject: "alice", WebID: alice}))
+		}
+		handler.serve(w, r)
+	}))
+	t.Cleanup(server.Close)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/subscribe"
+
+	t.Run("should refuse agents without Read", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(server.URL+"/containers/photos")))
+		message := readMessage(t, conn)
+		assert.Equal(t, "FORBIDDEN", message["error"])
+		assert.Equal(t, "photos", message["containerId"])
+		assert.Equal(t, 0, hub.SubscriberCount("photos"))
+	})
+
+	t.Run("should subscribe the verified agent", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?as=alice", nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(server.URL+"/containers/photos")))
+		assert.Equal(t, "subscribed", readMessage(t, conn)["type"])
+	})
+}
+
+func TestContainerSubscriptionHandler_CleansUpOnDisconnect(t *testing.T) {
+	hub := application.NewContainerChangeHub(&knownContainers{ids: map[string]bool{"photos": true}}, 8)
+	conn, baseURL := dialSubscriptions(t, hub)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(baseURL+"/containers/photos")))
+	readMessage(t, conn)
+	require.Equal(t, 1, hub.SubscriberCount("photos"))
+
+	conn.Close()
+	assert.Eventually(t, func() bool {
+		return hub.SubscriberCount("photos") == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestContainerSubscriptionHandler_Disabled(t *testing.T) {
+	handler := NewContainerSubscriptionHandler(nil, time.Second, log.DefaultLogger)
+	ctx := createTestContext("GET", "/subscribe", nil, nil)
+
+	require.NoError(t, handler.Subscribe(ctx))
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+}
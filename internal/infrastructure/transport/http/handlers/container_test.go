@@ -2,15 +2,22 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"encoding/xml"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/go-kratos/kratos/v2/transport/http/binding"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,7 +26,7 @@ import (
 func createTestContainerHandler() (*ContainerHandler, *MockContainerService, *MockContainerStorageService) {
 	mockContainerService := &MockContainerService{}
 	mockStorageService := &MockContainerStorageService{}
-	logger := log.NewStdLogger(log.NewFilter(log.NewStdLogger(nil), log.LevelError))
+	logger := log.NewFilter(log.NewStdLogger(io.Discard), log.FilterLevel(log.LevelError))
 
 	handler := NewContainerHandler(mockContainerService, mockStorageService, logger)
 	return handler, mockContainerService, mockStorageService
@@ -51,6 +58,43 @@ type mockHTTPContext struct {
 	vars     map[string][]string
 }
 
+var _ khttp.Context = (*mockHTTPContext)(nil)
+
+func (m *mockHTTPContext) Deadline() (time.Time, bool) {
+	return m.request.Context().Deadline()
+}
+
+func (m *mockHTTPContext) Done() <-chan struct{} {
+	return m.request.Context().Done()
+}
+
+func (m *mockHTTPContext) Err() error {
+	return m.request.Context().Err()
+}
+
+func (m *mockHTTPContext) Value(key interface{}) interface{} {
+	return m.request.Context().Value(key)
+}
+
+func (m *mockHTTPContext) Vars() url.Values {
+	return url.Values(m.vars)
+}
+
+func (m *mockHTTPContext) Query() url.Values {
+	return m.request.URL.Query()
+}
+
+func (m *mockHTTPContext) Form() url.Values {
+	if err := m.request.ParseForm(); err != nil {
+		return url.Values{}
+	}
+	return m.request.Form
+}
+
+func (m *mockHTTPContext) Header() http.Header {
+	return m.request.Header
+}
+
 func (m *mockHTTPContext) Request() *http.Request {
 	return m.request
 }
@@ -59,8 +103,35 @@ func (m *mockHTTPContext) Response() http.ResponseWriter {
 	return m.response
 }
 
-func (m *mockHTTPContext) Vars() map[string][]string {
-	return m.vars
+func (m *mockHTTPContext) Middleware(h middleware.Handler) middleware.Handler {
+	return h
+}
+
+func (m *mockHTTPContext) Bind(v interface{}) error {
+	return json.NewDecoder(m.request.Body).Decode(v)
+}
+
+func (m *mockHTTPContext) BindVars(v interface{}) error {
+	return binding.BindQuery(m.Vars(), v)
+}
+
+func (m *mockHTTPContext) BindQuery(v interface{}) error {
+	return binding.BindQuery(m.Query(), v)
+}
+
+func (m *mockHTTPContext) BindForm(v interface{}) error {
+	return binding.BindForm(m.request, v)
+}
+
+func (m *mockHTTPContext) Returns(v interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	return m.JSON(http.StatusOK, v)
+}
+
+func (m *mockHTTPContext) Result(code int, v interface{}) error {
+	return m.JSON(code, v)
 }
 
 func (m *mockHTTPContext) JSON(code int, v interface{}) error {
@@ -69,6 +140,40 @@ func (m *mockHTTPContext) JSON(code int, v interface{}) error {
 	return json.NewEncoder(m.response).Encode(v)
 }
 
+func (m *mockHTTPContext) XML(code int, v interface{}) error {
+	m.response.Header().Set("Content-Type", "application/xml")
+	m.response.WriteHeader(code)
+	return xml.NewEncoder(m.response).Encode(v)
+}
+
+func (m *mockHTTPContext) String(code int, text string) error {
+	m.response.Header().Set("Content-Type", "text/plain")
+	m.response.WriteHeader(code)
+	_, err := m.response.Write([]byte(text))
+	return err
+}
+
+func (m *mockHTTPContext) Blob(code int, contentType string, data []byte) error {
+	m.response.Header().Set("Content-Type", contentType)
+	m.response.WriteHeader(code)
+	_, err := m.response.Write(data)
+	return err
+}
+
+func (m *mockHTTPContext) Stream(code int, contentType string, reader io.Reader) error {
+	m.response.Header().Set("Content-Type", contentType)
+	m.response.WriteHeader(code)
+	_, err := io.Copy(m.response, reader)
+	return err
+}
+
+func (m *mockHTTPContext) Reset(w http.ResponseWriter, r *http.Request) {
+	m.request = r
+	if recorder, ok := w.(*httptest.ResponseRecorder); ok {
+		m.response = recorder
+	}
+}
+
 // Test GET /containers/{id} - Container retrieval with member listing
 func TestContainerHandler_GetContainer(t *testing.T) {
 	tests := []struct {
@@ -82,9 +187,9 @@ func TestContainerHandler_GetContainer(t *testing.T) {
 			name:        "successful container retrieval",
 			containerID: "test-container-1",
 			setupMocks: func(cs *MockContainerService, ss *MockContainerStorageService) {
-				container := domain.NewContainer("test-container-1", "", domain.BasicContainer)
-				container.AddMember("resource-1")
-				container.AddMember("resource-2")
+				container := domain.NewContainer(context.Background(), "test-container-1", "", domain.BasicContainer)
+				container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+				container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 				cs.On("GetContainer", mock.Anything, "test-container-1").Return(container, nil)
 
@@ -157,13 +262,16 @@ func TestContainerHandler_PostResource(t *testing.T) {
 			setupMocks: func(cs *MockContainerService, ss *MockContainerStorageService) {
 				// Container exists
 				cs.On("ContainerExists", mock.Anything, "test-container-1").Return(true, nil)
+				cs.On("NormalizeContainerRDF", mock.Anything, "test-container-1", mock.AnythingOfType("[]uint8"), "application/ld+json").Return([]byte(`{"data": "test resource data"}`), "application/json", nil)
+				cs.On("InheritedMetadata", mock.Anything, "test-container-1").Return(nil, nil)
 
 				// Resource creation
-				resource := domain.NewResource("generated-id", "application/json", []byte(`{"data": "test resource data"}`))
-				ss.On("StoreResource", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8"), "application/json").Return(resource, nil)
+				resource := domain.NewResource(context.Background(), "generated-id", "application/json", []byte(`{"data": "test resource data"}`))
+				ss.On("StoreResourceWithMetadata", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8"), "application/json", mock.Anything).Return(resource, nil)
 
 				// Add resource to container
-				cs.On("AddResource", mock.Anything, "test-container-1", mock.AnythingOfType("string")).Return(nil)
+				cs.On("AddResource", mock.Anything, "test-container-1", mock.AnythingOfType("string"), mock.Anything).Return(nil)
+				cs.On("GetContainer", mock.Anything, "test-container-1").Return(domain.NewContainer(context.Background(), "test-container-1", "", domain.BasicContainer), nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody:   `"message":"Resource created in container successfully"`,
@@ -174,15 +282,18 @@ func TestContainerHandler_PostResource(t *testing.T) {
 			requestBody: []byte(`{"data": "test resource data"}`),
 			setupMocks: func(cs *MockContainerService, ss *MockContainerStorageService) {
 				cs.On("ContainerExists", mock.Anything, "nonexistent-container").Return(false, nil)
+				ss.On("ResourceExists", mock.Anything, "nonexistent-container").Return(false, nil)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `"code":"CONTAINER_NOT_FOUND"`,
 		},
 		{
-			name:           "empty request body",
-			containerID:    "test-container-1",
-			requestBody:    []byte{},
-			setupMocks:     func(cs *MockContainerService, ss *MockContainerStorageService) {},
+			name:        "empty request body",
+			containerID: "test-container-1",
+			requestBody: []byte{},
+			setupMocks: func(cs *MockContainerService, ss *MockContainerStorageService) {
+				cs.On("ContainerExists", mock.Anything, "test-container-1").Return(true, nil)
+			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `"code":"EMPTY_BODY"`,
 		},
@@ -199,6 +310,7 @@ func TestContainerHandler_PostResource(t *testing.T) {
 			}
 
 			ctx := createTestContext("POST", "/containers/"+tt.containerID, tt.requestBody, vars)
+			ctx.Request().Header.Set("Content-Type", "application/json")
 
 			err := handler.PostResource(ctx)
 
@@ -227,7 +339,7 @@ func TestContainerHandler_PutContainer(t *testing.T) {
 			containerID: "test-container-1",
 			requestBody: []byte(`{"title": "Updated Title", "description": "Updated Description"}`),
 			setupMocks: func(cs *MockContainerService, ss *MockContainerStorageService) {
-				container := domain.NewContainer("test-container-1", "", domain.BasicContainer)
+				container := domain.NewContainer(context.Background(), "test-container-1", "", domain.BasicContainer)
 				cs.On("GetContainer", mock.Anything, "test-container-1").Return(container, nil)
 				cs.On("UpdateContainer", mock.Anything, mock.AnythingOfType("*domain.Container")).Return(nil)
 			},
@@ -353,8 +465,8 @@ func TestContainerHandler_HeadContainer(t *testing.T) {
 			name:        "successful container head request",
 			containerID: "test-container-1",
 			setupMocks: func(cs *MockContainerService, ss *MockContainerStorageService) {
-				container := domain.NewContainer("test-container-1", "", domain.BasicContainer)
-				container.AddMember("resource-1")
+				container := domain.NewContainer(context.Background(), "test-container-1", "", domain.BasicContainer)
+				container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
 				cs.On("GetContainer", mock.Anything, "test-container-1").Return(container, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -416,7 +528,7 @@ func TestContainerHandler_OptionsContainer(t *testing.T) {
 			expectedBody:   `"methods"`,
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Methods": "GET, POST, PUT, DELETE, HEAD, OPTIONS",
-				"Access-Control-Allow-Headers": "Content-Type, Accept, Authorization",
+				"Access-Control-Allow-Headers": "Content-Type, Accept, Authorization, If-Match, If-None-Match",
 			},
 		},
 	}
@@ -450,7 +562,7 @@ func TestContainerHandler_LDPCompliance(t *testing.T) {
 	t.Run("container response includes LDP headers", func(t *testing.T) {
 		handler, mockContainerService, _ := createTestContainerHandler()
 
-		container := domain.NewContainer("test-container-1", "", domain.BasicContainer)
+		container := domain.NewContainer(context.Background(), "test-container-1", "", domain.BasicContainer)
 		mockContainerService.On("GetContainer", mock.Anything, "test-container-1").Return(container, nil)
 
 		listing := &application.ContainerListing{
@@ -479,10 +591,13 @@ func TestContainerHandler_LDPCompliance(t *testing.T) {
 		handler, mockContainerService, mockStorageService := createTestContainerHandler()
 
 		mockContainerService.On("ContainerExists", mock.Anything, "test-container-1").Return(true, nil)
+		mockContainerService.On("NormalizeContainerRDF", mock.Anything, "test-container-1", mock.AnythingOfType("[]uint8"), "application/ld+json").Return([]byte(`{"data": "test"}`), "application/json", nil)
+		mockContainerService.On("InheritedMetadata", mock.Anything, "test-container-1").Return(nil, nil)
 
-		resource := domain.NewResource("new-resource-id", "application/json", []byte(`{"data": "test"}`))
-		mockStorageService.On("StoreResource", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8"), "application/json").Return(resource, nil)
-		mockContainerService.On("AddResource", mock.Anything, "test-container-1", mock.AnythingOfType("string")).Return(nil)
+		resource := domain.NewResource(context.Background(), "new-resource-id", "application/json", []byte(`{"data": "test"}`))
+		mockStorageService.On("StoreResourceWithMetadata", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8"), "application/json", mock.Anything).Return(resource, nil)
+		mockContainerService.On("AddResource", mock.Anything, "test-container-1", mock.AnythingOfType("string"), mock.Anything).Return(nil)
+		mockContainerService.On("GetContainer", mock.Anything, "test-container-1").Return(domain.NewContainer(context.Background(), "test-container-1", "", domain.BasicContainer), nil)
 
 		vars := map[string][]string{"id": {"test-container-1"}}
 		ctx := createTestContext("POST", "/containers/test-container-1", []byte(`{"data": "test"}`), vars)
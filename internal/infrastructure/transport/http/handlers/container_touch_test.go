@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// touchContainerService mocks TouchContainer; other container service methods are not expected
+type touchContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *touchContainerService) TouchContainer(ctx context.Context, containerID string) (*domain.Container, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Container), args.Error(1)
+}
+
+func TestContainerHandler_TouchContainer(t *testing.T) {
+	t.Run("should return the bumped ETag", func(t *testing.T) {
+		mockService := new(touchContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+		container := domain.NewContainer(context.Background(), "docs", "", domain.BasicContainer)
+		untouchedETag := handler.generateContainerETag(container)
+		container.Touch(context.Background())
+		mockService.On("TouchContainer", mock.Anything, "docs").Return(container, nil)
+
+		ctx := createTestContext("POST", "/containers/docs/touch", nil, map[string][]string{"id": {"docs"}})
+		require.NoError(t, handler.TouchContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.NotEmpty(t, response.Header().Get("ETag"))
+		assert.NotEqual(t, `"`+untouchedETag+`"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("should touch as the verified agent", func(t *testing.T) {
+		const alice = "https://alice.example/profile#me"
+		mockService := new(touchContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+		asAlice := mock.MatchedBy(func(ctx context.Context) bool {
+			return domain.AgentFromContext(ctx) == alice
+		})
+		container := domain.NewContainer(context.Background(), "docs", "", domain.BasicContainer)
+		mockService.On("TouchContainer", asAlice, "docs").Return(container, nil)
+
+		ctx := createTestContext("POST", "/containers/docs/touch", nil, map[string][]string{"id": {"docs"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.TouchContainer(ctx))
+
+		assert.Equal(t, http.StatusNoContent, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should refuse callers without write permission", func(t *testing.T) {
+		mockService := new(touchContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+		mockService.On("TouchContainer", mock.Anything, "docs").Return(nil, domain.ErrAccessDenied.WithOperation("TouchContainer"))
+
+		ctx := createTestContext("POST", "/containers/docs/touch", nil, map[string][]string{"id": {"docs"}})
+		require.NoError(t, handler.TouchContainer(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -35,11 +35,11 @@ func TestContainerHandler_BuildContainerResponse(t *testing.T) {
 	handler := NewContainerHandler(mockContainerService, mockStorageService, logger)
 
 	// Create test container
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Create test listing
 	listing := &application.ContainerListing{
@@ -172,14 +172,20 @@ func TestContainerHandler_GenerateContainerETag(t *testing.T) {
 	logger := log.NewStdLogger(io.Discard)
 	handler := NewContainerHandler(mockContainerService, mockStorageService, logger)
 
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	etag := handler.generateContainerETag(container)
-	expected := "test-container-2" // ID + member count
+	assert.Len(t, etag, 32)
+	assert.Equal(t, etag, handler.generateContainerETag(container), "the ETag should be stable while the container is unchanged")
 
-	assert.Equal(t, expected, etag)
+	container.Members = append(container.Members, "resource-3")
+	withMember := handler.generateContainerETag(container)
+	assert.NotEqual(t, etag, withMember, "adding a member should change the ETag")
+
+	container.SetTitle("Renamed")
+	assert.NotEqual(t, withMember, handler.generateContainerETag(container), "changing metadata should change the ETag")
 }
 
 // Test pagination parsing
@@ -290,8 +296,8 @@ func TestContainerHandler_ServiceIntegration(t *testing.T) {
 		handler := NewContainerHandler(mockContainerService, mockStorageService, logger)
 
 		// Setup expectations
-		container := domain.NewContainer("test-container", "", domain.BasicContainer)
-		container.AddMember("resource-1")
+		container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
+		container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
 
 		mockContainerService.On("GetContainer", mock.Anything, "test-container").Return(container, nil)
 
@@ -324,10 +330,10 @@ func TestContainerHandler_ServiceIntegration(t *testing.T) {
 		// Setup expectations
 		mockContainerService.On("ContainerExists", mock.Anything, "test-container").Return(true, nil)
 
-		resource := domain.NewResource("new-resource", "application/json", []byte(`{"test": "data"}`))
+		resource := domain.NewResource(context.Background(), "new-resource", "application/json", []byte(`{"test": "data"}`))
 		mockStorageService.On("StoreResource", mock.Anything, "new-resource", mock.AnythingOfType("[]uint8"), "application/json").Return(resource, nil)
 
-		mockContainerService.On("AddResource", mock.Anything, "test-container", "new-resource").Return(nil)
+		mockContainerService.On("AddResource", mock.Anything, "test-container", "new-resource", resource).Return(nil)
 
 		// Test the service calls directly
 		exists, err := handler.containerService.ContainerExists(context.Background(), "test-container")
@@ -338,7 +344,7 @@ func TestContainerHandler_ServiceIntegration(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "new-resource", storedResource.ID())
 
-		err = handler.containerService.AddResource(context.Background(), "test-container", "new-resource")
+		err = handler.containerService.AddResource(context.Background(), "test-container", "new-resource", storedResource)
 		assert.NoError(t, err)
 
 		mockContainerService.AssertExpectations(t)
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SetContentTransformer sets the service that rewrites content written into containers, such as
+// stripping EXIF from JPEGs. Without one, content is stored as posted.
+func (h *ContainerHandler) SetContentTransformer(transformer ContainerContentTransformer) {
+	h.contentTransform = transformer
+}
+
+// transformContent runs the write-time transformers over a validated body before it is stored
+func (h *ContainerHandler) transformContent(ctx khttp.Context, containerID string, body []byte, contentType string) ([]byte, error) {
+	if h.contentTransform == nil {
+		return body, nil
+	}
+	return h.contentTransform.TransformContainerContent(ctx.Request().Context(), containerID, body, contentType)
+}
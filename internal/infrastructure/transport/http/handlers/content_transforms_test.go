@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upperCaseTransformer uppercases content, or fails when err is set
+type upperCaseTransformer struct {
+	err error
+}
+
+func (u upperCaseTransformer) SetContainerContentTransforms(ctx context.Context, containerID string, enabled bool) error {
+	return nil
+}
+
+func (u upperCaseTransformer) TransformContainerContent(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, error) {
+	if u.err != nil {
+		return nil, domain.WrapStorageError(u.err, domain.ErrContentTransformFailed.Code, "content transformer failed")
+	}
+	return []byte(strings.ToUpper(string(data))), nil
+}
+
+// recordingCreator keeps the content of each resource it creates
+type recordingCreator struct {
+	stored [][]byte
+}
+
+func (c *recordingCreator) CreateNamedResource(ctx context.Context, name string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	c.stored = append(c.stored, data)
+	return domain.NewResource(ctx, name, contentType, data), nil
+}
+
+func TestContainerHandler_PostResource_ContentTransforms(t *testing.T) {
+	newPost := func() *mockHTTPContext {
+		ctx := createTestContext("POST", "/containers/notes", []byte("hello"), map[string][]string{"id": {"notes"}})
+		ctx.Request().Header.Set("Content-Type", "text/plain")
+		ctx.Request().Header.Set("Slug", "greeting")
+		return ctx.(*mockHTTPContext)
+	}
+
+	t.Run("should store the transformed content", func(t *testing.T) {
+		creator := &recordingCreator{}
+		handler := NewContainerHandler(&postTargetContainerService{}, nil, log.DefaultLogger)
+		handler.SetNamedResourceCreator(creator)
+		handler.SetContentTransformer(upperCaseTransformer{})
+
+		ctx := newPost()
+		require.NoError(t, handler.PostResource(ctx))
+		assert.Equal(t, http.StatusCreated, ctx.response.Code)
+		require.Len(t, creator.stored, 1)
+		assert.Equal(t, "HELLO", string(creator.stored[0]))
+	})
+
+	t.Run("should answer 422 and store nothing when a transformer fails", func(t *testing.T) {
+		creator := &recordingCreator{}
+		handler := NewContainerHandler(&postTargetContainerService{}, nil, log.DefaultLogger)
+		handler.SetNamedResourceCreator(creator)
+		handler.SetContentTransformer(upperCaseTransformer{err: errors.New("corrupt image")})
+
+		ctx := newPost()
+		require.NoError(t, handler.PostResource(ctx))
+		assert.Equal(t, http.StatusUnprocessableEntity, ctx.response.Code)
+		assert.Contains(t, ctx.response.Body.String(), "CONTENT_TRANSFORM_FAILED")
+		assert.Empty(t, creator.stored)
+	})
+}
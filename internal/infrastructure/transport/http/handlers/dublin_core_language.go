@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// languageTaggedFields are the metadata fields holding language-tagged Dublin Core literals,
+// with the properties they are served as
+var languageTaggedFields = []struct {
+	field    string
+	property string
+}{
+	{"title", "dcterms:title"},
+	{"description", "dcterms:description"},
+}
+
+// localizeDublinCore selects the language-tagged Dublin Core literals in metadata that best
+// match the Accept-Language header into response and sets Content-Language to their
+// languages. A representation with tagged literals varies with Accept-Language whether or not
+// the header matched any of them.
+func localizeDublinCore(header http.Header, response map[string]interface{}, metadata map[string]interface{}, acceptLanguage string) {
+	if !hasLanguageTaggedLiterals(metadata) {
+		return
+	}
+	header.Add("Vary", "Accept-Language")
+
+	if contentLanguage := applyLanguagePreferences(response, metadata, acceptLanguage); contentLanguage != "" {
+		header.Set("Content-Language", contentLanguage)
+	}
+}
+
+// hasLanguageTaggedLiterals reports whether metadata holds any language-tagged Dublin Core literal
+func hasLanguageTaggedLiterals(metadata map[string]interface{}) bool {
+	for _, tagged := range languageTaggedFields {
+		if len(domain.LocalizedLiterals(metadata, tagged.field)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// applyLanguagePreferences replaces dcterms:title/dcterms:description with the language-tagged
+// literals that best match the Accept-Language header, or with all tagged literals when the
+// client asks for "*". It returns the value for the Content-Language header.
+func applyLanguagePreferences(response map[string]interface{}, metadata map[string]interface{}, acceptLanguage string) string {
+	prefs := domain.ParseAcceptLanguage(acceptLanguage)
+	if len(prefs) == 0 {
+		return ""
+	}
+
+	var languages []string
+	addLanguage := func(language string) {
+		for _, existing := range languages {
+			if existing == language {
+				return
+			}
+		}
+		languages = append(languages, language)
+	}
+
+	for _, tagged := range languageTaggedFields {
+		literals := domain.LocalizedLiterals(metadata, tagged.field)
+		if len(literals) == 0 {
+			continue
+		}
+
+		if domain.WantsAllLanguages(prefs) {
+			values := make([]map[string]interface{}, 0, len(literals))
+			for _, literal := range literals {
+				values = append(values, map[string]interface{}{"@value": literal.Value, "@language": literal.Language})
+				addLanguage(literal.Language)
+			}
+			response[tagged.property] = values
+			continue
+		}
+
+		if literal, ok := domain.SelectLangLiteral(literals, prefs); ok {
+			response[tagged.property] = map[string]interface{}{"@value": literal.Value, "@language": literal.Language}
+			addLanguage(literal.Language)
+		}
+	}
+
+	return strings.Join(languages, ", ")
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerHandler_GetContainer_AcceptLanguage(t *testing.T) {
+	get := func(t *testing.T, container *domain.Container, acceptLanguage string) (*mockHTTPContext, map[string]interface{}) {
+		mockService := new(MockContainerService)
+		handler := NewContainerHandler(mockService, nil, log.NewStdLogger(io.Discard))
+		mockService.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+		mockService.On("ListContainerMembers", mock.Anything, "photos", mock.AnythingOfType("domain.PaginationOptions")).Return(&application.ContainerListing{}, nil)
+
+		ctx := createTestContext("GET", "/containers/photos", nil, map[string][]string{"id": {"photos"}})
+		ctx.Request().Header.Set("Accept", "application/json")
+		if acceptLanguage != "" {
+			ctx.Request().Header.Set("Accept-Language", acceptLanguage)
+		}
+		require.NoError(t, handler.GetContainer(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		require.Equal(t, http.StatusOK, response.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		return ctx.(*mockHTTPContext), body
+	}
+	localized := func() *domain.Container {
+		container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+		container.SetLocalizedTitle("en", "Photos")
+		container.SetLocalizedTitle("fr", "Photos de vacances")
+		return container
+	}
+
+	t.Run("selects the preferred language", func(t *testing.T) {
+		ctx, body := get(t, localized(), "fr")
+
+		assert.Equal(t, "fr", ctx.response.Header().Get("Content-Language"))
+		assert.Contains(t, ctx.response.Header().Values("Vary"), "Accept-Language")
+		assert.Equal(t, map[string]interface{}{"@value": "Photos de vacances", "@language": "fr"}, body["dcterms:title"])
+	})
+
+	t.Run("varies with Accept-Language when none matches", func(t *testing.T) {
+		ctx, _ := get(t, localized(), "de")
+
+		assert.Empty(t, ctx.response.Header().Get("Content-Language"))
+		assert.Contains(t, ctx.response.Header().Values("Vary"), "Accept-Language")
+	})
+
+	t.Run("varies with Accept-Language when it is not sent", func(t *testing.T) {
+		ctx, _ := get(t, localized(), "")
+
+		assert.Contains(t, ctx.response.Header().Values("Vary"), "Accept-Language")
+	})
+
+	t.Run("does not vary without language-tagged literals", func(t *testing.T) {
+		ctx, _ := get(t, domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer), "fr")
+
+		assert.NotContains(t, ctx.response.Header().Values("Vary"), "Accept-Language")
+	})
+}
+
+func TestResourceHandler_GetResourceMetadata_AcceptLanguage(t *testing.T) {
+	mockService := new(MockStorageService)
+	handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+
+	photo := domain.NewResource(context.Background(), "beach.jpg", "image/jpeg", []byte{0xff, 0xd8})
+	photo.SetMetadata("title@en", "Beach")
+	photo.SetMetadata("title@fr", "Plage")
+	mockService.On("RetrieveResource", mock.Anything, "beach.jpg", "").Return(photo, nil)
+
+	ctx := createTestContext("GET", "/resources/beach.jpg/meta", nil, map[string][]string{"id": {"beach.jpg"}})
+	ctx.Request().Header.Set("Accept-Language", "fr-CA, en;q=0.5")
+	require.NoError(t, handler.GetResourceMetadata(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "fr", response.Header().Get("Content-Language"))
+	assert.Contains(t, response.Header().Values("Vary"), "Accept-Language")
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"@value": "Plage", "@language": "fr"}, body["dcterms:title"])
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Vocabulary of RDF problem descriptions: a hydra:Error carrying the HTTP status, its reason
+// phrase and the error message, with the error code as its dcterms:identifier
+const (
+	problemHydraNamespace   = "http://www.w3.org/ns/hydra/core#"
+	problemDCTermsNamespace = "http://purl.org/dc/terms/"
+)
+
+// Error body representations chosen from the Accept header
+const (
+	errorFormatJSON   = "json"
+	errorFormatTurtle = "turtle"
+	errorFormatJSONLD = "jsonld"
+)
+
+// turtleStringEscaper escapes the characters a Turtle string literal cannot hold as is
+var turtleStringEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// negotiateErrorFormat picks the error body representation the client prefers. JSON is the
+// default; an RDF problem description is only sent when the client ranks Turtle or JSON-LD
+// above JSON and wildcards.
+func negotiateErrorFormat(acceptHeader string) string {
+	for _, accepted := range parseAcceptTypes(acceptHeader) {
+		if accepted.quality <= 0 {
+			continue
+		}
+		switch strings.ToLower(baseMediaType(accepted.mediaType)) {
+		case "text/turtle":
+			return errorFormatTurtle
+		case "application/ld+json":
+			return errorFormatJSONLD
+		case "application/json", "application/*", "*/*":
+			return errorFormatJSON
+		}
+	}
+	return errorFormatJSON
+}
+
+// writeNegotiatedError writes an error response in the representation the client prefers.
+// The status and error code are the same in every representation; JSON clients receive the
+// error object as is, RDF clients a problem description built from its code and message.
+func writeNegotiatedError(ctx khttp.Context, status int, errorResponse map[string]interface{}) error {
+	code, _ := errorResponse["code"].(string)
+	message, _ := errorResponse["message"].(string)
+	ctx.Response().Header().Add("Vary", "Accept")
+
+	switch negotiateErrorFormat(ctx.Request().Header.Get("Accept")) {
+	case errorFormatTurtle:
+		ctx.Response().Header().Set("Content-Type", "text/turtle")
+		ctx.Response().WriteHeader(status)
+		_, err := ctx.Response().Write([]byte(problemTurtle(status, code, message)))
+		return err
+	case errorFormatJSONLD:
+		ctx.Response().Header().Set("Content-Type", "application/ld+json")
+		ctx.Response().WriteHeader(status)
+		return json.NewEncoder(ctx.Response()).Encode(problemJSONLD(status, code, message))
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	return ctx.JSON(status, map[string]interface{}{
+		"error": errorResponse,
+	})
+}
+
+// problemTurtle renders a problem description as Turtle
+func problemTurtle(status int, code, message string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@prefix hydra: <%s> .\n", problemHydraNamespace)
+	fmt.Fprintf(&b, "@prefix dcterms: <%s> .\n\n", problemDCTermsNamespace)
+	b.WriteString("[] a hydra:Error ;\n")
+	fmt.Fprintf(&b, "    hydra:statusCode %d ;\n", status)
+	fmt.Fprintf(&b, "    hydra:title \"%s\" ;\n", turtleStringEscaper.Replace(http.StatusText(status)))
+	fmt.Fprintf(&b, "    dcterms:identifier \"%s\" ;\n", turtleStringEscaper.Replace(code))
+	fmt.Fprintf(&b, "    hydra:description \"%s\" .\n", turtleStringEscaper.Replace(message))
+	return b.String()
+}
+
+// problemJSONLD builds a problem description as JSON-LD
+func problemJSONLD(status int, code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"@context": map[string]interface{}{
+			"hydra":   problemHydraNamespace,
+			"dcterms": problemDCTermsNamespace,
+		},
+		"@type":              "hydra:Error",
+		"hydra:statusCode":   status,
+		"hydra:title":        http.StatusText(status),
+		"dcterms:identifier": code,
+		"hydra:description":  message,
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateErrorFormat(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{"", errorFormatJSON},
+		{"*/*", errorFormatJSON},
+		{"application/json", errorFormatJSON},
+		{"text/turtle", errorFormatTurtle},
+		{"application/ld+json", errorFormatJSONLD},
+		{"application/json;q=0.5, text/turtle", errorFormatTurtle},
+		{"text/turtle;q=0.5, application/json", errorFormatJSON},
+		{"text/html, application/ld+json;q=0.9", errorFormatJSONLD},
+		{"text/turtle;q=0, */*", errorFormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateErrorFormat(tt.accept))
+		})
+	}
+}
+
+func TestWriteNegotiatedError(t *testing.T) {
+	errorResponse := map[string]interface{}{
+		"code":    "RESOURCE_NOT_FOUND",
+		"message": `The requested "resource" could not be found`,
+		"status":  http.StatusNotFound,
+	}
+
+	t.Run("JSON by default", func(t *testing.T) {
+		ctx := createTestContext("GET", "/resources/missing", nil, nil)
+		require.NoError(t, writeNegotiatedError(ctx, http.StatusNotFound, errorResponse))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+		assert.Contains(t, response.Body.String(), `"code":"RESOURCE_NOT_FOUND"`)
+	})
+
+	t.Run("Turtle problem description", func(t *testing.T) {
+		ctx := createTestContext("GET", "/resources/missing", nil, nil)
+		ctx.Request().Header.Set("Accept", "text/turtle")
+		require.NoError(t, writeNegotiatedError(ctx, http.StatusNotFound, errorResponse))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Equal(t, "text/turtle", response.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", response.Header().Get("Vary"))
+		body := response.Body.String()
+		assert.Contains(t, body, "[] a hydra:Error ;")
+		assert.Contains(t, body, "hydra:statusCode 404 ;")
+		assert.Contains(t, body, `dcterms:identifier "RESOURCE_NOT_FOUND" ;`)
+		assert.Contains(t, body, `hydra:description "The requested \"resource\" could not be found" .`)
+	})
+
+	t.Run("JSON-LD problem description", func(t *testing.T) {
+		ctx := createTestContext("GET", "/resources/missing", nil, nil)
+		ctx.Request().Header.Set("Accept", "application/ld+json")
+		require.NoError(t, writeNegotiatedError(ctx, http.StatusNotFound, errorResponse))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Equal(t, "application/ld+json", response.Header().Get("Content-Type"))
+
+		var problem map[string]interface{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &problem))
+		assert.Equal(t, "hydra:Error", problem["@type"])
+		assert.Equal(t, float64(http.StatusNotFound), problem["hydra:statusCode"])
+		assert.Equal(t, "Not Found", problem["hydra:title"])
+		assert.Equal(t, "RESOURCE_NOT_FOUND", problem["dcterms:identifier"])
+	})
+}
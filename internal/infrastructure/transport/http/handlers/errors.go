@@ -35,6 +35,13 @@ func (h *ErrorHandler) HandleError(ctx khttp.Context, err error) error {
 
 	// Check if it's a Kratos error
 	if kratosErr := errors.FromError(err); kratosErr != nil {
+		// RDF clients get a problem description with the same status and code
+		if negotiateErrorFormat(ctx.Request().Header.Get("Accept")) != errorFormatJSON {
+			return writeNegotiatedError(ctx, int(kratosErr.Code), map[string]interface{}{
+				"code":    kratosErr.Reason,
+				"message": kratosErr.Message,
+			})
+		}
 		return ctx.JSON(int(kratosErr.Code), map[string]interface{}{
 			"error":   kratosErr.Reason,
 			"message": kratosErr.Message,
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// ETagPolicy builds the ETags of content-negotiated representations and answers conditional
+// GETs against them. A resource served as Turtle and as JSON-LD is two representations, so by
+// default each format gets its own strong ETag; the weak scheme instead shares one ETag across
+// formats, keyed on the stored version.
+type ETagPolicy struct {
+	weak bool
+}
+
+// defaultETagPolicy is used by handlers that were not given a policy
+var defaultETagPolicy = NewETagPolicy(conf.ETagsStrong)
+
+// NewETagPolicy creates an ETag policy for one of the conf.ETags schemes; unknown schemes get
+// the strong default
+func NewETagPolicy(scheme string) *ETagPolicy {
+	return &ETagPolicy{weak: scheme == conf.ETagsWeak}
+}
+
+// Tag returns the ETag header value of the representation of version in format
+func (p *ETagPolicy) Tag(version, format string) string {
+	if p.weak {
+		return fmt.Sprintf(`W/"%s"`, version)
+	}
+	if token := formatToken(format); token != "" {
+		return fmt.Sprintf(`"%s-%s"`, version, token)
+	}
+	return fmt.Sprintf(`"%s"`, version)
+}
+
+// apply sets the ETag of a negotiated representation on the response and reports whether the
+// request's If-None-Match already names it, in which case 304 Not Modified has been written
+func (p *ETagPolicy) apply(ctx khttp.Context, etag string) bool {
+	header := ctx.Response().Header()
+	header.Set("ETag", etag)
+	header.Add("Vary", "Accept")
+
+	if !ifNoneMatchMatches(ctx.Request().Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	ctx.Response().WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// ifNoneMatchMatches reports whether an If-None-Match header names etag. If-None-Match uses
+// the weak comparison, so W/ prefixes are ignored; strong ETags still never match across
+// formats because the format is part of the tag.
+func ifNoneMatchMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// formatToken turns a media type into characters allowed in an ETag, dropping parameters
+func formatToken(format string) string {
+	return strings.NewReplacer("/", "-", "+", "-").Replace(baseMediaType(format))
+}
+
+// SetETagPolicy sets the policy building the ETags of negotiated representations
+func (h *ResourceHandler) SetETagPolicy(policy *ETagPolicy) {
+	h.etagPolicy = policy
+}
+
+// etags returns the handler's ETag policy, falling back to the default policy
+func (h *ResourceHandler) etags() *ETagPolicy {
+	if h.etagPolicy == nil {
+		return defaultETagPolicy
+	}
+	return h.etagPolicy
+}
+
+// SetETagPolicy sets the policy building the ETags of negotiated representations
+func (h *ContainerHandler) SetETagPolicy(policy *ETagPolicy) {
+	h.etagPolicy = policy
+}
+
+// etags returns the handler's ETag policy, falling back to the default policy
+func (h *ContainerHandler) etags() *ETagPolicy {
+	if h.etagPolicy == nil {
+		return defaultETagPolicy
+	}
+	return h.etagPolicy
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETagPolicy_Tag(t *testing.T) {
+	strong := NewETagPolicy(conf.ETagsStrong)
+	weak := NewETagPolicy(conf.ETagsWeak)
+
+	assert.Equal(t, `"doc-1-text-turtle"`, strong.Tag("doc-1", "text/turtle; charset=utf-8"))
+	assert.Equal(t, `"doc-1-application-ld-json"`, strong.Tag("doc-1", "application/ld+json"))
+	assert.Equal(t, `"doc-1"`, strong.Tag("doc-1", ""))
+	assert.Equal(t, `W/"doc-1"`, weak.Tag("doc-1", "text/turtle"))
+	assert.Equal(t, weak.Tag("doc-1", "text/turtle"), weak.Tag("doc-1", "application/ld+json"))
+}
+
+func TestIfNoneMatchMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		want   bool
+	}{
+		{"no header", "", `"a"`, false},
+		{"same tag", `"a"`, `"a"`, true},
+		{"listed tag", `"b", "a"`, `"a"`, true},
+		{"other tag", `"b"`, `"a"`, false},
+		{"any", "*", `"a"`, true},
+		{"weak header", `W/"a"`, `"a"`, true},
+		{"weak etag", `"a"`, `W/"a"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ifNoneMatchMatches(tt.header, tt.etag))
+		})
+	}
+}
+
+func TestResourceHandler_GetResource_ConditionalPerFormat(t *testing.T) {
+	newHandler := func(scheme string) (*ResourceHandler, *MockStorageService) {
+		mockService := new(MockStorageService)
+		handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+		handler.SetETagPolicy(NewETagPolicy(scheme))
+
+		turtle := domain.NewResource(context.Background(), "doc", "text/turtle", []byte("<a> <b> <c> ."))
+		turtle.SetMetadata("checksum", "0123456789abcdef0123")
+		jsonLD := domain.NewResource(context.Background(), "doc", "application/ld+json", []byte(`{"@id":"a"}`))
+		jsonLD.SetMetadata("checksum", "0123456789abcdef0123")
+		mockService.On("RetrieveResource", mock.Anything, "doc", "text/turtle").Return(turtle, nil)
+		mockService.On("RetrieveResource", mock.Anything, "doc", "application/ld+json").Return(jsonLD, nil)
+		return handler, mockService
+	}
+
+	get := func(handler *ResourceHandler, accept, ifNoneMatch string) *mockHTTPContext {
+		ctx := createTestContext("GET", "/resources/doc", nil, map[string][]string{"id": {"doc"}})
+		ctx.Request().Header.Set("Accept", accept)
+		// A known small length keeps unconditional GETs off the streaming path
+		ctx.Request().Header.Set("Content-Length", "0")
+		if ifNoneMatch != "" {
+			ctx.Request().Header.Set("If-None-Match", ifNoneMatch)
+		}
+		require.NoError(t, handler.GetResource(ctx))
+		return ctx.(*mockHTTPContext)
+	}
+
+	t.Run("strong ETags only match the negotiated format", func(t *testing.T) {
+		handler, _ := newHandler(conf.ETagsStrong)
+
+		turtleETag := get(handler, "text/turtle", "").response.Header().Get("ETag")
+		jsonLDETag := get(handler, "application/ld+json", "").response.Header().Get("ETag")
+		assert.Equal(t, `"doc-0123456789abcdef-text-turtle"`, turtleETag)
+		assert.NotEqual(t, turtleETag, jsonLDETag)
+
+		notModified := get(handler, "text/turtle", turtleETag).response
+		assert.Equal(t, http.StatusNotModified, notModified.Code)
+		assert.Empty(t, notModified.Body.Bytes())
+		assert.Equal(t, turtleETag, notModified.Header().Get("ETag"))
+		assert.Equal(t, "Accept", notModified.Header().Get("Vary"))
+
+		otherFormat := get(handler, "application/ld+json", turtleETag).response
+		assert.Equal(t, http.StatusOK, otherFormat.Code)
+		assert.Equal(t, jsonLDETag, otherFormat.Header().Get("ETag"))
+	})
+
+	t.Run("weak ETags are shared across formats", func(t *testing.T) {
+		handler, _ := newHandler(conf.ETagsWeak)
+
+		turtleETag := get(handler, "text/turtle", "").response.Header().Get("ETag")
+		assert.Equal(t, `W/"doc-0123456789abcdef"`, turtleETag)
+		assert.Equal(t, http.StatusNotModified, get(handler, "application/ld+json", turtleETag).response.Code)
+		assert.Equal(t, http.StatusOK, get(handler, "application/ld+json", `W/"doc-stale"`).response.Code)
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// requestAgent returns who a request is made as for access control: the WebID, or else the
+// subject, of the access token verified for it, or "" when it is unauthenticated. Identity is
+// only ever taken from a verified token, never from headers the client sets.
+func requestAgent(r *http.Request) string {
+	identity, ok := middleware.IdentityFromContext(r.Context())
+	if !ok {
+		return ""
+	}
+	return identity.Agent()
+}
+
+// requestUserID returns the subject of the access token verified for a request, the user it
+// was issued to, or "" when the request is unauthenticated
+func requestUserID(r *http.Request) string {
+	identity, _ := middleware.IdentityFromContext(r.Context())
+	return identity.Subject
+}
+
+// agentContext returns a request's context naming its verified agent, for access checks made
+// by the application services
+func agentContext(r *http.Request) context.Context {
+	return domain.WithAgent(r.Context(), requestAgent(r))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
@@ -29,7 +31,7 @@ func TestResourceHandlerBusinessLogicIntegration(t *testing.T) {
 	converter := infrastructure.NewRDFConverter()
 
 	// Create database and event infrastructure
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	require.NoError(t, err)
 
 	eventStore, err := infrastructure.EventStoreProvider(db)
@@ -145,9 +147,9 @@ func TestResourceHandlerBusinessLogicIntegration(t *testing.T) {
 
 	t.Run("ETag Generation", func(t *testing.T) {
 		// Create test resources
-		resource1 := domain.NewResource("test-1", "application/ld+json", []byte(`{"test": "data1"}`))
-		resource2 := domain.NewResource("test-2", "application/ld+json", []byte(`{"test": "data2"}`))
-		resource3 := domain.NewResource("test-1", "application/ld+json", []byte(`{"test": "data1"}`)) // Same as resource1
+		resource1 := domain.NewResource(context.Background(), "test-1", "application/ld+json", []byte(`{"test": "data1"}`))
+		resource2 := domain.NewResource(context.Background(), "test-2", "application/ld+json", []byte(`{"test": "data2"}`))
+		resource3 := domain.NewResource(context.Background(), "test-1", "application/ld+json", []byte(`{"test": "data1"}`)) // Same as resource1
 
 		// Generate ETags
 		etag1 := handler.generateETag(resource1)
@@ -197,7 +199,7 @@ func TestResourceHandlerBusinessLogicIntegration(t *testing.T) {
 
 		for contentType, data := range formats {
 			t.Run("Format_"+contentType, func(t *testing.T) {
-				resourceID := "format-test-" + contentType
+				resourceID := "format-test-" + strings.ReplaceAll(contentType, "/", "-")
 
 				// Store resource
 				resource, err := storageService.StoreResource(ctx, resourceID, data, contentType)
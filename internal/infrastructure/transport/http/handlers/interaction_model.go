@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// setInteractionModelLinks advertises the LDP interaction model of a resource. Non-RDF
+// sources also point at the metadata resource that describes them.
+func setInteractionModelLinks(header http.Header, id string, model domain.InteractionModel) {
+	header.Add("Link", fmt.Sprintf(`<%sResource>; rel="type"`, domain.LDPNamespace))
+	header.Add("Link", fmt.Sprintf(`<%s>; rel="type"`, model.URI()))
+	if model == domain.NonRDFSourceModel {
+		header.Add("Link", fmt.Sprintf(`<%s>; rel="describedby"`, resourceMetadataPath(id)))
+	}
+}
+
+// resourceMetadataPath returns the path of the metadata resource describing a resource
+func resourceMetadataPath(id string) string {
+	return fmt.Sprintf("/resources/%s/meta", id)
+}
+
+// GetResourceMetadata handles GET requests for the metadata resource describing a resource
+func (h *ResourceHandler) GetResourceMetadata(ctx khttp.Context) error {
+	// Extract resource ID from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+
+	if id == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Resource ID is required")
+	}
+
+	// Retrieve the stored representation; the metadata describes it, not a converted copy
+	resource, err := h.storageService.RetrieveResource(context.Background(), id, "")
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+
+	model := domain.ResourceInteractionModel(resource)
+	ctx.Response().Header().Set("Link", fmt.Sprintf(`</resources/%s>; rel="describes"`, id))
+
+	response := map[string]interface{}{
+		"id":               id,
+		"contentType":      resource.GetContentType(),
+		"size":             resource.GetSize(),
+		"interactionModel": model.URI(),
+		"metadata":         resource.GetMetadata(),
+	}
+
+	// Select language-tagged Dublin Core literals based on Accept-Language, as for containers
+	localizeDublinCore(ctx.Response().Header(), response, resource.GetMetadata(), ctx.Request().Header.Get("Accept-Language"))
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResourceHandler_HeadResource_InteractionModelLinks(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		expectedType   string
+		hasDescribedBy bool
+	}{
+		{"binary resource", "image/png", `<http://www.w3.org/ns/ldp#NonRDFSource>; rel="type"`, true},
+		{"rdf resource", "text/turtle", `<http://www.w3.org/ns/ldp#RDFSource>; rel="type"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storageService := &MockStorageService{}
+			handler := NewResourceHandler(storageService, log.DefaultLogger)
+
+			resource := domain.NewResource(context.Background(), "doc", tt.contentType, []byte("data"))
+			storageService.On("RetrieveResource", mock.Anything, "doc", mock.Anything).Return(resource, nil)
+
+			ctx := createTestContext("HEAD", "/resources/doc", nil, map[string][]string{"id": {"doc"}})
+			assert.NoError(t, handler.HeadResource(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			links := response.Header().Values("Link")
+			assert.Contains(t, links, `<http://www.w3.org/ns/ldp#Resource>; rel="type"`)
+			assert.Contains(t, links, tt.expectedType)
+			if tt.hasDescribedBy {
+				assert.Contains(t, links, `</resources/doc/meta>; rel="describedby"`)
+			} else {
+				assert.Len(t, links, 2)
+			}
+		})
+	}
+}
+
+func TestResourceHandler_OptionsResource_InteractionModelLinks(t *testing.T) {
+	storageService := &MockStorageService{}
+	handler := NewResourceHandler(storageService, log.DefaultLogger)
+
+	resource := domain.NewResource(context.Background(), "photo", "image/jpeg", []byte("data"))
+	storageService.On("RetrieveResource", mock.Anything, "photo", "").Return(resource, nil)
+
+	ctx := createTestContext("OPTIONS", "/resources/photo", nil, map[string][]string{"id": {"photo"}})
+	assert.NoError(t, handler.OptionsResource(ctx))
+
+	links := ctx.(*mockHTTPContext).response.Header().Values("Link")
+	assert.Contains(t, links, `<http://www.w3.org/ns/ldp#NonRDFSource>; rel="type"`)
+	assert.Contains(t, links, `</resources/photo/meta>; rel="describedby"`)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
@@ -11,16 +12,20 @@ import (
 // StorageServiceInterface defines the interface for storage operations
 type StorageServiceInterface interface {
 	StoreResource(ctx context.Context, id string, data []byte, contentType string) (domain.Resource, error)
+	StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error)
 	RetrieveResource(ctx context.Context, id string, acceptFormat string) (domain.Resource, error)
 	DeleteResource(ctx context.Context, id string) error
 	ResourceExists(ctx context.Context, id string) (bool, error)
 	StreamResource(ctx context.Context, id string, acceptFormat string) (io.ReadCloser, string, error)
 	StoreResourceStream(ctx context.Context, id string, reader io.Reader, contentType string, size int64) (domain.Resource, error)
+	PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error)
+	QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error)
+	DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error)
 }
 
 // ContainerServiceInterface defines the interface for container operations
 type ContainerServiceInterface interface {
-	CreateContainer(ctx context.Context, id, parentID string, containerType domain.ContainerType) (domain.ContainerResource, error)
+	CreateContainer(ctx context.Context, id, parentID string, containerType domain.ContainerType) (*domain.Container, error)
 	GetContainer(ctx context.Context, id string) (domain.ContainerResource, error)
 	UpdateContainer(ctx context.Context, container domain.ContainerResource) error
 	DeleteContainer(ctx context.Context, id string) error
@@ -28,8 +33,155 @@ type ContainerServiceInterface interface {
 	RemoveResource(ctx context.Context, containerID, resourceID string) error
 	ListContainerMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions) (*application.ContainerListing, error)
 	GetContainerPath(ctx context.Context, containerID string) ([]string, error)
-	FindContainerByPath(ctx context.Context, path string) (domain.ContainerResource, error)
-	GetChildren(ctx context.Context, containerID string) ([]domain.ContainerResource, error)
-	GetParent(ctx context.Context, containerID string) (domain.ContainerResource, error)
+	FindContainerByPath(ctx context.Context, path string) (*domain.Container, error)
+	GetChildren(ctx context.Context, containerID string) ([]*domain.Container, error)
+	GetParent(ctx context.Context, containerID string) (*domain.Container, error)
 	ContainerExists(ctx context.Context, id string) (bool, error)
+	SetContainerRDFNormalization(ctx context.Context, containerID string, enabled bool) error
+	SetContainerExternalMembers(ctx context.Context, containerID string, allowed bool) error
+	SetContainerDefaultSort(ctx context.Context, containerID string, sort domain.SortOptions) error
+	SetContainerRetentionPolicy(ctx context.Context, containerID string, policy domain.RetentionPolicy) error
+	NormalizeContainerRDF(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, string, error)
+	SetContainerInheritableMetadata(ctx context.Context, containerID string, values map[string]interface{}, inheritOnMove bool) error
+	InheritedMetadata(ctx context.Context, containerID string) (map[string]interface{}, error)
+	MoveResource(ctx context.Context, resourceID, sourceContainerID, targetContainerID string, resource domain.Resource) error
+	GenerateAuthorizedBreadcrumbs(ctx context.Context, containerID string, authorizer application.ContainerReadAuthorizer) ([]application.BreadcrumbItem, error)
+	DiffContainerMembers(ctx context.Context, containerID string, desired []string) (*application.MembershipDiff, error)
+	CreateContainersWithOptions(ctx context.Context, specs []application.CreateContainerSpec, options application.BatchCreateOptions) ([]application.CreateContainerResult, error)
+	TouchContainer(ctx context.Context, containerID string) (*domain.Container, error)
+	SearchPod(ctx context.Context, accountID string, query domain.SearchQuery, pagination domain.PaginationOptions) (*application.SearchResults, error)
+	GetContainerFeed(ctx context.Context, containerID string) (*application.ContainerFeed, error)
+	GenerateStructureInfo(ctx context.Context, containerID string, maxDepth int) (*application.ContainerStructureInfo, error)
+	ContinueStructureInfo(ctx context.Context, containerID, token string) (*application.ContainerStructureInfo, error)
+	SetContainerMembershipResource(ctx context.Context, containerID, reference string) error
+	SetContainerMembershipRelations(ctx context.Context, containerID, hasMemberRelation, insertedContentRelation string) error
+	ApplyMembershipTriple(ctx context.Context, containerID string, member domain.Resource) (*domain.MembershipTriple, error)
+	StartAsyncListing(ctx context.Context, containerID string, release func()) (*application.ListingResult, error)
+	GetAsyncListing(ctx context.Context, containerID, listingID string, pagination domain.PaginationOptions) (*application.ListingResult, error)
+	WaitAsyncListing(ctx context.Context, containerID, listingID string, timeout time.Duration, pagination domain.PaginationOptions) (*application.ListingResult, error)
+	ListContainerMembersWithMetadata(ctx context.Context, containerID, format, baseURI string, pagination domain.PaginationOptions) ([]byte, error)
+	GetContainerWithPreference(ctx context.Context, id, format, baseURI string, preference domain.ContainerPreference) ([]byte, error)
+}
+
+// NamedResourceCreator creates resources under client-chosen names, never overwriting one
+type NamedResourceCreator interface {
+	CreateNamedResource(ctx context.Context, name string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error)
+}
+
+// ResourceUploader receives large resources in ranges through resumable upload sessions
+type ResourceUploader interface {
+	CreateUpload(ctx context.Context, resourceID, contentType string, size int64) (domain.UploadSession, error)
+	GetUpload(ctx context.Context, uploadID string) (domain.UploadSession, error)
+	AppendUpload(ctx context.Context, uploadID string, offset int64, chunk io.Reader) (domain.UploadSession, error)
+	CompleteUpload(ctx context.Context, uploadID string) (domain.Resource, error)
+	AbortUpload(ctx context.Context, uploadID string) error
+}
+
+// ResourceExistenceChecker reports which of many resources exist in one call
+type ResourceExistenceChecker interface {
+	ResourcesExist(ctx context.Context, ids []string) (map[string]bool, error)
+}
+
+// ResourceMetadataStreamer streams a resource along with its metadata
+type ResourceMetadataStreamer interface {
+	StreamResourceWithMetadata(ctx context.Context, id string, acceptFormat string) (io.ReadCloser, string, map[string]interface{}, error)
+}
+
+// ResourceAccountChecker refuses reads of resources whose account was deleted
+type ResourceAccountChecker interface {
+	CheckResourceAccount(ctx context.Context, id string) error
+}
+
+// ResourceContentOpener opens the stored content of a resource for random access
+type ResourceContentOpener interface {
+	OpenResourceContent(ctx context.Context, id string) (io.ReadSeekCloser, *domain.ResourceMetadata, error)
+}
+
+// ResourceRDFNormalizer canonicalizes RDF replacing the content of resources held by containers
+// that have opted in to normalization
+type ResourceRDFNormalizer interface {
+	NormalizesResourceRDF(ctx context.Context, resourceID string, contentType string) (bool, error)
+	NormalizeResourceRDF(ctx context.Context, resourceID string, data []byte, contentType string) ([]byte, string, error)
+}
+
+// ContainerContentTransformer rewrites content written into containers and toggles that per container
+type ContainerContentTransformer interface {
+	SetContainerContentTransforms(ctx context.Context, containerID string, enabled bool) error
+	TransformContainerContent(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, error)
+}
+
+// ContainerRDFStreamer writes a whole container in an RDF format as its members are read
+type ContainerRDFStreamer interface {
+	StreamContainerRDF(ctx context.Context, id, format, baseURI string, w io.Writer) error
+}
+
+// ContainerMemberExporter streams a page of a container's members from the membership index
+type ContainerMemberExporter interface {
+	ExportContainerMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions, emit func(domain.IndexedMember) error) error
+}
+
+// RecursiveContainerDeleter deletes a container together with its sub-containers and members
+type RecursiveContainerDeleter interface {
+	DeleteContainerRecursive(ctx context.Context, id string) (*application.RecursiveDeletion, error)
+}
+
+// ContainerCreateAuthorizer decides whether the caller may create a container in a parent,
+// which takes Append or Write access to the parent; an empty parent ID asks about root containers
+type ContainerCreateAuthorizer interface {
+	CanCreateContainer(ctx context.Context, parentID string) bool
+}
+
+// MovedContainerResolver finds where a container that was moved or renamed now lives
+type MovedContainerResolver interface {
+	ResolveMovedContainer(ctx context.Context, containerID string) (string, bool)
+}
+
+// PodAccountResolver finds the account owning the pod a container or resource is in
+type PodAccountResolver interface {
+	PodAccountID(ctx context.Context, id string) (string, error)
+}
+
+// DeadLetterManager lists and replays events their handlers could not process
+type DeadLetterManager interface {
+	ListDeadLetters(ctx context.Context) ([]domain.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) error
+}
+
+// EventLogStreamer writes the stored event log as newline-delimited JSON
+type EventLogStreamer interface {
+	StreamEventLog(ctx context.Context, since time.Time, w io.Writer) error
+	StreamEventLogFrom(ctx context.Context, afterSequence int64, w io.Writer) error
+}
+
+// RetentionSweepRunner expires members of containers with a retention policy and reports on
+// the most recent sweep
+type RetentionSweepRunner interface {
+	Sweep(ctx context.Context) (application.RetentionReport, error)
+	LastReport() (application.RetentionReport, bool)
+}
+
+// CacheInvalidationRunner drops cached container and resource data, so it is read from storage
+// on next access
+type CacheInvalidationRunner interface {
+	InvalidateContainerCaches(ctx context.Context, containerID string) (application.CacheInvalidation, error)
+	InvalidateAllCaches(ctx context.Context) application.CacheInvalidation
+}
+
+// NotificationSubscriber opens and closes Solid Notifications Protocol channels
+type NotificationSubscriber interface {
+	Subscribe(ctx context.Context, request application.SubscriptionRequest) (domain.NotificationChannel, error)
+	GetChannel(ctx context.Context, channelID string) (domain.NotificationChannel, error)
+	Unsubscribe(ctx context.Context, channelID string) error
+}
+
+// ContainerChangeSubscriber subscribes to the live changes of a container or resource
+type ContainerChangeSubscriber interface {
+	Subscribe(ctx context.Context, topicID string) (*application.ContainerChangeSubscription, error)
+	SubscribeFrom(ctx context.Context, topicID string, lastSequence uint64) (*application.ContainerChangeSubscription, error)
+}
+
+// AccessAuthorizer decides whether a user may access a resource or container in the required
+// Web Access Control modes
+type AccessAuthorizer interface {
+	Authorize(ctx context.Context, userID, resourceID string, required domain.AccessMode) (*application.AccessDecision, error)
 }
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// knownRDFMediaTypes are RDF syntaxes the server recognises but cannot parse or serialize.
+// Strict policies refuse them instead of storing them as opaque content.
+var knownRDFMediaTypes = map[string]bool{
+	"application/n-triples": true,
+	"application/n-quads":   true,
+	"application/trig":      true,
+	"application/trix":      true,
+	"text/n3":               true,
+	"text/rdf+n3":           true,
+	"application/n3":        true,
+	"application/rdf+json":  true,
+}
+
+// defaultMediaTypePolicy is used by handlers that were not given a policy
+var defaultMediaTypePolicy = NewMediaTypePolicy(conf.MediaTypes{})
+
+// MediaTypePolicy decides which RDF media types are served on reads and accepted on writes.
+// OPTIONS, Accept-Post and error responses advertise exactly the set it enforces.
+type MediaTypePolicy struct {
+	supported []string
+	aliases   map[string]string
+	rejected  map[string]bool
+	strict    bool
+}
+
+// NewMediaTypePolicy creates a media-type policy from configuration, filling in defaults
+func NewMediaTypePolicy(config conf.MediaTypes) *MediaTypePolicy {
+	config.SetDefaults()
+
+	policy := &MediaTypePolicy{
+		supported: append([]string(nil), conf.SupportedRDFMediaTypes...),
+		aliases:   make(map[string]string, len(config.Aliases)),
+		rejected:  make(map[string]bool, len(config.Rejected)),
+		strict:    config.Strict,
+	}
+	for alias, target := range config.Aliases {
+		policy.aliases[baseMediaType(alias)] = target
+	}
+	for _, rejected := range config.Rejected {
+		policy.rejected[baseMediaType(rejected)] = true
+	}
+	return policy
+}
+
+// Supported returns the supported media types in order of preference
+func (p *MediaTypePolicy) Supported() []string {
+	return append([]string(nil), p.supported...)
+}
+
+// Canonical returns the supported media type a media type stands for, resolving aliases and
+// dropping parameters. Media types the policy does not know are returned unchanged.
+func (p *MediaTypePolicy) Canonical(mediaType string) string {
+	base := baseMediaType(mediaType)
+	if target, ok := p.aliases[base]; ok {
+		return target
+	}
+	if p.IsSupported(base) {
+		return base
+	}
+	return mediaType
+}
+
+// IsSupported reports whether a media type is one of the supported RDF formats
+func (p *MediaTypePolicy) IsSupported(mediaType string) bool {
+	base := baseMediaType(mediaType)
+	for _, supported := range p.supported {
+		if base == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Refuses reports whether a media type must be refused: it is explicitly rejected, or the
+// policy is strict and it is an RDF syntax outside the supported set.
+func (p *MediaTypePolicy) Refuses(mediaType string) bool {
+	base := baseMediaType(mediaType)
+	if p.rejected[base] {
+		return true
+	}
+	if !p.strict {
+		return false
+	}
+	if _, aliased := p.aliases[base]; aliased || p.IsSupported(base) {
+		return false
+	}
+	return knownRDFMediaTypes[base] || domain.IsRDFFormat(base)
+}
+
+// Acceptable reports whether an Accept header leaves the server something to serve. It is
+// false only when every listed media type is refused and no wildcard is present.
+func (p *MediaTypePolicy) Acceptable(acceptHeader string) bool {
+	acceptTypes := parseAcceptTypes(acceptHeader)
+	if len(acceptTypes) == 0 {
+		return true
+	}
+
+	for _, accepted := range acceptTypes {
+		if accepted.quality <= 0 {
+			continue
+		}
+		if strings.Contains(accepted.mediaType, "*") || !p.Refuses(accepted.mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate returns the supported media type that best matches an Accept header, or an
+// empty string when nothing matches
+func (p *MediaTypePolicy) Negotiate(acceptHeader string) string {
+	acceptTypes := parseAcceptTypes(acceptHeader)
+
+	// Find the best match
+	for _, accepted := range acceptTypes {
+		if p.Refuses(accepted.mediaType) {
+			continue
+		}
+		if canonical := p.Canonical(accepted.mediaType); p.IsSupported(canonical) {
+			return canonical
+		}
+	}
+
+	// Check for wildcard acceptance
+	for _, accepted := range acceptTypes {
+		if accepted.mediaType == "*/*" || accepted.mediaType == "application/*" {
+			return p.supported[0]
+		}
+	}
+
+	return ""
+}
+
+// writeNotAcceptable answers a read whose Accept header only lists refused media types
+func (p *MediaTypePolicy) writeNotAcceptable(ctx khttp.Context) error {
+	return p.writeRefusal(ctx, http.StatusNotAcceptable, "NOT_ACCEPTABLE",
+		"None of the requested media types are supported")
+}
+
+// writeUnsupportedMediaType answers a write whose Content-Type is refused
+func (p *MediaTypePolicy) writeUnsupportedMediaType(ctx khttp.Context, contentType string) error {
+	ctx.Response().Header().Set("Accept-Post", strings.Join(p.supported, ", "))
+	return p.writeRefusal(ctx, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+		"Media type "+baseMediaType(contentType)+" is not supported")
+}
+
+// writeRefusal writes a refusal listing the supported media types
+func (p *MediaTypePolicy) writeRefusal(ctx khttp.Context, status int, code, message string) error {
+	ctx.Response().Header().Set("Cache-Control", "no-cache")
+
+	response := map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":             code,
+			"message":          message,
+			"status":           status,
+			"supportedFormats": p.Supported(),
+		},
+	}
+	return ctx.JSON(status, response)
+}
+
+// baseMediaType lower-cases a media type and strips its parameters
+func baseMediaType(mediaType string) string {
+	return strings.TrimSpace(strings.ToLower(strings.Split(mediaType, ";")[0]))
+}
+
+// SetMediaTypePolicy sets the policy deciding which media types are served and accepted
+func (h *ResourceHandler) SetMediaTypePolicy(policy *MediaTypePolicy) {
+	h.mediaTypePolicy = policy
+}
+
+// mediaTypes returns the handler's media-type policy, falling back to the default policy
+func (h *ResourceHandler) mediaTypes() *MediaTypePolicy {
+	if h.mediaTypePolicy == nil {
+		return defaultMediaTypePolicy
+	}
+	return h.mediaTypePolicy
+}
+
+// SetMediaTypePolicy sets the policy deciding which media types are served and accepted
+func (h *ContainerHandler) SetMediaTypePolicy(policy *MediaTypePolicy) {
+	h.mediaTypePolicy = policy
+}
+
+// mediaTypes returns the handler's media-type policy, falling back to the default policy
+func (h *ContainerHandler) mediaTypes() *MediaTypePolicy {
+	if h.mediaTypePolicy == nil {
+		return defaultMediaTypePolicy
+	}
+	return h.mediaTypePolicy
+}
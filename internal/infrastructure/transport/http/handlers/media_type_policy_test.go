@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaTypePolicy_Canonical(t *testing.T) {
+	policy := NewMediaTypePolicy(conf.MediaTypes{})
+
+	assert.Equal(t, "application/ld+json", policy.Canonical("application/json"))
+	assert.Equal(t, "text/turtle", policy.Canonical("application/x-turtle; charset=utf-8"))
+	assert.Equal(t, "text/turtle", policy.Canonical("Text/Turtle"))
+	assert.Equal(t, "image/png", policy.Canonical("image/png"))
+}
+
+func TestMediaTypePolicy_Refuses(t *testing.T) {
+	lenient := NewMediaTypePolicy(conf.MediaTypes{})
+	strict := NewMediaTypePolicy(conf.MediaTypes{Strict: true})
+
+	tests := []struct {
+		mediaType       string
+		refusedLenient  bool
+		refusedStrictly bool
+	}{
+		{"text/turtle", false, false},
+		{"application/json", false, false},
+		{"text/rdf+n3", true, true},
+		{"text/n3; charset=utf-8", true, true},
+		{"application/n-triples", false, true},
+		{"application/trig", false, true},
+		{"image/png", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			assert.Equal(t, tt.refusedLenient, lenient.Refuses(tt.mediaType))
+			assert.Equal(t, tt.refusedStrictly, strict.Refuses(tt.mediaType))
+		})
+	}
+}
+
+func TestMediaTypePolicy_Negotiate(t *testing.T) {
+	policy := NewMediaTypePolicy(conf.MediaTypes{
+		Strict:  true,
+		Aliases: map[string]string{"application/x-turtle": "text/turtle"},
+	})
+
+	assert.Equal(t, "text/turtle", policy.Negotiate("application/x-turtle"))
+	assert.Equal(t, "", policy.Negotiate("application/json"), "aliases are only those configured")
+	assert.Equal(t, "application/rdf+xml", policy.Negotiate("text/n3, application/rdf+xml;q=0.5"))
+	assert.Equal(t, "application/ld+json", policy.Negotiate("*/*"))
+
+	assert.True(t, policy.Acceptable(""))
+	assert.True(t, policy.Acceptable("text/html"))
+	assert.True(t, policy.Acceptable("application/n-triples, */*;q=0.1"))
+	assert.False(t, policy.Acceptable("application/n-triples, text/rdf+n3"))
+}
+
+func TestResourceHandler_PutResource_RefusesRejectedMediaType(t *testing.T) {
+	mockService := new(MockStorageService)
+	handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+
+	ctx := createTestContext("PUT", "/resources/doc", []byte("@prefix ex: <http://example.org/> ."), map[string][]string{"id": {"doc"}})
+	ctx.Request().Header.Set("Content-Type", "text/rdf+n3")
+	require.NoError(t, handler.PutResource(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusUnsupportedMediaType, response.Code)
+	assert.Equal(t, "application/ld+json, text/turtle, application/rdf+xml", response.Header().Get("Accept-Post"))
+
+	var body map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "UNSUPPORTED_MEDIA_TYPE", body["error"]["code"])
+	assert.Len(t, body["error"]["supportedFormats"], 3)
+	mockService.AssertNotCalled(t, "StoreResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResourceHandler_PutResource_StoresAliasAsCanonicalType(t *testing.T) {
+	mockService := new(MockStorageService)
+	handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+
+	data := []byte("@prefix ex: <http://example.org/> .")
+	resource := domain.NewResource(context.Background(), "doc", "text/turtle", data)
+	mockService.On("ResourceExists", mock.Anything, "doc").Return(false, nil)
+	mockService.On("StoreResource", mock.Anything, "doc", data, "text/turtle").Return(resource, nil)
+
+	ctx := createTestContext("PUT", "/resources/doc", data, map[string][]string{"id": {"doc"}})
+	ctx.Request().Header.Set("Content-Type", "application/x-turtle")
+	ctx.Request().Header.Set("Content-Length", strconv.Itoa(len(data)))
+	require.NoError(t, handler.PutResource(ctx))
+
+	assert.Equal(t, http.StatusCreated, ctx.(*mockHTTPContext).response.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestResourceHandler_GetResource_StrictPolicyRefusesUnknownRDF(t *testing.T) {
+	mockService := new(MockStorageService)
+	handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+	handler.SetMediaTypePolicy(NewMediaTypePolicy(conf.MediaTypes{Strict: true}))
+
+	ctx := createTestContext("GET", "/resources/doc", nil, map[string][]string{"id": {"doc"}})
+	ctx.Request().Header.Set("Accept", "application/n-triples")
+	require.NoError(t, handler.GetResource(ctx))
+
+	assert.Equal(t, http.StatusNotAcceptable, ctx.(*mockHTTPContext).response.Code)
+	mockService.AssertNotCalled(t, "RetrieveResource", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContainerHandler_OptionsContainer_AdvertisesPolicyFormats(t *testing.T) {
+	handler := NewContainerHandler(nil, nil, log.NewStdLogger(io.Discard))
+
+	ctx := createTestContext("OPTIONS", "/containers/docs", nil, map[string][]string{"id": {"docs"}})
+	require.NoError(t, handler.OptionsContainer(ctx))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(ctx.(*mockHTTPContext).response.Body.Bytes(), &body))
+	assert.Equal(t, []interface{}{"application/ld+json", "text/turtle", "application/rdf+xml"}, body["formats"])
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// resourceAllowedMethods lists the methods a non-container resource supports
+const resourceAllowedMethods = "GET, PUT, PATCH, DELETE, HEAD, OPTIONS"
+
+// ContainerLocator reports whether an ID identifies a container
+type ContainerLocator interface {
+	ContainerExists(ctx context.Context, id string) (bool, error)
+}
+
+// SetNonContainerPostBehavior sets how POST to a resource that is not a container is answered
+func (h *ContainerHandler) SetNonContainerPostBehavior(behavior string) {
+	h.nonContainerPost = behavior
+}
+
+// handlePostToMissingContainer answers a POST whose target is not a container. A container that
+// moved is redirected; when the target is an existing resource, LDP requires 405 with the
+// methods the resource does support.
+func (h *ContainerHandler) handlePostToMissingContainer(ctx khttp.Context, id string) error {
+	if redirected, err := h.redirectMovedContainer(ctx, id); redirected {
+		return err
+	}
+
+	if h.nonContainerPost != conf.NonContainerPostNotFound {
+		isResource, err := h.storageService.ResourceExists(context.Background(), id)
+		if err != nil {
+			return h.handleStorageError(ctx, err)
+		}
+		if isResource {
+			ctx.Response().Header().Set("Allow", resourceAllowedMethods)
+			return h.writeErrorResponse(ctx, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "POST is only supported on containers")
+		}
+	}
+
+	return h.writeErrorResponse(ctx, http.StatusNotFound, "CONTAINER_NOT_FOUND", "Container not found")
+}
+
+// SetContainerLocator sets the lookup used to tell containers apart from resources
+func (h *ResourceHandler) SetContainerLocator(locator ContainerLocator) {
+	h.containerLocator = locator
+}
+
+// SetNonContainerPostBehavior sets how POST to a resource that is not a container is answered
+func (h *ResourceHandler) SetNonContainerPostBehavior(behavior string) {
+	h.nonContainerPost = behavior
+}
+
+// PostToResource handles POST requests addressed to an individual resource. Containers are
+// redirected to their member endpoint; other resources do not accept POST.
+func (h *ResourceHandler) PostToResource(ctx khttp.Context) error {
+	// Extract resource ID from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+
+	if id == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Resource ID is required")
+	}
+
+	if h.containerLocator != nil {
+		isContainer, err := h.containerLocator.ContainerExists(context.Background(), id)
+		if err != nil {
+			return h.handleStorageError(ctx, err)
+		}
+		if isContainer {
+			// 307 preserves the method and body so the client re-sends the POST to the container
+			ctx.Response().Header().Set("Location", fmt.Sprintf("/containers/%s/members", id))
+			ctx.Response().WriteHeader(http.StatusTemporaryRedirect)
+			return nil
+		}
+	}
+
+	exists, err := h.storageService.ResourceExists(context.Background(), id)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+	if exists && h.nonContainerPost != conf.NonContainerPostNotFound {
+		ctx.Response().Header().Set("Allow", resourceAllowedMethods)
+		return h.writeErrorResponse(ctx, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "POST is only supported on containers")
+	}
+
+	return h.writeErrorResponse(ctx, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Resource not found")
+}
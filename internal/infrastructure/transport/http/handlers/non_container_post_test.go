@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestContainerHandler_PostResource_TargetIsResource(t *testing.T) {
+	containerService := &MockContainerService{}
+	storageService := &MockStorageService{}
+	handler := NewContainerHandler(containerService, storageService, log.DefaultLogger)
+
+	containerService.On("ContainerExists", mock.Anything, "doc").Return(false, nil)
+	storageService.On("ResourceExists", mock.Anything, "doc").Return(true, nil)
+
+	ctx := createTestContext("POST", "/containers/doc", []byte(`{"data": "test"}`), map[string][]string{"id": {"doc"}})
+	assert.NoError(t, handler.PostResource(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, resourceAllowedMethods, response.Header().Get("Allow"))
+	assert.NotContains(t, response.Header().Get("Allow"), "POST")
+}
+
+func TestContainerHandler_PostResource_TargetIsResource_NotFoundBehavior(t *testing.T) {
+	containerService := &MockContainerService{}
+	storageService := &MockStorageService{}
+	handler := NewContainerHandler(containerService, storageService, log.DefaultLogger)
+	handler.SetNonContainerPostBehavior(conf.NonContainerPostNotFound)
+
+	containerService.On("ContainerExists", mock.Anything, "doc").Return(false, nil)
+
+	ctx := createTestContext("POST", "/containers/doc", []byte(`{"data": "test"}`), map[string][]string{"id": {"doc"}})
+	assert.NoError(t, handler.PostResource(ctx))
+
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	storageService.AssertNotCalled(t, "ResourceExists", mock.Anything, "doc")
+}
+
+func TestResourceHandler_PostToResource(t *testing.T) {
+	tests := []struct {
+		name           string
+		isContainer    bool
+		resourceExists bool
+		expectedStatus int
+		expectedHeader string
+		expectedValue  string
+	}{
+		{
+			name:           "resource rejects POST",
+			resourceExists: true,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedHeader: "Allow",
+			expectedValue:  resourceAllowedMethods,
+		},
+		{
+			name:           "container is redirected to its member endpoint",
+			isContainer:    true,
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedHeader: "Location",
+			expectedValue:  "/containers/target/members",
+		},
+		{
+			name:           "missing target",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			containerService := &MockContainerService{}
+			storageService := &MockStorageService{}
+			handler := NewResourceHandler(storageService, log.DefaultLogger)
+			handler.SetContainerLocator(containerService)
+
+			containerService.On("ContainerExists", mock.Anything, "target").Return(tt.isContainer, nil)
+			storageService.On("ResourceExists", mock.Anything, "target").Return(tt.resourceExists, nil)
+
+			ctx := createTestContext("POST", "/resources/target", []byte(`{"data": "test"}`), map[string][]string{"id": {"target"}})
+			assert.NoError(t, handler.PostToResource(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, tt.expectedStatus, response.Code)
+			if tt.expectedHeader != "" {
+				assert.Equal(t, tt.expectedValue, response.Header().Get(tt.expectedHeader))
+			}
+		})
+	}
+}
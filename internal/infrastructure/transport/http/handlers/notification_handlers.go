@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/application"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// defaultNotificationPageSize is used when a notification listing does not specify a limit
+const defaultNotificationPageSize = 50
+
+// NotificationHandler handles HTTP requests for the caller's notifications
+type NotificationHandler struct {
+	notificationService application.NotificationService
+	logger              log.Logger
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(notificationService application.NotificationService, logger log.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// NotificationResponse represents the HTTP response for a single notification
+type NotificationResponse struct {
+	ID          string `json:"id"`
+	AccountID   string `json:"account_id"`
+	Type        string `json:"type"`
+	ContainerID string `json:"container_id"`
+	MemberID    string `json:"member_id"`
+	Read        bool   `json:"read"`
+	CreatedAt   string `json:"created_at"`
+	ReadAt      string `json:"read_at,omitempty"`
+}
+
+// NotificationListResponse represents the HTTP response for a notification listing
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int64                  `json:"unread_count"`
+}
+
+// ListMyNotifications handles GET /me/notifications. Supports unread=true, limit and offset.
+func (h *NotificationHandler) ListMyNotifications(ctx khttp.Context) error {
+	userID := requestUserID(ctx.Request())
+	if userID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	query := ctx.Request().URL.Query()
+	filter := domain.NotificationFilter{
+		UnreadOnly: query.Get("unread") == "true",
+		Limit:      defaultNotificationPageSize,
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 {
+			return h.handleError(ctx, http.StatusBadRequest, "INVALID_LIMIT", "Limit must be a positive integer")
+		}
+		filter.Limit = value
+	}
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return h.handleError(ctx, http.StatusBadRequest, "INVALID_OFFSET", "Offset must be a non-negative integer")
+		}
+		filter.Offset = value
+	}
+
+	notifications, unread, err := h.notificationService.ListNotifications(ctx.Request().Context(), userID, filter)
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to list notifications", "user", userID, "error", err.Error())
+		return h.handleError(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+	}
+
+	response := NotificationListResponse{
+		Notifications: make([]NotificationResponse, len(notifications)),
+		UnreadCount:   unread,
+	}
+	for i, notification := range notifications {
+		response.Notifications[i] = h.buildNotificationResponse(notification)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// MarkNotificationRead handles POST /me/notifications/{id}/read
+func (h *NotificationHandler) MarkNotificationRead(ctx khttp.Context) error {
+	userID := requestUserID(ctx.Request())
+	if userID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	vars := ctx.Vars()
+	idSlice, exists := vars["id"]
+	if !exists || len(idSlice) == 0 || strings.TrimSpace(idSlice[0]) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_NOTIFICATION_ID", "Notification ID is required")
+	}
+
+	if err := h.notificationService.MarkNotificationRead(ctx.Request().Context(), userID, idSlice[0]); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return h.handleError(ctx, http.StatusNotFound, "NOT_FOUND", "Notification not found")
+		}
+		h.logger.Log(log.LevelError, "msg", "Failed to mark notification read", "user", userID, "error", err.Error())
+		return h.handleError(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "Notification marked as read"})
+}
+
+// Helper methods
+
+func (h *NotificationHandler) buildNotificationResponse(notification *domain.Notification) NotificationResponse {
+	response := NotificationResponse{
+		ID:          notification.ID,
+		AccountID:   notification.AccountID,
+		Type:        string(notification.Type),
+		ContainerID: notification.ContainerID,
+		MemberID:    notification.MemberID,
+		Read:        notification.IsRead(),
+		CreatedAt:   notification.CreatedAt.Format(time.RFC3339),
+	}
+	if notification.ReadAt != nil {
+		response.ReadAt = notification.ReadAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+func (h *NotificationHandler) handleError(ctx khttp.Context, status int, code, message string) error {
+	response := map[string]interface{}{
+		"error":   code,
+		"message": message,
+	}
+	return ctx.JSON(status, response)
+}
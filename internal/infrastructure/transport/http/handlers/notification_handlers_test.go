@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockNotificationService is a mock implementation of NotificationService
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) ListNotifications(ctx context.Context, userID string, filter domain.NotificationFilter) ([]*domain.Notification, int64, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Notification), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationService) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func TestNotificationHandler_ListMyNotifications(t *testing.T) {
+	t.Run("should require a user identity", func(t *testing.T) {
+		handler := NewNotificationHandler(&MockNotificationService{}, log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/me/notifications", nil, nil)
+		require.NoError(t, handler.ListMyNotifications(ctx))
+
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should list unread notifications with the unread count", func(t *testing.T) {
+		mockService := &MockNotificationService{}
+		handler := NewNotificationHandler(mockService, log.DefaultLogger)
+
+		notifications := []*domain.Notification{
+			{ID: "n-1", AccountID: "acct", UserID: "user-1", Type: domain.NotificationTypeMemberAdded, ContainerID: "docs", MemberID: "a.ttl", CreatedAt: time.Now()},
+		}
+		filter := domain.NotificationFilter{UnreadOnly: true, Limit: 10, Offset: 5}
+		mockService.On("ListNotifications", mock.Anything, "user-1", filter).Return(notifications, int64(3), nil)
+
+		ctx := createTestContext("GET", "/me/notifications?unread=true&limit=10&offset=5", nil, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.ListMyNotifications(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var body NotificationListResponse
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, int64(3), body.UnreadCount)
+		require.Len(t, body.Notifications, 1)
+		assert.Equal(t, "member_added", body.Notifications[0].Type)
+		assert.False(t, body.Notifications[0].Read)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid limit", func(t *testing.T) {
+		handler := NewNotificationHandler(&MockNotificationService{}, log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/me/notifications?limit=0", nil, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.ListMyNotifications(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+	})
+}
+
+func TestNotificationHandler_MarkNotificationRead(t *testing.T) {
+	t.Run("should mark the notification read", func(t *testing.T) {
+		mockService := &MockNotificationService{}
+		handler := NewNotificationHandler(mockService, log.DefaultLogger)
+		mockService.On("MarkNotificationRead", mock.Anything, "user-1", "n-1").Return(nil)
+
+		ctx := createTestContext("POST", "/me/notifications/n-1/read", nil, map[string][]string{"id": {"n-1"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.MarkNotificationRead(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return not found for another user's notification", func(t *testing.T) {
+		mockService := &MockNotificationService{}
+		handler := NewNotificationHandler(mockService, log.DefaultLogger)
+		mockService.On("MarkNotificationRead", mock.Anything, "user-1", "n-3").
+			Return(fmt.Errorf("failed to mark notification read: %w", domain.ErrNotificationNotFound))
+
+		ctx := createTestContext("POST", "/me/notifications/n-3/read", nil, map[string][]string{"id": {"n-3"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.MarkNotificationRead(ctx))
+
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// operationLimitRetryAfter is the Retry-After, in seconds, sent with 429 when an account is
+// at its heavy operation limit
+const operationLimitRetryAfter = "5"
+
+// SetOperationGate sets the gate capping concurrent heavy operations per account. Without
+// one, heavy operations are not limited.
+func (h *ContainerHandler) SetOperationGate(gate *application.OperationGate) {
+	h.operationGate = gate
+}
+
+// acquireHeavyOperation takes a heavy operation slot for the account owning the pod of the
+// container the operation targets. Containers whose pod cannot be resolved are limited on
+// their own. The returned release must be called once the operation's response is written.
+func (h *ContainerHandler) acquireHeavyOperation(ctx khttp.Context, containerID, operation string) (func(), error) {
+	accountID := h.podAccount(ctx.Request().Context(), containerID)
+	if accountID == "" {
+		accountID = containerID
+	}
+	return h.operationGate.Acquire(ctx.Request().Context(), accountID, operation)
+}
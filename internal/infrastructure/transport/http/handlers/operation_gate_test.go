@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchContainerService creates every container in a batch; other container service methods
+// are not expected
+type batchContainerService struct {
+	ContainerServiceInterface
+}
+
+func (batchContainerService) CreateContainersWithOptions(ctx context.Context, specs []application.CreateContainerSpec, options application.BatchCreateOptions) ([]application.CreateContainerResult, error) {
+	results := make([]application.CreateContainerResult, 0, len(specs))
+	for _, spec := range specs {
+		results = append(results, application.CreateContainerResult{ID: spec.ID, Created: true})
+	}
+	return results, nil
+}
+
+func TestContainerHandler_PostContainerBatch_OperationLimit(t *testing.T) {
+	setup := func() *ContainerHandler {
+		gate := application.NewOperationGate(1, 0)
+		handler := NewContainerHandler(batchContainerService{}, nil, log.NewStdLogger(io.Discard))
+		handler.SetOperationGate(gate)
+		handler.SetPodAccountResolver(podAccounts{"acct-1/docs": "acct-1", "acct-2/docs": "acct-2"})
+
+		running, err := gate.Acquire(context.Background(), "acct-1", application.OperationExport)
+		require.NoError(t, err)
+		t.Cleanup(running)
+		return handler
+	}
+
+	t.Run("should refuse when the pod's account is at its limit", func(t *testing.T) {
+		handler := setup()
+		ctx := createTestContext("POST", "/containers/batch", []byte(`{"containers":[{"id":"reports","parentId":"acct-1/docs"}]}`), nil)
+		ctx.Request().Header.Set("X-Account-ID", "acct-2")
+		require.NoError(t, handler.PostContainerBatch(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusTooManyRequests, response.Code)
+		assert.Equal(t, operationLimitRetryAfter, response.Header().Get("Retry-After"))
+		assert.Contains(t, response.Body.String(), "OPERATION_LIMIT_REACHED")
+	})
+
+	t.Run("should not limit other pods however the request names its account", func(t *testing.T) {
+		handler := setup()
+		ctx := createTestContext("POST", "/containers/batch", []byte(`{"containers":[{"id":"reports","parentId":"acct-2/docs"}]}`), nil)
+		ctx.Request().Header.Set("X-Account-ID", "acct-1")
+		require.NoError(t, handler.PostContainerBatch(ctx))
+
+		assert.Equal(t, http.StatusCreated, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import "context"
+
+// SetPodAccountResolver sets the lookup of the account owning the pod a container is in, which
+// resources created in the container are charged to and its heavy operations are limited under
+func (h *ContainerHandler) SetPodAccountResolver(resolver PodAccountResolver) {
+	h.podAccounts = resolver
+}
+
+// podAccount returns the account owning the pod a container is in, or "" when there is no
+// resolver or the pod cannot be resolved
+func (h *ContainerHandler) podAccount(ctx context.Context, containerID string) string {
+	if h.podAccounts == nil || containerID == "" {
+		return ""
+	}
+	accountID, err := h.podAccounts.PodAccountID(ctx, containerID)
+	if err != nil {
+		return ""
+	}
+	return accountID
+}
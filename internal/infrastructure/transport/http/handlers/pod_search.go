@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SearchPod handles GET requests searching an account's pod by member metadata.
+// Query parameters q, contentType, tag (repeatable) and type are AND-combined.
+func (h *ContainerHandler) SearchPod(ctx khttp.Context) error {
+	// Extract account ID from path parameters
+	vars := ctx.Vars()
+	accountID := ""
+	if len(vars["account_id"]) > 0 {
+		accountID = vars["account_id"][0]
+	}
+
+	if accountID == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Account ID is required")
+	}
+
+	params := ctx.Request().URL.Query()
+	query := domain.SearchQuery{
+		Text:        params.Get("q"),
+		ContentType: params.Get("contentType"),
+		Tags:        params["tag"],
+		MemberType:  params.Get("type"),
+	}
+	if query.MemberType != "" && query.MemberType != domain.SearchTypeContainer && query.MemberType != domain.SearchTypeResource {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Type must be Container or Resource")
+	}
+
+	results, err := h.containerService.SearchPod(agentContext(ctx.Request()), accountID, query, h.parsePaginationOptions(ctx.Request()))
+	if err != nil {
+		return h.handleContainerError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	return ctx.JSON(http.StatusOK, results)
+}
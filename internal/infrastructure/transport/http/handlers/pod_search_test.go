@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// searchContainerService mocks SearchPod; other container service methods are not expected
+type searchContainerService struct {
+	ContainerServiceInterface
+	mock.Mock
+}
+
+func (m *searchContainerService) SearchPod(ctx context.Context, accountID string, query domain.SearchQuery, pagination domain.PaginationOptions) (*application.SearchResults, error) {
+	args := m.Called(ctx, accountID, query, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.SearchResults), args.Error(1)
+}
+
+func TestContainerHandler_SearchPod(t *testing.T) {
+	t.Run("should pass AND-combined filters and pagination to the service", func(t *testing.T) {
+		mockService := new(searchContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+		query := domain.SearchQuery{
+			Text:        "trip",
+			ContentType: "image/jpeg",
+			Tags:        []string{"travel", "city"},
+			MemberType:  domain.SearchTypeResource,
+		}
+		pagination := domain.PaginationOptions{Limit: 10, Offset: 20}
+		results := &application.SearchResults{
+			Hits:       []application.SearchHit{{ID: "lisbon", Type: domain.SearchTypeResource, ContainerPath: "/alice/trips"}},
+			TotalCount: 21,
+			Pagination: pagination,
+		}
+		mockService.On("SearchPod", mock.Anything, "alice", query, pagination).Return(results, nil)
+
+		ctx := createTestContext("GET", "/pods/alice/search?q=trip&contentType=image/jpeg&tag=travel&tag=city&type=Resource&limit=10&offset=20", nil, map[string][]string{"account_id": {"alice"}})
+		require.NoError(t, handler.SearchPod(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var body application.SearchResults
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, 21, body.TotalCount)
+		require.Len(t, body.Hits, 1)
+		assert.Equal(t, "/alice/trips", body.Hits[0].ContainerPath)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject an unknown member type", func(t *testing.T) {
+		mockService := new(searchContainerService)
+		handler := NewContainerHandler(mockService, nil, log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/pods/alice/search?type=Folder", nil, map[string][]string{"account_id": {"alice"}})
+		require.NoError(t, handler.SearchPod(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertNotCalled(t, "SearchPod", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+)
+
+// headerRequestID is the request header correlating a read with the request it was made in
+const headerRequestID = "X-Request-ID"
+
+// newReadAuditEntry builds a read audit entry from the incoming request. The reader is the
+// subject of the verified access token; the auditor charges the read to the account owning
+// the pod it is made in.
+func newReadAuditEntry(req *http.Request, format string) application.ReadAuditEntry {
+	requestID := req.Header.Get(headerRequestID)
+	if requestID == "" {
+		requestID = middleware.GetCorrelationID(req.Context())
+	}
+
+	return application.ReadAuditEntry{
+		UserID:    requestUserID(req),
+		RequestID: requestID,
+		Format:    format,
+	}
+}
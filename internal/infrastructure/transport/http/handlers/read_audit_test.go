@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReadAuditEntry(t *testing.T) {
+	t.Run("should attribute reads to the verified subject", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/resources/beach.jpg", nil)
+		req.Header.Set("X-User-ID", "mallory")
+		req.Header.Set("X-Account-ID", "mallory-account")
+		req.Header.Set("X-Request-ID", "req-1")
+		req = req.WithContext(middleware.WithIdentity(req.Context(), middleware.Identity{Subject: "alice"}))
+
+		entry := newReadAuditEntry(req, "text/turtle")
+		assert.Equal(t, "alice", entry.UserID)
+		assert.Empty(t, entry.AccountID)
+		assert.Equal(t, "req-1", entry.RequestID)
+		assert.Equal(t, "text/turtle", entry.Format)
+	})
+
+	t.Run("should leave unauthenticated reads without a user", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/resources/beach.jpg", nil)
+		req.Header.Set("X-User-ID", "mallory")
+
+		assert.Empty(t, newReadAuditEntry(req, "text/turtle").UserID)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/ldp/application"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/go-kratos/kratos/v2/log"
 	khttp "github.com/go-kratos/kratos/v2/transport/http"
@@ -17,8 +18,22 @@ import (
 
 // ResourceHandler handles HTTP storage operations for resources
 type ResourceHandler struct {
-	storageService StorageServiceInterface
-	logger         log.Logger
+	storageService   StorageServiceInterface
+	readAuditor      *application.ReadAuditor
+	accessTracker    *application.ResourceAccessTracker
+	accountChecker   ResourceAccountChecker
+	containerLocator ContainerLocator
+	contentOpener    ResourceContentOpener
+	resourceStreamer ResourceMetadataStreamer
+	existenceChecker ResourceExistenceChecker
+	accessAuthorizer AccessAuthorizer
+	uploader         ResourceUploader
+	rdfNormalizer    ResourceRDFNormalizer
+	nonContainerPost string
+	mediaTypePolicy  *MediaTypePolicy
+	etagPolicy       *ETagPolicy
+	cachePolicy      *CachePolicy
+	logger           log.Logger
 }
 
 // NewResourceHandler creates a new resource handler
@@ -29,6 +44,28 @@ func NewResourceHandler(storageService StorageServiceInterface, logger log.Logge
 	}
 }
 
+// SetReadAuditor sets the auditor used to record resource reads
+func (h *ResourceHandler) SetReadAuditor(auditor *application.ReadAuditor) {
+	h.readAuditor = auditor
+}
+
+// SetRDFNormalizer sets the normalizer canonicalizing RDF that replaces resources in containers
+// that opted in; without one PUT stores documents verbatim
+func (h *ResourceHandler) SetRDFNormalizer(normalizer ResourceRDFNormalizer) {
+	h.rdfNormalizer = normalizer
+}
+
+// SetAccountChecker sets the check GET makes before choosing how to read a resource, so
+// resources of deleted accounts are refused on every read path
+func (h *ResourceHandler) SetAccountChecker(checker ResourceAccountChecker) {
+	h.accountChecker = checker
+}
+
+// SetAccessTracker sets the tracker recording when resources were last read
+func (h *ResourceHandler) SetAccessTracker(tracker *application.ResourceAccessTracker) {
+	h.accessTracker = tracker
+}
+
 // GetResource handles GET requests for resource retrieval with streaming support
 func (h *ResourceHandler) GetResource(ctx khttp.Context) error {
 	// Extract resource ID from path parameters
@@ -44,11 +81,39 @@ func (h *ResourceHandler) GetResource(ctx khttp.Context) error {
 
 	// Get Accept header for content negotiation
 	acceptHeader := ctx.Request().Header.Get("Accept")
+	if !h.mediaTypes().Acceptable(acceptHeader) {
+		return h.mediaTypes().writeNotAcceptable(ctx)
+	}
 	acceptFormat := h.negotiateContentType(acceptHeader)
 
-	// Check if client supports streaming (large files)
+	// Resources of deleted accounts are refused whichever way they would be read
+	if h.accountChecker != nil {
+		if err := h.accountChecker.CheckResourceAccount(context.Background(), id); err != nil {
+			return h.handleStorageError(ctx, err)
+		}
+	}
+
+	// Answer a triple pattern query with the matching triples only
+	if pattern := triplePatternFromQuery(ctx.Request().URL.Query()); !pattern.IsEmpty() {
+		return h.getMatchingTriples(ctx, id, pattern, acceptFormat)
+	}
+
+	// Answer a version diff request with the triples changed between the two versions
+	if versions := ctx.Request().URL.Query().Get("diff"); versions != "" {
+		return h.getVersionDiff(ctx, id, versions)
+	}
+
+	// Serve a single byte range of a binary resource from its stored content
+	if rangeHeader := ctx.Request().Header.Get("Range"); rangeHeader != "" && ctx.Request().Header.Get("If-None-Match") == "" {
+		if handled, err := h.serveResourceRange(ctx, id, rangeHeader, acceptFormat); handled {
+			return err
+		}
+	}
+
+	// Check if client supports streaming (large files). A conditional GET is answered from the
+	// regular retrieval, which carries the version its ETag is compared against.
 	contentLength := ctx.Request().Header.Get("Content-Length")
-	useStreaming := h.shouldUseStreaming(ctx.Request(), contentLength)
+	useStreaming := h.shouldUseStreaming(ctx.Request(), contentLength) && ctx.Request().Header.Get("If-None-Match") == ""
 
 	if useStreaming {
 		return h.streamResourceResponse(ctx, id, acceptFormat)
@@ -60,10 +125,19 @@ func (h *ResourceHandler) GetResource(ctx khttp.Context) error {
 		return h.handleStorageError(ctx, err)
 	}
 
+	// Answer a conditional GET against the representation in the negotiated format
+	ctx.Response().Header().Set("Cache-Control", h.caching().ForResource(resource.GetContentType(), resource.GetMetadata()))
+	if h.etags().apply(ctx, h.etags().Tag(h.resourceVersion(resource), resource.GetContentType())) {
+		return nil
+	}
+
 	// Set response headers
 	ctx.Response().Header().Set("Content-Type", resource.GetContentType())
 	ctx.Response().Header().Set("Content-Length", strconv.Itoa(resource.GetSize()))
-	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateETag(resource)))
+	setInteractionModelLinks(ctx.Response().Header(), id, domain.ResourceInteractionModel(resource))
+	h.setAcceptRanges(ctx.Response().Header(), domain.ResourceInteractionModel(resource))
+
+	h.recordResourceRead(ctx, id, acceptFormat)
 
 	// Write response body
 	ctx.Response().WriteHeader(http.StatusOK)
@@ -71,6 +145,17 @@ func (h *ResourceHandler) GetResource(ctx khttp.Context) error {
 	return err
 }
 
+// recordResourceRead records a resource read for auditing and last-accessed tracking; failures
+// must not affect the response
+func (h *ResourceHandler) recordResourceRead(ctx khttp.Context, id string, acceptFormat string) {
+	h.accessTracker.RecordAccess(id)
+
+	entry := newReadAuditEntry(ctx.Request(), acceptFormat)
+	if err := h.readAuditor.RecordResourceRead(ctx.Request().Context(), id, entry); err != nil {
+		h.logger.Log(log.LevelWarn, "msg", "Failed to record resource read audit event", "resourceID", id, "error", err)
+	}
+}
+
 // PostResource handles POST requests for resource creation with streaming support
 func (h *ResourceHandler) PostResource(ctx khttp.Context) error {
 	// Extract resource ID from path parameters (optional for POST)
@@ -90,6 +175,10 @@ func (h *ResourceHandler) PostResource(ctx khttp.Context) error {
 	if contentType == "" {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "MISSING_CONTENT_TYPE", "Content-Type header is required")
 	}
+	if h.mediaTypes().Refuses(contentType) {
+		return h.mediaTypes().writeUnsupportedMediaType(ctx, contentType)
+	}
+	contentType = h.mediaTypes().Canonical(contentType)
 
 	// Check if streaming should be used
 	contentLength := ctx.Request().Header.Get("Content-Length")
@@ -110,7 +199,7 @@ func (h *ResourceHandler) PostResource(ctx khttp.Context) error {
 	}
 
 	// Store the resource
-	resource, err := h.storageService.StoreResource(context.Background(), id, body, contentType)
+	resource, err := h.storageService.StoreResource(writeContext(ctx), id, body, contentType)
 	if err != nil {
 		return h.handleStorageError(ctx, err)
 	}
@@ -143,18 +232,36 @@ func (h *ResourceHandler) PutResource(ctx khttp.Context) error {
 	if id == "" {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Resource ID is required")
 	}
+	if handled, err := h.checkResourcePreconditions(ctx, id); handled {
+		return err
+	}
 
 	// Get content type from request
 	contentType := ctx.Request().Header.Get("Content-Type")
 	if contentType == "" {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "MISSING_CONTENT_TYPE", "Content-Type header is required")
 	}
+	if h.mediaTypes().Refuses(contentType) {
+		return h.mediaTypes().writeUnsupportedMediaType(ctx, contentType)
+	}
+	contentType = h.mediaTypes().Canonical(contentType)
+
+	// RDF replacing a resource in a normalizing container is canonicalized, which needs the
+	// whole document
+	normalize := false
+	if h.rdfNormalizer != nil {
+		var err error
+		normalize, err = h.rdfNormalizer.NormalizesResourceRDF(context.Background(), id, contentType)
+		if err != nil {
+			return h.handleStorageError(ctx, err)
+		}
+	}
 
 	// Check if streaming should be used
 	contentLength := ctx.Request().Header.Get("Content-Length")
 	useStreaming := h.shouldUseStreaming(ctx.Request(), contentLength)
 
-	if useStreaming {
+	if useStreaming && !normalize {
 		return h.handleStreamingUpload(ctx, id, contentType)
 	}
 
@@ -168,6 +275,13 @@ func (h *ResourceHandler) PutResource(ctx khttp.Context) error {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "EMPTY_BODY", "Request body cannot be empty")
 	}
 
+	if normalize {
+		body, contentType, err = h.rdfNormalizer.NormalizeResourceRDF(context.Background(), id, body, contentType)
+		if err != nil {
+			return h.handleStorageError(ctx, err)
+		}
+	}
+
 	// Check if resource exists to determine response status
 	exists, err := h.storageService.ResourceExists(context.Background(), id)
 	if err != nil {
@@ -175,7 +289,7 @@ func (h *ResourceHandler) PutResource(ctx khttp.Context) error {
 	}
 
 	// Store the resource
-	resource, err := h.storageService.StoreResource(context.Background(), id, body, contentType)
+	resource, err := h.storageService.StoreResource(writeContext(ctx), id, body, contentType)
 	if err != nil {
 		return h.handleStorageError(ctx, err)
 	}
@@ -220,8 +334,12 @@ func (h *ResourceHandler) DeleteResource(ctx khttp.Context) error {
 		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Resource ID is required")
 	}
 
+	if handled, err := h.checkResourcePreconditions(ctx, id); handled {
+		return err
+	}
+
 	// Delete the resource
-	err := h.storageService.DeleteResource(context.Background(), id)
+	err := h.storageService.DeleteResource(writeContext(ctx), id)
 	if err != nil {
 		return h.handleStorageError(ctx, err)
 	}
@@ -251,6 +369,10 @@ func (h *ResourceHandler) HeadResource(ctx khttp.Context) error {
 
 	// Get Accept header for content negotiation
 	acceptHeader := ctx.Request().Header.Get("Accept")
+	if !h.mediaTypes().Acceptable(acceptHeader) {
+		ctx.Response().WriteHeader(http.StatusNotAcceptable)
+		return nil
+	}
 	acceptFormat := h.negotiateContentType(acceptHeader)
 
 	// Retrieve the resource
@@ -260,6 +382,10 @@ func (h *ResourceHandler) HeadResource(ctx khttp.Context) error {
 			ctx.Response().WriteHeader(http.StatusNotFound)
 			return nil
 		}
+		if domain.IsAccountDeleted(err) {
+			ctx.Response().WriteHeader(http.StatusGone)
+			return nil
+		}
 		if domain.IsUnsupportedFormat(err) {
 			ctx.Response().WriteHeader(http.StatusNotAcceptable)
 			return nil
@@ -269,9 +395,13 @@ func (h *ResourceHandler) HeadResource(ctx khttp.Context) error {
 	}
 
 	// Set response headers (same as GET but no body)
+	ctx.Response().Header().Set("Cache-Control", h.caching().ForResource(resource.GetContentType(), resource.GetMetadata()))
+	if h.etags().apply(ctx, h.etags().Tag(h.resourceVersion(resource), resource.GetContentType())) {
+		return nil
+	}
 	ctx.Response().Header().Set("Content-Type", resource.GetContentType())
 	ctx.Response().Header().Set("Content-Length", strconv.Itoa(resource.GetSize()))
-	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateETag(resource)))
+	setInteractionModelLinks(ctx.Response().Header(), id, domain.ResourceInteractionModel(resource))
 
 	ctx.Response().WriteHeader(http.StatusOK)
 	return nil
@@ -280,14 +410,25 @@ func (h *ResourceHandler) HeadResource(ctx khttp.Context) error {
 // OptionsResource handles OPTIONS requests for resource endpoints
 func (h *ResourceHandler) OptionsResource(ctx khttp.Context) error {
 	// Set CORS headers
-	ctx.Response().Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, HEAD, OPTIONS")
-	ctx.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept, Authorization")
+	ctx.Response().Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS")
+	ctx.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Accept, Authorization, If-Match, If-None-Match")
 	ctx.Response().Header().Set("Access-Control-Max-Age", "86400")
+	ctx.Response().Header().Set("Accept-Patch", acceptPatch)
+
+	// Advertise the interaction model of an individual resource
+	if ids := ctx.Vars()["id"]; len(ids) > 0 && ids[0] != "" {
+		resource, err := h.storageService.RetrieveResource(context.Background(), ids[0], "")
+		if err == nil {
+			setInteractionModelLinks(ctx.Response().Header(), ids[0], domain.ResourceInteractionModel(resource))
+		} else if !domain.IsResourceNotFound(err) {
+			return h.handleStorageError(ctx, err)
+		}
+	}
 
 	// Return allowed methods
 	response := map[string]interface{}{
-		"methods": []string{"GET", "POST", "PUT", "DELETE", "HEAD", "OPTIONS"},
-		"formats": []string{"application/ld+json", "text/turtle", "application/rdf+xml"},
+		"methods": []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		"formats": h.mediaTypes().Supported(),
 	}
 
 	return ctx.JSON(http.StatusOK, response)
@@ -295,37 +436,7 @@ func (h *ResourceHandler) OptionsResource(ctx khttp.Context) error {
 
 // negotiateContentType performs content negotiation based on Accept header
 func (h *ResourceHandler) negotiateContentType(acceptHeader string) string {
-	if acceptHeader == "" {
-		return ""
-	}
-
-	// Parse Accept header and find the best match
-	acceptTypes := h.parseAcceptHeader(acceptHeader)
-
-	// Supported RDF formats in order of preference
-	supportedFormats := []string{
-		"application/ld+json",
-		"text/turtle",
-		"application/rdf+xml",
-	}
-
-	// Find the best match
-	for _, acceptType := range acceptTypes {
-		for _, format := range supportedFormats {
-			if h.matchesMediaType(acceptType.mediaType, format) {
-				return format
-			}
-		}
-	}
-
-	// Check for wildcard acceptance
-	for _, acceptType := range acceptTypes {
-		if acceptType.mediaType == "*/*" || acceptType.mediaType == "application/*" {
-			return supportedFormats[0] // Return preferred format
-		}
-	}
-
-	return ""
+	return h.mediaTypes().Negotiate(acceptHeader)
 }
 
 // acceptType represents a parsed Accept header entry
@@ -336,6 +447,11 @@ type acceptType struct {
 
 // parseAcceptHeader parses the Accept header into media types with quality values
 func (h *ResourceHandler) parseAcceptHeader(acceptHeader string) []acceptType {
+	return parseAcceptTypes(acceptHeader)
+}
+
+// parseAcceptTypes parses an Accept header into media types sorted by quality
+func parseAcceptTypes(acceptHeader string) []acceptType {
 	var acceptTypes []acceptType
 
 	parts := strings.Split(acceptHeader, ",")
@@ -381,22 +497,7 @@ func (h *ResourceHandler) parseAcceptHeader(acceptHeader string) []acceptType {
 
 // matchesMediaType checks if an accept type matches a supported format
 func (h *ResourceHandler) matchesMediaType(acceptType, format string) bool {
-	// Exact match
-	if acceptType == format {
-		return true
-	}
-
-	// Handle common aliases
-	switch acceptType {
-	case "application/json":
-		return format == "application/ld+json"
-	case "text/plain":
-		return format == "text/turtle"
-	case "application/xml":
-		return format == "application/rdf+xml"
-	}
-
-	return false
+	return h.mediaTypes().Canonical(acceptType) == format
 }
 
 // handleStorageError converts storage errors to appropriate HTTP responses
@@ -413,9 +514,14 @@ func (h *ResourceHandler) handleStorageError(ctx khttp.Context, err error) error
 			"The requested resource could not be found", storageErr)
 	}
 
+	if domain.IsAccountDeleted(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusGone, "ACCOUNT_DELETED",
+			"The resource belongs to a deleted account", storageErr)
+	}
+
 	if domain.IsUnsupportedFormat(err) {
 		return h.writeDetailedErrorResponse(ctx, http.StatusNotAcceptable, "UNSUPPORTED_FORMAT",
-			"The requested format is not supported. Supported formats: application/ld+json, text/turtle, application/rdf+xml", storageErr)
+			"The requested format is not supported. Supported formats: "+strings.Join(h.mediaTypes().Supported(), ", "), storageErr)
 	}
 
 	if domain.IsInsufficientStorage(err) {
@@ -423,6 +529,11 @@ func (h *ResourceHandler) handleStorageError(ctx khttp.Context, err error) error
 			"Insufficient storage space available to complete the operation", storageErr)
 	}
 
+	if domain.IsGraphTooLarge(err) {
+		return h.writeDetailedErrorResponse(ctx, http.StatusRequestEntityTooLarge, "GRAPH_TOO_LARGE",
+			"The RDF document holds more triples than a resource may", storageErr)
+	}
+
 	if domain.IsDataCorruption(err) {
 		return h.writeDetailedErrorResponse(ctx, http.StatusUnprocessableEntity, "DATA_CORRUPTION",
 			"Data corruption detected. The resource cannot be processed safely", storageErr)
@@ -442,9 +553,27 @@ func (h *ResourceHandler) handleStorageError(ctx khttp.Context, err error) error
 		case "INVALID_RESOURCE":
 			return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "INVALID_RESOURCE",
 				"The resource data is invalid or cannot be processed", storageErr)
+		case "INVALID_FORMAT":
+			return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "INVALID_FORMAT",
+				"The RDF document could not be parsed", storageErr)
 		case "RESOURCE_EXISTS":
 			return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "RESOURCE_EXISTS",
 				"A resource with this ID already exists", storageErr)
+		case "UPLOAD_TOO_LARGE":
+			return h.writeDetailedErrorResponse(ctx, http.StatusRequestEntityTooLarge, "UPLOAD_TOO_LARGE",
+				"The upload is larger than the server accepts", storageErr)
+		case "UPLOAD_NOT_FOUND":
+			return h.writeDetailedErrorResponse(ctx, http.StatusNotFound, "UPLOAD_NOT_FOUND",
+				"The upload does not exist or has expired", storageErr)
+		case "UPLOAD_OFFSET_MISMATCH":
+			return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "UPLOAD_OFFSET_MISMATCH",
+				"The range does not start where the upload left off; resume from Upload-Offset", storageErr)
+		case "UPLOAD_INCOMPLETE":
+			return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "UPLOAD_INCOMPLETE",
+				"The upload has not received all of its bytes", storageErr)
+		case "DUPLICATE_IDENTIFIER":
+			return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "DUPLICATE_IDENTIFIER",
+				"Another resource in this account already uses this dc:identifier", storageErr)
 		case "CHECKSUM_MISMATCH":
 			return h.writeDetailedErrorResponse(ctx, http.StatusUnprocessableEntity, "CHECKSUM_MISMATCH",
 				"Data integrity check failed. The resource may be corrupted", storageErr)
@@ -466,7 +595,6 @@ func (h *ResourceHandler) writeErrorResponse(ctx khttp.Context, status int, code
 
 // writeDetailedErrorResponse writes a comprehensive error response with additional context
 func (h *ResourceHandler) writeDetailedErrorResponse(ctx khttp.Context, status int, code, message string, storageErr *domain.StorageError) error {
-	ctx.Response().Header().Set("Content-Type", "application/json")
 	ctx.Response().Header().Set("Cache-Control", "no-cache")
 
 	// Build error response with comprehensive information
@@ -488,7 +616,7 @@ func (h *ResourceHandler) writeDetailedErrorResponse(ctx khttp.Context, status i
 			safeContext := make(map[string]interface{})
 			for key, value := range storageErr.Context {
 				switch key {
-				case "resourceID", "contentType", "format", "operation", "size":
+				case "resourceID", "contentType", "format", "operation", "size", "maxTriples":
 					safeContext[key] = value
 				}
 			}
@@ -506,31 +634,35 @@ func (h *ResourceHandler) writeDetailedErrorResponse(ctx khttp.Context, status i
 	// Add helpful information for specific error types
 	switch code {
 	case "UNSUPPORTED_FORMAT":
-		errorResponse["supportedFormats"] = []string{
-			"application/ld+json",
-			"text/turtle",
-			"application/rdf+xml",
-		}
+		errorResponse["supportedFormats"] = h.mediaTypes().Supported()
 	case "INSUFFICIENT_STORAGE":
 		errorResponse["suggestion"] = "Try reducing the size of your request or contact the administrator"
 	case "DATA_CORRUPTION":
 		errorResponse["suggestion"] = "Please try uploading the resource again"
 	}
 
-	response := map[string]interface{}{
-		"error": errorResponse,
-	}
-
-	return ctx.JSON(status, response)
+	return writeNegotiatedError(ctx, status, errorResponse)
 }
 
 // generateETag generates an ETag for a resource
-func (h *ResourceHandler) generateETag(resource *domain.Resource) string {
+func (h *ResourceHandler) generateETag(resource domain.Resource) string {
 	// Simple ETag generation based on resource ID and content hash
 	// In production, this could be more sophisticated
 	return fmt.Sprintf("%s-%d", resource.ID(), len(resource.GetData()))
 }
 
+// resourceVersion identifies the stored version of a resource independently of the format it
+// is served in, using the checksum of its stored content when the repository recorded one
+func (h *ResourceHandler) resourceVersion(resource domain.Resource) string {
+	if checksum, ok := resource.GetMetadata()["checksum"].(string); ok && checksum != "" {
+		if len(checksum) > 16 {
+			checksum = checksum[:16]
+		}
+		return fmt.Sprintf("%s-%s", resource.ID(), checksum)
+	}
+	return h.generateETag(resource)
+}
+
 // generateResourceID generates a unique resource ID using KSUID
 func (h *ResourceHandler) generateResourceID() string {
 	// Generate a KSUID which provides lexicographically sortable, globally unique identifiers
@@ -545,7 +677,7 @@ func (h *ResourceHandler) logError(err error, storageErr *domain.StorageError) {
 	// Adjust log level based on error type
 	if storageErr != nil {
 		switch storageErr.Code {
-		case "RESOURCE_NOT_FOUND", "UNSUPPORTED_FORMAT", "INVALID_ID", "INVALID_RESOURCE":
+		case "RESOURCE_NOT_FOUND", "UNSUPPORTED_FORMAT", "INVALID_ID", "INVALID_RESOURCE", "GRAPH_TOO_LARGE", "DUPLICATE_IDENTIFIER":
 			logLevel = log.LevelWarn // Client errors are warnings
 		case "INSUFFICIENT_STORAGE", "DATA_CORRUPTION", "CHECKSUM_MISMATCH":
 			logLevel = log.LevelError // System errors are errors
@@ -622,7 +754,17 @@ func (h *ResourceHandler) shouldUseStreaming(req *http.Request, contentLength st
 // streamResourceResponse handles streaming resource retrieval
 func (h *ResourceHandler) streamResourceResponse(ctx khttp.Context, id string, acceptFormat string) error {
 	// Get streaming reader from storage service
-	reader, contentType, err := h.storageService.StreamResource(context.Background(), id, acceptFormat)
+	var (
+		reader      io.ReadCloser
+		contentType string
+		metadata    map[string]interface{}
+		err         error
+	)
+	if h.resourceStreamer != nil {
+		reader, contentType, metadata, err = h.resourceStreamer.StreamResourceWithMetadata(context.Background(), id, acceptFormat)
+	} else {
+		reader, contentType, err = h.storageService.StreamResource(context.Background(), id, acceptFormat)
+	}
 	if err != nil {
 		return h.handleStorageError(ctx, err)
 	}
@@ -631,7 +773,11 @@ func (h *ResourceHandler) streamResourceResponse(ctx khttp.Context, id string, a
 	// Set response headers for streaming
 	ctx.Response().Header().Set("Content-Type", contentType)
 	ctx.Response().Header().Set("Transfer-Encoding", "chunked")
-	ctx.Response().Header().Set("Cache-Control", "no-cache")
+	ctx.Response().Header().Set("Cache-Control", h.caching().ForResource(contentType, metadata))
+	setInteractionModelLinks(ctx.Response().Header(), id, domain.InteractionModelForContentType(contentType))
+	h.setAcceptRanges(ctx.Response().Header(), domain.InteractionModelForContentType(contentType))
+
+	h.recordResourceRead(ctx, id, acceptFormat)
 
 	// Write response status
 	ctx.Response().WriteHeader(http.StatusOK)
@@ -664,7 +810,7 @@ func (h *ResourceHandler) handleStreamingUpload(ctx khttp.Context, id string, co
 	}
 
 	// Store the resource using streaming
-	resource, err := h.storageService.StoreResourceStream(context.Background(), id, ctx.Request().Body, contentType, contentLength)
+	resource, err := h.storageService.StoreResourceStream(writeContext(ctx), id, ctx.Request().Body, contentType, contentLength)
 	if err != nil {
 		return h.handleStorageError(ctx, err)
 	}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// deletedAccountChecker refuses every resource as belonging to a deleted account
+type deletedAccountChecker struct{}
+
+func (deletedAccountChecker) CheckResourceAccount(ctx context.Context, id string) error {
+	return domain.ErrAccountDeleted.WithOperation("CheckResourceAccount").WithContext("id", id)
+}
+
+func TestResourceHandler_DeletedAccountResources(t *testing.T) {
+	t.Run("GET of a resource awaiting purge is gone on every read path", func(t *testing.T) {
+		requests := map[string]map[string]string{
+			"streamed":  {},
+			"retrieved": {"Content-Length": "0"},
+			"ranged":    {"Content-Length": "0", "Range": "bytes=0-9"},
+		}
+		for name, headers := range requests {
+			t.Run(name, func(t *testing.T) {
+				storageService := &MockStorageService{}
+				handler := NewResourceHandler(storageService, log.DefaultLogger)
+				handler.SetAccountChecker(deletedAccountChecker{})
+
+				ctx := createTestContext("GET", "/resources/doc", nil, map[string][]string{"id": {"doc"}})
+				for header, value := range headers {
+					ctx.Request().Header.Set(header, value)
+				}
+				require.NoError(t, handler.GetResource(ctx))
+
+				response := ctx.(*mockHTTPContext).response
+				assert.Equal(t, http.StatusGone, response.Code)
+				assert.Contains(t, response.Body.String(), "ACCOUNT_DELETED")
+				storageService.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("HEAD of a resource awaiting purge is gone", func(t *testing.T) {
+		storageService := &MockStorageService{}
+		handler := NewResourceHandler(storageService, log.DefaultLogger)
+		storageService.On("RetrieveResource", mock.Anything, "doc", mock.Anything).
+			Return(nil, domain.ErrAccountDeleted.WithOperation("RetrieveResource"))
+
+		ctx := createTestContext("HEAD", "/resources/doc", nil, map[string][]string{"id": {"doc"}})
+		require.NoError(t, handler.HeadResource(ctx))
+		assert.Equal(t, http.StatusGone, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// getVersionDiff answers a resource GET carrying ?diff=<from>,<to> with the triples added and
+// removed between the two stored versions, as JSON with one N-Triples statement per entry
+func (h *ResourceHandler) getVersionDiff(ctx khttp.Context, id, versions string) error {
+	fromVersion, toVersion, ok := strings.Cut(versions, ",")
+	fromVersion, toVersion = strings.TrimSpace(fromVersion), strings.TrimSpace(toVersion)
+	if !ok || fromVersion == "" || toVersion == "" || strings.Contains(toVersion, ",") {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_DIFF",
+			"The diff parameter must name two versions separated by a comma, such as diff=v1,v2")
+	}
+
+	diff, err := h.storageService.DiffResourceVersions(context.Background(), id, fromVersion, toVersion)
+	if err != nil {
+		storageErr, _ := domain.GetStorageError(err)
+		switch {
+		case domain.IsVersioningDisabled(err):
+			h.logError(err, storageErr)
+			return h.writeDetailedErrorResponse(ctx, http.StatusNotFound, "VERSIONING_DISABLED",
+				"Resource versioning is not enabled on this server", storageErr)
+		case domain.IsResourceVersionNotFound(err):
+			h.logError(err, storageErr)
+			return h.writeDetailedErrorResponse(ctx, http.StatusNotFound, "RESOURCE_VERSION_NOT_FOUND",
+				"The requested resource version could not be found", storageErr)
+		}
+		return h.handleStorageError(ctx, err)
+	}
+
+	// Both versions are fixed once stored, so their diff never changes
+	ctx.Response().Header().Set("Cache-Control", h.caching().ForVersion())
+
+	// The diff discloses the resource's content, so it is audited as a read of it
+	h.recordResourceRead(ctx, id, "application/json")
+
+	return ctx.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// ExistenceRequest names the resources a POST /exists checks
+type ExistenceRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// ExistenceResponse reports, for each requested resource, whether it exists
+type ExistenceResponse struct {
+	Exists map[string]bool `json:"exists"`
+}
+
+// SetExistenceChecker sets the batch existence check POST /exists uses; without one, each
+// resource is checked in turn
+func (h *ResourceHandler) SetExistenceChecker(checker ResourceExistenceChecker) {
+	h.existenceChecker = checker
+}
+
+// SetAccessAuthorizer sets the authorizer deciding which resources a caller may probe; without
+// one, as when Web Access Control is disabled, every resource may be probed
+func (h *ResourceHandler) SetAccessAuthorizer(authorizer AccessAuthorizer) {
+	h.accessAuthorizer = authorizer
+}
+
+// CheckExistence handles POST /exists, reporting which of the named resources exist so
+// clients rendering links need not send a HEAD for each. Resources the caller may not read
+// are reported as missing, so the answer reveals nothing a GET would not.
+func (h *ResourceHandler) CheckExistence(ctx khttp.Context) error {
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_BODY", "Failed to read request body")
+	}
+
+	var req ExistenceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON in request body")
+	}
+	if len(req.IDs) == 0 {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "At least one resource ID is required")
+	}
+
+	exists, err := h.resourcesExist(ctx.Request().Context(), req.IDs)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+
+	agent := requestAgent(ctx.Request())
+	for id, found := range exists {
+		if found && !h.canRead(ctx.Request().Context(), agent, id) {
+			exists[id] = false
+		}
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, ExistenceResponse{Exists: exists})
+}
+
+// resourcesExist checks the resources in one call when a batch check is set, else one by one
+func (h *ResourceHandler) resourcesExist(ctx context.Context, ids []string) (map[string]bool, error) {
+	if h.existenceChecker != nil {
+		return h.existenceChecker.ResourcesExist(ctx, ids)
+	}
+
+	exists := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		found, err := h.storageService.ResourceExists(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		exists[id] = found
+	}
+	return exists, nil
+}
+
+// canRead reports whether the agent may read a resource; a failed check counts as a refusal
+func (h *ResourceHandler) canRead(ctx context.Context, agent, id string) bool {
+	if h.accessAuthorizer == nil {
+		return true
+	}
+	decision, err := h.accessAuthorizer.Authorize(ctx, agent, id, domain.AccessMode{Read: true})
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to authorize existence check", "resource", id, "error", err)
+		return false
+	}
+	return decision.Allowed()
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// readableResources lets every caller read the listed resources and nothing else
+type readableResources map[string]bool
+
+func (r readableResources) Authorize(ctx context.Context, userID, resourceID string, required domain.AccessMode) (*application.AccessDecision, error) {
+	decision := &application.AccessDecision{Agent: userID, Required: required}
+	if r[resourceID] {
+		decision.Granted = domain.AccessMode{Read: true}
+	}
+	return decision, nil
+}
+
+func TestResourceHandler_CheckExistence(t *testing.T) {
+	t.Run("should report existence of readable resources only", func(t *testing.T) {
+		storage := new(MockStorageService)
+		storage.On("ResourceExists", mock.Anything, "public-note").Return(true, nil)
+		storage.On("ResourceExists", mock.Anything, "private-note").Return(true, nil)
+		storage.On("ResourceExists", mock.Anything, "missing").Return(false, nil)
+		handler := NewResourceHandler(storage, log.DefaultLogger)
+		handler.SetAccessAuthorizer(readableResources{"public-note": true, "missing": true})
+
+		ctx := createTestContext("POST", "/exists", []byte(`{"ids":["public-note","private-note","missing"]}`), nil)
+		require.NoError(t, handler.CheckExistence(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		require.Equal(t, http.StatusOK, response.Code)
+		var body ExistenceResponse
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, map[string]bool{"public-note": true, "private-note": false, "missing": false}, body.Exists,
+			"an unreadable resource looks the same as a missing one")
+	})
+
+	t.Run("should reject a request naming no resources", func(t *testing.T) {
+		handler := NewResourceHandler(new(MockStorageService), log.DefaultLogger)
+
+		ctx := createTestContext("POST", "/exists", []byte(`{"ids":[]}`), nil)
+		require.NoError(t, handler.CheckExistence(ctx))
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SlugPolicy turns Slug headers into names safe for URLs and storage, following the
+// deployment's conf.SlugRules
+type SlugPolicy struct {
+	allowed   string
+	maxLength int
+}
+
+// defaultSlugPolicy is used by handlers that were not given a policy
+var defaultSlugPolicy = NewSlugPolicy(conf.SlugRules{})
+
+// NewSlugPolicy creates a slug policy from configuration, filling in defaults
+func NewSlugPolicy(rules conf.SlugRules) *SlugPolicy {
+	rules.SetDefaults()
+	return &SlugPolicy{allowed: rules.AllowedCharacters, maxLength: rules.MaxLength}
+}
+
+// SetSlugPolicy sets the policy turning Slug headers into resource names
+func (h *ContainerHandler) SetSlugPolicy(policy *SlugPolicy) {
+	h.slugPolicy = policy
+}
+
+// slugs returns the handler's slug policy, falling back to the default policy
+func (h *ContainerHandler) slugs() *SlugPolicy {
+	if h.slugPolicy == nil {
+		return defaultSlugPolicy
+	}
+	return h.slugPolicy
+}
+
+// SetNamedResourceCreator sets the creator used to honor Slug headers on POST. Without one,
+// posted resources always get generated IDs.
+func (h *ContainerHandler) SetNamedResourceCreator(creator NamedResourceCreator) {
+	h.namedResources = creator
+}
+
+// createPostedResource stores a resource posted to a container, charged to the account owning
+// the container's pod. A usable Slug header names the resource, and the creator resolves
+// collisions atomically; otherwise an ID is generated.
+func (h *ContainerHandler) createPostedResource(ctx khttp.Context, containerID string, body []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	if h.namedResources != nil {
+		if name := h.slugs().ResourceName(ctx.Request().Header.Get("Slug")); name != "" {
+			return h.namedResources.CreateNamedResource(h.containerWriteContext(agentContext(ctx.Request()), containerID), name, body, contentType, metadata)
+		}
+	}
+	return h.storageService.StoreResourceWithMetadata(h.containerWriteContext(writeContext(ctx), containerID), h.generateResourceID(), body, contentType, metadata)
+}
+
+// ResourceName turns a Slug header into a resource name. The slug is percent-decoded; letters,
+// digits and the allowed characters are kept, whitespace becomes '-' when '-' is allowed and
+// anything else, path separators included, is dropped. Leading and trailing dots and dashes are
+// trimmed, so a slug with nothing usable yields "".
+func (p *SlugPolicy) ResourceName(slug string) string {
+	if decoded, err := url.PathUnescape(slug); err == nil {
+		slug = decoded
+	}
+	dash := strings.ContainsRune(p.allowed, '-')
+
+	var name strings.Builder
+	for _, r := range strings.TrimSpace(slug) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r < utf8.RuneSelf && strings.ContainsRune(p.allowed, r):
+			name.WriteRune(r)
+		case (r == ' ' || r == '\t') && dash:
+			name.WriteRune('-')
+		}
+		if name.Len() >= p.maxLength {
+			break
+		}
+	}
+
+	return strings.Trim(name.String(), ".-")
+}
+
+// slugToResourceName turns a Slug header into a resource name under the default rules
+func slugToResourceName(slug string) string {
+	return defaultSlugPolicy.ResourceName(slug)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postTargetContainerService accepts every resource posted to any container
+type postTargetContainerService struct {
+	ContainerServiceInterface
+	added []string
+}
+
+func (s *postTargetContainerService) ContainerExists(ctx context.Context, id string) (bool, error) {
+	return true, nil
+}
+
+func (s *postTargetContainerService) GetContainer(ctx context.Context, id string) (domain.ContainerResource, error) {
+	return domain.NewContainer(ctx, id, "", domain.BasicContainer), nil
+}
+
+func (s *postTargetContainerService) NormalizeContainerRDF(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, string, error) {
+	return data, contentType, nil
+}
+
+func (s *postTargetContainerService) InheritedMetadata(ctx context.Context, containerID string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (s *postTargetContainerService) AddResource(ctx context.Context, containerID, resourceID string, resource domain.Resource) error {
+	s.added = append(s.added, resourceID)
+	return nil
+}
+
+// suffixingCreator names resources as a storage service with one taken name would
+type suffixingCreator struct {
+	taken map[string]bool
+}
+
+func (c *suffixingCreator) CreateNamedResource(ctx context.Context, name string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	id := name
+	if c.taken[name] {
+		id = name + "-1"
+	}
+	c.taken[id] = true
+	return domain.NewResource(ctx, id, contentType, data), nil
+}
+
+// takenNameCreator rejects every requested name
+type takenNameCreator struct{}
+
+func (takenNameCreator) CreateNamedResource(ctx context.Context, name string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	return nil, domain.ErrResourceAlreadyExists.WithContext("name", name)
+}
+
+// accountRecordingCreator records the account each resource is created on behalf of
+type accountRecordingCreator struct {
+	accounts []string
+}
+
+func (c *accountRecordingCreator) CreateNamedResource(ctx context.Context, name string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	c.accounts = append(c.accounts, domain.AccountIDFromContext(ctx))
+	return domain.NewResource(ctx, name, contentType, data), nil
+}
+
+// podAccounts resolves pods from a fixed table
+type podAccounts map[string]string
+
+func (p podAccounts) PodAccountID(ctx context.Context, id string) (string, error) {
+	return p[id], nil
+}
+
+func TestContainerHandler_PostResource_Slug(t *testing.T) {
+	newPost := func(slug string) *mockHTTPContext {
+		ctx := createTestContext("POST", "/containers/notes", []byte("hello"), map[string][]string{"id": {"notes"}})
+		ctx.Request().Header.Set("Content-Type", "text/plain")
+		ctx.Request().Header.Set("Slug", slug)
+		return ctx.(*mockHTTPContext)
+	}
+
+	t.Run("should name the resource after the slug and suffix a taken name", func(t *testing.T) {
+		containers := &postTargetContainerService{}
+		handler := NewContainerHandler(containers, nil, log.DefaultLogger)
+		handler.SetNamedResourceCreator(&suffixingCreator{taken: map[string]bool{}})
+
+		first := newPost("My Notes")
+		require.NoError(t, handler.PostResource(first))
+		assert.Equal(t, http.StatusCreated, first.response.Code)
+		assert.Equal(t, "/resources/My-Notes", first.response.Header().Get("Location"))
+
+		second := newPost("My Notes")
+		require.NoError(t, handler.PostResource(second))
+		assert.Equal(t, "/resources/My-Notes-1", second.response.Header().Get("Location"))
+
+		assert.Equal(t, []string{"My-Notes", "My-Notes-1"}, containers.added)
+	})
+
+	t.Run("should answer 409 when taken names are rejected", func(t *testing.T) {
+		handler := NewContainerHandler(&postTargetContainerService{}, nil, log.DefaultLogger)
+		handler.SetNamedResourceCreator(takenNameCreator{})
+
+		ctx := newPost("notes")
+		require.NoError(t, handler.PostResource(ctx))
+		assert.Equal(t, http.StatusConflict, ctx.response.Code)
+		assert.Contains(t, ctx.response.Body.String(), "RESOURCE_NAME_TAKEN")
+	})
+
+	t.Run("should charge the resource to the pod of the container", func(t *testing.T) {
+		creator := &accountRecordingCreator{}
+		handler := NewContainerHandler(&postTargetContainerService{}, nil, log.DefaultLogger)
+		handler.SetNamedResourceCreator(creator)
+		handler.SetPodAccountResolver(podAccounts{"notes": "alice"})
+
+		ctx := newPost("notes")
+		ctx.Request().Header.Set("X-Account-ID", "mallory")
+		require.NoError(t, handler.PostResource(ctx))
+		assert.Equal(t, http.StatusCreated, ctx.response.Code)
+		assert.Equal(t, []string{"alice"}, creator.accounts)
+	})
+}
+
+func TestSlugToResourceName(t *testing.T) {
+	tests := []struct {
+		slug string
+		want string
+	}{
+		{"notes", "notes"},
+		{"  Trip Photos  ", "Trip-Photos"},
+		{"report%202024.ttl", "report-2024.ttl"},
+		{"../../etc/passwd", "etcpasswd"},
+		{"café?", "caf"},
+		{"...", ""},
+		{"", ""},
+		{strings.Repeat("a", 200), strings.Repeat("a", defaultSlugPolicy.maxLength)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, slugToResourceName(tt.slug), "slug %q", tt.slug)
+	}
+}
+
+func TestSlugPolicy_ResourceName(t *testing.T) {
+	policy := NewSlugPolicy(conf.SlugRules{AllowedCharacters: "_~", MaxLength: 8})
+
+	assert.Equal(t, "my_notes", policy.ResourceName("my_notes"))
+	assert.Equal(t, "TripPhot", policy.ResourceName("Trip Photos"), "whitespace is dropped when '-' is not allowed")
+	assert.Equal(t, "a~bcd", policy.ResourceName("a~b.c/d"))
+	assert.Equal(t, "", policy.ResourceName("///"))
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockResourceRDFNormalizer mocks the canonicalization of RDF replacing resources
+type mockResourceRDFNormalizer struct {
+	mock.Mock
+}
+
+func (m *mockResourceRDFNormalizer) NormalizesResourceRDF(ctx context.Context, resourceID string, contentType string) (bool, error) {
+	args := m.Called(ctx, resourceID, contentType)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockResourceRDFNormalizer) NormalizeResourceRDF(ctx context.Context, resourceID string, data []byte, contentType string) ([]byte, string, error) {
+	args := m.Called(ctx, resourceID, data, contentType)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).([]byte), args.String(1), args.Error(2)
+}
+
+func TestResourceHandler_PutResource_NormalizesRDF(t *testing.T) {
+	data := []byte("<http://example.org/s> <http://example.org/p> [ ] .")
+	canonical := []byte("<http://example.org/s> <http://example.org/p> _:c14n0 .\n")
+
+	t.Run("should store the canonical form, even without a Content-Length", func(t *testing.T) {
+		mockService := new(MockStorageService)
+		normalizer := new(mockResourceRDFNormalizer)
+		handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+		handler.SetRDFNormalizer(normalizer)
+
+		normalizer.On("NormalizesResourceRDF", mock.Anything, "doc", "text/turtle").Return(true, nil)
+		normalizer.On("NormalizeResourceRDF", mock.Anything, "doc", data, "text/turtle").Return(canonical, "text/turtle", nil)
+		mockService.On("ResourceExists", mock.Anything, "doc").Return(true, nil)
+		mockService.On("StoreResource", mock.Anything, "doc", canonical, "text/turtle").
+			Return(domain.NewResource(context.Background(), "doc", "text/turtle", canonical), nil)
+
+		ctx := createTestContext("PUT", "/resources/doc", data, map[string][]string{"id": {"doc"}})
+		ctx.Request().Header.Set("Content-Type", "text/turtle")
+		require.NoError(t, handler.PutResource(ctx))
+
+		assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertExpectations(t)
+		normalizer.AssertExpectations(t)
+	})
+
+	t.Run("should refuse documents that cannot be parsed", func(t *testing.T) {
+		mockService := new(MockStorageService)
+		normalizer := new(mockResourceRDFNormalizer)
+		handler := NewResourceHandler(mockService, log.NewStdLogger(io.Discard))
+		handler.SetRDFNormalizer(normalizer)
+
+		normalizer.On("NormalizesResourceRDF", mock.Anything, "doc", "text/turtle").Return(true, nil)
+		normalizer.On("NormalizeResourceRDF", mock.Anything, "doc", data, "text/turtle").
+			Return(nil, "", domain.ErrInvalidFormat.WithOperation("NormalizeResourceRDF"))
+
+		ctx := createTestContext("PUT", "/resources/doc", data, map[string][]string{"id": {"doc"}})
+		ctx.Request().Header.Set("Content-Type", "text/turtle")
+		require.NoError(t, handler.PutResource(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+		mockService.AssertNotCalled(t, "StoreResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// patchContentTypes are the media types of the patch documents PATCH accepts
+var patchContentTypes = []string{domain.SPARQLUpdateMediaType, domain.N3PatchMediaType}
+
+// acceptPatch is the Accept-Patch header value advertising the accepted patch formats
+var acceptPatch = strings.Join(patchContentTypes, ", ")
+
+// PatchResource handles PATCH requests modifying an RDF resource with a SPARQL Update or an
+// N3 Patch, selected by the request's Content-Type
+func (h *ResourceHandler) PatchResource(ctx khttp.Context) error {
+	// Extract resource ID from path parameters
+	vars := ctx.Vars()
+	id := ""
+	if len(vars["id"]) > 0 {
+		id = vars["id"][0]
+	}
+
+	if id == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "Resource ID is required")
+	}
+
+	ctx.Response().Header().Set("Accept-Patch", acceptPatch)
+	if handled, err := h.checkResourcePreconditions(ctx, id); handled {
+		return err
+	}
+
+	mediaType, _, _ := strings.Cut(ctx.Request().Header.Get("Content-Type"), ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if !slices.Contains(patchContentTypes, mediaType) {
+		return h.writeErrorResponse(ctx, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+			"PATCH requests must be sent as one of: "+acceptPatch)
+	}
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_BODY", "Failed to read request body")
+	}
+
+	resource, err := h.storageService.PatchResource(context.Background(), id, mediaType, string(body))
+	if err != nil {
+		storageErr, _ := domain.GetStorageError(err)
+		switch {
+		case domain.IsInvalidPatch(err):
+			h.logError(err, storageErr)
+			return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "INVALID_PATCH",
+				"The patch document is malformed or uses unsupported features", storageErr)
+		case domain.IsPatchConflict(err):
+			h.logError(err, storageErr)
+			return h.writeDetailedErrorResponse(ctx, http.StatusConflict, "PATCH_CONFLICT",
+				"The patch's conditions do not hold for the current state of the resource", storageErr)
+		case domain.IsUnsupportedFormat(err):
+			h.logError(err, storageErr)
+			return h.writeDetailedErrorResponse(ctx, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+				"Only RDF resources can be patched", storageErr)
+		}
+		return h.handleStorageError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s"`, h.generateETag(resource)))
+
+	response := map[string]interface{}{
+		"id":          resource.ID(),
+		"contentType": resource.GetContentType(),
+		"size":        resource.GetSize(),
+		"message":     "Resource patched successfully",
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceHandler_PatchResource(t *testing.T) {
+	update := `INSERT DATA { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" }`
+
+	tests := []struct {
+		name           string
+		contentType    string
+		result         domain.Resource
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "applies SPARQL update",
+			contentType:    "application/sparql-update; charset=utf-8",
+			result:         domain.NewResource(context.Background(), "card", "text/turtle", []byte("<#me> <http://xmlns.com/foaf/0.1/nick> \"Al\" .\n")),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "malformed update",
+			contentType:    "application/sparql-update",
+			err:            domain.WrapStorageError(errors.New("SPARQL parse error at offset 52: expected '}'"), domain.ErrInvalidPatch.Code, "malformed SPARQL update"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_PATCH",
+		},
+		{
+			name:           "non-RDF resource",
+			contentType:    "application/sparql-update",
+			err:            domain.ErrUnsupportedFormat.WithContext("reason", "only RDF sources can be patched"),
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   "UNSUPPORTED_MEDIA_TYPE",
+		},
+		{
+			name:           "missing resource",
+			contentType:    "application/sparql-update",
+			err:            domain.ErrResourceNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "RESOURCE_NOT_FOUND",
+		},
+		{
+			name:           "applies N3 patch",
+			contentType:    "text/n3",
+			result:         domain.NewResource(context.Background(), "card", "text/turtle", []byte("<#me> <http://xmlns.com/foaf/0.1/nick> \"Al\" .\n")),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "N3 patch conditions not met",
+			contentType:    "text/n3",
+			err:            domain.WrapStorageError(errors.New("the where formula matches the document 0 times, not exactly once"), domain.ErrPatchConflict.Code, "N3 patch conditions not met"),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "PATCH_CONFLICT",
+		},
+		{
+			name:           "other patch formats",
+			contentType:    "application/json-patch+json",
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   "UNSUPPORTED_MEDIA_TYPE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockStorageService)
+			handler := NewResourceHandler(mockService, log.DefaultLogger)
+			if tt.result != nil || tt.err != nil {
+				mediaType, _, _ := strings.Cut(tt.contentType, ";")
+				mockService.On("PatchResource", mock.Anything, "card", mediaType, update).Return(tt.result, tt.err)
+			}
+
+			ctx := createTestContext("PATCH", "/resources/card", []byte(update), map[string][]string{"id": {"card"}})
+			ctx.Request().Header.Set("Content-Type", tt.contentType)
+			require.NoError(t, handler.PatchResource(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, tt.expectedStatus, response.Code)
+			assert.Equal(t, "application/sparql-update, text/n3", response.Header().Get("Accept-Patch"))
+			if tt.expectedCode != "" {
+				assert.Contains(t, response.Body.String(), tt.expectedCode)
+			}
+			if tt.result == nil && tt.err == nil {
+				mockService.AssertNotCalled(t, "PatchResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
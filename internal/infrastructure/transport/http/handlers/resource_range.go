@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// errRangeNotSatisfiable reports a byte range lying wholly outside a resource
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// byteRange is a range of a resource's bytes; both ends are inclusive
+type byteRange struct {
+	start int64
+	end   int64
+}
+
+// length returns the number of bytes in the range
+func (r byteRange) length() int64 {
+	return r.end - r.start + 1
+}
+
+// contentRange formats the Content-Range header value of the range within size bytes
+func (r byteRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.end, size)
+}
+
+// SetContentOpener sets the source of random access to stored content that byte ranges of
+// binary resources are read from; without one, Range headers are ignored
+func (h *ResourceHandler) SetContentOpener(opener ResourceContentOpener) {
+	h.contentOpener = opener
+}
+
+// parseByteRange reads a single-range Range header, such as bytes=0-499, bytes=500- or the
+// suffix form bytes=-500, against a resource of size bytes. It reports false for headers the
+// server ignores, serving the whole resource: other units, several ranges, or malformed
+// values. A range starting past the end is errRangeNotSatisfiable.
+func parseByteRange(header string, size int64) (byteRange, bool, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return byteRange{}, false, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return byteRange{}, false, nil
+	}
+
+	// bytes=-N asks for the last N bytes
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return byteRange{}, false, nil
+		}
+		if suffix == 0 || size == 0 {
+			return byteRange{}, false, errRangeNotSatisfiable
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return byteRange{start: size - suffix, end: size - 1}, true, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, false, nil
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return byteRange{}, false, nil
+		}
+	}
+	if start >= size {
+		return byteRange{}, false, errRangeNotSatisfiable
+	}
+	if end >= size {
+		end = size - 1
+	}
+	return byteRange{start: start, end: end}, true, nil
+}
+
+// serveResourceRange answers a Range request for a binary resource with 206 Partial Content,
+// or 416 Range Not Satisfiable when the range lies outside it. It reports false, writing
+// nothing, when the request is better served whole: RDF resources, whose representation is
+// negotiated, and Range headers the server ignores.
+func (h *ResourceHandler) serveResourceRange(ctx khttp.Context, id, rangeHeader, acceptFormat string) (bool, error) {
+	if h.contentOpener == nil {
+		return false, nil
+	}
+
+	content, metadata, err := h.contentOpener.OpenResourceContent(ctx.Request().Context(), id)
+	if err != nil {
+		return true, h.handleStorageError(ctx, err)
+	}
+	defer content.Close()
+
+	if domain.InteractionModelForContentType(metadata.ContentType) != domain.NonRDFSourceModel {
+		return false, nil
+	}
+
+	header := ctx.Response().Header()
+	size := metadata.Size
+	requested, ok, err := parseByteRange(rangeHeader, size)
+	if errors.Is(err, errRangeNotSatisfiable) {
+		header.Set("Accept-Ranges", "bytes")
+		header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		return true, h.writeErrorResponse(ctx, http.StatusRequestedRangeNotSatisfiable, "RANGE_NOT_SATISFIABLE", "The requested range lies outside the resource")
+	}
+	if !ok {
+		return false, nil
+	}
+
+	if _, err := content.Seek(requested.start, io.SeekStart); err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to seek resource content", "error", err.Error(), "resourceID", id)
+		return true, h.writeErrorResponse(ctx, http.StatusInternalServerError, "STREAM_ERROR", "Failed to read the requested range")
+	}
+
+	header.Set("Content-Type", metadata.ContentType)
+	header.Set("Content-Length", strconv.FormatInt(requested.length(), 10))
+	header.Set("Content-Range", requested.contentRange(size))
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Cache-Control", h.caching().ForResource(metadata.ContentType, metadata.Tags))
+	setInteractionModelLinks(header, id, domain.NonRDFSourceModel)
+
+	h.recordResourceRead(ctx, id, acceptFormat)
+
+	ctx.Response().WriteHeader(http.StatusPartialContent)
+	if _, err := io.CopyN(ctx.Response(), content, requested.length()); err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to stream resource range", "error", err.Error(), "resourceID", id)
+		return true, err
+	}
+	return true, nil
+}
+
+// setAcceptRanges advertises byte range support on full responses for binary resources
+func (h *ResourceHandler) setAcceptRanges(header http.Header, model domain.InteractionModel) {
+	if h.contentOpener != nil && model == domain.NonRDFSourceModel {
+		header.Set("Accept-Ranges", "bytes")
+	}
+}
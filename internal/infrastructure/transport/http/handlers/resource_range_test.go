@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryContent opens resource content held in memory, keyed by resource ID
+type memoryContent map[string]domain.Resource
+
+func (m memoryContent) OpenResourceContent(ctx context.Context, id string) (io.ReadSeekCloser, *domain.ResourceMetadata, error) {
+	resource, ok := m[id]
+	if !ok {
+		return nil, nil, domain.ErrResourceNotFound
+	}
+	metadata := &domain.ResourceMetadata{ID: id, ContentType: resource.GetContentType(), Size: int64(resource.GetSize())}
+	return nopReadSeekCloser{bytes.NewReader(resource.GetData())}, metadata, nil
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error {
+	return nil
+}
+
+func TestParseByteRange(t *testing.T) {
+	tests := []struct {
+		header         string
+		want           byteRange
+		ok             bool
+		notSatisfiable bool
+	}{
+		{"bytes=0-4", byteRange{0, 4}, true, false},
+		{"bytes=5-", byteRange{5, 9}, true, false},
+		{"bytes=-3", byteRange{7, 9}, true, false},
+		{"bytes=-30", byteRange{0, 9}, true, false},
+		{"bytes=8-100", byteRange{8, 9}, true, false},
+		{"bytes=10-", byteRange{}, false, true},
+		{"bytes=-0", byteRange{}, false, true},
+		{"bytes=0-1,4-5", byteRange{}, false, false},
+		{"items=0-4", byteRange{}, false, false},
+		{"bytes=4-2", byteRange{}, false, false},
+		{"bytes=abc", byteRange{}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok, err := parseByteRange(tt.header, 10)
+			assert.Equal(t, tt.notSatisfiable, err != nil)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResourceHandler_GetResource_Range(t *testing.T) {
+	content := memoryContent{
+		"clip.mp4": domain.NewResource(context.Background(), "clip.mp4", "video/mp4", []byte("0123456789")),
+		"card.ttl": domain.NewResource(context.Background(), "card.ttl", "text/turtle", []byte("<a> <b> c.")),
+	}
+
+	tests := []struct {
+		name         string
+		id           string
+		rangeHeader  string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{"first bytes", "clip.mp4", "bytes=0-3", http.StatusPartialContent, "0123", "bytes 0-3/10"},
+		{"open-ended", "clip.mp4", "bytes=7-", http.StatusPartialContent, "789", "bytes 7-9/10"},
+		{"suffix", "clip.mp4", "bytes=-2", http.StatusPartialContent, "89", "bytes 8-9/10"},
+		{"past the end", "clip.mp4", "bytes=20-30", http.StatusRequestedRangeNotSatisfiable, "", "bytes */10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewResourceHandler(new(MockStorageService), log.DefaultLogger)
+			handler.SetContentOpener(content)
+
+			ctx := createTestContext("GET", "/resources/"+tt.id, nil, map[string][]string{"id": {tt.id}})
+			ctx.Request().Header.Set("Range", tt.rangeHeader)
+			require.NoError(t, handler.GetResource(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, tt.status, response.Code)
+			assert.Equal(t, tt.contentRange, response.Header().Get("Content-Range"))
+			assert.Equal(t, "bytes", response.Header().Get("Accept-Ranges"))
+			if tt.status == http.StatusPartialContent {
+				assert.Equal(t, tt.body, response.Body.String())
+				assert.Equal(t, "video/mp4", response.Header().Get("Content-Type"))
+			}
+		})
+	}
+
+	t.Run("RDF resources are served whole", func(t *testing.T) {
+		mockService := new(MockStorageService)
+		mockService.On("StreamResource", mock.Anything, "card.ttl", mock.Anything).Return(io.NopCloser(strings.NewReader("<a> <b> c.")), "text/turtle", nil)
+		handler := NewResourceHandler(mockService, log.DefaultLogger)
+		handler.SetContentOpener(content)
+
+		ctx := createTestContext("GET", "/resources/card.ttl", nil, map[string][]string{"id": {"card.ttl"}})
+		ctx.Request().Header.Set("Range", "bytes=0-3")
+		require.NoError(t, handler.GetResource(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "<a> <b> c.", response.Body.String())
+		assert.Empty(t, response.Header().Get("Content-Range"))
+	})
+}
@@ -24,6 +24,14 @@ func (m *MockStorageService) StoreResource(ctx context.Context, id string, data
 	return args.Get(0).(domain.Resource), args.Error(1)
 }
 
+func (m *MockStorageService) StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	args := m.Called(ctx, id, data, contentType, metadata)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
 func (m *MockStorageService) RetrieveResource(ctx context.Context, id string, acceptFormat string) (domain.Resource, error) {
 	args := m.Called(ctx, id, acceptFormat)
 	if args.Get(0) == nil {
@@ -58,6 +66,27 @@ func (m *MockStorageService) StoreResourceStream(ctx context.Context, id string,
 	return args.Get(0).(domain.Resource), args.Error(1)
 }
 
+func (m *MockStorageService) PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error) {
+	args := m.Called(ctx, id, mediaType, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
+func (m *MockStorageService) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	args := m.Called(ctx, id, pattern, acceptFormat)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Resource), args.Error(1)
+}
+
+func (m *MockStorageService) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	args := m.Called(ctx, id, fromVersion, toVersion)
+	return args.Get(0).(domain.GraphDiff), args.Error(1)
+}
+
 func TestNewResourceHandler(t *testing.T) {
 	mockService := new(MockStorageService)
 	logger := log.NewStdLogger(io.Discard)
@@ -287,7 +316,7 @@ func TestResourceHandler_GenerateETag(t *testing.T) {
 	handler := NewResourceHandler(mockService, logger)
 
 	// Create test resource
-	resource := domain.NewResource("test-id", "application/ld+json", []byte(`{"test": "data"}`))
+	resource := domain.NewResource(context.Background(), "test-id", "application/ld+json", []byte(`{"test": "data"}`))
 
 	// Test ETag generation
 	etag := handler.generateETag(resource)
@@ -301,7 +330,7 @@ func TestResourceHandler_GenerateETag(t *testing.T) {
 	assert.Equal(t, etag, etag2)
 
 	// Test that different resource generates different ETag
-	resource2 := domain.NewResource("test-id-2", "application/ld+json", []byte(`{"test": "different"}`))
+	resource2 := domain.NewResource(context.Background(), "test-id-2", "application/ld+json", []byte(`{"test": "different"}`))
 	etag3 := handler.generateETag(resource2)
 	assert.NotEqual(t, etag, etag3)
 }
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// triplePatternFromQuery reads the subject, predicate and object query parameters of a
+// resource GET
+func triplePatternFromQuery(query url.Values) domain.TriplePattern {
+	return domain.TriplePattern{
+		Subject:   query.Get("subject"),
+		Predicate: query.Get("predicate"),
+		Object:    query.Get("object"),
+	}
+}
+
+// getMatchingTriples answers a resource GET carrying a triple pattern with only the triples of
+// the resource that match it, in the negotiated format
+func (h *ResourceHandler) getMatchingTriples(ctx khttp.Context, id string, pattern domain.TriplePattern, acceptFormat string) error {
+	resource, err := h.storageService.QueryResourceTriples(context.Background(), id, pattern, acceptFormat)
+	if err != nil {
+		if domain.IsInvalidTriplePattern(err) {
+			storageErr, _ := domain.GetStorageError(err)
+			h.logError(err, storageErr)
+			return h.writeDetailedErrorResponse(ctx, http.StatusBadRequest, "INVALID_TRIPLE_PATTERN",
+				"The subject, predicate and object parameters must be IRIs, literals or blank nodes in N-Triples syntax", storageErr)
+		}
+		return h.handleStorageError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", resource.GetContentType())
+	ctx.Response().Header().Set("Content-Length", strconv.Itoa(resource.GetSize()))
+	setInteractionModelLinks(ctx.Response().Header(), id, domain.ResourceInteractionModel(resource))
+
+	h.recordResourceRead(ctx, id, acceptFormat)
+
+	ctx.Response().WriteHeader(http.StatusOK)
+	_, err = ctx.Response().Write(resource.GetData())
+	return err
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceHandler_GetResourceTriplePattern(t *testing.T) {
+	pattern := domain.TriplePattern{Subject: "<#me>", Predicate: "http://xmlns.com/foaf/0.1/name"}
+	matched := "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n"
+
+	tests := []struct {
+		name           string
+		result         domain.Resource
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "returns matching triples",
+			result:         domain.NewResource(context.Background(), "card", "text/turtle", []byte(matched)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   matched,
+		},
+		{
+			name:           "malformed pattern",
+			err:            domain.WrapStorageError(errors.New("invalid subject: unterminated IRI"), domain.ErrInvalidTriplePattern.Code, "malformed triple pattern"),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "INVALID_TRIPLE_PATTERN",
+		},
+		{
+			name:           "missing resource",
+			err:            domain.ErrResourceNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "RESOURCE_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockStorageService)
+			handler := NewResourceHandler(mockService, log.DefaultLogger)
+			mockService.On("QueryResourceTriples", mock.Anything, "card", pattern, "text/turtle").Return(tt.result, tt.err)
+
+			ctx := createTestContext("GET", "/resources/card?subject=%3C%23me%3E&predicate=http%3A%2F%2Fxmlns.com%2Ffoaf%2F0.1%2Fname", nil, map[string][]string{"id": {"card"}})
+			ctx.Request().Header.Set("Accept", "text/turtle")
+			require.NoError(t, handler.GetResource(ctx))
+
+			response := ctx.(*mockHTTPContext).response
+			assert.Equal(t, tt.expectedStatus, response.Code)
+			assert.Contains(t, response.Body.String(), tt.expectedBody)
+			mockService.AssertNotCalled(t, "RetrieveResource", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
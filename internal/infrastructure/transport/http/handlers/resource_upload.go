@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+const (
+	// headerUploadOffset carries how many bytes of an upload the server has received
+	headerUploadOffset = "Upload-Offset"
+	// headerUploadLength carries the declared size of an upload
+	headerUploadLength = "Upload-Length"
+)
+
+// CreateUploadRequest opens a resumable upload of a resource
+type CreateUploadRequest struct {
+	ResourceID  string `json:"resource_id"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// UploadResponse describes an upload session and how far it has got
+type UploadResponse struct {
+	ID          string    `json:"id"`
+	ResourceID  string    `json:"resource_id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Offset      int64     `json:"offset"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// SetResourceUploader sets the service receiving resumable uploads; without one the /uploads
+// endpoints answer 404
+func (h *ResourceHandler) SetResourceUploader(uploader ResourceUploader) {
+	h.uploader = uploader
+}
+
+// CreateUpload handles POST /uploads, opening a session for a resource to be sent in ranges.
+// The caller needs Write on the resource when it exists and Append when the upload creates it.
+func (h *ResourceHandler) CreateUpload(ctx khttp.Context) error {
+	if h.uploader == nil {
+		return h.writeUploadsDisabled(ctx)
+	}
+
+	var request CreateUploadRequest
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&request); err != nil {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_BODY", "Request body must be a JSON upload request")
+	}
+	if request.ResourceID == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "resource_id is required")
+	}
+	if request.ContentType == "" {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "MISSING_CONTENT_TYPE", "content_type is required")
+	}
+	if h.mediaTypes().Refuses(request.ContentType) {
+		return h.mediaTypes().writeUnsupportedMediaType(ctx, request.ContentType)
+	}
+
+	exists, err := h.storageService.ResourceExists(ctx.Request().Context(), request.ResourceID)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+	if allowed, err := h.authorizeUpload(ctx, request.ResourceID, exists); !allowed {
+		return err
+	}
+
+	session, err := h.uploader.CreateUpload(writeContext(ctx), request.ResourceID, h.mediaTypes().Canonical(request.ContentType), request.Size)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Location", "/uploads/"+session.ID)
+	return h.writeUpload(ctx, http.StatusCreated, session)
+}
+
+// GetUpload handles GET and HEAD /uploads/{id}, reporting the offset to resume an upload from
+func (h *ResourceHandler) GetUpload(ctx khttp.Context) error {
+	if h.uploader == nil {
+		return h.writeUploadsDisabled(ctx)
+	}
+
+	session, err := h.uploader.GetUpload(writeContext(ctx), uploadID(ctx))
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+	return h.writeUpload(ctx, http.StatusOK, session)
+}
+
+// AppendUpload handles PUT /uploads/{id}, receiving the range of the upload named by the
+// Content-Range header. The range must start at the session's offset; a range starting
+// elsewhere is answered 409 with the offset to resume from.
+func (h *ResourceHandler) AppendUpload(ctx khttp.Context) error {
+	if h.uploader == nil {
+		return h.writeUploadsDisabled(ctx)
+	}
+
+	id := uploadID(ctx)
+	chunk, total, ok := parseContentRange(ctx.Request().Header.Get("Content-Range"))
+	if !ok {
+		return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_CONTENT_RANGE",
+			"Content-Range header of the form bytes start-end/size is required")
+	}
+	if total >= 0 {
+		session, err := h.uploader.GetUpload(writeContext(ctx), id)
+		if err != nil {
+			return h.handleStorageError(ctx, err)
+		}
+		if total != session.Size {
+			return h.writeErrorResponse(ctx, http.StatusBadRequest, "INVALID_CONTENT_RANGE",
+				fmt.Sprintf("Content-Range size %d does not match the upload size %d", total, session.Size))
+		}
+	}
+
+	session, err := h.uploader.AppendUpload(writeContext(ctx), id, chunk.start, io.LimitReader(ctx.Request().Body, chunk.length()))
+	if err != nil {
+		if session.ID != "" {
+			ctx.Response().Header().Set(headerUploadOffset, strconv.FormatInt(session.Offset, 10))
+		}
+		return h.handleStorageError(ctx, err)
+	}
+	return h.writeUpload(ctx, http.StatusOK, session)
+}
+
+// CompleteUpload handles POST /uploads/{id}/complete, storing the received content as the
+// upload's resource. Access is checked again, as it may have changed since the upload opened.
+func (h *ResourceHandler) CompleteUpload(ctx khttp.Context) error {
+	if h.uploader == nil {
+		return h.writeUploadsDisabled(ctx)
+	}
+
+	id := uploadID(ctx)
+	session, err := h.uploader.GetUpload(writeContext(ctx), id)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+	exists, err := h.storageService.ResourceExists(ctx.Request().Context(), session.ResourceID)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+	if allowed, err := h.authorizeUpload(ctx, session.ResourceID, exists); !allowed {
+		return err
+	}
+
+	resource, err := h.uploader.CompleteUpload(writeContext(ctx), id)
+	if err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/json")
+	ctx.Response().Header().Set("ETag", fmt.Sprintf(`"%s-%d"`, resource.ID(), resource.GetSize()))
+
+	status := http.StatusOK
+	message := "Resource updated successfully"
+	if !exists {
+		status = http.StatusCreated
+		message = "Resource created successfully"
+		ctx.Response().Header().Set("Location", fmt.Sprintf("/resources/%s", resource.ID()))
+	}
+
+	return ctx.JSON(status, map[string]interface{}{
+		"id":          resource.ID(),
+		"contentType": resource.GetContentType(),
+		"size":        resource.GetSize(),
+		"message":     message,
+	})
+}
+
+// AbortUpload handles DELETE /uploads/{id}, discarding an upload and what it received
+func (h *ResourceHandler) AbortUpload(ctx khttp.Context) error {
+	if h.uploader == nil {
+		return h.writeUploadsDisabled(ctx)
+	}
+
+	id := uploadID(ctx)
+	if err := h.uploader.AbortUpload(writeContext(ctx), id); err != nil {
+		return h.handleStorageError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"id":      id,
+		"message": "Upload aborted successfully",
+	})
+}
+
+// authorizeUpload checks the caller may store an upload as its resource: replacing an existing
+// resource needs Write and creating one needs Append. Uploads sit outside the resource routes
+// Web Access Control covers, so the check is made here. A refusal is answered and reported as
+// false.
+func (h *ResourceHandler) authorizeUpload(ctx khttp.Context, resourceID string, exists bool) (bool, error) {
+	if h.accessAuthorizer == nil {
+		return true, nil
+	}
+
+	required := domain.AccessMode{Append: true}
+	if exists {
+		required = domain.AccessMode{Write: true}
+	}
+	decision, err := h.accessAuthorizer.Authorize(ctx.Request().Context(), requestAgent(ctx.Request()), resourceID, required)
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to authorize upload", "resource", resourceID, "error", err)
+		return false, h.writeErrorResponse(ctx, http.StatusInternalServerError, "AUTHORIZATION_FAILED", "failed to check access to the resource")
+	}
+	if !decision.Allowed() {
+		if !decision.Authenticated() {
+			return false, h.writeErrorResponse(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "authentication is required to access the resource")
+		}
+		return false, h.writeErrorResponse(ctx, http.StatusForbidden, "ACCESS_DENIED", "the agent is not allowed to access the resource")
+	}
+	return true, nil
+}
+
+// writeUpload answers with an upload session, carrying its progress in headers as well
+func (h *ResourceHandler) writeUpload(ctx khttp.Context, status int, session domain.UploadSession) error {
+	header := ctx.Response().Header()
+	header.Set(headerUploadOffset, strconv.FormatInt(session.Offset, 10))
+	header.Set(headerUploadLength, strconv.FormatInt(session.Size, 10))
+	header.Set("Cache-Control", "no-store")
+
+	return ctx.JSON(status, UploadResponse{
+		ID:          session.ID,
+		ResourceID:  session.ResourceID,
+		ContentType: session.ContentType,
+		Size:        session.Size,
+		Offset:      session.Offset,
+		ExpiresAt:   session.ExpiresAt,
+	})
+}
+
+// writeUploadsDisabled answers upload requests while no upload service is configured
+func (h *ResourceHandler) writeUploadsDisabled(ctx khttp.Context) error {
+	return h.writeErrorResponse(ctx, http.StatusNotFound, "UPLOADS_DISABLED", "Resumable uploads are not enabled on this server")
+}
+
+// uploadID returns the upload session named in the path
+func uploadID(ctx khttp.Context) string {
+	if ids := ctx.Vars()["id"]; len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// parseContentRange reads a Content-Range header such as bytes 0-499/1000 or bytes 0-499/*.
+// The total is -1 when the client leaves it unstated.
+func parseContentRange(header string) (byteRange, int64, bool) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes ")
+	if !ok {
+		return byteRange{}, 0, false
+	}
+	span, totalSpec, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	if !ok {
+		return byteRange{}, 0, false
+	}
+	first, last, ok := strings.Cut(span, "-")
+	if !ok {
+		return byteRange{}, 0, false
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, 0, false
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < start {
+		return byteRange{}, 0, false
+	}
+
+	total := int64(-1)
+	if totalSpec != "*" {
+		if total, err = strconv.ParseInt(totalSpec, 10, 64); err != nil || total <= end {
+			return byteRange{}, 0, false
+		}
+	}
+	return byteRange{start: start, end: end}, total, true
+}
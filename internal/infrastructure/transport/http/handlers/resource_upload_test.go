@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryUploader keeps one upload session in memory
+type memoryUploader struct {
+	session domain.UploadSession
+	data    []byte
+}
+
+func (u *memoryUploader) CreateUpload(ctx context.Context, resourceID, contentType string, size int64) (domain.UploadSession, error) {
+	u.session = domain.UploadSession{ID: "upload-1", ResourceID: resourceID, ContentType: contentType, Size: size}
+	return u.session, nil
+}
+
+func (u *memoryUploader) GetUpload(ctx context.Context, uploadID string) (domain.UploadSession, error) {
+	if uploadID != u.session.ID {
+		return domain.UploadSession{}, domain.WrapStorageError(nil, domain.ErrUploadNotFound.Code, domain.ErrUploadNotFound.Message)
+	}
+	return u.session, nil
+}
+
+func (u *memoryUploader) AppendUpload(ctx context.Context, uploadID string, offset int64, chunk io.Reader) (domain.UploadSession, error) {
+	session, err := u.GetUpload(ctx, uploadID)
+	if err != nil {
+		return session, err
+	}
+	if offset != session.Offset {
+		return session, domain.WrapStorageError(nil, domain.ErrUploadOffsetMismatch.Code, domain.ErrUploadOffsetMismatch.Message)
+	}
+	data, _ := io.ReadAll(chunk)
+	u.data = append(u.data, data...)
+	u.session.Offset += int64(len(data))
+	return u.session, nil
+}
+
+func (u *memoryUploader) CompleteUpload(ctx context.Context, uploadID string) (domain.Resource, error) {
+	session, err := u.GetUpload(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Received() {
+		return nil, domain.WrapStorageError(nil, domain.ErrUploadIncomplete.Code, domain.ErrUploadIncomplete.Message)
+	}
+	return domain.NewResource(ctx, session.ResourceID, session.ContentType, u.data), nil
+}
+
+func (u *memoryUploader) AbortUpload(ctx context.Context, uploadID string) error {
+	_, err := u.GetUpload(ctx, uploadID)
+	return err
+}
+
+// grantedAccess grants every caller the same modes on every resource
+type grantedAccess domain.AccessMode
+
+func (g *grantedAccess) Authorize(ctx context.Context, userID, resourceID string, required domain.AccessMode) (*application.AccessDecision, error) {
+	return &application.AccessDecision{Agent: userID, Required: required, Granted: domain.AccessMode(*g)}, nil
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		header string
+		want   byteRange
+		total  int64
+		ok     bool
+	}{
+		{"bytes 0-4/10", byteRange{0, 4}, 10, true},
+		{"bytes 5-9/*", byteRange{5, 9}, -1, true},
+		{"bytes 5-10/10", byteRange{}, 0, false},
+		{"bytes 4-2/10", byteRange{}, 0, false},
+		{"bytes 0-4", byteRange{}, 0, false},
+		{"bytes=0-4/10", byteRange{}, 0, false},
+		{"", byteRange{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, total, ok := parseContentRange(tt.header)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.total, total)
+		})
+	}
+}
+
+func TestResourceHandler_Uploads(t *testing.T) {
+	uploader := &memoryUploader{}
+	mockService := new(MockStorageService)
+	mockService.On("ResourceExists", mock.Anything, "video").Return(false, nil)
+	handler := NewResourceHandler(mockService, log.DefaultLogger)
+	handler.SetResourceUploader(uploader)
+
+	put := func(contentRange, body string) *mockHTTPContext {
+		ctx := createTestContext("PUT", "/uploads/upload-1", []byte(body), map[string][]string{"id": {"upload-1"}})
+		ctx.Request().Header.Set("Content-Range", contentRange)
+		require.NoError(t, handler.AppendUpload(ctx))
+		return ctx.(*mockHTTPContext)
+	}
+
+	ctx := createTestContext("POST", "/uploads", []byte(`{"resource_id":"video","content_type":"video/mp4","size":10}`), nil)
+	require.NoError(t, handler.CreateUpload(ctx))
+	response := ctx.(*mockHTTPContext).response
+	require.Equal(t, http.StatusCreated, response.Code)
+	assert.Equal(t, "/uploads/upload-1", response.Header().Get("Location"))
+	assert.Equal(t, "0", response.Header().Get(headerUploadOffset))
+
+	response = put("bytes 0-4/10", "01234").response
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "5", response.Header().Get(headerUploadOffset))
+
+	t.Run("a range not starting at the offset is refused", func(t *testing.T) {
+		response := put("bytes 0-4/10", "01234").response
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Equal(t, "5", response.Header().Get(headerUploadOffset))
+	})
+
+	t.Run("a range sized for another upload is refused", func(t *testing.T) {
+		response := put("bytes 5-9/20", "56789").response
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("an incomplete upload cannot be completed", func(t *testing.T) {
+		ctx := createTestContext("POST", "/uploads/upload-1/complete", nil, map[string][]string{"id": {"upload-1"}})
+		require.NoError(t, handler.CompleteUpload(ctx))
+		assert.Equal(t, http.StatusConflict, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	response = put("bytes 5-9/*", "56789").response
+	require.Equal(t, http.StatusOK, response.Code)
+
+	ctx = createTestContext("POST", "/uploads/upload-1/complete", nil, map[string][]string{"id": {"upload-1"}})
+	require.NoError(t, handler.CompleteUpload(ctx))
+	response = ctx.(*mockHTTPContext).response
+	require.Equal(t, http.StatusCreated, response.Code)
+	assert.Equal(t, "/resources/video", response.Header().Get("Location"))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, float64(10), body["size"])
+
+	t.Run("unknown uploads are not found", func(t *testing.T) {
+		ctx := createTestContext("GET", "/uploads/missing", nil, map[string][]string{"id": {"missing"}})
+		require.NoError(t, handler.GetUpload(ctx))
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	})
+}
+
+func TestResourceHandler_Uploads_AccessControl(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	createBody := []byte(`{"resource_id":"report","content_type":"text/plain","size":5}`)
+
+	newHandler := func(exists bool, access *grantedAccess) (*ResourceHandler, *memoryUploader) {
+		uploader := &memoryUploader{}
+		mockService := new(MockStorageService)
+		mockService.On("ResourceExists", mock.Anything, "report").Return(exists, nil)
+		handler := NewResourceHandler(mockService, log.DefaultLogger)
+		handler.SetResourceUploader(uploader)
+		handler.SetAccessAuthorizer(access)
+		return handler, uploader
+	}
+
+	t.Run("anonymous callers cannot replace a resource", func(t *testing.T) {
+		handler, uploader := newHandler(true, &grantedAccess{Read: true, Append: true})
+
+		ctx := createTestContext("POST", "/uploads", createBody, nil)
+		require.NoError(t, handler.CreateUpload(ctx))
+
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+		assert.Empty(t, uploader.session.ID)
+	})
+
+	t.Run("agents without Write cannot replace a resource", func(t *testing.T) {
+		handler, uploader := newHandler(true, &grantedAccess{Read: true, Append: true})
+
+		ctx := createTestContext("POST", "/uploads", createBody, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.CreateUpload(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+		assert.Empty(t, uploader.session.ID)
+	})
+
+	t.Run("Append is enough to create a resource", func(t *testing.T) {
+		handler, _ := newHandler(false, &grantedAccess{Append: true})
+
+		ctx := createTestContext("POST", "/uploads", createBody, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.CreateUpload(ctx))
+
+		assert.Equal(t, http.StatusCreated, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("completion is refused once access is revoked", func(t *testing.T) {
+		access := &grantedAccess{Write: true}
+		handler, uploader := newHandler(true, access)
+
+		ctx := createTestContext("POST", "/uploads", createBody, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.CreateUpload(ctx))
+		require.Equal(t, http.StatusCreated, ctx.(*mockHTTPContext).response.Code)
+		uploader.data = []byte("01234")
+		uploader.session.Offset = 5
+
+		*access = grantedAccess{Read: true}
+		ctx = createTestContext("POST", "/uploads/upload-1/complete", nil, map[string][]string{"id": {"upload-1"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+		require.NoError(t, handler.CompleteUpload(ctx))
+
+		assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+	})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// serverMethods are the HTTP methods the server supports on some resource
+var serverMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// ServerCapabilities describes what the server supports as a whole, independent of any one
+// resource: its methods, the RDF formats it reads and writes, how clients authenticate and
+// which optional features are turned on
+type ServerCapabilities struct {
+	Methods     []string        `json:"methods"`
+	Formats     []string        `json:"formats"`
+	AuthMethods []string        `json:"authMethods"`
+	Features    map[string]bool `json:"features"`
+}
+
+// ServerCapabilitiesFromConfig derives the server's capabilities from its configuration
+func ServerCapabilitiesFromConfig(config *conf.Container, auth *conf.Auth) ServerCapabilities {
+	capabilities := ServerCapabilities{
+		Methods:     append([]string(nil), serverMethods...),
+		Formats:     defaultMediaTypePolicy.Supported(),
+		AuthMethods: []string{},
+		Features:    map[string]bool{},
+	}
+
+	if config != nil {
+		capabilities.Formats = NewMediaTypePolicy(config.MediaTypes).Supported()
+		capabilities.Features["feeds"] = config.FeedsEnabled
+		capabilities.Features["csvExport"] = config.CSVExportEnabled
+		capabilities.Features["notifications"] = config.Notifications.Enabled
+		capabilities.Features["liveNotifications"] = config.Notifications.Live.Enabled
+		capabilities.Features["accessTracking"] = config.AccessTracking.Enabled
+		capabilities.Features["contentTransforms"] = len(config.ContentTransformers) > 0
+	}
+
+	if auth != nil {
+		capabilities.Features["webAccessControl"] = auth.WebAccessControl
+		if len(auth.AdminTokens) > 0 {
+			capabilities.AuthMethods = append(capabilities.AuthMethods, "Bearer")
+		}
+		if auth.DPoP.Enabled || auth.DPoP.Required {
+			capabilities.AuthMethods = append(capabilities.AuthMethods, "DPoP")
+		}
+		providers := make([]string, 0, len(auth.OAuthRedirectURIs))
+		for provider := range auth.OAuthRedirectURIs {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+		for _, provider := range providers {
+			capabilities.AuthMethods = append(capabilities.AuthMethods, "OAuth2:"+provider)
+		}
+	}
+
+	return capabilities
+}
+
+// ServerCapabilitiesHandler answers OPTIONS * with the server-wide capabilities, a one-shot
+// probe that does not target a resource. Capabilities are sent as headers and as a JSON body.
+type ServerCapabilitiesHandler struct {
+	capabilities ServerCapabilities
+	logger       log.Logger
+}
+
+// NewServerCapabilitiesHandler creates a new server capabilities handler
+func NewServerCapabilitiesHandler(capabilities ServerCapabilities, logger log.Logger) *ServerCapabilitiesHandler {
+	return &ServerCapabilitiesHandler{
+		capabilities: capabilities,
+		logger:       logger,
+	}
+}
+
+// Wrap answers OPTIONS * requests and passes every other request on to next. OPTIONS * has no
+// path to route on, so it is intercepted before the router.
+func (h *ServerCapabilitiesHandler) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && r.RequestURI == "*" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ServeHTTP writes the server-wide capabilities
+func (h *ServerCapabilitiesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	formats := strings.Join(h.capabilities.Formats, ", ")
+
+	header := w.Header()
+	header.Set("Allow", strings.Join(h.capabilities.Methods, ", "))
+	header.Set("Accept-Post", formats)
+	header.Set("Accept-Put", formats)
+	header.Set("X-Auth-Methods", strings.Join(h.capabilities.AuthMethods, ", "))
+	header.Set("X-Features", strings.Join(h.enabledFeatures(), ", "))
+	header.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(h.capabilities); err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to write server capabilities", "error", err)
+	}
+}
+
+// enabledFeatures lists the names of the features that are turned on, sorted
+func (h *ServerCapabilitiesHandler) enabledFeatures() []string {
+	enabled := make([]string, 0, len(h.capabilities.Features))
+	for feature, on := range h.capabilities.Features {
+		if on {
+			enabled = append(enabled, feature)
+		}
+	}
+	sort.Strings(enabled)
+	return enabled
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/application"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// SessionHandler handles HTTP requests for the caller's sign-in sessions
+type SessionHandler struct {
+	sessionService application.SessionService
+	logger         log.Logger
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(sessionService application.SessionService, logger log.Logger) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+// SessionResponse represents the HTTP response for a single session
+type SessionResponse struct {
+	ID             string `json:"id"`
+	CreatedAt      string `json:"created_at"`
+	LastActivityAt string `json:"last_activity_at"`
+	UserAgent      string `json:"user_agent"`
+	IPAddress      string `json:"ip_address"`
+}
+
+// SessionListResponse represents the HTTP response for a session listing
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// StartSession records a session for a user who just signed in, keeping the user agent and
+// IP address of the request so the session can be recognised later
+func (h *SessionHandler) StartSession(ctx khttp.Context, userID string) (*domain.Session, error) {
+	req := ctx.Request()
+	return h.sessionService.StartSession(req.Context(), userID, req.UserAgent(), clientIPAddress(req))
+}
+
+// ListMySessions handles GET /auth/sessions, listing the active sessions of the user the
+// request's verified access token was issued to
+func (h *SessionHandler) ListMySessions(ctx khttp.Context) error {
+	userID := requestUserID(ctx.Request())
+	if userID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	sessions, err := h.sessionService.ListSessions(ctx.Request().Context(), userID)
+	if err != nil {
+		h.logger.Log(log.LevelError, "msg", "Failed to list sessions", "user", userID, "error", err.Error())
+		return h.handleError(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+	}
+
+	response := SessionListResponse{Sessions: make([]SessionResponse, len(sessions))}
+	for i, session := range sessions {
+		response.Sessions[i] = SessionResponse{
+			ID:             session.ID,
+			CreatedAt:      session.CreatedAt.Format(time.RFC3339),
+			LastActivityAt: session.LastActivityAt.Format(time.RFC3339),
+			UserAgent:      session.UserAgent,
+			IPAddress:      session.IPAddress,
+		}
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// RevokeSession handles DELETE /auth/sessions/{id}. Sessions of other users are answered as not
+// found, the same as sessions that do not exist.
+func (h *SessionHandler) RevokeSession(ctx khttp.Context) error {
+	userID := requestUserID(ctx.Request())
+	if userID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	vars := ctx.Vars()
+	idSlice, exists := vars["id"]
+	if !exists || len(idSlice) == 0 || strings.TrimSpace(idSlice[0]) == "" {
+		return h.handleError(ctx, http.StatusBadRequest, "MISSING_SESSION_ID", "Session ID is required")
+	}
+
+	if err := h.sessionService.RevokeSession(ctx.Request().Context(), userID, idSlice[0]); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return h.handleError(ctx, http.StatusNotFound, "NOT_FOUND", "Session not found")
+		}
+		h.logger.Log(log.LevelError, "msg", "Failed to revoke session", "user", userID, "error", err.Error())
+		return h.handleError(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+	}
+
+	h.logger.Log(log.LevelInfo, "msg", "Session revoked", "user", userID, "session", idSlice[0])
+	ctx.Response().WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (h *SessionHandler) handleError(ctx khttp.Context, status int, code, message string) error {
+	response := map[string]interface{}{
+		"error":   code,
+		"message": message,
+	}
+	return ctx.JSON(status, response)
+}
+
+// clientIPAddress returns the address a request came from: the first hop of X-Forwarded-For
+// when a proxy set it, otherwise the connection's remote address
+func clientIPAddress(req *http.Request) string {
+	if forwarded := req.Header.Get("X-Forwarded-For"); forwarded != "" {
+		first, _, _ := strings.Cut(forwarded, ",")
+		if ip := strings.TrimSpace(first); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSessionService is a mock implementation of SessionService
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) StartSession(ctx context.Context, userID, userAgent, ipAddress string) (*domain.Session, error) {
+	args := m.Called(ctx, userID, userAgent, ipAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Session), args.Error(1)
+}
+
+func (m *MockSessionService) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Session), args.Error(1)
+}
+
+func (m *MockSessionService) TouchSession(ctx context.Context, userID, sessionID string) error {
+	return m.Called(ctx, userID, sessionID).Error(0)
+}
+
+func (m *MockSessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	return m.Called(ctx, userID, sessionID).Error(0)
+}
+
+// authenticateAs makes a test request carry a verified identity, as middleware.Authenticate
+// does for requests with a valid access token
+func authenticateAs(ctx khttp.Context, identity middleware.Identity) {
+	request := ctx.Request()
+	*request = *request.WithContext(middleware.WithIdentity(request.Context(), identity))
+}
+
+func TestSessionHandler_ListMySessions(t *testing.T) {
+	t.Run("should require a verified identity", func(t *testing.T) {
+		handler := NewSessionHandler(&MockSessionService{}, log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/auth/sessions", nil, nil)
+		ctx.Request().Header.Set("X-User-ID", "user-1")
+		require.NoError(t, handler.ListMySessions(ctx))
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should list the caller's sessions", func(t *testing.T) {
+		mockService := &MockSessionService{}
+		handler := NewSessionHandler(mockService, log.DefaultLogger)
+
+		started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockService.On("ListSessions", mock.Anything, "user-1").Return([]*domain.Session{
+			{ID: "s-1", UserID: "user-1", UserAgent: "Firefox", IPAddress: "192.0.2.1", CreatedAt: started, LastActivityAt: started.Add(time.Hour)},
+		}, nil)
+
+		ctx := createTestContext("GET", "/auth/sessions", nil, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.ListMySessions(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		require.Equal(t, http.StatusOK, response.Code)
+		var body SessionListResponse
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, []SessionResponse{{
+			ID:             "s-1",
+			CreatedAt:      "2026-01-02T03:04:05Z",
+			LastActivityAt: "2026-01-02T04:04:05Z",
+			UserAgent:      "Firefox",
+			IPAddress:      "192.0.2.1",
+		}}, body.Sessions)
+	})
+}
+
+func TestSessionHandler_RevokeSession(t *testing.T) {
+	t.Run("should revoke the caller's session", func(t *testing.T) {
+		mockService := &MockSessionService{}
+		handler := NewSessionHandler(mockService, log.DefaultLogger)
+		mockService.On("RevokeSession", mock.Anything, "user-1", "s-1").Return(nil)
+
+		ctx := createTestContext("DELETE", "/auth/sessions/s-1", nil, map[string][]string{"id": {"s-1"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.RevokeSession(ctx))
+		assert.Equal(t, http.StatusNoContent, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should answer sessions of other users as not found", func(t *testing.T) {
+		mockService := &MockSessionService{}
+		handler := NewSessionHandler(mockService, log.DefaultLogger)
+		mockService.On("RevokeSession", mock.Anything, "user-2", "s-1").
+			Return(fmt.Errorf("failed to revoke session: %w", domain.ErrSessionNotFound))
+
+		ctx := createTestContext("DELETE", "/auth/sessions/s-1", nil, map[string][]string{"id": {"s-1"}})
+		authenticateAs(ctx, middleware.Identity{Subject: "user-2"})
+		require.NoError(t, handler.RevokeSession(ctx))
+		assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+	})
+}
+
+func TestSessionHandler_StartSessionCapturesClient(t *testing.T) {
+	mockService := &MockSessionService{}
+	handler := NewSessionHandler(mockService, log.DefaultLogger)
+	session := &domain.Session{ID: "s-1"}
+	mockService.On("StartSession", mock.Anything, "user-1", "Firefox", "203.0.113.7").Return(session, nil)
+
+	ctx := createTestContext("POST", "/login", nil, nil)
+	ctx.Request().Header.Set("User-Agent", "Firefox")
+	ctx.Request().Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	started, err := handler.StartSession(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Same(t, session, started)
+}
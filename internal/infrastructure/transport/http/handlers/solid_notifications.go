@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// maxSubscriptionBodySize bounds a subscription request body
+const maxSubscriptionBodySize = 64 << 10
+
+// subscriptionRequestBody is the JSON-LD subscription request of the Solid Notifications
+// Protocol; the @context is accepted but not interpreted
+type subscriptionRequestBody struct {
+	Type   string `json:"type"`
+	Topic  string `json:"topic"`
+	SendTo string `json:"sendTo"`
+}
+
+// SolidNotificationHandler serves the Solid Notifications Protocol subscription resource and
+// the channels it opens
+type SolidNotificationHandler struct {
+	subscriber NotificationSubscriber
+	logger     log.Logger
+}
+
+// NewSolidNotificationHandler creates a new Solid notification handler; a nil subscriber
+// answers every request with 404, as when notifications are disabled
+func NewSolidNotificationHandler(subscriber NotificationSubscriber, logger log.Logger) *SolidNotificationHandler {
+	return &SolidNotificationHandler{
+		subscriber: subscriber,
+		logger:     logger,
+	}
+}
+
+// Subscribe opens a notification channel on the container named by the request's topic and
+// answers 201 Created with the channel description
+func (h *SolidNotificationHandler) Subscribe(ctx khttp.Context) error {
+	if h.subscriber == nil {
+		return h.writeDisabled(ctx)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxSubscriptionBodySize))
+	if err != nil {
+		return h.writeError(ctx, http.StatusBadRequest, "INVALID_BODY", "failed to read request body")
+	}
+
+	var request subscriptionRequestBody
+	if err := json.Unmarshal(body, &request); err != nil {
+		return h.writeError(ctx, http.StatusBadRequest, "INVALID_BODY", "request body must be a JSON-LD subscription request")
+	}
+	if request.Type == "" {
+		request.Type = domain.WebhookChannel2023
+	}
+
+	baseURL := requestBaseURL(ctx.Request())
+	containerID, ok := topicContainerID(baseURL, request.Topic)
+	if !ok {
+		return h.writeError(ctx, http.StatusBadRequest, "INVALID_TOPIC", "topic must be the URI of a container on this server")
+	}
+
+	channel, err := h.subscriber.Subscribe(agentContext(ctx.Request()), application.SubscriptionRequest{
+		Type:        request.Type,
+		Topic:       request.Topic,
+		ContainerID: containerID,
+		SendTo:      request.SendTo,
+	})
+	if err != nil {
+		return h.handleChannelError(ctx, err)
+	}
+
+	h.logger.Log(log.LevelInfo, "msg", "Opened notification channel", "id", channel.ID, "container", containerID)
+	ctx.Response().Header().Set("Location", channelURL(baseURL, channel.ID))
+	return h.writeChannel(ctx, http.StatusCreated, baseURL, channel)
+}
+
+// GetChannel returns the description of an open channel; channels opened by another agent
+// answer 404
+func (h *SolidNotificationHandler) GetChannel(ctx khttp.Context) error {
+	if h.subscriber == nil {
+		return h.writeDisabled(ctx)
+	}
+
+	channel, err := h.subscriber.GetChannel(agentContext(ctx.Request()), ctx.Vars().Get("id"))
+	if err != nil {
+		return h.handleChannelError(ctx, err)
+	}
+
+	return h.writeChannel(ctx, http.StatusOK, requestBaseURL(ctx.Request()), channel)
+}
+
+// DeleteChannel closes a channel; no further notifications are sent on it. Only the agent that
+// opened the channel may close it; others are answered 404.
+func (h *SolidNotificationHandler) DeleteChannel(ctx khttp.Context) error {
+	if h.subscriber == nil {
+		return h.writeDisabled(ctx)
+	}
+
+	id := ctx.Vars().Get("id")
+	if err := h.subscriber.Unsubscribe(agentContext(ctx.Request()), id); err != nil {
+		return h.handleChannelError(ctx, err)
+	}
+
+	h.logger.Log(log.LevelInfo, "msg", "Closed notification channel", "id", id)
+	ctx.Response().WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// writeChannel writes a channel description as JSON-LD
+func (h *SolidNotificationHandler) writeChannel(ctx khttp.Context, status int, baseURL string, channel domain.NotificationChannel) error {
+	ctx.Response().Header().Set("Content-Type", "application/ld+json")
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	ctx.Response().WriteHeader(status)
+	return json.NewEncoder(ctx.Response()).Encode(map[string]interface{}{
+		"@context": application.SolidNotificationContext,
+		"id":       channelURL(baseURL, channel.ID),
+		"type":     channel.Type,
+		"topic":    channel.Topic,
+		"sendTo":   channel.SendTo,
+	})
+}
+
+// handleChannelError maps subscription and channel failures to responses
+func (h *SolidNotificationHandler) handleChannelError(ctx khttp.Context, err error) error {
+	switch {
+	case domain.IsUnsupportedChannelType(err):
+		return h.writeError(ctx, http.StatusUnprocessableEntity, "UNSUPPORTED_CHANNEL_TYPE", "only WebhookChannel2023 channels are offered")
+	case domain.IsNotificationChannelNotFound(err):
+		return h.writeError(ctx, http.StatusNotFound, "CHANNEL_NOT_FOUND", "no notification channel has this ID")
+	case domain.IsResourceNotFound(err):
+		return h.writeError(ctx, http.StatusNotFound, "TOPIC_NOT_FOUND", "the topic container does not exist")
+	case domain.IsAccessDenied(err):
+		return h.writeError(ctx, http.StatusForbidden, "ACCESS_DENIED", "subscribing requires Read access to the topic")
+	}
+
+	if storageErr, ok := domain.GetStorageError(err); ok && storageErr.Code == domain.ErrInvalidResource.Code {
+		message := "invalid subscription request"
+		if reason, ok := storageErr.Context["reason"].(string); ok {
+			message = reason
+		}
+		return h.writeError(ctx, http.StatusBadRequest, "INVALID_SUBSCRIPTION", message)
+	}
+
+	h.logger.Log(log.LevelError, "msg", "Notification channel request failed", "error", err)
+	return h.writeError(ctx, http.StatusInternalServerError, "NOTIFICATION_CHANNEL_FAILED", err.Error())
+}
+
+// writeDisabled answers requests while notifications are not enabled
+func (h *SolidNotificationHandler) writeDisabled(ctx khttp.Context) error {
+	return h.writeError(ctx, http.StatusNotFound, "NOTIFICATIONS_DISABLED", "notifications are not enabled")
+}
+
+// writeError writes an error response
+func (h *SolidNotificationHandler) writeError(ctx khttp.Context, status int, code, message string) error {
+	return ctx.JSON(status, map[string]interface{}{
+		"error":   code,
+		"message": message,
+	})
+}
+
+// channelURL returns the URI of a notification channel
+func channelURL(baseURL, id string) string {
+	return baseURL + "/notifications/channels/" + url.PathEscape(id)
+}
+
+// topicContainerID returns the ID of the container a topic URI names, which must be on the
+// server at baseURL
+func topicContainerID(baseURL, topic string) (string, bool) {
+	return topicPathID(baseURL, topic, "/containers/")
+}
+
+// topicResourceID returns the ID of the resource a topic URI names, which must be on the
+// server at baseURL
+func topicResourceID(baseURL, topic string) (string, bool) {
+	return topicPathID(baseURL, topic, "/resources/")
+}
+
+// topicPathID returns the single path segment following prefix in a topic URI on the server
+// at baseURL
+func topicPathID(baseURL, topic, prefix string) (string, bool) {
+	if !strings.HasPrefix(topic, baseURL+prefix) {
+		return "", false
+	}
+
+	parsed, err := url.Parse(topic)
+	if err != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", false
+	}
+
+	id := strings.Trim(strings.TrimPrefix(parsed.Path, prefix), "/")
+	if id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockNotificationSubscriber mocks the opening and closing of notification channels
+type mockNotificationSubscriber struct {
+	mock.Mock
+}
+
+func (m *mockNotificationSubscriber) Subscribe(ctx context.Context, request application.SubscriptionRequest) (domain.NotificationChannel, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(domain.NotificationChannel), args.Error(1)
+}
+
+func (m *mockNotificationSubscriber) GetChannel(ctx context.Context, channelID string) (domain.NotificationChannel, error) {
+	args := m.Called(ctx, channelID)
+	return args.Get(0).(domain.NotificationChannel), args.Error(1)
+}
+
+func (m *mockNotificationSubscriber) Unsubscribe(ctx context.Context, channelID string) error {
+	args := m.Called(ctx, channelID)
+	return args.Error(0)
+}
+
+func TestSolidNotificationHandler_Subscribe(t *testing.T) {
+	subscriber := &mockNotificationSubscriber{}
+	handler := NewSolidNotificationHandler(subscriber, log.DefaultLogger)
+
+	subscriber.On("Subscribe", mock.Anything, application.SubscriptionRequest{
+		Type:        domain.WebhookChannel2023,
+		Topic:       "http://example.com/containers/photos",
+		ContainerID: "photos",
+		SendTo:      "https://app.example/hook",
+	}).Return(domain.NotificationChannel{
+		ID:          "channel-1",
+		Type:        domain.WebhookChannel2023,
+		Topic:       "http://example.com/containers/photos",
+		ContainerID: "photos",
+		SendTo:      "https://app.example/hook",
+	}, nil)
+
+	body := []byte(`{"@context":["https://www.w3.org/ns/solid/notification/v1"],"type":"WebhookChannel2023","topic":"http://example.com/containers/photos","sendTo":"https://app.example/hook"}`)
+	ctx := createTestContext("POST", "/notifications/subscriptions", body, nil)
+	assert.NoError(t, handler.Subscribe(ctx))
+
+	response := ctx.(*mockHTTPContext).response
+	assert.Equal(t, http.StatusCreated, response.Code)
+	assert.Equal(t, "application/ld+json", response.Header().Get("Content-Type"))
+	assert.Equal(t, "http://example.com/notifications/channels/channel-1", response.Header().Get("Location"))
+	assert.Contains(t, response.Body.String(), `"id":"http://example.com/notifications/channels/channel-1"`)
+	assert.Contains(t, response.Body.String(), `"sendTo":"https://app.example/hook"`)
+	subscriber.AssertExpectations(t)
+}
+
+func TestSolidNotificationHandler_SubscribeRejectsRequests(t *testing.T) {
+	subscriber := &mockNotificationSubscriber{}
+	handler := NewSolidNotificationHandler(subscriber, log.DefaultLogger)
+
+	subscriber.On("Subscribe", mock.Anything, mock.MatchedBy(func(request application.SubscriptionRequest) bool {
+		return request.Type == "WebSocketChannel2023"
+	})).Return(domain.NotificationChannel{}, domain.ErrUnsupportedChannelType)
+
+	ctx := createTestContext("POST", "/notifications/subscriptions", []byte(`{"type":"WebSocketChannel2023","topic":"http://example.com/containers/photos"}`), nil)
+	assert.NoError(t, handler.Subscribe(ctx))
+	assert.Equal(t, http.StatusUnprocessableEntity, ctx.(*mockHTTPContext).response.Code)
+
+	ctx = createTestContext("POST", "/notifications/subscriptions", []byte(`{"topic":"https://elsewhere.example/containers/photos","sendTo":"https://app.example/hook"}`), nil)
+	assert.NoError(t, handler.Subscribe(ctx))
+	assert.Equal(t, http.StatusBadRequest, ctx.(*mockHTTPContext).response.Code)
+	assert.Contains(t, ctx.(*mockHTTPContext).response.Body.String(), "INVALID_TOPIC")
+
+	disabled := NewSolidNotificationHandler(nil, log.DefaultLogger)
+	ctx = createTestContext("POST", "/notifications/subscriptions", []byte(`{}`), nil)
+	assert.NoError(t, disabled.Subscribe(ctx))
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+}
+
+func TestSolidNotificationHandler_SubscribeAsVerifiedAgent(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	subscriber := &mockNotificationSubscriber{}
+	handler := NewSolidNotificationHandler(subscriber, log.DefaultLogger)
+
+	asAlice := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.AgentFromContext(ctx) == alice
+	})
+	unauthenticated := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.AgentFromContext(ctx) == ""
+	})
+	subscriber.On("Subscribe", asAlice, mock.Anything).Return(domain.NotificationChannel{ID: "channel-1", Type: domain.WebhookChannel2023}, nil)
+	subscriber.On("Subscribe", unauthenticated, mock.Anything).Return(domain.NotificationChannel{}, domain.ErrAccessDenied)
+
+	body := []byte(`{"type":"WebhookChannel2023","topic":"http://example.com/containers/photos","sendTo":"https://app.example/hook"}`)
+	ctx := createTestContext("POST", "/notifications/subscriptions", body, nil)
+	authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+	assert.NoError(t, handler.Subscribe(ctx))
+	assert.Equal(t, http.StatusCreated, ctx.(*mockHTTPContext).response.Code)
+
+	ctx = createTestContext("POST", "/notifications/subscriptions", body, nil)
+	ctx.Request().Header.Set("X-User-ID", alice)
+	assert.NoError(t, handler.Subscribe(ctx))
+	assert.Equal(t, http.StatusForbidden, ctx.(*mockHTTPContext).response.Code)
+	subscriber.AssertExpectations(t)
+}
+
+func TestSolidNotificationHandler_DeleteChannel(t *testing.T) {
+	subscriber := &mockNotificationSubscriber{}
+	handler := NewSolidNotificationHandler(subscriber, log.DefaultLogger)
+
+	subscriber.On("Unsubscribe", mock.Anything, "channel-1").Return(nil)
+	subscriber.On("Unsubscribe", mock.Anything, "missing").Return(domain.ErrNotificationChannelNotFound)
+
+	ctx := createTestContext("DELETE", "/notifications/channels/channel-1", nil, map[string][]string{"id": {"channel-1"}})
+	assert.NoError(t, handler.DeleteChannel(ctx))
+	assert.Equal(t, http.StatusNoContent, ctx.(*mockHTTPContext).response.Code)
+
+	ctx = createTestContext("DELETE", "/notifications/channels/missing", nil, map[string][]string{"id": {"missing"}})
+	assert.NoError(t, handler.DeleteChannel(ctx))
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+}
+
+func TestSolidNotificationHandler_ChannelsAsVerifiedAgent(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	subscriber := &mockNotificationSubscriber{}
+	handler := NewSolidNotificationHandler(subscriber, log.DefaultLogger)
+
+	asAlice := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.AgentFromContext(ctx) == alice
+	})
+	unauthenticated := mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.AgentFromContext(ctx) == ""
+	})
+	channel := domain.NotificationChannel{ID: "channel-1", Type: domain.WebhookChannel2023, Owner: alice}
+	subscriber.On("GetChannel", asAlice, "channel-1").Return(channel, nil)
+	subscriber.On("GetChannel", unauthenticated, "channel-1").Return(domain.NotificationChannel{}, domain.ErrNotificationChannelNotFound)
+	subscriber.On("Unsubscribe", asAlice, "channel-1").Return(nil)
+	subscriber.On("Unsubscribe", unauthenticated, "channel-1").Return(domain.ErrNotificationChannelNotFound)
+
+	vars := map[string][]string{"id": {"channel-1"}}
+	ctx := createTestContext("GET", "/notifications/channels/channel-1", nil, vars)
+	ctx.Request().Header.Set("X-User-ID", alice)
+	assert.NoError(t, handler.GetChannel(ctx))
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code, "an unverified header must not name the agent")
+
+	ctx = createTestContext("DELETE", "/notifications/channels/channel-1", nil, vars)
+	assert.NoError(t, handler.DeleteChannel(ctx))
+	assert.Equal(t, http.StatusNotFound, ctx.(*mockHTTPContext).response.Code)
+
+	ctx = createTestContext("GET", "/notifications/channels/channel-1", nil, vars)
+	authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+	assert.NoError(t, handler.GetChannel(ctx))
+	assert.Equal(t, http.StatusOK, ctx.(*mockHTTPContext).response.Code)
+
+	ctx = createTestContext("DELETE", "/notifications/channels/channel-1", nil, vars)
+	authenticateAs(ctx, middleware.Identity{Subject: "alice", WebID: alice})
+	assert.NoError(t, handler.DeleteChannel(ctx))
+	assert.Equal(t, http.StatusNoContent, ctx.(*mockHTTPContext).response.Code)
+	subscriber.AssertExpectations(t)
+}
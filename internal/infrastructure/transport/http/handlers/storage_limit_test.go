@@ -29,6 +29,10 @@ func (m *MockUnsupportedFormatService) StoreResource(ctx context.Context, id str
 	return nil, &domain.StorageError{Code: "RESOURCE_NOT_FOUND", Message: "not found"}
 }
 
+func (m *MockUnsupportedFormatService) StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	return m.StoreResource(ctx, id, data, contentType)
+}
+
 func (m *MockUnsupportedFormatService) RetrieveResource(ctx context.Context, id string, acceptFormat string) (domain.Resource, error) {
 	return nil, &domain.StorageError{
 		Code:      "UNSUPPORTED_FORMAT",
@@ -50,13 +54,26 @@ func (m *MockUnsupportedFormatService) ResourceExists(ctx context.Context, id st
 }
 
 func (m *MockUnsupportedFormatService) StreamResource(ctx context.Context, id string, acceptFormat string) (io.ReadCloser, string, error) {
-	return nil, "", &domain.StorageError{Code: "RESOURCE_NOT_FOUND", Message: "not found"}
+	_, err := m.RetrieveResource(ctx, id, acceptFormat)
+	return nil, "", err
 }
 
 func (m *MockUnsupportedFormatService) StoreResourceStream(ctx context.Context, id string, reader io.Reader, contentType string, size int64) (domain.Resource, error) {
 	return nil, &domain.StorageError{Code: "RESOURCE_NOT_FOUND", Message: "not found"}
 }
 
+func (m *MockUnsupportedFormatService) PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error) {
+	return m.RetrieveResource(ctx, id, "")
+}
+
+func (m *MockUnsupportedFormatService) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	return m.RetrieveResource(ctx, id, acceptFormat)
+}
+
+func (m *MockUnsupportedFormatService) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	return domain.GraphDiff{}, domain.ErrVersioningDisabled
+}
+
 func (m *MockStorageServiceWithLimits) StoreResource(ctx context.Context, id string, data []byte, contentType string) (domain.Resource, error) {
 	if m.simulateInsufficientStorage {
 		return nil, &domain.StorageError{
@@ -98,6 +115,10 @@ func (m *MockStorageServiceWithLimits) StoreResource(ctx context.Context, id str
 	return domain.NewResource(ctx, id, contentType, data), nil
 }
 
+func (m *MockStorageServiceWithLimits) StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	return m.StoreResource(ctx, id, data, contentType)
+}
+
 func (m *MockStorageServiceWithLimits) RetrieveResource(ctx context.Context, id string, acceptFormat string) (domain.Resource, error) {
 	return nil, &domain.StorageError{
 		Code:      "RESOURCE_NOT_FOUND",
@@ -152,7 +173,19 @@ func (m *MockStorageServiceWithLimits) StoreResourceStream(ctx context.Context,
 		return nil, err
 	}
 
-	return domain.NewResource(ctx, id, contentType, data), nil
+	return m.StoreResource(ctx, id, data, contentType)
+}
+
+func (m *MockStorageServiceWithLimits) PatchResource(ctx context.Context, id string, mediaType string, patch string) (domain.Resource, error) {
+	return m.RetrieveResource(ctx, id, "")
+}
+
+func (m *MockStorageServiceWithLimits) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	return m.RetrieveResource(ctx, id, acceptFormat)
+}
+
+func (m *MockStorageServiceWithLimits) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	return domain.GraphDiff{}, domain.ErrVersioningDisabled
 }
 
 // TestStorageLimitErrors tests specific storage limitation error scenarios
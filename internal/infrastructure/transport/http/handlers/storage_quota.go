@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// writeContext returns the context for a write made by the request's verified agent. The
+// storage service charges the write to the account owning the pod the resource is in.
+func writeContext(ctx khttp.Context) context.Context {
+	return domain.WithAgent(context.Background(), requestAgent(ctx.Request()))
+}
+
+// containerWriteContext returns ctx naming the account owning the pod of the container a
+// write targets, so resources created there are charged to it
+func (h *ContainerHandler) containerWriteContext(ctx context.Context, containerID string) context.Context {
+	accountID := h.podAccount(ctx, containerID)
+	if accountID == "" {
+		return ctx
+	}
+	return domain.WithAccountID(ctx, accountID)
+}
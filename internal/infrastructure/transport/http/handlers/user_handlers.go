@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -182,6 +183,32 @@ func (h *UserHandler) DeleteAccount(ctx khttp.Context) error {
 	return ctx.JSON(http.StatusNoContent, nil)
 }
 
+// OwnedAccountListResponse represents the HTTP response for the accounts a user owns
+type OwnedAccountListResponse struct {
+	Accounts []AccountResponse `json:"accounts"`
+}
+
+// ListMyOwnedAccounts handles GET /me/accounts/owned, listing only the accounts the caller
+// owns and can therefore delete or transfer
+func (h *UserHandler) ListMyOwnedAccounts(ctx khttp.Context) error {
+	userID := requestUserID(ctx.Request())
+	if userID == "" {
+		return h.handleError(ctx, http.StatusUnauthorized, "UNAUTHENTICATED", "A verified access token is required")
+	}
+
+	accounts, err := h.userService.GetOwnedAccounts(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.handleServiceError(ctx, err)
+	}
+
+	response := OwnedAccountListResponse{Accounts: make([]AccountResponse, len(accounts))}
+	for i, account := range accounts {
+		response.Accounts[i] = newAccountResponse(account)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // Helper methods
 
 func (h *UserHandler) validateRegistrationRequest(req RegisterUserRequest) error {
@@ -226,6 +253,9 @@ func (h *UserHandler) handleServiceError(ctx khttp.Context, err error) error {
 	errMsg := strings.ToLower(err.Error())
 
 	switch {
+	case errors.Is(err, domain.ErrOAuthRedirectNotAllowed), errors.Is(err, domain.ErrOAuthStateMismatch):
+		h.logger.Log(log.LevelWarn, "msg", "OAuth redirect rejected", "error", err.Error())
+		return h.handleError(ctx, http.StatusBadRequest, "OAUTH_ERROR", "Invalid OAuth redirect or state")
 	case strings.Contains(errMsg, "not found"):
 		return h.handleError(ctx, http.StatusNotFound, "NOT_FOUND", "User not found")
 	case strings.Contains(errMsg, "already exists"):
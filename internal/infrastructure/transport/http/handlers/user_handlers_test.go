@@ -2,16 +2,20 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
 	"github.com/akeemphilbert/goro/internal/user/application"
 	"github.com/akeemphilbert/goro/internal/user/domain"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockUserService is a mock implementation of UserService for testing
@@ -53,6 +57,19 @@ func (m *MockUserService) GetUserByWebID(ctx context.Context, webID string) (*do
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserService) UnlinkOAuthProvider(ctx context.Context, userID, provider string) error {
+	args := m.Called(ctx, userID, provider)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetOwnedAccounts(ctx context.Context, userID string) ([]*domain.Account, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Account), args.Error(1)
+}
+
 // TestNewUserHandler tests the user handler creation - THIS SHOULD FAIL
 func TestNewUserHandler(t *testing.T) {
 	mockService := new(MockUserService)
@@ -396,6 +413,40 @@ func TestUserHandlers_DeleteAccountValidation(t *testing.T) {
 	}
 }
 
+func TestUserHandler_ListMyOwnedAccounts(t *testing.T) {
+	t.Run("should require the caller identity", func(t *testing.T) {
+		handler := NewUserHandler(new(MockUserService), log.DefaultLogger)
+
+		ctx := createTestContext("GET", "/me/accounts/owned", nil, nil)
+		require.NoError(t, handler.ListMyOwnedAccounts(ctx))
+
+		assert.Equal(t, http.StatusUnauthorized, ctx.(*mockHTTPContext).response.Code)
+	})
+
+	t.Run("should list the caller's owned accounts", func(t *testing.T) {
+		mockService := new(MockUserService)
+		handler := NewUserHandler(mockService, log.DefaultLogger)
+
+		account, err := domain.NewAccount(context.Background(), "account-1", createTestUser(), "Owned", "")
+		require.NoError(t, err)
+		mockService.On("GetOwnedAccounts", mock.Anything, "user-1").Return([]*domain.Account{account}, nil)
+
+		ctx := createTestContext("GET", "/me/accounts/owned", nil, nil)
+		authenticateAs(ctx, middleware.Identity{Subject: "user-1"})
+		require.NoError(t, handler.ListMyOwnedAccounts(ctx))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var body OwnedAccountListResponse
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		require.Len(t, body.Accounts, 1)
+		assert.Equal(t, "account-1", body.Accounts[0].ID)
+		assert.Equal(t, "Owned", body.Accounts[0].Name)
+		mockService.AssertExpectations(t)
+	})
+}
+
 // Helper functions
 
 func createTestUser() *domain.User {
@@ -428,3 +479,22 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestUserHandler_HandleServiceError_OAuthRedirect(t *testing.T) {
+	handler := NewUserHandler(&MockUserService{}, log.DefaultLogger)
+
+	for _, err := range []error{
+		fmt.Errorf("%w: https://evil.example.com for provider github", domain.ErrOAuthRedirectNotAllowed),
+		fmt.Errorf("%w: unknown or expired state", domain.ErrOAuthStateMismatch),
+	} {
+		ctx := createTestContext("GET", "/auth/github/callback", nil, nil)
+		require.NoError(t, handler.handleServiceError(ctx, err))
+
+		response := ctx.(*mockHTTPContext).response
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, "OAUTH_ERROR", body["error"])
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// rootContainerID is the container requests without a target container are checked against
+const rootContainerID = "/"
+
+// WebAccessControlHandler checks resource and container requests against Web Access Control
+// before they are routed. Requests need the modes their method implies on the target: Read to
+// GET or HEAD, Append to POST, and Write to PUT, PATCH or DELETE. Requests are made as the
+// identity of their verified access token, and without one as the public. Denied requests get
+// 401 when they carry no identity and 403 otherwise; allowed GET and HEAD responses list the modes held
+// in a WAC-Allow header.
+type WebAccessControlHandler struct {
+	authorizer AccessAuthorizer
+	logger     log.Logger
+}
+
+// NewWebAccessControlHandler creates a new Web Access Control handler; a nil authorizer lets
+// every request through
+func NewWebAccessControlHandler(authorizer AccessAuthorizer, logger log.Logger) *WebAccessControlHandler {
+	return &WebAccessControlHandler{
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+// Wrap checks requests for resources and containers and passes the allowed ones, and every
+// other request, on to next
+func (h *WebAccessControlHandler) Wrap(next http.Handler) http.Handler {
+	if h.authorizer == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceID, required, ok := accessTarget(r.Method, r.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		decision, err := h.authorizer.Authorize(r.Context(), requestAgent(r), resourceID, required)
+		if err != nil {
+			h.logger.Log(log.LevelError, "msg", "Failed to authorize request", "resource", resourceID, "error", err)
+			writeAccessError(w, http.StatusInternalServerError, "AUTHORIZATION_FAILED", "failed to check access to the resource")
+			return
+		}
+		if !decision.Allowed() {
+			if !decision.Authenticated() {
+				writeAccessError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "authentication is required to access the resource")
+				return
+			}
+			writeAccessError(w, http.StatusForbidden, "ACCESS_DENIED", "the agent is not allowed to access the resource")
+			return
+		}
+
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			w.Header().Set("WAC-Allow", wacAllow(decision.Granted, decision.Public))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// accessTarget returns the resource or container a request acts on and the modes it needs,
+// reporting false for requests outside the resource and container endpoints and for OPTIONS,
+// which discovers what is allowed without acting. Endpoints outside it authorize their own
+// targets: uploads need Write or Append on the resource, subscriptions Read on the topic, and
+// pod search drops hits the caller cannot read, and batch creation Append on the parent of
+// each container it creates. A recursive DELETE is only checked here on the top container; the
+// deletion itself needs Write on everything below it.
+func accessTarget(method, path string) (string, domain.AccessMode, bool) {
+	rest, isContainer := strings.CutPrefix(path, "/containers/")
+	if !isContainer {
+		var isResource bool
+		if rest, isResource = strings.CutPrefix(path, "/resources/"); !isResource {
+			return "", domain.AccessMode{}, false
+		}
+	}
+
+	if isContainer && rest == "batch" && method == http.MethodPost {
+		return "", domain.AccessMode{}, false
+	}
+
+	var required domain.AccessMode
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		required.Read = true
+	case http.MethodPost:
+		required.Append = true
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		required.Write = true
+	default:
+		return "", domain.AccessMode{}, false
+	}
+
+	id, subresource, _ := strings.Cut(rest, "/")
+	switch {
+	case id == "":
+		// Creating at the top level adds to the root container
+		id = rootContainerID
+	case subresource == "touch":
+		// Touching changes the container's modification time
+		required = domain.AccessMode{Write: true}
+	case isContainer && method == http.MethodPost && strings.HasPrefix(subresource, "members/") && strings.HasSuffix(subresource, "/move"):
+		// Moving a member out removes it from the container
+		required = domain.AccessMode{Write: true}
+	}
+	return id, required, true
+}
+
+// wacAllow formats the WAC-Allow header value for the modes the agent and the public hold
+func wacAllow(user, public domain.AccessMode) string {
+	return `user="` + wacModes(user) + `", public="` + wacModes(public) + `"`
+}
+
+// wacModes lists the modes held, as WAC-Allow names them
+func wacModes(mode domain.AccessMode) string {
+	var modes []string
+	if mode.Read {
+		modes = append(modes, "read")
+	}
+	if mode.Write {
+		modes = append(modes, "write")
+	}
+	if mode.Append {
+		modes = append(modes, "append")
+	}
+	if mode.Control {
+		modes = append(modes, "control")
+	}
+	return strings.Join(modes, " ")
+}
+
+// writeAccessError answers a request that was not allowed through
+func writeAccessError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   code,
+		"message": message,
+	})
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
+	"github.com/akeemphilbert/goro/internal/ldp/application"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedAccess grants each user the same modes on every resource; users without an entry are
+// unauthenticated
+type fixedAccess struct {
+	agents map[string]string
+	user   domain.AccessMode
+	public domain.AccessMode
+}
+
+func (f *fixedAccess) Authorize(ctx context.Context, userID, resourceID string, required domain.AccessMode) (*application.AccessDecision, error) {
+	decision := &application.AccessDecision{Agent: f.agents[userID], Required: required, Public: f.public}
+	decision.Granted = f.public
+	if decision.Agent != "" {
+		decision.Granted = f.user
+	}
+	return decision, nil
+}
+
+func TestWebAccessControlHandler_Wrap(t *testing.T) {
+	authorizer := &fixedAccess{
+		agents: map[string]string{"alice-id": "https://alice.example/profile#me"},
+		user:   domain.AccessMode{Read: true, Append: true},
+		public: domain.AccessMode{Read: true},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := NewWebAccessControlHandler(authorizer, log.DefaultLogger).Wrap(next)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		userID   string
+		status   int
+		wacAllow string
+	}{
+		{"public read", http.MethodGet, "/resources/card", "", http.StatusOK, `user="read", public="read"`},
+		{"agent read", http.MethodHead, "/containers/photos", "alice-id", http.StatusOK, `user="read append", public="read"`},
+		{"agent append", http.MethodPost, "/containers/photos", "alice-id", http.StatusOK, ""},
+		{"unauthenticated write", http.MethodPut, "/resources/card", "", http.StatusUnauthorized, ""},
+		{"unknown user", http.MethodDelete, "/resources/card", "mallory-id", http.StatusUnauthorized, ""},
+		{"denied write", http.MethodPatch, "/resources/card", "alice-id", http.StatusForbidden, ""},
+		{"denied touch", http.MethodPost, "/containers/photos/touch", "alice-id", http.StatusForbidden, ""},
+		{"other endpoints", http.MethodPost, "/notifications/subscriptions", "", http.StatusOK, ""},
+		{"discovery", http.MethodOptions, "/resources/card", "", http.StatusOK, ""},
+	}
+
+	t.Run("identity headers are not trusted", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/containers/photos", nil)
+		request.Header.Set("X-User-ID", "alice-id")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.userID != "" {
+				request = request.WithContext(middleware.WithIdentity(request.Context(), middleware.Identity{Subject: tt.userID}))
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.wacAllow, recorder.Header().Get("WAC-Allow"))
+		})
+	}
+}
+
+func TestAccessTarget(t *testing.T) {
+	tests := []struct {
+		method     string
+		path       string
+		resourceID string
+		required   domain.AccessMode
+	}{
+		{http.MethodGet, "/resources/card/meta", "card", domain.AccessMode{Read: true}},
+		{http.MethodGet, "/containers/photos/structure", "photos", domain.AccessMode{Read: true}},
+		{http.MethodPost, "/containers/photos/members", "photos", domain.AccessMode{Append: true}},
+		{http.MethodPost, "/containers/photos/members/card/move", "photos", domain.AccessMode{Write: true}},
+		{http.MethodPost, "/resources/", rootContainerID, domain.AccessMode{Append: true}},
+		{http.MethodPut, "/resources/card.acl", "card.acl", domain.AccessMode{Write: true}},
+	}
+
+	for _, tt := range tests {
+		resourceID, required, ok := accessTarget(tt.method, tt.path)
+		assert.True(t, ok, tt.path)
+		assert.Equal(t, tt.resourceID, resourceID, tt.path)
+		assert.Equal(t, tt.required, required, tt.path)
+	}
+
+	_, _, ok := accessTarget(http.MethodGet, "/health/")
+	assert.False(t, ok)
+	_, _, ok = accessTarget(http.MethodPost, "/containers/batch")
+	assert.False(t, ok, "batch creation authorizes the parent of each container itself")
+}
+
+func TestWebAccessControlHandler_DisabledPassesThrough(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := NewWebAccessControlHandler(nil, log.DefaultLogger).Wrap(next)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/resources/card", nil))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
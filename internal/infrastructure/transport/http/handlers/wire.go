@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/ldp/application"
+	userApplication "github.com/akeemphilbert/goro/internal/user/application"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 )
@@ -12,16 +16,74 @@ var ProviderSet = wire.NewSet(
 	NewContainerHandlerProvider,
 	NewUserHandlerProvider,
 	NewAccountHandlerProvider,
+	NewAdminHandlerProvider,
+	NewNotificationHandlerProvider,
+	NewSolidNotificationHandlerProvider,
+	NewContainerSubscriptionHandlerProvider,
+	NewServerCapabilitiesHandlerProvider,
+	NewWebAccessControlHandlerProvider,
 )
 
 // NewResourceHandlerProvider creates a ResourceHandler with proper dependency injection
-func NewResourceHandlerProvider(storageService *application.StorageService, logger log.Logger) *ResourceHandler {
-	return NewResourceHandler(storageService, logger)
+func NewResourceHandlerProvider(storageService *application.StorageService, containerService *application.ContainerService, readAuditor *application.ReadAuditor, accessTracker *application.ResourceAccessTracker, accessControl *application.WebAccessControl, uploadService *application.UploadService, config *conf.Container, logger log.Logger) *ResourceHandler {
+	handler := NewResourceHandler(storageService, logger)
+	handler.SetReadAuditor(readAuditor)
+	handler.SetAccessTracker(accessTracker)
+	handler.SetContainerLocator(containerService)
+	if containerService != nil {
+		handler.SetRDFNormalizer(containerService)
+	}
+	handler.SetContentOpener(storageService)
+	handler.SetResourceStreamer(storageService)
+	handler.SetExistenceChecker(storageService)
+	handler.SetAccountChecker(storageService)
+	if accessControl != nil {
+		handler.SetAccessAuthorizer(accessControl)
+	}
+	if uploadService != nil {
+		handler.SetResourceUploader(uploadService)
+	}
+	if config != nil {
+		handler.SetNonContainerPostBehavior(config.NonContainerPost)
+		handler.SetMediaTypePolicy(NewMediaTypePolicy(config.MediaTypes))
+		handler.SetETagPolicy(NewETagPolicy(config.ETags))
+		handler.SetCachePolicy(NewCachePolicy(config.CacheControl))
+	}
+	return handler
 }
 
 // NewContainerHandlerProvider creates a ContainerHandler with proper dependency injection
-func NewContainerHandlerProvider(containerService *application.ContainerService, storageService *application.StorageService, logger log.Logger) *ContainerHandler {
-	return NewContainerHandler(containerService, storageService, logger)
+func NewContainerHandlerProvider(containerService *application.ContainerService, storageService *application.StorageService, readAuditor *application.ReadAuditor, operationGate *application.OperationGate, accessControl *application.WebAccessControl, config *conf.Container, logger log.Logger) *ContainerHandler {
+	handler := NewContainerHandler(containerService, storageService, logger)
+	handler.SetReadAuditor(readAuditor)
+	handler.SetOperationGate(operationGate)
+	if accessControl != nil {
+		handler.SetReadAuthorizer(accessControl)
+		handler.SetAccessAuthorizer(accessControl)
+	}
+	handler.SetMovedContainerResolver(containerService)
+	handler.SetNamedResourceCreator(storageService)
+	handler.SetContentTransformer(containerService)
+	handler.SetRDFStreamer(containerService)
+	handler.SetPodAccountResolver(containerService)
+	if config != nil {
+		handler.SetNonContainerPostBehavior(config.NonContainerPost)
+		handler.SetEmptyPutBehavior(config.EmptyContainerPut)
+		handler.SetMissingContainerBehavior(config.MissingContainer)
+		handler.SetSlugPolicy(NewSlugPolicy(config.Slug))
+		handler.SetMediaTypePolicy(NewMediaTypePolicy(config.MediaTypes))
+		handler.SetETagPolicy(NewETagPolicy(config.ETags))
+		handler.SetCachePolicy(NewCachePolicy(config.CacheControl))
+		handler.SetFeedsEnabled(config.FeedsEnabled)
+		handler.SetJSONLDForm(config.JSONLD.Form)
+		if config.CSVExportEnabled {
+			handler.SetCSVExporter(containerService)
+		}
+		if config.RecursiveDelete {
+			handler.SetRecursiveDeleter(containerService)
+		}
+	}
+	return handler
 }
 
 // NewUserHandlerProvider creates a UserHandler with proper dependency injection
@@ -33,3 +95,74 @@ func NewUserHandlerProvider(userService userApplication.UserService, logger log.
 func NewAccountHandlerProvider(accountService userApplication.AccountService, userService userApplication.UserService, logger log.Logger) *AccountHandler {
 	return NewAccountHandler(accountService, userService, logger)
 }
+
+// NewNotificationHandlerProvider creates a NotificationHandler with proper dependency injection
+func NewNotificationHandlerProvider(notificationService userApplication.NotificationService, logger log.Logger) *NotificationHandler {
+	return NewNotificationHandler(notificationService, logger)
+}
+
+// NewSolidNotificationHandlerProvider creates a SolidNotificationHandler with proper dependency
+// injection; a nil service, when notifications are disabled, leaves the endpoints answering 404
+func NewSolidNotificationHandlerProvider(notificationService *application.SolidNotificationService, logger log.Logger) *SolidNotificationHandler {
+	if notificationService == nil {
+		return NewSolidNotificationHandler(nil, logger)
+	}
+	return NewSolidNotificationHandler(notificationService, logger)
+}
+
+// NewContainerSubscriptionHandlerProvider creates a ContainerSubscriptionHandler with proper
+// dependency injection; a nil hub, when live notifications are disabled, leaves the
+// endpoint answering 404
+func NewContainerSubscriptionHandlerProvider(hub *application.ContainerChangeHub, accessControl *application.WebAccessControl, config *conf.Container, logger log.Logger) *ContainerSubscriptionHandler {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	writeTimeout := time.Duration(config.Notifications.Live.WriteTimeout)
+	if hub == nil {
+		return NewContainerSubscriptionHandler(nil, writeTimeout, logger)
+	}
+	handler := NewContainerSubscriptionHandler(hub, writeTimeout, logger)
+	if accessControl != nil {
+		handler.SetReadAuthorizer(accessControl)
+	}
+	return handler
+}
+
+// NewServerCapabilitiesHandlerProvider creates a ServerCapabilitiesHandler describing the
+// configured server
+func NewServerCapabilitiesHandlerProvider(config *conf.Container, auth *conf.Auth, logger log.Logger) *ServerCapabilitiesHandler {
+	return NewServerCapabilitiesHandler(ServerCapabilitiesFromConfig(config, auth), logger)
+}
+
+// NewWebAccessControlHandlerProvider creates a WebAccessControlHandler with proper dependency
+// injection; a nil decision point, when Web Access Control is disabled, lets every request through
+func NewWebAccessControlHandlerProvider(accessControl *application.WebAccessControl, logger log.Logger) *WebAccessControlHandler {
+	if accessControl == nil {
+		return NewWebAccessControlHandler(nil, logger)
+	}
+	return NewWebAccessControlHandler(accessControl, logger)
+}
+
+// NewAdminHandlerProvider creates an AdminHandler with proper dependency injection
+func NewAdminHandlerProvider(config *conf.Auth, eventRetry *application.EventRetry, eventLog *application.EventLogExporter, retention *application.RetentionSweeper, containerService *application.ContainerService, logger log.Logger) *AdminHandler {
+	var handler *AdminHandler
+	if config == nil {
+		handler = NewAdminHandler(nil, logger)
+	} else {
+		handler = NewAdminHandler(config.AdminTokens, logger)
+	}
+	if eventRetry != nil {
+		handler.SetDeadLetterManager(eventRetry)
+	}
+	if eventLog != nil {
+		handler.SetEventLogStreamer(eventLog)
+	}
+	if retention != nil {
+		handler.SetRetentionSweepRunner(retention)
+	}
+	if containerService != nil {
+		handler.SetCacheInvalidationRunner(containerService)
+	}
+	return handler
+}
@@ -0,0 +1,306 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// accessTokenClockSkew is how far exp and nbf may be off, for issuers whose clocks differ from
+// the server's
+const accessTokenClockSkew = 30 * time.Second
+
+// ErrInvalidAccessToken is returned for access tokens that are malformed, not signed by a
+// trusted key, issued by another issuer or for another audience, expired, or naming no one
+var ErrInvalidAccessToken = errors.New("invalid access token")
+
+// Identity is the caller named by a verified access token
+type Identity struct {
+	// Subject is the token's sub: the user at the issuer
+	Subject string
+	// WebID is the token's webid claim, set by Solid-OIDC issuers
+	WebID string
+	// ClientID is the application the token was issued to
+	ClientID string
+}
+
+// Agent returns who the caller acts as for access control: their WebID, or the subject when
+// the token names none
+func (i Identity) Agent() string {
+	if i.WebID != "" {
+		return i.WebID
+	}
+	return i.Subject
+}
+
+// identityKey is the context key of the identity a request was authenticated as
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the identity a request was authenticated as
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity a request was authenticated as, reporting false for
+// unauthenticated requests
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// AccessToken is a verified JWT access token
+type AccessToken struct {
+	Identity
+	// Thumbprint is the token's cnf.jkt, the thumbprint of the DPoP key it is bound to; empty
+	// for tokens bound to no key
+	Thumbprint string
+	ExpiresAt  time.Time
+}
+
+// accessTokenClaims are the claims read from a JWT access token
+type accessTokenClaims struct {
+	Issuer    string          `json:"iss"`
+	Subject   string          `json:"sub"`
+	Audience  json.RawMessage `json:"aud"`
+	WebID     string          `json:"webid"`
+	ClientID  string          `json:"client_id"`
+	AZP       string          `json:"azp"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	Cnf       struct {
+		JKT string `json:"jkt"`
+	} `json:"cnf"`
+}
+
+// AccessTokenVerifier verifies JWT access tokens signed by a trusted issuer
+type AccessTokenVerifier struct {
+	issuer   string
+	audience string
+	keys     []crypto.PublicKey
+	now      func() time.Time
+}
+
+// NewAccessTokenVerifier creates a verifier accepting tokens from issuer signed by one of keys.
+// When audience is set, tokens must list it in aud.
+func NewAccessTokenVerifier(issuer, audience string, keys []crypto.PublicKey) *AccessTokenVerifier {
+	return &AccessTokenVerifier{
+		issuer:   issuer,
+		audience: audience,
+		keys:     keys,
+		now:      time.Now,
+	}
+}
+
+// LoadAccessTokenKeys reads the PEM public keys or certificates of a token issuer from files
+func LoadAccessTokenKeys(paths []string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read access token key %s: %w", path, err)
+		}
+		found := false
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			key, err := pemPublicKey(block)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse access token key %s: %w", path, err)
+			}
+			if key != nil {
+				keys = append(keys, key)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("access token key %s holds no PEM public key or certificate", path)
+		}
+	}
+	return keys, nil
+}
+
+// pemPublicKey returns the RSA or EC public key of a PEM block, or nil for blocks of any other
+// type
+func pemPublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	var key crypto.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = parsed
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = parsed
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = certificate.PublicKey
+	default:
+		return nil, nil
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < dpopMinRSABits {
+			return nil, fmt.Errorf("RSA key is shorter than %d bits", dpopMinRSABits)
+		}
+		return key, nil
+	case *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// Verify checks a JWT access token: that it is signed by a trusted key with an asymmetric
+// algorithm, was issued by the trusted issuer for the audience, is within its validity period,
+// and names a subject or WebID
+func (v *AccessTokenVerifier) Verify(token string) (*AccessToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a compact JWS", ErrInvalidAccessToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidAccessToken)
+	}
+	if _, ok := dpopHashes[header.Alg]; !ok {
+		return nil, fmt.Errorf("%w: unsupported alg %q", ErrInvalidAccessToken, header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidAccessToken)
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range v.keys {
+		if verifySignature(header.Alg, key, signingInput, signature) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: not signed by a trusted key", ErrInvalidAccessToken)
+	}
+
+	var claims accessTokenClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidAccessToken)
+	}
+	if claims.Issuer != v.issuer {
+		return nil, fmt.Errorf("%w: issued by another issuer", ErrInvalidAccessToken)
+	}
+	if v.audience != "" && !hasAudience(claims.Audience, v.audience) {
+		return nil, fmt.Errorf("%w: issued for another audience", ErrInvalidAccessToken)
+	}
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: exp is required", ErrInvalidAccessToken)
+	}
+	now := v.now()
+	expiresAt := time.Unix(int64(*claims.ExpiresAt), 0)
+	if now.After(expiresAt.Add(accessTokenClockSkew)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidAccessToken)
+	}
+	if claims.NotBefore != nil && time.Unix(int64(*claims.NotBefore), 0).After(now.Add(accessTokenClockSkew)) {
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidAccessToken)
+	}
+	if claims.Subject == "" && claims.WebID == "" {
+		return nil, fmt.Errorf("%w: names neither a subject nor a WebID", ErrInvalidAccessToken)
+	}
+
+	clientID := claims.ClientID
+	if clientID == "" {
+		clientID = claims.AZP
+	}
+	return &AccessToken{
+		Identity:   Identity{Subject: claims.Subject, WebID: claims.WebID, ClientID: clientID},
+		Thumbprint: claims.Cnf.JKT,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// hasAudience reports whether an aud claim, a string or a list of strings, names audience
+func hasAudience(aud json.RawMessage, audience string) bool {
+	var single string
+	if json.Unmarshal(aud, &single) == nil {
+		return single == audience
+	}
+	var list []string
+	if json.Unmarshal(aud, &list) != nil {
+		return false
+	}
+	for _, entry := range list {
+		if entry == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate returns a filter verifying the JWT access token sent with each request and
+// putting the identity it names on the request context. Requests without a token, or with an
+// opaque one such as an admin token, pass through unauthenticated. Invalid tokens, and tokens
+// bound to a DPoP key that come without a verified proof by that key, are refused with 401.
+// The filter must run inside DPoP, which puts the verified proof on the context.
+func Authenticate(verifier *AccessTokenVerifier) khttp.FilterFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, accessToken := authorizationToken(r)
+			if accessToken == "" || strings.Count(accessToken, ".") != 2 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, err := verifier.Verify(accessToken)
+			if err != nil {
+				writeTokenChallenge(w, scheme, err.Error())
+				return
+			}
+			if token.Thumbprint != "" {
+				proof, ok := DPoPProofFromContext(r.Context())
+				if !ok || proof.Thumbprint != token.Thumbprint {
+					writeTokenChallenge(w, scheme, "the access token is bound to a key and must be sent with a DPoP proof signed by it")
+					return
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), token.Identity)))
+		})
+	}
+}
+
+// writeTokenChallenge refuses a request whose access token is invalid with 401 and an
+// invalid_token challenge in the scheme the token was sent with
+func writeTokenChallenge(w http.ResponseWriter, scheme, description string) {
+	if scheme == "dpop" {
+		writeDPoPChallenge(w, "invalid_token", description)
+		return
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", error_description="%s"`, strings.ReplaceAll(description, `"`, `'`)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   "INVALID_TOKEN",
+		"message": description,
+	})
+}
@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "https://idp.example"
+
+// tokenIssuer signs ES256 access tokens
+type tokenIssuer struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTokenIssuer(t *testing.T) *tokenIssuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &tokenIssuer{key: key}
+}
+
+// token signs an access token with the given claims
+func (i *tokenIssuer) token(t *testing.T, claims map[string]interface{}) string {
+	signingInput := encodeJWTPart(t, map[string]string{"typ": "at+jwt", "alg": "ES256"}) + "." + encodeJWTPart(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, i.key, digest[:])
+	require.NoError(t, err)
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// tokenClaims returns valid claims for alice, with the overrides applied
+func tokenClaims(overrides map[string]interface{}) map[string]interface{} {
+	claims := map[string]interface{}{
+		"iss":       testIssuer,
+		"aud":       []string{"solid", "https://pod.example"},
+		"sub":       "alice",
+		"webid":     "https://alice.example/profile#me",
+		"client_id": "https://app.example/id",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+	return claims
+}
+
+// tamperTokenClaims swaps a signed token's claims for ones naming mallory, keeping its signature
+func tamperTokenClaims(t *testing.T, token string) string {
+	parts := strings.Split(token, ".")
+	parts[1] = encodeJWTPart(t, tokenClaims(map[string]interface{}{"sub": "mallory", "webid": nil}))
+	return strings.Join(parts, ".")
+}
+
+func (i *tokenIssuer) verifier() *AccessTokenVerifier {
+	return NewAccessTokenVerifier(testIssuer, "https://pod.example", []crypto.PublicKey{&i.key.PublicKey})
+}
+
+func TestAccessTokenVerifier_Verify(t *testing.T) {
+	issuer := newTokenIssuer(t)
+
+	t.Run("accepts a valid token", func(t *testing.T) {
+		token, err := issuer.verifier().Verify(issuer.token(t, tokenClaims(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, Identity{Subject: "alice", WebID: "https://alice.example/profile#me", ClientID: "https://app.example/id"}, token.Identity)
+		assert.Equal(t, "https://alice.example/profile#me", token.Agent())
+		assert.Empty(t, token.Thumbprint)
+	})
+
+	t.Run("reads the bound key thumbprint", func(t *testing.T) {
+		token, err := issuer.verifier().Verify(issuer.token(t, tokenClaims(map[string]interface{}{"cnf": map[string]string{"jkt": "thumb"}})))
+		require.NoError(t, err)
+		assert.Equal(t, "thumb", token.Thumbprint)
+	})
+
+	t.Run("accepts a single audience string", func(t *testing.T) {
+		_, err := issuer.verifier().Verify(issuer.token(t, tokenClaims(map[string]interface{}{"aud": "https://pod.example"})))
+		assert.NoError(t, err)
+	})
+
+	refused := map[string]string{
+		"unsigned":        encodeJWTPart(t, map[string]string{"alg": "none"}) + "." + encodeJWTPart(t, tokenClaims(nil)) + ".",
+		"untrusted key":   newTokenIssuer(t).token(t, tokenClaims(nil)),
+		"other issuer":    issuer.token(t, tokenClaims(map[string]interface{}{"iss": "https://evil.example"})),
+		"other audience":  issuer.token(t, tokenClaims(map[string]interface{}{"aud": "https://other.example"})),
+		"expired":         issuer.token(t, tokenClaims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"without exp":     issuer.token(t, tokenClaims(map[string]interface{}{"exp": nil})),
+		"not yet valid":   issuer.token(t, tokenClaims(map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()})),
+		"naming no one":   issuer.token(t, tokenClaims(map[string]interface{}{"sub": nil, "webid": nil})),
+		"not a JWS":       "opaque",
+		"tampered claims": tamperTokenClaims(t, issuer.token(t, tokenClaims(nil))),
+	}
+	for name, token := range refused {
+		t.Run("refuses a token "+name, func(t *testing.T) {
+			_, err := issuer.verifier().Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidAccessToken)
+		})
+	}
+}
+
+func TestLoadAccessTokenKeys(t *testing.T) {
+	issuer := newTokenIssuer(t)
+	der, err := x509.MarshalPKIXPublicKey(&issuer.key.PublicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "issuer.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	keys, err := LoadAccessTokenKeys([]string{path})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	_, err = NewAccessTokenVerifier(testIssuer, "", keys).Verify(issuer.token(t, tokenClaims(nil)))
+	assert.NoError(t, err)
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("not a key"), 0o600))
+	_, err = LoadAccessTokenKeys([]string{empty})
+	assert.Error(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	issuer := newTokenIssuer(t)
+	var seen *Identity
+	handler := Authenticate(issuer.verifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = nil
+		if identity, ok := IdentityFromContext(r.Context()); ok {
+			seen = &identity
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(request *http.Request) *httptest.ResponseRecorder {
+		seen = nil
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("passes requests without a token unauthenticated", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/resources/a", nil)
+		request.Header.Set("X-User-ID", "alice")
+		recorder := serve(request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("passes opaque tokens unauthenticated", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/admin/events", nil)
+		request.Header.Set("Authorization", "Bearer admin-token")
+		recorder := serve(request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("puts the identity of a valid token on the context", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/resources/a", nil)
+		request.Header.Set("Authorization", "Bearer "+issuer.token(t, tokenClaims(nil)))
+		recorder := serve(request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, seen)
+		assert.Equal(t, "https://alice.example/profile#me", seen.Agent())
+	})
+
+	t.Run("refuses an invalid token", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/resources/a", nil)
+		request.Header.Set("Authorization", "Bearer "+newTokenIssuer(t).token(t, tokenClaims(nil)))
+		recorder := serve(request)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("WWW-Authenticate"), `Bearer error="invalid_token"`)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("refuses a bound token without a proof by its key", func(t *testing.T) {
+		token := issuer.token(t, tokenClaims(map[string]interface{}{"cnf": map[string]string{"jkt": "thumb"}}))
+		request := httptest.NewRequest(http.MethodGet, "/resources/a", nil)
+		request.Header.Set("Authorization", "DPoP "+token)
+		recorder := serve(request)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("WWW-Authenticate"), `DPoP algs=`)
+
+		request = httptest.NewRequest(http.MethodGet, "/resources/a", nil)
+		request.Header.Set("Authorization", "DPoP "+token)
+		request = request.WithContext(WithDPoPProof(request.Context(), &DPoPProof{Thumbprint: "other"}))
+		assert.Equal(t, http.StatusUnauthorized, serve(request).Code)
+	})
+
+	t.Run("accepts a bound token with a proof by its key", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/resources/a", nil)
+		request.Header.Set("Authorization", "DPoP "+issuer.token(t, tokenClaims(map[string]interface{}{"cnf": map[string]string{"jkt": "thumb"}})))
+		request = request.WithContext(WithDPoPProof(request.Context(), &DPoPProof{Thumbprint: "thumb"}))
+		recorder := serve(request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, seen)
+		assert.Equal(t, "alice", seen.Subject)
+	})
+}
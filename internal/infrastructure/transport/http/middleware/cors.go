@@ -21,7 +21,7 @@ func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"},
-		AllowedHeaders: []string{"Content-Type", "Authorization", "X-Requested-With"},
+		AllowedHeaders: []string{"Content-Type", "Authorization", "X-Requested-With", "If-Match", "If-None-Match"},
 		MaxAge:         86400, // 24 hours
 	}
 }
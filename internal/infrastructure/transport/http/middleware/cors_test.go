@@ -26,7 +26,7 @@ func TestCORS(t *testing.T) {
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Origin":  "*",
 				"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,PATCH,HEAD,OPTIONS",
-				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With",
+				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With,If-Match,If-None-Match",
 			},
 			shouldCallNext: true,
 		},
@@ -38,7 +38,7 @@ func TestCORS(t *testing.T) {
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Origin":  "*",
 				"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,PATCH,HEAD,OPTIONS",
-				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With",
+				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With,If-Match,If-None-Match",
 			},
 			shouldCallNext: true,
 		},
@@ -51,7 +51,7 @@ func TestCORS(t *testing.T) {
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Origin":  "*",
 				"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,PATCH,HEAD,OPTIONS",
-				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With",
+				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With,If-Match,If-None-Match",
 				"Access-Control-Max-Age":       "86400",
 			},
 			shouldCallNext: false,
@@ -63,7 +63,7 @@ func TestCORS(t *testing.T) {
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Origin":  "*",
 				"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,PATCH,HEAD,OPTIONS",
-				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With",
+				"Access-Control-Allow-Headers": "Content-Type,Authorization,X-Requested-With,If-Match,If-None-Match",
 			},
 			shouldCallNext: true,
 		},
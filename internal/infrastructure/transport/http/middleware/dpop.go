@@ -0,0 +1,507 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // registers SHA-384 and SHA-512 for the ES/RS/PS 384 and 512 algorithms
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// dpopAlgorithms are the JWS algorithms accepted for DPoP proofs, as advertised in the algs
+// parameter of DPoP challenges
+const dpopAlgorithms = "ES256 ES384 ES512 PS256 PS384 PS512 RS256 RS384 RS512"
+
+// dpopClockSkew is how far in the future a proof's iat may be, for clients whose clocks run
+// ahead of the server's
+const dpopClockSkew = 30 * time.Second
+
+// dpopMinRSABits is the smallest RSA key accepted for signing proofs
+const dpopMinRSABits = 2048
+
+// dpopHashes are the hashes the accepted JWS algorithms sign with
+var dpopHashes = map[string]crypto.Hash{
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+}
+
+// ErrInvalidDPoPProof is returned for DPoP proofs that are malformed, badly signed, made for
+// another request, too old, replayed, or for another access token
+var ErrInvalidDPoPProof = errors.New("invalid DPoP proof")
+
+// DPoPProof is a verified DPoP proof
+type DPoPProof struct {
+	// Thumbprint is the RFC 7638 SHA-256 thumbprint of the key that signed the proof, which
+	// DPoP-bound access tokens carry as cnf.jkt
+	Thumbprint string
+	JTI        string
+	IssuedAt   time.Time
+}
+
+// dpopProofKey is the context key of the DPoP proof verified for a request
+type dpopProofKey struct{}
+
+// WithDPoPProof returns a context carrying the DPoP proof verified for a request
+func WithDPoPProof(ctx context.Context, proof *DPoPProof) context.Context {
+	return context.WithValue(ctx, dpopProofKey{}, proof)
+}
+
+// DPoPProofFromContext returns the DPoP proof verified for a request, reporting false when the
+// request came without one or DPoP is not checked
+func DPoPProofFromContext(ctx context.Context) (*DPoPProof, bool) {
+	proof, ok := ctx.Value(dpopProofKey{}).(*DPoPProof)
+	return proof, ok && proof != nil
+}
+
+// DPoPVerifier verifies DPoP proofs (RFC 9449) and remembers the jti of every proof it accepts
+// so a captured proof cannot be sent again
+type DPoPVerifier struct {
+	maxAge    time.Duration
+	replayTTL time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	nextPurge time.Time
+}
+
+// NewDPoPVerifier creates a verifier accepting proofs issued at most maxAge ago, remembering
+// each accepted jti for replayTTL and at least until its proof is too old to be accepted
+func NewDPoPVerifier(maxAge, replayTTL time.Duration) *DPoPVerifier {
+	return &DPoPVerifier{
+		maxAge:    maxAge,
+		replayTTL: replayTTL,
+		now:       time.Now,
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// dpopHeader is the JOSE header of a DPoP proof
+type dpopHeader struct {
+	Typ string          `json:"typ"`
+	Alg string          `json:"alg"`
+	JWK json.RawMessage `json:"jwk"`
+}
+
+// dpopJWK is the public key embedded in a DPoP proof
+type dpopJWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d"`
+}
+
+// dpopClaims are the claims of a DPoP proof
+type dpopClaims struct {
+	JTI string   `json:"jti"`
+	HTM string   `json:"htm"`
+	HTU string   `json:"htu"`
+	IAT *float64 `json:"iat"`
+	ATH string   `json:"ath"`
+}
+
+// Verify checks a DPoP proof sent with a request: that it is a dpop+jwt signed by the public
+// key in its header, made for the request's method and URL, recent, not seen before, and, when
+// an access token is sent with it, that its ath is the token's hash
+func (v *DPoPVerifier) Verify(proof, method, requestURL, accessToken string) (*DPoPProof, error) {
+	parts := strings.Split(proof, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a compact JWS", ErrInvalidDPoPProof)
+	}
+
+	var header dpopHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidDPoPProof)
+	}
+	if header.Typ != "dpop+jwt" {
+		return nil, fmt.Errorf("%w: typ must be dpop+jwt", ErrInvalidDPoPProof)
+	}
+	var jwk dpopJWK
+	if len(header.JWK) == 0 || json.Unmarshal(header.JWK, &jwk) != nil {
+		return nil, fmt.Errorf("%w: header carries no jwk", ErrInvalidDPoPProof)
+	}
+	if jwk.D != "" {
+		return nil, fmt.Errorf("%w: jwk must be a public key", ErrInvalidDPoPProof)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidDPoPProof)
+	}
+	if err := verifyJWS(header.Alg, jwk, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDPoPProof, err)
+	}
+
+	var claims dpopClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidDPoPProof)
+	}
+	if claims.JTI == "" || claims.IAT == nil {
+		return nil, fmt.Errorf("%w: jti and iat are required", ErrInvalidDPoPProof)
+	}
+	if claims.HTM != method {
+		return nil, fmt.Errorf("%w: htm does not match the request method", ErrInvalidDPoPProof)
+	}
+	if !sameTargetURI(claims.HTU, requestURL) {
+		return nil, fmt.Errorf("%w: htu does not match the request URL", ErrInvalidDPoPProof)
+	}
+	now := v.now()
+	issuedAt := time.Unix(int64(*claims.IAT), 0)
+	if issuedAt.After(now.Add(dpopClockSkew)) || now.Sub(issuedAt) > v.maxAge {
+		return nil, fmt.Errorf("%w: iat is outside the accepted window", ErrInvalidDPoPProof)
+	}
+	if accessToken != "" && claims.ATH != AccessTokenHash(accessToken) {
+		return nil, fmt.Errorf("%w: ath does not match the access token", ErrInvalidDPoPProof)
+	}
+
+	thumbprint, err := jwkThumbprint(jwk)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDPoPProof, err)
+	}
+	if !v.remember(claims.JTI, issuedAt, now) {
+		return nil, fmt.Errorf("%w: jti was already used", ErrInvalidDPoPProof)
+	}
+	return &DPoPProof{Thumbprint: thumbprint, JTI: claims.JTI, IssuedAt: issuedAt}, nil
+}
+
+// remember records a proof's jti, reporting false if it was already recorded. Expired entries
+// are dropped at most once a minute.
+func (v *DPoPVerifier) remember(jti string, issuedAt, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if now.After(v.nextPurge) {
+		for seenJTI, expires := range v.seen {
+			if now.After(expires) {
+				delete(v.seen, seenJTI)
+			}
+		}
+		v.nextPurge = now.Add(time.Minute)
+	}
+
+	if expires, found := v.seen[jti]; found && !now.After(expires) {
+		return false
+	}
+	expires := now.Add(v.replayTTL)
+	if acceptable := issuedAt.Add(v.maxAge); acceptable.After(expires) {
+		expires = acceptable
+	}
+	v.seen[jti] = expires
+	return true
+}
+
+// AccessTokenHash returns the ath a DPoP proof carries for an access token: the base64url
+// SHA-256 hash of the token
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AccessTokenThumbprint returns the cnf.jkt of a JWT access token, the thumbprint of the key
+// the token is bound to. It reports false for opaque tokens and tokens bound to no key. The
+// token's own signature is not checked; that is left to whoever issued it.
+func AccessTokenThumbprint(accessToken string) (string, bool) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	var claims struct {
+		Cnf struct {
+			JKT string `json:"jkt"`
+		} `json:"cnf"`
+	}
+	if err := decodeJWTPart(parts[1], &claims); err != nil || claims.Cnf.JKT == "" {
+		return "", false
+	}
+	return claims.Cnf.JKT, true
+}
+
+// decodeJWTPart decodes one base64url JSON part of a JWT
+func decodeJWTPart(part string, into interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, into)
+}
+
+// verifyJWS checks a JWS signature made with alg by the key jwk describes
+func verifyJWS(alg string, jwk dpopJWK, signingInput, signature []byte) error {
+	var key crypto.PublicKey
+	var err error
+	switch {
+	case strings.HasPrefix(alg, "ES"):
+		key, err = ecdsaKey(jwk, alg)
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		key, err = rsaKey(jwk)
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	if err != nil {
+		return err
+	}
+	return verifySignature(alg, key, signingInput, signature)
+}
+
+// verifySignature checks a JWS signature made with alg by a public key, which must be of the
+// kind alg signs with
+func verifySignature(alg string, key crypto.PublicKey, signingInput, signature []byte) error {
+	hash, ok := dpopHashes[alg]
+	if !ok {
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	hasher := hash.New()
+	hasher.Write(signingInput)
+	digest := hasher.Sum(nil)
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") || key.Curve != ecdsaCurves[alg] {
+			return fmt.Errorf("key does not match alg %s", alg)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("signature has the wrong length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("signature does not verify")
+		}
+		return nil
+	case *rsa.PublicKey:
+		var err error
+		switch {
+		case strings.HasPrefix(alg, "PS"):
+			err = rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		case strings.HasPrefix(alg, "RS"):
+			err = rsa.VerifyPKCS1v15(key, hash, digest, signature)
+		default:
+			return fmt.Errorf("key does not match alg %s", alg)
+		}
+		if err != nil {
+			return errors.New("signature does not verify")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// ecdsaCurves are the curves the ES algorithms sign with
+var ecdsaCurves = map[string]elliptic.Curve{"ES256": elliptic.P256(), "ES384": elliptic.P384(), "ES512": elliptic.P521()}
+
+// ecdsaKey builds the EC public key of a JWK, checking its curve is the one alg signs with
+func ecdsaKey(jwk dpopJWK, alg string) (*ecdsa.PublicKey, error) {
+	curve, ok := ecdsaCurves[alg]
+	if !ok || jwk.Kty != "EC" || jwk.Crv != curve.Params().Name {
+		return nil, fmt.Errorf("jwk does not match alg %s", alg)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+	y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if errX != nil || errY != nil {
+		return nil, errors.New("malformed EC jwk")
+	}
+	key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("EC jwk is not on its curve")
+	}
+	return key, nil
+}
+
+// rsaKey builds the RSA public key of a JWK
+func rsaKey(jwk dpopJWK) (*rsa.PublicKey, error) {
+	if jwk.Kty != "RSA" {
+		return nil, errors.New("jwk is not an RSA key")
+	}
+	n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+	e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+	if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("malformed RSA jwk")
+	}
+	key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	if key.N.BitLen() < dpopMinRSABits {
+		return nil, fmt.Errorf("RSA jwk is shorter than %d bits", dpopMinRSABits)
+	}
+	return key, nil
+}
+
+// jwkThumbprint computes the RFC 7638 SHA-256 thumbprint of a public JWK: the hash of its
+// required members, in lexical order, without whitespace
+func jwkThumbprint(jwk dpopJWK) (string, error) {
+	var members interface{}
+	switch jwk.Kty {
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	default:
+		return "", fmt.Errorf("unsupported jwk kty %q", jwk.Kty)
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// sameTargetURI reports whether a proof's htu names the request URL, comparing scheme, host
+// and path case-insensitively where URIs are, ignoring default ports, query and fragment
+func sameTargetURI(htu, requestURL string) bool {
+	normalize := func(raw string) (string, bool) {
+		parsed, err := url.Parse(raw)
+		if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+			return "", false
+		}
+		scheme := strings.ToLower(parsed.Scheme)
+		host := strings.ToLower(parsed.Hostname())
+		if port := parsed.Port(); port != "" && !(scheme == "https" && port == "443") && !(scheme == "http" && port == "80") {
+			host += ":" + port
+		}
+		path := parsed.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		return scheme + "://" + host + path, true
+	}
+	claimed, ok := normalize(htu)
+	if !ok {
+		return false
+	}
+	actual, ok := normalize(requestURL)
+	return ok && claimed == actual
+}
+
+// DPoP returns a filter verifying the DPoP proof sent with each request to the given path
+// prefixes and putting each verified proof on the request context. Proofs sent with an access
+// token must carry the token's hash, and a token bound to a key through cnf.jkt must be sent
+// under the DPoP scheme with a proof signed by that key (RFC 9449 section 7.1). When DPoP is
+// required, access tokens sent without a proof, or not bound to a key, are refused. Refused
+// requests get 401 with a WWW-Authenticate: DPoP challenge. The request URL proofs are
+// checked against is https only when IsSecureRequest says so for the trusted proxies.
+func DPoP(verifier *DPoPVerifier, required bool, pathPrefixes []string, proxies TrustedProxies) khttp.FilterFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasPathPrefix(r.URL.Path, pathPrefixes) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scheme, accessToken := authorizationToken(r)
+			proofs := r.Header.Values("DPoP")
+			if len(proofs) > 1 {
+				writeDPoPChallenge(w, "invalid_dpop_proof", "exactly one DPoP proof must be sent")
+				return
+			}
+
+			if len(proofs) == 0 {
+				switch {
+				case accessToken == "":
+				case scheme == "dpop":
+					writeDPoPChallenge(w, "invalid_dpop_proof", "DPoP access tokens must be sent with a DPoP proof")
+					return
+				case required:
+					writeDPoPChallenge(w, "", "access tokens must be sent with a DPoP proof")
+					return
+				default:
+					if _, bound := AccessTokenThumbprint(accessToken); bound {
+						writeDPoPChallenge(w, "invalid_token", "the access token is bound to a key and must be sent with a DPoP proof")
+						return
+					}
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			proof, err := verifier.Verify(proofs[0], r.Method, requestURL(r, proxies), accessToken)
+			if err != nil {
+				writeDPoPChallenge(w, "invalid_dpop_proof", err.Error())
+				return
+			}
+			if accessToken != "" {
+				thumbprint, bound := AccessTokenThumbprint(accessToken)
+				if bound && scheme != "dpop" {
+					writeDPoPChallenge(w, "invalid_token", "access tokens bound to a key must be sent with the DPoP scheme")
+					return
+				}
+				if bound && thumbprint != proof.Thumbprint {
+					writeDPoPChallenge(w, "invalid_token", "the access token is bound to a different key")
+					return
+				}
+				if !bound && required {
+					writeDPoPChallenge(w, "invalid_token", "the access token is not bound to a DPoP key")
+					return
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(WithDPoPProof(r.Context(), proof)))
+		})
+	}
+}
+
+// authorizationToken returns the lower-cased scheme and the token of a Bearer or DPoP
+// Authorization header, or empty strings for any other
+func authorizationToken(r *http.Request) (string, string) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	scheme = strings.ToLower(scheme)
+	if !found || (scheme != "bearer" && scheme != "dpop") {
+		return "", ""
+	}
+	return scheme, strings.TrimSpace(token)
+}
+
+// requestURL returns the absolute URL a request was made to, as a DPoP proof names it in htu
+func requestURL(r *http.Request, proxies TrustedProxies) string {
+	scheme := "http"
+	if IsSecureRequest(r, proxies) {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.EscapedPath()
+}
+
+// writeDPoPChallenge refuses a request with 401 and a DPoP challenge listing the accepted
+// algorithms, naming the error when there is one
+func writeDPoPChallenge(w http.ResponseWriter, errorCode, description string) {
+	challenge := fmt.Sprintf(`DPoP algs="%s"`, dpopAlgorithms)
+	if errorCode != "" {
+		challenge += fmt.Sprintf(`, error="%s", error_description="%s"`, errorCode, strings.ReplaceAll(description, `"`, `'`))
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+
+	code := "DPOP_REQUIRED"
+	if errorCode != "" {
+		code = strings.ToUpper(errorCode)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   code,
+		"message": description,
+	})
+}
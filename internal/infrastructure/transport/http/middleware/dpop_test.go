@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dpopClient signs DPoP proofs with an ES256 key
+type dpopClient struct {
+	key *ecdsa.PrivateKey
+	jwk map[string]string
+}
+
+func newDPoPClient(t *testing.T) *dpopClient {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &dpopClient{key: key, jwk: map[string]string{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}}
+}
+
+// proof signs a proof with the given claims
+func (c *dpopClient) proof(t *testing.T, claims map[string]interface{}) string {
+	signingInput := encodeJWTPart(t, map[string]interface{}{"typ": "dpop+jwt", "alg": "ES256", "jwk": c.jwk}) + "." + encodeJWTPart(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, c.key, digest[:])
+	require.NoError(t, err)
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// requestProof signs a fresh proof for a request
+func (c *dpopClient) requestProof(t *testing.T, jti, method, htu, accessToken string) string {
+	claims := map[string]interface{}{"jti": jti, "htm": method, "htu": htu, "iat": time.Now().Unix()}
+	if accessToken != "" {
+		claims["ath"] = AccessTokenHash(accessToken)
+	}
+	return c.proof(t, claims)
+}
+
+// thumbprint returns the thumbprint of the client's key
+func (c *dpopClient) thumbprint(t *testing.T) string {
+	thumbprint, err := jwkThumbprint(dpopJWK{Kty: "EC", Crv: "P-256", X: c.jwk["x"], Y: c.jwk["y"]})
+	require.NoError(t, err)
+	return thumbprint
+}
+
+func encodeJWTPart(t *testing.T, value interface{}) string {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// boundAccessToken returns an unsigned JWT access token bound to a key thumbprint
+func boundAccessToken(t *testing.T, thumbprint string) string {
+	return encodeJWTPart(t, map[string]string{"alg": "none"}) + "." +
+		encodeJWTPart(t, map[string]interface{}{"sub": "https://alice.example/profile#me", "cnf": map[string]string{"jkt": thumbprint}}) + ".sig"
+}
+
+// tamperClaims swaps a signed proof's claims for ones naming another jti, keeping its signature
+func tamperClaims(t *testing.T, proof string) string {
+	parts := strings.Split(proof, ".")
+	claims := map[string]interface{}{}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &claims))
+	claims["jti"] = "tampered"
+	return parts[0] + "." + encodeJWTPart(t, claims) + "." + parts[2]
+}
+
+func TestDPoPVerifier_Verify(t *testing.T) {
+	client := newDPoPClient(t)
+	const target = "https://pod.example/resources/notes"
+
+	t.Run("accepts a fresh proof and returns the key thumbprint", func(t *testing.T) {
+		verifier := NewDPoPVerifier(time.Minute, time.Minute)
+		proof, err := verifier.Verify(client.requestProof(t, "fresh", "GET", target+"?page=2", "token"), "GET", target, "token")
+		require.NoError(t, err)
+		assert.Equal(t, client.thumbprint(t), proof.Thumbprint)
+		assert.Equal(t, "fresh", proof.JTI)
+	})
+
+	t.Run("refuses replayed proofs", func(t *testing.T) {
+		verifier := NewDPoPVerifier(time.Minute, time.Minute)
+		proof := client.requestProof(t, "once", "GET", target, "")
+		_, err := verifier.Verify(proof, "GET", target, "")
+		require.NoError(t, err)
+		_, err = verifier.Verify(proof, "GET", target, "")
+		assert.True(t, errors.Is(err, ErrInvalidDPoPProof))
+	})
+
+	t.Run("forgets jtis after the replay TTL once proofs are too old", func(t *testing.T) {
+		verifier := NewDPoPVerifier(time.Minute, time.Minute)
+		now := time.Now()
+		verifier.now = func() time.Time { return now }
+		require.True(t, verifier.remember("old", now, now))
+		assert.False(t, verifier.remember("old", now, now.Add(30*time.Second)))
+		assert.True(t, verifier.remember("old", now, now.Add(2*time.Minute)))
+	})
+
+	refused := []struct {
+		name        string
+		proof       string
+		method      string
+		accessToken string
+	}{
+		{"another method", client.requestProof(t, "m", "POST", target, ""), "GET", ""},
+		{"another URL", client.requestProof(t, "u", "GET", "https://pod.example/resources/other", ""), "GET", ""},
+		{"another host", client.requestProof(t, "h", "GET", "https://evil.example/resources/notes", ""), "GET", ""},
+		{"another access token", client.requestProof(t, "a", "GET", target, "other"), "GET", "token"},
+		{"missing ath", client.requestProof(t, "n", "GET", target, ""), "GET", "token"},
+		{"stale iat", client.proof(t, map[string]interface{}{"jti": "s", "htm": "GET", "htu": target, "iat": time.Now().Add(-2 * time.Minute).Unix()}), "GET", ""},
+		{"future iat", client.proof(t, map[string]interface{}{"jti": "f", "htm": "GET", "htu": target, "iat": time.Now().Add(2 * time.Minute).Unix()}), "GET", ""},
+		{"missing jti", client.proof(t, map[string]interface{}{"htm": "GET", "htu": target, "iat": time.Now().Unix()}), "GET", ""},
+		{"tampered claims", tamperClaims(t, client.requestProof(t, "t", "GET", target, "")), "GET", ""},
+		{"not a JWT", "proof", "GET", ""},
+	}
+	for _, tt := range refused {
+		t.Run("refuses a proof for "+tt.name, func(t *testing.T) {
+			verifier := NewDPoPVerifier(time.Minute, time.Minute)
+			_, err := verifier.Verify(tt.proof, tt.method, target, tt.accessToken)
+			assert.True(t, errors.Is(err, ErrInvalidDPoPProof), "got %v", err)
+		})
+	}
+
+	t.Run("refuses proofs signed by another key than the embedded one", func(t *testing.T) {
+		impostor := newDPoPClient(t)
+		impostor.jwk = client.jwk
+		verifier := NewDPoPVerifier(time.Minute, time.Minute)
+		_, err := verifier.Verify(impostor.requestProof(t, "i", "GET", target, ""), "GET", target, "")
+		assert.True(t, errors.Is(err, ErrInvalidDPoPProof))
+	})
+
+	t.Run("refuses proofs embedding a private key", func(t *testing.T) {
+		leaky := newDPoPClient(t)
+		leaky.jwk["d"] = base64.RawURLEncoding.EncodeToString(leaky.key.D.Bytes())
+		verifier := NewDPoPVerifier(time.Minute, time.Minute)
+		_, err := verifier.Verify(leaky.requestProof(t, "d", "GET", target, ""), "GET", target, "")
+		assert.True(t, errors.Is(err, ErrInvalidDPoPProof))
+	})
+
+	t.Run("accepts RS256 proofs", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		jwk := map[string]string{
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+		signingInput := encodeJWTPart(t, map[string]interface{}{"typ": "dpop+jwt", "alg": "RS256", "jwk": jwk}) + "." +
+			encodeJWTPart(t, map[string]interface{}{"jti": "rsa", "htm": "PUT", "htu": target, "iat": time.Now().Unix()})
+		digest := sha256.Sum256([]byte(signingInput))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+
+		verifier := NewDPoPVerifier(time.Minute, time.Minute)
+		_, err = verifier.Verify(signingInput+"."+base64.RawURLEncoding.EncodeToString(signature), "PUT", target, "")
+		assert.NoError(t, err)
+	})
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// The example key of RFC 7638 section 3.1
+	jwk := dpopJWK{
+		Kty: "RSA",
+		E:   "AQAB",
+		N: "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn6" +
+			"4tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91Cb" +
+			"OpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	}
+	thumbprint, err := jwkThumbprint(jwk)
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+}
+
+func TestDPoP(t *testing.T) {
+	client := newDPoPClient(t)
+	boundToken := boundAccessToken(t, client.thumbprint(t))
+	otherToken := boundAccessToken(t, newDPoPClient(t).thumbprint(t))
+	const target = "https://pod.example/resources/notes"
+	// The test requests come through a TLS-terminating proxy at 192.0.2.1
+	proxies, err := ParseTrustedProxies([]string{"192.0.2.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		required       bool
+		path           string
+		authorization  string
+		proof          func() string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "no credentials", expectedStatus: http.StatusOK},
+		{name: "bearer token when DPoP is optional", authorization: "Bearer opaque", expectedStatus: http.StatusOK},
+		{name: "bearer token when DPoP is required", required: true, authorization: "Bearer opaque", expectedStatus: http.StatusUnauthorized},
+		{name: "bound token sent as a plain bearer token", authorization: "Bearer " + boundToken, expectedStatus: http.StatusUnauthorized, expectedError: "invalid_token"},
+		{name: "DPoP token without a proof", authorization: "DPoP " + boundToken, expectedStatus: http.StatusUnauthorized, expectedError: "invalid_dpop_proof"},
+		{
+			name: "bound token with a matching proof", required: true, authorization: "DPoP " + boundToken,
+			proof:          func() string { return client.requestProof(t, "ok", "GET", target, boundToken) },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "bound token with a proof but the Bearer scheme", authorization: "Bearer " + boundToken,
+			proof:          func() string { return client.requestProof(t, "bearer", "GET", target, boundToken) },
+			expectedStatus: http.StatusUnauthorized, expectedError: "invalid_token",
+		},
+		{
+			name: "token bound to another key", authorization: "DPoP " + otherToken,
+			proof:          func() string { return client.requestProof(t, "other", "GET", target, otherToken) },
+			expectedStatus: http.StatusUnauthorized, expectedError: "invalid_token",
+		},
+		{
+			name: "unbound token when DPoP is required", required: true, authorization: "DPoP opaque",
+			proof:          func() string { return client.requestProof(t, "unbound", "GET", target, "opaque") },
+			expectedStatus: http.StatusUnauthorized, expectedError: "invalid_token",
+		},
+		{
+			name: "proof for another request", authorization: "DPoP " + boundToken,
+			proof:          func() string { return client.requestProof(t, "post", "POST", target, boundToken) },
+			expectedStatus: http.StatusUnauthorized, expectedError: "invalid_dpop_proof",
+		},
+		{name: "uncovered path", required: true, path: "/health", authorization: "Bearer opaque", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verified *DPoPProof
+			handler := DPoP(NewDPoPVerifier(time.Minute, time.Minute), tt.required, []string{"/resources/", "/containers/"}, proxies)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					verified, _ = DPoPProofFromContext(r.Context())
+					w.WriteHeader(http.StatusOK)
+				}))
+
+			path := tt.path
+			if path == "" {
+				path = "/resources/notes"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Host = "pod.example"
+			req.Header.Set("X-Forwarded-Proto", "https")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.proof != nil {
+				req.Header.Set("DPoP", tt.proof())
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK && tt.proof != nil {
+				require.NotNil(t, verified)
+				assert.Equal(t, client.thumbprint(t), verified.Thumbprint)
+			}
+			if tt.expectedStatus == http.StatusUnauthorized {
+				challenge := rec.Header().Get("WWW-Authenticate")
+				assert.Contains(t, challenge, `DPoP algs="`)
+				if tt.expectedError != "" {
+					assert.Contains(t, challenge, `error="`+tt.expectedError+`"`)
+				}
+			}
+		})
+	}
+}
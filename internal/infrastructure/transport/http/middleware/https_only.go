@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// TrustedProxies are the networks of the proxies in front of the server. Only requests whose
+// peer is one of them have their X-Forwarded-Proto and Forwarded headers believed; any client
+// can set those headers itself.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses proxy addresses given as IPs or CIDR ranges
+func ParseTrustedProxies(entries []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// Trusts reports whether a request came straight from one of the trusted proxies
+func (p TrustedProxies) Trusts(r *http.Request) bool {
+	if len(p) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPSOnly returns a filter refusing requests to the given path prefixes that were not made
+// over TLS with 426 Upgrade Required, so credentials sent to them are never exposed in transit.
+// A request counts as secure when it arrived over TLS or a trusted proxy in front of the server
+// reports that it did through X-Forwarded-Proto or Forwarded.
+func HTTPSOnly(pathPrefixes []string, proxies TrustedProxies) khttp.FilterFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasPathPrefix(r.URL.Path, pathPrefixes) || IsSecureRequest(r, proxies) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Upgrade", "TLS/1.2, HTTP/1.1")
+			w.Header().Set("Connection", "Upgrade")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUpgradeRequired)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"error":   "HTTPS_REQUIRED",
+				"message": "This endpoint only accepts requests made over HTTPS",
+			})
+		})
+	}
+}
+
+// IsSecureRequest reports whether a request arrived over TLS, directly or through a trusted
+// proxy that terminated TLS and said so in X-Forwarded-Proto or Forwarded. The headers of
+// requests from any other peer are ignored.
+func IsSecureRequest(r *http.Request, proxies TrustedProxies) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !proxies.Trusts(r) {
+		return false
+	}
+
+	// A proxy chain lists the protocol of the first hop first
+	if proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ","); proto != "" {
+		return strings.EqualFold(strings.TrimSpace(proto), "https")
+	}
+
+	forwarded, _, _ := strings.Cut(r.Header.Get("Forwarded"), ",")
+	for _, pair := range strings.Split(forwarded, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && strings.EqualFold(key, "proto") {
+			return strings.EqualFold(strings.Trim(value, `"`), "https")
+		}
+	}
+	return false
+}
+
+// hasPathPrefix reports whether a path falls under any of the prefixes. A prefix without a
+// trailing slash also matches the paths below it, so "/login" covers "/login/callback" but
+// not "/loginhelp".
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		trimmed := strings.TrimSuffix(prefix, "/")
+		if path == trimmed || strings.HasPrefix(path, trimmed+"/") {
+			return true
+		}
+	}
+	return false
+}
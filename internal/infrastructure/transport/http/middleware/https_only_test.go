@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSOnly(t *testing.T) {
+	// httptest requests come from 192.0.2.1, the trusted proxy unless a test says otherwise
+	proxies, err := ParseTrustedProxies([]string{"192.0.2.1", "10.0.0.0/8"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		path           string
+		headers        map[string]string
+		remoteAddr     string
+		tls            bool
+		expectedStatus int
+	}{
+		{name: "plain HTTP to a login endpoint", path: "/login", expectedStatus: http.StatusUpgradeRequired},
+		{name: "plain HTTP below an auth prefix", path: "/auth/callback", expectedStatus: http.StatusUpgradeRequired},
+		{name: "TLS to a login endpoint", path: "/login", tls: true, expectedStatus: http.StatusOK},
+		{name: "proxy reporting HTTPS", path: "/login", headers: map[string]string{"X-Forwarded-Proto": "https"}, expectedStatus: http.StatusOK},
+		{name: "proxy reporting HTTP", path: "/login", headers: map[string]string{"X-Forwarded-Proto": "http"}, expectedStatus: http.StatusUpgradeRequired},
+		{name: "first hop of a proxy chain decides", path: "/login", headers: map[string]string{"X-Forwarded-Proto": "http, https"}, expectedStatus: http.StatusUpgradeRequired},
+		{name: "Forwarded header reporting HTTPS", path: "/login", headers: map[string]string{"Forwarded": `for=192.0.2.60;proto="https"`}, expectedStatus: http.StatusOK},
+		{name: "proxy in a trusted range", path: "/login", headers: map[string]string{"X-Forwarded-Proto": "https"}, remoteAddr: "10.1.2.3:4567", expectedStatus: http.StatusOK},
+		{name: "client spoofing X-Forwarded-Proto", path: "/login", headers: map[string]string{"X-Forwarded-Proto": "https"}, remoteAddr: "203.0.113.9:4567", expectedStatus: http.StatusUpgradeRequired},
+		{name: "client spoofing Forwarded", path: "/login", headers: map[string]string{"Forwarded": "proto=https"}, remoteAddr: "203.0.113.9:4567", expectedStatus: http.StatusUpgradeRequired},
+		{name: "path sharing a prefix", path: "/loginhelp", expectedStatus: http.StatusOK},
+		{name: "unrelated path", path: "/resources/doc", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+
+			w := httptest.NewRecorder()
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			HTTPSOnly([]string{"/auth/", "/login"}, proxies)(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUpgradeRequired {
+				assert.Equal(t, "TLS/1.2, HTTP/1.1", w.Header().Get("Upgrade"))
+				assert.Contains(t, w.Body.String(), "HTTPS_REQUIRED")
+			}
+		})
+	}
+}
+
+func TestIsSecureRequest_WithoutTrustedProxies(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/login", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+
+	assert.False(t, IsSecureRequest(req, nil))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"127.0.0.1", "::1", "172.16.0.0/12"})
+	require.NoError(t, err)
+	assert.Len(t, proxies, 3)
+
+	for _, invalid := range []string{"proxy.internal", "10.0.0.0/33", ""} {
+		_, err := ParseTrustedProxies([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
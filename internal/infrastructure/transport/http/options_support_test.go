@@ -138,7 +138,7 @@ func TestOPTIONSCORSPreflight(t *testing.T) {
 			requestHeaders:         "Content-Type,Authorization",
 			expectCORSHeaders:      true,
 			expectedAllowedMethods: "GET,POST,PUT,DELETE,PATCH,HEAD,OPTIONS",
-			expectedAllowedHeaders: "Content-Type,Authorization,X-Requested-With",
+			expectedAllowedHeaders: "Content-Type,Authorization,X-Requested-With,If-Match,If-None-Match",
 		},
 		{
 			name:                   "Simple OPTIONS without CORS headers should go to handler",
@@ -147,7 +147,7 @@ func TestOPTIONSCORSPreflight(t *testing.T) {
 			requestHeaders:         "",
 			expectCORSHeaders:      true, // CORS middleware still adds headers
 			expectedAllowedMethods: "GET,POST,PUT,DELETE,PATCH,HEAD,OPTIONS",
-			expectedAllowedHeaders: "Content-Type,Authorization,X-Requested-With",
+			expectedAllowedHeaders: "Content-Type,Authorization,X-Requested-With,If-Match,If-None-Match",
 		},
 	}
 
@@ -288,3 +288,55 @@ func TestOPTIONSWithPathParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestServerWideOPTIONS(t *testing.T) {
+	logger := log.NewStdLogger(nil)
+	config := &conf.HTTP{
+		Addr:    ":0",
+		Timeout: 30000000000,
+	}
+
+	server := NewHTTPServerWithoutResourceHandler(config, logger, handlers.NewHealthHandler(logger), handlers.NewRequestResponseHandler(logger))
+	capabilities := handlers.ServerCapabilitiesFromConfig(&conf.Container{FeedsEnabled: true}, &conf.Auth{AdminTokens: []string{"secret"}})
+	RegisterServerCapabilities(server, handlers.NewServerCapabilitiesHandler(capabilities, logger))
+
+	if !server.DisableGeneralOptionsHandler {
+		t.Fatal("Go's built-in OPTIONS * handler should be disabled")
+	}
+
+	req := httptest.NewRequest("OPTIONS", "*", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET, HEAD, POST, PUT, DELETE, OPTIONS" {
+		t.Errorf("Unexpected Allow header %q", allow)
+	}
+	if !strings.Contains(w.Header().Get("Accept-Post"), "text/turtle") {
+		t.Errorf("Accept-Post should list the RDF formats, got %q", w.Header().Get("Accept-Post"))
+	}
+	if got := w.Header().Get("X-Auth-Methods"); got != "Bearer" {
+		t.Errorf("Unexpected X-Auth-Methods header %q", got)
+	}
+	if got := w.Header().Get("X-Features"); got != "feeds" {
+		t.Errorf("Unexpected X-Features header %q", got)
+	}
+
+	var body handlers.ServerCapabilities
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to parse capabilities body: %v", err)
+	}
+	if !body.Features["feeds"] || body.Features["notifications"] {
+		t.Errorf("Unexpected features %v", body.Features)
+	}
+
+	// Path-targeted OPTIONS requests still reach the router
+	req = httptest.NewRequest("OPTIONS", "/health", nil)
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Header().Get("X-Features") != "" {
+		t.Error("OPTIONS on a path should not be answered with server-wide capabilities")
+	}
+}
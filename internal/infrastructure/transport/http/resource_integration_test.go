@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -85,7 +86,7 @@ func TestResourceEndpointsIntegration(t *testing.T) {
 // createResourceTestServer creates a test HTTP server with all dependencies
 func createResourceTestServer(t *testing.T, tempDir string, logger log.Logger) *khttp.Server {
 	// Create infrastructure dependencies
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	require.NoError(t, err)
 
 	eventStore, err := infrastructure.EventStoreProvider(db)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/handlers"
 	"github.com/akeemphilbert/goro/internal/infrastructure/transport/http/middleware"
 	"github.com/go-kratos/kratos/v2/log"
@@ -128,10 +129,25 @@ func RegisterResourceRoutes(srv *http.Server, resourceHandler *handlers.Resource
 
 	// Individual resource operations
 	resourceRoute.GET("/{id}", resourceHandler.GetResource)
+	resourceRoute.POST("/{id}", resourceHandler.PostToResource)
 	resourceRoute.PUT("/{id}", resourceHandler.PutResource)
+	resourceRoute.PATCH("/{id}", resourceHandler.PatchResource)
 	resourceRoute.DELETE("/{id}", resourceHandler.DeleteResource)
 	resourceRoute.HEAD("/{id}", resourceHandler.HeadResource)
 	resourceRoute.OPTIONS("/{id}", resourceHandler.OptionsResource)
+	resourceRoute.GET("/{id}/meta", resourceHandler.GetResourceMetadata)
+
+	// Batch existence check, limited to resources the caller may read
+	srv.Route("/").POST("/exists", resourceHandler.CheckExistence)
+
+	// Resumable uploads of large resources, assembled on completion
+	uploadRoute := srv.Route("/uploads")
+	uploadRoute.POST("/", resourceHandler.CreateUpload)
+	uploadRoute.GET("/{id}", resourceHandler.GetUpload)
+	uploadRoute.HEAD("/{id}", resourceHandler.GetUpload)
+	uploadRoute.PUT("/{id}", resourceHandler.AppendUpload)
+	uploadRoute.DELETE("/{id}", resourceHandler.AbortUpload)
+	uploadRoute.POST("/{id}/complete", resourceHandler.CompleteUpload)
 }
 
 // RegisterContainerRoutes registers container management endpoints
@@ -143,15 +159,125 @@ func RegisterContainerRoutes(srv *http.Server, containerHandler *handlers.Contai
 	containerRoute.POST("/", containerHandler.PostResource)
 	containerRoute.OPTIONS("/", containerHandler.OptionsContainer)
 
+	// Batch container creation
+	containerRoute.POST("/batch", containerHandler.PostContainerBatch)
+
 	// Individual container operations
 	containerRoute.GET("/{id}", containerHandler.GetContainer)
+	containerRoute.POST("/{id}", containerHandler.PostResource)
 	containerRoute.PUT("/{id}", containerHandler.PutContainer)
 	containerRoute.DELETE("/{id}", containerHandler.DeleteContainer)
 	containerRoute.HEAD("/{id}", containerHandler.HeadContainer)
 	containerRoute.OPTIONS("/{id}", containerHandler.OptionsContainer)
+	containerRoute.GET("/{id}/breadcrumbs", containerHandler.GetBreadcrumbs)
+	containerRoute.GET("/{id}/structure", containerHandler.GetContainerStructure)
+	containerRoute.GET("/{id}/listings/{listing_id}", containerHandler.GetAsyncListing)
+	containerRoute.POST("/{id}/touch", containerHandler.TouchContainer)
 
 	// Container member operations - use PostResource for adding members
 	containerRoute.POST("/{id}/members", containerHandler.PostResource)
+	containerRoute.POST("/{id}/members/{member_id}/move", containerHandler.MoveMember)
+
+	// Pod-wide metadata search
+	srv.Route("/pods").GET("/{account_id}/search", containerHandler.SearchPod)
+}
+
+// RegisterAdminRoutes registers operator endpoints restricted to admin principals
+func RegisterAdminRoutes(srv *http.Server, adminHandler *handlers.AdminHandler) {
+	admin := srv.Route("/admin")
+	admin.GET("/stats", adminHandler.GetStats)
+	admin.GET("/dead-letters", adminHandler.ListDeadLetters)
+	admin.POST("/dead-letters/{id}/replay", adminHandler.ReplayDeadLetter)
+	admin.GET("/events", adminHandler.ExportEventLog)
+	admin.GET("/retention", adminHandler.GetRetentionReport)
+	admin.POST("/retention/sweep", adminHandler.SweepRetention)
+	admin.POST("/cache/invalidate", adminHandler.InvalidateCache)
+}
+
+// RegisterSolidNotificationRoutes registers the Solid Notifications Protocol subscription
+// resource and channel endpoints
+func RegisterSolidNotificationRoutes(srv *http.Server, notificationHandler *handlers.SolidNotificationHandler) {
+	notifications := srv.Route("/notifications")
+	notifications.POST("/subscriptions", notificationHandler.Subscribe)
+	notifications.GET("/channels/{id}", notificationHandler.GetChannel)
+	notifications.DELETE("/channels/{id}", notificationHandler.DeleteChannel)
+}
+
+// RegisterContainerSubscriptionRoutes registers the WebSocket and Server-Sent Events endpoint
+// serving live container and resource change notifications
+func RegisterContainerSubscriptionRoutes(srv *http.Server, subscriptionHandler *handlers.ContainerSubscriptionHandler) {
+	srv.Route("/").GET("/subscribe", subscriptionHandler.Subscribe)
+}
+
+// RegisterHTTPSOnlyPaths makes the endpoints carrying credentials refuse requests not made over
+// TLS, unless the insecure development flag is set. Forwarded protocol headers are only
+// believed from the configured trusted proxies. The check wraps the server's handler so it
+// runs before any route, including ones registered later.
+func RegisterHTTPSOnlyPaths(srv *http.Server, auth *conf.Auth, logger log.Logger) error {
+	if auth == nil || len(auth.HTTPSOnlyPaths) == 0 {
+		return nil
+	}
+	if auth.InsecureDev {
+		log.NewHelper(logger).Warnf("HTTPS is not enforced on auth endpoints %v; insecure_dev must not be used in production", auth.HTTPSOnlyPaths)
+		return nil
+	}
+	proxies, err := middleware.ParseTrustedProxies(auth.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	srv.Handler = middleware.HTTPSOnly(auth.HTTPSOnlyPaths, proxies)(srv.Handler)
+	return nil
+}
+
+// RegisterWebAccessControl checks resource and container requests against their effective
+// .acl. The check wraps the server's handler so it runs before the routes and their filters.
+func RegisterWebAccessControl(srv *http.Server, accessControlHandler *handlers.WebAccessControlHandler) {
+	srv.Handler = accessControlHandler.Wrap(srv.Handler)
+}
+
+// RegisterAuthentication verifies the JWT access tokens sent with requests and puts the
+// identity each names on the request context. It must be registered after Web Access Control
+// and before DPoP, so it runs after the proof is verified and before access is checked. Without
+// configured issuer keys no request is authenticated.
+func RegisterAuthentication(srv *http.Server, auth *conf.Auth) error {
+	if auth == nil || len(auth.AccessTokens.PublicKeyFiles) == 0 {
+		return nil
+	}
+	keys, err := middleware.LoadAccessTokenKeys(auth.AccessTokens.PublicKeyFiles)
+	if err != nil {
+		return err
+	}
+	verifier := middleware.NewAccessTokenVerifier(auth.AccessTokens.Issuer, auth.AccessTokens.Audience, keys)
+	srv.Handler = middleware.Authenticate(verifier)(srv.Handler)
+	return nil
+}
+
+// RegisterDPoP verifies the DPoP proofs sent to the configured paths and, when DPoP is
+// required, refuses access tokens sent there without one. The check wraps the server's
+// handler so it runs before authentication, Web Access Control, the routes and their filters.
+func RegisterDPoP(srv *http.Server, auth *conf.Auth) error {
+	if auth == nil || !(auth.DPoP.Enabled || auth.DPoP.Required) {
+		return nil
+	}
+	// Default only the DPoP settings; the other auth settings keep their configured values
+	settings := *auth
+	settings.SetDefaults()
+	dpop := settings.DPoP
+	proxies, err := middleware.ParseTrustedProxies(auth.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	verifier := middleware.NewDPoPVerifier(time.Duration(dpop.ProofMaxAge), time.Duration(dpop.ReplayTTL))
+	srv.Handler = middleware.DPoP(verifier, dpop.Required, dpop.Paths, proxies)(srv.Handler)
+	return nil
+}
+
+// RegisterServerCapabilities answers OPTIONS * with the server-wide capabilities. Go's HTTP
+// server answers OPTIONS * on its own by default, so that is turned off, and the request is
+// intercepted ahead of the filters and router, which only handle path request targets.
+func RegisterServerCapabilities(srv *http.Server, capabilitiesHandler *handlers.ServerCapabilitiesHandler) {
+	srv.DisableGeneralOptionsHandler = true
+	srv.Handler = capabilitiesHandler.Wrap(srv.Handler)
 }
 
 // RegisterRoutes registers basic routes on the HTTP server
@@ -174,6 +300,9 @@ func RegisterRoutes(srv *http.Server, healthHandler *handlers.HealthHandler, req
 		})
 	})
 
+	// Prometheus metrics endpoint
+	srv.Handle("/metrics", metrics.DefaultRegistry.Handler())
+
 	// Resource storage endpoints
 	RegisterResourceRoutes(srv, resourceHandler)
 
@@ -655,6 +784,21 @@ func RegisterUserRoutes(srv *http.Server, userHandler *handlers.UserHandler) {
 
 	// WebID endpoints
 	srv.Route("/api/v1/users").GET("/{id}/webid", userHandler.GetWebID)
+
+	// Caller's accounts
+	srv.Route("/me").GET("/accounts/owned", userHandler.ListMyOwnedAccounts)
+}
+
+// RegisterNotificationRoutes registers the caller's notification routes
+func RegisterNotificationRoutes(srv *http.Server, notificationHandler *handlers.NotificationHandler) {
+	srv.Route("/me").GET("/notifications", notificationHandler.ListMyNotifications)
+	srv.Route("/me").POST("/notifications/{id}/read", notificationHandler.MarkNotificationRead)
+}
+
+// RegisterSessionRoutes registers the caller's sign-in session routes
+func RegisterSessionRoutes(srv *http.Server, sessionHandler *handlers.SessionHandler) {
+	srv.Route("/auth").GET("/sessions", sessionHandler.ListMySessions)
+	srv.Route("/auth").DELETE("/sessions/{id}", sessionHandler.RevokeSession)
 }
 
 // RegisterAccountRoutes registers account management routes
@@ -663,11 +807,14 @@ func RegisterAccountRoutes(srv *http.Server, accountHandler *handlers.AccountHan
 	srv.Route("/api/v1/accounts").POST("/", accountHandler.CreateAccount)
 	srv.Route("/api/v1/accounts").GET("/{id}", accountHandler.GetAccount)
 	srv.Route("/api/v1/accounts").PUT("/{id}", accountHandler.UpdateAccount)
+	srv.Route("/api/v1/accounts").PATCH("/{id}/settings", accountHandler.PatchAccountSettings)
 
 	// Invitation management
 	srv.Route("/api/v1/accounts").POST("/{id}/invitations", accountHandler.InviteUser)
 	srv.Route("/api/v1/accounts").GET("/{id}/invitations", accountHandler.ListInvitations)
+	srv.Route("/api/v1/accounts").POST("/{id}/invitations/{invitation_id}/resend", accountHandler.ResendInvitation)
 	srv.Route("/api/v1/invitations").POST("/{token}/accept", accountHandler.AcceptInvitation)
+	srv.Route("/api/v1/invitations").POST("/{id}/deliver", accountHandler.DeliverInvitation)
 
 	// Member management
 	srv.Route("/api/v1/accounts").GET("/{id}/members", accountHandler.ListMembers)
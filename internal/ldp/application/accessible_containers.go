@@ -0,0 +1,139 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// ContainerAccessResolver decides the access a user effectively holds on a container in an
+// account's pod, from the user's role in the account and any access rules on the container
+type ContainerAccessResolver interface {
+	ContainerAccess(ctx context.Context, userID, accountID, containerID string) (domain.AccessMode, error)
+}
+
+// AccessibleContainer is a container a user may read, located by its path in the pod
+type AccessibleContainer struct {
+	ID     string            `json:"id"`
+	Title  string            `json:"title,omitempty"`
+	Type   string            `json:"type"`
+	Path   string            `json:"path"`
+	Access domain.AccessMode `json:"access"`
+}
+
+// AccessibleContainers is a page of the containers a user may read
+type AccessibleContainers struct {
+	Containers []AccessibleContainer    `json:"containers"`
+	TotalCount int                      `json:"totalCount"`
+	Pagination domain.PaginationOptions `json:"pagination"`
+	HasMore    bool                     `json:"hasMore"`
+}
+
+// SetContainerAccessResolver sets the resolver deciding which containers a user may access
+func (s *ContainerService) SetContainerAccessResolver(resolver ContainerAccessResolver) {
+	s.accessResolver = resolver
+}
+
+// ListAccessibleContainers lists the containers in an account's pod the user holds at least
+// Read on, ordered by path, with the access they hold on each. The pod is the hierarchy rooted
+// at the container named after the account. Containers below one the user cannot read are
+// still listed when the user can read them, since access rules may differ per container.
+// Without an access resolver no container is known to be accessible.
+func (s *ContainerService) ListAccessibleContainers(ctx context.Context, userID, accountID string, pagination domain.PaginationOptions) (*AccessibleContainers, error) {
+	if userID == "" || accountID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("user ID and account ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"user ID and account ID cannot be empty",
+		).WithOperation("ListAccessibleContainers")
+	}
+	if !pagination.IsValid() {
+		pagination = domain.GetDefaultPagination()
+	}
+	if s.accessResolver == nil {
+		return &AccessibleContainers{Containers: []AccessibleContainer{}, Pagination: pagination}, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	root, err := s.containerRepo.GetContainer(ctx, accountID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("ListAccessibleContainers").WithContext("accountID", accountID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve account pod",
+		).WithOperation("ListAccessibleContainers").WithContext("accountID", accountID)
+	}
+
+	// Walk the pod breadth first, carrying each container's path down to its children
+	type pending struct {
+		container domain.ContainerResource
+		path      string
+		depth     int
+	}
+	queue := []pending{{container: root, path: "/" + root.ID()}}
+	accessible := []AccessibleContainer{}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+
+		access, err := s.accessResolver.ContainerAccess(ctx, userID, accountID, next.container.ID())
+		if err != nil {
+			return nil, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to resolve container access",
+			).WithOperation("ListAccessibleContainers").WithContext("containerID", next.container.ID())
+		}
+		if access.Read {
+			accessible = append(accessible, AccessibleContainer{
+				ID:     next.container.ID(),
+				Title:  next.container.GetTitle(),
+				Type:   next.container.GetContainerType().String(),
+				Path:   next.path,
+				Access: access,
+			})
+		}
+
+		if next.depth >= maxBreadcrumbDepth {
+			continue
+		}
+		children, err := s.containerRepo.GetChildren(ctx, next.container.ID())
+		if err != nil {
+			return nil, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to get container children",
+			).WithOperation("ListAccessibleContainers").WithContext("containerID", next.container.ID())
+		}
+		for _, child := range children {
+			queue = append(queue, pending{container: child, path: next.path + "/" + child.ID(), depth: next.depth + 1})
+		}
+	}
+
+	sort.Slice(accessible, func(i, j int) bool {
+		return accessible[i].Path < accessible[j].Path
+	})
+
+	results := &AccessibleContainers{
+		Containers: []AccessibleContainer{},
+		TotalCount: len(accessible),
+		Pagination: pagination,
+	}
+	if pagination.Offset < len(accessible) {
+		end := pagination.Offset + pagination.Limit
+		if end > len(accessible) {
+			end = len(accessible)
+		}
+		results.Containers = accessible[pagination.Offset:end]
+		results.HasMore = end < len(accessible)
+	}
+
+	return results, nil
+}
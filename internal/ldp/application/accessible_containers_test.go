@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedContainerAccess grants the access listed for each container and nothing elsewhere
+type fixedContainerAccess map[string]domain.AccessMode
+
+func (f fixedContainerAccess) ContainerAccess(ctx context.Context, userID, accountID, containerID string) (domain.AccessMode, error) {
+	return f[containerID], nil
+}
+
+func TestContainerService_ListAccessibleContainers(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() *ContainerService {
+		service, mockRepo, _ := setupContainerServiceTest()
+
+		pod := domain.NewContainer(ctx, "alice", "", domain.BasicContainer)
+		private := domain.NewContainer(ctx, "private", "alice", domain.BasicContainer)
+		shared := domain.NewContainer(ctx, "shared", "private", domain.BasicContainer)
+		shared.SetTitle("Shared notes")
+
+		mockRepo.On("GetContainer", ctx, "alice").Return(pod, nil)
+		mockRepo.On("GetChildren", ctx, "alice").Return([]domain.ContainerResource{private}, nil)
+		mockRepo.On("GetChildren", ctx, "private").Return([]domain.ContainerResource{shared}, nil)
+		mockRepo.On("GetChildren", ctx, "shared").Return([]domain.ContainerResource{}, nil)
+
+		service.SetContainerAccessResolver(fixedContainerAccess{
+			"alice":  {Read: true},
+			"shared": {Read: true, Append: true, Write: true},
+		})
+		return service
+	}
+
+	t.Run("should list readable containers with their paths and access", func(t *testing.T) {
+		results, err := setup().ListAccessibleContainers(ctx, "bob", "alice", domain.GetDefaultPagination())
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, results.TotalCount)
+		require.Len(t, results.Containers, 2)
+		assert.Equal(t, "/alice", results.Containers[0].Path)
+		assert.Equal(t, domain.AccessMode{Read: true}, results.Containers[0].Access)
+		assert.Equal(t, "shared", results.Containers[1].ID)
+		assert.Equal(t, "Shared notes", results.Containers[1].Title)
+		assert.Equal(t, "/alice/private/shared", results.Containers[1].Path)
+		assert.True(t, results.Containers[1].Access.Write)
+	})
+
+	t.Run("should paginate", func(t *testing.T) {
+		results, err := setup().ListAccessibleContainers(ctx, "bob", "alice", domain.PaginationOptions{Limit: 1, Offset: 0})
+		require.NoError(t, err)
+
+		require.Len(t, results.Containers, 1)
+		assert.True(t, results.HasMore)
+	})
+
+	t.Run("should list nothing without an access resolver", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+
+		results, err := service.ListAccessibleContainers(ctx, "bob", "alice", domain.GetDefaultPagination())
+		require.NoError(t, err)
+		assert.Empty(t, results.Containers)
+	})
+
+	t.Run("should reject an empty user ID", func(t *testing.T) {
+		_, err := setup().ListAccessibleContainers(ctx, "", "alice", domain.GetDefaultPagination())
+		assert.Error(t, err)
+	})
+}
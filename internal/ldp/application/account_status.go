@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// AccountStatusPolicy resolves an account's deletion status. Unknown accounts are active.
+type AccountStatusPolicy interface {
+	AccountStatus(ctx context.Context, accountID string) domain.AccountStatus
+}
+
+// SetAccountStatusPolicy refuses access to the resources of deleted accounts: they are gone
+// while the account's purge runs, and not found once it completed. Resources are matched to
+// their account through the storage usage store, so the policy only applies alongside
+// SetStorageQuota. Deleting resources stays allowed, as the purge itself deletes them.
+func (s *StorageService) SetAccountStatusPolicy(policy AccountStatusPolicy) {
+	s.accountStatus = policy
+}
+
+// CheckResourceAccount refuses to serve a resource whose account was deleted, for callers
+// that must decide before choosing how to read it
+func (s *StorageService) CheckResourceAccount(ctx context.Context, id string) error {
+	return s.checkResourceAccount(ctx, id, "CheckResourceAccount")
+}
+
+// checkResourceAccount refuses to serve a resource whose account was deleted. A usage store
+// that cannot be read leaves the resource served, as reads do not otherwise depend on it.
+func (s *StorageService) checkResourceAccount(ctx context.Context, id, operation string) error {
+	if s.accountStatus == nil || s.storageUsage == nil {
+		return nil
+	}
+	accountID, _, found, err := s.storageUsage.ResourceUsage(ctx, id)
+	if err != nil || !found {
+		return nil
+	}
+	return s.accountStatusError(ctx, accountID, id, operation)
+}
+
+// accountStatusError returns the error for a resource of the account, or nil while the
+// account is active
+func (s *StorageService) accountStatusError(ctx context.Context, accountID, id, operation string) error {
+	if s.accountStatus == nil || accountID == "" {
+		return nil
+	}
+	switch s.accountStatus.AccountStatus(ctx, accountID) {
+	case domain.AccountDeleted:
+		return domain.ErrAccountDeleted.WithOperation(operation).WithContext("id", id).WithContext("accountID", accountID)
+	case domain.AccountPurged:
+		return domain.ErrResourceNotFound.WithOperation(operation).WithContext("id", id)
+	}
+	return nil
+}
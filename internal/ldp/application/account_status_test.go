@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedAccountStatuses gives each account a fixed deletion status
+type fixedAccountStatuses map[string]domain.AccountStatus
+
+func (s fixedAccountStatuses) AccountStatus(ctx context.Context, accountID string) domain.AccountStatus {
+	return s[accountID]
+}
+
+func TestStorageService_AccountStatus(t *testing.T) {
+	ctx := domain.WithAccountID(context.Background(), "acct-1")
+	setup := func(status domain.AccountStatus) (*StorageService, *quotaResourceRepo) {
+		repo := &quotaResourceRepo{streamingPatchResourceRepo{patchResourceRepo: patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}}}
+		mockUoW := &MockUnitOfWork{}
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+		service := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+		service.SetStorageQuota(newMemoryStorageUsage(), nil)
+		statuses := fixedAccountStatuses{}
+		service.SetAccountStatusPolicy(statuses)
+
+		_, err := service.StoreResource(ctx, "a", []byte("content"), "text/plain")
+		require.NoError(t, err)
+		statuses["acct-1"] = status
+		return service, repo
+	}
+
+	t.Run("serves resources of active accounts", func(t *testing.T) {
+		service, _ := setup(domain.AccountActive)
+
+		_, err := service.RetrieveResource(ctx, "a", "")
+		assert.NoError(t, err)
+		assert.NoError(t, service.CheckResourceAccount(ctx, "a"))
+	})
+
+	t.Run("resources of accounts awaiting purge are gone", func(t *testing.T) {
+		service, _ := setup(domain.AccountDeleted)
+
+		_, err := service.RetrieveResource(ctx, "a", "")
+		assert.True(t, domain.IsAccountDeleted(err))
+		_, _, err = service.StreamResource(ctx, "a", "")
+		assert.True(t, domain.IsAccountDeleted(err))
+		_, err = service.StoreResource(ctx, "a", []byte("new content"), "text/plain")
+		assert.True(t, domain.IsAccountDeleted(err))
+		assert.True(t, domain.IsAccountDeleted(service.CheckResourceAccount(ctx, "a")))
+	})
+
+	t.Run("resources of purged accounts are not found", func(t *testing.T) {
+		service, _ := setup(domain.AccountPurged)
+
+		_, err := service.RetrieveResource(ctx, "a", "")
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+
+	t.Run("the purge may still delete resources", func(t *testing.T) {
+		service, repo := setup(domain.AccountDeleted)
+
+		require.NoError(t, service.DeleteResource(ctx, "a"))
+		assert.NotContains(t, repo.resources, "a")
+	})
+}
@@ -0,0 +1,288 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/segmentio/ksuid"
+)
+
+// Async listing statuses
+const (
+	ListingStatusRunning  = "running"
+	ListingStatusComplete = "complete"
+	ListingStatusFailed   = "failed"
+)
+
+// listingRetention is how long a finished async listing stays available for polling
+const listingRetention = 10 * time.Minute
+
+// listingFlushInterval is how many members are written to a listing's document between
+// flushes; polls only see flushed members
+const listingFlushInterval = 100
+
+// ListingResult is the state of an async container listing with one page of its members.
+// Members are spooled to a temporary document on disk, so a listing never holds the whole
+// container in memory and polls read just the page they ask for.
+type ListingResult struct {
+	ID          string                   `json:"id"`
+	ContainerID string                   `json:"containerId"`
+	Status      string                   `json:"status"`
+	Members     []string                 `json:"members"`
+	MemberCount int                      `json:"memberCount"`
+	Pagination  domain.PaginationOptions `json:"pagination"`
+	HasMore     bool                     `json:"hasMore"`
+	Error       string                   `json:"error,omitempty"`
+	StartedAt   time.Time                `json:"startedAt"`
+	CompletedAt *time.Time               `json:"completedAt,omitempty"`
+}
+
+// listingJob is an async listing in progress or awaiting collection. result holds no members;
+// they are read from the document at path.
+type listingJob struct {
+	result ListingResult
+	path   string
+	done   chan struct{}
+	mu     sync.RWMutex
+}
+
+// asyncListings holds async listing jobs until their retention expires. Documents are written
+// to dir, or the system temporary directory when it is empty.
+type asyncListings struct {
+	jobs map[string]*listingJob
+	dir  string
+	mu   sync.Mutex
+}
+
+// StartAsyncListing begins listing a container in the background and returns the listing's
+// initial state. Members are written to a temporary document as they are read, and pages of it
+// can be fetched with GetAsyncListing until it expires after completion. release is called
+// once the listing ends, or at once when it cannot be started, so callers can hold a heavy
+// operation slot for the life of the job.
+func (s *ContainerService) StartAsyncListing(ctx context.Context, containerID string, release func()) (*ListingResult, error) {
+	if release == nil {
+		release = func() {}
+	}
+
+	file, err := os.CreateTemp(s.listings.dir, "listing-*")
+	if err != nil {
+		release()
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to create async listing document",
+		).WithOperation("StartAsyncListing").WithContext("containerID", containerID)
+	}
+
+	// Start from a context that outlives the request; the job finishes after the response is sent
+	streamCtx, cancel := context.WithCancel(context.Background())
+	members, errs, err := s.StreamContainerMembers(streamCtx, containerID, domain.ListingOptions{})
+	if err != nil {
+		cancel()
+		file.Close()
+		os.Remove(file.Name())
+		release()
+		return nil, err
+	}
+
+	job := &listingJob{
+		result: ListingResult{
+			ID:          ksuid.New().String(),
+			ContainerID: containerID,
+			Status:      ListingStatusRunning,
+			StartedAt:   time.Now(),
+		},
+		path: file.Name(),
+		done: make(chan struct{}),
+	}
+
+	s.listings.mu.Lock()
+	if s.listings.jobs == nil {
+		s.listings.jobs = make(map[string]*listingJob)
+	}
+	s.listings.expire(time.Now())
+	s.listings.jobs[job.result.ID] = job
+	s.listings.mu.Unlock()
+
+	// The slot is released before waiters are woken
+	go func() {
+		defer close(job.done)
+		defer release()
+		defer cancel()
+		job.collect(file, members, errs, cancel)
+	}()
+
+	result := job.state()
+	result.Members = []string{}
+	result.Pagination = domain.GetDefaultPagination()
+	return &result, nil
+}
+
+// GetAsyncListing returns the current state of an async listing of a container with one page
+// of the members read so far
+func (s *ContainerService) GetAsyncListing(ctx context.Context, containerID, listingID string, pagination domain.PaginationOptions) (*ListingResult, error) {
+	if !pagination.IsValid() {
+		pagination = domain.GetDefaultPagination()
+	}
+
+	s.listings.mu.Lock()
+	s.listings.expire(time.Now())
+	job, ok := s.listings.jobs[listingID]
+	s.listings.mu.Unlock()
+
+	if !ok || job.state().ContainerID != containerID {
+		return nil, domain.ErrResourceNotFound.WithOperation("GetAsyncListing").WithContext("listingID", listingID)
+	}
+
+	result, err := job.page(pagination)
+	if err != nil {
+		// The document is removed when the listing expires between the lookup and the read
+		if os.IsNotExist(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("GetAsyncListing").WithContext("listingID", listingID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read async listing document",
+		).WithOperation("GetAsyncListing").WithContext("listingID", listingID)
+	}
+	return result, nil
+}
+
+// WaitAsyncListing waits up to timeout for an async listing to finish, returning its state and
+// one page of members either way
+func (s *ContainerService) WaitAsyncListing(ctx context.Context, containerID, listingID string, timeout time.Duration, pagination domain.PaginationOptions) (*ListingResult, error) {
+	s.listings.mu.Lock()
+	job, ok := s.listings.jobs[listingID]
+	s.listings.mu.Unlock()
+
+	if ok {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-job.done:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	return s.GetAsyncListing(ctx, containerID, listingID, pagination)
+}
+
+// collect writes streamed members to the listing's document, one quoted ID per line, until the
+// stream ends. A write failure cancels the stream and fails the listing.
+func (j *listingJob) collect(file *os.File, members <-chan infrastructure.MemberInfo, errs <-chan error, cancel context.CancelFunc) {
+	writer := bufio.NewWriter(file)
+	written := 0
+	var writeErr error
+	for member := range members {
+		// Drain the stream after a failure so its goroutine can finish
+		if writeErr != nil {
+			continue
+		}
+		if _, writeErr = writer.WriteString(strconv.Quote(member.ID) + "\n"); writeErr != nil {
+			cancel()
+			continue
+		}
+		written++
+		if written%listingFlushInterval == 0 {
+			if writeErr = writer.Flush(); writeErr != nil {
+				cancel()
+				continue
+			}
+			j.mu.Lock()
+			j.result.MemberCount = written
+			j.mu.Unlock()
+		}
+	}
+	if writeErr == nil {
+		writeErr = writer.Flush()
+	}
+	if err := file.Close(); writeErr == nil {
+		writeErr = err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	completedAt := time.Now()
+	j.result.CompletedAt = &completedAt
+	j.result.Status = ListingStatusComplete
+
+	failure := writeErr
+	if failure == nil {
+		j.result.MemberCount = written
+		if err, failed := <-errs; failed && err != nil {
+			failure = err
+		}
+	}
+	if failure != nil {
+		j.result.Status = ListingStatusFailed
+		j.result.Error = failure.Error()
+		fmt.Printf("Warning: async listing %s of container %s failed: %v\n", j.result.ID, j.result.ContainerID, failure)
+	}
+}
+
+// state returns the listing's status and counts
+func (j *listingJob) state() ListingResult {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.result
+}
+
+// page reads one page of the members flushed to the listing's document
+func (j *listingJob) page(pagination domain.PaginationOptions) (*ListingResult, error) {
+	result := j.state()
+	result.Members = []string{}
+	result.Pagination = pagination
+	if pagination.Offset >= result.MemberCount {
+		return &result, nil
+	}
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	end := min(pagination.Offset+pagination.Limit, result.MemberCount)
+	scanner := bufio.NewScanner(file)
+	for line := 0; line < end && scanner.Scan(); line++ {
+		if line < pagination.Offset {
+			continue
+		}
+		id, err := strconv.Unquote(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		result.Members = append(result.Members, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result.HasMore = end < result.MemberCount
+	return &result, nil
+}
+
+// expire drops finished jobs older than the retention period and removes their documents; the
+// caller holds l.mu
+func (l *asyncListings) expire(now time.Time) {
+	for id, job := range l.jobs {
+		completedAt := job.state().CompletedAt
+		if completedAt != nil && now.Sub(*completedAt) > listingRetention {
+			delete(l.jobs, id)
+			if err := os.Remove(job.path); err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Warning: failed to remove async listing document %s: %v\n", job.path, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_AsyncListing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("streams every member into the result", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		firstPage := make([]string, 100)
+		for i := range firstPage {
+			firstPage[i] = fmt.Sprintf("member-%d", i)
+		}
+		mockRepo.On("ContainerExists", mock.Anything, "big").Return(true, nil)
+		mockRepo.On("ListMembers", mock.Anything, "big", domain.PaginationOptions{Limit: 100, Offset: 0}).Return(firstPage, nil)
+		mockRepo.On("ListMembers", mock.Anything, "big", domain.PaginationOptions{Limit: 100, Offset: 100}).Return([]string{"last"}, nil)
+
+		service.listings.dir = t.TempDir()
+
+		released := make(chan struct{})
+		started, err := service.StartAsyncListing(ctx, "big", func() { close(released) })
+		require.NoError(t, err)
+		assert.Equal(t, "big", started.ContainerID)
+		assert.NotEmpty(t, started.ID)
+
+		result, err := service.WaitAsyncListing(ctx, "big", started.ID, time.Second, domain.PaginationOptions{Limit: 1000})
+		require.NoError(t, err)
+		assert.Equal(t, ListingStatusComplete, result.Status)
+		assert.Equal(t, 101, result.MemberCount)
+		assert.Len(t, result.Members, 101)
+		assert.Equal(t, "last", result.Members[100])
+		assert.False(t, result.HasMore)
+		assert.NotNil(t, result.CompletedAt)
+
+		select {
+		case <-released:
+		default:
+			t.Fatal("release was not called when the listing finished")
+		}
+	})
+
+	t.Run("pages members from the document on disk", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", mock.Anything, "docs").Return(true, nil)
+		mockRepo.On("ListMembers", mock.Anything, "docs", mock.Anything).Return([]string{"a", "b\nc", "d"}, nil).Once()
+		mockRepo.On("ListMembers", mock.Anything, "docs", mock.Anything).Return([]string{}, nil)
+		dir := t.TempDir()
+		service.listings.dir = dir
+
+		started, err := service.StartAsyncListing(ctx, "docs", nil)
+		require.NoError(t, err)
+		_, err = service.WaitAsyncListing(ctx, "docs", started.ID, time.Second, domain.GetDefaultPagination())
+		require.NoError(t, err)
+
+		page, err := service.GetAsyncListing(ctx, "docs", started.ID, domain.PaginationOptions{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b\nc", "d"}, page.Members)
+		assert.Equal(t, 3, page.MemberCount)
+		assert.False(t, page.HasMore)
+
+		page, err = service.GetAsyncListing(ctx, "docs", started.ID, domain.PaginationOptions{Limit: 1, Offset: 0})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, page.Members)
+		assert.True(t, page.HasMore)
+
+		documents, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, documents, 1)
+	})
+
+	t.Run("removes the document when the listing expires", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", mock.Anything, "docs").Return(true, nil)
+		mockRepo.On("ListMembers", mock.Anything, "docs", mock.Anything).Return([]string{}, nil)
+		dir := t.TempDir()
+		service.listings.dir = dir
+
+		started, err := service.StartAsyncListing(ctx, "docs", nil)
+		require.NoError(t, err)
+		_, err = service.WaitAsyncListing(ctx, "docs", started.ID, time.Second, domain.GetDefaultPagination())
+		require.NoError(t, err)
+
+		service.listings.mu.Lock()
+		service.listings.expire(time.Now().Add(2 * listingRetention))
+		service.listings.mu.Unlock()
+
+		_, err = service.GetAsyncListing(ctx, "docs", started.ID, domain.GetDefaultPagination())
+		assert.True(t, domain.IsResourceNotFound(err))
+		documents, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, documents)
+	})
+
+	t.Run("records listing failures", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", mock.Anything, "broken").Return(true, nil)
+		mockRepo.On("ListMembers", mock.Anything, "broken", mock.Anything).Return(nil, errors.New("disk error"))
+
+		service.listings.dir = t.TempDir()
+
+		started, err := service.StartAsyncListing(ctx, "broken", nil)
+		require.NoError(t, err)
+
+		result, err := service.WaitAsyncListing(ctx, "broken", started.ID, time.Second, domain.GetDefaultPagination())
+		require.NoError(t, err)
+		assert.Equal(t, ListingStatusFailed, result.Status)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("rejects missing containers and unknown listings", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", mock.Anything, "missing").Return(false, nil)
+
+		dir := t.TempDir()
+		service.listings.dir = dir
+
+		released := false
+		_, err := service.StartAsyncListing(ctx, "missing", func() { released = true })
+		assert.True(t, domain.IsResourceNotFound(err))
+		assert.True(t, released)
+		documents, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, documents)
+
+		_, err = service.GetAsyncListing(ctx, "missing", "unknown", domain.GetDefaultPagination())
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+
+	t.Run("scopes listings to their container", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", mock.Anything, "docs").Return(true, nil)
+		mockRepo.On("ListMembers", mock.Anything, "docs", mock.Anything).Return([]string{}, nil)
+
+		service.listings.dir = t.TempDir()
+
+		started, err := service.StartAsyncListing(ctx, "docs", nil)
+		require.NoError(t, err)
+
+		_, err = service.GetAsyncListing(ctx, "other", started.ID, domain.GetDefaultPagination())
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+}
@@ -0,0 +1,349 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// BatchMode controls how a batch operation reacts to individual failures
+type BatchMode string
+
+const (
+	// BatchModeAtomic creates every container or none of them
+	BatchModeAtomic BatchMode = "atomic"
+	// BatchModeBestEffort creates as many containers as possible and reports failures per item
+	BatchModeBestEffort BatchMode = "best_effort"
+)
+
+// CreateContainerSpec describes a single container to create as part of a batch
+type CreateContainerSpec struct {
+	ID            string               `json:"id"`
+	ParentID      string               `json:"parentId,omitempty"`
+	ContainerType domain.ContainerType `json:"containerType,omitempty"`
+	Title         string               `json:"title,omitempty"`
+	Description   string               `json:"description,omitempty"`
+	// MembershipRelations configures Direct and Indirect containers
+	MembershipRelations
+}
+
+// CreateContainerResult reports the outcome of a single spec in a batch
+type CreateContainerResult struct {
+	ID        string            `json:"id"`
+	Created   bool              `json:"created"`
+	Error     string            `json:"error,omitempty"`
+	Container *domain.Container `json:"-"`
+	Err       error             `json:"-"`
+}
+
+// BatchCreateOptions configures batch container creation
+type BatchCreateOptions struct {
+	Mode BatchMode `json:"mode"`
+}
+
+// CreateContainers creates multiple containers atomically.
+// Parents referenced within the same batch are created before their children.
+func (s *ContainerService) CreateContainers(ctx context.Context, specs []CreateContainerSpec) ([]CreateContainerResult, error) {
+	return s.CreateContainersWithOptions(ctx, specs, BatchCreateOptions{Mode: BatchModeAtomic})
+}
+
+// CreateContainersWithOptions creates multiple containers in dependency order.
+// In atomic mode all creation events are committed in a single unit of work, so a failure
+// leaves the repository untouched. In best-effort mode each container is committed on its own
+// and children of failed parents are skipped.
+func (s *ContainerService) CreateContainersWithOptions(ctx context.Context, specs []CreateContainerSpec, options BatchCreateOptions) ([]CreateContainerResult, error) {
+	if len(specs) == 0 {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("batch cannot be empty"),
+			domain.ErrInvalidResource.Code,
+			"batch cannot be empty",
+		).WithOperation("CreateContainers")
+	}
+
+	ordered, err := orderContainerSpecs(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Mode == BatchModeBestEffort {
+		return s.createContainersBestEffort(ctx, specs, ordered), nil
+	}
+
+	return s.createContainersAtomic(ctx, specs, ordered)
+}
+
+// createContainersAtomic validates every spec and commits all creation events together
+func (s *ContainerService) createContainersAtomic(ctx context.Context, specs []CreateContainerSpec, ordered []int) ([]CreateContainerResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := newBatchResults(specs)
+	containers := make(map[string]*domain.Container, len(specs))
+	paths := make(map[string][]string, len(specs))
+	var batchErr error
+
+	for _, i := range ordered {
+		spec := normalizeContainerSpec(specs[i])
+
+		container, err := s.buildBatchContainer(ctx, spec, containers, paths)
+		if err != nil {
+			results[i].Err = err
+			results[i].Error = err.Error()
+			if batchErr == nil {
+				batchErr = err
+			}
+			continue
+		}
+
+		containers[spec.ID] = container
+		results[i].Container = container
+	}
+
+	if batchErr != nil {
+		return results, domain.WrapStorageError(
+			batchErr,
+			domain.ErrInvalidResource.Code,
+			"batch validation failed, no containers were created",
+		).WithOperation("CreateContainers")
+	}
+
+	// Register events in dependency order so parents are projected before children
+	unitOfWork := s.unitOfWorkFactory()
+	for _, i := range ordered {
+		events := results[i].Container.UncommittedEvents()
+		if len(events) > 0 {
+			unitOfWork.RegisterEvents(events)
+		}
+	}
+
+	envelopes, err := unitOfWork.Commit(ctx)
+	if err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		for i := range results {
+			results[i].Container = nil
+			results[i].Err = err
+			results[i].Error = err.Error()
+		}
+		return results, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit batch container creation events",
+		).WithOperation("CreateContainers")
+	}
+
+	for i := range results {
+		results[i].Container.ClearEvents()
+		results[i].Created = true
+	}
+
+	if len(envelopes) > 0 {
+		fmt.Printf("Successfully processed %d events for batch creation of %d containers\n", len(envelopes), len(specs))
+	}
+
+	return results, nil
+}
+
+// createContainersBestEffort creates containers one by one, skipping children of failed parents
+func (s *ContainerService) createContainersBestEffort(ctx context.Context, specs []CreateContainerSpec, ordered []int) []CreateContainerResult {
+	results := newBatchResults(specs)
+	failed := make(map[string]bool, len(specs))
+
+	for _, i := range ordered {
+		spec := normalizeContainerSpec(specs[i])
+
+		if spec.ParentID != "" && failed[spec.ParentID] {
+			err := domain.WrapStorageError(
+				fmt.Errorf("parent container was not created"),
+				domain.ErrResourceNotFound.Code,
+				"parent container was not created",
+			).WithOperation("CreateContainers").WithContext("parentID", spec.ParentID)
+			results[i].Err = err
+			results[i].Error = err.Error()
+			failed[spec.ID] = true
+			continue
+		}
+
+		container, err := s.CreateContainerWithOptions(ctx, spec.ID, spec.ParentID, spec.ContainerType, CreateContainerOptions{
+			Membership: spec.MembershipRelations,
+		})
+		if err == nil && (spec.Title != "" || spec.Description != "") {
+			if spec.Title != "" {
+				container.SetTitle(spec.Title)
+			}
+			if spec.Description != "" {
+				container.SetDescription(spec.Description)
+			}
+			err = s.UpdateContainer(ctx, container)
+		}
+
+		if err != nil {
+			results[i].Err = err
+			results[i].Error = err.Error()
+			failed[spec.ID] = true
+			continue
+		}
+
+		results[i].Container = container
+		results[i].Created = true
+	}
+
+	return results
+}
+
+// buildBatchContainer validates a spec and builds its container entity without committing it.
+// Parents may either already exist in the repository or be part of the same batch.
+func (s *ContainerService) buildBatchContainer(ctx context.Context, spec CreateContainerSpec, batch map[string]*domain.Container, paths map[string][]string) (*domain.Container, error) {
+	if err := s.validator.ValidateContainerID(spec.ID); err != nil {
+		return nil, domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("CreateContainers")
+	}
+
+	if err := s.validator.ValidateContainerType(spec.ContainerType); err != nil {
+		return nil, domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("CreateContainers")
+	}
+
+	exists, err := s.containerRepo.ContainerExists(ctx, spec.ID)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check container existence",
+		).WithOperation("CreateContainers").WithContext("containerID", spec.ID)
+	}
+	if exists {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("container already exists"),
+			domain.ErrResourceAlreadyExists.Code,
+			"container already exists",
+		).WithOperation("CreateContainers").WithContext("containerID", spec.ID)
+	}
+
+	var path []string
+	if spec.ParentID != "" {
+		if _, inBatch := batch[spec.ParentID]; inBatch {
+			path = append(append([]string{}, paths[spec.ParentID]...), spec.ParentID)
+		} else {
+			parentExists, err := s.containerRepo.ContainerExists(ctx, spec.ParentID)
+			if err != nil {
+				return nil, domain.WrapStorageError(
+					err,
+					domain.ErrStorageOperation.Code,
+					"failed to check parent container existence",
+				).WithOperation("CreateContainers").WithContext("parentID", spec.ParentID)
+			}
+			if !parentExists {
+				return nil, domain.WrapStorageError(
+					fmt.Errorf("parent container not found"),
+					domain.ErrResourceNotFound.Code,
+					"parent container not found",
+				).WithOperation("CreateContainers").WithContext("parentID", spec.ParentID)
+			}
+
+			path, err = s.containerRepo.GetPath(ctx, spec.ParentID)
+			if err != nil {
+				return nil, domain.WrapStorageError(
+					err,
+					domain.ErrStorageOperation.Code,
+					"failed to get parent path for hierarchy validation",
+				).WithOperation("CreateContainers").WithContext("parentID", spec.ParentID)
+			}
+		}
+	}
+
+	container := domain.NewContainer(ctx, spec.ID, spec.ParentID, spec.ContainerType)
+	if err := container.ValidateHierarchy(path); err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrInvalidHierarchy.Code,
+			"hierarchy validation failed",
+		).WithOperation("CreateContainers").WithContext("containerID", spec.ID)
+	}
+
+	if err := applyMembershipRelations(container, spec.MembershipRelations, "CreateContainers"); err != nil {
+		return nil, err
+	}
+
+	if spec.Title != "" {
+		container.SetTitle(spec.Title)
+	}
+	if spec.Description != "" {
+		container.SetDescription(spec.Description)
+	}
+
+	paths[spec.ID] = path
+	return container, nil
+}
+
+// orderContainerSpecs returns spec indexes ordered so that in-batch parents precede their children
+func orderContainerSpecs(specs []CreateContainerSpec) ([]int, error) {
+	index := make(map[string]int, len(specs))
+	for i, spec := range specs {
+		if _, duplicate := index[spec.ID]; duplicate {
+			return nil, domain.WrapStorageError(
+				fmt.Errorf("duplicate container ID in batch"),
+				domain.ErrResourceAlreadyExists.Code,
+				"duplicate container ID in batch",
+			).WithOperation("CreateContainers").WithContext("containerID", spec.ID)
+		}
+		index[spec.ID] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(specs))
+	ordered := make([]int, 0, len(specs))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return domain.WrapStorageError(
+				fmt.Errorf("circular parent reference in batch"),
+				domain.ErrCircularReference.Code,
+				"circular parent reference in batch",
+			).WithOperation("CreateContainers").WithContext("containerID", specs[i].ID)
+		}
+
+		state[i] = visiting
+		if parent, inBatch := index[specs[i].ParentID]; inBatch && specs[i].ParentID != "" {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		ordered = append(ordered, i)
+		return nil
+	}
+
+	for i := range specs {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// normalizeContainerSpec applies defaults to a spec
+func normalizeContainerSpec(spec CreateContainerSpec) CreateContainerSpec {
+	if spec.ContainerType == "" {
+		spec.ContainerType = domain.BasicContainer
+	}
+	return spec
+}
+
+// newBatchResults creates a result slot for every spec, in input order
+func newBatchResults(specs []CreateContainerSpec) []CreateContainerResult {
+	results := make([]CreateContainerResult, len(specs))
+	for i, spec := range specs {
+		results[i].ID = spec.ID
+	}
+	return results
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderContainerSpecs_ParentsBeforeChildren(t *testing.T) {
+	specs := []CreateContainerSpec{
+		{ID: "grandchild", ParentID: "child"},
+		{ID: "child", ParentID: "root"},
+		{ID: "root"},
+		{ID: "sibling", ParentID: "existing"},
+	}
+
+	ordered, err := orderContainerSpecs(specs)
+	require.NoError(t, err)
+	require.Len(t, ordered, len(specs))
+
+	position := make(map[string]int, len(ordered))
+	for pos, i := range ordered {
+		position[specs[i].ID] = pos
+	}
+
+	assert.Less(t, position["root"], position["child"])
+	assert.Less(t, position["child"], position["grandchild"])
+}
+
+func TestOrderContainerSpecs_DuplicateID(t *testing.T) {
+	specs := []CreateContainerSpec{{ID: "a"}, {ID: "a"}}
+
+	_, err := orderContainerSpecs(specs)
+	require.Error(t, err)
+
+	storageErr, ok := err.(*domain.StorageError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrResourceAlreadyExists.Code, storageErr.Code)
+}
+
+func TestOrderContainerSpecs_CircularReference(t *testing.T) {
+	specs := []CreateContainerSpec{
+		{ID: "a", ParentID: "b"},
+		{ID: "b", ParentID: "a"},
+	}
+
+	_, err := orderContainerSpecs(specs)
+	require.Error(t, err)
+
+	storageErr, ok := err.(*domain.StorageError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCircularReference.Code, storageErr.Code)
+}
+
+func TestNormalizeContainerSpec_DefaultsToBasicContainer(t *testing.T) {
+	spec := normalizeContainerSpec(CreateContainerSpec{ID: "a"})
+	assert.Equal(t, domain.BasicContainer, spec.ContainerType)
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// maxBreadcrumbDepth bounds the ancestor walk, matching the validator's maximum hierarchy depth
+const maxBreadcrumbDepth = 100
+
+// ContainerReadAuthorizer decides whether the caller in ctx, the agent named by
+// domain.WithAgent, may read a container
+type ContainerReadAuthorizer interface {
+	CanReadContainer(ctx context.Context, containerID string) bool
+}
+
+// AllowAllContainerReads is a ContainerReadAuthorizer that permits every read
+type AllowAllContainerReads struct{}
+
+// CanReadContainer always returns true
+func (AllowAllContainerReads) CanReadContainer(ctx context.Context, containerID string) bool {
+	return true
+}
+
+// GenerateAuthorizedBreadcrumbs builds the ancestor chain of a container for navigation UIs.
+// Ancestors the caller cannot read are masked so their position is kept without revealing
+// their ID or title. A target the caller cannot read is reported as not found.
+func (s *ContainerService) GenerateAuthorizedBreadcrumbs(ctx context.Context, containerID string, authorizer ContainerReadAuthorizer) ([]BreadcrumbItem, error) {
+	if containerID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("GenerateAuthorizedBreadcrumbs")
+	}
+	if authorizer == nil {
+		authorizer = AllowAllContainerReads{}
+	}
+
+	if !authorizer.CanReadContainer(ctx, containerID) {
+		return nil, domain.ErrResourceNotFound.WithOperation("GenerateAuthorizedBreadcrumbs").WithContext("containerID", containerID)
+	}
+
+	s.mu.RLock()
+	ancestors, err := s.collectAncestors(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build breadcrumbs from the root down
+	breadcrumbs := make([]BreadcrumbItem, 0, len(ancestors))
+	currentPath := ""
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		ancestor := ancestors[i]
+		currentPath = currentPath + "/" + ancestor.ID()
+
+		if i > 0 && !authorizer.CanReadContainer(ctx, ancestor.ID()) {
+			breadcrumbs = append(breadcrumbs, BreadcrumbItem{Masked: true})
+			continue
+		}
+
+		title := ancestor.GetTitle()
+		if title == "" {
+			title = ancestor.ID()
+		}
+		breadcrumbs = append(breadcrumbs, BreadcrumbItem{
+			ID:    ancestor.ID(),
+			Title: title,
+			Path:  currentPath,
+		})
+	}
+
+	return breadcrumbs, nil
+}
+
+// collectAncestors walks the parent links from a container to the root, loading each
+// container once. The result starts with the container itself.
+func (s *ContainerService) collectAncestors(ctx context.Context, containerID string) ([]domain.ContainerResource, error) {
+	var ancestors []domain.ContainerResource
+	visited := make(map[string]bool)
+
+	for currentID := containerID; currentID != ""; {
+		if visited[currentID] || len(ancestors) >= maxBreadcrumbDepth {
+			return nil, domain.ErrCircularReference.WithOperation("GenerateAuthorizedBreadcrumbs").WithContext("containerID", currentID)
+		}
+		visited[currentID] = true
+
+		container, err := s.containerRepo.GetContainer(ctx, currentID)
+		if err != nil {
+			if domain.IsResourceNotFound(err) && currentID == containerID {
+				return nil, domain.ErrResourceNotFound.WithOperation("GenerateAuthorizedBreadcrumbs").WithContext("containerID", containerID)
+			}
+			return nil, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to retrieve container for breadcrumbs",
+			).WithOperation("GenerateAuthorizedBreadcrumbs").WithContext("containerID", currentID)
+		}
+
+		ancestors = append(ancestors, container)
+		currentID = container.GetParentID()
+	}
+
+	return ancestors, nil
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// denyContainerReads is a ContainerReadAuthorizer that denies reads of the listed containers
+type denyContainerReads map[string]bool
+
+func (d denyContainerReads) CanReadContainer(ctx context.Context, containerID string) bool {
+	return !d[containerID]
+}
+
+func setupBreadcrumbHierarchy(mockRepo *MockContainerRepository) {
+	root := domain.NewContainer(context.Background(), "root", "", domain.BasicContainer)
+	root.SetTitle("Root")
+	child := domain.NewContainer(context.Background(), "child", "root", domain.BasicContainer)
+	child.SetTitle("Child")
+	grandchild := domain.NewContainer(context.Background(), "grandchild", "child", domain.BasicContainer)
+
+	mockRepo.On("GetContainer", mock.Anything, "root").Return(root, nil).Once()
+	mockRepo.On("GetContainer", mock.Anything, "child").Return(child, nil).Once()
+	mockRepo.On("GetContainer", mock.Anything, "grandchild").Return(grandchild, nil).Once()
+}
+
+func TestContainerService_GenerateAuthorizedBreadcrumbs(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	setupBreadcrumbHierarchy(mockRepo)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	breadcrumbs, err := service.GenerateAuthorizedBreadcrumbs(context.Background(), "grandchild", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []BreadcrumbItem{
+		{ID: "root", Title: "Root", Path: "/root"},
+		{ID: "child", Title: "Child", Path: "/root/child"},
+		{ID: "grandchild", Title: "grandchild", Path: "/root/child/grandchild"},
+	}, breadcrumbs)
+
+	// Each ancestor is loaded exactly once
+	mockRepo.AssertExpectations(t)
+}
+
+func TestContainerService_GenerateAuthorizedBreadcrumbs_MasksUnreadableAncestors(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	setupBreadcrumbHierarchy(mockRepo)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	breadcrumbs, err := service.GenerateAuthorizedBreadcrumbs(context.Background(), "grandchild", denyContainerReads{"child": true})
+	require.NoError(t, err)
+	require.Len(t, breadcrumbs, 3)
+
+	assert.Equal(t, "root", breadcrumbs[0].ID)
+	assert.Equal(t, BreadcrumbItem{Masked: true}, breadcrumbs[1])
+	assert.Equal(t, "/root/child/grandchild", breadcrumbs[2].Path)
+}
+
+func TestContainerService_GenerateAuthorizedBreadcrumbs_UnreadableTarget(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	_, err := service.GenerateAuthorizedBreadcrumbs(context.Background(), "secret", denyContainerReads{"secret": true})
+	require.Error(t, err)
+	assert.True(t, domain.IsResourceNotFound(err))
+	mockRepo.AssertNotCalled(t, "GetContainer", mock.Anything, "secret")
+}
+
+func TestContainerService_GenerateAuthorizedBreadcrumbs_CircularParents(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	a := domain.NewContainer(context.Background(), "a", "b", domain.BasicContainer)
+	b := domain.NewContainer(context.Background(), "b", "a", domain.BasicContainer)
+	mockRepo.On("GetContainer", mock.Anything, "a").Return(a, nil)
+	mockRepo.On("GetContainer", mock.Anything, "b").Return(b, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	_, err := service.GenerateAuthorizedBreadcrumbs(context.Background(), "a", nil)
+	require.Error(t, err)
+
+	storageErr, ok := err.(*domain.StorageError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCircularReference.Code, storageErr.Code)
+}
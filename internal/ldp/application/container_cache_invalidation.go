@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// CacheInvalidation reports how many cached entries an invalidation dropped
+type CacheInvalidation struct {
+	// ContainerID is the container whose entries were dropped; empty when every cache was cleared
+	ContainerID string `json:"containerId,omitempty"`
+	// Entries counts the entries dropped across the size aggregates and every registered cache
+	Entries int `json:"entries"`
+}
+
+// AddCacheInvalidator registers a cache of resources or containers to be dropped along with
+// the service's own size aggregates when caches are invalidated
+func (s *ContainerService) AddCacheInvalidator(cache domain.CacheInvalidator) {
+	if cache != nil {
+		s.caches = append(s.caches, cache)
+	}
+}
+
+// InvalidateContainerCaches drops every cached entry of a container and of its members, so
+// they are read from the backing store on next access. It is meant for operators who repaired
+// data on disk. A container no longer in the store still has its own entries dropped.
+func (s *ContainerService) InvalidateContainerCaches(ctx context.Context, containerID string) (CacheInvalidation, error) {
+	if containerID == "" {
+		return CacheInvalidation{}, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID is required",
+		).WithOperation("InvalidateContainerCaches")
+	}
+
+	// Drop the container first, so its members are read from the store rather than a stale copy
+	invalidation := CacheInvalidation{ContainerID: containerID}
+	invalidation.Entries += s.invalidateCached(containerID)
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsContainerNotFound(err) || domain.IsResourceNotFound(err) {
+			return invalidation, nil
+		}
+		return invalidation, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read container members",
+		).WithOperation("InvalidateContainerCaches").WithContext("containerID", containerID)
+	}
+
+	if members := container.GetMembers(); len(members) > 0 {
+		invalidation.Entries += s.invalidateCached(members...)
+	}
+	return invalidation, nil
+}
+
+// InvalidateAllCaches drops every cached entry of every container and resource
+func (s *ContainerService) InvalidateAllCaches(ctx context.Context) CacheInvalidation {
+	invalidation := CacheInvalidation{Entries: s.dropAllSizeAggregates()}
+	for _, cache := range s.caches {
+		invalidation.Entries += cache.InvalidateAllCached()
+	}
+	return invalidation
+}
+
+// invalidateCached drops the given IDs' size aggregates and entries in every registered cache
+func (s *ContainerService) invalidateCached(ids ...string) int {
+	dropped := s.dropSizeAggregates(ids...)
+	for _, cache := range s.caches {
+		dropped += cache.InvalidateCached(ids...)
+	}
+	return dropped
+}
@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is a CacheInvalidator over a set of cached IDs
+type memoryCache struct {
+	ids map[string]bool
+}
+
+func newMemoryCache(ids ...string) *memoryCache {
+	cache := &memoryCache{ids: make(map[string]bool)}
+	for _, id := range ids {
+		cache.ids[id] = true
+	}
+	return cache
+}
+
+func (m *memoryCache) InvalidateCached(ids ...string) int {
+	dropped := 0
+	for _, id := range ids {
+		if m.ids[id] {
+			delete(m.ids, id)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+func (m *memoryCache) InvalidateAllCached() int {
+	dropped := len(m.ids)
+	m.ids = make(map[string]bool)
+	return dropped
+}
+
+func TestContainerService_InvalidateContainerCaches(t *testing.T) {
+	ctx := context.Background()
+	container := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+	container.Members = []string{"a.jpg", "b.jpg"}
+
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	containers := newMemoryCache("photos", "docs")
+	resources := newMemoryCache("a.jpg", "b.jpg", "notes.txt")
+	service.AddCacheInvalidator(containers)
+	service.AddCacheInvalidator(resources)
+	service.storeSizeAggregate("photos", ContainerSizeAggregate{MemberCount: 2})
+	service.storeSizeAggregate("docs", ContainerSizeAggregate{MemberCount: 1})
+
+	invalidation, err := service.InvalidateContainerCaches(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, "photos", invalidation.ContainerID)
+	assert.Equal(t, 4, invalidation.Entries)
+
+	// Entries of other containers and resources are kept
+	assert.Equal(t, map[string]bool{"docs": true}, containers.ids)
+	assert.Equal(t, map[string]bool{"notes.txt": true}, resources.ids)
+	assert.Equal(t, 1, service.dropAllSizeAggregates())
+}
+
+func TestContainerService_InvalidateContainerCaches_MissingContainer(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "gone").Return(nil, domain.ErrContainerNotFound)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	containers := newMemoryCache("gone")
+	service.AddCacheInvalidator(containers)
+
+	invalidation, err := service.InvalidateContainerCaches(ctx, "gone")
+	require.NoError(t, err)
+	assert.Equal(t, 1, invalidation.Entries)
+	assert.Empty(t, containers.ids)
+
+	_, err = service.InvalidateContainerCaches(ctx, "")
+	assert.True(t, domain.IsInvalidID(err))
+}
+
+func TestContainerService_InvalidateAllCaches(t *testing.T) {
+	service := NewContainerService(new(MockContainerRepository), nil, nil)
+	containers := newMemoryCache("photos", "docs")
+	resources := newMemoryCache("a.jpg")
+	service.AddCacheInvalidator(containers)
+	service.AddCacheInvalidator(resources)
+	service.storeSizeAggregate("photos", ContainerSizeAggregate{MemberCount: 2})
+
+	invalidation := service.InvalidateAllCaches(context.Background())
+	assert.Empty(t, invalidation.ContainerID)
+	assert.Equal(t, 4, invalidation.Entries)
+	assert.Empty(t, containers.ids)
+	assert.Empty(t, resources.ids)
+}
@@ -0,0 +1,310 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// ContainerChangeNotification is one change to a container's membership or metadata, or to a
+// resource, as sent to live subscribers. Sequence numbers increase across every notification
+// the hub handles, so a subscriber can tell the hub the last one it received when it resumes.
+type ContainerChangeNotification struct {
+	Sequence    uint64    `json:"sequence"`
+	Type        string    `json:"type"`
+	ContainerID string    `json:"containerId,omitempty"`
+	ResourceID  string    `json:"resourceId,omitempty"`
+	MemberID    string    `json:"memberId,omitempty"`
+	MemberType  string    `json:"memberType,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// TopicID returns the ID of the container or resource the notification is about
+func (n ContainerChangeNotification) TopicID() string {
+	if n.ResourceID != "" {
+		return n.ResourceID
+	}
+	return n.ContainerID
+}
+
+// ActivityType returns the Activity Streams type of the change, as named in Solid
+// notifications
+func (n ContainerChangeNotification) ActivityType() string {
+	switch n.Type {
+	case domain.EventTypeContainerDeleted, domain.EventTypeResourceDeleted:
+		return "Delete"
+	case domain.EventTypeMemberAdded:
+		return "Add"
+	case domain.EventTypeMemberRemoved:
+		return "Remove"
+	default:
+		return "Update"
+	}
+}
+
+// SolidNotification returns the change as a Solid Notifications Protocol notification on the
+// topic URI
+func (n ContainerChangeNotification) SolidNotification(topic string) map[string]interface{} {
+	return solidActivity(topic, n.ActivityType(), n.MemberID, n.MemberType, n.Timestamp)
+}
+
+// ends reports whether the change removed its topic, ending the topic's subscriptions
+func (n ContainerChangeNotification) ends() bool {
+	return n.ActivityType() == "Delete"
+}
+
+// ContainerChangeSubscription receives the changes of one container or resource.
+// Notifications arrives closed once the subscription ends: when it is closed, when its topic
+// is deleted, or when the subscriber falls a full buffer behind.
+type ContainerChangeSubscription struct {
+	TopicID       string
+	Notifications <-chan ContainerChangeNotification
+
+	hub       *ContainerChangeHub
+	send      chan ContainerChangeNotification
+	closeOnce sync.Once
+	slow      bool
+}
+
+// Close ends the subscription; it is safe to call more than once
+func (s *ContainerChangeSubscription) Close() {
+	s.hub.remove(s)
+}
+
+// Slow reports whether the subscription was ended because its buffer was full
+func (s *ContainerChangeSubscription) Slow() bool {
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+	return s.slow
+}
+
+// ContainerChangeHub fans committed container and resource events out to live subscribers,
+// keyed by the container or resource the event belongs to. Each subscriber has its own
+// buffer; Handle never blocks on one, and a subscriber whose buffer is full is dropped so it
+// cannot hold up the others. The most recent notifications are kept for replay, so a
+// subscriber that reconnects can resume where it left off.
+type ContainerChangeHub struct {
+	containerRepo domain.ContainerRepository
+	resourceRepo  domain.ResourceRepository
+	bufferSize    int
+	replaySize    int
+
+	mu          sync.RWMutex
+	sequence    uint64
+	replay      []ContainerChangeNotification
+	subscribers map[string]map[*ContainerChangeSubscription]struct{}
+}
+
+// NewContainerChangeHub creates a hub buffering up to bufferSize notifications per subscriber
+func NewContainerChangeHub(containerRepo domain.ContainerRepository, bufferSize int) *ContainerChangeHub {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &ContainerChangeHub{
+		containerRepo: containerRepo,
+		bufferSize:    bufferSize,
+		subscribers:   make(map[string]map[*ContainerChangeSubscription]struct{}),
+	}
+}
+
+// SetResourceRepository lets subscribers follow resources as well as containers
+func (h *ContainerChangeHub) SetResourceRepository(resourceRepo domain.ResourceRepository) {
+	h.resourceRepo = resourceRepo
+}
+
+// SetReplaySize sets how many of the most recent notifications are kept for subscribers
+// resuming with SubscribeFrom; zero keeps none
+func (h *ContainerChangeHub) SetReplaySize(replaySize int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.replaySize = replaySize
+	if len(h.replay) > replaySize {
+		h.replay = append([]ContainerChangeNotification(nil), h.replay[len(h.replay)-replaySize:]...)
+	}
+}
+
+// Subscribe starts receiving the changes of an existing container or resource
+func (h *ContainerChangeHub) Subscribe(ctx context.Context, topicID string) (*ContainerChangeSubscription, error) {
+	return h.subscribe(ctx, topicID, false, 0)
+}
+
+// SubscribeFrom starts receiving the changes of a container or resource after the one with
+// the given sequence number. The kept changes the subscriber missed arrive first; changes
+// older than the replay buffer are lost. A topic deleted while the subscriber was away can
+// still be resumed: the missed changes, ending with the deletion, are delivered and the
+// subscription then ends.
+func (h *ContainerChangeHub) SubscribeFrom(ctx context.Context, topicID string, lastSequence uint64) (*ContainerChangeSubscription, error) {
+	return h.subscribe(ctx, topicID, true, lastSequence)
+}
+
+// subscribe registers a subscription, first delivering the kept changes after lastSequence
+// when resuming
+func (h *ContainerChangeHub) subscribe(ctx context.Context, topicID string, resume bool, lastSequence uint64) (*ContainerChangeSubscription, error) {
+	exists, err := h.topicExists(ctx, topicID)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check topic existence",
+		).WithOperation("SubscribeContainerChanges").WithContext("topicID", topicID)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var missed []ContainerChangeNotification
+	if resume {
+		for _, notification := range h.replay {
+			if notification.Sequence > lastSequence && notification.TopicID() == topicID {
+				missed = append(missed, notification)
+			}
+		}
+	}
+	ended := len(missed) > 0 && missed[len(missed)-1].ends()
+	if !exists && !ended {
+		return nil, domain.ErrResourceNotFound.WithOperation("SubscribeContainerChanges").WithContext("topicID", topicID)
+	}
+
+	send := make(chan ContainerChangeNotification, h.bufferSize+len(missed))
+	for _, notification := range missed {
+		send <- notification
+	}
+	subscription := &ContainerChangeSubscription{
+		TopicID:       topicID,
+		Notifications: send,
+		hub:           h,
+		send:          send,
+	}
+	if ended {
+		subscription.closeOnce.Do(func() {
+			close(send)
+		})
+		return subscription, nil
+	}
+
+	if h.subscribers[topicID] == nil {
+		h.subscribers[topicID] = make(map[*ContainerChangeSubscription]struct{})
+	}
+	h.subscribers[topicID][subscription] = struct{}{}
+	return subscription, nil
+}
+
+// topicExists reports whether a container, or a resource when resources can be followed, has
+// the given ID
+func (h *ContainerChangeHub) topicExists(ctx context.Context, topicID string) (bool, error) {
+	exists, err := h.containerRepo.ContainerExists(ctx, topicID)
+	if err != nil || exists || h.resourceRepo == nil {
+		return exists, err
+	}
+	return h.resourceRepo.Exists(ctx, topicID)
+}
+
+// SubscriberCount returns how many subscriptions a container or resource has
+func (h *ContainerChangeHub) SubscriberCount(topicID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[topicID])
+}
+
+// EventTypes returns the container and resource event types delivered to subscribers
+func (h *ContainerChangeHub) EventTypes() []string {
+	return []string{
+		"container." + domain.EventTypeContainerUpdated,
+		"container." + domain.EventTypeContainerDeleted,
+		"container." + domain.EventTypeContainerMoved,
+		"container." + domain.EventTypeMemberAdded,
+		"container." + domain.EventTypeMemberRemoved,
+		"resource." + domain.EventTypeResourceUpdated,
+		"resource." + domain.EventTypeResourceDeleted,
+	}
+}
+
+// Handle delivers a committed container or resource event to its subscribers. The
+// subscriptions of a deleted container or resource receive the notification and are then
+// ended.
+func (h *ContainerChangeHub) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event, ok := envelope.Event().(*pericarpdomain.EntityEvent)
+	if !ok {
+		return nil
+	}
+
+	notification := ContainerChangeNotification{
+		Type:      event.Type,
+		Timestamp: event.CreatedAt().UTC(),
+	}
+	switch event.EntityType {
+	case "container":
+		notification.ContainerID = event.AggregateID()
+	case "resource":
+		if event.Type != domain.EventTypeResourceUpdated && event.Type != domain.EventTypeResourceDeleted {
+			return nil
+		}
+		notification.ResourceID = event.AggregateID()
+	default:
+		return nil
+	}
+	if event.Type == domain.EventTypeMemberAdded || event.Type == domain.EventTypeMemberRemoved {
+		var payload struct {
+			MemberID     string `json:"memberID"`
+			ResourceType string `json:"resourceType"`
+		}
+		if err := json.Unmarshal(event.Payload(), &payload); err == nil {
+			notification.MemberID = payload.MemberID
+			notification.MemberType = payload.ResourceType
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sequence++
+	notification.Sequence = h.sequence
+	if h.replaySize > 0 {
+		if len(h.replay) >= h.replaySize {
+			h.replay = append(h.replay[1:], notification)
+		} else {
+			h.replay = append(h.replay, notification)
+		}
+	}
+
+	topicID := notification.TopicID()
+	for subscription := range h.subscribers[topicID] {
+		select {
+		case subscription.send <- notification:
+		default:
+			fmt.Printf("Warning: dropping slow subscriber of %s\n", topicID)
+			subscription.slow = true
+			h.removeLocked(subscription)
+		}
+	}
+	if notification.ends() {
+		for subscription := range h.subscribers[topicID] {
+			h.removeLocked(subscription)
+		}
+	}
+	return nil
+}
+
+// remove ends a subscription
+func (h *ContainerChangeHub) remove(subscription *ContainerChangeSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(subscription)
+}
+
+// removeLocked ends a subscription; the caller holds the write lock
+func (h *ContainerChangeHub) removeLocked(subscription *ContainerChangeSubscription) {
+	subscription.closeOnce.Do(func() {
+		close(subscription.send)
+	})
+	subscribers := h.subscribers[subscription.TopicID]
+	delete(subscribers, subscription)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, subscription.TopicID)
+	}
+}
@@ -0,0 +1,207 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerChangeHub_Subscribe(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	hub := NewContainerChangeHub(containerRepo, 4)
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	containerRepo.On("ContainerExists", mock.Anything, "missing").Return(false, nil)
+
+	subscription, err := hub.Subscribe(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, 1, hub.SubscriberCount("photos"))
+
+	_, err = hub.Subscribe(ctx, "missing")
+	assert.True(t, domain.IsResourceNotFound(err))
+
+	// Closing removes the subscriber and ends its stream; closing again is harmless
+	subscription.Close()
+	subscription.Close()
+	assert.Equal(t, 0, hub.SubscriberCount("photos"))
+	_, open := <-subscription.Notifications
+	assert.False(t, open)
+}
+
+func TestContainerChangeHub_FansOutByContainer(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	hub := NewContainerChangeHub(containerRepo, 4)
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, mock.Anything).Return(true, nil)
+	first, err := hub.Subscribe(ctx, "photos")
+	require.NoError(t, err)
+	second, err := hub.Subscribe(ctx, "photos")
+	require.NoError(t, err)
+	other, err := hub.Subscribe(ctx, "notes")
+	require.NoError(t, err)
+
+	added := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": "beach.jpg"})
+	updated := domain.NewContainerUpdatedEvent("photos", map[string]interface{}{"title": "Holidays"})
+	for _, event := range []*domain.EntityEvent{added, updated} {
+		require.NoError(t, hub.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+	}
+
+	for _, subscription := range []*ContainerChangeSubscription{first, second} {
+		notification := <-subscription.Notifications
+		assert.Equal(t, domain.EventTypeMemberAdded, notification.Type)
+		assert.Equal(t, "photos", notification.ContainerID)
+		assert.Equal(t, "beach.jpg", notification.MemberID)
+		assert.False(t, notification.Timestamp.IsZero())
+
+		notification = <-subscription.Notifications
+		assert.Equal(t, domain.EventTypeContainerUpdated, notification.Type)
+		assert.Empty(t, notification.MemberID)
+	}
+	assert.Empty(t, other.Notifications)
+}
+
+func TestContainerChangeHub_DropsSlowSubscribers(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	hub := NewContainerChangeHub(containerRepo, 1)
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	subscription, err := hub.Subscribe(ctx, "photos")
+	require.NoError(t, err)
+
+	for _, memberID := range []string{"a.jpg", "b.jpg"} {
+		event := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": memberID})
+		require.NoError(t, hub.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+	}
+
+	// The buffered notification is still delivered, then the stream ends
+	notification, open := <-subscription.Notifications
+	require.True(t, open)
+	assert.Equal(t, "a.jpg", notification.MemberID)
+	_, open = <-subscription.Notifications
+	assert.False(t, open)
+	assert.True(t, subscription.Slow())
+	assert.Equal(t, 0, hub.SubscriberCount("photos"))
+}
+
+func TestContainerChangeHub_EndsSubscriptionsOfDeletedContainer(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	hub := NewContainerChangeHub(containerRepo, 4)
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	subscription, err := hub.Subscribe(ctx, "photos")
+	require.NoError(t, err)
+
+	deleted := domain.NewContainerDeletedEvent("photos", map[string]interface{}{})
+	require.NoError(t, hub.Handle(ctx, &testEnvelope{event: deleted, timestamp: time.Now()}))
+
+	notification, open := <-subscription.Notifications
+	require.True(t, open)
+	assert.Equal(t, domain.EventTypeContainerDeleted, notification.Type)
+	_, open = <-subscription.Notifications
+	assert.False(t, open)
+	assert.False(t, subscription.Slow())
+	assert.Equal(t, 0, hub.SubscriberCount("photos"))
+}
+
+func TestContainerChangeHub_FollowsResources(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	resourceRepo := &patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}
+	ctx := context.Background()
+	resourceRepo.resources["card"] = domain.NewResource(ctx, "card", "text/turtle", []byte("<#me> a <#Person> ."))
+
+	hub := NewContainerChangeHub(containerRepo, 4)
+	containerRepo.On("ContainerExists", mock.Anything, mock.Anything).Return(false, nil)
+
+	// Resources can only be followed once the hub can check them
+	_, err := hub.Subscribe(ctx, "card")
+	assert.True(t, domain.IsResourceNotFound(err))
+
+	hub.SetResourceRepository(resourceRepo)
+	subscription, err := hub.Subscribe(ctx, "card")
+	require.NoError(t, err)
+
+	created := domain.NewResourceCreatedEvent("card", map[string]interface{}{})
+	updated := domain.NewResourceUpdatedEvent("card", map[string]interface{}{})
+	deleted := domain.NewResourceDeletedEvent("card", map[string]interface{}{})
+	for _, event := range []*domain.EntityEvent{created, updated, deleted} {
+		require.NoError(t, hub.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+	}
+
+	notification := <-subscription.Notifications
+	assert.Equal(t, "card", notification.ResourceID)
+	assert.Equal(t, "Update", notification.ActivityType())
+	notification = <-subscription.Notifications
+	assert.Equal(t, "Delete", notification.ActivityType())
+	_, open := <-subscription.Notifications
+	assert.False(t, open)
+}
+
+func TestContainerChangeHub_SubscribeFromReplaysMissedChanges(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	hub := NewContainerChangeHub(containerRepo, 4)
+	hub.SetReplaySize(3)
+	ctx := context.Background()
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil).Once()
+
+	for _, memberID := range []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"} {
+		event := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": memberID})
+		require.NoError(t, hub.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+	}
+	other := domain.NewMemberAddedEvent("notes", map[string]interface{}{"memberID": "todo.txt"})
+	require.NoError(t, hub.Handle(ctx, &testEnvelope{event: other, timestamp: time.Now()}))
+
+	// Sequence 1 (a.jpg) has left the replay buffer; only the photos changes after it remain
+	subscription, err := hub.SubscribeFrom(ctx, "photos", 1)
+	require.NoError(t, err)
+	for _, expected := range []struct {
+		sequence uint64
+		memberID string
+	}{{3, "c.jpg"}, {4, "d.jpg"}} {
+		notification := <-subscription.Notifications
+		assert.Equal(t, expected.sequence, notification.Sequence)
+		assert.Equal(t, expected.memberID, notification.MemberID)
+	}
+	assert.Equal(t, 1, hub.SubscriberCount("photos"))
+
+	// A container deleted while the subscriber was away is resumed up to its deletion
+	deleted := domain.NewContainerDeletedEvent("photos", map[string]interface{}{})
+	require.NoError(t, hub.Handle(ctx, &testEnvelope{event: deleted, timestamp: time.Now()}))
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(false, nil)
+
+	resumed, err := hub.SubscribeFrom(ctx, "photos", 4)
+	require.NoError(t, err)
+	notification := <-resumed.Notifications
+	assert.Equal(t, domain.EventTypeContainerDeleted, notification.Type)
+	_, open := <-resumed.Notifications
+	assert.False(t, open)
+
+	_, err = hub.SubscribeFrom(ctx, "photos", notification.Sequence)
+	assert.True(t, domain.IsResourceNotFound(err))
+}
+
+func TestContainerChangeNotification_SolidNotification(t *testing.T) {
+	published := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	topic := "https://pod.example/containers/photos"
+
+	added := ContainerChangeNotification{Type: domain.EventTypeMemberAdded, ContainerID: "photos", MemberID: "beach.jpg", Timestamp: published}
+	notification := added.SolidNotification(topic)
+	assert.Equal(t, "Add", notification["type"])
+	assert.Equal(t, "https://pod.example/resources/beach.jpg", notification["object"])
+	assert.Equal(t, topic, notification["target"])
+	assert.Equal(t, "2024-03-01T12:00:00Z", notification["published"])
+
+	moved := ContainerChangeNotification{Type: domain.EventTypeContainerMoved, ContainerID: "photos", Timestamp: published}
+	notification = moved.SolidNotification(topic)
+	assert.Equal(t, "Update", notification["type"])
+	assert.Equal(t, topic, notification["object"])
+	assert.NotContains(t, notification, "target")
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// ContainmentCleanupHandler keeps ldp:contains consistent with resource existence. When a
+// resource is deleted directly rather than removed through its container, it takes the
+// resource out of every container the membership index still lists it in, emitting
+// member_removed for each, so no container goes on containing a resource that is gone.
+//
+// It works on the repository rather than through ContainerService: deletions committed by
+// the service itself, such as recursive container deletes, are dispatched while the service
+// holds its lock.
+type ContainmentCleanupHandler struct {
+	containerRepo     domain.ContainerRepository
+	holders           domain.MemberContainerSource
+	unitOfWorkFactory func() pericarpdomain.UnitOfWork
+}
+
+// NewContainmentCleanupHandler creates a handler finding the containers of deleted resources
+// through holders
+func NewContainmentCleanupHandler(
+	containerRepo domain.ContainerRepository,
+	holders domain.MemberContainerSource,
+	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
+) *ContainmentCleanupHandler {
+	return &ContainmentCleanupHandler{
+		containerRepo:     containerRepo,
+		holders:           holders,
+		unitOfWorkFactory: unitOfWorkFactory,
+	}
+}
+
+// EventTypes returns the resource event types the handler cleans up after
+func (h *ContainmentCleanupHandler) EventTypes() []string {
+	return []string{
+		"resource." + domain.EventTypeResourceDeleted,
+	}
+}
+
+// Handle removes a deleted resource from the containers still holding it. Containers that no
+// longer list the resource, such as the one it was deleted through, are left alone, so
+// handling the same deletion again changes nothing.
+func (h *ContainmentCleanupHandler) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event, ok := envelope.Event().(*pericarpdomain.EntityEvent)
+	if !ok || event.EntityType != "resource" || event.Type != domain.EventTypeResourceDeleted {
+		return nil
+	}
+	memberID := event.AggregateID()
+
+	holders, err := h.holders.ListMemberContainers(ctx, memberID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to list containers of deleted resource",
+		).WithOperation("CleanupContainment").WithContext("resourceID", memberID)
+	}
+
+	now := time.Now()
+	var events []pericarpdomain.Event
+	for _, containerID := range holders {
+		container, err := h.containerRepo.GetContainer(ctx, containerID)
+		if err != nil {
+			if domain.IsResourceNotFound(err) {
+				continue
+			}
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to retrieve container",
+			).WithOperation("CleanupContainment").WithContext("containerID", containerID)
+		}
+		if !container.HasMember(memberID) {
+			continue
+		}
+
+		// Containers with membership events disabled drop the member without touching the event log
+		if !domain.MembershipEventsEnabled(container.GetMetadata()) {
+			if err := h.containerRepo.RemoveMember(ctx, containerID, memberID); err != nil {
+				return domain.WrapStorageError(
+					err,
+					domain.ErrStorageOperation.Code,
+					"failed to remove member",
+				).WithOperation("CleanupContainment").WithContext("containerID", containerID).WithContext("resourceID", memberID)
+			}
+			continue
+		}
+
+		events = append(events, domain.NewMemberRemovedEvent(containerID, map[string]interface{}{
+			"memberID":   memberID,
+			"memberType": "Resource",
+			"removedAt":  now,
+		}))
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	unitOfWork := h.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(events)
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit containment cleanup events",
+		).WithOperation("CleanupContainment").WithContext("resourceID", memberID)
+	}
+
+	fmt.Printf("Removed deleted resource %s from %d containers\n", memberID, len(events))
+	return nil
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainmentCleanupHandler_RemovesDeletedResourceFromContainers(t *testing.T) {
+	ctx := context.Background()
+	doc := domain.NewResource(ctx, "doc", "text/plain", []byte("hello"))
+
+	setup := func() (*ContainmentCleanupHandler, *TestMockContainerRepository, *MockUnitOfWork) {
+		mockRepo := &TestMockContainerRepository{}
+		mockUoW := &MockUnitOfWork{}
+		repo := &memberContainerRepository{
+			TestMockContainerRepository: mockRepo,
+			holders:                     map[string][]string{"doc": {"notes", "trash", "archive", "gone"}},
+		}
+
+		notes := domain.NewContainer(ctx, "notes", "", domain.BasicContainer)
+		require.NoError(t, notes.AddMember(ctx, doc))
+		trash := domain.NewContainer(ctx, "trash", "", domain.BasicContainer)
+		require.NoError(t, trash.AddMember(ctx, doc))
+		trash.SetMembershipEvents(false)
+		// The index is behind: archive no longer lists the resource
+		archive := domain.NewContainer(ctx, "archive", "", domain.BasicContainer)
+
+		mockRepo.On("GetContainer", ctx, "notes").Return(notes, nil)
+		mockRepo.On("GetContainer", ctx, "trash").Return(trash, nil)
+		mockRepo.On("GetContainer", ctx, "archive").Return(archive, nil)
+		mockRepo.On("GetContainer", ctx, "gone").Return(nil, domain.ErrResourceNotFound)
+		mockRepo.On("RemoveMember", ctx, "trash", "doc").Return(nil)
+
+		handler := NewContainmentCleanupHandler(repo, repo, func() pericarpdomain.UnitOfWork { return mockUoW })
+		return handler, mockRepo, mockUoW
+	}
+	deleted := &testEnvelope{event: domain.NewResourceDeletedEvent("doc", map[string]interface{}{}), timestamp: time.Now()}
+
+	t.Run("emits member_removed for each container still listing the resource", func(t *testing.T) {
+		handler, mockRepo, mockUoW := setup()
+		var registered []pericarpdomain.Event
+		mockUoW.On("RegisterEvents", mock.Anything).Run(func(args mock.Arguments) {
+			registered = append(registered, args.Get(0).([]pericarpdomain.Event)...)
+		}).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		require.NoError(t, handler.Handle(ctx, deleted))
+
+		require.Len(t, registered, 1)
+		event := registered[0].(*pericarpdomain.EntityEvent)
+		assert.Equal(t, domain.EventTypeMemberRemoved, event.Type)
+		assert.Equal(t, "notes", event.AggregateID())
+		assert.Contains(t, string(event.Payload()), `"memberID":"doc"`)
+
+		// Containers with membership events disabled drop the member directly
+		mockRepo.AssertCalled(t, "RemoveMember", ctx, "trash", "doc")
+		mockRepo.AssertNotCalled(t, "RemoveMember", ctx, "notes", "doc")
+	})
+
+	t.Run("reports a failed commit for retry", func(t *testing.T) {
+		handler, _, mockUoW := setup()
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return(nil, errors.New("event store unavailable"))
+		mockUoW.On("Rollback").Return(nil)
+
+		err := handler.Handle(ctx, deleted)
+		require.Error(t, err)
+		mockUoW.AssertCalled(t, "Rollback")
+	})
+
+	t.Run("ignores other events", func(t *testing.T) {
+		handler, mockRepo, mockUoW := setup()
+		updated := &testEnvelope{event: domain.NewResourceUpdatedEvent("doc", map[string]interface{}{}), timestamp: time.Now()}
+
+		require.NoError(t, handler.Handle(ctx, updated))
+		mockRepo.AssertNotCalled(t, "GetContainer", mock.Anything, mock.Anything)
+		mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
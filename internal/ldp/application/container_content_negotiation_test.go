@@ -15,17 +15,14 @@ func TestContainerService_GetContainerWithFormat_Turtle(t *testing.T) {
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create a test container with members
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Set up mock expectations
 	mockRepo.On("GetContainer", mock.Anything, container.ID()).Return(container, nil)
@@ -61,17 +58,14 @@ func TestContainerService_GetContainerWithFormat_JSONLD(t *testing.T) {
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create a test container with members
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Set up mock expectations
 	mockRepo.On("GetContainer", mock.Anything, container.ID()).Return(container, nil)
@@ -110,17 +104,14 @@ func TestContainerService_GetContainerWithFormat_RDFXML(t *testing.T) {
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create a test container with members
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Set up mock expectations
 	mockRepo.On("GetContainer", mock.Anything, container.ID()).Return(container, nil)
@@ -159,13 +150,10 @@ func TestContainerService_GetContainerWithFormat_UnsupportedFormat(t *testing.T)
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create a test container
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 
 	// Set up mock expectations
 	mockRepo.On("GetContainer", mock.Anything, container.ID()).Return(container, nil)
@@ -189,13 +177,10 @@ func TestContainerService_GetContainerWithFormat_EmptyContainer(t *testing.T) {
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create an empty test container
-	container := domain.NewContainer("empty-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "empty-container", "", domain.BasicContainer)
 	container.SetTitle("Empty Container")
 
 	// Set up mock expectations
@@ -230,17 +215,14 @@ func TestContainerService_ListContainerMembersWithFormat_Turtle(t *testing.T) {
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create a test container with multiple members
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
-	container.AddMember("sub-container-1")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "sub-container-1", "text/plain", nil))
 
 	// Set up mock expectations
 	mockRepo.On("GetContainer", mock.Anything, container.ID()).Return(container, nil)
@@ -276,17 +258,14 @@ func TestContainerService_ListContainerMembersWithFormat_JSONLD(t *testing.T) {
 
 	// Create a container service with RDF converter
 	rdfConverter := infrastructure.NewContainerRDFConverter()
-	service := &ContainerService{
-		containerRepo: mockRepo,
-		rdfConverter:  rdfConverter,
-	}
+	service := NewContainerService(mockRepo, nil, rdfConverter)
 
 	// Create a test container with multiple members
-	container := domain.NewContainer("test-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
-	container.AddMember("sub-container-1")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "sub-container-1", "text/plain", nil))
 
 	// Set up mock expectations
 	mockRepo.On("GetContainer", mock.Anything, container.ID()).Return(container, nil)
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetContentTransformPipeline sets the transformers that rewrite resource content on write
+func (s *ContainerService) SetContentTransformPipeline(pipeline *domain.ContentTransformPipeline) {
+	s.contentTransforms = pipeline
+}
+
+// SetContainerContentTransforms enables or disables write-time content transforms for a container
+func (s *ContainerService) SetContainerContentTransforms(ctx context.Context, containerID string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerContentTransforms").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerContentTransforms").WithContext("containerID", containerID)
+	}
+
+	if domain.ContentTransformsEnabled(concreteContainer.GetMetadata()) == enabled {
+		return nil
+	}
+
+	// Only the toggle event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetContentTransforms(enabled)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerContentTransforms").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// TransformContainerContent runs the write-time transformers over content being written into a
+// container. Content is returned unchanged when no transformers are registered or the container
+// has opted out; a transformer error is returned as ErrContentTransformFailed.
+func (s *ContainerService) TransformContainerContent(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, error) {
+	if s.contentTransforms.Len() == 0 {
+		return data, nil
+	}
+
+	s.mu.RLock()
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("TransformContainerContent").WithContext("containerID", containerID)
+	}
+
+	if !domain.ContentTransformsEnabled(container.GetMetadata()) {
+		return data, nil
+	}
+
+	transformed, err := s.contentTransforms.Transform(data, contentType)
+	if err != nil {
+		if storageErr, ok := domain.GetStorageError(err); ok {
+			return nil, storageErr.WithOperation("TransformContainerContent").WithContext("containerID", containerID)
+		}
+		return nil, err
+	}
+
+	return transformed, nil
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// rejectingTransformer fails on every JSON document
+type rejectingTransformer struct{}
+
+func (rejectingTransformer) Name() string { return "rejecting" }
+func (rejectingTransformer) Handles(contentType string) bool {
+	return contentType == "application/json"
+}
+func (rejectingTransformer) Transform(data []byte, contentType string) ([]byte, error) {
+	return nil, errors.New("refused")
+}
+
+func TestContainerService_TransformContainerContent(t *testing.T) {
+	ctx := context.Background()
+	document := []byte("{ \"a\": 1 }")
+
+	t.Run("applies transformers to containers that have not opted out", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetContentTransformPipeline(domain.NewContentTransformPipeline(infrastructure.NewJSONMinifier()))
+		mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+
+		out, err := service.TransformContainerContent(ctx, "docs", document, "application/json")
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":1}`, string(out))
+	})
+
+	t.Run("leaves content alone in containers that opted out", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetContentTransformPipeline(domain.NewContentTransformPipeline(infrastructure.NewJSONMinifier()))
+		container := domain.NewContainer(ctx, "raw", "", domain.BasicContainer)
+		container.SetContentTransforms(false)
+		mockRepo.On("GetContainer", ctx, "raw").Return(container, nil)
+
+		out, err := service.TransformContainerContent(ctx, "raw", document, "application/json")
+		require.NoError(t, err)
+		assert.Equal(t, document, out)
+	})
+
+	t.Run("skips the container lookup without transformers", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+
+		out, err := service.TransformContainerContent(ctx, "docs", document, "application/json")
+		require.NoError(t, err)
+		assert.Equal(t, document, out)
+		mockRepo.AssertNotCalled(t, "GetContainer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports transformer errors", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetContentTransformPipeline(domain.NewContentTransformPipeline(rejectingTransformer{}))
+		mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+
+		_, err := service.TransformContainerContent(ctx, "docs", document, "application/json")
+		assert.True(t, domain.IsContentTransformFailed(err))
+	})
+}
+
+func TestContainerService_SetContainerContentTransforms(t *testing.T) {
+	service, mockRepo, mockUoW := setupContainerServiceTest()
+	ctx := context.Background()
+
+	container := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+	mockRepo.On("GetContainer", ctx, "photos").Return(container, nil)
+	mockUoW.On("RegisterEvents", mock.Anything).Return()
+	mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	require.NoError(t, service.SetContainerContentTransforms(ctx, "photos", false))
+	assert.False(t, domain.ContentTransformsEnabled(container.GetMetadata()))
+	mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+
+	// Setting the current value commits nothing
+	require.NoError(t, service.SetContainerContentTransforms(ctx, "photos", false))
+	mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+}
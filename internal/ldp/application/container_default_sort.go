@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetContainerDefaultSort sets the order a container's members are listed in when the client
+// specifies no sort. The zero SortOptions clears it, restoring the global default.
+func (s *ContainerService) SetContainerDefaultSort(ctx context.Context, containerID string, sort domain.SortOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sort != (domain.SortOptions{}) && !sort.IsValid() {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid sort %s %s", sort.Field, sort.Direction),
+			domain.ErrInvalidFormat.Code,
+			"invalid default sort",
+		).WithOperation("SetContainerDefaultSort").WithContext("containerID", containerID)
+	}
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerDefaultSort").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerDefaultSort").WithContext("containerID", containerID)
+	}
+
+	current, configured := domain.DefaultSort(concreteContainer.GetMetadata())
+	if (configured && current == sort) || (!configured && sort == (domain.SortOptions{})) {
+		return nil
+	}
+
+	// Only the default sort event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetDefaultSort(sort)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerDefaultSort").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// DefaultListingOptions returns the listing options that apply to a container when the client
+// specifies none, including the container's configured default sort
+func (s *ContainerService) DefaultListingOptions(ctx context.Context, containerID string) (domain.ListingOptions, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.defaultListingOptions(ctx, containerID)
+}
+
+// defaultListingOptions resolves a container's default listing options; callers hold the lock
+func (s *ContainerService) defaultListingOptions(ctx context.Context, containerID string) (domain.ListingOptions, error) {
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return domain.ListingOptions{}, domain.ErrResourceNotFound.WithOperation("DefaultListingOptions").WithContext("containerID", containerID)
+		}
+		return domain.ListingOptions{}, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("DefaultListingOptions").WithContext("containerID", containerID)
+	}
+	return domain.GetDefaultListingOptions(container), nil
+}
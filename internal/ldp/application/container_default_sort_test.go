@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_DefaultSort(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies the container default when no sort is requested", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "gallery", "", domain.BasicContainer)
+		container.SetDefaultSort(domain.SortOptions{Field: "createdAt", Direction: "desc"})
+		mockRepo.On("GetContainer", ctx, "gallery").Return(container, nil)
+		mockRepo.On("ContainerExists", ctx, "gallery").Return(true, nil)
+		mockRepo.On("ListMembers", ctx, "gallery", mock.Anything).Return([]string{}, nil)
+
+		listing, err := service.ListContainerMembersEnhanced(ctx, "gallery", domain.ListingOptions{Pagination: domain.GetDefaultPagination()})
+		require.NoError(t, err)
+		assert.Equal(t, domain.SortOptions{Field: "createdAt", Direction: "desc"}, listing.Sort)
+	})
+
+	t.Run("keeps a requested sort", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "gallery").Return(true, nil)
+		mockRepo.On("ListMembers", ctx, "gallery", mock.Anything).Return([]string{}, nil)
+
+		requested := domain.SortOptions{Field: "name", Direction: "asc"}
+		listing, err := service.ListContainerMembersEnhanced(ctx, "gallery", domain.ListingOptions{Pagination: domain.GetDefaultPagination(), Sort: requested})
+		require.NoError(t, err)
+		assert.Equal(t, requested, listing.Sort)
+		mockRepo.AssertNotCalled(t, "GetContainer", ctx, "gallery")
+	})
+
+	t.Run("falls back to the global default", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+
+		options, err := service.DefaultListingOptions(ctx, "docs")
+		require.NoError(t, err)
+		assert.Equal(t, domain.GetDefaultSort(), options.Sort)
+	})
+
+	t.Run("stores the default sort", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "docs").Return(container, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		require.NoError(t, service.SetContainerDefaultSort(ctx, "docs", domain.SortOptions{Field: "name", Direction: "asc"}))
+		sort, ok := domain.DefaultSort(container.GetMetadata())
+		assert.True(t, ok)
+		assert.Equal(t, domain.SortOptions{Field: "name", Direction: "asc"}, sort)
+
+		require.NoError(t, service.SetContainerDefaultSort(ctx, "docs", domain.SortOptions{Field: "name", Direction: "asc"}))
+		mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+	})
+
+	t.Run("rejects an invalid sort", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+
+		err := service.SetContainerDefaultSort(ctx, "docs", domain.SortOptions{Field: "colour", Direction: "asc"})
+		require.Error(t, err)
+	})
+}
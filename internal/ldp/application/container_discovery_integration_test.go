@@ -29,22 +29,24 @@ func TestContainerDiscovery_Integration(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a hierarchy: root -> documents -> images
-	rootContainer := domain.NewContainer("root", "", domain.BasicContainer)
+	rootContainer := domain.NewContainer(context.Background(), "root", "", domain.BasicContainer)
 	rootContainer.SetTitle("Root Container")
 	err = repo.CreateContainer(ctx, rootContainer)
 	require.NoError(t, err)
 
-	documentsContainer := domain.NewContainer("documents", "root", domain.BasicContainer)
+	documentsContainer := domain.NewContainer(context.Background(), "documents", "root", domain.BasicContainer)
 	documentsContainer.SetTitle("Documents")
 	err = repo.CreateContainer(ctx, documentsContainer)
 	require.NoError(t, err)
 
-	imagesContainer := domain.NewContainer("images", "documents", domain.BasicContainer)
+	imagesContainer := domain.NewContainer(context.Background(), "images", "documents", domain.BasicContainer)
 	imagesContainer.SetTitle("Images")
 	err = repo.CreateContainer(ctx, imagesContainer)
 	require.NoError(t, err)
 
 	// Add some members
+	require.NoError(t, repo.Store(ctx, domain.NewResource(ctx, "doc1.txt", "text/plain", []byte("doc"))))
+	require.NoError(t, repo.Store(ctx, domain.NewResource(ctx, "photo1.jpg", "image/jpeg", []byte("photo"))))
 	err = repo.AddMember(ctx, "documents", "doc1.txt")
 	require.NoError(t, err)
 	err = repo.AddMember(ctx, "images", "photo1.jpg")
@@ -235,12 +235,12 @@ func TestContainerService_GetContainerTypeInfo(t *testing.T) {
 				container := domain.NewContainer(context.Background(), "container1", "", domain.BasicContainer)
 				container.SetTitle("Test Container")
 				container.SetDescription("A test container")
-				container.AddMember(context.Background(), "resource1")
-				container.AddMember(context.Background(), "resource2")
+				container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource1", "text/plain", nil))
+				container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource2", "text/plain", nil))
 
 				mockRepo.On("GetContainer", mock.Anything, "container1").Return(container, nil)
 				mockRepo.On("ListMembers", mock.Anything, "container1", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{"resource1", "resource2"}, nil)
-				mockRepo.On("GetChildren", mock.Anything, "container1").Return([]*domain.Container{}, nil)
+				mockRepo.On("GetChildren", mock.Anything, "container1").Return([]domain.ContainerResource{}, nil)
 			},
 			expectedInfo: &ContainerTypeInfo{
 				ID:            "container1",
@@ -264,7 +264,7 @@ func TestContainerService_GetContainerTypeInfo(t *testing.T) {
 
 				mockRepo.On("GetContainer", mock.Anything, "empty").Return(container, nil)
 				mockRepo.On("ListMembers", mock.Anything, "empty", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{}, nil)
-				mockRepo.On("GetChildren", mock.Anything, "empty").Return([]*domain.Container{}, nil)
+				mockRepo.On("GetChildren", mock.Anything, "empty").Return([]domain.ContainerResource{}, nil)
 			},
 			expectedInfo: &ContainerTypeInfo{
 				ID:            "empty",
@@ -291,7 +291,7 @@ func TestContainerService_GetContainerTypeInfo(t *testing.T) {
 
 				mockRepo.On("GetContainer", mock.Anything, "parent").Return(container, nil)
 				mockRepo.On("ListMembers", mock.Anything, "parent", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{}, nil)
-				mockRepo.On("GetChildren", mock.Anything, "parent").Return([]*domain.Container{child1, child2}, nil)
+				mockRepo.On("GetChildren", mock.Anything, "parent").Return([]domain.ContainerResource{child1, child2}, nil)
 			},
 			expectedInfo: &ContainerTypeInfo{
 				ID:            "parent",
@@ -360,14 +360,14 @@ func TestContainerService_GenerateStructureInfo(t *testing.T) {
 			setupMocks: func(mockRepo *MockContainerRepository) {
 				container := domain.NewContainer(context.Background(), "root", "", domain.BasicContainer)
 				container.SetTitle("Root Container")
-				container.AddMember("resource1")
+				container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource1", "text/plain", nil))
 
 				child1 := domain.NewContainer(context.Background(), "child1", "root", domain.BasicContainer)
 				child1.SetTitle("Child 1")
 
 				mockRepo.On("GetContainer", mock.Anything, "root").Return(container, nil)
 				mockRepo.On("ListMembers", mock.Anything, "root", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{"resource1"}, nil)
-				mockRepo.On("GetChildren", mock.Anything, "root").Return([]*domain.Container{child1}, nil)
+				mockRepo.On("GetChildren", mock.Anything, "root").Return([]domain.ContainerResource{child1}, nil)
 				// Mocks for child1 container
 				mockRepo.On("GetContainer", mock.Anything, "child1").Return(child1, nil)
 				mockRepo.On("ListMembers", mock.Anything, "child1", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{}, nil)
@@ -416,10 +416,10 @@ func TestContainerService_GenerateStructureInfo(t *testing.T) {
 
 				mockRepo.On("GetContainer", mock.Anything, "root").Return(container, nil)
 				mockRepo.On("ListMembers", mock.Anything, "root", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{}, nil)
-				mockRepo.On("GetChildren", mock.Anything, "root").Return([]*domain.Container{child}, nil)
+				mockRepo.On("GetChildren", mock.Anything, "root").Return([]domain.ContainerResource{child}, nil)
 				mockRepo.On("GetContainer", mock.Anything, "child").Return(child, nil)
 				mockRepo.On("ListMembers", mock.Anything, "child", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{}, nil)
-				mockRepo.On("GetChildren", mock.Anything, "child").Return([]*domain.Container{grandchild}, nil)
+				mockRepo.On("GetChildren", mock.Anything, "child").Return([]domain.ContainerResource{grandchild}, nil)
 				mockRepo.On("GetContainer", mock.Anything, "grandchild").Return(grandchild, nil)
 				mockRepo.On("ListMembers", mock.Anything, "grandchild", mock.AnythingOfType("domain.PaginationOptions")).Return([]string{}, nil)
 				// Note: GetChildren for grandchild is not called because depth limit is reached
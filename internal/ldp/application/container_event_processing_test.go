@@ -45,7 +45,6 @@ func TestContainerEventHandler_HandleContainerCreated(t *testing.T) {
 	// Setup
 	mockRepo := new(MockContainerRepository)
 	handler := NewContainerEventHandler(mockRepo)
-	handler.SetFilePersistenceEnabled(false) // Disable file persistence for testing
 
 	ctx := context.Background()
 	containerID := "test-container-id"
@@ -84,14 +83,13 @@ func TestContainerEventHandler_HandleContainerUpdated(t *testing.T) {
 	// Setup
 	mockRepo := new(MockContainerRepository)
 	handler := NewContainerEventHandler(mockRepo)
-	handler.SetFilePersistenceEnabled(false) // Disable file persistence for testing
 
 	ctx := context.Background()
 	containerID := "test-container-id"
 	newTitle := "Updated Container Title"
 
 	// Create existing container
-	existingContainer := domain.NewContainer(containerID, "", domain.BasicContainer)
+	existingContainer := domain.NewContainer(context.Background(), containerID, "", domain.BasicContainer)
 	existingContainer.MarkEventsAsCommitted() // Clear creation events
 
 	// Create event payload
@@ -122,7 +120,6 @@ func TestContainerEventHandler_HandleContainerDeleted(t *testing.T) {
 	// Setup
 	mockRepo := new(MockContainerRepository)
 	handler := NewContainerEventHandler(mockRepo)
-	handler.SetFilePersistenceEnabled(false) // Disable file persistence for testing
 
 	ctx := context.Background()
 	containerID := "test-container-id"
@@ -151,7 +148,6 @@ func TestContainerEventHandler_HandleMemberAdded(t *testing.T) {
 	// Setup
 	mockRepo := new(MockContainerRepository)
 	handler := NewContainerEventHandler(mockRepo)
-	handler.SetFilePersistenceEnabled(false) // Disable file persistence for testing
 
 	ctx := context.Background()
 	containerID := "test-container-id"
@@ -183,7 +179,6 @@ func TestContainerEventHandler_HandleMemberRemoved(t *testing.T) {
 	// Setup
 	mockRepo := new(MockContainerRepository)
 	handler := NewContainerEventHandler(mockRepo)
-	handler.SetFilePersistenceEnabled(false) // Disable file persistence for testing
 
 	ctx := context.Background()
 	containerID := "test-container-id"
@@ -266,6 +261,7 @@ func TestContainerEventHandler_EventTypes(t *testing.T) {
 		"container.created",
 		"container.updated",
 		"container.deleted",
+		"container.moved",
 		"container.member_added",
 		"container.member_removed",
 	}
@@ -356,7 +352,7 @@ func TestContainerEventHandler_ApplyContainerUpdatesFromEvent(t *testing.T) {
 	handler := NewContainerEventHandler(mockRepo)
 
 	// Create existing container
-	container := domain.NewContainer("test-id", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "test-id", "", domain.BasicContainer)
 	container.MarkEventsAsCommitted() // Clear creation events
 
 	newTitle := "Updated Title"
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// exportBatchSize is how many members are read from the membership index at a time on export
+const exportBatchSize = 500
+
+// ExportContainerMembers passes each member of one page of a container's listing to emit, in
+// membership index order. Members are read from the index in batches so a large page is never
+// held in memory at once; content types are taken from the search index where it knows them.
+// Export stops at the first error emit returns.
+func (s *ContainerService) ExportContainerMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions, emit func(domain.IndexedMember) error) error {
+	if containerID == "" {
+		return domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("ExportContainerMembers")
+	}
+	if !pagination.IsValid() {
+		pagination = domain.GetDefaultPagination()
+	}
+
+	s.mu.RLock()
+	exists, err := s.containerRepo.ContainerExists(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check container existence",
+		).WithOperation("ExportContainerMembers").WithContext("containerID", containerID)
+	}
+	if !exists {
+		return domain.ErrResourceNotFound.WithOperation("ExportContainerMembers").WithContext("containerID", containerID)
+	}
+
+	// The lock is not held while emitting, so a slow client cannot stall writers
+	for offset, remaining := pagination.Offset, pagination.Limit; remaining > 0; {
+		batchSize := exportBatchSize
+		if remaining < batchSize {
+			batchSize = remaining
+		}
+
+		members, err := s.indexedMembersPage(ctx, containerID, domain.PaginationOptions{Limit: batchSize, Offset: offset})
+		if err != nil {
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to read membership index",
+			).WithOperation("ExportContainerMembers").WithContext("containerID", containerID)
+		}
+
+		for _, member := range members {
+			if err := emit(s.describeExportedMember(ctx, member)); err != nil {
+				return err
+			}
+		}
+
+		if len(members) < batchSize {
+			return nil
+		}
+		offset += len(members)
+		remaining -= len(members)
+	}
+
+	return nil
+}
+
+// indexedMembersPage reads one page of members, slicing the full listing when the membership
+// index cannot page itself
+func (s *ContainerService) indexedMembersPage(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]domain.IndexedMember, error) {
+	if paged, ok := s.memberIndex.(domain.PagedMemberIndexSource); ok {
+		return paged.ListIndexedMembersPage(ctx, containerID, pagination)
+	}
+	if s.memberIndex == nil {
+		return nil, nil
+	}
+
+	members, err := s.memberIndex.ListIndexedMembers(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if pagination.Offset >= len(members) {
+		return nil, nil
+	}
+	end := pagination.Offset + pagination.Limit
+	if end > len(members) {
+		end = len(members)
+	}
+	return members[pagination.Offset:end], nil
+}
+
+// describeExportedMember fills in the member's content type from the search index, which
+// records the type each resource was stored with
+func (s *ContainerService) describeExportedMember(ctx context.Context, member domain.IndexedMember) domain.IndexedMember {
+	if s.searchIndex == nil {
+		return member
+	}
+	doc, found, err := s.searchIndex.Get(ctx, member.ID)
+	if err != nil {
+		fmt.Printf("Warning: failed to read search index for member %s: %v\n", member.ID, err)
+		return member
+	}
+	if found && doc.ContentType != "" {
+		member.ContentType = doc.ContentType
+	}
+	return member
+}
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedMemberIndex serves members a page at a time and records the pages requested
+type pagedMemberIndex struct {
+	members []domain.IndexedMember
+	pages   []domain.PaginationOptions
+}
+
+func (p *pagedMemberIndex) ListIndexedMembers(ctx context.Context, containerID string) ([]domain.IndexedMember, error) {
+	return p.members, nil
+}
+
+func (p *pagedMemberIndex) ListIndexedMembersPage(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]domain.IndexedMember, error) {
+	p.pages = append(p.pages, pagination)
+	return staticMemberIndex(p.members).page(pagination), nil
+}
+
+// page slices the members the way a paging index would
+func (m staticMemberIndex) page(pagination domain.PaginationOptions) []domain.IndexedMember {
+	if pagination.Offset >= len(m) {
+		return nil
+	}
+	end := pagination.Offset + pagination.Limit
+	if end > len(m) {
+		end = len(m)
+	}
+	return m[pagination.Offset:end]
+}
+
+func exportMembers(n int) []domain.IndexedMember {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	members := make([]domain.IndexedMember, n)
+	for i := range members {
+		members[i] = domain.IndexedMember{ID: fmt.Sprintf("m%04d", i), Type: "Resource", CreatedAt: created, UpdatedAt: created}
+	}
+	return members
+}
+
+func collectExport(t *testing.T, service *ContainerService, pagination domain.PaginationOptions) []string {
+	var ids []string
+	err := service.ExportContainerMembers(context.Background(), "photos", pagination, func(member domain.IndexedMember) error {
+		ids = append(ids, member.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+func TestContainerService_ExportContainerMembers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads the requested page from the index in batches", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+		index := &pagedMemberIndex{members: exportMembers(1200)}
+		service.SetMemberIndex(index)
+
+		ids := collectExport(t, service, domain.PaginationOptions{Limit: 1000, Offset: 100})
+		require.Len(t, ids, 1000)
+		assert.Equal(t, "m0100", ids[0])
+		assert.Equal(t, "m1099", ids[999])
+		assert.Equal(t, []domain.PaginationOptions{{Limit: 500, Offset: 100}, {Limit: 500, Offset: 600}}, index.pages)
+	})
+
+	t.Run("stops at the end of the container", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+		service.SetMemberIndex(&pagedMemberIndex{members: exportMembers(3)})
+
+		assert.Equal(t, []string{"m0001", "m0002"}, collectExport(t, service, domain.PaginationOptions{Limit: 10, Offset: 1}))
+	})
+
+	t.Run("pages indexes that cannot page themselves", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+		service.SetMemberIndex(staticMemberIndex(exportMembers(5)))
+
+		assert.Equal(t, []string{"m0002", "m0003"}, collectExport(t, service, domain.PaginationOptions{Limit: 2, Offset: 2}))
+	})
+
+	t.Run("takes content types from the search index", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+		service.SetMemberIndex(staticMemberIndex{{ID: "beach", Type: "Resource", ContentType: "application/octet-stream"}})
+		index := infrastructure.NewMemorySearchIndex()
+		require.NoError(t, index.Index(ctx, domain.SearchDocument{ID: "beach", Type: domain.SearchTypeResource, ContentType: "image/jpeg"}))
+		service.SetSearchIndex(index)
+
+		var exported []domain.IndexedMember
+		require.NoError(t, service.ExportContainerMembers(ctx, "photos", domain.GetDefaultPagination(), func(member domain.IndexedMember) error {
+			exported = append(exported, member)
+			return nil
+		}))
+		require.Len(t, exported, 1)
+		assert.Equal(t, "image/jpeg", exported[0].ContentType)
+	})
+
+	t.Run("stops when emit fails", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+		service.SetMemberIndex(staticMemberIndex(exportMembers(5)))
+
+		calls := 0
+		clientGone := errors.New("client gone")
+		err := service.ExportContainerMembers(ctx, "photos", domain.GetDefaultPagination(), func(domain.IndexedMember) error {
+			calls++
+			return clientGone
+		})
+		assert.ErrorIs(t, err, clientGone)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("reports missing containers", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "photos").Return(false, nil)
+
+		err := service.ExportContainerMembers(ctx, "photos", domain.GetDefaultPagination(), func(domain.IndexedMember) error { return nil })
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+}
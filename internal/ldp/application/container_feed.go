@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// FeedEntry is a container member as it appears in a syndication feed
+type FeedEntry struct {
+	ID        string
+	Type      string
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ContainerFeed is a container and its members, newest first, for syndication feeds
+type ContainerFeed struct {
+	ID        string
+	Title     string
+	UpdatedAt time.Time
+	Entries   []FeedEntry
+}
+
+// SetMemberIndex sets the membership index used for member timestamps in feeds
+func (s *ContainerService) SetMemberIndex(source domain.MemberIndexSource) {
+	s.memberIndex = source
+}
+
+// GetContainerFeed lists a container's members ordered by creation date, newest first.
+// Timestamps come from the membership index and titles from the search index; members
+// missing from either fall back to the container's timestamps and their own ID.
+func (s *ContainerService) GetContainerFeed(ctx context.Context, containerID string) (*ContainerFeed, error) {
+	if containerID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("GetContainerFeed")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("GetContainerFeed").WithContext("containerID", containerID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("GetContainerFeed").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidResource.Code,
+			"invalid container type",
+		).WithOperation("GetContainerFeed").WithContext("containerID", containerID)
+	}
+
+	createdAt, _ := s.timestampManager.ResolveCreatedTimestamp(concreteContainer)
+	updatedAt, _ := s.timestampManager.ResolveUpdatedTimestamp(concreteContainer)
+
+	indexed := make(map[string]domain.IndexedMember)
+	if s.memberIndex != nil {
+		members, err := s.memberIndex.ListIndexedMembers(ctx, containerID)
+		if err != nil {
+			fmt.Printf("Warning: failed to read membership index for container %s: %v\n", containerID, err)
+		}
+		for _, member := range members {
+			indexed[member.ID] = member
+		}
+	}
+
+	feed := &ContainerFeed{
+		ID:        containerID,
+		Title:     concreteContainer.GetTitle(),
+		UpdatedAt: updatedAt,
+		Entries:   make([]FeedEntry, 0, concreteContainer.GetMemberCount()),
+	}
+	if feed.Title == "" {
+		feed.Title = containerID
+	}
+
+	// The container's member list is authoritative; the indexes only describe its members
+	for _, memberID := range concreteContainer.GetMembers() {
+		entry := FeedEntry{
+			ID:        memberID,
+			Type:      domain.SearchTypeResource,
+			Title:     memberID,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		}
+		if member, ok := indexed[memberID]; ok {
+			if member.Type != "" {
+				entry.Type = member.Type
+			}
+			entry.CreatedAt = member.CreatedAt
+			entry.UpdatedAt = member.UpdatedAt
+		}
+		if s.searchIndex != nil {
+			doc, found, err := s.searchIndex.Get(ctx, memberID)
+			if err != nil {
+				fmt.Printf("Warning: failed to read search index for member %s: %v\n", memberID, err)
+			} else if found {
+				if doc.Title != "" {
+					entry.Title = doc.Title
+				}
+				if doc.Type != "" {
+					entry.Type = doc.Type
+				}
+				if doc.UpdatedAt.After(entry.UpdatedAt) {
+					entry.UpdatedAt = doc.UpdatedAt
+				}
+			}
+		}
+		if entry.UpdatedAt.Before(entry.CreatedAt) {
+			entry.UpdatedAt = entry.CreatedAt
+		}
+		if entry.UpdatedAt.After(feed.UpdatedAt) {
+			feed.UpdatedAt = entry.UpdatedAt
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	sort.SliceStable(feed.Entries, func(a, b int) bool {
+		if !feed.Entries[a].CreatedAt.Equal(feed.Entries[b].CreatedAt) {
+			return feed.Entries[a].CreatedAt.After(feed.Entries[b].CreatedAt)
+		}
+		return feed.Entries[a].ID < feed.Entries[b].ID
+	})
+
+	return feed, nil
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticMemberIndex is a MemberIndexSource returning fixed members
+type staticMemberIndex []domain.IndexedMember
+
+func (m staticMemberIndex) ListIndexedMembers(ctx context.Context, containerID string) ([]domain.IndexedMember, error) {
+	return m, nil
+}
+
+func TestContainerService_GetContainerFeed(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("orders members by creation date with indexed titles", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "trips", "", domain.BasicContainer)
+		container.SetTitle("Trips")
+		container.Members = []string{"lisbon", "porto", "2024"}
+		mockRepo.On("GetContainer", ctx, "trips").Return(container, nil)
+
+		service.SetMemberIndex(staticMemberIndex{
+			{ID: "lisbon", Type: "Resource", CreatedAt: base, UpdatedAt: base},
+			{ID: "porto", Type: "Resource", CreatedAt: base.Add(time.Hour), UpdatedAt: base.Add(time.Hour)},
+			{ID: "2024", Type: "Container", CreatedAt: base.Add(-time.Hour), UpdatedAt: base.Add(-time.Hour)},
+		})
+		index := infrastructure.NewMemorySearchIndex()
+		require.NoError(t, index.Index(ctx, domain.SearchDocument{ID: "lisbon", Type: domain.SearchTypeResource, Title: "Trip to Lisbon"}))
+		service.SetSearchIndex(index)
+
+		feed, err := service.GetContainerFeed(ctx, "trips")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Trips", feed.Title)
+		require.Len(t, feed.Entries, 3)
+		assert.Equal(t, "porto", feed.Entries[0].ID)
+		assert.Equal(t, "porto", feed.Entries[0].Title)
+		assert.Equal(t, "lisbon", feed.Entries[1].ID)
+		assert.Equal(t, "Trip to Lisbon", feed.Entries[1].Title)
+		assert.Equal(t, base, feed.Entries[1].CreatedAt)
+		assert.Equal(t, "2024", feed.Entries[2].ID)
+		assert.Equal(t, "Container", feed.Entries[2].Type)
+	})
+
+	t.Run("falls back to container timestamps for unindexed members", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "notes", "", domain.BasicContainer)
+		container.Members = []string{"draft"}
+		mockRepo.On("GetContainer", ctx, "notes").Return(container, nil)
+
+		feed, err := service.GetContainerFeed(ctx, "notes")
+		require.NoError(t, err)
+
+		assert.Equal(t, "notes", feed.Title)
+		require.Len(t, feed.Entries, 1)
+		assert.Equal(t, "draft", feed.Entries[0].Title)
+		assert.Equal(t, domain.SearchTypeResource, feed.Entries[0].Type)
+		assert.False(t, feed.Entries[0].CreatedAt.IsZero())
+	})
+
+	t.Run("reports missing containers", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("GetContainer", ctx, "missing").Return(nil, domain.ErrResourceNotFound)
+
+		_, err := service.GetContainerFeed(ctx, "missing")
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -60,12 +61,7 @@ func GetDefaultSort() SortOptions {
 // TestFilteringByMemberType tests filtering container members by type
 func TestFilteringByMemberType(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create parent container
 	parentID := "parent-container"
@@ -77,14 +73,14 @@ func TestFilteringByMemberType(t *testing.T) {
 		childID := fmt.Sprintf("child-container-%d", i)
 		_, err := service.CreateContainer(ctx, childID, parentID, domain.BasicContainer)
 		require.NoError(t, err)
-		err = service.AddResource(ctx, parentID, childID)
+		err = storeAndAddMember(ctx, service, containerRepo, parentID, childID)
 		require.NoError(t, err)
 	}
 
 	// Add regular resources
 	for i := 0; i < 10; i++ {
 		resourceID := fmt.Sprintf("resource-%d", i)
-		err := service.AddResource(ctx, parentID, resourceID)
+		err := storeAndAddMember(ctx, service, containerRepo, parentID, resourceID)
 		require.NoError(t, err)
 	}
 
@@ -106,12 +102,7 @@ func TestFilteringByMemberType(t *testing.T) {
 // TestFilteringByContentType tests filtering by content type
 func TestFilteringByContentType(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "content-type-test"
@@ -130,7 +121,7 @@ func TestFilteringByContentType(t *testing.T) {
 	for _, contentType := range contentTypes {
 		for j := 0; j < 3; j++ {
 			resourceID := fmt.Sprintf("%s-resource-%d", strings.ReplaceAll(contentType, "/", "-"), j)
-			err := service.AddResource(ctx, containerID, resourceID)
+			err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 			require.NoError(t, err)
 		}
 	}
@@ -147,12 +138,7 @@ func TestFilteringByContentType(t *testing.T) {
 // TestFilteringByNamePattern tests filtering by name pattern
 func TestFilteringByNamePattern(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "name-pattern-test"
@@ -173,7 +159,7 @@ func TestFilteringByNamePattern(t *testing.T) {
 	for _, pattern := range patterns {
 		for i := 0; i < pattern.count; i++ {
 			resourceID := fmt.Sprintf("%s-%03d", pattern.prefix, i)
-			err := service.AddResource(ctx, containerID, resourceID)
+			err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 			require.NoError(t, err)
 		}
 	}
@@ -201,12 +187,7 @@ func TestFilteringByNamePattern(t *testing.T) {
 // TestFilteringByDateRange tests filtering by creation date range
 func TestFilteringByDateRange(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "date-range-test"
@@ -217,7 +198,7 @@ func TestFilteringByDateRange(t *testing.T) {
 	resourceCount := 20
 	for i := 0; i < resourceCount; i++ {
 		resourceID := fmt.Sprintf("resource-%03d", i)
-		err := service.AddResource(ctx, containerID, resourceID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 		require.NoError(t, err)
 
 		// In a real implementation, we might add a small delay or mock timestamps
@@ -236,12 +217,7 @@ func TestFilteringByDateRange(t *testing.T) {
 // TestSortingByName tests sorting container members by name
 func TestSortingByName(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "name-sort-test"
@@ -258,7 +234,7 @@ func TestSortingByName(t *testing.T) {
 	}
 
 	for _, name := range names {
-		err := service.AddResource(ctx, containerID, name)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, name)
 		require.NoError(t, err)
 	}
 
@@ -284,12 +260,7 @@ func TestSortingByName(t *testing.T) {
 // TestSortingByCreationDate tests sorting by creation date
 func TestSortingByCreationDate(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "date-sort-test"
@@ -303,7 +274,7 @@ func TestSortingByCreationDate(t *testing.T) {
 	for i := 0; i < resourceCount; i++ {
 		resourceID := fmt.Sprintf("resource-%03d", i)
 		addedResources[i] = resourceID
-		err := service.AddResource(ctx, containerID, resourceID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 		require.NoError(t, err)
 
 		// Small delay to ensure different timestamps
@@ -325,12 +296,7 @@ func TestSortingByCreationDate(t *testing.T) {
 // TestSortingBySize tests sorting by resource size
 func TestSortingBySize(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "size-sort-test"
@@ -342,7 +308,7 @@ func TestSortingBySize(t *testing.T) {
 
 	for i, size := range sizes {
 		resourceID := fmt.Sprintf("resource-%d-bytes-%d", size, i)
-		err := service.AddResource(ctx, containerID, resourceID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 		require.NoError(t, err)
 	}
 
@@ -358,12 +324,7 @@ func TestSortingBySize(t *testing.T) {
 // TestCombinedFilteringAndSorting tests combining filters with sorting
 func TestCombinedFilteringAndSorting(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container
 	containerID := "combined-test"
@@ -374,14 +335,14 @@ func TestCombinedFilteringAndSorting(t *testing.T) {
 	// Documents
 	for i := 0; i < 5; i++ {
 		resourceID := fmt.Sprintf("document-%03d.txt", i)
-		err := service.AddResource(ctx, containerID, resourceID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 		require.NoError(t, err)
 	}
 
 	// Images
 	for i := 0; i < 3; i++ {
 		resourceID := fmt.Sprintf("image-%03d.jpg", i)
-		err := service.AddResource(ctx, containerID, resourceID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, resourceID)
 		require.NoError(t, err)
 	}
 
@@ -390,7 +351,7 @@ func TestCombinedFilteringAndSorting(t *testing.T) {
 		childID := fmt.Sprintf("subfolder-%d", i)
 		_, err := service.CreateContainer(ctx, childID, containerID, domain.BasicContainer)
 		require.NoError(t, err)
-		err = service.AddResource(ctx, containerID, childID)
+		err = storeAndAddMember(ctx, service, containerRepo, containerID, childID)
 		require.NoError(t, err)
 	}
 
@@ -461,3 +422,61 @@ func TestSortOptionsValidation(t *testing.T) {
 	assert.Equal(t, "asc", defaultSort.Direction)
 	assert.True(t, defaultSort.IsValid())
 }
+
+// newProjectedContainerService returns a container service whose committed events are applied
+// to a filesystem repository straight away, as the event dispatcher does in the server
+func newProjectedContainerService(t testing.TB) (*ContainerService, *infrastructure.FileSystemContainerRepository) {
+	t.Helper()
+	dir := t.TempDir()
+
+	indexer, err := infrastructure.NewSQLiteMembershipIndexer(filepath.Join(dir, "membership.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { indexer.Close() })
+
+	repo, err := infrastructure.NewFileSystemContainerRepository(dir, indexer)
+	require.NoError(t, err)
+
+	handler := NewContainerEventHandler(repo)
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return &projectingUnitOfWork{handler: handler}
+	}
+	return NewContainerService(repo, unitOfWorkFactory, infrastructure.NewContainerRDFConverter()), repo
+}
+
+// storeAndAddMember stores a plain resource and adds it to the container, since the repository
+// only accepts members that are already stored
+func storeAndAddMember(ctx context.Context, service *ContainerService, repo *infrastructure.FileSystemContainerRepository, containerID, memberID string) error {
+	resource := domain.NewResource(ctx, memberID, "text/plain", []byte(memberID))
+	if err := repo.Store(ctx, resource); err != nil {
+		return err
+	}
+	return service.AddResource(ctx, containerID, memberID, resource)
+}
+
+// projectingUnitOfWork hands committed events to an event handler synchronously
+type projectingUnitOfWork struct {
+	handler pericarpdomain.EventHandler
+	events  []pericarpdomain.Event
+}
+
+func (u *projectingUnitOfWork) RegisterEvents(events []pericarpdomain.Event) {
+	u.events = append(u.events, events...)
+}
+
+func (u *projectingUnitOfWork) Commit(ctx context.Context) ([]pericarpdomain.Envelope, error) {
+	envelopes := make([]pericarpdomain.Envelope, 0, len(u.events))
+	for _, event := range u.events {
+		envelope := &mockEnvelope{event: event, timestamp: time.Now(), metadata: map[string]interface{}{}}
+		if err := u.handler.Handle(ctx, envelope); err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, envelope)
+	}
+	u.events = nil
+	return envelopes, nil
+}
+
+func (u *projectingUnitOfWork) Rollback() error {
+	u.events = nil
+	return nil
+}
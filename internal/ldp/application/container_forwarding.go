@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// maxForwardHops bounds how many successive moves are followed when resolving an old ID
+const maxForwardHops = 16
+
+// SetContainerForwarding sets where container moves are recorded and how long a moved
+// container's old ID keeps redirecting to its new one
+func (s *ContainerService) SetContainerForwarding(forwarding domain.ContainerForwarding, retention time.Duration) {
+	s.forwarding = forwarding
+	s.forwardRetention = retention
+}
+
+// RecordContainerMove leaves a forward from a container's former ID to its new ID, so requests
+// for the old URI can be redirected until the retention period passes
+func (s *ContainerService) RecordContainerMove(ctx context.Context, fromID, toID string) error {
+	if s.forwarding == nil || s.forwardRetention <= 0 || fromID == toID {
+		return nil
+	}
+
+	movedAt := time.Now()
+	forward := domain.ContainerForward{
+		FromID:    fromID,
+		ToID:      toID,
+		MovedAt:   movedAt,
+		ExpiresAt: movedAt.Add(s.forwardRetention),
+	}
+	if err := s.forwarding.RecordForward(ctx, forward); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to record container forward",
+		).WithOperation("RecordContainerMove").WithContext("fromID", fromID).WithContext("toID", toID)
+	}
+	return nil
+}
+
+// ResolveMovedContainer returns the current ID of a container that was moved away from
+// containerID, following successive moves. It reports false once the forward has expired or
+// when the container it leads to no longer exists.
+func (s *ContainerService) ResolveMovedContainer(ctx context.Context, containerID string) (string, bool) {
+	if s.forwarding == nil {
+		return "", false
+	}
+
+	now := time.Now()
+	current := containerID
+	for hop := 0; hop < maxForwardHops; hop++ {
+		forward, found, err := s.forwarding.LookupForward(ctx, current, now)
+		if err != nil {
+			fmt.Printf("Warning: failed to look up forward for container %s: %v\n", current, err)
+			return "", false
+		}
+		if !found || forward.ToID == containerID {
+			return "", false
+		}
+		current = forward.ToID
+
+		exists, err := s.containerRepo.ContainerExists(ctx, current)
+		if err != nil {
+			fmt.Printf("Warning: failed to check forwarded container %s: %v\n", current, err)
+			return "", false
+		}
+		if exists {
+			return current, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_ResolveMovedContainer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("follows successive moves to the current container", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetContainerForwarding(infrastructure.NewMemoryContainerForwarding(), time.Hour)
+		mockRepo.On("ContainerExists", mock.Anything, "pictures").Return(false, nil)
+		mockRepo.On("ContainerExists", mock.Anything, "gallery").Return(true, nil)
+
+		require.NoError(t, service.RecordContainerMove(ctx, "photos", "pictures"))
+		require.NoError(t, service.RecordContainerMove(ctx, "pictures", "gallery"))
+
+		toID, moved := service.ResolveMovedContainer(ctx, "photos")
+		assert.True(t, moved)
+		assert.Equal(t, "gallery", toID)
+	})
+
+	t.Run("reverts to not found when the target was deleted", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetContainerForwarding(infrastructure.NewMemoryContainerForwarding(), time.Hour)
+		mockRepo.On("ContainerExists", mock.Anything, "pictures").Return(false, nil)
+
+		require.NoError(t, service.RecordContainerMove(ctx, "photos", "pictures"))
+
+		_, moved := service.ResolveMovedContainer(ctx, "photos")
+		assert.False(t, moved)
+	})
+
+	t.Run("stops forwarding after the retention period", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+		forwarding := infrastructure.NewMemoryContainerForwarding()
+		service.SetContainerForwarding(forwarding, time.Hour)
+
+		require.NoError(t, forwarding.RecordForward(ctx, domain.ContainerForward{
+			FromID:    "photos",
+			ToID:      "pictures",
+			MovedAt:   time.Now().Add(-2 * time.Hour),
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}))
+
+		_, moved := service.ResolveMovedContainer(ctx, "photos")
+		assert.False(t, moved)
+	})
+
+	t.Run("does not loop when a container moves back", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetContainerForwarding(infrastructure.NewMemoryContainerForwarding(), time.Hour)
+		mockRepo.On("ContainerExists", mock.Anything, mock.Anything).Return(false, nil)
+
+		require.NoError(t, service.RecordContainerMove(ctx, "photos", "pictures"))
+		require.NoError(t, service.RecordContainerMove(ctx, "pictures", "photos"))
+
+		_, moved := service.ResolveMovedContainer(ctx, "photos")
+		assert.False(t, moved)
+	})
+
+	t.Run("records nothing without forwarding configured", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+
+		require.NoError(t, service.RecordContainerMove(ctx, "photos", "pictures"))
+		_, moved := service.ResolveMovedContainer(ctx, "photos")
+		assert.False(t, moved)
+	})
+}
@@ -0,0 +1,207 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetContainerInheritableMetadata sets the metadata members inherit from a container and whether
+// members moved into it re-inherit that metadata (inheritOnMove) or preserve what they had
+func (s *ContainerService) SetContainerInheritableMetadata(ctx context.Context, containerID string, values map[string]interface{}, inheritOnMove bool) error {
+	if err := domain.ValidateInheritableMetadata(values); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrInvalidResource.Code,
+			"invalid inheritable metadata",
+		).WithOperation("SetContainerInheritableMetadata").WithContext("containerID", containerID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerInheritableMetadata").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerInheritableMetadata").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.SetInheritableMetadata(values, inheritOnMove)
+
+	unitOfWork := s.unitOfWorkFactory()
+	events := concreteContainer.UncommittedEvents()
+	if len(events) > 0 {
+		unitOfWork.RegisterEvents(events)
+	}
+
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerInheritableMetadata").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.ClearEvents()
+	return nil
+}
+
+// InheritedMetadata returns the metadata a resource created in the container should carry,
+// including the bookkeeping needed to replace it if the resource is later moved
+func (s *ContainerService) InheritedMetadata(ctx context.Context, containerID string) (map[string]interface{}, error) {
+	s.mu.RLock()
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("InheritedMetadata").WithContext("containerID", containerID)
+	}
+
+	return domain.InheritedResourceMetadata(containerID, domain.InheritableMetadata(container.GetMetadata())), nil
+}
+
+// MoveResource moves a resource's membership from one container to another. When the target
+// container inherits on move, the resource's previously inherited metadata is replaced with the
+// target's and the resource is stored; otherwise its metadata is preserved. The caller needs
+// write permission on the target container.
+func (s *ContainerService) MoveResource(ctx context.Context, resourceID, sourceContainerID, targetContainerID string, resource domain.Resource) error {
+	return s.MoveResourceWithOptions(ctx, resourceID, sourceContainerID, targetContainerID, resource, MoveResourceOptions{})
+}
+
+// MoveResourceWithOptions moves a resource like MoveResource and, when asked to, rewrites the
+// references other RDF resources in the pod hold to it. The move is undone if the rewrite fails.
+func (s *ContainerService) MoveResourceWithOptions(ctx context.Context, resourceID, sourceContainerID, targetContainerID string, resource domain.Resource, options MoveResourceOptions) error {
+	if sourceContainerID == targetContainerID {
+		return nil
+	}
+
+	authorizer := s.writeAuthorizer
+	if authorizer == nil {
+		authorizer = AllowAllContainerWrites{}
+	}
+	if !authorizer.CanWriteContainer(ctx, targetContainerID) {
+		return domain.ErrAccessDenied.WithOperation("MoveResource").WithContext("containerID", targetContainerID)
+	}
+
+	s.mu.RLock()
+	target, err := s.containerRepo.GetContainer(ctx, targetContainerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"target container not found",
+		).WithOperation("MoveResource").WithContext("containerID", targetContainerID)
+	}
+
+	if err := s.RemoveResource(ctx, sourceContainerID, resourceID); err != nil {
+		return err
+	}
+
+	// Re-inherited metadata is stored before the resource joins the target, so a failed store
+	// leaves it where it was
+	var original map[string]interface{}
+	if domain.InheritOnMoveEnabled(target.GetMetadata()) {
+		original = copyMetadata(resource.GetMetadata())
+		domain.ReapplyInheritedMetadata(resource, targetContainerID, domain.InheritableMetadata(target.GetMetadata()))
+		if err := s.storeMovedResource(ctx, resource); err != nil {
+			restoreMetadata(resource, original)
+			if restoreErr := s.AddResource(ctx, sourceContainerID, resourceID, resource); restoreErr != nil {
+				fmt.Printf("Warning: failed to restore membership of %s in %s: %v\n", resourceID, sourceContainerID, restoreErr)
+			}
+			return err
+		}
+	}
+
+	undo := func() {
+		if original != nil {
+			restoreMetadata(resource, original)
+			if restoreErr := s.storeMovedResource(ctx, resource); restoreErr != nil {
+				fmt.Printf("Warning: failed to restore metadata of %s: %v\n", resourceID, restoreErr)
+			}
+		}
+		if restoreErr := s.AddResource(ctx, sourceContainerID, resourceID, resource); restoreErr != nil {
+			fmt.Printf("Warning: failed to restore membership of %s in %s: %v\n", resourceID, sourceContainerID, restoreErr)
+		}
+	}
+
+	if err := s.AddResource(ctx, targetContainerID, resourceID, resource); err != nil {
+		// Restore the original membership so the resource is not orphaned
+		undo()
+		return err
+	}
+
+	if options.RewriteReferences {
+		if err := s.rewritePodReferences(ctx, targetContainerID, options.OldIRI, options.NewIRI); err != nil {
+			// Move the resource back so its references and membership stay consistent
+			if restoreErr := s.RemoveResource(ctx, targetContainerID, resourceID); restoreErr != nil {
+				fmt.Printf("Warning: failed to undo move of %s to %s: %v\n", resourceID, targetContainerID, restoreErr)
+			} else {
+				undo()
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeMovedResource stores a moved resource whose inherited metadata was replaced
+func (s *ContainerService) storeMovedResource(ctx context.Context, resource domain.Resource) error {
+	if s.resourceRepo == nil {
+		return domain.WrapStorageError(
+			fmt.Errorf("no resource repository configured"),
+			domain.ErrStorageOperation.Code,
+			"moved resource cannot be stored",
+		).WithOperation("MoveResource").WithContext("resourceID", resource.ID())
+	}
+	if err := s.resourceRepo.Store(ctx, resource); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to store moved resource",
+		).WithOperation("MoveResource").WithContext("resourceID", resource.ID())
+	}
+	return nil
+}
+
+// copyMetadata returns a shallow copy of resource metadata
+func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		copied[key] = value
+	}
+	return copied
+}
+
+// restoreMetadata puts back the metadata a resource had before it was re-inherited
+func restoreMetadata(resource domain.Resource, original map[string]interface{}) {
+	metadata := resource.GetMetadata()
+	for key := range metadata {
+		if _, kept := original[key]; !kept {
+			delete(metadata, key)
+		}
+	}
+	for key, value := range original {
+		resource.SetMetadata(key, value)
+	}
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_MoveResource(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(inheritOnMove bool) (*ContainerService, *TestMockContainerRepository, *memoryResourceRepository, domain.Resource) {
+		service, mockRepo, _ := setupContainerServiceTest()
+
+		resource := domain.NewResource(ctx, "report", "text/plain", []byte("q3"))
+		domain.ApplyInheritedMetadata(resource, "inbox", map[string]interface{}{"project": "apollo"})
+
+		inbox := domain.NewContainer(ctx, "inbox", "", domain.BasicContainer)
+		require.NoError(t, inbox.AddMember(ctx, resource))
+		inbox.SetMembershipEvents(false)
+		archive := domain.NewContainer(ctx, "archive", "", domain.BasicContainer)
+		archive.SetInheritableMetadata(map[string]interface{}{"retention": "P10Y"}, inheritOnMove)
+		archive.SetMembershipEvents(false)
+
+		mockRepo.On("GetContainer", mock.Anything, "inbox").Return(inbox, nil)
+		mockRepo.On("GetContainer", mock.Anything, "archive").Return(archive, nil)
+		mockRepo.On("GetContainer", mock.Anything, "report").Return(nil, domain.ErrResourceNotFound)
+		mockRepo.On("RemoveMember", mock.Anything, "inbox", "report").Return(nil)
+		mockRepo.On("AddMember", mock.Anything, mock.Anything, "report").Return(nil)
+
+		resourceRepo := &memoryResourceRepository{resources: map[string]domain.Resource{}}
+		service.SetResourceRepository(resourceRepo)
+		return service, mockRepo, resourceRepo, resource
+	}
+
+	t.Run("stores the resource with the target's inherited metadata", func(t *testing.T) {
+		service, mockRepo, resourceRepo, resource := setup(true)
+
+		require.NoError(t, service.MoveResource(ctx, "report", "inbox", "archive", resource))
+
+		stored, ok := resourceRepo.resources["report"]
+		require.True(t, ok, "the re-inherited resource is stored")
+		metadata := stored.GetMetadata()
+		assert.Equal(t, "P10Y", metadata["retention"])
+		assert.NotContains(t, metadata, "project")
+		assert.Equal(t, "archive", metadata[domain.InheritedFromKey])
+		mockRepo.AssertCalled(t, "AddMember", mock.Anything, "archive", "report")
+	})
+
+	t.Run("preserves metadata without storing when the target does not inherit on move", func(t *testing.T) {
+		service, _, resourceRepo, resource := setup(false)
+
+		require.NoError(t, service.MoveResource(ctx, "report", "inbox", "archive", resource))
+
+		assert.Empty(t, resourceRepo.resources)
+		assert.Equal(t, "apollo", resource.GetMetadata()["project"])
+	})
+
+	t.Run("leaves the resource in place when it cannot be stored", func(t *testing.T) {
+		service, mockRepo, resourceRepo, resource := setup(true)
+		resourceRepo.failStore = "report"
+
+		err := service.MoveResource(ctx, "report", "inbox", "archive", resource)
+
+		require.Error(t, err)
+		assert.Equal(t, "apollo", resource.GetMetadata()["project"])
+		assert.NotContains(t, resource.GetMetadata(), "retention")
+		mockRepo.AssertNotCalled(t, "AddMember", mock.Anything, "archive", "report")
+	})
+
+	t.Run("refuses targets the caller cannot write", func(t *testing.T) {
+		service, mockRepo, _, resource := setup(true)
+		service.SetWriteAuthorizer(denyContainerWrites{"archive": true})
+
+		err := service.MoveResource(ctx, "report", "inbox", "archive", resource)
+
+		assert.True(t, domain.IsAccessDenied(err))
+		mockRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, "inbox", "report")
+	})
+}
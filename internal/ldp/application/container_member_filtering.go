@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+)
+
+// FilteredMemberLister filters, sorts and pages a container's members in the membership index,
+// returning the page with the container's total member count and the count matching the filter
+type FilteredMemberLister interface {
+	ListMembersFiltered(ctx context.Context, containerID string, options domain.ListingOptions) ([]infrastructure.MemberInfo, int, int, error)
+}
+
+// recordMemberDetails stores a new member's content type and size in the membership index so
+// enhanced listings can filter and sort on them
+func (s *ContainerService) recordMemberDetails(ctx context.Context, containerID string, resource domain.Resource) {
+	recorder, ok := s.containerRepo.(domain.MemberDetailsRecorder)
+	if !ok {
+		return
+	}
+
+	member := domain.IndexedMember{
+		ID:          resource.ID(),
+		ContentType: resource.GetContentType(),
+		Size:        int64(resource.GetSize()),
+		UpdatedAt:   time.Now(),
+	}
+	if err := recorder.RecordMemberDetails(ctx, containerID, member); err != nil {
+		fmt.Printf("Warning: failed to record details of member %s: %v\n", resource.ID(), err)
+	}
+}
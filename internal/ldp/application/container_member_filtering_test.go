@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// filteringContainerRepository answers filtered listings and records member details, as a
+// repository backed by the membership index would
+type filteringContainerRepository struct {
+	*TestMockContainerRepository
+	members  []infrastructure.MemberInfo
+	total    int
+	filtered int
+	options  []domain.ListingOptions
+	details  []domain.IndexedMember
+}
+
+func (r *filteringContainerRepository) ListMembersFiltered(ctx context.Context, containerID string, options domain.ListingOptions) ([]infrastructure.MemberInfo, int, int, error) {
+	r.options = append(r.options, options)
+	return r.members, r.total, r.filtered, nil
+}
+
+func (r *filteringContainerRepository) RecordMemberDetails(ctx context.Context, containerID string, member domain.IndexedMember) error {
+	r.details = append(r.details, member)
+	return nil
+}
+
+func TestContainerService_ListContainerMembersEnhanced_Filtered(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &TestMockContainerRepository{}
+	repo := &filteringContainerRepository{
+		TestMockContainerRepository: mockRepo,
+		members:                     []infrastructure.MemberInfo{{ID: "notes.txt", Type: infrastructure.ResourceTypeResource, ContentType: "text/plain", Size: 12}},
+		total:                       5,
+		filtered:                    2,
+	}
+	service := NewContainerService(repo, func() pericarpdomain.UnitOfWork { return &MockUnitOfWork{} }, infrastructure.NewContainerRDFConverter())
+	mockRepo.On("ContainerExists", ctx, "docs").Return(true, nil)
+
+	options := domain.ListingOptions{
+		Pagination: domain.PaginationOptions{Limit: 1, Offset: 0},
+		Filter:     domain.FilterOptions{ContentType: "text/*"},
+		Sort:       domain.SortOptions{Field: "createdAt", Direction: "desc"},
+	}
+	listing, err := service.ListContainerMembersEnhanced(ctx, "docs", options)
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.ListingOptions{options}, repo.options)
+	assert.Equal(t, repo.members, listing.Members)
+	assert.Equal(t, 5, listing.TotalCount)
+	assert.Equal(t, 2, listing.FilteredCount)
+	mockRepo.AssertNotCalled(t, "ListMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContainerService_AddResource_RecordsMemberDetails(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &TestMockContainerRepository{}
+	mockUoW := &MockUnitOfWork{}
+	repo := &filteringContainerRepository{TestMockContainerRepository: mockRepo}
+	service := NewContainerService(repo, func() pericarpdomain.UnitOfWork { return mockUoW }, infrastructure.NewContainerRDFConverter())
+
+	mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+	mockUoW.On("RegisterEvents", mock.Anything).Return()
+	mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	resource := domain.NewResource(ctx, "notes.txt", "text/plain", []byte("meeting notes"))
+	require.NoError(t, service.AddResource(ctx, "docs", "notes.txt", resource))
+
+	require.Len(t, repo.details, 1)
+	assert.Equal(t, "notes.txt", repo.details[0].ID)
+	assert.Equal(t, "text/plain", repo.details[0].ContentType)
+	assert.Equal(t, int64(13), repo.details[0].Size)
+	assert.False(t, repo.details[0].UpdatedAt.IsZero())
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_ListContainerMembersWithMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("describes the page's members with titles from the search index", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+		container.Members = []string{"m0000", "m0001", "m0002"}
+		mockRepo.On("GetContainer", ctx, "photos").Return(container, nil)
+		service.SetMemberIndex(&pagedMemberIndex{members: exportMembers(3)})
+		index := infrastructure.NewMemorySearchIndex()
+		require.NoError(t, index.Index(ctx, domain.SearchDocument{ID: "m0001", Type: domain.SearchTypeResource, Title: "Beach"}))
+		service.SetSearchIndex(index)
+
+		result, err := service.ListContainerMembersWithMetadata(ctx, "photos", "application/n-triples", "http://example.org/", domain.PaginationOptions{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+
+		output := string(result)
+		assert.Contains(t, output, "<http://example.org/photos> <http://www.w3.org/ns/ldp#contains> <http://example.org/m0000> .\n")
+		assert.Contains(t, output, `<http://example.org/m0001> <http://purl.org/dc/terms/title> "Beach" .`+"\n")
+		assert.Contains(t, output, "<http://example.org/m0002> <http://purl.org/dc/terms/created> ")
+		assert.NotContains(t, output, "<http://example.org/m0000> <http://purl.org/dc/terms/created> ", "members outside the page are not described")
+	})
+
+	t.Run("caps the members described inline", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("GetContainer", ctx, "photos").Return(domain.NewContainer(ctx, "photos", "", domain.BasicContainer), nil)
+		index := &pagedMemberIndex{members: exportMembers(500)}
+		service.SetMemberIndex(index)
+
+		_, err := service.ListContainerMembersWithMetadata(ctx, "photos", "text/turtle", "http://example.org/", domain.PaginationOptions{Limit: 1000})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.PaginationOptions{{Limit: maxInlineMemberMetadata}}, index.pages)
+	})
+
+	t.Run("rejects formats that are not RDF", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("GetContainer", ctx, "photos").Return(domain.NewContainer(ctx, "photos", "", domain.BasicContainer), nil)
+
+		_, err := service.ListContainerMembersWithMetadata(ctx, "photos", "text/csv", "http://example.org/", domain.GetDefaultPagination())
+		storageErr, ok := domain.GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrInvalidFormat.Code, storageErr.Code)
+	})
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+)
+
+// memberTypeBatchSize is how many indexed members are read at a time when looking up types
+const memberTypeBatchSize = 1000
+
+// memberTypes looks up the types the membership index recorded for the given members:
+// "Container", "Resource" or "NonRDFSource". Members the index does not know are left out.
+func (s *ContainerService) memberTypes(ctx context.Context, containerID string, memberIDs []string) map[string]infrastructure.ResourceType {
+	types := make(map[string]infrastructure.ResourceType, len(memberIDs))
+	if s.memberIndex == nil || len(memberIDs) == 0 {
+		return types
+	}
+
+	wanted := make(map[string]bool, len(memberIDs))
+	for _, memberID := range memberIDs {
+		wanted[memberID] = true
+	}
+
+	for offset := 0; len(wanted) > 0; {
+		page, err := s.indexedMembersPage(ctx, containerID, domain.PaginationOptions{Limit: memberTypeBatchSize, Offset: offset})
+		if err != nil {
+			fmt.Printf("Warning: failed to read member types of container %s: %v\n", containerID, err)
+			return types
+		}
+
+		for _, member := range page {
+			if wanted[member.ID] && member.Type != "" {
+				types[member.ID] = infrastructure.ResourceType(member.Type)
+				delete(wanted, member.ID)
+			}
+		}
+
+		if len(page) < memberTypeBatchSize {
+			break
+		}
+		offset += len(page)
+	}
+
+	return types
+}
+
+// memberType returns a member's type from types, defaulting to "Resource" for members whose
+// type is not known
+func memberType(types map[string]infrastructure.ResourceType, memberID string) infrastructure.ResourceType {
+	if memberType, ok := types[memberID]; ok {
+		return memberType
+	}
+	return infrastructure.ResourceTypeResource
+}
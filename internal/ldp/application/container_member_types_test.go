@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_GenerateStructureInfo_MemberTypes(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &TestMockContainerRepository{}
+	service := NewContainerService(mockRepo, func() pericarpdomain.UnitOfWork { return &MockUnitOfWork{} }, infrastructure.NewContainerRDFConverter())
+	service.SetMemberIndex(staticMemberIndex{
+		{ID: "archive", Type: "Container"},
+		{ID: "card.ttl", Type: "Resource"},
+		{ID: "photo.jpg", Type: "NonRDFSource"},
+	})
+
+	root := domain.NewContainer(ctx, "root", "", domain.BasicContainer)
+	mockRepo.On("GetContainer", mock.Anything, "root").Return(root, nil)
+	mockRepo.On("ListMembers", mock.Anything, "root", mock.AnythingOfType("domain.PaginationOptions")).
+		Return([]string{"card.ttl", "photo.jpg", "archive", "unindexed"}, nil)
+
+	info, err := service.GenerateStructureInfo(ctx, "root", 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, []MemberInfo{
+		{ID: "card.ttl", Type: "Resource"},
+		{ID: "photo.jpg", Type: "NonRDFSource"},
+		{ID: "archive", Type: "Container"},
+		{ID: "unindexed", Type: "Resource"},
+	}, info.Members)
+}
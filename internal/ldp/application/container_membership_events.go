@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// CreateContainerOptions holds creation-time settings for a container
+type CreateContainerOptions struct {
+	// DisableMembershipEvents stores membership changes of the container without emitting
+	// member added/removed events. Intended for high-churn system containers such as trash or
+	// index containers; see SetContainerMembershipEvents for the audit implications.
+	DisableMembershipEvents bool
+	// Membership holds the membership predicates a Direct or Indirect container is created with
+	Membership MembershipRelations
+}
+
+// SetContainerMembershipEvents enables or disables membership events for a container. While
+// disabled, AddResource and RemoveResource still update the stored membership but the changes
+// are not recorded in the event log: there is no audit trail of who was added or removed, the
+// membership cannot be rebuilt by replaying events, and event subscribers such as notification
+// channels are not told about them. The toggle itself is recorded as a container update event.
+func (s *ContainerService) SetContainerMembershipEvents(ctx context.Context, containerID string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerMembershipEvents").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerMembershipEvents").WithContext("containerID", containerID)
+	}
+
+	if domain.MembershipEventsEnabled(concreteContainer.GetMetadata()) == enabled {
+		return nil
+	}
+
+	// Only the toggle event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetMembershipEvents(enabled)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerMembershipEvents").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// addMemberWithoutEvents stores a new member directly in the repository for a container that
+// has membership events disabled, discarding the events raised on the loaded container
+func (s *ContainerService) addMemberWithoutEvents(ctx context.Context, container *domain.Container, resourceID string) error {
+	container.MarkEventsAsCommitted()
+
+	if err := s.containerRepo.AddMember(ctx, container.ID(), resourceID); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to add member",
+		).WithOperation("AddResource").WithContext("containerID", container.ID()).WithContext("resourceID", resourceID)
+	}
+	return nil
+}
+
+// removeMemberWithoutEvents removes a member directly from the repository when the container
+// has membership events disabled. It reports false, leaving the member in place, when the
+// container still emits events and the removal must go through the event log.
+func (s *ContainerService) removeMemberWithoutEvents(ctx context.Context, containerID, resourceID string) (bool, error) {
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return false, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("RemoveResource").WithContext("containerID", containerID)
+	}
+
+	if domain.MembershipEventsEnabled(container.GetMetadata()) {
+		return false, nil
+	}
+
+	if err := s.containerRepo.RemoveMember(ctx, containerID, resourceID); err != nil {
+		return false, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to remove member",
+		).WithOperation("RemoveResource").WithContext("containerID", containerID).WithContext("resourceID", resourceID)
+	}
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_MembershipEventsDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("adds members without committing events", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "trash", "", domain.BasicContainer)
+		container.SetMembershipEvents(false)
+		mockRepo.On("GetContainer", ctx, "trash").Return(container, nil)
+		mockRepo.On("AddMember", ctx, "trash", "doc").Return(nil)
+
+		resource := domain.NewResource(ctx, "doc", "text/plain", []byte("hello"))
+		require.NoError(t, service.AddResource(ctx, "trash", "doc", resource))
+
+		mockRepo.AssertCalled(t, "AddMember", ctx, "trash", "doc")
+		mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+		assert.Empty(t, container.UncommittedEvents())
+	})
+
+	t.Run("removes members without committing events", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "trash", "", domain.BasicContainer)
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, "doc", "text/plain", []byte("hello"))))
+		container.SetMembershipEvents(false)
+		mockRepo.On("GetContainer", ctx, "trash").Return(container, nil)
+		mockRepo.On("GetContainer", ctx, "doc").Return(nil, domain.ErrResourceNotFound)
+		mockRepo.On("RemoveMember", ctx, "trash", "doc").Return(nil)
+
+		require.NoError(t, service.RemoveResource(ctx, "trash", "doc"))
+
+		mockRepo.AssertCalled(t, "RemoveMember", ctx, "trash", "doc")
+		mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("opts out at creation", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "index").Return(false, nil)
+
+		var registered []pericarpdomain.Event
+		mockUoW.On("RegisterEvents", mock.Anything).Run(func(args mock.Arguments) {
+			registered = append(registered, args.Get(0).([]pericarpdomain.Event)...)
+		}).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		container, err := service.CreateContainerWithOptions(ctx, "index", "", domain.BasicContainer, CreateContainerOptions{DisableMembershipEvents: true})
+		require.NoError(t, err)
+		assert.False(t, domain.MembershipEventsEnabled(container.GetMetadata()))
+
+		var toggled bool
+		for _, event := range registered {
+			if event.EventType() == "container."+domain.EventTypeContainerUpdated {
+				toggled = true
+			}
+		}
+		assert.True(t, toggled, "the opt-out is committed with the container's creation events")
+	})
+
+	t.Run("defaults to emitting events", func(t *testing.T) {
+		assert.True(t, domain.MembershipEventsEnabled(domain.NewContainer(ctx, "docs", "", domain.BasicContainer).GetMetadata()))
+	})
+}
@@ -0,0 +1,218 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MembershipRelations configures the membership triples of a Direct or Indirect container
+type MembershipRelations struct {
+	// MembershipResource is the subject of the container's membership triples
+	MembershipResource string `json:"ldp:membershipResource,omitempty"`
+	// HasMemberRelation is the predicate of the container's membership triples
+	HasMemberRelation string `json:"ldp:hasMemberRelation,omitempty"`
+	// InsertedContentRelation names the predicate an IndirectContainer's members use to state
+	// the object of their membership triple
+	InsertedContentRelation string `json:"ldp:insertedContentRelation,omitempty"`
+}
+
+// IsZero reports whether no membership relation is set
+func (r MembershipRelations) IsZero() bool {
+	return r == MembershipRelations{}
+}
+
+// applyMembershipRelations sets the membership relations of a container being created and checks
+// that its type has the predicates it requires. The membership resource policy is applied once
+// the container exists, when members are added.
+func applyMembershipRelations(container *domain.Container, relations MembershipRelations, operation string) error {
+	if !container.GetContainerType().HasMembershipTriples() {
+		if relations.IsZero() {
+			return nil
+		}
+		return domain.WrapStorageError(
+			fmt.Errorf("membership relations require a Direct or Indirect container"),
+			domain.ErrInvalidContainerType.Code,
+			"membership relations require a Direct or Indirect container",
+		).WithOperation(operation).WithContext("containerID", container.ID())
+	}
+
+	if relations.MembershipResource != "" {
+		container.SetMembershipResource(relations.MembershipResource)
+	}
+	if relations.HasMemberRelation != "" || relations.InsertedContentRelation != "" {
+		container.SetMembershipRelations(relations.HasMemberRelation, relations.InsertedContentRelation)
+	}
+
+	if err := domain.ValidateMembershipRelations(container); err != nil {
+		storageErr, _ := domain.GetStorageError(err)
+		return storageErr.WithOperation(operation)
+	}
+	return nil
+}
+
+// SetContainerMembershipRelations sets the ldp:hasMemberRelation of a Direct or Indirect container
+// and the ldp:insertedContentRelation of an IndirectContainer
+func (s *ContainerService) SetContainerMembershipRelations(ctx context.Context, containerID, hasMemberRelation, insertedContentRelation string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return domain.ErrResourceNotFound.WithOperation("SetContainerMembershipRelations").WithContext("containerID", containerID)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("SetContainerMembershipRelations").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok || !concreteContainer.GetContainerType().HasMembershipTriples() {
+		return domain.WrapStorageError(
+			fmt.Errorf("membership relations require a Direct or Indirect container"),
+			domain.ErrInvalidContainerType.Code,
+			"membership relations require a Direct or Indirect container",
+		).WithOperation("SetContainerMembershipRelations").WithContext("containerID", containerID)
+	}
+
+	if hasMemberRelation == "" || (concreteContainer.GetContainerType() == domain.IndirectContainer && insertedContentRelation == "") {
+		return domain.WrapStorageError(
+			fmt.Errorf("%s requires its membership predicates", concreteContainer.GetContainerType()),
+			domain.ErrMissingMembershipRelation.Code,
+			"membership predicates cannot be cleared",
+		).WithOperation("SetContainerMembershipRelations").WithContext("containerID", containerID)
+	}
+
+	if concreteContainer.GetHasMemberRelation() == hasMemberRelation && concreteContainer.GetInsertedContentRelation() == insertedContentRelation {
+		return nil
+	}
+
+	// Only the relation event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetMembershipRelations(hasMemberRelation, insertedContentRelation)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerMembershipRelations").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// ApplyMembershipTriple asserts the membership triple a Direct or Indirect container produces for
+// a new member. When the membership resource is a Turtle or N-Triples resource held by this server
+// the triple is appended to it; containers assert their own membership triples in their RDF
+// representation, and external membership resources are left to their owner. Other containers
+// produce no membership triple and return nil.
+func (s *ContainerService) ApplyMembershipTriple(ctx context.Context, containerID string, member domain.Resource) (*domain.MembershipTriple, error) {
+	s.mu.RLock()
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("ApplyMembershipTriple").WithContext("containerID", containerID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("ApplyMembershipTriple").WithContext("containerID", containerID)
+	}
+	if !container.GetContainerType().HasMembershipTriples() {
+		return nil, nil
+	}
+
+	triple, err := domain.NewMembershipTriple(container, "/resources/"+member.ID(), member.GetData(), member.GetContentType())
+	if err != nil {
+		storageErr, _ := domain.GetStorageError(err)
+		return nil, storageErr.WithOperation("ApplyMembershipTriple")
+	}
+
+	reference := triple.Subject
+	if domain.IsExternalReference(reference) || s.resourceRepo == nil {
+		return &triple, nil
+	}
+	isContainer, err := s.containerRepo.ContainerExists(ctx, domain.LocalReferenceID(reference))
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check membership resource",
+		).WithOperation("ApplyMembershipTriple").WithContext("containerID", containerID)
+	}
+	if isContainer {
+		return &triple, nil
+	}
+
+	if err := s.appendMembershipTriple(ctx, domain.LocalReferenceID(reference), triple); err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to update membership resource",
+		).WithOperation("ApplyMembershipTriple").WithContext("containerID", containerID).WithContext("membershipResource", reference)
+	}
+	return &triple, nil
+}
+
+// appendMembershipTriple adds a membership triple to a Turtle or N-Triples resource, emitting the
+// resource's update event. Resources already holding the triple are left alone.
+func (s *ContainerService) appendMembershipTriple(ctx context.Context, resourceID string, triple domain.MembershipTriple) error {
+	resource, err := s.resourceRepo.Retrieve(ctx, resourceID)
+	if err != nil {
+		return err
+	}
+
+	contentType := resource.GetContentType()
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "text/turtle", "application/n-triples":
+	default:
+		fmt.Printf("Warning: membership resource %s is %s, membership triple not stored\n", resourceID, contentType)
+		return nil
+	}
+
+	statement := triple.String()
+	data := resource.GetData()
+	if strings.Contains(string(data), statement) {
+		return nil
+	}
+	updated := append([]byte{}, data...)
+	if len(updated) > 0 && updated[len(updated)-1] != '\n' {
+		updated = append(updated, '\n')
+	}
+	updated = append(updated, statement+"\n"...)
+
+	// Only the membership update is registered; events left on a loaded resource were already committed
+	resource.ClearEvents()
+	resource.Update(ctx, updated, contentType)
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(resource.UncommittedEvents())
+	if err := s.resourceRepo.Store(ctx, resource); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return err
+	}
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return err
+	}
+
+	resource.ClearEvents()
+	return nil
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testLikesRelation = "http://example.org/vocab#likes"
+
+func TestContainerService_CreateContainer_MembershipRelations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects a direct container without membership predicates", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "likes").Return(false, nil)
+
+		_, err := service.CreateContainer(ctx, "likes", "", domain.DirectContainer)
+
+		assert.True(t, domain.IsMissingMembershipRelation(err))
+		mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("creates a direct container with its membership predicates", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "likes").Return(false, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		container, err := service.CreateContainerWithOptions(ctx, "likes", "", domain.DirectContainer, CreateContainerOptions{
+			Membership: MembershipRelations{MembershipResource: "/resources/profile", HasMemberRelation: testLikesRelation},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/resources/profile", container.GetMembershipResource())
+		assert.Equal(t, testLikesRelation, container.GetHasMemberRelation())
+	})
+
+	t.Run("rejects membership predicates on a basic container", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("ContainerExists", ctx, "docs").Return(false, nil)
+
+		_, err := service.CreateContainerWithOptions(ctx, "docs", "", domain.BasicContainer, CreateContainerOptions{
+			Membership: MembershipRelations{HasMemberRelation: testLikesRelation},
+		})
+
+		assert.True(t, domain.IsInvalidContainerType(err))
+	})
+}
+
+func TestContainerService_ApplyMembershipTriple(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*ContainerService, *TestMockContainerRepository, *MockUnitOfWork, *memoryResourceRepository) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		resourceRepo := &memoryResourceRepository{resources: map[string]domain.Resource{
+			"profile": domain.NewResource(ctx, "profile", "text/turtle", []byte("<> a <http://xmlns.com/foaf/0.1/Person> .")),
+		}}
+		service.SetResourceRepository(resourceRepo)
+		return service, mockRepo, mockUoW, resourceRepo
+	}
+
+	t.Run("appends the triple to the membership resource", func(t *testing.T) {
+		service, mockRepo, mockUoW, resourceRepo := setup()
+		container := domain.NewContainer(ctx, "likes", "alice", domain.DirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikesRelation, "")
+		mockRepo.On("GetContainer", ctx, "likes").Return(container, nil)
+		mockRepo.On("ContainerExists", ctx, "profile").Return(false, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		member := domain.NewResource(ctx, "like-1", "text/turtle", []byte("<> a <http://example.org/Like> ."))
+		triple, err := service.ApplyMembershipTriple(ctx, "likes", member)
+
+		require.NoError(t, err)
+		require.NotNil(t, triple)
+		assert.Equal(t, "/resources/like-1", triple.Object)
+		assert.Contains(t, string(resourceRepo.resources["profile"].GetData()),
+			"\n</resources/profile> <"+testLikesRelation+"> </resources/like-1> .\n")
+		mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+	})
+
+	t.Run("reports a member without the inserted content", func(t *testing.T) {
+		service, mockRepo, _, resourceRepo := setup()
+		container := domain.NewContainer(ctx, "topics", "alice", domain.IndirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikesRelation, "http://example.org/vocab#primaryTopic")
+		mockRepo.On("GetContainer", ctx, "topics").Return(container, nil)
+
+		_, err := service.ApplyMembershipTriple(ctx, "topics", domain.NewResource(ctx, "doc-1", "text/plain", []byte("notes")))
+
+		assert.True(t, domain.IsMissingInsertedContent(err))
+		assert.NotContains(t, string(resourceRepo.resources["profile"].GetData()), "doc-1")
+	})
+
+	t.Run("basic containers produce no triple", func(t *testing.T) {
+		service, mockRepo, _, _ := setup()
+		mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+
+		triple, err := service.ApplyMembershipTriple(ctx, "docs", domain.NewResource(ctx, "a", "text/plain", nil))
+
+		require.NoError(t, err)
+		assert.Nil(t, triple)
+	})
+}
@@ -0,0 +1,159 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MembershipResourcePolicy decides which ldp:membershipResource references Direct and Indirect
+// containers may use
+type MembershipResourcePolicy struct {
+	// Enforce requires local references to exist in the container's pod
+	Enforce bool
+	// AllowedExternal lists URI prefixes external references may start with; others are refused
+	AllowedExternal []string
+}
+
+// SetMembershipResourcePolicy sets the policy applied to Direct and Indirect container membership
+// resources
+func (s *ContainerService) SetMembershipResourcePolicy(policy MembershipResourcePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.membershipPolicy = policy
+}
+
+// SetContainerMembershipResource sets the ldp:membershipResource of a Direct or Indirect container after
+// checking the reference against the membership resource policy
+func (s *ContainerService) SetContainerMembershipResource(ctx context.Context, containerID, reference string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return domain.ErrResourceNotFound.WithOperation("SetContainerMembershipResource").WithContext("containerID", containerID)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("SetContainerMembershipResource").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok || !concreteContainer.GetContainerType().HasMembershipTriples() {
+		return domain.WrapStorageError(
+			fmt.Errorf("membership resource requires a Direct or Indirect container"),
+			domain.ErrInvalidContainerType.Code,
+			"membership resource requires a Direct or Indirect container",
+		).WithOperation("SetContainerMembershipResource").WithContext("containerID", containerID)
+	}
+
+	if concreteContainer.GetMembershipResource() == reference {
+		return nil
+	}
+
+	if err := s.checkMembershipReference(ctx, concreteContainer, reference); err != nil {
+		return err
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetMembershipResource(reference)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerMembershipResource").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// validateMembershipResource checks a Direct or Indirect container's stored membership resource
+// against the policy. Other containers pass.
+func (s *ContainerService) validateMembershipResource(ctx context.Context, container domain.ContainerResource) error {
+	if !container.GetContainerType().HasMembershipTriples() {
+		return nil
+	}
+	return s.checkMembershipReference(ctx, container, domain.MembershipResource(container.GetMetadata()))
+}
+
+// checkMembershipReference checks a membership resource reference for a container. Local
+// references must name a container or resource that exists, and a container must be in the
+// same pod; external references must match an allowed prefix. An empty reference passes.
+func (s *ContainerService) checkMembershipReference(ctx context.Context, container domain.ContainerResource, reference string) error {
+	if !s.membershipPolicy.Enforce || reference == "" {
+		return nil
+	}
+
+	invalid := func(reason string) error {
+		return domain.WrapStorageError(
+			fmt.Errorf("membership resource %s %s", reference, reason),
+			domain.ErrInvalidMembershipResource.Code,
+			"membership resource "+reason,
+		).WithOperation("ValidateMembershipResource").WithContext("containerID", container.ID()).WithContext("membershipResource", reference)
+	}
+
+	if domain.IsExternalReference(reference) {
+		for _, prefix := range s.membershipPolicy.AllowedExternal {
+			if prefix != "" && strings.HasPrefix(reference, prefix) {
+				return nil
+			}
+		}
+		return invalid("is not an allowed external reference")
+	}
+
+	id := domain.LocalReferenceID(reference)
+	if id == "" {
+		return invalid("is empty")
+	}
+
+	isContainer, err := s.containerRepo.ContainerExists(ctx, id)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check membership resource",
+		).WithOperation("ValidateMembershipResource").WithContext("containerID", container.ID())
+	}
+	if isContainer {
+		podPath, err := s.containerRepo.GetPath(ctx, container.ID())
+		if err != nil {
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to resolve container pod",
+			).WithOperation("ValidateMembershipResource").WithContext("containerID", container.ID())
+		}
+		targetPath, err := s.containerRepo.GetPath(ctx, id)
+		if err != nil || len(podPath) == 0 || len(targetPath) == 0 || podPath[0] != targetPath[0] {
+			return invalid("is outside the container's pod")
+		}
+		return nil
+	}
+
+	isResource, err := s.containerRepo.Exists(ctx, id)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check membership resource",
+		).WithOperation("ValidateMembershipResource").WithContext("containerID", container.ID())
+	}
+	if !isResource {
+		return invalid("does not exist")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMembershipResourceTest() (*ContainerService, *TestMockContainerRepository, *MockUnitOfWork) {
+	service, mockRepo, mockUoW := setupContainerServiceTest()
+	service.SetMembershipResourcePolicy(MembershipResourcePolicy{
+		Enforce:         true,
+		AllowedExternal: []string{"https://example.org/"},
+	})
+
+	mockRepo.On("GetPath", mock.Anything, "likes").Return([]string{"alice", "likes"}, nil)
+	mockRepo.On("ContainerExists", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("Exists", mock.Anything, "profile").Return(true, nil)
+	mockRepo.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	mockUoW.On("RegisterEvents", mock.Anything).Return()
+	mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+	return service, mockRepo, mockUoW
+}
+
+func TestContainerService_SetContainerMembershipResource(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		reference string
+		wantErr   bool
+	}{
+		{name: "existing resource", reference: "/resources/profile"},
+		{name: "allowed external reference", reference: "https://example.org/people/bob"},
+		{name: "missing resource", reference: "/resources/ghost", wantErr: true},
+		{name: "disallowed external reference", reference: "https://elsewhere.net/bob", wantErr: true},
+		{name: "cleared", reference: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := setupMembershipResourceTest()
+			container := domain.NewContainer(ctx, "likes", "alice", domain.DirectContainer)
+			container.SetMembershipResource("/resources/previous")
+			mockRepo.On("GetContainer", ctx, "likes").Return(container, nil)
+
+			err := service.SetContainerMembershipResource(ctx, "likes", tt.reference)
+			if tt.wantErr {
+				assert.True(t, domain.IsInvalidMembershipResource(err))
+				assert.Equal(t, "/resources/previous", container.GetMembershipResource())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.reference, container.GetMembershipResource())
+		})
+	}
+}
+
+func TestContainerService_SetContainerMembershipResource_RequiresDirectContainer(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, _ := setupMembershipResourceTest()
+	mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+
+	err := service.SetContainerMembershipResource(ctx, "docs", "/resources/profile")
+	assert.True(t, domain.IsInvalidContainerType(err))
+}
+
+func TestContainerService_AddResource_RevalidatesMembershipResource(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, _ := setupMembershipResourceTest()
+
+	// The membership resource was deleted after it was configured
+	container := domain.NewContainer(ctx, "likes", "alice", domain.DirectContainer)
+	container.SetMetadata(domain.MembershipResourceKey, "/resources/ghost")
+	mockRepo.On("GetContainer", ctx, "likes").Return(container, nil)
+
+	resource := domain.NewResource(ctx, "like-1", "text/turtle", []byte(""))
+	err := service.AddResource(ctx, "likes", "like-1", resource)
+	assert.True(t, domain.IsInvalidMembershipResource(err))
+	assert.False(t, container.HasMember("like-1"))
+
+	// Unchecked policies let the addition through
+	service.SetMembershipResourcePolicy(MembershipResourcePolicy{})
+	require.NoError(t, service.AddResource(ctx, "likes", "like-1", resource))
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MembershipDiff describes the member changes needed to match a requested ldp:contains set
+type MembershipDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// IsEmpty reports whether the diff requires no changes
+func (d *MembershipDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffContainerMembers compares a requested ldp:contains set with a container's current members.
+// Containment of containers is server-managed, so a diff that would add or remove a container
+// is rejected with a membership conflict.
+func (s *ContainerService) DiffContainerMembers(ctx context.Context, containerID string, desired []string) (*MembershipDiff, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("DiffContainerMembers").WithContext("containerID", containerID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("DiffContainerMembers").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("DiffContainerMembers").WithContext("containerID", containerID)
+	}
+
+	current := make(map[string]bool)
+	for _, memberID := range concreteContainer.GetMembers() {
+		current[memberID] = true
+	}
+
+	diff := &MembershipDiff{Added: []string{}, Removed: []string{}}
+	requested := make(map[string]bool, len(desired))
+	for _, memberID := range desired {
+		if memberID == "" || requested[memberID] {
+			continue
+		}
+		requested[memberID] = true
+		if !current[memberID] {
+			diff.Added = append(diff.Added, memberID)
+		}
+	}
+	for _, memberID := range concreteContainer.GetMembers() {
+		if !requested[memberID] {
+			diff.Removed = append(diff.Removed, memberID)
+		}
+	}
+
+	for _, memberIDs := range [][]string{diff.Added, diff.Removed} {
+		for _, memberID := range memberIDs {
+			isContainer, err := s.containerRepo.ContainerExists(ctx, memberID)
+			if err != nil {
+				return nil, domain.WrapStorageError(
+					err,
+					domain.ErrStorageOperation.Code,
+					"failed to check member type",
+				).WithOperation("DiffContainerMembers").WithContext("memberID", memberID)
+			}
+			if isContainer {
+				return nil, domain.WrapStorageError(
+					fmt.Errorf("containment of container %s is server-managed", memberID),
+					domain.ErrMembershipConflict.Code,
+					"containment of containers cannot be changed through ldp:contains",
+				).WithOperation("DiffContainerMembers").WithContext("containerID", containerID).WithContext("memberID", memberID)
+			}
+		}
+	}
+
+	return diff, nil
+}
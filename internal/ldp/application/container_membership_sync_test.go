@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newContainerWithMembers(id string, members ...string) *domain.Container {
+	container := domain.NewContainer(context.Background(), id, "", domain.BasicContainer)
+	container.Members = append(container.Members, members...)
+	return container
+}
+
+func TestContainerService_DiffContainerMembers(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "photos").Return(newContainerWithMembers("photos", "a", "b"), nil)
+	mockRepo.On("ContainerExists", mock.Anything, mock.Anything).Return(false, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	diff, err := service.DiffContainerMembers(context.Background(), "photos", []string{"b", "c", "c"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"c"}, diff.Added)
+	assert.Equal(t, []string{"a"}, diff.Removed)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestContainerService_DiffContainerMembers_Unchanged(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "photos").Return(newContainerWithMembers("photos", "a"), nil)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	diff, err := service.DiffContainerMembers(context.Background(), "photos", []string{"a"})
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestContainerService_DiffContainerMembers_ContainerContainmentIsServerManaged(t *testing.T) {
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "photos").Return(newContainerWithMembers("photos", "album"), nil)
+	mockRepo.On("ContainerExists", mock.Anything, "album").Return(true, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+
+	_, err := service.DiffContainerMembers(context.Background(), "photos", []string{})
+	require.Error(t, err)
+
+	storageErr, ok := err.(*domain.StorageError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrMembershipConflict.Code, storageErr.Code)
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MoveContainer moves the container sourceID under destParentID, renaming it to newID when
+// newID is set and differs. An empty destParentID makes it a root container. The container
+// keeps its metadata, members and children: the membership index is re-keyed to the new ID and
+// its children are reparented, so the whole subtree moves with it. All changes are committed in
+// one unit of work, and a rename leaves a forward from the old ID when forwarding is configured.
+func (s *ContainerService) MoveContainer(ctx context.Context, sourceID, destParentID, newID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if newID == "" {
+		newID = sourceID
+	}
+	if err := s.validator.ValidateContainerID(sourceID); err != nil {
+		return domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("MoveContainer")
+	}
+	if err := s.validator.ValidateContainerID(newID); err != nil {
+		return domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("MoveContainer")
+	}
+
+	container, err := s.containerRepo.GetContainer(ctx, sourceID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return domain.ErrResourceNotFound.WithOperation("MoveContainer").WithContext("containerID", sourceID)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container to move",
+		).WithOperation("MoveContainer").WithContext("containerID", sourceID)
+	}
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidResource.Code,
+			"invalid container type",
+		).WithOperation("MoveContainer").WithContext("containerID", sourceID)
+	}
+
+	oldParentID := concreteContainer.GetParentID()
+	if oldParentID == destParentID && newID == sourceID {
+		return nil
+	}
+
+	if newID != sourceID {
+		taken, err := s.containerRepo.ContainerExists(ctx, newID)
+		if err != nil {
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to check target container ID",
+			).WithOperation("MoveContainer").WithContext("containerID", newID)
+		}
+		if taken {
+			return domain.ErrResourceAlreadyExists.WithOperation("MoveContainer").WithContext("containerID", newID)
+		}
+	}
+
+	// The destination's path must not run through the container, or the move would make it
+	// its own ancestor
+	var ancestorPath []string
+	if destParentID != "" {
+		ancestorPath, err = s.containerRepo.GetPath(ctx, destParentID)
+		if err != nil {
+			if domain.IsResourceNotFound(err) {
+				return domain.WrapStorageError(
+					err,
+					domain.ErrResourceNotFound.Code,
+					"destination parent container not found",
+				).WithOperation("MoveContainer").WithContext("parentID", destParentID)
+			}
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to get destination path for hierarchy validation",
+			).WithOperation("MoveContainer").WithContext("parentID", destParentID)
+		}
+	}
+	if err := concreteContainer.Move(ctx, destParentID, newID, ancestorPath); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrInvalidHierarchy.Code,
+			"hierarchy validation failed",
+		).WithOperation("MoveContainer").WithContext("containerID", sourceID).WithContext("parentID", destParentID)
+	}
+
+	events := concreteContainer.UncommittedEvents()
+
+	// A container listed as a member of its old parent is listed in the new one instead
+	if oldParentID != "" {
+		listed, err := s.isListedMember(ctx, oldParentID, sourceID)
+		if err != nil {
+			return err
+		}
+		if listed {
+			movedAt := time.Now()
+			events = append(events, domain.NewMemberRemovedEvent(oldParentID, map[string]interface{}{
+				"memberID":   sourceID,
+				"memberType": "Container",
+				"removedAt":  movedAt,
+			}))
+			if destParentID != "" {
+				events = append(events, domain.NewMemberAddedEvent(destParentID, map[string]interface{}{
+					"memberID":     newID,
+					"memberType":   "Container",
+					"resourceType": "Container",
+					"addedAt":      movedAt,
+				}))
+			}
+		}
+	}
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(events)
+
+	envelopes, err := unitOfWork.Commit(ctx)
+	if err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container move events",
+		).WithOperation("MoveContainer").WithContext("containerID", sourceID)
+	}
+	concreteContainer.ClearEvents()
+
+	if len(envelopes) > 0 {
+		fmt.Printf("Successfully processed %d events for moving container %s to %s\n", len(envelopes), sourceID, newID)
+	}
+
+	if newID != sourceID {
+		s.unindex(ctx, sourceID)
+		s.forgetSizeAggregate(sourceID)
+		if moved, err := s.containerRepo.GetContainer(ctx, newID); err == nil {
+			if concreteMoved, ok := moved.(*domain.Container); ok {
+				s.indexContainer(ctx, concreteMoved)
+			}
+		}
+		if err := s.RecordContainerMove(ctx, sourceID, newID); err != nil {
+			fmt.Printf("Warning: failed to record move of container %s to %s: %v\n", sourceID, newID, err)
+		}
+	}
+
+	return nil
+}
+
+// isListedMember reports whether a container's membership index lists memberID
+func (s *ContainerService) isListedMember(ctx context.Context, containerID, memberID string) (bool, error) {
+	members, err := s.allMembers(ctx, containerID, "MoveContainer")
+	if err != nil {
+		return false, err
+	}
+	for _, id := range members {
+		if id == memberID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
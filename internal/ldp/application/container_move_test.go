@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_MoveContainer(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*ContainerService, *TestMockContainerRepository, *MockUnitOfWork) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+
+		trips := domain.NewContainer(ctx, "trips", "photos", domain.BasicContainer)
+		trips.MarkEventsAsCommitted()
+		mockRepo.On("GetContainer", ctx, "trips").Return(trips, nil)
+		mockRepo.On("GetPath", ctx, "archive").Return([]string{"archive"}, nil)
+		mockRepo.On("GetPath", ctx, "lisbon").Return([]string{"photos", "trips", "lisbon"}, nil)
+		return service, mockRepo, mockUoW
+	}
+
+	t.Run("should move and rename in one unit of work", func(t *testing.T) {
+		service, mockRepo, mockUoW := setup()
+		mockRepo.On("ContainerExists", ctx, "old-trips").Return(false, nil)
+		mockRepo.On("ListMembers", ctx, "photos", mock.Anything).Return([]string{"trips", "cover"}, nil)
+
+		var registered []pericarpdomain.Event
+		mockUoW.On("RegisterEvents", mock.Anything).Run(func(args mock.Arguments) {
+			registered = append(registered, args.Get(0).([]pericarpdomain.Event)...)
+		}).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil).Once()
+		mockRepo.On("GetContainer", ctx, "old-trips").Return(nil, domain.ErrResourceNotFound)
+
+		require.NoError(t, service.MoveContainer(ctx, "trips", "archive", "old-trips"))
+
+		var kinds []string
+		for _, event := range registered {
+			kinds = append(kinds, event.EventType()+":"+event.AggregateID())
+		}
+		assert.Equal(t, []string{
+			"container." + domain.EventTypeContainerMoved + ":trips",
+			"container." + domain.EventTypeMemberRemoved + ":photos",
+			"container." + domain.EventTypeMemberAdded + ":archive",
+		}, kinds)
+	})
+
+	t.Run("should refuse to move a container below itself", func(t *testing.T) {
+		service, _, mockUoW := setup()
+
+		err := service.MoveContainer(ctx, "trips", "lisbon", "")
+		require.Error(t, err)
+		assert.True(t, domain.IsInvalidHierarchy(err))
+		mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("should refuse a new ID that is taken", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		mockRepo.On("ContainerExists", ctx, "archive").Return(true, nil)
+
+		err := service.MoveContainer(ctx, "trips", "", "archive")
+		require.Error(t, err)
+		assert.True(t, domain.IsResourceAlreadyExists(err))
+	})
+}
+
+func TestContainerEventHandler_HandleContainerMoved_Replay(t *testing.T) {
+	ctx := context.Background()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
+	require.NoError(t, err)
+	repo, err := infrastructure.NewGORMContainerRepository(db)
+	require.NoError(t, err)
+
+	for _, container := range []*domain.Container{
+		domain.NewContainer(ctx, "photos", "", domain.BasicContainer),
+		domain.NewContainer(ctx, "trips", "photos", domain.BasicContainer),
+		domain.NewContainer(ctx, "lisbon", "trips", domain.BasicContainer),
+	} {
+		require.NoError(t, repo.CreateContainer(ctx, container))
+	}
+	for _, memberID := range []string{"cover", "map"} {
+		require.NoError(t, repo.AddMember(ctx, "trips", memberID))
+	}
+
+	// An earlier attempt stored the new ID and carried one member over before failing
+	journeys := domain.NewContainer(ctx, "journeys", "photos", domain.BasicContainer)
+	require.NoError(t, repo.CreateContainer(ctx, journeys))
+	require.NoError(t, repo.AddMember(ctx, "journeys", "cover"))
+
+	handler := NewContainerEventHandler(repo)
+	event := domain.NewContainerMovedEvent("trips", map[string]interface{}{"toParentID": "photos", "toID": "journeys"})
+	require.NoError(t, handler.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+
+	exists, err := repo.ContainerExists(ctx, "trips")
+	require.NoError(t, err)
+	assert.False(t, exists, "the rename finishes by removing the old ID")
+	members, err := repo.ListMembers(ctx, "journeys", domain.GetDefaultPagination())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cover", "map", "lisbon"}, members)
+	lisbon, err := repo.GetContainer(ctx, "lisbon")
+	require.NoError(t, err)
+	assert.Equal(t, "journeys", lisbon.(*domain.Container).ParentID)
+
+	// Replaying the event once the rename finished changes nothing
+	require.NoError(t, handler.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+	members, err = repo.ListMembers(ctx, "journeys", domain.GetDefaultPagination())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cover", "map", "lisbon"}, members)
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetRDFNormalizer sets the normalizer used for containers that opt in to canonical RDF
+func (s *ContainerService) SetRDFNormalizer(normalizer domain.RDFNormalizer) {
+	s.rdfNormalizer = normalizer
+}
+
+// SetContainerRDFNormalization enables or disables write-time RDF normalization for a container
+func (s *ContainerService) SetContainerRDFNormalization(ctx context.Context, containerID string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerRDFNormalization").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerRDFNormalization").WithContext("containerID", containerID)
+	}
+
+	if concreteContainer.IsRDFNormalizationEnabled() == enabled {
+		return nil
+	}
+
+	concreteContainer.SetRDFNormalization(enabled)
+
+	unitOfWork := s.unitOfWorkFactory()
+	events := concreteContainer.UncommittedEvents()
+	if len(events) > 0 {
+		unitOfWork.RegisterEvents(events)
+	}
+
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerRDFNormalization").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.ClearEvents()
+	return nil
+}
+
+// NormalizeContainerRDF canonicalizes RDF written into a container that has opted in to normalization.
+// Data is returned unchanged when the container has not opted in or the format cannot be normalized.
+func (s *ContainerService) NormalizeContainerRDF(ctx context.Context, containerID string, data []byte, contentType string) ([]byte, string, error) {
+	if s.rdfNormalizer == nil || !s.rdfNormalizer.CanNormalize(contentType) {
+		return data, contentType, nil
+	}
+
+	s.mu.RLock()
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, "", domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("NormalizeContainerRDF").WithContext("containerID", containerID)
+	}
+
+	if !domain.RDFNormalizationEnabled(container.GetMetadata()) {
+		return data, contentType, nil
+	}
+
+	return s.normalizeRDF(data, contentType, "NormalizeContainerRDF", "containerID", containerID)
+}
+
+// NormalizesResourceRDF reports whether RDF of the given type written to an existing resource
+// is canonicalized: the normalizer handles the format and a container holding the resource has
+// opted in to normalization
+func (s *ContainerService) NormalizesResourceRDF(ctx context.Context, resourceID string, contentType string) (bool, error) {
+	if s.rdfNormalizer == nil || !s.rdfNormalizer.CanNormalize(contentType) {
+		return false, nil
+	}
+	holders, ok := s.containerRepo.(domain.MemberContainerSource)
+	if !ok {
+		return false, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	containerIDs, err := holders.ListMemberContainers(ctx, resourceID)
+	if err != nil {
+		return false, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to find containers holding resource",
+		).WithOperation("NormalizesResourceRDF").WithContext("resourceID", resourceID)
+	}
+	for _, containerID := range containerIDs {
+		container, err := s.containerRepo.GetContainer(ctx, containerID)
+		if err != nil {
+			if domain.IsResourceNotFound(err) {
+				continue
+			}
+			return false, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to retrieve container holding resource",
+			).WithOperation("NormalizesResourceRDF").WithContext("containerID", containerID)
+		}
+		if domain.RDFNormalizationEnabled(container.GetMetadata()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NormalizeResourceRDF canonicalizes RDF replacing the content of a resource held by a container
+// that has opted in to normalization. Data is returned unchanged otherwise.
+func (s *ContainerService) NormalizeResourceRDF(ctx context.Context, resourceID string, data []byte, contentType string) ([]byte, string, error) {
+	normalizes, err := s.NormalizesResourceRDF(ctx, resourceID, contentType)
+	if err != nil || !normalizes {
+		return data, contentType, err
+	}
+	return s.normalizeRDF(data, contentType, "NormalizeResourceRDF", "resourceID", resourceID)
+}
+
+// normalizeRDF canonicalizes an RDF document, reporting parse failures as invalid format
+func (s *ContainerService) normalizeRDF(data []byte, contentType, operation, key, id string) ([]byte, string, error) {
+	normalized, normalizedType, err := s.rdfNormalizer.Normalize(data, contentType)
+	if err != nil {
+		return nil, "", domain.WrapStorageError(
+			err,
+			domain.ErrInvalidFormat.Code,
+			"failed to normalize RDF",
+		).WithOperation(operation).WithContext(key, id)
+	}
+
+	return normalized, normalizedType, nil
+}
@@ -6,10 +6,7 @@ import (
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
-	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
-	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -76,25 +73,9 @@ func TestPaginationValidation(t *testing.T) {
 // TestContainerPaginationBasic tests basic pagination functionality
 func TestContainerPaginationBasic(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
-	// Setup mocks for container creation
 	containerID := "pagination-test-container"
-	containerRepo.On("ContainerExists", ctx, containerID).Return(false, nil)
-	containerRepo.On("ContainerExists", ctx, "").Return(false, nil)
-
-	mockUoW := &MockUnitOfWork{}
-	mockUoW.On("RegisterEvents", mock.Anything).Return()
-	mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
-	unitOfWorkFactory = func() pericarpdomain.UnitOfWork {
-		return mockUoW
-	}
-	service = NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
 
 	// Create container
 	_, err := service.CreateContainer(ctx, containerID, "", domain.BasicContainer)
@@ -104,7 +85,7 @@ func TestContainerPaginationBasic(t *testing.T) {
 	memberCount := 100
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%03d", i) // Zero-padded for consistent ordering
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -136,12 +117,7 @@ func TestContainerPaginationBasic(t *testing.T) {
 // TestContainerPaginationEdgeCases tests pagination edge cases
 func TestContainerPaginationEdgeCases(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create empty container
 	containerID := "empty-container"
@@ -156,7 +132,7 @@ func TestContainerPaginationEdgeCases(t *testing.T) {
 	assert.Empty(t, listing.Members)
 
 	// Add single member
-	err = service.AddResource(ctx, containerID, "single-member")
+	err = storeAndAddMember(ctx, service, containerRepo, containerID, "single-member")
 	require.NoError(t, err)
 
 	// Test pagination with single member
@@ -177,12 +153,7 @@ func TestContainerPaginationEdgeCases(t *testing.T) {
 // TestContainerPaginationConsistency tests pagination consistency across multiple calls
 func TestContainerPaginationConsistency(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with members
 	containerID := "consistency-test-container"
@@ -194,7 +165,7 @@ func TestContainerPaginationConsistency(t *testing.T) {
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%03d", i)
 		expectedMembers[i] = memberID
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -224,12 +195,7 @@ func TestContainerPaginationConsistency(t *testing.T) {
 // TestContainerPaginationWithInvalidOptions tests service behavior with invalid pagination
 func TestContainerPaginationWithInvalidOptions(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with members
 	containerID := "invalid-pagination-test"
@@ -239,7 +205,7 @@ func TestContainerPaginationWithInvalidOptions(t *testing.T) {
 	// Add some members
 	for i := 0; i < 10; i++ {
 		memberID := fmt.Sprintf("member-%d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -257,12 +223,7 @@ func TestContainerPaginationWithInvalidOptions(t *testing.T) {
 // TestContainerPaginationBoundaryValues tests pagination with boundary values
 func TestContainerPaginationBoundaryValues(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with members
 	containerID := "boundary-test-container"
@@ -272,7 +233,7 @@ func TestContainerPaginationBoundaryValues(t *testing.T) {
 	memberCount := 100
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -326,12 +287,7 @@ func TestContainerPaginationBoundaryValues(t *testing.T) {
 // TestContainerPaginationWithTotalCount tests pagination with total count information
 func TestContainerPaginationWithTotalCount(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with known number of members
 	containerID := "total-count-test"
@@ -341,7 +297,7 @@ func TestContainerPaginationWithTotalCount(t *testing.T) {
 	memberCount := 75
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
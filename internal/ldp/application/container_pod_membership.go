@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetRejectCrossPodMembers selects whether members must belong to the pod of the container
+// they are added to. A pod is the hierarchy rooted at the top-level container named after an
+// account; containers that allow external members are exempt.
+func (s *ContainerService) SetRejectCrossPodMembers(reject bool) {
+	s.rejectCrossPod = reject
+}
+
+// SetContainerExternalMembers allows or forbids members from other pods in a container, such as
+// the external resources an indirect container references
+func (s *ContainerService) SetContainerExternalMembers(ctx context.Context, containerID string, allowed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerExternalMembers").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerExternalMembers").WithContext("containerID", containerID)
+	}
+
+	if domain.ExternalMembersAllowed(concreteContainer.GetMetadata()) == allowed {
+		return nil
+	}
+
+	// Only the toggle event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetExternalMembers(allowed)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerExternalMembers").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// checkMemberPod refuses a member from another pod with ErrCrossPodMembership. A member
+// container's pod comes from its own path; any other resource's pods are those of the
+// containers already holding it, so a resource no container holds yet fits any pod.
+func (s *ContainerService) checkMemberPod(ctx context.Context, container domain.ContainerResource, memberID, operation string) error {
+	if !s.rejectCrossPod || domain.ExternalMembersAllowed(container.GetMetadata()) {
+		return nil
+	}
+
+	pod, err := s.podOf(ctx, container.ID())
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to resolve container pod",
+		).WithOperation(operation).WithContext("containerID", container.ID())
+	}
+
+	memberPods, err := s.memberPods(ctx, memberID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to resolve member pod",
+		).WithOperation(operation).WithContext("containerID", container.ID()).WithContext("resourceID", memberID)
+	}
+
+	for _, memberPod := range memberPods {
+		if memberPod != pod {
+			return domain.ErrCrossPodMembership.WithOperation(operation).
+				WithContext("containerID", container.ID()).
+				WithContext("resourceID", memberID).
+				WithContext("containerPod", pod).
+				WithContext("memberPod", memberPod)
+		}
+	}
+	return nil
+}
+
+// podOf returns the pod a container belongs to, the top-level container of its path
+func (s *ContainerService) podOf(ctx context.Context, containerID string) (string, error) {
+	path, err := s.containerRepo.GetPath(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if len(path) == 0 {
+		return containerID, nil
+	}
+	return path[0], nil
+}
+
+// memberPods returns the pods a prospective member already belongs to
+func (s *ContainerService) memberPods(ctx context.Context, memberID string) ([]string, error) {
+	isContainer, err := s.containerRepo.ContainerExists(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if isContainer {
+		pod, err := s.podOf(ctx, memberID)
+		if err != nil {
+			return nil, err
+		}
+		return []string{pod}, nil
+	}
+
+	source, ok := s.containerRepo.(domain.MemberContainerSource)
+	if !ok {
+		return nil, nil
+	}
+	holders, err := source.ListMemberContainers(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]string, 0, len(holders))
+	for _, holder := range holders {
+		pod, err := s.podOf(ctx, holder)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// PodAccountID returns the account owning the pod a container or resource is in, or "" for a
+// resource no container holds
+func (s *ContainerService) PodAccountID(ctx context.Context, id string) (string, error) {
+	return podAccountID(ctx, s.containerRepo, id)
+}
+
+// podAccountID returns the account owning the pod a container or resource is in: the
+// top-level container of its path, which is named after the account. A resource is in the pod
+// of the first container holding it; one no container holds is in no pod and gets "".
+func podAccountID(ctx context.Context, containerRepo domain.ContainerRepository, id string) (string, error) {
+	containerID := id
+	isContainer, err := containerRepo.ContainerExists(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !isContainer {
+		source, ok := containerRepo.(domain.MemberContainerSource)
+		if !ok {
+			return "", nil
+		}
+		holders, err := source.ListMemberContainers(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if len(holders) == 0 {
+			return "", nil
+		}
+		containerID = holders[0]
+	}
+
+	path, err := containerRepo.GetPath(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if len(path) == 0 {
+		return containerID, nil
+	}
+	return path[0], nil
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memberContainerRepository reports which containers already hold each member
+type memberContainerRepository struct {
+	*TestMockContainerRepository
+	holders map[string][]string
+}
+
+func (r *memberContainerRepository) ListMemberContainers(ctx context.Context, memberID string) ([]string, error) {
+	return r.holders[memberID], nil
+}
+
+func TestContainerService_CrossPodMembership(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*ContainerService, *TestMockContainerRepository, *MockUnitOfWork) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		service.SetRejectCrossPodMembers(true)
+		mockRepo.On("GetPath", ctx, "alice/notes").Return([]string{"alice", "alice/notes"}, nil)
+		mockRepo.On("GetPath", ctx, "alice/drafts").Return([]string{"alice", "alice/drafts"}, nil)
+		mockRepo.On("GetPath", ctx, "bob/photos").Return([]string{"bob", "bob/photos"}, nil)
+		return service, mockRepo, mockUoW
+	}
+
+	t.Run("rejects a container from another pod", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		container := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(container, nil)
+		mockRepo.On("ContainerExists", ctx, "bob/photos").Return(true, nil)
+
+		resource := domain.NewResource(ctx, "bob/photos", "text/turtle", nil)
+		err := service.AddResource(ctx, "alice/notes", "bob/photos", resource)
+
+		require.Error(t, err)
+		assert.True(t, domain.IsCrossPodMembership(err))
+		assert.False(t, container.HasMember("bob/photos"))
+		mockRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts a container from the same pod", func(t *testing.T) {
+		service, mockRepo, mockUoW := setup()
+		container := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(container, nil)
+		mockRepo.On("ContainerExists", ctx, "alice/drafts").Return(true, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		resource := domain.NewResource(ctx, "alice/drafts", "text/turtle", nil)
+		require.NoError(t, service.AddResource(ctx, "alice/notes", "alice/drafts", resource))
+		assert.True(t, container.HasMember("alice/drafts"))
+	})
+
+	t.Run("rejects a resource held in another pod", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		service.containerRepo = &memberContainerRepository{
+			TestMockContainerRepository: mockRepo,
+			holders:                     map[string][]string{"photo.jpg": {"bob/photos"}},
+		}
+		container := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(container, nil)
+		mockRepo.On("ContainerExists", ctx, "photo.jpg").Return(false, nil)
+
+		resource := domain.NewResource(ctx, "photo.jpg", "image/jpeg", []byte("jpeg"))
+		err := service.AddResource(ctx, "alice/notes", "photo.jpg", resource)
+
+		require.Error(t, err)
+		assert.True(t, domain.IsCrossPodMembership(err))
+	})
+
+	t.Run("allows external members when the container opts in", func(t *testing.T) {
+		service, mockRepo, mockUoW := setup()
+		container := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		container.SetExternalMembers(true)
+		container.MarkEventsAsCommitted()
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(container, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		resource := domain.NewResource(ctx, "bob/photos", "text/turtle", nil)
+		require.NoError(t, service.AddResource(ctx, "alice/notes", "bob/photos", resource))
+		mockRepo.AssertNotCalled(t, "ContainerExists", mock.Anything, "bob/photos")
+	})
+
+	t.Run("skips the check when cross-pod membership is allowed", func(t *testing.T) {
+		service, mockRepo, mockUoW := setup()
+		service.SetRejectCrossPodMembers(false)
+		container := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(container, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		resource := domain.NewResource(ctx, "bob/photos", "text/turtle", nil)
+		require.NoError(t, service.AddResource(ctx, "alice/notes", "bob/photos", resource))
+	})
+
+	t.Run("toggles external members on a container", func(t *testing.T) {
+		service, mockRepo, mockUoW := setup()
+		container := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(container, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		require.NoError(t, service.SetContainerExternalMembers(ctx, "alice/notes", true))
+		assert.True(t, domain.ExternalMembersAllowed(container.GetMetadata()))
+		mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+
+		require.NoError(t, service.SetContainerExternalMembers(ctx, "alice/notes", true))
+		mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+	})
+}
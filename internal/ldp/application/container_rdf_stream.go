@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// StreamContainerRDF writes a container in an RDF format to w. Turtle, N-Triples and JSON-LD
+// are streamed: the container's own triples are written first, then its membership triples as
+// members are read from the membership index in batches, so memory use stays constant however
+// many members the container holds. Other formats are converted in memory and written whole.
+// Nothing is written when the container cannot be read; an error after writing has begun
+// leaves the document truncated.
+func (s *ContainerService) StreamContainerRDF(ctx context.Context, id, format, baseURI string, w io.Writer) error {
+	if !s.rdfConverter.CanStream(format) {
+		data, err := s.GetContainerWithFormat(ctx, id, format, baseURI)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	if id == "" {
+		return domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("StreamContainerRDF")
+	}
+	if baseURI == "" {
+		return domain.WrapStorageError(
+			fmt.Errorf("base URI cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"base URI cannot be empty",
+		).WithOperation("StreamContainerRDF")
+	}
+
+	s.mu.RLock()
+	container, err := s.containerRepo.GetContainer(ctx, id)
+	s.mu.RUnlock()
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return domain.ErrResourceNotFound.WithOperation("StreamContainerRDF").WithContext("containerID", id)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("StreamContainerRDF").WithContext("containerID", id)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidResource.Code,
+			"invalid container type",
+		).WithOperation("StreamContainerRDF").WithContext("containerID", id)
+	}
+
+	// Avoid emitting empty Dublin Core dates for containers restored from partial data
+	s.timestampManager.FillMissingTimestamps(concreteContainer)
+
+	// The lock is not held while writing, so a slow client cannot stall writers
+	err = s.rdfConverter.StreamContainer(w, concreteContainer, format, baseURI, func(emit func(string) error) error {
+		return s.streamMemberIDs(ctx, concreteContainer, emit)
+	})
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to stream container",
+		).WithOperation("StreamContainerRDF").WithContext("containerID", id).WithContext("format", format)
+	}
+	return nil
+}
+
+// streamMemberIDs passes a container's member IDs to emit, reading them from the membership
+// index in batches, or from the container itself when there is no index
+func (s *ContainerService) streamMemberIDs(ctx context.Context, container *domain.Container, emit func(string) error) error {
+	if s.memberIndex == nil {
+		for _, memberID := range container.Members {
+			if err := emit(memberID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for offset := 0; ; {
+		members, err := s.indexedMembersPage(ctx, container.ID(), domain.PaginationOptions{Limit: exportBatchSize, Offset: offset})
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if err := emit(member.ID); err != nil {
+				return err
+			}
+		}
+		if len(members) < exportBatchSize {
+			return nil
+		}
+		offset += len(members)
+	}
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_StreamContainerRDF(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pages membership triples from the index", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "photos").Return(container, nil)
+		index := &pagedMemberIndex{members: exportMembers(1200)}
+		service.SetMemberIndex(index)
+
+		var output bytes.Buffer
+		require.NoError(t, service.StreamContainerRDF(ctx, "photos", "text/turtle", "http://example.org/", &output))
+		assert.Equal(t, 1200, strings.Count(output.String(), "ldp:contains"))
+		assert.Contains(t, output.String(), "<http://example.org/photos> ldp:contains <http://example.org/m1199> .\n")
+		assert.Equal(t, []domain.PaginationOptions{{Limit: 500, Offset: 0}, {Limit: 500, Offset: 500}, {Limit: 500, Offset: 1000}}, index.pages)
+	})
+
+	t.Run("writes valid JSON-LD", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "photos").Return(container, nil)
+		service.SetMemberIndex(&pagedMemberIndex{members: exportMembers(3)})
+
+		var output bytes.Buffer
+		require.NoError(t, service.StreamContainerRDF(ctx, "photos", "application/ld+json", "http://example.org/", &output))
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &document))
+		assert.Len(t, document["contains"], 3)
+	})
+
+	t.Run("writes nothing for a missing container", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		mockRepo.On("GetContainer", ctx, "missing").Return(nil, domain.ErrResourceNotFound)
+
+		var output bytes.Buffer
+		err := service.StreamContainerRDF(ctx, "missing", "text/turtle", "http://example.org/", &output)
+		assert.True(t, domain.IsResourceNotFound(err))
+		assert.Zero(t, output.Len())
+	})
+}
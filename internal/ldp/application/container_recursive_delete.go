@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// memberPageSize is how many member IDs are read from the membership index at a time when
+// every member of a container is visited
+const memberPageSize = 1000
+
+// RecursiveDeletion lists what a recursive container deletion removed
+type RecursiveDeletion struct {
+	Containers []string `json:"containers"`
+	Resources  []string `json:"resources"`
+}
+
+// DeleteContainerRecursive deletes a container together with everything below it. The tree is
+// walked depth first: each sub-container's children and members are removed before the
+// sub-container itself, and the container named by id goes last. Every removal is committed in
+// one unit of work, so the event handlers drop the membership index entries and stored rows of
+// every node or, when the commit fails, none of them.
+func (s *ContainerService) DeleteContainerRecursive(ctx context.Context, id string) (*RecursiveDeletion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.validator.ValidateContainerID(id); err != nil {
+		return nil, domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("DeleteContainerRecursive")
+	}
+
+	container, err := s.containerRepo.GetContainer(ctx, id)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("DeleteContainerRecursive").WithContext("containerID", id)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container for deletion",
+		).WithOperation("DeleteContainerRecursive").WithContext("containerID", id)
+	}
+
+	authorizer := s.writeAuthorizer
+	if authorizer == nil {
+		authorizer = AllowAllContainerWrites{}
+	}
+
+	deletion := &RecursiveDeletion{Containers: []string{}, Resources: []string{}}
+	var events []pericarpdomain.Event
+	visited := make(map[string]bool)
+	if err := s.collectRecursiveDeletion(ctx, authorizer, container, visited, deletion, &events); err != nil {
+		return nil, err
+	}
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(events)
+
+	envelopes, err := unitOfWork.Commit(ctx)
+	if err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit recursive container deletion events",
+		).WithOperation("DeleteContainerRecursive").WithContext("containerID", id)
+	}
+
+	if len(envelopes) > 0 {
+		fmt.Printf("Successfully processed %d events for recursive deletion of container %s\n", len(envelopes), id)
+	}
+
+	for _, resourceID := range deletion.Resources {
+		s.unindex(ctx, resourceID)
+		s.identifiers.release(ctx, resourceID)
+	}
+	for _, containerID := range deletion.Containers {
+		s.unindex(ctx, containerID)
+		s.identifiers.release(ctx, containerID)
+		s.forgetSizeAggregate(containerID)
+	}
+
+	return deletion, nil
+}
+
+// collectRecursiveDeletion appends the events deleting a container's subtree, children first,
+// then the container's members, then the container itself. Members that are containers outside
+// the subtree only lose their membership; they are not deleted. Every container and resource
+// deleted needs Write, so a single one the caller may not modify refuses the whole deletion
+// before anything is committed.
+func (s *ContainerService) collectRecursiveDeletion(ctx context.Context, authorizer ContainerWriteAuthorizer, container domain.ContainerResource, visited map[string]bool, deletion *RecursiveDeletion, events *[]pericarpdomain.Event) error {
+	id := container.ID()
+	if visited[id] {
+		return nil
+	}
+	visited[id] = true
+
+	if !authorizer.CanWriteContainer(ctx, id) {
+		return domain.ErrAccessDenied.WithOperation("DeleteContainerRecursive").WithContext("containerID", id)
+	}
+
+	children, err := s.containerRepo.GetChildren(ctx, id)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to get container children",
+		).WithOperation("DeleteContainerRecursive").WithContext("containerID", id)
+	}
+	childIDs := make(map[string]bool, len(children))
+	for _, child := range children {
+		childIDs[child.ID()] = true
+		if err := s.collectRecursiveDeletion(ctx, authorizer, child, visited, deletion, events); err != nil {
+			return err
+		}
+	}
+
+	members, err := s.allMembers(ctx, id, "DeleteContainerRecursive")
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, memberID := range members {
+		isContainer := childIDs[memberID]
+		if !isContainer {
+			if isContainer, err = s.containerRepo.ContainerExists(ctx, memberID); err != nil {
+				return domain.WrapStorageError(
+					err,
+					domain.ErrStorageOperation.Code,
+					"failed to check member type",
+				).WithOperation("DeleteContainerRecursive").WithContext("memberID", memberID)
+			}
+		}
+		memberType := "Resource"
+		if isContainer {
+			memberType = "Container"
+		}
+
+		*events = append(*events, domain.NewMemberRemovedEvent(id, map[string]interface{}{
+			"memberID":   memberID,
+			"memberType": memberType,
+			"removedAt":  now,
+		}))
+		if memberType == "Resource" && !visited[memberID] {
+			visited[memberID] = true
+			if !authorizer.CanWriteContainer(ctx, memberID) {
+				return domain.ErrAccessDenied.WithOperation("DeleteContainerRecursive").WithContext("resourceID", memberID)
+			}
+			*events = append(*events, domain.NewResourceDeletedEvent(memberID, map[string]interface{}{
+				"deletedAt": now,
+			}))
+			deletion.Resources = append(deletion.Resources, memberID)
+		}
+	}
+
+	*events = append(*events, domain.NewContainerDeletedEvent(id, map[string]interface{}{
+		"deletedAt": now,
+	}))
+	deletion.Containers = append(deletion.Containers, id)
+	return nil
+}
+
+// allMembers reads every member ID of a container from the membership index, page by page
+func (s *ContainerService) allMembers(ctx context.Context, containerID, operation string) ([]string, error) {
+	var members []string
+	pagination := domain.PaginationOptions{Limit: memberPageSize}
+	for {
+		page, err := s.containerRepo.ListMembers(ctx, containerID, pagination)
+		if err != nil {
+			return nil, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to list container members",
+			).WithOperation(operation).WithContext("containerID", containerID)
+		}
+		members = append(members, page...)
+		if len(page) < pagination.Limit {
+			return members, nil
+		}
+		pagination.Offset += len(page)
+	}
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerService_DeleteContainerRecursive(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*ContainerService, *MockUnitOfWork) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+
+		photos := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+		trips := domain.NewContainer(ctx, "trips", "photos", domain.BasicContainer)
+
+		mockRepo.On("GetContainer", ctx, "photos").Return(photos, nil)
+		mockRepo.On("GetChildren", ctx, "photos").Return([]domain.ContainerResource{trips}, nil)
+		mockRepo.On("GetChildren", ctx, "trips").Return([]domain.ContainerResource{}, nil)
+		mockRepo.On("ListMembers", ctx, "photos", mock.Anything).Return([]string{"trips", "cover"}, nil)
+		mockRepo.On("ListMembers", ctx, "trips", mock.Anything).Return([]string{"lisbon"}, nil)
+		mockRepo.On("ContainerExists", ctx, mock.Anything).Return(false, nil)
+		return service, mockUoW
+	}
+
+	t.Run("should delete the tree children first in one unit of work", func(t *testing.T) {
+		service, mockUoW := setup()
+		var registered []pericarpdomain.Event
+		mockUoW.On("RegisterEvents", mock.Anything).Run(func(args mock.Arguments) {
+			registered = append(registered, args.Get(0).([]pericarpdomain.Event)...)
+		}).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil).Once()
+
+		deletion, err := service.DeleteContainerRecursive(ctx, "photos")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"trips", "photos"}, deletion.Containers)
+		assert.Equal(t, []string{"lisbon", "cover"}, deletion.Resources)
+
+		var kinds []string
+		for _, event := range registered {
+			kinds = append(kinds, event.EventType()+":"+event.AggregateID())
+		}
+		assert.Equal(t, []string{
+			"container." + domain.EventTypeMemberRemoved + ":trips",
+			"resource." + domain.EventTypeResourceDeleted + ":lisbon",
+			"container." + domain.EventTypeContainerDeleted + ":trips",
+			"container." + domain.EventTypeMemberRemoved + ":photos",
+			"container." + domain.EventTypeMemberRemoved + ":photos",
+			"resource." + domain.EventTypeResourceDeleted + ":cover",
+			"container." + domain.EventTypeContainerDeleted + ":photos",
+		}, kinds)
+		mockUoW.AssertExpectations(t)
+	})
+
+	t.Run("should roll back when the commit fails", func(t *testing.T) {
+		service, mockUoW := setup()
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return(nil, errors.New("disk full"))
+		mockUoW.On("Rollback").Return(nil)
+
+		_, err := service.DeleteContainerRecursive(ctx, "photos")
+		assert.Error(t, err)
+		mockUoW.AssertCalled(t, "Rollback")
+	})
+
+	for _, denied := range []string{"trips", "lisbon"} {
+		t.Run("should refuse the whole tree when "+denied+" cannot be written", func(t *testing.T) {
+			service, mockUoW := setup()
+			service.SetWriteAuthorizer(denyContainerWrites{denied: true})
+
+			_, err := service.DeleteContainerRecursive(ctx, "photos")
+			assert.True(t, domain.IsAccessDenied(err))
+			mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MoveResourceOptions tunes MoveResourceWithOptions
+type MoveResourceOptions struct {
+	// RewriteReferences rewrites references to OldIRI in the pod's RDF resources to NewIRI.
+	// Every RDF resource in the pod is read, so it is off unless asked for.
+	RewriteReferences bool
+	// OldIRI is the IRI the resource was addressed by before the move
+	OldIRI string
+	// NewIRI is the IRI the resource is addressed by after the move
+	NewIRI string
+}
+
+// referenceRewrite is a resource whose references were rewritten, with its content before
+type referenceRewrite struct {
+	resource domain.Resource
+	original []byte
+}
+
+// SetResourceRepository sets the repository holding resource content, which reference
+// rewriting on move reads and updates
+func (s *ContainerService) SetResourceRepository(repo domain.ResourceRepository) {
+	s.resourceRepo = repo
+}
+
+// rewritePodReferences replaces references to oldIRI with newIRI in every RDF resource of the
+// pod containerID belongs to. All rewrites are registered in one unit of work, so their update
+// events are committed together, and resources already stored are restored if one store fails.
+func (s *ContainerService) rewritePodReferences(ctx context.Context, containerID, oldIRI, newIRI string) error {
+	if oldIRI == "" || oldIRI == newIRI {
+		return nil
+	}
+	if s.resourceRepo == nil {
+		return domain.WrapStorageError(
+			fmt.Errorf("no resource repository configured"),
+			domain.ErrStorageOperation.Code,
+			"reference rewriting is not available",
+		).WithOperation("MoveResource").WithContext("containerID", containerID)
+	}
+
+	s.mu.RLock()
+	resourceIDs, err := s.podResourceIDs(ctx, containerID)
+	s.mu.RUnlock()
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to list pod resources",
+		).WithOperation("MoveResource").WithContext("containerID", containerID)
+	}
+
+	// Rewrite in memory first so nothing is stored unless every resource could be read
+	var rewrites []referenceRewrite
+	for _, resourceID := range resourceIDs {
+		resource, err := s.resourceRepo.Retrieve(ctx, resourceID)
+		if err != nil {
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to read resource for reference rewriting",
+			).WithOperation("MoveResource").WithContext("resourceID", resourceID)
+		}
+
+		original := resource.GetData()
+		data, changed := domain.RewriteIRIReferences(original, resource.GetContentType(), oldIRI, newIRI)
+		if !changed {
+			continue
+		}
+		resource.Update(ctx, data, resource.GetContentType())
+		rewrites = append(rewrites, referenceRewrite{resource: resource, original: original})
+	}
+	if len(rewrites) == 0 {
+		return nil
+	}
+
+	unitOfWork := s.unitOfWorkFactory()
+	for _, rw := range rewrites {
+		unitOfWork.RegisterEvents(rw.resource.UncommittedEvents())
+	}
+
+	for i, rw := range rewrites {
+		if err := s.resourceRepo.Store(ctx, rw.resource); err != nil {
+			s.restoreRewrittenResources(ctx, rewrites[:i])
+			if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+				fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+			}
+			return domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to store rewritten resource",
+			).WithOperation("MoveResource").WithContext("resourceID", rw.resource.ID())
+		}
+	}
+
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		s.restoreRewrittenResources(ctx, rewrites)
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit reference rewrite events",
+		).WithOperation("MoveResource").WithContext("containerID", containerID)
+	}
+
+	for _, rw := range rewrites {
+		rw.resource.ClearEvents()
+	}
+	return nil
+}
+
+// restoreRewrittenResources puts back the content of resources whose rewrite was stored. The
+// restore is not an event of its own: the rewrite events it undoes are rolled back.
+func (s *ContainerService) restoreRewrittenResources(ctx context.Context, rewrites []referenceRewrite) {
+	for _, rw := range rewrites {
+		rw.resource.Update(ctx, rw.original, rw.resource.GetContentType())
+		rw.resource.ClearEvents()
+		if err := s.resourceRepo.Store(ctx, rw.resource); err != nil {
+			fmt.Printf("Warning: failed to restore resource %s after reference rewrite: %v\n", rw.resource.ID(), err)
+		}
+	}
+}
+
+// podResourceIDs lists the non-container members of every container in the pod containerID
+// belongs to
+func (s *ContainerService) podResourceIDs(ctx context.Context, containerID string) ([]string, error) {
+	pod, err := s.podOf(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceIDs []string
+	seen := map[string]bool{pod: true}
+	queue := []string{pod}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		container, err := s.containerRepo.GetContainer(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		for _, memberID := range container.GetMembers() {
+			if seen[memberID] {
+				continue
+			}
+			seen[memberID] = true
+
+			isContainer, err := s.containerRepo.ContainerExists(ctx, memberID)
+			if err != nil {
+				return nil, err
+			}
+			if isContainer {
+				queue = append(queue, memberID)
+			} else {
+				resourceIDs = append(resourceIDs, memberID)
+			}
+		}
+
+		children, err := s.containerRepo.GetChildren(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if !seen[child.ID()] {
+				seen[child.ID()] = true
+				queue = append(queue, child.ID())
+			}
+		}
+	}
+	return resourceIDs, nil
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryResourceRepository keeps resources in a map and can fail stores of one resource
+type memoryResourceRepository struct {
+	resources map[string]domain.Resource
+	failStore string
+}
+
+func (r *memoryResourceRepository) Store(ctx context.Context, resource domain.Resource) error {
+	if resource.ID() == r.failStore {
+		return errors.New("disk full")
+	}
+	r.resources[resource.ID()] = resource
+	return nil
+}
+
+func (r *memoryResourceRepository) Retrieve(ctx context.Context, id string) (domain.Resource, error) {
+	resource, ok := r.resources[id]
+	if !ok {
+		return nil, domain.ErrResourceNotFound
+	}
+	return resource, nil
+}
+
+func (r *memoryResourceRepository) Delete(ctx context.Context, id string) error {
+	delete(r.resources, id)
+	return nil
+}
+
+func (r *memoryResourceRepository) Exists(ctx context.Context, id string) (bool, error) {
+	_, ok := r.resources[id]
+	return ok, nil
+}
+
+func TestContainerService_RewritePodReferences(t *testing.T) {
+	ctx := context.Background()
+	oldIRI := "https://pod.example/resources/note"
+	newIRI := "https://pod.example/resources/archived-note"
+
+	setup := func() (*ContainerService, *memoryResourceRepository, *MockUnitOfWork) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+
+		pod := domain.NewContainer(ctx, "alice", "", domain.BasicContainer)
+		pod.AddMember(ctx, domain.NewResource(ctx, "index", "text/turtle", nil))
+		notes := domain.NewContainer(ctx, "alice/notes", "alice", domain.BasicContainer)
+		notes.AddMember(ctx, domain.NewResource(ctx, "note", "text/plain", nil))
+		notes.AddMember(ctx, domain.NewResource(ctx, "links", "application/ld+json", nil))
+
+		mockRepo.On("GetPath", ctx, "alice/notes").Return([]string{"alice", "alice/notes"}, nil)
+		mockRepo.On("GetContainer", ctx, "alice").Return(pod, nil)
+		mockRepo.On("GetContainer", ctx, "alice/notes").Return(notes, nil)
+		mockRepo.On("GetChildren", ctx, "alice").Return([]domain.ContainerResource{notes}, nil)
+		mockRepo.On("GetChildren", ctx, "alice/notes").Return([]domain.ContainerResource{}, nil)
+		mockRepo.On("ContainerExists", ctx, mock.Anything).Return(false, nil)
+
+		resourceRepo := &memoryResourceRepository{resources: map[string]domain.Resource{
+			"index": domain.NewResource(ctx, "index", "text/turtle",
+				[]byte(`<https://pod.example/resources/index> <http://schema.org/hasPart> <https://pod.example/resources/note> .`)),
+			"note": domain.NewResource(ctx, "note", "text/plain", []byte(`https://pod.example/resources/note`)),
+			"links": domain.NewResource(ctx, "links", "application/ld+json",
+				[]byte(`{"related":{"@id":"https://pod.example/resources/note"}}`)),
+		}}
+		for _, resource := range resourceRepo.resources {
+			resource.ClearEvents()
+		}
+		service.SetResourceRepository(resourceRepo)
+		return service, resourceRepo, mockUoW
+	}
+
+	t.Run("rewrites references across the pod in one unit of work", func(t *testing.T) {
+		service, resourceRepo, mockUoW := setup()
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		require.NoError(t, service.rewritePodReferences(ctx, "alice/notes", oldIRI, newIRI))
+
+		assert.Contains(t, string(resourceRepo.resources["index"].GetData()), "<"+newIRI+">")
+		assert.Contains(t, string(resourceRepo.resources["links"].GetData()), `"`+newIRI+`"`)
+		assert.Equal(t, oldIRI, string(resourceRepo.resources["note"].GetData()))
+		mockUoW.AssertNumberOfCalls(t, "RegisterEvents", 2)
+		mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+	})
+
+	t.Run("restores stored rewrites when a later store fails", func(t *testing.T) {
+		service, resourceRepo, mockUoW := setup()
+		resourceRepo.failStore = "links"
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Rollback").Return(nil)
+
+		err := service.rewritePodReferences(ctx, "alice/notes", oldIRI, newIRI)
+
+		require.Error(t, err)
+		assert.Contains(t, string(resourceRepo.resources["index"].GetData()), "<"+oldIRI+">")
+		mockUoW.AssertCalled(t, "Rollback")
+		mockUoW.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("fails without a resource repository", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+
+		err := service.rewritePodReferences(ctx, "alice/notes", oldIRI, newIRI)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,267 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+)
+
+// SetContainerLister sets the source of container IDs the retention sweep walks
+func (s *ContainerService) SetContainerLister(lister domain.ContainerLister) {
+	s.containerLister = lister
+}
+
+// SetContainerRetentionPolicy sets how long a container keeps its members before the retention
+// sweep expires them. The zero policy clears it, so members are kept until removed.
+func (s *ContainerService) SetContainerRetentionPolicy(ctx context.Context, containerID string, policy domain.RetentionPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if policy.MaxAge < 0 {
+		return domain.WrapStorageError(
+			fmt.Errorf("retention max age cannot be negative"),
+			domain.ErrInvalidFormat.Code,
+			"invalid retention policy",
+		).WithOperation("SetContainerRetentionPolicy").WithContext("containerID", containerID)
+	}
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrResourceNotFound.Code,
+			"container not found",
+		).WithOperation("SetContainerRetentionPolicy").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidContainerType.Code,
+			"invalid container type",
+		).WithOperation("SetContainerRetentionPolicy").WithContext("containerID", containerID)
+	}
+
+	current, configured := domain.ContainerRetentionPolicy(concreteContainer.GetMetadata())
+	if (configured && current == policy) || (!configured && policy.IsZero()) {
+		return nil
+	}
+
+	// Only the retention policy event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.SetRetentionPolicy(policy)
+
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container update events",
+		).WithOperation("SetContainerRetentionPolicy").WithContext("containerID", containerID)
+	}
+
+	concreteContainer.MarkEventsAsCommitted()
+	return nil
+}
+
+// ExpiredMembers lists the members, by container, that have outlived their container's
+// retention policy at the given time. Member ages come from the membership index; child
+// containers and members without a recorded creation time are never expired. Containers
+// without a policy or without expired members are left out.
+func (s *ContainerService) ExpiredMembers(ctx context.Context, now time.Time) (map[string][]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expired := make(map[string][]string)
+	if s.containerLister == nil || s.memberIndex == nil {
+		return expired, nil
+	}
+
+	containerIDs, err := s.containerLister.ListContainerIDs(ctx)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to list containers",
+		).WithOperation("ExpiredMembers")
+	}
+
+	for _, containerID := range containerIDs {
+		container, err := s.containerRepo.GetContainer(ctx, containerID)
+		if err != nil {
+			fmt.Printf("Warning: failed to load container %s for retention: %v\n", containerID, err)
+			continue
+		}
+		policy, ok := domain.ContainerRetentionPolicy(container.GetMetadata())
+		if !ok {
+			continue
+		}
+
+		members, err := s.memberIndex.ListIndexedMembers(ctx, containerID)
+		if err != nil {
+			return nil, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to read membership index",
+			).WithOperation("ExpiredMembers").WithContext("containerID", containerID)
+		}
+		for _, member := range members {
+			if member.Type == string(infrastructure.ResourceTypeContainer) || !policy.IsExpired(member.CreatedAt, now) {
+				continue
+			}
+			expired[containerID] = append(expired[containerID], member.ID)
+		}
+	}
+	return expired, nil
+}
+
+// ResourceDeleter deletes stored resources
+type ResourceDeleter interface {
+	DeleteResource(ctx context.Context, id string) error
+}
+
+// ContainerExpiry is how many members the retention sweep expired from one container
+type ContainerExpiry struct {
+	ContainerID string `json:"containerId"`
+	Expired     int    `json:"expired"`
+}
+
+// RetentionReport summarizes one retention sweep
+type RetentionReport struct {
+	SweptAt    time.Time         `json:"sweptAt"`
+	Containers []ContainerExpiry `json:"containers"`
+	Expired    int               `json:"expired"`
+	Failed     int               `json:"failed"`
+}
+
+// RetentionSweeper expires the members of containers with a retention policy. Each expired
+// member is removed from its container, emitting member_removed, and its resource deleted.
+// Sweeps run on an interval and can be triggered on demand.
+type RetentionSweeper struct {
+	containers *ContainerService
+	resources  ResourceDeleter
+	now        func() time.Time
+
+	sweepMu  sync.Mutex // keeps sweeps from overlapping
+	mu       sync.RWMutex
+	last     *RetentionReport
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRetentionSweeper creates a sweeper expiring members through the given services. A positive
+// interval starts a background sweep loop, stopped by Close.
+func NewRetentionSweeper(containers *ContainerService, resources ResourceDeleter, interval time.Duration) *RetentionSweeper {
+	s := &RetentionSweeper{
+		containers: containers,
+		resources:  resources,
+		now:        time.Now,
+		stop:       make(chan struct{}),
+	}
+
+	if interval > 0 {
+		go s.sweepPeriodically(interval)
+	}
+
+	return s
+}
+
+// Sweep expires every member that has outlived its container's retention policy and reports
+// how many it expired per container. A member that cannot be removed is counted as failed and
+// retried by the next sweep.
+func (s *RetentionSweeper) Sweep(ctx context.Context) (RetentionReport, error) {
+	s.sweepMu.Lock()
+	defer s.sweepMu.Unlock()
+
+	now := s.now()
+	expired, err := s.containers.ExpiredMembers(ctx, now)
+	if err != nil {
+		return RetentionReport{}, err
+	}
+
+	containerIDs := make([]string, 0, len(expired))
+	for containerID := range expired {
+		containerIDs = append(containerIDs, containerID)
+	}
+	sort.Strings(containerIDs)
+
+	report := RetentionReport{SweptAt: now, Containers: []ContainerExpiry{}}
+	for _, containerID := range containerIDs {
+		count := 0
+		for _, memberID := range expired[containerID] {
+			if err := s.expire(ctx, containerID, memberID); err != nil {
+				fmt.Printf("Warning: failed to expire member %s of container %s: %v\n", memberID, containerID, err)
+				report.Failed++
+				continue
+			}
+			count++
+		}
+		if count > 0 {
+			report.Containers = append(report.Containers, ContainerExpiry{ContainerID: containerID, Expired: count})
+			report.Expired += count
+		}
+	}
+
+	s.mu.Lock()
+	s.last = &report
+	s.mu.Unlock()
+	return report, nil
+}
+
+// expire removes a member from its container and deletes its resource; a resource that is
+// already gone still counts as expired
+func (s *RetentionSweeper) expire(ctx context.Context, containerID, memberID string) error {
+	if err := s.containers.RemoveResource(ctx, containerID, memberID); err != nil {
+		return err
+	}
+	if err := s.resources.DeleteResource(ctx, memberID); err != nil && !domain.IsResourceNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// LastReport returns the report of the most recent sweep, reporting false before the first
+func (s *RetentionSweeper) LastReport() (RetentionReport, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.last == nil {
+		return RetentionReport{}, false
+	}
+	return *s.last, true
+}
+
+// Close stops the background sweep loop
+func (s *RetentionSweeper) Close() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// sweepPeriodically sweeps every interval until the sweeper is closed
+func (s *RetentionSweeper) sweepPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.Sweep(context.Background()); err != nil {
+				fmt.Printf("Warning: retention sweep failed: %v\n", err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticContainerLister lists a fixed set of container IDs
+type staticContainerLister []string
+
+func (l staticContainerLister) ListContainerIDs(ctx context.Context) ([]string, error) {
+	return l, nil
+}
+
+func TestContainerService_RetentionPolicy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("stores the retention policy", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		container := domain.NewContainer(ctx, "logs", "", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "logs").Return(container, nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		require.NoError(t, service.SetContainerRetentionPolicy(ctx, "logs", domain.RetentionPolicy{MaxAge: 24 * time.Hour}))
+		policy, ok := domain.ContainerRetentionPolicy(container.GetMetadata())
+		assert.True(t, ok)
+		assert.Equal(t, 24*time.Hour, policy.MaxAge)
+
+		require.NoError(t, service.SetContainerRetentionPolicy(ctx, "logs", domain.RetentionPolicy{MaxAge: 24 * time.Hour}))
+		mockUoW.AssertNumberOfCalls(t, "Commit", 1)
+	})
+
+	t.Run("rejects a negative max age", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+
+		err := service.SetContainerRetentionPolicy(ctx, "logs", domain.RetentionPolicy{MaxAge: -time.Hour})
+		require.Error(t, err)
+	})
+
+	t.Run("lists members older than the policy", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		logs := domain.NewContainer(ctx, "logs", "", domain.BasicContainer)
+		logs.SetRetentionPolicy(domain.RetentionPolicy{MaxAge: 24 * time.Hour})
+		mockRepo.On("GetContainer", ctx, "logs").Return(logs, nil)
+		mockRepo.On("GetContainer", ctx, "docs").Return(domain.NewContainer(ctx, "docs", "", domain.BasicContainer), nil)
+
+		service.SetContainerLister(staticContainerLister{"docs", "logs"})
+		service.SetMemberIndex(staticMemberIndex{
+			{ID: "old.log", Type: "Resource", CreatedAt: now.Add(-48 * time.Hour)},
+			{ID: "new.log", Type: "Resource", CreatedAt: now.Add(-time.Hour)},
+			{ID: "archive", Type: "Container", CreatedAt: now.Add(-48 * time.Hour)},
+			{ID: "undated.log", Type: "Resource"},
+		})
+
+		expired, err := service.ExpiredMembers(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"logs": {"old.log"}}, expired)
+	})
+
+	t.Run("expires nothing without a container lister", func(t *testing.T) {
+		service, _, _ := setupContainerServiceTest()
+		service.SetMemberIndex(staticMemberIndex{{ID: "old.log", Type: "Resource", CreatedAt: now.Add(-48 * time.Hour)}})
+
+		expired, err := service.ExpiredMembers(ctx, now)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+	})
+}
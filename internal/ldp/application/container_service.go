@@ -16,7 +16,8 @@ type ContainerListing struct {
 	ContainerID string                   `json:"containerId"`
 	Members     []string                 `json:"members"`
 	Pagination  domain.PaginationOptions `json:"pagination"`
-	TotalCount  int                      `json:"totalCount,omitempty"`
+	// TotalCount is the container's member count; zero when the repository cannot count members
+	TotalCount int `json:"totalCount,omitempty"`
 }
 
 // EnhancedContainerListing represents a paginated list with filtering and sorting
@@ -35,6 +36,8 @@ type BreadcrumbItem struct {
 	ID    string `json:"id"`
 	Title string `json:"title"`
 	Path  string `json:"path"`
+	// Masked marks an ancestor the caller is not allowed to read
+	Masked bool `json:"masked,omitempty"`
 }
 
 // ContainerInfo represents basic information about a container
@@ -65,12 +68,14 @@ type ContainerTypeInfo struct {
 	IsEmpty       bool     `json:"isEmpty"`
 	AcceptedTypes []string `json:"acceptedTypes"`
 	Capabilities  []string `json:"capabilities"`
+	// Membership holds the membership predicates of a Direct or Indirect container
+	Membership *MembershipRelations `json:"membership,omitempty"`
 }
 
 // MemberInfo represents information about a container member
 type MemberInfo struct {
 	ID   string `json:"id"`
-	Type string `json:"type"` // "Container" or "Resource"
+	Type string `json:"type"` // "Container", "Resource" or "NonRDFSource"
 }
 
 // ContainerStructureInfo represents hierarchical structure information
@@ -79,6 +84,13 @@ type ContainerStructureInfo struct {
 	Members   []MemberInfo             `json:"members"`
 	Children  []ContainerStructureInfo `json:"children"`
 	Depth     int                      `json:"depth"`
+	// Continued marks a container repeated from an earlier page only to hold further children;
+	// its members were returned with it there
+	Continued bool `json:"continued,omitempty"`
+	// Truncated is set on the root when the structure limits were hit; ContinuationToken
+	// fetches the containers left out
+	Truncated         bool   `json:"truncated,omitempty"`
+	ContinuationToken string `json:"continuationToken,omitempty"`
 }
 
 // ContainerService orchestrates container operations with business logic and event handling
@@ -89,6 +101,27 @@ type ContainerService struct {
 	timestampManager   *domain.TimestampManager
 	corruptionDetector *domain.MetadataCorruptionDetector
 	validator          *domain.ContainerValidator
+	rdfNormalizer      domain.RDFNormalizer
+	contentTransforms  *domain.ContentTransformPipeline
+	searchIndex        domain.SearchIndex
+	searchAuthorizer   ContainerReadAuthorizer
+	accessResolver     ContainerAccessResolver
+	writeAuthorizer    ContainerWriteAuthorizer
+	rejectDuplicates   bool
+	rejectCrossPod     bool
+	resourceRepo       domain.ResourceRepository
+	memberIndex        domain.MemberIndexSource
+	structureLimits    StructureLimits
+	listings           asyncListings
+	membershipPolicy   MembershipResourcePolicy
+	dublinCoreLimits   domain.DublinCoreLimits
+	forwarding         domain.ContainerForwarding
+	forwardRetention   time.Duration
+	accessStore        domain.ResourceAccessStore
+	sizeAggregates     sizeAggregates
+	containerLister    domain.ContainerLister
+	identifiers        identifierTracking
+	caches             []domain.CacheInvalidator
 	mu                 sync.RWMutex // For concurrent access handling
 }
 
@@ -114,6 +147,11 @@ func NewContainerService(
 
 // CreateContainer creates a new container with validation and event handling
 func (s *ContainerService) CreateContainer(ctx context.Context, id, parentID string, containerType domain.ContainerType) (*domain.Container, error) {
+	return s.CreateContainerWithOptions(ctx, id, parentID, containerType, CreateContainerOptions{})
+}
+
+// CreateContainerWithOptions creates a new container, applying creation-time options
+func (s *ContainerService) CreateContainerWithOptions(ctx context.Context, id, parentID string, containerType domain.ContainerType, options CreateContainerOptions) (*domain.Container, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -172,6 +210,14 @@ func (s *ContainerService) CreateContainer(ctx context.Context, id, parentID str
 
 	// Create container entity
 	container := domain.NewContainer(ctx, id, parentID, containerType)
+	if options.DisableMembershipEvents {
+		container.SetMembershipEvents(false)
+	}
+
+	// Direct and Indirect containers must say which triples their members produce
+	if err := applyMembershipRelations(container, options.Membership, "CreateContainer"); err != nil {
+		return nil, err
+	}
 
 	// Validate hierarchy to prevent circular references
 	if parentID != "" {
@@ -224,6 +270,8 @@ func (s *ContainerService) CreateContainer(ctx context.Context, id, parentID str
 		fmt.Printf("Successfully processed %d events for container creation %s\n", len(envelopes), id)
 	}
 
+	s.indexContainer(ctx, container)
+
 	return container, nil
 }
 
@@ -258,7 +306,7 @@ func (s *ContainerService) GetContainer(ctx context.Context, id string) (domain.
 }
 
 // UpdateContainer updates an existing container
-func (s *ContainerService) UpdateContainer(ctx context.Context, container *domain.Container) error {
+func (s *ContainerService) UpdateContainer(ctx context.Context, container domain.ContainerResource) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -279,6 +327,20 @@ func (s *ContainerService) UpdateContainer(ctx context.Context, container *domai
 		).WithOperation("UpdateContainer")
 	}
 
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidResource.Code,
+			"invalid container type",
+		).WithOperation("UpdateContainer").WithContext("containerID", container.ID())
+	}
+
+	// A DirectContainer must not be saved pointing at a missing membership resource
+	if err := s.validateMembershipResource(ctx, container); err != nil {
+		return err
+	}
+
 	// Create unit of work for event handling
 	unitOfWork := s.unitOfWorkFactory()
 
@@ -310,6 +372,8 @@ func (s *ContainerService) UpdateContainer(ctx context.Context, container *domai
 		fmt.Printf("Successfully processed %d events for container update %s\n", len(envelopes), container.ID())
 	}
 
+	s.indexContainer(ctx, concreteContainer)
+
 	return nil
 }
 
@@ -323,6 +387,16 @@ func (s *ContainerService) DeleteContainer(ctx context.Context, id string) error
 		return domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("DeleteContainer")
 	}
 
+	// Refuse non-empty containers before loading their member lists when the index can tell
+	if checker, ok := s.containerRepo.(domain.ContainerEmptinessChecker); ok {
+		empty, err := checker.IsContainerEmpty(ctx, id)
+		if err != nil {
+			fmt.Printf("Warning: failed to check emptiness of container %s: %v\n", id, err)
+		} else if !empty {
+			return domain.ErrContainerNotEmpty.WithOperation("DeleteContainer").WithContext("containerID", id)
+		}
+	}
+
 	// Retrieve container to validate deletion
 	container, err := s.containerRepo.GetContainer(ctx, id)
 	if err != nil {
@@ -385,10 +459,21 @@ func (s *ContainerService) DeleteContainer(ctx context.Context, id string) error
 		fmt.Printf("Successfully processed %d events for container deletion %s\n", len(envelopes), id)
 	}
 
+	s.unindex(ctx, id)
+	s.identifiers.release(ctx, id)
+	s.forgetSizeAggregate(id)
+
 	return nil
 }
 
-// AddResource adds a resource to a container
+// SetRejectDuplicateMembers selects whether adding a member the container already holds is
+// refused with a membership conflict instead of succeeding as a no-op
+func (s *ContainerService) SetRejectDuplicateMembers(reject bool) {
+	s.rejectDuplicates = reject
+}
+
+// AddResource adds a resource to a container. Adding a member the container already holds
+// succeeds without emitting events unless duplicate members are rejected.
 func (s *ContainerService) AddResource(ctx context.Context, containerID, resourceID string, resource domain.Resource) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -402,6 +487,14 @@ func (s *ContainerService) AddResource(ctx context.Context, containerID, resourc
 		).WithOperation("AddResource")
 	}
 
+	if resourceID == "" {
+		return domain.WrapStorageError(
+			fmt.Errorf("resource ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"resource ID cannot be empty",
+		).WithOperation("AddResource")
+	}
+
 	if resource == nil {
 		return domain.WrapStorageError(
 			fmt.Errorf("resource cannot be nil"),
@@ -441,6 +534,27 @@ func (s *ContainerService) AddResource(ctx context.Context, containerID, resourc
 		).WithOperation("AddResource").WithContext("containerID", containerID)
 	}
 
+	if concreteContainer.HasMember(resourceID) {
+		if s.rejectDuplicates {
+			return domain.WrapStorageError(
+				fmt.Errorf("member already exists"),
+				domain.ErrMembershipConflict.Code,
+				"member already exists",
+			).WithOperation("AddResource").WithContext("containerID", containerID).WithContext("resourceID", resourceID)
+		}
+		return nil
+	}
+
+	// Re-check the membership resource so new members never produce dangling membership triples
+	if err := s.validateMembershipResource(ctx, concreteContainer); err != nil {
+		return err
+	}
+
+	// Keep the member inside the container's pod unless the container accepts external members
+	if err := s.checkMemberPod(ctx, concreteContainer, resourceID, "AddResource"); err != nil {
+		return err
+	}
+
 	// Use the new AddMember method that accepts Resource entity
 	if err := concreteContainer.AddMember(ctx, resource); err != nil {
 		return domain.WrapStorageError(
@@ -450,6 +564,17 @@ func (s *ContainerService) AddResource(ctx context.Context, containerID, resourc
 		).WithOperation("AddResource").WithContext("containerID", containerID).WithContext("resourceID", resourceID)
 	}
 
+	// Containers with membership events disabled store the member without touching the event log
+	if !domain.MembershipEventsEnabled(concreteContainer.GetMetadata()) {
+		if err := s.addMemberWithoutEvents(ctx, concreteContainer, resourceID); err != nil {
+			return err
+		}
+		s.indexMembership(ctx, containerID, resource)
+		s.recordMemberDetails(ctx, containerID, resource)
+		s.sizeAggregateMemberAdded(containerID, int64(resource.GetSize()))
+		return nil
+	}
+
 	// Create unit of work for event handling
 	unitOfWork := s.unitOfWorkFactory()
 
@@ -479,6 +604,10 @@ func (s *ContainerService) AddResource(ctx context.Context, containerID, resourc
 		fmt.Printf("Successfully processed %d events for adding resource %s to container %s\n", len(envelopes), resourceID, containerID)
 	}
 
+	s.indexMembership(ctx, containerID, resource)
+	s.recordMemberDetails(ctx, containerID, resource)
+	s.sizeAggregateMemberAdded(containerID, int64(resource.GetSize()))
+
 	return nil
 }
 
@@ -497,6 +626,17 @@ func (s *ContainerService) RemoveResource(ctx context.Context, containerID, reso
 		return domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("RemoveResource")
 	}
 
+	// Containers with membership events disabled drop the member without touching the event log
+	removed, err := s.removeMemberWithoutEvents(ctx, containerID, resourceID)
+	if err != nil {
+		return err
+	}
+	if removed {
+		s.unindexMembership(ctx, resourceID)
+		s.sizeAggregateChanged(ctx, containerID)
+		return nil
+	}
+
 	// Create unit of work for event handling
 	unitOfWork := s.unitOfWorkFactory()
 
@@ -527,6 +667,9 @@ func (s *ContainerService) RemoveResource(ctx context.Context, containerID, reso
 		fmt.Printf("Successfully processed %d events for removing resource %s from container %s\n", len(envelopes), resourceID, containerID)
 	}
 
+	s.unindexMembership(ctx, resourceID)
+	s.sizeAggregateChanged(ctx, containerID)
+
 	return nil
 }
 
@@ -549,8 +692,15 @@ func (s *ContainerService) ListContainerMembers(ctx context.Context, containerID
 		pagination = domain.GetDefaultPagination()
 	}
 
-	// List members from repository
-	members, err := s.containerRepo.ListMembers(ctx, containerID, pagination)
+	// List members from repository, with the total count when the repository can provide it
+	var members []string
+	var totalCount int
+	var err error
+	if lister, ok := s.containerRepo.(domain.CountedMemberLister); ok {
+		members, totalCount, err = lister.ListMembersWithTotal(ctx, containerID, pagination)
+	} else {
+		members, err = s.containerRepo.ListMembers(ctx, containerID, pagination)
+	}
 	if err != nil {
 		return nil, domain.WrapStorageError(
 			err,
@@ -564,6 +714,7 @@ func (s *ContainerService) ListContainerMembers(ctx context.Context, containerID
 		ContainerID: containerID,
 		Members:     members,
 		Pagination:  pagination,
+		TotalCount:  totalCount,
 	}
 
 	return listing, nil
@@ -700,6 +851,9 @@ func (s *ContainerService) GetParent(ctx context.Context, containerID string) (*
 			"failed to get container parent",
 		).WithOperation("GetParent").WithContext("containerID", containerID)
 	}
+	if parent == nil {
+		return nil, nil // Root container
+	}
 
 	// Type assert to concrete type
 	concreteParent, ok := parent.(*domain.Container)
@@ -741,6 +895,12 @@ func (s *ContainerService) ContainerExists(ctx context.Context, id string) (bool
 
 // GetContainerWithFormat retrieves a container and converts it to the specified RDF format
 func (s *ContainerService) GetContainerWithFormat(ctx context.Context, id, format, baseURI string) ([]byte, error) {
+	return s.GetContainerWithPreference(ctx, id, format, baseURI, domain.ContainerPreference{})
+}
+
+// GetContainerWithPreference retrieves a container and converts it to the specified RDF format,
+// leaving out the containment or membership triples the LDP preference omits
+func (s *ContainerService) GetContainerWithPreference(ctx context.Context, id, format, baseURI string, preference domain.ContainerPreference) ([]byte, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -792,14 +952,13 @@ func (s *ContainerService) GetContainerWithFormat(ctx context.Context, id, forma
 		).WithOperation("GetContainerWithFormat").WithContext("containerID", id)
 	}
 
+	// Avoid emitting empty Dublin Core dates for containers restored from partial data
+	s.timestampManager.FillMissingTimestamps(concreteContainer)
+
 	// Convert to requested format
 	switch format {
-	case "text/turtle":
-		return s.rdfConverter.ConvertToTurtle(concreteContainer, baseURI)
-	case "application/ld+json":
-		return s.rdfConverter.ConvertToJSONLD(concreteContainer, baseURI)
-	case "application/rdf+xml":
-		return s.rdfConverter.ConvertToRDFXML(concreteContainer, baseURI)
+	case "text/turtle", "application/ld+json", "application/rdf+xml", "application/n-triples", infrastructure.JSONLDExpandedFormat:
+		return s.rdfConverter.ConvertWithPreference(concreteContainer, format, baseURI, preference)
 	default:
 		return nil, domain.WrapStorageError(
 			fmt.Errorf("unsupported format: %s", format),
@@ -814,13 +973,44 @@ func (s *ContainerService) ListContainerMembersWithFormat(ctx context.Context, c
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	concreteContainer, err := s.loadListingContainer(ctx, containerID, format, baseURI, "ListContainerMembersWithFormat")
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate pagination options
+	if !pagination.IsValid() {
+		pagination = domain.GetDefaultPagination()
+	}
+
+	// Convert to requested format (this will include membership triples)
+	switch format {
+	case "text/turtle":
+		return s.rdfConverter.ConvertToTurtle(concreteContainer, baseURI)
+	case "application/ld+json":
+		return s.rdfConverter.ConvertToJSONLD(concreteContainer, baseURI)
+	case "application/rdf+xml":
+		return s.rdfConverter.ConvertToRDFXML(concreteContainer, baseURI)
+	case "application/n-triples":
+		return s.rdfConverter.ConvertToNTriples(concreteContainer, baseURI)
+	default:
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("unsupported format: %s", format),
+			domain.ErrInvalidFormat.Code,
+			"unsupported RDF format",
+		).WithOperation("ListContainerMembersWithFormat").WithContext("format", format)
+	}
+}
+
+// loadListingContainer validates the arguments of an RDF listing and loads the container to list
+func (s *ContainerService) loadListingContainer(ctx context.Context, containerID, format, baseURI, operation string) (*domain.Container, error) {
 	// Validate input
 	if containerID == "" {
 		return nil, domain.WrapStorageError(
 			fmt.Errorf("container ID cannot be empty"),
 			domain.ErrInvalidID.Code,
 			"container ID cannot be empty",
-		).WithOperation("ListContainerMembersWithFormat")
+		).WithOperation(operation)
 	}
 
 	if format == "" {
@@ -828,7 +1018,7 @@ func (s *ContainerService) ListContainerMembersWithFormat(ctx context.Context, c
 			fmt.Errorf("format cannot be empty"),
 			domain.ErrInvalidID.Code,
 			"format cannot be empty",
-		).WithOperation("ListContainerMembersWithFormat")
+		).WithOperation(operation)
 	}
 
 	if baseURI == "" {
@@ -836,25 +1026,20 @@ func (s *ContainerService) ListContainerMembersWithFormat(ctx context.Context, c
 			fmt.Errorf("base URI cannot be empty"),
 			domain.ErrInvalidID.Code,
 			"base URI cannot be empty",
-		).WithOperation("ListContainerMembersWithFormat")
-	}
-
-	// Validate pagination options
-	if !pagination.IsValid() {
-		pagination = domain.GetDefaultPagination()
+		).WithOperation(operation)
 	}
 
 	// Get container from repository to access its members
 	container, err := s.containerRepo.GetContainer(ctx, containerID)
 	if err != nil {
 		if domain.IsResourceNotFound(err) {
-			return nil, domain.ErrResourceNotFound.WithOperation("ListContainerMembersWithFormat").WithContext("containerID", containerID)
+			return nil, domain.ErrResourceNotFound.WithOperation(operation).WithContext("containerID", containerID)
 		}
 		return nil, domain.WrapStorageError(
 			err,
 			domain.ErrStorageOperation.Code,
 			"failed to retrieve container for member listing",
-		).WithOperation("ListContainerMembersWithFormat").WithContext("containerID", containerID)
+		).WithOperation(operation).WithContext("containerID", containerID)
 	}
 
 	// Type assert to concrete type for RDF converter
@@ -864,24 +1049,13 @@ func (s *ContainerService) ListContainerMembersWithFormat(ctx context.Context, c
 			fmt.Errorf("invalid container type"),
 			domain.ErrInvalidResource.Code,
 			"invalid container type",
-		).WithOperation("ListContainerMembersWithFormat").WithContext("containerID", containerID)
+		).WithOperation(operation).WithContext("containerID", containerID)
 	}
 
-	// Convert to requested format (this will include membership triples)
-	switch format {
-	case "text/turtle":
-		return s.rdfConverter.ConvertToTurtle(concreteContainer, baseURI)
-	case "application/ld+json":
-		return s.rdfConverter.ConvertToJSONLD(concreteContainer, baseURI)
-	case "application/rdf+xml":
-		return s.rdfConverter.ConvertToRDFXML(concreteContainer, baseURI)
-	default:
-		return nil, domain.WrapStorageError(
-			fmt.Errorf("unsupported format: %s", format),
-			domain.ErrInvalidFormat.Code,
-			"unsupported RDF format",
-		).WithOperation("ListContainerMembersWithFormat").WithContext("format", format)
-	}
+	// Avoid emitting empty Dublin Core dates for containers restored from partial data
+	s.timestampManager.FillMissingTimestamps(concreteContainer)
+
+	return concreteContainer, nil
 }
 
 // GenerateMembershipTriples generates LDP membership triples for a container
@@ -944,6 +1118,15 @@ func (s *ContainerService) ListContainerMembersEnhanced(ctx context.Context, con
 		return nil, domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("ListContainerMembersEnhanced")
 	}
 
+	// Fall back to the container's default sort, then the global one, when none is requested
+	if options.Sort == (domain.SortOptions{}) {
+		defaults, err := s.defaultListingOptions(ctx, containerID)
+		if err != nil {
+			return nil, err
+		}
+		options.Sort = defaults.Sort
+	}
+
 	// Validate listing options
 	if err := s.validator.ValidateListingOptions(options); err != nil {
 		return nil, domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("ListContainerMembersEnhanced")
@@ -963,20 +1146,40 @@ func (s *ContainerService) ListContainerMembersEnhanced(ctx context.Context, con
 		return nil, domain.ErrResourceNotFound.WithOperation("ListContainerMembersEnhanced").WithContext("containerID", containerID)
 	}
 
-	// For now, use basic listing (enhanced filtering would require repository interface updates)
-	// This is a placeholder for the enhanced implementation
+	// Filter, sort and count in the membership index when the repository supports it
+	if lister, ok := s.containerRepo.(FilteredMemberLister); ok {
+		members, total, filtered, err := lister.ListMembersFiltered(ctx, containerID, options)
+		if err != nil {
+			return nil, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to list container members",
+			).WithOperation("ListContainerMembersEnhanced").WithContext("containerID", containerID)
+		}
+
+		return &EnhancedContainerListing{
+			ContainerID:   containerID,
+			Members:       members,
+			Pagination:    options.Pagination,
+			Filter:        options.Filter,
+			Sort:          options.Sort,
+			TotalCount:    total,
+			FilteredCount: filtered,
+		}, nil
+	}
+
+	// Otherwise list the page unfiltered; beyond their indexed types, member details are not known
 	basicListing, err := s.ListContainerMembers(ctx, containerID, options.Pagination)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to enhanced listing format
-	// In a full implementation, this would use the membership indexer with filtering
+	types := s.memberTypes(ctx, containerID, basicListing.Members)
 	members := make([]infrastructure.MemberInfo, len(basicListing.Members))
 	for i, memberID := range basicListing.Members {
 		members[i] = infrastructure.MemberInfo{
 			ID:          memberID,
-			Type:        infrastructure.ResourceTypeResource, // Default type
+			Type:        memberType(types, memberID),
 			ContentType: "application/octet-stream",
 			Size:        0,
 			CreatedAt:   time.Now(),
@@ -1024,13 +1227,13 @@ func (s *ContainerService) GetContainerStats(ctx context.Context, containerID st
 		).WithOperation("GetContainerStats").WithContext("containerID", containerID)
 	}
 
-	// Get member count from repository instead of container.Members
-	members, err := s.containerRepo.ListMembers(ctx, containerID, domain.PaginationOptions{Limit: 1000, Offset: 0})
+	// Member count and total size come from the cached aggregate, computed on first request
+	aggregate, err := s.sizeAggregate(ctx, containerID)
 	if err != nil {
 		return nil, domain.WrapStorageError(
 			err,
 			domain.ErrStorageOperation.Code,
-			"failed to get member count for stats",
+			"failed to compute container size aggregate for stats",
 		).WithOperation("GetContainerStats").WithContext("containerID", containerID)
 	}
 
@@ -1049,10 +1252,27 @@ func (s *ContainerService) GetContainerStats(ctx context.Context, containerID st
 	stats["container_id"] = containerID
 	stats["container_type"] = concreteContainer.ContainerType.String()
 	stats["parent_id"] = concreteContainer.ParentID
-	stats["member_count"] = len(members)
-	stats["is_empty"] = len(members) == 0
+	stats["member_count"] = aggregate.MemberCount
+	stats["is_empty"] = aggregate.MemberCount == 0
+	stats["total_size"] = aggregate.TotalBytes
+	stats["size_computed_at"] = aggregate.ComputedAt
 	stats["created_at"] = concreteContainer.GetMetadata()["createdAt"]
 	stats["updated_at"] = concreteContainer.GetMetadata()["updatedAt"]
+	if s.timestampManager.FillMissingTimestamps(concreteContainer) {
+		stats["created_at"] = concreteContainer.GetMetadata()["createdAt"]
+		stats["updated_at"] = concreteContainer.GetMetadata()["updatedAt"]
+		stats["timestamps_derived"] = true
+	}
+
+	// Report the latest read of any member when reads are tracked
+	if s.accessStore != nil {
+		lastAccessed, err := s.accessStore.ContainerLastAccessed(ctx, containerID)
+		if err != nil {
+			fmt.Printf("Warning: failed to read last access of container %s: %v\n", containerID, err)
+		} else if !lastAccessed.IsZero() {
+			stats["last_accessed_at"] = lastAccessed
+		}
+	}
 
 	// Add title and description if available
 	if title := concreteContainer.GetTitle(); title != "" {
@@ -1118,10 +1338,11 @@ func (s *ContainerService) StreamContainerMembers(ctx context.Context, container
 				}
 
 				// Stream members from this page
+				types := s.memberTypes(ctx, containerID, listing.Members)
 				for _, memberID := range listing.Members {
 					member := infrastructure.MemberInfo{
 						ID:          memberID,
-						Type:        infrastructure.ResourceTypeResource,
+						Type:        memberType(types, memberID),
 						ContentType: "application/octet-stream",
 						Size:        0,
 						CreatedAt:   time.Now(),
@@ -1151,6 +1372,11 @@ func (s *ContainerService) StreamContainerMembers(ctx context.Context, container
 
 // SetContainerDublinCoreMetadata sets Dublin Core metadata on a container
 func (s *ContainerService) SetContainerDublinCoreMetadata(ctx context.Context, containerID string, dc domain.DublinCoreMetadata) error {
+	dc, err := limitDublinCore(s.dublinCoreLimits, dc, containerID, "SetContainerDublinCoreMetadata")
+	if err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -1166,6 +1392,11 @@ func (s *ContainerService) SetContainerDublinCoreMetadata(ctx context.Context, c
 		return fmt.Errorf("invalid container type")
 	}
 
+	// Refuse an identifier already held within the account before changing anything
+	if err := s.identifiers.claim(ctx, domain.AccountIDFromContext(ctx), containerID, dc.Identifier, "SetContainerDublinCoreMetadata"); err != nil {
+		return err
+	}
+
 	// Set Dublin Core metadata
 	concreteContainer.SetDublinCoreMetadata(dc)
 
@@ -1196,6 +1427,8 @@ func (s *ContainerService) SetContainerDublinCoreMetadata(ctx context.Context, c
 	// Mark events as committed
 	concreteContainer.ClearEvents()
 
+	s.indexContainer(ctx, concreteContainer)
+
 	return nil
 }
 
@@ -1446,6 +1679,10 @@ func (s *ContainerService) AddResourceWithTimestamp(ctx context.Context, contain
 		return fmt.Errorf("invalid container type")
 	}
 
+	if err := s.checkMemberPod(ctx, concreteContainer, resourceID, "AddResourceWithTimestamp"); err != nil {
+		return err
+	}
+
 	// Add member with timestamp management
 	if err := concreteContainer.AddMemberWithTimestamp(resourceID, s.timestampManager); err != nil {
 		return fmt.Errorf("failed to add member with timestamp: %w", err)
@@ -1480,6 +1717,8 @@ func (s *ContainerService) AddResourceWithTimestamp(ctx context.Context, contain
 	// Mark events as committed
 	concreteContainer.ClearEvents()
 
+	s.sizeAggregateChanged(ctx, containerID)
+
 	return nil
 }
 
@@ -1534,6 +1773,8 @@ func (s *ContainerService) RemoveResourceWithTimestamp(ctx context.Context, cont
 	// Mark events as committed
 	concreteContainer.ClearEvents()
 
+	s.sizeAggregateChanged(ctx, containerID)
+
 	return nil
 }
 
@@ -1731,23 +1972,50 @@ func (s *ContainerService) GetContainerTypeInfo(ctx context.Context, containerID
 		MemberCount:   len(members),
 		ChildCount:    len(children),
 		IsEmpty:       len(members) == 0,
-		AcceptedTypes: []string{"*/*"}, // BasicContainer accepts all types
+		AcceptedTypes: []string{"*/*"},
 		Capabilities:  []string{"create", "read", "update", "delete", "list"},
 	}
 
+	if container.GetContainerType().HasMembershipTriples() {
+		metadata := container.GetMetadata()
+		typeInfo.Membership = &MembershipRelations{
+			MembershipResource:      domain.MembershipResource(metadata),
+			HasMemberRelation:       domain.HasMemberRelation(metadata),
+			InsertedContentRelation: domain.InsertedContentRelation(metadata),
+		}
+		typeInfo.Capabilities = append(typeInfo.Capabilities, "membership")
+
+		// Members must be RDF that states the inserted content unless they are the content themselves
+		if domain.EffectiveInsertedContentRelation(container) != domain.LDPMemberSubject {
+			typeInfo.AcceptedTypes = []string{"text/turtle", "application/n-triples", "application/ld+json"}
+		}
+	}
+
 	return typeInfo, nil
 }
 
-// GenerateStructureInfo generates machine-readable hierarchical structure information
+// GenerateStructureInfo generates machine-readable hierarchical structure information. Once the
+// structure limits are reached the remaining containers are left out and the root is marked
+// truncated, with a token for ContinueStructureInfo.
 func (s *ContainerService) GenerateStructureInfo(ctx context.Context, containerID string, maxDepth int) (*ContainerStructureInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.generateStructureInfoRecursive(ctx, containerID, 0, maxDepth)
+	budget := newStructureBudget(s.structureLimits)
+	info, err := s.generateStructureInfoRecursive(ctx, containerID, 0, maxDepth, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := budget.finish(info, containerID, maxDepth); err != nil {
+		return nil, err
+	}
+	return info, nil
 }
 
-// generateStructureInfoRecursive is a helper method for recursive structure generation
-func (s *ContainerService) generateStructureInfoRecursive(ctx context.Context, containerID string, currentDepth, maxDepth int) (*ContainerStructureInfo, error) {
+// generateStructureInfoRecursive is a helper method for recursive structure generation. Children
+// not visited before the budget runs out are deferred to the budget instead.
+func (s *ContainerService) generateStructureInfoRecursive(ctx context.Context, containerID string, currentDepth, maxDepth int, budget *structureBudget) (*ContainerStructureInfo, error) {
 	// Validate input
 	if containerID == "" {
 		return nil, domain.WrapStorageError(
@@ -1788,14 +2056,13 @@ func (s *ContainerService) generateStructureInfoRecursive(ctx context.Context, c
 		).WithOperation("GenerateStructureInfo").WithContext("containerID", containerID)
 	}
 
-	// Convert member IDs to MemberInfo
+	// Convert member IDs to MemberInfo, typed as the membership index recorded them
+	types := s.memberTypes(ctx, containerID, memberIDs)
 	members := make([]MemberInfo, len(memberIDs))
 	for i, memberID := range memberIDs {
-		// For now, assume all members are resources
-		// In a full implementation, we would check if the member is a container
 		members[i] = MemberInfo{
 			ID:   memberID,
-			Type: "Resource",
+			Type: string(memberType(types, memberID)),
 		}
 	}
 
@@ -1811,16 +2078,22 @@ func (s *ContainerService) generateStructureInfoRecursive(ctx context.Context, c
 			).WithOperation("GenerateStructureInfo").WithContext("containerID", containerID)
 		}
 
-		// Recursively generate structure info for children
-		childrenInfo = make([]ContainerStructureInfo, len(children))
+		// Recursively generate structure info for children, deferring those past the limits
+		budget.charge(containerInfo, members)
+		childrenInfo = make([]ContainerStructureInfo, 0, len(children))
 		for i, child := range children {
-			childInfo, err := s.generateStructureInfoRecursive(ctx, child.ID(), currentDepth+1, maxDepth)
+			if budget.exhausted() {
+				budget.postpone(containerID, i, currentDepth+1)
+				break
+			}
+			childInfo, err := s.generateStructureInfoRecursive(ctx, child.ID(), currentDepth+1, maxDepth, budget)
 			if err != nil {
 				return nil, err
 			}
-			childrenInfo[i] = *childInfo
+			childrenInfo = append(childrenInfo, *childInfo)
 		}
 	} else {
+		budget.charge(containerInfo, members)
 		childrenInfo = []ContainerStructureInfo{}
 	}
 
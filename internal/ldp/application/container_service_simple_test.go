@@ -152,13 +152,13 @@ func TestContainerService_DeleteContainer_Logic(t *testing.T) {
 
 	t.Run("container not empty", func(t *testing.T) {
 		container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
-		container.AddMember("member1") // Make container non-empty
+		container.AddMember(context.Background(), domain.NewResource(context.Background(), "member1", "text/plain", nil)) // Make container non-empty
 
 		mockRepo.On("GetContainer", ctx, "test-container").Return(container, nil)
 
 		err := service.DeleteContainer(ctx, "test-container")
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not empty")
+		assert.True(t, domain.IsContainerNotEmpty(err))
 
 		mockRepo.AssertExpectations(t)
 	})
@@ -169,13 +169,13 @@ func TestContainerService_MembershipOperations_Logic(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("add resource - empty container ID", func(t *testing.T) {
-		err := service.AddResource(ctx, "", "resource1")
+		err := service.AddResource(ctx, "", "resource1", domain.NewResource(ctx, "resource1", "text/plain", nil))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "container ID cannot be empty")
 	})
 
 	t.Run("add resource - empty resource ID", func(t *testing.T) {
-		err := service.AddResource(ctx, "container1", "")
+		err := service.AddResource(ctx, "container1", "", domain.NewResource(ctx, "", "text/plain", nil))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "resource ID cannot be empty")
 	})
@@ -189,7 +189,7 @@ func TestContainerService_MembershipOperations_Logic(t *testing.T) {
 	t.Run("remove resource - empty resource ID", func(t *testing.T) {
 		err := service.RemoveResource(ctx, "container1", "")
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "resource ID cannot be empty")
+		assert.Contains(t, err.Error(), "member ID cannot be empty")
 	})
 }
 
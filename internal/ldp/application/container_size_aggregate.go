@@ -0,0 +1,159 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ContainerSizeAggregate is a container's member count and the total size of its members
+type ContainerSizeAggregate struct {
+	MemberCount int
+	// TotalBytes sums member sizes as recorded in the membership index; zero without one
+	TotalBytes int64
+	ComputedAt time.Time
+}
+
+// sizeAggregates caches container size aggregates between membership writes. In lazy mode a
+// write drops the container's aggregate and the next stats read recomputes it; in eager mode
+// writes keep the cached aggregate current so reads never scan the membership.
+type sizeAggregates struct {
+	eager   bool
+	entries map[string]ContainerSizeAggregate
+	mu      sync.Mutex
+}
+
+// SetEagerSizeAggregates selects whether container size aggregates are maintained on every
+// membership write instead of being computed lazily on the next stats read
+func (s *ContainerService) SetEagerSizeAggregates(eager bool) {
+	s.sizeAggregates.mu.Lock()
+	defer s.sizeAggregates.mu.Unlock()
+	s.sizeAggregates.eager = eager
+}
+
+// sizeAggregate returns the cached size aggregate of a container, computing and caching it
+// when there is none
+func (s *ContainerService) sizeAggregate(ctx context.Context, containerID string) (ContainerSizeAggregate, error) {
+	s.sizeAggregates.mu.Lock()
+	aggregate, ok := s.sizeAggregates.entries[containerID]
+	s.sizeAggregates.mu.Unlock()
+	if ok {
+		return aggregate, nil
+	}
+
+	aggregate, err := s.computeSizeAggregate(ctx, containerID)
+	if err != nil {
+		return ContainerSizeAggregate{}, err
+	}
+	s.storeSizeAggregate(containerID, aggregate)
+	return aggregate, nil
+}
+
+// computeSizeAggregate scans a container's members. Sizes come from the membership index;
+// without one only the member count is known.
+func (s *ContainerService) computeSizeAggregate(ctx context.Context, containerID string) (ContainerSizeAggregate, error) {
+	aggregate := ContainerSizeAggregate{ComputedAt: time.Now()}
+
+	if s.memberIndex != nil {
+		members, err := s.memberIndex.ListIndexedMembers(ctx, containerID)
+		if err != nil {
+			return ContainerSizeAggregate{}, err
+		}
+		aggregate.MemberCount = len(members)
+		for _, member := range members {
+			aggregate.TotalBytes += member.Size
+		}
+		return aggregate, nil
+	}
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return ContainerSizeAggregate{}, err
+	}
+	aggregate.MemberCount = len(container.GetMembers())
+	return aggregate, nil
+}
+
+// storeSizeAggregate caches a container's size aggregate
+func (s *ContainerService) storeSizeAggregate(containerID string, aggregate ContainerSizeAggregate) {
+	s.sizeAggregates.mu.Lock()
+	defer s.sizeAggregates.mu.Unlock()
+	if s.sizeAggregates.entries == nil {
+		s.sizeAggregates.entries = make(map[string]ContainerSizeAggregate)
+	}
+	s.sizeAggregates.entries[containerID] = aggregate
+}
+
+// forgetSizeAggregate drops a container's cached size aggregate
+func (s *ContainerService) forgetSizeAggregate(containerID string) {
+	s.sizeAggregates.mu.Lock()
+	defer s.sizeAggregates.mu.Unlock()
+	delete(s.sizeAggregates.entries, containerID)
+}
+
+// dropSizeAggregates drops the cached size aggregates of the given containers and reports how
+// many were cached
+func (s *ContainerService) dropSizeAggregates(containerIDs ...string) int {
+	s.sizeAggregates.mu.Lock()
+	defer s.sizeAggregates.mu.Unlock()
+
+	dropped := 0
+	for _, containerID := range containerIDs {
+		if _, ok := s.sizeAggregates.entries[containerID]; ok {
+			delete(s.sizeAggregates.entries, containerID)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// dropAllSizeAggregates drops every cached size aggregate and reports how many were cached
+func (s *ContainerService) dropAllSizeAggregates() int {
+	s.sizeAggregates.mu.Lock()
+	defer s.sizeAggregates.mu.Unlock()
+
+	dropped := len(s.sizeAggregates.entries)
+	s.sizeAggregates.entries = nil
+	return dropped
+}
+
+// sizeAggregateMemberAdded accounts for a member added to a container. Eager mode adds the
+// member to a cached aggregate; a container without one is computed on its next read.
+func (s *ContainerService) sizeAggregateMemberAdded(containerID string, size int64) {
+	s.sizeAggregates.mu.Lock()
+	defer s.sizeAggregates.mu.Unlock()
+
+	aggregate, ok := s.sizeAggregates.entries[containerID]
+	if !ok {
+		return
+	}
+	if !s.sizeAggregates.eager {
+		delete(s.sizeAggregates.entries, containerID)
+		return
+	}
+
+	aggregate.MemberCount++
+	aggregate.TotalBytes += size
+	aggregate.ComputedAt = time.Now()
+	s.sizeAggregates.entries[containerID] = aggregate
+}
+
+// sizeAggregateChanged accounts for a membership change whose size effect is unknown, such
+// as a removal. Eager mode recomputes the aggregate right away; lazy mode drops it.
+func (s *ContainerService) sizeAggregateChanged(ctx context.Context, containerID string) {
+	s.sizeAggregates.mu.Lock()
+	eager := s.sizeAggregates.eager
+	s.sizeAggregates.mu.Unlock()
+
+	s.forgetSizeAggregate(containerID)
+	if !eager {
+		return
+	}
+
+	aggregate, err := s.computeSizeAggregate(ctx, containerID)
+	if err != nil {
+		// Left uncached, the aggregate is computed on the next read instead
+		return
+	}
+	s.storeSizeAggregate(containerID, aggregate)
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// countingMemberIndex is a MemberIndexSource that counts how often members are listed
+type countingMemberIndex struct {
+	members []domain.IndexedMember
+	scans   int
+}
+
+func (c *countingMemberIndex) ListIndexedMembers(ctx context.Context, containerID string) ([]domain.IndexedMember, error) {
+	c.scans++
+	return c.members, nil
+}
+
+func newSizeAggregateTestService(index *countingMemberIndex) *ContainerService {
+	container := domain.NewContainer(context.Background(), "photos", "", domain.BasicContainer)
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "photos").Return(container, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+	service.SetMemberIndex(index)
+	return service
+}
+
+func TestContainerService_GetContainerStats_LazySizeAggregate(t *testing.T) {
+	ctx := context.Background()
+	index := &countingMemberIndex{members: []domain.IndexedMember{{ID: "a.jpg", Size: 10}, {ID: "b.jpg", Size: 20}}}
+	service := newSizeAggregateTestService(index)
+
+	stats, err := service.GetContainerStats(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats["member_count"])
+	assert.Equal(t, int64(30), stats["total_size"])
+
+	// Served from the cache until a write invalidates it
+	index.members = append(index.members, domain.IndexedMember{ID: "c.jpg", Size: 5})
+	stats, err = service.GetContainerStats(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), stats["total_size"])
+	assert.Equal(t, 1, index.scans)
+
+	service.sizeAggregateMemberAdded("photos", 5)
+	assert.Equal(t, 1, index.scans, "lazy mode does no work on writes")
+
+	stats, err = service.GetContainerStats(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats["member_count"])
+	assert.Equal(t, int64(35), stats["total_size"])
+	assert.Equal(t, 2, index.scans)
+}
+
+func TestContainerService_GetContainerStats_EagerSizeAggregate(t *testing.T) {
+	ctx := context.Background()
+	index := &countingMemberIndex{members: []domain.IndexedMember{{ID: "a.jpg", Size: 10}, {ID: "b.jpg", Size: 20}}}
+	service := newSizeAggregateTestService(index)
+	service.SetEagerSizeAggregates(true)
+
+	_, err := service.GetContainerStats(ctx, "photos")
+	require.NoError(t, err)
+
+	// Additions are applied to the cached aggregate without a scan
+	service.sizeAggregateMemberAdded("photos", 5)
+	stats, err := service.GetContainerStats(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats["member_count"])
+	assert.Equal(t, int64(35), stats["total_size"])
+	assert.Equal(t, 1, index.scans)
+
+	// Removals recompute at write time, so the next read is still served from the cache
+	index.members = index.members[:1]
+	service.sizeAggregateChanged(ctx, "photos")
+	assert.Equal(t, 2, index.scans)
+
+	stats, err = service.GetContainerStats(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats["member_count"])
+	assert.Equal(t, int64(10), stats["total_size"])
+	assert.Equal(t, 2, index.scans)
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
-	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -70,12 +69,7 @@ func (s *ContainerMemberStream) Close() {
 func TestStreamingBasicFunctionality(t *testing.T) {
 	t.Skip("Skipping streaming test - requires complex mock setup")
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with members
 	containerID := "streaming-test-container"
@@ -87,7 +81,7 @@ func TestStreamingBasicFunctionality(t *testing.T) {
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%03d", i)
 		expectedMembers[i] = memberID
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -152,12 +146,7 @@ streamComplete:
 // TestStreamingWithPagination tests streaming with pagination
 func TestStreamingWithPagination(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with members
 	containerID := "streaming-pagination-test"
@@ -167,7 +156,7 @@ func TestStreamingWithPagination(t *testing.T) {
 	memberCount := 250
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%03d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -196,12 +185,7 @@ func TestStreamingWithPagination(t *testing.T) {
 // TestStreamingMemoryEfficiency tests that streaming doesn't load all data into memory
 func TestStreamingMemoryEfficiency(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with many members
 	containerID := "memory-efficiency-test"
@@ -211,7 +195,7 @@ func TestStreamingMemoryEfficiency(t *testing.T) {
 	memberCount := 1000
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%04d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -253,8 +237,10 @@ func TestStreamingWithCancellation(t *testing.T) {
 	stream := NewContainerMemberStream(ctx)
 	defer stream.Close()
 
-	// Start streaming
+	// Start streaming; the producer must stop before the deferred Close closes its channels
+	producerDone := make(chan struct{})
 	go func() {
+		defer close(producerDone)
 		for i := 0; i < 1000; i++ {
 			select {
 			case stream.members <- infrastructure.MemberInfo{
@@ -299,6 +285,7 @@ func TestStreamingWithCancellation(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 		t.Fatal("Stream did not cancel in time")
 	}
+	<-producerDone
 
 	assert.Greater(t, receivedCount, 0)
 	assert.Less(t, receivedCount, 1000) // Should not have received all members
@@ -359,6 +346,18 @@ func TestStreamingErrorHandling(t *testing.T) {
 	}
 
 streamComplete:
+	// The channels are buffered, so done can arrive before everything sent ahead of it is read
+	for drained := false; !drained; {
+		select {
+		case member := <-stream.Members():
+			receivedMembers = append(receivedMembers, member)
+		case err := <-stream.Errors():
+			receivedErrors = append(receivedErrors, err)
+		default:
+			drained = true
+		}
+	}
+
 	// Verify we received members and errors
 	assert.Equal(t, 9, len(receivedMembers)) // 10 total - 1 error
 	assert.Equal(t, 1, len(receivedErrors))
@@ -368,12 +367,7 @@ streamComplete:
 // TestStreamingLargeContainerRDFConversion tests streaming RDF conversion for large containers
 func TestStreamingLargeContainerRDFConversion(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with many members
 	containerID := "large-rdf-test"
@@ -383,7 +377,7 @@ func TestStreamingLargeContainerRDFConversion(t *testing.T) {
 	memberCount := 500
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%04d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -412,12 +406,7 @@ func TestStreamingLargeContainerRDFConversion(t *testing.T) {
 // TestStreamingConcurrentAccess tests concurrent streaming access
 func TestStreamingConcurrentAccess(t *testing.T) {
 	ctx := context.Background()
-	containerRepo := &MockContainerRepository{}
-	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
-		return &MockUnitOfWork{}
-	}
-	rdfConverter := &infrastructure.ContainerRDFConverter{}
-	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service, containerRepo := newProjectedContainerService(t)
 
 	// Create container with members
 	containerID := "concurrent-streaming-test"
@@ -427,7 +416,7 @@ func TestStreamingConcurrentAccess(t *testing.T) {
 	memberCount := 200
 	for i := 0; i < memberCount; i++ {
 		memberID := fmt.Sprintf("member-%03d", i)
-		err := service.AddResource(ctx, containerID, memberID)
+		err := storeAndAddMember(ctx, service, containerRepo, containerID, memberID)
 		require.NoError(t, err)
 	}
 
@@ -479,7 +468,7 @@ func TestStreamingConcurrentAccess(t *testing.T) {
 
 // TestStreamingBackpressure tests streaming with backpressure handling
 func TestStreamingBackpressure(t *testing.T) {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create stream with small buffer to test backpressure
 	stream := &ContainerMemberStream{
@@ -487,6 +476,7 @@ func TestStreamingBackpressure(t *testing.T) {
 		errors:  make(chan error, 1),
 		done:    make(chan bool, 1),
 		ctx:     ctx,
+		cancel:  cancel,
 	}
 	defer stream.Close()
 
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// StructureLimits caps the structure information generated for one response. Zero disables a cap.
+type StructureLimits struct {
+	// MaxNodes is the most containers included
+	MaxNodes int
+	// MaxBytes is the approximate serialized size after which no further containers are added
+	MaxBytes int
+}
+
+// structureCursor points at the children of a container not yet returned
+type structureCursor struct {
+	ParentID string `json:"parent"`
+	Offset   int    `json:"offset"`
+	Depth    int    `json:"depth"`
+}
+
+// structureToken is the state carried by a structure continuation token
+type structureToken struct {
+	RootID   string            `json:"root"`
+	MaxDepth int               `json:"maxDepth"`
+	Pending  []structureCursor `json:"pending"`
+}
+
+// structureBudget tracks what a structure response has used and the children it had to leave out
+type structureBudget struct {
+	limits  StructureLimits
+	nodes   int
+	bytes   int
+	pending []structureCursor
+}
+
+// SetStructureLimits sets the caps applied when generating structure information
+func (s *ContainerService) SetStructureLimits(limits StructureLimits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.structureLimits = limits
+}
+
+// ContinueStructureInfo returns the next page of a truncated structure. The page is rooted at
+// the container whose remaining children come next, marked continued, and carries a further
+// token while containers are still left out.
+func (s *ContainerService) ContinueStructureInfo(ctx context.Context, containerID, token string) (*ContainerStructureInfo, error) {
+	state, err := decodeStructureToken(token)
+	if err != nil || state.RootID != containerID || len(state.Pending) == 0 {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("invalid continuation token"),
+			domain.ErrInvalidFormat.Code,
+			"invalid continuation token",
+		).WithOperation("ContinueStructureInfo").WithContext("containerID", containerID)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cursor := state.Pending[0]
+	parent, err := s.containerRepo.GetContainer(ctx, cursor.ParentID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("ContinueStructureInfo").WithContext("containerID", cursor.ParentID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container for structure info",
+		).WithOperation("ContinueStructureInfo").WithContext("containerID", cursor.ParentID)
+	}
+
+	children, err := s.containerRepo.GetChildren(ctx, cursor.ParentID)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to get children for structure info",
+		).WithOperation("ContinueStructureInfo").WithContext("containerID", cursor.ParentID)
+	}
+
+	info := &ContainerStructureInfo{
+		Container: ContainerInfo{
+			ID:       parent.ID(),
+			Title:    parent.GetTitle(),
+			Type:     parent.GetContainerType().String(),
+			ParentID: parent.GetParentID(),
+		},
+		Members:   []MemberInfo{},
+		Children:  []ContainerStructureInfo{},
+		Depth:     cursor.Depth - 1,
+		Continued: true,
+	}
+
+	budget := newStructureBudget(s.structureLimits)
+	for i := cursor.Offset; i < len(children); i++ {
+		// The first child is always returned so every page makes progress
+		if i > cursor.Offset && budget.exhausted() {
+			budget.postpone(cursor.ParentID, i, cursor.Depth)
+			break
+		}
+		childInfo, err := s.generateStructureInfoRecursive(ctx, children[i].ID(), cursor.Depth, state.MaxDepth, budget)
+		if err != nil {
+			return nil, err
+		}
+		info.Children = append(info.Children, *childInfo)
+	}
+	budget.pending = append(budget.pending, state.Pending[1:]...)
+
+	if err := budget.finish(info, state.RootID, state.MaxDepth); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// newStructureBudget creates an unused budget for the given limits
+func newStructureBudget(limits StructureLimits) *structureBudget {
+	return &structureBudget{limits: limits}
+}
+
+// charge records a container added to the structure, approximating its serialized size
+func (b *structureBudget) charge(container ContainerInfo, members []MemberInfo) {
+	b.nodes++
+	if encoded, err := json.Marshal(ContainerStructureInfo{Container: container, Members: members}); err == nil {
+		b.bytes += len(encoded)
+	}
+}
+
+// exhausted reports whether either cap has been reached
+func (b *structureBudget) exhausted() bool {
+	if b.limits.MaxNodes > 0 && b.nodes >= b.limits.MaxNodes {
+		return true
+	}
+	return b.limits.MaxBytes > 0 && b.bytes >= b.limits.MaxBytes
+}
+
+// postpone records that a container's children from offset on were left out
+func (b *structureBudget) postpone(parentID string, offset, depth int) {
+	b.pending = append(b.pending, structureCursor{ParentID: parentID, Offset: offset, Depth: depth})
+}
+
+// finish marks the structure truncated with a continuation token when anything was left out
+func (b *structureBudget) finish(info *ContainerStructureInfo, rootID string, maxDepth int) error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(structureToken{RootID: rootID, MaxDepth: maxDepth, Pending: b.pending})
+	if err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to encode continuation token",
+		).WithOperation("GenerateStructureInfo").WithContext("containerID", rootID)
+	}
+
+	info.Truncated = true
+	info.ContinuationToken = base64.RawURLEncoding.EncodeToString(encoded)
+	return nil
+}
+
+// decodeStructureToken parses a continuation token issued by finish
+func decodeStructureToken(token string) (*structureToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var state structureToken
+	if err := json.Unmarshal(decoded, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
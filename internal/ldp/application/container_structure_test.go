@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupStructureTest builds root -> (c1 -> g1, c2, c3) in a mock repository
+func setupStructureTest() (*ContainerService, *TestMockContainerRepository) {
+	service, mockRepo, _ := setupContainerServiceTest()
+	ctx := context.Background()
+
+	tree := map[string][]string{
+		"root": {"c1", "c2", "c3"},
+		"c1":   {"g1"},
+		"c2":   {},
+		"c3":   {},
+		"g1":   {},
+	}
+	containers := make(map[string]*domain.Container)
+	for _, id := range []string{"root", "c1", "c2", "c3", "g1"} {
+		containers[id] = domain.NewContainer(ctx, id, "", domain.BasicContainer)
+	}
+	for id, childIDs := range tree {
+		children := make([]domain.ContainerResource, 0, len(childIDs))
+		for _, childID := range childIDs {
+			children = append(children, containers[childID])
+		}
+		mockRepo.On("GetContainer", mock.Anything, id).Return(containers[id], nil)
+		mockRepo.On("ListMembers", mock.Anything, id, mock.Anything).Return([]string{}, nil)
+		mockRepo.On("GetChildren", mock.Anything, id).Return(children, nil)
+	}
+
+	return service, mockRepo
+}
+
+// structureIDs lists the container IDs in a structure in document order
+func structureIDs(info ContainerStructureInfo) []string {
+	ids := []string{info.Container.ID}
+	for _, child := range info.Children {
+		ids = append(ids, structureIDs(child)...)
+	}
+	return ids
+}
+
+func TestContainerService_GenerateStructureInfo_Unlimited(t *testing.T) {
+	service, _ := setupStructureTest()
+
+	info, err := service.GenerateStructureInfo(context.Background(), "root", 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"root", "c1", "g1", "c2", "c3"}, structureIDs(*info))
+	assert.False(t, info.Truncated)
+	assert.Empty(t, info.ContinuationToken)
+}
+
+func TestContainerService_GenerateStructureInfo_NodeCapPages(t *testing.T) {
+	service, _ := setupStructureTest()
+	service.SetStructureLimits(StructureLimits{MaxNodes: 2})
+	ctx := context.Background()
+
+	info, err := service.GenerateStructureInfo(ctx, "root", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "c1"}, structureIDs(*info))
+	require.True(t, info.Truncated)
+	require.NotEmpty(t, info.ContinuationToken)
+
+	// The next page resumes inside c1, repeating it only to hold g1
+	page, err := service.ContinueStructureInfo(ctx, "root", info.ContinuationToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c1", "g1"}, structureIDs(*page))
+	assert.True(t, page.Continued)
+	assert.Equal(t, 1, page.Depth)
+	assert.Equal(t, 2, page.Children[0].Depth)
+	require.True(t, page.Truncated)
+
+	page, err = service.ContinueStructureInfo(ctx, "root", page.ContinuationToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "c2", "c3"}, structureIDs(*page))
+	assert.True(t, page.Continued)
+	assert.False(t, page.Truncated)
+	assert.Empty(t, page.ContinuationToken)
+}
+
+func TestContainerService_GenerateStructureInfo_ByteCap(t *testing.T) {
+	service, _ := setupStructureTest()
+	service.SetStructureLimits(StructureLimits{MaxBytes: 1})
+
+	info, err := service.GenerateStructureInfo(context.Background(), "root", 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"root"}, structureIDs(*info))
+	assert.True(t, info.Truncated)
+}
+
+func TestContainerService_ContinueStructureInfo_RejectsForeignToken(t *testing.T) {
+	service, _ := setupStructureTest()
+	service.SetStructureLimits(StructureLimits{MaxNodes: 1})
+	ctx := context.Background()
+
+	info, err := service.GenerateStructureInfo(ctx, "root", 5)
+	require.NoError(t, err)
+
+	_, err = service.ContinueStructureInfo(ctx, "c2", info.ContinuationToken)
+	require.Error(t, err)
+	storageErr, ok := domain.GetStorageError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidFormat.Code, storageErr.Code)
+
+	_, err = service.ContinueStructureInfo(ctx, "root", "not-a-token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// ContainerRepairSummary reports what repairing one container of a subtree found and fixed
+type ContainerRepairSummary struct {
+	ContainerID        string `json:"containerId"`
+	TimestampsRepaired bool   `json:"timestampsRepaired"`
+	// CorruptionIssues counts the metadata corruption issues found after timestamp repair
+	CorruptionIssues   int  `json:"corruptionIssues"`
+	CorruptionRepaired bool `json:"corruptionRepaired"`
+	// MembersIndexed and MembersUnindexed count the membership index entries added for members
+	// the index missed and dropped for members the container no longer holds
+	MembersIndexed   int `json:"membersIndexed"`
+	MembersUnindexed int `json:"membersUnindexed"`
+	// Error is why the container could not be fully repaired; the rest of the subtree is still
+	// repaired
+	Error string `json:"error,omitempty"`
+}
+
+// Repaired reports whether anything about the container was fixed
+func (s ContainerRepairSummary) Repaired() bool {
+	return s.TimestampsRepaired || s.CorruptionRepaired || s.MembersIndexed > 0 || s.MembersUnindexed > 0
+}
+
+// SubtreeRepairReport lists what repairing a container subtree did, one summary per container
+// in the order they were visited, the root first
+type SubtreeRepairReport struct {
+	RootID     string                   `json:"rootId"`
+	Containers []ContainerRepairSummary `json:"containers"`
+	Repaired   int                      `json:"repaired"`
+	Failed     int                      `json:"failed"`
+}
+
+// RepairContainerSubtree validates and repairs a container and every container below it: it
+// repairs timestamps, then any remaining metadata corruption, then reconciles the membership
+// index with the members the container records, when the repository can. Each repair commits
+// its own events. A container that cannot be repaired is reported and the walk goes on, so one
+// broken container does not keep the rest of the branch from being fixed.
+func (s *ContainerService) RepairContainerSubtree(ctx context.Context, rootID string) (SubtreeRepairReport, error) {
+	if err := s.validator.ValidateContainerID(rootID); err != nil {
+		return SubtreeRepairReport{}, domain.WrapStorageError(err, err.(*domain.StorageError).Code, err.Error()).WithOperation("RepairContainerSubtree")
+	}
+
+	exists, err := s.containerRepo.ContainerExists(ctx, rootID)
+	if err != nil {
+		return SubtreeRepairReport{}, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check container existence",
+		).WithOperation("RepairContainerSubtree").WithContext("containerID", rootID)
+	}
+	if !exists {
+		return SubtreeRepairReport{}, domain.ErrResourceNotFound.WithOperation("RepairContainerSubtree").WithContext("containerID", rootID)
+	}
+
+	report := SubtreeRepairReport{RootID: rootID, Containers: []ContainerRepairSummary{}}
+	visited := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		containerID := queue[0]
+		queue = queue[1:]
+
+		summary := s.repairSubtreeContainer(ctx, containerID)
+		children, err := s.subtreeChildren(ctx, containerID)
+		if err != nil && summary.Error == "" {
+			summary.Error = err.Error()
+		}
+		for _, childID := range children {
+			if !visited[childID] {
+				visited[childID] = true
+				queue = append(queue, childID)
+			}
+		}
+
+		if summary.Error != "" {
+			report.Failed++
+		}
+		if summary.Repaired() {
+			report.Repaired++
+		}
+		report.Containers = append(report.Containers, summary)
+	}
+	return report, nil
+}
+
+// repairSubtreeContainer runs every repair on one container, stopping at the first failure
+func (s *ContainerService) repairSubtreeContainer(ctx context.Context, containerID string) ContainerRepairSummary {
+	summary := ContainerRepairSummary{ContainerID: containerID}
+
+	repaired, err := s.RepairContainerTimestamps(ctx, containerID)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+	summary.TimestampsRepaired = repaired
+
+	corruption, err := s.DetectContainerCorruption(ctx, containerID)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+	summary.CorruptionIssues = len(corruption.Issues)
+	if corruption.IsCorrupted {
+		repaired, err := s.RepairContainerCorruption(ctx, containerID)
+		if err != nil {
+			summary.Error = err.Error()
+			return summary
+		}
+		summary.CorruptionRepaired = repaired
+	}
+
+	indexed, unindexed, err := s.reconcileMembershipIndex(ctx, containerID)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+	summary.MembersIndexed, summary.MembersUnindexed = indexed, unindexed
+	return summary
+}
+
+// reconcileMembershipIndex brings the container's membership index in line with its members
+// and records a container update when entries changed. Repositories without a membership index
+// to reconcile are left alone.
+func (s *ContainerService) reconcileMembershipIndex(ctx context.Context, containerID string) (int, int, error) {
+	reconciler, ok := s.containerRepo.(domain.MembershipIndexReconciler)
+	if !ok {
+		return 0, 0, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	indexed, unindexed, err := reconciler.ReconcileMembershipIndex(ctx, containerID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to reconcile membership index: %w", err)
+	}
+	if indexed == 0 && unindexed == 0 {
+		return 0, 0, nil
+	}
+
+	event := domain.NewContainerUpdatedEvent(containerID, map[string]interface{}{
+		"membershipIndexReconciled": true,
+		"membersIndexed":            indexed,
+		"membersUnindexed":          unindexed,
+		"updatedAt":                 time.Now(),
+	})
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents([]pericarpdomain.Event{event})
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			return indexed, unindexed, fmt.Errorf("failed to rollback unit of work: %v", rollbackErr)
+		}
+		return indexed, unindexed, fmt.Errorf("failed to commit membership index repair events: %w", err)
+	}
+	return indexed, unindexed, nil
+}
+
+// subtreeChildren returns the IDs of the containers directly below a container: its children,
+// and the members that are containers naming it as their parent, as repositories that cannot
+// list children only record sub-containers among the members
+func (s *ContainerService) subtreeChildren(ctx context.Context, containerID string) ([]string, error) {
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container for subtree repair: %w", err)
+	}
+	children, err := s.containerRepo.GetChildren(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container children: %w", err)
+	}
+
+	var ids []string
+	seen := make(map[string]bool, len(children))
+	for _, child := range children {
+		seen[child.ID()] = true
+		ids = append(ids, child.ID())
+	}
+	for _, memberID := range container.GetMembers() {
+		if seen[memberID] {
+			continue
+		}
+		seen[memberID] = true
+
+		isContainer, err := s.containerRepo.ContainerExists(ctx, memberID)
+		if err != nil {
+			return ids, fmt.Errorf("failed to check member type of %s: %w", memberID, err)
+		}
+		if !isContainer {
+			continue
+		}
+		member, err := s.containerRepo.GetContainer(ctx, memberID)
+		if err != nil {
+			return ids, fmt.Errorf("failed to get member container %s: %w", memberID, err)
+		}
+		if member.GetParentID() == containerID {
+			ids = append(ids, memberID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcilingContainerRepository adds membership index reconciliation to the mock repository
+type reconcilingContainerRepository struct {
+	*TestMockContainerRepository
+}
+
+func (r *reconcilingContainerRepository) ReconcileMembershipIndex(ctx context.Context, containerID string) (int, int, error) {
+	args := r.Called(ctx, containerID)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func TestContainerService_RepairContainerSubtree(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*ContainerService, *reconcilingContainerRepository, *MockUnitOfWork) {
+		mockRepo := &reconcilingContainerRepository{TestMockContainerRepository: &TestMockContainerRepository{}}
+		mockUoW := &MockUnitOfWork{}
+		service := NewContainerService(mockRepo, func() pericarpdomain.UnitOfWork { return mockUoW }, infrastructure.NewContainerRDFConverter())
+
+		createdAt := time.Now().Add(-time.Hour)
+		photos := domain.NewContainer(ctx, "photos", "", domain.BasicContainer)
+		photos.SetMetadata("createdAt", createdAt)
+		photos.SetMetadata("updatedAt", createdAt)
+		photos.Members = []string{"trips", "cover"}
+		// trips was updated before it was created, which timestamp repair fixes
+		trips := domain.NewContainer(ctx, "trips", "photos", domain.BasicContainer)
+		trips.SetMetadata("createdAt", createdAt)
+		trips.SetMetadata("updatedAt", createdAt.Add(-time.Minute))
+		trips.Members = []string{"lisbon"}
+
+		// Sub-containers are only found among the members, as with the filesystem repository
+		mockRepo.On("GetContainer", ctx, "photos").Return(photos, nil)
+		mockRepo.On("GetContainer", ctx, "trips").Return(trips, nil)
+		mockRepo.On("GetChildren", ctx, mock.Anything).Return([]domain.ContainerResource{}, nil)
+		mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+		mockRepo.On("ContainerExists", ctx, "trips").Return(true, nil)
+		mockRepo.On("ContainerExists", ctx, mock.Anything).Return(false, nil)
+		mockRepo.On("UpdateContainer", ctx, trips).Return(nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+		return service, mockRepo, mockUoW
+	}
+
+	t.Run("should repair every container of the subtree", func(t *testing.T) {
+		service, mockRepo, mockUoW := setup()
+		mockRepo.On("ReconcileMembershipIndex", ctx, "photos").Return(1, 0, nil)
+		mockRepo.On("ReconcileMembershipIndex", ctx, "trips").Return(0, 2, nil)
+
+		report, err := service.RepairContainerSubtree(ctx, "photos")
+		require.NoError(t, err)
+
+		assert.Equal(t, "photos", report.RootID)
+		require.Len(t, report.Containers, 2)
+		assert.Equal(t, ContainerRepairSummary{ContainerID: "photos", MembersIndexed: 1}, report.Containers[0])
+		assert.Equal(t, "trips", report.Containers[1].ContainerID)
+		assert.True(t, report.Containers[1].TimestampsRepaired)
+		assert.Equal(t, 2, report.Containers[1].MembersUnindexed)
+		assert.Empty(t, report.Containers[1].Error)
+		assert.Equal(t, 2, report.Repaired)
+		assert.Equal(t, 0, report.Failed)
+
+		mockRepo.AssertCalled(t, "UpdateContainer", ctx, mock.Anything)
+		mockUoW.AssertNumberOfCalls(t, "Commit", 3)
+	})
+
+	t.Run("should report a container that cannot be repaired and carry on", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		mockRepo.On("ReconcileMembershipIndex", ctx, "photos").Return(0, 0, errors.New("index unavailable"))
+		mockRepo.On("ReconcileMembershipIndex", ctx, "trips").Return(0, 0, nil)
+
+		report, err := service.RepairContainerSubtree(ctx, "photos")
+		require.NoError(t, err)
+
+		require.Len(t, report.Containers, 2)
+		assert.Contains(t, report.Containers[0].Error, "index unavailable")
+		assert.True(t, report.Containers[1].TimestampsRepaired)
+		assert.Equal(t, 1, report.Repaired)
+		assert.Equal(t, 1, report.Failed)
+	})
+
+	t.Run("should fail when the root does not exist", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		_, err := service.RepairContainerSubtree(ctx, "missing")
+		assert.True(t, domain.IsResourceNotFound(err))
+		mockRepo.AssertNotCalled(t, "ReconcileMembershipIndex", ctx, mock.Anything)
+	})
+}
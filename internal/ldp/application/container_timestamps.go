@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetTimestampFallback sets the source used to derive container timestamps that are missing
+// from stored metadata. Derived values are served on read and persisted only by
+// RepairContainerTimestamps. A nil source disables the fallback.
+func (s *ContainerService) SetTimestampFallback(source domain.ContainerModTimeSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if source == nil {
+		s.timestampManager.SetFallback(nil)
+		return
+	}
+
+	s.timestampManager.SetFallback(func(container *domain.Container) (time.Time, bool) {
+		modTime, err := source.ContainerModTime(context.Background(), container.ID())
+		if err != nil {
+			return time.Time{}, false
+		}
+		return modTime, true
+	})
+}
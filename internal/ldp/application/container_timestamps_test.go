@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedModTimeSource reports the same modification time for every container
+type fixedModTimeSource time.Time
+
+func (f fixedModTimeSource) ContainerModTime(ctx context.Context, containerID string) (time.Time, error) {
+	return time.Time(f), nil
+}
+
+func TestContainerService_GetContainerStats_DerivesMissingTimestamps(t *testing.T) {
+	modTime := time.Date(2025, 8, 1, 9, 30, 0, 0, time.UTC)
+
+	container := domain.NewContainer(context.Background(), "partial", "", domain.BasicContainer)
+	delete(container.GetMetadata(), "createdAt")
+	delete(container.GetMetadata(), "updatedAt")
+
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "partial").Return(container, nil)
+	mockRepo.On("ListMembers", mock.Anything, "partial", mock.Anything).Return([]string{}, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+	service.SetTimestampFallback(fixedModTimeSource(modTime))
+
+	stats, err := service.GetContainerStats(context.Background(), "partial")
+	require.NoError(t, err)
+
+	assert.Equal(t, modTime, stats["created_at"])
+	assert.Equal(t, modTime, stats["updated_at"])
+	assert.Equal(t, true, stats["timestamps_derived"])
+}
+
+func TestContainerService_GetContainerStats_StoredTimestampsAreNotDerived(t *testing.T) {
+	container := domain.NewContainer(context.Background(), "complete", "", domain.BasicContainer)
+	domain.NewTimestampManager().SetCreatedTimestamp(container)
+	createdAt := container.GetMetadata()["createdAt"]
+
+	mockRepo := new(MockContainerRepository)
+	mockRepo.On("GetContainer", mock.Anything, "complete").Return(container, nil)
+	mockRepo.On("ListMembers", mock.Anything, "complete", mock.Anything).Return([]string{}, nil)
+	service := NewContainerService(mockRepo, nil, nil)
+	service.SetTimestampFallback(fixedModTimeSource(time.Date(2025, 8, 1, 9, 30, 0, 0, time.UTC)))
+
+	stats, err := service.GetContainerStats(context.Background(), "complete")
+	require.NoError(t, err)
+
+	assert.Equal(t, createdAt, stats["created_at"])
+	assert.NotContains(t, stats, "timestamps_derived")
+}
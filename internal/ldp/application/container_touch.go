@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// ContainerWriteAuthorizer decides whether the caller in ctx may modify a container
+type ContainerWriteAuthorizer interface {
+	CanWriteContainer(ctx context.Context, containerID string) bool
+}
+
+// AllowAllContainerWrites is a ContainerWriteAuthorizer that permits every write
+type AllowAllContainerWrites struct{}
+
+// CanWriteContainer always returns true
+func (AllowAllContainerWrites) CanWriteContainer(ctx context.Context, containerID string) bool {
+	return true
+}
+
+// SetWriteAuthorizer sets the authorizer consulted before container writes that check permissions
+func (s *ContainerService) SetWriteAuthorizer(authorizer ContainerWriteAuthorizer) {
+	s.writeAuthorizer = authorizer
+}
+
+// TouchContainer marks a container modified without changing its members or metadata, so
+// sync clients can signal downstream recomputation. It refreshes updatedAt, bumps the version
+// and emits a container updated event marked as touched. The caller needs write permission.
+func (s *ContainerService) TouchContainer(ctx context.Context, containerID string) (*domain.Container, error) {
+	if containerID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("TouchContainer")
+	}
+
+	authorizer := s.writeAuthorizer
+	if authorizer == nil {
+		authorizer = AllowAllContainerWrites{}
+	}
+	if !authorizer.CanWriteContainer(ctx, containerID) {
+		return nil, domain.ErrAccessDenied.WithOperation("TouchContainer").WithContext("containerID", containerID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	container, err := s.containerRepo.GetContainer(ctx, containerID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("TouchContainer").WithContext("containerID", containerID)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve container",
+		).WithOperation("TouchContainer").WithContext("containerID", containerID)
+	}
+
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("invalid container type"),
+			domain.ErrInvalidResource.Code,
+			"invalid container type",
+		).WithOperation("TouchContainer").WithContext("containerID", containerID)
+	}
+
+	// Only the touch event is registered; events left on a loaded container were already committed
+	concreteContainer.MarkEventsAsCommitted()
+	concreteContainer.Touch(ctx)
+
+	// Commit unit of work for event processing - this will trigger event handlers to update repository
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(concreteContainer.UncommittedEvents())
+	envelopes, err := unitOfWork.Commit(ctx)
+	if err != nil {
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			fmt.Printf("Warning: failed to rollback unit of work: %v\n", rollbackErr)
+		}
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to commit container touch events",
+		).WithOperation("TouchContainer").WithContext("containerID", containerID)
+	}
+
+	// Mark events as committed
+	concreteContainer.MarkEventsAsCommitted()
+
+	if len(envelopes) > 0 {
+		fmt.Printf("Successfully processed %d events for touching container %s\n", len(envelopes), containerID)
+	}
+
+	s.indexContainer(ctx, concreteContainer)
+
+	return concreteContainer, nil
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// denyContainerWrites is a ContainerWriteAuthorizer that denies writes to the listed containers
+type denyContainerWrites map[string]bool
+
+func (d denyContainerWrites) CanWriteContainer(ctx context.Context, containerID string) bool {
+	return !d[containerID]
+}
+
+func TestContainerService_TouchContainer(t *testing.T) {
+	service, mockRepo, mockUoW := setupContainerServiceTest()
+	ctx := context.Background()
+
+	container := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+	container.SetTitle("Docs")
+	container.Members = []string{"a.ttl"}
+	container.MarkEventsAsCommitted()
+	previous := time.Now().Add(-time.Hour)
+	container.SetMetadata("updatedAt", previous)
+
+	var registered []pericarpdomain.Event
+	mockRepo.On("GetContainer", ctx, "docs").Return(container, nil)
+	mockUoW.On("RegisterEvents", mock.Anything).Run(func(args mock.Arguments) {
+		registered = args.Get(0).([]pericarpdomain.Event)
+	}).Return()
+	mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	touched, err := service.TouchContainer(ctx, "docs")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, touched.GetVersion())
+	updatedAt, ok := touched.GetMetadata()["updatedAt"].(time.Time)
+	require.True(t, ok)
+	assert.True(t, updatedAt.After(previous))
+	assert.Equal(t, "Docs", touched.GetTitle())
+	assert.Equal(t, []string{"a.ttl"}, touched.GetMembers())
+
+	require.Len(t, registered, 1)
+	assert.Equal(t, "container."+domain.EventTypeContainerUpdated, registered[0].EventType())
+	assert.Contains(t, string(registered[0].Payload()), `"touched":true`)
+
+	// A second touch bumps the version again
+	_, err = service.TouchContainer(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, 2, container.GetVersion())
+}
+
+func TestContainerService_TouchContainer_RequiresWritePermission(t *testing.T) {
+	service, mockRepo, _ := setupContainerServiceTest()
+	service.SetWriteAuthorizer(denyContainerWrites{"docs": true})
+
+	_, err := service.TouchContainer(context.Background(), "docs")
+	require.Error(t, err)
+	assert.True(t, domain.IsAccessDenied(err))
+	mockRepo.AssertNotCalled(t, "GetContainer", mock.Anything, mock.Anything)
+}
+
+func TestContainerService_TouchContainer_NotFound(t *testing.T) {
+	service, mockRepo, _ := setupContainerServiceTest()
+	mockRepo.On("GetContainer", mock.Anything, "missing").Return(nil, domain.ErrResourceNotFound)
+
+	_, err := service.TouchContainer(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, domain.IsResourceNotFound(err))
+}
+
+func TestContainerEventHandler_AppliesTouch(t *testing.T) {
+	ctx := context.Background()
+	source := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+	source.MarkEventsAsCommitted()
+	source.Touch(ctx)
+	event := source.UncommittedEvents()[0].(*pericarpdomain.EntityEvent)
+
+	stored := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+	stored.SetMetadata("updatedAt", time.Now().Add(-time.Hour))
+	handler := NewContainerEventHandler(nil)
+	require.NoError(t, handler.applyContainerUpdatesFromEvent(stored, event))
+
+	assert.Equal(t, 1, stored.GetVersion())
+	assert.WithinDuration(t, source.GetMetadata()["updatedAt"].(time.Time), stored.GetMetadata()["updatedAt"].(time.Time), time.Millisecond)
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetDublinCoreLimits sets the maximum Dublin Core field lengths enforced on containers
+func (s *ContainerService) SetDublinCoreLimits(limits domain.DublinCoreLimits) {
+	s.dublinCoreLimits = limits
+}
+
+// SetDublinCoreLimits sets the maximum Dublin Core field lengths enforced on resources
+func (s *StorageService) SetDublinCoreLimits(limits domain.DublinCoreLimits) {
+	s.dublinCoreLimits = limits
+}
+
+// SetResourceDublinCoreMetadata sets Dublin Core metadata on a stored resource, leaving its
+// content untouched. Fields longer than the configured limits are rejected or truncated, and a
+// dc:identifier already used in an account requiring unique identifiers is refused.
+func (s *StorageService) SetResourceDublinCoreMetadata(ctx context.Context, id string, dc domain.DublinCoreMetadata) (domain.Resource, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidID.WithOperation("SetResourceDublinCoreMetadata")
+	}
+
+	dc, err := limitDublinCore(s.dublinCoreLimits, dc, id, "SetResourceDublinCoreMetadata")
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, err := s.repo.Retrieve(ctx, id)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("SetResourceDublinCoreMetadata").WithContext("resourceID", id)
+		}
+		return nil, domain.WrapStorageError(err, "RETRIEVE_FAILED", "failed to retrieve resource").WithOperation("SetResourceDublinCoreMetadata")
+	}
+
+	// Refuse an identifier already held within the account before changing anything
+	if err := s.identifiers.claim(ctx, s.resourceAccount(ctx, id), id, dc.Identifier, "SetResourceDublinCoreMetadata"); err != nil {
+		return nil, err
+	}
+
+	for key, value := range dc.Entries() {
+		resource.SetMetadata(key, value)
+	}
+
+	if err := s.repo.Store(ctx, resource); err != nil {
+		return nil, domain.WrapStorageError(err, "STORE_FAILED", "failed to store resource metadata").WithOperation("SetResourceDublinCoreMetadata")
+	}
+
+	s.indexResource(ctx, resource)
+
+	return resource, nil
+}
+
+// limitDublinCore applies the field length limits, warning about any field it truncates
+func limitDublinCore(limits domain.DublinCoreLimits, dc domain.DublinCoreMetadata, id, operation string) (domain.DublinCoreMetadata, error) {
+	limited, truncated, err := limits.Apply(dc)
+	if err != nil {
+		if storageErr, ok := domain.GetStorageError(err); ok {
+			return dc, storageErr.WithOperation(operation).WithContext("id", id)
+		}
+		return dc, err
+	}
+	if len(truncated) > 0 {
+		fmt.Printf("Warning: truncated Dublin Core fields %s on %s to their maximum length\n", strings.Join(truncated, ", "), id)
+	}
+	return limited, nil
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dublinCoreResourceRepo keeps resources in memory for Dublin Core metadata tests
+type dublinCoreResourceRepo struct {
+	domain.StreamingResourceRepository
+	resources map[string]domain.Resource
+}
+
+func (r *dublinCoreResourceRepo) Retrieve(ctx context.Context, id string) (domain.Resource, error) {
+	resource, ok := r.resources[id]
+	if !ok {
+		return nil, domain.ErrResourceNotFound
+	}
+	return resource, nil
+}
+
+func (r *dublinCoreResourceRepo) Store(ctx context.Context, resource domain.Resource) error {
+	r.resources[resource.ID()] = resource
+	return nil
+}
+
+func TestContainerService_SetContainerDublinCoreMetadata_EnforcesLimits(t *testing.T) {
+	ctx := context.Background()
+	longTitle := strings.Repeat("t", 20)
+
+	t.Run("rejects oversized fields", func(t *testing.T) {
+		service, mockRepo, _ := setupContainerServiceTest()
+		service.SetDublinCoreLimits(domain.DublinCoreLimits{MaxTitleLength: 10})
+
+		err := service.SetContainerDublinCoreMetadata(ctx, "notes", domain.DublinCoreMetadata{Title: longTitle})
+		assert.True(t, domain.IsMetadataTooLong(err))
+		mockRepo.AssertNotCalled(t, "GetContainer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("truncates oversized fields when lenient", func(t *testing.T) {
+		service, mockRepo, mockUoW := setupContainerServiceTest()
+		service.SetDublinCoreLimits(domain.DublinCoreLimits{MaxTitleLength: 10, Truncate: true})
+
+		container := domain.NewContainer(ctx, "notes", "", domain.BasicContainer)
+		mockRepo.On("GetContainer", ctx, "notes").Return(container, nil)
+		mockRepo.On("UpdateContainer", ctx, container).Return(nil)
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		err := service.SetContainerDublinCoreMetadata(ctx, "notes", domain.DublinCoreMetadata{Title: longTitle})
+		require.NoError(t, err)
+		assert.Equal(t, longTitle[:10], container.GetDublinCoreMetadata().Title)
+	})
+}
+
+func TestStorageService_SetResourceDublinCoreMetadata(t *testing.T) {
+	ctx := context.Background()
+	repo := &dublinCoreResourceRepo{resources: map[string]domain.Resource{
+		"photo": domain.NewResource(ctx, "photo", "image/jpeg", []byte("jpeg")),
+	}}
+	service := NewStorageService(repo, nil, nil)
+	service.SetDublinCoreLimits(domain.DublinCoreLimits{MaxDescriptionLength: 8})
+
+	resource, err := service.SetResourceDublinCoreMetadata(ctx, "photo", domain.DublinCoreMetadata{Title: "Lisbon", Description: "Tram 28"})
+	require.NoError(t, err)
+	assert.Equal(t, "Lisbon", resource.GetMetadata()["dc:title"])
+	assert.Equal(t, "Tram 28", resource.GetMetadata()["dc:description"])
+	assert.Equal(t, []byte("jpeg"), resource.GetData())
+
+	_, err = service.SetResourceDublinCoreMetadata(ctx, "photo", domain.DublinCoreMetadata{Description: "Tram 28 at dusk"})
+	assert.True(t, domain.IsMetadataTooLong(err))
+	assert.Equal(t, "Tram 28", repo.resources["photo"].GetMetadata()["dc:description"])
+
+	_, err = service.SetResourceDublinCoreMetadata(ctx, "missing", domain.DublinCoreMetadata{Title: "Nothing"})
+	assert.True(t, domain.IsResourceNotFound(err))
+}
+
+// memoryIdentifierIndex keeps identifiers in memory for uniqueness tests
+type memoryIdentifierIndex struct {
+	accounts    map[string]string
+	identifiers map[string]string
+}
+
+func newMemoryIdentifierIndex() *memoryIdentifierIndex {
+	return &memoryIdentifierIndex{accounts: map[string]string{}, identifiers: map[string]string{}}
+}
+
+func (i *memoryIdentifierIndex) SetIdentifier(ctx context.Context, accountID, resourceID, identifier string, unique bool) error {
+	if unique {
+		for holder, held := range i.identifiers {
+			if holder != resourceID && held == identifier && i.accounts[holder] == accountID {
+				return domain.ErrDuplicateIdentifier
+			}
+		}
+	}
+	i.accounts[resourceID] = accountID
+	i.identifiers[resourceID] = identifier
+	return nil
+}
+
+func (i *memoryIdentifierIndex) RemoveIdentifier(ctx context.Context, resourceID string) error {
+	delete(i.accounts, resourceID)
+	delete(i.identifiers, resourceID)
+	return nil
+}
+
+// uniqueAccounts requires unique identifiers of the accounts it holds
+type uniqueAccounts map[string]bool
+
+func (u uniqueAccounts) UniqueIdentifiers(ctx context.Context, accountID string) bool {
+	return u[accountID]
+}
+
+func TestStorageService_SetResourceDublinCoreMetadata_UniqueIdentifiers(t *testing.T) {
+	ctx := context.Background()
+	repo := &dublinCoreResourceRepo{resources: map[string]domain.Resource{
+		"notes": domain.NewResource(ctx, "notes", "text/plain", []byte("notes")),
+		"photo": domain.NewResource(ctx, "photo", "image/jpeg", []byte("jpeg")),
+	}}
+	index := newMemoryIdentifierIndex()
+	service := NewStorageService(repo, nil, nil)
+	service.SetIdentifierIndex(index, uniqueAccounts{"alice": true})
+
+	alice := domain.WithAccountID(ctx, "alice")
+	_, err := service.SetResourceDublinCoreMetadata(alice, "notes", domain.DublinCoreMetadata{Identifier: "isbn:1"})
+	require.NoError(t, err)
+
+	_, err = service.SetResourceDublinCoreMetadata(alice, "photo", domain.DublinCoreMetadata{Identifier: "isbn:1"})
+	storageErr, ok := domain.GetStorageError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrDuplicateIdentifier.Code, storageErr.Code)
+	assert.Nil(t, repo.resources["photo"].GetMetadata()["dc:identifier"], "a refused identifier is not stored")
+
+	t.Run("accounts that have not opted in allow duplicates", func(t *testing.T) {
+		_, err := service.SetResourceDublinCoreMetadata(domain.WithAccountID(ctx, "bob"), "photo", domain.DublinCoreMetadata{Identifier: "isbn:1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("writes on behalf of no account are not indexed", func(t *testing.T) {
+		_, err := service.SetResourceDublinCoreMetadata(ctx, "notes", domain.DublinCoreMetadata{Identifier: "isbn:2"})
+		require.NoError(t, err)
+		assert.Equal(t, "isbn:1", index.identifiers["notes"])
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 )
@@ -204,6 +206,7 @@ func (h *ResourceEventHandler) reconstructResourceFromEvent(event *pericarpdomai
 type EventHandlerRegistrar struct {
 	dispatcher pericarpdomain.EventDispatcher
 	handlers   []pericarpdomain.EventHandler
+	retry      *EventRetry
 }
 
 // NewEventHandlerRegistrar creates a new event handler registrar
@@ -214,6 +217,12 @@ func NewEventHandlerRegistrar(dispatcher pericarpdomain.EventDispatcher) *EventH
 	}
 }
 
+// SetEventRetry retries handlers registered from now on under their policies, dead-lettering
+// events they cannot process
+func (r *EventHandlerRegistrar) SetEventRetry(retry *EventRetry) {
+	r.retry = retry
+}
+
 // RegisterResourceEventHandler registers the resource event handler for all resource events
 func (r *EventHandlerRegistrar) RegisterResourceEventHandler(handler *ResourceEventHandler) error {
 	// Register for all resource event types
@@ -223,8 +232,9 @@ func (r *EventHandlerRegistrar) RegisterResourceEventHandler(handler *ResourceEv
 		"resource.deleted",
 	}
 
+	subscriber := r.retry.Wrap(EventHandlerResource, handler)
 	for _, eventType := range eventTypes {
-		if err := r.dispatcher.Subscribe(eventType, handler); err != nil {
+		if err := r.dispatcher.Subscribe(eventType, subscriber); err != nil {
 			return fmt.Errorf("failed to subscribe to event type %s: %w", eventType, err)
 		}
 	}
@@ -252,6 +262,7 @@ func (h *ContainerEventHandler) EventTypes() []string {
 		"container.created",
 		"container.updated",
 		"container.deleted",
+		"container.moved",
 		"container.member_added",
 		"container.member_removed",
 	}
@@ -275,6 +286,8 @@ func (h *ContainerEventHandler) Handle(ctx context.Context, envelope pericarpdom
 			return h.handleContainerUpdated(ctx, entityEvent)
 		case domain.EventTypeContainerDeleted:
 			return h.handleContainerDeleted(ctx, entityEvent)
+		case domain.EventTypeContainerMoved:
+			return h.handleContainerMoved(ctx, entityEvent)
 		case domain.EventTypeMemberAdded:
 			return h.handleMemberAdded(ctx, entityEvent)
 		case domain.EventTypeMemberRemoved:
@@ -301,6 +314,7 @@ func (h *ContainerEventHandler) handleContainerCreated(ctx context.Context, even
 	if err := h.containerRepo.CreateContainer(ctx, container); err != nil {
 		return fmt.Errorf("failed to store container in repository: %w", err)
 	}
+	metrics.StoredContainers.Inc()
 
 	fmt.Printf("Repository updated: container %s created\n", event.AggregateID())
 	return nil
@@ -313,9 +327,13 @@ func (h *ContainerEventHandler) handleContainerUpdated(ctx context.Context, even
 	if err != nil {
 		return fmt.Errorf("failed to get container for update: %w", err)
 	}
+	concreteContainer, ok := container.(*domain.Container)
+	if !ok {
+		return fmt.Errorf("invalid container type for update: %T", container)
+	}
 
 	// Apply updates from event payload
-	if err := h.applyContainerUpdatesFromEvent(container, event); err != nil {
+	if err := h.applyContainerUpdatesFromEvent(concreteContainer, event); err != nil {
 		return fmt.Errorf("failed to apply container updates from event: %w", err)
 	}
 
@@ -334,11 +352,125 @@ func (h *ContainerEventHandler) handleContainerDeleted(ctx context.Context, even
 	if err := h.containerRepo.DeleteContainer(ctx, event.AggregateID()); err != nil {
 		return fmt.Errorf("failed to delete container from repository: %w", err)
 	}
+	metrics.StoredContainers.Dec()
 
 	fmt.Printf("Repository updated: container %s deleted\n", event.AggregateID())
 	return nil
 }
 
+// handleContainerMoved handles container moved events. A move under the same ID only changes
+// the container's parent; a rename stores the container under its new ID, carries its members
+// and children over and then removes it under the old one. The rename takes several repository
+// writes, so each step is safe to repeat: an event replayed after a failure part way through
+// picks up where the last attempt stopped, and one replayed after the rename finished is a no-op.
+func (h *ContainerEventHandler) handleContainerMoved(ctx context.Context, event *pericarpdomain.EntityEvent) error {
+	var payload struct {
+		ToParentID string `json:"toParentID"`
+		ToID       string `json:"toID"`
+	}
+	if err := json.Unmarshal(event.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal container moved event payload: %w", err)
+	}
+
+	fromID := event.AggregateID()
+	renamed := payload.ToID != "" && payload.ToID != fromID
+
+	// The old ID is removed last, so a rename whose old ID is gone and new one present finished
+	if renamed {
+		fromExists, err := h.containerRepo.ContainerExists(ctx, fromID)
+		if err != nil {
+			return fmt.Errorf("failed to check container for move: %w", err)
+		}
+		if !fromExists {
+			toExists, err := h.containerRepo.ContainerExists(ctx, payload.ToID)
+			if err != nil {
+				return fmt.Errorf("failed to check renamed container: %w", err)
+			}
+			if toExists {
+				return nil
+			}
+		}
+	}
+
+	container, err := h.containerRepo.GetContainer(ctx, fromID)
+	if err != nil {
+		return fmt.Errorf("failed to get container for move: %w", err)
+	}
+	concrete, ok := container.(*domain.Container)
+	if !ok {
+		return fmt.Errorf("invalid container type for move")
+	}
+
+	if !renamed {
+		concrete.ParentID = payload.ToParentID
+		if err := h.containerRepo.UpdateContainer(ctx, concrete); err != nil {
+			return fmt.Errorf("failed to update moved container in repository: %w", err)
+		}
+		fmt.Printf("Repository updated: container %s moved under %q\n", fromID, payload.ToParentID)
+		return nil
+	}
+
+	// A replay finds the container already stored under its new ID by the earlier attempt
+	toExists, err := h.containerRepo.ContainerExists(ctx, payload.ToID)
+	if err != nil {
+		return fmt.Errorf("failed to check renamed container: %w", err)
+	}
+	if !toExists {
+		moved := domain.NewContainer(ctx, payload.ToID, payload.ToParentID, concrete.GetContainerType())
+		for key, value := range concrete.GetMetadata() {
+			moved.SetMetadata(key, value)
+		}
+		moved.MarkEventsAsCommitted()
+		if err := h.containerRepo.CreateContainer(ctx, moved); err != nil {
+			return fmt.Errorf("failed to store renamed container in repository: %w", err)
+		}
+	}
+
+	// Re-key the membership index; each page is taken off the old container, so the next page
+	// starts at offset zero again. Adding a member the new container already holds is a no-op,
+	// so members carried over before a failure are not duplicated.
+	pagination := domain.PaginationOptions{Limit: memberPageSize}
+	for {
+		members, err := h.containerRepo.ListMembers(ctx, fromID, pagination)
+		if err != nil {
+			return fmt.Errorf("failed to list members of moved container: %w", err)
+		}
+		for _, memberID := range members {
+			if err := h.containerRepo.AddMember(ctx, payload.ToID, memberID); err != nil {
+				return fmt.Errorf("failed to add member to renamed container: %w", err)
+			}
+			if err := h.containerRepo.RemoveMember(ctx, fromID, memberID); err != nil {
+				return fmt.Errorf("failed to remove member from old container: %w", err)
+			}
+		}
+		if len(members) < pagination.Limit {
+			break
+		}
+	}
+
+	children, err := h.containerRepo.GetChildren(ctx, fromID)
+	if err != nil {
+		return fmt.Errorf("failed to get children of moved container: %w", err)
+	}
+	for _, child := range children {
+		concreteChild, ok := child.(*domain.Container)
+		if !ok {
+			continue
+		}
+		concreteChild.ParentID = payload.ToID
+		if err := h.containerRepo.UpdateContainer(ctx, concreteChild); err != nil {
+			return fmt.Errorf("failed to reparent child container %s: %w", child.ID(), err)
+		}
+	}
+
+	if err := h.containerRepo.DeleteContainer(ctx, fromID); err != nil {
+		return fmt.Errorf("failed to remove container under its old ID: %w", err)
+	}
+
+	fmt.Printf("Repository updated: container %s moved to %s under %q\n", fromID, payload.ToID, payload.ToParentID)
+	return nil
+}
+
 // handleMemberAdded handles member added events
 func (h *ContainerEventHandler) handleMemberAdded(ctx context.Context, event *pericarpdomain.EntityEvent) error {
 	// Extract member information from event payload
@@ -433,12 +565,59 @@ func (h *ContainerEventHandler) applyContainerUpdatesFromEvent(container *domain
 		return fmt.Errorf("failed to unmarshal event payload: %w", err)
 	}
 
-	// Apply updates based on what's in the payload
+	// Apply updates based on what's in the payload; language-tagged values are kept separately
+	language, _ := payload["language"].(string)
 	if title, ok := payload["title"].(string); ok {
-		container.SetTitle(title)
+		if language != "" {
+			container.SetLocalizedTitle(language, title)
+		} else {
+			container.SetTitle(title)
+		}
 	}
 	if description, ok := payload["description"].(string); ok {
-		container.SetDescription(description)
+		if language != "" {
+			container.SetLocalizedDescription(language, description)
+		} else {
+			container.SetDescription(description)
+		}
+	}
+	if normalize, ok := payload["rdfNormalization"].(bool); ok {
+		container.SetRDFNormalization(normalize)
+	}
+	if enabled, ok := payload["membershipEvents"].(bool); ok {
+		container.SetMembershipEvents(enabled)
+	}
+	if allowed, ok := payload["allowExternalMembers"].(bool); ok {
+		container.SetExternalMembers(allowed)
+	}
+	if _, ok := payload["defaultSort"]; ok {
+		sort, _ := domain.DefaultSort(payload)
+		container.SetDefaultSort(sort)
+	}
+	if _, ok := payload[domain.RetentionPolicyKey]; ok {
+		policy, _ := domain.ContainerRetentionPolicy(payload)
+		container.SetRetentionPolicy(policy)
+	}
+	if inheritable, ok := payload[domain.InheritableMetadataKey].(map[string]interface{}); ok {
+		inheritOnMove, _ := payload[domain.InheritOnMoveKey].(bool)
+		container.SetInheritableMetadata(inheritable, inheritOnMove)
+	}
+	if reference, ok := payload[domain.MembershipResourceKey].(string); ok {
+		container.SetMembershipResource(reference)
+	}
+	if relation, ok := payload[domain.HasMemberRelationKey].(string); ok {
+		inserted, _ := payload[domain.InsertedContentRelationKey].(string)
+		container.SetMembershipRelations(relation, inserted)
+	}
+	if touched, _ := payload["touched"].(bool); touched {
+		if version, ok := payload[domain.ContainerVersionKey].(float64); ok {
+			container.SetMetadata(domain.ContainerVersionKey, int(version))
+		}
+		if updatedAt, ok := payload["updatedAt"].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, updatedAt); err == nil {
+				container.SetMetadata("updatedAt", t)
+			}
+		}
 	}
 
 	// Clear events since we're applying from events
@@ -454,12 +633,14 @@ func (r *EventHandlerRegistrar) RegisterContainerEventHandler(handler *Container
 		"container.created",
 		"container.updated",
 		"container.deleted",
+		"container.moved",
 		"container.member_added",
 		"container.member_removed",
 	}
 
+	subscriber := r.retry.Wrap(EventHandlerContainer, handler)
 	for _, eventType := range eventTypes {
-		if err := r.dispatcher.Subscribe(eventType, handler); err != nil {
+		if err := r.dispatcher.Subscribe(eventType, subscriber); err != nil {
 			return fmt.Errorf("failed to subscribe to event type %s: %w", eventType, err)
 		}
 	}
@@ -469,6 +650,72 @@ func (r *EventHandlerRegistrar) RegisterContainerEventHandler(handler *Container
 	return nil
 }
 
+// RegisterContainmentCleanupHandler registers the handler removing deleted resources from the
+// containers that still list them
+func (r *EventHandlerRegistrar) RegisterContainmentCleanupHandler(handler *ContainmentCleanupHandler) error {
+	eventTypes := handler.EventTypes()
+
+	subscriber := r.retry.Wrap(EventHandlerContainment, handler)
+	for _, eventType := range eventTypes {
+		if err := r.dispatcher.Subscribe(eventType, subscriber); err != nil {
+			return fmt.Errorf("failed to subscribe to event type %s: %w", eventType, err)
+		}
+	}
+
+	r.handlers = append(r.handlers, handler)
+	fmt.Printf("Registered containment cleanup handler for events: %v\n", eventTypes)
+	return nil
+}
+
+// RegisterSolidNotificationService registers the Solid Notifications service for the
+// container changes it delivers. It is not retried: it only queues changes and never fails.
+func (r *EventHandlerRegistrar) RegisterSolidNotificationService(service *SolidNotificationService) error {
+	eventTypes := service.EventTypes()
+
+	for _, eventType := range eventTypes {
+		if err := r.dispatcher.Subscribe(eventType, service); err != nil {
+			return fmt.Errorf("failed to subscribe to event type %s: %w", eventType, err)
+		}
+	}
+
+	r.handlers = append(r.handlers, service)
+	fmt.Printf("Registered Solid notification service for events: %v\n", eventTypes)
+	return nil
+}
+
+// RegisterContainerChangeHub registers the hub fanning container changes out to live
+// subscribers. It is not retried: it never blocks on a subscriber and never fails.
+func (r *EventHandlerRegistrar) RegisterContainerChangeHub(hub *ContainerChangeHub) error {
+	eventTypes := hub.EventTypes()
+
+	for _, eventType := range eventTypes {
+		if err := r.dispatcher.Subscribe(eventType, hub); err != nil {
+			return fmt.Errorf("failed to subscribe to event type %s: %w", eventType, err)
+		}
+	}
+
+	r.handlers = append(r.handlers, hub)
+	fmt.Printf("Registered container change hub for events: %v\n", eventTypes)
+	return nil
+}
+
+// RegisterEventMirror registers the mirror publishing events to the message broker, retried
+// under the broker policy so events the broker refuses are dead-lettered rather than lost
+func (r *EventHandlerRegistrar) RegisterEventMirror(mirror *EventMirror) error {
+	eventTypes := mirror.EventTypes()
+
+	subscriber := r.retry.Wrap(EventHandlerBroker, mirror)
+	for _, eventType := range eventTypes {
+		if err := r.dispatcher.Subscribe(eventType, subscriber); err != nil {
+			return fmt.Errorf("failed to subscribe to event type %s: %w", eventType, err)
+		}
+	}
+
+	r.handlers = append(r.handlers, mirror)
+	fmt.Printf("Registered event mirror for events: %v\n", eventTypes)
+	return nil
+}
+
 // RegisterAllHandlers registers all event handlers
 func (r *EventHandlerRegistrar) RegisterAllHandlers(repo domain.ResourceRepository) error {
 	// Create and register resource event handler
@@ -495,12 +742,14 @@ func (r *EventHandlerRegistrar) RegisterEventPersistenceHandler(handler *EventPe
 		"container.created",
 		"container.updated",
 		"container.deleted",
+		"container.moved",
 		"container.member_added",
 		"container.member_removed",
 	}
 
+	subscriber := r.retry.Wrap(EventHandlerPersistence, handler)
 	for _, eventType := range allEventTypes {
-		if err := r.dispatcher.Subscribe(eventType, handler); err != nil {
+		if err := r.dispatcher.Subscribe(eventType, subscriber); err != nil {
 			return fmt.Errorf("failed to subscribe persistence handler to event type %s: %w", eventType, err)
 		}
 	}
@@ -239,13 +239,12 @@ func TestResourceEventHandler_ReconstructResourceFromEvent(t *testing.T) {
 	}
 }
 
-func TestResourceEventHandler_FilePersistence(t *testing.T) {
+func TestEventPersistenceHandler_FilePersistence(t *testing.T) {
 	// Create temporary directory for test
 	tempDir := t.TempDir()
 	eventLogPath := filepath.Join(tempDir, "events")
 
-	repo := newMockRepository()
-	handler := NewResourceEventHandlerWithConfig(repo, eventLogPath, true)
+	handler := NewEventPersistenceHandlerWithConfig(EventPersistenceConfig{EventLogPath: eventLogPath, EnableFilePersistence: true})
 	ctx := context.Background()
 
 	// Test resource created event with file persistence
@@ -270,7 +269,7 @@ func TestResourceEventHandler_FilePersistence(t *testing.T) {
 
 	// Verify event was persisted to file system
 	today := time.Now().Format("2006-01-02")
-	logFile := filepath.Join(eventLogPath, today, "events.log")
+	logFile := filepath.Join(eventLogPath, today, "resource-events.log")
 
 	// Check if log file exists
 	if _, err := os.Stat(logFile); os.IsNotExist(err) {
@@ -301,13 +300,12 @@ func TestResourceEventHandler_FilePersistence(t *testing.T) {
 	}
 }
 
-func TestResourceEventHandler_FilePersistenceDisabled(t *testing.T) {
+func TestEventPersistenceHandler_FilePersistenceDisabled(t *testing.T) {
 	// Create temporary directory for test
 	tempDir := t.TempDir()
 	eventLogPath := filepath.Join(tempDir, "events")
 
-	repo := newMockRepository()
-	handler := NewResourceEventHandlerWithConfig(repo, eventLogPath, false) // Disable persistence
+	handler := NewEventPersistenceHandlerWithConfig(EventPersistenceConfig{EventLogPath: eventLogPath, EnableFilePersistence: false}) // Disable persistence
 	ctx := context.Background()
 
 	// Test resource created event without file persistence
@@ -332,29 +330,19 @@ func TestResourceEventHandler_FilePersistenceDisabled(t *testing.T) {
 
 	// Verify no event log file was created
 	today := time.Now().Format("2006-01-02")
-	logFile := filepath.Join(eventLogPath, today, "events.log")
+	logFile := filepath.Join(eventLogPath, today, "resource-events.log")
 
 	if _, err := os.Stat(logFile); !os.IsNotExist(err) {
 		t.Errorf("Event log file should not exist when persistence is disabled")
 	}
-
-	// Verify resource was still stored in repository
-	exists, err := repo.Exists(ctx, "no-persistence-test")
-	if err != nil {
-		t.Fatalf("Failed to check resource existence: %v", err)
-	}
-	if !exists {
-		t.Error("Resource should have been stored in repository even without file persistence")
-	}
 }
 
-func TestResourceEventHandler_MultipleEventsFilePersistence(t *testing.T) {
+func TestEventPersistenceHandler_MultipleEventsFilePersistence(t *testing.T) {
 	// Create temporary directory for test
 	tempDir := t.TempDir()
 	eventLogPath := filepath.Join(tempDir, "events")
 
-	repo := newMockRepository()
-	handler := NewResourceEventHandlerWithConfig(repo, eventLogPath, true)
+	handler := NewEventPersistenceHandlerWithConfig(EventPersistenceConfig{EventLogPath: eventLogPath, EnableFilePersistence: true})
 	ctx := context.Background()
 
 	// Create multiple events
@@ -402,7 +390,7 @@ func TestResourceEventHandler_MultipleEventsFilePersistence(t *testing.T) {
 
 	// Verify all events were persisted to file system
 	today := time.Now().Format("2006-01-02")
-	logFile := filepath.Join(eventLogPath, today, "events.log")
+	logFile := filepath.Join(eventLogPath, today, "resource-events.log")
 
 	// Check if log file exists
 	if _, err := os.Stat(logFile); os.IsNotExist(err) {
@@ -430,9 +418,8 @@ func TestResourceEventHandler_MultipleEventsFilePersistence(t *testing.T) {
 	}
 }
 
-func TestResourceEventHandler_ConfigurationMethods(t *testing.T) {
-	repo := newMockRepository()
-	handler := NewResourceEventHandler(repo)
+func TestEventPersistenceHandler_ConfigurationMethods(t *testing.T) {
+	handler := NewEventPersistenceHandler()
 
 	// Test default configuration
 	if !handler.IsFilePersistenceEnabled() {
@@ -461,7 +448,9 @@ func TestResourceEventHandler_EventWorkflow(t *testing.T) {
 	eventLogPath := filepath.Join(tempDir, "events")
 
 	repo := newMockRepository()
-	handler := NewResourceEventHandlerWithConfig(repo, eventLogPath, true)
+	resourceHandler := NewResourceEventHandler(repo)
+	persistenceHandler := NewEventPersistenceHandlerWithConfig(EventPersistenceConfig{EventLogPath: eventLogPath, EnableFilePersistence: true})
+	handler := &fanOutHandler{handlers: []pericarpdomain.EventHandler{resourceHandler, persistenceHandler}}
 	ctx := context.Background()
 
 	// Test complete workflow: create -> update -> delete
@@ -548,7 +537,7 @@ func TestResourceEventHandler_EventWorkflow(t *testing.T) {
 
 	// Verify all events were persisted
 	today := time.Now().Format("2006-01-02")
-	logFile := filepath.Join(eventLogPath, today, "events.log")
+	logFile := filepath.Join(eventLogPath, today, "resource-events.log")
 
 	content, err := os.ReadFile(logFile)
 	if err != nil {
@@ -560,3 +549,17 @@ func TestResourceEventHandler_EventWorkflow(t *testing.T) {
 		t.Errorf("Expected 3 event entries for workflow, got %d", len(lines))
 	}
 }
+
+// fanOutHandler hands each envelope to several handlers in turn, as the dispatcher does
+type fanOutHandler struct {
+	handlers []pericarpdomain.EventHandler
+}
+
+func (f *fanOutHandler) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	for _, handler := range f.handlers {
+		if err := handler.Handle(ctx, envelope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
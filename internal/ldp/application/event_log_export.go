@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// EventLogExporter writes the event log as newline-delimited JSON for external archival. Each
+// line is one domain.EventLogEntry; its sequence number lets an archiver pull the log
+// incrementally, asking only for entries after the last one it stored.
+type EventLogExporter struct {
+	reader domain.EventLogReader
+}
+
+// NewEventLogExporter creates an exporter over the stored event log
+func NewEventLogExporter(reader domain.EventLogReader) *EventLogExporter {
+	return &EventLogExporter{reader: reader}
+}
+
+// StreamEventLog writes the events stored at or after since, in sequence order. The zero time
+// writes the whole log.
+func (e *EventLogExporter) StreamEventLog(ctx context.Context, since time.Time, w io.Writer) error {
+	return e.stream(ctx, domain.EventLogQuery{Since: since}, w)
+}
+
+// StreamEventLogFrom writes the events after the given sequence number, in sequence order, so
+// an archiver can resume where its last pull ended
+func (e *EventLogExporter) StreamEventLogFrom(ctx context.Context, afterSequence int64, w io.Writer) error {
+	return e.stream(ctx, domain.EventLogQuery{AfterSequence: afterSequence}, w)
+}
+
+// stream encodes each selected entry as it is read, flushing writers that buffer, so neither
+// side holds the whole log
+func (e *EventLogExporter) stream(ctx context.Context, query domain.EventLogQuery, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() })
+
+	return e.reader.ReadEventLog(ctx, query, func(entry domain.EventLogEntry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := encoder.Encode(entry); err != nil {
+			return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to write event log entry").
+				WithOperation("StreamEventLog").WithContext("eventID", entry.EventID)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// mirroredEventTypes are the committed domain events published to the message broker
+var mirroredEventTypes = []string{
+	"resource.created",
+	"resource.updated",
+	"resource.deleted",
+	"resource.created_with_relations",
+	"resource.linked",
+	"resource.relationship_updated",
+	"container.created",
+	"container.updated",
+	"container.deleted",
+	"container.moved",
+	"container.member_added",
+	"container.member_removed",
+}
+
+// EventMirror publishes committed domain events to an external message broker, one message per
+// event in the event log's export format. It is registered under the broker retry policy: an
+// event the broker does not accept is retried and then dead-lettered for replay, so every
+// event is published at least once. Consumers should deduplicate on eventId.
+type EventMirror struct {
+	publisher    domain.MessagePublisher
+	topics       map[string]string
+	defaultTopic string
+}
+
+// NewEventMirror creates a mirror publishing each event to the topic mapped to its type, or to
+// defaultTopic; events of a type mapped to no topic are not published
+func NewEventMirror(publisher domain.MessagePublisher, topics map[string]string, defaultTopic string) *EventMirror {
+	return &EventMirror{
+		publisher:    publisher,
+		topics:       topics,
+		defaultTopic: defaultTopic,
+	}
+}
+
+// EventTypes returns the event types published to the broker
+func (m *EventMirror) EventTypes() []string {
+	return mirroredEventTypes
+}
+
+// Topic returns the topic events of a type are published to, or "" when they are not published
+func (m *EventMirror) Topic(eventType string) string {
+	if topic, ok := m.topics[eventType]; ok {
+		return topic
+	}
+	return m.defaultTopic
+}
+
+// Handle publishes an event to its topic. A publishing failure is returned so the event is
+// retried.
+func (m *EventMirror) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event := envelope.Event()
+	if event == nil {
+		return nil
+	}
+	topic := m.Topic(event.EventType())
+	if topic == "" {
+		return nil
+	}
+
+	entry := domain.EventLogEntry{
+		EventID:           envelope.EventID(),
+		EventType:         event.EventType(),
+		AggregateID:       event.AggregateID(),
+		AggregateSequence: event.SequenceNo(),
+		Timestamp:         envelope.Timestamp(),
+		Metadata:          envelope.Metadata(),
+	}
+	if payload := event.Payload(); json.Valid(payload) {
+		entry.Data = payload
+	}
+
+	message, err := json.Marshal(entry)
+	if err != nil {
+		// A message that cannot be serialized will not serialize on a retry either
+		fmt.Printf("Warning: skipping broker message for event %s: %v\n", envelope.EventID(), err)
+		return nil
+	}
+
+	if err := m.publisher.Publish(ctx, topic, message); err != nil {
+		return domain.WrapStorageError(err, "BROKER_PUBLISH_FAILED", "failed to publish event to message broker").
+			WithOperation("MirrorEvent").WithContext("eventID", envelope.EventID()).WithContext("topic", topic)
+	}
+	return nil
+}
+
+// Close closes the connection to the broker; a nil mirror has nothing to close
+func (m *EventMirror) Close() error {
+	if m == nil {
+		return nil
+	}
+	return m.publisher.Close()
+}
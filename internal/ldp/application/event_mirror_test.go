@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps published messages by topic and fails the first failures publishes
+type recordingPublisher struct {
+	mu       sync.Mutex
+	failures int
+	messages map[string][][]byte
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, message []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	if p.messages == nil {
+		p.messages = make(map[string][][]byte)
+	}
+	p.messages[topic] = append(p.messages[topic], message)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func mirrorTestEnvelope(eventType string) *testEnvelope {
+	event := pericarpdomain.NewEntityEvent("container", eventType, "photos", "", "", map[string]interface{}{"memberID": "cat.jpg"})
+	return &testEnvelope{event: event, timestamp: time.Now(), eventID: "event-" + eventType}
+}
+
+func TestEventMirror_PublishesToMappedTopics(t *testing.T) {
+	publisher := &recordingPublisher{}
+	mirror := NewEventMirror(publisher, map[string]string{
+		"container.member_added": "goro.members",
+		"container.updated":      "",
+	}, "goro.events")
+	ctx := context.Background()
+
+	require.NoError(t, mirror.Handle(ctx, mirrorTestEnvelope("member_added")))
+	require.NoError(t, mirror.Handle(ctx, mirrorTestEnvelope("created")))
+	require.NoError(t, mirror.Handle(ctx, mirrorTestEnvelope("updated")))
+
+	require.Len(t, publisher.messages["goro.members"], 1)
+	require.Len(t, publisher.messages["goro.events"], 1)
+	assert.Len(t, publisher.messages, 2, "an empty topic leaves the type unpublished")
+
+	var entry domain.EventLogEntry
+	require.NoError(t, json.Unmarshal(publisher.messages["goro.members"][0], &entry))
+	assert.Equal(t, "event-member_added", entry.EventID)
+	assert.Equal(t, "container.member_added", entry.EventType)
+	assert.Equal(t, "photos", entry.AggregateID)
+	assert.JSONEq(t, `{"memberID":"cat.jpg"}`, string(entry.Data))
+}
+
+func TestEventMirror_RetriesAndDeadLettersUnacknowledgedEvents(t *testing.T) {
+	retry, _ := newTestEventRetry(EventRetryPolicy{MaxAttempts: 3}, nil)
+	publisher := &recordingPublisher{failures: 2}
+	subscriber := retry.Wrap(EventHandlerBroker, NewEventMirror(publisher, nil, "goro.events"))
+	ctx := context.Background()
+
+	require.NoError(t, subscriber.Handle(ctx, mirrorTestEnvelope("created")))
+	assert.Len(t, publisher.messages["goro.events"], 1, "published on the third attempt")
+
+	publisher.failures = 3
+	require.NoError(t, subscriber.Handle(ctx, mirrorTestEnvelope("deleted")))
+	letters, err := retry.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, EventHandlerBroker, letters[0].Handler)
+	assert.Equal(t, "event-deleted", letters[0].EventID)
+
+	require.NoError(t, retry.ReplayDeadLetter(ctx, letters[0].ID))
+	assert.Len(t, publisher.messages["goro.events"], 2)
+}
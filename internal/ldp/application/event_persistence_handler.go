@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 )
 
@@ -83,7 +84,7 @@ func (h *EventPersistenceHandler) handleEntityEvent(ctx context.Context, event *
 // handleGenericEvent handles non-entity events
 func (h *EventPersistenceHandler) handleGenericEvent(ctx context.Context, event pericarpdomain.Event) error {
 	// For now, just log generic events
-	fmt.Printf("Generic event received: Type=%T, ID=%s\n", event, event.GetID())
+	fmt.Printf("Generic event received: Type=%T, AggregateID=%s\n", event, event.AggregateID())
 
 	// Future: Implement generic event persistence
 	return nil
@@ -117,7 +118,7 @@ func (h *EventPersistenceHandler) persistEventToFileSystem(event *pericarpdomain
 		// Additional metadata for activity stream compatibility
 		"version":     "1.0",
 		"source":      "ldp-server",
-		"dataVersion": event.GetVersion(), // If available
+		"dataVersion": event.SequenceNo(),
 	}
 
 	// Marshal event to JSON (single line for easier parsing)
@@ -126,12 +127,14 @@ func (h *EventPersistenceHandler) persistEventToFileSystem(event *pericarpdomain
 		return fmt.Errorf("failed to marshal event to JSON: %w", err)
 	}
 
-	// Determine log file based on entity type
+	// Determine log file based on entity type; read audit events get their own log
 	var logFile string
-	switch event.EntityType {
-	case "resource":
+	switch {
+	case event.Type == domain.EventTypeContainerRead || event.Type == domain.EventTypeResourceRead:
+		logFile = filepath.Join(eventDir, "read-audit.log")
+	case event.EntityType == "resource":
 		logFile = filepath.Join(eventDir, "resource-events.log")
-	case "container":
+	case event.EntityType == "container":
 		logFile = filepath.Join(eventDir, "container-events.log")
 	default:
 		logFile = filepath.Join(eventDir, "events.log")
@@ -197,7 +200,7 @@ func (h *EventPersistenceHandler) convertToActivityStreamFormat(event *pericarpd
 			},
 		},
 		"type":      activityType,
-		"id":        fmt.Sprintf("urn:event:%s", event.GetID()),
+		"id":        fmt.Sprintf("urn:event:%s:%d", event.AggregateID(), event.SequenceNo()),
 		"published": time.Now().Format(time.RFC3339),
 		"actor": map[string]interface{}{
 			"type": "Service",
@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// Names the registrar gives its event handlers, used to select per-handler retry policies
+const (
+	EventHandlerResource    = "resource"
+	EventHandlerContainer   = "container"
+	EventHandlerContainment = "containment"
+	EventHandlerPersistence = "persistence"
+	EventHandlerBroker      = "broker"
+)
+
+// EventRetryPolicy bounds how often a failing event handler is retried. The wait between
+// attempts starts at InitialBackoff and doubles up to MaxBackoff.
+type EventRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// backoff returns the wait after the given failed attempt, counting from 1
+func (p EventRetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// EventRetry retries failed event handlers under their policies and dead-letters events that
+// exhaust them, so a transient failure neither loses an event nor blocks the handlers after it.
+// Dead-lettered events can be listed and replayed against the handler that failed them.
+type EventRetry struct {
+	defaultPolicy EventRetryPolicy
+	policies      map[string]EventRetryPolicy
+	deadLetters   domain.DeadLetterStore
+	handlers      map[string]pericarpdomain.EventHandler
+	mu            sync.RWMutex
+	sleep         func(ctx context.Context, wait time.Duration) error
+}
+
+// NewEventRetry creates an EventRetry applying defaultPolicy to handlers without their own policy
+func NewEventRetry(defaultPolicy EventRetryPolicy, policies map[string]EventRetryPolicy, deadLetters domain.DeadLetterStore) *EventRetry {
+	return &EventRetry{
+		defaultPolicy: defaultPolicy,
+		policies:      policies,
+		deadLetters:   deadLetters,
+		handlers:      make(map[string]pericarpdomain.EventHandler),
+		sleep:         sleepContext,
+	}
+}
+
+// Wrap returns the handler retried under its named policy; a nil EventRetry returns it unchanged
+func (r *EventRetry) Wrap(name string, handler pericarpdomain.EventHandler) pericarpdomain.EventHandler {
+	if r == nil {
+		return handler
+	}
+
+	r.mu.Lock()
+	r.handlers[name] = handler
+	r.mu.Unlock()
+
+	return &retryingEventHandler{name: name, handler: handler, retry: r}
+}
+
+// policy returns the retry policy for a named handler
+func (r *EventRetry) policy(name string) EventRetryPolicy {
+	policy, ok := r.policies[name]
+	if !ok {
+		return r.defaultPolicy
+	}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = r.defaultPolicy.MaxAttempts
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = r.defaultPolicy.InitialBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = r.defaultPolicy.MaxBackoff
+	}
+	return policy
+}
+
+// ListDeadLetters returns the events that exhausted their handler's retries
+func (r *EventRetry) ListDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	return r.deadLetters.List(ctx)
+}
+
+// ReplayDeadLetter hands a dead-lettered event to its handler once more. On success the dead
+// letter is removed; on failure it is kept with the new error and attempt count.
+func (r *EventRetry) ReplayDeadLetter(ctx context.Context, id string) error {
+	letter, err := r.deadLetters.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	handler, ok := r.handlers[letter.Handler]
+	r.mu.RUnlock()
+	if !ok {
+		return domain.NewStorageError("DEAD_LETTER_REPLAY_FAILED", "handler is not registered").
+			WithOperation("ReplayDeadLetter").WithContext("handler", letter.Handler)
+	}
+
+	if err := handler.Handle(ctx, letter.Envelope); err != nil {
+		letter.Attempts++
+		letter.LastError = err.Error()
+		letter.FailedAt = time.Now()
+		if putErr := r.deadLetters.Put(ctx, letter); putErr != nil {
+			fmt.Printf("Warning: failed to update dead letter %s: %v\n", letter.ID, putErr)
+		}
+		return domain.WrapStorageError(err, "DEAD_LETTER_REPLAY_FAILED", "event handler failed on replay").
+			WithOperation("ReplayDeadLetter").WithContext("id", id)
+	}
+
+	return r.deadLetters.Remove(ctx, id)
+}
+
+// retryingEventHandler retries a handler under its policy and dead-letters events it cannot process
+type retryingEventHandler struct {
+	name    string
+	handler pericarpdomain.EventHandler
+	retry   *EventRetry
+}
+
+// EventTypes returns the event types of the wrapped handler
+func (h *retryingEventHandler) EventTypes() []string {
+	return h.handler.EventTypes()
+}
+
+// Handle processes the event, retrying with backoff. An event that exhausts its retries is
+// dead-lettered and reported as handled, so the dispatcher does not redeliver it as well.
+func (h *retryingEventHandler) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	policy := h.retry.policy(h.name)
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	attempts := 0
+	for attempts < maxAttempts {
+		attempts++
+		if err = h.handler.Handle(ctx, envelope); err == nil {
+			return nil
+		}
+		if attempts < maxAttempts {
+			if sleepErr := h.retry.sleep(ctx, policy.backoff(attempts)); sleepErr != nil {
+				break
+			}
+		}
+	}
+
+	letter := domain.DeadLetter{
+		ID:        fmt.Sprintf("%s:%s", h.name, envelope.EventID()),
+		Handler:   h.name,
+		EventID:   envelope.EventID(),
+		Attempts:  attempts,
+		LastError: err.Error(),
+		FailedAt:  time.Now(),
+		Envelope:  envelope,
+	}
+	if event := envelope.Event(); event != nil {
+		letter.EventType = event.EventType()
+		letter.AggregateID = event.AggregateID()
+	}
+
+	if putErr := h.retry.deadLetters.Put(ctx, letter); putErr != nil {
+		return fmt.Errorf("failed to dead-letter event %s after %d attempts: %w", envelope.EventID(), attempts, err)
+	}
+	fmt.Printf("Warning: event %s dead-lettered by %s handler after %d attempts: %v\n", envelope.EventID(), h.name, attempts, err)
+	return nil
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
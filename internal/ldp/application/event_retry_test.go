@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyEventHandler fails until it has been called more than failures times
+type flakyEventHandler struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (h *flakyEventHandler) EventTypes() []string {
+	return []string{"resource.created"}
+}
+
+func (h *flakyEventHandler) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.calls++
+	if h.calls <= h.failures {
+		return errors.New("storage unavailable")
+	}
+	return nil
+}
+
+func newTestEventRetry(policy EventRetryPolicy, policies map[string]EventRetryPolicy) (*EventRetry, *[]time.Duration) {
+	retry := NewEventRetry(policy, policies, infrastructure.NewMemoryDeadLetterStore(0))
+	waits := &[]time.Duration{}
+	retry.sleep = func(ctx context.Context, wait time.Duration) error {
+		*waits = append(*waits, wait)
+		return nil
+	}
+	return retry, waits
+}
+
+func retryTestEnvelope() *testEnvelope {
+	event := pericarpdomain.NewEntityEvent("resource", "created", "notes", "", "", map[string]interface{}{})
+	return &testEnvelope{event: event, timestamp: time.Now(), eventID: "event-1"}
+}
+
+func TestEventRetry_RetriesUntilHandlerSucceeds(t *testing.T) {
+	retry, waits := newTestEventRetry(EventRetryPolicy{MaxAttempts: 5, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 25 * time.Millisecond}, nil)
+	handler := &flakyEventHandler{failures: 3}
+
+	require.NoError(t, retry.Wrap(EventHandlerResource, handler).Handle(context.Background(), retryTestEnvelope()))
+
+	assert.Equal(t, 4, handler.calls)
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond}, *waits)
+
+	letters, err := retry.ListDeadLetters(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
+
+func TestEventRetry_DeadLettersExhaustedEvents(t *testing.T) {
+	retry, _ := newTestEventRetry(EventRetryPolicy{MaxAttempts: 3}, nil)
+	handler := &flakyEventHandler{failures: 10}
+
+	// The event is reported handled so the dispatcher does not redeliver it
+	require.NoError(t, retry.Wrap(EventHandlerResource, handler).Handle(context.Background(), retryTestEnvelope()))
+	assert.Equal(t, 3, handler.calls)
+
+	letters, err := retry.ListDeadLetters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "resource:event-1", letters[0].ID)
+	assert.Equal(t, EventHandlerResource, letters[0].Handler)
+	assert.Equal(t, "resource.created", letters[0].EventType)
+	assert.Equal(t, "notes", letters[0].AggregateID)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Equal(t, "storage unavailable", letters[0].LastError)
+}
+
+func TestEventRetry_AppliesPerHandlerPolicy(t *testing.T) {
+	retry, _ := newTestEventRetry(EventRetryPolicy{MaxAttempts: 2}, map[string]EventRetryPolicy{
+		EventHandlerPersistence: {MaxAttempts: 6},
+	})
+	resourceHandler := &flakyEventHandler{failures: 10}
+	persistenceHandler := &flakyEventHandler{failures: 10}
+
+	require.NoError(t, retry.Wrap(EventHandlerResource, resourceHandler).Handle(context.Background(), retryTestEnvelope()))
+	require.NoError(t, retry.Wrap(EventHandlerPersistence, persistenceHandler).Handle(context.Background(), retryTestEnvelope()))
+
+	assert.Equal(t, 2, resourceHandler.calls)
+	assert.Equal(t, 6, persistenceHandler.calls)
+}
+
+func TestEventRetry_ReplayDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	retry, _ := newTestEventRetry(EventRetryPolicy{MaxAttempts: 2}, nil)
+	handler := &flakyEventHandler{failures: 3}
+	require.NoError(t, retry.Wrap(EventHandlerResource, handler).Handle(ctx, retryTestEnvelope()))
+
+	// The third call still fails, so the dead letter is kept with the new attempt count
+	err := retry.ReplayDeadLetter(ctx, "resource:event-1")
+	require.Error(t, err)
+	letters, err := retry.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, 3, letters[0].Attempts)
+
+	require.NoError(t, retry.ReplayDeadLetter(ctx, "resource:event-1"))
+	assert.Equal(t, 4, handler.calls)
+
+	letters, err = retry.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+
+	assert.True(t, domain.IsDeadLetterNotFound(retry.ReplayDeadLetter(ctx, "resource:event-1")))
+}
+
+func TestEventRetry_NilRetryLeavesHandlerUnwrapped(t *testing.T) {
+	var retry *EventRetry
+	handler := &flakyEventHandler{}
+
+	assert.Same(t, handler, retry.Wrap(EventHandlerResource, handler))
+}
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// idValidatorSetter is implemented by repositories that check the IDs they store
+type idValidatorSetter interface {
+	SetIDValidator(ids *domain.IDValidator)
+}
+
+// SetIDValidator sets the URI-safe pattern IDs of written resources must match; without one
+// IDs are checked against domain.DefaultIDPattern
+func (s *StorageService) SetIDValidator(ids *domain.IDValidator) {
+	s.ids = ids
+}
+
+// SetIDValidator sets the URI-safe pattern IDs of created and moved containers must match
+func (s *ContainerService) SetIDValidator(ids *domain.IDValidator) {
+	s.validator.SetIDValidator(ids)
+}
+
+// validateResourceID refuses a resource ID that is empty or not URI-safe with ErrInvalidID
+func (s *StorageService) validateResourceID(id, operation string) error {
+	if err := s.ids.ValidateID(id); err != nil {
+		return err.(*domain.StorageError).WithOperation(operation)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// UniqueIdentifierPolicy reports whether an account requires the Dublin Core identifiers of
+// its resources and containers to be unique
+type UniqueIdentifierPolicy interface {
+	UniqueIdentifiers(ctx context.Context, accountID string) bool
+}
+
+// identifierTracking records Dublin Core identifiers in an index and, for accounts whose
+// policy asks for it, refuses an identifier already held within the account
+type identifierTracking struct {
+	index  domain.IdentifierIndex
+	policy UniqueIdentifierPolicy
+}
+
+// SetIdentifierIndex records the dc:identifier of each resource in index, refusing duplicates
+// within the accounts policy requires unique identifiers for. Without a policy identifiers
+// are recorded but never refused.
+func (s *StorageService) SetIdentifierIndex(index domain.IdentifierIndex, policy UniqueIdentifierPolicy) {
+	s.identifiers = identifierTracking{index: index, policy: policy}
+}
+
+// SetIdentifierIndex records the dc:identifier of each container in index, refusing duplicates
+// within the accounts policy requires unique identifiers for
+func (s *ContainerService) SetIdentifierIndex(index domain.IdentifierIndex, policy UniqueIdentifierPolicy) {
+	s.identifiers = identifierTracking{index: index, policy: policy}
+}
+
+// claim records a resource's identifier for an account. Writes on behalf of no account, and
+// writes leaving the identifier unset, record nothing.
+func (t identifierTracking) claim(ctx context.Context, accountID, resourceID, identifier, operation string) error {
+	if t.index == nil || accountID == "" || identifier == "" {
+		return nil
+	}
+
+	unique := t.policy != nil && t.policy.UniqueIdentifiers(ctx, accountID)
+	if err := t.index.SetIdentifier(ctx, accountID, resourceID, identifier, unique); err != nil {
+		if storageErr, ok := domain.GetStorageError(err); ok {
+			return storageErr.WithOperation(operation).WithContext("id", resourceID)
+		}
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to record dc:identifier").
+			WithOperation(operation).WithContext("id", resourceID)
+	}
+	return nil
+}
+
+// release drops a deleted resource's identifier. A failure is logged rather than returned:
+// the resource is gone, and only its stale identifier is left behind.
+func (t identifierTracking) release(ctx context.Context, resourceID string) {
+	if t.index == nil {
+		return
+	}
+	if err := t.index.RemoveIdentifier(ctx, resourceID); err != nil {
+		fmt.Printf("Warning: failed to release dc:identifier of %s: %v\n", resourceID, err)
+	}
+}
+
+// resourceAccount returns the account a resource belongs to: the account its storage is
+// charged to, or the one a write to it is made on behalf of
+func (s *StorageService) resourceAccount(ctx context.Context, resourceID string) string {
+	if s.storageUsage != nil {
+		if accountID, _, found, err := s.storageUsage.ResourceUsage(ctx, resourceID); err == nil && found {
+			return accountID
+		}
+	}
+	if accountID, err := s.writeAccount(ctx, resourceID); err == nil {
+		return accountID
+	}
+	return domain.AccountIDFromContext(ctx)
+}
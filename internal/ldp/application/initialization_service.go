@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/go-kratos/kratos/v2/log"
 )
@@ -12,6 +13,7 @@ import (
 // InitializationService handles system initialization tasks
 type InitializationService struct {
 	containerRepo domain.ContainerRepository
+	storageUsage  domain.StorageUsageStore
 	logger        *log.Helper
 }
 
@@ -26,6 +28,11 @@ func NewInitializationService(
 	}
 }
 
+// SetStorageUsage sets the usage store the stored bytes statistic is seeded from at startup
+func (s *InitializationService) SetStorageUsage(usage domain.StorageUsageStore) {
+	s.storageUsage = usage
+}
+
 // Initialize performs all system initialization tasks
 func (s *InitializationService) Initialize(ctx context.Context) error {
 	s.logger.Info("Starting system initialization...")
@@ -35,6 +42,8 @@ func (s *InitializationService) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to ensure root container: %w", err)
 	}
 
+	s.SeedServerStats(ctx)
+
 	s.logger.Info("System initialization completed successfully")
 	return nil
 }
@@ -70,6 +79,31 @@ func (s *InitializationService) EnsureRootContainer(ctx context.Context) error {
 	return nil
 }
 
+// SeedServerStats sets the stored containers, resources and bytes statistics from the
+// membership index and the storage usage totals. Writes only move the statistics by what they
+// change, so without seeding they would count from zero after every restart. A statistic whose
+// source cannot count it is left as it is.
+func (s *InitializationService) SeedServerStats(ctx context.Context) {
+	if source, ok := s.containerRepo.(domain.StoredCountSource); ok {
+		containers, resources, err := source.StoredCounts(ctx)
+		if err != nil {
+			s.logger.Warnf("Failed to count stored containers and resources: %v", err)
+		} else {
+			metrics.StoredContainers.Set(containers)
+			metrics.StoredResources.Set(resources)
+		}
+	}
+
+	if totaler, ok := s.storageUsage.(domain.StorageUsageTotaler); ok {
+		total, err := totaler.TotalUsage(ctx)
+		if err != nil {
+			s.logger.Warnf("Failed to total stored bytes: %v", err)
+		} else {
+			metrics.StoredBytes.Set(total)
+		}
+	}
+}
+
 // createRootContainer creates the root container domain object
 func (s *InitializationService) createRootContainer(ctx context.Context) domain.ContainerResource {
 	const rootContainerID = "/"
@@ -125,7 +159,7 @@ func (s *InitializationService) GetRootContainer(ctx context.Context) (domain.Co
 
 	s.logger.Debug("Root container retrieved successfully",
 		"id", rootContainer.ID(),
-		"childCount", len(rootContainer.GetChildren()))
+		"memberCount", rootContainer.GetMemberCount())
 
 	return rootContainer, nil
 }
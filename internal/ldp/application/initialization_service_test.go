@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/assert"
@@ -358,3 +359,46 @@ func TestInitializationService_RepairInconsistencies(t *testing.T) {
 	err := service.RepairInconsistencies(ctx)
 	assert.NoError(t, err)
 }
+
+// countedContainerRepository reports fixed stored counts from its membership index
+type countedContainerRepository struct {
+	*MockContainerRepository
+	containers, resources int64
+}
+
+func (r *countedContainerRepository) StoredCounts(ctx context.Context) (int64, int64, error) {
+	return r.containers, r.resources, nil
+}
+
+// totaledStorageUsage reports a fixed total of charged bytes
+type totaledStorageUsage struct {
+	domain.StorageUsageStore
+	total int64
+}
+
+func (u *totaledStorageUsage) TotalUsage(ctx context.Context) (int64, error) {
+	return u.total, nil
+}
+
+func TestInitializationService_SeedServerStats(t *testing.T) {
+	previous := []int64{metrics.StoredContainers.Value(), metrics.StoredResources.Value(), metrics.StoredBytes.Value()}
+	t.Cleanup(func() {
+		metrics.StoredContainers.Set(previous[0])
+		metrics.StoredResources.Set(previous[1])
+		metrics.StoredBytes.Set(previous[2])
+	})
+	metrics.StoredContainers.Set(0)
+	metrics.StoredResources.Set(0)
+	metrics.StoredBytes.Set(0)
+
+	repo := &countedContainerRepository{MockContainerRepository: &MockContainerRepository{}, containers: 4, resources: 12}
+	service := NewInitializationService(repo, log.DefaultLogger)
+	service.SetStorageUsage(&totaledStorageUsage{total: 2048})
+
+	service.SeedServerStats(context.Background())
+
+	stats := metrics.SnapshotServerStats()
+	assert.Equal(t, int64(4), stats.TotalContainers)
+	assert.Equal(t, int64(12), stats.TotalResources)
+	assert.Equal(t, int64(2048), stats.TotalBytes)
+}
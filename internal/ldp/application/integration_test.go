@@ -23,11 +23,11 @@ func (r *RepositoryAdapter) Retrieve(ctx context.Context, id string) (domain.Res
 	if err != nil {
 		return nil, err
 	}
-	return *resource, nil
+	return resource, nil
 }
 
 func (r *RepositoryAdapter) Store(ctx context.Context, resource domain.Resource) error {
-	return r.FileSystemRepository.Store(ctx, &resource)
+	return r.FileSystemRepository.Store(ctx, resource)
 }
 
 func (r *RepositoryAdapter) StreamStore(ctx context.Context, id string, contentType string, reader io.Reader) (domain.Resource, error) {
@@ -74,7 +74,7 @@ func TestStorageServiceIntegration(t *testing.T) {
 	converter := infrastructure.NewRDFConverter()
 
 	// Create database and event infrastructure
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	if err != nil {
 		t.Fatalf("Failed to create database: %v", err)
 	}
@@ -111,8 +111,8 @@ func TestStorageServiceIntegration(t *testing.T) {
 		t.Fatalf("Failed to store resource: %v", err)
 	}
 
-	if (*resource).ID() != "integration-test" {
-		t.Errorf("Expected resource ID 'integration-test', got %s", (*resource).ID())
+	if resource.ID() != "integration-test" {
+		t.Errorf("Expected resource ID 'integration-test', got %s", resource.ID())
 	}
 
 	// Test retrieving the resource
@@ -121,7 +121,7 @@ func TestStorageServiceIntegration(t *testing.T) {
 		t.Fatalf("Failed to retrieve resource: %v", err)
 	}
 
-	if string((*retrievedResource).GetData()) != string(testData) {
+	if string(retrievedResource.GetData()) != string(testData) {
 		t.Errorf("Retrieved data doesn't match stored data")
 	}
 
@@ -131,8 +131,8 @@ func TestStorageServiceIntegration(t *testing.T) {
 		t.Fatalf("Failed to retrieve resource with format conversion: %v", err)
 	}
 
-	if (*convertedResource).GetContentType() != "text/turtle" {
-		t.Errorf("Expected content type 'text/turtle', got %s", (*convertedResource).GetContentType())
+	if convertedResource.GetContentType() != "text/turtle" {
+		t.Errorf("Expected content type 'text/turtle', got %s", convertedResource.GetContentType())
 	}
 
 	// Test resource existence
@@ -185,7 +185,7 @@ func TestStorageServiceStreamingIntegration(t *testing.T) {
 	converter := infrastructure.NewRDFConverter()
 
 	// Create database and event infrastructure
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	if err != nil {
 		t.Fatalf("Failed to create database: %v", err)
 	}
@@ -271,7 +271,7 @@ func TestStorageServiceErrorHandlingIntegration(t *testing.T) {
 	converter := infrastructure.NewRDFConverter()
 
 	// Create database and event infrastructure
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	if err != nil {
 		t.Fatalf("Failed to create database: %v", err)
 	}
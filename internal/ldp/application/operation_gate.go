@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// Heavy operations limited by the OperationGate
+const (
+	OperationBatchCreate = "batch_create"
+	OperationExport      = "export"
+	OperationStructure   = "structure"
+	OperationListing     = "listing"
+)
+
+// OperationLimitPolicy resolves an account's own cap on concurrent heavy operations. Zero
+// means the account has no override and the server-wide limit applies.
+type OperationLimitPolicy interface {
+	MaxConcurrentOperations(ctx context.Context, accountID string) int
+}
+
+// OperationGate caps how many heavy operations, such as batch creates, exports and async listings, each
+// account runs at once, so one tenant cannot monopolize workers and I/O. Excess operations
+// wait for a slot up to the queue timeout and are then refused with ErrOperationLimitReached.
+// Requests without an account share one slot pool.
+type OperationGate struct {
+	mu           sync.Mutex
+	limit        int
+	queueTimeout time.Duration
+	policy       OperationLimitPolicy
+	running      map[string]int
+	released     chan struct{}
+}
+
+// NewOperationGate creates a gate allowing limit concurrent heavy operations per account.
+// queueTimeout is how long an excess operation waits for a slot; zero refuses it at once.
+func NewOperationGate(limit int, queueTimeout time.Duration) *OperationGate {
+	return &OperationGate{
+		limit:        limit,
+		queueTimeout: queueTimeout,
+		running:      make(map[string]int),
+		released:     make(chan struct{}),
+	}
+}
+
+// SetPolicy sets the per-account operation limit policy
+func (g *OperationGate) SetPolicy(policy OperationLimitPolicy) {
+	g.policy = policy
+}
+
+// Acquire takes a slot for a heavy operation on behalf of an account, waiting for one to free
+// up within the queue timeout. The returned release must be called when the operation ends.
+func (g *OperationGate) Acquire(ctx context.Context, accountID, operation string) (func(), error) {
+	if g == nil {
+		return func() {}, nil
+	}
+
+	limit := g.limitFor(ctx, accountID)
+	deadline := time.Now().Add(g.queueTimeout)
+	for {
+		g.mu.Lock()
+		if g.running[accountID] < limit {
+			g.running[accountID]++
+			g.mu.Unlock()
+			return g.releaser(accountID), nil
+		}
+		released := g.released
+		g.mu.Unlock()
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, domain.WrapStorageError(
+				fmt.Errorf("account already runs %d heavy operations", limit),
+				domain.ErrOperationLimitReached.Code,
+				domain.ErrOperationLimitReached.Message,
+			).WithOperation(operation).WithContext("accountID", accountID).WithContext("limit", limit)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-released:
+			timer.Stop()
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Running returns how many heavy operations an account is running
+func (g *OperationGate) Running(accountID string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.running[accountID]
+}
+
+// limitFor returns the account's override when it has one, or the server-wide limit
+func (g *OperationGate) limitFor(ctx context.Context, accountID string) int {
+	if g.policy != nil && accountID != "" {
+		if limit := g.policy.MaxConcurrentOperations(ctx, accountID); limit > 0 {
+			return limit
+		}
+	}
+	return g.limit
+}
+
+// releaser frees an account's slot once and wakes the operations waiting for one
+func (g *OperationGate) releaser(accountID string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			if g.running[accountID] <= 1 {
+				delete(g.running, accountID)
+			} else {
+				g.running[accountID]--
+			}
+			close(g.released)
+			g.released = make(chan struct{})
+		})
+	}
+}
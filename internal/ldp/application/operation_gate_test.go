@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedOperationLimits overrides the operation limit of listed accounts
+type fixedOperationLimits map[string]int
+
+func (l fixedOperationLimits) MaxConcurrentOperations(ctx context.Context, accountID string) int {
+	return l[accountID]
+}
+
+func TestOperationGate_RefusesOperationsOverTheLimit(t *testing.T) {
+	gate := NewOperationGate(2, 0)
+	ctx := context.Background()
+
+	first, err := gate.Acquire(ctx, "acct-1", OperationExport)
+	require.NoError(t, err)
+	_, err = gate.Acquire(ctx, "acct-1", OperationBatchCreate)
+	require.NoError(t, err)
+
+	_, err = gate.Acquire(ctx, "acct-1", OperationStructure)
+	assert.True(t, domain.IsOperationLimitReached(err))
+
+	_, err = gate.Acquire(ctx, "acct-2", OperationExport)
+	assert.NoError(t, err, "other accounts have their own slots")
+
+	first()
+	first()
+	assert.Equal(t, 1, gate.Running("acct-1"), "releasing twice frees one slot")
+	_, err = gate.Acquire(ctx, "acct-1", OperationStructure)
+	assert.NoError(t, err)
+}
+
+func TestOperationGate_QueuesUntilASlotFrees(t *testing.T) {
+	gate := NewOperationGate(1, time.Second)
+	ctx := context.Background()
+
+	release, err := gate.Acquire(ctx, "acct-1", OperationExport)
+	require.NoError(t, err)
+	time.AfterFunc(20*time.Millisecond, release)
+
+	start := time.Now()
+	second, err := gate.Acquire(ctx, "acct-1", OperationExport)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	second()
+
+	t.Run("should give up at the queue timeout", func(t *testing.T) {
+		gate := NewOperationGate(1, 20*time.Millisecond)
+		_, err := gate.Acquire(ctx, "acct-1", OperationExport)
+		require.NoError(t, err)
+
+		_, err = gate.Acquire(ctx, "acct-1", OperationExport)
+		assert.True(t, domain.IsOperationLimitReached(err))
+	})
+}
+
+func TestOperationGate_AppliesAccountOverrides(t *testing.T) {
+	gate := NewOperationGate(1, 0)
+	gate.SetPolicy(fixedOperationLimits{"big": 3})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := gate.Acquire(ctx, "big", OperationExport)
+		require.NoError(t, err)
+	}
+	_, err := gate.Acquire(ctx, "big", OperationExport)
+	assert.True(t, domain.IsOperationLimitReached(err))
+
+	_, err = gate.Acquire(ctx, "small", OperationExport)
+	require.NoError(t, err)
+	_, err = gate.Acquire(ctx, "small", OperationExport)
+	assert.True(t, domain.IsOperationLimitReached(err), "accounts without an override get the server limit")
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SearchHit is a pod member matching a search, located by its container path
+type SearchHit struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	ContentType string   `json:"contentType,omitempty"`
+	// ContainerPath is the path of a container hit itself, or of the container holding a resource hit
+	ContainerPath string    `json:"containerPath"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// SearchResults is a page of search hits
+type SearchResults struct {
+	Hits       []SearchHit              `json:"hits"`
+	TotalCount int                      `json:"totalCount"`
+	Pagination domain.PaginationOptions `json:"pagination"`
+	HasMore    bool                     `json:"hasMore"`
+}
+
+// SetSearchIndex sets the index updated as containers and memberships change
+func (s *ContainerService) SetSearchIndex(index domain.SearchIndex) {
+	s.searchIndex = index
+}
+
+// SetSearchAuthorizer sets the authorizer used to drop search hits the caller cannot read
+func (s *ContainerService) SetSearchAuthorizer(authorizer ContainerReadAuthorizer) {
+	s.searchAuthorizer = authorizer
+}
+
+// SearchPod finds containers and resources in an account's pod by metadata. The pod is the
+// hierarchy rooted at the container named after the account. Hits are limited to those whose
+// container the caller can read, then paginated. Searches are refused when no search authorizer
+// is set.
+func (s *ContainerService) SearchPod(ctx context.Context, accountID string, query domain.SearchQuery, pagination domain.PaginationOptions) (*SearchResults, error) {
+	if accountID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("account ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"account ID cannot be empty",
+		).WithOperation("SearchPod")
+	}
+	if !pagination.IsValid() {
+		pagination = domain.GetDefaultPagination()
+	}
+	// Without an authorizer there is no way to hide members the caller cannot read
+	authorizer := s.searchAuthorizer
+	if authorizer == nil {
+		return nil, domain.ErrAccessDenied.WithOperation("SearchPod").WithContext("accountID", accountID)
+	}
+	if s.searchIndex == nil {
+		return &SearchResults{Hits: []SearchHit{}, Pagination: pagination}, nil
+	}
+
+	documents, err := s.searchIndex.Search(ctx, query)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to search pod",
+		).WithOperation("SearchPod").WithContext("accountID", accountID)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Resolve each hit's container once; hits outside the account's pod or in unreadable
+	// containers are dropped before paginating so counts never reveal hidden members
+	paths := make(map[string][]string)
+	hits := make([]SearchHit, 0, len(documents))
+	for _, doc := range documents {
+		containerID := doc.ContainerID
+		if doc.Type == domain.SearchTypeContainer {
+			containerID = doc.ID
+		}
+		if containerID == "" {
+			continue
+		}
+
+		path, resolved := paths[containerID]
+		if !resolved {
+			path, err = s.containerRepo.GetPath(ctx, containerID)
+			if err != nil {
+				path = nil
+			}
+			paths[containerID] = path
+		}
+		if len(path) == 0 || path[0] != accountID {
+			continue
+		}
+		if !authorizer.CanReadContainer(ctx, containerID) {
+			continue
+		}
+
+		hits = append(hits, SearchHit{
+			ID:            doc.ID,
+			Type:          doc.Type,
+			Title:         doc.Title,
+			Description:   doc.Description,
+			Tags:          doc.Tags,
+			ContentType:   doc.ContentType,
+			ContainerPath: "/" + strings.Join(path, "/"),
+			UpdatedAt:     doc.UpdatedAt,
+		})
+	}
+
+	results := &SearchResults{
+		Hits:       []SearchHit{},
+		TotalCount: len(hits),
+		Pagination: pagination,
+	}
+	if pagination.Offset < len(hits) {
+		end := pagination.Offset + pagination.Limit
+		if end > len(hits) {
+			end = len(hits)
+		}
+		results.Hits = hits[pagination.Offset:end]
+		results.HasMore = end < len(hits)
+	}
+
+	return results, nil
+}
+
+// indexContainer records a container's current metadata in the search index
+func (s *ContainerService) indexContainer(ctx context.Context, container *domain.Container) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.Index(ctx, domain.NewContainerSearchDocument(container)); err != nil {
+		fmt.Printf("Warning: failed to index container %s for search: %v\n", container.ID(), err)
+	}
+}
+
+// indexMembership records the container holding a resource in the search index
+func (s *ContainerService) indexMembership(ctx context.Context, containerID string, resource domain.Resource) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.Index(ctx, domain.NewResourceSearchDocument(resource, containerID)); err != nil {
+		fmt.Printf("Warning: failed to index resource %s for search: %v\n", resource.ID(), err)
+	}
+}
+
+// unindexMembership detaches a resource from its container in the search index
+func (s *ContainerService) unindexMembership(ctx context.Context, resourceID string) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.SetContainer(ctx, resourceID, ""); err != nil {
+		fmt.Printf("Warning: failed to detach resource %s in search index: %v\n", resourceID, err)
+	}
+}
+
+// unindex removes a member from the search index
+func (s *ContainerService) unindex(ctx context.Context, id string) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.Remove(ctx, id); err != nil {
+		fmt.Printf("Warning: failed to remove %s from search index: %v\n", id, err)
+	}
+}
+
+// SetSearchIndex sets the index updated as resources are stored and deleted
+func (s *StorageService) SetSearchIndex(index domain.SearchIndex) {
+	s.searchIndex = index
+}
+
+// indexResource records a resource's current metadata in the search index, keeping its container
+func (s *StorageService) indexResource(ctx context.Context, resource domain.Resource) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.Index(ctx, domain.NewResourceSearchDocument(resource, "")); err != nil {
+		fmt.Printf("Warning: failed to index resource %s for search: %v\n", resource.ID(), err)
+	}
+}
+
+// unindexResource removes a deleted resource from the search index
+func (s *StorageService) unindexResource(ctx context.Context, id string) {
+	if s.searchIndex == nil {
+		return
+	}
+	if err := s.searchIndex.Remove(ctx, id); err != nil {
+		fmt.Printf("Warning: failed to remove resource %s from search index: %v\n", id, err)
+	}
+}
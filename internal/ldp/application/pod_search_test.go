@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPodSearchTest(t *testing.T) (*ContainerService, *infrastructure.MemorySearchIndex) {
+	service, mockRepo, _ := setupContainerServiceTest()
+	index := infrastructure.NewMemorySearchIndex()
+	service.SetSearchIndex(index)
+	service.SetSearchAuthorizer(AllowAllContainerReads{})
+
+	mockRepo.On("GetPath", mock.Anything, "alice").Return([]string{"alice"}, nil)
+	mockRepo.On("GetPath", mock.Anything, "trips").Return([]string{"alice", "trips"}, nil)
+	mockRepo.On("GetPath", mock.Anything, "bob").Return([]string{"bob"}, nil)
+
+	ctx := context.Background()
+	documents := []domain.SearchDocument{
+		{ID: "trips", Type: domain.SearchTypeContainer, Title: "Trip photos", ContainerID: "alice"},
+		{ID: "lisbon", Type: domain.SearchTypeResource, Title: "Trip to Lisbon", Tags: []string{"travel"}, ContentType: "image/jpeg", ContainerID: "trips"},
+		{ID: "porto", Type: domain.SearchTypeResource, Title: "Porto", Description: "Weekend trip", Tags: []string{"travel", "food"}, ContentType: "text/turtle", ContainerID: "trips"},
+		{ID: "bobs-trip", Type: domain.SearchTypeResource, Title: "Trip to Oslo", ContainerID: "bob"},
+		{ID: "unfiled", Type: domain.SearchTypeResource, Title: "Trip draft"},
+	}
+	for _, doc := range documents {
+		require.NoError(t, index.Index(ctx, doc))
+	}
+
+	return service, index
+}
+
+func TestContainerService_SearchPod_ScopesToAccount(t *testing.T) {
+	service, _ := setupPodSearchTest(t)
+
+	results, err := service.SearchPod(context.Background(), "alice", domain.SearchQuery{Text: "TRIP"}, domain.GetDefaultPagination())
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, results.TotalCount)
+	require.Len(t, results.Hits, 3)
+	assert.Equal(t, "lisbon", results.Hits[0].ID)
+	assert.Equal(t, "/alice/trips", results.Hits[0].ContainerPath)
+	assert.Equal(t, "porto", results.Hits[1].ID)
+	assert.Equal(t, "trips", results.Hits[2].ID)
+	assert.Equal(t, "/alice/trips", results.Hits[2].ContainerPath)
+}
+
+func TestContainerService_SearchPod_CombinesFilters(t *testing.T) {
+	service, _ := setupPodSearchTest(t)
+
+	results, err := service.SearchPod(context.Background(), "alice", domain.SearchQuery{
+		Text:        "trip",
+		Tags:        []string{"travel", "FOOD"},
+		ContentType: "text/turtle; charset=utf-8",
+		MemberType:  domain.SearchTypeResource,
+	}, domain.GetDefaultPagination())
+	require.NoError(t, err)
+
+	require.Len(t, results.Hits, 1)
+	assert.Equal(t, "porto", results.Hits[0].ID)
+}
+
+func TestContainerService_SearchPod_FiltersUnreadableContainers(t *testing.T) {
+	service, _ := setupPodSearchTest(t)
+	service.SetSearchAuthorizer(denyContainerReads{"trips": true})
+
+	results, err := service.SearchPod(context.Background(), "alice", domain.SearchQuery{Text: "trip"}, domain.GetDefaultPagination())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, results.TotalCount)
+	assert.Empty(t, results.Hits)
+}
+
+func TestContainerService_SearchPod_Paginates(t *testing.T) {
+	service, _ := setupPodSearchTest(t)
+
+	results, err := service.SearchPod(context.Background(), "alice", domain.SearchQuery{Text: "trip"}, domain.PaginationOptions{Limit: 2, Offset: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, results.TotalCount)
+	require.Len(t, results.Hits, 2)
+	assert.Equal(t, "porto", results.Hits[0].ID)
+	assert.False(t, results.HasMore)
+
+	results, err = service.SearchPod(context.Background(), "alice", domain.SearchQuery{Text: "trip"}, domain.PaginationOptions{Limit: 1, Offset: 0})
+	require.NoError(t, err)
+	assert.True(t, results.HasMore)
+}
+
+func TestContainerService_SearchPod_RequiresAccount(t *testing.T) {
+	service, _ := setupPodSearchTest(t)
+
+	_, err := service.SearchPod(context.Background(), "", domain.SearchQuery{}, domain.GetDefaultPagination())
+	assert.Error(t, err)
+}
+
+func TestContainerService_SearchPod_RefusedWithoutAuthorizer(t *testing.T) {
+	service, _ := setupPodSearchTest(t)
+	service.SetSearchAuthorizer(nil)
+
+	results, err := service.SearchPod(context.Background(), "alice", domain.SearchQuery{Text: "trip"}, domain.GetDefaultPagination())
+	assert.True(t, domain.IsAccessDenied(err))
+	assert.Nil(t, results)
+}
+
+func TestContainerService_AddResource_IndexesMembership(t *testing.T) {
+	service, mockRepo, mockUoW := setupContainerServiceTest()
+	index := infrastructure.NewMemorySearchIndex()
+	service.SetSearchIndex(index)
+	service.SetSearchAuthorizer(AllowAllContainerReads{})
+	ctx := context.Background()
+
+	container := domain.NewContainer(ctx, "trips", "alice", domain.BasicContainer)
+	mockRepo.On("GetContainer", ctx, "trips").Return(container, nil)
+	mockRepo.On("GetPath", mock.Anything, "trips").Return([]string{"alice", "trips"}, nil)
+	mockUoW.On("RegisterEvents", mock.Anything).Return()
+	mockUoW.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	resource := domain.NewResource(ctx, "lisbon", "image/jpeg", []byte("jpeg"))
+	resource.SetMetadata("title", "Lisbon")
+	resource.SetMetadata("tags", "travel, city")
+	require.NoError(t, service.AddResource(ctx, "trips", "lisbon", resource))
+
+	results, err := service.SearchPod(ctx, "alice", domain.SearchQuery{Tags: []string{"city"}}, domain.GetDefaultPagination())
+	require.NoError(t, err)
+	require.Len(t, results.Hits, 1)
+	assert.Equal(t, "Lisbon", results.Hits[0].Title)
+	assert.Equal(t, "/alice/trips", results.Hits[0].ContainerPath)
+}
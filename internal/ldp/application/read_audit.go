@@ -0,0 +1,248 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// ReadAuditPolicy decides whether reads performed on behalf of an account are audited.
+// It allows individual accounts to opt in even when read auditing is globally disabled.
+type ReadAuditPolicy interface {
+	ReadAuditEnabled(ctx context.Context, accountID string) bool
+}
+
+// ReadAuditOptIns is implemented by read audit policies that can tell whether any account has
+// opted in, so that while none has, reads skip attributing themselves to a pod
+type ReadAuditOptIns interface {
+	AnyReadAuditEnabled(ctx context.Context) bool
+}
+
+// readAuditPolicyTTL is how long an account's read audit setting, and whether any account has
+// opted in, is reused before the policy is asked again, so a change to the setting takes
+// effect within this long
+const readAuditPolicyTTL = 30 * time.Second
+
+// readAuditFlag is a read audit setting as last reported by the policy
+type readAuditFlag struct {
+	enabled   bool
+	checkedAt time.Time
+}
+
+// ReadAuditEntry describes the caller and request context of a single read. AccountID is the
+// account whose pod was read.
+type ReadAuditEntry struct {
+	UserID    string
+	AccountID string
+	RequestID string
+	Format    string
+}
+
+// ReadAuditor emits container_read/resource_read audit events.
+// Events are handed directly to the audit projection and bypass the unit of work,
+// so they never reach the general event store.
+type ReadAuditor struct {
+	enabled       bool
+	projection    pericarpdomain.EventHandler
+	policy        ReadAuditPolicy
+	containerRepo domain.ContainerRepository
+
+	mu           sync.Mutex
+	accountFlags map[string]readAuditFlag
+	optIns       readAuditFlag
+}
+
+// NewReadAuditor creates a new read auditor
+func NewReadAuditor(enabled bool, projection pericarpdomain.EventHandler, policy ReadAuditPolicy) *ReadAuditor {
+	return &ReadAuditor{
+		enabled:      enabled,
+		projection:   projection,
+		policy:       policy,
+		accountFlags: make(map[string]readAuditFlag),
+	}
+}
+
+// SetPolicy sets the per-account read audit policy
+func (a *ReadAuditor) SetPolicy(policy ReadAuditPolicy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policy = policy
+	a.accountFlags = make(map[string]readAuditFlag)
+	a.optIns = readAuditFlag{}
+}
+
+// SetPodAccounts sets the container repository reads are attributed through: each read is
+// charged to the account owning the pod it was made in, overriding the entry's AccountID
+func (a *ReadAuditor) SetPodAccounts(containerRepo domain.ContainerRepository) {
+	a.containerRepo = containerRepo
+}
+
+// IsEnabled returns whether read auditing is globally enabled
+func (a *ReadAuditor) IsEnabled() bool {
+	return a != nil && a.enabled
+}
+
+// ShouldAudit reports whether a read on behalf of the given account should be audited
+func (a *ReadAuditor) ShouldAudit(ctx context.Context, accountID string) bool {
+	if a == nil || a.projection == nil {
+		return false
+	}
+	if a.enabled {
+		return true
+	}
+	if a.policy != nil && accountID != "" {
+		return a.accountAuditEnabled(ctx, accountID)
+	}
+	return false
+}
+
+// mayAudit reports whether any read could be audited, so reads skip attributing themselves to
+// a pod when auditing is off and no account has opted in
+func (a *ReadAuditor) mayAudit(ctx context.Context) bool {
+	if a == nil || a.projection == nil {
+		return false
+	}
+	if a.enabled {
+		return true
+	}
+	if a.policy == nil {
+		return false
+	}
+	optIns, ok := a.policy.(ReadAuditOptIns)
+	if !ok {
+		return true
+	}
+	return a.anyAccountAudits(ctx, optIns)
+}
+
+// anyAccountAudits asks the policy whether any account audits its reads, reusing the answer for
+// readAuditPolicyTTL so that reads do not each query the accounts
+func (a *ReadAuditor) anyAccountAudits(ctx context.Context, optIns ReadAuditOptIns) bool {
+	a.mu.Lock()
+	flag := a.optIns
+	a.mu.Unlock()
+	if !flag.checkedAt.IsZero() && time.Since(flag.checkedAt) < readAuditPolicyTTL {
+		return flag.enabled
+	}
+
+	flag = readAuditFlag{enabled: optIns.AnyReadAuditEnabled(ctx), checkedAt: time.Now()}
+	a.mu.Lock()
+	a.optIns = flag
+	a.mu.Unlock()
+	return flag.enabled
+}
+
+// accountAuditEnabled asks the policy whether an account audits its reads, reusing the answer
+// for readAuditPolicyTTL so that reads do not each look the account up
+func (a *ReadAuditor) accountAuditEnabled(ctx context.Context, accountID string) bool {
+	a.mu.Lock()
+	flag, cached := a.accountFlags[accountID]
+	a.mu.Unlock()
+	if cached && time.Since(flag.checkedAt) < readAuditPolicyTTL {
+		return flag.enabled
+	}
+
+	flag = readAuditFlag{enabled: a.policy.ReadAuditEnabled(ctx, accountID), checkedAt: time.Now()}
+	a.mu.Lock()
+	a.accountFlags[accountID] = flag
+	a.mu.Unlock()
+	return flag.enabled
+}
+
+// RecordContainerRead records a container_read audit event
+func (a *ReadAuditor) RecordContainerRead(ctx context.Context, containerID string, entry ReadAuditEntry) error {
+	if !a.mayAudit(ctx) {
+		return nil
+	}
+	entry = a.attribute(ctx, containerID, entry)
+	if !a.ShouldAudit(ctx, entry.AccountID) {
+		return nil
+	}
+	return a.record(ctx, domain.NewContainerReadEvent(containerID, entry.eventData()))
+}
+
+// RecordResourceRead records a resource_read audit event
+func (a *ReadAuditor) RecordResourceRead(ctx context.Context, resourceID string, entry ReadAuditEntry) error {
+	if !a.mayAudit(ctx) {
+		return nil
+	}
+	entry = a.attribute(ctx, resourceID, entry)
+	if !a.ShouldAudit(ctx, entry.AccountID) {
+		return nil
+	}
+	return a.record(ctx, domain.NewResourceReadEvent(resourceID, entry.eventData()))
+}
+
+// attribute charges a read to the account owning the pod it was made in. Reads outside any
+// pod, or whose pod cannot be resolved, are charged to no account.
+func (a *ReadAuditor) attribute(ctx context.Context, id string, entry ReadAuditEntry) ReadAuditEntry {
+	if a == nil || a.projection == nil || a.containerRepo == nil {
+		return entry
+	}
+	accountID, err := podAccountID(ctx, a.containerRepo, id)
+	if err != nil {
+		accountID = ""
+	}
+	entry.AccountID = accountID
+	return entry
+}
+
+// record hands the event to the audit projection
+func (a *ReadAuditor) record(ctx context.Context, event *pericarpdomain.EntityEvent) error {
+	if err := a.projection.Handle(ctx, newAuditEnvelope(event)); err != nil {
+		return fmt.Errorf("failed to record read audit event: %w", err)
+	}
+	return nil
+}
+
+// eventData converts the entry into the audit event payload
+func (e ReadAuditEntry) eventData() *domain.ReadAuditEventData {
+	return &domain.ReadAuditEventData{
+		UserID:    e.UserID,
+		AccountID: e.AccountID,
+		RequestID: e.RequestID,
+		Format:    e.Format,
+		ReadAt:    time.Now(),
+	}
+}
+
+// auditEnvelope wraps audit events that are not persisted through the event store
+type auditEnvelope struct {
+	event     pericarpdomain.Event
+	eventID   string
+	timestamp time.Time
+}
+
+// newAuditEnvelope creates an envelope for an audit event
+func newAuditEnvelope(event pericarpdomain.Event) *auditEnvelope {
+	now := time.Now()
+	return &auditEnvelope{
+		event:     event,
+		eventID:   fmt.Sprintf("%s-%d", event.AggregateID(), now.UnixNano()),
+		timestamp: now,
+	}
+}
+
+// Event returns the wrapped audit event
+func (e *auditEnvelope) Event() pericarpdomain.Event {
+	return e.event
+}
+
+// Metadata returns the envelope metadata
+func (e *auditEnvelope) Metadata() map[string]interface{} {
+	return map[string]interface{}{"audit": true}
+}
+
+// EventID returns the envelope identifier
+func (e *auditEnvelope) EventID() string {
+	return e.eventID
+}
+
+// Timestamp returns when the envelope was created
+func (e *auditEnvelope) Timestamp() time.Time {
+	return e.timestamp
+}
@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// recordingAuditProjection captures events handed to the audit projection
+type recordingAuditProjection struct {
+	envelopes []pericarpdomain.Envelope
+}
+
+func (p *recordingAuditProjection) EventTypes() []string {
+	return []string{}
+}
+
+func (p *recordingAuditProjection) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	p.envelopes = append(p.envelopes, envelope)
+	return nil
+}
+
+// staticReadAuditPolicy enables read auditing for a fixed set of accounts
+type staticReadAuditPolicy map[string]bool
+
+func (p staticReadAuditPolicy) ReadAuditEnabled(ctx context.Context, accountID string) bool {
+	return p[accountID]
+}
+
+func TestReadAuditor_DisabledByDefault(t *testing.T) {
+	projection := &recordingAuditProjection{}
+	auditor := NewReadAuditor(false, projection, nil)
+
+	if err := auditor.RecordContainerRead(context.Background(), "container-1", ReadAuditEntry{UserID: "user-1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(projection.envelopes) != 0 {
+		t.Errorf("Expected no audit events when disabled, got %d", len(projection.envelopes))
+	}
+}
+
+func TestReadAuditor_RecordsContainerRead(t *testing.T) {
+	projection := &recordingAuditProjection{}
+	auditor := NewReadAuditor(true, projection, nil)
+
+	entry := ReadAuditEntry{UserID: "user-1", AccountID: "account-1", RequestID: "req-1", Format: "text/turtle"}
+	if err := auditor.RecordContainerRead(context.Background(), "container-1", entry); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(projection.envelopes) != 1 {
+		t.Fatalf("Expected 1 audit event, got %d", len(projection.envelopes))
+	}
+
+	event, ok := projection.envelopes[0].Event().(*pericarpdomain.EntityEvent)
+	if !ok {
+		t.Fatalf("Expected EntityEvent, got %T", projection.envelopes[0].Event())
+	}
+	if event.Type != domain.EventTypeContainerRead {
+		t.Errorf("Expected event type %s, got %s", domain.EventTypeContainerRead, event.Type)
+	}
+	if event.AggregateID() != "container-1" {
+		t.Errorf("Expected aggregate ID container-1, got %s", event.AggregateID())
+	}
+	if event.User() != "user-1" || event.Account() != "account-1" {
+		t.Errorf("Expected user-1/account-1, got %s/%s", event.User(), event.Account())
+	}
+
+	var data domain.ReadAuditEventData
+	if err := json.Unmarshal(event.Payload(), &data); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %v", err)
+	}
+	if data.RequestID != "req-1" {
+		t.Errorf("Expected request ID req-1, got %s", data.RequestID)
+	}
+	if data.ReadAt.IsZero() {
+		t.Error("Expected read timestamp to be set")
+	}
+}
+
+func TestReadAuditor_PerAccountPolicy(t *testing.T) {
+	projection := &recordingAuditProjection{}
+	auditor := NewReadAuditor(false, projection, staticReadAuditPolicy{"audited": true})
+	ctx := context.Background()
+
+	if err := auditor.RecordResourceRead(ctx, "resource-1", ReadAuditEntry{AccountID: "audited"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := auditor.RecordResourceRead(ctx, "resource-2", ReadAuditEntry{AccountID: "other"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(projection.envelopes) != 1 {
+		t.Fatalf("Expected 1 audit event, got %d", len(projection.envelopes))
+	}
+	if got := projection.envelopes[0].Event().AggregateID(); got != "resource-1" {
+		t.Errorf("Expected audited resource-1, got %s", got)
+	}
+}
+
+func TestReadAuditor_NilAuditor(t *testing.T) {
+	var auditor *ReadAuditor
+	if err := auditor.RecordContainerRead(context.Background(), "container-1", ReadAuditEntry{}); err != nil {
+		t.Errorf("Expected nil auditor to be a no-op, got %v", err)
+	}
+}
+
+func TestReadAuditor_AttributesReadsToPodAccount(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &TestMockContainerRepository{}
+	repo := &memberContainerRepository{
+		TestMockContainerRepository: mockRepo,
+		holders:                     map[string][]string{"beach.jpg": {"alice/photos"}},
+	}
+	mockRepo.On("ContainerExists", ctx, "alice/photos").Return(true, nil)
+	mockRepo.On("ContainerExists", ctx, "beach.jpg").Return(false, nil)
+	mockRepo.On("ContainerExists", ctx, "unfiled.txt").Return(false, nil)
+	mockRepo.On("GetPath", ctx, "alice/photos").Return([]string{"alice", "alice/photos"}, nil)
+
+	projection := &recordingAuditProjection{}
+	auditor := NewReadAuditor(false, projection, staticReadAuditPolicy{"alice": true})
+	auditor.SetPodAccounts(repo)
+
+	// The account named by the caller is ignored in favour of the pod read
+	if err := auditor.RecordContainerRead(ctx, "alice/photos", ReadAuditEntry{UserID: "bob", AccountID: "bob"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := auditor.RecordResourceRead(ctx, "beach.jpg", ReadAuditEntry{UserID: "bob"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := auditor.RecordResourceRead(ctx, "unfiled.txt", ReadAuditEntry{UserID: "bob", AccountID: "alice"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(projection.envelopes) != 2 {
+		t.Fatalf("Expected 2 audit events, got %d", len(projection.envelopes))
+	}
+	for _, envelope := range projection.envelopes {
+		event := envelope.Event().(*pericarpdomain.EntityEvent)
+		if event.Account() != "alice" {
+			t.Errorf("Expected read of %s charged to alice, got %q", event.AggregateID(), event.Account())
+		}
+	}
+}
+
+// countingReadAuditPolicy enables read auditing for a fixed set of accounts and counts lookups
+type countingReadAuditPolicy struct {
+	audited map[string]bool
+	lookups int
+}
+
+func (p *countingReadAuditPolicy) ReadAuditEnabled(ctx context.Context, accountID string) bool {
+	p.lookups++
+	return p.audited[accountID]
+}
+
+func TestReadAuditor_SkipsAttributionWhenNothingAudits(t *testing.T) {
+	// The repository has no expectations, so attributing a read would fail the test
+	auditor := NewReadAuditor(false, &recordingAuditProjection{}, nil)
+	auditor.SetPodAccounts(&TestMockContainerRepository{})
+
+	if err := auditor.RecordContainerRead(context.Background(), "alice/photos", ReadAuditEntry{UserID: "bob"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := auditor.RecordResourceRead(context.Background(), "beach.jpg", ReadAuditEntry{UserID: "bob"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestReadAuditor_CachesAccountPolicy(t *testing.T) {
+	projection := &recordingAuditProjection{}
+	policy := &countingReadAuditPolicy{audited: map[string]bool{"audited": true}}
+	auditor := NewReadAuditor(false, projection, policy)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := auditor.RecordResourceRead(ctx, "resource-1", ReadAuditEntry{AccountID: "audited"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := auditor.RecordResourceRead(ctx, "resource-2", ReadAuditEntry{AccountID: "other"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(projection.envelopes) != 3 {
+		t.Fatalf("Expected 3 audit events, got %d", len(projection.envelopes))
+	}
+	if policy.lookups != 2 {
+		t.Errorf("Expected each account's setting to be looked up once, got %d lookups", policy.lookups)
+	}
+}
+
+// optInReadAuditPolicy also reports whether any account has opted in, counting the checks
+type optInReadAuditPolicy struct {
+	countingReadAuditPolicy
+	checks int
+}
+
+func (p *optInReadAuditPolicy) AnyReadAuditEnabled(ctx context.Context) bool {
+	p.checks++
+	for _, audited := range p.audited {
+		if audited {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReadAuditor_SkipsAttributionUntilAnAccountOptsIn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no account has opted in", func(t *testing.T) {
+		projection := &recordingAuditProjection{}
+		policy := &optInReadAuditPolicy{countingReadAuditPolicy: countingReadAuditPolicy{audited: map[string]bool{}}}
+		auditor := NewReadAuditor(false, projection, policy)
+		// The repository has no expectations, so attributing a read would fail the test
+		auditor.SetPodAccounts(&TestMockContainerRepository{})
+
+		for i := 0; i < 3; i++ {
+			if err := auditor.RecordContainerRead(ctx, "alice/photos", ReadAuditEntry{UserID: "bob"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := auditor.RecordResourceRead(ctx, "beach.jpg", ReadAuditEntry{UserID: "bob"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		if len(projection.envelopes) != 0 {
+			t.Errorf("Expected no audit events, got %d", len(projection.envelopes))
+		}
+		if policy.checks != 1 {
+			t.Errorf("Expected the opt-ins to be checked once, got %d checks", policy.checks)
+		}
+		if policy.lookups != 0 {
+			t.Errorf("Expected no account lookups, got %d", policy.lookups)
+		}
+	})
+
+	t.Run("an account has opted in", func(t *testing.T) {
+		projection := &recordingAuditProjection{}
+		policy := &optInReadAuditPolicy{countingReadAuditPolicy: countingReadAuditPolicy{audited: map[string]bool{"audited": true}}}
+		auditor := NewReadAuditor(false, projection, policy)
+
+		if err := auditor.RecordResourceRead(ctx, "resource-1", ReadAuditEntry{AccountID: "audited"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := auditor.RecordResourceRead(ctx, "resource-2", ReadAuditEntry{AccountID: "other"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(projection.envelopes) != 1 {
+			t.Errorf("Expected 1 audit event, got %d", len(projection.envelopes))
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// ResourceAccessTracker records resource reads for lifecycle policies without adding a write
+// to every GET. Reads are buffered in memory and written to the store in batches; each
+// resource is buffered at most once per throttle window, and the buffer is flushed on an
+// interval or as soon as it holds maxPending resources.
+type ResourceAccessTracker struct {
+	store      domain.ResourceAccessStore
+	throttle   time.Duration
+	maxPending int
+	now        func() time.Time
+
+	mu       sync.Mutex
+	pending  map[string]time.Time
+	buffered map[string]time.Time // when each resource's last read was buffered, for throttling
+
+	flushMu  sync.Mutex // keeps batches reaching the store in order
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewResourceAccessTracker creates a tracker writing to the given store. A positive flush
+// interval starts a background flush loop, stopped by Close.
+func NewResourceAccessTracker(store domain.ResourceAccessStore, throttle, flushInterval time.Duration, maxPending int) *ResourceAccessTracker {
+	t := &ResourceAccessTracker{
+		store:      store,
+		throttle:   throttle,
+		maxPending: maxPending,
+		now:        time.Now,
+		pending:    make(map[string]time.Time),
+		buffered:   make(map[string]time.Time),
+		stop:       make(chan struct{}),
+	}
+
+	if flushInterval > 0 {
+		go t.flushPeriodically(flushInterval)
+	}
+
+	return t
+}
+
+// RecordAccess notes that a resource was read. It never blocks on the store; a nil tracker
+// records nothing, so callers need not check whether tracking is enabled.
+func (t *ResourceAccessTracker) RecordAccess(resourceID string) {
+	if t == nil || resourceID == "" {
+		return
+	}
+
+	now := t.now()
+	t.mu.Lock()
+	if last, ok := t.buffered[resourceID]; ok && now.Sub(last) < t.throttle {
+		t.mu.Unlock()
+		return
+	}
+	t.buffered[resourceID] = now
+	t.pending[resourceID] = now
+	full := t.maxPending > 0 && len(t.pending) >= t.maxPending
+	t.mu.Unlock()
+
+	if full {
+		go t.flushLogged()
+	}
+}
+
+// Flush writes buffered reads to the store. A failed batch is kept and retried by the next
+// flush, merged with any newer reads of the same resources.
+func (t *ResourceAccessTracker) Flush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	t.flushMu.Lock()
+	defer t.flushMu.Unlock()
+
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = make(map[string]time.Time)
+	// Forget reads older than the throttle window so the throttle state stays bounded
+	now := t.now()
+	for resourceID, last := range t.buffered {
+		if now.Sub(last) >= t.throttle {
+			delete(t.buffered, resourceID)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := t.store.RecordResourceAccesses(ctx, batch); err != nil {
+		t.mu.Lock()
+		for resourceID, accessedAt := range batch {
+			if newer, ok := t.pending[resourceID]; !ok || accessedAt.After(newer) {
+				t.pending[resourceID] = accessedAt
+			}
+		}
+		t.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Close stops the background flush loop and writes any buffered reads
+func (t *ResourceAccessTracker) Close(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.stopOnce.Do(func() { close(t.stop) })
+	return t.Flush(ctx)
+}
+
+// flushPeriodically flushes the buffer every interval until the tracker is closed
+func (t *ResourceAccessTracker) flushPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.flushLogged()
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// flushLogged flushes the buffer in the background, where a failure can only be reported
+func (t *ResourceAccessTracker) flushLogged() {
+	if err := t.Flush(context.Background()); err != nil {
+		fmt.Printf("Warning: failed to record resource accesses: %v\n", err)
+	}
+}
+
+// SetResourceAccessStore sets the store of recorded resource reads, enabling FindStale and the
+// last_accessed_at container statistic
+func (s *ContainerService) SetResourceAccessStore(store domain.ResourceAccessStore) {
+	s.accessStore = store
+}
+
+// FindStale lists the resources in an account's pod that have not been read for at least
+// unusedFor, least recently used first. Resources never read since tracking was enabled count
+// from when they were added. Reads still buffered by the tracker are not yet visible.
+func (s *ContainerService) FindStale(ctx context.Context, accountID string, unusedFor time.Duration) ([]domain.StaleResource, error) {
+	if accountID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("account ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"account ID cannot be empty",
+		).WithOperation("FindStale")
+	}
+	if unusedFor <= 0 {
+		return nil, domain.ErrInvalidResource.WithOperation("FindStale").WithContext("reason", "unused duration must be positive")
+	}
+	if s.accessStore == nil {
+		return nil, domain.ErrAccessTrackingDisabled.WithOperation("FindStale")
+	}
+
+	candidates, err := s.accessStore.FindStaleResources(ctx, time.Now().Add(-unusedFor))
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to find stale resources",
+		).WithOperation("FindStale").WithContext("accountID", accountID)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Keep only resources held by containers in the account's pod, resolving each container once
+	inPod := make(map[string]bool)
+	stale := make([]domain.StaleResource, 0, len(candidates))
+	for _, candidate := range candidates {
+		member, resolved := inPod[candidate.ContainerID]
+		if !resolved {
+			path, err := s.containerRepo.GetPath(ctx, candidate.ContainerID)
+			member = err == nil && len(path) > 0 && path[0] == accountID
+			inPod[candidate.ContainerID] = member
+		}
+		if member {
+			stale = append(stale, candidate)
+		}
+	}
+
+	return stale, nil
+}
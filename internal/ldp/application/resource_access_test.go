@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResourceAccessStore records flushed batches and can be told to fail
+type fakeResourceAccessStore struct {
+	mu      sync.Mutex
+	batches []map[string]time.Time
+	stale   []domain.StaleResource
+	fail    bool
+}
+
+func (s *fakeResourceAccessStore) RecordResourceAccesses(ctx context.Context, accesses map[string]time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return errors.New("index unavailable")
+	}
+	s.batches = append(s.batches, accesses)
+	return nil
+}
+
+func (s *fakeResourceAccessStore) FindStaleResources(ctx context.Context, cutoff time.Time) ([]domain.StaleResource, error) {
+	return s.stale, nil
+}
+
+func (s *fakeResourceAccessStore) ContainerLastAccessed(ctx context.Context, containerID string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func TestResourceAccessTracker_ThrottlesAndBatches(t *testing.T) {
+	store := &fakeResourceAccessStore{}
+	tracker := NewResourceAccessTracker(store, time.Hour, 0, 0)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	tracker.RecordAccess("a")
+	tracker.RecordAccess("b")
+	now = now.Add(time.Minute)
+	tracker.RecordAccess("a") // within the throttle window
+
+	require.NoError(t, tracker.Flush(context.Background()))
+	require.Len(t, store.batches, 1)
+	assert.Equal(t, map[string]time.Time{
+		"a": time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		"b": time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, store.batches[0])
+
+	// Once the window has passed the next read is recorded again
+	now = now.Add(time.Hour)
+	tracker.RecordAccess("a")
+	require.NoError(t, tracker.Close(context.Background()))
+	require.Len(t, store.batches, 2)
+	assert.Equal(t, map[string]time.Time{"a": now}, store.batches[1])
+}
+
+func TestResourceAccessTracker_RetriesFailedBatch(t *testing.T) {
+	store := &fakeResourceAccessStore{fail: true}
+	tracker := NewResourceAccessTracker(store, time.Hour, 0, 0)
+
+	tracker.RecordAccess("a")
+	assert.Error(t, tracker.Flush(context.Background()))
+
+	store.fail = false
+	require.NoError(t, tracker.Flush(context.Background()))
+	require.Len(t, store.batches, 1)
+	assert.Contains(t, store.batches[0], "a")
+}
+
+func TestResourceAccessTracker_NilRecordsNothing(t *testing.T) {
+	var tracker *ResourceAccessTracker
+	tracker.RecordAccess("a")
+	assert.NoError(t, tracker.Flush(context.Background()))
+}
+
+func TestContainerService_FindStale(t *testing.T) {
+	service, mockRepo, _ := setupContainerServiceTest()
+	ctx := context.Background()
+
+	_, err := service.FindStale(ctx, "alice", 24*time.Hour)
+	assert.True(t, domain.IsAccessTrackingDisabled(err))
+
+	service.SetResourceAccessStore(&fakeResourceAccessStore{stale: []domain.StaleResource{
+		{ID: "old-photo", ContainerID: "alice-photos"},
+		{ID: "old-note", ContainerID: "bob"},
+		{ID: "old-doc", ContainerID: "alice-photos"},
+	}})
+	mockRepo.On("GetPath", mock.Anything, "alice-photos").Return([]string{"alice", "alice-photos"}, nil).Once()
+	mockRepo.On("GetPath", mock.Anything, "bob").Return([]string{"bob"}, nil).Once()
+
+	stale, err := service.FindStale(ctx, "alice", 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, stale, 2)
+	assert.Equal(t, "old-photo", stale[0].ID)
+	assert.Equal(t, "old-doc", stale[1].ID)
+	mockRepo.AssertExpectations(t)
+
+	_, err = service.FindStale(ctx, "alice", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetResourceVersionSource sets the store DiffResourceVersions reads earlier versions from
+func (s *StorageService) SetResourceVersionSource(source domain.ResourceVersionSource) {
+	s.versionSource = source
+}
+
+// SetResourceVersionStore sets the store that records a version of each RDF source as it is
+// written and that DiffResourceVersions reads versions from
+func (s *StorageService) SetResourceVersionStore(store domain.ResourceVersionStore) {
+	s.versionSource = store
+	s.versionStore = store
+}
+
+// SetGraphDiffer sets the differ DiffResourceVersions compares graphs with
+func (s *StorageService) SetGraphDiffer(differ domain.GraphDiffer) {
+	s.graphDiffer = differ
+}
+
+// DiffResourceVersions returns the triples added and removed between two stored versions of
+// an RDF resource. It fails with ErrVersioningDisabled when no version store is configured.
+func (s *StorageService) DiffResourceVersions(ctx context.Context, id, fromVersion, toVersion string) (domain.GraphDiff, error) {
+	if id == "" {
+		return domain.GraphDiff{}, domain.ErrInvalidID.WithOperation("DiffResourceVersions")
+	}
+	if s.versionSource == nil || s.graphDiffer == nil {
+		return domain.GraphDiff{}, domain.ErrVersioningDisabled.WithOperation("DiffResourceVersions").WithContext("resourceID", id)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	from, err := s.versionDocument(ctx, id, fromVersion)
+	if err != nil {
+		return domain.GraphDiff{}, err
+	}
+	to, err := s.versionDocument(ctx, id, toVersion)
+	if err != nil {
+		return domain.GraphDiff{}, err
+	}
+
+	diff, err := s.graphDiffer.DiffGraphs(from, to)
+	if err != nil {
+		if storageErr, ok := domain.GetStorageError(err); ok {
+			return domain.GraphDiff{}, storageErr.WithContext("resourceID", id)
+		}
+		return domain.GraphDiff{}, domain.WrapStorageError(err, domain.ErrFormatConversion.Code, "failed to compare resource versions").WithOperation("DiffResourceVersions")
+	}
+	diff.From, diff.To = fromVersion, toVersion
+	return diff, nil
+}
+
+// versionDocument reads one version of an RDF resource as Turtle
+func (s *StorageService) versionDocument(ctx context.Context, id, version string) ([]byte, error) {
+	resource, err := s.versionSource.RetrieveResourceVersion(ctx, id, version)
+	if err != nil {
+		if domain.IsResourceVersionNotFound(err) || domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceVersionNotFound.WithOperation("DiffResourceVersions").WithContext("resourceID", id).WithContext("version", version)
+		}
+		return nil, domain.WrapStorageError(err, "RETRIEVE_FAILED", "failed to retrieve resource version").WithOperation("DiffResourceVersions")
+	}
+
+	if domain.ResourceInteractionModel(resource) != domain.RDFSourceModel {
+		return nil, domain.ErrUnsupportedFormat.WithOperation("DiffResourceVersions").WithContext("resourceID", id).WithContext("reason", "only RDF sources can be diffed")
+	}
+	return s.turtleDocument(resource)
+}
+
+// recordResourceVersion stores the content just written to an RDF source as its next version.
+// Only RDF sources can be diffed, so other resources are not versioned. A failure is logged
+// and does not fail the write, which is already committed.
+func (s *StorageService) recordResourceVersion(ctx context.Context, resource domain.Resource) {
+	if s.versionStore == nil || domain.ResourceInteractionModel(resource) != domain.RDFSourceModel {
+		return
+	}
+	if _, err := s.versionStore.RecordResourceVersion(ctx, resource); err != nil {
+		fmt.Printf("Warning: failed to record a version of resource %s: %v\n", resource.ID(), err)
+	}
+}
+
+// deleteResourceVersions drops the stored versions of a deleted resource, so a resource later
+// created with the same ID starts again from v1
+func (s *StorageService) deleteResourceVersions(ctx context.Context, id string) {
+	if s.versionStore == nil {
+		return
+	}
+	if err := s.versionStore.DeleteResourceVersions(ctx, id); err != nil {
+		fmt.Printf("Warning: failed to delete the versions of resource %s: %v\n", id, err)
+	}
+}
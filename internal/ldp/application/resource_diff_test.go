@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryVersionSource serves resource versions from a map keyed by version
+type memoryVersionSource map[string]domain.Resource
+
+func (m memoryVersionSource) RetrieveResourceVersion(ctx context.Context, id, version string) (domain.Resource, error) {
+	resource, ok := m[version]
+	if !ok || resource.ID() != id {
+		return nil, domain.ErrResourceVersionNotFound
+	}
+	return resource, nil
+}
+
+func TestStorageService_DiffResourceVersions(t *testing.T) {
+	ctx := context.Background()
+	newService := func() *StorageService {
+		repo := &dublinCoreResourceRepo{resources: map[string]domain.Resource{}}
+		return NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return &MockUnitOfWork{} })
+	}
+	versions := memoryVersionSource{
+		"v1": domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)),
+		"v2": domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice", "Alicia" .`)),
+	}
+	for _, resource := range versions {
+		resource.SetMetadata(domain.InteractionModelKey, domain.RDFSourceModel.String())
+	}
+
+	t.Run("diffs two versions", func(t *testing.T) {
+		service := newService()
+		service.SetResourceVersionSource(versions)
+		service.SetGraphDiffer(infrastructure.NewRDFGraphDiffer())
+
+		diff, err := service.DiffResourceVersions(ctx, "card", "v1", "v2")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", diff.From)
+		assert.Equal(t, "v2", diff.To)
+		assert.Equal(t, []string{`<#me> <http://xmlns.com/foaf/0.1/name> "Alicia" .`}, diff.Added)
+		assert.Empty(t, diff.Removed)
+	})
+
+	t.Run("reports an unknown version", func(t *testing.T) {
+		service := newService()
+		service.SetResourceVersionSource(versions)
+		service.SetGraphDiffer(infrastructure.NewRDFGraphDiffer())
+
+		_, err := service.DiffResourceVersions(ctx, "card", "v1", "v9")
+		assert.True(t, domain.IsResourceVersionNotFound(err))
+	})
+
+	t.Run("diffs versions recorded as the resource is written", func(t *testing.T) {
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
+		require.NoError(t, err)
+		store, err := infrastructure.NewGormResourceVersionStore(db, 0)
+		require.NoError(t, err)
+
+		repo := &quotaResourceRepo{streamingPatchResourceRepo{patchResourceRepo: patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}}}
+		mockUoW := &MockUnitOfWork{}
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+		service := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+		service.SetResourceVersionStore(store)
+		service.SetGraphDiffer(infrastructure.NewRDFGraphDiffer())
+
+		_, err = service.StoreResource(ctx, "card", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`), "text/turtle")
+		require.NoError(t, err)
+		_, err = service.StoreResource(ctx, "card", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice", "Alicia" .`), "text/turtle")
+		require.NoError(t, err)
+
+		diff, err := service.DiffResourceVersions(ctx, "card", "v1", "v2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{`<#me> <http://xmlns.com/foaf/0.1/name> "Alicia" .`}, diff.Added)
+
+		require.NoError(t, service.DeleteResource(ctx, "card"))
+		_, err = store.RetrieveResourceVersion(ctx, "card", "v1")
+		assert.True(t, domain.IsResourceVersionNotFound(err), "versions go with the resource")
+	})
+
+	t.Run("fails without a version store", func(t *testing.T) {
+		service := newService()
+		service.SetGraphDiffer(infrastructure.NewRDFGraphDiffer())
+
+		_, err := service.DiffResourceVersions(ctx, "card", "v1", "v2")
+		assert.True(t, domain.IsVersioningDisabled(err))
+	})
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// SetGraphSizeLimit sets the limiter counting the triples of RDF documents written to
+// resources and the most triples a resource may hold; zero leaves graphs unbounded
+func (s *StorageService) SetGraphSizeLimit(limiter domain.GraphSizeLimiter, maxTriples int) {
+	s.graphSizeLimiter = limiter
+	s.maxTriples = maxTriples
+}
+
+// graphSizeLimited reports whether documents of the content type are checked against the
+// triple limit
+func (s *StorageService) graphSizeLimited(contentType string) bool {
+	return s.graphSizeLimiter != nil && s.maxTriples > 0 && (s.isRDFFormat(contentType) || isTurtleCompatible(contentType))
+}
+
+// checkGraphSize refuses an RDF document holding more triples than a resource may. A document
+// the limiter cannot read is let through; refusing malformed RDF is not the limit's job.
+func (s *StorageService) checkGraphSize(data []byte, contentType string, operation string) error {
+	if !s.graphSizeLimited(contentType) {
+		return nil
+	}
+	_, err := s.graphSizeLimiter.CountTriples(bytes.NewReader(data), contentType, s.maxTriples)
+	return graphTooLarge(err, operation)
+}
+
+// graphTooLarge returns a limiter's error as the error of the operation when it reports a graph
+// over the limit, and nil otherwise
+func graphTooLarge(err error, operation string) error {
+	if storageErr, ok := domain.GetStorageError(err); ok && storageErr.Code == domain.ErrGraphTooLarge.Code {
+		return storageErr.WithOperation(operation)
+	}
+	return nil
+}
+
+// graphLimitReader passes an uploaded RDF document through to the store while the limiter
+// counts its triples alongside. Once the limit is passed the upload fails on the next read,
+// so an oversized graph is never stored in full; the end of the document is only reported
+// after the count is complete.
+type graphLimitReader struct {
+	source io.Reader
+	pipe   *io.PipeWriter
+	result chan error
+
+	once sync.Once
+	err  error
+}
+
+// limitGraphStream wraps an uploaded document so reading it also counts its triples
+func (s *StorageService) limitGraphStream(reader io.Reader, contentType string) *graphLimitReader {
+	pipeReader, pipeWriter := io.Pipe()
+	limited := &graphLimitReader{
+		source: reader,
+		pipe:   pipeWriter,
+		result: make(chan error, 1),
+	}
+
+	go func() {
+		_, err := s.graphSizeLimiter.CountTriples(pipeReader, contentType, s.maxTriples)
+		if domain.IsGraphTooLarge(err) {
+			pipeReader.CloseWithError(err)
+		} else {
+			// Keep the upload flowing when the limiter stops early for any other reason
+			io.Copy(io.Discard, pipeReader)
+			err = nil
+		}
+		limited.result <- err
+	}()
+
+	return limited
+}
+
+// Read reads the next part of the document, handing it to the limiter as well
+func (r *graphLimitReader) Read(p []byte) (int, error) {
+	n, err := r.source.Read(p)
+	if n > 0 {
+		if _, writeErr := r.pipe.Write(p[:n]); writeErr != nil {
+			return n, writeErr
+		}
+	}
+	if err == io.EOF {
+		if limitErr := r.finish(nil); limitErr != nil {
+			return n, limitErr
+		}
+	}
+	return n, err
+}
+
+// finish ends the count, passing cause on to the limiter when the upload stopped early, and
+// returns the limit error if the document was too large
+func (r *graphLimitReader) finish(cause error) error {
+	r.once.Do(func() {
+		r.pipe.CloseWithError(cause)
+		r.err = <-r.result
+	})
+	return r.err
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingPatchResourceRepo also stores streamed uploads, reading them to the end
+type streamingPatchResourceRepo struct {
+	patchResourceRepo
+	streamed int
+}
+
+func (r *streamingPatchResourceRepo) StoreStream(ctx context.Context, id string, reader io.Reader, contentType string, size int64) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	r.streamed++
+	r.resources[id] = domain.NewResource(ctx, id, contentType, data)
+	return nil
+}
+
+// turtleStatements returns a Turtle document of n statements
+func turtleStatements(n int) string {
+	var document strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&document, "<#me> <http://example.org/p%d> \"value\" .\n", i)
+	}
+	return document.String()
+}
+
+func TestStorageService_GraphSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	setup := func() (*StorageService, *streamingPatchResourceRepo) {
+		repo := &streamingPatchResourceRepo{patchResourceRepo: patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}}
+		mockUoW := &MockUnitOfWork{}
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+		service := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+		service.SetResourcePatcher(domain.SPARQLUpdateMediaType, infrastructure.NewSPARQLUpdateProcessor())
+		service.SetGraphSizeLimit(infrastructure.NewRDFTripleCounter(), 3)
+		return service, repo
+	}
+
+	t.Run("stores graphs within the limit", func(t *testing.T) {
+		service, repo := setup()
+
+		_, err := service.StoreResource(ctx, "card", []byte(turtleStatements(3)), "text/turtle")
+		require.NoError(t, err)
+		assert.Equal(t, 1, repo.stores)
+	})
+
+	t.Run("refuses graphs over the limit", func(t *testing.T) {
+		service, repo := setup()
+
+		_, err := service.StoreResource(ctx, "card", []byte(turtleStatements(4)), "text/turtle")
+		require.Error(t, err)
+		assert.True(t, domain.IsGraphTooLarge(err))
+		assert.Zero(t, repo.stores)
+	})
+
+	t.Run("does not count non-RDF content", func(t *testing.T) {
+		service, _ := setup()
+
+		_, err := service.StoreResource(ctx, "notes", []byte(turtleStatements(10)), "application/octet-stream")
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses patches growing the graph over the limit", func(t *testing.T) {
+		service, repo := setup()
+		_, err := service.StoreResource(ctx, "card", []byte(turtleStatements(3)), "text/turtle")
+		require.NoError(t, err)
+
+		_, err = service.PatchResource(ctx, "card", domain.SPARQLUpdateMediaType, `INSERT DATA { <#me> <http://example.org/extra> "one more" }`)
+		require.Error(t, err)
+		assert.True(t, domain.IsGraphTooLarge(err))
+		assert.Equal(t, 1, repo.stores)
+	})
+
+	t.Run("aborts streamed uploads over the limit", func(t *testing.T) {
+		service, repo := setup()
+
+		_, err := service.StoreResourceStream(ctx, "card", strings.NewReader(turtleStatements(5000)), "text/turtle", -1)
+		require.Error(t, err)
+		assert.True(t, domain.IsGraphTooLarge(err))
+		assert.Zero(t, repo.streamed)
+	})
+}
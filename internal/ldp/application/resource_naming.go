@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// maxNameSuffix bounds the suffixes tried for a taken resource name
+const maxNameSuffix = 1000
+
+// ContainerNameChecker reports whether an ID is held by a container. Containers list child
+// containers and resources as members by ID, so a resource must not take a container's name.
+type ContainerNameChecker interface {
+	ContainerExists(ctx context.Context, id string) (bool, error)
+}
+
+// SetContainerNameChecker sets the checker keeping named resources from taking container IDs
+func (s *StorageService) SetContainerNameChecker(checker ContainerNameChecker) {
+	s.containerNames = checker
+}
+
+// SetRejectTakenResourceNames selects whether creating a resource under a taken name fails
+// with ErrResourceAlreadyExists instead of suffixing the name
+func (s *StorageService) SetRejectTakenResourceNames(reject bool) {
+	s.rejectTakenNames = reject
+}
+
+// CreateNamedResource stores a new resource under a client-chosen name, such as one derived
+// from a Slug header. The name is claimed atomically, so concurrent creates can never overwrite
+// each other: when it is taken, by a resource or a container, name-1, name-2, ... are tried
+// in order and the first free one is used, unless taken names are rejected.
+func (s *StorageService) CreateNamedResource(ctx context.Context, name string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.validateResourceID(name, "CreateNamedResource"); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, domain.ErrInvalidResource.WithOperation("CreateNamedResource").WithContext("reason", "empty data")
+	}
+
+	normalizedContentType := s.normalizeContentType(contentType)
+	if s.isRDFFormat(normalizedContentType) && !s.converter.ValidateFormat(normalizedContentType) {
+		return nil, domain.ErrUnsupportedFormat.WithOperation("CreateNamedResource").WithContext("format", contentType)
+	}
+
+	var resource *domain.BasicResource
+	for suffix := 0; ; suffix++ {
+		if suffix > maxNameSuffix {
+			return nil, domain.WrapStorageError(
+				fmt.Errorf("no free name after %d suffixes", maxNameSuffix),
+				domain.ErrResourceAlreadyExists.Code,
+				"resource name is taken",
+			).WithOperation("CreateNamedResource").WithContext("name", name)
+		}
+
+		id := name
+		if suffix > 0 {
+			id = fmt.Sprintf("%s-%d", name, suffix)
+		}
+
+		candidate := domain.NewResource(ctx, id, normalizedContentType, data)
+		for key, value := range metadata {
+			candidate.SetMetadata(key, value)
+		}
+		candidate.SetMetadata(domain.InteractionModelKey, domain.InteractionModelForContentType(normalizedContentType).String())
+		if !candidate.IsValid() {
+			if errors := candidate.Errors(); len(errors) > 0 {
+				return nil, domain.WrapStorageError(errors[0], "INVALID_RESOURCE", "resource validation failed").WithOperation("CreateNamedResource")
+			}
+			return nil, domain.ErrInvalidResource.WithOperation("CreateNamedResource").WithContext("reason", "resource is not valid")
+		}
+
+		err := s.checkContainerName(ctx, id)
+		if err == nil {
+			err = s.createResource(ctx, candidate)
+		}
+		if err == nil {
+			resource = candidate
+			break
+		}
+		if !domain.IsResourceAlreadyExists(err) {
+			return nil, domain.WrapStorageError(err, "STORE_FAILED", "failed to store resource").WithOperation("CreateNamedResource")
+		}
+		if s.rejectTakenNames {
+			return nil, domain.ErrResourceAlreadyExists.WithOperation("CreateNamedResource").WithContext("name", name)
+		}
+	}
+
+	// Only the stored candidate's events are committed; rejected candidates were never persisted
+	unitOfWork := s.unitOfWorkFactory()
+	unitOfWork.RegisterEvents(resource.UncommittedEvents())
+	envelopes, err := unitOfWork.Commit(ctx)
+	if err != nil {
+		return nil, domain.WrapStorageError(err, "EVENT_COMMIT_FAILED", "failed to commit events").WithOperation("CreateNamedResource")
+	}
+	resource.MarkEventsAsCommitted()
+
+	metrics.ObserveResourceUploadSize(normalizedContentType, int64(len(data)))
+	recordStoredResource(false, 0, resource.GetSize())
+
+	if len(envelopes) > 0 {
+		fmt.Printf("Successfully processed %d events for resource %s\n", len(envelopes), resource.ID())
+	}
+
+	s.indexResource(ctx, resource)
+	s.recordResourceVersion(ctx, resource)
+
+	return resource, nil
+}
+
+// createResource stores a resource only if its ID is free. Repositories with an exclusive
+// create decide atomically; otherwise the existence check is guarded by the service lock.
+func (s *StorageService) createResource(ctx context.Context, resource domain.Resource) error {
+	if exclusive, ok := s.repo.(domain.ExclusiveResourceRepository); ok {
+		return exclusive.Create(ctx, resource)
+	}
+
+	exists, err := s.repo.Exists(ctx, resource.ID())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrResourceAlreadyExists.WithOperation("CreateNamedResource").WithContext("id", resource.ID())
+	}
+	return s.repo.Store(ctx, resource)
+}
+
+// checkContainerName fails with ErrResourceAlreadyExists when a container holds id
+func (s *StorageService) checkContainerName(ctx context.Context, id string) error {
+	if s.containerNames == nil {
+		return nil
+	}
+	exists, err := s.containerNames.ContainerExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrResourceAlreadyExists.WithOperation("CreateNamedResource").WithContext("id", id)
+	}
+	return nil
+}
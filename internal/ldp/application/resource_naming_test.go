@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupResourceNamingTest(t *testing.T) *StorageService {
+	repo, err := infrastructure.NewFileSystemRepository(t.TempDir())
+	require.NoError(t, err)
+
+	mockUoW := &MockUnitOfWork{}
+	mockUoW.On("RegisterEvents", mock.Anything).Return()
+	mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+	return NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+}
+
+func TestStorageService_CreateNamedResource_SuffixesTakenNames(t *testing.T) {
+	service := setupResourceNamingTest(t)
+	ctx := context.Background()
+
+	first, err := service.CreateNamedResource(ctx, "notes", []byte("first"), "text/plain", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "notes", first.ID())
+
+	second, err := service.CreateNamedResource(ctx, "notes", []byte("second"), "text/plain", map[string]interface{}{"title": "Second"})
+	require.NoError(t, err)
+	assert.Equal(t, "notes-1", second.ID())
+	assert.Equal(t, "Second", second.GetMetadata()["title"])
+
+	original, err := service.RetrieveResource(ctx, "notes", "")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), original.GetData())
+}
+
+func TestStorageService_CreateNamedResource_RejectsTakenNames(t *testing.T) {
+	service := setupResourceNamingTest(t)
+	service.SetRejectTakenResourceNames(true)
+	ctx := context.Background()
+
+	_, err := service.CreateNamedResource(ctx, "notes", []byte("first"), "text/plain", nil)
+	require.NoError(t, err)
+
+	_, err = service.CreateNamedResource(ctx, "notes", []byte("second"), "text/plain", nil)
+	assert.True(t, domain.IsResourceAlreadyExists(err))
+}
+
+func TestStorageService_CreateNamedResource_ConcurrentSlugsGetDistinctNames(t *testing.T) {
+	service := setupResourceNamingTest(t)
+	ctx := context.Background()
+
+	const writers = 8
+	type result struct {
+		id  string
+		err error
+	}
+	results := make(chan result, writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			resource, err := service.CreateNamedResource(ctx, "photo", []byte(fmt.Sprintf("data-%d", i)), "text/plain", nil)
+			if err != nil {
+				results <- result{err: err}
+				return
+			}
+			results <- result{id: resource.ID()}
+		}(i)
+	}
+
+	var ids []string
+	for i := 0; i < writers; i++ {
+		r := <-results
+		require.NoError(t, r.err)
+		ids = append(ids, r.id)
+	}
+	sort.Strings(ids)
+
+	assert.Equal(t, []string{"photo", "photo-1", "photo-2", "photo-3", "photo-4", "photo-5", "photo-6", "photo-7"}, ids)
+}
+
+// containerNames holds a fixed set of container IDs
+type containerNames map[string]bool
+
+func (c containerNames) ContainerExists(ctx context.Context, id string) (bool, error) {
+	return c[id], nil
+}
+
+func TestStorageService_CreateNamedResource_AvoidsContainerNames(t *testing.T) {
+	service := setupResourceNamingTest(t)
+	service.SetContainerNameChecker(containerNames{"photos": true, "photos-1": true})
+	ctx := context.Background()
+
+	resource, err := service.CreateNamedResource(ctx, "photos", []byte("data"), "text/plain", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "photos-2", resource.ID())
+
+	service.SetRejectTakenResourceNames(true)
+	_, err = service.CreateNamedResource(ctx, "photos", []byte("data"), "text/plain", nil)
+	assert.True(t, domain.IsResourceAlreadyExists(err))
+}
+
+func TestStorageService_CreateNamedResource_RequiresName(t *testing.T) {
+	service := setupResourceNamingTest(t)
+
+	_, err := service.CreateNamedResource(context.Background(), "", []byte("data"), "text/plain", nil)
+	assert.Error(t, err)
+}
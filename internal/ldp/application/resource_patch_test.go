@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// patchResourceRepo keeps resources in memory and counts stores for patch tests
+type patchResourceRepo struct {
+	dublinCoreResourceRepo
+	stores int
+}
+
+func (r *patchResourceRepo) Exists(ctx context.Context, id string) (bool, error) {
+	_, ok := r.resources[id]
+	return ok, nil
+}
+
+func (r *patchResourceRepo) Store(ctx context.Context, resource domain.Resource) error {
+	r.stores++
+	return r.dublinCoreResourceRepo.Store(ctx, resource)
+}
+
+func TestStorageService_PatchResource(t *testing.T) {
+	ctx := context.Background()
+	setup := func(resources ...domain.Resource) (*StorageService, *patchResourceRepo) {
+		repo := &patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}
+		for _, resource := range resources {
+			resource.SetMetadata(domain.InteractionModelKey, domain.InteractionModelForContentType(resource.GetContentType()).String())
+			repo.resources[resource.ID()] = resource
+		}
+		mockUoW := &MockUnitOfWork{}
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+		service := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+		service.SetResourcePatcher(domain.SPARQLUpdateMediaType, infrastructure.NewSPARQLUpdateProcessor())
+		service.SetResourcePatcher(domain.N3PatchMediaType, infrastructure.NewN3PatchProcessor())
+		return service, repo
+	}
+
+	t.Run("applies the update and stores the result", func(t *testing.T) {
+		card := domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`))
+		card.SetMetadata("updatedAt", time.Now().Add(-time.Hour))
+		service, repo := setup(card)
+
+		resource, err := service.PatchResource(ctx, "card", domain.SPARQLUpdateMediaType, `
+			PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+			DELETE { <#me> foaf:name "Alice" } INSERT { <#me> foaf:name "Alicia" } WHERE {}`)
+		require.NoError(t, err)
+
+		assert.Equal(t, "<#me> <http://xmlns.com/foaf/0.1/name> \"Alicia\" .\n", string(resource.GetData()))
+		assert.Equal(t, "text/turtle", resource.GetContentType())
+		assert.WithinDuration(t, time.Now(), resource.GetMetadata()["updatedAt"].(time.Time), time.Minute)
+		assert.Equal(t, 1, repo.stores)
+	})
+
+	t.Run("deleting a missing triple is a no-op", func(t *testing.T) {
+		document := []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)
+		service, repo := setup(domain.NewResource(ctx, "card", "text/turtle", document))
+
+		resource, err := service.PatchResource(ctx, "card", domain.SPARQLUpdateMediaType, `DELETE DATA { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" }`)
+		require.NoError(t, err)
+		assert.Equal(t, document, resource.GetData())
+		assert.Zero(t, repo.stores)
+	})
+
+	t.Run("rejects malformed updates", func(t *testing.T) {
+		service, repo := setup(domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)))
+
+		_, err := service.PatchResource(ctx, "card", domain.SPARQLUpdateMediaType, `INSERT DATA { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" `)
+		assert.True(t, domain.IsInvalidPatch(err))
+		assert.Zero(t, repo.stores)
+	})
+
+	t.Run("applies N3 patches through the same path", func(t *testing.T) {
+		service, repo := setup(domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)))
+
+		resource, err := service.PatchResource(ctx, "card", domain.N3PatchMediaType, `
+			@prefix solid: <http://www.w3.org/ns/solid/terms#>.
+			_:rename a solid:InsertDeletePatch;
+				solid:where { ?me <http://xmlns.com/foaf/0.1/name> "Alice" };
+				solid:inserts { ?me <http://xmlns.com/foaf/0.1/name> "Alicia" };
+				solid:deletes { ?me <http://xmlns.com/foaf/0.1/name> "Alice" }.`)
+		require.NoError(t, err)
+		assert.Equal(t, "<#me> <http://xmlns.com/foaf/0.1/name> \"Alicia\" .\n", string(resource.GetData()))
+		assert.Equal(t, 1, repo.stores)
+	})
+
+	t.Run("N3 patches whose conditions fail leave the resource untouched", func(t *testing.T) {
+		service, repo := setup(domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)))
+
+		_, err := service.PatchResource(ctx, "card", domain.N3PatchMediaType, `
+			@prefix solid: <http://www.w3.org/ns/solid/terms#>.
+			_:p a solid:InsertDeletePatch;
+				solid:inserts { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" };
+				solid:deletes { <#me> <http://xmlns.com/foaf/0.1/name> "Bob" }.`)
+		assert.True(t, domain.IsPatchConflict(err))
+		assert.Zero(t, repo.stores)
+	})
+
+	t.Run("rejects unsupported patch formats", func(t *testing.T) {
+		service, _ := setup(domain.NewResource(ctx, "card", "text/turtle", []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)))
+
+		_, err := service.PatchResource(ctx, "card", "application/json-patch+json", `[]`)
+		assert.True(t, domain.IsUnsupportedFormat(err))
+	})
+
+	t.Run("rejects non-RDF resources", func(t *testing.T) {
+		service, _ := setup(domain.NewResource(ctx, "photo", "image/jpeg", []byte("jpeg")))
+
+		_, err := service.PatchResource(ctx, "photo", domain.SPARQLUpdateMediaType, `INSERT DATA { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" }`)
+		assert.True(t, domain.IsUnsupportedFormat(err))
+	})
+
+	t.Run("reports missing resources", func(t *testing.T) {
+		service, _ := setup()
+
+		_, err := service.PatchResource(ctx, "missing", domain.SPARQLUpdateMediaType, `INSERT DATA { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" }`)
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// emptyRDFDocuments are the documents written for an empty graph in formats where an empty
+// body is not a valid document
+var emptyRDFDocuments = map[string]string{
+	"application/ld+json": "[]\n",
+	"application/rdf+xml": "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\"/>\n",
+}
+
+// SetTripleMatcher sets the matcher QueryResourceTriples selects triples with
+func (s *StorageService) SetTripleMatcher(matcher domain.TripleMatcher) {
+	s.tripleMatcher = matcher
+}
+
+// QueryResourceTriples returns the triples of an RDF resource matching a pattern, in the
+// requested format or the resource's own format when none is requested. The graph is
+// filtered server-side so clients reading part of a large resource do not download all of
+// it. An empty pattern returns the whole resource, as RetrieveResource does.
+func (s *StorageService) QueryResourceTriples(ctx context.Context, id string, pattern domain.TriplePattern, acceptFormat string) (domain.Resource, error) {
+	if pattern.IsEmpty() {
+		return s.RetrieveResource(ctx, id, acceptFormat)
+	}
+	if id == "" {
+		return nil, domain.ErrInvalidID.WithOperation("QueryResourceTriples")
+	}
+	if s.tripleMatcher == nil {
+		return nil, domain.ErrUnsupportedFormat.WithOperation("QueryResourceTriples").WithContext("reason", "triple pattern queries are not supported")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resource, err := s.repo.Retrieve(ctx, id)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, domain.ErrResourceNotFound.WithOperation("QueryResourceTriples").WithContext("id", id)
+		}
+		return nil, domain.WrapStorageError(err, "RETRIEVE_FAILED", "failed to retrieve resource").WithOperation("QueryResourceTriples")
+	}
+
+	if domain.ResourceInteractionModel(resource) != domain.RDFSourceModel {
+		return nil, domain.ErrUnsupportedFormat.WithOperation("QueryResourceTriples").WithContext("resourceID", id).WithContext("reason", "only RDF sources can be queried by triple pattern")
+	}
+
+	document, err := s.turtleDocument(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := s.tripleMatcher.MatchTriples(document, pattern)
+	if err != nil {
+		if storageErr, ok := domain.GetStorageError(err); ok {
+			return nil, storageErr.WithContext("resourceID", id)
+		}
+		return nil, domain.WrapStorageError(err, domain.ErrInvalidTriplePattern.Code, "failed to match triple pattern").WithOperation("QueryResourceTriples")
+	}
+
+	// The matches are N-Triples, which is Turtle; other formats are converted from it
+	targetFormat := resource.GetContentType()
+	if acceptFormat != "" {
+		targetFormat = s.normalizeContentType(acceptFormat)
+	}
+	if !isTurtleCompatible(targetFormat) {
+		if empty, ok := emptyRDFDocuments[targetFormat]; ok && len(matched) == 0 {
+			return s.matchedTriplesResource(resource, targetFormat, []byte(empty)), nil
+		}
+		return s.convertResourceFormat(s.matchedTriplesResource(resource, "text/turtle", matched), targetFormat)
+	}
+
+	return s.matchedTriplesResource(resource, targetFormat, matched), nil
+}
+
+// matchedTriplesResource builds the resource returned for the triples of a resource matching a
+// pattern, carrying the metadata of the resource they were selected from
+func (s *StorageService) matchedTriplesResource(resource domain.Resource, contentType string, data []byte) domain.Resource {
+	matched := domain.NewResource(context.Background(), resource.ID(), contentType, data)
+	for key, value := range resource.GetMetadata() {
+		matched.SetMetadata(key, value)
+	}
+	return matched
+}
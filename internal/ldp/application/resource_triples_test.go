@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageService_QueryResourceTriples(t *testing.T) {
+	ctx := context.Background()
+	document := []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" ; <http://xmlns.com/foaf/0.1/nick> "Al" .`)
+	setup := func(resources ...domain.Resource) *StorageService {
+		repo := &dublinCoreResourceRepo{resources: map[string]domain.Resource{}}
+		for _, resource := range resources {
+			resource.SetMetadata(domain.InteractionModelKey, domain.InteractionModelForContentType(resource.GetContentType()).String())
+			repo.resources[resource.ID()] = resource
+		}
+		service := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return &MockUnitOfWork{} })
+		service.SetTripleMatcher(infrastructure.NewTriplePatternMatcher())
+		return service
+	}
+
+	t.Run("returns only matching triples", func(t *testing.T) {
+		service := setup(domain.NewResource(ctx, "card", "text/turtle", document))
+
+		resource, err := service.QueryResourceTriples(ctx, "card", domain.TriplePattern{Predicate: "http://xmlns.com/foaf/0.1/nick"}, "text/turtle")
+		require.NoError(t, err)
+		assert.Equal(t, "<#me> <http://xmlns.com/foaf/0.1/nick> \"Al\" .\n", string(resource.GetData()))
+		assert.Equal(t, "text/turtle", resource.GetContentType())
+	})
+
+	t.Run("empty pattern returns the whole resource", func(t *testing.T) {
+		service := setup(domain.NewResource(ctx, "card", "text/turtle", document))
+
+		resource, err := service.QueryResourceTriples(ctx, "card", domain.TriplePattern{}, "")
+		require.NoError(t, err)
+		assert.Equal(t, document, resource.GetData())
+	})
+
+	t.Run("no matches in a format without empty documents", func(t *testing.T) {
+		service := setup(domain.NewResource(ctx, "card", "text/turtle", document))
+
+		resource, err := service.QueryResourceTriples(ctx, "card", domain.TriplePattern{Subject: "<#bob>"}, "application/ld+json")
+		require.NoError(t, err)
+		assert.Equal(t, "[]\n", string(resource.GetData()))
+		assert.Equal(t, "application/ld+json", resource.GetContentType())
+	})
+
+	t.Run("rejects malformed patterns", func(t *testing.T) {
+		service := setup(domain.NewResource(ctx, "card", "text/turtle", document))
+
+		_, err := service.QueryResourceTriples(ctx, "card", domain.TriplePattern{Subject: "<#me"}, "")
+		assert.True(t, domain.IsInvalidTriplePattern(err))
+	})
+
+	t.Run("rejects non-RDF resources", func(t *testing.T) {
+		service := setup(domain.NewResource(ctx, "photo", "image/jpeg", []byte("jpeg")))
+
+		_, err := service.QueryResourceTriples(ctx, "photo", domain.TriplePattern{Subject: "<#me>"}, "")
+		assert.True(t, domain.IsUnsupportedFormat(err))
+	})
+
+	t.Run("reports missing resources", func(t *testing.T) {
+		service := setup()
+
+		_, err := service.QueryResourceTriples(ctx, "missing", domain.TriplePattern{Subject: "<#me>"}, "")
+		assert.True(t, domain.IsResourceNotFound(err))
+	})
+}
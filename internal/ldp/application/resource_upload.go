@@ -0,0 +1,285 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// UploadService receives large resources in ranges. A client opens a session declaring the
+// resource, its content type and size, appends the content range by range, resuming from the
+// session's offset after an interruption, and completes the session, which stores the
+// resource in one write. Sessions not completed within their TTL are removed with what they
+// received.
+type UploadService struct {
+	store   domain.UploadStore
+	storage *StorageService
+	ttl     time.Duration
+	maxSize int64
+	now     func() time.Time
+
+	mu         sync.Mutex
+	completing map[string]bool // sessions being stored, which sweeps must leave alone
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewUploadService creates an upload service keeping sessions in store and storing completed
+// uploads through storage. A positive sweep interval starts a background loop removing
+// expired sessions, stopped by Close.
+func NewUploadService(store domain.UploadStore, storage *StorageService, ttl, sweepInterval time.Duration) *UploadService {
+	s := &UploadService{
+		store:      store,
+		storage:    storage,
+		ttl:        ttl,
+		now:        time.Now,
+		completing: make(map[string]bool),
+		stop:       make(chan struct{}),
+	}
+
+	if sweepInterval > 0 {
+		go s.sweepPeriodically(sweepInterval)
+	}
+
+	return s
+}
+
+// SetMaxSize sets the largest size a session may declare; zero or less means no limit
+func (s *UploadService) SetMaxSize(maxSize int64) {
+	s.maxSize = maxSize
+}
+
+// CreateUpload opens a session for size bytes of content to be stored as resourceID. Uploads
+// over the maximum upload size, or that could not fit in the account's storage quota, are
+// refused before any byte is sent.
+func (s *UploadService) CreateUpload(ctx context.Context, resourceID, contentType string, size int64) (domain.UploadSession, error) {
+	if err := s.storage.validateResourceID(resourceID, "CreateUpload"); err != nil {
+		return domain.UploadSession{}, err
+	}
+	if contentType == "" {
+		return domain.UploadSession{}, domain.WrapStorageError(
+			fmt.Errorf("content type cannot be empty"),
+			domain.ErrInvalidResource.Code,
+			"upload content type is required",
+		).WithOperation("CreateUpload").WithContext("id", resourceID)
+	}
+	if size <= 0 {
+		return domain.UploadSession{}, domain.WrapStorageError(
+			fmt.Errorf("upload size must be positive, got %d", size),
+			domain.ErrInvalidResource.Code,
+			"upload size must be positive",
+		).WithOperation("CreateUpload").WithContext("id", resourceID)
+	}
+	if s.maxSize > 0 && size > s.maxSize {
+		return domain.UploadSession{}, domain.WrapStorageError(
+			fmt.Errorf("upload of %d bytes exceeds the maximum of %d", size, s.maxSize),
+			domain.ErrUploadTooLarge.Code,
+			domain.ErrUploadTooLarge.Message,
+		).WithOperation("CreateUpload").WithContext("id", resourceID).WithContext("maxSize", s.maxSize)
+	}
+	if err := s.storage.checkUploadQuota(ctx, resourceID, size); err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	now := s.now()
+	session := domain.UploadSession{
+		ID:          ksuid.New().String(),
+		ResourceID:  resourceID,
+		ContentType: contentType,
+		Size:        size,
+		AccountID:   domain.AccountIDFromContext(ctx),
+		Owner:       domain.AgentFromContext(ctx),
+		CreatedAt:   now,
+	}
+	if s.ttl > 0 {
+		session.ExpiresAt = now.Add(s.ttl)
+	}
+
+	if err := s.store.CreateUpload(ctx, session); err != nil {
+		return domain.UploadSession{}, err
+	}
+	return session, nil
+}
+
+// GetUpload returns a session, so a client can learn the offset to resume from
+func (s *UploadService) GetUpload(ctx context.Context, uploadID string) (domain.UploadSession, error) {
+	return s.session(ctx, uploadID, "GetUpload")
+}
+
+// AppendUpload adds a range of content starting at offset, which must be the session's
+// current offset. The returned session holds the offset to continue from, also when
+// receiving the range failed part way.
+func (s *UploadService) AppendUpload(ctx context.Context, uploadID string, offset int64, chunk io.Reader) (domain.UploadSession, error) {
+	if _, err := s.session(ctx, uploadID, "AppendUpload"); err != nil {
+		return domain.UploadSession{}, err
+	}
+	return s.store.AppendUpload(ctx, uploadID, offset, chunk)
+}
+
+// CompleteUpload stores a session's content as its resource and closes the session. A session
+// still missing bytes is refused with ErrUploadIncomplete and kept for them to be sent.
+func (s *UploadService) CompleteUpload(ctx context.Context, uploadID string) (domain.Resource, error) {
+	session, err := s.session(ctx, uploadID, "CompleteUpload")
+	if err != nil {
+		return nil, err
+	}
+	if !session.Received() {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("received %d of %d bytes", session.Offset, session.Size),
+			domain.ErrUploadIncomplete.Code,
+			domain.ErrUploadIncomplete.Message,
+		).WithOperation("CompleteUpload").WithContext("uploadID", uploadID).WithContext("offset", session.Offset)
+	}
+
+	if !s.startCompleting(uploadID) {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("upload is already being completed"),
+			domain.ErrUploadNotFound.Code,
+			domain.ErrUploadNotFound.Message,
+		).WithOperation("CompleteUpload").WithContext("uploadID", uploadID)
+	}
+	defer s.finishCompleting(uploadID)
+
+	content, err := s.store.OpenUpload(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	resource, err := s.storage.StoreResourceStream(domain.WithAccountID(ctx, session.AccountID), session.ResourceID, content, session.ContentType, session.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.DeleteUpload(ctx, uploadID); err != nil {
+		fmt.Printf("Warning: failed to remove completed upload %s: %v\n", uploadID, err)
+	}
+	return resource, nil
+}
+
+// AbortUpload closes a session, discarding what it received
+func (s *UploadService) AbortUpload(ctx context.Context, uploadID string) error {
+	if _, err := s.session(ctx, uploadID, "AbortUpload"); err != nil {
+		return err
+	}
+	return s.store.DeleteUpload(ctx, uploadID)
+}
+
+// PurgeExpiredUploads removes the sessions that have outlived their TTL and reports how many
+// it removed. A session that cannot be removed is retried by the next sweep.
+func (s *UploadService) PurgeExpiredUploads(ctx context.Context) (int, error) {
+	sessions, err := s.store.ListUploads(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	purged := 0
+	for _, session := range sessions {
+		if !session.Expired(now) || s.isCompleting(session.ID) {
+			continue
+		}
+		if err := s.store.DeleteUpload(ctx, session.ID); err != nil {
+			fmt.Printf("Warning: failed to remove expired upload %s: %v\n", session.ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// Close stops the background sweep loop
+func (s *UploadService) Close() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// session returns a live session. Expired sessions, and sessions opened on behalf of another
+// account or by another agent, are reported as not found.
+func (s *UploadService) session(ctx context.Context, uploadID, operation string) (domain.UploadSession, error) {
+	session, err := s.store.GetUpload(ctx, uploadID)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+	if session.Expired(s.now()) {
+		return domain.UploadSession{}, domain.WrapStorageError(
+			fmt.Errorf("upload expired at %s", session.ExpiresAt.Format(time.RFC3339)),
+			domain.ErrUploadNotFound.Code,
+			domain.ErrUploadNotFound.Message,
+		).WithOperation(operation).WithContext("uploadID", uploadID)
+	}
+	if accountID := domain.AccountIDFromContext(ctx); session.AccountID != "" && accountID != session.AccountID {
+		return domain.UploadSession{}, domain.WrapStorageError(
+			fmt.Errorf("upload belongs to another account"),
+			domain.ErrUploadNotFound.Code,
+			domain.ErrUploadNotFound.Message,
+		).WithOperation(operation).WithContext("uploadID", uploadID)
+	}
+	if session.Owner != "" && domain.AgentFromContext(ctx) != session.Owner {
+		return domain.UploadSession{}, domain.WrapStorageError(
+			fmt.Errorf("upload belongs to another agent"),
+			domain.ErrUploadNotFound.Code,
+			domain.ErrUploadNotFound.Message,
+		).WithOperation(operation).WithContext("uploadID", uploadID)
+	}
+	return session, nil
+}
+
+func (s *UploadService) startCompleting(uploadID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.completing[uploadID] {
+		return false
+	}
+	s.completing[uploadID] = true
+	return true
+}
+
+func (s *UploadService) finishCompleting(uploadID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.completing, uploadID)
+}
+
+func (s *UploadService) isCompleting(uploadID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.completing[uploadID]
+}
+
+// sweepPeriodically removes expired sessions every interval until the service is closed
+func (s *UploadService) sweepPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.PurgeExpiredUploads(context.Background()); err != nil {
+				fmt.Printf("Warning: upload sweep failed: %v\n", err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// checkUploadQuota refuses an upload of size bytes to a resource that would take its account
+// past its storage quota. Completing the upload checks again, as usage may have grown since.
+func (s *StorageService) checkUploadQuota(ctx context.Context, resourceID string, size int64) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	charge, err := s.storageChargeFor(ctx, resourceID, "CreateUpload")
+	if err != nil {
+		return err
+	}
+	return s.checkStorageQuota(charge, size, "CreateUpload")
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadService(t *testing.T) {
+	ctx := domain.WithAccountID(context.Background(), "acct-1")
+	setup := func(t *testing.T) (*UploadService, *quotaResourceRepo, *memoryStorageUsage) {
+		repo := &quotaResourceRepo{streamingPatchResourceRepo{patchResourceRepo: patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}}}
+		mockUoW := &MockUnitOfWork{}
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+		usage := newMemoryStorageUsage()
+		storage := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+		storage.SetStorageQuota(usage, fixedStorageQuotas{"acct-1": 100})
+
+		store, err := infrastructure.NewFileSystemUploadStore(t.TempDir())
+		require.NoError(t, err)
+		return NewUploadService(store, storage, time.Hour, 0), repo, usage
+	}
+
+	t.Run("assembles the resource only on completion", func(t *testing.T) {
+		service, repo, usage := setup(t)
+
+		session, err := service.CreateUpload(ctx, "video", "video/mp4", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "acct-1", session.AccountID)
+
+		_, err = service.AppendUpload(ctx, session.ID, 0, strings.NewReader("01234"))
+		require.NoError(t, err)
+		_, err = service.CompleteUpload(ctx, session.ID)
+		storageErr, ok := domain.GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrUploadIncomplete.Code, storageErr.Code)
+		assert.NotContains(t, repo.resources, "video")
+
+		_, err = service.AppendUpload(ctx, session.ID, 5, strings.NewReader("56789"))
+		require.NoError(t, err)
+		resource, err := service.CompleteUpload(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(resource.GetData()))
+		assert.Equal(t, int64(10), usage.totals["acct-1"])
+
+		_, err = service.GetUpload(ctx, session.ID)
+		assert.True(t, domain.IsUploadNotFound(err), "completed sessions are closed")
+	})
+
+	t.Run("refuses uploads past the storage quota up front", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		_, err := service.CreateUpload(ctx, "huge", "video/mp4", 101)
+		assert.True(t, domain.IsInsufficientStorage(err))
+	})
+
+	t.Run("refuses uploads over the maximum size", func(t *testing.T) {
+		service, _, _ := setup(t)
+		service.SetMaxSize(8)
+
+		_, err := service.CreateUpload(ctx, "video", "video/mp4", 9)
+		storageErr, ok := domain.GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrUploadTooLarge.Code, storageErr.Code)
+
+		_, err = service.CreateUpload(ctx, "video", "video/mp4", 8)
+		assert.NoError(t, err)
+	})
+
+	t.Run("hides sessions of other accounts", func(t *testing.T) {
+		service, _, _ := setup(t)
+		session, err := service.CreateUpload(ctx, "video", "video/mp4", 10)
+		require.NoError(t, err)
+
+		other := domain.WithAccountID(context.Background(), "acct-2")
+		_, err = service.AppendUpload(other, session.ID, 0, strings.NewReader("01234"))
+		assert.True(t, domain.IsUploadNotFound(err))
+		assert.True(t, domain.IsUploadNotFound(service.AbortUpload(other, session.ID)))
+	})
+
+	t.Run("hides sessions of other agents", func(t *testing.T) {
+		service, _, _ := setup(t)
+		alice := domain.WithAgent(ctx, "https://alice.example/profile#me")
+		session, err := service.CreateUpload(alice, "video", "video/mp4", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "https://alice.example/profile#me", session.Owner)
+
+		bob := domain.WithAgent(ctx, "https://bob.example/profile#me")
+		_, err = service.AppendUpload(bob, session.ID, 0, strings.NewReader("01234"))
+		assert.True(t, domain.IsUploadNotFound(err))
+		_, err = service.AppendUpload(ctx, session.ID, 0, strings.NewReader("01234"))
+		assert.True(t, domain.IsUploadNotFound(err), "unauthenticated callers cannot take over an upload")
+		_, err = service.AppendUpload(alice, session.ID, 0, strings.NewReader("01234"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("purges sessions past their TTL", func(t *testing.T) {
+		service, _, _ := setup(t)
+		expired, err := service.CreateUpload(ctx, "old", "video/mp4", 10)
+		require.NoError(t, err)
+		service.now = func() time.Time { return time.Now().Add(30 * time.Minute) }
+		live, err := service.CreateUpload(ctx, "new", "video/mp4", 10)
+		require.NoError(t, err)
+
+		service.now = func() time.Time { return time.Now().Add(61 * time.Minute) }
+		_, err = service.GetUpload(ctx, expired.ID)
+		assert.True(t, domain.IsUploadNotFound(err), "expired sessions cannot be resumed")
+
+		purged, err := service.PurgeExpiredUploads(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		_, err = service.GetUpload(ctx, live.ID)
+		assert.NoError(t, err)
+	})
+}
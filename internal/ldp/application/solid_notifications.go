@@ -0,0 +1,312 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// SolidNotificationContext is the JSON-LD context of notifications and channel descriptions
+var SolidNotificationContext = []string{
+	"https://www.w3.org/ns/activitystreams",
+	"https://www.w3.org/ns/solid/notification/v1",
+}
+
+// SubscriptionRequest asks for a notification channel on a container
+type SubscriptionRequest struct {
+	Type        string
+	Topic       string
+	ContainerID string
+	SendTo      string
+}
+
+// containerChange is a committed container event waiting to be delivered to its channels
+type containerChange struct {
+	containerID  string
+	activityType string
+	memberID     string
+	memberType   string
+	published    time.Time
+}
+
+// SolidNotificationService serves Solid Notifications Protocol channels. Subscribers open a
+// channel on a container and receive an Activity Streams notification for each change to
+// it. Handle only enqueues the change; a background worker delivers it, so a slow or failing
+// receiver never holds up the commit that produced the event.
+type SolidNotificationService struct {
+	channels      domain.NotificationChannelStore
+	sender        domain.NotificationSender
+	containerRepo domain.ContainerRepository
+	readers       ContainerReadAuthorizer
+	now           func() time.Time
+
+	queue     chan containerChange
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSolidNotificationService creates a notification service and starts its delivery worker.
+// At most queueSize changes wait for delivery; further changes are dropped and logged.
+func NewSolidNotificationService(
+	channels domain.NotificationChannelStore,
+	sender domain.NotificationSender,
+	containerRepo domain.ContainerRepository,
+	queueSize int,
+) *SolidNotificationService {
+	service := &SolidNotificationService{
+		channels:      channels,
+		sender:        sender,
+		containerRepo: containerRepo,
+		now:           time.Now,
+		queue:         make(chan containerChange, queueSize),
+		done:          make(chan struct{}),
+	}
+
+	go service.run()
+
+	return service
+}
+
+// SetReadAuthorizer sets the authorizer deciding who may subscribe to a container: channels are
+// only opened for agents with Read on it. Without one every subscription is allowed.
+func (s *SolidNotificationService) SetReadAuthorizer(authorizer ContainerReadAuthorizer) {
+	s.readers = authorizer
+}
+
+// Subscribe opens a channel delivering the changes of a container to the subscriber, the agent
+// named on ctx by domain.WithAgent. Only WebhookChannel2023 is offered; its sendTo must be an
+// absolute http or https URI that the sender is willing to reach.
+func (s *SolidNotificationService) Subscribe(ctx context.Context, request SubscriptionRequest) (domain.NotificationChannel, error) {
+	if request.Type != domain.WebhookChannel2023 {
+		return domain.NotificationChannel{}, domain.ErrUnsupportedChannelType.WithOperation("Subscribe").WithContext("type", request.Type)
+	}
+	if request.ContainerID == "" {
+		return domain.NotificationChannel{}, domain.ErrInvalidResource.WithOperation("Subscribe").WithContext("reason", "topic must be a container")
+	}
+
+	sendTo, err := url.Parse(request.SendTo)
+	if err != nil || (sendTo.Scheme != "http" && sendTo.Scheme != "https") || sendTo.Host == "" {
+		return domain.NotificationChannel{}, domain.ErrInvalidResource.WithOperation("Subscribe").WithContext("reason", "sendTo must be an absolute http or https URI")
+	}
+	if checker, ok := s.sender.(domain.NotificationTargetChecker); ok {
+		if err := checker.CheckTarget(ctx, request.SendTo); err != nil {
+			return domain.NotificationChannel{}, domain.ErrInvalidResource.WithOperation("Subscribe").WithContext("reason", "sendTo must not target a private, loopback or link-local address")
+		}
+	}
+
+	if s.readers != nil && !s.readers.CanReadContainer(ctx, request.ContainerID) {
+		return domain.NotificationChannel{}, domain.ErrAccessDenied.WithOperation("Subscribe").WithContext("containerID", request.ContainerID)
+	}
+
+	exists, err := s.containerRepo.ContainerExists(ctx, request.ContainerID)
+	if err != nil {
+		return domain.NotificationChannel{}, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to check container existence",
+		).WithOperation("Subscribe").WithContext("containerID", request.ContainerID)
+	}
+	if !exists {
+		return domain.NotificationChannel{}, domain.ErrResourceNotFound.WithOperation("Subscribe").WithContext("containerID", request.ContainerID)
+	}
+
+	channel := domain.NotificationChannel{
+		ID:          uuid.New().String(),
+		Type:        request.Type,
+		Topic:       request.Topic,
+		ContainerID: request.ContainerID,
+		SendTo:      request.SendTo,
+		Owner:       domain.AgentFromContext(ctx),
+		CreatedAt:   s.now(),
+	}
+	if err := s.channels.Save(ctx, channel); err != nil {
+		return domain.NotificationChannel{}, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to save notification channel",
+		).WithOperation("Subscribe").WithContext("containerID", request.ContainerID)
+	}
+
+	return channel, nil
+}
+
+// Unsubscribe closes a channel opened by the agent named on ctx
+func (s *SolidNotificationService) Unsubscribe(ctx context.Context, channelID string) error {
+	if _, err := s.GetChannel(ctx, channelID); err != nil {
+		return err
+	}
+	return s.channels.Delete(ctx, channelID)
+}
+
+// GetChannel returns an open channel. Channels opened by another agent than the one named on
+// ctx answer ErrNotificationChannelNotFound, so their IDs are not disclosed.
+func (s *SolidNotificationService) GetChannel(ctx context.Context, channelID string) (domain.NotificationChannel, error) {
+	channel, err := s.channels.Get(ctx, channelID)
+	if err != nil {
+		return domain.NotificationChannel{}, err
+	}
+	if channel.Owner != "" && domain.AgentFromContext(ctx) != channel.Owner {
+		return domain.NotificationChannel{}, domain.WrapStorageError(
+			fmt.Errorf("notification channel belongs to another agent"),
+			domain.ErrNotificationChannelNotFound.Code,
+			domain.ErrNotificationChannelNotFound.Message,
+		).WithOperation("GetChannel").WithContext("id", channelID)
+	}
+	return channel, nil
+}
+
+// EventTypes returns the container event types delivered as notifications
+func (s *SolidNotificationService) EventTypes() []string {
+	return []string{
+		"container." + domain.EventTypeContainerUpdated,
+		"container." + domain.EventTypeContainerDeleted,
+		"container." + domain.EventTypeMemberAdded,
+		"container." + domain.EventTypeMemberRemoved,
+	}
+}
+
+// Handle queues a container change for delivery. It never returns an error for a well-formed
+// event; when the queue is full the change is dropped and logged.
+func (s *SolidNotificationService) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event, ok := envelope.Event().(*pericarpdomain.EntityEvent)
+	if !ok || event.EntityType != "container" {
+		return nil
+	}
+
+	change := containerChange{
+		containerID: event.AggregateID(),
+		published:   event.CreatedAt(),
+	}
+
+	switch event.Type {
+	case domain.EventTypeContainerUpdated:
+		change.activityType = "Update"
+	case domain.EventTypeContainerDeleted:
+		change.activityType = "Delete"
+	case domain.EventTypeMemberAdded:
+		change.activityType = "Add"
+	case domain.EventTypeMemberRemoved:
+		change.activityType = "Remove"
+	default:
+		return nil
+	}
+
+	if change.activityType == "Add" || change.activityType == "Remove" {
+		var payload struct {
+			MemberID     string `json:"memberID"`
+			ResourceType string `json:"resourceType"`
+		}
+		if err := json.Unmarshal(event.Payload(), &payload); err != nil || payload.MemberID == "" {
+			fmt.Printf("Warning: skipping notification for malformed %s event on container %s\n", event.EventType(), event.AggregateID())
+			return nil
+		}
+		change.memberID = payload.MemberID
+		change.memberType = payload.ResourceType
+	}
+
+	select {
+	case s.queue <- change:
+	default:
+		fmt.Printf("Warning: notification queue full, dropping %s notification for container %s\n", change.activityType, change.containerID)
+	}
+	return nil
+}
+
+// Close stops accepting changes, delivers any queued ones and waits for the worker
+func (s *SolidNotificationService) Close() {
+	s.closeOnce.Do(func() {
+		close(s.queue)
+	})
+	<-s.done
+}
+
+// run delivers queued changes until the service is closed
+func (s *SolidNotificationService) run() {
+	defer close(s.done)
+
+	for change := range s.queue {
+		s.deliver(context.Background(), change)
+	}
+}
+
+// deliver sends a change to every channel on its container whose owner can still read it, so
+// revoking an agent's access also stops its notifications. A deleted container's channels
+// receive the Delete notification and are then closed, as their topic no longer exists.
+func (s *SolidNotificationService) deliver(ctx context.Context, change containerChange) {
+	channels, err := s.channels.ListByContainer(ctx, change.containerID)
+	if err != nil {
+		fmt.Printf("Warning: failed to list notification channels for container %s: %v\n", change.containerID, err)
+		return
+	}
+
+	for _, channel := range channels {
+		s.send(ctx, channel, change)
+
+		if change.activityType == "Delete" {
+			if err := s.channels.Delete(ctx, channel.ID); err != nil {
+				fmt.Printf("Warning: failed to close notification channel %s: %v\n", channel.ID, err)
+			}
+		}
+	}
+}
+
+// send delivers a change over one channel if the channel's owner can still read its container
+func (s *SolidNotificationService) send(ctx context.Context, channel domain.NotificationChannel, change containerChange) {
+	if s.readers != nil && !s.readers.CanReadContainer(domain.WithAgent(ctx, channel.Owner), channel.ContainerID) {
+		fmt.Printf("Warning: skipping %s notification on channel %s: its owner can no longer read container %s\n", change.activityType, channel.ID, channel.ContainerID)
+		return
+	}
+
+	notification, err := json.Marshal(s.notification(channel, change))
+	if err != nil {
+		fmt.Printf("Warning: failed to serialize notification for channel %s: %v\n", channel.ID, err)
+		return
+	}
+	if err := s.sender.Send(ctx, channel, notification); err != nil {
+		fmt.Printf("Warning: failed to deliver %s notification on channel %s: %v\n", change.activityType, channel.ID, err)
+	}
+}
+
+// notification builds the Activity Streams notification of a change for one channel
+func (s *SolidNotificationService) notification(channel domain.NotificationChannel, change containerChange) map[string]interface{} {
+	return solidActivity(channel.Topic, change.activityType, change.memberID, change.memberType, change.published)
+}
+
+// solidActivity builds an Activity Streams notification about a topic. Member changes name
+// the member as the object and the topic as the target; changes to the topic itself name the
+// topic as the object.
+func solidActivity(topic, activityType, memberID, memberType string, published time.Time) map[string]interface{} {
+	notification := map[string]interface{}{
+		"@context":  SolidNotificationContext,
+		"id":        "urn:uuid:" + uuid.New().String(),
+		"type":      activityType,
+		"object":    topic,
+		"published": published.UTC().Format(time.RFC3339),
+	}
+
+	if memberID != "" {
+		notification["object"] = memberURI(topic, memberID, memberType)
+		notification["target"] = topic
+	}
+
+	return notification
+}
+
+// memberURI returns the URI of a container member on the same server as the topic
+func memberURI(topic, memberID, memberType string) string {
+	base := ""
+	if parsed, err := url.Parse(topic); err == nil && parsed.Host != "" {
+		base = parsed.Scheme + "://" + parsed.Host
+	}
+	if memberType == domain.SearchTypeContainer {
+		return base + "/containers/" + url.PathEscape(memberID)
+	}
+	return base + "/resources/" + url.PathEscape(memberID)
+}
@@ -0,0 +1,223 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotificationSender keeps every notification it is asked to send
+type recordingNotificationSender struct {
+	mu   sync.Mutex
+	sent []map[string]interface{}
+}
+
+func (s *recordingNotificationSender) Send(ctx context.Context, channel domain.NotificationChannel, notification []byte) error {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(notification, &decoded); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, decoded)
+	return nil
+}
+
+// checkingNotificationSender refuses receivers on the listed hosts
+type checkingNotificationSender struct {
+	recordingNotificationSender
+	refused map[string]bool
+}
+
+func (s *checkingNotificationSender) CheckTarget(ctx context.Context, sendTo string) error {
+	if target, err := url.Parse(sendTo); err != nil || s.refused[target.Hostname()] {
+		return errors.New("refused receiver")
+	}
+	return nil
+}
+
+func newTestSolidNotificationService() (*SolidNotificationService, *recordingNotificationSender, *MockContainerRepository) {
+	containerRepo := &MockContainerRepository{}
+	sender := &recordingNotificationSender{}
+	service := NewSolidNotificationService(infrastructure.NewMemoryNotificationChannelStore(), sender, containerRepo, 16)
+	return service, sender, containerRepo
+}
+
+func TestSolidNotificationService_Subscribe(t *testing.T) {
+	service, _, containerRepo := newTestSolidNotificationService()
+	defer service.Close()
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	containerRepo.On("ContainerExists", mock.Anything, "missing").Return(false, nil)
+
+	channel, err := service.Subscribe(ctx, SubscriptionRequest{
+		Type:        domain.WebhookChannel2023,
+		Topic:       "https://pod.example/containers/photos",
+		ContainerID: "photos",
+		SendTo:      "https://app.example/hooks/photos",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, channel.ID)
+	assert.Equal(t, "photos", channel.ContainerID)
+
+	stored, err := service.GetChannel(ctx, channel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, channel, stored)
+
+	_, err = service.Subscribe(ctx, SubscriptionRequest{Type: "WebSocketChannel2023", ContainerID: "photos", SendTo: "https://app.example/"})
+	assert.True(t, domain.IsUnsupportedChannelType(err))
+
+	_, err = service.Subscribe(ctx, SubscriptionRequest{Type: domain.WebhookChannel2023, ContainerID: "photos", SendTo: "ftp://app.example/"})
+	assert.Error(t, err)
+
+	_, err = service.Subscribe(ctx, SubscriptionRequest{Type: domain.WebhookChannel2023, ContainerID: "missing", SendTo: "https://app.example/"})
+	assert.True(t, domain.IsResourceNotFound(err))
+
+	require.NoError(t, service.Unsubscribe(ctx, channel.ID))
+	assert.True(t, domain.IsNotificationChannelNotFound(service.Unsubscribe(ctx, channel.ID)))
+}
+
+func TestSolidNotificationService_SubscribeGuards(t *testing.T) {
+	containerRepo := &MockContainerRepository{}
+	sender := &checkingNotificationSender{refused: map[string]bool{"169.254.169.254": true}}
+	service := NewSolidNotificationService(infrastructure.NewMemoryNotificationChannelStore(), sender, containerRepo, 16)
+	defer service.Close()
+	service.SetReadAuthorizer(denyContainerReads{"private": true})
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+
+	t.Run("refuses receivers the sender will not reach", func(t *testing.T) {
+		_, err := service.Subscribe(ctx, SubscriptionRequest{Type: domain.WebhookChannel2023, ContainerID: "photos", SendTo: "http://169.254.169.254/latest/meta-data"})
+		storageErr, ok := domain.GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrInvalidResource.Code, storageErr.Code)
+	})
+
+	t.Run("refuses topics the agent cannot read", func(t *testing.T) {
+		_, err := service.Subscribe(ctx, SubscriptionRequest{Type: domain.WebhookChannel2023, ContainerID: "private", SendTo: "https://app.example/"})
+		assert.True(t, domain.IsAccessDenied(err))
+		containerRepo.AssertNotCalled(t, "ContainerExists", mock.Anything, "private")
+	})
+
+	t.Run("opens channels on readable topics", func(t *testing.T) {
+		_, err := service.Subscribe(ctx, SubscriptionRequest{Type: domain.WebhookChannel2023, ContainerID: "photos", SendTo: "https://app.example/"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestSolidNotificationService_DeliversContainerChanges(t *testing.T) {
+	service, sender, containerRepo := newTestSolidNotificationService()
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	_, err := service.Subscribe(ctx, SubscriptionRequest{
+		Type:        domain.WebhookChannel2023,
+		Topic:       "https://pod.example/containers/photos",
+		ContainerID: "photos",
+		SendTo:      "https://app.example/hooks/photos",
+	})
+	require.NoError(t, err)
+
+	added := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": "beach.jpg", "resourceType": "Resource"})
+	deleted := domain.NewContainerDeletedEvent("photos", map[string]interface{}{})
+	unrelated := domain.NewMemberAddedEvent("notes", map[string]interface{}{"memberID": "todo.txt"})
+	for _, event := range []*domain.EntityEvent{added, unrelated, deleted} {
+		require.NoError(t, service.Handle(ctx, &testEnvelope{event: event, timestamp: time.Now()}))
+	}
+	service.Close()
+
+	require.Len(t, sender.sent, 2)
+	assert.Equal(t, "Add", sender.sent[0]["type"])
+	assert.Equal(t, "https://pod.example/resources/beach.jpg", sender.sent[0]["object"])
+	assert.Equal(t, "https://pod.example/containers/photos", sender.sent[0]["target"])
+	assert.Contains(t, sender.sent[0]["id"], "urn:uuid:")
+	assert.Equal(t, "Delete", sender.sent[1]["type"])
+	assert.Equal(t, "https://pod.example/containers/photos", sender.sent[1]["object"])
+
+	// The deleted container's channel is closed with it
+	channels, err := service.channels.ListByContainer(ctx, "photos")
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+}
+
+func TestSolidNotificationService_ChannelsBelongToTheirSubscriber(t *testing.T) {
+	const (
+		alice = "https://alice.example/profile#me"
+		bob   = "https://bob.example/profile#me"
+	)
+	service, _, containerRepo := newTestSolidNotificationService()
+	defer service.Close()
+	asAlice := domain.WithAgent(context.Background(), alice)
+	asBob := domain.WithAgent(context.Background(), bob)
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	channel, err := service.Subscribe(asAlice, SubscriptionRequest{Type: domain.WebhookChannel2023, ContainerID: "photos", SendTo: "https://app.example/"})
+	require.NoError(t, err)
+	assert.Equal(t, alice, channel.Owner)
+
+	_, err = service.GetChannel(asBob, channel.ID)
+	assert.True(t, domain.IsNotificationChannelNotFound(err))
+	_, err = service.GetChannel(context.Background(), channel.ID)
+	assert.True(t, domain.IsNotificationChannelNotFound(err))
+	assert.True(t, domain.IsNotificationChannelNotFound(service.Unsubscribe(asBob, channel.ID)))
+
+	_, err = service.GetChannel(asAlice, channel.ID)
+	require.NoError(t, err, "another agent must not close the channel")
+	assert.NoError(t, service.Unsubscribe(asAlice, channel.ID))
+}
+
+// revocableContainerReads lets the listed agents read every container until they are revoked
+type revocableContainerReads struct {
+	mu      sync.Mutex
+	readers map[string]bool
+}
+
+func (r *revocableContainerReads) CanReadContainer(ctx context.Context, containerID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.readers[domain.AgentFromContext(ctx)]
+}
+
+func (r *revocableContainerReads) revoke(agent string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.readers, agent)
+}
+
+func TestSolidNotificationService_StopsDeliveringAfterReadIsRevoked(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	service, sender, containerRepo := newTestSolidNotificationService()
+	readers := &revocableContainerReads{readers: map[string]bool{alice: true}}
+	service.SetReadAuthorizer(readers)
+	ctx := context.Background()
+
+	containerRepo.On("ContainerExists", mock.Anything, "photos").Return(true, nil)
+	_, err := service.Subscribe(domain.WithAgent(ctx, alice), SubscriptionRequest{
+		Type:        domain.WebhookChannel2023,
+		Topic:       "https://pod.example/containers/photos",
+		ContainerID: "photos",
+		SendTo:      "https://app.example/hooks/photos",
+	})
+	require.NoError(t, err)
+
+	// Revoke only once the change is queued, as happens when access is withdrawn while
+	// notifications are waiting for delivery
+	added := domain.NewMemberAddedEvent("photos", map[string]interface{}{"memberID": "beach.jpg", "resourceType": "Resource"})
+	readers.revoke(alice)
+	require.NoError(t, service.Handle(ctx, &testEnvelope{event: added, timestamp: time.Now()}))
+	service.Close()
+
+	assert.Empty(t, sender.sent)
+}
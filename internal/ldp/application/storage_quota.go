@@ -0,0 +1,218 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// StorageQuotaPolicy resolves an account's storage quota in bytes. Zero means the account has
+// no quota.
+type StorageQuotaPolicy interface {
+	MaxStorageBytes(ctx context.Context, accountID string) int64
+}
+
+// SetStorageQuota tracks the bytes each account stores in usage and refuses writes that would
+// take an account past the quota policy resolves for it. Without a usage store nothing is
+// tracked; without a policy usage is tracked but no write is refused.
+func (s *StorageService) SetStorageQuota(usage domain.StorageUsageStore, policy StorageQuotaPolicy) {
+	s.storageUsage = usage
+	s.quotaPolicy = policy
+}
+
+// SetPodAccounts sets the container repository writes are charged through: a resource not yet
+// charged to an account is charged to the account owning the pod it is in
+func (s *StorageService) SetPodAccounts(containerRepo domain.ContainerRepository) {
+	s.podContainers = containerRepo
+}
+
+// writeAccount returns the account a write to a resource not yet charged to one is made on
+// behalf of: the account owning the pod the resource is in, or for a resource no container
+// holds yet, the account callers named from the pod of the container the write targets
+func (s *StorageService) writeAccount(ctx context.Context, resourceID string) (string, error) {
+	if s.podContainers != nil {
+		accountID, err := podAccountID(ctx, s.podContainers, resourceID)
+		if err != nil {
+			return "", err
+		}
+		if accountID != "" {
+			return accountID, nil
+		}
+	}
+	return domain.AccountIDFromContext(ctx), nil
+}
+
+// StorageUsage returns how many bytes an account stores and its quota, for showing users how
+// close they are to their limit
+func (s *StorageService) StorageUsage(ctx context.Context, accountID string) (domain.StorageUsage, error) {
+	if accountID == "" {
+		return domain.StorageUsage{}, domain.ErrInvalidID.WithOperation("StorageUsage").WithContext("reason", "account ID cannot be empty")
+	}
+
+	usage := domain.StorageUsage{AccountID: accountID}
+	if s.storageUsage != nil {
+		used, err := s.storageUsage.AccountUsage(ctx, accountID)
+		if err != nil {
+			return domain.StorageUsage{}, err
+		}
+		usage.UsedBytes = used
+	}
+	if s.quotaPolicy != nil {
+		usage.MaxBytes = s.quotaPolicy.MaxStorageBytes(ctx, accountID)
+	}
+	return usage, nil
+}
+
+// storageCharge is what a write to one resource is charged against: the account and its
+// quota, the account's bytes apart from this resource, and the bytes the resource held before
+type storageCharge struct {
+	accountID string
+	limit     int64
+	others    int64
+	previous  int64
+}
+
+// remaining returns how many bytes the resource may hold, or -1 when there is no limit. A
+// resource may always keep its earlier size, so an account over its quota can still shrink
+// its resources back under it.
+func (c *storageCharge) remaining() int64 {
+	if c == nil || c.limit <= 0 {
+		return -1
+	}
+	allowed := c.limit - c.others
+	if allowed < c.previous {
+		allowed = c.previous
+	}
+	if allowed < 0 {
+		allowed = 0
+	}
+	return allowed
+}
+
+// storageChargeFor resolves the account a write to a resource is charged to: the account the
+// resource is already charged to, or the one writeAccount finds for it. It returns nil
+// when usage is not tracked or the write is on behalf of no account, and refuses writes to
+// accounts that were deleted. Callers hold the write lock, so the usage read here cannot
+// change before the write is recorded.
+func (s *StorageService) storageChargeFor(ctx context.Context, resourceID, operation string) (*storageCharge, error) {
+	if s.storageUsage == nil {
+		return nil, nil
+	}
+
+	accountID, previous, found, err := s.storageUsage.ResourceUsage(ctx, resourceID)
+	if err != nil {
+		return nil, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to read storage usage").
+			WithOperation(operation).WithContext("id", resourceID)
+	}
+	if !found {
+		accountID, err = s.writeAccount(ctx, resourceID)
+		if err != nil {
+			return nil, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to resolve resource pod").
+				WithOperation(operation).WithContext("id", resourceID)
+		}
+		previous = 0
+	}
+	if accountID == "" {
+		return nil, nil
+	}
+	if err := s.accountStatusError(ctx, accountID, resourceID, operation); err != nil {
+		return nil, err
+	}
+
+	charge := &storageCharge{accountID: accountID, previous: previous}
+	if s.quotaPolicy != nil {
+		charge.limit = s.quotaPolicy.MaxStorageBytes(ctx, accountID)
+	}
+	if charge.limit > 0 {
+		used, err := s.storageUsage.AccountUsage(ctx, accountID)
+		if err != nil {
+			return nil, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to read storage usage").
+				WithOperation(operation).WithContext("accountID", accountID)
+		}
+		charge.others = used - previous
+	}
+	return charge, nil
+}
+
+// checkStorageQuota refuses a write of size bytes that would take the account past its quota
+func (s *StorageService) checkStorageQuota(charge *storageCharge, size int64, operation string) error {
+	remaining := charge.remaining()
+	if remaining < 0 || size <= remaining {
+		return nil
+	}
+	return quotaExceeded(charge, size, operation)
+}
+
+// recordStorageUsage charges a stored resource to its account. A failure is logged rather than
+// returned: the resource is stored, and only the usage total is left behind.
+func (s *StorageService) recordStorageUsage(ctx context.Context, charge *storageCharge, resourceID string, size int64) {
+	if charge == nil {
+		return
+	}
+	if err := s.storageUsage.SetResourceUsage(ctx, charge.accountID, resourceID, size); err != nil {
+		fmt.Printf("Warning: failed to record storage usage of resource %s: %v\n", resourceID, err)
+	}
+}
+
+// releaseStorageUsage drops a deleted resource's charge
+func (s *StorageService) releaseStorageUsage(ctx context.Context, resourceID string) {
+	if s.storageUsage == nil {
+		return
+	}
+	if err := s.storageUsage.RemoveResourceUsage(ctx, resourceID); err != nil {
+		fmt.Printf("Warning: failed to release storage usage of resource %s: %v\n", resourceID, err)
+	}
+}
+
+// quotaExceeded builds the error refusing a write past an account's quota
+func quotaExceeded(charge *storageCharge, size int64, operation string) error {
+	return domain.WrapStorageError(
+		fmt.Errorf("storing %d bytes would exceed the account's quota of %d bytes", size, charge.limit),
+		domain.ErrInsufficientStorage.Code,
+		"storage quota exceeded",
+	).WithOperation(operation).WithContext("accountID", charge.accountID).WithContext("limit", charge.limit).WithContext("used", charge.others)
+}
+
+// quotaReader fails an upload of unknown size once it passes the bytes the account has left
+type quotaReader struct {
+	source    io.Reader
+	charge    *storageCharge
+	remaining int64
+	read      int64
+	operation string
+	err       error
+}
+
+// limitStorageStream wraps an upload so reading past the account's remaining quota fails. It
+// returns nil when the upload is not limited.
+func limitStorageStream(reader io.Reader, charge *storageCharge, operation string) *quotaReader {
+	remaining := charge.remaining()
+	if remaining < 0 {
+		return nil
+	}
+	return &quotaReader{source: reader, charge: charge, remaining: remaining, operation: operation}
+}
+
+// Read reads the next part of the upload, failing once it holds more than the quota allows
+func (r *quotaReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.source.Read(p)
+	r.read += int64(n)
+	if r.read > r.remaining {
+		r.err = quotaExceeded(r.charge, r.read, r.operation)
+		return n, r.err
+	}
+	return n, err
+}
+
+// exceeded returns the quota error if the upload went past the quota, or nil
+func (r *quotaReader) exceeded() error {
+	if r == nil {
+		return nil
+	}
+	return r.err
+}
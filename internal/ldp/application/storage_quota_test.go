@@ -0,0 +1,179 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// quotaResourceRepo also deletes resources, for storage quota tests
+type quotaResourceRepo struct {
+	streamingPatchResourceRepo
+}
+
+func (r *quotaResourceRepo) Delete(ctx context.Context, id string) error {
+	delete(r.resources, id)
+	return nil
+}
+
+// memoryStorageUsage keeps storage usage in memory
+type memoryStorageUsage struct {
+	accounts map[string]string
+	sizes    map[string]int64
+	totals   map[string]int64
+}
+
+func newMemoryStorageUsage() *memoryStorageUsage {
+	return &memoryStorageUsage{accounts: map[string]string{}, sizes: map[string]int64{}, totals: map[string]int64{}}
+}
+
+func (u *memoryStorageUsage) ResourceUsage(ctx context.Context, resourceID string) (string, int64, bool, error) {
+	accountID, ok := u.accounts[resourceID]
+	return accountID, u.sizes[resourceID], ok, nil
+}
+
+func (u *memoryStorageUsage) SetResourceUsage(ctx context.Context, accountID, resourceID string, size int64) error {
+	u.RemoveResourceUsage(ctx, resourceID)
+	u.accounts[resourceID] = accountID
+	u.sizes[resourceID] = size
+	u.totals[accountID] += size
+	return nil
+}
+
+func (u *memoryStorageUsage) RemoveResourceUsage(ctx context.Context, resourceID string) error {
+	if accountID, ok := u.accounts[resourceID]; ok {
+		u.totals[accountID] -= u.sizes[resourceID]
+		delete(u.accounts, resourceID)
+		delete(u.sizes, resourceID)
+	}
+	return nil
+}
+
+func (u *memoryStorageUsage) AccountUsage(ctx context.Context, accountID string) (int64, error) {
+	return u.totals[accountID], nil
+}
+
+// fixedStorageQuotas gives each account a fixed quota
+type fixedStorageQuotas map[string]int64
+
+func (q fixedStorageQuotas) MaxStorageBytes(ctx context.Context, accountID string) int64 {
+	return q[accountID]
+}
+
+func TestStorageService_StorageQuota(t *testing.T) {
+	ctx := domain.WithAccountID(context.Background(), "acct-1")
+	setup := func() (*StorageService, *quotaResourceRepo, *memoryStorageUsage) {
+		repo := &quotaResourceRepo{streamingPatchResourceRepo{patchResourceRepo: patchResourceRepo{dublinCoreResourceRepo: dublinCoreResourceRepo{resources: map[string]domain.Resource{}}}}}
+		mockUoW := &MockUnitOfWork{}
+		mockUoW.On("RegisterEvents", mock.Anything).Return()
+		mockUoW.On("Commit", mock.Anything).Return([]pericarpdomain.Envelope{}, nil)
+
+		usage := newMemoryStorageUsage()
+		service := NewStorageService(repo, infrastructure.NewRDFConverter(), func() pericarpdomain.UnitOfWork { return mockUoW })
+		service.SetStorageQuota(usage, fixedStorageQuotas{"acct-1": 10})
+		return service, repo, usage
+	}
+
+	t.Run("charges writes to the account", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.StoreResource(ctx, "a", []byte("123456"), "text/plain")
+		require.NoError(t, err)
+
+		usage, err := service.StorageUsage(ctx, "acct-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StorageUsage{AccountID: "acct-1", UsedBytes: 6, MaxBytes: 10}, usage)
+	})
+
+	t.Run("refuses writes past the quota", func(t *testing.T) {
+		service, repo, _ := setup()
+
+		_, err := service.StoreResource(ctx, "a", []byte("123456"), "text/plain")
+		require.NoError(t, err)
+		_, err = service.StoreResource(ctx, "b", []byte("12345"), "text/plain")
+		require.Error(t, err)
+		assert.True(t, domain.IsInsufficientStorage(err))
+		assert.NotContains(t, repo.resources, "b")
+	})
+
+	t.Run("replacing a resource charges only the difference", func(t *testing.T) {
+		service, _, usage := setup()
+
+		_, err := service.StoreResource(ctx, "a", []byte("123456"), "text/plain")
+		require.NoError(t, err)
+		_, err = service.StoreResource(ctx, "a", []byte("1234567890"), "text/plain")
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), usage.totals["acct-1"])
+	})
+
+	t.Run("accounts over their quota may still shrink resources", func(t *testing.T) {
+		service, _, usage := setup()
+		usage.SetResourceUsage(ctx, "acct-1", "old", 20)
+
+		_, err := service.StoreResource(ctx, "old", []byte("12345"), "text/plain")
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), usage.totals["acct-1"])
+	})
+
+	t.Run("deleting a resource releases its charge", func(t *testing.T) {
+		service, _, usage := setup()
+
+		_, err := service.StoreResource(ctx, "a", []byte("123456"), "text/plain")
+		require.NoError(t, err)
+		require.NoError(t, service.DeleteResource(ctx, "a"))
+		assert.Zero(t, usage.totals["acct-1"])
+	})
+
+	t.Run("refuses streamed uploads of unknown size once past the quota", func(t *testing.T) {
+		service, repo, _ := setup()
+
+		_, err := service.StoreResourceStream(ctx, "big", strings.NewReader(strings.Repeat("x", 11)), "application/octet-stream", 0)
+		require.Error(t, err)
+		assert.True(t, domain.IsInsufficientStorage(err))
+		assert.NotContains(t, repo.resources, "big")
+	})
+
+	t.Run("refuses streamed uploads declared past the quota", func(t *testing.T) {
+		service, repo, _ := setup()
+
+		_, err := service.StoreResourceStream(ctx, "big", strings.NewReader(strings.Repeat("x", 11)), "application/octet-stream", 11)
+		require.Error(t, err)
+		assert.True(t, domain.IsInsufficientStorage(err))
+		assert.Zero(t, repo.streamed)
+	})
+
+	t.Run("writes on behalf of no account are not limited", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.StoreResource(context.Background(), "a", []byte(strings.Repeat("x", 20)), "text/plain")
+		assert.NoError(t, err)
+	})
+
+	t.Run("charges resources in a pod to the account owning it", func(t *testing.T) {
+		service, _, usage := setup()
+		mockRepo := &TestMockContainerRepository{}
+		mockRepo.On("ContainerExists", mock.Anything, "note").Return(false, nil)
+		mockRepo.On("GetPath", mock.Anything, "acct-1/notes").Return([]string{"acct-1", "acct-1/notes"}, nil)
+		service.SetPodAccounts(&memberContainerRepository{
+			TestMockContainerRepository: mockRepo,
+			holders:                     map[string][]string{"note": {"acct-1/notes"}},
+		})
+
+		// The account the write names is ignored for a resource already in a pod
+		other := domain.WithAccountID(context.Background(), "acct-2")
+		_, err := service.StoreResource(other, "note", []byte("123456"), "text/plain")
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), usage.totals["acct-1"])
+		assert.Zero(t, usage.totals["acct-2"])
+
+		_, err = service.StoreResource(other, "note", []byte(strings.Repeat("x", 11)), "text/plain")
+		assert.True(t, domain.IsInsufficientStorage(err))
+	})
+}
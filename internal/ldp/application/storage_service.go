@@ -1,6 +1,7 @@
 package application
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 )
@@ -22,6 +24,23 @@ type StorageService struct {
 	repo              domain.StreamingResourceRepository
 	converter         domain.FormatConverter
 	unitOfWorkFactory UnitOfWorkFactory
+	searchIndex       domain.SearchIndex
+	dublinCoreLimits  domain.DublinCoreLimits
+	rejectTakenNames  bool
+	containerNames    ContainerNameChecker
+	patchers          map[string]domain.ResourcePatcher
+	tripleMatcher     domain.TripleMatcher
+	versionSource     domain.ResourceVersionSource
+	versionStore      domain.ResourceVersionStore
+	graphDiffer       domain.GraphDiffer
+	graphSizeLimiter  domain.GraphSizeLimiter
+	maxTriples        int
+	storageUsage      domain.StorageUsageStore
+	quotaPolicy       StorageQuotaPolicy
+	podContainers     domain.ContainerRepository
+	accountStatus     AccountStatusPolicy
+	identifiers       identifierTracking
+	ids               *domain.IDValidator
 	mu                sync.RWMutex // For concurrent access handling
 }
 
@@ -40,12 +59,23 @@ func NewStorageService(
 
 // StoreResource stores a resource with content negotiation support
 func (s *StorageService) StoreResource(ctx context.Context, id string, data []byte, contentType string) (domain.Resource, error) {
+	return s.StoreResourceWithMetadata(ctx, id, data, contentType, nil)
+}
+
+// StoreResourceWithMetadata stores a resource and sets the given metadata on it, such as
+// metadata inherited from the container it is created in
+func (s *StorageService) StoreResourceWithMetadata(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.storeResource(ctx, id, data, contentType, metadata)
+}
+
+// storeResource creates or updates a resource; callers hold the write lock
+func (s *StorageService) storeResource(ctx context.Context, id string, data []byte, contentType string, metadata map[string]interface{}) (domain.Resource, error) {
 	// Validate input
-	if id == "" {
-		return nil, domain.ErrInvalidID.WithOperation("StoreResource")
+	if err := s.validateResourceID(id, "StoreResource"); err != nil {
+		return nil, err
 	}
 	if len(data) == 0 {
 		return nil, domain.ErrInvalidResource.WithOperation("StoreResource").WithContext("reason", "empty data")
@@ -58,6 +88,9 @@ func (s *StorageService) StoreResource(ctx context.Context, id string, data []by
 	if s.isRDFFormat(normalizedContentType) && !s.converter.ValidateFormat(normalizedContentType) {
 		return nil, domain.ErrUnsupportedFormat.WithOperation("StoreResource").WithContext("format", contentType)
 	}
+	if err := s.checkGraphSize(data, normalizedContentType, "StoreResource"); err != nil {
+		return nil, err
+	}
 
 	// Check if resource already exists (for business logic, not for repository updates)
 	exists, err := s.repo.Exists(ctx, id)
@@ -67,18 +100,29 @@ func (s *StorageService) StoreResource(ctx context.Context, id string, data []by
 
 	// Create or update resource (in-memory only)
 	var resource domain.Resource
+	var previousSize int
 	if exists {
 		// Update existing resource - retrieve current state
 		resource, err = s.repo.Retrieve(ctx, id)
 		if err != nil {
 			return nil, domain.WrapStorageError(err, "RETRIEVE_FAILED", "failed to retrieve existing resource").WithOperation("StoreResource")
 		}
+		previousSize = resource.GetSize()
 		resource.Update(ctx, data, normalizedContentType)
 	} else {
 		// Create new resource
 		resource = domain.NewResource(ctx, id, normalizedContentType, data)
 	}
 
+	for key, value := range metadata {
+		resource.SetMetadata(key, value)
+	}
+
+	// Record the interaction model once so later content changes do not alter how clients treat the resource
+	if _, recorded := resource.GetMetadata()[domain.InteractionModelKey]; !recorded {
+		resource.SetMetadata(domain.InteractionModelKey, domain.InteractionModelForContentType(normalizedContentType).String())
+	}
+
 	// Check if resource is valid before proceeding
 	if !resource.IsValid() {
 		errors := resource.Errors()
@@ -88,6 +132,15 @@ func (s *StorageService) StoreResource(ctx context.Context, id string, data []by
 		return nil, domain.ErrInvalidResource.WithOperation("StoreResource").WithContext("reason", "resource is not valid")
 	}
 
+	// Refuse writes that would take the account past its storage quota
+	charge, err := s.storageChargeFor(ctx, id, "StoreResource")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkStorageQuota(charge, int64(len(data)), "StoreResource"); err != nil {
+		return nil, err
+	}
+
 	// Create a new unit of work for this operation
 	unitOfWork := s.unitOfWorkFactory()
 
@@ -114,13 +167,20 @@ func (s *StorageService) StoreResource(ctx context.Context, id string, data []by
 	}
 
 	// Mark events as committed on the resource
-	resource.MarkEventsAsCommitted()
+	resource.ClearEvents()
+
+	metrics.ObserveResourceUploadSize(normalizedContentType, int64(len(data)))
+	recordStoredResource(exists, previousSize, resource.GetSize())
 
 	// Log successful event processing (in production, use proper logger)
 	if len(envelopes) > 0 {
 		fmt.Printf("Successfully processed %d events for resource %s\n", len(envelopes), id)
 	}
 
+	s.indexResource(ctx, resource)
+	s.recordResourceVersion(ctx, resource)
+	s.recordStorageUsage(ctx, charge, id, int64(resource.GetSize()))
+
 	return resource, nil
 }
 
@@ -133,6 +193,9 @@ func (s *StorageService) RetrieveResource(ctx context.Context, id string, accept
 	if id == "" {
 		return nil, domain.ErrInvalidID.WithOperation("RetrieveResource")
 	}
+	if err := s.checkResourceAccount(ctx, id, "RetrieveResource"); err != nil {
+		return nil, err
+	}
 
 	// Retrieve the resource
 	resource, err := s.repo.Retrieve(ctx, id)
@@ -181,7 +244,7 @@ func (s *StorageService) DeleteResource(ctx context.Context, id string) error {
 	}
 
 	// Mark as deleted (this will add delete event)
-	resource.Delete()
+	resource.Delete(ctx)
 
 	// Create a new unit of work for this operation
 	unitOfWork := s.unitOfWorkFactory()
@@ -209,33 +272,56 @@ func (s *StorageService) DeleteResource(ctx context.Context, id string) error {
 	}
 
 	// Mark events as committed on the resource
-	resource.MarkEventsAsCommitted()
+	resource.ClearEvents()
+
+	metrics.StoredResources.Dec()
+	metrics.StoredBytes.Add(-int64(resource.GetSize()))
+	s.releaseStorageUsage(ctx, id)
+	s.identifiers.release(ctx, id)
 
 	// Log successful event processing (in production, use proper logger)
 	if len(envelopes) > 0 {
 		fmt.Printf("Successfully processed %d delete events for resource %s\n", len(envelopes), id)
 	}
 
+	s.unindexResource(ctx, id)
+	s.deleteResourceVersions(ctx, id)
+
 	return nil
 }
 
 // StreamResource provides streaming access to large resources
 func (s *StorageService) StreamResource(ctx context.Context, id string, acceptFormat string) (io.ReadCloser, string, error) {
+	reader, contentType, _, err := s.streamResource(ctx, id, acceptFormat, "StreamResource")
+	return reader, contentType, err
+}
+
+// StreamResourceWithMetadata streams a resource like StreamResource and also returns the
+// resource's metadata, so a response can be shaped by it without reading the resource twice
+func (s *StorageService) StreamResourceWithMetadata(ctx context.Context, id string, acceptFormat string) (io.ReadCloser, string, map[string]interface{}, error) {
+	return s.streamResource(ctx, id, acceptFormat, "StreamResourceWithMetadata")
+}
+
+// streamResource opens a resource's content as a stream, converting it to acceptFormat if needed
+func (s *StorageService) streamResource(ctx context.Context, id, acceptFormat, operation string) (io.ReadCloser, string, map[string]interface{}, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// Validate input
 	if id == "" {
-		return nil, "", domain.ErrInvalidID.WithOperation("StreamResource")
+		return nil, "", nil, domain.ErrInvalidID.WithOperation(operation)
+	}
+	if err := s.checkResourceAccount(ctx, id, operation); err != nil {
+		return nil, "", nil, err
 	}
 
 	// Use streaming repository for efficient access
 	reader, metadata, err := s.repo.RetrieveStream(ctx, id)
 	if err != nil {
 		if domain.IsResourceNotFound(err) {
-			return nil, "", domain.ErrResourceNotFound.WithOperation("StreamResource").WithContext("id", id)
+			return nil, "", nil, domain.ErrResourceNotFound.WithOperation(operation).WithContext("id", id)
 		}
-		return nil, "", domain.WrapStorageError(err, "STREAM_RETRIEVE_FAILED", "failed to retrieve resource stream").WithOperation("StreamResource")
+		return nil, "", nil, domain.WrapStorageError(err, "STREAM_RETRIEVE_FAILED", "failed to retrieve resource stream").WithOperation(operation)
 	}
 
 	// Handle content negotiation for RDF formats
@@ -245,14 +331,14 @@ func (s *StorageService) StreamResource(ctx context.Context, id string, acceptFo
 		defer reader.Close()
 		data, err := io.ReadAll(reader)
 		if err != nil {
-			return nil, "", domain.WrapStorageError(err, "STREAM_READ_FAILED", "failed to read stream for format conversion").WithOperation("StreamResource")
+			return nil, "", nil, domain.WrapStorageError(err, "STREAM_READ_FAILED", "failed to read stream for format conversion").WithOperation(operation)
 		}
 
 		// Create temporary resource for conversion
-		tempResource := domain.NewResource(id, metadata.ContentType, data)
+		tempResource := domain.NewResource(ctx, id, metadata.ContentType, data)
 		convertedResource, err := s.convertResourceFormat(tempResource, acceptFormat)
 		if err != nil {
-			return nil, "", err
+			return nil, "", nil, err
 		}
 
 		// Return converted data as stream
@@ -260,10 +346,60 @@ func (s *StorageService) StreamResource(ctx context.Context, id string, acceptFo
 			data:   convertedResource.GetData(),
 			offset: 0,
 		}
-		return convertedReader, convertedResource.GetContentType(), nil
+		return convertedReader, convertedResource.GetContentType(), metadata.Tags, nil
 	}
 
-	return reader, metadata.ContentType, nil
+	return reader, metadata.ContentType, metadata.Tags, nil
+}
+
+// OpenResourceContent opens a resource's stored content, unconverted, for random access, so a
+// byte range of a large resource can be served without reading the rest of it. Repositories
+// without random access have the content read into memory instead.
+func (s *StorageService) OpenResourceContent(ctx context.Context, id string) (io.ReadSeekCloser, *domain.ResourceMetadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if id == "" {
+		return nil, nil, domain.ErrInvalidID.WithOperation("OpenResourceContent")
+	}
+	if err := s.checkResourceAccount(ctx, id, "OpenResourceContent"); err != nil {
+		return nil, nil, err
+	}
+
+	if seekable, ok := s.repo.(domain.SeekableResourceRepository); ok {
+		content, metadata, err := seekable.OpenContent(ctx, id)
+		if err != nil {
+			if domain.IsResourceNotFound(err) {
+				return nil, nil, domain.ErrResourceNotFound.WithOperation("OpenResourceContent").WithContext("id", id)
+			}
+			return nil, nil, domain.WrapStorageError(err, "STREAM_RETRIEVE_FAILED", "failed to open resource content").WithOperation("OpenResourceContent")
+		}
+		return content, metadata, nil
+	}
+
+	reader, metadata, err := s.repo.RetrieveStream(ctx, id)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, nil, domain.ErrResourceNotFound.WithOperation("OpenResourceContent").WithContext("id", id)
+		}
+		return nil, nil, domain.WrapStorageError(err, "STREAM_RETRIEVE_FAILED", "failed to retrieve resource stream").WithOperation("OpenResourceContent")
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, domain.WrapStorageError(err, "STREAM_READ_FAILED", "failed to read resource content").WithOperation("OpenResourceContent")
+	}
+	return nopSeekCloser{bytes.NewReader(data)}, metadata, nil
+}
+
+// nopSeekCloser gives an in-memory reader a no-op Close
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
 }
 
 // StoreResourceStream stores a resource from a stream with efficient memory usage
@@ -272,8 +408,8 @@ func (s *StorageService) StoreResourceStream(ctx context.Context, id string, rea
 	defer s.mu.Unlock()
 
 	// Validate input
-	if id == "" {
-		return nil, domain.ErrInvalidID.WithOperation("StoreResourceStream")
+	if err := s.validateResourceID(id, "StoreResourceStream"); err != nil {
+		return nil, err
 	}
 	if reader == nil {
 		return nil, domain.ErrInvalidResource.WithOperation("StoreResourceStream").WithContext("reason", "reader is nil")
@@ -293,8 +429,49 @@ func (s *StorageService) StoreResourceStream(ctx context.Context, id string, rea
 		return nil, domain.WrapStorageError(err, "EXISTENCE_CHECK_FAILED", "failed to check resource existence").WithOperation("StoreResourceStream")
 	}
 
+	// Read the previous size from metadata so the stored bytes total stays accurate without loading content
+	var previousSize int
+	if exists {
+		if stream, previous, err := s.repo.RetrieveStream(ctx, id); err == nil {
+			stream.Close()
+			previousSize = int(previous.Size)
+		}
+	}
+
+	// Refuse uploads that would take the account past its storage quota: at once when the
+	// size is declared, otherwise as soon as the upload passes what the account has left
+	charge, err := s.storageChargeFor(ctx, id, "StoreResourceStream")
+	if err != nil {
+		return nil, err
+	}
+	if size > 0 {
+		if err := s.checkStorageQuota(charge, size, "StoreResourceStream"); err != nil {
+			return nil, err
+		}
+	}
+	quota := limitStorageStream(reader, charge, "StoreResourceStream")
+	if quota != nil {
+		reader = quota
+	}
+
+	// Count the triples of RDF uploads as they stream in
+	var limited *graphLimitReader
+	if s.graphSizeLimited(normalizedContentType) {
+		limited = s.limitGraphStream(reader, normalizedContentType)
+		reader = limited
+	}
+
 	// Store using streaming repository
-	if err := s.repo.StoreStream(ctx, id, reader, normalizedContentType, size); err != nil {
+	err = s.repo.StoreStream(ctx, id, reader, normalizedContentType, size)
+	if limited != nil {
+		if limitErr := graphTooLarge(limited.finish(err), "StoreResourceStream"); limitErr != nil {
+			return nil, limitErr
+		}
+	}
+	if quotaErr := quota.exceeded(); quotaErr != nil {
+		return nil, quotaErr
+	}
+	if err != nil {
 		return nil, domain.WrapStorageError(err, "STREAM_STORE_FAILED", "failed to store resource stream").WithOperation("StoreResourceStream")
 	}
 
@@ -332,11 +509,18 @@ func (s *StorageService) StoreResourceStream(ctx context.Context, id string, rea
 		return nil, domain.WrapStorageError(err, "EVENT_COMMIT_FAILED", "failed to commit events").WithOperation("StoreResourceStream")
 	}
 
+	metrics.ObserveResourceUploadSize(normalizedContentType, int64(resource.GetSize()))
+	recordStoredResource(exists, previousSize, resource.GetSize())
+
 	// Log successful event processing
 	if len(envelopes) > 0 {
 		fmt.Printf("Successfully processed %d events for streamed resource %s\n", len(envelopes), id)
 	}
 
+	s.indexResource(ctx, resource)
+	s.recordResourceVersion(ctx, resource)
+	s.recordStorageUsage(ctx, charge, id, int64(resource.GetSize()))
+
 	return resource, nil
 }
 
@@ -357,6 +541,61 @@ func (s *StorageService) ResourceExists(ctx context.Context, id string) (bool, e
 	return exists, nil
 }
 
+// MaxExistenceBatch is the most resources one ResourcesExist call may check
+const MaxExistenceBatch = 1000
+
+// ResourcesExist reports which of the resources exist, checking them in one repository call
+// when the repository supports it. Every requested ID appears in the result.
+func (s *StorageService) ResourcesExist(ctx context.Context, ids []string) (map[string]bool, error) {
+	if len(ids) > MaxExistenceBatch {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("cannot check more than %d resources at once, got %d", MaxExistenceBatch, len(ids)),
+			domain.ErrInvalidFormat.Code,
+			"too many resources to check",
+		).WithOperation("ResourcesExist")
+	}
+
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, domain.ErrInvalidID.WithOperation("ResourcesExist")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if batch, ok := s.repo.(domain.BatchExistenceRepository); ok {
+		exists, err := batch.ExistsBatch(ctx, unique)
+		if err != nil {
+			return nil, domain.WrapStorageError(err, "EXISTENCE_CHECK_FAILED", "failed to check resource existence").WithOperation("ResourcesExist")
+		}
+		// A repository may leave out resources it does not have
+		for _, id := range unique {
+			if _, ok := exists[id]; !ok {
+				exists[id] = false
+			}
+		}
+		return exists, nil
+	}
+
+	exists := make(map[string]bool, len(unique))
+	for _, id := range unique {
+		found, err := s.repo.Exists(ctx, id)
+		if err != nil {
+			return nil, domain.WrapStorageError(err, "EXISTENCE_CHECK_FAILED", "failed to check resource existence").
+				WithOperation("ResourcesExist").WithContext("id", id)
+		}
+		exists[id] = found
+	}
+	return exists, nil
+}
+
 // convertResourceFormat converts a resource to the requested format
 func (s *StorageService) convertResourceFormat(resource domain.Resource, targetFormat string) (domain.Resource, error) {
 	normalizedTargetFormat := s.normalizeContentType(targetFormat)
@@ -389,6 +628,14 @@ func (s *StorageService) convertResourceFormat(resource domain.Resource, targetF
 	return convertedResource, nil
 }
 
+// recordStoredResource updates the server-wide storage totals after a resource is stored
+func recordStoredResource(existed bool, previousSize, size int) {
+	if !existed {
+		metrics.StoredResources.Inc()
+	}
+	metrics.StoredBytes.Add(int64(size - previousSize))
+}
+
 // normalizeContentType normalizes content type strings
 func (s *StorageService) normalizeContentType(contentType string) string {
 	contentType = strings.ToLower(strings.TrimSpace(contentType))
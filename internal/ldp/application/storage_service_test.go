@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 )
@@ -18,7 +19,7 @@ import (
 // Mock implementations for testing
 
 type mockRepository struct {
-	resources map[string]*domain.Resource
+	resources map[string]domain.Resource
 	mu        sync.RWMutex
 	storeErr  error
 	getErr    error
@@ -28,11 +29,11 @@ type mockRepository struct {
 
 func newMockRepository() *mockRepository {
 	return &mockRepository{
-		resources: make(map[string]*domain.Resource),
+		resources: make(map[string]domain.Resource),
 	}
 }
 
-func (m *mockRepository) Store(ctx context.Context, resource *domain.Resource) error {
+func (m *mockRepository) Store(ctx context.Context, resource domain.Resource) error {
 	if m.storeErr != nil {
 		return m.storeErr
 	}
@@ -42,7 +43,7 @@ func (m *mockRepository) Store(ctx context.Context, resource *domain.Resource) e
 	return nil
 }
 
-func (m *mockRepository) Retrieve(ctx context.Context, id string) (*domain.Resource, error) {
+func (m *mockRepository) Retrieve(ctx context.Context, id string) (domain.Resource, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
@@ -88,7 +89,7 @@ func (m *mockRepository) StoreStream(ctx context.Context, id string, reader io.R
 	}
 
 	// Create resource and store it
-	resource := domain.NewResource(id, contentType, data)
+	resource := domain.NewResource(context.Background(), id, contentType, data)
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.resources[id] = resource
@@ -615,6 +616,43 @@ func TestStorageService_StoreResourceStream(t *testing.T) {
 	}
 }
 
+func TestStorageService_TracksStoredTotals(t *testing.T) {
+	repo := newMockRepository()
+	converter := newMockConverter()
+	unitOfWorkFactory := createMockUnitOfWorkFactory()
+
+	service := NewStorageService(repo, converter, unitOfWorkFactory)
+	ctx := context.Background()
+
+	resources := metrics.StoredResources.Value()
+	storedBytes := metrics.StoredBytes.Value()
+
+	if _, err := service.StoreResource(ctx, "totals", []byte("1234"), "text/plain"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := service.StoreResource(ctx, "totals", []byte("123456"), "text/plain"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := metrics.StoredResources.Value() - resources; got != 1 {
+		t.Errorf("Expected 1 stored resource after create and update, got %d", got)
+	}
+	if got := metrics.StoredBytes.Value() - storedBytes; got != 6 {
+		t.Errorf("Expected 6 stored bytes after update, got %d", got)
+	}
+
+	if err := service.DeleteResource(ctx, "totals"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := metrics.StoredResources.Value() - resources; got != 0 {
+		t.Errorf("Expected no stored resources after delete, got %d", got)
+	}
+	if got := metrics.StoredBytes.Value() - storedBytes; got != 0 {
+		t.Errorf("Expected no stored bytes after delete, got %d", got)
+	}
+}
+
 func TestStorageService_ResourceExists(t *testing.T) {
 	repo := newMockRepository()
 	converter := newMockConverter()
@@ -652,6 +690,35 @@ func TestStorageService_ResourceExists(t *testing.T) {
 	}
 }
 
+func TestStorageService_ResourcesExist(t *testing.T) {
+	repo := newMockRepository()
+	repo.resources["a"] = domain.NewResource(context.Background(), "a", "text/plain", []byte("a"))
+	repo.resources["b"] = domain.NewResource(context.Background(), "b", "text/plain", []byte("b"))
+	service := NewStorageService(repo, newMockConverter(), createMockUnitOfWorkFactory())
+	ctx := context.Background()
+
+	exists, err := service.ResourcesExist(ctx, []string{"a", "missing", "b", "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]bool{"a": true, "b": true, "missing": false}
+	if len(exists) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, exists)
+	}
+	for id, found := range want {
+		if exists[id] != found {
+			t.Errorf("Expected %s to exist = %v, got %v", id, found, exists[id])
+		}
+	}
+
+	if _, err := service.ResourcesExist(ctx, []string{"a", ""}); err == nil {
+		t.Error("Expected error for an empty ID")
+	}
+	if _, err := service.ResourcesExist(ctx, make([]string, MaxExistenceBatch+1)); err == nil {
+		t.Error("Expected error for too many IDs")
+	}
+}
+
 func TestStorageService_ConcurrentAccess(t *testing.T) {
 	repo := newMockRepository()
 	converter := newMockConverter()
@@ -797,7 +864,7 @@ func BenchmarkStorageService_RetrieveResource(b *testing.B) {
 	testData := []byte(`{"@context": {}, "@id": "benchmark-test"}`)
 	for i := 0; i < 1000; i++ {
 		resourceID := fmt.Sprintf("benchmark-resource-%d", i)
-		resource := domain.NewResource(resourceID, "application/ld+json", testData)
+		resource := domain.NewResource(context.Background(), resourceID, "application/ld+json", testData)
 		repo.resources[resourceID] = resource
 	}
 
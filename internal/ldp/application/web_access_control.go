@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// rootContainerID is the container every pod hangs from; resources and containers outside
+// any other container inherit its ACL
+const rootContainerID = "/"
+
+// AgentResolver finds the WebID an authenticated user acts as
+type AgentResolver interface {
+	// WebIDForUser returns the user's WebID, or an empty string when the user is unknown
+	WebIDForUser(ctx context.Context, userID string) (string, error)
+}
+
+// AccessDecision is the outcome of checking a request against Web Access Control: the modes
+// the request needs, those its agent and the public hold, and the ACL they come from
+type AccessDecision struct {
+	// Agent is the WebID the request was made as; empty when it is unauthenticated
+	Agent    string               `json:"agent,omitempty"`
+	Required domain.AccessMode    `json:"required"`
+	Granted  domain.AccessMode    `json:"granted"`
+	Public   domain.AccessMode    `json:"public"`
+	ACL      *domain.EffectiveACL `json:"acl"`
+}
+
+// Authenticated reports whether the request was made as an agent with a WebID
+func (d *AccessDecision) Authenticated() bool {
+	return d.Agent != ""
+}
+
+// Allowed reports whether the agent holds every mode the request needs
+func (d *AccessDecision) Allowed() bool {
+	return d.Granted.Covers(d.Required)
+}
+
+// WebAccessControl decides access to resources and containers from their .acl resources. A
+// resource without an ACL of its own inherits the acl:default authorizations of the nearest
+// container above it that has one, and the .acl resources themselves need Control on the
+// resource they belong to.
+type WebAccessControl struct {
+	source        domain.ACLSource
+	containerRepo domain.ContainerRepository
+	agents        AgentResolver
+}
+
+// NewWebAccessControl creates a new WebAccessControl reading ACLs from source
+func NewWebAccessControl(source domain.ACLSource, containerRepo domain.ContainerRepository) *WebAccessControl {
+	return &WebAccessControl{
+		source:        source,
+		containerRepo: containerRepo,
+	}
+}
+
+// SetAgentResolver sets the lookup from user IDs to WebIDs for callers identified by user ID.
+// Callers already identified by an http(s) WebID, and every caller when there is no resolver,
+// are taken as that WebID.
+func (w *WebAccessControl) SetAgentResolver(resolver AgentResolver) {
+	w.agents = resolver
+}
+
+// Authorize decides whether a user may access a resource or container in the required modes.
+// An empty user ID is an unauthenticated request, and so is a user without a WebID.
+func (w *WebAccessControl) Authorize(ctx context.Context, userID, resourceID string, required domain.AccessMode) (*AccessDecision, error) {
+	if resourceID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("resource ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"resource ID cannot be empty",
+		).WithOperation("Authorize")
+	}
+
+	agent, err := w.agent(ctx, userID)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to resolve agent WebID",
+		).WithOperation("Authorize").WithContext("userID", userID)
+	}
+
+	// An ACL is read and changed with Control on the resource it belongs to
+	governedID := resourceID
+	if subjectID, ok := domain.ACLSubjectID(resourceID); ok {
+		governedID = subjectID
+		required = domain.AccessMode{Control: true}
+	}
+
+	ancestors, err := w.ancestors(ctx, governedID)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to resolve containers above resource",
+		).WithOperation("Authorize").WithContext("resourceID", governedID)
+	}
+
+	effective, err := domain.ResolveEffectiveACL(ctx, w.source, governedID, ancestors)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to resolve effective ACL",
+		).WithOperation("Authorize").WithContext("resourceID", governedID)
+	}
+
+	return &AccessDecision{
+		Agent:    agent,
+		Required: required,
+		Granted:  effective.AccessFor(agent),
+		Public:   effective.AccessFor(""),
+		ACL:      effective,
+	}, nil
+}
+
+// CanReadContainer reports whether the agent named on ctx by domain.WithAgent holds Read on a
+// container. Errors deciding access deny it.
+func (w *WebAccessControl) CanReadContainer(ctx context.Context, containerID string) bool {
+	decision, err := w.Authorize(ctx, domain.AgentFromContext(ctx), containerID, domain.AccessMode{Read: true})
+	return err == nil && decision.Allowed()
+}
+
+// CanWriteContainer reports whether the agent named on ctx by domain.WithAgent holds Write on a
+// container. Errors deciding access deny it.
+func (w *WebAccessControl) CanWriteContainer(ctx context.Context, containerID string) bool {
+	decision, err := w.Authorize(ctx, domain.AgentFromContext(ctx), containerID, domain.AccessMode{Write: true})
+	return err == nil && decision.Allowed()
+}
+
+// agent returns the WebID of the user a request is made as
+func (w *WebAccessControl) agent(ctx context.Context, userID string) (string, error) {
+	if userID == "" || w.agents == nil || isWebID(userID) {
+		return userID, nil
+	}
+	return w.agents.WebIDForUser(ctx, userID)
+}
+
+// isWebID reports whether an identity is already a WebID: an absolute http(s) URI
+func isWebID(identity string) bool {
+	parsed, err := url.Parse(identity)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// ancestors returns the containers above a resource or container, nearest first, ending with
+// the root container. A resource's containers are those of the first container holding it.
+func (w *WebAccessControl) ancestors(ctx context.Context, resourceID string) ([]string, error) {
+	if resourceID == rootContainerID {
+		return nil, nil
+	}
+
+	var path []string
+	isContainer, err := w.containerRepo.ContainerExists(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if isContainer {
+		path, err = w.containerRepo.GetPath(ctx, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		// The path ends with the container itself
+		if len(path) > 0 {
+			path = path[:len(path)-1]
+		}
+	} else if holders, ok := w.containerRepo.(domain.MemberContainerSource); ok {
+		containerIDs, err := holders.ListMemberContainers(ctx, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		if len(containerIDs) > 0 {
+			path, err = w.containerRepo.GetPath(ctx, containerIDs[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ancestors := make([]string, 0, len(path)+1)
+	for i := len(path) - 1; i >= 0; i-- {
+		ancestors = append(ancestors, path[i])
+	}
+	if len(ancestors) == 0 || ancestors[len(ancestors)-1] != rootContainerID {
+		ancestors = append(ancestors, rootContainerID)
+	}
+	return ancestors, nil
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryACLSource holds ACLs by the resource they belong to
+type memoryACLSource map[string]*domain.ACL
+
+func (m memoryACLSource) GetACL(ctx context.Context, resourceID string) (*domain.ACL, bool, error) {
+	acl, ok := m[resourceID]
+	return acl, ok, nil
+}
+
+// webIDs resolves users to WebIDs from a fixed table
+type webIDs map[string]string
+
+func (w webIDs) WebIDForUser(ctx context.Context, userID string) (string, error) {
+	return w[userID], nil
+}
+
+func TestWebAccessControl_Authorize(t *testing.T) {
+	ctx := context.Background()
+	const alice = "https://alice.example/profile#me"
+	const bob = "https://bob.example/profile#me"
+
+	mockRepo := &TestMockContainerRepository{}
+	repo := &memberContainerRepository{
+		TestMockContainerRepository: mockRepo,
+		holders:                     map[string][]string{"beach.jpg": {"photos"}},
+	}
+	mockRepo.On("ContainerExists", ctx, "photos").Return(true, nil)
+	mockRepo.On("ContainerExists", ctx, "beach.jpg").Return(false, nil)
+	mockRepo.On("GetPath", ctx, "photos").Return([]string{"/", "alice", "photos"}, nil)
+
+	// alice's pod grants her everything below it and the public read access to photos
+	source := memoryACLSource{
+		"alice": {ResourceID: "alice", Authorizations: []domain.Authorization{
+			{Agents: []string{alice}, Default: []string{"alice"}, Modes: []string{domain.ACLModeRead, domain.ACLModeWrite, domain.ACLModeControl}},
+			{AgentClasses: []string{domain.FOAFAgent}, Default: []string{"alice"}, Modes: []string{domain.ACLModeRead}},
+			{AgentClasses: []string{domain.ACLAuthenticatedAgent}, Default: []string{"alice"}, Modes: []string{domain.ACLModeAppend}},
+		}},
+	}
+
+	accessControl := NewWebAccessControl(source, repo)
+	accessControl.SetAgentResolver(webIDs{"alice-id": alice, "bob-id": bob})
+
+	read := domain.AccessMode{Read: true}
+	write := domain.AccessMode{Write: true}
+	tests := []struct {
+		name          string
+		userID        string
+		resourceID    string
+		required      domain.AccessMode
+		allowed       bool
+		authenticated bool
+		granted       domain.AccessMode
+	}{
+		{"owner writes a resource in a nested container", "alice-id", "beach.jpg", write, true, true, domain.AccessMode{Read: true, Append: true, Write: true, Control: true}},
+		{"a WebID needs no resolving", alice, "beach.jpg", write, true, true, domain.AccessMode{Read: true, Append: true, Write: true, Control: true}},
+		{"public reads through acl:default", "", "beach.jpg", read, true, false, domain.AccessMode{Read: true}},
+		{"unauthenticated write is refused", "", "photos", write, false, false, domain.AccessMode{Read: true}},
+		{"authenticated agent may only append", "bob-id", "photos", write, false, true, domain.AccessMode{Read: true, Append: true}},
+		{"unknown user is unauthenticated", "mallory-id", "photos", read, true, false, domain.AccessMode{Read: true}},
+		{"the ACL needs Control", "bob-id", "photos.acl", read, false, true, domain.AccessMode{Read: true, Append: true}},
+		{"the owner controls the ACL", "alice-id", "photos.acl", read, true, true, domain.AccessMode{Read: true, Append: true, Write: true, Control: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := accessControl.Authorize(ctx, tt.userID, tt.resourceID, tt.required)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, decision.Allowed())
+			assert.Equal(t, tt.authenticated, decision.Authenticated())
+			assert.Equal(t, tt.granted, decision.Granted)
+			assert.Equal(t, domain.AccessMode{Read: true}, decision.Public)
+			assert.Equal(t, "alice", decision.ACL.ACLResourceID)
+			assert.True(t, decision.ACL.Inherited)
+		})
+	}
+}
+
+func TestWebAccessControl_NoACLGrantsNothing(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &TestMockContainerRepository{}
+	mockRepo.On("ContainerExists", ctx, "orphan").Return(false, nil)
+
+	// Without an agent resolver the user ID is the WebID
+	accessControl := NewWebAccessControl(memoryACLSource{}, mockRepo)
+	decision, err := accessControl.Authorize(ctx, "https://alice.example/profile#me", "orphan", domain.AccessMode{Read: true})
+	require.NoError(t, err)
+	assert.True(t, decision.Authenticated())
+	assert.False(t, decision.Allowed())
+	assert.True(t, decision.Granted.IsZero())
+}
+
+func TestWebAccessControl_CanReadContainer(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	mockRepo := &TestMockContainerRepository{}
+	mockRepo.On("ContainerExists", mock.Anything, "private").Return(true, nil)
+	mockRepo.On("GetPath", mock.Anything, "private").Return([]string{"alice", "private"}, nil)
+
+	source := memoryACLSource{
+		"private": {ResourceID: "private", Authorizations: []domain.Authorization{
+			{Agents: []string{alice}, AccessTo: []string{"private"}, Modes: []string{domain.ACLModeRead}},
+		}},
+	}
+	accessControl := NewWebAccessControl(source, mockRepo)
+
+	ctx := context.Background()
+	assert.True(t, accessControl.CanReadContainer(domain.WithAgent(ctx, alice), "private"))
+	assert.False(t, accessControl.CanReadContainer(domain.WithAgent(ctx, "https://bob.example/profile#me"), "private"))
+	assert.False(t, accessControl.CanReadContainer(ctx, "private"), "unauthenticated callers read only what the public may")
+}
+
+func TestWebAccessControl_CanWriteContainer(t *testing.T) {
+	const alice = "https://alice.example/profile#me"
+	const bob = "https://bob.example/profile#me"
+	mockRepo := &TestMockContainerRepository{}
+	mockRepo.On("ContainerExists", mock.Anything, "shared").Return(true, nil)
+	mockRepo.On("GetPath", mock.Anything, "shared").Return([]string{"alice", "shared"}, nil)
+
+	source := memoryACLSource{
+		"shared": {ResourceID: "shared", Authorizations: []domain.Authorization{
+			{Agents: []string{alice}, AccessTo: []string{"shared"}, Modes: []string{domain.ACLModeWrite}},
+			{Agents: []string{bob}, AccessTo: []string{"shared"}, Modes: []string{domain.ACLModeRead}},
+		}},
+	}
+	accessControl := NewWebAccessControl(source, mockRepo)
+
+	ctx := context.Background()
+	assert.True(t, accessControl.CanWriteContainer(domain.WithAgent(ctx, alice), "shared"))
+	assert.False(t, accessControl.CanWriteContainer(domain.WithAgent(ctx, bob), "shared"))
+	assert.False(t, accessControl.CanWriteContainer(ctx, "shared"))
+}
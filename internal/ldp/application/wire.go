@@ -2,7 +2,9 @@ package application
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"github.com/akeemphilbert/goro/internal/ldp/infrastructure"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
@@ -16,6 +18,17 @@ var ProviderSet = wire.NewSet(
 	NewContainerServiceProvider,
 	NewEventHandlerRegistrarProvider,
 	NewInitializationServiceProvider,
+	NewReadAuditorProvider,
+	NewEventRetryProvider,
+	NewResourceAccessTrackerProvider,
+	NewOperationGateProvider,
+	NewSolidNotificationServiceProvider,
+	NewContainerChangeHubProvider,
+	NewEventLogExporter,
+	NewRetentionSweeperProvider,
+	NewUploadServiceProvider,
+	NewWebAccessControlProvider,
+	NewEventMirrorProvider,
 )
 
 // NewStorageServiceProvider creates a StorageService with all dependencies and registers event handlers
@@ -24,12 +37,60 @@ func NewStorageServiceProvider(
 	converter domain.FormatConverter,
 	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
 	eventDispatcher pericarpdomain.EventDispatcher,
+	searchIndex domain.SearchIndex,
+	config *conf.Container,
+	eventRetry *EventRetry,
+	containerRepo domain.ContainerRepository,
+	storageUsage domain.StorageUsageStore,
+	identifierIndex domain.IdentifierIndex,
+	quotaPolicy StorageQuotaPolicy,
+	versionStore domain.ResourceVersionStore,
 ) (*StorageService, error) {
 	// Create the storage service
 	service := NewStorageService(repo, converter, unitOfWorkFactory)
+	if searchIndex != nil {
+		service.SetSearchIndex(searchIndex)
+	}
+
+	// Bound Dublin Core fields set on resources
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	service.SetDublinCoreLimits(dublinCoreLimits(config.DublinCore))
+	service.SetRejectTakenResourceNames(config.ResourceNameCollision == conf.ResourceNameCollisionReject)
+	if containerRepo != nil {
+		service.SetContainerNameChecker(containerRepo)
+	}
+	service.SetResourcePatcher(domain.SPARQLUpdateMediaType, infrastructure.NewSPARQLUpdateProcessor())
+	service.SetResourcePatcher(domain.N3PatchMediaType, infrastructure.NewN3PatchProcessor())
+	service.SetTripleMatcher(infrastructure.NewTriplePatternMatcher())
+	service.SetGraphDiffer(infrastructure.NewRDFGraphDiffer())
+	if versionStore != nil {
+		service.SetResourceVersionStore(versionStore)
+	}
+	service.SetGraphSizeLimit(infrastructure.NewRDFTripleCounter(), config.MaxTriples)
+
+	// Check written IDs against the configured URI-safe pattern, in the service and the repository
+	ids, err := domain.NewIDValidator(config.IDPattern)
+	if err != nil {
+		return nil, err
+	}
+	service.SetIDValidator(ids)
+	if setter, ok := repo.(idValidatorSetter); ok {
+		setter.SetIDValidator(ids)
+	}
+	// Writes are charged to the account owning the pod they land in, against its quota
+	service.SetStorageQuota(storageUsage, quotaPolicy)
+	if containerRepo != nil {
+		service.SetPodAccounts(containerRepo)
+	}
+	// Identifiers are indexed from the start; uniqueness applies once an account policy is set
+	service.SetIdentifierIndex(identifierIndex, nil)
 
 	// Register event handlers to update repository after events are committed
 	registrar := NewEventHandlerRegistrar(eventDispatcher)
+	registrar.SetEventRetry(eventRetry)
 	if err := registrar.RegisterAllHandlers(repo); err != nil {
 		return nil, fmt.Errorf("failed to register event handlers: %w", err)
 	}
@@ -43,6 +104,12 @@ func NewContainerServiceProvider(
 	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
 	eventDispatcher pericarpdomain.EventDispatcher,
 	rdfConverter *infrastructure.ContainerRDFConverter,
+	config *conf.Container,
+	searchIndex domain.SearchIndex,
+	eventRetry *EventRetry,
+	resourceRepo domain.StreamingResourceRepository,
+	identifierIndex domain.IdentifierIndex,
+	accessControl *WebAccessControl,
 ) (*ContainerService, error) {
 	// Validate dependencies
 	if containerRepo == nil {
@@ -60,25 +127,368 @@ func NewContainerServiceProvider(
 
 	// Create the container service
 	service := NewContainerService(containerRepo, unitOfWorkFactory, rdfConverter)
+	service.SetRDFNormalizer(infrastructure.NewRDFCanonicalizer())
+	if searchIndex != nil {
+		service.SetSearchIndex(searchIndex)
+	}
+	// Pod search is refused unless Web Access Control can drop hits the caller cannot read, and
+	// moves check Write on the container a resource is moved into
+	if accessControl != nil {
+		service.SetSearchAuthorizer(accessControl)
+		service.SetWriteAuthorizer(accessControl)
+	}
+	if source, ok := containerRepo.(domain.MemberIndexSource); ok {
+		service.SetMemberIndex(source)
+	}
+	if resourceRepo != nil {
+		service.SetResourceRepository(resourceRepo)
+	}
+	if lister, ok := containerRepo.(domain.ContainerLister); ok {
+		service.SetContainerLister(lister)
+	}
+	// Let operators drop cached containers and resources after repairing data on disk
+	if cache, ok := containerRepo.(domain.CacheInvalidator); ok {
+		service.AddCacheInvalidator(cache)
+	}
+	if cache, ok := resourceRepo.(domain.CacheInvalidator); ok {
+		service.AddCacheInvalidator(cache)
+	}
+
+	// Derive missing timestamps from the backing store when the repository can report them
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	if source, ok := containerRepo.(domain.ContainerModTimeSource); ok && config.TimestampFallback == conf.TimestampFallbackMTime {
+		service.SetTimestampFallback(source)
+	}
+
+	// Check created container IDs against the configured URI-safe pattern
+	ids, err := domain.NewIDValidator(config.IDPattern)
+	if err != nil {
+		return nil, err
+	}
+	service.SetIDValidator(ids)
+	if setter, ok := containerRepo.(idValidatorSetter); ok {
+		setter.SetIDValidator(ids)
+	}
+
+	// Answer additions of members a container already holds as configured
+	service.SetRejectDuplicateMembers(config.DuplicateMember == conf.DuplicateMemberConflict)
+
+	// Keep members in the pod of their container unless cross-pod membership is allowed
+	service.SetRejectCrossPodMembers(config.CrossPodMembership == conf.CrossPodMembershipReject)
+
+	// Keep size aggregates current on writes, or compute them on the next stats read, as configured
+	service.SetEagerSizeAggregates(config.SizeAggregate == conf.SizeAggregateEager)
+
+	// Keep Direct and Indirect container membership resources pointing at something that exists
+	service.SetMembershipResourcePolicy(MembershipResourcePolicy{
+		Enforce:         config.MembershipResource == conf.MembershipResourceEnforce,
+		AllowedExternal: config.ExternalMembershipResources,
+	})
+
+	// Bound Dublin Core fields before they reach metadata and RDF output
+	service.SetDublinCoreLimits(dublinCoreLimits(config.DublinCore))
+	service.SetIdentifierIndex(identifierIndex, nil)
+
+	// Rewrite uploaded content with the configured transformers before it is stored
+	service.SetContentTransformPipeline(contentTransformPipeline(config.ContentTransformers))
+
+	// Redirect a moved container's old URI to its new one for the retention period
+	service.SetContainerForwarding(infrastructure.NewMemoryContainerForwarding(), time.Duration(config.MoveRetention))
+
+	// Answer lifecycle queries from recorded reads when last-accessed tracking is on
+	if store, ok := containerRepo.(domain.ResourceAccessStore); ok && config.AccessTracking.Enabled {
+		service.SetResourceAccessStore(store)
+	}
+
+	// Cap structure responses before they are serialized
+	service.SetStructureLimits(StructureLimits{
+		MaxNodes: config.StructureMaxNodes,
+		MaxBytes: config.StructureMaxBytes,
+	})
 
 	// Register container event handlers to update repository after events are committed
 	registrar := NewEventHandlerRegistrar(eventDispatcher)
+	registrar.SetEventRetry(eventRetry)
 	if err := registrar.RegisterContainerEventHandler(NewContainerEventHandler(containerRepo)); err != nil {
 		return nil, fmt.Errorf("failed to register container event handlers: %w", err)
 	}
 
+	// Drop resources deleted outside their containers from ldp:contains, when the membership
+	// index can say which containers list them
+	if holders, ok := containerRepo.(domain.MemberContainerSource); ok {
+		cleanup := NewContainmentCleanupHandler(containerRepo, holders, unitOfWorkFactory)
+		if err := registrar.RegisterContainmentCleanupHandler(cleanup); err != nil {
+			return nil, fmt.Errorf("failed to register containment cleanup handler: %w", err)
+		}
+	}
+
 	return service, nil
 }
 
+// dublinCoreLimits converts the configured Dublin Core field limits
+func dublinCoreLimits(config conf.DublinCore) domain.DublinCoreLimits {
+	return domain.DublinCoreLimits{
+		MaxTitleLength:       config.MaxTitleLength,
+		MaxDescriptionLength: config.MaxDescriptionLength,
+		MaxFieldLength:       config.MaxFieldLength,
+		Truncate:             config.Overflow == conf.DublinCoreOverflowTruncate,
+	}
+}
+
+// contentTransformPipeline builds the configured write-time content transformers, in order
+func contentTransformPipeline(names []string) *domain.ContentTransformPipeline {
+	pipeline := domain.NewContentTransformPipeline()
+	for _, name := range names {
+		switch name {
+		case conf.ContentTransformStripEXIF:
+			pipeline.Register(infrastructure.NewEXIFStripper())
+		case conf.ContentTransformMinifyJSON:
+			pipeline.Register(infrastructure.NewJSONMinifier())
+		}
+	}
+	return pipeline
+}
+
 // NewEventHandlerRegistrarProvider creates an event handler registrar
 func NewEventHandlerRegistrarProvider(eventDispatcher pericarpdomain.EventDispatcher) *EventHandlerRegistrar {
 	return NewEventHandlerRegistrar(eventDispatcher)
 }
 
+// NewEventRetryProvider creates the EventRetry shared by every registered event handler, so
+// events dead-lettered by any of them are listed and replayed in one place
+func NewEventRetryProvider(config *conf.Container) *EventRetry {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	retry := config.EventRetry
+
+	policies := make(map[string]EventRetryPolicy, len(retry.Handlers))
+	for name, policy := range retry.Handlers {
+		policies[name] = EventRetryPolicy{
+			MaxAttempts:    policy.MaxAttempts,
+			InitialBackoff: time.Duration(policy.InitialBackoff),
+			MaxBackoff:     time.Duration(policy.MaxBackoff),
+		}
+	}
+
+	return NewEventRetry(EventRetryPolicy{
+		MaxAttempts:    retry.MaxAttempts,
+		InitialBackoff: time.Duration(retry.InitialBackoff),
+		MaxBackoff:     time.Duration(retry.MaxBackoff),
+	}, policies, infrastructure.NewMemoryDeadLetterStore(retry.DeadLetterCapacity))
+}
+
+// NewResourceAccessTrackerProvider creates the tracker recording resource reads in the
+// membership index. It returns nil, which records nothing, unless last-accessed tracking is
+// enabled and the container repository can store reads.
+func NewResourceAccessTrackerProvider(config *conf.Container, containerRepo domain.ContainerRepository) *ResourceAccessTracker {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	tracking := config.AccessTracking
+	if !tracking.Enabled {
+		return nil
+	}
+
+	store, ok := containerRepo.(domain.ResourceAccessStore)
+	if !ok {
+		return nil
+	}
+
+	return NewResourceAccessTracker(store, time.Duration(tracking.Throttle), time.Duration(tracking.FlushInterval), tracking.MaxPending)
+}
+
+// NewRetentionSweeperProvider creates the sweeper expiring members of containers with a
+// retention policy, sweeping on the configured interval
+func NewRetentionSweeperProvider(config *conf.Container, containerService *ContainerService, storageService *StorageService) *RetentionSweeper {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+
+	return NewRetentionSweeper(containerService, storageService, time.Duration(config.Retention.SweepInterval))
+}
+
+// NewUploadServiceProvider creates the service receiving resumable uploads, removing sessions
+// past their TTL on the configured interval
+func NewUploadServiceProvider(config *conf.Container, storageService *StorageService, store domain.UploadStore) *UploadService {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+
+	service := NewUploadService(store, storageService, time.Duration(config.Uploads.TTL), time.Duration(config.Uploads.SweepInterval))
+	service.SetMaxSize(config.Uploads.MaxBytes)
+	return service
+}
+
+// NewOperationGateProvider creates the gate capping concurrent heavy operations per account
+func NewOperationGateProvider(config *conf.Container) *OperationGate {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	return NewOperationGate(config.HeavyOperations.MaxConcurrent, time.Duration(config.HeavyOperations.QueueTimeout))
+}
+
+// NewSolidNotificationServiceProvider creates the Solid Notifications service and subscribes
+// it to container events. It returns nil, which leaves the subscription endpoint unserved,
+// unless notifications are enabled. Subscribing needs Read on the topic when accessControl is
+// set.
+func NewSolidNotificationServiceProvider(
+	config *conf.Container,
+	containerRepo domain.ContainerRepository,
+	eventDispatcher pericarpdomain.EventDispatcher,
+	accessControl *WebAccessControl,
+) (*SolidNotificationService, error) {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	notifications := config.Notifications
+	if !notifications.Enabled {
+		return nil, nil
+	}
+
+	sender := infrastructure.NewWebhookNotificationSender(time.Duration(notifications.DeliveryTimeout))
+	sender.SetAllowPrivateTargets(notifications.AllowPrivateTargets)
+	service := NewSolidNotificationService(
+		infrastructure.NewMemoryNotificationChannelStore(),
+		sender,
+		containerRepo,
+		notifications.QueueSize,
+	)
+	if accessControl != nil {
+		service.SetReadAuthorizer(accessControl)
+	}
+
+	registrar := NewEventHandlerRegistrar(eventDispatcher)
+	if err := registrar.RegisterSolidNotificationService(service); err != nil {
+		return nil, fmt.Errorf("failed to register Solid notification service: %w", err)
+	}
+
+	return service, nil
+}
+
+// NewContainerChangeHubProvider creates the hub serving live container and resource change
+// subscriptions and subscribes it to their events. It returns nil, which leaves the
+// subscription endpoint unserved, unless live notifications are enabled.
+func NewContainerChangeHubProvider(
+	config *conf.Container,
+	containerRepo domain.ContainerRepository,
+	resourceRepo domain.StreamingResourceRepository,
+	eventDispatcher pericarpdomain.EventDispatcher,
+) (*ContainerChangeHub, error) {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	live := config.Notifications.Live
+	if !live.Enabled {
+		return nil, nil
+	}
+
+	hub := NewContainerChangeHub(containerRepo, live.BufferSize)
+	hub.SetResourceRepository(resourceRepo)
+	hub.SetReplaySize(live.ReplaySize)
+
+	registrar := NewEventHandlerRegistrar(eventDispatcher)
+	if err := registrar.RegisterContainerChangeHub(hub); err != nil {
+		return nil, fmt.Errorf("failed to register container change hub: %w", err)
+	}
+
+	return hub, nil
+}
+
+// NewEventMirrorProvider creates the mirror publishing committed events to the configured
+// message broker and subscribes it to them under the broker retry policy. With broker kind
+// "none" it publishes to a no-op publisher and is not subscribed, so events stay in-process.
+func NewEventMirrorProvider(
+	config *conf.Container,
+	eventDispatcher pericarpdomain.EventDispatcher,
+	eventRetry *EventRetry,
+) (*EventMirror, error) {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+	broker := config.Broker
+
+	var publisher domain.MessagePublisher = infrastructure.NewNoopMessagePublisher()
+	switch broker.Kind {
+	case conf.BrokerNATS:
+		nats, err := infrastructure.NewNATSMessagePublisher(broker.URL, time.Duration(broker.PublishTimeout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create NATS publisher: %w", err)
+		}
+		publisher = nats
+	}
+
+	mirror := NewEventMirror(publisher, broker.Topics, broker.DefaultTopic)
+	if broker.Kind == conf.BrokerNone {
+		return mirror, nil
+	}
+
+	registrar := NewEventHandlerRegistrar(eventDispatcher)
+	registrar.SetEventRetry(eventRetry)
+	if err := registrar.RegisterEventMirror(mirror); err != nil {
+		return nil, fmt.Errorf("failed to register event mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// NewWebAccessControlProvider creates the Web Access Control decision point. It returns nil,
+// which leaves LDP requests unchecked, unless web_access_control is enabled.
+func NewWebAccessControlProvider(
+	auth *conf.Auth,
+	source domain.ACLSource,
+	containerRepo domain.ContainerRepository,
+) *WebAccessControl {
+	if auth == nil || !auth.WebAccessControl {
+		return nil
+	}
+	return NewWebAccessControl(source, containerRepo)
+}
+
 // NewInitializationServiceProvider creates an InitializationService
 func NewInitializationServiceProvider(
 	containerRepo domain.ContainerRepository,
+	storageUsage domain.StorageUsageStore,
 	logger log.Logger,
 ) *InitializationService {
-	return NewInitializationService(containerRepo, logger)
+	service := NewInitializationService(containerRepo, logger)
+	service.SetStorageUsage(storageUsage)
+	return service
+}
+
+// NewReadAuditorProvider creates a ReadAuditor that writes read audit events to the audit log.
+// Reads are charged to the account owning the pod they are made in, and policy lets that
+// account opt in to auditing when it is disabled globally.
+func NewReadAuditorProvider(config *conf.Audit, policy ReadAuditPolicy, containerRepo domain.ContainerRepository) (*ReadAuditor, error) {
+	// Set defaults if config is nil
+	if config == nil {
+		config = &conf.Audit{}
+	}
+	config.SetDefaults()
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid audit configuration: %w", err)
+	}
+
+	projection := NewEventPersistenceHandlerWithConfig(EventPersistenceConfig{
+		EventLogPath:          config.LogPath,
+		EnableFilePersistence: true,
+	})
+
+	auditor := NewReadAuditor(config.ReadEventsEnabled, projection, policy)
+	if containerRepo != nil {
+		auditor.SetPodAccounts(containerRepo)
+	}
+	return auditor, nil
 }
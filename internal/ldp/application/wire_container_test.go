@@ -32,7 +32,7 @@ func TestNewContainerServiceProvider(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create event store and dispatcher
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	require.NoError(t, err)
 
 	eventStore, err := infrastructure.EventStoreProvider(db)
@@ -55,6 +55,12 @@ func TestNewContainerServiceProvider(t *testing.T) {
 			unitOfWorkFactory,
 			eventDispatcher,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		require.NoError(t, err, "Container service provider should create service successfully")
@@ -68,6 +74,12 @@ func TestNewContainerServiceProvider(t *testing.T) {
 			unitOfWorkFactory,
 			eventDispatcher,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		require.NoError(t, err, "Container service provider should register event handlers")
@@ -84,6 +96,12 @@ func TestNewContainerServiceProvider(t *testing.T) {
 			unitOfWorkFactory,
 			eventDispatcher,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 		assert.Error(t, err, "Should return error with nil container repository")
 
@@ -93,6 +111,12 @@ func TestNewContainerServiceProvider(t *testing.T) {
 			nil,
 			eventDispatcher,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 		assert.Error(t, err, "Should return error with nil unit of work factory")
 
@@ -102,6 +126,12 @@ func TestNewContainerServiceProvider(t *testing.T) {
 			unitOfWorkFactory,
 			nil,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 		assert.Error(t, err, "Should return error with nil event dispatcher")
 
@@ -111,6 +141,12 @@ func TestNewContainerServiceProvider(t *testing.T) {
 			unitOfWorkFactory,
 			eventDispatcher,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 		assert.Error(t, err, "Should return error with nil RDF converter")
 	})
@@ -154,7 +190,7 @@ func TestContainerServiceProviderIntegration(t *testing.T) {
 		containerRepo, err := infrastructure.NewFileSystemContainerRepository(basePath, indexer)
 		require.NoError(t, err)
 
-		db, err := infrastructure.DatabaseProvider()
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := infrastructure.EventStoreProvider(db)
@@ -175,6 +211,12 @@ func TestContainerServiceProviderIntegration(t *testing.T) {
 			unitOfWorkFactory,
 			eventDispatcher,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		require.NoError(t, err, "Full provider chain should work correctly")
@@ -192,7 +234,7 @@ func TestContainerServiceProviderIntegration(t *testing.T) {
 		containerRepo, err := infrastructure.NewFileSystemContainerRepository(basePath, indexer)
 		require.NoError(t, err)
 
-		db, err := infrastructure.DatabaseProvider()
+		db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := infrastructure.EventStoreProvider(db)
@@ -212,6 +254,12 @@ func TestContainerServiceProviderIntegration(t *testing.T) {
 			unitOfWorkFactory,
 			eventDispatcher,
 			rdfConverter,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		require.NoError(t, err)
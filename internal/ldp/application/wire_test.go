@@ -50,7 +50,7 @@ func TestWireProviderSet(t *testing.T) {
 	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
 		return nil // Mock implementation
 	}
-	service, err := NewStorageServiceProvider(repo, converter, unitOfWorkFactory, eventDispatcher)
+	service, err := NewStorageServiceProvider(repo, converter, unitOfWorkFactory, eventDispatcher, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("NewStorageServiceProvider returned error: %v", err)
 	}
@@ -0,0 +1,44 @@
+package domain
+
+import "context"
+
+// AccessMode is the set of Web Access Control modes a user effectively holds on a container:
+// Read lists and reads it, Append adds members, Write also changes and removes them, and
+// Control manages who else may access it
+type AccessMode struct {
+	Read    bool `json:"read"`
+	Append  bool `json:"append"`
+	Write   bool `json:"write"`
+	Control bool `json:"control"`
+}
+
+// IsZero reports whether the mode grants no access at all
+func (m AccessMode) IsZero() bool {
+	return m == AccessMode{}
+}
+
+// Covers reports whether the mode grants everything the required mode asks for
+func (m AccessMode) Covers(required AccessMode) bool {
+	return (!required.Read || m.Read) &&
+		(!required.Append || m.Append) &&
+		(!required.Write || m.Write) &&
+		(!required.Control || m.Control)
+}
+
+// agentKey is the context key of the agent a request is made as
+type agentKey struct{}
+
+// WithAgent returns a context naming the WebID a request is made as, for access checks made
+// below the transport; an empty agent leaves the request unauthenticated
+func WithAgent(ctx context.Context, agent string) context.Context {
+	if agent == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, agentKey{}, agent)
+}
+
+// AgentFromContext returns the agent named by WithAgent, or "" for unauthenticated requests
+func AgentFromContext(ctx context.Context) string {
+	agent, _ := ctx.Value(agentKey{}).(string)
+	return agent
+}
@@ -0,0 +1,13 @@
+package domain
+
+// AccountStatus is where an account stands in its deletion, as far as serving its resources goes
+type AccountStatus int
+
+const (
+	// AccountActive accounts serve their resources as usual
+	AccountActive AccountStatus = iota
+	// AccountDeleted accounts were deleted while their resources are still being purged
+	AccountDeleted
+	// AccountPurged accounts were deleted and their resources purged
+	AccountPurged
+)
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// Web Access Control vocabulary
+const (
+	// ACLNamespace is the namespace of the Web Access Control vocabulary
+	ACLNamespace = "http://www.w3.org/ns/auth/acl#"
+	// ACLModeRead allows reading a resource or listing a container
+	ACLModeRead = ACLNamespace + "Read"
+	// ACLModeAppend allows adding to a resource or container without removing anything
+	ACLModeAppend = ACLNamespace + "Append"
+	// ACLModeWrite allows any change, and includes Append
+	ACLModeWrite = ACLNamespace + "Write"
+	// ACLModeControl allows reading and changing the ACL itself
+	ACLModeControl = ACLNamespace + "Control"
+	// ACLAuthenticatedAgent is the agent class of every agent with a verified WebID
+	ACLAuthenticatedAgent = ACLNamespace + "AuthenticatedAgent"
+	// FOAFAgent is the agent class of everyone, authenticated or not
+	FOAFAgent = "http://xmlns.com/foaf/0.1/Agent"
+	// ACLResourceSuffix names the .acl resource holding a resource's or container's ACL
+	ACLResourceSuffix = ".acl"
+)
+
+// ACLResourceID returns the ID of the .acl resource holding a resource's ACL
+func ACLResourceID(resourceID string) string {
+	return resourceID + ACLResourceSuffix
+}
+
+// ACLSubjectID returns the resource an .acl resource holds the ACL of, reporting false when
+// the ID is not an .acl resource
+func ACLSubjectID(id string) (string, bool) {
+	subject := strings.TrimSuffix(id, ACLResourceSuffix)
+	return subject, subject != id && subject != ""
+}
+
+// Authorization is one acl:Authorization: the agents it names, the modes it grants them, and
+// the resources it applies to, either directly through acl:accessTo or, for a container's
+// contents, through acl:default
+type Authorization struct {
+	ID           string   `json:"id,omitempty"`
+	Agents       []string `json:"agents,omitempty"`
+	AgentClasses []string `json:"agentClasses,omitempty"`
+	AccessTo     []string `json:"accessTo,omitempty"`
+	Default      []string `json:"default,omitempty"`
+	Modes        []string `json:"modes"`
+}
+
+// AppliesTo reports whether the authorization grants anything to the agent. An empty agent is
+// an unauthenticated request, matched only through foaf:Agent.
+func (a Authorization) AppliesTo(agent string) bool {
+	for _, class := range a.AgentClasses {
+		if class == FOAFAgent || (class == ACLAuthenticatedAgent && agent != "") {
+			return true
+		}
+	}
+	if agent == "" {
+		return false
+	}
+	return hasString(a.Agents, agent)
+}
+
+// AccessMode returns the modes the authorization grants; Write includes Append
+func (a Authorization) AccessMode() AccessMode {
+	var mode AccessMode
+	for _, m := range a.Modes {
+		switch m {
+		case ACLModeRead:
+			mode.Read = true
+		case ACLModeAppend:
+			mode.Append = true
+		case ACLModeWrite:
+			mode.Write = true
+			mode.Append = true
+		case ACLModeControl:
+			mode.Control = true
+		}
+	}
+	return mode
+}
+
+// ACL is the access control list of one resource or container: the authorizations stated in
+// its .acl resource
+type ACL struct {
+	// ResourceID is the resource or container the ACL belongs to
+	ResourceID     string          `json:"resourceId"`
+	Authorizations []Authorization `json:"authorizations"`
+}
+
+// AccessToAuthorizations returns the authorizations naming the resource with acl:accessTo
+func (a *ACL) AccessToAuthorizations(resourceID string) []Authorization {
+	var matched []Authorization
+	for _, authorization := range a.Authorizations {
+		if hasString(authorization.AccessTo, resourceID) {
+			matched = append(matched, authorization)
+		}
+	}
+	return matched
+}
+
+// DefaultAuthorizations returns the authorizations naming the container with acl:default,
+// which are inherited by everything below it
+func (a *ACL) DefaultAuthorizations(containerID string) []Authorization {
+	var matched []Authorization
+	for _, authorization := range a.Authorizations {
+		if hasString(authorization.Default, containerID) {
+			matched = append(matched, authorization)
+		}
+	}
+	return matched
+}
+
+// ACLSource finds the ACL a resource or container has of its own
+type ACLSource interface {
+	// GetACL returns the resource's own ACL, reporting false when it has none
+	GetACL(ctx context.Context, resourceID string) (*ACL, bool, error)
+}
+
+// EffectiveACL is the set of authorizations that decides access to a resource, with the
+// resource whose ACL they come from
+type EffectiveACL struct {
+	// ResourceID is the resource access was resolved for
+	ResourceID string `json:"resourceId"`
+	// ACLResourceID is the resource or container whose ACL applies
+	ACLResourceID string `json:"aclResourceId"`
+	// Inherited is set when the ACL is an ancestor container's, applied through acl:default
+	Inherited      bool            `json:"inherited"`
+	Authorizations []Authorization `json:"authorizations"`
+}
+
+// AccessFor returns the modes the effective authorizations grant the agent; an empty agent is an
+// unauthenticated request
+func (e *EffectiveACL) AccessFor(agent string) AccessMode {
+	var mode AccessMode
+	for _, authorization := range e.Authorizations {
+		if !authorization.AppliesTo(agent) {
+			continue
+		}
+		granted := authorization.AccessMode()
+		mode.Read = mode.Read || granted.Read
+		mode.Append = mode.Append || granted.Append
+		mode.Write = mode.Write || granted.Write
+		mode.Control = mode.Control || granted.Control
+	}
+	return mode
+}
+
+// ResolveEffectiveACL finds the authorizations deciding access to a resource. A resource with an
+// ACL of its own is governed only by that ACL's acl:accessTo statements, even when they grant
+// nothing. Only a resource without one inherits, and then only from the nearest ancestor
+// container that has an ACL, through that ACL's acl:default statements; ACLs further up are not
+// consulted. ancestors lists the resource's containers nearest first. Without any ACL on the way
+// up, nothing is granted.
+func ResolveEffectiveACL(ctx context.Context, source ACLSource, resourceID string, ancestors []string) (*EffectiveACL, error) {
+	own, found, err := source.GetACL(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &EffectiveACL{
+			ResourceID:     resourceID,
+			ACLResourceID:  resourceID,
+			Authorizations: own.AccessToAuthorizations(resourceID),
+		}, nil
+	}
+
+	for _, ancestorID := range ancestors {
+		acl, found, err := source.GetACL(ctx, ancestorID)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return &EffectiveACL{
+				ResourceID:     resourceID,
+				ACLResourceID:  ancestorID,
+				Inherited:      true,
+				Authorizations: acl.DefaultAuthorizations(ancestorID),
+			}, nil
+		}
+	}
+
+	return &EffectiveACL{ResourceID: resourceID}, nil
+}
+
+// hasString reports whether values holds value
+func hasString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryACLSource holds ACLs by the resource they belong to
+type memoryACLSource map[string]*ACL
+
+func (m memoryACLSource) GetACL(ctx context.Context, resourceID string) (*ACL, bool, error) {
+	acl, ok := m[resourceID]
+	return acl, ok, nil
+}
+
+const (
+	alice = "https://alice.example/profile#me"
+	bob   = "https://bob.example/profile#me"
+)
+
+func TestResolveEffectiveACL(t *testing.T) {
+	ctx := context.Background()
+
+	// The pod grants its owner everything below it and the public read access to /pod/public;
+	// /pod/shared/report overrides the inherited rules with an ACL of its own that names bob
+	source := memoryACLSource{
+		"pod": {ResourceID: "pod", Authorizations: []Authorization{
+			{AccessTo: []string{"pod"}, Default: []string{"pod"}, Agents: []string{alice},
+				Modes: []string{ACLModeRead, ACLModeWrite, ACLModeControl}},
+		}},
+		"pod/public": {ResourceID: "pod/public", Authorizations: []Authorization{
+			{AccessTo: []string{"pod/public"}, Agents: []string{alice}, Modes: []string{ACLModeRead, ACLModeWrite, ACLModeControl}},
+			{Default: []string{"pod/public"}, AgentClasses: []string{FOAFAgent}, Modes: []string{ACLModeRead}},
+		}},
+		"pod/shared/report": {ResourceID: "pod/shared/report", Authorizations: []Authorization{
+			{AccessTo: []string{"pod/shared/report"}, Agents: []string{bob}, Modes: []string{ACLModeAppend}},
+			{Default: []string{"pod/shared/report"}, Agents: []string{alice}, Modes: []string{ACLModeRead}},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		resourceID   string
+		ancestors    []string
+		agent        string
+		expectedACL  string
+		inherited    bool
+		expectedMode AccessMode
+	}{
+		{name: "own accessTo on the pod", resourceID: "pod", agent: alice, expectedACL: "pod",
+			expectedMode: AccessMode{Read: true, Append: true, Write: true, Control: true}},
+		{name: "default inherited two levels down", resourceID: "pod/shared/notes/todo", ancestors: []string{"pod/shared/notes", "pod/shared", "pod"},
+			agent: alice, expectedACL: "pod", inherited: true, expectedMode: AccessMode{Read: true, Append: true, Write: true, Control: true}},
+		{name: "nearest ancestor ACL wins over the pod's", resourceID: "pod/public/photo", ancestors: []string{"pod/public", "pod"},
+			agent: alice, expectedACL: "pod/public", inherited: true, expectedMode: AccessMode{Read: true}},
+		{name: "public default reaches unauthenticated requests", resourceID: "pod/public/photo", ancestors: []string{"pod/public", "pod"},
+			expectedACL: "pod/public", inherited: true, expectedMode: AccessMode{Read: true}},
+		{name: "own ACL overrides inherited defaults", resourceID: "pod/shared/report", ancestors: []string{"pod/shared", "pod"},
+			agent: alice, expectedACL: "pod/shared/report", expectedMode: AccessMode{}},
+		{name: "own accessTo grants the agent it names", resourceID: "pod/shared/report", ancestors: []string{"pod/shared", "pod"},
+			agent: bob, expectedACL: "pod/shared/report", expectedMode: AccessMode{Append: true}},
+		{name: "no ACL on the way up grants nothing", resourceID: "other/doc", ancestors: []string{"other"},
+			agent: alice, expectedMode: AccessMode{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effective, err := ResolveEffectiveACL(ctx, source, tt.resourceID, tt.ancestors)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedACL, effective.ACLResourceID)
+			assert.Equal(t, tt.inherited, effective.Inherited)
+			assert.Equal(t, tt.expectedMode, effective.AccessFor(tt.agent))
+		})
+	}
+}
+
+func TestAuthorization_AppliesTo(t *testing.T) {
+	authenticated := Authorization{AgentClasses: []string{ACLAuthenticatedAgent}}
+	assert.True(t, authenticated.AppliesTo(bob))
+	assert.False(t, authenticated.AppliesTo(""))
+
+	named := Authorization{Agents: []string{alice}}
+	assert.True(t, named.AppliesTo(alice))
+	assert.False(t, named.AppliesTo(bob))
+}
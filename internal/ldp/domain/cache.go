@@ -0,0 +1,11 @@
+package domain
+
+// CacheInvalidator is implemented by components holding cached copies of resources or
+// containers, so operators can force a re-read after repairing data in the backing store
+type CacheInvalidator interface {
+	// InvalidateCached drops the cached entries of the given resource and container IDs and
+	// reports how many it dropped
+	InvalidateCached(ids ...string) int
+	// InvalidateAllCached drops every cached entry and reports how many it dropped
+	InvalidateAllCached() int
+}
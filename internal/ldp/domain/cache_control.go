@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CacheControlKey holds the Cache-Control directives sent when a container, or a resource,
+// is read. A container's value covers its own listing; members pick it up through the
+// container's inheritable metadata.
+const CacheControlKey = "cacheControl"
+
+// ValidateCacheControl checks that directives are a comma-separated list of Cache-Control
+// directives, each a token optionally followed by =value. The empty string is valid and
+// clears an override.
+func ValidateCacheControl(directives string) error {
+	if strings.TrimSpace(directives) == "" {
+		return nil
+	}
+	for _, directive := range strings.Split(directives, ",") {
+		directive = strings.TrimSpace(directive)
+		name, value, hasValue := strings.Cut(directive, "=")
+		if name == "" || !isCacheControlToken(name) {
+			return fmt.Errorf("invalid cache control directive %q", directive)
+		}
+		if hasValue && (value == "" || strings.ContainsAny(value, "\r\n,")) {
+			return fmt.Errorf("invalid value for cache control directive %q", name)
+		}
+	}
+	return nil
+}
+
+// isCacheControlToken reports whether name is a valid directive name
+func isCacheControlToken(name string) bool {
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`()<>@,;:\"/[]?={}`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetCacheControl sets the Cache-Control directives sent on the container's listing. The
+// empty string clears the override, so the configured default applies again.
+func (c *Container) SetCacheControl(directives string) {
+	directives = strings.TrimSpace(directives)
+	c.SetMetadata(CacheControlKey, directives)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		CacheControlKey: directives,
+		"updatedAt":     time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// CacheControlOverride returns the Cache-Control directives set in container or resource
+// metadata, reporting false when none are set
+func CacheControlOverride(metadata map[string]interface{}) (string, bool) {
+	directives, _ := metadata[CacheControlKey].(string)
+	directives = strings.TrimSpace(directives)
+	return directives, directives != ""
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCacheControl(t *testing.T) {
+	valid := []string{"", "no-cache", "public, max-age=86400", "private, max-age=60, must-revalidate", `no-cache="Set-Cookie"`}
+	for _, directives := range valid {
+		assert.NoError(t, ValidateCacheControl(directives), directives)
+	}
+
+	invalid := []string{"max-age=", "public,,max-age=60", "no cache", "max-age=60\r\nX-Injected: 1", "private;max-age=60"}
+	for _, directives := range invalid {
+		assert.Error(t, ValidateCacheControl(directives), directives)
+	}
+}
+
+func TestContainer_SetCacheControl(t *testing.T) {
+	ctx := context.Background()
+	container := NewContainer(ctx, "photos", "", BasicContainer)
+	container.MarkEventsAsCommitted()
+
+	container.SetCacheControl(" public, max-age=300 ")
+
+	directives, ok := CacheControlOverride(container.GetMetadata())
+	assert.True(t, ok)
+	assert.Equal(t, "public, max-age=300", directives)
+
+	events := container.UncommittedEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeContainerUpdated, events[0].(*EntityEvent).Type)
+
+	container.SetCacheControl("")
+	_, ok = CacheControlOverride(container.GetMetadata())
+	assert.False(t, ok, "an empty value clears the override")
+}
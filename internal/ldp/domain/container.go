@@ -2,7 +2,11 @@ package domain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,10 +16,14 @@ import (
 type ContainerType string
 
 const (
-	BasicContainer  ContainerType = "BasicContainer"
-	DirectContainer ContainerType = "DirectContainer"
+	BasicContainer    ContainerType = "BasicContainer"
+	DirectContainer   ContainerType = "DirectContainer"
+	IndirectContainer ContainerType = "IndirectContainer"
 )
 
+// ContainerVersionKey is the metadata key holding the container version bumped by Touch
+const ContainerVersionKey = "version"
+
 // String returns the string representation of the container type
 func (ct ContainerType) String() string {
 	return string(ct)
@@ -24,13 +32,19 @@ func (ct ContainerType) String() string {
 // IsValid checks if the container type is valid
 func (ct ContainerType) IsValid() bool {
 	switch ct {
-	case BasicContainer, DirectContainer:
+	case BasicContainer, DirectContainer, IndirectContainer:
 		return true
 	default:
 		return false
 	}
 }
 
+// HasMembershipTriples reports whether containers of this type assert membership triples about
+// a configured membership resource rather than containment triples about themselves
+func (ct ContainerType) HasMembershipTriples() bool {
+	return ct == DirectContainer || ct == IndirectContainer
+}
+
 // Container represents a container resource that can hold other resources
 type Container struct {
 	*BasicResource               // Inherits from BasicResource
@@ -84,11 +98,10 @@ func (c *Container) AddMember(ctx context.Context, resource Resource) error {
 		return fmt.Errorf("resource ID cannot be empty")
 	}
 
-	// Check for duplicates
-	for _, existingID := range c.Members {
-		if existingID == memberID {
-			return fmt.Errorf("member already exists")
-		}
+	// Adding a member the container already holds is a no-op, so concurrent identical
+	// additions neither duplicate the member nor emit a second event
+	if c.HasMember(memberID) {
+		return nil
 	}
 
 	// Add to members list
@@ -232,6 +245,132 @@ func (c *Container) GetDescription() string {
 	return ""
 }
 
+// SetLocalizedTitle sets a language-tagged container title
+func (c *Container) SetLocalizedTitle(language, title string) {
+	c.SetMetadata(localizedMetadataKey("title", language), title)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"title":     title,
+		"language":  language,
+		"updatedAt": time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// GetLocalizedTitles returns all language-tagged container titles
+func (c *Container) GetLocalizedTitles() []LangLiteral {
+	return LocalizedLiterals(c.GetMetadata(), "title")
+}
+
+// SetLocalizedDescription sets a language-tagged container description
+func (c *Container) SetLocalizedDescription(language, description string) {
+	c.SetMetadata(localizedMetadataKey("description", language), description)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"description": description,
+		"language":    language,
+		"updatedAt":   time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// GetLocalizedDescriptions returns all language-tagged container descriptions
+func (c *Container) GetLocalizedDescriptions() []LangLiteral {
+	return LocalizedLiterals(c.GetMetadata(), "description")
+}
+
+// SetRDFNormalization enables or disables canonical normalization of RDF written into the container.
+// Normalization is opt-in because it discards the client's original serialization.
+func (c *Container) SetRDFNormalization(enabled bool) {
+	c.SetMetadata("rdfNormalization", enabled)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"rdfNormalization": enabled,
+		"updatedAt":        time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// IsRDFNormalizationEnabled returns whether RDF written into the container is normalized
+func (c *Container) IsRDFNormalizationEnabled() bool {
+	return RDFNormalizationEnabled(c.GetMetadata())
+}
+
+// RDFNormalizationEnabled reports whether container metadata opts in to RDF normalization
+func RDFNormalizationEnabled(metadata map[string]interface{}) bool {
+	enabled, ok := metadata["rdfNormalization"].(bool)
+	return ok && enabled
+}
+
+// SetContentTransforms enables or disables write-time content transformers for resources
+// written into the container. Transforms apply unless a container opts out.
+func (c *Container) SetContentTransforms(enabled bool) {
+	c.SetMetadata("contentTransforms", enabled)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"contentTransforms": enabled,
+		"updatedAt":         time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// ContentTransformsEnabled reports whether container metadata leaves content transforms on
+func ContentTransformsEnabled(metadata map[string]interface{}) bool {
+	enabled, ok := metadata["contentTransforms"].(bool)
+	return !ok || enabled
+}
+
+// SetExternalMembers allows or forbids members that belong to another pod, such as the
+// external resources an indirect container references. Members must share the container's
+// pod unless it allows external ones.
+func (c *Container) SetExternalMembers(allowed bool) {
+	c.SetMetadata("allowExternalMembers", allowed)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"allowExternalMembers": allowed,
+		"updatedAt":            time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// ExternalMembersAllowed reports whether container metadata allows members from other pods
+func ExternalMembersAllowed(metadata map[string]interface{}) bool {
+	allowed, ok := metadata["allowExternalMembers"].(bool)
+	return ok && allowed
+}
+
+// SetMembershipEvents enables or disables member added/removed events for the container.
+// Membership changes of a container with events disabled are still stored, but they leave no
+// trace in the event log: they cannot be audited or replayed, and projections, notification
+// channels and other event subscribers never see them. Meant for high-churn system containers.
+func (c *Container) SetMembershipEvents(enabled bool) {
+	c.SetMetadata("membershipEvents", enabled)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"membershipEvents": enabled,
+		"updatedAt":        time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// MembershipEventsEnabled reports whether container metadata leaves membership events on
+func MembershipEventsEnabled(metadata map[string]interface{}) bool {
+	enabled, ok := metadata["membershipEvents"].(bool)
+	return !ok || enabled
+}
+
 // GetPath returns the path representation of the container
 func (c *Container) GetPath() string {
 	if c.ParentID == "" {
@@ -318,6 +457,36 @@ func (c *Container) SetDublinCoreMetadata(dc DublinCoreMetadata) {
 	c.AddEvent(event)
 }
 
+// Entries returns the metadata keys and values for the fields that are set
+func (dc DublinCoreMetadata) Entries() map[string]interface{} {
+	entries := make(map[string]interface{})
+	fields := map[string]string{
+		"dc:title":       dc.Title,
+		"dc:description": dc.Description,
+		"dc:creator":     dc.Creator,
+		"dc:subject":     dc.Subject,
+		"dc:publisher":   dc.Publisher,
+		"dc:contributor": dc.Contributor,
+		"dc:type":        dc.Type,
+		"dc:format":      dc.Format,
+		"dc:identifier":  dc.Identifier,
+		"dc:source":      dc.Source,
+		"dc:language":    dc.Language,
+		"dc:relation":    dc.Relation,
+		"dc:coverage":    dc.Coverage,
+		"dc:rights":      dc.Rights,
+	}
+	for key, value := range fields {
+		if value != "" {
+			entries[key] = value
+		}
+	}
+	if !dc.Date.IsZero() {
+		entries["dc:date"] = dc.Date
+	}
+	return entries
+}
+
 // GetDublinCoreMetadata retrieves Dublin Core metadata from a container
 func (c *Container) GetDublinCoreMetadata() DublinCoreMetadata {
 	metadata := c.GetMetadata()
@@ -414,6 +583,92 @@ func (c *Container) Delete(ctx context.Context) {
 	c.AddEvent(event)
 }
 
+// Move places the container under newParentID, and under newID when it is renamed as well.
+// ancestorPath is the path of the new parent, which must not run through the container itself.
+// The move is recorded as a single event carrying the container's former and new location.
+func (c *Container) Move(ctx context.Context, newParentID, newID string, ancestorPath []string) error {
+	if newID == "" {
+		newID = c.ID()
+	}
+	if newParentID == c.ID() || newParentID == newID {
+		return fmt.Errorf("container cannot be its own parent")
+	}
+
+	oldParentID := c.ParentID
+	c.ParentID = newParentID
+	if err := c.ValidateHierarchy(ancestorPath); err != nil {
+		c.ParentID = oldParentID
+		return err
+	}
+
+	event := NewContainerMovedEvent(c.ID(), map[string]interface{}{
+		"fromParentID": oldParentID,
+		"toParentID":   newParentID,
+		"toID":         newID,
+		"movedAt":      time.Now(),
+	})
+	c.AddEvent(event)
+	return nil
+}
+
+// Touch marks the container modified without changing its members or metadata. It refreshes
+// updatedAt and bumps the version so clients see a new ETag.
+func (c *Container) Touch(ctx context.Context) {
+	now := time.Now()
+	version := c.GetVersion() + 1
+	c.SetMetadata("updatedAt", now)
+	c.SetMetadata(ContainerVersionKey, version)
+
+	// Emit update event marked as a touch so consumers can tell it apart from edits
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"touched":           true,
+		ContainerVersionKey: version,
+		"updatedAt":         now,
+	})
+	c.AddEvent(event)
+}
+
+// GetVersion returns the container version, which starts at zero and is bumped by Touch
+func (c *Container) GetVersion() int {
+	return ContainerVersion(c.GetMetadata())
+}
+
+// ContainerVersion reads the container version from metadata, whether stored or decoded from JSON
+func ContainerVersion(metadata map[string]interface{}) int {
+	switch version := metadata[ContainerVersionKey].(type) {
+	case int:
+		return version
+	case int64:
+		return int(version)
+	case float64:
+		return int(version)
+	}
+	return 0
+}
+
+// ContainerETag returns a strong entity tag for the current state of a container, hashed from
+// its type, parent, metadata and members. Any change to the container's members or metadata
+// yields a new tag, even within the same second.
+func ContainerETag(container ContainerResource) string {
+	members := append([]string(nil), container.GetMembers()...)
+	sort.Strings(members)
+
+	state, err := json.Marshal(map[string]interface{}{
+		"id":            container.ID(),
+		"parentID":      container.GetParentID(),
+		"containerType": container.GetContainerType(),
+		"metadata":      container.GetMetadata(),
+		"members":       members,
+	})
+	if err != nil {
+		// Metadata that cannot be encoded is still hashed from its printed form
+		state = []byte(fmt.Sprintf("%s|%s|%s|%v|%v", container.ID(), container.GetParentID(), container.GetContainerType(), container.GetMetadata(), members))
+	}
+
+	sum := sha256.Sum256(state)
+	return hex.EncodeToString(sum[:16])
+}
+
 // ContainerResource interface methods
 
 // GetParentID returns the parent container ID
@@ -472,13 +727,17 @@ func GetDefaultPagination() PaginationOptions {
 
 // FilterOptions represents filtering options for container members
 type FilterOptions struct {
-	MemberType    string     `json:"memberType,omitempty"`    // "Container" or "Resource"
+	MemberType    string     `json:"memberType,omitempty"`    // "Container", "Resource" or "NonRDFSource"
 	ContentType   string     `json:"contentType,omitempty"`   // MIME type filter
 	NamePattern   string     `json:"namePattern,omitempty"`   // Name pattern matching
 	CreatedAfter  *time.Time `json:"createdAfter,omitempty"`  // Created after timestamp
 	CreatedBefore *time.Time `json:"createdBefore,omitempty"` // Created before timestamp
-	SizeMin       *int64     `json:"sizeMin,omitempty"`       // Minimum size in bytes
-	SizeMax       *int64     `json:"sizeMax,omitempty"`       // Maximum size in bytes
+	// ModifiedAfter and ModifiedBefore bound when a member last changed; a member never
+	// updated last changed when it was created
+	ModifiedAfter  *time.Time `json:"modifiedAfter,omitempty"`  // Modified after timestamp
+	ModifiedBefore *time.Time `json:"modifiedBefore,omitempty"` // Modified before timestamp
+	SizeMin        *int64     `json:"sizeMin,omitempty"`        // Minimum size in bytes
+	SizeMax        *int64     `json:"sizeMax,omitempty"`        // Maximum size in bytes
 }
 
 // SortOptions represents sorting options for container members
@@ -513,6 +772,47 @@ func GetDefaultSort() SortOptions {
 	}
 }
 
+// SetDefaultSort sets the order the container's members are listed in when a client asks for
+// none, such as newest first for a gallery or by name for a document folder. An invalid sort,
+// including the zero value, clears the default so the global one applies again.
+func (c *Container) SetDefaultSort(sort SortOptions) {
+	value := map[string]interface{}{
+		"field":     sort.Field,
+		"direction": sort.Direction,
+	}
+	c.SetMetadata("defaultSort", value)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		"defaultSort": value,
+		"updatedAt":   time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// DefaultSort returns the default sort configured in container metadata, reporting false when
+// the container has no valid default of its own
+func DefaultSort(metadata map[string]interface{}) (SortOptions, bool) {
+	var sort SortOptions
+	switch value := metadata["defaultSort"].(type) {
+	case SortOptions:
+		sort = value
+	case map[string]interface{}:
+		sort.Field, _ = value["field"].(string)
+		sort.Direction, _ = value["direction"].(string)
+	case map[string]string:
+		sort.Field, sort.Direction = value["field"], value["direction"]
+	default:
+		return SortOptions{}, false
+	}
+
+	if !sort.IsValid() {
+		return SortOptions{}, false
+	}
+	return sort, true
+}
+
 // ListingOptions combines pagination, filtering, and sorting options
 type ListingOptions struct {
 	Pagination PaginationOptions `json:"pagination"`
@@ -520,12 +820,21 @@ type ListingOptions struct {
 	Sort       SortOptions       `json:"sort"`
 }
 
-// GetDefaultListingOptions returns default listing options
-func GetDefaultListingOptions() ListingOptions {
+// GetDefaultListingOptions returns default listing options for a container. The sort is the
+// container's configured default, or the global default when it has none; a nil container
+// gets the global defaults.
+func GetDefaultListingOptions(container ContainerResource) ListingOptions {
+	sort := GetDefaultSort()
+	if container != nil {
+		if configured, ok := DefaultSort(container.GetMetadata()); ok {
+			sort = configured
+		}
+	}
+
 	return ListingOptions{
 		Pagination: GetDefaultPagination(),
 		Filter:     FilterOptions{},
-		Sort:       GetDefaultSort(),
+		Sort:       sort,
 	}
 }
 
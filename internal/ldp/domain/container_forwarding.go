@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ContainerForward points a moved or renamed container's former ID at its new ID until it expires
+type ContainerForward struct {
+	FromID    string    `json:"fromId"`
+	ToID      string    `json:"toId"`
+	MovedAt   time.Time `json:"movedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// IsExpired reports whether the forward no longer applies at the given time
+func (f ContainerForward) IsExpired(now time.Time) bool {
+	return !now.Before(f.ExpiresAt)
+}
+
+// ContainerForwarding records the forwards left behind when containers move
+type ContainerForwarding interface {
+	// RecordForward stores a forward, replacing any earlier forward from the same ID
+	RecordForward(ctx context.Context, forward ContainerForward) error
+	// LookupForward returns the unexpired forward from an ID, if any
+	LookupForward(ctx context.Context, fromID string, now time.Time) (ContainerForward, bool, error)
+}
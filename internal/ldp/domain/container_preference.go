@@ -0,0 +1,76 @@
+package domain
+
+const (
+	// LDPPreferContainment names a container's ldp:contains triples in a Prefer header
+	LDPPreferContainment = "http://www.w3.org/ns/ldp#PreferContainment"
+	// LDPPreferMembership names a Direct or Indirect container's membership triples
+	LDPPreferMembership = "http://www.w3.org/ns/ldp#PreferMembership"
+	// LDPPreferMinimalContainer names the container's own triples, without containment or
+	// membership triples
+	LDPPreferMinimalContainer = "http://www.w3.org/ns/ldp#PreferMinimalContainer"
+	// LDPPreferEmptyContainer is the earlier name of LDPPreferMinimalContainer, still sent by
+	// some clients
+	LDPPreferEmptyContainer = "http://www.w3.org/ns/ldp#PreferEmptyContainer"
+)
+
+// ContainerPreference says which triples a container representation leaves out, as asked for
+// with Prefer: return=representation; include="..." or omit="...". The zero value is the full
+// representation.
+type ContainerPreference struct {
+	OmitContainment bool
+	OmitMembership  bool
+}
+
+// NewContainerPreference builds the preference expressed by a Prefer header's include and omit
+// IRIs. Including the minimal container leaves out containment and membership triples unless
+// those are included too; IRIs the server does not know are ignored.
+func NewContainerPreference(include, omit []string) ContainerPreference {
+	var preference ContainerPreference
+	includes := make(map[string]bool, len(include))
+	for _, iri := range include {
+		includes[iri] = true
+	}
+
+	if includes[LDPPreferMinimalContainer] || includes[LDPPreferEmptyContainer] {
+		preference.OmitContainment = !includes[LDPPreferContainment]
+		preference.OmitMembership = !includes[LDPPreferMembership]
+	}
+	for _, iri := range omit {
+		switch iri {
+		case LDPPreferContainment:
+			preference.OmitContainment = true
+		case LDPPreferMembership:
+			preference.OmitMembership = true
+		}
+	}
+	return preference
+}
+
+// IsZero reports whether the preference asks for the full representation
+func (p ContainerPreference) IsZero() bool {
+	return !p.OmitContainment && !p.OmitMembership
+}
+
+// OmitsMembers reports whether the preference leaves out the member triples a container of the
+// given type states: containment triples for BasicContainers, membership triples for Direct and
+// Indirect containers
+func (p ContainerPreference) OmitsMembers(containerType ContainerType) bool {
+	if containerType.HasMembershipTriples() {
+		return p.OmitMembership
+	}
+	return p.OmitContainment
+}
+
+// Token names the preference for distinguishing the representations it selects, such as in an
+// entity tag; it is empty for the full representation
+func (p ContainerPreference) Token() string {
+	switch {
+	case p.OmitContainment && p.OmitMembership:
+		return "minimal"
+	case p.OmitContainment:
+		return "nocontainment"
+	case p.OmitMembership:
+		return "nomembership"
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContainerPreference(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		omit     []string
+		expected ContainerPreference
+	}{
+		{name: "no preference", expected: ContainerPreference{}},
+		{name: "minimal container", include: []string{LDPPreferMinimalContainer}, expected: ContainerPreference{OmitContainment: true, OmitMembership: true}},
+		{name: "empty container", include: []string{LDPPreferEmptyContainer}, expected: ContainerPreference{OmitContainment: true, OmitMembership: true}},
+		{name: "minimal container with containment", include: []string{LDPPreferMinimalContainer, LDPPreferContainment}, expected: ContainerPreference{OmitMembership: true}},
+		{name: "omit containment", omit: []string{LDPPreferContainment}, expected: ContainerPreference{OmitContainment: true}},
+		{name: "omit membership", omit: []string{LDPPreferMembership}, expected: ContainerPreference{OmitMembership: true}},
+		{name: "unknown IRIs", include: []string{"urn:example:other"}, omit: []string{"urn:example:other"}, expected: ContainerPreference{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewContainerPreference(tt.include, tt.omit))
+		})
+	}
+}
+
+func TestContainerPreference_OmitsMembers(t *testing.T) {
+	omitContainment := ContainerPreference{OmitContainment: true}
+	assert.True(t, omitContainment.OmitsMembers(BasicContainer))
+	assert.False(t, omitContainment.OmitsMembers(DirectContainer))
+
+	omitMembership := ContainerPreference{OmitMembership: true}
+	assert.False(t, omitMembership.OmitsMembers(BasicContainer))
+	assert.True(t, omitMembership.OmitsMembers(IndirectContainer))
+
+	assert.Equal(t, "minimal", ContainerPreference{OmitContainment: true, OmitMembership: true}.Token())
+	assert.Empty(t, ContainerPreference{}.Token())
+}
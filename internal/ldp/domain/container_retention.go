@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RetentionPolicyKey holds a container's member retention policy
+const RetentionPolicyKey = "retentionPolicy"
+
+// RetentionPolicy expires a container's members once they are older than MaxAge. Containers
+// such as logs or temporary uploads use it to clean up after themselves; the zero policy
+// keeps members forever.
+type RetentionPolicy struct {
+	MaxAge time.Duration
+}
+
+// IsZero reports whether the policy keeps members forever
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxAge <= 0
+}
+
+// IsExpired reports whether a member created at the given time has outlived the policy
+func (p RetentionPolicy) IsExpired(createdAt, now time.Time) bool {
+	return !p.IsZero() && !createdAt.IsZero() && now.Sub(createdAt) > p.MaxAge
+}
+
+// MarshalJSON writes the policy with its max age as a duration string, such as "720h0m0s"
+func (p RetentionPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"maxAge": p.MaxAge.String()})
+}
+
+// UnmarshalJSON reads a policy whose max age is a duration string, such as "24h"
+func (p *RetentionPolicy) UnmarshalJSON(data []byte) error {
+	var value struct {
+		MaxAge string `json:"maxAge"`
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value.MaxAge == "" {
+		*p = RetentionPolicy{}
+		return nil
+	}
+	maxAge, err := time.ParseDuration(value.MaxAge)
+	if err != nil {
+		return fmt.Errorf("invalid retention max age %q: %w", value.MaxAge, err)
+	}
+	if maxAge < 0 {
+		return fmt.Errorf("retention max age cannot be negative")
+	}
+	p.MaxAge = maxAge
+	return nil
+}
+
+// SetRetentionPolicy sets how long the container keeps its members. The zero policy clears
+// it, so members are kept until they are removed.
+func (c *Container) SetRetentionPolicy(policy RetentionPolicy) {
+	value := map[string]interface{}{}
+	if !policy.IsZero() {
+		value["maxAge"] = policy.MaxAge.String()
+	}
+	c.SetMetadata(RetentionPolicyKey, value)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		RetentionPolicyKey: value,
+		"updatedAt":        time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// ContainerRetentionPolicy returns the retention policy configured in container metadata,
+// reporting false when the container keeps its members forever
+func ContainerRetentionPolicy(metadata map[string]interface{}) (RetentionPolicy, bool) {
+	var policy RetentionPolicy
+	switch value := metadata[RetentionPolicyKey].(type) {
+	case RetentionPolicy:
+		policy = value
+	case map[string]interface{}:
+		maxAge, _ := value["maxAge"].(string)
+		policy.MaxAge, _ = time.ParseDuration(maxAge)
+	case map[string]string:
+		policy.MaxAge, _ = time.ParseDuration(value["maxAge"])
+	default:
+		return RetentionPolicy{}, false
+	}
+
+	if policy.IsZero() {
+		return RetentionPolicy{}, false
+	}
+	return policy, true
+}
+
+// ContainerLister lists the IDs of every stored container, so background jobs such as the
+// retention sweep can visit them all
+type ContainerLister interface {
+	ListContainerIDs(ctx context.Context) ([]string, error)
+}
@@ -61,6 +61,19 @@ func TestContainer_AddMember(t *testing.T) {
 	assert.Equal(t, EventTypeMemberAdded, events[len(events)-1].(*EntityEvent).Type)
 }
 
+func TestContainer_AddMember_Duplicate(t *testing.T) {
+	ctx := context.Background()
+	container := NewContainer(ctx, "test-container", "", BasicContainer)
+	resource := NewResource(ctx, "resource-1", "text/plain", []byte("test data"))
+	assert.NoError(t, container.AddMember(ctx, resource))
+	initialEvents := len(container.UncommittedEvents())
+
+	// Adding the same member again succeeds without duplicating it or emitting an event
+	assert.NoError(t, container.AddMember(ctx, resource))
+	assert.Equal(t, []string{"resource-1"}, container.GetMembers())
+	assert.Len(t, container.UncommittedEvents(), initialEvents)
+}
+
 func TestContainer_AddMember_NilResource(t *testing.T) {
 	ctx := context.Background()
 	container := NewContainer(ctx, "test-container", "", BasicContainer)
@@ -227,6 +240,25 @@ func TestContainer_Delete_Success(t *testing.T) {
 	assert.Equal(t, EventTypeContainerDeleted, events[len(events)-1].(*EntityEvent).Type)
 }
 
+func TestContainer_Touch(t *testing.T) {
+	ctx := context.Background()
+	container := NewContainer(ctx, "test-container", "", BasicContainer)
+	container.SetTitle("Test")
+	initialEvents := len(container.UncommittedEvents())
+	assert.Equal(t, 0, container.GetVersion())
+
+	container.Touch(ctx)
+	container.Touch(ctx)
+
+	// Each touch bumps the version and emits an update event; metadata is left alone
+	assert.Equal(t, 2, container.GetVersion())
+	assert.Equal(t, "Test", container.GetTitle())
+	events := container.UncommittedEvents()
+	assert.Len(t, events, initialEvents+2)
+	assert.Equal(t, EventTypeContainerUpdated, events[len(events)-1].(*EntityEvent).Type)
+	assert.Contains(t, string(events[len(events)-1].Payload()), `"touched":true`)
+}
+
 func TestContainerType_String(t *testing.T) {
 	assert.Equal(t, "BasicContainer", BasicContainer.String())
 	assert.Equal(t, "DirectContainer", DirectContainer.String())
@@ -237,3 +269,34 @@ func TestContainerType_IsValid(t *testing.T) {
 	assert.True(t, DirectContainer.IsValid())
 	assert.False(t, ContainerType("InvalidType").IsValid())
 }
+
+func TestContainer_Move(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records the new parent and ID", func(t *testing.T) {
+		container := NewContainer(ctx, "trips", "photos", BasicContainer)
+		container.MarkEventsAsCommitted()
+
+		assert.NoError(t, container.Move(ctx, "archive", "old-trips", []string{"root", "archive"}))
+
+		assert.Equal(t, "archive", container.ParentID)
+		events := container.UncommittedEvents()
+		assert.Len(t, events, 1)
+		assert.Equal(t, EventTypeContainerMoved, events[0].(*EntityEvent).Type)
+		assert.Contains(t, string(events[0].(*EntityEvent).Payload()), `"toID":"old-trips"`)
+	})
+
+	t.Run("refuses to move a container below itself", func(t *testing.T) {
+		container := NewContainer(ctx, "trips", "photos", BasicContainer)
+		container.MarkEventsAsCommitted()
+
+		assert.Error(t, container.Move(ctx, "lisbon", "", []string{"photos", "trips", "lisbon"}))
+		assert.Equal(t, "photos", container.ParentID)
+		assert.Empty(t, container.UncommittedEvents())
+	})
+
+	t.Run("refuses to make a container its own parent", func(t *testing.T) {
+		container := NewContainer(ctx, "trips", "photos", BasicContainer)
+		assert.Error(t, container.Move(ctx, "trips", "", []string{"trips"}))
+	})
+}
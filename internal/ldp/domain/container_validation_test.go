@@ -156,10 +156,10 @@ func TestContainerValidation_MembershipConsistency(t *testing.T) {
 			expectAddError: false,
 		},
 		{
-			name:           "add duplicate member - error",
+			name:           "add duplicate member - idempotent",
 			initialMembers: []string{"resource-1", "resource-2"},
 			addMember:      "resource-1",
-			expectAddError: true,
+			expectAddError: false,
 		},
 		{
 			name:           "remove existing member - success",
@@ -384,7 +384,7 @@ func TestContainerValidation_ListingOptionsValidation(t *testing.T) {
 	}{
 		{
 			name:        "valid listing - default",
-			listing:     GetDefaultListingOptions(),
+			listing:     GetDefaultListingOptions(nil),
 			expectValid: true,
 		},
 		{
@@ -423,6 +423,34 @@ func TestContainerValidation_ListingOptionsValidation(t *testing.T) {
 	}
 }
 
+// TestGetDefaultListingOptions_ContainerDefaultSort tests that a container's default sort
+// replaces the global one
+func TestGetDefaultListingOptions_ContainerDefaultSort(t *testing.T) {
+	ctx := context.Background()
+
+	gallery := NewContainer(ctx, "gallery", "", BasicContainer)
+	gallery.SetDefaultSort(SortOptions{Field: "createdAt", Direction: "desc"})
+	if sort := GetDefaultListingOptions(gallery).Sort; sort != (SortOptions{Field: "createdAt", Direction: "desc"}) {
+		t.Errorf("expected the container's default sort, got %+v", sort)
+	}
+
+	folder := NewContainer(ctx, "folder", "", BasicContainer)
+	if sort := GetDefaultListingOptions(folder).Sort; sort != GetDefaultSort() {
+		t.Errorf("expected the global default sort, got %+v", sort)
+	}
+
+	// Metadata decoded from JSON holds the sort as a generic map
+	folder.SetMetadata("defaultSort", map[string]interface{}{"field": "name", "direction": "asc"})
+	if sort := GetDefaultListingOptions(folder).Sort; sort != (SortOptions{Field: "name", Direction: "asc"}) {
+		t.Errorf("expected the decoded default sort, got %+v", sort)
+	}
+
+	gallery.SetDefaultSort(SortOptions{})
+	if sort := GetDefaultListingOptions(gallery).Sort; sort != GetDefaultSort() {
+		t.Errorf("expected a cleared default to fall back to the global one, got %+v", sort)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || (len(s) > len(substr) &&
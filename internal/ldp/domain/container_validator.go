@@ -7,11 +7,18 @@ import (
 )
 
 // ContainerValidator provides validation functionality for containers
-type ContainerValidator struct{}
+type ContainerValidator struct {
+	ids *IDValidator
+}
 
 // NewContainerValidator creates a new container validator
 func NewContainerValidator() *ContainerValidator {
-	return &ContainerValidator{}
+	return &ContainerValidator{ids: DefaultIDValidator()}
+}
+
+// SetIDValidator sets the URI-safe pattern container IDs must match
+func (v *ContainerValidator) SetIDValidator(ids *IDValidator) {
+	v.ids = ids
 }
 
 // ValidateContainer performs comprehensive validation on a container
@@ -53,19 +60,20 @@ func (v *ContainerValidator) ValidateContainer(ctx context.Context, container Co
 // ValidateContainerID validates a container ID
 func (v *ContainerValidator) ValidateContainerID(id string) error {
 	if id == "" {
-		return NewContainerError("EMPTY_CONTAINER_ID", "container ID cannot be empty")
+		return WrapContainerError(ErrInvalidID, "EMPTY_CONTAINER_ID", "container ID cannot be empty")
 	}
 
 	if len(id) > 255 {
-		return NewContainerError("CONTAINER_ID_TOO_LONG", "container ID cannot exceed 255 characters")
+		return WrapContainerError(ErrInvalidID, "CONTAINER_ID_TOO_LONG", "container ID cannot exceed 255 characters")
 	}
 
 	// Check for invalid characters
 	if strings.ContainsAny(id, "/\\:*?\"<>|") {
-		return NewContainerError("INVALID_CONTAINER_ID_CHARS", "container ID contains invalid characters")
+		return WrapContainerError(ErrInvalidID, "INVALID_CONTAINER_ID_CHARS", "container ID contains invalid characters")
 	}
 
-	return nil
+	// Check the ID is usable as a URI path segment as it is
+	return v.ids.ValidateID(id)
 }
 
 // ValidateContainerType validates a container type
@@ -248,6 +256,12 @@ func (v *ContainerValidator) ValidateListingOptions(options ListingOptions) erro
 		}
 	}
 
+	if options.Filter.ModifiedAfter != nil && options.Filter.ModifiedBefore != nil {
+		if options.Filter.ModifiedAfter.After(*options.Filter.ModifiedBefore) {
+			return NewContainerError("INVALID_DATE_RANGE", "modified after date cannot be later than modified before date")
+		}
+	}
+
 	return nil
 }
 
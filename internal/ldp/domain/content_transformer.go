@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceContentTransformer rewrites a resource body before it is stored, for example to strip
+// EXIF metadata from images or minify JSON. Transformers only see content on write; reads return
+// whatever was stored.
+type ResourceContentTransformer interface {
+	// Name identifies the transformer in configuration and errors
+	Name() string
+	// Handles reports whether the transformer applies to content of the given media type
+	Handles(contentType string) bool
+	// Transform returns the rewritten body
+	Transform(data []byte, contentType string) ([]byte, error)
+}
+
+// ContentTransformPipeline runs registered transformers, in registration order, over content
+// being written
+type ContentTransformPipeline struct {
+	transformers []ResourceContentTransformer
+}
+
+// NewContentTransformPipeline creates a pipeline running the given transformers in order
+func NewContentTransformPipeline(transformers ...ResourceContentTransformer) *ContentTransformPipeline {
+	return &ContentTransformPipeline{transformers: transformers}
+}
+
+// Register appends a transformer to the pipeline
+func (p *ContentTransformPipeline) Register(transformer ResourceContentTransformer) {
+	p.transformers = append(p.transformers, transformer)
+}
+
+// Len returns the number of registered transformers
+func (p *ContentTransformPipeline) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.transformers)
+}
+
+// Transform passes the content through every transformer that handles its media type. The first
+// transformer error aborts the pipeline as an ErrContentTransformFailed error naming it.
+func (p *ContentTransformPipeline) Transform(data []byte, contentType string) ([]byte, error) {
+	if p == nil {
+		return data, nil
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	for _, transformer := range p.transformers {
+		if !transformer.Handles(mediaType) {
+			continue
+		}
+
+		transformed, err := transformer.Transform(data, mediaType)
+		if err != nil {
+			return nil, WrapStorageError(
+				err,
+				ErrContentTransformFailed.Code,
+				fmt.Sprintf("content transformer %s failed", transformer.Name()),
+			).WithContext("transformer", transformer.Name()).WithContext("contentType", mediaType)
+		}
+		data = transformed
+	}
+
+	return data, nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// funcTransformer applies fn to content of one media type
+type funcTransformer struct {
+	name      string
+	mediaType string
+	fn        func([]byte) ([]byte, error)
+}
+
+func (f funcTransformer) Name() string                    { return f.name }
+func (f funcTransformer) Handles(contentType string) bool { return contentType == f.mediaType }
+func (f funcTransformer) Transform(data []byte, contentType string) ([]byte, error) {
+	return f.fn(data)
+}
+
+func TestContentTransformPipeline_Transform(t *testing.T) {
+	upper := funcTransformer{"upper", "text/plain", func(b []byte) ([]byte, error) { return []byte(strings.ToUpper(string(b))), nil }}
+	exclaim := funcTransformer{"exclaim", "text/plain", func(b []byte) ([]byte, error) { return append(b, '!'), nil }}
+
+	t.Run("runs matching transformers in registration order", func(t *testing.T) {
+		pipeline := NewContentTransformPipeline(upper)
+		pipeline.Register(exclaim)
+
+		out, err := pipeline.Transform([]byte("hi"), "Text/Plain; charset=utf-8")
+		require.NoError(t, err)
+		assert.Equal(t, "HI!", string(out))
+	})
+
+	t.Run("leaves other media types unchanged", func(t *testing.T) {
+		out, err := NewContentTransformPipeline(upper).Transform([]byte("hi"), "text/turtle")
+		require.NoError(t, err)
+		assert.Equal(t, "hi", string(out))
+	})
+
+	t.Run("aborts on the first transformer error", func(t *testing.T) {
+		failing := funcTransformer{"failing", "text/plain", func([]byte) ([]byte, error) { return nil, errors.New("bad input") }}
+		pipeline := NewContentTransformPipeline(failing, exclaim)
+
+		_, err := pipeline.Transform([]byte("hi"), "text/plain")
+		require.Error(t, err)
+		assert.True(t, IsContentTransformFailed(err))
+		storageErr, _ := GetStorageError(err)
+		assert.Equal(t, "failing", storageErr.Context["transformer"])
+	})
+
+	t.Run("nil pipeline passes content through", func(t *testing.T) {
+		var pipeline *ContentTransformPipeline
+		out, err := pipeline.Transform([]byte("hi"), "text/plain")
+		require.NoError(t, err)
+		assert.Equal(t, "hi", string(out))
+		assert.Equal(t, 0, pipeline.Len())
+	})
+}
+
+func TestContainer_SetContentTransforms(t *testing.T) {
+	container := NewContainer(context.Background(), "photos", "", BasicContainer)
+	assert.True(t, ContentTransformsEnabled(container.GetMetadata()), "transforms apply until a container opts out")
+
+	container.MarkEventsAsCommitted()
+	container.SetContentTransforms(false)
+	assert.False(t, ContentTransformsEnabled(container.GetMetadata()))
+	assert.Len(t, container.UncommittedEvents(), 1)
+
+	container.SetContentTransforms(true)
+	assert.True(t, ContentTransformsEnabled(container.GetMetadata()))
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// DeadLetter is an event a handler could not process within its retry policy. The envelope is
+// kept so an operator can replay the event once the cause of the failure is fixed.
+type DeadLetter struct {
+	ID          string                  `json:"id"`
+	Handler     string                  `json:"handler"`
+	EventID     string                  `json:"eventId"`
+	EventType   string                  `json:"eventType"`
+	AggregateID string                  `json:"aggregateId"`
+	Attempts    int                     `json:"attempts"`
+	LastError   string                  `json:"lastError"`
+	FailedAt    time.Time               `json:"failedAt"`
+	Envelope    pericarpdomain.Envelope `json:"-"`
+}
+
+// DeadLetterStore holds dead-lettered events until they are replayed
+type DeadLetterStore interface {
+	// Put stores a dead letter, replacing any earlier one with the same ID
+	Put(ctx context.Context, letter DeadLetter) error
+	// Get returns a dead letter, or ErrDeadLetterNotFound
+	Get(ctx context.Context, id string) (DeadLetter, error)
+	// List returns the stored dead letters, oldest failure first
+	List(ctx context.Context) ([]DeadLetter, error)
+	// Remove deletes a dead letter once it has been replayed
+	Remove(ctx context.Context, id string) error
+}
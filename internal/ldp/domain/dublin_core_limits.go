@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// DublinCoreLimits caps the length, in characters, of Dublin Core text fields. A zero maximum
+// leaves that field unbounded.
+type DublinCoreLimits struct {
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	// MaxFieldLength applies to every other text field
+	MaxFieldLength int
+	// Truncate shortens oversized fields instead of rejecting them
+	Truncate bool
+}
+
+// Apply checks each field against its maximum. When the limits truncate, the returned metadata
+// has oversized fields shortened and the names of those fields are returned; otherwise the first
+// oversized field is reported as an ErrMetadataTooLong error.
+func (l DublinCoreLimits) Apply(dc DublinCoreMetadata) (DublinCoreMetadata, []string, error) {
+	fields := []struct {
+		name  string
+		value *string
+		max   int
+	}{
+		{"title", &dc.Title, l.MaxTitleLength},
+		{"description", &dc.Description, l.MaxDescriptionLength},
+		{"creator", &dc.Creator, l.MaxFieldLength},
+		{"subject", &dc.Subject, l.MaxFieldLength},
+		{"publisher", &dc.Publisher, l.MaxFieldLength},
+		{"contributor", &dc.Contributor, l.MaxFieldLength},
+		{"type", &dc.Type, l.MaxFieldLength},
+		{"format", &dc.Format, l.MaxFieldLength},
+		{"identifier", &dc.Identifier, l.MaxFieldLength},
+		{"source", &dc.Source, l.MaxFieldLength},
+		{"language", &dc.Language, l.MaxFieldLength},
+		{"relation", &dc.Relation, l.MaxFieldLength},
+		{"coverage", &dc.Coverage, l.MaxFieldLength},
+		{"rights", &dc.Rights, l.MaxFieldLength},
+	}
+
+	var truncated []string
+	for _, field := range fields {
+		if field.max <= 0 {
+			continue
+		}
+		length := utf8.RuneCountInString(*field.value)
+		if length <= field.max {
+			continue
+		}
+		if !l.Truncate {
+			return dc, nil, WrapStorageError(
+				fmt.Errorf("dc:%s is %d characters, maximum is %d", field.name, length, field.max),
+				ErrMetadataTooLong.Code,
+				ErrMetadataTooLong.Message,
+			).WithContext("field", "dc:"+field.name).WithContext("maxLength", field.max)
+		}
+		*field.value = truncateRunes(*field.value, field.max)
+		truncated = append(truncated, "dc:"+field.name)
+	}
+
+	return dc, truncated, nil
+}
+
+// truncateRunes shortens s to at most max characters without splitting a multi-byte character
+func truncateRunes(s string, max int) string {
+	count := 0
+	for i := range s {
+		if count == max {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
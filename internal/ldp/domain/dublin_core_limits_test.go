@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDublinCoreLimits_Apply(t *testing.T) {
+	limits := DublinCoreLimits{MaxTitleLength: 5, MaxDescriptionLength: 10, MaxFieldLength: 3}
+
+	t.Run("accepts fields within their limits", func(t *testing.T) {
+		dc := DublinCoreMetadata{Title: "Notes", Description: "Short", Creator: "Ann"}
+
+		limited, truncated, err := limits.Apply(dc)
+		require.NoError(t, err)
+		assert.Equal(t, dc, limited)
+		assert.Empty(t, truncated)
+	})
+
+	t.Run("rejects an oversized field", func(t *testing.T) {
+		_, _, err := limits.Apply(DublinCoreMetadata{Title: "Notes", Rights: "CC-BY"})
+		require.Error(t, err)
+		assert.True(t, IsMetadataTooLong(err))
+
+		storageErr, ok := GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, "dc:rights", storageErr.Context["field"])
+	})
+
+	t.Run("truncates oversized fields in lenient mode", func(t *testing.T) {
+		lenient := limits
+		lenient.Truncate = true
+
+		limited, truncated, err := lenient.Apply(DublinCoreMetadata{Title: "Résumé draft", Subject: "art"})
+		require.NoError(t, err)
+		assert.Equal(t, "Résum", limited.Title)
+		assert.Equal(t, "art", limited.Subject)
+		assert.Equal(t, []string{"dc:title"}, truncated)
+	})
+
+	t.Run("leaves fields without a maximum unbounded", func(t *testing.T) {
+		dc := DublinCoreMetadata{Title: strings.Repeat("a", 10000)}
+
+		limited, _, err := DublinCoreLimits{}.Apply(dc)
+		require.NoError(t, err)
+		assert.Equal(t, dc.Title, limited.Title)
+	})
+}
@@ -140,6 +140,13 @@ var (
 		Message: "membership already exists",
 	}
 
+	// ErrDuplicateIdentifier indicates another resource of the account already holds a
+	// dc:identifier that must be unique
+	ErrDuplicateIdentifier = &StorageError{
+		Code:    "DUPLICATE_IDENTIFIER",
+		Message: "dc:identifier is already used in this account",
+	}
+
 	// ErrInvalidContainerType indicates an unsupported container type
 	ErrInvalidContainerType = &StorageError{
 		Code:    "INVALID_CONTAINER_TYPE",
@@ -151,6 +158,150 @@ var (
 		Code:    "INVALID_FORMAT",
 		Message: "invalid format specified",
 	}
+
+	// ErrInvalidMembershipResource indicates a DirectContainer's membership resource does not exist
+	// in the pod and is not an allowed external reference
+	ErrInvalidMembershipResource = &StorageError{
+		Code:    "INVALID_MEMBERSHIP_RESOURCE",
+		Message: "invalid membership resource",
+	}
+
+	// ErrMissingMembershipRelation indicates a Direct or Indirect container lacks a membership
+	// predicate its type requires
+	ErrMissingMembershipRelation = &StorageError{
+		Code:    "MISSING_MEMBERSHIP_RELATION",
+		Message: "container is missing a required membership predicate",
+	}
+
+	// ErrMissingInsertedContent indicates a member of an IndirectContainer does not state the
+	// container's ldp:insertedContentRelation
+	ErrMissingInsertedContent = &StorageError{
+		Code:    "MISSING_INSERTED_CONTENT",
+		Message: "member does not state the container's inserted content relation",
+	}
+
+	// ErrCrossPodMembership indicates a member belongs to a different pod than its container
+	ErrCrossPodMembership = &StorageError{
+		Code:    "CROSS_POD_MEMBERSHIP",
+		Message: "member belongs to a different pod than the container",
+	}
+
+	// ErrMetadataTooLong indicates a Dublin Core field exceeds its configured maximum length
+	ErrMetadataTooLong = &StorageError{
+		Code:    "METADATA_TOO_LONG",
+		Message: "metadata field exceeds maximum length",
+	}
+
+	// ErrContentTransformFailed indicates a write-time content transformer rejected the content
+	ErrContentTransformFailed = &StorageError{
+		Code:    "CONTENT_TRANSFORM_FAILED",
+		Message: "content transformation failed",
+	}
+
+	// ErrOperationLimitReached indicates an account already runs as many heavy operations as
+	// it may at once
+	ErrOperationLimitReached = &StorageError{
+		Code:    "OPERATION_LIMIT_REACHED",
+		Message: "too many concurrent heavy operations for this account",
+	}
+
+	// ErrDeadLetterNotFound indicates no dead-lettered event has the given ID
+	ErrDeadLetterNotFound = &StorageError{
+		Code:    "DEAD_LETTER_NOT_FOUND",
+		Message: "dead letter not found",
+	}
+
+	// ErrAccessTrackingDisabled indicates last-accessed tracking is not enabled
+	ErrAccessTrackingDisabled = &StorageError{
+		Code:    "ACCESS_TRACKING_DISABLED",
+		Message: "resource access tracking is not enabled",
+	}
+
+	// ErrNotificationChannelNotFound indicates no notification channel has the given ID
+	ErrNotificationChannelNotFound = &StorageError{
+		Code:    "NOTIFICATION_CHANNEL_NOT_FOUND",
+		Message: "notification channel not found",
+	}
+
+	// ErrUnsupportedChannelType indicates a subscription asked for a channel type the server
+	// does not offer
+	ErrUnsupportedChannelType = &StorageError{
+		Code:    "UNSUPPORTED_CHANNEL_TYPE",
+		Message: "notification channel type is not supported",
+	}
+
+	// ErrAccessDenied indicates the caller lacks permission for the operation
+	ErrAccessDenied = &StorageError{
+		Code:    "ACCESS_DENIED",
+		Message: "access denied",
+	}
+
+	// ErrInvalidPatch indicates a patch document is malformed or uses unsupported features
+	ErrInvalidPatch = &StorageError{
+		Code:    "INVALID_PATCH",
+		Message: "invalid patch document",
+	}
+
+	// ErrPatchConflict indicates a patch's conditions do not hold for the resource it targets
+	ErrPatchConflict = &StorageError{
+		Code:    "PATCH_CONFLICT",
+		Message: "patch conditions not met",
+	}
+
+	// ErrInvalidTriplePattern indicates a triple pattern term cannot be parsed
+	ErrInvalidTriplePattern = &StorageError{
+		Code:    "INVALID_TRIPLE_PATTERN",
+		Message: "invalid triple pattern",
+	}
+
+	// ErrVersioningDisabled indicates no store keeps earlier versions of resources
+	ErrVersioningDisabled = &StorageError{
+		Code:    "VERSIONING_DISABLED",
+		Message: "resource versioning is not enabled",
+	}
+
+	// ErrResourceVersionNotFound indicates a resource has no stored version with the given ID
+	ErrResourceVersionNotFound = &StorageError{
+		Code:    "RESOURCE_VERSION_NOT_FOUND",
+		Message: "resource version not found",
+	}
+
+	// ErrGraphTooLarge indicates an RDF document holds more triples than a resource may
+	ErrGraphTooLarge = &StorageError{
+		Code:    "GRAPH_TOO_LARGE",
+		Message: "RDF graph exceeds the maximum triple count",
+	}
+
+	// ErrUploadTooLarge indicates an upload session declares more bytes than uploads may hold
+	ErrUploadTooLarge = &StorageError{
+		Code:    "UPLOAD_TOO_LARGE",
+		Message: "upload exceeds the maximum upload size",
+	}
+
+	// ErrUploadNotFound indicates an upload session does not exist or has expired
+	ErrUploadNotFound = &StorageError{
+		Code:    "UPLOAD_NOT_FOUND",
+		Message: "upload session not found",
+	}
+
+	// ErrUploadOffsetMismatch indicates a chunk does not start where the upload left off
+	ErrUploadOffsetMismatch = &StorageError{
+		Code:    "UPLOAD_OFFSET_MISMATCH",
+		Message: "chunk does not start at the upload offset",
+	}
+
+	// ErrUploadIncomplete indicates an upload is finalized before all its bytes arrived
+	ErrUploadIncomplete = &StorageError{
+		Code:    "UPLOAD_INCOMPLETE",
+		Message: "upload is incomplete",
+	}
+
+	// ErrAccountDeleted indicates a resource belongs to an account that was deleted and whose
+	// resources are still being purged
+	ErrAccountDeleted = &StorageError{
+		Code:    "ACCOUNT_DELETED",
+		Message: "resource belongs to a deleted account",
+	}
 )
 
 // NewStorageError creates a new storage error with the given code and message
@@ -227,6 +378,46 @@ func IsFormatConversion(err error) bool {
 	return false
 }
 
+// IsInvalidPatch checks if an error indicates a malformed patch document
+func IsInvalidPatch(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrInvalidPatch.Code
+	}
+	return false
+}
+
+// IsPatchConflict checks if an error indicates a patch's conditions do not hold
+func IsPatchConflict(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrPatchConflict.Code
+	}
+	return false
+}
+
+// IsInvalidTriplePattern checks if an error indicates a malformed triple pattern
+func IsInvalidTriplePattern(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrInvalidTriplePattern.Code
+	}
+	return false
+}
+
+// IsVersioningDisabled checks if an error indicates resource versioning is not enabled
+func IsVersioningDisabled(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrVersioningDisabled.Code
+	}
+	return false
+}
+
+// IsResourceVersionNotFound checks if an error indicates a missing resource version
+func IsResourceVersionNotFound(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrResourceVersionNotFound.Code
+	}
+	return false
+}
+
 // Container error helper functions
 
 // NewContainerError creates a new container-specific storage error
@@ -287,6 +478,142 @@ func IsInvalidContainerType(err error) bool {
 	return false
 }
 
+// IsResourceAlreadyExists checks if an error indicates the resource ID is already taken
+func IsResourceAlreadyExists(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrResourceAlreadyExists.Code || storageErr.Code == ErrResourceExists.Code
+	}
+	return false
+}
+
+// IsContentTransformFailed checks if an error is a write-time content transformation error
+func IsContentTransformFailed(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrContentTransformFailed.Code
+	}
+	return false
+}
+
+// IsAccessDenied checks if an error indicates the caller lacks permission
+func IsAccessDenied(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrAccessDenied.Code
+	}
+	return false
+}
+
+// IsInvalidMembershipResource checks if an error is an invalid membership resource error
+func IsInvalidMembershipResource(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrInvalidMembershipResource.Code
+	}
+	return false
+}
+
+// IsMissingMembershipRelation checks if an error is a missing membership predicate error
+func IsMissingMembershipRelation(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrMissingMembershipRelation.Code
+	}
+	return false
+}
+
+// IsMissingInsertedContent checks if an error is a missing inserted content error
+func IsMissingInsertedContent(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrMissingInsertedContent.Code
+	}
+	return false
+}
+
+// IsOperationLimitReached checks if an error is an operation limit error
+func IsOperationLimitReached(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrOperationLimitReached.Code
+	}
+	return false
+}
+
+// IsCrossPodMembership checks if an error is a cross-pod membership error
+func IsCrossPodMembership(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrCrossPodMembership.Code
+	}
+	return false
+}
+
+// IsMetadataTooLong checks if an error is a metadata length error
+func IsMetadataTooLong(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrMetadataTooLong.Code
+	}
+	return false
+}
+
+// IsAccessTrackingDisabled checks if an error indicates last-accessed tracking is not enabled
+func IsAccessTrackingDisabled(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrAccessTrackingDisabled.Code
+	}
+	return false
+}
+
+// IsDeadLetterNotFound checks if an error indicates a dead letter was not found
+func IsDeadLetterNotFound(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrDeadLetterNotFound.Code
+	}
+	return false
+}
+
+// IsNotificationChannelNotFound checks if an error indicates a notification channel was not found
+func IsNotificationChannelNotFound(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrNotificationChannelNotFound.Code
+	}
+	return false
+}
+
+// IsUnsupportedChannelType checks if an error indicates an unsupported notification channel type
+func IsUnsupportedChannelType(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrUnsupportedChannelType.Code
+	}
+	return false
+}
+
+// IsGraphTooLarge checks if an error indicates an RDF document exceeds the triple limit
+func IsGraphTooLarge(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrGraphTooLarge.Code
+	}
+	return false
+}
+
+// IsUploadNotFound checks if an error is an upload not found error
+func IsUploadNotFound(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrUploadNotFound.Code
+	}
+	return false
+}
+
+// IsInvalidID checks if an error indicates an ID was empty or not URI-safe
+func IsInvalidID(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrInvalidID.Code
+	}
+	return false
+}
+
+// IsAccountDeleted checks if an error is an account deleted error
+func IsAccountDeleted(err error) bool {
+	if storageErr, ok := GetStorageError(err); ok {
+		return storageErr.Code == ErrAccountDeleted.Code
+	}
+	return false
+}
+
 // DomainError represents a domain-specific error
 type DomainError struct {
 	Code    string
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// EventLogEntry is one stored event as it is exported for archival. Sequence is the event's
+// position in the whole log, ordered by when events were stored, so an archiver can resume after
+// the last entry it received.
+type EventLogEntry struct {
+	Sequence          int64                  `json:"sequence"`
+	EventID           string                 `json:"eventId"`
+	EventType         string                 `json:"eventType"`
+	AggregateID       string                 `json:"aggregateId"`
+	AggregateSequence int64                  `json:"aggregateSequence"`
+	Timestamp         time.Time              `json:"timestamp"`
+	Data              json.RawMessage        `json:"data,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// EventLogQuery selects the part of the log to read. Entries must satisfy both bounds.
+type EventLogQuery struct {
+	// AfterSequence skips entries up to and including this sequence number
+	AfterSequence int64
+	// Since skips entries stored before this time; the zero time reads from the beginning
+	Since time.Time
+}
+
+// EventLogReader reads the stored event log in sequence order
+type EventLogReader interface {
+	// ReadEventLog calls fn for each selected entry in sequence order, one at a time, and stops
+	// at the first error fn returns
+	ReadEventLog(ctx context.Context, query EventLogQuery, fn func(EventLogEntry) error) error
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 )
 
@@ -25,10 +27,27 @@ const (
 	EventTypeContainerCreated = "container_created"
 	EventTypeContainerUpdated = "container_updated"
 	EventTypeContainerDeleted = "container_deleted"
+	EventTypeContainerMoved   = "container_moved"
 	EventTypeMemberAdded      = "member_added"
 	EventTypeMemberRemoved    = "member_removed"
 )
 
+// Event types for read auditing. These are routed only to the audit projection
+// and are never written to the general event store.
+const (
+	EventTypeContainerRead = "container_read"
+	EventTypeResourceRead  = "resource_read"
+)
+
+// ReadAuditEventData captures who read what, when, and as part of which request
+type ReadAuditEventData struct {
+	UserID    string    `json:"user_id,omitempty"`
+	AccountID string    `json:"account_id,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	Format    string    `json:"format,omitempty"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
 // NewResourceCreatedEvent creates a new resource created event
 func NewResourceCreatedEvent(resourceID string, data interface{}) *EntityEvent {
 	return pericarpdomain.NewEntityEvent("resource", EventTypeResourceCreated, resourceID, "", "", data)
@@ -81,6 +100,11 @@ func NewContainerDeletedEvent(containerID string, data interface{}) *EntityEvent
 	return pericarpdomain.NewEntityEvent("container", EventTypeContainerDeleted, containerID, "", "", data)
 }
 
+// NewContainerMovedEvent creates a new container moved event
+func NewContainerMovedEvent(containerID string, data interface{}) *EntityEvent {
+	return pericarpdomain.NewEntityEvent("container", EventTypeContainerMoved, containerID, "", "", data)
+}
+
 // NewMemberAddedEvent creates a new member added event
 func NewMemberAddedEvent(containerID string, data interface{}) *EntityEvent {
 	return pericarpdomain.NewEntityEvent("container", EventTypeMemberAdded, containerID, "", "", data)
@@ -90,3 +114,15 @@ func NewMemberAddedEvent(containerID string, data interface{}) *EntityEvent {
 func NewMemberRemovedEvent(containerID string, data interface{}) *EntityEvent {
 	return pericarpdomain.NewEntityEvent("container", EventTypeMemberRemoved, containerID, "", "", data)
 }
+
+// Read audit event constructors
+
+// NewContainerReadEvent creates a new container read audit event
+func NewContainerReadEvent(containerID string, data *ReadAuditEventData) *EntityEvent {
+	return pericarpdomain.NewEntityEvent("container", EventTypeContainerRead, containerID, data.UserID, data.AccountID, data)
+}
+
+// NewResourceReadEvent creates a new resource read audit event
+func NewResourceReadEvent(resourceID string, data *ReadAuditEventData) *EntityEvent {
+	return pericarpdomain.NewEntityEvent("resource", EventTypeResourceRead, resourceID, data.UserID, data.AccountID, data)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultIDPattern matches IDs made only of characters a URI path segment may hold unescaped:
+// letters, digits and - . _ ~ ! $ & ' ( ) * + , ; = : @. Slashes, spaces, percent signs and
+// control characters are left out, as they change how the ID's path is resolved.
+const DefaultIDPattern = `[A-Za-z0-9._~!$&'()*+,;=:@-]+`
+
+// defaultIDValidator checks IDs against DefaultIDPattern
+var defaultIDValidator = &IDValidator{pattern: regexp.MustCompile(`^(?:` + DefaultIDPattern + `)$`)}
+
+// IDValidator checks that resource and container IDs match a URI-safe pattern, so every ID
+// can be used as a path segment as it is
+type IDValidator struct {
+	pattern *regexp.Regexp
+}
+
+// NewIDValidator creates a validator for IDs matching pattern as a whole. An empty pattern
+// means DefaultIDPattern.
+func NewIDValidator(pattern string) (*IDValidator, error) {
+	if pattern == "" {
+		return defaultIDValidator, nil
+	}
+	compiled, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID pattern: %w", err)
+	}
+	return &IDValidator{pattern: compiled}, nil
+}
+
+// DefaultIDValidator returns the validator checking IDs against DefaultIDPattern
+func DefaultIDValidator() *IDValidator {
+	return defaultIDValidator
+}
+
+// ValidateID refuses, with ErrInvalidID, an empty ID, the dot segments "." and "..", and an ID
+// not matching the pattern. A nil validator checks against DefaultIDPattern.
+func (v *IDValidator) ValidateID(id string) error {
+	if v == nil {
+		v = defaultIDValidator
+	}
+
+	switch {
+	case id == "":
+		return WrapStorageError(fmt.Errorf("ID cannot be empty"), ErrInvalidID.Code, "ID cannot be empty")
+	case id == "." || id == "..":
+		return WrapStorageError(fmt.Errorf("ID %q is a dot segment", id), ErrInvalidID.Code,
+			"ID cannot be a relative path segment").WithContext("id", id)
+	case !v.pattern.MatchString(id):
+		return WrapStorageError(fmt.Errorf("ID %q does not match %s", id, v.pattern), ErrInvalidID.Code,
+			"ID contains characters that are not URI-safe").WithContext("id", id)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestIDValidator_DefaultPattern(t *testing.T) {
+	validator := DefaultIDValidator()
+
+	tests := []struct {
+		name        string
+		id          string
+		expectError bool
+	}{
+		{name: "letters and digits", id: "photo123"},
+		{name: "unreserved punctuation", id: "my-photo_v1.2~draft"},
+		{name: "sub-delimiters", id: "a+b=c;d,e!f$g&h'i(j)k*l:m@n"},
+		{name: "empty", id: "", expectError: true},
+		{name: "dot segment", id: ".", expectError: true},
+		{name: "double dot segment", id: "..", expectError: true},
+		{name: "space", id: "my photo", expectError: true},
+		{name: "slash", id: "photos/cat", expectError: true},
+		{name: "percent", id: "100%", expectError: true},
+		{name: "question mark", id: "photo?size=large", expectError: true},
+		{name: "hash", id: "photo#me", expectError: true},
+		{name: "control character", id: "photo\n", expectError: true},
+		{name: "non-ASCII", id: "café", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateID(tt.id)
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("expected %q to be valid, got %v", tt.id, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected %q to be refused", tt.id)
+			}
+			if !IsInvalidID(err) {
+				t.Errorf("expected ErrInvalidID, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIDValidator_CustomPattern(t *testing.T) {
+	validator, err := NewIDValidator(`[a-z0-9-]+`)
+	if err != nil {
+		t.Fatalf("NewIDValidator returned error: %v", err)
+	}
+
+	if err := validator.ValidateID("photo-1"); err != nil {
+		t.Errorf("expected photo-1 to be valid, got %v", err)
+	}
+	// The pattern must match the whole ID, not just part of it
+	if err := validator.ValidateID("Photo-1"); !IsInvalidID(err) {
+		t.Errorf("expected Photo-1 to be refused with ErrInvalidID, got %v", err)
+	}
+	if err := validator.ValidateID("photo.1"); !IsInvalidID(err) {
+		t.Errorf("expected photo.1 to be refused with ErrInvalidID, got %v", err)
+	}
+}
+
+func TestIDValidator_InvalidPattern(t *testing.T) {
+	if _, err := NewIDValidator(`[a-z`); err == nil {
+		t.Error("expected an invalid pattern to be refused")
+	}
+}
+
+func TestIDValidator_NilUsesDefault(t *testing.T) {
+	var validator *IDValidator
+	if err := validator.ValidateID("photo"); err != nil {
+		t.Errorf("expected photo to be valid, got %v", err)
+	}
+	if err := validator.ValidateID("a b"); !IsInvalidID(err) {
+		t.Errorf("expected a b to be refused with ErrInvalidID, got %v", err)
+	}
+}
+
+func TestContainerValidator_CustomIDPattern(t *testing.T) {
+	ids, err := NewIDValidator(`[a-z]+`)
+	if err != nil {
+		t.Fatalf("NewIDValidator returned error: %v", err)
+	}
+	validator := NewContainerValidator()
+	validator.SetIDValidator(ids)
+
+	if err := validator.ValidateContainerID("photos"); err != nil {
+		t.Errorf("expected photos to be valid, got %v", err)
+	}
+	if err := validator.ValidateContainerID("photos-2024"); !IsInvalidID(err) {
+		t.Errorf("expected photos-2024 to be refused with ErrInvalidID, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// IdentifierIndex records the Dublin Core identifier of each resource and container by the
+// account it belongs to, so accounts that treat identifiers as keys can keep them unique
+type IdentifierIndex interface {
+	// SetIdentifier records a resource's identifier, replacing its earlier one. When unique is
+	// set, an identifier another resource of the account holds is refused with
+	// ErrDuplicateIdentifier and nothing is recorded.
+	SetIdentifier(ctx context.Context, accountID, resourceID, identifier string, unique bool) error
+	// RemoveIdentifier drops a deleted resource's identifier; removing one never recorded does
+	// nothing
+	RemoveIdentifier(ctx context.Context, resourceID string) error
+}
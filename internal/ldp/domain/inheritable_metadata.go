@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Metadata keys used for container metadata inherited by members
+const (
+	// InheritableMetadataKey holds the key/value pairs a container passes on to its members
+	InheritableMetadataKey = "inheritableMetadata"
+	// InheritOnMoveKey holds whether members moved into the container take on its inheritable metadata
+	InheritOnMoveKey = "inheritOnMove"
+	// InheritedFromKey records on a resource which container its inherited metadata came from
+	InheritedFromKey = "inheritedFrom"
+	// InheritedKeysKey records on a resource which of its metadata keys were inherited
+	InheritedKeysKey = "inheritedKeys"
+)
+
+// reservedMetadataKeys are server-managed and can never be inherited
+var reservedMetadataKeys = map[string]bool{
+	"createdAt":      true,
+	"updatedAt":      true,
+	"contentType":    true,
+	"type":           true,
+	"parentID":       true,
+	InheritedFromKey: true,
+	InheritedKeysKey: true,
+}
+
+// ValidateInheritableMetadata checks that no inheritable key collides with server-managed metadata
+func ValidateInheritableMetadata(values map[string]interface{}) error {
+	for key := range values {
+		if key == "" {
+			return fmt.Errorf("inheritable metadata key cannot be empty")
+		}
+		if reservedMetadataKeys[key] {
+			return fmt.Errorf("metadata key %q is server-managed and cannot be inherited", key)
+		}
+	}
+	return nil
+}
+
+// SetInheritableMetadata sets the metadata members inherit from the container and whether
+// members moved into the container re-inherit it (true) or preserve what they had (false)
+func (c *Container) SetInheritableMetadata(values map[string]interface{}, inheritOnMove bool) {
+	inheritable := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		inheritable[key] = value
+	}
+
+	c.SetMetadata(InheritableMetadataKey, inheritable)
+	c.SetMetadata(InheritOnMoveKey, inheritOnMove)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		InheritableMetadataKey: inheritable,
+		InheritOnMoveKey:       inheritOnMove,
+		"updatedAt":            time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// GetInheritableMetadata returns the metadata members inherit from the container
+func (c *Container) GetInheritableMetadata() map[string]interface{} {
+	return InheritableMetadata(c.GetMetadata())
+}
+
+// InheritsOnMove returns whether members moved into the container re-inherit its metadata
+func (c *Container) InheritsOnMove() bool {
+	return InheritOnMoveEnabled(c.GetMetadata())
+}
+
+// InheritableMetadata extracts the inheritable key/value pairs from container metadata
+func InheritableMetadata(metadata map[string]interface{}) map[string]interface{} {
+	values, ok := metadata[InheritableMetadataKey].(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+
+	inheritable := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		inheritable[key] = value
+	}
+	return inheritable
+}
+
+// InheritOnMoveEnabled reports whether container metadata opts in to re-inheritance on move
+func InheritOnMoveEnabled(metadata map[string]interface{}) bool {
+	enabled, ok := metadata[InheritOnMoveKey].(bool)
+	return ok && enabled
+}
+
+// InheritedResourceMetadata returns the metadata a resource carries when it inherits from a
+// container, including the origin needed to replace it if the resource later moves
+func InheritedResourceMetadata(containerID string, inherited map[string]interface{}) map[string]interface{} {
+	if len(inherited) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]interface{}, len(inherited)+2)
+	keys := make([]string, 0, len(inherited))
+	for key, value := range inherited {
+		if reservedMetadataKeys[key] {
+			continue
+		}
+		metadata[key] = value
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metadata[InheritedFromKey] = containerID
+	metadata[InheritedKeysKey] = keys
+	return metadata
+}
+
+// ApplyInheritedMetadata stores a container's inheritable metadata on a resource
+func ApplyInheritedMetadata(resource Resource, containerID string, inherited map[string]interface{}) {
+	for key, value := range InheritedResourceMetadata(containerID, inherited) {
+		resource.SetMetadata(key, value)
+	}
+}
+
+// ReapplyInheritedMetadata replaces the metadata a resource previously inherited with the
+// inheritable metadata of the container it now belongs to
+func ReapplyInheritedMetadata(resource Resource, containerID string, inherited map[string]interface{}) {
+	metadata := resource.GetMetadata()
+	for _, key := range InheritedKeys(metadata) {
+		delete(metadata, key)
+	}
+	delete(metadata, InheritedFromKey)
+	delete(metadata, InheritedKeysKey)
+
+	ApplyInheritedMetadata(resource, containerID, inherited)
+}
+
+// InheritedKeys returns the metadata keys a resource inherited from its container
+func InheritedKeys(metadata map[string]interface{}) []string {
+	switch keys := metadata[InheritedKeysKey].(type) {
+	case []string:
+		return keys
+	case []interface{}:
+		// Metadata decoded from JSON storage yields generic slices
+		result := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if s, ok := key.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_SetInheritableMetadata(t *testing.T) {
+	ctx := context.Background()
+	container := NewContainer(ctx, "projects", "", BasicContainer)
+	container.MarkEventsAsCommitted()
+
+	container.SetInheritableMetadata(map[string]interface{}{"project": "apollo"}, true)
+
+	assert.Equal(t, map[string]interface{}{"project": "apollo"}, container.GetInheritableMetadata())
+	assert.True(t, container.InheritsOnMove())
+
+	events := container.UncommittedEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeContainerUpdated, events[0].(*EntityEvent).Type)
+}
+
+func TestApplyInheritedMetadata(t *testing.T) {
+	ctx := context.Background()
+	resource := NewResource(ctx, "doc", "application/json", []byte(`{}`))
+
+	ApplyInheritedMetadata(resource, "projects", map[string]interface{}{
+		"retention": "P30D",
+		"project":   "apollo",
+	})
+
+	metadata := resource.GetMetadata()
+	assert.Equal(t, "apollo", metadata["project"])
+	assert.Equal(t, "P30D", metadata["retention"])
+	assert.Equal(t, "projects", metadata[InheritedFromKey])
+	assert.Equal(t, []string{"project", "retention"}, InheritedKeys(metadata))
+}
+
+func TestReapplyInheritedMetadata_ReplacesPreviouslyInheritedKeys(t *testing.T) {
+	ctx := context.Background()
+	resource := NewResource(ctx, "doc", "application/json", []byte(`{}`))
+	resource.SetMetadata("owner", "alice")
+	ApplyInheritedMetadata(resource, "projects", map[string]interface{}{"project": "apollo", "retention": "P30D"})
+
+	ReapplyInheritedMetadata(resource, "archive", map[string]interface{}{"retention": "P10Y"})
+
+	metadata := resource.GetMetadata()
+	assert.NotContains(t, metadata, "project")
+	assert.Equal(t, "P10Y", metadata["retention"])
+	assert.Equal(t, "alice", metadata["owner"], "metadata that was not inherited must be kept")
+	assert.Equal(t, "archive", metadata[InheritedFromKey])
+}
+
+func TestInheritedKeys_DecodedFromJSON(t *testing.T) {
+	metadata := map[string]interface{}{InheritedKeysKey: []interface{}{"project", "retention"}}
+	assert.Equal(t, []string{"project", "retention"}, InheritedKeys(metadata))
+}
+
+func TestValidateInheritableMetadata_RejectsServerManagedKeys(t *testing.T) {
+	assert.NoError(t, ValidateInheritableMetadata(map[string]interface{}{"project": "apollo"}))
+	assert.Error(t, ValidateInheritableMetadata(map[string]interface{}{"createdAt": "now"}))
+	assert.Error(t, ValidateInheritableMetadata(map[string]interface{}{InheritedFromKey: "x"}))
+	assert.Error(t, ValidateInheritableMetadata(map[string]interface{}{"": "x"}))
+}
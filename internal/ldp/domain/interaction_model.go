@@ -0,0 +1,62 @@
+package domain
+
+// LDPNamespace is the namespace of the Linked Data Platform vocabulary
+const LDPNamespace = "http://www.w3.org/ns/ldp#"
+
+// InteractionModelKey holds the LDP interaction model a resource was stored with
+const InteractionModelKey = "interactionModel"
+
+// InteractionModel represents how LDP clients should interact with a resource
+type InteractionModel string
+
+const (
+	RDFSourceModel    InteractionModel = "RDFSource"
+	NonRDFSourceModel InteractionModel = "NonRDFSource"
+)
+
+// String returns the string representation of the interaction model
+func (m InteractionModel) String() string {
+	return string(m)
+}
+
+// IsValid checks if the interaction model is valid
+func (m InteractionModel) IsValid() bool {
+	switch m {
+	case RDFSourceModel, NonRDFSourceModel:
+		return true
+	default:
+		return false
+	}
+}
+
+// URI returns the LDP vocabulary URI of the interaction model
+func (m InteractionModel) URI() string {
+	return LDPNamespace + string(m)
+}
+
+// InteractionModelForContentType returns the interaction model implied by a content type
+func InteractionModelForContentType(contentType string) InteractionModel {
+	if IsRDFFormat(contentType) {
+		return RDFSourceModel
+	}
+	return NonRDFSourceModel
+}
+
+// ResourceInteractionModel returns the interaction model of a resource. The model recorded
+// when the resource was stored wins; resources stored before it was recorded fall back to
+// their original content type, so a converted representation keeps the stored model.
+func ResourceInteractionModel(resource Resource) InteractionModel {
+	metadata := resource.GetMetadata()
+
+	if model, ok := metadata[InteractionModelKey].(string); ok && InteractionModel(model).IsValid() {
+		return InteractionModel(model)
+	}
+
+	for _, key := range []string{"originalFormat", "convertedFrom"} {
+		if contentType, ok := metadata[key].(string); ok && contentType != "" {
+			return InteractionModelForContentType(contentType)
+		}
+	}
+
+	return InteractionModelForContentType(resource.GetContentType())
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResourceInteractionModel(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		resource *BasicResource
+		metadata map[string]interface{}
+		expected InteractionModel
+	}{
+		{"turtle resource", NewResource(ctx, "a", "text/turtle", []byte("<a> <b> <c> .")), nil, RDFSourceModel},
+		{"binary resource", NewResource(ctx, "b", "image/png", []byte{0x89}), nil, NonRDFSourceModel},
+		{
+			"stored model wins over content type",
+			NewResource(ctx, "c", "application/ld+json", []byte("{}")),
+			map[string]interface{}{InteractionModelKey: "NonRDFSource"},
+			NonRDFSourceModel,
+		},
+		{
+			"converted representation keeps original model",
+			NewResource(ctx, "d", "application/ld+json", []byte("{}")),
+			map[string]interface{}{"convertedFrom": "text/turtle"},
+			RDFSourceModel,
+		},
+		{
+			"unknown stored model falls back to content type",
+			NewResource(ctx, "e", "text/plain", []byte("hello")),
+			map[string]interface{}{InteractionModelKey: "Container"},
+			NonRDFSourceModel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.metadata {
+				tt.resource.SetMetadata(key, value)
+			}
+			if got := ResourceInteractionModel(tt.resource); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInteractionModelURI(t *testing.T) {
+	if NonRDFSourceModel.URI() != "http://www.w3.org/ns/ldp#NonRDFSource" {
+		t.Errorf("Unexpected URI: %s", NonRDFSourceModel.URI())
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LangLiteral represents a language-tagged RDF literal
+type LangLiteral struct {
+	Value    string `json:"value"`
+	Language string `json:"language"`
+}
+
+// LanguagePreference represents a single entry of an Accept-Language header
+type LanguagePreference struct {
+	Tag     string  `json:"tag"`
+	Quality float64 `json:"quality"`
+}
+
+// ParseAcceptLanguage parses an Accept-Language header into preferences ordered by quality.
+// Entries with q=0 are dropped; ties keep the order given by the client.
+func ParseAcceptLanguage(header string) []LanguagePreference {
+	var prefs []LanguagePreference
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag := part
+		quality := 1.0
+		if idx := strings.Index(part, ";"); idx != -1 {
+			tag = strings.TrimSpace(part[:idx])
+			params := strings.TrimSpace(part[idx+1:])
+			if strings.HasPrefix(params, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		if tag == "" || quality <= 0 {
+			continue
+		}
+
+		prefs = append(prefs, LanguagePreference{Tag: strings.ToLower(tag), Quality: quality})
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].Quality > prefs[j].Quality
+	})
+
+	return prefs
+}
+
+// WantsAllLanguages reports whether the preferences only contain the wildcard,
+// meaning the client wants every language-tagged literal
+func WantsAllLanguages(prefs []LanguagePreference) bool {
+	return len(prefs) == 1 && prefs[0].Tag == "*"
+}
+
+// SelectLangLiteral selects the literal that best matches the language preferences.
+// Matching follows RFC 4647 lookup: an exact tag match wins, then a literal whose
+// tag is a more specific form of the preference (en matches en-GB), then a literal
+// whose tag is a prefix of the preference (en-GB falls back to en).
+func SelectLangLiteral(literals []LangLiteral, prefs []LanguagePreference) (LangLiteral, bool) {
+	if len(literals) == 0 {
+		return LangLiteral{}, false
+	}
+
+	for _, pref := range prefs {
+		if pref.Tag == "*" {
+			return literals[0], true
+		}
+
+		for _, literal := range literals {
+			if strings.EqualFold(literal.Language, pref.Tag) {
+				return literal, true
+			}
+		}
+
+		for _, literal := range literals {
+			if strings.HasPrefix(strings.ToLower(literal.Language), pref.Tag+"-") {
+				return literal, true
+			}
+		}
+
+		for _, literal := range literals {
+			if strings.HasPrefix(pref.Tag, strings.ToLower(literal.Language)+"-") {
+				return literal, true
+			}
+		}
+	}
+
+	return LangLiteral{}, false
+}
+
+// localizedMetadataKey returns the metadata key for a language-tagged field
+func localizedMetadataKey(field, language string) string {
+	return field + "@" + strings.ToLower(language)
+}
+
+// LocalizedLiterals collects the language-tagged values of a metadata field such as "title"
+func LocalizedLiterals(metadata map[string]interface{}, field string) []LangLiteral {
+	prefix := field + "@"
+	var literals []LangLiteral
+
+	for key, value := range metadata {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if str, ok := value.(string); ok && str != "" {
+			literals = append(literals, LangLiteral{Value: str, Language: strings.TrimPrefix(key, prefix)})
+		}
+	}
+
+	sort.Slice(literals, func(i, j int) bool {
+		return literals[i].Language < literals[j].Language
+	})
+
+	return literals
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	prefs := ParseAcceptLanguage("fr;q=0.5, en-GB, de;q=0, *;q=0.1")
+
+	if len(prefs) != 3 {
+		t.Fatalf("Expected 3 preferences, got %d: %v", len(prefs), prefs)
+	}
+	if prefs[0].Tag != "en-gb" || prefs[1].Tag != "fr" || prefs[2].Tag != "*" {
+		t.Errorf("Unexpected preference order: %v", prefs)
+	}
+}
+
+func TestSelectLangLiteral(t *testing.T) {
+	literals := []LangLiteral{
+		{Value: "Documents", Language: "en"},
+		{Value: "Dokumente", Language: "de-AT"},
+		{Value: "Documentos", Language: "es"},
+	}
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+		found    bool
+	}{
+		{"exact match", "es", "Documentos", true},
+		{"more specific preference falls back", "en-US", "Documents", true},
+		{"less specific preference matches regional literal", "de", "Dokumente", true},
+		{"quality ordering", "fr, es;q=0.4, en;q=0.8", "Documents", true},
+		{"wildcard", "*", "Documents", true},
+		{"no match", "ja", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			literal, found := SelectLangLiteral(literals, ParseAcceptLanguage(tt.header))
+			if found != tt.found {
+				t.Fatalf("Expected found=%v, got %v", tt.found, found)
+			}
+			if literal.Value != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, literal.Value)
+			}
+		})
+	}
+}
+
+func TestContainer_LocalizedTitles(t *testing.T) {
+	container := NewContainer(context.Background(), "test-container", "", BasicContainer)
+	container.SetTitle("Untagged")
+	container.SetLocalizedTitle("fr", "Titre")
+	container.SetLocalizedTitle("EN", "Title")
+	container.SetLocalizedDescription("fr", "Description en français")
+
+	titles := container.GetLocalizedTitles()
+	if len(titles) != 2 {
+		t.Fatalf("Expected 2 localized titles, got %d", len(titles))
+	}
+	if titles[0].Language != "en" || titles[0].Value != "Title" {
+		t.Errorf("Expected normalized en title first, got %+v", titles[0])
+	}
+	if container.GetTitle() != "Untagged" {
+		t.Errorf("Expected untagged title to be preserved, got %q", container.GetTitle())
+	}
+	if len(container.GetLocalizedDescriptions()) != 1 {
+		t.Errorf("Expected 1 localized description, got %d", len(container.GetLocalizedDescriptions()))
+	}
+}
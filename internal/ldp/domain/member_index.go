@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IndexedMember is a container member as recorded in the membership index
+type IndexedMember struct {
+	ID   string
+	Type string
+	// Title is the member's dcterms:title; empty when the index does not record one
+	Title       string
+	ContentType string
+	// Size is the member's size in bytes; zero when the index does not track it
+	Size      int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// LastAccessedAt is when the member was last read; zero when access tracking is off or
+	// no read has been recorded
+	LastAccessedAt time.Time
+}
+
+// MemberIndexSource lists a container's members as recorded in the membership index
+type MemberIndexSource interface {
+	ListIndexedMembers(ctx context.Context, containerID string) ([]IndexedMember, error)
+}
+
+// MemberContainerSource lists the containers a resource is a member of, as recorded in the
+// membership index
+type MemberContainerSource interface {
+	ListMemberContainers(ctx context.Context, memberID string) ([]string, error)
+}
+
+// PagedMemberIndexSource reads a container's indexed members one page at a time, in the
+// index's order, so large containers can be walked without loading every member
+type PagedMemberIndexSource interface {
+	ListIndexedMembersPage(ctx context.Context, containerID string, pagination PaginationOptions) ([]IndexedMember, error)
+}
+
+// StoredCountSource counts the containers and resources recorded in the membership index
+type StoredCountSource interface {
+	StoredCounts(ctx context.Context) (containers, resources int64, err error)
+}
+
+// ContainerEmptinessChecker reports whether a container has members without loading its
+// member list
+type ContainerEmptinessChecker interface {
+	IsContainerEmpty(ctx context.Context, containerID string) (bool, error)
+}
+
+// CountedMemberLister lists one page of a container's members along with the container's
+// total member count, so listings can tell clients where the last page is
+type CountedMemberLister interface {
+	ListMembersWithTotal(ctx context.Context, containerID string, pagination PaginationOptions) ([]string, int, error)
+}
+
+// MemberDetailsRecorder records a member's content type, size and timestamps in the
+// membership index, so listings can filter and sort on them
+type MemberDetailsRecorder interface {
+	RecordMemberDetails(ctx context.Context, containerID string, member IndexedMember) error
+}
+
+// MembershipIndexReconciler brings a container's membership index in line with the members
+// its metadata records, indexing missing members and dropping entries for members the
+// container no longer holds
+type MembershipIndexReconciler interface {
+	ReconcileMembershipIndex(ctx context.Context, containerID string) (added, removed int, err error)
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// HasMemberRelationKey holds the ldp:hasMemberRelation of a Direct or Indirect container
+	HasMemberRelationKey = "hasMemberRelation"
+	// InsertedContentRelationKey holds the ldp:insertedContentRelation of an IndirectContainer
+	InsertedContentRelationKey = "insertedContentRelation"
+
+	// LDPContains is the containment predicate BasicContainers use for their members
+	LDPContains = "http://www.w3.org/ns/ldp#contains"
+	// LDPMemberSubject is the inserted content relation naming the member itself
+	LDPMemberSubject = "http://www.w3.org/ns/ldp#MemberSubject"
+)
+
+// SetMembershipRelations sets the predicate of the container's membership triples and, for an
+// IndirectContainer, the predicate naming the member's inserted content. Empty values clear them.
+func (c *Container) SetMembershipRelations(hasMemberRelation, insertedContentRelation string) {
+	c.SetMetadata(HasMemberRelationKey, hasMemberRelation)
+	c.SetMetadata(InsertedContentRelationKey, insertedContentRelation)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		HasMemberRelationKey:       hasMemberRelation,
+		InsertedContentRelationKey: insertedContentRelation,
+		"updatedAt":                time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// GetHasMemberRelation returns the container's ldp:hasMemberRelation, if any
+func (c *Container) GetHasMemberRelation() string {
+	return HasMemberRelation(c.GetMetadata())
+}
+
+// GetInsertedContentRelation returns the container's ldp:insertedContentRelation, if any
+func (c *Container) GetInsertedContentRelation() string {
+	return InsertedContentRelation(c.GetMetadata())
+}
+
+// HasMemberRelation extracts the ldp:hasMemberRelation from container metadata
+func HasMemberRelation(metadata map[string]interface{}) string {
+	relation, _ := metadata[HasMemberRelationKey].(string)
+	return relation
+}
+
+// InsertedContentRelation extracts the ldp:insertedContentRelation from container metadata
+func InsertedContentRelation(metadata map[string]interface{}) string {
+	relation, _ := metadata[InsertedContentRelationKey].(string)
+	return relation
+}
+
+// EffectiveInsertedContentRelation returns the inserted content relation that applies to a
+// container's members. DirectContainers always use ldp:MemberSubject.
+func EffectiveInsertedContentRelation(container ContainerResource) string {
+	if container.GetContainerType() == IndirectContainer {
+		return InsertedContentRelation(container.GetMetadata())
+	}
+	return LDPMemberSubject
+}
+
+// ValidateMembershipRelations checks that a container carries the membership predicates its type
+// requires: ldp:membershipResource and ldp:hasMemberRelation for Direct and Indirect containers,
+// and ldp:insertedContentRelation for IndirectContainers
+func ValidateMembershipRelations(container ContainerResource) error {
+	containerType := container.GetContainerType()
+	if !containerType.HasMembershipTriples() {
+		return nil
+	}
+
+	metadata := container.GetMetadata()
+	var missing []string
+	if MembershipResource(metadata) == "" {
+		missing = append(missing, "ldp:membershipResource")
+	}
+	if HasMemberRelation(metadata) == "" {
+		missing = append(missing, "ldp:hasMemberRelation")
+	}
+	if containerType == IndirectContainer && InsertedContentRelation(metadata) == "" {
+		missing = append(missing, "ldp:insertedContentRelation")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return WrapStorageError(
+		fmt.Errorf("%s requires %s", containerType, strings.Join(missing, ", ")),
+		ErrMissingMembershipRelation.Code,
+		fmt.Sprintf("%s is missing %s", containerType, strings.Join(missing, ", ")),
+	).WithContext("containerID", container.ID())
+}
+
+// MembershipTriple is a triple a Direct or Indirect container asserts about its membership
+// resource when a member is added
+type MembershipTriple struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+}
+
+// String renders the triple as an N-Triples statement, which is also valid Turtle
+func (t MembershipTriple) String() string {
+	return fmt.Sprintf("<%s> <%s> <%s> .", t.Subject, t.Predicate, t.Object)
+}
+
+// NewMembershipTriple builds the membership triple a Direct or Indirect container asserts for a
+// member. memberIRI names the member; for an IndirectContainer whose inserted content relation is
+// not ldp:MemberSubject the object is read from the member's content instead.
+func NewMembershipTriple(container ContainerResource, memberIRI string, data []byte, contentType string) (MembershipTriple, error) {
+	if !container.GetContainerType().HasMembershipTriples() {
+		return MembershipTriple{}, WrapStorageError(
+			fmt.Errorf("%s has no membership triples", container.GetContainerType()),
+			ErrInvalidContainerType.Code,
+			"membership triples require a Direct or Indirect container",
+		).WithOperation("NewMembershipTriple").WithContext("containerID", container.ID())
+	}
+	if err := ValidateMembershipRelations(container); err != nil {
+		return MembershipTriple{}, err
+	}
+
+	object := memberIRI
+	if relation := EffectiveInsertedContentRelation(container); relation != LDPMemberSubject {
+		content, ok := InsertedContent(data, contentType, relation)
+		if !ok {
+			return MembershipTriple{}, WrapStorageError(
+				fmt.Errorf("member content has no %s", relation),
+				ErrMissingInsertedContent.Code,
+				ErrMissingInsertedContent.Message,
+			).WithOperation("NewMembershipTriple").WithContext("containerID", container.ID()).WithContext("insertedContentRelation", relation)
+		}
+		object = content
+	}
+
+	metadata := container.GetMetadata()
+	return MembershipTriple{
+		Subject:   MembershipResource(metadata),
+		Predicate: HasMemberRelation(metadata),
+		Object:    object,
+	}, nil
+}
+
+// InsertedContent finds the IRI a member's content gives for an inserted content relation. Turtle
+// and N-Triples statements must spell the predicate as a full IRI; JSON-LD is searched for the
+// predicate as a key of the top-level node.
+func InsertedContent(data []byte, contentType, relation string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "text/turtle", "application/n-triples":
+		pattern := regexp.MustCompile(`<` + regexp.QuoteMeta(relation) + `>\s*<([^>]*)>`)
+		match := pattern.FindSubmatch(data)
+		if match == nil {
+			return "", false
+		}
+		return string(match[1]), true
+	case "application/ld+json":
+		var node map[string]interface{}
+		if err := json.Unmarshal(data, &node); err != nil {
+			return "", false
+		}
+		return jsonLDReference(node[relation])
+	default:
+		return "", false
+	}
+}
+
+// jsonLDReference returns the IRI of a JSON-LD value, taking the first of several values
+func jsonLDReference(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case map[string]interface{}:
+		id, _ := v["@id"].(string)
+		return id, id != ""
+	case []interface{}:
+		if len(v) > 0 {
+			return jsonLDReference(v[0])
+		}
+	}
+	return "", false
+}
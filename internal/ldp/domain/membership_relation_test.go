@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLikes   = "http://example.org/vocab#likes"
+	testPrimary = "http://example.org/vocab#primaryTopic"
+)
+
+func TestValidateMembershipRelations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("basic containers need nothing", func(t *testing.T) {
+		assert.NoError(t, ValidateMembershipRelations(NewContainer(ctx, "docs", "", BasicContainer)))
+	})
+
+	t.Run("direct containers need a membership resource and relation", func(t *testing.T) {
+		container := NewContainer(ctx, "likes", "alice", DirectContainer)
+		err := ValidateMembershipRelations(container)
+		assert.True(t, IsMissingMembershipRelation(err))
+		assert.Contains(t, err.Error(), "ldp:membershipResource")
+		assert.Contains(t, err.Error(), "ldp:hasMemberRelation")
+
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikes, "")
+		assert.NoError(t, ValidateMembershipRelations(container))
+	})
+
+	t.Run("indirect containers also need an inserted content relation", func(t *testing.T) {
+		container := NewContainer(ctx, "topics", "alice", IndirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikes, "")
+		err := ValidateMembershipRelations(container)
+		assert.True(t, IsMissingMembershipRelation(err))
+		assert.Contains(t, err.Error(), "ldp:insertedContentRelation")
+
+		container.SetMembershipRelations(testLikes, testPrimary)
+		assert.NoError(t, ValidateMembershipRelations(container))
+	})
+}
+
+func TestNewMembershipTriple(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("direct containers name the member", func(t *testing.T) {
+		container := NewContainer(ctx, "likes", "alice", DirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikes, "")
+
+		triple, err := NewMembershipTriple(container, "/resources/like-1", nil, "text/plain")
+		require.NoError(t, err)
+		assert.Equal(t, MembershipTriple{Subject: "/resources/profile", Predicate: testLikes, Object: "/resources/like-1"}, triple)
+		assert.Equal(t, "</resources/profile> <"+testLikes+"> </resources/like-1> .", triple.String())
+	})
+
+	t.Run("indirect containers name the inserted content", func(t *testing.T) {
+		container := NewContainer(ctx, "topics", "alice", IndirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikes, testPrimary)
+
+		turtle := []byte("<> <" + testPrimary + "> <https://example.org/topics/go> .")
+		triple, err := NewMembershipTriple(container, "/resources/doc-1", turtle, "text/turtle")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.org/topics/go", triple.Object)
+
+		jsonld := []byte(`{"@id":"","` + testPrimary + `":{"@id":"https://example.org/topics/rdf"}}`)
+		triple, err = NewMembershipTriple(container, "/resources/doc-2", jsonld, "application/ld+json")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.org/topics/rdf", triple.Object)
+
+		_, err = NewMembershipTriple(container, "/resources/doc-3", []byte("<> <http://example.org/other> <x> ."), "text/turtle")
+		assert.True(t, IsMissingInsertedContent(err))
+	})
+
+	t.Run("indirect containers using the member subject name the member", func(t *testing.T) {
+		container := NewContainer(ctx, "topics", "alice", IndirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(testLikes, LDPMemberSubject)
+
+		triple, err := NewMembershipTriple(container, "/resources/doc-1", nil, "text/plain")
+		require.NoError(t, err)
+		assert.Equal(t, "/resources/doc-1", triple.Object)
+	})
+
+	t.Run("basic containers have no membership triples", func(t *testing.T) {
+		_, err := NewMembershipTriple(NewContainer(ctx, "docs", "", BasicContainer), "/resources/a", nil, "text/plain")
+		assert.True(t, IsInvalidContainerType(err))
+	})
+}
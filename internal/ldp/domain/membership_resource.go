@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MembershipResourceKey holds a Direct or Indirect container's ldp:membershipResource reference
+const MembershipResourceKey = "membershipResource"
+
+// SetMembershipResource sets the resource a Direct or Indirect container's membership triples
+// are about. An empty reference clears it.
+func (c *Container) SetMembershipResource(reference string) {
+	c.SetMetadata(MembershipResourceKey, reference)
+	c.SetMetadata("updatedAt", time.Now())
+
+	// Emit update event
+	event := NewContainerUpdatedEvent(c.ID(), map[string]interface{}{
+		MembershipResourceKey: reference,
+		"updatedAt":           time.Now(),
+	})
+	c.AddEvent(event)
+}
+
+// GetMembershipResource returns the container's ldp:membershipResource reference, if any
+func (c *Container) GetMembershipResource() string {
+	return MembershipResource(c.GetMetadata())
+}
+
+// MembershipResource extracts the ldp:membershipResource reference from container metadata
+func MembershipResource(metadata map[string]interface{}) string {
+	reference, _ := metadata[MembershipResourceKey].(string)
+	return reference
+}
+
+// IsExternalReference reports whether a membership resource reference is an absolute URI
+// rather than a container or resource held by this server
+func IsExternalReference(reference string) bool {
+	parsed, err := url.Parse(reference)
+	return err == nil && parsed.IsAbs() && parsed.Host != ""
+}
+
+// LocalReferenceID returns the container or resource ID a local reference points at. Local
+// references may be a bare ID or a /containers/{id} or /resources/{id} path.
+func LocalReferenceID(reference string) string {
+	id := strings.Trim(strings.TrimSpace(reference), "/")
+	for _, prefix := range []string{"containers/", "resources/"} {
+		if strings.HasPrefix(id, prefix) {
+			return strings.TrimPrefix(id, prefix)
+		}
+	}
+	return id
+}
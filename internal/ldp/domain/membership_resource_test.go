@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_SetMembershipResource(t *testing.T) {
+	container := NewContainer(context.Background(), "likes", "alice", DirectContainer)
+	container.MarkEventsAsCommitted()
+
+	container.SetMembershipResource("/resources/profile")
+
+	assert.Equal(t, "/resources/profile", container.GetMembershipResource())
+	events := container.UncommittedEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeContainerUpdated, events[0].(*EntityEvent).Type)
+}
+
+func TestMembershipResourceReferences(t *testing.T) {
+	tests := []struct {
+		reference string
+		external  bool
+		localID   string
+	}{
+		{reference: "profile", localID: "profile"},
+		{reference: "/resources/profile", localID: "profile"},
+		{reference: "/containers/photos/", localID: "photos"},
+		{reference: "https://example.org/people/bob", external: true},
+		{reference: "urn:isbn:0451450523", localID: "urn:isbn:0451450523"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			assert.Equal(t, tt.external, IsExternalReference(tt.reference))
+			if !tt.external {
+				assert.Equal(t, tt.localID, LocalReferenceID(tt.reference))
+			}
+		})
+	}
+}
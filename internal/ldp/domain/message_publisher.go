@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// MessagePublisher publishes messages to the topics of an external message broker. Publish
+// returns only once the broker has accepted the message, so a nil error means it was handed
+// over and an error means it may have to be sent again.
+type MessagePublisher interface {
+	// Publish sends one message to a topic
+	Publish(ctx context.Context, topic string, message []byte) error
+	// Close releases the connection to the broker
+	Close() error
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Notification channel types of the Solid Notifications Protocol
+const (
+	// WebhookChannel2023 delivers each notification as a POST to the subscriber's sendTo URI
+	WebhookChannel2023 = "WebhookChannel2023"
+)
+
+// NotificationChannel is a subscription to the changes of a container. Topic is the URI the
+// subscriber named; notifications about the container's members are built relative to it.
+type NotificationChannel struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Topic       string `json:"topic"`
+	ContainerID string `json:"containerId"`
+	SendTo      string `json:"sendTo"`
+	// Owner is the agent that opened the channel; only it may read or close the channel, and
+	// notifications are only sent while it can still read the container
+	Owner     string    `json:"owner,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NotificationChannelStore holds the open notification channels
+type NotificationChannelStore interface {
+	// Save stores a channel, replacing any earlier one with the same ID
+	Save(ctx context.Context, channel NotificationChannel) error
+	// Get returns a channel, or ErrNotificationChannelNotFound
+	Get(ctx context.Context, id string) (NotificationChannel, error)
+	// Delete removes a channel, or returns ErrNotificationChannelNotFound
+	Delete(ctx context.Context, id string) error
+	// ListByContainer returns the channels subscribed to a container
+	ListByContainer(ctx context.Context, containerID string) ([]NotificationChannel, error)
+}
+
+// NotificationSender delivers a serialized notification over a channel
+type NotificationSender interface {
+	Send(ctx context.Context, channel NotificationChannel, notification []byte) error
+}
+
+// NotificationTargetChecker is implemented by senders that refuse some receivers, so channels
+// to them are refused when they are opened rather than failing on every delivery
+type NotificationTargetChecker interface {
+	CheckTarget(ctx context.Context, sendTo string) error
+}
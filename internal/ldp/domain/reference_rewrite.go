@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+)
+
+// RewriteIRIReferences replaces references to oldIRI in RDF content with newIRI and reports
+// whether anything changed. Only whole IRI references are replaced, <oldIRI> in Turtle and
+// N-Triples and "oldIRI" in JSON-LD and RDF/XML, so longer IRIs that merely start with oldIRI
+// and literals that mention it in passing are left alone. Content that is not RDF is returned
+// unchanged.
+func RewriteIRIReferences(data []byte, contentType, oldIRI, newIRI string) ([]byte, bool) {
+	if oldIRI == "" || oldIRI == newIRI || !IsRDFFormat(contentType) {
+		return data, false
+	}
+
+	var oldRef, newRef []byte
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "application/ld+json", "application/rdf+xml":
+		oldRef, newRef = []byte(`"`+oldIRI+`"`), []byte(`"`+newIRI+`"`)
+	default:
+		oldRef, newRef = []byte("<"+oldIRI+">"), []byte("<"+newIRI+">")
+	}
+
+	if !bytes.Contains(data, oldRef) {
+		return data, false
+	}
+	return bytes.ReplaceAll(data, oldRef, newRef), true
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteIRIReferences(t *testing.T) {
+	oldIRI := "https://pod.example/resources/note"
+	newIRI := "https://pod.example/resources/archived-note"
+
+	t.Run("rewrites turtle references", func(t *testing.T) {
+		data := []byte(`<https://pod.example/resources/index> <http://schema.org/hasPart> <https://pod.example/resources/note> .`)
+
+		rewritten, changed := RewriteIRIReferences(data, "text/turtle", oldIRI, newIRI)
+
+		assert.True(t, changed)
+		assert.Equal(t, `<https://pod.example/resources/index> <http://schema.org/hasPart> <https://pod.example/resources/archived-note> .`, string(rewritten))
+	})
+
+	t.Run("rewrites JSON-LD references", func(t *testing.T) {
+		data := []byte(`{"@id":"https://pod.example/resources/index","hasPart":{"@id":"https://pod.example/resources/note"}}`)
+
+		rewritten, changed := RewriteIRIReferences(data, "application/ld+json", oldIRI, newIRI)
+
+		assert.True(t, changed)
+		assert.Equal(t, `{"@id":"https://pod.example/resources/index","hasPart":{"@id":"https://pod.example/resources/archived-note"}}`, string(rewritten))
+	})
+
+	t.Run("leaves longer IRIs and literals alone", func(t *testing.T) {
+		data := []byte(`<https://pod.example/resources/notebook> <http://schema.org/name> "see https://pod.example/resources/note" .`)
+
+		rewritten, changed := RewriteIRIReferences(data, "text/turtle", oldIRI, newIRI)
+
+		assert.False(t, changed)
+		assert.Equal(t, string(data), string(rewritten))
+	})
+
+	t.Run("ignores non-RDF content", func(t *testing.T) {
+		data := []byte(`<https://pod.example/resources/note>`)
+
+		rewritten, changed := RewriteIRIReferences(data, "text/plain", oldIRI, newIRI)
+
+		assert.False(t, changed)
+		assert.Equal(t, string(data), string(rewritten))
+	})
+}
@@ -376,6 +376,26 @@ type StreamingResourceRepository interface {
 	RetrieveStream(ctx context.Context, id string) (io.ReadCloser, *ResourceMetadata, error)
 }
 
+// SeekableResourceRepository opens the stored content of a resource for random access, so
+// part of a large resource can be read without loading the rest of it. Content read this way
+// is not checked against the resource's checksum, which covers the whole content.
+type SeekableResourceRepository interface {
+	OpenContent(ctx context.Context, id string) (io.ReadSeekCloser, *ResourceMetadata, error)
+}
+
+// ExclusiveResourceRepository creates resources only under IDs that are not already taken.
+// Create is atomic, so of two concurrent creates with one ID exactly one succeeds and the
+// other fails with ErrResourceAlreadyExists.
+type ExclusiveResourceRepository interface {
+	Create(ctx context.Context, resource Resource) error
+}
+
+// BatchExistenceRepository reports which of many resources exist in one call, without
+// reading any of them
+type BatchExistenceRepository interface {
+	ExistsBatch(ctx context.Context, ids []string) (map[string]bool, error)
+}
+
 // ResourceMetadata represents metadata for a resource
 type ResourceMetadata struct {
 	ID             string                 `json:"id"`
@@ -393,3 +413,94 @@ type FormatConverter interface {
 	Convert(data []byte, fromFormat, toFormat string) ([]byte, error)
 	ValidateFormat(format string) bool
 }
+
+// RDFNormalizer defines the interface for canonicalizing RDF before it is stored
+type RDFNormalizer interface {
+	// Normalize returns the canonical form of the RDF data and the content type of that form
+	Normalize(data []byte, format string) ([]byte, string, error)
+	// CanNormalize reports whether data in the given format can be normalized
+	CanNormalize(format string) bool
+}
+
+// Media types of the patch documents RDF resources can be modified with
+const (
+	SPARQLUpdateMediaType = "application/sparql-update"
+	N3PatchMediaType      = "text/n3"
+)
+
+// ResourcePatcher applies patch documents of one media type, such as SPARQL Update or N3
+// Patch, to RDF documents
+type ResourcePatcher interface {
+	// ApplyPatch applies the patch to a Turtle document and returns the resulting document.
+	// Malformed or unsupported patches fail with ErrInvalidPatch, and patches whose
+	// conditions the document does not meet fail with ErrPatchConflict.
+	ApplyPatch(document []byte, patch string) ([]byte, error)
+}
+
+// TriplePattern selects the triples of an RDF graph by subject, predicate and object. Each
+// term is written in N-Triples syntax, such as <http://example.org/a> or "Alice"@en, or bare,
+// which matches an IRI or literal with that value. An empty term matches any term.
+type TriplePattern struct {
+	Subject   string
+	Predicate string
+	Object    string
+}
+
+// IsEmpty reports whether the pattern matches every triple
+func (p TriplePattern) IsEmpty() bool {
+	return p.Subject == "" && p.Predicate == "" && p.Object == ""
+}
+
+// TripleMatcher selects the triples of RDF documents matching a pattern
+type TripleMatcher interface {
+	// MatchTriples returns the triples of a Turtle document matching the pattern, as
+	// N-Triples. Terms that cannot be parsed fail with ErrInvalidTriplePattern.
+	MatchTriples(document []byte, pattern TriplePattern) ([]byte, error)
+}
+
+// ResourceVersionSource reads stored earlier versions of resources
+type ResourceVersionSource interface {
+	// RetrieveResourceVersion returns a resource as it was at the given version. Unknown
+	// versions fail with ErrResourceVersionNotFound.
+	RetrieveResourceVersion(ctx context.Context, id, version string) (Resource, error)
+}
+
+// ResourceVersionStore records the versions of resources as they are written
+type ResourceVersionStore interface {
+	ResourceVersionSource
+	// RecordResourceVersion stores a resource's current content as its next version and
+	// returns the version's name
+	RecordResourceVersion(ctx context.Context, resource Resource) (string, error)
+	// DeleteResourceVersions removes every stored version of a resource
+	DeleteResourceVersions(ctx context.Context, id string) error
+}
+
+// GraphDiff is the difference between two RDF graphs as N-Triples statements. Blank nodes are
+// labeled by their content, so an unchanged blank node keeps its label in both graphs.
+type GraphDiff struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// IsEmpty reports whether the two graphs hold the same statements
+func (d GraphDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// GraphDiffer compares RDF documents
+type GraphDiffer interface {
+	// DiffGraphs returns the statements of the Turtle document to that are not in from, and
+	// those of from that are not in to, treating equivalent literals and isomorphic blank
+	// node structures as equal
+	DiffGraphs(from, to []byte) (GraphDiff, error)
+}
+
+// GraphSizeLimiter bounds the number of triples in an RDF document
+type GraphSizeLimiter interface {
+	// CountTriples counts the statements of a document in the given RDF format as it reads
+	// it, and fails with ErrGraphTooLarge as soon as there are more than maxTriples, without
+	// reading or holding the rest of the document
+	CountTriples(r io.Reader, format string, maxTriples int) (int, error)
+}
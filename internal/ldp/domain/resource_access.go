@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StaleResource is a resource that has not been read since a cutoff
+type StaleResource struct {
+	ID          string
+	ContainerID string
+	// LastAccessedAt is zero when no read has been recorded since tracking was enabled
+	LastAccessedAt time.Time
+	CreatedAt      time.Time
+}
+
+// ResourceAccessStore persists when resources were last read, for lifecycle policies such as
+// archiving resources nobody uses
+type ResourceAccessStore interface {
+	// RecordResourceAccesses stores the given read times; an older time never replaces a newer one
+	RecordResourceAccesses(ctx context.Context, accesses map[string]time.Time) error
+	// FindStaleResources lists resources whose last read, or their creation when they were never
+	// read, is before the cutoff
+	FindStaleResources(ctx context.Context, cutoff time.Time) ([]StaleResource, error)
+	// ContainerLastAccessed returns the latest read of any member of a container; zero when none
+	// was recorded
+	ContainerLastAccessed(ctx context.Context, containerID string) (time.Time, error)
+}
@@ -65,6 +65,7 @@ type ContainerResource interface {
 	GetTitle() string
 	SetDescription(description string)
 	GetDescription() string
+	SetCacheControl(directives string)
 }
 
 // ResourceType represents the type of resource for polymorphic operations
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Search document types
+const (
+	SearchTypeContainer = "Container"
+	SearchTypeResource  = "Resource"
+)
+
+// SearchQuery selects pod members by their metadata. Every non-empty filter must match.
+type SearchQuery struct {
+	// Text is matched case-insensitively against the title and description
+	Text string `json:"text,omitempty"`
+	// ContentType matches the member's media type, ignoring parameters
+	ContentType string `json:"contentType,omitempty"`
+	// Tags must all be present on the member
+	Tags []string `json:"tags,omitempty"`
+	// MemberType restricts hits to "Container" or "Resource"
+	MemberType string `json:"memberType,omitempty"`
+}
+
+// SearchDocument is the indexed view of a container or resource
+type SearchDocument struct {
+	ID          string
+	Type        string
+	Title       string
+	Description string
+	Tags        []string
+	ContentType string
+	// ContainerID is the container holding the member; empty for pod roots and unfiled resources
+	ContainerID string
+	UpdatedAt   time.Time
+}
+
+// SearchIndex keeps search documents up to date as members are written
+type SearchIndex interface {
+	// Index adds or replaces a document. An empty ContainerID keeps the one already indexed.
+	Index(ctx context.Context, doc SearchDocument) error
+	// SetContainer records which container holds a member; an empty ID detaches it
+	SetContainer(ctx context.Context, id, containerID string) error
+	Remove(ctx context.Context, id string) error
+	// Get returns the document indexed for a member, reporting whether one exists
+	Get(ctx context.Context, id string) (SearchDocument, bool, error)
+	Search(ctx context.Context, query SearchQuery) ([]SearchDocument, error)
+}
+
+// Matches reports whether the document satisfies every filter in the query
+func (d SearchDocument) Matches(query SearchQuery) bool {
+	if query.MemberType != "" && !strings.EqualFold(query.MemberType, d.Type) {
+		return false
+	}
+
+	if query.ContentType != "" && normalizeSearchMediaType(query.ContentType) != normalizeSearchMediaType(d.ContentType) {
+		return false
+	}
+
+	for _, tag := range query.Tags {
+		if !containsFold(d.Tags, tag) {
+			return false
+		}
+	}
+
+	if text := strings.ToLower(strings.TrimSpace(query.Text)); text != "" {
+		if !strings.Contains(strings.ToLower(d.Title), text) && !strings.Contains(strings.ToLower(d.Description), text) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewResourceSearchDocument builds the search document for a resource held by containerID.
+// Title, description and tags are read from the resource metadata.
+func NewResourceSearchDocument(resource Resource, containerID string) SearchDocument {
+	metadata := resource.GetMetadata()
+	return SearchDocument{
+		ID:          resource.ID(),
+		Type:        SearchTypeResource,
+		Title:       metadataString(metadata, "title"),
+		Description: metadataString(metadata, "description"),
+		Tags:        metadataTags(metadata),
+		ContentType: resource.GetContentType(),
+		ContainerID: containerID,
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// NewContainerSearchDocument builds the search document for a container
+func NewContainerSearchDocument(container *Container) SearchDocument {
+	return SearchDocument{
+		ID:          container.ID(),
+		Type:        SearchTypeContainer,
+		Title:       container.GetTitle(),
+		Description: container.GetDescription(),
+		Tags:        metadataTags(container.GetMetadata()),
+		ContentType: container.GetContentType(),
+		ContainerID: container.GetParentID(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// metadataString returns a string metadata value, or an empty string
+func metadataString(metadata map[string]interface{}, key string) string {
+	if value, ok := metadata[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// metadataTags reads the "tags" metadata value, accepting a list or a comma-separated string
+func metadataTags(metadata map[string]interface{}) []string {
+	var raw []string
+	switch value := metadata["tags"].(type) {
+	case []string:
+		raw = value
+	case []interface{}:
+		for _, item := range value {
+			if tag, ok := item.(string); ok {
+				raw = append(raw, tag)
+			}
+		}
+	case string:
+		raw = strings.Split(value, ",")
+	}
+
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// normalizeSearchMediaType lower-cases a media type and strips its parameters
+func normalizeSearchMediaType(mediaType string) string {
+	return strings.TrimSpace(strings.ToLower(strings.Split(mediaType, ";")[0]))
+}
+
+// containsFold reports whether values contains target, ignoring case
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, strings.TrimSpace(target)) {
+			return true
+		}
+	}
+	return false
+}
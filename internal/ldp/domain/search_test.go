@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchDocument_Matches(t *testing.T) {
+	doc := SearchDocument{
+		ID:          "lisbon",
+		Type:        SearchTypeResource,
+		Title:       "Trip to Lisbon",
+		Description: "Photos from the old town",
+		Tags:        []string{"travel", "Portugal"},
+		ContentType: "image/jpeg",
+	}
+
+	tests := []struct {
+		name    string
+		query   SearchQuery
+		matches bool
+	}{
+		{"empty query", SearchQuery{}, true},
+		{"title text", SearchQuery{Text: "lisbon"}, true},
+		{"description text", SearchQuery{Text: "OLD TOWN"}, true},
+		{"missing text", SearchQuery{Text: "porto"}, false},
+		{"content type with parameters", SearchQuery{ContentType: "Image/JPEG; q=1"}, true},
+		{"other content type", SearchQuery{ContentType: "text/turtle"}, false},
+		{"all tags", SearchQuery{Tags: []string{"portugal", "travel"}}, true},
+		{"one tag missing", SearchQuery{Tags: []string{"travel", "food"}}, false},
+		{"member type", SearchQuery{MemberType: "resource"}, true},
+		{"other member type", SearchQuery{MemberType: SearchTypeContainer}, false},
+		{"filters are combined", SearchQuery{Text: "trip", ContentType: "text/turtle"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, doc.Matches(tt.query))
+		})
+	}
+}
+
+func TestNewResourceSearchDocument(t *testing.T) {
+	resource := NewResource(context.Background(), "lisbon", "image/jpeg", []byte("jpeg"))
+	resource.SetMetadata("title", "Lisbon")
+	resource.SetMetadata("description", "Old town")
+	resource.SetMetadata("tags", []interface{}{"travel", " city ", ""})
+
+	doc := NewResourceSearchDocument(resource, "trips")
+
+	assert.Equal(t, "lisbon", doc.ID)
+	assert.Equal(t, SearchTypeResource, doc.Type)
+	assert.Equal(t, "Lisbon", doc.Title)
+	assert.Equal(t, "Old town", doc.Description)
+	assert.Equal(t, []string{"travel", "city"}, doc.Tags)
+	assert.Equal(t, "image/jpeg", doc.ContentType)
+	assert.Equal(t, "trips", doc.ContainerID)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "context"
+
+// StorageUsage is how much resource content an account stores against its quota. MaxBytes is
+// zero when the account has no quota.
+type StorageUsage struct {
+	AccountID string `json:"accountId"`
+	UsedBytes int64  `json:"usedBytes"`
+	MaxBytes  int64  `json:"maxBytes"`
+}
+
+// StorageUsageStore keeps how many bytes of resource content each account stores. Every
+// resource is charged to the account that first wrote it, so overwrites and deletes move that
+// account's total by the bytes they change, whoever makes them.
+type StorageUsageStore interface {
+	// ResourceUsage returns the account a resource is charged to and its charged size; found
+	// is false when the resource is not charged to any account
+	ResourceUsage(ctx context.Context, resourceID string) (accountID string, size int64, found bool, err error)
+	// SetResourceUsage charges a resource of size bytes to an account, replacing its earlier
+	// charge and adjusting the account totals
+	SetResourceUsage(ctx context.Context, accountID, resourceID string, size int64) error
+	// RemoveResourceUsage drops a resource's charge; removing an uncharged resource does nothing
+	RemoveResourceUsage(ctx context.Context, resourceID string) error
+	// AccountUsage returns the bytes charged to an account
+	AccountUsage(ctx context.Context, accountID string) (int64, error)
+}
+
+// StorageUsageTotaler sums the bytes charged to every account
+type StorageUsageTotaler interface {
+	TotalUsage(ctx context.Context) (int64, error)
+}
+
+// accountIDKey is the context key of the account a write is made on behalf of
+type accountIDKey struct{}
+
+// WithAccountID returns a context naming the account a write is made on behalf of, so new
+// resources are charged to its storage quota
+func WithAccountID(ctx context.Context, accountID string) context.Context {
+	if accountID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, accountIDKey{}, accountID)
+}
+
+// AccountIDFromContext returns the account named by WithAccountID, or ""
+func AccountIDFromContext(ctx context.Context) string {
+	accountID, _ := ctx.Value(accountIDKey{}).(string)
+	return accountID
+}
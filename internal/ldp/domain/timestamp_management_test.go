@@ -423,3 +423,64 @@ func TestContainer_RemoveMemberWithTimestamp_AlwaysSucceeds(t *testing.T) {
 	events := container.UncommittedEvents()
 	assert.Len(t, events, 1)
 }
+
+func TestTimestampManager_ResolveTimestamps_Fallback(t *testing.T) {
+	// Setup
+	fixedTime := time.Date(2025, 9, 13, 10, 0, 0, 0, time.UTC)
+	modTime := time.Date(2025, 8, 1, 9, 30, 0, 0, time.UTC)
+	tm := NewTimestampManagerWithProvider(func() time.Time { return fixedTime })
+	ctx := context.Background()
+	container := NewContainer(ctx, "test-container", "", BasicContainer)
+	container.MarkEventsAsCommitted() // Clear creation events
+
+	// Simulate partial data: createdAt missing, updatedAt restored as zero
+	metadata := container.GetMetadata()
+	delete(metadata, "createdAt")
+	metadata["updatedAt"] = time.Time{}
+
+	// Without a fallback the timestamps are reported missing
+	_, source := tm.ResolveCreatedTimestamp(container)
+	assert.Equal(t, TimestampMissing, source)
+
+	tm.SetFallback(func(c *Container) (time.Time, bool) { return modTime, true })
+
+	// Execute
+	createdAt, createdSource := tm.ResolveCreatedTimestamp(container)
+	updatedAt, updatedSource := tm.ResolveUpdatedTimestamp(container)
+
+	// Assert
+	assert.Equal(t, TimestampDerived, createdSource)
+	assert.Equal(t, modTime, createdAt)
+	assert.Equal(t, TimestampDerived, updatedSource)
+	assert.Equal(t, modTime, updatedAt)
+
+	// Filling sets the derived values in memory
+	assert.True(t, tm.FillMissingTimestamps(container))
+	_, source = tm.ResolveCreatedTimestamp(container)
+	assert.Equal(t, TimestampStored, source)
+}
+
+func TestTimestampManager_RepairTimestamps_PrefersFallback(t *testing.T) {
+	// Setup
+	fixedTime := time.Date(2025, 9, 13, 10, 0, 0, 0, time.UTC)
+	modTime := time.Date(2025, 8, 1, 9, 30, 0, 0, time.UTC)
+	tm := NewTimestampManagerWithProvider(func() time.Time { return fixedTime })
+	tm.SetFallback(func(c *Container) (time.Time, bool) { return modTime, true })
+	ctx := context.Background()
+	container := NewContainer(ctx, "test-container", "", BasicContainer)
+	container.MarkEventsAsCommitted() // Clear creation events
+
+	metadata := container.GetMetadata()
+	metadata["createdAt"] = time.Time{}
+	delete(metadata, "updatedAt")
+
+	// Execute
+	repaired := tm.RepairTimestamps(container)
+
+	// Assert
+	require.True(t, repaired)
+	createdAt, _ := tm.GetCreatedTimestamp(container)
+	updatedAt, _ := tm.GetUpdatedTimestamp(container)
+	assert.Equal(t, modTime, createdAt)
+	assert.Equal(t, modTime, updatedAt)
+}
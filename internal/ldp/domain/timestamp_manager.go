@@ -1,14 +1,35 @@
 package domain
 
 import (
+	"context"
 	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
 )
 
 // TimestampManager handles automatic timestamp management for containers
 type TimestampManager struct {
 	timeProvider func() time.Time
+	fallback     TimestampFallback
+}
+
+// TimestampFallback derives a best-effort timestamp for a container whose metadata lacks one
+type TimestampFallback func(container *Container) (time.Time, bool)
+
+// ContainerModTimeSource reports when a container was last modified in its backing store
+type ContainerModTimeSource interface {
+	ContainerModTime(ctx context.Context, containerID string) (time.Time, error)
 }
 
+// TimestampSource describes where a resolved timestamp came from
+type TimestampSource string
+
+const (
+	TimestampStored  TimestampSource = "stored"
+	TimestampDerived TimestampSource = "derived"
+	TimestampMissing TimestampSource = "missing"
+)
+
 // NewTimestampManager creates a new timestamp manager
 func NewTimestampManager() *TimestampManager {
 	return &TimestampManager{
@@ -58,6 +79,57 @@ func (tm *TimestampManager) GetUpdatedTimestamp(container *Container) (time.Time
 	return time.Time{}, false
 }
 
+// SetFallback sets the source used to derive timestamps missing from container metadata.
+// A nil fallback leaves missing timestamps empty.
+func (tm *TimestampManager) SetFallback(fallback TimestampFallback) {
+	tm.fallback = fallback
+}
+
+// ResolveCreatedTimestamp returns the creation timestamp, deriving it when it is missing
+func (tm *TimestampManager) ResolveCreatedTimestamp(container *Container) (time.Time, TimestampSource) {
+	createdAt, ok := tm.GetCreatedTimestamp(container)
+	return tm.resolve(container, "createdAt", createdAt, ok)
+}
+
+// ResolveUpdatedTimestamp returns the modification timestamp, deriving it when it is missing
+func (tm *TimestampManager) ResolveUpdatedTimestamp(container *Container) (time.Time, TimestampSource) {
+	updatedAt, ok := tm.GetUpdatedTimestamp(container)
+	return tm.resolve(container, "updatedAt", updatedAt, ok)
+}
+
+// resolve falls back to a derived value for a missing or zero timestamp
+func (tm *TimestampManager) resolve(container *Container, key string, stored time.Time, ok bool) (time.Time, TimestampSource) {
+	if ok && !stored.IsZero() {
+		return stored, TimestampStored
+	}
+
+	if tm.fallback != nil {
+		if derived, found := tm.fallback(container); found && !derived.IsZero() {
+			log.Warnf("Container %s has no %s; using derived value %s (not authoritative)", container.ID(), key, derived.Format(time.RFC3339))
+			return derived, TimestampDerived
+		}
+	}
+
+	return time.Time{}, TimestampMissing
+}
+
+// FillMissingTimestamps sets derived values for missing timestamps on the in-memory container
+// so readers do not see empty dates. Nothing is persisted; it reports whether any value was derived.
+func (tm *TimestampManager) FillMissingTimestamps(container *Container) bool {
+	derived := false
+
+	if createdAt, source := tm.ResolveCreatedTimestamp(container); source == TimestampDerived {
+		container.SetMetadata("createdAt", createdAt)
+		derived = true
+	}
+	if updatedAt, source := tm.ResolveUpdatedTimestamp(container); source == TimestampDerived {
+		container.SetMetadata("updatedAt", updatedAt)
+		derived = true
+	}
+
+	return derived
+}
+
 // ValidateTimestamps validates that timestamps are consistent
 func (tm *TimestampManager) ValidateTimestamps(container *Container) error {
 	createdAt, hasCreated := tm.GetCreatedTimestamp(container)
@@ -86,6 +158,17 @@ func (tm *TimestampManager) RepairTimestamps(container *Container) bool {
 	createdAt, hasCreated := tm.GetCreatedTimestamp(container)
 	updatedAt, hasUpdated := tm.GetUpdatedTimestamp(container)
 
+	// Zero timestamps restored from partial data count as missing
+	hasCreated = hasCreated && !createdAt.IsZero()
+	hasUpdated = hasUpdated && !updatedAt.IsZero()
+
+	// Prefer a derived value over the current time when one is available
+	if (!hasCreated || !hasUpdated) && tm.fallback != nil {
+		if derived, found := tm.fallback(container); found && !derived.IsZero() {
+			now = derived
+		}
+	}
+
 	// If no creation timestamp, set it to now
 	if !hasCreated {
 		container.SetMetadata("createdAt", now)
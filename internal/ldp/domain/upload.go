@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// UploadSession is a resumable upload of a resource's content. The client declares the size
+// up front and sends the content in chunks, each starting where the last one ended; the
+// resource is only written once every byte has arrived and the upload is completed.
+type UploadSession struct {
+	ID          string `json:"id"`
+	ResourceID  string `json:"resource_id"`
+	ContentType string `json:"content_type"`
+	// Size is the declared length of the content in bytes
+	Size int64 `json:"size"`
+	// Offset is how many bytes have been received so far
+	Offset int64 `json:"offset"`
+	// AccountID is the account the upload is made on behalf of, so the finished resource is
+	// charged to its storage quota
+	AccountID string `json:"account_id,omitempty"`
+	// Owner is the agent that started the upload; only it may continue, complete or abort it
+	Owner     string    `json:"owner,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Received reports whether every declared byte of the upload has arrived
+func (u UploadSession) Received() bool {
+	return u.Offset == u.Size
+}
+
+// Expired reports whether the upload has outlived its TTL at the given time
+func (u UploadSession) Expired(now time.Time) bool {
+	return !u.ExpiresAt.IsZero() && !now.Before(u.ExpiresAt)
+}
+
+// UploadStore keeps upload sessions and the content they have received so far
+type UploadStore interface {
+	// CreateUpload records a new session with no content received
+	CreateUpload(ctx context.Context, session UploadSession) error
+	// GetUpload returns a session, or ErrUploadNotFound
+	GetUpload(ctx context.Context, id string) (UploadSession, error)
+	// AppendUpload adds a chunk starting at offset, refusing with ErrUploadOffsetMismatch a
+	// chunk that does not start at the session's offset and with ErrInvalidResource one
+	// running past the declared size. Bytes received before a failed read are kept, so the
+	// client can resume from the returned session's offset.
+	AppendUpload(ctx context.Context, id string, offset int64, chunk io.Reader) (UploadSession, error)
+	// OpenUpload reads the content a session has received
+	OpenUpload(ctx context.Context, id string) (io.ReadCloser, error)
+	// DeleteUpload drops a session and its content; deleting a missing session does nothing
+	DeleteUpload(ctx context.Context, id string) error
+	// ListUploads returns every session
+	ListUploads(ctx context.Context) ([]UploadSession, error)
+}
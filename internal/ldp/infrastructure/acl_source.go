@@ -0,0 +1,125 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// aclTargetMarkers are the path segments resource and container IRIs name their ID after
+var aclTargetMarkers = []string{"/containers/", "/resources/"}
+
+// ResourceACLSource reads the ACL of a resource or container from its .acl resource, a Turtle
+// or N-Triples document of acl:Authorization statements
+type ResourceACLSource struct {
+	repo domain.ResourceRepository
+}
+
+// NewResourceACLSource creates an ACL source reading .acl resources from the repository
+func NewResourceACLSource(repo domain.ResourceRepository) *ResourceACLSource {
+	return &ResourceACLSource{repo: repo}
+}
+
+// NewResourceACLSourceProvider creates the ACL source for Wire dependency injection
+func NewResourceACLSourceProvider(repo domain.StreamingResourceRepository) *ResourceACLSource {
+	return NewResourceACLSource(repo)
+}
+
+// GetACL returns the ACL stored in the resource's .acl resource, reporting false when there is none
+func (s *ResourceACLSource) GetACL(ctx context.Context, resourceID string) (*domain.ACL, bool, error) {
+	aclID := domain.ACLResourceID(resourceID)
+	resource, err := s.repo.Retrieve(ctx, aclID)
+	if err != nil {
+		if domain.IsResourceNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to retrieve ACL resource",
+		).WithOperation("GetACL").WithContext("resourceID", aclID)
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(resource.GetContentType(), ";")[0]))
+	if contentType != "text/turtle" && contentType != "application/n-triples" {
+		return nil, false, domain.WrapStorageError(
+			fmt.Errorf("ACL resources must be Turtle or N-Triples, got %s", contentType),
+			domain.ErrUnsupportedFormat.Code,
+			"unsupported ACL format",
+		).WithOperation("GetACL").WithContext("resourceID", aclID)
+	}
+
+	authorizations, err := ParseACL(resource.GetData())
+	if err != nil {
+		return nil, false, domain.WrapStorageError(
+			err,
+			domain.ErrFormatConversion.Code,
+			"malformed ACL resource",
+		).WithOperation("GetACL").WithContext("resourceID", aclID)
+	}
+	return &domain.ACL{ResourceID: resourceID, Authorizations: authorizations}, true, nil
+}
+
+// ParseACL reads the acl:Authorization statements of a Turtle document, in the order their
+// subjects sort. The resources named by acl:accessTo and acl:default are given by ID: the rest
+// of a container or resource IRI after /containers/ or /resources/, or a relative reference as is.
+func ParseACL(document []byte) ([]domain.Authorization, error) {
+	triples, err := newTurtleParser(string(document)).parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ACL document: %w", err)
+	}
+
+	bySubject := make(map[string]*domain.Authorization)
+	isAuthorization := make(map[string]bool)
+	for _, triple := range triples {
+		subject := triple.subject.value
+		authorization, ok := bySubject[subject]
+		if !ok {
+			authorization = &domain.Authorization{ID: subject, Modes: []string{}}
+			bySubject[subject] = authorization
+		}
+
+		object := triple.object.value
+		switch triple.predicate.value {
+		case rdfTypeIRI:
+			if object == domain.ACLNamespace+"Authorization" {
+				isAuthorization[subject] = true
+			}
+		case domain.ACLNamespace + "agent":
+			authorization.Agents = append(authorization.Agents, object)
+		case domain.ACLNamespace + "agentClass":
+			authorization.AgentClasses = append(authorization.AgentClasses, object)
+		case domain.ACLNamespace + "accessTo":
+			authorization.AccessTo = append(authorization.AccessTo, aclTargetID(object))
+		case domain.ACLNamespace + "default", domain.ACLNamespace + "defaultForNew":
+			authorization.Default = append(authorization.Default, aclTargetID(object))
+		case domain.ACLNamespace + "mode":
+			authorization.Modes = append(authorization.Modes, object)
+		}
+	}
+
+	subjects := make([]string, 0, len(isAuthorization))
+	for subject := range isAuthorization {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	authorizations := make([]domain.Authorization, 0, len(subjects))
+	for _, subject := range subjects {
+		authorizations = append(authorizations, *bySubject[subject])
+	}
+	return authorizations, nil
+}
+
+// aclTargetID returns the ID of the resource or container an ACL statement names
+func aclTargetID(iri string) string {
+	for _, marker := range aclTargetMarkers {
+		if index := strings.LastIndex(iri, marker); index >= 0 {
+			return iri[index+len(marker):]
+		}
+	}
+	return strings.TrimPrefix(iri, "./")
+}
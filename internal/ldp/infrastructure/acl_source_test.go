@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const photosACL = `@prefix acl: <http://www.w3.org/ns/auth/acl#> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+<#owner> a acl:Authorization ;
+    acl:agent <https://alice.example/profile#me> ;
+    acl:accessTo <https://pod.example/containers/photos> ;
+    acl:default <https://pod.example/containers/photos> ;
+    acl:mode acl:Read, acl:Write, acl:Control .
+
+<#public> a acl:Authorization ;
+    acl:agentClass foaf:Agent ;
+    acl:accessTo <./photos> ;
+    acl:mode acl:Read .
+
+<#note> <http://www.w3.org/2000/01/rdf-schema#comment> "not an authorization" .
+`
+
+func TestParseACL(t *testing.T) {
+	authorizations, err := ParseACL([]byte(photosACL))
+	require.NoError(t, err)
+	require.Len(t, authorizations, 2)
+
+	owner := authorizations[0]
+	assert.Equal(t, "#owner", owner.ID)
+	assert.Equal(t, []string{"https://alice.example/profile#me"}, owner.Agents)
+	assert.Equal(t, []string{"photos"}, owner.AccessTo)
+	assert.Equal(t, []string{"photos"}, owner.Default)
+	assert.Equal(t, domain.AccessMode{Read: true, Append: true, Write: true, Control: true}, owner.AccessMode())
+
+	public := authorizations[1]
+	assert.Equal(t, []string{domain.FOAFAgent}, public.AgentClasses)
+	assert.Equal(t, []string{"photos"}, public.AccessTo)
+	assert.Equal(t, domain.AccessMode{Read: true}, public.AccessMode())
+
+	_, err = ParseACL([]byte(`<#owner> a `))
+	assert.Error(t, err)
+}
+
+func TestResourceACLSource_GetACL(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewFileSystemRepository(t.TempDir())
+	require.NoError(t, err)
+	source := NewResourceACLSource(repo)
+
+	require.NoError(t, repo.Store(ctx, domain.NewResource(ctx, "photos.acl", "text/turtle", []byte(photosACL))))
+	acl, found, err := source.GetACL(ctx, "photos")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "photos", acl.ResourceID)
+	assert.Len(t, acl.AccessToAuthorizations("photos"), 2)
+	assert.Len(t, acl.DefaultAuthorizations("photos"), 1)
+
+	_, found, err = source.GetACL(ctx, "documents")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, repo.Store(ctx, domain.NewResource(ctx, "notes.acl", "application/ld+json", []byte(`{}`))))
+	_, _, err = source.GetACL(ctx, "notes")
+	assert.True(t, domain.IsUnsupportedFormat(err))
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 )
 
@@ -51,11 +52,13 @@ func (c *ContainerCache) Get(containerID string) (*domain.Container, bool) {
 
 	entry, exists := c.entries[containerID]
 	if !exists || entry.IsExpired() {
+		metrics.RecordCacheLookup(false)
 		return nil, false
 	}
 
 	// Update last accessed time
 	entry.LastAccessed = time.Now()
+	metrics.RecordCacheLookup(true)
 	return entry.Container, true
 }
 
@@ -137,6 +140,31 @@ func (c *ContainerCache) Clear() {
 	c.entries = make(map[string]*ContainerCacheEntry)
 }
 
+// InvalidateCached drops the cached containers with the given IDs and reports how many were cached
+func (c *ContainerCache) InvalidateCached(ids ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dropped := 0
+	for _, id := range ids {
+		if _, exists := c.entries[id]; exists {
+			delete(c.entries, id)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// InvalidateAllCached clears the cache and reports how many containers it held
+func (c *ContainerCache) InvalidateAllCached() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dropped := len(c.entries)
+	c.entries = make(map[string]*ContainerCacheEntry)
+	return dropped
+}
+
 // Size returns the number of entries in cache
 func (c *ContainerCache) Size() int {
 	c.mu.RLock()
@@ -306,6 +334,16 @@ func (r *CachedContainerRepository) RemoveMember(ctx context.Context, containerI
 	return nil
 }
 
+// InvalidateCached drops the cached containers with the given IDs
+func (r *CachedContainerRepository) InvalidateCached(ids ...string) int {
+	return r.cache.InvalidateCached(ids...)
+}
+
+// InvalidateAllCached drops every cached container
+func (r *CachedContainerRepository) InvalidateAllCached() int {
+	return r.cache.InvalidateAllCached()
+}
+
 // ListMembers lists members (no caching for dynamic results)
 func (r *CachedContainerRepository) ListMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]string, error) {
 	return r.repo.ListMembers(ctx, containerID, pagination)
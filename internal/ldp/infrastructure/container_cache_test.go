@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -137,7 +138,7 @@ func TestContainerCacheClear(t *testing.T) {
 
 	// Add multiple containers
 	for i := 0; i < 5; i++ {
-		container := domain.NewContainer(fmt.Sprintf("container-%d", i), "", domain.BasicContainer)
+		container := domain.NewContainer(context.Background(), fmt.Sprintf("container-%d", i), "", domain.BasicContainer)
 		cache.Put(fmt.Sprintf("container-%d", i), container, i, int64(i*100))
 	}
 
@@ -161,7 +162,7 @@ func TestContainerCacheStats(t *testing.T) {
 
 	// Add some containers
 	for i := 0; i < 3; i++ {
-		container := domain.NewContainer(fmt.Sprintf("container-%d", i), "", domain.BasicContainer)
+		container := domain.NewContainer(context.Background(), fmt.Sprintf("container-%d", i), "", domain.BasicContainer)
 		cache.Put(fmt.Sprintf("container-%d", i), container, i, int64(i*100))
 	}
 
@@ -222,7 +223,7 @@ func TestContainerCacheConcurrentAccess(t *testing.T) {
 
 			for j := 0; j < 10; j++ {
 				containerID := fmt.Sprintf("container-%d-%d", workerID, j)
-				container := domain.NewContainer(containerID, "", domain.BasicContainer)
+				container := domain.NewContainer(context.Background(), containerID, "", domain.BasicContainer)
 				cache.Put(containerID, container, j, int64(j*100))
 
 				// Try to read it back
@@ -0,0 +1,48 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MemoryContainerForwarding is an in-process ContainerForwarding. Forwards do not survive a
+// restart, so a moved container's old URI reverts to 404 early if the server restarts.
+type MemoryContainerForwarding struct {
+	forwards map[string]domain.ContainerForward
+	mu       sync.Mutex
+}
+
+// NewMemoryContainerForwarding creates an empty in-memory forwarding table
+func NewMemoryContainerForwarding() *MemoryContainerForwarding {
+	return &MemoryContainerForwarding{
+		forwards: make(map[string]domain.ContainerForward),
+	}
+}
+
+// RecordForward stores a forward, replacing any earlier forward from the same ID
+func (f *MemoryContainerForwarding) RecordForward(ctx context.Context, forward domain.ContainerForward) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.forwards[forward.FromID] = forward
+	return nil
+}
+
+// LookupForward returns the unexpired forward from an ID, dropping it once it has expired
+func (f *MemoryContainerForwarding) LookupForward(ctx context.Context, fromID string, now time.Time) (domain.ContainerForward, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	forward, ok := f.forwards[fromID]
+	if !ok {
+		return domain.ContainerForward{}, false, nil
+	}
+	if forward.IsExpired(now) {
+		delete(f.forwards, fromID)
+		return domain.ContainerForward{}, false, nil
+	}
+	return forward, true, nil
+}
@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryContainerForwarding(t *testing.T) {
+	ctx := context.Background()
+	forwarding := NewMemoryContainerForwarding()
+	movedAt := time.Now()
+
+	require.NoError(t, forwarding.RecordForward(ctx, domain.ContainerForward{
+		FromID:    "photos",
+		ToID:      "pictures",
+		MovedAt:   movedAt,
+		ExpiresAt: movedAt.Add(time.Hour),
+	}))
+
+	forward, found, err := forwarding.LookupForward(ctx, "photos", movedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "pictures", forward.ToID)
+
+	_, found, err = forwarding.LookupForward(ctx, "videos", movedAt)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, found, err = forwarding.LookupForward(ctx, "photos", movedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, found, "forwards expire at the end of the retention period")
+
+	_, found, err = forwarding.LookupForward(ctx, "photos", movedAt)
+	require.NoError(t, err)
+	assert.False(t, found, "expired forwards are dropped")
+}
@@ -0,0 +1,163 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// posixStatSize is the predicate stating a member's size in bytes, as used by Solid servers
+const posixStatSize = "http://www.w3.org/ns/posix/stat#size"
+
+// MemberMetadataTriples generates the dcterms:title, dcterms:created, dcterms:modified and size
+// triples of indexed members. Values the index does not record are left out.
+func (c *ContainerRDFConverter) MemberMetadataTriples(members []domain.IndexedMember, baseURI string) []ContainerTriple {
+	var triples []ContainerTriple
+	for _, member := range members {
+		memberURI := baseURI + member.ID
+		if member.Title != "" {
+			triples = append(triples, ContainerTriple{
+				Subject:    memberURI,
+				Predicate:  "http://purl.org/dc/terms/title",
+				Object:     member.Title,
+				ObjectType: "literal",
+			})
+		}
+		if !member.CreatedAt.IsZero() {
+			triples = append(triples, ContainerTriple{
+				Subject:    memberURI,
+				Predicate:  "http://purl.org/dc/terms/created",
+				Object:     member.CreatedAt.Format(time.RFC3339),
+				ObjectType: "literal",
+				DataType:   "http://www.w3.org/2001/XMLSchema#dateTime",
+			})
+		}
+		if !member.UpdatedAt.IsZero() {
+			triples = append(triples, ContainerTriple{
+				Subject:    memberURI,
+				Predicate:  "http://purl.org/dc/terms/modified",
+				Object:     member.UpdatedAt.Format(time.RFC3339),
+				ObjectType: "literal",
+				DataType:   "http://www.w3.org/2001/XMLSchema#dateTime",
+			})
+		}
+		if member.Size > 0 {
+			triples = append(triples, ContainerTriple{
+				Subject:    memberURI,
+				Predicate:  posixStatSize,
+				Object:     strconv.FormatInt(member.Size, 10),
+				ObjectType: "literal",
+				DataType:   "http://www.w3.org/2001/XMLSchema#integer",
+			})
+		}
+	}
+	return triples
+}
+
+// ConvertWithMemberMetadata converts a container to an RDF format with the metadata of the
+// given members described alongside it, so one document carries a rich listing
+func (c *ContainerRDFConverter) ConvertWithMemberMetadata(container *domain.Container, format, baseURI string, members []domain.IndexedMember) ([]byte, error) {
+	if container == nil {
+		return nil, fmt.Errorf("container cannot be nil")
+	}
+
+	memberTriples := c.MemberMetadataTriples(members, baseURI)
+
+	var result []byte
+	switch format {
+	case "text/turtle":
+		result = c.turtleDocument(append(c.generateAllTriples(container, baseURI, domain.ContainerPreference{}), memberTriples...))
+	case "application/n-triples":
+		result = c.nTriplesDocument(append(c.generateAllTriples(container, baseURI, domain.ContainerPreference{}), memberTriples...))
+	case "application/ld+json":
+		jsonld := c.jsonLDDocument(container, baseURI, domain.ContainerPreference{})
+		if nodes := c.jsonLDMemberNodes(memberTriples); len(nodes) > 0 {
+			included, _ := jsonld["@included"].([]map[string]interface{})
+			jsonld["@included"] = append(included, nodes...)
+		}
+
+		var err error
+		result, err = json.MarshalIndent(jsonld, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON-LD: %w", err)
+		}
+	case "application/rdf+xml":
+		var rdfxml strings.Builder
+		c.writeRDFXMLHeader(&rdfxml)
+		c.writeRDFXMLContainer(&rdfxml, container, baseURI, domain.ContainerPreference{})
+		c.writeRDFXMLMembers(&rdfxml, memberTriples)
+		rdfxml.WriteString("</rdf:RDF>\n")
+		result = []byte(rdfxml.String())
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	metrics.ObserveRDFDocumentSize(format, metrics.OperationContainerListing, len(result))
+	return result, nil
+}
+
+// jsonLDMemberNodes groups member metadata triples into one JSON-LD node per member, in the
+// order the members were listed
+func (c *ContainerRDFConverter) jsonLDMemberNodes(triples []ContainerTriple) []map[string]interface{} {
+	var nodes []map[string]interface{}
+	bySubject := make(map[string]map[string]interface{})
+	for _, triple := range triples {
+		node, exists := bySubject[triple.Subject]
+		if !exists {
+			node = map[string]interface{}{"@id": triple.Subject}
+			bySubject[triple.Subject] = node
+			nodes = append(nodes, node)
+		}
+
+		key := c.jsonLDContext.Compact(triple.Predicate)
+		if triple.DataType == "" {
+			node[key] = triple.Object
+			continue
+		}
+		node[key] = map[string]interface{}{
+			"@type":  c.jsonLDContext.Compact(triple.DataType),
+			"@value": triple.Object,
+		}
+	}
+	return nodes
+}
+
+// writeRDFXMLMembers writes one rdf:Description per member from its metadata triples
+func (c *ContainerRDFConverter) writeRDFXMLMembers(rdfxml *strings.Builder, triples []ContainerTriple) {
+	subject := ""
+	for _, triple := range triples {
+		if triple.Subject != subject {
+			if subject != "" {
+				rdfxml.WriteString("  </rdf:Description>\n")
+			}
+			subject = triple.Subject
+			rdfxml.WriteString(fmt.Sprintf("  <rdf:Description rdf:about=\"%s\">\n", c.escapeXML(subject)))
+		}
+		rdfxml.WriteString("    " + c.rdfXMLLiteralProperty(triple) + "\n")
+	}
+	if subject != "" {
+		rdfxml.WriteString("  </rdf:Description>\n")
+	}
+}
+
+// rdfXMLLiteralProperty renders a triple with a literal object as an RDF/XML property element.
+// Predicates outside the declared namespaces get a namespace declaration of their own.
+func (c *ContainerRDFConverter) rdfXMLLiteralProperty(triple ContainerTriple) string {
+	name := c.shortenURI(triple.Predicate)
+	open, closing := name, name
+	if strings.HasPrefix(name, "<") {
+		split := strings.LastIndexAny(triple.Predicate, "#/") + 1
+		closing = "ns0:" + triple.Predicate[split:]
+		open = fmt.Sprintf("%s xmlns:ns0=\"%s\"", closing, c.escapeXML(triple.Predicate[:split]))
+	}
+
+	if triple.DataType != "" {
+		open += fmt.Sprintf(" rdf:datatype=\"%s\"", c.escapeXML(triple.DataType))
+	}
+	return fmt.Sprintf("<%s>%s</%s>", open, c.escapeXML(triple.Object), closing)
+}
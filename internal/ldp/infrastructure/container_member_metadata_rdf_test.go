@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRDFConverter_ConvertWithMemberMetadata(t *testing.T) {
+	ctx := context.Background()
+	converter := NewContainerRDFConverter()
+
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	modified := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	members := []domain.IndexedMember{
+		{ID: "notes", Type: "Container", Title: "Meeting notes", CreatedAt: created, UpdatedAt: modified},
+		{ID: "a.txt", Type: "Resource", Size: 42, CreatedAt: created, UpdatedAt: created},
+	}
+	newContainer := func(t *testing.T) *domain.Container {
+		container := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, "a.txt", "text/plain", nil)))
+		return container
+	}
+
+	t.Run("describes members in N-Triples", func(t *testing.T) {
+		result, err := converter.ConvertWithMemberMetadata(newContainer(t), "application/n-triples", "http://example.org/", members)
+		require.NoError(t, err)
+
+		output := string(result)
+		assert.Contains(t, output, "<http://example.org/docs> <http://www.w3.org/ns/ldp#contains> <http://example.org/a.txt> .\n")
+		assert.Contains(t, output, `<http://example.org/notes> <http://purl.org/dc/terms/title> "Meeting notes" .`+"\n")
+		assert.Contains(t, output, `<http://example.org/notes> <http://purl.org/dc/terms/modified> "2024-03-02T09:00:00Z"^^<http://www.w3.org/2001/XMLSchema#dateTime> .`+"\n")
+		assert.Contains(t, output, `<http://example.org/a.txt> <http://www.w3.org/ns/posix/stat#size> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .`+"\n")
+		assert.NotContains(t, output, `<http://example.org/a.txt> <http://purl.org/dc/terms/title>`, "untitled members get no title")
+	})
+
+	t.Run("describes members in Turtle", func(t *testing.T) {
+		result, err := converter.ConvertWithMemberMetadata(newContainer(t), "text/turtle", "http://example.org/", members)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(result), `dcterms:title "Meeting notes"`)
+		assert.Contains(t, string(result), `<http://www.w3.org/ns/posix/stat#size> "42"^^xsd:integer`)
+	})
+
+	t.Run("includes member nodes in JSON-LD", func(t *testing.T) {
+		result, err := converter.ConvertWithMemberMetadata(newContainer(t), "application/ld+json", "http://example.org/", members)
+		require.NoError(t, err)
+
+		var document struct {
+			ID       string                   `json:"@id"`
+			Included []map[string]interface{} `json:"@included"`
+		}
+		require.NoError(t, json.Unmarshal(result, &document))
+		assert.Equal(t, "http://example.org/docs", document.ID)
+		require.Len(t, document.Included, 2)
+		assert.Equal(t, "http://example.org/notes", document.Included[0]["@id"])
+		assert.Equal(t, "Meeting notes", document.Included[0]["title"])
+		assert.Equal(t, map[string]interface{}{"@type": "xsd:integer", "@value": "42"}, document.Included[1]["http://www.w3.org/ns/posix/stat#size"])
+	})
+
+	t.Run("writes well-formed RDF/XML member descriptions", func(t *testing.T) {
+		result, err := converter.ConvertWithMemberMetadata(newContainer(t), "application/rdf+xml", "http://example.org/", members)
+		require.NoError(t, err)
+
+		assert.NoError(t, xml.Unmarshal(result, new(interface{})))
+		assert.Contains(t, string(result), `<rdf:Description rdf:about="http://example.org/notes">`)
+		assert.Contains(t, string(result), `<ns0:size xmlns:ns0="http://www.w3.org/ns/posix/stat#" rdf:datatype="http://www.w3.org/2001/XMLSchema#integer">42</ns0:size>`)
+	})
+
+	t.Run("rejects formats that are not RDF", func(t *testing.T) {
+		_, err := converter.ConvertWithMemberMetadata(newContainer(t), "text/csv", "http://example.org/", members)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRDFConverter_MembershipRelations(t *testing.T) {
+	ctx := context.Background()
+	converter := NewContainerRDFConverter()
+	likes := "http://example.org/vocab#likes"
+
+	newDirectContainer := func(t *testing.T) *domain.Container {
+		container := domain.NewContainer(ctx, "likes", "alice", domain.DirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(likes, "")
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, "like-1", "text/turtle", nil)))
+		return container
+	}
+
+	t.Run("direct containers state the configured relation about the membership resource", func(t *testing.T) {
+		result, err := converter.ConvertToNTriples(newDirectContainer(t), "http://example.org/")
+		require.NoError(t, err)
+
+		output := string(result)
+		assert.Contains(t, output, "<http://example.org/likes> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/ns/ldp#DirectContainer> .\n")
+		assert.Contains(t, output, "<http://example.org/likes> <http://www.w3.org/ns/ldp#membershipResource> <http://example.org/profile> .\n")
+		assert.Contains(t, output, "<http://example.org/likes> <http://www.w3.org/ns/ldp#hasMemberRelation> <"+likes+"> .\n")
+		assert.Contains(t, output, "<http://example.org/profile> <"+likes+"> <http://example.org/like-1> .\n")
+		assert.NotContains(t, output, "ldp#contains")
+	})
+
+	t.Run("JSON-LD includes the membership resource node", func(t *testing.T) {
+		result, err := converter.ConvertToJSONLD(newDirectContainer(t), "http://example.org/")
+		require.NoError(t, err)
+
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(result, &document))
+		assert.Equal(t, []interface{}{"ldp:DirectContainer"}, document["@type"])
+		assert.Equal(t, map[string]interface{}{"@id": likes}, document["ldp:hasMemberRelation"])
+		assert.NotContains(t, document, "contains")
+		included := document["@included"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "http://example.org/profile", included["@id"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"@id": "http://example.org/like-1"}}, included[likes])
+	})
+
+	t.Run("RDF/XML declares the relation namespace", func(t *testing.T) {
+		result, err := converter.ConvertToRDFXML(newDirectContainer(t), "http://example.org/")
+		require.NoError(t, err)
+
+		output := string(result)
+		assert.Contains(t, output, `<ldp:hasMemberRelation rdf:resource="`+likes+`"/>`)
+		assert.Contains(t, output, `<rdf:Description rdf:about="http://example.org/profile">`)
+		assert.Contains(t, output, `<ns0:likes xmlns:ns0="http://example.org/vocab#" rdf:resource="http://example.org/like-1"/>`)
+	})
+
+	t.Run("indirect containers leave member content triples to the membership resource", func(t *testing.T) {
+		container := domain.NewContainer(ctx, "topics", "alice", domain.IndirectContainer)
+		container.SetMembershipResource("/resources/profile")
+		container.SetMembershipRelations(likes, "http://example.org/vocab#primaryTopic")
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, "doc-1", "text/turtle", nil)))
+
+		result, err := converter.ConvertToNTriples(container, "http://example.org/")
+		require.NoError(t, err)
+
+		output := string(result)
+		assert.Contains(t, output, "<http://example.org/topics> <http://www.w3.org/ns/ldp#insertedContentRelation> <http://example.org/vocab#primaryTopic> .\n")
+		assert.NotContains(t, output, "<http://example.org/doc-1>")
+	})
+}
@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRDFConverter_ConvertToNTriples(t *testing.T) {
+	ctx := context.Background()
+	converter := NewContainerRDFConverter()
+
+	t.Run("writes one fully expanded triple per line", func(t *testing.T) {
+		container := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+		container.SetTitle(`Team "docs"`)
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, "a.txt", "text/plain", nil)))
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, "b.txt", "text/plain", nil)))
+
+		result, err := converter.ConvertToNTriples(container, "http://example.org/")
+		require.NoError(t, err)
+
+		output := string(result)
+		assert.NotContains(t, output, "@prefix")
+		assert.True(t, strings.HasSuffix(output, " .\n"))
+		for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+			assert.True(t, strings.HasPrefix(line, "<http://example.org/docs> <"), line)
+			assert.True(t, strings.HasSuffix(line, " ."), line)
+		}
+
+		assert.Contains(t, output, "<http://example.org/docs> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/ns/ldp#BasicContainer> .\n")
+		assert.Contains(t, output, `<http://example.org/docs> <http://purl.org/dc/terms/title> "Team \"docs\"" .`+"\n")
+		assert.Contains(t, output, "<http://purl.org/dc/terms/created> \"")
+		assert.Contains(t, output, "^^<http://www.w3.org/2001/XMLSchema#dateTime> .\n")
+		assert.Contains(t, output, "<http://example.org/docs> <http://www.w3.org/ns/ldp#contains> <http://example.org/a.txt> .\n")
+		assert.Contains(t, output, "<http://example.org/docs> <http://www.w3.org/ns/ldp#contains> <http://example.org/b.txt> .\n")
+	})
+
+	t.Run("escapes member IDs that are not valid in an IRI", func(t *testing.T) {
+		container := domain.NewContainer(ctx, "docs", "", domain.BasicContainer)
+		require.NoError(t, container.AddMember(ctx, domain.NewResource(ctx, `my notes<1>{"é"}.txt`, "text/plain", nil)))
+
+		result, err := converter.ConvertToNTriples(container, "http://example.org/")
+		require.NoError(t, err)
+
+		assert.Contains(t, string(result), "<http://www.w3.org/ns/ldp#contains> <http://example.org/my%20notes%3C1%3E%7B%22é%22%7D.txt> .\n")
+	})
+
+	t.Run("writes no membership triples for an empty container", func(t *testing.T) {
+		container := domain.NewContainer(ctx, "empty", "", domain.BasicContainer)
+
+		result, err := converter.ConvertToNTriples(container, "http://example.org/")
+		require.NoError(t, err)
+
+		assert.Contains(t, string(result), "<http://www.w3.org/ns/ldp#BasicContainer> .\n")
+		assert.NotContains(t, string(result), "ldp#contains")
+	})
+
+	t.Run("rejects a nil container", func(t *testing.T) {
+		_, err := converter.ConvertToNTriples(nil, "http://example.org/")
+		assert.Error(t, err)
+	})
+}
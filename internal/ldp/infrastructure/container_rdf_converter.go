@@ -6,21 +6,29 @@ import (
 	"strings"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 )
 
 // ContainerRDFConverter handles conversion of container entities to various RDF formats
 type ContainerRDFConverter struct {
-	rdfConverter *RDFConverter
+	rdfConverter  *RDFConverter
+	jsonLDContext *JSONLDContext
 }
 
 // NewContainerRDFConverter creates a new container RDF converter
 func NewContainerRDFConverter() *ContainerRDFConverter {
 	return &ContainerRDFConverter{
-		rdfConverter: NewRDFConverter(),
+		rdfConverter:  NewRDFConverter(),
+		jsonLDContext: DefaultJSONLDContext(),
 	}
 }
 
+// SetJSONLDContext sets the context compact JSON-LD is written with
+func (c *ContainerRDFConverter) SetJSONLDContext(context *JSONLDContext) {
+	c.jsonLDContext = context
+}
+
 // ContainerTriple represents an RDF triple for container serialization
 type ContainerTriple struct {
 	Subject    string `json:"subject"`
@@ -28,6 +36,7 @@ type ContainerTriple struct {
 	Object     string `json:"object"`
 	ObjectType string `json:"objectType"` // "uri", "literal", "blank"
 	DataType   string `json:"dataType,omitempty"`
+	Language   string `json:"language,omitempty"`
 }
 
 // ConvertToTurtle converts a container to Turtle format
@@ -36,10 +45,15 @@ func (c *ContainerRDFConverter) ConvertToTurtle(container *domain.Container, bas
 		return nil, fmt.Errorf("container cannot be nil")
 	}
 
-	// Generate all triples for the container
-	triples := c.generateAllTriples(container, baseURI)
+	// Serialize all triples of the container
+	result := c.turtleDocument(c.generateAllTriples(container, baseURI, domain.ContainerPreference{}))
+	metrics.ObserveRDFDocumentSize("text/turtle", metrics.OperationContainerListing, len(result))
+
+	return result, nil
+}
 
-	// Build Turtle representation
+// turtleDocument serializes triples as a Turtle document, grouped by subject
+func (c *ContainerRDFConverter) turtleDocument(triples []ContainerTriple) []byte {
 	var turtle strings.Builder
 
 	// Add namespace prefixes
@@ -78,6 +92,8 @@ func (c *ContainerRDFConverter) ConvertToTurtle(container *domain.Container, bas
 				if triple.DataType != "" {
 					dataType := c.shortenURI(triple.DataType)
 					turtle.WriteString(fmt.Sprintf("\"%s\"^^%s", c.escapeLiteral(triple.Object), dataType))
+				} else if triple.Language != "" {
+					turtle.WriteString(fmt.Sprintf("\"%s\"@%s", c.escapeLiteral(triple.Object), triple.Language))
 				} else {
 					turtle.WriteString(fmt.Sprintf("\"%s\"", c.escapeLiteral(triple.Object)))
 				}
@@ -87,7 +103,7 @@ func (c *ContainerRDFConverter) ConvertToTurtle(container *domain.Container, bas
 		turtle.WriteString(" .\n\n")
 	}
 
-	return []byte(turtle.String()), nil
+	return []byte(turtle.String())
 }
 
 // ConvertToJSONLD converts a container to JSON-LD format
@@ -96,42 +112,87 @@ func (c *ContainerRDFConverter) ConvertToJSONLD(container *domain.Container, bas
 		return nil, fmt.Errorf("container cannot be nil")
 	}
 
+	// Marshal to JSON
+	result, err := json.MarshalIndent(c.jsonLDDocument(container, baseURI, domain.ContainerPreference{}), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON-LD: %w", err)
+	}
+	metrics.ObserveRDFDocumentSize("application/ld+json", metrics.OperationContainerListing, len(result))
+
+	return result, nil
+}
+
+// ConvertToExpandedJSONLD converts a container to expanded JSON-LD: full IRIs, no context, and
+// every value an array, for clients that process JSON-LD without the server's context
+func (c *ContainerRDFConverter) ConvertToExpandedJSONLD(container *domain.Container, baseURI string) ([]byte, error) {
+	return c.ConvertWithPreference(container, JSONLDExpandedFormat, baseURI, domain.ContainerPreference{})
+}
+
+// expandedJSONLDDocument serializes triples as expanded JSON-LD, one node object per subject
+// in the order the subjects first appear
+func (c *ContainerRDFConverter) expandedJSONLDDocument(triples []ContainerTriple) []map[string]interface{} {
+	nodes := []map[string]interface{}{}
+	bySubject := make(map[string]map[string]interface{})
+	for _, triple := range triples {
+		node, exists := bySubject[triple.Subject]
+		if !exists {
+			node = map[string]interface{}{"@id": triple.Subject}
+			bySubject[triple.Subject] = node
+			nodes = append(nodes, node)
+		}
+
+		if triple.Predicate == rdfTypeIRI && triple.ObjectType == "uri" {
+			types, _ := node["@type"].([]string)
+			node["@type"] = append(types, triple.Object)
+			continue
+		}
+
+		var value map[string]interface{}
+		switch {
+		case triple.ObjectType == "uri":
+			value = map[string]interface{}{"@id": triple.Object}
+		case triple.DataType != "":
+			value = map[string]interface{}{"@value": triple.Object, "@type": triple.DataType}
+		case triple.Language != "":
+			value = map[string]interface{}{"@value": triple.Object, "@language": triple.Language}
+		default:
+			value = map[string]interface{}{"@value": triple.Object}
+		}
+		values, _ := node[triple.Predicate].([]map[string]interface{})
+		node[triple.Predicate] = append(values, value)
+	}
+	return nodes
+}
+
+// jsonLDDocument builds the JSON-LD structure of a container, leaving out the member triples
+// the preference omits
+func (c *ContainerRDFConverter) jsonLDDocument(container *domain.Container, baseURI string, preference domain.ContainerPreference) map[string]interface{} {
 	containerURI := baseURI + container.ID()
 
 	// Build JSON-LD structure
+	terms := c.jsonLDContext
 	jsonld := map[string]interface{}{
-		"@context": map[string]interface{}{
-			"rdf":         "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
-			"ldp":         "http://www.w3.org/ns/ldp#",
-			"dcterms":     "http://purl.org/dc/terms/",
-			"xsd":         "http://www.w3.org/2001/XMLSchema#",
-			"type":        "rdf:type",
-			"contains":    "ldp:contains",
-			"title":       "dcterms:title",
-			"description": "dcterms:description",
-			"created":     "dcterms:created",
-			"modified":    "dcterms:modified",
-		},
-		"@id":   containerURI,
-		"@type": []string{"ldp:" + container.ContainerType.String()},
+		"@context": terms.Document(),
+		"@id":      containerURI,
+		"@type":    []string{terms.Compact(domain.LDPNamespace + container.ContainerType.String())},
 	}
 
-	// Add title if present
-	if title := container.GetTitle(); title != "" {
-		jsonld["title"] = title
+	// Add title if present, including language-tagged variants
+	if title := c.jsonLDLiteralValue(container.GetTitle(), container.GetLocalizedTitles()); title != nil {
+		jsonld[terms.Compact(dctermsTitle)] = title
 	}
 
-	// Add description if present
-	if description := container.GetDescription(); description != "" {
-		jsonld["description"] = description
+	// Add description if present, including language-tagged variants
+	if description := c.jsonLDLiteralValue(container.GetDescription(), container.GetLocalizedDescriptions()); description != nil {
+		jsonld[terms.Compact(dctermsDescription)] = description
 	}
 
 	// Add timestamps
 	metadata := container.GetMetadata()
 	if createdAt, exists := metadata["createdAt"]; exists {
 		if t, ok := createdAt.(time.Time); ok {
-			jsonld["created"] = map[string]interface{}{
-				"@type":  "xsd:dateTime",
+			jsonld[terms.Compact(dctermsCreated)] = map[string]interface{}{
+				"@type":  terms.Compact(xsdDateTime),
 				"@value": t.Format(time.RFC3339),
 			}
 		}
@@ -139,31 +200,36 @@ func (c *ContainerRDFConverter) ConvertToJSONLD(container *domain.Container, bas
 
 	if updatedAt, exists := metadata["updatedAt"]; exists {
 		if t, ok := updatedAt.(time.Time); ok {
-			jsonld["modified"] = map[string]interface{}{
-				"@type":  "xsd:dateTime",
+			jsonld[terms.Compact(dctermsModified)] = map[string]interface{}{
+				"@type":  terms.Compact(xsdDateTime),
 				"@value": t.Format(time.RFC3339),
 			}
 		}
 	}
 
-	// Add membership information
-	if len(container.Members) > 0 {
-		contains := make([]map[string]interface{}, len(container.Members))
-		for i, memberID := range container.Members {
-			contains[i] = map[string]interface{}{
-				"@id": baseURI + memberID,
-			}
-		}
-		jsonld["contains"] = contains
+	// Add membership configuration
+	for _, triple := range c.generateMembershipConfigTriples(container, baseURI) {
+		jsonld[terms.Compact(triple.Predicate)] = map[string]interface{}{"@id": triple.Object}
 	}
 
-	// Marshal to JSON
-	result, err := json.MarshalIndent(jsonld, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal JSON-LD: %w", err)
+	// Add membership information; membership triples about another resource are included as
+	// a separate node
+	var included map[string]interface{}
+	for _, triple := range c.preferredMembershipTriples(container, baseURI, preference) {
+		node := jsonld
+		key := terms.Compact(triple.Predicate)
+		if triple.Subject != containerURI {
+			if included == nil {
+				included = map[string]interface{}{"@id": triple.Subject}
+				jsonld["@included"] = []map[string]interface{}{included}
+			}
+			node = included
+		}
+		values, _ := node[key].([]map[string]interface{})
+		node[key] = append(values, map[string]interface{}{"@id": triple.Object})
 	}
 
-	return result, nil
+	return jsonld
 }
 
 // ConvertToRDFXML converts a container to RDF/XML format
@@ -172,17 +238,31 @@ func (c *ContainerRDFConverter) ConvertToRDFXML(container *domain.Container, bas
 		return nil, fmt.Errorf("container cannot be nil")
 	}
 
-	containerURI := baseURI + container.ID()
-
 	var rdfxml strings.Builder
+	c.writeRDFXMLHeader(&rdfxml)
+	c.writeRDFXMLContainer(&rdfxml, container, baseURI, domain.ContainerPreference{})
+	rdfxml.WriteString("</rdf:RDF>\n")
+
+	result := []byte(rdfxml.String())
+	metrics.ObserveRDFDocumentSize("application/rdf+xml", metrics.OperationContainerListing, len(result))
 
-	// XML declaration and RDF root element
+	return result, nil
+}
+
+// writeRDFXMLHeader writes the XML declaration and the opening RDF root element
+func (c *ContainerRDFConverter) writeRDFXMLHeader(rdfxml *strings.Builder) {
 	rdfxml.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
 	rdfxml.WriteString("<rdf:RDF\n")
 	rdfxml.WriteString("    xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\"\n")
 	rdfxml.WriteString("    xmlns:ldp=\"http://www.w3.org/ns/ldp#\"\n")
 	rdfxml.WriteString("    xmlns:dcterms=\"http://purl.org/dc/terms/\"\n")
 	rdfxml.WriteString("    xmlns:xsd=\"http://www.w3.org/2001/XMLSchema#\">\n\n")
+}
+
+// writeRDFXMLContainer writes the descriptions of a container and of its membership resource,
+// leaving out the member triples the preference omits
+func (c *ContainerRDFConverter) writeRDFXMLContainer(rdfxml *strings.Builder, container *domain.Container, baseURI string, preference domain.ContainerPreference) {
+	containerURI := baseURI + container.ID()
 
 	// Container description
 	rdfxml.WriteString(fmt.Sprintf("  <rdf:Description rdf:about=\"%s\">\n", containerURI))
@@ -192,11 +272,17 @@ func (c *ContainerRDFConverter) ConvertToRDFXML(container *domain.Container, bas
 	if title := container.GetTitle(); title != "" {
 		rdfxml.WriteString(fmt.Sprintf("    <dcterms:title>%s</dcterms:title>\n", c.escapeXML(title)))
 	}
+	for _, title := range container.GetLocalizedTitles() {
+		rdfxml.WriteString(fmt.Sprintf("    <dcterms:title xml:lang=\"%s\">%s</dcterms:title>\n", c.escapeXML(title.Language), c.escapeXML(title.Value)))
+	}
 
 	// Add description if present
 	if description := container.GetDescription(); description != "" {
 		rdfxml.WriteString(fmt.Sprintf("    <dcterms:description>%s</dcterms:description>\n", c.escapeXML(description)))
 	}
+	for _, description := range container.GetLocalizedDescriptions() {
+		rdfxml.WriteString(fmt.Sprintf("    <dcterms:description xml:lang=\"%s\">%s</dcterms:description>\n", c.escapeXML(description.Language), c.escapeXML(description.Value)))
+	}
 
 	// Add timestamps
 	metadata := container.GetMetadata()
@@ -212,30 +298,132 @@ func (c *ContainerRDFConverter) ConvertToRDFXML(container *domain.Container, bas
 		}
 	}
 
-	// Add membership triples
-	for _, memberID := range container.Members {
-		memberURI := baseURI + memberID
-		rdfxml.WriteString(fmt.Sprintf("    <ldp:contains rdf:resource=\"%s\"/>\n", memberURI))
+	// Add membership configuration
+	for _, triple := range c.generateMembershipConfigTriples(container, baseURI) {
+		rdfxml.WriteString(fmt.Sprintf("    <%s rdf:resource=\"%s\"/>\n", c.shortenURI(triple.Predicate), c.escapeXML(triple.Object)))
+	}
+
+	// Add membership triples; those about another resource get a description of their own
+	var external []ContainerTriple
+	for _, triple := range c.preferredMembershipTriples(container, baseURI, preference) {
+		if triple.Subject != containerURI {
+			external = append(external, triple)
+			continue
+		}
+		rdfxml.WriteString("    " + c.rdfXMLResourceProperty(triple) + "\n")
 	}
 
 	rdfxml.WriteString("  </rdf:Description>\n")
-	rdfxml.WriteString("</rdf:RDF>\n")
+	if len(external) > 0 {
+		rdfxml.WriteString(fmt.Sprintf("  <rdf:Description rdf:about=\"%s\">\n", c.escapeXML(external[0].Subject)))
+		for _, triple := range external {
+			rdfxml.WriteString("    " + c.rdfXMLResourceProperty(triple) + "\n")
+		}
+		rdfxml.WriteString("  </rdf:Description>\n")
+	}
+}
+
+// ConvertToNTriples converts a container to N-Triples format: one triple per line with fully
+// expanded IRIs and no prefixes, for pipelines that consume line-oriented RDF
+func (c *ContainerRDFConverter) ConvertToNTriples(container domain.ContainerResource, baseURI string) ([]byte, error) {
+	if container == nil {
+		return nil, fmt.Errorf("container cannot be nil")
+	}
+
+	var triples []ContainerTriple
+	if concreteContainer, ok := container.(*domain.Container); ok {
+		triples = c.generateAllTriples(concreteContainer, baseURI, domain.ContainerPreference{})
+	} else {
+		triples = c.generateResourceTriples(container, baseURI)
+	}
+
+	result := c.nTriplesDocument(triples)
+	metrics.ObserveRDFDocumentSize("application/n-triples", metrics.OperationContainerListing, len(result))
+
+	return result, nil
+}
+
+// nTriplesDocument serializes triples one per line with fully expanded IRIs
+func (c *ContainerRDFConverter) nTriplesDocument(triples []ContainerTriple) []byte {
+	var ntriples strings.Builder
+	for _, triple := range triples {
+		ntriples.WriteString(fmt.Sprintf("<%s> <%s> ", c.escapeIRI(triple.Subject), c.escapeIRI(triple.Predicate)))
+
+		if triple.ObjectType == "uri" {
+			ntriples.WriteString(fmt.Sprintf("<%s>", c.escapeIRI(triple.Object)))
+		} else if triple.DataType != "" {
+			ntriples.WriteString(fmt.Sprintf("\"%s\"^^<%s>", c.escapeLiteral(triple.Object), c.escapeIRI(triple.DataType)))
+		} else if triple.Language != "" {
+			ntriples.WriteString(fmt.Sprintf("\"%s\"@%s", c.escapeLiteral(triple.Object), triple.Language))
+		} else {
+			ntriples.WriteString(fmt.Sprintf("\"%s\"", c.escapeLiteral(triple.Object)))
+		}
+
+		ntriples.WriteString(" .\n")
+	}
+
+	return []byte(ntriples.String())
+}
+
+// generateResourceTriples generates the type, Dublin Core and membership triples available
+// through the ContainerResource interface, for containers other than *domain.Container
+func (c *ContainerRDFConverter) generateResourceTriples(container domain.ContainerResource, baseURI string) []ContainerTriple {
+	containerURI := baseURI + container.ID()
+
+	triples := []ContainerTriple{{
+		Subject:    containerURI,
+		Predicate:  "http://www.w3.org/1999/02/22-rdf-syntax-ns#type",
+		Object:     "http://www.w3.org/ns/ldp#" + container.GetContainerType().String(),
+		ObjectType: "uri",
+	}}
+	if title := container.GetTitle(); title != "" {
+		triples = append(triples, ContainerTriple{
+			Subject:    containerURI,
+			Predicate:  "http://purl.org/dc/terms/title",
+			Object:     title,
+			ObjectType: "literal",
+		})
+	}
+	if description := container.GetDescription(); description != "" {
+		triples = append(triples, ContainerTriple{
+			Subject:    containerURI,
+			Predicate:  "http://purl.org/dc/terms/description",
+			Object:     description,
+			ObjectType: "literal",
+		})
+	}
+	triples = append(triples, c.generateMembershipConfigTriples(container, baseURI)...)
+	if subject, predicate, ok := c.membershipPattern(container, baseURI); ok {
+		for _, memberID := range container.GetMembers() {
+			triples = append(triples, ContainerTriple{
+				Subject:    subject,
+				Predicate:  predicate,
+				Object:     baseURI + memberID,
+				ObjectType: "uri",
+			})
+		}
+	}
 
-	return []byte(rdfxml.String()), nil
+	return triples
 }
 
-// GenerateMembershipTriples generates LDP membership triples for a container
+// GenerateMembershipTriples generates LDP membership triples for a container. BasicContainers
+// state ldp:contains about themselves; Direct and Indirect containers state their configured
+// ldp:hasMemberRelation about their ldp:membershipResource.
 func (c *ContainerRDFConverter) GenerateMembershipTriples(container *domain.Container, baseURI string) []ContainerTriple {
 	var triples []ContainerTriple
 
-	containerURI := baseURI + container.ID()
+	subject, predicate, ok := c.membershipPattern(container, baseURI)
+	if !ok {
+		return triples
+	}
 
-	// Generate ldp:contains triples for each member
+	// Generate a membership triple for each member
 	for _, memberID := range container.Members {
 		memberURI := baseURI + memberID
 		triple := ContainerTriple{
-			Subject:    containerURI,
-			Predicate:  "http://www.w3.org/ns/ldp#contains",
+			Subject:    subject,
+			Predicate:  predicate,
 			Object:     memberURI,
 			ObjectType: "uri",
 		}
@@ -245,8 +433,124 @@ func (c *ContainerRDFConverter) GenerateMembershipTriples(container *domain.Cont
 	return triples
 }
 
-// generateAllTriples generates all RDF triples for a container
-func (c *ContainerRDFConverter) generateAllTriples(container *domain.Container, baseURI string) []ContainerTriple {
+// preferredMembershipTriples generates a container's membership triples unless the preference
+// omits them
+func (c *ContainerRDFConverter) preferredMembershipTriples(container *domain.Container, baseURI string, preference domain.ContainerPreference) []ContainerTriple {
+	if preference.OmitsMembers(container.GetContainerType()) {
+		return nil
+	}
+	return c.GenerateMembershipTriples(container, baseURI)
+}
+
+// ConvertWithPreference converts a container to an RDF format, leaving out the containment or
+// membership triples an LDP Prefer header asked to omit
+func (c *ContainerRDFConverter) ConvertWithPreference(container *domain.Container, format, baseURI string, preference domain.ContainerPreference) ([]byte, error) {
+	if container == nil {
+		return nil, fmt.Errorf("container cannot be nil")
+	}
+
+	var result []byte
+	switch format {
+	case "text/turtle":
+		result = c.turtleDocument(c.generateAllTriples(container, baseURI, preference))
+	case "application/n-triples":
+		result = c.nTriplesDocument(c.generateAllTriples(container, baseURI, preference))
+	case "application/ld+json":
+		var err error
+		result, err = json.MarshalIndent(c.jsonLDDocument(container, baseURI, preference), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON-LD: %w", err)
+		}
+	case JSONLDExpandedFormat:
+		var err error
+		result, err = json.MarshalIndent(c.expandedJSONLDDocument(c.generateAllTriples(container, baseURI, preference)), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON-LD: %w", err)
+		}
+	case "application/rdf+xml":
+		var rdfxml strings.Builder
+		c.writeRDFXMLHeader(&rdfxml)
+		c.writeRDFXMLContainer(&rdfxml, container, baseURI, preference)
+		rdfxml.WriteString("</rdf:RDF>\n")
+		result = []byte(rdfxml.String())
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	metrics.ObserveRDFDocumentSize(format, metrics.OperationContainerListing, len(result))
+	return result, nil
+}
+
+// membershipPattern returns the subject and predicate of a container's membership triples. It
+// reports false for Direct and Indirect containers missing their membership predicates, and for
+// IndirectContainers whose members name other content, whose triples only the membership
+// resource holds.
+func (c *ContainerRDFConverter) membershipPattern(container domain.ContainerResource, baseURI string) (string, string, bool) {
+	if !container.GetContainerType().HasMembershipTriples() {
+		return baseURI + container.ID(), domain.LDPContains, true
+	}
+
+	metadata := container.GetMetadata()
+	reference, relation := domain.MembershipResource(metadata), domain.HasMemberRelation(metadata)
+	if reference == "" || relation == "" || domain.EffectiveInsertedContentRelation(container) != domain.LDPMemberSubject {
+		return "", "", false
+	}
+	return c.resolveReference(reference, baseURI), relation, true
+}
+
+// generateMembershipConfigTriples generates the ldp:membershipResource, ldp:hasMemberRelation and
+// ldp:insertedContentRelation triples of a Direct or Indirect container
+func (c *ContainerRDFConverter) generateMembershipConfigTriples(container domain.ContainerResource, baseURI string) []ContainerTriple {
+	if !container.GetContainerType().HasMembershipTriples() {
+		return nil
+	}
+
+	containerURI := baseURI + container.ID()
+	metadata := container.GetMetadata()
+
+	var triples []ContainerTriple
+	if reference := domain.MembershipResource(metadata); reference != "" {
+		triples = append(triples, ContainerTriple{
+			Subject:    containerURI,
+			Predicate:  "http://www.w3.org/ns/ldp#membershipResource",
+			Object:     c.resolveReference(reference, baseURI),
+			ObjectType: "uri",
+		})
+	}
+	if relation := domain.HasMemberRelation(metadata); relation != "" {
+		triples = append(triples, ContainerTriple{
+			Subject:    containerURI,
+			Predicate:  "http://www.w3.org/ns/ldp#hasMemberRelation",
+			Object:     relation,
+			ObjectType: "uri",
+		})
+	}
+	if container.GetContainerType() == domain.IndirectContainer {
+		if relation := domain.InsertedContentRelation(metadata); relation != "" {
+			triples = append(triples, ContainerTriple{
+				Subject:    containerURI,
+				Predicate:  "http://www.w3.org/ns/ldp#insertedContentRelation",
+				Object:     relation,
+				ObjectType: "uri",
+			})
+		}
+	}
+
+	return triples
+}
+
+// resolveReference turns a membership resource reference into an IRI; local references are
+// resolved against the base URI
+func (c *ContainerRDFConverter) resolveReference(reference, baseURI string) string {
+	if domain.IsExternalReference(reference) {
+		return reference
+	}
+	return baseURI + domain.LocalReferenceID(reference)
+}
+
+// generateAllTriples generates all RDF triples for a container but the member triples the
+// preference omits
+func (c *ContainerRDFConverter) generateAllTriples(container *domain.Container, baseURI string, preference domain.ContainerPreference) []ContainerTriple {
 	var triples []ContainerTriple
 
 	containerURI := baseURI + container.ID()
@@ -279,6 +583,26 @@ func (c *ContainerRDFConverter) generateAllTriples(container *domain.Container,
 		})
 	}
 
+	// Language-tagged title and description triples
+	for _, title := range container.GetLocalizedTitles() {
+		triples = append(triples, ContainerTriple{
+			Subject:    containerURI,
+			Predicate:  "http://purl.org/dc/terms/title",
+			Object:     title.Value,
+			ObjectType: "literal",
+			Language:   title.Language,
+		})
+	}
+	for _, description := range container.GetLocalizedDescriptions() {
+		triples = append(triples, ContainerTriple{
+			Subject:    containerURI,
+			Predicate:  "http://purl.org/dc/terms/description",
+			Object:     description.Value,
+			ObjectType: "literal",
+			Language:   description.Language,
+		})
+	}
+
 	// Timestamp triples
 	metadata := container.GetMetadata()
 	if createdAt, exists := metadata["createdAt"]; exists {
@@ -305,13 +629,36 @@ func (c *ContainerRDFConverter) generateAllTriples(container *domain.Container,
 		}
 	}
 
-	// Add membership triples
-	membershipTriples := c.GenerateMembershipTriples(container, baseURI)
-	triples = append(triples, membershipTriples...)
+	// Add membership configuration and membership triples
+	triples = append(triples, c.generateMembershipConfigTriples(container, baseURI)...)
+	triples = append(triples, c.preferredMembershipTriples(container, baseURI, preference)...)
 
 	return triples
 }
 
+// jsonLDLiteralValue builds a JSON-LD value from a plain literal and its language-tagged variants.
+// A lone plain literal is returned as a string; otherwise a value array is returned.
+func (c *ContainerRDFConverter) jsonLDLiteralValue(plain string, localized []domain.LangLiteral) interface{} {
+	if len(localized) == 0 {
+		if plain == "" {
+			return nil
+		}
+		return plain
+	}
+
+	values := make([]interface{}, 0, len(localized)+1)
+	if plain != "" {
+		values = append(values, plain)
+	}
+	for _, literal := range localized {
+		values = append(values, map[string]interface{}{
+			"@value":    literal.Value,
+			"@language": literal.Language,
+		})
+	}
+	return values
+}
+
 // shortenURI shortens common URIs using prefixes
 func (c *ContainerRDFConverter) shortenURI(uri string) string {
 	prefixes := map[string]string{
@@ -330,6 +677,19 @@ func (c *ContainerRDFConverter) shortenURI(uri string) string {
 	return "<" + uri + ">"
 }
 
+// rdfXMLResourceProperty renders a triple with an IRI object as an RDF/XML property element.
+// Predicates outside the declared namespaces get a namespace declaration of their own.
+func (c *ContainerRDFConverter) rdfXMLResourceProperty(triple ContainerTriple) string {
+	object := c.escapeXML(triple.Object)
+	if name := c.shortenURI(triple.Predicate); !strings.HasPrefix(name, "<") {
+		return fmt.Sprintf("<%s rdf:resource=\"%s\"/>", name, object)
+	}
+
+	split := strings.LastIndexAny(triple.Predicate, "#/") + 1
+	return fmt.Sprintf("<ns0:%s xmlns:ns0=\"%s\" rdf:resource=\"%s\"/>",
+		triple.Predicate[split:], c.escapeXML(triple.Predicate[:split]), object)
+}
+
 // escapeLiteral escapes special characters in RDF literals
 func (c *ContainerRDFConverter) escapeLiteral(literal string) string {
 	literal = strings.ReplaceAll(literal, "\\", "\\\\")
@@ -340,6 +700,24 @@ func (c *ContainerRDFConverter) escapeLiteral(literal string) string {
 	return literal
 }
 
+// escapeIRI percent-encodes the characters an N-Triples IRI reference cannot contain: spaces,
+// control characters and <>"{}|^`\. Other characters, including non-ASCII ones, are valid in
+// an IRI and kept as they are.
+func (c *ContainerRDFConverter) escapeIRI(iri string) string {
+	const disallowed = "<>\"{}|^`\\"
+
+	var escaped strings.Builder
+	for i := 0; i < len(iri); i++ {
+		b := iri[i]
+		if b <= 0x20 || b == 0x7f || strings.IndexByte(disallowed, b) >= 0 {
+			escaped.WriteString(fmt.Sprintf("%%%02X", b))
+			continue
+		}
+		escaped.WriteByte(b)
+	}
+	return escaped.String()
+}
+
 // escapeXML escapes special characters for XML
 func (c *ContainerRDFConverter) escapeXML(text string) string {
 	text = strings.ReplaceAll(text, "&", "&amp;")
@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -11,11 +12,11 @@ func TestContainerRDFConverter_ConvertToTurtle(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create a test container with members
-	container := domain.NewContainer("container-1", "parent-1", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "container-1", "parent-1", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container for unit testing")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Convert to Turtle
 	result, err := converter.ConvertToTurtle(container, "http://example.org/")
@@ -59,11 +60,11 @@ func TestContainerRDFConverter_ConvertToJSONLD(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create a test container with members
-	container := domain.NewContainer("container-1", "parent-1", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "container-1", "parent-1", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container for unit testing")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Convert to JSON-LD
 	result, err := converter.ConvertToJSONLD(container, "http://example.org/")
@@ -104,11 +105,11 @@ func TestContainerRDFConverter_ConvertToRDFXML(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create a test container with members
-	container := domain.NewContainer("container-1", "parent-1", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "container-1", "parent-1", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container for unit testing")
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
 
 	// Convert to RDF/XML
 	result, err := converter.ConvertToRDFXML(container, "http://example.org/")
@@ -149,10 +150,10 @@ func TestContainerRDFConverter_GenerateMembershipTriples(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create a test container with members
-	container := domain.NewContainer("container-1", "", domain.BasicContainer)
-	container.AddMember("resource-1")
-	container.AddMember("resource-2")
-	container.AddMember("sub-container-1")
+	container := domain.NewContainer(context.Background(), "container-1", "", domain.BasicContainer)
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-2", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "sub-container-1", "text/plain", nil))
 
 	// Generate membership triples
 	triples := converter.GenerateMembershipTriples(container, "http://example.org/")
@@ -201,7 +202,7 @@ func TestContainerRDFConverter_EmptyContainer(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create an empty container
-	container := domain.NewContainer("empty-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "empty-container", "", domain.BasicContainer)
 	container.SetTitle("Empty Container")
 
 	// Test Turtle conversion
@@ -236,9 +237,9 @@ func TestContainerRDFConverter_DirectContainer(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create a DirectContainer
-	container := domain.NewContainer("direct-container", "", domain.DirectContainer)
+	container := domain.NewContainer(context.Background(), "direct-container", "", domain.DirectContainer)
 	container.SetTitle("Direct Container")
-	container.AddMember("resource-1")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "resource-1", "text/plain", nil))
 
 	// Convert to Turtle
 	result, err := converter.ConvertToTurtle(container, "http://example.org/")
@@ -257,7 +258,7 @@ func TestContainerRDFConverter_WithTimestamps(t *testing.T) {
 	converter := NewContainerRDFConverter()
 
 	// Create a container and set specific timestamps
-	container := domain.NewContainer("timestamped-container", "", domain.BasicContainer)
+	container := domain.NewContainer(context.Background(), "timestamped-container", "", domain.BasicContainer)
 	container.SetTitle("Timestamped Container")
 
 	// Set specific timestamps in metadata
@@ -286,6 +287,40 @@ func TestContainerRDFConverter_WithTimestamps(t *testing.T) {
 }
 
 // Helper function to check if a string contains a substring
+func TestContainerRDFConverter_ConvertWithPreference(t *testing.T) {
+	converter := NewContainerRDFConverter()
+
+	container := domain.NewContainer(context.Background(), "container-1", "", domain.BasicContainer)
+	container.SetTitle("Test Container")
+	container.Members = []string{"resource-1"}
+
+	minimal := domain.ContainerPreference{OmitContainment: true, OmitMembership: true}
+	for _, format := range []string{"text/turtle", "application/n-triples", "application/ld+json", "application/rdf+xml"} {
+		full, err := converter.ConvertWithPreference(container, format, "http://example.org/", domain.ContainerPreference{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if !contains(string(full), "resource-1") {
+			t.Errorf("%s: full representation should list the member", format)
+		}
+
+		preferred, err := converter.ConvertWithPreference(container, format, "http://example.org/", minimal)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if contains(string(preferred), "resource-1") {
+			t.Errorf("%s: minimal representation should leave out containment triples", format)
+		}
+		if !contains(string(preferred), "Test Container") {
+			t.Errorf("%s: minimal representation should keep the container's own triples", format)
+		}
+	}
+
+	if _, err := converter.ConvertWithPreference(container, "text/html", "http://example.org/", minimal); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > len(substr) && (s[:len(substr)] == substr ||
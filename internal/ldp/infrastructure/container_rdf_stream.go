@@ -0,0 +1,127 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// ContainerMemberSource passes a container's member IDs to emit one at a time, stopping at the
+// first error emit returns
+type ContainerMemberSource func(emit func(memberID string) error) error
+
+// withoutMembers leaves out the containment and membership triples of every container type
+var withoutMembers = domain.ContainerPreference{OmitContainment: true, OmitMembership: true}
+
+// CanStream reports whether containers can be streamed in a format
+func (c *ContainerRDFConverter) CanStream(format string) bool {
+	switch format {
+	case "text/turtle", "application/n-triples", "application/ld+json":
+		return true
+	default:
+		return false
+	}
+}
+
+// StreamContainer writes a container as Turtle, N-Triples or JSON-LD, taking its members from
+// members rather than from the container. The container's own triples are written first and
+// each membership triple as its member is read, so memory use does not grow with the number
+// of members; the output is a complete document however many members there are.
+func (c *ContainerRDFConverter) StreamContainer(w io.Writer, container *domain.Container, format, baseURI string, members ContainerMemberSource) error {
+	if container == nil {
+		return fmt.Errorf("container cannot be nil")
+	}
+
+	subject, predicate, hasMembership := c.membershipPattern(container, baseURI)
+	if !hasMembership {
+		members = func(func(string) error) error { return nil }
+	}
+
+	switch format {
+	case "text/turtle":
+		if _, err := w.Write(c.turtleDocument(c.generateAllTriples(container, baseURI, withoutMembers))); err != nil {
+			return err
+		}
+		// Each membership triple is a statement of its own, which Turtle allows after the
+		// container's description
+		statement := fmt.Sprintf("<%s> %s ", subject, c.shortenURI(predicate))
+		return members(func(memberID string) error {
+			_, err := fmt.Fprintf(w, "%s<%s> .\n", statement, baseURI+memberID)
+			return err
+		})
+	case "application/n-triples":
+		if _, err := w.Write(c.nTriplesDocument(c.generateAllTriples(container, baseURI, withoutMembers))); err != nil {
+			return err
+		}
+		return members(func(memberID string) error {
+			_, err := w.Write(c.nTriplesDocument([]ContainerTriple{{
+				Subject:    subject,
+				Predicate:  predicate,
+				Object:     baseURI + memberID,
+				ObjectType: "uri",
+			}}))
+			return err
+		})
+	case "application/ld+json":
+		return c.streamJSONLD(w, container, baseURI, subject, predicate, members)
+	default:
+		return fmt.Errorf("unsupported streaming format: %s", format)
+	}
+}
+
+// streamJSONLD writes a container's JSON-LD document without its members, then reopens it to
+// add the membership values as they are read. Membership about another resource goes into an
+// @included node, as in the buffered document.
+func (c *ContainerRDFConverter) streamJSONLD(w io.Writer, container *domain.Container, baseURI, subject, predicate string, members ContainerMemberSource) error {
+	document, err := json.Marshal(c.jsonLDDocument(container, baseURI, withoutMembers))
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON-LD: %w", err)
+	}
+	// Leave the document object open for the membership values
+	if _, err := w.Write(document[:len(document)-1]); err != nil {
+		return err
+	}
+
+	key, err := json.Marshal(c.jsonLDContext.Compact(predicate))
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON-LD: %w", err)
+	}
+	opening := fmt.Sprintf(",%s:[", key)
+	closing := "]"
+	if subject != baseURI+container.ID() {
+		node, err := json.Marshal(subject)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON-LD: %w", err)
+		}
+		opening = fmt.Sprintf(`,"@included":[{"@id":%s,%s:[`, node, key)
+		closing = "]}]"
+	}
+
+	written := 0
+	err = members(func(memberID string) error {
+		value, err := json.Marshal(map[string]string{"@id": baseURI + memberID})
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON-LD: %w", err)
+		}
+		separator := ","
+		if written == 0 {
+			separator = opening
+		}
+		written++
+		_, err = fmt.Fprintf(w, "%s%s", separator, value)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if written > 0 {
+		if _, err := io.WriteString(w, closing); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memberIDs yields the given number of generated member IDs
+func memberIDs(count int) ContainerMemberSource {
+	return func(emit func(string) error) error {
+		for i := 0; i < count; i++ {
+			if err := emit(fmt.Sprintf("member-%d", i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestContainerRDFConverter_StreamContainer_Turtle(t *testing.T) {
+	converter := NewContainerRDFConverter()
+	container := domain.NewContainer(context.Background(), "big", "", domain.BasicContainer)
+	container.SetTitle("Big Container")
+
+	var output bytes.Buffer
+	require.NoError(t, converter.StreamContainer(&output, container, "text/turtle", "http://example.org/", memberIDs(1000)))
+
+	// The streamed document parses, with every membership triple in it
+	triples, err := newTurtleParser(output.String()).parse()
+	require.NoError(t, err)
+	contained := 0
+	for _, triple := range triples {
+		if triple.predicate.value == domain.LDPContains {
+			contained++
+		}
+	}
+	assert.Equal(t, 1000, contained)
+	assert.Contains(t, output.String(), "Big Container")
+}
+
+func TestContainerRDFConverter_StreamContainer_JSONLD(t *testing.T) {
+	converter := NewContainerRDFConverter()
+	container := domain.NewContainer(context.Background(), "big", "", domain.BasicContainer)
+
+	for _, count := range []int{0, 1, 1000} {
+		var output bytes.Buffer
+		require.NoError(t, converter.StreamContainer(&output, container, "application/ld+json", "http://example.org/", memberIDs(count)))
+		require.True(t, json.Valid(output.Bytes()), "%d members: invalid JSON", count)
+
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &document))
+		assert.Equal(t, "http://example.org/big", document["@id"])
+		contains, _ := document["contains"].([]interface{})
+		assert.Len(t, contains, count)
+	}
+}
+
+func TestContainerRDFConverter_StreamContainer_DirectContainer(t *testing.T) {
+	converter := NewContainerRDFConverter()
+	container := domain.NewContainer(context.Background(), "photos", "", domain.DirectContainer)
+	container.SetMetadata(domain.MembershipResourceKey, "http://example.org/album")
+	container.SetMetadata(domain.HasMemberRelationKey, "http://purl.org/dc/terms/hasPart")
+
+	var output bytes.Buffer
+	require.NoError(t, converter.StreamContainer(&output, container, "application/ld+json", "http://example.org/", memberIDs(2)))
+
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &document))
+	included, _ := document["@included"].([]interface{})
+	require.Len(t, included, 1)
+	node := included[0].(map[string]interface{})
+	assert.Equal(t, "http://example.org/album", node["@id"])
+	assert.Len(t, node["dcterms:hasPart"], 2)
+
+	output.Reset()
+	require.NoError(t, converter.StreamContainer(&output, container, "application/n-triples", "http://example.org/", memberIDs(2)))
+	assert.Contains(t, output.String(), "<http://example.org/album> <http://purl.org/dc/terms/hasPart> <http://example.org/member-1> .\n")
+}
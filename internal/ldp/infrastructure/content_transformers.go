@@ -0,0 +1,125 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JPEG markers the EXIF stripper inspects
+const (
+	jpegMarkerSOI   = 0xD8 // start of image
+	jpegMarkerSOS   = 0xDA // start of scan; entropy-coded data follows
+	jpegMarkerAPP1  = 0xE1 // EXIF and XMP
+	jpegMarkerAPP13 = 0xED // Photoshop IRB and IPTC
+)
+
+// EXIFStripper removes EXIF, XMP and IPTC metadata segments from JPEG images, which can carry
+// camera details and GPS coordinates. Image data and other segments, such as the ICC colour
+// profile, are kept byte for byte.
+type EXIFStripper struct{}
+
+// NewEXIFStripper creates a JPEG metadata stripper
+func NewEXIFStripper() *EXIFStripper {
+	return &EXIFStripper{}
+}
+
+// Name returns the transformer's configuration name
+func (s *EXIFStripper) Name() string {
+	return "strip_exif"
+}
+
+// Handles reports whether the content is a JPEG image
+func (s *EXIFStripper) Handles(contentType string) bool {
+	return contentType == "image/jpeg" || contentType == "image/jpg"
+}
+
+// Transform returns the JPEG without its metadata segments. Malformed JPEG structure is an error
+// rather than being passed through, so metadata is never stored by accident.
+func (s *EXIFStripper) Transform(data []byte, contentType string) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != jpegMarkerSOI {
+		return nil, fmt.Errorf("not a JPEG image")
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return nil, fmt.Errorf("malformed JPEG: expected marker at offset %d", pos)
+		}
+		// Markers may be preceded by any number of 0xFF fill bytes
+		for pos < len(data) && data[pos] == 0xFF {
+			pos++
+		}
+		if pos >= len(data) {
+			return nil, fmt.Errorf("malformed JPEG: truncated marker")
+		}
+		marker := data[pos]
+		pos++
+
+		// Standalone markers carry no length
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out.Write([]byte{0xFF, marker})
+			continue
+		}
+		if marker == 0xD9 {
+			out.Write([]byte{0xFF, marker})
+			return out.Bytes(), nil
+		}
+
+		if pos+2 > len(data) {
+			return nil, fmt.Errorf("malformed JPEG: truncated segment length")
+		}
+		length := int(data[pos])<<8 | int(data[pos+1])
+		if length < 2 || pos+length > len(data) {
+			return nil, fmt.Errorf("malformed JPEG: invalid segment length at offset %d", pos)
+		}
+		segment := data[pos : pos+length]
+		pos += length
+
+		if marker == jpegMarkerAPP1 || marker == jpegMarkerAPP13 {
+			continue
+		}
+
+		out.Write([]byte{0xFF, marker})
+		out.Write(segment)
+
+		if marker == jpegMarkerSOS {
+			// Everything after the scan header is image data up to the end of the file
+			out.Write(data[pos:])
+			return out.Bytes(), nil
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// JSONMinifier removes insignificant whitespace from JSON documents, including JSON-LD
+type JSONMinifier struct{}
+
+// NewJSONMinifier creates a JSON minifier
+func NewJSONMinifier() *JSONMinifier {
+	return &JSONMinifier{}
+}
+
+// Name returns the transformer's configuration name
+func (m *JSONMinifier) Name() string {
+	return "minify_json"
+}
+
+// Handles reports whether the content is JSON
+func (m *JSONMinifier) Handles(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+// Transform returns the compacted document; invalid JSON is an error
+func (m *JSONMinifier) Transform(data []byte, contentType string) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Compact(&out, data); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return out.Bytes(), nil
+}
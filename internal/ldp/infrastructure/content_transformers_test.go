@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jpegSegment builds a marker segment whose length covers the two length bytes and the payload
+func jpegSegment(marker byte, payload []byte) []byte {
+	length := len(payload) + 2
+	return append([]byte{0xFF, marker, byte(length >> 8), byte(length)}, payload...)
+}
+
+func TestEXIFStripper_Transform(t *testing.T) {
+	stripper := NewEXIFStripper()
+	jfif := jpegSegment(0xE0, []byte("JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00"))
+	exif := jpegSegment(0xE1, []byte("Exif\x00\x00GPS 38.7,-9.1"))
+	iptc := jpegSegment(0xED, []byte("Photoshop 3.0\x00caption"))
+	icc := jpegSegment(0xE2, []byte("ICC_PROFILE\x00profile"))
+	scan := append(jpegSegment(0xDA, []byte{0x01, 0x01, 0x00, 0x00, 0x3F, 0x00}), 0x12, 0xFF, 0x00, 0x34, 0xFF, 0xD9)
+
+	var image []byte
+	for _, part := range [][]byte{{0xFF, 0xD8}, jfif, exif, iptc, icc, scan} {
+		image = append(image, part...)
+	}
+
+	t.Run("removes metadata segments and keeps the image", func(t *testing.T) {
+		stripped, err := stripper.Transform(image, "image/jpeg")
+		require.NoError(t, err)
+
+		var want []byte
+		for _, part := range [][]byte{{0xFF, 0xD8}, jfif, icc, scan} {
+			want = append(want, part...)
+		}
+		assert.Equal(t, want, stripped)
+		assert.False(t, bytes.Contains(stripped, []byte("GPS")))
+	})
+
+	t.Run("rejects content that is not a JPEG", func(t *testing.T) {
+		_, err := stripper.Transform([]byte("GIF89a"), "image/jpeg")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects truncated segments", func(t *testing.T) {
+		_, err := stripper.Transform(image[:len(image)/2], "image/jpeg")
+		assert.Error(t, err)
+	})
+
+	t.Run("handles only JPEG", func(t *testing.T) {
+		assert.True(t, stripper.Handles("image/jpeg"))
+		assert.False(t, stripper.Handles("image/png"))
+	})
+}
+
+func TestJSONMinifier_Transform(t *testing.T) {
+	minifier := NewJSONMinifier()
+
+	out, err := minifier.Transform([]byte("{\n  \"name\": \"Ann Smith\",\n  \"tags\": [ 1, 2 ]\n}\n"), "application/ld+json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Ann Smith","tags":[1,2]}`, string(out))
+
+	_, err = minifier.Transform([]byte("{not json"), "application/json")
+	assert.Error(t, err)
+
+	assert.True(t, minifier.Handles("application/ld+json"))
+	assert.False(t, minifier.Handles("text/turtle"))
+}
@@ -19,6 +19,15 @@ func DatabaseProvider() (*gorm.DB, error) {
 	return db, nil
 }
 
+// SQLiteDatabaseProvider creates a GORM database instance backed by the SQLite file at path,
+// such as one in a test's temporary directory
+func SQLiteDatabaseProvider(path string) (*gorm.DB, error) {
+	config := infrastructure.DefaultSQLiteConfig()
+	config.DSN = "file:" + path + "?cache=shared&mode=rwc"
+
+	return infrastructure.NewDatabase(config)
+}
+
 // EventStoreProvider creates a GormEventStore using pericarp's implementation
 func EventStoreProvider(db *gorm.DB) (*infrastructure.GormEventStore, error) {
 	eventStore, err := infrastructure.NewGormEventStore(db)
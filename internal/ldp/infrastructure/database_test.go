@@ -1,12 +1,24 @@
 package infrastructure
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/akeemphilbert/pericarp/pkg/infrastructure"
 )
 
 func TestDatabaseProvider(t *testing.T) {
+	// The default database is created in the working directory, so run from a temporary one
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change working directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(workingDir) })
+
 	// Test database creation
 	db, err := DatabaseProvider()
 	if err != nil {
@@ -27,11 +39,16 @@ func TestDatabaseProvider(t *testing.T) {
 
 	// Verify the event store implements the correct interface
 	// The type assertion is done implicitly by the EventStoreProvider function
+
+	// Close the default database so the temporary directory can be removed
+	if sqlDB, err := db.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
 }
 
 func TestEventStoreProvider(t *testing.T) {
 	// Create database first
-	db, err := DatabaseProvider()
+	db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	if err != nil {
 		t.Fatalf("Failed to create database: %v", err)
 	}
@@ -52,7 +69,7 @@ func TestEventStoreProvider(t *testing.T) {
 
 func TestInfrastructureIntegration(t *testing.T) {
 	// Test the full infrastructure stack
-	db, err := DatabaseProvider()
+	db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	if err != nil {
 		t.Fatalf("Failed to create database: %v", err)
 	}
@@ -8,7 +8,7 @@ import (
 )
 
 // Example: Using SQLite for development
-func ExampleSQLiteUsage() {
+func Example_sqliteUsage() {
 	// Create temporary database for example
 	tmpFile, err := os.CreateTemp("", "example_sqlite_*.db")
 	if err != nil {
@@ -53,7 +53,7 @@ func ExampleSQLiteUsage() {
 }
 
 // Example: Using PostgreSQL for production (requires PostgreSQL server)
-func ExamplePostgreSQLUsage() {
+func Example_postgreSQLUsage() {
 	// Skip if PostgreSQL is not available
 	if !isPostgreSQLAvailable() {
 		fmt.Println("PostgreSQL not available, skipping example")
@@ -99,7 +99,7 @@ func ExamplePostgreSQLUsage() {
 }
 
 // Example: Environment-based configuration
-func ExampleEnvironmentConfiguration() {
+func Example_environmentConfiguration() {
 	// Set environment variables (in real usage, these would be set externally)
 	os.Setenv("DB_DRIVER", "sqlite3")
 	os.Setenv("DB_PATH", ":memory:")
@@ -234,8 +234,8 @@ func TestMigrationCompatibility(t *testing.T) {
 				t.Fatalf("Failed to get schema version: %v", err)
 			}
 
-			if version != 1 {
-				t.Errorf("Expected schema version 1, got %d", version)
+			if version != 3 {
+				t.Errorf("Expected schema version 3, got %d", version)
 			}
 
 			t.Logf("%s migration test completed successfully", db.name)
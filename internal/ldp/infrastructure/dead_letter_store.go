@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MemoryDeadLetterStore is an in-process DeadLetterStore. Dead letters do not survive a restart,
+// and once the store is full the oldest failure is dropped to make room for the newest.
+type MemoryDeadLetterStore struct {
+	letters  map[string]domain.DeadLetter
+	order    []string
+	capacity int
+	mu       sync.Mutex
+}
+
+// NewMemoryDeadLetterStore creates an empty in-memory store holding up to capacity dead letters;
+// a capacity of zero or less means unbounded
+func NewMemoryDeadLetterStore(capacity int) *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{
+		letters:  make(map[string]domain.DeadLetter),
+		capacity: capacity,
+	}
+}
+
+// Put stores a dead letter, replacing any earlier one with the same ID
+func (s *MemoryDeadLetterStore) Put(ctx context.Context, letter domain.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.letters[letter.ID]; !exists {
+		if s.capacity > 0 && len(s.order) >= s.capacity {
+			oldest := s.order[0]
+			s.order = s.order[1:]
+			delete(s.letters, oldest)
+			fmt.Printf("Warning: dead letter store full, dropped dead letter %s\n", oldest)
+		}
+		s.order = append(s.order, letter.ID)
+	}
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+// Get returns a dead letter, or ErrDeadLetterNotFound
+func (s *MemoryDeadLetterStore) Get(ctx context.Context, id string) (domain.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return domain.DeadLetter{}, domain.ErrDeadLetterNotFound.WithOperation("Get").WithContext("id", id)
+	}
+	return letter, nil
+}
+
+// List returns the stored dead letters in the order they were first dead-lettered
+func (s *MemoryDeadLetterStore) List(ctx context.Context) ([]domain.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letters := make([]domain.DeadLetter, 0, len(s.order))
+	for _, id := range s.order {
+		letters = append(letters, s.letters[id])
+	}
+	return letters, nil
+}
+
+// Remove deletes a dead letter; removing an unknown ID returns ErrDeadLetterNotFound
+func (s *MemoryDeadLetterStore) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.letters[id]; !ok {
+		return domain.ErrDeadLetterNotFound.WithOperation("Remove").WithContext("id", id)
+	}
+	delete(s.letters, id)
+	for i, existing := range s.order {
+		if existing == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDeadLetterStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore(0)
+
+	require.NoError(t, store.Put(ctx, domain.DeadLetter{ID: "resource:1", Attempts: 5}))
+	require.NoError(t, store.Put(ctx, domain.DeadLetter{ID: "container:2", Attempts: 5}))
+	require.NoError(t, store.Put(ctx, domain.DeadLetter{ID: "resource:1", Attempts: 6}))
+
+	letters, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "resource:1", letters[0].ID)
+	assert.Equal(t, 6, letters[0].Attempts)
+
+	require.NoError(t, store.Remove(ctx, "resource:1"))
+	_, err = store.Get(ctx, "resource:1")
+	assert.True(t, domain.IsDeadLetterNotFound(err))
+	assert.True(t, domain.IsDeadLetterNotFound(store.Remove(ctx, "resource:1")))
+
+	letter, err := store.Get(ctx, "container:2")
+	require.NoError(t, err)
+	assert.Equal(t, "container:2", letter.ID)
+}
+
+func TestMemoryDeadLetterStore_DropsOldestWhenFull(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore(2)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Put(ctx, domain.DeadLetter{ID: id}))
+	}
+
+	letters, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "b", letters[0].ID)
+	assert.Equal(t, "c", letters[1].ID)
+}
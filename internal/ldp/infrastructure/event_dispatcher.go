@@ -1,8 +1,12 @@
 package infrastructure
 
 import (
+	"context"
+
 	"github.com/ThreeDotsLabs/watermill"
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/akeemphilbert/pericarp/pkg/infrastructure"
 )
 
@@ -16,3 +20,15 @@ func NewEventDispatcher() (domain.EventDispatcher, error) {
 
 	return dispatcher, nil
 }
+
+// countingEventDispatcher records dispatched events for the server statistics.
+// Units of work dispatch through it, so every committed event is counted once.
+type countingEventDispatcher struct {
+	domain.EventDispatcher
+}
+
+// Dispatch dispatches the envelopes and counts them
+func (d *countingEventDispatcher) Dispatch(ctx context.Context, envelopes []pericarpdomain.Envelope) error {
+	metrics.RecordEventsDispatched(len(envelopes))
+	return d.EventDispatcher.Dispatch(ctx, envelopes)
+}
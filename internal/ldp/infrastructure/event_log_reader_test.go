@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpinfra "github.com/akeemphilbert/pericarp/pkg/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupEventLogReader(t *testing.T) (*GormEventLogReader, time.Time) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	_, err = pericarpinfra.NewGormEventStore(db)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []pericarpinfra.EventRecord{
+		{ID: "evt-3", AggregateID: "docs", EventType: "container.updated", SequenceNo: 2, Data: `{"title":"Docs"}`, Timestamp: start.Add(2 * time.Minute)},
+		{ID: "evt-1", AggregateID: "docs", EventType: "container.created", SequenceNo: 1, Data: `{"id":"docs"}`, Metadata: `{"user":"alice"}`, Timestamp: start},
+		{ID: "evt-2", AggregateID: "note", EventType: "resource.created", SequenceNo: 1, Data: "not json", Timestamp: start.Add(time.Minute)},
+	}
+	require.NoError(t, db.Create(&records).Error)
+
+	return NewGormEventLogReader(db), start
+}
+
+func readEventLog(t *testing.T, reader *GormEventLogReader, query domain.EventLogQuery) []domain.EventLogEntry {
+	var entries []domain.EventLogEntry
+	require.NoError(t, reader.ReadEventLog(context.Background(), query, func(entry domain.EventLogEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	return entries
+}
+
+func TestGormEventLogReader_ReadEventLog(t *testing.T) {
+	t.Run("reads the whole log in storage order", func(t *testing.T) {
+		reader, _ := setupEventLogReader(t)
+
+		entries := readEventLog(t, reader, domain.EventLogQuery{})
+		require.Len(t, entries, 3)
+		assert.Equal(t, []string{"evt-1", "evt-2", "evt-3"}, []string{entries[0].EventID, entries[1].EventID, entries[2].EventID})
+		assert.Equal(t, []int64{1, 2, 3}, []int64{entries[0].Sequence, entries[1].Sequence, entries[2].Sequence})
+		assert.JSONEq(t, `{"id":"docs"}`, string(entries[0].Data))
+		assert.Equal(t, "alice", entries[0].Metadata["user"])
+		assert.JSONEq(t, `"not json"`, string(entries[1].Data))
+	})
+
+	t.Run("resumes after a sequence number", func(t *testing.T) {
+		reader, _ := setupEventLogReader(t)
+
+		entries := readEventLog(t, reader, domain.EventLogQuery{AfterSequence: 2})
+		require.Len(t, entries, 1)
+		assert.Equal(t, "evt-3", entries[0].EventID)
+		assert.Equal(t, int64(3), entries[0].Sequence)
+	})
+
+	t.Run("keeps sequence numbers when selecting by time", func(t *testing.T) {
+		reader, start := setupEventLogReader(t)
+
+		entries := readEventLog(t, reader, domain.EventLogQuery{Since: start.Add(time.Minute)})
+		require.Len(t, entries, 2)
+		assert.Equal(t, "evt-2", entries[0].EventID)
+		assert.Equal(t, int64(2), entries[0].Sequence)
+	})
+
+	t.Run("stops at the first callback error", func(t *testing.T) {
+		reader, _ := setupEventLogReader(t)
+
+		calls := 0
+		err := reader.ReadEventLog(context.Background(), domain.EventLogQuery{}, func(entry domain.EventLogEntry) error {
+			calls++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+}
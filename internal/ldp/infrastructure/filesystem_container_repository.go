@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -65,6 +66,9 @@ func (r *FileSystemContainerRepository) CreateContainer(ctx context.Context, con
 			"container ID cannot be empty",
 		).WithOperation("CreateContainer")
 	}
+	if err := r.ids.ValidateID(container.ID()); err != nil {
+		return err.(*domain.StorageError).WithOperation("CreateContainer")
+	}
 
 	// Check if container already exists
 	exists, err := r.ContainerExists(ctx, container.ID())
@@ -299,7 +303,7 @@ func (r *FileSystemContainerRepository) DeleteContainer(ctx context.Context, id
 	}
 
 	// Check if container is empty
-	members, err := r.ListMembers(ctx, id, domain.PaginationOptions{Limit: 1})
+	empty, err := r.IsContainerEmpty(ctx, id)
 	if err != nil {
 		return domain.WrapStorageError(
 			err,
@@ -308,7 +312,7 @@ func (r *FileSystemContainerRepository) DeleteContainer(ctx context.Context, id
 		).WithOperation("DeleteContainer").WithContext("containerID", id)
 	}
 
-	if len(members) > 0 {
+	if !empty {
 		return domain.WrapStorageError(
 			fmt.Errorf("container is not empty"),
 			domain.ErrContainerNotEmpty.Code,
@@ -374,6 +378,32 @@ func (r *FileSystemContainerRepository) ContainerExists(ctx context.Context, id
 	return true, nil
 }
 
+// ContainerModTime returns the modification time of a container's metadata file. It is a
+// best-effort stand-in for timestamps missing from the metadata itself.
+func (r *FileSystemContainerRepository) ContainerModTime(ctx context.Context, id string) (time.Time, error) {
+	if id == "" {
+		return time.Time{}, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("ContainerModTime")
+	}
+
+	metadataPath := filepath.Join(r.getContainerPath(id), "container.json")
+	info, err := os.Stat(metadataPath)
+	if os.IsNotExist(err) {
+		return time.Time{}, domain.ErrResourceNotFound.WithOperation("ContainerModTime").WithContext("containerID", id)
+	} else if err != nil {
+		return time.Time{}, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to stat container metadata file",
+		).WithOperation("ContainerModTime").WithContext("containerID", id)
+	}
+
+	return info.ModTime(), nil
+}
+
 // AddMember adds a member to a container
 func (r *FileSystemContainerRepository) AddMember(ctx context.Context, containerID, memberID string) error {
 	if containerID == "" {
@@ -439,7 +469,11 @@ func (r *FileSystemContainerRepository) AddMember(ctx context.Context, container
 		).WithOperation("AddMember").WithContext("containerID", containerID).WithContext("memberID", memberID)
 	}
 
-	return nil
+	return r.RecordMemberDetails(ctx, containerID, domain.IndexedMember{
+		ID:          memberID,
+		ContentType: resource.GetContentType(),
+		Size:        int64(resource.GetSize()),
+	})
 }
 
 // RemoveMember removes a member from a container
@@ -502,28 +536,40 @@ func (r *FileSystemContainerRepository) RemoveMember(ctx context.Context, contai
 
 // ListMembers lists all members of a container
 func (r *FileSystemContainerRepository) ListMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]string, error) {
+	members, _, err := r.listMembers(ctx, containerID, pagination, "ListMembers")
+	return members, err
+}
+
+// ListMembersWithTotal lists one page of a container's members and the container's total
+// member count
+func (r *FileSystemContainerRepository) ListMembersWithTotal(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]string, int, error) {
+	return r.listMembers(ctx, containerID, pagination, "ListMembersWithTotal")
+}
+
+// listMembers slices a page out of a container's member list, returning it with the list's length
+func (r *FileSystemContainerRepository) listMembers(ctx context.Context, containerID string, pagination domain.PaginationOptions, operation string) ([]string, int, error) {
 	if containerID == "" {
-		return nil, domain.WrapStorageError(
+		return nil, 0, domain.WrapStorageError(
 			fmt.Errorf("container ID cannot be empty"),
 			domain.ErrInvalidID.Code,
 			"container ID cannot be empty",
-		).WithOperation("ListMembers")
+		).WithOperation(operation)
 	}
 
 	// Get container to ensure it exists
 	container, err := r.GetContainer(ctx, containerID)
 	if err != nil {
-		return nil, domain.WrapStorageError(
+		return nil, 0, domain.WrapStorageError(
 			err,
 			domain.ErrStorageOperation.Code,
 			"failed to get container",
-		).WithOperation("ListMembers").WithContext("containerID", containerID)
+		).WithOperation(operation).WithContext("containerID", containerID)
 	}
 
 	// Apply pagination to members list
 	members := container.GetMembers()
 	if pagination.Offset >= len(members) {
-		return []string{}, nil
+		return []string{}, len(members), nil
 	}
 
 	end := pagination.Offset + pagination.Limit
@@ -531,7 +577,361 @@ func (r *FileSystemContainerRepository) ListMembers(ctx context.Context, contain
 		end = len(members)
 	}
 
-	return members[pagination.Offset:end], nil
+	return members[pagination.Offset:end], len(members), nil
+}
+
+// memberPresenceIndex is a membership index that can tell whether a container has any members
+type memberPresenceIndex interface {
+	HasMembers(ctx context.Context, containerID string) (bool, error)
+}
+
+// IsContainerEmpty reports whether a container has no members. It is answered by the
+// membership index when the index supports it, without loading the container's metadata.
+func (r *FileSystemContainerRepository) IsContainerEmpty(ctx context.Context, containerID string) (bool, error) {
+	if containerID == "" {
+		return false, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("IsContainerEmpty")
+	}
+
+	if index, ok := r.indexer.(memberPresenceIndex); ok {
+		hasMembers, err := index.HasMembers(ctx, containerID)
+		if err != nil {
+			return false, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to check container members",
+			).WithOperation("IsContainerEmpty").WithContext("containerID", containerID)
+		}
+		return !hasMembers, nil
+	}
+
+	container, err := r.GetContainer(ctx, containerID)
+	if err != nil {
+		return false, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to get container",
+		).WithOperation("IsContainerEmpty").WithContext("containerID", containerID)
+	}
+	return container.GetMemberCount() == 0, nil
+}
+
+// storedCountIndex is a membership index that can count everything it records
+type storedCountIndex interface {
+	StoredCounts(ctx context.Context) (int64, int64, error)
+}
+
+// StoredCounts returns how many containers and resources are stored, as counted by the
+// membership index
+func (r *FileSystemContainerRepository) StoredCounts(ctx context.Context) (int64, int64, error) {
+	index, ok := r.indexer.(storedCountIndex)
+	if !ok {
+		return 0, 0, domain.WrapStorageError(
+			fmt.Errorf("membership index cannot count stored members"),
+			domain.ErrStorageOperation.Code,
+			"membership index cannot count stored members",
+		).WithOperation("StoredCounts")
+	}
+	containers, resources, err := index.StoredCounts(ctx)
+	if err != nil {
+		return 0, 0, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to count stored members",
+		).WithOperation("StoredCounts")
+	}
+	return containers, resources, nil
+}
+
+// ReconcileMembershipIndex makes the membership index hold exactly the members recorded in the
+// container's metadata, which is the source of truth for membership
+func (r *FileSystemContainerRepository) ReconcileMembershipIndex(ctx context.Context, containerID string) (int, int, error) {
+	container, err := r.GetContainer(ctx, containerID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	indexed, err := r.indexer.GetMembers(ctx, containerID, PaginationOptions{})
+	if err != nil {
+		return 0, 0, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read membership index",
+		).WithOperation("ReconcileMembershipIndex").WithContext("containerID", containerID)
+	}
+	inIndex := make(map[string]bool, len(indexed))
+	for _, info := range indexed {
+		inIndex[info.ID] = true
+	}
+
+	added, removed := 0, 0
+	recorded := make(map[string]bool, len(container.GetMembers()))
+	for _, memberID := range container.GetMembers() {
+		recorded[memberID] = true
+		if inIndex[memberID] {
+			continue
+		}
+		if err := r.indexer.IndexMembership(ctx, containerID, memberID); err != nil {
+			return added, removed, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to index membership",
+			).WithOperation("ReconcileMembershipIndex").WithContext("containerID", containerID).WithContext("memberID", memberID)
+		}
+		added++
+	}
+	for _, info := range indexed {
+		if recorded[info.ID] {
+			continue
+		}
+		if err := r.indexer.RemoveMembership(ctx, containerID, info.ID); err != nil {
+			return added, removed, domain.WrapStorageError(
+				err,
+				domain.ErrStorageOperation.Code,
+				"failed to remove membership from index",
+			).WithOperation("ReconcileMembershipIndex").WithContext("containerID", containerID).WithContext("memberID", info.ID)
+		}
+		removed++
+	}
+	return added, removed, nil
+}
+
+// ListIndexedMembers returns every member of a container with the timestamps recorded in the
+// membership index
+func (r *FileSystemContainerRepository) ListIndexedMembers(ctx context.Context, containerID string) ([]domain.IndexedMember, error) {
+	return r.listIndexedMembers(ctx, containerID, PaginationOptions{}, "ListIndexedMembers")
+}
+
+// ListIndexedMembersPage returns one page of a container's members from the membership index
+func (r *FileSystemContainerRepository) ListIndexedMembersPage(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]domain.IndexedMember, error) {
+	return r.listIndexedMembers(ctx, containerID, PaginationOptions{
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+	}, "ListIndexedMembersPage")
+}
+
+// listIndexedMembers reads members from the membership index; a zero limit reads them all
+func (r *FileSystemContainerRepository) listIndexedMembers(ctx context.Context, containerID string, pagination PaginationOptions, operation string) ([]domain.IndexedMember, error) {
+	if containerID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation(operation)
+	}
+
+	infos, err := r.indexer.GetMembers(ctx, containerID, pagination)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read membership index",
+		).WithOperation(operation).WithContext("containerID", containerID)
+	}
+
+	members := make([]domain.IndexedMember, 0, len(infos))
+	for _, info := range infos {
+		members = append(members, domain.IndexedMember{
+			ID:             info.ID,
+			Type:           string(info.Type),
+			Title:          info.Title,
+			ContentType:    info.ContentType,
+			Size:           info.Size,
+			CreatedAt:      info.CreatedAt,
+			UpdatedAt:      info.UpdatedAt,
+			LastAccessedAt: info.LastAccessedAt,
+		})
+	}
+	return members, nil
+}
+
+// filteredMemberIndex is a membership index that filters, sorts and counts members itself
+type filteredMemberIndex interface {
+	GetMembersWithFiltering(ctx context.Context, containerID string, pagination PaginationOptions, filter FilterOptions, sort SortOptions) ([]MemberInfo, error)
+	GetFilteredMemberCount(ctx context.Context, containerID string, filter FilterOptions) (int, error)
+	GetMemberCount(ctx context.Context, containerID string) (int, error)
+}
+
+// ListMembersFiltered returns one page of a container's members matching the listing's filter,
+// in its sort order, along with the container's total member count and the count matching the
+// filter. Filtering and sorting happen in the membership index.
+func (r *FileSystemContainerRepository) ListMembersFiltered(ctx context.Context, containerID string, options domain.ListingOptions) ([]MemberInfo, int, int, error) {
+	if containerID == "" {
+		return nil, 0, 0, domain.WrapStorageError(
+			fmt.Errorf("container ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"container ID cannot be empty",
+		).WithOperation("ListMembersFiltered")
+	}
+
+	index, ok := r.indexer.(filteredMemberIndex)
+	if !ok {
+		return nil, 0, 0, domain.WrapStorageError(
+			fmt.Errorf("membership index cannot filter members"),
+			domain.ErrStorageOperation.Code,
+			"membership index cannot filter members",
+		).WithOperation("ListMembersFiltered").WithContext("containerID", containerID)
+	}
+
+	filter := FilterOptions(options.Filter)
+	total, err := index.GetMemberCount(ctx, containerID)
+	if err != nil {
+		return nil, 0, 0, wrapFilteredListingError(err, containerID)
+	}
+
+	filtered, err := index.GetFilteredMemberCount(ctx, containerID, filter)
+	if err != nil {
+		return nil, 0, 0, wrapFilteredListingError(err, containerID)
+	}
+
+	members, err := index.GetMembersWithFiltering(ctx, containerID, PaginationOptions{
+		Limit:  options.Pagination.Limit,
+		Offset: options.Pagination.Offset,
+	}, filter, SortOptions(options.Sort))
+	if err != nil {
+		return nil, 0, 0, wrapFilteredListingError(err, containerID)
+	}
+
+	return members, total, filtered, nil
+}
+
+// wrapFilteredListingError reports a membership index failure while listing filtered members
+func wrapFilteredListingError(err error, containerID string) error {
+	return domain.WrapStorageError(
+		err,
+		domain.ErrStorageOperation.Code,
+		"failed to read membership index",
+	).WithOperation("ListMembersFiltered").WithContext("containerID", containerID)
+}
+
+// memberDetailsIndex is a membership index that stores members' content type, size and timestamps
+type memberDetailsIndex interface {
+	UpdateMemberDetails(ctx context.Context, containerID string, member MemberInfo) error
+}
+
+// RecordMemberDetails stores a member's type, content type, size and timestamps in the
+// membership index. Members recorded without a type get the one detected from their content
+// type and container metadata. It does nothing when the index does not store member details.
+func (r *FileSystemContainerRepository) RecordMemberDetails(ctx context.Context, containerID string, member domain.IndexedMember) error {
+	index, ok := r.indexer.(memberDetailsIndex)
+	if !ok {
+		return nil
+	}
+
+	memberType := ResourceType(member.Type)
+	if memberType == "" {
+		memberType = DetectMemberType(member.ContentType, r.hasContainerMetadata(member.ID))
+	}
+
+	if err := index.UpdateMemberDetails(ctx, containerID, MemberInfo{
+		ID:          member.ID,
+		Type:        memberType,
+		ContentType: member.ContentType,
+		Size:        member.Size,
+		CreatedAt:   member.CreatedAt,
+		UpdatedAt:   member.UpdatedAt,
+	}); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to record member details",
+		).WithOperation("RecordMemberDetails").WithContext("containerID", containerID).WithContext("memberID", member.ID)
+	}
+	return nil
+}
+
+// ListMemberContainers returns the containers a resource is a member of, from the membership index
+func (r *FileSystemContainerRepository) ListMemberContainers(ctx context.Context, memberID string) ([]string, error) {
+	if memberID == "" {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("member ID cannot be empty"),
+			domain.ErrInvalidID.Code,
+			"member ID cannot be empty",
+		).WithOperation("ListMemberContainers")
+	}
+
+	containers, err := r.indexer.GetContainers(ctx, memberID)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read membership index",
+		).WithOperation("ListMemberContainers").WithContext("memberID", memberID)
+	}
+	return containers, nil
+}
+
+// resourceAccessIndex is a membership index that records when resources were last read
+type resourceAccessIndex interface {
+	RecordAccesses(ctx context.Context, accesses map[string]time.Time) error
+	GetStaleMembers(ctx context.Context, cutoff time.Time) ([]domain.StaleResource, error)
+	ContainerLastAccessed(ctx context.Context, containerID string) (time.Time, error)
+}
+
+// accessIndex returns the membership index as a resourceAccessIndex, or an
+// ErrAccessTrackingDisabled error when the index cannot record reads
+func (r *FileSystemContainerRepository) accessIndex(operation string) (resourceAccessIndex, error) {
+	index, ok := r.indexer.(resourceAccessIndex)
+	if !ok {
+		return nil, domain.ErrAccessTrackingDisabled.WithOperation(operation).WithContext("reason", "membership index does not track reads")
+	}
+	return index, nil
+}
+
+// RecordResourceAccesses stores when resources were last read in the membership index
+func (r *FileSystemContainerRepository) RecordResourceAccesses(ctx context.Context, accesses map[string]time.Time) error {
+	index, err := r.accessIndex("RecordResourceAccesses")
+	if err != nil {
+		return err
+	}
+
+	if err := index.RecordAccesses(ctx, accesses); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to record resource accesses",
+		).WithOperation("RecordResourceAccesses").WithContext("count", len(accesses))
+	}
+	return nil
+}
+
+// FindStaleResources lists resources not read since the cutoff, least recently used first
+func (r *FileSystemContainerRepository) FindStaleResources(ctx context.Context, cutoff time.Time) ([]domain.StaleResource, error) {
+	index, err := r.accessIndex("FindStaleResources")
+	if err != nil {
+		return nil, err
+	}
+
+	stale, err := index.GetStaleMembers(ctx, cutoff)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to find stale resources",
+		).WithOperation("FindStaleResources")
+	}
+	return stale, nil
+}
+
+// ContainerLastAccessed returns the latest recorded read of any member of a container
+func (r *FileSystemContainerRepository) ContainerLastAccessed(ctx context.Context, containerID string) (time.Time, error) {
+	index, err := r.accessIndex("ContainerLastAccessed")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	lastAccessed, err := index.ContainerLastAccessed(ctx, containerID)
+	if err != nil {
+		return time.Time{}, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read container last access",
+		).WithOperation("ContainerLastAccessed").WithContext("containerID", containerID)
+	}
+	return lastAccessed, nil
 }
 
 // GetChildren returns all child containers of a container
@@ -541,6 +941,42 @@ func (r *FileSystemContainerRepository) GetChildren(ctx context.Context, contain
 	return []domain.ContainerResource{}, nil
 }
 
+// ListContainerIDs returns the ID of every stored container, read from the container metadata
+// files; directories without readable metadata are skipped
+func (r *FileSystemContainerRepository) ListContainerIDs(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(r.basePath, "containers"))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to read containers directory",
+		).WithOperation("ListContainerIDs")
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.basePath, "containers", entry.Name(), "container.json"))
+		if err != nil {
+			continue
+		}
+		var metadata ContainerMetadata
+		if err := json.Unmarshal(data, &metadata); err != nil || metadata.ID == "" {
+			continue
+		}
+		ids = append(ids, metadata.ID)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
 // GetParent returns the parent container of a container
 func (r *FileSystemContainerRepository) GetParent(ctx context.Context, containerID string) (domain.ContainerResource, error) {
 	container, err := r.GetContainer(ctx, containerID)
@@ -588,6 +1024,12 @@ func (r *FileSystemContainerRepository) getContainerPath(id string) string {
 	return filepath.Join(r.basePath, "containers", sanitizedID)
 }
 
+// hasContainerMetadata reports whether a container's metadata is stored under the given ID
+func (r *FileSystemContainerRepository) hasContainerMetadata(id string) bool {
+	_, err := os.Stat(filepath.Join(r.getContainerPath(id), "container.json"))
+	return err == nil
+}
+
 // sanitizeID sanitizes a container ID for safe filesystem usage
 func (r *FileSystemContainerRepository) sanitizeID(id string) string {
 	// Replace any potentially dangerous characters
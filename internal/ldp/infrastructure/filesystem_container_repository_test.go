@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
@@ -33,7 +35,7 @@ func TestFileSystemContainerRepository_CreateContainer(t *testing.T) {
 
 	tests := []struct {
 		name      string
-		container *domain.Container
+		container domain.ContainerResource
 		wantErr   bool
 	}{
 		{
@@ -131,8 +133,8 @@ func TestFileSystemContainerRepository_CreateContainerWithParent(t *testing.T) {
 	if err != nil {
 		t.Errorf("GetContainer() error = %v", err)
 	}
-	if retrievedChild.ParentID != "parent-container" {
-		t.Errorf("Child container ParentID = %v, want %v", retrievedChild.ParentID, "parent-container")
+	if retrievedChild.GetParentID() != "parent-container" {
+		t.Errorf("Child container ParentID = %v, want %v", retrievedChild.GetParentID(), "parent-container")
 	}
 }
 
@@ -240,6 +242,7 @@ func TestFileSystemContainerRepository_AddMember(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create test container: %v", err)
 	}
+	storeTestMembers(t, repo, "member-1", "member-2")
 
 	tests := []struct {
 		name        string
@@ -341,6 +344,7 @@ func TestFileSystemContainerRepository_RemoveMember(t *testing.T) {
 		t.Fatalf("Failed to create test container: %v", err)
 	}
 
+	storeTestMembers(t, repo, "member-1")
 	err = repo.AddMember(context.Background(), "test-container", "member-1")
 	if err != nil {
 		t.Fatalf("Failed to add test member: %v", err)
@@ -461,8 +465,8 @@ func TestFileSystemContainerRepository_HierarchicalDirectoryStructure(t *testing
 		t.Fatalf("Failed to retrieve child container: %v", err)
 	}
 
-	if retrievedChild.ParentID != "parent" {
-		t.Errorf("Child container ParentID = %v, want %v", retrievedChild.ParentID, "parent")
+	if retrievedChild.GetParentID() != "parent" {
+		t.Errorf("Child container ParentID = %v, want %v", retrievedChild.GetParentID(), "parent")
 	}
 }
 
@@ -491,8 +495,8 @@ func TestFileSystemContainerRepository_ContainerMetadataPersistence(t *testing.T
 	container := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
 	container.SetTitle("Test Container")
 	container.SetDescription("A test container with metadata")
-	container.AddMember("member-1")
-	container.AddMember("member-2")
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "member-1", "text/plain", nil))
+	container.AddMember(context.Background(), domain.NewResource(context.Background(), "member-2", "text/plain", nil))
 
 	// Store container
 	err = repo.CreateContainer(context.Background(), container)
@@ -511,12 +515,12 @@ func TestFileSystemContainerRepository_ContainerMetadataPersistence(t *testing.T
 		t.Errorf("Container ID = %v, want %v", retrievedContainer.ID(), "test-container")
 	}
 
-	if retrievedContainer.ParentID != "" {
-		t.Errorf("Container ParentID = %v, want %v", retrievedContainer.ParentID, "")
+	if retrievedContainer.GetParentID() != "" {
+		t.Errorf("Container ParentID = %v, want %v", retrievedContainer.GetParentID(), "")
 	}
 
-	if retrievedContainer.ContainerType != domain.BasicContainer {
-		t.Errorf("Container Type = %v, want %v", retrievedContainer.ContainerType, domain.BasicContainer)
+	if retrievedContainer.GetContainerType() != domain.BasicContainer {
+		t.Errorf("Container Type = %v, want %v", retrievedContainer.GetContainerType(), domain.BasicContainer)
 	}
 
 	if retrievedContainer.GetTitle() != "Test Container" {
@@ -527,8 +531,8 @@ func TestFileSystemContainerRepository_ContainerMetadataPersistence(t *testing.T
 		t.Errorf("Container Description = %v, want %v", retrievedContainer.GetDescription(), "A test container with metadata")
 	}
 
-	if len(retrievedContainer.Members) != 2 {
-		t.Errorf("Container Members count = %v, want %v", len(retrievedContainer.Members), 2)
+	if len(retrievedContainer.GetMembers()) != 2 {
+		t.Errorf("Container Members count = %v, want %v", len(retrievedContainer.GetMembers()), 2)
 	}
 
 	// Verify JSON serialization format
@@ -586,6 +590,7 @@ func TestFileSystemContainerRepository_MembershipTrackingIntegration(t *testing.
 
 	// Add members
 	members := []string{"member-1", "member-2", "member-3"}
+	storeTestMembers(t, repo, members...)
 	for _, memberID := range members {
 		err = repo.AddMember(context.Background(), "test-container", memberID)
 		if err != nil {
@@ -643,3 +648,203 @@ func TestFileSystemContainerRepository_MembershipTrackingIntegration(t *testing.
 		}
 	}
 }
+
+func TestFileSystemContainerRepository_ListIndexedMembers(t *testing.T) {
+	// Setup temporary directory
+	tempDir, err := os.MkdirTemp("", "container_repo_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create membership indexer
+	indexer, err := NewSQLiteMembershipIndexer(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	defer indexer.Close()
+
+	// Create repository
+	repo, err := NewFileSystemContainerRepository(tempDir, indexer)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	testContainer := domain.NewContainer(context.Background(), "test-container", "", domain.BasicContainer)
+	if err := repo.CreateContainer(context.Background(), testContainer); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+	for _, memberID := range []string{"member-1", "member-2"} {
+		if err := indexer.IndexMembership(context.Background(), "test-container", memberID); err != nil {
+			t.Fatalf("Failed to index member %s: %v", memberID, err)
+		}
+	}
+
+	members, err := repo.ListIndexedMembers(context.Background(), "test-container")
+	if err != nil {
+		t.Fatalf("ListIndexedMembers() error = %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 indexed members, got %d", len(members))
+	}
+	for _, member := range members {
+		if member.CreatedAt.IsZero() {
+			t.Errorf("Member %s should have a creation time", member.ID)
+		}
+	}
+
+	if _, err := repo.ListIndexedMembers(context.Background(), ""); err == nil {
+		t.Error("ListIndexedMembers() should reject an empty container ID")
+	}
+}
+
+func TestFileSystemContainerRepository_ListIndexedMembersPage(t *testing.T) {
+	tempDir := t.TempDir()
+	indexer, err := NewSQLiteMembershipIndexer(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	defer indexer.Close()
+
+	repo, err := NewFileSystemContainerRepository(tempDir, indexer)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := repo.CreateContainer(ctx, domain.NewContainer(ctx, "test-container", "", domain.BasicContainer)); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+	for _, memberID := range []string{"member-1", "member-2", "member-3"} {
+		if err := indexer.IndexMembership(ctx, "test-container", memberID); err != nil {
+			t.Fatalf("Failed to index member %s: %v", memberID, err)
+		}
+	}
+
+	page, err := repo.ListIndexedMembersPage(ctx, "test-container", domain.PaginationOptions{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("ListIndexedMembersPage() error = %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("Expected 2 members in the page, got %d", len(page))
+	}
+	for _, member := range page {
+		if member.ContentType == "" {
+			t.Errorf("Member %s should have a content type", member.ID)
+		}
+	}
+}
+
+func TestFileSystemContainerRepository_ReconcileMembershipIndex(t *testing.T) {
+	tempDir := t.TempDir()
+	indexer, err := NewSQLiteMembershipIndexer(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	defer indexer.Close()
+
+	repo, err := NewFileSystemContainerRepository(tempDir, indexer)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	ctx := context.Background()
+	container := domain.NewContainer(ctx, "test-container", "", domain.BasicContainer)
+	container.Members = []string{"member-1", "member-2"}
+	if err := repo.CreateContainer(ctx, container); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+
+	// Drift the index away from the container's metadata
+	if err := indexer.RemoveMembership(ctx, "test-container", "member-1"); err != nil {
+		t.Fatalf("Failed to remove member-1 from the index: %v", err)
+	}
+	if err := indexer.IndexMembership(ctx, "test-container", "stale-member"); err != nil {
+		t.Fatalf("Failed to index stale-member: %v", err)
+	}
+
+	added, removed, err := repo.ReconcileMembershipIndex(ctx, "test-container")
+	if err != nil {
+		t.Fatalf("ReconcileMembershipIndex() error = %v", err)
+	}
+	if added != 1 || removed != 1 {
+		t.Errorf("Expected 1 member added and 1 removed, got %d added and %d removed", added, removed)
+	}
+
+	members, err := repo.ListIndexedMembers(ctx, "test-container")
+	if err != nil {
+		t.Fatalf("ListIndexedMembers() error = %v", err)
+	}
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.ID
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != "member-1,member-2" {
+		t.Errorf("Expected the index to hold member-1 and member-2, got %v", ids)
+	}
+
+	added, removed, err = repo.ReconcileMembershipIndex(ctx, "test-container")
+	if err != nil || added != 0 || removed != 0 {
+		t.Errorf("Reconciling a consistent index should change nothing, got %d added, %d removed, err %v", added, removed, err)
+	}
+}
+
+func TestFileSystemContainerRepository_IsContainerEmpty(t *testing.T) {
+	tempDir := t.TempDir()
+	indexer, err := NewSQLiteMembershipIndexer(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	defer indexer.Close()
+
+	repo, err := NewFileSystemContainerRepository(tempDir, indexer)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := repo.CreateContainer(ctx, domain.NewContainer(ctx, "test-container", "", domain.BasicContainer)); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+
+	empty, err := repo.IsContainerEmpty(ctx, "test-container")
+	if err != nil {
+		t.Fatalf("IsContainerEmpty() error = %v", err)
+	}
+	if !empty {
+		t.Error("New container should be empty")
+	}
+
+	if err := indexer.IndexMembership(ctx, "test-container", "member-1"); err != nil {
+		t.Fatalf("Failed to index member: %v", err)
+	}
+
+	empty, err = repo.IsContainerEmpty(ctx, "test-container")
+	if err != nil {
+		t.Fatalf("IsContainerEmpty() error = %v", err)
+	}
+	if empty {
+		t.Error("Container with an indexed member should not be empty")
+	}
+
+	err = repo.DeleteContainer(ctx, "test-container")
+	if !domain.IsContainerNotEmpty(err) {
+		t.Errorf("DeleteContainer() error = %v, want a container-not-empty error", err)
+	}
+
+	if _, err := repo.IsContainerEmpty(ctx, ""); err == nil {
+		t.Error("IsContainerEmpty() should reject an empty container ID")
+	}
+}
+
+// storeTestMembers stores plain resources for the given IDs so they can be added as members
+func storeTestMembers(t *testing.T, repo *FileSystemContainerRepository, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		resource := domain.NewResource(context.Background(), id, "application/ld+json", []byte(`{"@context": "http://example.org", "name": "test"}`))
+		if err := repo.Store(context.Background(), resource); err != nil {
+			t.Fatalf("Failed to store member resource %s: %v", id, err)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 // FileSystemRepository implements StreamingResourceRepository using file system storage
 type FileSystemRepository struct {
 	basePath string
+	ids      *domain.IDValidator
 }
 
 // NewFileSystemRepository creates a new FileSystemRepository
@@ -37,6 +38,12 @@ func NewFileSystemRepository(basePath string) (*FileSystemRepository, error) {
 	}, nil
 }
 
+// SetIDValidator sets the URI-safe pattern IDs of stored resources must match; without one
+// IDs are checked against domain.DefaultIDPattern
+func (r *FileSystemRepository) SetIDValidator(ids *domain.IDValidator) {
+	r.ids = ids
+}
+
 // NewFileSystemRepositoryProvider provides a FileSystemRepository for Wire dependency injection
 // This function uses a default base path for the repository
 func NewFileSystemRepositoryProvider() (domain.StreamingResourceRepository, error) {
@@ -75,6 +82,9 @@ func (r *FileSystemRepository) Store(ctx context.Context, resource domain.Resour
 			"resource ID cannot be empty",
 		).WithOperation("Store")
 	}
+	if err := r.ids.ValidateID(resource.ID()); err != nil {
+		return err.(*domain.StorageError).WithOperation("Store")
+	}
 
 	// Create resource directory
 	resourceDir := r.getResourcePath(resource.ID())
@@ -124,6 +134,53 @@ func (r *FileSystemRepository) Store(ctx context.Context, resource domain.Resour
 	return nil
 }
 
+// Create stores a new resource, failing with ErrResourceAlreadyExists when its ID is taken.
+// The content file is claimed with O_EXCL before anything is written, so concurrent creates of
+// one ID cannot both succeed or overwrite each other.
+func (r *FileSystemRepository) Create(ctx context.Context, resource domain.Resource) error {
+	if resource == nil || resource.ID() == "" {
+		// Store reports the invalid resource or ID
+		return r.Store(ctx, resource)
+	}
+
+	resourceDir := r.getResourcePath(resource.ID())
+	if err := os.MkdirAll(resourceDir, 0755); err != nil {
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to create resource directory",
+		).WithOperation("Create").WithContext("resourceID", resource.ID())
+	}
+
+	contentPath := filepath.Join(resourceDir, "content")
+	claim, err := os.OpenFile(contentPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return domain.WrapStorageError(
+				err,
+				domain.ErrResourceAlreadyExists.Code,
+				"resource already exists",
+			).WithOperation("Create").WithContext("resourceID", resource.ID())
+		}
+		return domain.WrapStorageError(
+			err,
+			domain.ErrStorageOperation.Code,
+			"failed to claim resource",
+		).WithOperation("Create").WithContext("resourceID", resource.ID())
+	}
+	claim.Close()
+
+	if err := r.Store(ctx, resource); err != nil {
+		// Release the claim so the ID is free again
+		if removeErr := os.RemoveAll(resourceDir); removeErr != nil {
+			fmt.Printf("Warning: failed to release claim on resource %s: %v\n", resource.ID(), removeErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
 // Retrieve loads a resource from the file system with checksum validation
 func (r *FileSystemRepository) Retrieve(ctx context.Context, id string) (domain.Resource, error) {
 	if id == "" {
@@ -250,6 +307,23 @@ func (r *FileSystemRepository) Exists(ctx context.Context, id string) (bool, err
 	return r.resourceExists(resourceDir), nil
 }
 
+// ExistsBatch reports which of the resources exist. Each resource is one directory, so this
+// checks the directories alone and never opens a resource's metadata or content.
+func (r *FileSystemRepository) ExistsBatch(ctx context.Context, ids []string) (map[string]bool, error) {
+	exists := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, domain.WrapStorageError(
+				fmt.Errorf("resource ID cannot be empty"),
+				domain.ErrInvalidID.Code,
+				"resource ID cannot be empty",
+			).WithOperation("ExistsBatch")
+		}
+		exists[id] = r.resourceExists(r.getResourcePath(id))
+	}
+	return exists, nil
+}
+
 // Helper methods
 
 // getResourcePath returns the file system path for a resource
@@ -364,6 +438,9 @@ func (r *FileSystemRepository) StoreStream(ctx context.Context, id string, reade
 			"resource ID cannot be empty",
 		).WithOperation("StoreStream")
 	}
+	if err := r.ids.ValidateID(id); err != nil {
+		return err.(*domain.StorageError).WithOperation("StoreStream")
+	}
 
 	if reader == nil {
 		return domain.WrapStorageError(
@@ -464,12 +541,36 @@ func (r *FileSystemRepository) StoreStream(ctx context.Context, id string, reade
 
 // RetrieveStream retrieves a resource as a stream for efficient memory usage
 func (r *FileSystemRepository) RetrieveStream(ctx context.Context, id string) (io.ReadCloser, *domain.ResourceMetadata, error) {
+	contentFile, metadata, err := r.openContent(id, "RetrieveStream")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Create a validating reader that checks checksum while streaming
+	validatingReader := &checksumValidatingReader{
+		reader:           contentFile,
+		hasher:           sha256.New(),
+		expectedChecksum: metadata.Checksum,
+		resourceID:       id,
+	}
+
+	return validatingReader, metadata, nil
+}
+
+// OpenContent opens a resource's content file for random access, for reading byte ranges of
+// large resources
+func (r *FileSystemRepository) OpenContent(ctx context.Context, id string) (io.ReadSeekCloser, *domain.ResourceMetadata, error) {
+	return r.openContent(id, "OpenContent")
+}
+
+// openContent reads a resource's metadata and opens its content file
+func (r *FileSystemRepository) openContent(id, operation string) (*os.File, *domain.ResourceMetadata, error) {
 	if id == "" {
 		return nil, nil, domain.WrapStorageError(
 			fmt.Errorf("resource ID cannot be empty"),
 			domain.ErrInvalidID.Code,
 			"resource ID cannot be empty",
-		).WithOperation("RetrieveStream")
+		).WithOperation(operation)
 	}
 
 	resourceDir := r.getResourcePath(id)
@@ -480,7 +581,7 @@ func (r *FileSystemRepository) RetrieveStream(ctx context.Context, id string) (i
 			fmt.Errorf("resource not found"),
 			domain.ErrResourceNotFound.Code,
 			"resource not found",
-		).WithOperation("RetrieveStream").WithContext("resourceID", id)
+		).WithOperation(operation).WithContext("resourceID", id)
 	}
 
 	// Read metadata first
@@ -491,7 +592,7 @@ func (r *FileSystemRepository) RetrieveStream(ctx context.Context, id string) (i
 			err,
 			domain.ErrStorageOperation.Code,
 			"failed to read metadata file",
-		).WithOperation("RetrieveStream").WithContext("resourceID", id)
+		).WithOperation(operation).WithContext("resourceID", id)
 	}
 
 	var metadata domain.ResourceMetadata
@@ -500,7 +601,7 @@ func (r *FileSystemRepository) RetrieveStream(ctx context.Context, id string) (i
 			err,
 			domain.ErrStorageOperation.Code,
 			"failed to unmarshal metadata",
-		).WithOperation("RetrieveStream").WithContext("resourceID", id)
+		).WithOperation(operation).WithContext("resourceID", id)
 	}
 
 	// Open content file for streaming read
@@ -511,18 +612,10 @@ func (r *FileSystemRepository) RetrieveStream(ctx context.Context, id string) (i
 			err,
 			domain.ErrStorageOperation.Code,
 			"failed to open content file",
-		).WithOperation("RetrieveStream").WithContext("resourceID", id)
-	}
-
-	// Create a validating reader that checks checksum while streaming
-	validatingReader := &checksumValidatingReader{
-		reader:           contentFile,
-		hasher:           sha256.New(),
-		expectedChecksum: metadata.Checksum,
-		resourceID:       id,
+		).WithOperation(operation).WithContext("resourceID", id)
 	}
 
-	return validatingReader, &metadata, nil
+	return contentFile, &metadata, nil
 }
 
 // checksumValidatingReader wraps a reader to validate checksum while streaming
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -68,7 +69,7 @@ func TestFileSystemRepository_Store(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		resource    *domain.Resource
+		resource    domain.Resource
 		expectError bool
 		errorCode   string
 	}{
@@ -317,6 +318,21 @@ func TestFileSystemRepository_Exists(t *testing.T) {
 	}
 }
 
+func TestFileSystemRepository_ExistsBatch(t *testing.T) {
+	repo, err := NewFileSystemRepository(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Store(ctx, createTestResource("present", "text/plain", "data")))
+
+	exists, err := repo.ExistsBatch(ctx, []string{"present", "absent"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"present": true, "absent": false}, exists)
+
+	_, err = repo.ExistsBatch(ctx, []string{"present", ""})
+	assert.Error(t, err)
+}
+
 func TestFileSystemRepository_ChecksumValidation(t *testing.T) {
 	tempDir := t.TempDir()
 	repo, err := NewFileSystemRepository(tempDir)
@@ -546,15 +562,19 @@ func TestFileSystemRepository_MetadataPreservation(t *testing.T) {
 
 // Helper functions for tests
 
-func createTestResource(id, contentType, data string) *domain.Resource {
-	return domain.NewResource(id, contentType, []byte(data))
+func createTestResource(id, contentType, data string) domain.Resource {
+	return domain.NewResource(context.Background(), id, contentType, []byte(data))
 }
 
-func createTestResourceWithID(id, contentType, data string) *domain.Resource {
-	resource := &domain.Resource{}
+func createTestResourceWithID(id, contentType, data string) domain.Resource {
 	// This is a bit of a hack to create a resource with empty ID for testing
 	// In real usage, NewResource should always be used
-	return resource
+	return &domain.BasicResource{
+		BasicEntity: pericarpdomain.NewEntity(id),
+		ContentType: contentType,
+		Data:        []byte(data),
+		Metadata:    make(map[string]interface{}),
+	}
 }
 
 func TestNewFileSystemRepositoryProvider(t *testing.T) {
@@ -581,3 +601,44 @@ func TestNewFileSystemRepositoryProvider(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, testResource.GetData(), retrieved.GetData())
 }
+
+func TestFileSystemRepository_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fails when the ID is taken", func(t *testing.T) {
+		repo, err := NewFileSystemRepository(t.TempDir())
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Create(ctx, domain.NewResource(ctx, "notes", "text/plain", []byte("first"))))
+
+		err = repo.Create(ctx, domain.NewResource(ctx, "notes", "text/plain", []byte("second")))
+		assert.True(t, domain.IsResourceAlreadyExists(err))
+
+		retrieved, err := repo.Retrieve(ctx, "notes")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), retrieved.GetData())
+	})
+
+	t.Run("concurrent creates of one ID have a single winner", func(t *testing.T) {
+		repo, err := NewFileSystemRepository(t.TempDir())
+		require.NoError(t, err)
+
+		const writers = 10
+		results := make(chan error, writers)
+		for i := 0; i < writers; i++ {
+			go func(i int) {
+				results <- repo.Create(ctx, domain.NewResource(ctx, "race", "text/plain", []byte(fmt.Sprintf("data-%d", i))))
+			}(i)
+		}
+
+		created := 0
+		for i := 0; i < writers; i++ {
+			if err := <-results; err == nil {
+				created++
+			} else {
+				assert.True(t, domain.IsResourceAlreadyExists(err))
+			}
+		}
+		assert.Equal(t, 1, created)
+	})
+}
@@ -8,11 +8,13 @@ import (
 
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GORMContainerRepository implements ContainerRepository using GORM
 type GORMContainerRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	ids *domain.IDValidator
 }
 
 // NewGORMContainerRepository creates a new GORM-based container repository
@@ -31,6 +33,12 @@ func NewGORMContainerRepository(db *gorm.DB) (*GORMContainerRepository, error) {
 	}, nil
 }
 
+// SetIDValidator sets the URI-safe pattern IDs of created containers must match; without one
+// IDs are checked against domain.DefaultIDPattern
+func (r *GORMContainerRepository) SetIDValidator(ids *domain.IDValidator) {
+	r.ids = ids
+}
+
 // Store implements ResourceRepository.Store
 func (r *GORMContainerRepository) Store(ctx context.Context, resource domain.Resource) error {
 	if resource == nil {
@@ -151,6 +159,9 @@ func (r *GORMContainerRepository) CreateContainer(ctx context.Context, container
 	if container == nil {
 		return fmt.Errorf("container cannot be nil")
 	}
+	if err := r.ids.ValidateID(container.ID()); err != nil {
+		return err.(*domain.StorageError).WithOperation("CreateContainer")
+	}
 
 	// Check if container already exists
 	exists, err := r.ContainerExists(ctx, container.ID())
@@ -300,7 +311,8 @@ func (r *GORMContainerRepository) AddMember(ctx context.Context, containerID, me
 		CreatedAt:   time.Now(),
 	}
 
-	return r.db.WithContext(ctx).Create(membership).Error
+	// The (container_id, member_id) key makes re-adding an existing member a no-op
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(membership).Error
 }
 
 // RemoveMember implements ContainerRepository.RemoveMember
@@ -344,6 +356,44 @@ func (r *GORMContainerRepository) ListMembers(ctx context.Context, containerID s
 	return memberIDs, nil
 }
 
+// ListMembersWithTotal lists one page of a container's members and counts all of its memberships
+func (r *GORMContainerRepository) ListMembersWithTotal(ctx context.Context, containerID string, pagination domain.PaginationOptions) ([]string, int, error) {
+	memberIDs, err := r.ListMembers(ctx, containerID, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	err = r.db.WithContext(ctx).
+		Model(&MembershipModel{}).
+		Where("container_id = ?", containerID).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count members: %w", err)
+	}
+
+	return memberIDs, int(total), nil
+}
+
+// IsContainerEmpty reports whether a container has no memberships, reading at most one row
+func (r *GORMContainerRepository) IsContainerEmpty(ctx context.Context, containerID string) (bool, error) {
+	if containerID == "" {
+		return false, fmt.Errorf("container ID cannot be empty")
+	}
+
+	var memberIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&MembershipModel{}).
+		Where("container_id = ?", containerID).
+		Limit(1).
+		Pluck("member_id", &memberIDs).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check container members: %w", err)
+	}
+
+	return len(memberIDs) == 0, nil
+}
+
 // GetChildren implements ContainerRepository.GetChildren
 func (r *GORMContainerRepository) GetChildren(ctx context.Context, containerID string) ([]domain.ContainerResource, error) {
 	if containerID == "" {
@@ -426,8 +476,8 @@ func (r *GORMContainerRepository) FindByPath(ctx context.Context, path string) (
 
 func (r *GORMContainerRepository) containerDomainToModel(container domain.ContainerResource) *ContainerModel {
 	var parentID *string
-	if container.GetParentID() != "" {
-		parentID = &container.GetParentID()
+	if id := container.GetParentID(); id != "" {
+		parentID = &id
 	}
 
 	return &ContainerModel{
@@ -465,6 +515,8 @@ func (r *GORMContainerRepository) containerModelToDomain(model *ContainerModel)
 	switch model.Type {
 	case "DirectContainer":
 		containerType = domain.DirectContainer
+	case "IndirectContainer":
+		containerType = domain.IndirectContainer
 	default:
 		containerType = domain.BasicContainer
 	}
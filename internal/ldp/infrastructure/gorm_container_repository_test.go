@@ -211,6 +211,42 @@ func TestGORMContainerRepository_ContainerHierarchy(t *testing.T) {
 	assert.Equal(t, []string{"parent", "child"}, path)
 }
 
+func TestGORMContainerRepository_AddMember_Idempotent(t *testing.T) {
+	db := setupTestDB(t)
+	repo, err := NewGORMContainerRepository(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	container := domain.NewContainer(ctx, "container", "", domain.BasicContainer)
+	require.NoError(t, repo.CreateContainer(ctx, container))
+
+	// Adding the same member twice keeps a single membership row
+	require.NoError(t, repo.AddMember(ctx, "container", "resource-1"))
+	require.NoError(t, repo.AddMember(ctx, "container", "resource-1"))
+
+	var count int64
+	require.NoError(t, db.Model(&MembershipModel{}).Where("container_id = ? AND member_id = ?", "container", "resource-1").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGORMContainerRepository_ListMembersWithTotal(t *testing.T) {
+	db := setupTestDB(t)
+	repo, err := NewGORMContainerRepository(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	container := domain.NewContainer(ctx, "container", "", domain.BasicContainer)
+	require.NoError(t, repo.CreateContainer(ctx, container))
+	for _, memberID := range []string{"resource-1", "resource-2", "resource-3"} {
+		require.NoError(t, repo.AddMember(ctx, "container", memberID))
+	}
+
+	members, total, err := repo.ListMembersWithTotal(ctx, "container", domain.PaginationOptions{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, 3, total)
+}
+
 func TestGORMContainerRepository_Membership(t *testing.T) {
 	db := setupTestDB(t)
 	repo, err := NewGORMContainerRepository(db)
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"gorm.io/gorm"
+)
+
+// ResourceIdentifierModel is the Dublin Core identifier of one resource or container
+type ResourceIdentifierModel struct {
+	ResourceID string `gorm:"primaryKey;type:varchar(255)"`
+	AccountID  string `gorm:"not null;type:varchar(255);index:idx_account_identifier"`
+	Identifier string `gorm:"not null;type:varchar(1024);index:idx_account_identifier"`
+}
+
+// TableName specifies the table name for ResourceIdentifierModel
+func (ResourceIdentifierModel) TableName() string {
+	return "resource_identifiers"
+}
+
+// GormIdentifierIndex keeps Dublin Core identifiers in a table indexed by account and
+// identifier. The index is not unique, since only some accounts require unique identifiers;
+// the check runs in the same transaction as the write.
+type GormIdentifierIndex struct {
+	db *gorm.DB
+}
+
+// NewGormIdentifierIndex creates an identifier index, creating its table if needed
+func NewGormIdentifierIndex(db *gorm.DB) (*GormIdentifierIndex, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	if err := db.AutoMigrate(&ResourceIdentifierModel{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate resource identifier table: %w", err)
+	}
+	return &GormIdentifierIndex{db: db}, nil
+}
+
+// SetIdentifier records a resource's identifier, refusing one another resource of the account
+// holds when unique is set
+func (i *GormIdentifierIndex) SetIdentifier(ctx context.Context, accountID, resourceID, identifier string, unique bool) error {
+	var holder string
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if unique {
+			var existing ResourceIdentifierModel
+			result := tx.Where("account_id = ? AND identifier = ? AND resource_id <> ?", accountID, identifier, resourceID).
+				Limit(1).Find(&existing)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				holder = existing.ResourceID
+				return domain.ErrDuplicateIdentifier
+			}
+		}
+		return tx.Save(&ResourceIdentifierModel{ResourceID: resourceID, AccountID: accountID, Identifier: identifier}).Error
+	})
+	if holder != "" {
+		return domain.WrapStorageError(
+			fmt.Errorf("dc:identifier %q is held by %s", identifier, holder),
+			domain.ErrDuplicateIdentifier.Code,
+			domain.ErrDuplicateIdentifier.Message,
+		).WithOperation("SetIdentifier").WithContext("identifier", identifier).WithContext("heldBy", holder)
+	}
+	if err != nil {
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to record resource identifier").
+			WithOperation("SetIdentifier").WithContext("resourceID", resourceID).WithContext("accountID", accountID)
+	}
+	return nil
+}
+
+// RemoveIdentifier drops a resource's identifier
+func (i *GormIdentifierIndex) RemoveIdentifier(ctx context.Context, resourceID string) error {
+	if err := i.db.WithContext(ctx).Delete(&ResourceIdentifierModel{}, "resource_id = ?", resourceID).Error; err != nil {
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to remove resource identifier").
+			WithOperation("RemoveIdentifier").WithContext("resourceID", resourceID)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormIdentifierIndex(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	index, err := NewGormIdentifierIndex(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, index.SetIdentifier(ctx, "alice", "notes", "isbn:1", true))
+
+	t.Run("duplicate within an account is refused", func(t *testing.T) {
+		err := index.SetIdentifier(ctx, "alice", "photo", "isbn:1", true)
+		require.Error(t, err)
+		storageErr, ok := domain.GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrDuplicateIdentifier.Code, storageErr.Code)
+		assert.Equal(t, "notes", storageErr.Context["heldBy"])
+	})
+
+	t.Run("a resource may keep its own identifier", func(t *testing.T) {
+		assert.NoError(t, index.SetIdentifier(ctx, "alice", "notes", "isbn:1", true))
+	})
+
+	t.Run("other accounts and non-unique writes are not checked", func(t *testing.T) {
+		assert.NoError(t, index.SetIdentifier(ctx, "bob", "bob-notes", "isbn:1", true))
+		assert.NoError(t, index.SetIdentifier(ctx, "alice", "draft", "isbn:1", false))
+	})
+
+	t.Run("a removed or replaced identifier is free again", func(t *testing.T) {
+		require.NoError(t, index.RemoveIdentifier(ctx, "draft"))
+		require.NoError(t, index.SetIdentifier(ctx, "alice", "notes", "isbn:2", true))
+		assert.NoError(t, index.SetIdentifier(ctx, "alice", "photo", "isbn:1", true))
+		assert.NoError(t, index.RemoveIdentifier(ctx, "never-recorded"))
+	})
+}
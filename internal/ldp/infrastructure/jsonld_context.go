@@ -0,0 +1,150 @@
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// JSONLDExpandedProfile is the profile a client asks for to receive expanded JSON-LD: full IRIs,
+// every value an array of value or node objects, and no context
+const JSONLDExpandedProfile = "http://www.w3.org/ns/json-ld#expanded"
+
+// JSONLDExpandedFormat identifies expanded JSON-LD among the formats containers are converted to
+const JSONLDExpandedFormat = `application/ld+json; profile="` + JSONLDExpandedProfile + `"`
+
+// IRIs of the container description the JSON-LD context has terms for
+const (
+	dctermsTitle       = "http://purl.org/dc/terms/title"
+	dctermsDescription = "http://purl.org/dc/terms/description"
+	dctermsCreated     = "http://purl.org/dc/terms/created"
+	dctermsModified    = "http://purl.org/dc/terms/modified"
+	xsdDateTime        = "http://www.w3.org/2001/XMLSchema#dateTime"
+)
+
+// defaultJSONLDPrefixes are the namespace prefixes of the server's JSON-LD context
+var defaultJSONLDPrefixes = map[string]string{
+	"rdf":     "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"ldp":     "http://www.w3.org/ns/ldp#",
+	"dcterms": "http://purl.org/dc/terms/",
+	"xsd":     "http://www.w3.org/2001/XMLSchema#",
+}
+
+// defaultJSONLDTerms are the terms the server's JSON-LD context aliases to common IRIs
+var defaultJSONLDTerms = map[string]string{
+	"type":        "http://www.w3.org/1999/02/22-rdf-syntax-ns#type",
+	"contains":    domain.LDPContains,
+	"title":       dctermsTitle,
+	"description": dctermsDescription,
+	"created":     dctermsCreated,
+	"modified":    dctermsModified,
+}
+
+// JSONLDContext is the context compact JSON-LD is written with: namespace prefixes, and terms
+// aliasing whole IRIs. Every term names exactly one IRI and every IRI has at most one term, so
+// compacting and expanding round-trip.
+type JSONLDContext struct {
+	prefixes map[string]string
+	terms    map[string]string
+	byIRI    map[string]string
+}
+
+// NewJSONLDContext creates the server's default context extended with the given aliases, from
+// term to IRI. An alias repeating a default is accepted; one giving a default term another IRI,
+// naming an IRI that already has a term, or shaped like a keyword, prefix or compact IRI is not.
+func NewJSONLDContext(aliases map[string]string) (*JSONLDContext, error) {
+	context := &JSONLDContext{
+		prefixes: defaultJSONLDPrefixes,
+		terms:    make(map[string]string, len(defaultJSONLDTerms)+len(aliases)),
+		byIRI:    make(map[string]string, len(defaultJSONLDTerms)+len(aliases)),
+	}
+	for term, iri := range defaultJSONLDTerms {
+		context.terms[term] = iri
+		context.byIRI[iri] = term
+	}
+
+	// Add aliases in a fixed order so a collision is always reported the same way
+	terms := make([]string, 0, len(aliases))
+	for term := range aliases {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	for _, term := range terms {
+		iri := aliases[term]
+		switch {
+		case term == "" || strings.HasPrefix(term, "@") || strings.ContainsAny(term, ":/#"):
+			return nil, fmt.Errorf("JSON-LD term %q must be a plain name", term)
+		case !strings.Contains(iri, ":"):
+			return nil, fmt.Errorf("JSON-LD term %q must alias an absolute IRI, got %q", term, iri)
+		}
+		if _, isPrefix := context.prefixes[term]; isPrefix {
+			return nil, fmt.Errorf("JSON-LD term %q collides with a namespace prefix", term)
+		}
+		if existing, ok := context.terms[term]; ok {
+			if existing == iri {
+				continue
+			}
+			return nil, fmt.Errorf("JSON-LD term %q already aliases %s", term, existing)
+		}
+		if existing, ok := context.byIRI[iri]; ok {
+			return nil, fmt.Errorf("JSON-LD terms %q and %q both alias %s", existing, term, iri)
+		}
+		context.terms[term] = iri
+		context.byIRI[iri] = term
+	}
+
+	return context, nil
+}
+
+// DefaultJSONLDContext returns the server's context without additional aliases
+func DefaultJSONLDContext() *JSONLDContext {
+	context, _ := NewJSONLDContext(nil)
+	return context
+}
+
+// Compact returns the shortest name for an IRI: its term, else a compact IRI using one of the
+// prefixes, else the IRI itself
+func (c *JSONLDContext) Compact(iri string) string {
+	if term, ok := c.byIRI[iri]; ok {
+		return term
+	}
+	return c.compactPrefixed(iri)
+}
+
+// Expand returns the IRI a term or compact IRI stands for
+func (c *JSONLDContext) Expand(name string) string {
+	if iri, ok := c.terms[name]; ok {
+		return iri
+	}
+	if prefix, suffix, ok := strings.Cut(name, ":"); ok {
+		if namespace, known := c.prefixes[prefix]; known {
+			return namespace + suffix
+		}
+	}
+	return name
+}
+
+// Document returns the @context value declaring the prefixes and terms
+func (c *JSONLDContext) Document() map[string]interface{} {
+	document := make(map[string]interface{}, len(c.prefixes)+len(c.terms))
+	for prefix, namespace := range c.prefixes {
+		document[prefix] = namespace
+	}
+	for term, iri := range c.terms {
+		document[term] = c.compactPrefixed(iri)
+	}
+	return document
+}
+
+// compactPrefixed writes an IRI as a compact IRI when one of the prefixes covers it
+func (c *JSONLDContext) compactPrefixed(iri string) string {
+	for prefix, namespace := range c.prefixes {
+		if suffix, ok := strings.CutPrefix(iri, namespace); ok && suffix != "" {
+			return prefix + ":" + suffix
+		}
+	}
+	return iri
+}
@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSONLDContext_Aliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases map[string]string
+		wantErr bool
+	}{
+		{"no aliases", nil, false},
+		{"new term", map[string]string{"creator": "http://purl.org/dc/terms/creator"}, false},
+		{"repeating a default", map[string]string{"contains": domain.LDPContains}, false},
+		{"redefining a default term", map[string]string{"title": "http://xmlns.com/foaf/0.1/name"}, true},
+		{"second term for an IRI", map[string]string{"member": domain.LDPContains}, true},
+		{"two aliases for one IRI", map[string]string{"a": "http://example.com/p", "b": "http://example.com/p"}, true},
+		{"prefix as a term", map[string]string{"ldp": "http://example.com/ldp"}, true},
+		{"keyword", map[string]string{"@id": "http://example.com/id"}, true},
+		{"compact IRI as a term", map[string]string{"ex:name": "http://example.com/name"}, true},
+		{"relative IRI", map[string]string{"name": "name"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJSONLDContext(tt.aliases)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJSONLDContext_RoundTrip(t *testing.T) {
+	context, err := NewJSONLDContext(map[string]string{"creator": "http://purl.org/dc/terms/creator"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "contains", context.Compact(domain.LDPContains))
+	assert.Equal(t, "title", context.Compact("http://purl.org/dc/terms/title"))
+	assert.Equal(t, "creator", context.Compact("http://purl.org/dc/terms/creator"))
+	assert.Equal(t, "ldp:membershipResource", context.Compact(domain.LDPNamespace+"membershipResource"))
+	assert.Equal(t, "http://example.com/p", context.Compact("http://example.com/p"))
+
+	for _, iri := range []string{
+		domain.LDPContains,
+		domain.LDPNamespace + "BasicContainer",
+		"http://purl.org/dc/terms/title",
+		"http://purl.org/dc/terms/creator",
+		"http://purl.org/dc/terms/subject",
+		"http://www.w3.org/2001/XMLSchema#dateTime",
+		"http://example.com/p",
+	} {
+		assert.Equal(t, iri, context.Expand(context.Compact(iri)), iri)
+	}
+
+	// Every term in the document expands to the IRI it aliases
+	document := context.Document()
+	for term, iri := range context.terms {
+		assert.Equal(t, iri, context.Expand(document[term].(string)), term)
+	}
+}
+
+func TestContainerRDFConverter_ExpandedJSONLD(t *testing.T) {
+	converter := NewContainerRDFConverter()
+
+	container := domain.NewContainer(context.Background(), "container-1", "", domain.BasicContainer)
+	container.SetTitle("Test Container")
+	container.Members = []string{"resource-1"}
+
+	result, err := converter.ConvertToExpandedJSONLD(container, "http://example.org/")
+	require.NoError(t, err)
+
+	var nodes []map[string]interface{}
+	require.NoError(t, json.Unmarshal(result, &nodes))
+	require.Len(t, nodes, 1)
+
+	node := nodes[0]
+	assert.Equal(t, "http://example.org/container-1", node["@id"])
+	assert.NotContains(t, node, "@context")
+	assert.Contains(t, node["@type"], domain.LDPNamespace+"BasicContainer")
+	assert.Equal(t, []interface{}{map[string]interface{}{"@id": "http://example.org/resource-1"}}, node[domain.LDPContains])
+	assert.Equal(t, []interface{}{map[string]interface{}{"@value": "Test Container"}}, node["http://purl.org/dc/terms/title"])
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -13,18 +15,39 @@ import (
 type ResourceType string
 
 const (
-	ResourceTypeContainer ResourceType = "Container"
-	ResourceTypeResource  ResourceType = "Resource"
+	ResourceTypeContainer    ResourceType = "Container"
+	ResourceTypeResource     ResourceType = "Resource"
+	ResourceTypeNonRDFSource ResourceType = "NonRDFSource"
 )
 
+// DetectMemberType returns the type of a member from its stored content type and whether it
+// has container metadata: containers are Containers, members stored in an RDF format are RDF
+// Resources and anything else is a NonRDFSource. Members without a known content type are
+// reported as Resources.
+func DetectMemberType(contentType string, isContainer bool) ResourceType {
+	if isContainer {
+		return ResourceTypeContainer
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	if strings.TrimSpace(mediaType) == "" || domain.IsRDFFormat(mediaType) {
+		return ResourceTypeResource
+	}
+	return ResourceTypeNonRDFSource
+}
+
 // MemberInfo contains information about a container member
 type MemberInfo struct {
-	ID          string
-	Type        ResourceType
+	ID   string
+	Type ResourceType
+	// Title is the member's dcterms:title when the index records one
+	Title       string
 	ContentType string
 	Size        int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// LastAccessedAt is zero when no read of the member has been recorded
+	LastAccessedAt time.Time
 }
 
 // PaginationOptions contains pagination parameters
@@ -35,13 +58,17 @@ type PaginationOptions struct {
 
 // FilterOptions represents filtering options for container members
 type FilterOptions struct {
-	MemberType    string     `json:"memberType,omitempty"`    // "Container" or "Resource"
+	MemberType    string     `json:"memberType,omitempty"`    // "Container", "Resource" or "NonRDFSource"
 	ContentType   string     `json:"contentType,omitempty"`   // MIME type filter
 	NamePattern   string     `json:"namePattern,omitempty"`   // Name pattern matching
 	CreatedAfter  *time.Time `json:"createdAfter,omitempty"`  // Created after timestamp
 	CreatedBefore *time.Time `json:"createdBefore,omitempty"` // Created before timestamp
-	SizeMin       *int64     `json:"sizeMin,omitempty"`       // Minimum size in bytes
-	SizeMax       *int64     `json:"sizeMax,omitempty"`       // Maximum size in bytes
+	// ModifiedAfter and ModifiedBefore bound when a member last changed; a member never
+	// updated last changed when it was created
+	ModifiedAfter  *time.Time `json:"modifiedAfter,omitempty"`  // Modified after timestamp
+	ModifiedBefore *time.Time `json:"modifiedBefore,omitempty"` // Modified before timestamp
+	SizeMin        *int64     `json:"sizeMin,omitempty"`        // Minimum size in bytes
+	SizeMax        *int64     `json:"sizeMax,omitempty"`        // Maximum size in bytes
 }
 
 // SortOptions represents sorting options for container members
@@ -101,9 +128,9 @@ func (s *SQLiteMembershipIndexer) IndexMembership(ctx context.Context, container
 		return fmt.Errorf("failed to check member type: %w", err)
 	}
 
-	// Insert membership
+	// Insert membership; the (container_id, member_id) key keeps re-indexing idempotent
 	query := `
-		INSERT OR REPLACE INTO memberships (container_id, member_id, member_type, created_at) 
+		INSERT OR IGNORE INTO memberships (container_id, member_id, member_type, created_at) 
 		VALUES (?, ?, ?, CURRENT_TIMESTAMP)`
 
 	_, err = s.db.ExecContext(ctx, query, containerID, memberID, string(memberType))
@@ -137,26 +164,11 @@ func (s *SQLiteMembershipIndexer) RemoveMembership(ctx context.Context, containe
 
 // GetMembers retrieves all members of a container with pagination
 func (s *SQLiteMembershipIndexer) GetMembers(ctx context.Context, containerID string, pagination PaginationOptions) ([]MemberInfo, error) {
-	query := `
-		SELECT m.member_id, m.member_type, m.created_at,
-			   COALESCE(c.updated_at, m.created_at) as updated_at
-		FROM memberships m
-		LEFT JOIN containers c ON m.member_id = c.id AND m.member_type = 'Container'
-		WHERE m.container_id = ?
-		ORDER BY m.created_at`
-
+	query := memberSelectSQL + " ORDER BY m.created_at"
 	args := []interface{}{containerID}
 
 	// Add pagination if specified
-	if pagination.Limit > 0 {
-		query += " LIMIT ?"
-		args = append(args, pagination.Limit)
-
-		if pagination.Offset > 0 {
-			query += " OFFSET ?"
-			args = append(args, pagination.Offset)
-		}
-	}
+	query, args = appendMemberPagination(query, args, pagination)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -164,49 +176,8 @@ func (s *SQLiteMembershipIndexer) GetMembers(ctx context.Context, containerID st
 	}
 	defer rows.Close()
 
-	var members []MemberInfo
-	for rows.Next() {
-		var member MemberInfo
-		var memberTypeStr string
-		var createdAtStr, updatedAtStr string
-
-		err := rows.Scan(&member.ID, &memberTypeStr, &createdAtStr, &updatedAtStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan member: %w", err)
-		}
-
-		// Parse timestamps
-		member.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAtStr)
-		if err != nil {
-			// Try alternative format
-			member.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
-			if err != nil {
-				member.CreatedAt = time.Now() // fallback
-			}
-		}
-
-		member.UpdatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAtStr)
-		if err != nil {
-			// Try alternative format
-			member.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
-			if err != nil {
-				member.UpdatedAt = member.CreatedAt // fallback
-			}
-		}
-
-		member.Type = ResourceType(memberTypeStr)
-
-		// Set default content type based on type
-		if member.Type == ResourceTypeContainer {
-			member.ContentType = "application/ld+json"
-		} else {
-			member.ContentType = "application/octet-stream"
-		}
-
-		members = append(members, member)
-	}
-
-	if err := rows.Err(); err != nil {
+	members, err := scanMembers(rows)
+	if err != nil {
 		return nil, fmt.Errorf("error iterating members: %w", err)
 	}
 
@@ -291,6 +262,34 @@ func (s *SQLiteMembershipIndexer) GetMemberCount(ctx context.Context, containerI
 	return count, nil
 }
 
+// HasMembers reports whether a container has any members. The lookup stops at the first
+// membership found through the (container_id, member_id) key, so it does not grow with the
+// container's size.
+func (s *SQLiteMembershipIndexer) HasMembers(ctx context.Context, containerID string) (bool, error) {
+	var exists bool
+	query := "SELECT EXISTS(SELECT 1 FROM memberships WHERE container_id = ?)"
+
+	if err := s.db.QueryRowContext(ctx, query, containerID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check for members: %w", err)
+	}
+
+	return exists, nil
+}
+
+// StoredCounts returns how many containers the index records and how many distinct resources
+// are members of them
+func (s *SQLiteMembershipIndexer) StoredCounts(ctx context.Context) (int64, int64, error) {
+	var containers, resources int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM containers").Scan(&containers); err != nil {
+		return 0, 0, fmt.Errorf("failed to count containers: %w", err)
+	}
+	query := "SELECT COUNT(DISTINCT member_id) FROM memberships WHERE member_type <> ?"
+	if err := s.db.QueryRowContext(ctx, query, string(ResourceTypeContainer)).Scan(&resources); err != nil {
+		return 0, 0, fmt.Errorf("failed to count resources: %w", err)
+	}
+	return containers, resources, nil
+}
+
 // GetContainerStats returns statistics about a container
 func (s *SQLiteMembershipIndexer) GetContainerStats(ctx context.Context, containerID string) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
@@ -331,67 +330,290 @@ func (s *SQLiteMembershipIndexer) GetContainerStats(ctx context.Context, contain
 
 	stats["type_breakdown"] = typeStats
 
+	lastAccessed, err := s.ContainerLastAccessed(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if !lastAccessed.IsZero() {
+		stats["last_accessed_at"] = lastAccessed
+	}
+
 	return stats, nil
 }
 
+// RecordAccesses stores when resources were last read. A read older than the one already
+// recorded is ignored, so batches flushed out of order cannot move a resource back in time.
+func (s *SQLiteMembershipIndexer) RecordAccesses(ctx context.Context, accesses map[string]time.Time) error {
+	if len(accesses) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO resource_access (resource_id, last_accessed_at) VALUES (?, ?)
+		ON CONFLICT (resource_id) DO UPDATE SET last_accessed_at = excluded.last_accessed_at
+		WHERE excluded.last_accessed_at > resource_access.last_accessed_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare access update: %w", err)
+	}
+	defer stmt.Close()
+
+	for resourceID, accessedAt := range accesses {
+		if _, err := stmt.ExecContext(ctx, resourceID, formatAccessTimestamp(accessedAt)); err != nil {
+			return fmt.Errorf("failed to record access: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit access updates: %w", err)
+	}
+
+	return nil
+}
+
+// GetStaleMembers lists resource members whose last read, or their indexing when no read was
+// recorded, is before the cutoff, least recently used first
+func (s *SQLiteMembershipIndexer) GetStaleMembers(ctx context.Context, cutoff time.Time) ([]domain.StaleResource, error) {
+	query := `
+		SELECT m.member_id, m.container_id, m.created_at, a.last_accessed_at
+		FROM memberships m
+		LEFT JOIN resource_access a ON a.resource_id = m.member_id
+		WHERE m.member_type = 'Resource' AND COALESCE(a.last_accessed_at, m.created_at) < ?
+		ORDER BY COALESCE(a.last_accessed_at, m.created_at)`
+
+	rows, err := s.db.QueryContext(ctx, query, formatAccessTimestamp(cutoff))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale members: %w", err)
+	}
+	defer rows.Close()
+
+	var stale []domain.StaleResource
+	for rows.Next() {
+		var resource domain.StaleResource
+		var createdAtStr string
+		var lastAccessed sql.NullString
+		if err := rows.Scan(&resource.ID, &resource.ContainerID, &createdAtStr, &lastAccessed); err != nil {
+			return nil, fmt.Errorf("failed to scan stale member: %w", err)
+		}
+		resource.CreatedAt = parseAccessTimestamp(createdAtStr)
+		if lastAccessed.Valid {
+			resource.LastAccessedAt = parseAccessTimestamp(lastAccessed.String)
+		}
+		stale = append(stale, resource)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stale members: %w", err)
+	}
+
+	return stale, nil
+}
+
+// ContainerLastAccessed returns the latest recorded read of any member of a container
+func (s *SQLiteMembershipIndexer) ContainerLastAccessed(ctx context.Context, containerID string) (time.Time, error) {
+	var lastAccessed sql.NullString
+	query := `
+		SELECT MAX(a.last_accessed_at)
+		FROM memberships m
+		JOIN resource_access a ON a.resource_id = m.member_id
+		WHERE m.container_id = ?`
+
+	if err := s.db.QueryRowContext(ctx, query, containerID).Scan(&lastAccessed); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last access: %w", err)
+	}
+	if !lastAccessed.Valid {
+		return time.Time{}, nil
+	}
+
+	return parseAccessTimestamp(lastAccessed.String), nil
+}
+
+// formatAccessTimestamp renders a time in the UTC layout CURRENT_TIMESTAMP uses, so recorded
+// reads compare correctly with membership creation times
+func formatAccessTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// parseAccessTimestamp parses a timestamp read from the index; unparseable values are zero
+func parseAccessTimestamp(value string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339Nano} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 // GetMembersWithFiltering retrieves container members with filtering and sorting
 func (s *SQLiteMembershipIndexer) GetMembersWithFiltering(ctx context.Context, containerID string, pagination PaginationOptions, filter FilterOptions, sort SortOptions) ([]MemberInfo, error) {
-	// Build base query
+	conditions, filterArgs := memberFilterSQL(filter)
+	query := memberSelectSQL + conditions + memberOrderSQL(sort)
+	args := append([]interface{}{containerID}, filterArgs...)
+
+	// Add pagination
+	query, args = appendMemberPagination(query, args, pagination)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query filtered members: %w", err)
+	}
+	defer rows.Close()
+
+	members, err := scanMembers(rows)
+	if err != nil {
+		return nil, fmt.Errorf("error iterating filtered members: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetFilteredMemberCount returns the count of members matching the filter
+func (s *SQLiteMembershipIndexer) GetFilteredMemberCount(ctx context.Context, containerID string, filter FilterOptions) (int, error) {
+	conditions, filterArgs := memberFilterSQL(filter)
+	query := "SELECT COUNT(*) FROM memberships m WHERE m.container_id = ?" + conditions
+	args := append([]interface{}{containerID}, filterArgs...)
+
+	var count int
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get filtered member count: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateMemberDetails records a member's type, content type, size and timestamps, indexing the
+// membership first when it is not recorded yet. An empty type and zero timestamps keep the
+// recorded ones.
+func (s *SQLiteMembershipIndexer) UpdateMemberDetails(ctx context.Context, containerID string, member MemberInfo) error {
+	if err := s.IndexMembership(ctx, containerID, member.ID); err != nil {
+		return err
+	}
+
 	query := `
+		UPDATE memberships
+		SET member_type = COALESCE(NULLIF(?, ''), member_type), content_type = ?, size = ?,
+			created_at = COALESCE(?, created_at), updated_at = COALESCE(?, updated_at)
+		WHERE container_id = ? AND member_id = ?`
+
+	_, err := s.db.ExecContext(ctx, query, string(member.Type), member.ContentType, member.Size,
+		nullableTimestamp(member.CreatedAt), nullableTimestamp(member.UpdatedAt), containerID, member.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update member details: %w", err)
+	}
+
+	return nil
+}
+
+// memberContentTypeSQL is a member's recorded content type, or the default for its type when
+// none was recorded
+const memberContentTypeSQL = `COALESCE(NULLIF(m.content_type, ''),
+	CASE WHEN m.member_type = 'Container' THEN 'application/ld+json' ELSE 'application/octet-stream' END)`
+
+// memberUpdatedAtSQL is when a member last changed: a member container's own update time,
+// else the recorded update, else when the membership was indexed
+const memberUpdatedAtSQL = `COALESCE(c.updated_at, m.updated_at, m.created_at)`
+
+// memberSelectSQL selects the members of the container bound to its one parameter, in the
+// column order scanMembers reads
+const memberSelectSQL = `
 		SELECT m.member_id, m.member_type, m.created_at,
-			   COALESCE(c.updated_at, m.created_at) as updated_at
+			   ` + memberUpdatedAtSQL + ` as updated_at, a.last_accessed_at,
+			   COALESCE(c.title, '') as title, ` + memberContentTypeSQL + ` as content_type, m.size
 		FROM memberships m
 		LEFT JOIN containers c ON m.member_id = c.id AND m.member_type = 'Container'
+		LEFT JOIN resource_access a ON a.resource_id = m.member_id
 		WHERE m.container_id = ?`
 
-	args := []interface{}{containerID}
+// memberFilterSQL renders a filter as conditions on the memberships alias m, with their
+// arguments. A content type ending in "/*", such as "text/*", matches every subtype.
+func memberFilterSQL(filter FilterOptions) (string, []interface{}) {
+	var conditions strings.Builder
+	var args []interface{}
 
-	// Add filtering conditions
 	if filter.MemberType != "" {
-		query += " AND m.member_type = ?"
+		conditions.WriteString(" AND m.member_type = ?")
 		args = append(args, filter.MemberType)
 	}
 
+	if filter.ContentType != "" {
+		if prefix, ok := strings.CutSuffix(filter.ContentType, "*"); ok {
+			conditions.WriteString(" AND substr(" + memberContentTypeSQL + ", 1, ?) = ?")
+			args = append(args, len(prefix), prefix)
+		} else {
+			conditions.WriteString(" AND " + memberContentTypeSQL + " = ?")
+			args = append(args, filter.ContentType)
+		}
+	}
+
 	if filter.NamePattern != "" {
-		query += " AND m.member_id LIKE ?"
+		conditions.WriteString(" AND m.member_id LIKE ?")
 		args = append(args, "%"+filter.NamePattern+"%")
 	}
 
 	if filter.CreatedAfter != nil {
-		query += " AND m.created_at > ?"
-		args = append(args, filter.CreatedAfter.Format("2006-01-02 15:04:05"))
+		conditions.WriteString(" AND m.created_at > ?")
+		args = append(args, formatAccessTimestamp(*filter.CreatedAfter))
 	}
 
 	if filter.CreatedBefore != nil {
-		query += " AND m.created_at < ?"
-		args = append(args, filter.CreatedBefore.Format("2006-01-02 15:04:05"))
-	}
-
-	// Add sorting
-	if sort.Field != "" && sort.Direction != "" {
-		switch sort.Field {
-		case "name":
-			query += " ORDER BY m.member_id"
-		case "createdAt":
-			query += " ORDER BY m.created_at"
-		case "updatedAt":
-			query += " ORDER BY COALESCE(c.updated_at, m.created_at)"
-		case "type":
-			query += " ORDER BY m.member_type"
-		default:
-			query += " ORDER BY m.created_at" // Default sort
-		}
+		conditions.WriteString(" AND m.created_at < ?")
+		args = append(args, formatAccessTimestamp(*filter.CreatedBefore))
+	}
 
-		if sort.Direction == "desc" {
-			query += " DESC"
-		} else {
-			query += " ASC"
-		}
-	} else {
-		query += " ORDER BY m.created_at ASC" // Default sort
+	if filter.ModifiedAfter != nil {
+		conditions.WriteString(" AND " + memberUpdatedAtSQL + " > ?")
+		args = append(args, formatAccessTimestamp(*filter.ModifiedAfter))
 	}
 
-	// Add pagination
+	if filter.ModifiedBefore != nil {
+		conditions.WriteString(" AND " + memberUpdatedAtSQL + " < ?")
+		args = append(args, formatAccessTimestamp(*filter.ModifiedBefore))
+	}
+
+	if filter.SizeMin != nil {
+		conditions.WriteString(" AND m.size >= ?")
+		args = append(args, *filter.SizeMin)
+	}
+
+	if filter.SizeMax != nil {
+		conditions.WriteString(" AND m.size <= ?")
+		args = append(args, *filter.SizeMax)
+	}
+
+	return conditions.String(), args
+}
+
+// memberOrderSQL renders a sort as an ORDER BY clause, oldest first by default. Ties are
+// broken by indexing order so pages stay stable.
+func memberOrderSQL(sort SortOptions) string {
+	column := "m.created_at"
+	switch sort.Field {
+	case "name":
+		column = "m.member_id"
+	case "updatedAt":
+		column = memberUpdatedAtSQL
+	case "size":
+		column = "m.size"
+	case "type":
+		column = "m.member_type"
+	}
+
+	direction := "ASC"
+	if sort.Direction == "desc" {
+		direction = "DESC"
+	}
+
+	return " ORDER BY " + column + " " + direction + ", m.rowid " + direction
+}
+
+// appendMemberPagination adds LIMIT and OFFSET to a member query; a zero limit reads every member
+func appendMemberPagination(query string, args []interface{}, pagination PaginationOptions) (string, []interface{}) {
 	if pagination.Limit > 0 {
 		query += " LIMIT ?"
 		args = append(args, pagination.Limit)
@@ -401,91 +623,56 @@ func (s *SQLiteMembershipIndexer) GetMembersWithFiltering(ctx context.Context, c
 			args = append(args, pagination.Offset)
 		}
 	}
+	return query, args
+}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query filtered members: %w", err)
-	}
-	defer rows.Close()
-
+// scanMembers reads rows selected by memberSelectSQL
+func scanMembers(rows *sql.Rows) ([]MemberInfo, error) {
 	var members []MemberInfo
 	for rows.Next() {
 		var member MemberInfo
 		var memberTypeStr string
 		var createdAtStr, updatedAtStr string
+		var lastAccessed sql.NullString
 
-		err := rows.Scan(&member.ID, &memberTypeStr, &createdAtStr, &updatedAtStr)
+		err := rows.Scan(&member.ID, &memberTypeStr, &createdAtStr, &updatedAtStr, &lastAccessed, &member.Title, &member.ContentType, &member.Size)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan member: %w", err)
 		}
+		if lastAccessed.Valid {
+			member.LastAccessedAt = parseAccessTimestamp(lastAccessed.String)
+		}
 
 		// Parse timestamps
 		member.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAtStr)
 		if err != nil {
+			// Try alternative format
 			member.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
 			if err != nil {
-				member.CreatedAt = time.Now()
+				member.CreatedAt = time.Now() // fallback
 			}
 		}
 
 		member.UpdatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAtStr)
 		if err != nil {
+			// Try alternative format
 			member.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
 			if err != nil {
-				member.UpdatedAt = member.CreatedAt
+				member.UpdatedAt = member.CreatedAt // fallback
 			}
 		}
 
 		member.Type = ResourceType(memberTypeStr)
-
-		// Set default content type based on type
-		if member.Type == ResourceTypeContainer {
-			member.ContentType = "application/ld+json"
-		} else {
-			member.ContentType = "application/octet-stream"
-		}
-
 		members = append(members, member)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating filtered members: %w", err)
-	}
-
-	return members, nil
+	return members, rows.Err()
 }
 
-// GetFilteredMemberCount returns the count of members matching the filter
-func (s *SQLiteMembershipIndexer) GetFilteredMemberCount(ctx context.Context, containerID string, filter FilterOptions) (int, error) {
-	query := "SELECT COUNT(*) FROM memberships WHERE container_id = ?"
-	args := []interface{}{containerID}
-
-	// Add filtering conditions
-	if filter.MemberType != "" {
-		query += " AND member_type = ?"
-		args = append(args, filter.MemberType)
-	}
-
-	if filter.NamePattern != "" {
-		query += " AND member_id LIKE ?"
-		args = append(args, "%"+filter.NamePattern+"%")
+// nullableTimestamp renders a time for the index, or nil for the zero time
+func nullableTimestamp(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
 	}
-
-	if filter.CreatedAfter != nil {
-		query += " AND created_at > ?"
-		args = append(args, filter.CreatedAfter.Format("2006-01-02 15:04:05"))
-	}
-
-	if filter.CreatedBefore != nil {
-		query += " AND created_at < ?"
-		args = append(args, filter.CreatedBefore.Format("2006-01-02 15:04:05"))
-	}
-
-	var count int
-	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get filtered member count: %w", err)
-	}
-
-	return count, nil
+	return formatAccessTimestamp(t)
 }
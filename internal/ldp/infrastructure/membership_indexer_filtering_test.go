@@ -280,3 +280,93 @@ func TestGetFilteredMemberCount(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 2, count)
 }
+
+// TestMembershipIndexerMemberDetails tests filtering and sorting on recorded member details
+func TestMembershipIndexerMemberDetails(t *testing.T) {
+	indexer, err := NewSQLiteMembershipIndexer(":memory:")
+	require.NoError(t, err)
+	defer indexer.Close()
+
+	ctx := context.Background()
+	containerID := "docs"
+	_, err = indexer.db.ExecContext(ctx,
+		"INSERT INTO containers (id, type, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+		containerID, "BasicContainer")
+	require.NoError(t, err)
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	details := []MemberInfo{
+		{ID: "notes.txt", ContentType: "text/plain", Size: 120, CreatedAt: base},
+		{ID: "photo.jpg", ContentType: "image/jpeg", Size: 90000, CreatedAt: base.Add(time.Hour)},
+		{ID: "card.ttl", ContentType: "text/turtle", Size: 640, CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "report.pdf", ContentType: "application/pdf", Size: 4000, CreatedAt: base.Add(3 * time.Hour), UpdatedAt: base.Add(4 * time.Hour)},
+	}
+	for _, member := range details {
+		require.NoError(t, indexer.UpdateMemberDetails(ctx, containerID, member))
+	}
+	require.NoError(t, indexer.IndexMembership(ctx, containerID, "untyped"))
+
+	memberIDs := func(members []MemberInfo) []string {
+		ids := make([]string, len(members))
+		for i, member := range members {
+			ids[i] = member.ID
+		}
+		return ids
+	}
+	pagination := PaginationOptions{Limit: 10}
+
+	t.Run("records content type, size and timestamps", func(t *testing.T) {
+		members, err := indexer.GetMembersWithFiltering(ctx, containerID, pagination, FilterOptions{NamePattern: "report"}, SortOptions{})
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, "application/pdf", members[0].ContentType)
+		assert.Equal(t, int64(4000), members[0].Size)
+		assert.Equal(t, base.Add(3*time.Hour), members[0].CreatedAt)
+		assert.Equal(t, base.Add(4*time.Hour), members[0].UpdatedAt)
+	})
+
+	t.Run("sorts by creation date descending", func(t *testing.T) {
+		members, err := indexer.GetMembersWithFiltering(ctx, containerID, pagination, FilterOptions{NamePattern: "."}, SortOptions{Field: "createdAt", Direction: "desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"report.pdf", "card.ttl", "photo.jpg", "notes.txt"}, memberIDs(members))
+	})
+
+	t.Run("filters by content type prefix", func(t *testing.T) {
+		filter := FilterOptions{ContentType: "text/*"}
+		members, err := indexer.GetMembersWithFiltering(ctx, containerID, pagination, filter, SortOptions{Field: "name", Direction: "asc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"card.ttl", "notes.txt"}, memberIDs(members))
+
+		count, err := indexer.GetFilteredMemberCount(ctx, containerID, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("filters by exact content type and size range", func(t *testing.T) {
+		members, err := indexer.GetMembersWithFiltering(ctx, containerID, pagination, FilterOptions{ContentType: "application/octet-stream"}, SortOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"untyped"}, memberIDs(members), "members without a recorded type get the default")
+
+		minSize, maxSize := int64(500), int64(5000)
+		members, err = indexer.GetMembersWithFiltering(ctx, containerID, pagination, FilterOptions{SizeMin: &minSize, SizeMax: &maxSize}, SortOptions{Field: "size", Direction: "desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"report.pdf", "card.ttl"}, memberIDs(members))
+	})
+
+	t.Run("filters by creation and modification windows", func(t *testing.T) {
+		after, before := base.Add(3*time.Hour+30*time.Minute), base.Add(5*time.Hour)
+
+		members, err := indexer.GetMembersWithFiltering(ctx, containerID, pagination, FilterOptions{ModifiedAfter: &after, ModifiedBefore: &before}, SortOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"report.pdf"}, memberIDs(members), "report.pdf was created earlier but updated in the window")
+
+		count, err := indexer.GetFilteredMemberCount(ctx, containerID, FilterOptions{CreatedAfter: &after, CreatedBefore: &before})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		recent := base.Add(90 * time.Minute)
+		members, err = indexer.GetMembersWithFiltering(ctx, containerID, pagination, FilterOptions{CreatedAfter: &recent, NamePattern: "."}, SortOptions{Field: "createdAt", Direction: "desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"report.pdf", "card.ttl"}, memberIDs(members))
+	})
+}
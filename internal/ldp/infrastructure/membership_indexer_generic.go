@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
 )
 
 // GenericMembershipIndexer implements MembershipIndexer for multiple database types
@@ -55,17 +57,17 @@ func (g *GenericMembershipIndexer) IndexMembership(ctx context.Context, containe
 		return fmt.Errorf("failed to check member type: %w", err)
 	}
 
-	// Insert membership with database-specific syntax
+	// Insert membership with database-specific syntax; the (container_id, member_id) key
+	// keeps re-indexing an existing member idempotent
 	var query string
 	switch g.driver {
 	case "sqlite3", "sqlite":
-		query = `INSERT OR REPLACE INTO memberships (container_id, member_id, member_type, created_at) 
+		query = `INSERT OR IGNORE INTO memberships (container_id, member_id, member_type, created_at) 
 				 VALUES (?, ?, ?, CURRENT_TIMESTAMP)`
 	case "postgres", "postgresql":
 		query = `INSERT INTO memberships (container_id, member_id, member_type, created_at) 
 				 VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
-				 ON CONFLICT (container_id, member_id) DO UPDATE SET
-				 member_type = EXCLUDED.member_type, created_at = EXCLUDED.created_at`
+				 ON CONFLICT (container_id, member_id) DO NOTHING`
 	default:
 		return fmt.Errorf("unsupported database driver: %s", g.driver)
 	}
@@ -103,9 +105,11 @@ func (g *GenericMembershipIndexer) RemoveMembership(ctx context.Context, contain
 func (g *GenericMembershipIndexer) GetMembers(ctx context.Context, containerID string, pagination PaginationOptions) ([]MemberInfo, error) {
 	query := `
 		SELECT m.member_id, m.member_type, m.created_at,
-			   COALESCE(c.updated_at, m.created_at) as updated_at
+			   COALESCE(c.updated_at, m.created_at) as updated_at, a.last_accessed_at,
+			   COALESCE(c.title, '') as title
 		FROM memberships m
 		LEFT JOIN containers c ON m.member_id = c.id AND m.member_type = 'Container'
+		LEFT JOIN resource_access a ON a.resource_id = m.member_id
 		WHERE m.container_id = ` + g.placeholder(1) + `
 		ORDER BY m.created_at`
 
@@ -133,11 +137,15 @@ func (g *GenericMembershipIndexer) GetMembers(ctx context.Context, containerID s
 		var member MemberInfo
 		var memberTypeStr string
 		var createdAtStr, updatedAtStr string
+		var lastAccessed sql.NullString
 
-		err := rows.Scan(&member.ID, &memberTypeStr, &createdAtStr, &updatedAtStr)
+		err := rows.Scan(&member.ID, &memberTypeStr, &createdAtStr, &updatedAtStr, &lastAccessed, &member.Title)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan member: %w", err)
 		}
+		if lastAccessed.Valid {
+			member.LastAccessedAt, _ = g.parseTimestamp(lastAccessed.String)
+		}
 
 		// Parse timestamps based on database type
 		member.CreatedAt, err = g.parseTimestamp(createdAtStr)
@@ -238,6 +246,34 @@ func (g *GenericMembershipIndexer) GetMemberCount(ctx context.Context, container
 	return count, nil
 }
 
+// HasMembers reports whether a container has any members. The lookup stops at the first
+// membership found through the (container_id, member_id) key, so it does not grow with the
+// container's size.
+func (g *GenericMembershipIndexer) HasMembers(ctx context.Context, containerID string) (bool, error) {
+	var exists bool
+	query := "SELECT EXISTS(SELECT 1 FROM memberships WHERE container_id = " + g.placeholder(1) + ")"
+
+	if err := g.db.QueryRowContext(ctx, query, containerID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check for members: %w", err)
+	}
+
+	return exists, nil
+}
+
+// StoredCounts returns how many containers the index records and how many distinct resources
+// are members of them
+func (g *GenericMembershipIndexer) StoredCounts(ctx context.Context) (int64, int64, error) {
+	var containers, resources int64
+	if err := g.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM containers").Scan(&containers); err != nil {
+		return 0, 0, fmt.Errorf("failed to count containers: %w", err)
+	}
+	query := "SELECT COUNT(DISTINCT member_id) FROM memberships WHERE member_type <> " + g.placeholder(1)
+	if err := g.db.QueryRowContext(ctx, query, string(ResourceTypeContainer)).Scan(&resources); err != nil {
+		return 0, 0, fmt.Errorf("failed to count resources: %w", err)
+	}
+	return containers, resources, nil
+}
+
 // GetContainerStats returns statistics about a container
 func (g *GenericMembershipIndexer) GetContainerStats(ctx context.Context, containerID string) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
@@ -278,9 +314,110 @@ func (g *GenericMembershipIndexer) GetContainerStats(ctx context.Context, contai
 
 	stats["type_breakdown"] = typeStats
 
+	lastAccessed, err := g.ContainerLastAccessed(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if !lastAccessed.IsZero() {
+		stats["last_accessed_at"] = lastAccessed
+	}
+
 	return stats, nil
 }
 
+// RecordAccesses stores when resources were last read. A read older than the one already
+// recorded is ignored, so batches flushed out of order cannot move a resource back in time.
+func (g *GenericMembershipIndexer) RecordAccesses(ctx context.Context, accesses map[string]time.Time) error {
+	if len(accesses) == 0 {
+		return nil
+	}
+
+	tx, err := g.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Both SQLite and PostgreSQL accept the ON CONFLICT upsert form
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO resource_access (resource_id, last_accessed_at) VALUES (`+g.placeholder(1)+`, `+g.placeholder(2)+`)
+		ON CONFLICT (resource_id) DO UPDATE SET last_accessed_at = excluded.last_accessed_at
+		WHERE excluded.last_accessed_at > resource_access.last_accessed_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare access update: %w", err)
+	}
+	defer stmt.Close()
+
+	for resourceID, accessedAt := range accesses {
+		if _, err := stmt.ExecContext(ctx, resourceID, formatAccessTimestamp(accessedAt)); err != nil {
+			return fmt.Errorf("failed to record access: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit access updates: %w", err)
+	}
+
+	return nil
+}
+
+// GetStaleMembers lists resource members whose last read, or their indexing when no read was
+// recorded, is before the cutoff, least recently used first
+func (g *GenericMembershipIndexer) GetStaleMembers(ctx context.Context, cutoff time.Time) ([]domain.StaleResource, error) {
+	query := `
+		SELECT m.member_id, m.container_id, m.created_at, a.last_accessed_at
+		FROM memberships m
+		LEFT JOIN resource_access a ON a.resource_id = m.member_id
+		WHERE m.member_type = 'Resource' AND COALESCE(a.last_accessed_at, m.created_at) < ` + g.placeholder(1) + `
+		ORDER BY COALESCE(a.last_accessed_at, m.created_at)`
+
+	rows, err := g.db.QueryContext(ctx, query, formatAccessTimestamp(cutoff))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale members: %w", err)
+	}
+	defer rows.Close()
+
+	var stale []domain.StaleResource
+	for rows.Next() {
+		var resource domain.StaleResource
+		var createdAtStr string
+		var lastAccessed sql.NullString
+		if err := rows.Scan(&resource.ID, &resource.ContainerID, &createdAtStr, &lastAccessed); err != nil {
+			return nil, fmt.Errorf("failed to scan stale member: %w", err)
+		}
+		resource.CreatedAt, _ = g.parseTimestamp(createdAtStr)
+		if lastAccessed.Valid {
+			resource.LastAccessedAt, _ = g.parseTimestamp(lastAccessed.String)
+		}
+		stale = append(stale, resource)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stale members: %w", err)
+	}
+
+	return stale, nil
+}
+
+// ContainerLastAccessed returns the latest recorded read of any member of a container
+func (g *GenericMembershipIndexer) ContainerLastAccessed(ctx context.Context, containerID string) (time.Time, error) {
+	var lastAccessed sql.NullString
+	query := `
+		SELECT MAX(a.last_accessed_at)
+		FROM memberships m
+		JOIN resource_access a ON a.resource_id = m.member_id
+		WHERE m.container_id = ` + g.placeholder(1)
+
+	if err := g.db.QueryRowContext(ctx, query, containerID).Scan(&lastAccessed); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last access: %w", err)
+	}
+	if !lastAccessed.Valid {
+		return time.Time{}, nil
+	}
+
+	return g.parseTimestamp(lastAccessed.String)
+}
+
 // placeholder returns the appropriate placeholder for the database type
 func (g *GenericMembershipIndexer) placeholder(n int) string {
 	switch g.driver {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -44,6 +45,132 @@ func TestSQLiteMembershipIndexer_IndexMembership(t *testing.T) {
 	}
 }
 
+func TestSQLiteMembershipIndexer_HasMembers(t *testing.T) {
+	indexer, cleanup := setupTestIndexer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	containerID := "container-1"
+
+	if err := createTestContainer(indexer, containerID); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+
+	hasMembers, err := indexer.HasMembers(ctx, containerID)
+	if err != nil {
+		t.Fatalf("HasMembers() error = %v", err)
+	}
+	if hasMembers {
+		t.Error("Container without memberships should have no members")
+	}
+
+	if err := indexer.IndexMembership(ctx, containerID, "resource-1"); err != nil {
+		t.Fatalf("Failed to index membership: %v", err)
+	}
+
+	hasMembers, err = indexer.HasMembers(ctx, containerID)
+	if err != nil {
+		t.Fatalf("HasMembers() error = %v", err)
+	}
+	if !hasMembers {
+		t.Error("Container with a membership should have members")
+	}
+}
+
+func TestSQLiteMembershipIndexer_RecordAccesses(t *testing.T) {
+	indexer, cleanup := setupTestIndexer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	containerID := "container-1"
+
+	if err := createTestContainer(indexer, containerID); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+	for _, memberID := range []string{"read", "unread"} {
+		if err := indexer.IndexMembership(ctx, containerID, memberID); err != nil {
+			t.Fatalf("Failed to index membership: %v", err)
+		}
+	}
+
+	readAt := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	if err := indexer.RecordAccesses(ctx, map[string]time.Time{"read": readAt}); err != nil {
+		t.Fatalf("RecordAccesses() error = %v", err)
+	}
+	// An older read must not replace the newer one
+	if err := indexer.RecordAccesses(ctx, map[string]time.Time{"read": readAt.Add(-time.Hour)}); err != nil {
+		t.Fatalf("RecordAccesses() error = %v", err)
+	}
+
+	members, err := indexer.GetMembers(ctx, containerID, PaginationOptions{})
+	if err != nil {
+		t.Fatalf("GetMembers() error = %v", err)
+	}
+	accessed := make(map[string]time.Time)
+	for _, member := range members {
+		accessed[member.ID] = member.LastAccessedAt
+	}
+	if !accessed["read"].Equal(readAt) {
+		t.Errorf("LastAccessedAt of read member = %v, want %v", accessed["read"], readAt)
+	}
+	if !accessed["unread"].IsZero() {
+		t.Errorf("LastAccessedAt of unread member = %v, want zero", accessed["unread"])
+	}
+
+	stats, err := indexer.GetContainerStats(ctx, containerID)
+	if err != nil {
+		t.Fatalf("GetContainerStats() error = %v", err)
+	}
+	if last, ok := stats["last_accessed_at"].(time.Time); !ok || !last.Equal(readAt) {
+		t.Errorf("last_accessed_at stat = %v, want %v", stats["last_accessed_at"], readAt)
+	}
+
+	// A cutoff just after the read catches the read member but not the unread one, indexed now
+	stale, err := indexer.GetStaleMembers(ctx, readAt.Add(time.Second))
+	if err != nil {
+		t.Fatalf("GetStaleMembers() error = %v", err)
+	}
+	if len(stale) != 1 || stale[0].ID != "read" || stale[0].ContainerID != containerID {
+		t.Errorf("GetStaleMembers() = %+v, want only the read member", stale)
+	}
+
+	stale, err = indexer.GetStaleMembers(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetStaleMembers() error = %v", err)
+	}
+	if len(stale) != 2 {
+		t.Errorf("GetStaleMembers() returned %d members, want 2", len(stale))
+	}
+}
+
+func TestSQLiteMembershipIndexer_IndexMembership_Idempotent(t *testing.T) {
+	indexer, cleanup := setupTestIndexer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	containerID := "container-1"
+	memberID := "resource-1"
+
+	if err := createTestContainer(indexer, containerID); err != nil {
+		t.Fatalf("Failed to create test container: %v", err)
+	}
+
+	// Indexing the same membership twice must not fail or add a second row
+	for i := 0; i < 2; i++ {
+		if err := indexer.IndexMembership(ctx, containerID, memberID); err != nil {
+			t.Fatalf("Failed to index membership (attempt %d): %v", i+1, err)
+		}
+	}
+
+	count, err := indexer.GetMemberCount(ctx, containerID)
+	if err != nil {
+		t.Fatalf("Failed to count members: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 member, got %d", count)
+	}
+}
+
 func TestSQLiteMembershipIndexer_RemoveMembership(t *testing.T) {
 	indexer, cleanup := setupTestIndexer(t)
 	defer cleanup()
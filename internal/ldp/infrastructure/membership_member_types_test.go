@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectMemberType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		isContainer bool
+		want        ResourceType
+	}{
+		{"container", "application/ld+json", true, ResourceTypeContainer},
+		{"turtle", "text/turtle", false, ResourceTypeResource},
+		{"json-ld with parameters", "application/ld+json; charset=utf-8", false, ResourceTypeResource},
+		{"image", "image/jpeg", false, ResourceTypeNonRDFSource},
+		{"plain text", "text/plain", false, ResourceTypeNonRDFSource},
+		{"unknown content type", "", false, ResourceTypeResource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectMemberType(tt.contentType, tt.isContainer))
+		})
+	}
+}
+
+func TestMembershipIndexerMemberTypes(t *testing.T) {
+	indexer, err := NewSQLiteMembershipIndexer(":memory:")
+	require.NoError(t, err)
+	defer indexer.Close()
+
+	ctx := context.Background()
+	_, err = indexer.db.ExecContext(ctx,
+		"INSERT INTO containers (id, type, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+		"docs", "BasicContainer")
+	require.NoError(t, err)
+
+	require.NoError(t, indexer.UpdateMemberDetails(ctx, "docs", MemberInfo{ID: "card.ttl", Type: ResourceTypeResource, ContentType: "text/turtle"}))
+	require.NoError(t, indexer.UpdateMemberDetails(ctx, "docs", MemberInfo{ID: "photo.jpg", Type: ResourceTypeNonRDFSource, ContentType: "image/jpeg"}))
+
+	// Updating without a type keeps the recorded one
+	require.NoError(t, indexer.UpdateMemberDetails(ctx, "docs", MemberInfo{ID: "photo.jpg", ContentType: "image/jpeg", Size: 2048}))
+
+	members, err := indexer.GetMembersWithFiltering(ctx, "docs", PaginationOptions{Limit: 10}, FilterOptions{MemberType: string(ResourceTypeNonRDFSource)}, SortOptions{})
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, "photo.jpg", members[0].ID)
+	assert.Equal(t, ResourceTypeNonRDFSource, members[0].Type)
+	assert.Equal(t, int64(2048), members[0].Size)
+}
+
+func TestFileSystemContainerRepository_RecordsMemberTypes(t *testing.T) {
+	ctx := context.Background()
+	indexer, err := NewSQLiteMembershipIndexer(":memory:")
+	require.NoError(t, err)
+	defer indexer.Close()
+
+	repo, err := NewFileSystemContainerRepository(t.TempDir(), indexer)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateContainer(ctx, domain.NewContainer(ctx, "docs", "", domain.BasicContainer)))
+	require.NoError(t, repo.CreateContainer(ctx, domain.NewContainer(ctx, "archive", "docs", domain.BasicContainer)))
+	require.NoError(t, repo.Store(ctx, domain.NewResource(ctx, "card.ttl", "text/turtle", []byte("<#me> a <#Person> ."))))
+	require.NoError(t, repo.Store(ctx, domain.NewResource(ctx, "photo.jpg", "image/jpeg", []byte{0xff, 0xd8, 0xff})))
+
+	require.NoError(t, repo.AddMember(ctx, "docs", "card.ttl"))
+	require.NoError(t, repo.AddMember(ctx, "docs", "photo.jpg"))
+	require.NoError(t, repo.RecordMemberDetails(ctx, "docs", domain.IndexedMember{ID: "archive", ContentType: "application/ld+json"}))
+
+	members, err := repo.ListIndexedMembers(ctx, "docs")
+	require.NoError(t, err)
+
+	types := make(map[string]string, len(members))
+	for _, member := range members {
+		types[member.ID] = member.Type
+	}
+	assert.Equal(t, map[string]string{
+		"card.ttl":  "Resource",
+		"photo.jpg": "NonRDFSource",
+		"archive":   "Container",
+	}, types)
+}
@@ -0,0 +1,195 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NoopMessagePublisher publishes nothing; it stands in for a broker when event mirroring is off
+type NoopMessagePublisher struct{}
+
+// NewNoopMessagePublisher creates a publisher that discards every message
+func NewNoopMessagePublisher() *NoopMessagePublisher {
+	return &NoopMessagePublisher{}
+}
+
+// Publish discards the message
+func (p *NoopMessagePublisher) Publish(ctx context.Context, topic string, message []byte) error {
+	return nil
+}
+
+// Close does nothing
+func (p *NoopMessagePublisher) Close() error {
+	return nil
+}
+
+// NATSMessagePublisher publishes messages to NATS subjects over the NATS client protocol. Each
+// publish is followed by a PING, and Publish returns once the server's PONG shows it has
+// processed the message. The connection is opened on the first publish and reopened on the
+// next one after any failure.
+type NATSMessagePublisher struct {
+	address  string
+	user     string
+	password string
+	timeout  time.Duration
+
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+// NewNATSMessagePublisher creates a publisher for the server at a nats:// URL, which may carry
+// a user and password. Connecting and each publish time out after the given duration; zero
+// means no timeout beyond the caller's context.
+func NewNATSMessagePublisher(serverURL string, timeout time.Duration) (*NATSMessagePublisher, error) {
+	parsed, err := url.Parse(serverURL)
+	if err != nil || parsed.Scheme != "nats" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid NATS URL %q", serverURL)
+	}
+
+	publisher := &NATSMessagePublisher{
+		address: parsed.Host,
+		timeout: timeout,
+	}
+	if parsed.Port() == "" {
+		publisher.address = net.JoinHostPort(parsed.Hostname(), "4222")
+	}
+	if parsed.User != nil {
+		publisher.user = parsed.User.Username()
+		publisher.password, _ = parsed.User.Password()
+	}
+	return publisher, nil
+}
+
+// Publish sends a message to a subject and waits for the server to acknowledge it
+func (p *NATSMessagePublisher) Publish(ctx context.Context, topic string, message []byte) error {
+	if topic == "" || strings.ContainsAny(topic, " \t\r\n") {
+		return fmt.Errorf("invalid NATS subject %q", topic)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := p.publish(ctx, topic, message); err != nil {
+		// The connection is in an unknown state; the next publish starts a fresh one
+		p.closeConn()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (p *NATSMessagePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closeConn()
+}
+
+// connect dials the server, reads its INFO and introduces the client
+func (p *NATSMessagePublisher) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS server %s: %w", p.address, err)
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+	p.setDeadline(ctx)
+
+	line, err := p.reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		p.closeConn()
+		return fmt.Errorf("NATS server %s did not introduce itself: %v", p.address, err)
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "goro",
+		"lang":     "go",
+	}
+	if p.user != "" {
+		options["user"] = p.user
+		options["pass"] = p.password
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		p.closeConn()
+		return fmt.Errorf("failed to serialize NATS CONNECT: %w", err)
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\n", connect); err != nil {
+		p.closeConn()
+		return fmt.Errorf("failed to connect to NATS server %s: %w", p.address, err)
+	}
+	return nil
+}
+
+// publish writes one PUB followed by a PING and reads until the matching PONG, answering the
+// server's own PINGs on the way
+func (p *NATSMessagePublisher) publish(ctx context.Context, topic string, message []byte) error {
+	p.setDeadline(ctx)
+
+	var frame strings.Builder
+	fmt.Fprintf(&frame, "PUB %s %d\r\n", topic, len(message))
+	frame.Write(message)
+	frame.WriteString("\r\nPING\r\n")
+	if _, err := p.conn.Write([]byte(frame.String())); err != nil {
+		return fmt.Errorf("failed to publish to NATS subject %s: %w", topic, err)
+	}
+
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("NATS server did not acknowledge subject %s: %w", topic, err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("failed to answer NATS server: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server refused subject %s: %s", topic, strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// +OK and INFO updates need no answer
+	}
+}
+
+// setDeadline bounds the next exchange by the publish timeout and the context's deadline
+func (p *NATSMessagePublisher) setDeadline(ctx context.Context) {
+	var deadline time.Time
+	if p.timeout > 0 {
+		deadline = time.Now().Add(p.timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	p.conn.SetDeadline(deadline)
+}
+
+// closeConn closes the connection, if open
+func (p *NATSMessagePublisher) closeConn() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	p.reader = nil
+	return err
+}
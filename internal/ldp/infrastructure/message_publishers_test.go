@@ -0,0 +1,140 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATSServer speaks enough of the NATS protocol to acknowledge publishes. It refuses
+// subjects starting with "refused." and drops the connection on subjects starting with "drop.".
+type fakeNATSServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	connects []string
+	messages map[string][]string
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeNATSServer{listener: listener, messages: make(map[string][]string)}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\"}\r\n")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connects = append(s.connects, strings.TrimSpace(strings.TrimPrefix(line, "CONNECT")))
+			s.mu.Unlock()
+		case "PUB":
+			var size int
+			fmt.Sscanf(fields[2], "%d", &size)
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			subject := fields[1]
+			switch {
+			case strings.HasPrefix(subject, "drop."):
+				return
+			case strings.HasPrefix(subject, "refused."):
+				fmt.Fprint(conn, "-ERR 'Permissions Violation'\r\n")
+				continue
+			}
+			s.mu.Lock()
+			s.messages[subject] = append(s.messages[subject], string(payload[:size]))
+			s.mu.Unlock()
+		case "PING":
+			fmt.Fprint(conn, "PING\r\nPONG\r\n")
+		}
+	}
+}
+
+func (s *fakeNATSServer) received(subject string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages[subject]...)
+}
+
+func TestNATSMessagePublisher_Publish(t *testing.T) {
+	server := newFakeNATSServer(t)
+	publisher, err := NewNATSMessagePublisher("nats://goro:secret@"+server.listener.Addr().String(), time.Second)
+	require.NoError(t, err)
+	defer publisher.Close()
+	ctx := context.Background()
+
+	require.NoError(t, publisher.Publish(ctx, "goro.events", []byte(`{"eventId":"1"}`)))
+	require.NoError(t, publisher.Publish(ctx, "goro.events", []byte(`{"eventId":"2"}`)))
+	assert.Equal(t, []string{`{"eventId":"1"}`, `{"eventId":"2"}`}, server.received("goro.events"))
+
+	server.mu.Lock()
+	require.Len(t, server.connects, 1, "publishes share one connection")
+	assert.Contains(t, server.connects[0], `"user":"goro"`)
+	assert.Contains(t, server.connects[0], `"pass":"secret"`)
+	server.mu.Unlock()
+
+	t.Run("refused subject", func(t *testing.T) {
+		err := publisher.Publish(ctx, "refused.events", []byte("{}"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Permissions Violation")
+	})
+
+	t.Run("reconnects after the connection drops", func(t *testing.T) {
+		assert.Error(t, publisher.Publish(ctx, "drop.events", []byte("{}")))
+		require.NoError(t, publisher.Publish(ctx, "goro.events", []byte(`{"eventId":"3"}`)))
+		assert.Len(t, server.received("goro.events"), 3)
+	})
+
+	t.Run("invalid subject", func(t *testing.T) {
+		assert.Error(t, publisher.Publish(ctx, "goro events", []byte("{}")))
+	})
+}
+
+func TestNATSMessagePublisher_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	publisher, err := NewNATSMessagePublisher("nats://"+address, time.Second)
+	require.NoError(t, err)
+	assert.Error(t, publisher.Publish(context.Background(), "goro.events", []byte("{}")))
+
+	_, err = NewNATSMessagePublisher("http://"+address, time.Second)
+	assert.Error(t, err)
+}
@@ -0,0 +1,374 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// Terms of the Solid vocabulary N3 patches are written with
+const (
+	solidNamespace          = "http://www.w3.org/ns/solid/terms#"
+	solidInsertDeletePatch  = solidNamespace + "InsertDeletePatch"
+	solidPatches            = solidNamespace + "patches"
+	solidWhere              = solidNamespace + "where"
+	solidInserts            = solidNamespace + "inserts"
+	solidDeletes            = solidNamespace + "deletes"
+	n3PatchFormulaPredicate = "solid:where, solid:inserts or solid:deletes"
+)
+
+// N3PatchProcessor applies Solid N3 Patches to Turtle documents. A patch is a single
+// solid:InsertDeletePatch whose solid:where, solid:inserts and solid:deletes formulae may use
+// variables. The where formula must match the document in exactly one way, and every triple
+// the patch deletes must be in the document; otherwise the patch fails with ErrPatchConflict
+// and the document is left untouched.
+type N3PatchProcessor struct{}
+
+// NewN3PatchProcessor creates a new N3 patch processor
+func NewN3PatchProcessor() *N3PatchProcessor {
+	return &N3PatchProcessor{}
+}
+
+// ApplyPatch applies an N3 patch to a Turtle document. The patched graph is written as
+// N-Triples, which is also valid Turtle; a patch that changes no triple returns the document
+// untouched.
+func (u *N3PatchProcessor) ApplyPatch(document []byte, patch string) ([]byte, error) {
+	parsed, err := parseN3Patch(patch)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("invalid N3 patch: %w", err),
+			domain.ErrInvalidPatch.Code,
+			"malformed N3 patch",
+		).WithOperation("ApplyPatch")
+	}
+
+	triples, err := newTurtleParser(string(document)).parse()
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("failed to parse stored document: %w", err),
+			domain.ErrFormatConversion.Code,
+			"stored document cannot be patched",
+		).WithOperation("ApplyPatch")
+	}
+
+	graph := newPatchGraph(triples)
+	bindings := graph.solutions(parsed.where)
+	if len(bindings) != 1 {
+		return nil, n3PatchConflict("the where formula matches the document %d times, not exactly once", len(bindings))
+	}
+
+	deletes := bindTriples(parsed.deletes, bindings[0])
+	for _, triple := range deletes {
+		if _, ok := graph.keys[triple.serialize(nil)]; !ok {
+			return nil, n3PatchConflict("the document does not contain the deleted triple %s", triple.serialize(nil))
+		}
+	}
+
+	changed := false
+	for _, triple := range deletes {
+		if graph.remove(triple) {
+			changed = true
+		}
+	}
+	for _, triple := range graph.freshBlankNodes(bindTriples(parsed.inserts, bindings[0])) {
+		if graph.add(triple) {
+			changed = true
+		}
+	}
+	if !changed {
+		return document, nil
+	}
+
+	return graph.serialize(), nil
+}
+
+// n3PatchConflict returns the error for a patch whose conditions the document does not meet
+func n3PatchConflict(format string, args ...interface{}) error {
+	return domain.WrapStorageError(
+		fmt.Errorf(format, args...),
+		domain.ErrPatchConflict.Code,
+		"N3 patch conditions not met",
+	).WithOperation("ApplyPatch")
+}
+
+// n3Patch is the where, inserts and deletes formulae of an N3 patch
+type n3Patch struct {
+	typed   bool
+	where   []canonicalTriple
+	inserts []canonicalTriple
+	deletes []canonicalTriple
+	seen    map[string]bool
+}
+
+// n3PatchParser parses N3 patch documents: Turtle whose patch resource has formulae as objects
+type n3PatchParser struct {
+	*turtleParser
+	patches map[string]*n3Patch
+}
+
+// parseN3Patch parses a patch document holding exactly one solid:InsertDeletePatch
+func parseN3Patch(patch string) (*n3Patch, error) {
+	p := n3PatchParser{turtleParser: newTurtleParser(patch), patches: make(map[string]*n3Patch)}
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			break
+		}
+
+		if p.peek() == '@' || p.matchKeyword("PREFIX") || p.matchKeyword("BASE") {
+			if err := p.parseDirective(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := p.parsePatchStatement(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(p.patches) != 1 {
+		return nil, fmt.Errorf("document must contain exactly one solid:InsertDeletePatch, found %d", len(p.patches))
+	}
+	var parsed *n3Patch
+	for _, patch := range p.patches {
+		parsed = patch
+	}
+	if !parsed.typed {
+		return nil, fmt.Errorf("patch resource must have type solid:InsertDeletePatch")
+	}
+
+	for _, triples := range [][]canonicalTriple{parsed.where, parsed.deletes} {
+		if err := rejectBlankNodes(triples); err != nil {
+			return nil, err
+		}
+	}
+	bound := tripleVariables(parsed.where)
+	for _, triples := range [][]canonicalTriple{parsed.inserts, parsed.deletes} {
+		for name := range tripleVariables(triples) {
+			if !bound[name] {
+				return nil, fmt.Errorf("variable ?%s is not bound by the where formula", name)
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// parsePatchStatement parses a subject followed by a predicate/object list whose objects may
+// be formulae, recording the statements that describe a patch
+func (p *n3PatchParser) parsePatchStatement() error {
+	subject, err := p.parseTerm()
+	if err != nil {
+		return err
+	}
+	if subject.kind == "literal" {
+		return p.errorf("literal cannot be used as subject")
+	}
+
+	for {
+		p.skipWhitespace()
+		predicate, err := p.parsePredicate()
+		if err != nil {
+			return err
+		}
+
+		for {
+			p.skipWhitespace()
+			if p.peek() == '{' {
+				formula, err := p.parseFormula()
+				if err != nil {
+					return err
+				}
+				if err := p.recordFormula(subject, predicate, formula); err != nil {
+					return err
+				}
+			} else {
+				object, err := p.parseTerm()
+				if err != nil {
+					return err
+				}
+				if err := p.recordTerm(subject, predicate, object); err != nil {
+					return err
+				}
+			}
+
+			p.skipWhitespace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+
+		switch p.peek() {
+		case ';':
+			p.pos++
+			p.skipWhitespace()
+			// A trailing ';' before '.' is allowed
+			if p.peek() == '.' {
+				p.pos++
+				return nil
+			}
+		case '.':
+			p.pos++
+			return nil
+		default:
+			return p.errorf("expected ',', ';' or '.'")
+		}
+	}
+}
+
+// parseFormula parses a formula: triples that may contain variables, enclosed in braces
+func (p *n3PatchParser) parseFormula() ([]canonicalTriple, error) {
+	p.pos++
+	start := len(p.triples)
+	p.inGroup, p.variables = true, true
+	defer func() { p.inGroup, p.variables = false, false }()
+
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return nil, p.errorf("expected '}'")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			formula := append([]canonicalTriple(nil), p.triples[start:]...)
+			p.triples = p.triples[:start]
+			return formula, nil
+		}
+		if err := p.parseStatement(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// recordFormula records a formula as the where, inserts or deletes formula of a patch
+func (p *n3PatchParser) recordFormula(subject, predicate canonicalTerm, formula []canonicalTriple) error {
+	switch {
+	case predicate.kind != "iri":
+		return p.errorf("formulae are only allowed as the object of %s", n3PatchFormulaPredicate)
+	case predicate.value != solidWhere && predicate.value != solidInserts && predicate.value != solidDeletes:
+		return p.errorf("formulae are only allowed as the object of %s", n3PatchFormulaPredicate)
+	}
+
+	patch := p.patch(subject)
+	if patch.seen[predicate.value] {
+		return p.errorf("patch has more than one %s formula", strings.TrimPrefix(predicate.value, solidNamespace))
+	}
+	patch.seen[predicate.value] = true
+
+	switch predicate.value {
+	case solidWhere:
+		patch.where = formula
+	case solidInserts:
+		patch.inserts = formula
+	case solidDeletes:
+		patch.deletes = formula
+	}
+	return nil
+}
+
+// recordTerm records the type and target of a patch; other statements are ignored
+func (p *n3PatchParser) recordTerm(subject, predicate, object canonicalTerm) error {
+	switch {
+	case predicate.value == rdfTypeIRI && object.kind == "iri" && object.value == solidInsertDeletePatch:
+		p.patch(subject).typed = true
+	case predicate.value == solidPatches:
+		p.patch(subject)
+	case predicate.value == solidWhere, predicate.value == solidInserts, predicate.value == solidDeletes:
+		return p.errorf("the object of %s must be a formula", n3PatchFormulaPredicate)
+	}
+	return nil
+}
+
+// patch returns the patch described by statements about the subject, creating it on first use
+func (p *n3PatchParser) patch(subject canonicalTerm) *n3Patch {
+	key := subject.serialize(nil)
+	patch, ok := p.patches[key]
+	if !ok {
+		patch = &n3Patch{seen: make(map[string]bool)}
+		p.patches[key] = patch
+	}
+	return patch
+}
+
+// errorf returns a parse error annotated with the current position
+func (p *n3PatchParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("N3 parse error at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// tripleVariables returns the names of the variables used in triples
+func tripleVariables(triples []canonicalTriple) map[string]bool {
+	variables := make(map[string]bool)
+	for _, triple := range triples {
+		for _, term := range []canonicalTerm{triple.subject, triple.predicate, triple.object} {
+			if term.kind == "variable" {
+				variables[term.value] = true
+			}
+		}
+	}
+	return variables
+}
+
+// bindTriples replaces the variables of triples with the terms they are bound to
+func bindTriples(triples []canonicalTriple, binding map[string]canonicalTerm) []canonicalTriple {
+	bind := func(term canonicalTerm) canonicalTerm {
+		if term.kind == "variable" {
+			return binding[term.value]
+		}
+		return term
+	}
+
+	result := make([]canonicalTriple, len(triples))
+	for i, triple := range triples {
+		result[i] = canonicalTriple{subject: bind(triple.subject), predicate: bind(triple.predicate), object: bind(triple.object)}
+	}
+	return result
+}
+
+// solutions returns every binding of the patterns' variables under which the graph holds all
+// of the patterns. Patterns without variables yield a single empty binding when they hold.
+func (g *patchGraph) solutions(patterns []canonicalTriple) []map[string]canonicalTerm {
+	solutions := []map[string]canonicalTerm{{}}
+	for _, pattern := range patterns {
+		var next []map[string]canonicalTerm
+		for _, binding := range solutions {
+			for _, triple := range g.triples {
+				if extended, ok := matchTriplePattern(pattern, triple, binding); ok {
+					next = append(next, extended)
+				}
+			}
+		}
+		solutions = next
+	}
+	return solutions
+}
+
+// matchTriplePattern extends a binding so the pattern matches the triple, if it can
+func matchTriplePattern(pattern, triple canonicalTriple, binding map[string]canonicalTerm) (map[string]canonicalTerm, bool) {
+	extended := binding
+	pairs := [][2]canonicalTerm{{pattern.subject, triple.subject}, {pattern.predicate, triple.predicate}, {pattern.object, triple.object}}
+	for _, pair := range pairs {
+		want, got := pair[0], pair[1]
+		if want.kind != "variable" {
+			if want.serialize(nil) != got.serialize(nil) {
+				return nil, false
+			}
+			continue
+		}
+		if bound, ok := extended[want.value]; ok {
+			if bound.serialize(nil) != got.serialize(nil) {
+				return nil, false
+			}
+			continue
+		}
+		if len(extended) == len(binding) {
+			extended = make(map[string]canonicalTerm, len(binding)+1)
+			for name, term := range binding {
+				extended[name] = term
+			}
+		}
+		extended[want.value] = got
+	}
+	return extended, true
+}
@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const n3PatchPrefixes = `@prefix solid: <http://www.w3.org/ns/solid/terms#>.
+@prefix ex: <http://www.example.org/terms#>.
+`
+
+func TestN3PatchProcessor_ApplyPatch(t *testing.T) {
+	document := `@prefix ex: <http://www.example.org/terms#> .
+<#alex> ex:familyName "Garcia" ;
+    ex:givenName "Claudia" .
+`
+
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name: "rename with a where binding",
+			patch: n3PatchPrefixes + `_:rename a solid:InsertDeletePatch;
+  solid:where   { ?person ex:familyName "Garcia". };
+  solid:inserts { ?person ex:givenName "Alex". };
+  solid:deletes { ?person ex:givenName "Claudia". }.`,
+			want: "<#alex> <http://www.example.org/terms#familyName> \"Garcia\" .\n" +
+				"<#alex> <http://www.example.org/terms#givenName> \"Alex\" .\n",
+		},
+		{
+			name: "insert only",
+			patch: n3PatchPrefixes + `<> solid:patches <card>;
+  a solid:InsertDeletePatch;
+  solid:inserts { <#alex> ex:nick "Al" }.`,
+			want: "<#alex> <http://www.example.org/terms#familyName> \"Garcia\" .\n" +
+				"<#alex> <http://www.example.org/terms#givenName> \"Claudia\" .\n" +
+				"<#alex> <http://www.example.org/terms#nick> \"Al\" .\n",
+		},
+		{
+			name: "inserting a triple the document holds",
+			patch: n3PatchPrefixes + `_:p a solid:InsertDeletePatch;
+  solid:inserts { <#alex> ex:givenName "Claudia" }.`,
+			want: document,
+		},
+	}
+
+	processor := NewN3PatchProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := processor.ApplyPatch([]byte(document), tt.patch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(updated))
+		})
+	}
+}
+
+func TestN3PatchProcessor_ReportsConflicts(t *testing.T) {
+	document := `<#alex> <http://www.example.org/terms#knows> <#bob>, <#carol> .`
+
+	tests := []struct {
+		name   string
+		patch  string
+		reason string
+	}{
+		{
+			name: "where matches nothing",
+			patch: n3PatchPrefixes + `_:p a solid:InsertDeletePatch;
+  solid:where { ?person ex:familyName "Garcia" };
+  solid:inserts { ?person ex:givenName "Alex" }.`,
+			reason: "matches the document 0 times",
+		},
+		{
+			name: "where matches more than once",
+			patch: n3PatchPrefixes + `_:p a solid:InsertDeletePatch;
+  solid:where { <#alex> ex:knows ?friend };
+  solid:deletes { <#alex> ex:knows ?friend }.`,
+			reason: "matches the document 2 times",
+		},
+		{
+			name: "deleted triple is missing",
+			patch: n3PatchPrefixes + `_:p a solid:InsertDeletePatch;
+  solid:deletes { <#alex> ex:knows <#dave> }.`,
+			reason: "does not contain the deleted triple",
+		},
+	}
+
+	processor := NewN3PatchProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := processor.ApplyPatch([]byte(document), tt.patch)
+			require.Error(t, err)
+			assert.True(t, domain.IsPatchConflict(err))
+			assert.Contains(t, err.Error(), tt.reason)
+		})
+	}
+}
+
+func TestN3PatchProcessor_RejectsMalformedPatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		patch  string
+		reason string
+	}{
+		{"no patch", n3PatchPrefixes, "exactly one solid:InsertDeletePatch, found 0"},
+		{"missing type", n3PatchPrefixes + `_:p solid:inserts { <#a> ex:b "c" }.`, "must have type solid:InsertDeletePatch"},
+		{"two patches", n3PatchPrefixes + `_:p a solid:InsertDeletePatch. _:q a solid:InsertDeletePatch.`, "found 2"},
+		{"unbound variable", n3PatchPrefixes + `_:p a solid:InsertDeletePatch; solid:inserts { ?x ex:b "c" }.`, "?x is not bound"},
+		{"blank node in where", n3PatchPrefixes + `_:p a solid:InsertDeletePatch; solid:where { _:b ex:b "c" }.`, "blank nodes"},
+		{"formula as other object", n3PatchPrefixes + `_:p a solid:InsertDeletePatch; ex:other { <#a> ex:b "c" }.`, "formulae are only allowed"},
+		{"where is not a formula", n3PatchPrefixes + `_:p a solid:InsertDeletePatch; solid:where <#a>.`, "must be a formula"},
+		{"unterminated formula", n3PatchPrefixes + `_:p a solid:InsertDeletePatch; solid:inserts { <#a> ex:b "c" .`, "expected '}'"},
+	}
+
+	processor := NewN3PatchProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := processor.ApplyPatch([]byte(`<#a> <#b> "c" .`), tt.patch)
+			require.Error(t, err)
+			assert.True(t, domain.IsInvalidPatch(err))
+			assert.Contains(t, err.Error(), tt.reason)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MemoryNotificationChannelStore is an in-process NotificationChannelStore. Channels do not
+// survive a restart, so subscribers must subscribe again after the server restarts.
+type MemoryNotificationChannelStore struct {
+	channels map[string]domain.NotificationChannel
+	mu       sync.Mutex
+}
+
+// NewMemoryNotificationChannelStore creates an empty in-memory channel store
+func NewMemoryNotificationChannelStore() *MemoryNotificationChannelStore {
+	return &MemoryNotificationChannelStore{
+		channels: make(map[string]domain.NotificationChannel),
+	}
+}
+
+// Save stores a channel, replacing any earlier one with the same ID
+func (s *MemoryNotificationChannelStore) Save(ctx context.Context, channel domain.NotificationChannel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.channels[channel.ID] = channel
+	return nil
+}
+
+// Get returns a channel, or ErrNotificationChannelNotFound
+func (s *MemoryNotificationChannelStore) Get(ctx context.Context, id string) (domain.NotificationChannel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[id]
+	if !ok {
+		return domain.NotificationChannel{}, domain.ErrNotificationChannelNotFound.WithOperation("Get").WithContext("id", id)
+	}
+	return channel, nil
+}
+
+// Delete removes a channel; deleting an unknown ID returns ErrNotificationChannelNotFound
+func (s *MemoryNotificationChannelStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.channels[id]; !ok {
+		return domain.ErrNotificationChannelNotFound.WithOperation("Delete").WithContext("id", id)
+	}
+	delete(s.channels, id)
+	return nil
+}
+
+// ListByContainer returns the channels subscribed to a container, oldest first
+func (s *MemoryNotificationChannelStore) ListByContainer(ctx context.Context, containerID string) ([]domain.NotificationChannel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channels := make([]domain.NotificationChannel, 0)
+	for _, channel := range s.channels {
+		if channel.ContainerID == containerID {
+			channels = append(channels, channel)
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].CreatedAt.Before(channels[j].CreatedAt)
+	})
+	return channels, nil
+}
+
+// ErrPrivateNotificationTarget is returned for webhook receivers on loopback, private,
+// link-local or otherwise non-public addresses, which subscribers must not reach through the
+// server
+var ErrPrivateNotificationTarget = errors.New("notification receiver is not on a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which net.IP.IsPrivate does not cover
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicIP reports whether an address is routable on the public internet
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// WebhookNotificationSender delivers notifications to WebhookChannel2023 receivers by POSTing
+// them as JSON-LD to the channel's sendTo URI. Receivers must be on public addresses: the
+// address is checked when a channel is opened and again on every connection, so a name that
+// later resolves to an internal address is still refused.
+type WebhookNotificationSender struct {
+	client       *http.Client
+	resolver     *net.Resolver
+	allowPrivate atomic.Bool
+}
+
+// NewWebhookNotificationSender creates a sender whose POSTs each time out after the given
+// duration; zero means no timeout
+func NewWebhookNotificationSender(timeout time.Duration) *WebhookNotificationSender {
+	sender := &WebhookNotificationSender{resolver: net.DefaultResolver}
+	dialer := &net.Dialer{Control: sender.checkDialedAddress}
+	sender.client = &http.Client{
+		Timeout: timeout,
+		// Not proxied, so the address dialed is the receiver's own
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+	return sender
+}
+
+// SetAllowPrivateTargets lets receivers be on any address, for development servers whose
+// receivers run on the same host or network
+func (s *WebhookNotificationSender) SetAllowPrivateTargets(allow bool) {
+	s.allowPrivate.Store(allow)
+}
+
+// CheckTarget refuses a sendTo URI whose host is, or resolves to, a non-public address
+func (s *WebhookNotificationSender) CheckTarget(ctx context.Context, sendTo string) error {
+	if s.allowPrivate.Load() {
+		return nil
+	}
+	target, err := url.Parse(sendTo)
+	if err != nil || target.Hostname() == "" {
+		return fmt.Errorf("%w: %s has no host", ErrPrivateNotificationTarget, sendTo)
+	}
+
+	host := target.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !publicIP(ip) {
+			return fmt.Errorf("%w: %s", ErrPrivateNotificationTarget, host)
+		}
+		return nil
+	}
+	addresses, err := s.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve notification receiver %s: %w", host, err)
+	}
+	for _, address := range addresses {
+		if !publicIP(address.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrPrivateNotificationTarget, host, address.IP)
+		}
+	}
+	return nil
+}
+
+// checkDialedAddress refuses connections to non-public addresses as they are dialed, after
+// the receiver's name was resolved
+func (s *WebhookNotificationSender) checkDialedAddress(network, address string, conn syscall.RawConn) error {
+	if s.allowPrivate.Load() {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateNotificationTarget, host)
+	}
+	return nil
+}
+
+// Send POSTs a notification to the channel's receiver; any status other than 2xx is an error
+func (s *WebhookNotificationSender) Send(ctx context.Context, channel domain.NotificationChannel, notification []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.SendTo, bytes.NewReader(notification))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/ld+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver notification to %s: %w", channel.SendTo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification receiver %s answered %s", channel.SendTo, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryNotificationChannelStore(t *testing.T) {
+	store := NewMemoryNotificationChannelStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, store.Save(ctx, domain.NotificationChannel{ID: "second", ContainerID: "photos", CreatedAt: now.Add(time.Second)}))
+	require.NoError(t, store.Save(ctx, domain.NotificationChannel{ID: "first", ContainerID: "photos", CreatedAt: now}))
+	require.NoError(t, store.Save(ctx, domain.NotificationChannel{ID: "other", ContainerID: "notes", CreatedAt: now}))
+
+	channels, err := store.ListByContainer(ctx, "photos")
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, "first", channels[0].ID)
+	assert.Equal(t, "second", channels[1].ID)
+
+	require.NoError(t, store.Delete(ctx, "first"))
+	_, err = store.Get(ctx, "first")
+	assert.True(t, domain.IsNotificationChannelNotFound(err))
+	assert.True(t, domain.IsNotificationChannelNotFound(store.Delete(ctx, "first")))
+}
+
+func TestWebhookNotificationSender_Send(t *testing.T) {
+	var contentType, body string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	sender := NewWebhookNotificationSender(time.Second)
+	sender.SetAllowPrivateTargets(true)
+	notification := []byte(`{"type":"Add"}`)
+
+	require.NoError(t, sender.Send(context.Background(), domain.NotificationChannel{SendTo: receiver.URL + "/hook"}, notification))
+	assert.Equal(t, "application/ld+json", contentType)
+	assert.Equal(t, `{"type":"Add"}`, body)
+
+	assert.Error(t, sender.Send(context.Background(), domain.NotificationChannel{SendTo: receiver.URL + "/gone"}, notification))
+}
+
+func TestWebhookNotificationSender_PrivateTargets(t *testing.T) {
+	delivered := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered = true
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	sender := NewWebhookNotificationSender(time.Second)
+	ctx := context.Background()
+
+	for _, target := range []string{
+		receiver.URL + "/hook",
+		"http://localhost/hook",
+		"http://10.0.0.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://100.64.0.1/hook",
+	} {
+		assert.ErrorIs(t, sender.CheckTarget(ctx, target), ErrPrivateNotificationTarget, target)
+	}
+	assert.NoError(t, sender.CheckTarget(ctx, "https://203.0.113.10/hook"))
+
+	// Refused again at dial time, whatever the name resolved to when the channel was opened
+	err := sender.Send(ctx, domain.NotificationChannel{SendTo: receiver.URL + "/hook"}, []byte(`{}`))
+	assert.ErrorIs(t, err, ErrPrivateNotificationTarget)
+	assert.False(t, delivered)
+
+	sender.SetAllowPrivateTargets(true)
+	assert.NoError(t, sender.CheckTarget(ctx, receiver.URL+"/hook"))
+}
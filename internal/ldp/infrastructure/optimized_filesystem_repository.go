@@ -71,7 +71,7 @@ func NewOptimizedFileSystemRepositoryProvider() (domain.StreamingResourceReposit
 }
 
 // Store saves a resource with optimized indexing and cache invalidation
-func (r *OptimizedFileSystemRepository) Store(ctx context.Context, resource *domain.Resource) error {
+func (r *OptimizedFileSystemRepository) Store(ctx context.Context, resource domain.Resource) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -92,8 +92,36 @@ func (r *OptimizedFileSystemRepository) Store(ctx context.Context, resource *dom
 	return nil
 }
 
+// Create stores a new resource, failing with ErrResourceAlreadyExists when its ID is already
+// in the index or claimed on disk
+func (r *OptimizedFileSystemRepository) Create(ctx context.Context, resource domain.Resource) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.indexer.FindByID(resource.ID()); exists {
+		return domain.WrapStorageError(
+			fmt.Errorf("resource already exists"),
+			domain.ErrResourceAlreadyExists.Code,
+			"resource already exists in index",
+		).WithOperation("Create").WithContext("resourceID", resource.ID())
+	}
+
+	// The base repository's exclusive create guards against writers outside this process
+	if err := r.FileSystemRepository.Create(ctx, resource); err != nil {
+		return err
+	}
+
+	if err := r.indexer.AddResource(resource); err != nil {
+		fmt.Printf("Warning: failed to update index for resource %s: %v\n", resource.ID(), err)
+	}
+
+	r.cache.Put(ctx, resource)
+
+	return nil
+}
+
 // Retrieve loads a resource with cache-first lookup and index optimization
-func (r *OptimizedFileSystemRepository) Retrieve(ctx context.Context, id string) (*domain.Resource, error) {
+func (r *OptimizedFileSystemRepository) Retrieve(ctx context.Context, id string) (domain.Resource, error) {
 	// Try cache first
 	if resource, found := r.cache.Get(ctx, id); found {
 		return resource, nil
@@ -160,14 +188,14 @@ func (r *OptimizedFileSystemRepository) Exists(ctx context.Context, id string) (
 }
 
 // FindByContentType finds resources by content type using index
-func (r *OptimizedFileSystemRepository) FindByContentType(ctx context.Context, contentType string) ([]*domain.Resource, error) {
+func (r *OptimizedFileSystemRepository) FindByContentType(ctx context.Context, contentType string) ([]domain.Resource, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	// Use index for fast lookup
 	indexEntries := r.indexer.FindByContentType(contentType)
 
-	resources := make([]*domain.Resource, 0, len(indexEntries))
+	resources := make([]domain.Resource, 0, len(indexEntries))
 	for _, entry := range indexEntries {
 		// Try cache first
 		if resource, found := r.cache.Get(ctx, entry.ID); found {
@@ -192,14 +220,14 @@ func (r *OptimizedFileSystemRepository) FindByContentType(ctx context.Context, c
 }
 
 // FindByTag finds resources by tag using index
-func (r *OptimizedFileSystemRepository) FindByTag(ctx context.Context, key, value string) ([]*domain.Resource, error) {
+func (r *OptimizedFileSystemRepository) FindByTag(ctx context.Context, key, value string) ([]domain.Resource, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	// Use index for fast lookup
 	indexEntries := r.indexer.FindByTag(key, value)
 
-	resources := make([]*domain.Resource, 0, len(indexEntries))
+	resources := make([]domain.Resource, 0, len(indexEntries))
 	for _, entry := range indexEntries {
 		// Try cache first
 		if resource, found := r.cache.Get(ctx, entry.ID); found {
@@ -224,7 +252,7 @@ func (r *OptimizedFileSystemRepository) FindByTag(ctx context.Context, key, valu
 }
 
 // ListResources returns a paginated list of resources using index
-func (r *OptimizedFileSystemRepository) ListResources(ctx context.Context, offset, limit int) ([]*domain.Resource, error) {
+func (r *OptimizedFileSystemRepository) ListResources(ctx context.Context, offset, limit int) ([]domain.Resource, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -234,7 +262,7 @@ func (r *OptimizedFileSystemRepository) ListResources(ctx context.Context, offse
 	// Apply pagination
 	start := offset
 	if start >= len(allEntries) {
-		return []*domain.Resource{}, nil
+		return []domain.Resource{}, nil
 	}
 
 	end := start + limit
@@ -243,7 +271,7 @@ func (r *OptimizedFileSystemRepository) ListResources(ctx context.Context, offse
 	}
 
 	entries := allEntries[start:end]
-	resources := make([]*domain.Resource, 0, len(entries))
+	resources := make([]domain.Resource, 0, len(entries))
 
 	for _, entry := range entries {
 		// Try cache first
@@ -295,6 +323,17 @@ func (r *OptimizedFileSystemRepository) GetStats(ctx context.Context) map[string
 	return stats
 }
 
+// InvalidateCached drops the cached resources with the given IDs, so they are read from disk
+// on next access
+func (r *OptimizedFileSystemRepository) InvalidateCached(ids ...string) int {
+	return r.cache.InvalidateCached(ids...)
+}
+
+// InvalidateAllCached drops every cached resource
+func (r *OptimizedFileSystemRepository) InvalidateAllCached() int {
+	return r.cache.InvalidateAllCached()
+}
+
 // WarmupCache preloads frequently accessed resources into cache
 func (r *OptimizedFileSystemRepository) WarmupCache(ctx context.Context) error {
 	r.mu.Lock()
@@ -375,3 +414,12 @@ func (r *OptimizedFileSystemRepository) RetrieveStream(ctx context.Context, id s
 	// This is because we want to stream the data, not load it all into memory
 	return r.FileSystemRepository.RetrieveStream(ctx, id)
 }
+
+// OpenContent opens a resource's content for random access (delegates to base repository)
+func (r *OptimizedFileSystemRepository) OpenContent(ctx context.Context, id string) (io.ReadSeekCloser, *domain.ResourceMetadata, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Like streaming, random access bypasses the cache
+	return r.FileSystemRepository.OpenContent(ctx, id)
+}
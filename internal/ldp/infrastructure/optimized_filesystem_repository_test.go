@@ -27,7 +27,7 @@ func TestOptimizedFileSystemRepository(t *testing.T) {
 
 	// Test basic operations
 	t.Run("BasicOperations", func(t *testing.T) {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			"optimized-test-1",
 			"text/turtle",
 			[]byte("@prefix ex: <http://example.org/> .\nex:test ex:name \"Optimized Test\" ."),
@@ -77,7 +77,7 @@ func TestOptimizedFileSystemRepository(t *testing.T) {
 
 	// Test cache effectiveness
 	t.Run("CacheEffectiveness", func(t *testing.T) {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			"cache-test",
 			"application/ld+json",
 			[]byte(`{"@context": "http://example.org/", "@id": "cache-test", "name": "Cache Test"}`),
@@ -131,10 +131,10 @@ func TestOptimizedFileSystemRepository(t *testing.T) {
 		}
 
 		// Add multiple resources with different content types
-		resources := []*domain.Resource{
-			domain.NewResource("index-test-1", "text/turtle", []byte("@prefix ex: <http://example.org/> .\nex:test1 ex:name \"Test1\" .")),
-			domain.NewResource("index-test-2", "application/ld+json", []byte(`{"@context": "http://example.org/", "@id": "test2", "name": "Test2"}`)),
-			domain.NewResource("index-test-3", "text/turtle", []byte("@prefix ex: <http://example.org/> .\nex:test3 ex:name \"Test3\" .")),
+		resources := []domain.Resource{
+			domain.NewResource(context.Background(), "index-test-1", "text/turtle", []byte("@prefix ex: <http://example.org/> .\nex:test1 ex:name \"Test1\" .")),
+			domain.NewResource(context.Background(), "index-test-2", "application/ld+json", []byte(`{"@context": "http://example.org/", "@id": "test2", "name": "Test2"}`)),
+			domain.NewResource(context.Background(), "index-test-3", "text/turtle", []byte("@prefix ex: <http://example.org/> .\nex:test3 ex:name \"Test3\" .")),
 		}
 
 		// Set metadata for tag-based queries
@@ -180,7 +180,7 @@ func TestOptimizedFileSystemRepository(t *testing.T) {
 	t.Run("Pagination", func(t *testing.T) {
 		// Add more resources for pagination test
 		for i := 0; i < 15; i++ {
-			resource := domain.NewResource(
+			resource := domain.NewResource(context.Background(),
 				fmt.Sprintf("pagination-test-%d", i),
 				"text/turtle",
 				[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:page%d ex:name \"Page Test %d\" .", i, i)),
@@ -320,7 +320,7 @@ func TestOptimizedRepositoryPerformance(t *testing.T) {
 	// Pre-populate repository
 	numResources := 50
 	for i := 0; i < numResources; i++ {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			fmt.Sprintf("perf-resource-%d", i),
 			"text/turtle",
 			[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:perf%d ex:name \"Performance Test %d\" .", i, i)),
@@ -49,7 +49,7 @@ func benchmarkRepositoryOperations(b *testing.B, tempDir string, useOptimized bo
 	b.Run("Store", func(b *testing.B) {
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
-			resource := domain.NewResource(
+			resource := domain.NewResource(context.Background(),
 				fmt.Sprintf("resource-%d", i),
 				"text/turtle",
 				[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:resource%d ex:name \"Resource %d\" .", i, i)),
@@ -63,7 +63,7 @@ func benchmarkRepositoryOperations(b *testing.B, tempDir string, useOptimized bo
 
 	// Pre-populate repository for retrieve benchmarks
 	for i := 0; i < 100; i++ {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			fmt.Sprintf("bench-resource-%d", i),
 			"text/turtle",
 			[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:resource%d ex:name \"Resource %d\" .", i, i)),
@@ -134,9 +134,9 @@ func testPerformanceRequirements(t *testing.T, tempDir string, useOptimized bool
 	ctx := context.Background()
 
 	// Pre-populate with test data
-	testResources := make([]*domain.Resource, 50)
+	testResources := make([]domain.Resource, 50)
 	for i := 0; i < 50; i++ {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			fmt.Sprintf("perf-test-resource-%d", i),
 			"application/ld+json",
 			[]byte(fmt.Sprintf(`{
@@ -156,7 +156,7 @@ func testPerformanceRequirements(t *testing.T, tempDir string, useOptimized bool
 
 	// Test Store operation performance (Requirement 3.1: sub-second response times)
 	t.Run("StorePerformance", func(t *testing.T) {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			"perf-store-test",
 			"text/turtle",
 			[]byte("@prefix ex: <http://example.org/> .\nex:test ex:name \"Performance Test\" ."),
@@ -309,7 +309,7 @@ func TestLargeFilePerformance(t *testing.T) {
 		t.Run(fmt.Sprintf("FileSize_%dB", size), func(t *testing.T) {
 			// Create large resource
 			largeData := generateTestData(size)
-			resource := domain.NewResource(
+			resource := domain.NewResource(context.Background(),
 				fmt.Sprintf("large-file-%d", size),
 				"application/octet-stream",
 				largeData,
@@ -381,7 +381,7 @@ func TestIndexingPerformance(t *testing.T) {
 	// Pre-populate both repositories with the same data
 	numResources := 100
 	for i := 0; i < numResources; i++ {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			fmt.Sprintf("index-test-resource-%d", i),
 			"text/turtle",
 			[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:resource%d ex:name \"Resource %d\" .", i, i)),
@@ -452,9 +452,9 @@ func BenchmarkCacheOperations(b *testing.B) {
 	ctx := context.Background()
 
 	// Create test resources
-	resources := make([]*domain.Resource, 100)
+	resources := make([]domain.Resource, 100)
 	for i := 0; i < 100; i++ {
-		resources[i] = domain.NewResource(
+		resources[i] = domain.NewResource(context.Background(),
 			fmt.Sprintf("cache-bench-resource-%d", i),
 			"text/turtle",
 			[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:resource%d ex:name \"Resource %d\" .", i, i)),
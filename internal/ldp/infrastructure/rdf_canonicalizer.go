@@ -0,0 +1,1093 @@
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+	rdfTypeIRI   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfFirstIRI  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRestIRI   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNilIRI    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+)
+
+// RDFCanonicalizer normalizes Turtle and N-Triples documents into canonical N-Triples.
+// Triples are deduplicated and sorted, literals are written in canonical form and blank
+// nodes are relabeled (_:c14n0, _:c14n1, ...) by the URDNA2015 algorithm, so isomorphic
+// graphs produce identical bytes. Literals keep their lexical form: "01"^^xsd:integer and
+// "1"^^xsd:integer stay distinct, as in URDNA2015.
+type RDFCanonicalizer struct{}
+
+// NewRDFCanonicalizer creates a new RDF canonicalizer
+func NewRDFCanonicalizer() *RDFCanonicalizer {
+	return &RDFCanonicalizer{}
+}
+
+// CanNormalize reports whether the format can be canonicalized.
+// JSON-LD and RDF/XML are stored verbatim until a full parser is available.
+func (c *RDFCanonicalizer) CanNormalize(format string) bool {
+	if idx := strings.Index(format, ";"); idx != -1 {
+		format = format[:idx]
+	}
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "text/turtle", "turtle", "ttl", "application/n-triples":
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize parses the document and returns its canonical N-Triples form.
+// N-Triples is a subset of Turtle, so the result is served as text/turtle.
+func (c *RDFCanonicalizer) Normalize(data []byte, format string) ([]byte, string, error) {
+	if !c.CanNormalize(format) {
+		return nil, "", fmt.Errorf("unsupported format for normalization: %s", format)
+	}
+
+	triples, err := newTurtleParser(string(data)).parse()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse RDF for normalization: %w", err)
+	}
+
+	labels, err := canonicalBlankNodeLabels(triples)
+	if err != nil {
+		return nil, "", err
+	}
+
+	lines := make(map[string]struct{}, len(triples))
+	for _, t := range triples {
+		lines[t.serialize(labels)] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	for _, line := range sorted {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String()), "text/turtle", nil
+}
+
+// canonicalTerm is a parsed RDF term
+type canonicalTerm struct {
+	kind     string // "iri", "blank", "literal" or "variable"
+	value    string
+	datatype string
+	language string
+}
+
+// canonicalTriple is a parsed RDF statement
+type canonicalTriple struct {
+	subject   canonicalTerm
+	predicate canonicalTerm
+	object    canonicalTerm
+}
+
+// serialize writes the triple as a canonical N-Triples line using the given blank node labels
+func (t canonicalTriple) serialize(labels map[string]string) string {
+	return t.subject.serialize(labels) + " " + t.predicate.serialize(labels) + " " + t.object.serialize(labels) + " ."
+}
+
+// serialize writes the term in canonical N-Triples form
+func (t canonicalTerm) serialize(labels map[string]string) string {
+	switch t.kind {
+	case "iri":
+		return "<" + t.value + ">"
+	case "blank":
+		if label, ok := labels[t.value]; ok {
+			return "_:" + label
+		}
+		return "_:" + t.value
+	case "variable":
+		return "?" + t.value
+	default:
+		literal := `"` + escapeCanonicalLiteral(t.value) + `"`
+		if t.language != "" {
+			return literal + "@" + t.language
+		}
+		if t.datatype != "" && t.datatype != xsdNamespace+"string" {
+			return literal + "^^<" + t.datatype + ">"
+		}
+		return literal
+	}
+}
+
+// escapeCanonicalLiteral escapes a literal value as required by canonical N-Triples
+func escapeCanonicalLiteral(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return replacer.Replace(value)
+}
+
+// maxCanonicalizationSteps bounds the permutations tried while distinguishing blank nodes.
+// URDNA2015 is exponential on graphs of many interchangeable blank nodes; such documents are
+// refused rather than tying up the server.
+const maxCanonicalizationSteps = 100000
+
+// errCanonicalizationTooComplex is returned for graphs exceeding maxCanonicalizationSteps
+var errCanonicalizationTooComplex = errors.New("blank node structure is too complex to canonicalize")
+
+// canonicalBlankNodeLabels assigns each blank node its URDNA2015 canonical label (c14n0,
+// c14n1, ...), so isomorphic graphs get identical labels whatever their input labels
+func canonicalBlankNodeLabels(triples []canonicalTriple) (map[string]string, error) {
+	c := &urdna2015{
+		nodeQuads: make(map[string][]canonicalTriple),
+		canonical: newBlankNodeIssuer("c14n"),
+	}
+	for _, t := range triples {
+		for _, term := range []canonicalTerm{t.subject, t.object} {
+			if term.kind == "blank" && !containsTriple(c.nodeQuads[term.value], t) {
+				c.nodeQuads[term.value] = append(c.nodeQuads[term.value], t)
+			}
+		}
+	}
+	if len(c.nodeQuads) == 0 {
+		return nil, nil
+	}
+
+	// Nodes with a unique first-degree hash are labeled in hash order
+	hashToNodes := make(map[string][]string)
+	for node := range c.nodeQuads {
+		hash := c.hashFirstDegree(node)
+		hashToNodes[hash] = append(hashToNodes[hash], node)
+	}
+	hashes := make([]string, 0, len(hashToNodes))
+	for hash := range hashToNodes {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	for _, hash := range hashes {
+		if nodes := hashToNodes[hash]; len(nodes) == 1 {
+			c.canonical.issue(nodes[0])
+		}
+	}
+
+	// Shared hashes are told apart by the paths to their neighbours
+	for _, hash := range hashes {
+		nodes := hashToNodes[hash]
+		if len(nodes) == 1 {
+			continue
+		}
+		sort.Strings(nodes)
+
+		var results []nDegreeResult
+		for _, node := range nodes {
+			if c.canonical.has(node) {
+				continue
+			}
+			issuer := newBlankNodeIssuer("b")
+			issuer.issue(node)
+			result, err := c.hashNDegreeQuads(node, issuer)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		sort.SliceStable(results, func(i, j int) bool { return results[i].hash < results[j].hash })
+		for _, result := range results {
+			for _, node := range result.issuer.order {
+				c.canonical.issue(node)
+			}
+		}
+	}
+
+	return c.canonical.ids, nil
+}
+
+// urdna2015 holds the state of one run of the URDNA2015 canonicalization algorithm
+type urdna2015 struct {
+	nodeQuads map[string][]canonicalTriple
+	canonical *blankNodeIssuer
+	steps     int
+}
+
+// nDegreeResult is the hash of a blank node's paths and the labels issued while finding them
+type nDegreeResult struct {
+	hash   string
+	issuer *blankNodeIssuer
+}
+
+// hashFirstDegree hashes the statements mentioning a blank node, with the node written as _:a
+// and every other blank node as _:z
+func (c *urdna2015) hashFirstDegree(node string) string {
+	lines := make([]string, 0, len(c.nodeQuads[node]))
+	for _, q := range c.nodeQuads[node] {
+		lines = append(lines, firstDegreeTerm(q.subject, node)+" "+q.predicate.serialize(nil)+" "+firstDegreeTerm(q.object, node)+" .\n")
+	}
+	sort.Strings(lines)
+	return hashString(strings.Join(lines, ""))
+}
+
+// hashRelatedBlankNode hashes a neighbour of a blank node by its position in the connecting
+// statement and its label, or its first-degree hash while it has none
+func (c *urdna2015) hashRelatedBlankNode(related string, quad canonicalTriple, issuer *blankNodeIssuer, position string) string {
+	identifier, ok := c.canonical.ids[related]
+	if !ok {
+		identifier, ok = issuer.ids[related]
+	}
+	if ok {
+		identifier = "_:" + identifier
+	} else {
+		identifier = c.hashFirstDegree(related)
+	}
+	return hashString(position + "<" + quad.predicate.value + ">" + identifier)
+}
+
+// hashNDegreeQuads hashes the paths from a blank node to its neighbours, choosing the order
+// of interchangeable neighbours that gives the smallest path
+func (c *urdna2015) hashNDegreeQuads(node string, issuer *blankNodeIssuer) (nDegreeResult, error) {
+	hashToRelated := make(map[string][]string)
+	for _, q := range c.nodeQuads[node] {
+		for _, component := range []struct {
+			term     canonicalTerm
+			position string
+		}{{q.subject, "s"}, {q.object, "o"}} {
+			if component.term.kind == "blank" && component.term.value != node {
+				hash := c.hashRelatedBlankNode(component.term.value, q, issuer, component.position)
+				hashToRelated[hash] = append(hashToRelated[hash], component.term.value)
+			}
+		}
+	}
+	relatedHashes := make([]string, 0, len(hashToRelated))
+	for hash := range hashToRelated {
+		relatedHashes = append(relatedHashes, hash)
+	}
+	sort.Strings(relatedHashes)
+
+	var data strings.Builder
+	for _, relatedHash := range relatedHashes {
+		data.WriteString(relatedHash)
+
+		var chosenPath string
+		var chosenIssuer *blankNodeIssuer
+		err := permute(hashToRelated[relatedHash], func(permutation []string) error {
+			c.steps++
+			if c.steps > maxCanonicalizationSteps {
+				return errCanonicalizationTooComplex
+			}
+
+			issuerCopy := issuer.clone()
+			path := ""
+			var recursion []string
+			for _, related := range permutation {
+				if id, ok := c.canonical.ids[related]; ok {
+					path += "_:" + id
+				} else {
+					if !issuerCopy.has(related) {
+						recursion = append(recursion, related)
+					}
+					path += "_:" + issuerCopy.issue(related)
+				}
+				if chosenPath != "" && len(path) >= len(chosenPath) && path > chosenPath {
+					return nil
+				}
+			}
+
+			for _, related := range recursion {
+				result, err := c.hashNDegreeQuads(related, issuerCopy)
+				if err != nil {
+					return err
+				}
+				path += "_:" + issuerCopy.issue(related) + "<" + result.hash + ">"
+				issuerCopy = result.issuer
+				if chosenPath != "" && len(path) >= len(chosenPath) && path > chosenPath {
+					return nil
+				}
+			}
+
+			if chosenPath == "" || path < chosenPath {
+				chosenPath = path
+				chosenIssuer = issuerCopy
+			}
+			return nil
+		})
+		if err != nil {
+			return nDegreeResult{}, err
+		}
+
+		data.WriteString(chosenPath)
+		issuer = chosenIssuer
+	}
+
+	return nDegreeResult{hash: hashString(data.String()), issuer: issuer}, nil
+}
+
+// blankNodeIssuer issues sequential labels to blank nodes, remembering the order of issue
+type blankNodeIssuer struct {
+	prefix string
+	ids    map[string]string
+	order  []string
+}
+
+// newBlankNodeIssuer creates an issuer of labels with the given prefix
+func newBlankNodeIssuer(prefix string) *blankNodeIssuer {
+	return &blankNodeIssuer{prefix: prefix, ids: make(map[string]string)}
+}
+
+// issue returns the node's label, issuing the next one if it has none
+func (i *blankNodeIssuer) issue(node string) string {
+	if id, ok := i.ids[node]; ok {
+		return id
+	}
+	id := i.prefix + strconv.Itoa(len(i.order))
+	i.ids[node] = id
+	i.order = append(i.order, node)
+	return id
+}
+
+// has reports whether the node has been issued a label
+func (i *blankNodeIssuer) has(node string) bool {
+	_, ok := i.ids[node]
+	return ok
+}
+
+// clone returns an independent copy of the issuer
+func (i *blankNodeIssuer) clone() *blankNodeIssuer {
+	ids := make(map[string]string, len(i.ids))
+	for node, id := range i.ids {
+		ids[node] = id
+	}
+	return &blankNodeIssuer{prefix: i.prefix, ids: ids, order: append([]string(nil), i.order...)}
+}
+
+// permute calls visit with every ordering of the nodes, stopping at the first error
+func permute(nodes []string, visit func([]string) error) error {
+	permutation := append([]string(nil), nodes...)
+	sort.Strings(permutation)
+	var generate func(k int) error
+	generate = func(k int) error {
+		if k == len(permutation) {
+			return visit(permutation)
+		}
+		for i := k; i < len(permutation); i++ {
+			permutation[k], permutation[i] = permutation[i], permutation[k]
+			if err := generate(k + 1); err != nil {
+				return err
+			}
+			permutation[k], permutation[i] = permutation[i], permutation[k]
+		}
+		return nil
+	}
+	return generate(0)
+}
+
+// containsTriple reports whether a statement is already in the list
+func containsTriple(triples []canonicalTriple, t canonicalTriple) bool {
+	for _, existing := range triples {
+		if existing == t {
+			return true
+		}
+	}
+	return false
+}
+
+// blankNodeNeighbourhoodHashes returns a hash for each blank node derived from the statements
+// around it rather than its label, for comparing documents where a node should keep its label
+// when unrelated statements change. Each node starts from its first-degree hash and folds in
+// its neighbours' hashes until no more nodes are told apart. This is colour refinement, not
+// URDNA2015: nodes it cannot tell apart are separated by their input labels, so graphs whose
+// blank nodes are structurally alike (regular cycles, for instance) may hash differently when
+// relabeled.
+func blankNodeNeighbourhoodHashes(triples []canonicalTriple) map[string]string {
+	mentions := make(map[string][]canonicalTriple)
+	for _, t := range triples {
+		for _, term := range []canonicalTerm{t.subject, t.object} {
+			if term.kind == "blank" {
+				mentions[term.value] = append(mentions[term.value], t)
+			}
+		}
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	hashes := make(map[string]string, len(mentions))
+	for node, quads := range mentions {
+		hashes[node] = firstDegreeHash(node, quads)
+	}
+
+	for {
+		hashes = refineBlankNodeHashes(hashes, mentions)
+
+		tied := firstTiedGroup(hashes)
+		if tied == nil {
+			break
+		}
+		// Individualize one member of the smallest tied group and refine again
+		hashes[tied[0]] = hashString(hashes[tied[0]] + "!")
+	}
+	return hashes
+}
+
+// firstDegreeHash hashes the statements mentioning a blank node
+func firstDegreeHash(node string, quads []canonicalTriple) string {
+	lines := make([]string, 0, len(quads))
+	for _, q := range quads {
+		lines = append(lines, firstDegreeTerm(q.subject, node)+" "+q.predicate.serialize(nil)+" "+firstDegreeTerm(q.object, node)+" .")
+	}
+	sort.Strings(lines)
+	return hashString(strings.Join(lines, "\n"))
+}
+
+// firstDegreeTerm serializes a term with the reference blank node as _:a and others as _:z
+func firstDegreeTerm(term canonicalTerm, node string) string {
+	if term.kind != "blank" {
+		return term.serialize(nil)
+	}
+	if term.value == node {
+		return "_:a"
+	}
+	return "_:z"
+}
+
+// refineBlankNodeHashes folds neighbour hashes into each blank node hash until the
+// number of distinct hashes stops growing
+func refineBlankNodeHashes(hashes map[string]string, mentions map[string][]canonicalTriple) map[string]string {
+	for {
+		refined := make(map[string]string, len(hashes))
+		for node, quads := range mentions {
+			parts := make([]string, 0, len(quads))
+			for _, q := range quads {
+				parts = append(parts, refinedTerm(q.subject, node, hashes)+" "+q.predicate.value+" "+refinedTerm(q.object, node, hashes))
+			}
+			sort.Strings(parts)
+			refined[node] = hashString(hashes[node] + "\n" + strings.Join(parts, "\n"))
+		}
+
+		if distinctCount(refined) <= distinctCount(hashes) {
+			return hashes
+		}
+		hashes = refined
+	}
+}
+
+// refinedTerm serializes a term for refinement, replacing blank nodes with their current hash
+func refinedTerm(term canonicalTerm, node string, hashes map[string]string) string {
+	if term.kind != "blank" {
+		return term.serialize(nil)
+	}
+	if term.value == node {
+		return "_:a"
+	}
+	return "_:" + hashes[term.value]
+}
+
+// firstTiedGroup returns the blank nodes sharing the lowest duplicated hash, ordered by label
+func firstTiedGroup(hashes map[string]string) []string {
+	groups := make(map[string][]string)
+	for node, hash := range hashes {
+		groups[hash] = append(groups[hash], node)
+	}
+
+	var tiedHash string
+	for hash, nodes := range groups {
+		if len(nodes) > 1 && (tiedHash == "" || hash < tiedHash) {
+			tiedHash = hash
+		}
+	}
+	if tiedHash == "" {
+		return nil
+	}
+
+	tied := groups[tiedHash]
+	sort.Strings(tied)
+	return tied
+}
+
+// distinctCount returns the number of distinct hash values
+func distinctCount(hashes map[string]string) int {
+	seen := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		seen[hash] = struct{}{}
+	}
+	return len(seen)
+}
+
+// hashString returns the hex-encoded SHA-256 hash of a string
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// turtleParser parses Turtle and N-Triples: prefix and base directives, IRIs, prefixed names,
+// labeled and anonymous blank nodes ([ ] and [ predicate object ]), collections, literals and
+// predicate/object lists. Anonymous nodes and collection cells get generated labels that never
+// collide with the document's own labels.
+type turtleParser struct {
+	input    []rune
+	pos      int
+	prefixes map[string]string
+	base     string
+	triples  []canonicalTriple
+	// writtenLabels and generatedLabels hold the blank node labels taken from the document and
+	// generated for anonymous nodes; renamed maps written labels that a generated node already
+	// had to their replacements
+	writtenLabels   map[string]bool
+	generatedLabels map[string]bool
+	renamed         map[string]string
+	generated       int
+	// inGroup lets the last statement before a closing '}' omit its '.', as in the triple
+	// blocks of a SPARQL update
+	inGroup bool
+	// variables lets terms be ?name variables, as in the formulae of an N3 patch
+	variables bool
+}
+
+// newTurtleParser creates a parser for the given document
+func newTurtleParser(input string) *turtleParser {
+	return &turtleParser{
+		input:           []rune(input),
+		prefixes:        make(map[string]string),
+		writtenLabels:   make(map[string]bool),
+		generatedLabels: make(map[string]bool),
+		renamed:         make(map[string]string),
+	}
+}
+
+// parse parses the whole document
+func (p *turtleParser) parse() ([]canonicalTriple, error) {
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return p.triples, nil
+		}
+
+		if p.peek() == '@' || p.matchKeyword("PREFIX") || p.matchKeyword("BASE") {
+			if err := p.parseDirective(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := p.parseStatement(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseDirective parses @prefix, @base, PREFIX and BASE directives
+func (p *turtleParser) parseDirective() error {
+	sparqlStyle := p.peek() != '@'
+	if !sparqlStyle {
+		p.pos++
+	}
+
+	word := strings.ToLower(p.readWhile(func(r rune) bool { return unicode.IsLetter(r) }))
+	p.skipWhitespace()
+
+	switch word {
+	case "prefix":
+		name := p.readWhile(func(r rune) bool { return r != ':' && !unicode.IsSpace(r) })
+		if p.peek() != ':' {
+			return p.errorf("expected ':' in prefix declaration")
+		}
+		p.pos++
+		p.skipWhitespace()
+		iri, err := p.parseIRIRef()
+		if err != nil {
+			return err
+		}
+		p.prefixes[name] = iri
+	case "base":
+		iri, err := p.parseIRIRef()
+		if err != nil {
+			return err
+		}
+		p.base = iri
+	default:
+		return p.errorf("unknown directive %q", word)
+	}
+
+	if !sparqlStyle {
+		p.skipWhitespace()
+		if p.peek() != '.' {
+			return p.errorf("expected '.' after directive")
+		}
+		p.pos++
+	}
+	return nil
+}
+
+// parseStatement parses a subject followed by a predicate/object list. A [ ... ] subject
+// with properties may stand alone.
+func (p *turtleParser) parseStatement() error {
+	propertyList := p.peek() == '['
+	subject, err := p.parseTerm()
+	if err != nil {
+		return err
+	}
+	if subject.kind == "literal" {
+		return p.errorf("literal cannot be used as subject")
+	}
+
+	p.skipWhitespace()
+	if !propertyList || (p.peek() != '.' && !(p.inGroup && p.peek() == '}')) {
+		if err := p.parsePredicateObjectList(subject); err != nil {
+			return err
+		}
+	}
+
+	switch p.peek() {
+	case '.':
+		p.pos++
+		return nil
+	case '}':
+		if p.inGroup {
+			return nil
+		}
+	}
+	return p.errorf("expected ',', ';' or '.'")
+}
+
+// parsePredicateObjectList parses the predicates and objects of a subject, stopping before the
+// token that ends the list
+func (p *turtleParser) parsePredicateObjectList(subject canonicalTerm) error {
+	for {
+		p.skipWhitespace()
+		predicate, err := p.parsePredicate()
+		if err != nil {
+			return err
+		}
+
+		for {
+			p.skipWhitespace()
+			object, err := p.parseTerm()
+			if err != nil {
+				return err
+			}
+			p.triples = append(p.triples, canonicalTriple{subject: subject, predicate: predicate, object: object})
+
+			p.skipWhitespace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+
+		if p.peek() != ';' {
+			return nil
+		}
+		// Repeated and trailing ';' are allowed
+		for p.peek() == ';' {
+			p.pos++
+			p.skipWhitespace()
+		}
+		if r := p.peek(); p.eof() || r == '.' || r == ']' || r == '}' {
+			return nil
+		}
+	}
+}
+
+// parsePredicate parses a predicate, including the 'a' shorthand for rdf:type
+func (p *turtleParser) parsePredicate() (canonicalTerm, error) {
+	if p.peek() == 'a' && (p.pos+1 >= len(p.input) || !isNameRune(p.input[p.pos+1]) && p.input[p.pos+1] != ':') {
+		p.pos++
+		return canonicalTerm{kind: "iri", value: rdfTypeIRI}, nil
+	}
+
+	term, err := p.parseTerm()
+	if err != nil {
+		return canonicalTerm{}, err
+	}
+	if term.kind != "iri" && term.kind != "variable" {
+		return canonicalTerm{}, p.errorf("predicate must be an IRI")
+	}
+	return term, nil
+}
+
+// parseTerm parses an IRI, prefixed name, blank node or literal
+func (p *turtleParser) parseTerm() (canonicalTerm, error) {
+	p.skipWhitespace()
+	if p.eof() {
+		return canonicalTerm{}, p.errorf("unexpected end of input")
+	}
+
+	switch r := p.peek(); {
+	case r == '<':
+		iri, err := p.parseIRIRef()
+		if err != nil {
+			return canonicalTerm{}, err
+		}
+		return canonicalTerm{kind: "iri", value: iri}, nil
+	case r == '_' && p.pos+1 < len(p.input) && p.input[p.pos+1] == ':':
+		p.pos += 2
+		label := p.readWhile(isNameRune)
+		for strings.HasSuffix(label, ".") {
+			label = strings.TrimSuffix(label, ".")
+			p.pos--
+		}
+		if label == "" {
+			return canonicalTerm{}, p.errorf("empty blank node label")
+		}
+		return canonicalTerm{kind: "blank", value: p.writtenLabel(label)}, nil
+	case r == '"' || r == '\'':
+		return p.parseLiteral()
+	case r == '[':
+		return p.parseBlankNodePropertyList()
+	case r == '(':
+		return p.parseCollection()
+	case r == '?' || r == '$':
+		if !p.variables {
+			return canonicalTerm{}, p.errorf("variables are not supported")
+		}
+		p.pos++
+		name := p.readWhile(isNameRune)
+		for strings.HasSuffix(name, ".") {
+			name = strings.TrimSuffix(name, ".")
+			p.pos--
+		}
+		if name == "" {
+			return canonicalTerm{}, p.errorf("empty variable name")
+		}
+		return canonicalTerm{kind: "variable", value: name}, nil
+	case r == '+' || r == '-' || r == '.' || unicode.IsDigit(r):
+		return p.parseNumber()
+	default:
+		return p.parsePrefixedNameOrKeyword()
+	}
+}
+
+// parseBlankNodePropertyList parses [ ] or [ predicate object ; ... ], returning the new
+// blank node the properties describe
+func (p *turtleParser) parseBlankNodePropertyList() (canonicalTerm, error) {
+	p.pos++
+	node := canonicalTerm{kind: "blank", value: p.generateLabel()}
+
+	p.skipWhitespace()
+	if p.peek() != ']' {
+		if err := p.parsePredicateObjectList(node); err != nil {
+			return canonicalTerm{}, err
+		}
+		p.skipWhitespace()
+		if p.peek() != ']' {
+			return canonicalTerm{}, p.errorf("expected ']'")
+		}
+	}
+	p.pos++
+	return node, nil
+}
+
+// parseCollection parses ( item ... ) into an rdf:first/rdf:rest list, returning its head:
+// rdf:nil for an empty collection
+func (p *turtleParser) parseCollection() (canonicalTerm, error) {
+	p.pos++
+	var items []canonicalTerm
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return canonicalTerm{}, p.errorf("unterminated collection")
+		}
+		if p.peek() == ')' {
+			p.pos++
+			break
+		}
+		item, err := p.parseTerm()
+		if err != nil {
+			return canonicalTerm{}, err
+		}
+		items = append(items, item)
+	}
+
+	head := canonicalTerm{kind: "iri", value: rdfNilIRI}
+	for i := len(items) - 1; i >= 0; i-- {
+		cell := canonicalTerm{kind: "blank", value: p.generateLabel()}
+		p.triples = append(p.triples,
+			canonicalTriple{subject: cell, predicate: canonicalTerm{kind: "iri", value: rdfFirstIRI}, object: items[i]},
+			canonicalTriple{subject: cell, predicate: canonicalTerm{kind: "iri", value: rdfRestIRI}, object: head},
+		)
+		head = cell
+	}
+	return head, nil
+}
+
+// writtenLabel returns the label used for a blank node label written in the document,
+// renaming it when a generated node already has it
+func (p *turtleParser) writtenLabel(label string) string {
+	if renamed, ok := p.renamed[label]; ok {
+		return renamed
+	}
+	if p.generatedLabels[label] {
+		renamed := p.generateLabel()
+		p.renamed[label] = renamed
+		return renamed
+	}
+	p.writtenLabels[label] = true
+	return label
+}
+
+// generateLabel returns a new blank node label not used by the document so far
+func (p *turtleParser) generateLabel() string {
+	for {
+		label := "genid" + strconv.Itoa(p.generated)
+		p.generated++
+		if !p.writtenLabels[label] && !p.generatedLabels[label] {
+			p.generatedLabels[label] = true
+			return label
+		}
+	}
+}
+
+// parseIRIRef parses an IRI enclosed in angle brackets, resolving it against the base
+func (p *turtleParser) parseIRIRef() (string, error) {
+	if p.peek() != '<' {
+		return "", p.errorf("expected '<'")
+	}
+	p.pos++
+	iri := p.readWhile(func(r rune) bool { return r != '>' })
+	if p.eof() {
+		return "", p.errorf("unterminated IRI")
+	}
+	p.pos++
+
+	return p.resolveIRI(iri), nil
+}
+
+// resolveIRI resolves a relative IRI reference against the base, when there is one
+func (p *turtleParser) resolveIRI(iri string) string {
+	if p.base == "" {
+		return iri
+	}
+	reference, err := url.Parse(iri)
+	if err != nil || reference.IsAbs() {
+		return iri
+	}
+	base, err := url.Parse(p.base)
+	if err != nil {
+		return p.base + iri
+	}
+	return base.ResolveReference(reference).String()
+}
+
+// parseLiteral parses a quoted literal with an optional language tag or datatype
+func (p *turtleParser) parseLiteral() (canonicalTerm, error) {
+	quote := p.peek()
+	long := p.pos+2 < len(p.input) && p.input[p.pos+1] == quote && p.input[p.pos+2] == quote
+	if long {
+		p.pos += 3
+	} else {
+		p.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return canonicalTerm{}, p.errorf("unterminated literal")
+		}
+		r := p.input[p.pos]
+
+		if r == '\\' {
+			if p.pos+1 >= len(p.input) {
+				return canonicalTerm{}, p.errorf("unterminated escape sequence")
+			}
+			if escape := p.input[p.pos+1]; escape == 'u' || escape == 'U' {
+				r, err := p.parseUnicodeEscape(escape)
+				if err != nil {
+					return canonicalTerm{}, err
+				}
+				b.WriteRune(r)
+				continue
+			}
+			b.WriteRune(unescapeTurtleChar(p.input[p.pos+1]))
+			p.pos += 2
+			continue
+		}
+
+		if long {
+			if r == quote && p.pos+2 < len(p.input) && p.input[p.pos+1] == quote && p.input[p.pos+2] == quote {
+				p.pos += 3
+				break
+			}
+		} else if r == quote {
+			p.pos++
+			break
+		} else if r == '\n' || r == '\r' {
+			return canonicalTerm{}, p.errorf("newline in short literal")
+		}
+
+		b.WriteRune(r)
+		p.pos++
+	}
+
+	term := canonicalTerm{kind: "literal", value: b.String()}
+
+	switch {
+	case p.peek() == '@':
+		p.pos++
+		term.language = strings.ToLower(p.readWhile(func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' }))
+	case p.peek() == '^' && p.pos+1 < len(p.input) && p.input[p.pos+1] == '^':
+		p.pos += 2
+		datatype, err := p.parseTerm()
+		if err != nil {
+			return canonicalTerm{}, err
+		}
+		if datatype.kind != "iri" {
+			return canonicalTerm{}, p.errorf("datatype must be an IRI")
+		}
+		term.datatype = datatype.value
+	}
+
+	return term, nil
+}
+
+// parseUnicodeEscape parses a \uXXXX or \UXXXXXXXX escape sequence
+func (p *turtleParser) parseUnicodeEscape(escape rune) (rune, error) {
+	digits := 4
+	if escape == 'U' {
+		digits = 8
+	}
+	start := p.pos + 2
+	if start+digits > len(p.input) {
+		return 0, p.errorf("truncated unicode escape")
+	}
+
+	code, err := strconv.ParseUint(string(p.input[start:start+digits]), 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos = start + digits
+	return rune(code), nil
+}
+
+// parseNumber parses an integer, decimal or double shorthand literal
+func (p *turtleParser) parseNumber() (canonicalTerm, error) {
+	start := p.pos
+	if p.peek() == '+' || p.peek() == '-' {
+		p.pos++
+	}
+	p.readWhile(unicode.IsDigit)
+
+	datatype := xsdNamespace + "integer"
+	if p.peek() == '.' && p.pos+1 < len(p.input) && unicode.IsDigit(p.input[p.pos+1]) {
+		p.pos++
+		p.readWhile(unicode.IsDigit)
+		datatype = xsdNamespace + "decimal"
+	}
+	if p.peek() == 'e' || p.peek() == 'E' {
+		p.pos++
+		if p.peek() == '+' || p.peek() == '-' {
+			p.pos++
+		}
+		p.readWhile(unicode.IsDigit)
+		datatype = xsdNamespace + "double"
+	}
+
+	value := string(p.input[start:p.pos])
+	if value == "" || value == "+" || value == "-" {
+		return canonicalTerm{}, p.errorf("invalid numeric literal")
+	}
+	return canonicalTerm{kind: "literal", value: value, datatype: datatype}, nil
+}
+
+// parsePrefixedNameOrKeyword parses a prefixed name or the boolean keywords
+func (p *turtleParser) parsePrefixedNameOrKeyword() (canonicalTerm, error) {
+	name := p.readWhile(func(r rune) bool { return isNameRune(r) || r == ':' })
+	// A trailing '.' terminates the statement rather than belonging to the name
+	for strings.HasSuffix(name, ".") {
+		name = strings.TrimSuffix(name, ".")
+		p.pos--
+	}
+
+	if name == "true" || name == "false" {
+		return canonicalTerm{kind: "literal", value: name, datatype: xsdNamespace + "boolean"}, nil
+	}
+
+	idx := strings.Index(name, ":")
+	if idx == -1 {
+		return canonicalTerm{}, p.errorf("unexpected token %q", name)
+	}
+
+	namespace, ok := p.prefixes[name[:idx]]
+	if !ok {
+		return canonicalTerm{}, p.errorf("undefined prefix %q", name[:idx])
+	}
+	return canonicalTerm{kind: "iri", value: namespace + name[idx+1:]}, nil
+}
+
+// skipWhitespace skips whitespace and comments
+func (p *turtleParser) skipWhitespace() {
+	for !p.eof() {
+		r := p.peek()
+		if r == '#' {
+			p.readWhile(func(r rune) bool { return r != '\n' })
+			continue
+		}
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos++
+	}
+}
+
+// readWhile consumes runes while the predicate holds and returns them
+func (p *turtleParser) readWhile(accept func(rune) bool) string {
+	start := p.pos
+	for !p.eof() && accept(p.input[p.pos]) {
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+// matchKeyword reports whether a case-insensitive keyword followed by whitespace is next
+func (p *turtleParser) matchKeyword(keyword string) bool {
+	end := p.pos + len(keyword)
+	if end >= len(p.input) {
+		return false
+	}
+	return strings.EqualFold(string(p.input[p.pos:end]), keyword) && unicode.IsSpace(p.input[end])
+}
+
+// peek returns the current rune without consuming it
+func (p *turtleParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// eof reports whether the whole input has been consumed
+func (p *turtleParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+// errorf returns a parse error annotated with the current position
+func (p *turtleParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("turtle parse error at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// isNameRune reports whether the rune may appear in a prefixed name or blank node label
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+// unescapeTurtleChar resolves a single-character escape sequence
+func unescapeTurtleChar(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	default:
+		return r
+	}
+}
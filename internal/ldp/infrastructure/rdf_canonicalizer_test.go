@@ -0,0 +1,159 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRDFCanonicalizer_EquivalentSerializationsMatch(t *testing.T) {
+	canonicalizer := NewRDFCanonicalizer()
+
+	turtle := `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+ex:doc a ex:Document ;
+    ex:title "Hello"@EN , "Bonjour"@fr ;
+    ex:count 42 ;
+    ex:author _:someone .
+
+_:someone ex:name "Alice"^^xsd:string .
+`
+
+	ntriples := `_:x <http://example.org/name> "Alice" .
+<http://example.org/doc> <http://example.org/author> _:x .
+<http://example.org/doc> <http://example.org/count> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/doc> <http://example.org/title> "Bonjour"@fr .
+<http://example.org/doc> <http://example.org/title> "Hello"@en .
+<http://example.org/doc> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/Document> .
+<http://example.org/doc> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/Document> .
+`
+
+	first, contentType, err := canonicalizer.Normalize([]byte(turtle), "text/turtle")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	second, _, err := canonicalizer.Normalize([]byte(ntriples), "application/n-triples")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+
+	if contentType != "text/turtle" {
+		t.Errorf("Expected text/turtle content type, got %q", contentType)
+	}
+	if string(first) != string(second) {
+		t.Errorf("Expected identical canonical forms:\n%s\n---\n%s", first, second)
+	}
+	if lines := strings.Count(string(first), "\n"); lines != 6 {
+		t.Errorf("Expected 6 deduplicated triples, got %d:\n%s", lines, first)
+	}
+	if !strings.Contains(string(first), "_:c14n0") {
+		t.Errorf("Expected blank node to be relabeled, got:\n%s", first)
+	}
+}
+
+func TestRDFCanonicalizer_BlankNodeLabelsAreIndependentOfInput(t *testing.T) {
+	canonicalizer := NewRDFCanonicalizer()
+
+	a := `_:a <http://example.org/knows> _:b .
+_:b <http://example.org/knows> _:a .
+_:a <http://example.org/name> "A" .
+`
+	b := `_:n2 <http://example.org/name> "A" .
+_:n2 <http://example.org/knows> _:n1 .
+_:n1 <http://example.org/knows> _:n2 .
+`
+
+	first, _, err := canonicalizer.Normalize([]byte(a), "text/turtle")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	second, _, err := canonicalizer.Normalize([]byte(b), "text/turtle")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Errorf("Expected identical canonical forms:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestRDFCanonicalizer_CanNormalize(t *testing.T) {
+	canonicalizer := NewRDFCanonicalizer()
+
+	if !canonicalizer.CanNormalize("text/turtle; charset=utf-8") {
+		t.Error("Expected Turtle with parameters to be normalizable")
+	}
+	if canonicalizer.CanNormalize("application/ld+json") {
+		t.Error("Expected JSON-LD to be stored verbatim")
+	}
+}
+
+func TestRDFCanonicalizer_SymmetricGraphsMatchUnderRelabeling(t *testing.T) {
+	canonicalizer := NewRDFCanonicalizer()
+
+	// Two disjoint cycles of interchangeable nodes; only URDNA2015's path search, not the
+	// nodes' labels, can tell them apart
+	edges := [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}}
+	document := func(labels []string) string {
+		var b strings.Builder
+		for i := len(edges) - 1; i >= 0; i-- {
+			b.WriteString("_:" + labels[edges[i][0]] + " <http://example.org/next> _:" + labels[edges[i][1]] + " .\n")
+		}
+		return b.String()
+	}
+
+	expected, _, err := canonicalizer.Normalize([]byte(document([]string{"a", "b", "c", "d", "e", "f"})), "text/turtle")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	for _, labels := range [][]string{
+		{"f", "e", "d", "c", "b", "a"},
+		{"z", "a", "m", "b", "y", "c"},
+		{"n4", "n0", "n2", "n5", "n1", "n3"},
+	} {
+		actual, _, err := canonicalizer.Normalize([]byte(document(labels)), "text/turtle")
+		if err != nil {
+			t.Fatalf("Normalize returned error: %v", err)
+		}
+		if string(actual) != string(expected) {
+			t.Errorf("Expected identical canonical forms for labels %v:\n%s\n---\n%s", labels, expected, actual)
+		}
+	}
+}
+
+func TestRDFCanonicalizer_AnonymousNodesAndCollections(t *testing.T) {
+	canonicalizer := NewRDFCanonicalizer()
+
+	turtle := `@base <http://example.org/docs/> .
+<doc> <http://example.org/author> [ <http://example.org/name> "Alice" ] ;
+    <http://example.org/tags> ( "a" "b" ) ;
+    <http://example.org/none> () ;
+    <http://example.org/parent> <../index> .
+[ <http://example.org/name> "Bob" ] .
+_:genid0 <http://example.org/name> "Carol" .
+`
+	ntriples := `<http://example.org/docs/doc> <http://example.org/author> _:alice .
+_:alice <http://example.org/name> "Alice" .
+<http://example.org/docs/doc> <http://example.org/tags> _:l1 .
+_:l1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "a" .
+_:l1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:l2 .
+_:l2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "b" .
+_:l2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .
+<http://example.org/docs/doc> <http://example.org/none> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .
+<http://example.org/docs/doc> <http://example.org/parent> <http://example.org/index> .
+_:bob <http://example.org/name> "Bob" .
+_:carol <http://example.org/name> "Carol" .
+`
+
+	first, _, err := canonicalizer.Normalize([]byte(turtle), "text/turtle")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	second, _, err := canonicalizer.Normalize([]byte(ntriples), "application/n-triples")
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("Expected identical canonical forms:\n%s\n---\n%s", first, second)
+	}
+}
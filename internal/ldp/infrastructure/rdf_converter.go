@@ -3,6 +3,8 @@ package infrastructure
 import (
 	"fmt"
 	"strings"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 )
 
 // SupportedFormats defines the RDF formats supported by the converter
@@ -43,6 +45,7 @@ func (c *RDFConverter) Convert(data []byte, fromFormat, toFormat string) ([]byte
 
 	// If formats are the same, return original data
 	if fromFormat == toFormat {
+		metrics.ObserveRDFDocumentSize(toFormat, metrics.OperationResource, len(data))
 		return data, nil
 	}
 
@@ -57,6 +60,7 @@ func (c *RDFConverter) Convert(data []byte, fromFormat, toFormat string) ([]byte
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize to %s: %w", toFormat, err)
 	}
+	metrics.ObserveRDFDocumentSize(toFormat, metrics.OperationResource, len(result))
 
 	return result, nil
 }
@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// RDFGraphDiffer compares Turtle and N-Triples documents statement by statement. Literals
+// are compared by value rather than by how they are written, and blank nodes are labeled by
+// the statements around them, so reordering a document or renaming its blank nodes yields
+// an empty diff.
+type RDFGraphDiffer struct{}
+
+// NewRDFGraphDiffer creates a new RDF graph differ
+func NewRDFGraphDiffer() *RDFGraphDiffer {
+	return &RDFGraphDiffer{}
+}
+
+// DiffGraphs returns the statements added and removed going from one document to the other,
+// each list sorted. A blank node whose statements changed is reported as a new node: its old
+// statements are removed and its new ones added.
+func (d *RDFGraphDiffer) DiffGraphs(from, to []byte) (domain.GraphDiff, error) {
+	fromStatements, err := diffStatements(from)
+	if err != nil {
+		return domain.GraphDiff{}, err
+	}
+	toStatements, err := diffStatements(to)
+	if err != nil {
+		return domain.GraphDiff{}, err
+	}
+
+	diff := domain.GraphDiff{Added: []string{}, Removed: []string{}}
+	for statement := range toStatements {
+		if _, ok := fromStatements[statement]; !ok {
+			diff.Added = append(diff.Added, statement)
+		}
+	}
+	for statement := range fromStatements {
+		if _, ok := toStatements[statement]; !ok {
+			diff.Removed = append(diff.Removed, statement)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff, nil
+}
+
+// diffStatements parses a document into its set of comparable N-Triples statements
+func diffStatements(document []byte) (map[string]struct{}, error) {
+	triples, err := newTurtleParser(string(document)).parse()
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("failed to parse stored document: %w", err),
+			domain.ErrFormatConversion.Code,
+			"stored document cannot be compared",
+		).WithOperation("DiffGraphs")
+	}
+
+	// Label blank nodes by a prefix of their canonical hash rather than by position, so a
+	// blank node keeps its label when unrelated statements are added or removed
+	labels := make(map[string]string)
+	for node, hash := range blankNodeNeighbourhoodHashes(triples) {
+		labels[node] = "b" + hash[:16]
+	}
+
+	statements := make(map[string]struct{}, len(triples))
+	for _, t := range triples {
+		t.object = canonicalLiteral(t.object)
+		statements[t.serialize(labels)] = struct{}{}
+	}
+	return statements, nil
+}
+
+// canonicalLiteral rewrites a literal in the canonical lexical form of its datatype, so
+// literals with the same value compare equal: "01"^^xsd:integer and 1 are the same integer,
+// and language tags are case-insensitive. Values that are not valid for their datatype are
+// left as written.
+func canonicalLiteral(term canonicalTerm) canonicalTerm {
+	if term.kind != "literal" {
+		return term
+	}
+	if term.language != "" {
+		term.language = strings.ToLower(term.language)
+		return term
+	}
+
+	value := strings.TrimSpace(term.value)
+	switch term.datatype {
+	case xsdNamespace + "integer":
+		if n, ok := new(big.Int).SetString(value, 10); ok {
+			term.value = n.String()
+		}
+	case xsdNamespace + "decimal":
+		if r, ok := new(big.Rat).SetString(value); ok && !strings.ContainsAny(value, "eE") {
+			term.value = canonicalDecimal(r)
+		}
+	case xsdNamespace + "double", xsdNamespace + "float":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			term.value = strconv.FormatFloat(f, 'E', -1, 64)
+		}
+	case xsdNamespace + "boolean":
+		switch value {
+		case "true", "1":
+			term.value = "true"
+		case "false", "0":
+			term.value = "false"
+		}
+	}
+	return term
+}
+
+// canonicalDecimal writes a decimal with no leading or trailing zeros beyond the one digit
+// kept on each side of the point, as in "1.0" and "0.5"
+func canonicalDecimal(r *big.Rat) string {
+	// A terminating decimal's denominator only has factors of 2 and 5, so this many
+	// fractional digits is always exact
+	digits := 0
+	for denominator := new(big.Int).Set(r.Denom()); denominator.Cmp(big.NewInt(1)) != 0; digits++ {
+		if new(big.Int).Mod(denominator, big.NewInt(10)).Sign() == 0 {
+			denominator.Div(denominator, big.NewInt(10))
+		} else if new(big.Int).Mod(denominator, big.NewInt(2)).Sign() == 0 {
+			denominator.Div(denominator, big.NewInt(2))
+		} else {
+			denominator.Div(denominator, big.NewInt(5))
+		}
+	}
+	if digits == 0 {
+		return r.Num().String() + ".0"
+	}
+	return r.FloatString(digits)
+}
@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDFGraphDiffer_DiffGraphs(t *testing.T) {
+	differ := NewRDFGraphDiffer()
+
+	t.Run("reports added and removed triples", func(t *testing.T) {
+		from := []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" ; <http://xmlns.com/foaf/0.1/nick> "Al" .`)
+		to := []byte(`<#me> <http://xmlns.com/foaf/0.1/name> "Alice" ; <http://xmlns.com/foaf/0.1/nick> "Ally" .`)
+
+		diff, err := differ.DiffGraphs(from, to)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`<#me> <http://xmlns.com/foaf/0.1/nick> "Ally" .`}, diff.Added)
+		assert.Equal(t, []string{`<#me> <http://xmlns.com/foaf/0.1/nick> "Al" .`}, diff.Removed)
+	})
+
+	t.Run("treats equivalent literals as equal", func(t *testing.T) {
+		from := []byte(`@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<#me> <#age> "042"^^xsd:integer ; <#height> 1.50 ; <#active> "1"^^xsd:boolean ; <#bio> "hi"@EN .`)
+		to := []byte(`<#me> <#age> 42 ; <#height> 1.5 ; <#active> true ; <#bio> "hi"@en .`)
+
+		diff, err := differ.DiffGraphs(from, to)
+		require.NoError(t, err)
+		assert.True(t, diff.IsEmpty(), "unexpected diff: %+v", diff)
+	})
+
+	t.Run("ignores blank node labels", func(t *testing.T) {
+		from := []byte(`<#me> <#address> _:a . _:a <#city> "Paris" . <#me> <#name> "Alice" .`)
+		to := []byte(`<#me> <#name> "Alice" . <#me> <#address> _:home . _:home <#city> "Paris" .`)
+
+		diff, err := differ.DiffGraphs(from, to)
+		require.NoError(t, err)
+		assert.True(t, diff.IsEmpty(), "unexpected diff: %+v", diff)
+	})
+
+	t.Run("keeps unchanged blank nodes out of the diff", func(t *testing.T) {
+		from := []byte(`<#me> <#address> _:a . _:a <#city> "Paris" .`)
+		to := []byte(`<#me> <#address> _:b . _:b <#city> "Paris" . <#me> <#name> "Alice" .`)
+
+		diff, err := differ.DiffGraphs(from, to)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`<#me> <#name> "Alice" .`}, diff.Added)
+		assert.Empty(t, diff.Removed)
+	})
+
+	t.Run("rejects documents that cannot be parsed", func(t *testing.T) {
+		_, err := differ.DiffGraphs([]byte(`<#me> <#name> .`), []byte(``))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,570 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// rdfNamespace is the namespace of the RDF vocabulary
+const rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// RDFTripleCounter counts the statements of RDF documents as they are read, so an oversized
+// graph is refused before it is parsed into memory. Statements are counted as written rather
+// than parsed: Turtle and N-Triples by their objects, JSON-LD by its property values and
+// types, RDF/XML by its property elements, property attributes and typed nodes.
+type RDFTripleCounter struct{}
+
+// NewRDFTripleCounter creates a new RDF triple counter
+func NewRDFTripleCounter() *RDFTripleCounter {
+	return &RDFTripleCounter{}
+}
+
+// CountTriples counts the statements of a document, failing with ErrGraphTooLarge as soon as
+// there are more than maxTriples; a maxTriples of zero counts without a limit
+func (c *RDFTripleCounter) CountTriples(r io.Reader, format string, maxTriples int) (int, error) {
+	budget := &tripleBudget{max: maxTriples}
+
+	var err error
+	switch format {
+	case "text/turtle", "application/n-triples", "text/plain":
+		err = (&turtleCounter{r: bufio.NewReader(r), budget: budget}).count()
+	case "application/ld+json", "application/json":
+		err = countJSONLDValue(json.NewDecoder(r), budget)
+	case "application/rdf+xml":
+		err = countRDFXML(xml.NewDecoder(r), budget)
+	default:
+		return 0, domain.ErrUnsupportedFormat.WithOperation("CountTriples").WithContext("format", format)
+	}
+
+	return budget.count, err
+}
+
+// tripleBudget counts statements against a limit
+type tripleBudget struct {
+	count int
+	max   int
+}
+
+// add counts n statements, failing once the limit is passed
+func (b *tripleBudget) add(n int) error {
+	b.count += n
+	if b.max > 0 && b.count > b.max {
+		return domain.WrapStorageError(
+			fmt.Errorf("document holds more than %d triples", b.max),
+			domain.ErrGraphTooLarge.Code,
+			domain.ErrGraphTooLarge.Message,
+		).WithOperation("CountTriples").WithContext("maxTriples", b.max)
+	}
+	return nil
+}
+
+// turtleCounter counts the statements of a Turtle or N-Triples document one rune at a time. A
+// statement is counted at the ',', ';', '.' or ']' closing its object; collection members
+// count as the two statements of their list node.
+type turtleCounter struct {
+	r      *bufio.Reader
+	budget *tripleBudget
+
+	// sawObject is set once a term has been read since the last separator
+	sawObject bool
+	// atStart is set at the beginning of a statement, where directives may appear
+	atStart bool
+	// inDirective is set while reading an @prefix or @base directive
+	inDirective bool
+	// skipTerms is how many terms of a PREFIX or BASE directive remain to be read
+	skipTerms int
+	// collectionDepth is how many collections the reader is inside
+	collectionDepth int
+	// pendingDot is set when a name was ended by the '.' closing its statement
+	pendingDot bool
+}
+
+// count reads the whole document
+func (c *turtleCounter) count() error {
+	c.atStart = true
+	for {
+		ch, _, err := c.r.ReadRune()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case unicode.IsSpace(ch):
+		case ch == '#':
+			if _, err := c.r.ReadString('\n'); err != nil && err != io.EOF {
+				return err
+			}
+		case ch == '<':
+			if err := c.skipIRI(); err != nil {
+				return err
+			}
+			if err := c.term(); err != nil {
+				return err
+			}
+		case ch == '"' || ch == '\'':
+			if err := c.skipLiteral(ch); err != nil {
+				return err
+			}
+			if err := c.term(); err != nil {
+				return err
+			}
+		case ch == '[':
+			c.sawObject = false
+			c.atStart = false
+		case ch == ']':
+			if err := c.endObject(); err != nil {
+				return err
+			}
+			c.sawObject = true
+		case ch == '(':
+			c.collectionDepth++
+			c.atStart = false
+		case ch == ')':
+			c.collectionDepth--
+			c.sawObject = true
+		case ch == ',' || ch == ';':
+			if err := c.endObject(); err != nil {
+				return err
+			}
+		case ch == '.':
+			if err := c.endStatement(); err != nil {
+				return err
+			}
+		default:
+			word, err := c.readName(ch)
+			if err != nil {
+				return err
+			}
+			if err := c.name(word); err != nil {
+				return err
+			}
+		}
+
+		if c.pendingDot {
+			c.pendingDot = false
+			if err := c.endStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// term records an IRI, literal or name read in a statement
+func (c *turtleCounter) term() error {
+	if c.inDirective {
+		return nil
+	}
+	if c.skipTerms > 0 {
+		c.skipTerms--
+		c.atStart = c.skipTerms == 0
+		return nil
+	}
+	c.atStart = false
+	if c.collectionDepth > 0 {
+		return c.budget.add(2)
+	}
+	c.sawObject = true
+	return nil
+}
+
+// name records a prefixed name, keyword or number, recognizing directives at the start of a
+// statement
+func (c *turtleCounter) name(word string) error {
+	if c.atStart && !c.inDirective && c.skipTerms == 0 {
+		switch strings.ToLower(word) {
+		case "@prefix", "@base":
+			c.inDirective = true
+			return nil
+		case "prefix":
+			c.skipTerms = 2
+			return nil
+		case "base":
+			c.skipTerms = 1
+			return nil
+		}
+	}
+	return c.term()
+}
+
+// endObject counts the statement whose object was just read
+func (c *turtleCounter) endObject() error {
+	if c.inDirective {
+		return nil
+	}
+	counted := c.sawObject
+	c.sawObject = false
+	if counted {
+		return c.budget.add(1)
+	}
+	return nil
+}
+
+// endStatement handles the '.' closing a statement or @-directive
+func (c *turtleCounter) endStatement() error {
+	if c.inDirective {
+		c.inDirective = false
+		c.atStart = true
+		return nil
+	}
+	c.atStart = true
+	return c.endObject()
+}
+
+// skipIRI reads up to the '>' closing an IRI
+func (c *turtleCounter) skipIRI() error {
+	for {
+		ch, _, err := c.r.ReadRune()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if ch == '>' {
+			return nil
+		}
+	}
+}
+
+// skipLiteral reads a short or long string literal opened by quote, with its language tag or
+// datatype
+func (c *turtleCounter) skipLiteral(quote rune) error {
+	long := false
+	next, _, err := c.r.ReadRune()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if next == quote {
+		third, _, err := c.r.ReadRune()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err == nil && third == quote {
+			long = true
+		} else {
+			// An empty string
+			if err == nil {
+				c.r.UnreadRune()
+			}
+			return c.skipLiteralSuffix()
+		}
+	} else {
+		c.r.UnreadRune()
+	}
+
+	closing := 0
+	for {
+		ch, _, err := c.r.ReadRune()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		switch {
+		case ch == '\\':
+			if _, _, err := c.r.ReadRune(); err != nil {
+				return unexpectedEOF(err)
+			}
+			closing = 0
+		case ch == quote:
+			closing++
+			if !long || closing == 3 {
+				return c.skipLiteralSuffix()
+			}
+		default:
+			closing = 0
+		}
+	}
+}
+
+// skipLiteralSuffix reads the language tag or datatype following a literal, if any
+func (c *turtleCounter) skipLiteralSuffix() error {
+	ch, _, err := c.r.ReadRune()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch ch {
+	case '@':
+		_, err := c.readName(ch)
+		return err
+	case '^':
+		if next, _, err := c.r.ReadRune(); err != nil || next != '^' {
+			return fmt.Errorf("expected '^^' before a literal's datatype")
+		}
+		datatype, _, err := c.r.ReadRune()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if datatype == '<' {
+			return c.skipIRI()
+		}
+		_, err = c.readName(datatype)
+		return err
+	default:
+		c.r.UnreadRune()
+		return nil
+	}
+}
+
+// readName reads a prefixed name, blank node label, keyword or number starting with first. A
+// '.' belongs to the name only when more of the name follows it; otherwise it closes the
+// statement and pendingDot is set.
+func (c *turtleCounter) readName(first rune) (string, error) {
+	var name strings.Builder
+	name.WriteRune(first)
+	for {
+		ch, _, err := c.r.ReadRune()
+		if err == io.EOF {
+			return name.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if ch == '.' {
+			next, _, err := c.r.ReadRune()
+			if err == nil && isTurtleNameRune(next) {
+				name.WriteRune(ch)
+				name.WriteRune(next)
+				continue
+			}
+			if err == nil {
+				c.r.UnreadRune()
+			}
+			c.pendingDot = true
+			return name.String(), nil
+		}
+		if !isTurtleNameRune(ch) {
+			c.r.UnreadRune()
+			return name.String(), nil
+		}
+		name.WriteRune(ch)
+	}
+}
+
+// isTurtleNameRune reports whether a rune can continue a name
+func isTurtleNameRune(ch rune) bool {
+	if unicode.IsSpace(ch) {
+		return false
+	}
+	return !strings.ContainsRune(`<>"'()[],;#.`, ch)
+}
+
+// unexpectedEOF reports a document ending inside a term
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// countJSONLDValue counts the statements of the next JSON-LD value
+func countJSONLDValue(dec *json.Decoder, budget *tripleBudget) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return countJSONLDToken(dec, tok, budget)
+}
+
+// countJSONLDToken counts the statements of a JSON-LD value whose first token was read
+func countJSONLDToken(dec *json.Decoder, tok json.Token, budget *tripleBudget) error {
+	switch tok {
+	case json.Delim('{'):
+		return countJSONLDObject(dec, budget)
+	case json.Delim('['):
+		for dec.More() {
+			if err := countJSONLDValue(dec, budget); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	}
+	return nil
+}
+
+// countJSONLDObject counts the statements of a node object whose '{' was read: one for each
+// value of each property and each @type, plus those of nested nodes. Contexts are skipped.
+func countJSONLDObject(dec *json.Decoder, budget *tripleBudget) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+
+		switch {
+		case key == "@context":
+			var context json.RawMessage
+			if err := dec.Decode(&context); err != nil {
+				return err
+			}
+		case key == "@type":
+			if err := countJSONLDTypes(dec, budget); err != nil {
+				return err
+			}
+		case strings.HasPrefix(key, "@"):
+			if err := countJSONLDValue(dec, budget); err != nil {
+				return err
+			}
+		default:
+			if err := countJSONLDProperty(dec, budget); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// countJSONLDTypes counts the types of a node
+func countJSONLDTypes(dec *json.Decoder, budget *tripleBudget) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return budget.add(1)
+	}
+	for dec.More() {
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		if err := budget.add(1); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// countJSONLDProperty counts the values of a property
+func countJSONLDProperty(dec *json.Decoder, budget *tripleBudget) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		if err := budget.add(1); err != nil {
+			return err
+		}
+		return countJSONLDToken(dec, tok, budget)
+	}
+	for dec.More() {
+		if err := budget.add(1); err != nil {
+			return err
+		}
+		if err := countJSONLDValue(dec, budget); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// rdfXMLRole is the part an element plays in RDF/XML's striped syntax
+type rdfXMLRole int
+
+const (
+	rdfXMLRoot rdfXMLRole = iota
+	rdfXMLNode
+	rdfXMLProperty
+	rdfXMLLiteral
+)
+
+// countRDFXML counts the statements of an RDF/XML document: one for each property element,
+// each property attribute and each typed node element
+func countRDFXML(dec *xml.Decoder, budget *tripleBudget) error {
+	var roles []rdfXMLRole
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch element := tok.(type) {
+		case xml.StartElement:
+			role := rdfXMLNode
+			if len(roles) == 0 {
+				if element.Name.Space == rdfNamespace && element.Name.Local == "RDF" {
+					role = rdfXMLRoot
+				}
+			} else {
+				switch roles[len(roles)-1] {
+				case rdfXMLNode:
+					role = rdfXMLProperty
+				case rdfXMLLiteral:
+					role = rdfXMLLiteral
+				}
+			}
+
+			statements := 0
+			switch role {
+			case rdfXMLNode:
+				if element.Name.Space != rdfNamespace || element.Name.Local != "Description" {
+					statements++
+				}
+				statements += rdfXMLPropertyAttributes(element)
+			case rdfXMLProperty:
+				statements++
+				switch rdfXMLAttribute(element, "parseType") {
+				case "Literal":
+					role = rdfXMLLiteral
+				case "Resource":
+					// The property's children describe a new blank node
+					role = rdfXMLNode
+				}
+				statements += rdfXMLPropertyAttributes(element)
+			}
+			if err := budget.add(statements); err != nil {
+				return err
+			}
+			roles = append(roles, role)
+		case xml.EndElement:
+			if len(roles) > 0 {
+				roles = roles[:len(roles)-1]
+			}
+		}
+	}
+}
+
+// rdfXMLPropertyAttributes counts the attributes of an element that state properties
+func rdfXMLPropertyAttributes(element xml.StartElement) int {
+	count := 0
+	for _, attr := range element.Attr {
+		switch {
+		case attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns"):
+		case attr.Name.Space == "xml" || attr.Name.Space == "http://www.w3.org/XML/1998/namespace":
+		case attr.Name.Space == rdfNamespace:
+			if attr.Name.Local == "type" {
+				count++
+			}
+		default:
+			count++
+		}
+	}
+	return count
+}
+
+// rdfXMLAttribute returns the value of an attribute in the RDF namespace
+func rdfXMLAttribute(element xml.StartElement, local string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Space == rdfNamespace && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDFTripleCounter_CountsStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		document string
+		expected int
+	}{
+		{
+			name:   "turtle with prefixes, lists and literals",
+			format: "text/turtle",
+			document: `@prefix ex: <http://example.org/> .
+PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+# a comment; with, separators.
+ex:alice a foaf:Person ;
+    foaf:name "Alice" , "Alicia"@es ;
+    ex:age "30"^^<http://www.w3.org/2001/XMLSchema#integer> ;
+    ex:score 1.5 ;
+    ex:bio """Line one.
+Line "two"; still, the same.""" ;
+    ex:knows [ foaf:name "Bob" ; ex:age 40 ] .
+ex:bob ex:version ex:v1.2.`,
+			expected: 10,
+		},
+		{
+			name:     "turtle collection",
+			format:   "text/turtle",
+			document: `<http://example.org/s> <http://example.org/p> ( "a" "b" ) .`,
+			expected: 5,
+		},
+		{
+			name:   "n-triples",
+			format: "application/n-triples",
+			document: "<http://example.org/s> <http://example.org/p> \"o\" .\n" +
+				"<http://example.org/s> <http://example.org/q> <http://example.org/o> .\n",
+			expected: 2,
+		},
+		{
+			name:   "json-ld",
+			format: "application/ld+json",
+			document: `{
+  "@context": {"name": "http://xmlns.com/foaf/0.1/name", "knows": {"@id": "http://xmlns.com/foaf/0.1/knows", "@type": "@id"}},
+  "@id": "http://example.org/alice",
+  "@type": ["http://xmlns.com/foaf/0.1/Person", "http://example.org/Author"],
+  "name": ["Alice", "Alicia"],
+  "knows": {"@id": "http://example.org/bob", "name": "Bob"}
+}`,
+			expected: 6,
+		},
+		{
+			name:   "rdf/xml",
+			format: "application/rdf+xml",
+			document: `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:foaf="http://xmlns.com/foaf/0.1/">
+  <foaf:Person rdf:about="http://example.org/alice" foaf:nick="ali">
+    <foaf:name xml:lang="en">Alice</foaf:name>
+    <foaf:knows>
+      <rdf:Description rdf:about="http://example.org/bob">
+        <foaf:name>Bob</foaf:name>
+      </rdf:Description>
+    </foaf:knows>
+    <foaf:homepage rdf:resource="http://example.org/"/>
+  </foaf:Person>
+</rdf:RDF>`,
+			expected: 6,
+		},
+	}
+
+	counter := NewRDFTripleCounter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := counter.CountTriples(strings.NewReader(tt.document), tt.format, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, count)
+		})
+	}
+}
+
+func TestRDFTripleCounter_StopsAtLimit(t *testing.T) {
+	var document strings.Builder
+	for i := 0; i < 1000; i++ {
+		document.WriteString("<http://example.org/s> <http://example.org/p> \"value\" .\n")
+	}
+	reader := strings.NewReader(document.String())
+
+	count, err := NewRDFTripleCounter().CountTriples(reader, "text/turtle", 10)
+	require.Error(t, err)
+	assert.True(t, domain.IsGraphTooLarge(err))
+	assert.Equal(t, 11, count)
+	// The rest of the document was left unread
+	assert.Greater(t, reader.Len(), 0)
+}
+
+func TestRDFTripleCounter_UnsupportedFormat(t *testing.T) {
+	_, err := NewRDFTripleCounter().CountTriples(strings.NewReader("data"), "image/png", 10)
+	assert.True(t, domain.IsUnsupportedFormat(err))
+}
@@ -5,12 +5,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
 )
 
 // CacheEntry represents a cached resource with metadata
 type CacheEntry struct {
-	Resource *domain.Resource
+	Resource domain.Resource
 	AccessAt time.Time
 	HitCount int64
 	Size     int
@@ -60,12 +61,13 @@ func NewResourceCache(config CacheConfig) *ResourceCache {
 }
 
 // Get retrieves a resource from the cache
-func (rc *ResourceCache) Get(ctx context.Context, id string) (*domain.Resource, bool) {
+func (rc *ResourceCache) Get(ctx context.Context, id string) (domain.Resource, bool) {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
 
 	entry, exists := rc.entries[id]
 	if !exists {
+		metrics.RecordCacheLookup(false)
 		return nil, false
 	}
 
@@ -73,18 +75,20 @@ func (rc *ResourceCache) Get(ctx context.Context, id string) (*domain.Resource,
 	if time.Since(entry.AccessAt) > rc.ttl {
 		delete(rc.entries, id)
 		rc.currentSize -= int64(entry.Size)
+		metrics.RecordCacheLookup(false)
 		return nil, false
 	}
 
 	// Update access time and hit count
 	entry.AccessAt = time.Now()
 	entry.HitCount++
+	metrics.RecordCacheLookup(true)
 
 	return entry.Resource, true
 }
 
 // Put stores a resource in the cache
-func (rc *ResourceCache) Put(ctx context.Context, resource *domain.Resource) {
+func (rc *ResourceCache) Put(ctx context.Context, resource domain.Resource) {
 	if resource == nil {
 		return
 	}
@@ -139,6 +143,33 @@ func (rc *ResourceCache) Clear(ctx context.Context) {
 	rc.currentSize = 0
 }
 
+// InvalidateCached drops the cached resources with the given IDs and reports how many were cached
+func (rc *ResourceCache) InvalidateCached(ids ...string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	dropped := 0
+	for _, id := range ids {
+		if entry, exists := rc.entries[id]; exists {
+			delete(rc.entries, id)
+			rc.currentSize -= int64(entry.Size)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// InvalidateAllCached clears the cache and reports how many resources it held
+func (rc *ResourceCache) InvalidateAllCached() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	dropped := len(rc.entries)
+	rc.entries = make(map[string]*CacheEntry)
+	rc.currentSize = 0
+	return dropped
+}
+
 // GetStats returns cache statistics
 func (rc *ResourceCache) GetStats() map[string]interface{} {
 	rc.mu.RLock()
@@ -256,7 +287,7 @@ func (rc *ResourceCache) cleanupExpired() {
 }
 
 // Warmup preloads frequently accessed resources into the cache
-func (rc *ResourceCache) Warmup(ctx context.Context, resources []*domain.Resource) {
+func (rc *ResourceCache) Warmup(ctx context.Context, resources []domain.Resource) {
 	for _, resource := range resources {
 		if resource != nil {
 			rc.Put(ctx, resource)
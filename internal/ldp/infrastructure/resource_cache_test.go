@@ -20,7 +20,7 @@ func TestResourceCache(t *testing.T) {
 
 	// Test basic put and get
 	t.Run("PutAndGet", func(t *testing.T) {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			"cache-test-1",
 			"text/turtle",
 			[]byte("@prefix ex: <http://example.org/> .\nex:test ex:name \"Test\" ."),
@@ -61,14 +61,14 @@ func TestResourceCache(t *testing.T) {
 		smallCache := NewResourceCache(smallConfig)
 
 		// Add resources that exceed cache size
-		resources := make([]*domain.Resource, 5)
+		resources := make([]domain.Resource, 5)
 		for i := 0; i < 5; i++ {
 			data := make([]byte, 300) // 300 bytes each
 			for j := range data {
 				data[j] = byte('A' + (i % 26))
 			}
 
-			resources[i] = domain.NewResource(
+			resources[i] = domain.NewResource(context.Background(),
 				fmt.Sprintf("size-test-%d", i),
 				"application/octet-stream",
 				data,
@@ -104,7 +104,7 @@ func TestResourceCache(t *testing.T) {
 
 		// Add more resources than max entries
 		for i := 0; i < 5; i++ {
-			resource := domain.NewResource(
+			resource := domain.NewResource(context.Background(),
 				fmt.Sprintf("entry-test-%d", i),
 				"text/turtle",
 				[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:test%d ex:name \"Test%d\" .", i, i)),
@@ -131,7 +131,7 @@ func TestResourceCache(t *testing.T) {
 		}
 		ttlCache := NewResourceCache(ttlConfig)
 
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			"ttl-test",
 			"text/turtle",
 			[]byte("@prefix ex: <http://example.org/> .\nex:ttl ex:name \"TTL Test\" ."),
@@ -158,7 +158,7 @@ func TestResourceCache(t *testing.T) {
 
 	// Test remove operation
 	t.Run("Remove", func(t *testing.T) {
-		resource := domain.NewResource(
+		resource := domain.NewResource(context.Background(),
 			"remove-test",
 			"text/turtle",
 			[]byte("@prefix ex: <http://example.org/> .\nex:remove ex:name \"Remove Test\" ."),
@@ -186,7 +186,7 @@ func TestResourceCache(t *testing.T) {
 	t.Run("Clear", func(t *testing.T) {
 		// Add multiple resources
 		for i := 0; i < 3; i++ {
-			resource := domain.NewResource(
+			resource := domain.NewResource(context.Background(),
 				fmt.Sprintf("clear-test-%d", i),
 				"text/turtle",
 				[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:clear%d ex:name \"Clear Test %d\" .", i, i)),
@@ -227,7 +227,7 @@ func TestResourceCache(t *testing.T) {
 
 		// Add some resources
 		for i := 0; i < 5; i++ {
-			resource := domain.NewResource(
+			resource := domain.NewResource(context.Background(),
 				fmt.Sprintf("stats-test-%d", i),
 				"text/turtle",
 				[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:stats%d ex:name \"Stats Test %d\" .", i, i)),
@@ -287,7 +287,7 @@ func TestResourceCache(t *testing.T) {
 			largeData[i] = byte('X')
 		}
 
-		largeResource := domain.NewResource(
+		largeResource := domain.NewResource(context.Background(),
 			"large-resource",
 			"application/octet-stream",
 			largeData,
@@ -329,7 +329,7 @@ func TestResourceCacheConcurrency(t *testing.T) {
 
 			for j := 0; j < operationsPerGoroutine; j++ {
 				resourceID := fmt.Sprintf("concurrent-resource-%d-%d", goroutineID, j)
-				resource := domain.NewResource(
+				resource := domain.NewResource(context.Background(),
 					resourceID,
 					"text/turtle",
 					[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:resource%d%d ex:name \"Resource %d-%d\" .", goroutineID, j, goroutineID, j)),
@@ -386,9 +386,9 @@ func TestResourceCacheWarmup(t *testing.T) {
 	ctx := context.Background()
 
 	// Create resources for warmup
-	resources := make([]*domain.Resource, 10)
+	resources := make([]domain.Resource, 10)
 	for i := 0; i < 10; i++ {
-		resources[i] = domain.NewResource(
+		resources[i] = domain.NewResource(context.Background(),
 			fmt.Sprintf("warmup-resource-%d", i),
 			"text/turtle",
 			[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:warmup%d ex:name \"Warmup %d\" .", i, i)),
@@ -83,7 +83,7 @@ func TestResourceIndexer(t *testing.T) {
 
 	// Test finding by tag
 	t.Run("FindByTag", func(t *testing.T) {
-		resource4 := domain.NewResource(
+		resource4 := domain.NewResource(context.Background(),
 			"test-resource-4",
 			"text/turtle",
 			[]byte("@prefix ex: <http://example.org/> .\nex:test3 ex:name \"Test3\" ."),
@@ -167,7 +167,7 @@ func TestResourceIndexerPersistence(t *testing.T) {
 		t.Fatalf("Failed to create first indexer: %v", err)
 	}
 
-	resource := domain.NewResource(
+	resource := domain.NewResource(context.Background(),
 		"persistent-resource",
 		"text/turtle",
 		[]byte("@prefix ex: <http://example.org/> .\nex:persistent ex:name \"Persistent\" ."),
@@ -206,7 +206,7 @@ func TestResourceIndexerRebuild(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	resources := []*domain.Resource{
+	resources := []domain.Resource{
 		domain.NewResource(context.Background(), "rebuild-1", "text/turtle", []byte("@prefix ex: <http://example.org/> .\nex:rebuild1 ex:name \"Rebuild1\" .")),
 		domain.NewResource(context.Background(), "rebuild-2", "application/ld+json", []byte(`{"@context": "http://example.org/", "@id": "rebuild2", "name": "Rebuild2"}`)),
 		domain.NewResource(context.Background(), "rebuild-3", "text/turtle", []byte("@prefix ex: <http://example.org/> .\nex:rebuild3 ex:name \"Rebuild3\" .")),
@@ -269,7 +269,7 @@ func TestResourceIndexerConcurrency(t *testing.T) {
 
 			for j := 0; j < resourcesPerGoroutine; j++ {
 				resourceID := fmt.Sprintf("concurrent-resource-%d-%d", goroutineID, j)
-				resource := domain.NewResource(
+				resource := domain.NewResource(context.Background(),
 					resourceID,
 					"text/turtle",
 					[]byte(fmt.Sprintf("@prefix ex: <http://example.org/> .\nex:resource%d%d ex:name \"Resource %d-%d\" .", goroutineID, j, goroutineID, j)),
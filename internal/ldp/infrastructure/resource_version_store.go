@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"gorm.io/gorm"
+)
+
+// ResourceVersionModel is one stored version of a resource's content
+type ResourceVersionModel struct {
+	ResourceID  string    `gorm:"primaryKey;type:varchar(255)"`
+	Version     int       `gorm:"primaryKey;autoIncrement:false"`
+	ContentType string    `gorm:"not null;type:varchar(255)"`
+	Data        []byte    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for ResourceVersionModel
+func (ResourceVersionModel) TableName() string {
+	return "resource_versions"
+}
+
+// GormResourceVersionStore keeps the versions of resources in a table, numbered v1, v2, ... in
+// the order they were written. Only the latest maxVersions of each resource are kept; older
+// ones are dropped as new ones are recorded, and keep their numbers, so a dropped version is
+// reported as not found rather than renamed.
+type GormResourceVersionStore struct {
+	db          *gorm.DB
+	maxVersions int
+}
+
+// NewGormResourceVersionStore creates a version store keeping up to maxVersions versions of
+// each resource, creating its table if needed; maxVersions of 0 or less keeps every version
+func NewGormResourceVersionStore(db *gorm.DB, maxVersions int) (*GormResourceVersionStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	if err := db.AutoMigrate(&ResourceVersionModel{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate resource version table: %w", err)
+	}
+	return &GormResourceVersionStore{db: db, maxVersions: maxVersions}, nil
+}
+
+// RecordResourceVersion stores a resource's content as the version after its latest one
+func (s *GormResourceVersionStore) RecordResourceVersion(ctx context.Context, resource domain.Resource) (string, error) {
+	var number int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest *int
+		if err := tx.Model(&ResourceVersionModel{}).Where("resource_id = ?", resource.ID()).
+			Select("MAX(version)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		number = 1
+		if latest != nil {
+			number = *latest + 1
+		}
+
+		if err := tx.Create(&ResourceVersionModel{
+			ResourceID:  resource.ID(),
+			Version:     number,
+			ContentType: resource.GetContentType(),
+			Data:        resource.GetData(),
+			CreatedAt:   time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		if s.maxVersions > 0 && number > s.maxVersions {
+			return tx.Where("resource_id = ? AND version <= ?", resource.ID(), number-s.maxVersions).
+				Delete(&ResourceVersionModel{}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return "", domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to record resource version").
+			WithOperation("RecordResourceVersion").WithContext("resourceID", resource.ID())
+	}
+	return versionName(number), nil
+}
+
+// RetrieveResourceVersion returns a resource with the content it had at a version
+func (s *GormResourceVersionStore) RetrieveResourceVersion(ctx context.Context, id, version string) (domain.Resource, error) {
+	number, ok := parseVersionName(version)
+	if !ok {
+		return nil, domain.ErrResourceVersionNotFound.WithOperation("RetrieveResourceVersion").
+			WithContext("resourceID", id).WithContext("version", version)
+	}
+
+	var stored ResourceVersionModel
+	result := s.db.WithContext(ctx).Where("resource_id = ? AND version = ?", id, number).Limit(1).Find(&stored)
+	if result.Error != nil {
+		return nil, domain.WrapStorageError(result.Error, domain.ErrStorageOperation.Code, "failed to read resource version").
+			WithOperation("RetrieveResourceVersion").WithContext("resourceID", id).WithContext("version", version)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrResourceVersionNotFound.WithOperation("RetrieveResourceVersion").
+			WithContext("resourceID", id).WithContext("version", version)
+	}
+
+	resource := domain.NewResource(ctx, id, stored.ContentType, stored.Data)
+	resource.SetMetadata(domain.InteractionModelKey, domain.InteractionModelForContentType(stored.ContentType).String())
+	return resource, nil
+}
+
+// DeleteResourceVersions removes every stored version of a resource
+func (s *GormResourceVersionStore) DeleteResourceVersions(ctx context.Context, id string) error {
+	if err := s.db.WithContext(ctx).Where("resource_id = ?", id).Delete(&ResourceVersionModel{}).Error; err != nil {
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to delete resource versions").
+			WithOperation("DeleteResourceVersions").WithContext("resourceID", id)
+	}
+	return nil
+}
+
+// versionName returns the name clients use for a version number
+func versionName(number int) string {
+	return "v" + strconv.Itoa(number)
+}
+
+// parseVersionName returns the number of a version named v1, v2, ...
+func parseVersionName(version string) (int, bool) {
+	digits, ok := strings.CutPrefix(version, "v")
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.Atoi(digits)
+	if err != nil || number < 1 {
+		return 0, false
+	}
+	return number, true
+}
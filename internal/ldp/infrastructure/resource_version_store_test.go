@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormResourceVersionStore(t *testing.T) {
+	ctx := context.Background()
+	newStore := func(t *testing.T, maxVersions int) *GormResourceVersionStore {
+		db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
+		require.NoError(t, err)
+		store, err := NewGormResourceVersionStore(db, maxVersions)
+		require.NoError(t, err)
+		return store
+	}
+	record := func(t *testing.T, store *GormResourceVersionStore, id, data string) string {
+		version, err := store.RecordResourceVersion(ctx, domain.NewResource(ctx, id, "text/turtle", []byte(data)))
+		require.NoError(t, err)
+		return version
+	}
+
+	t.Run("numbers versions per resource and retrieves their content", func(t *testing.T) {
+		store := newStore(t, 0)
+
+		assert.Equal(t, "v1", record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`))
+		assert.Equal(t, "v2", record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "Alicia" .`))
+		assert.Equal(t, "v1", record(t, store, "notes", `<#n> <http://purl.org/dc/terms/title> "Notes" .`))
+
+		resource, err := store.RetrieveResourceVersion(ctx, "card", "v1")
+		require.NoError(t, err)
+		assert.Equal(t, "card", resource.ID())
+		assert.Equal(t, `<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`, string(resource.GetData()))
+		assert.Equal(t, "text/turtle", resource.GetContentType())
+		assert.Equal(t, domain.RDFSourceModel.String(), resource.GetMetadata()[domain.InteractionModelKey])
+	})
+
+	t.Run("reports unknown versions as not found", func(t *testing.T) {
+		store := newStore(t, 0)
+		record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)
+
+		for _, version := range []string{"v2", "1", "v0", "latest"} {
+			_, err := store.RetrieveResourceVersion(ctx, "card", version)
+			assert.True(t, domain.IsResourceVersionNotFound(err), version)
+		}
+	})
+
+	t.Run("drops the oldest versions beyond the limit", func(t *testing.T) {
+		store := newStore(t, 2)
+		record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "A" .`)
+		record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "B" .`)
+		assert.Equal(t, "v3", record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "C" .`))
+
+		_, err := store.RetrieveResourceVersion(ctx, "card", "v1")
+		assert.True(t, domain.IsResourceVersionNotFound(err))
+		_, err = store.RetrieveResourceVersion(ctx, "card", "v2")
+		assert.NoError(t, err)
+		_, err = store.RetrieveResourceVersion(ctx, "card", "v3")
+		assert.NoError(t, err)
+	})
+
+	t.Run("deletes every version of a resource", func(t *testing.T) {
+		store := newStore(t, 0)
+		record(t, store, "card", `<#me> <http://xmlns.com/foaf/0.1/name> "Alice" .`)
+		record(t, store, "notes", `<#n> <http://purl.org/dc/terms/title> "Notes" .`)
+
+		require.NoError(t, store.DeleteResourceVersions(ctx, "card"))
+
+		_, err := store.RetrieveResourceVersion(ctx, "card", "v1")
+		assert.True(t, domain.IsResourceVersionNotFound(err))
+		_, err = store.RetrieveResourceVersion(ctx, "notes", "v1")
+		assert.NoError(t, err)
+	})
+}
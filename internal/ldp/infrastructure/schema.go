@@ -6,8 +6,15 @@ import (
 	"time"
 )
 
+// SchemaExecutor runs schema statements, on a database or inside the transaction applying a
+// migration
+type SchemaExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 // createSQLiteContainerSchema creates the container and membership tables with indexes for SQLite
-func createSQLiteContainerSchema(db *sql.DB) error {
+func createSQLiteContainerSchema(db SchemaExecutor) error {
 	// Create containers table
 	containersSQL := `
 	CREATE TABLE IF NOT EXISTS containers (
@@ -56,8 +63,41 @@ func createSQLiteContainerSchema(db *sql.DB) error {
 	return nil
 }
 
+// createSQLiteResourceAccessSchema creates the table recording when resources were last read for SQLite
+func createSQLiteResourceAccessSchema(db SchemaExecutor) error {
+	accessSQL := `
+	CREATE TABLE IF NOT EXISTS resource_access (
+		resource_id TEXT PRIMARY KEY,
+		last_accessed_at TIMESTAMP NOT NULL
+	);`
+
+	if _, err := db.Exec(accessSQL); err != nil {
+		return fmt.Errorf("failed to create resource_access table: %w", err)
+	}
+
+	return nil
+}
+
+// createSQLiteMemberDetailsSchema adds the columns recording each member's content type, size and
+// update time for SQLite
+func createSQLiteMemberDetailsSchema(db SchemaExecutor) error {
+	columns := []string{
+		"ALTER TABLE memberships ADD COLUMN content_type TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE memberships ADD COLUMN size INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE memberships ADD COLUMN updated_at TIMESTAMP",
+	}
+
+	for _, columnSQL := range columns {
+		if _, err := db.Exec(columnSQL); err != nil {
+			return fmt.Errorf("failed to add membership column: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // createSQLiteSchemaMigrationsTable creates the schema migrations tracking table for SQLite
-func createSQLiteSchemaMigrationsTable(db *sql.DB) error {
+func createSQLiteSchemaMigrationsTable(db SchemaExecutor) error {
 	sql := `
 	CREATE TABLE IF NOT EXISTS schema_migrations (
 		version INTEGER PRIMARY KEY,
@@ -73,7 +113,7 @@ func createSQLiteSchemaMigrationsTable(db *sql.DB) error {
 }
 
 // recordMigration records a migration in the schema_migrations table
-func recordMigration(db *sql.DB, version int, description string) error {
+func recordMigration(db SchemaExecutor, version int, description string) error {
 	sql := `
 	INSERT OR IGNORE INTO schema_migrations (version, description, applied_at) 
 	VALUES (?, ?, ?);`
@@ -87,7 +127,7 @@ func recordMigration(db *sql.DB, version int, description string) error {
 }
 
 // getCurrentSchemaVersion returns the current schema version
-func getCurrentSchemaVersion(db *sql.DB) (int, error) {
+func getCurrentSchemaVersion(db SchemaExecutor) (int, error) {
 	var version int
 	err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
 	if err != nil {
@@ -3,10 +3,11 @@ package infrastructure
 import (
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 // createPostgreSQLContainerSchema creates the container and membership tables for PostgreSQL
-func createPostgreSQLContainerSchema(db *sql.DB) error {
+func createPostgreSQLContainerSchema(db SchemaExecutor) error {
 	// Create containers table
 	containersSQL := `
 	CREATE TABLE IF NOT EXISTS containers (
@@ -55,8 +56,41 @@ func createPostgreSQLContainerSchema(db *sql.DB) error {
 	return nil
 }
 
+// createPostgreSQLResourceAccessSchema creates the table recording when resources were last read for PostgreSQL
+func createPostgreSQLResourceAccessSchema(db SchemaExecutor) error {
+	accessSQL := `
+	CREATE TABLE IF NOT EXISTS resource_access (
+		resource_id TEXT PRIMARY KEY,
+		last_accessed_at TIMESTAMP NOT NULL
+	);`
+
+	if _, err := db.Exec(accessSQL); err != nil {
+		return fmt.Errorf("failed to create resource_access table: %w", err)
+	}
+
+	return nil
+}
+
+// createPostgreSQLMemberDetailsSchema adds the columns recording each member's content type, size and
+// update time for PostgreSQL
+func createPostgreSQLMemberDetailsSchema(db SchemaExecutor) error {
+	columns := []string{
+		"ALTER TABLE memberships ADD COLUMN content_type TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE memberships ADD COLUMN size BIGINT NOT NULL DEFAULT 0",
+		"ALTER TABLE memberships ADD COLUMN updated_at TIMESTAMP",
+	}
+
+	for _, columnSQL := range columns {
+		if _, err := db.Exec(columnSQL); err != nil {
+			return fmt.Errorf("failed to add membership column: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // createPostgreSQLSchemaMigrationsTable creates the schema migrations tracking table for PostgreSQL
-func createPostgreSQLSchemaMigrationsTable(db *sql.DB) error {
+func createPostgreSQLSchemaMigrationsTable(db SchemaExecutor) error {
 	sql := `
 	CREATE TABLE IF NOT EXISTS schema_migrations (
 		version INTEGER PRIMARY KEY,
@@ -71,6 +105,20 @@ func createPostgreSQLSchemaMigrationsTable(db *sql.DB) error {
 	return nil
 }
 
+// recordPostgreSQLMigration records a migration in the schema_migrations table for PostgreSQL
+func recordPostgreSQLMigration(db SchemaExecutor, version int, description string) error {
+	sql := `
+	INSERT INTO schema_migrations (version, description, applied_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (version) DO NOTHING;`
+
+	if _, err := db.Exec(sql, version, description, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", version, err)
+	}
+
+	return nil
+}
+
 // validatePostgreSQLSchema validates that the PostgreSQL schema is correctly applied
 func validatePostgreSQLSchema(db *sql.DB) error {
 	// Check that required tables exist
@@ -6,12 +6,16 @@ import (
 	"strings"
 )
 
-// SchemaProvider handles database schema operations for different database types
+// SchemaProvider handles database schema operations for different database types. The
+// statements creating or changing the schema run on a SchemaExecutor, so each migration can be
+// applied inside a transaction.
 type SchemaProvider interface {
-	CreateContainerSchema(db *sql.DB) error
-	CreateSchemaMigrationsTable(db *sql.DB) error
-	GetCurrentSchemaVersion(db *sql.DB) (int, error)
-	RecordMigration(db *sql.DB, version int, description string) error
+	CreateContainerSchema(db SchemaExecutor) error
+	CreateResourceAccessSchema(db SchemaExecutor) error
+	CreateMemberDetailsSchema(db SchemaExecutor) error
+	CreateSchemaMigrationsTable(db SchemaExecutor) error
+	GetCurrentSchemaVersion(db SchemaExecutor) (int, error)
+	RecordMigration(db SchemaExecutor, version int, description string) error
 	ValidateSchema(db *sql.DB) error
 }
 
@@ -30,19 +34,27 @@ func NewSchemaProvider(driver string) (SchemaProvider, error) {
 // SQLiteSchemaProvider implements schema operations for SQLite
 type SQLiteSchemaProvider struct{}
 
-func (p *SQLiteSchemaProvider) CreateContainerSchema(db *sql.DB) error {
+func (p *SQLiteSchemaProvider) CreateContainerSchema(db SchemaExecutor) error {
 	return createSQLiteContainerSchema(db)
 }
 
-func (p *SQLiteSchemaProvider) CreateSchemaMigrationsTable(db *sql.DB) error {
+func (p *SQLiteSchemaProvider) CreateResourceAccessSchema(db SchemaExecutor) error {
+	return createSQLiteResourceAccessSchema(db)
+}
+
+func (p *SQLiteSchemaProvider) CreateMemberDetailsSchema(db SchemaExecutor) error {
+	return createSQLiteMemberDetailsSchema(db)
+}
+
+func (p *SQLiteSchemaProvider) CreateSchemaMigrationsTable(db SchemaExecutor) error {
 	return createSQLiteSchemaMigrationsTable(db)
 }
 
-func (p *SQLiteSchemaProvider) GetCurrentSchemaVersion(db *sql.DB) (int, error) {
+func (p *SQLiteSchemaProvider) GetCurrentSchemaVersion(db SchemaExecutor) (int, error) {
 	return getCurrentSchemaVersion(db)
 }
 
-func (p *SQLiteSchemaProvider) RecordMigration(db *sql.DB, version int, description string) error {
+func (p *SQLiteSchemaProvider) RecordMigration(db SchemaExecutor, version int, description string) error {
 	return recordMigration(db, version, description)
 }
 
@@ -53,27 +65,87 @@ func (p *SQLiteSchemaProvider) ValidateSchema(db *sql.DB) error {
 // PostgreSQLSchemaProvider implements schema operations for PostgreSQL
 type PostgreSQLSchemaProvider struct{}
 
-func (p *PostgreSQLSchemaProvider) CreateContainerSchema(db *sql.DB) error {
+func (p *PostgreSQLSchemaProvider) CreateContainerSchema(db SchemaExecutor) error {
 	return createPostgreSQLContainerSchema(db)
 }
 
-func (p *PostgreSQLSchemaProvider) CreateSchemaMigrationsTable(db *sql.DB) error {
+func (p *PostgreSQLSchemaProvider) CreateResourceAccessSchema(db SchemaExecutor) error {
+	return createPostgreSQLResourceAccessSchema(db)
+}
+
+func (p *PostgreSQLSchemaProvider) CreateMemberDetailsSchema(db SchemaExecutor) error {
+	return createPostgreSQLMemberDetailsSchema(db)
+}
+
+func (p *PostgreSQLSchemaProvider) CreateSchemaMigrationsTable(db SchemaExecutor) error {
 	return createPostgreSQLSchemaMigrationsTable(db)
 }
 
-func (p *PostgreSQLSchemaProvider) GetCurrentSchemaVersion(db *sql.DB) (int, error) {
+func (p *PostgreSQLSchemaProvider) GetCurrentSchemaVersion(db SchemaExecutor) (int, error) {
 	return getCurrentSchemaVersion(db)
 }
 
-func (p *PostgreSQLSchemaProvider) RecordMigration(db *sql.DB, version int, description string) error {
-	return recordMigration(db, version, description)
+func (p *PostgreSQLSchemaProvider) RecordMigration(db SchemaExecutor, version int, description string) error {
+	return recordPostgreSQLMigration(db, version, description)
 }
 
 func (p *PostgreSQLSchemaProvider) ValidateSchema(db *sql.DB) error {
 	return validatePostgreSQLSchema(db)
 }
 
-// MigrateDatabase applies all pending migrations using the appropriate schema provider
+// SchemaMigration is one forward step of the membership index schema
+type SchemaMigration struct {
+	Version     int
+	Description string
+	Apply       func(db SchemaExecutor, provider SchemaProvider) error
+}
+
+// membershipIndexMigrations are the membership index schema's migrations, in version order.
+// A migration is never changed once released; schema changes are made by appending one.
+var membershipIndexMigrations = []SchemaMigration{
+	{
+		Version:     1,
+		Description: "Initial container schema",
+		Apply: func(db SchemaExecutor, p SchemaProvider) error {
+			return p.CreateContainerSchema(db)
+		},
+	},
+	{
+		Version:     2,
+		Description: "Resource last-accessed tracking",
+		Apply: func(db SchemaExecutor, p SchemaProvider) error {
+			return p.CreateResourceAccessSchema(db)
+		},
+	},
+	{
+		Version:     3,
+		Description: "Member content type, size and update time",
+		Apply: func(db SchemaExecutor, p SchemaProvider) error {
+			return p.CreateMemberDetailsSchema(db)
+		},
+	},
+}
+
+// LatestSchemaVersion returns the membership index schema version this build migrates to
+func LatestSchemaVersion() int {
+	return membershipIndexMigrations[len(membershipIndexMigrations)-1].Version
+}
+
+// PendingMigrations returns the migrations an index at the given schema version still needs
+func PendingMigrations(currentVersion int) []SchemaMigration {
+	var pending []SchemaMigration
+	for _, migration := range membershipIndexMigrations {
+		if migration.Version > currentVersion {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// MigrateDatabaseWithProvider applies all pending migrations using the appropriate schema
+// provider. Each migration is applied and recorded in one transaction, so a failed migration
+// leaves the index at the previous version rather than half changed. An index written by a
+// newer build, at a version this build does not know, is refused rather than used.
 func MigrateDatabaseWithProvider(db *sql.DB, provider SchemaProvider) error {
 	// Create schema migrations table first
 	if err := provider.CreateSchemaMigrationsTable(db); err != nil {
@@ -85,33 +157,61 @@ func MigrateDatabaseWithProvider(db *sql.DB, provider SchemaProvider) error {
 	if err != nil {
 		return err
 	}
+	if latest := LatestSchemaVersion(); currentVersion > latest {
+		return fmt.Errorf("index schema version %d is newer than the latest version %d this server supports", currentVersion, latest)
+	}
 
-	// Apply migrations
-	migrations := []struct {
-		version     int
-		description string
-		apply       func(*sql.DB, SchemaProvider) error
-	}{
-		{
-			version:     1,
-			description: "Initial container schema",
-			apply: func(db *sql.DB, p SchemaProvider) error {
-				return p.CreateContainerSchema(db)
-			},
-		},
+	for _, migration := range PendingMigrations(currentVersion) {
+		if err := applyMigration(db, provider, migration); err != nil {
+			return err
+		}
 	}
 
-	for _, migration := range migrations {
-		if migration.version > currentVersion {
-			if err := migration.apply(db, provider); err != nil {
-				return fmt.Errorf("failed to apply migration %d: %w", migration.version, err)
-			}
+	return nil
+}
 
-			if err := provider.RecordMigration(db, migration.version, migration.description); err != nil {
-				return err
-			}
-		}
+// applyMigration applies one migration and records it in a single transaction
+func applyMigration(db *sql.DB, provider SchemaProvider, migration SchemaMigration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start migration %d: %w", migration.Version, err)
 	}
 
+	if err := migration.Apply(tx, provider); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to apply migration %d: %w", migration.Version, err)
+	}
+	if err := provider.RecordMigration(tx, migration.Version, migration.Description); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d: %w", migration.Version, err)
+	}
 	return nil
 }
+
+// MigrateMembershipIndex brings a membership index database up to the latest schema version,
+// the LDP index counterpart of the user module's MigrateUserModels. Indexers migrate their
+// database when they open it; this is for migrating an index ahead of a server upgrade.
+func MigrateMembershipIndex(db *sql.DB, driver string) error {
+	provider, err := NewSchemaProvider(driver)
+	if err != nil {
+		return err
+	}
+	return MigrateDatabaseWithProvider(db, provider)
+}
+
+// MembershipIndexSchemaVersion returns the schema version of a membership index database,
+// zero for a database that has never been migrated
+func MembershipIndexSchemaVersion(db *sql.DB, driver string) (int, error) {
+	provider, err := NewSchemaProvider(driver)
+	if err != nil {
+		return 0, err
+	}
+	if err := provider.CreateSchemaMigrationsTable(db); err != nil {
+		return 0, err
+	}
+	return provider.GetCurrentSchemaVersion(db)
+}
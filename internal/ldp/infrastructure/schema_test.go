@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"database/sql"
+	"errors"
 	"os"
 	"testing"
 
@@ -9,7 +10,7 @@ import (
 )
 
 func TestCreateContainerSchema(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Test schema creation
@@ -39,7 +40,7 @@ func TestCreateContainerSchema(t *testing.T) {
 }
 
 func TestCreateContainerSchemaIndexes(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Create schema
@@ -65,7 +66,7 @@ func TestCreateContainerSchemaIndexes(t *testing.T) {
 }
 
 func TestContainerSchemaConstraints(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Create schema
@@ -114,7 +115,7 @@ func TestContainerSchemaConstraints(t *testing.T) {
 }
 
 func TestDatabaseMigration(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Test initial migration
@@ -130,8 +131,8 @@ func TestDatabaseMigration(t *testing.T) {
 		t.Fatalf("Failed to get migration version: %v", err)
 	}
 
-	if version != 1 {
-		t.Errorf("Expected migration version 1, got %d", version)
+	if version != 3 {
+		t.Errorf("Expected migration version 3, got %d", version)
 	}
 
 	// Test idempotent migration (running again should not fail)
@@ -142,7 +143,7 @@ func TestDatabaseMigration(t *testing.T) {
 }
 
 func TestSchemaVersioning(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Test schema migrations table creation
@@ -181,7 +182,7 @@ func TestSchemaVersioning(t *testing.T) {
 }
 
 func TestSchemaValidation(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Create full schema with migrations
@@ -198,7 +199,7 @@ func TestSchemaValidation(t *testing.T) {
 }
 
 func TestSchemaUpgrade(t *testing.T) {
-	db, cleanup := setupTestDB(t)
+	db, cleanup := setupSchemaTestDB(t)
 	defer cleanup()
 
 	// Create initial schema
@@ -213,13 +214,13 @@ func TestSchemaUpgrade(t *testing.T) {
 		t.Fatalf("Failed to get current schema version: %v", err)
 	}
 
-	if currentVersion != 1 {
-		t.Errorf("Expected current version 1, got %d", currentVersion)
+	if currentVersion != 3 {
+		t.Errorf("Expected current version 3, got %d", currentVersion)
 	}
 }
 
 // Helper functions for testing
-func setupTestDB(t *testing.T) (*sql.DB, func()) {
+func setupSchemaTestDB(t *testing.T) (*sql.DB, func()) {
 	// Create temporary database file
 	tmpFile, err := os.CreateTemp("", "test_schema_*.db")
 	if err != nil {
@@ -241,3 +242,97 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 
 	return db, cleanup
 }
+
+// failingMemberDetailsProvider adds one member details column and then fails, as a migration
+// interrupted halfway would
+type failingMemberDetailsProvider struct {
+	SQLiteSchemaProvider
+}
+
+func (p *failingMemberDetailsProvider) CreateMemberDetailsSchema(db SchemaExecutor) error {
+	if _, err := db.Exec("ALTER TABLE memberships ADD COLUMN content_type TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return errors.New("interrupted")
+}
+
+func TestSchemaMigrationRollsBackOnFailure(t *testing.T) {
+	db, cleanup := setupSchemaTestDB(t)
+	defer cleanup()
+
+	if err := MigrateDatabaseWithProvider(db, &failingMemberDetailsProvider{}); err == nil {
+		t.Fatal("Expected the interrupted migration to fail")
+	}
+	if version, _ := getCurrentSchemaVersion(db); version != 2 {
+		t.Errorf("Expected version 2 after the failed migration, got %d", version)
+	}
+
+	// The half-applied column was rolled back, so the migration applies cleanly on retry
+	if err := migrateDatabase(db); err != nil {
+		t.Fatalf("Failed to retry the migration: %v", err)
+	}
+	if version, _ := getCurrentSchemaVersion(db); version != LatestSchemaVersion() {
+		t.Errorf("Expected version %d after retrying, got %d", LatestSchemaVersion(), version)
+	}
+}
+
+func TestSchemaMigrationKeepsExistingData(t *testing.T) {
+	db, cleanup := setupSchemaTestDB(t)
+	defer cleanup()
+
+	// An index written by a build that knew only the first two migrations
+	if err := createSchemaMigrationsTable(db); err != nil {
+		t.Fatalf("Failed to create schema migrations table: %v", err)
+	}
+	for _, migration := range membershipIndexMigrations[:2] {
+		if err := applyMigration(db, &SQLiteSchemaProvider{}, migration); err != nil {
+			t.Fatalf("Failed to apply migration %d: %v", migration.Version, err)
+		}
+	}
+	if _, err := db.Exec("INSERT INTO containers (id) VALUES ('photos')"); err != nil {
+		t.Fatalf("Failed to insert container: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO memberships (container_id, member_id, member_type) VALUES ('photos', 'cat.jpg', 'Resource')"); err != nil {
+		t.Fatalf("Failed to insert membership: %v", err)
+	}
+
+	if err := MigrateMembershipIndex(db, "sqlite3"); err != nil {
+		t.Fatalf("Failed to migrate index: %v", err)
+	}
+
+	var memberID string
+	var size int64
+	if err := db.QueryRow("SELECT member_id, size FROM memberships WHERE container_id = 'photos'").Scan(&memberID, &size); err != nil {
+		t.Fatalf("Membership lost in migration: %v", err)
+	}
+	if memberID != "cat.jpg" || size != 0 {
+		t.Errorf("Expected cat.jpg with size 0, got %s with size %d", memberID, size)
+	}
+}
+
+func TestSchemaMigrationRefusesNewerIndex(t *testing.T) {
+	db, cleanup := setupSchemaTestDB(t)
+	defer cleanup()
+
+	if err := migrateDatabase(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := recordMigration(db, LatestSchemaVersion()+1, "From a newer build"); err != nil {
+		t.Fatalf("Failed to record migration: %v", err)
+	}
+
+	if err := migrateDatabase(db); err == nil {
+		t.Error("Expected an index from a newer build to be refused")
+	}
+
+	version, err := MembershipIndexSchemaVersion(db, "sqlite3")
+	if err != nil {
+		t.Fatalf("Failed to read schema version: %v", err)
+	}
+	if version != LatestSchemaVersion()+1 {
+		t.Errorf("Expected version %d, got %d", LatestSchemaVersion()+1, version)
+	}
+	if pending := PendingMigrations(0); len(pending) != LatestSchemaVersion() {
+		t.Errorf("Expected %d migrations pending for a new index, got %d", LatestSchemaVersion(), len(pending))
+	}
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// MemorySearchIndex is an in-process SearchIndex. It is filled as members are written, so
+// members stored before the process started are not searchable until they are written again.
+type MemorySearchIndex struct {
+	documents map[string]domain.SearchDocument
+	mu        sync.RWMutex
+}
+
+// NewMemorySearchIndex creates an empty in-memory search index
+func NewMemorySearchIndex() *MemorySearchIndex {
+	return &MemorySearchIndex{
+		documents: make(map[string]domain.SearchDocument),
+	}
+}
+
+// Index adds or replaces a document, keeping the indexed container when none is given
+func (i *MemorySearchIndex) Index(ctx context.Context, doc domain.SearchDocument) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if existing, ok := i.documents[doc.ID]; ok && doc.ContainerID == "" {
+		doc.ContainerID = existing.ContainerID
+	}
+	doc.Tags = append([]string(nil), doc.Tags...)
+	i.documents[doc.ID] = doc
+	return nil
+}
+
+// SetContainer records which container holds an indexed member
+func (i *MemorySearchIndex) SetContainer(ctx context.Context, id, containerID string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if doc, ok := i.documents[id]; ok {
+		doc.ContainerID = containerID
+		i.documents[id] = doc
+	}
+	return nil
+}
+
+// Remove drops a member from the index
+func (i *MemorySearchIndex) Remove(ctx context.Context, id string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.documents, id)
+	return nil
+}
+
+// Get returns the document indexed for a member
+func (i *MemorySearchIndex) Get(ctx context.Context, id string) (domain.SearchDocument, bool, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc, ok := i.documents[id]
+	if ok {
+		doc.Tags = append([]string(nil), doc.Tags...)
+	}
+	return doc, ok, nil
+}
+
+// Search returns every document matching the query, ordered by ID
+func (i *MemorySearchIndex) Search(ctx context.Context, query domain.SearchQuery) ([]domain.SearchDocument, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	matches := make([]domain.SearchDocument, 0)
+	for _, doc := range i.documents {
+		if doc.Matches(query) {
+			doc.Tags = append([]string(nil), doc.Tags...)
+			matches = append(matches, doc)
+		}
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		return matches[a].ID < matches[b].ID
+	})
+	return matches, nil
+}
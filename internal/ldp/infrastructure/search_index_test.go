@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySearchIndex(t *testing.T) {
+	index := NewMemorySearchIndex()
+	ctx := context.Background()
+
+	require.NoError(t, index.Index(ctx, domain.SearchDocument{ID: "b", Type: domain.SearchTypeResource, Title: "Trip", ContainerID: "trips"}))
+	require.NoError(t, index.Index(ctx, domain.SearchDocument{ID: "a", Type: domain.SearchTypeResource, Title: "Trip notes"}))
+
+	t.Run("results are ordered by ID", func(t *testing.T) {
+		results, err := index.Search(ctx, domain.SearchQuery{Text: "trip"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "a", results[0].ID)
+		assert.Equal(t, "b", results[1].ID)
+	})
+
+	t.Run("reindexing keeps the container", func(t *testing.T) {
+		require.NoError(t, index.Index(ctx, domain.SearchDocument{ID: "b", Type: domain.SearchTypeResource, Title: "Trip to Porto"}))
+
+		results, err := index.Search(ctx, domain.SearchQuery{Text: "porto"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "trips", results[0].ContainerID)
+	})
+
+	t.Run("get returns the indexed document", func(t *testing.T) {
+		doc, found, err := index.Get(ctx, "b")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "Trip to Porto", doc.Title)
+
+		_, found, err = index.Get(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("set container and remove", func(t *testing.T) {
+		require.NoError(t, index.SetContainer(ctx, "b", ""))
+		require.NoError(t, index.SetContainer(ctx, "missing", "trips"))
+		require.NoError(t, index.Remove(ctx, "a"))
+
+		results, err := index.Search(ctx, domain.SearchQuery{})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "b", results[0].ID)
+		assert.Empty(t, results[0].ContainerID)
+	})
+}
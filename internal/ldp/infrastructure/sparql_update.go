@@ -0,0 +1,359 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// unsupportedGroupKeywords are graph pattern keywords only a full SPARQL engine can evaluate
+var unsupportedGroupKeywords = []string{"GRAPH", "FILTER", "OPTIONAL", "UNION", "MINUS", "BIND", "VALUES", "SERVICE"}
+
+// SPARQLUpdateProcessor applies SPARQL 1.1 Update requests to Turtle documents. It supports
+// the operations Solid clients send to modify RDF resources: INSERT DATA, DELETE DATA,
+// DELETE WHERE and DELETE/INSERT ... WHERE, over ground triples only. A WHERE clause acts as
+// a condition: its operation applies only when the document holds every triple in it.
+type SPARQLUpdateProcessor struct{}
+
+// NewSPARQLUpdateProcessor creates a new SPARQL update processor
+func NewSPARQLUpdateProcessor() *SPARQLUpdateProcessor {
+	return &SPARQLUpdateProcessor{}
+}
+
+// ApplyPatch applies a SPARQL update to a Turtle document. The updated graph is written as
+// N-Triples, which is also valid Turtle; an update that changes no triple returns the document
+// untouched. Deleting a triple the document does not hold is not an error.
+func (u *SPARQLUpdateProcessor) ApplyPatch(document []byte, update string) ([]byte, error) {
+	operations, err := parseSPARQLUpdate(update)
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("invalid SPARQL update: %w", err),
+			domain.ErrInvalidPatch.Code,
+			"malformed SPARQL update",
+		).WithOperation("ApplyPatch")
+	}
+
+	triples, err := newTurtleParser(string(document)).parse()
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("failed to parse stored document: %w", err),
+			domain.ErrFormatConversion.Code,
+			"stored document cannot be updated",
+		).WithOperation("ApplyPatch")
+	}
+
+	graph := newPatchGraph(triples)
+	changed := false
+	for _, operation := range operations {
+		if graph.apply(operation) {
+			changed = true
+		}
+	}
+	if !changed {
+		return document, nil
+	}
+
+	return graph.serialize(), nil
+}
+
+// sparqlOperation is one operation of a SPARQL update, reduced to the triples it deletes and
+// inserts and the triples that must hold for it to apply
+type sparqlOperation struct {
+	deletes []canonicalTriple
+	inserts []canonicalTriple
+	where   []canonicalTriple
+}
+
+// sparqlUpdateParser parses SPARQL updates whose triple blocks are written in Turtle syntax
+type sparqlUpdateParser struct {
+	*turtleParser
+}
+
+// parseSPARQLUpdate parses a sequence of update operations separated by semicolons, each
+// optionally preceded by PREFIX and BASE declarations
+func parseSPARQLUpdate(update string) ([]sparqlOperation, error) {
+	p := sparqlUpdateParser{newTurtleParser(update)}
+	p.inGroup = true
+
+	var operations []sparqlOperation
+	for {
+		if err := p.parsePrologue(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			break
+		}
+
+		operation, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+
+		p.skipWhitespace()
+		if p.eof() {
+			break
+		}
+		if p.peek() != ';' {
+			return nil, p.errorf("expected ';' between update operations")
+		}
+		p.pos++
+	}
+
+	if len(operations) == 0 {
+		return nil, p.errorf("update contains no operations")
+	}
+	return operations, nil
+}
+
+// parsePrologue parses PREFIX and BASE declarations
+func (p *sparqlUpdateParser) parsePrologue() error {
+	for {
+		p.skipWhitespace()
+		if !p.matchKeyword("PREFIX") && !p.matchKeyword("BASE") {
+			return nil
+		}
+		if err := p.parseDirective(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseOperation parses a single INSERT or DELETE operation
+func (p *sparqlUpdateParser) parseOperation() (sparqlOperation, error) {
+	var operation sparqlOperation
+	var err error
+
+	switch {
+	case p.acceptKeyword("INSERT"):
+		if p.acceptKeyword("DATA") {
+			operation.inserts, err = p.parseGroup()
+			return operation, err
+		}
+		if operation.inserts, err = p.parseGroup(); err != nil {
+			return operation, err
+		}
+		operation.where, err = p.parseWhere()
+		if err != nil {
+			return operation, err
+		}
+	case p.acceptKeyword("DELETE"):
+		switch {
+		case p.acceptKeyword("DATA"):
+			operation.deletes, err = p.parseGroup()
+		case p.acceptKeyword("WHERE"):
+			operation.deletes, err = p.parseGroup()
+			operation.where = operation.deletes
+		default:
+			if operation.deletes, err = p.parseGroup(); err != nil {
+				return operation, err
+			}
+			if p.acceptKeyword("INSERT") {
+				if operation.inserts, err = p.parseGroup(); err != nil {
+					return operation, err
+				}
+			}
+			operation.where, err = p.parseWhere()
+		}
+		if err != nil {
+			return operation, err
+		}
+	default:
+		word := p.readWhile(func(r rune) bool { return unicode.IsLetter(r) })
+		if word == "" {
+			return operation, p.errorf("expected INSERT or DELETE")
+		}
+		return operation, p.errorf("unsupported update operation %q", strings.ToUpper(word))
+	}
+
+	for _, triples := range [][]canonicalTriple{operation.deletes, operation.where} {
+		if err := rejectBlankNodes(triples); err != nil {
+			return operation, p.errorf("%v", err)
+		}
+	}
+	return operation, nil
+}
+
+// parseWhere parses the WHERE clause of a DELETE/INSERT operation
+func (p *sparqlUpdateParser) parseWhere() ([]canonicalTriple, error) {
+	if p.acceptKeyword("USING") || p.acceptKeyword("WITH") {
+		return nil, p.errorf("named graphs are not supported")
+	}
+	if !p.acceptKeyword("WHERE") {
+		return nil, p.errorf("expected WHERE")
+	}
+	return p.parseGroup()
+}
+
+// parseGroup parses a block of triples enclosed in braces
+func (p *sparqlUpdateParser) parseGroup() ([]canonicalTriple, error) {
+	p.skipWhitespace()
+	if p.peek() != '{' {
+		return nil, p.errorf("expected '{'")
+	}
+	p.pos++
+
+	p.triples = nil
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return nil, p.errorf("expected '}'")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return p.triples, nil
+		}
+		for _, keyword := range unsupportedGroupKeywords {
+			if p.acceptKeyword(keyword) {
+				return nil, p.errorf("%s is not supported", keyword)
+			}
+		}
+		if err := p.parseStatement(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// acceptKeyword consumes a case-insensitive keyword when it is next
+func (p *sparqlUpdateParser) acceptKeyword(keyword string) bool {
+	p.skipWhitespace()
+	end := p.pos + len(keyword)
+	if end > len(p.input) || !strings.EqualFold(string(p.input[p.pos:end]), keyword) {
+		return false
+	}
+	if end < len(p.input) && (isNameRune(p.input[end]) || p.input[end] == ':') {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// errorf returns a parse error annotated with the current position
+func (p *sparqlUpdateParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("SPARQL parse error at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// rejectBlankNodes fails when triples that must match the document contain blank nodes, which
+// SPARQL treats as variables
+func rejectBlankNodes(triples []canonicalTriple) error {
+	for _, triple := range triples {
+		if triple.subject.kind == "blank" || triple.object.kind == "blank" {
+			return fmt.Errorf("blank nodes are only allowed in inserted data")
+		}
+	}
+	return nil
+}
+
+// patchGraph is a document's triples in their original order
+type patchGraph struct {
+	triples []canonicalTriple
+	keys    map[string]int
+	labels  map[string]bool
+}
+
+// newPatchGraph creates a graph from parsed triples, dropping duplicates
+func newPatchGraph(triples []canonicalTriple) *patchGraph {
+	graph := &patchGraph{keys: make(map[string]int), labels: make(map[string]bool)}
+	for _, triple := range triples {
+		graph.add(triple)
+	}
+	return graph
+}
+
+// apply applies an operation when its WHERE triples all hold, reporting whether the graph changed
+func (g *patchGraph) apply(operation sparqlOperation) bool {
+	for _, triple := range operation.where {
+		if _, ok := g.keys[triple.serialize(nil)]; !ok {
+			return false
+		}
+	}
+
+	changed := false
+	for _, triple := range operation.deletes {
+		if g.remove(triple) {
+			changed = true
+		}
+	}
+	for _, triple := range g.freshBlankNodes(operation.inserts) {
+		if g.add(triple) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// add adds a triple the graph does not hold yet
+func (g *patchGraph) add(triple canonicalTriple) bool {
+	key := triple.serialize(nil)
+	if _, ok := g.keys[key]; ok {
+		return false
+	}
+	g.keys[key] = len(g.triples)
+	g.triples = append(g.triples, triple)
+	for _, term := range []canonicalTerm{triple.subject, triple.object} {
+		if term.kind == "blank" {
+			g.labels[term.value] = true
+		}
+	}
+	return true
+}
+
+// remove removes a triple, reporting whether the graph held it
+func (g *patchGraph) remove(triple canonicalTriple) bool {
+	key := triple.serialize(nil)
+	index, ok := g.keys[key]
+	if !ok {
+		return false
+	}
+	g.triples = append(g.triples[:index], g.triples[index+1:]...)
+	delete(g.keys, key)
+	for i := index; i < len(g.triples); i++ {
+		g.keys[g.triples[i].serialize(nil)] = i
+	}
+	return true
+}
+
+// freshBlankNodes relabels the blank nodes of inserted triples that clash with labels already
+// in the graph, since blank nodes in inserted data always denote new nodes
+func (g *patchGraph) freshBlankNodes(triples []canonicalTriple) []canonicalTriple {
+	relabeled := make(map[string]string)
+	fresh := func(term canonicalTerm) canonicalTerm {
+		if term.kind != "blank" {
+			return term
+		}
+		label, ok := relabeled[term.value]
+		if !ok {
+			label = term.value
+			for n := 1; g.labels[label]; n++ {
+				label = term.value + "_" + strconv.Itoa(n)
+			}
+			relabeled[term.value] = label
+		}
+		term.value = label
+		return term
+	}
+
+	result := make([]canonicalTriple, len(triples))
+	for i, triple := range triples {
+		result[i] = canonicalTriple{subject: fresh(triple.subject), predicate: triple.predicate, object: fresh(triple.object)}
+	}
+	return result
+}
+
+// serialize writes the graph as N-Triples. An empty graph is written as a single newline so
+// the document stays non-empty.
+func (g *patchGraph) serialize() []byte {
+	if len(g.triples) == 0 {
+		return []byte("\n")
+	}
+
+	var b strings.Builder
+	for _, triple := range g.triples {
+		b.WriteString(triple.serialize(nil))
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
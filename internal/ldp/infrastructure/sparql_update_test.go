@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPARQLUpdateProcessor_ApplyPatch(t *testing.T) {
+	document := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<#me> foaf:name "Alice" ;
+    foaf:nick "Al" .
+`
+
+	tests := []struct {
+		name   string
+		update string
+		want   string
+	}{
+		{
+			name:   "insert data",
+			update: `INSERT DATA { <#me> <http://xmlns.com/foaf/0.1/age> 30 . }`,
+			want: "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n" +
+				"<#me> <http://xmlns.com/foaf/0.1/nick> \"Al\" .\n" +
+				"<#me> <http://xmlns.com/foaf/0.1/age> \"30\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n",
+		},
+		{
+			name:   "delete data",
+			update: `PREFIX foaf: <http://xmlns.com/foaf/0.1/> DELETE DATA { <#me> foaf:nick "Al" }`,
+			want:   "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n",
+		},
+		{
+			name: "delete insert where",
+			update: `PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+DELETE { <#me> foaf:name "Alice" }
+INSERT { <#me> foaf:name "Alicia" }
+WHERE { <#me> foaf:nick "Al" }`,
+			want: "<#me> <http://xmlns.com/foaf/0.1/nick> \"Al\" .\n" +
+				"<#me> <http://xmlns.com/foaf/0.1/name> \"Alicia\" .\n",
+		},
+		{
+			name: "where clause that does not hold",
+			update: `PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+DELETE { <#me> foaf:name "Alice" } INSERT { <#me> foaf:name "Alicia" } WHERE { <#me> foaf:nick "Ally" }`,
+			want: document,
+		},
+		{
+			name:   "delete missing triple",
+			update: `DELETE DATA { <#me> <http://xmlns.com/foaf/0.1/mbox> <mailto:alice@example.org> }`,
+			want:   document,
+		},
+		{
+			name:   "delete where",
+			update: `DELETE WHERE { <#me> <http://xmlns.com/foaf/0.1/nick> "Al" }`,
+			want:   "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n",
+		},
+		{
+			name: "several operations",
+			update: `PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+DELETE DATA { <#me> foaf:name "Alice" ; foaf:nick "Al" } ;
+INSERT DATA { <#me> foaf:name "Bob" } ;`,
+			want: "<#me> <http://xmlns.com/foaf/0.1/name> \"Bob\" .\n",
+		},
+	}
+
+	processor := NewSPARQLUpdateProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := processor.ApplyPatch([]byte(document), tt.update)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(updated))
+		})
+	}
+}
+
+func TestSPARQLUpdateProcessor_InsertsFreshBlankNodes(t *testing.T) {
+	document := `<#me> <http://xmlns.com/foaf/0.1/knows> _:friend .`
+	updated, err := NewSPARQLUpdateProcessor().ApplyPatch([]byte(document), `INSERT DATA { <#me> <http://xmlns.com/foaf/0.1/knows> _:friend }`)
+	require.NoError(t, err)
+	assert.Equal(t, "<#me> <http://xmlns.com/foaf/0.1/knows> _:friend .\n<#me> <http://xmlns.com/foaf/0.1/knows> _:friend_1 .\n", string(updated))
+}
+
+func TestSPARQLUpdateProcessor_RejectsMalformedUpdates(t *testing.T) {
+	tests := []struct {
+		name   string
+		update string
+		reason string
+	}{
+		{"empty update", "", "no operations"},
+		{"unterminated block", `INSERT DATA { <#me> <#p> "x" . `, "expected '}'"},
+		{"missing where", `DELETE { <#me> <#p> "x" } INSERT { <#me> <#p> "y" }`, "expected WHERE"},
+		{"variables", `DELETE { ?s <#p> "x" } WHERE { ?s <#p> "x" }`, "variables are not supported"},
+		{"blank nodes in deleted data", `DELETE DATA { _:b <#p> "x" }`, "blank nodes"},
+		{"unsupported operation", `CLEAR ALL`, `"CLEAR"`},
+		{"filters", `DELETE { <#me> <#p> "x" } WHERE { FILTER(true) }`, "FILTER is not supported"},
+		{"undefined prefix", `INSERT DATA { <#me> foaf:name "x" }`, "undefined prefix"},
+	}
+
+	processor := NewSPARQLUpdateProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := processor.ApplyPatch([]byte(`<#me> <#p> "x" .`), tt.update)
+			require.Error(t, err)
+			assert.True(t, domain.IsInvalidPatch(err))
+			assert.Contains(t, err.Error(), tt.reason)
+		})
+	}
+}
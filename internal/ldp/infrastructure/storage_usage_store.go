@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ResourceUsageModel is the charge of one resource against an account's storage quota
+type ResourceUsageModel struct {
+	ResourceID string `gorm:"primaryKey;type:varchar(255)"`
+	AccountID  string `gorm:"not null;type:varchar(255);index"`
+	Size       int64  `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for ResourceUsageModel
+func (ResourceUsageModel) TableName() string {
+	return "resource_storage_usage"
+}
+
+// AccountUsageModel is the running total of the bytes charged to an account
+type AccountUsageModel struct {
+	AccountID string `gorm:"primaryKey;type:varchar(255)"`
+	UsedBytes int64  `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for AccountUsageModel
+func (AccountUsageModel) TableName() string {
+	return "account_storage_usage"
+}
+
+// GormStorageUsageStore keeps storage usage in two tables: the charge of each resource, and a
+// running total per account that is adjusted in the same transaction as each charge, so
+// reading an account's usage never sums its resources.
+type GormStorageUsageStore struct {
+	db *gorm.DB
+}
+
+// NewGormStorageUsageStore creates a usage store, creating its tables if needed
+func NewGormStorageUsageStore(db *gorm.DB) (*GormStorageUsageStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	if err := db.AutoMigrate(&ResourceUsageModel{}, &AccountUsageModel{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate storage usage tables: %w", err)
+	}
+	return &GormStorageUsageStore{db: db}, nil
+}
+
+// ResourceUsage returns the account a resource is charged to and its charged size
+func (s *GormStorageUsageStore) ResourceUsage(ctx context.Context, resourceID string) (string, int64, bool, error) {
+	var usage ResourceUsageModel
+	err := s.db.WithContext(ctx).Where("resource_id = ?", resourceID).Take(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", 0, false, nil
+	}
+	if err != nil {
+		return "", 0, false, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to read resource usage").
+			WithOperation("ResourceUsage").WithContext("resourceID", resourceID)
+	}
+	return usage.AccountID, usage.Size, true, nil
+}
+
+// SetResourceUsage charges a resource to an account, moving its earlier charge off the total
+// of the account it was charged to
+func (s *GormStorageUsageStore) SetResourceUsage(ctx context.Context, accountID, resourceID string, size int64) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		previous, err := takeResourceUsage(tx, resourceID)
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			if err := adjustAccountUsage(tx, previous.AccountID, -previous.Size); err != nil {
+				return err
+			}
+		}
+
+		usage := ResourceUsageModel{ResourceID: resourceID, AccountID: accountID, Size: size}
+		if err := tx.Save(&usage).Error; err != nil {
+			return err
+		}
+		return adjustAccountUsage(tx, accountID, size)
+	})
+	if err != nil {
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to record resource usage").
+			WithOperation("SetResourceUsage").WithContext("resourceID", resourceID).WithContext("accountID", accountID)
+	}
+	return nil
+}
+
+// RemoveResourceUsage drops a resource's charge and takes it off its account's total
+func (s *GormStorageUsageStore) RemoveResourceUsage(ctx context.Context, resourceID string) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		previous, err := takeResourceUsage(tx, resourceID)
+		if err != nil || previous == nil {
+			return err
+		}
+		if err := tx.Delete(&ResourceUsageModel{}, "resource_id = ?", resourceID).Error; err != nil {
+			return err
+		}
+		return adjustAccountUsage(tx, previous.AccountID, -previous.Size)
+	})
+	if err != nil {
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to remove resource usage").
+			WithOperation("RemoveResourceUsage").WithContext("resourceID", resourceID)
+	}
+	return nil
+}
+
+// AccountUsage returns the bytes charged to an account; an account never charged has none
+func (s *GormStorageUsageStore) AccountUsage(ctx context.Context, accountID string) (int64, error) {
+	var usage AccountUsageModel
+	err := s.db.WithContext(ctx).Where("account_id = ?", accountID).Take(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to read account usage").
+			WithOperation("AccountUsage").WithContext("accountID", accountID)
+	}
+	return usage.UsedBytes, nil
+}
+
+// TotalUsage returns the bytes charged to every account, summed from the account totals
+func (s *GormStorageUsageStore) TotalUsage(ctx context.Context) (int64, error) {
+	var total int64
+	err := s.db.WithContext(ctx).Model(&AccountUsageModel{}).Select("COALESCE(SUM(used_bytes), 0)").Scan(&total).Error
+	if err != nil {
+		return 0, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to read total usage").
+			WithOperation("TotalUsage")
+	}
+	return total, nil
+}
+
+// takeResourceUsage returns a resource's charge, or nil when it has none
+func takeResourceUsage(tx *gorm.DB, resourceID string) (*ResourceUsageModel, error) {
+	var usage ResourceUsageModel
+	err := tx.Where("resource_id = ?", resourceID).Take(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// adjustAccountUsage adds delta bytes to an account's total, creating the total if needed
+func adjustAccountUsage(tx *gorm.DB, accountID string, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"used_bytes": gorm.Expr("account_storage_usage.used_bytes + ?", delta)}),
+	}).Create(&AccountUsageModel{AccountID: accountID, UsedBytes: delta}).Error
+}
@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupStorageUsageStore(t *testing.T) *GormStorageUsageStore {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	store, err := NewGormStorageUsageStore(db)
+	require.NoError(t, err)
+	return store
+}
+
+func TestGormStorageUsageStore(t *testing.T) {
+	store := setupStorageUsageStore(t)
+	ctx := context.Background()
+
+	accountUsage := func(accountID string) int64 {
+		used, err := store.AccountUsage(ctx, accountID)
+		require.NoError(t, err)
+		return used
+	}
+
+	assert.Equal(t, int64(0), accountUsage("alice"), "an account never charged uses nothing")
+
+	require.NoError(t, store.SetResourceUsage(ctx, "alice", "notes", 100))
+	require.NoError(t, store.SetResourceUsage(ctx, "alice", "photo", 400))
+	assert.Equal(t, int64(500), accountUsage("alice"))
+
+	t.Run("overwrite replaces the earlier charge", func(t *testing.T) {
+		require.NoError(t, store.SetResourceUsage(ctx, "alice", "notes", 30))
+		assert.Equal(t, int64(430), accountUsage("alice"))
+
+		accountID, size, found, err := store.ResourceUsage(ctx, "notes")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "alice", accountID)
+		assert.Equal(t, int64(30), size)
+	})
+
+	t.Run("recharging to another account moves the bytes", func(t *testing.T) {
+		require.NoError(t, store.SetResourceUsage(ctx, "bob", "photo", 400))
+		assert.Equal(t, int64(30), accountUsage("alice"))
+		assert.Equal(t, int64(400), accountUsage("bob"))
+	})
+
+	t.Run("removal releases the charge", func(t *testing.T) {
+		require.NoError(t, store.RemoveResourceUsage(ctx, "photo"))
+		assert.Equal(t, int64(0), accountUsage("bob"))
+
+		_, _, found, err := store.ResourceUsage(ctx, "photo")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		require.NoError(t, store.RemoveResourceUsage(ctx, "photo"), "removing an uncharged resource does nothing")
+	})
+}
@@ -167,6 +167,30 @@ func TestFileSystemRepository_StreamingOperations(t *testing.T) {
 		assert.Contains(t, err.Error(), "checksum mismatch")
 	})
 
+	t.Run("OpenContent_Seek", func(t *testing.T) {
+		testData := []byte("0123456789")
+		err := repo.StoreStream(ctx, "test-seek", bytes.NewReader(testData), "video/mp4", int64(len(testData)))
+		require.NoError(t, err)
+
+		content, metadata, err := repo.OpenContent(ctx, "test-seek")
+		require.NoError(t, err)
+		defer content.Close()
+		assert.Equal(t, "video/mp4", metadata.ContentType)
+		assert.Equal(t, int64(len(testData)), metadata.Size)
+
+		// Read a range from the middle without reading what precedes it
+		_, err = content.Seek(4, io.SeekStart)
+		require.NoError(t, err)
+		part := make([]byte, 3)
+		_, err = io.ReadFull(content, part)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("456"), part)
+
+		_, _, err = repo.OpenContent(ctx, "non-existent")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "resource not found")
+	})
+
 	t.Run("StreamingErrorHandling", func(t *testing.T) {
 		// Test storing with nil reader
 		err := repo.StoreStream(ctx, "test-nil", nil, "text/plain", 0)
@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// TriplePatternMatcher selects the triples of Turtle documents matching a triple pattern, so
+// clients can read part of a large graph without downloading the whole of it
+type TriplePatternMatcher struct{}
+
+// NewTriplePatternMatcher creates a new triple pattern matcher
+func NewTriplePatternMatcher() *TriplePatternMatcher {
+	return &TriplePatternMatcher{}
+}
+
+// MatchTriples returns the triples of a Turtle document matching the pattern as N-Triples, in
+// document order. A document with no matching triple yields an empty result.
+func (m *TriplePatternMatcher) MatchTriples(document []byte, pattern domain.TriplePattern) ([]byte, error) {
+	var terms [3]patternTerm
+	for i, value := range []string{pattern.Subject, pattern.Predicate, pattern.Object} {
+		term, err := parsePatternTerm(value)
+		if err != nil {
+			return nil, domain.WrapStorageError(
+				fmt.Errorf("invalid %s: %w", patternPositions[i], err),
+				domain.ErrInvalidTriplePattern.Code,
+				"malformed triple pattern",
+			).WithOperation("MatchTriples").WithContext(patternPositions[i], value)
+		}
+		terms[i] = term
+	}
+
+	triples, err := newTurtleParser(string(document)).parse()
+	if err != nil {
+		return nil, domain.WrapStorageError(
+			fmt.Errorf("failed to parse stored document: %w", err),
+			domain.ErrFormatConversion.Code,
+			"stored document cannot be queried",
+		).WithOperation("MatchTriples")
+	}
+
+	var b strings.Builder
+	for _, triple := range triples {
+		if terms[0].matches(triple.subject) && terms[1].matches(triple.predicate) && terms[2].matches(triple.object) {
+			b.WriteString(triple.serialize(nil))
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// patternPositions names the terms of a triple pattern in order
+var patternPositions = [3]string{"subject", "predicate", "object"}
+
+// patternTerm is one term of a triple pattern
+type patternTerm struct {
+	any   bool
+	bare  string
+	exact *canonicalTerm
+}
+
+// parsePatternTerm parses a pattern term: empty matches anything, N-Triples syntax matches
+// that exact term and anything else matches an IRI or literal with that value
+func parsePatternTerm(value string) (patternTerm, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return patternTerm{any: true}, nil
+	case strings.HasPrefix(value, "<"), strings.HasPrefix(value, `"`), strings.HasPrefix(value, "'"), strings.HasPrefix(value, "_:"):
+		p := newTurtleParser(value)
+		term, err := p.parseTerm()
+		if err != nil {
+			return patternTerm{}, err
+		}
+		p.skipWhitespace()
+		if !p.eof() {
+			return patternTerm{}, p.errorf("unexpected input after term")
+		}
+		return patternTerm{exact: &term}, nil
+	default:
+		return patternTerm{bare: value}, nil
+	}
+}
+
+// matches reports whether a term of a stored triple matches the pattern term
+func (t patternTerm) matches(term canonicalTerm) bool {
+	switch {
+	case t.any:
+		return true
+	case t.exact != nil:
+		return t.exact.serialize(nil) == term.serialize(nil)
+	default:
+		return term.kind != "blank" && term.value == t.bare
+	}
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriplePatternMatcher_MatchTriples(t *testing.T) {
+	document := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<#me> foaf:name "Alice" ;
+    foaf:nick "Al"@en ;
+    foaf:knows <#bob>, _:friend .
+<#bob> foaf:name "Bob" .
+`
+
+	tests := []struct {
+		name    string
+		pattern domain.TriplePattern
+		want    string
+	}{
+		{
+			name:    "bare predicate",
+			pattern: domain.TriplePattern{Predicate: "http://xmlns.com/foaf/0.1/name"},
+			want: "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n" +
+				"<#bob> <http://xmlns.com/foaf/0.1/name> \"Bob\" .\n",
+		},
+		{
+			name:    "subject and predicate",
+			pattern: domain.TriplePattern{Subject: "<#me>", Predicate: "<http://xmlns.com/foaf/0.1/knows>"},
+			want: "<#me> <http://xmlns.com/foaf/0.1/knows> <#bob> .\n" +
+				"<#me> <http://xmlns.com/foaf/0.1/knows> _:friend .\n",
+		},
+		{
+			name:    "bare object matches literal values",
+			pattern: domain.TriplePattern{Object: "Al"},
+			want:    "<#me> <http://xmlns.com/foaf/0.1/nick> \"Al\"@en .\n",
+		},
+		{
+			name:    "exact literal must match its language",
+			pattern: domain.TriplePattern{Object: `"Al"`},
+			want:    "",
+		},
+		{
+			name:    "blank node object",
+			pattern: domain.TriplePattern{Object: "_:friend"},
+			want:    "<#me> <http://xmlns.com/foaf/0.1/knows> _:friend .\n",
+		},
+		{
+			name:    "no match",
+			pattern: domain.TriplePattern{Subject: "<#carol>"},
+			want:    "",
+		},
+	}
+
+	matcher := NewTriplePatternMatcher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := matcher.MatchTriples([]byte(document), tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(matched))
+		})
+	}
+}
+
+func TestTriplePatternMatcher_RejectsMalformedTerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern domain.TriplePattern
+		reason  string
+	}{
+		{"unterminated IRI", domain.TriplePattern{Subject: "<#me"}, "invalid subject"},
+		{"unterminated literal", domain.TriplePattern{Object: `"Alice`}, "invalid object"},
+		{"trailing input", domain.TriplePattern{Predicate: "<#p> <#q>"}, "unexpected input after term"},
+	}
+
+	matcher := NewTriplePatternMatcher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := matcher.MatchTriples([]byte(`<#me> <#p> "x" .`), tt.pattern)
+			require.Error(t, err)
+			assert.True(t, domain.IsInvalidTriplePattern(err))
+			assert.Contains(t, err.Error(), tt.reason)
+		})
+	}
+}
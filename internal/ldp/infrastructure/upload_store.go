@@ -0,0 +1,287 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+)
+
+// FileSystemUploadStore keeps upload sessions in a directory: each session's state in
+// <id>.json and the content received so far in <id>.part. Each session has its own lock, so a
+// slow range being received only holds up requests for that session.
+type FileSystemUploadStore struct {
+	dir string
+	// mu guards sessions and is held while creating and listing sessions, so a listing never
+	// sees a content file whose state is still being written
+	mu       sync.Mutex
+	sessions map[string]*sync.Mutex
+}
+
+// NewFileSystemUploadStore creates an upload store in dir, creating the directory if needed
+func NewFileSystemUploadStore(dir string) (*FileSystemUploadStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("upload directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	return &FileSystemUploadStore{dir: dir, sessions: make(map[string]*sync.Mutex)}, nil
+}
+
+// CreateUpload records a new session with an empty content file
+func (s *FileSystemUploadStore) CreateUpload(ctx context.Context, session domain.UploadSession) error {
+	if !validUploadID(session.ID) {
+		return domain.WrapStorageError(
+			fmt.Errorf("invalid upload ID %q", session.ID),
+			domain.ErrInvalidID.Code,
+			"invalid upload ID",
+		).WithOperation("CreateUpload")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	part, err := os.OpenFile(s.partPath(session.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to create upload").
+			WithOperation("CreateUpload").WithContext("uploadID", session.ID)
+	}
+	part.Close()
+
+	session.Offset = 0
+	if err := s.writeSession(session); err != nil {
+		os.Remove(s.partPath(session.ID))
+		return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to create upload").
+			WithOperation("CreateUpload").WithContext("uploadID", session.ID)
+	}
+	return nil
+}
+
+// GetUpload returns a session
+func (s *FileSystemUploadStore) GetUpload(ctx context.Context, id string) (domain.UploadSession, error) {
+	lock := s.lock(id)
+	defer lock.Unlock()
+
+	return s.readSession(id, "GetUpload")
+}
+
+// AppendUpload writes a chunk to the end of a session's content. The session's offset is
+// advanced by the bytes written even when reading the chunk fails part way, so an interrupted
+// chunk is resumed rather than resent.
+func (s *FileSystemUploadStore) AppendUpload(ctx context.Context, id string, offset int64, chunk io.Reader) (domain.UploadSession, error) {
+	lock := s.lock(id)
+	defer lock.Unlock()
+
+	session, err := s.readSession(id, "AppendUpload")
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+	if offset != session.Offset {
+		return session, domain.WrapStorageError(
+			fmt.Errorf("chunk starts at %d, upload is at %d", offset, session.Offset),
+			domain.ErrUploadOffsetMismatch.Code,
+			domain.ErrUploadOffsetMismatch.Message,
+		).WithOperation("AppendUpload").WithContext("uploadID", id).WithContext("offset", session.Offset)
+	}
+
+	part, err := os.OpenFile(s.partPath(id), os.O_WRONLY, 0644)
+	if err != nil {
+		return session, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to open upload").
+			WithOperation("AppendUpload").WithContext("uploadID", id)
+	}
+	defer part.Close()
+	// Drop anything past the recorded offset left by a write that failed to record itself
+	if err := part.Truncate(session.Offset); err != nil {
+		return session, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to prepare upload").
+			WithOperation("AppendUpload").WithContext("uploadID", id)
+	}
+	if _, err := part.Seek(session.Offset, io.SeekStart); err != nil {
+		return session, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to prepare upload").
+			WithOperation("AppendUpload").WithContext("uploadID", id)
+	}
+
+	// Read one byte past what is left so a chunk running past the declared size is noticed
+	remaining := session.Size - session.Offset
+	written, copyErr := io.Copy(part, io.LimitReader(chunk, remaining+1))
+	if written > remaining {
+		part.Truncate(session.Offset)
+		return session, domain.WrapStorageError(
+			fmt.Errorf("chunk runs past the declared size of %d bytes", session.Size),
+			domain.ErrInvalidResource.Code,
+			"chunk exceeds the upload size",
+		).WithOperation("AppendUpload").WithContext("uploadID", id)
+	}
+	if err := part.Sync(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+
+	session.Offset += written
+	if err := s.writeSession(session); err != nil {
+		session.Offset -= written
+		return session, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to record upload progress").
+			WithOperation("AppendUpload").WithContext("uploadID", id)
+	}
+	if copyErr != nil {
+		return session, domain.WrapStorageError(copyErr, domain.ErrStorageOperation.Code, "failed to receive chunk").
+			WithOperation("AppendUpload").WithContext("uploadID", id).WithContext("offset", session.Offset)
+	}
+	return session, nil
+}
+
+// OpenUpload reads the content a session has received
+func (s *FileSystemUploadStore) OpenUpload(ctx context.Context, id string) (io.ReadCloser, error) {
+	lock := s.lock(id)
+	defer lock.Unlock()
+
+	session, err := s.readSession(id, "OpenUpload")
+	if err != nil {
+		return nil, err
+	}
+	part, err := os.Open(s.partPath(id))
+	if err != nil {
+		return nil, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to open upload").
+			WithOperation("OpenUpload").WithContext("uploadID", id)
+	}
+	// Bytes past the recorded offset were never acknowledged
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(part, session.Offset), part}, nil
+}
+
+// DeleteUpload removes a session's state and content
+func (s *FileSystemUploadStore) DeleteUpload(ctx context.Context, id string) error {
+	if !validUploadID(id) {
+		return nil
+	}
+
+	lock := s.lock(id)
+	defer lock.Unlock()
+
+	for _, path := range []string{s.sessionPath(id), s.partPath(id)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to delete upload").
+				WithOperation("DeleteUpload").WithContext("uploadID", id)
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.sessions, id)
+	s.mu.Unlock()
+	return nil
+}
+
+// ListUploads returns every session, oldest first. Content files left without a session, as
+// by a create interrupted part way, are removed.
+func (s *FileSystemUploadStore) ListUploads(ctx context.Context) ([]domain.UploadSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to list uploads").
+			WithOperation("ListUploads")
+	}
+
+	sessions := []domain.UploadSession{}
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case strings.HasSuffix(name, ".json"):
+			session, err := s.readSession(strings.TrimSuffix(name, ".json"), "ListUploads")
+			if err != nil {
+				continue
+			}
+			sessions = append(sessions, session)
+		case strings.HasSuffix(name, ".part"):
+			if _, err := os.Stat(s.sessionPath(strings.TrimSuffix(name, ".part"))); os.IsNotExist(err) {
+				os.Remove(filepath.Join(s.dir, name))
+			}
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// lock acquires the lock of one session; callers release it with Unlock
+func (s *FileSystemUploadStore) lock(id string) *sync.Mutex {
+	s.mu.Lock()
+	lock, ok := s.sessions[id]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.sessions[id] = lock
+	}
+	s.mu.Unlock()
+
+	lock.Lock()
+	return lock
+}
+
+// readSession loads a session's state; callers hold the session's lock. State is replaced by
+// rename, so ListUploads may read it without the lock.
+func (s *FileSystemUploadStore) readSession(id, operation string) (domain.UploadSession, error) {
+	if !validUploadID(id) {
+		return domain.UploadSession{}, uploadNotFound(fmt.Errorf("invalid upload ID %q", id), operation, id)
+	}
+
+	data, err := os.ReadFile(s.sessionPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.UploadSession{}, uploadNotFound(err, operation, id)
+	}
+	if err != nil {
+		return domain.UploadSession{}, domain.WrapStorageError(err, domain.ErrStorageOperation.Code, "failed to read upload").
+			WithOperation(operation).WithContext("uploadID", id)
+	}
+
+	var session domain.UploadSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return domain.UploadSession{}, domain.WrapStorageError(err, domain.ErrDataCorruption.Code, "invalid upload state").
+			WithOperation(operation).WithContext("uploadID", id)
+	}
+	return session, nil
+}
+
+// writeSession replaces a session's state through a temporary file, so a crash leaves either
+// the old state or the new one; callers hold the session's lock
+func (s *FileSystemUploadStore) writeSession(session domain.UploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	temp := s.sessionPath(session.ID) + ".tmp"
+	if err := os.WriteFile(temp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(temp, s.sessionPath(session.ID))
+}
+
+func (s *FileSystemUploadStore) sessionPath(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *FileSystemUploadStore) partPath(id string) string {
+	return filepath.Join(s.dir, id+".part")
+}
+
+// uploadNotFound reports a session that does not exist
+func uploadNotFound(cause error, operation, id string) error {
+	return domain.WrapStorageError(cause, domain.ErrUploadNotFound.Code, domain.ErrUploadNotFound.Message).
+		WithOperation(operation).WithContext("uploadID", id)
+}
+
+// validUploadID reports whether an ID can name files in the upload directory
+func validUploadID(id string) bool {
+	return id != "" && id != "." && id != ".." && !strings.ContainsAny(id, `/\`)
+}
@@ -0,0 +1,148 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingReader yields data and then fails, like a client connection dropping mid-chunk
+type failingReader struct {
+	data string
+	done bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, errors.New("connection reset")
+	}
+	r.done = true
+	return copy(p, r.data), nil
+}
+
+func TestFileSystemUploadStore(t *testing.T) {
+	store, err := NewFileSystemUploadStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	session := domain.UploadSession{
+		ID:          "upload-1",
+		ResourceID:  "video",
+		ContentType: "video/mp4",
+		Size:        10,
+		CreatedAt:   time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	require.NoError(t, store.CreateUpload(ctx, session))
+	assert.Error(t, store.CreateUpload(ctx, session), "session IDs are not reused")
+
+	t.Run("chunks are appended at the offset", func(t *testing.T) {
+		updated, err := store.AppendUpload(ctx, "upload-1", 0, strings.NewReader("0123"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), updated.Offset)
+		assert.False(t, updated.Received())
+
+		_, err = store.AppendUpload(ctx, "upload-1", 0, strings.NewReader("0123"))
+		storageErr, ok := domain.GetStorageError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrUploadOffsetMismatch.Code, storageErr.Code)
+		assert.Equal(t, int64(4), storageErr.Context["offset"])
+	})
+
+	t.Run("an interrupted chunk keeps what arrived", func(t *testing.T) {
+		updated, err := store.AppendUpload(ctx, "upload-1", 4, &failingReader{data: "45"})
+		require.Error(t, err)
+		assert.Equal(t, int64(6), updated.Offset)
+
+		stored, err := store.GetUpload(ctx, "upload-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), stored.Offset)
+	})
+
+	t.Run("chunks past the declared size are refused", func(t *testing.T) {
+		_, err := store.AppendUpload(ctx, "upload-1", 6, strings.NewReader("6789X"))
+		require.Error(t, err)
+		stored, err := store.GetUpload(ctx, "upload-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), stored.Offset)
+	})
+
+	t.Run("the received content is read back", func(t *testing.T) {
+		updated, err := store.AppendUpload(ctx, "upload-1", 6, strings.NewReader("6789"))
+		require.NoError(t, err)
+		assert.True(t, updated.Received())
+
+		reader, err := store.OpenUpload(ctx, "upload-1")
+		require.NoError(t, err)
+		defer reader.Close()
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(content))
+	})
+
+	t.Run("sessions are listed and deleted", func(t *testing.T) {
+		sessions, err := store.ListUploads(ctx)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "video", sessions[0].ResourceID)
+
+		require.NoError(t, store.DeleteUpload(ctx, "upload-1"))
+		require.NoError(t, store.DeleteUpload(ctx, "upload-1"))
+		_, err = store.GetUpload(ctx, "upload-1")
+		assert.True(t, domain.IsUploadNotFound(err))
+		sessions, err = store.ListUploads(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("a slow chunk holds up only its own session", func(t *testing.T) {
+		for _, id := range []string{"slow", "fast"} {
+			require.NoError(t, store.CreateUpload(ctx, domain.UploadSession{ID: id, ResourceID: id, Size: 4, CreatedAt: time.Now()}))
+		}
+
+		slowChunk, sendRest := io.Pipe()
+		slowDone := make(chan error, 1)
+		go func() {
+			_, err := store.AppendUpload(ctx, "slow", 0, slowChunk)
+			slowDone <- err
+		}()
+		_, err := sendRest.Write([]byte("01"))
+		require.NoError(t, err)
+
+		fastDone := make(chan error, 1)
+		go func() {
+			_, err := store.AppendUpload(ctx, "fast", 0, strings.NewReader("0123"))
+			fastDone <- err
+		}()
+		select {
+		case err := <-fastDone:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("appending to one session waited for a chunk of another")
+		}
+		_, err = store.ListUploads(ctx)
+		require.NoError(t, err)
+
+		_, err = sendRest.Write([]byte("23"))
+		require.NoError(t, err)
+		require.NoError(t, sendRest.Close())
+		require.NoError(t, <-slowDone)
+
+		stored, err := store.GetUpload(ctx, "slow")
+		require.NoError(t, err)
+		assert.True(t, stored.Received())
+	})
+
+	t.Run("IDs cannot leave the upload directory", func(t *testing.T) {
+		_, err := store.GetUpload(ctx, "../upload-1")
+		assert.True(t, domain.IsUploadNotFound(err))
+		assert.Error(t, store.CreateUpload(ctx, domain.UploadSession{ID: "../escape", Size: 1}))
+	})
+}
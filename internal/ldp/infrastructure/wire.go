@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/ldp/domain"
@@ -15,32 +16,50 @@ import (
 var InfrastructureSet = wire.NewSet(
 	DatabaseProvider,
 	EventStoreProvider,
+	NewGormEventLogReader,
 	NewEventDispatcher,
 	NewOptimizedFileSystemRepositoryProvider,
 	NewGORMContainerRepositoryProvider,
 	NewRDFConverter,
-	NewContainerRDFConverter,
+	NewContainerRDFConverterProvider,
 	NewUnitOfWorkFactory,
+	NewSearchIndexProvider,
+	NewResourceACLSourceProvider,
+	NewStorageUsageStoreProvider,
+	NewIdentifierIndexProvider,
+	NewResourceVersionStoreProvider,
+	NewUploadStoreProvider,
 	// Bind interfaces to implementations
 	wire.Bind(new(domain.FormatConverter), new(*RDFConverter)),
 	wire.Bind(new(pericarpdomain.EventStore), new(*pericarpinfra.GormEventStore)),
+	wire.Bind(new(domain.EventLogReader), new(*GormEventLogReader)),
 	wire.Bind(new(domain.ContainerRepository), new(*GORMContainerRepository)),
+	wire.Bind(new(domain.ACLSource), new(*ResourceACLSource)),
 )
 
 // OptimizedInfrastructureSet provides optimized infrastructure dependencies with caching and indexing
 var OptimizedInfrastructureSet = wire.NewSet(
 	DatabaseProvider,
 	EventStoreProvider,
+	NewGormEventLogReader,
 	NewEventDispatcher,
 	NewOptimizedFileSystemRepositoryProvider,
 	NewGORMContainerRepositoryProvider,
 	NewRDFConverter,
-	NewContainerRDFConverter,
+	NewContainerRDFConverterProvider,
 	NewUnitOfWorkFactory,
+	NewSearchIndexProvider,
+	NewResourceACLSourceProvider,
+	NewStorageUsageStoreProvider,
+	NewIdentifierIndexProvider,
+	NewResourceVersionStoreProvider,
+	NewUploadStoreProvider,
 	// Bind interfaces to implementations
 	wire.Bind(new(domain.FormatConverter), new(*RDFConverter)),
 	wire.Bind(new(pericarpdomain.EventStore), new(*pericarpinfra.GormEventStore)),
+	wire.Bind(new(domain.EventLogReader), new(*GormEventLogReader)),
 	wire.Bind(new(domain.ContainerRepository), new(*GORMContainerRepository)),
+	wire.Bind(new(domain.ACLSource), new(*ResourceACLSource)),
 )
 
 // NewUnitOfWorkFactory creates a factory function for creating UnitOfWork instances
@@ -48,11 +67,20 @@ func NewUnitOfWorkFactory(
 	eventStore pericarpdomain.EventStore,
 	eventDispatcher pericarpdomain.EventDispatcher,
 ) func() pericarpdomain.UnitOfWork {
+	dispatcher := eventDispatcher
+	if eventDispatcher != nil {
+		dispatcher = &countingEventDispatcher{EventDispatcher: eventDispatcher}
+	}
 	return func() pericarpdomain.UnitOfWork {
-		return pericarpinfra.UnitOfWorkProvider(eventStore, eventDispatcher)
+		return pericarpinfra.UnitOfWorkProvider(eventStore, dispatcher)
 	}
 }
 
+// NewSearchIndexProvider provides the search index shared by the storage and container services
+func NewSearchIndexProvider() domain.SearchIndex {
+	return NewMemorySearchIndex()
+}
+
 // NewFileSystemContainerRepositoryProvider provides a FileSystemContainerRepository for Wire dependency injection
 func NewFileSystemContainerRepositoryProvider(config *conf.Container) (domain.ContainerRepository, error) {
 	// Set defaults if config is nil
@@ -66,7 +94,10 @@ func NewFileSystemContainerRepositoryProvider(config *conf.Container) (domain.Co
 		return nil, fmt.Errorf("invalid container configuration: %w", err)
 	}
 
-	// Create membership indexer
+	// Create membership indexer, making sure its directory exists first
+	if err := os.MkdirAll(config.IndexPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create index directory: %w", err)
+	}
 	indexer, err := MembershipIndexerProvider(config.IndexPath)
 	if err != nil {
 		return nil, err
@@ -75,6 +106,53 @@ func NewFileSystemContainerRepositoryProvider(config *conf.Container) (domain.Co
 	return NewFileSystemContainerRepository(config.StoragePath, indexer)
 }
 
+// NewContainerRDFConverterProvider provides a ContainerRDFConverter writing JSON-LD with the
+// server's context extended by the configured aliases
+func NewContainerRDFConverterProvider(config *conf.Container) (*ContainerRDFConverter, error) {
+	converter := NewContainerRDFConverter()
+	if config == nil {
+		return converter, nil
+	}
+
+	context, err := NewJSONLDContext(config.JSONLD.Aliases)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON-LD aliases: %w", err)
+	}
+	converter.SetJSONLDContext(context)
+	return converter, nil
+}
+
+// NewStorageUsageStoreProvider provides the store tracking each account's storage usage
+func NewStorageUsageStoreProvider(db *gorm.DB) (domain.StorageUsageStore, error) {
+	return NewGormStorageUsageStore(db)
+}
+
+// NewIdentifierIndexProvider provides the index of resource and container Dublin Core identifiers
+func NewIdentifierIndexProvider(db *gorm.DB) (domain.IdentifierIndex, error) {
+	return NewGormIdentifierIndex(db)
+}
+
+// NewResourceVersionStoreProvider provides the store of earlier RDF resource versions that
+// ?diff= compares, keeping the configured number of versions of each resource
+func NewResourceVersionStoreProvider(db *gorm.DB, config *conf.Container) (domain.ResourceVersionStore, error) {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+
+	return NewGormResourceVersionStore(db, config.MaxResourceVersions)
+}
+
+// NewUploadStoreProvider provides the store keeping resumable uploads until they complete
+func NewUploadStoreProvider(config *conf.Container) (domain.UploadStore, error) {
+	if config == nil {
+		config = &conf.Container{}
+	}
+	config.SetDefaults()
+
+	return NewFileSystemUploadStore(config.Uploads.Path)
+}
+
 // NewGORMContainerRepositoryProvider provides a GORMContainerRepository for Wire dependency injection
 func NewGORMContainerRepositoryProvider(db *gorm.DB) (domain.ContainerRepository, error) {
 	if db == nil {
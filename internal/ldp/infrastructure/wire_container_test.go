@@ -100,7 +100,7 @@ func TestNewFileSystemContainerRepositoryProviderFixed(t *testing.T) {
 
 func TestNewUnitOfWorkFactoryFixed(t *testing.T) {
 	t.Run("creates unit of work factory successfully", func(t *testing.T) {
-		db, err := DatabaseProvider()
+		db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := EventStoreProvider(db)
@@ -116,7 +116,7 @@ func TestNewUnitOfWorkFactoryFixed(t *testing.T) {
 	})
 
 	t.Run("factory creates unit of work instances", func(t *testing.T) {
-		db, err := DatabaseProvider()
+		db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := EventStoreProvider(db)
@@ -140,7 +140,7 @@ func TestNewUnitOfWorkFactoryFixed(t *testing.T) {
 		assert.NotNil(t, factory, "Factory should be created even with nil event store")
 
 		// Test with nil event dispatcher
-		db2, err := DatabaseProvider()
+		db2, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore2, err := EventStoreProvider(db2)
@@ -180,7 +180,7 @@ func TestInfrastructureSetIntegrationFixed(t *testing.T) {
 		require.NoError(t, err)
 
 		// Test individual providers that would be used by Wire
-		db, err := DatabaseProvider()
+		db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := EventStoreProvider(db)
@@ -215,7 +215,7 @@ func TestInfrastructureSetIntegrationFixed(t *testing.T) {
 
 	t.Run("providers create compatible components", func(t *testing.T) {
 		// Test that providers create components that are compatible with each other
-		db, err := DatabaseProvider()
+		db, err := SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 		require.NoError(t, err)
 
 		eventStore, err := EventStoreProvider(db)
@@ -3,6 +3,7 @@ package integration
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/akeemphilbert/goro/internal/ldp/application"
@@ -118,7 +119,7 @@ func TestDataIntegrityAcrossOperations(t *testing.T) {
 // Helper function to create test storage service
 func createTestStorageService(t *testing.T, tempDir string) *application.StorageService {
 	// Create infrastructure dependencies
-	db, err := infrastructure.DatabaseProvider()
+	db, err := infrastructure.SQLiteDatabaseProvider(filepath.Join(t.TempDir(), "events.db"))
 	require.NoError(t, err)
 
 	eventStore, err := infrastructure.EventStoreProvider(db)
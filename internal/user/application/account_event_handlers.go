@@ -50,6 +50,16 @@ func (h *AccountEventHandler) HandleAccountUpdated(ctx context.Context, event *d
 	return nil
 }
 
+// HandleAccountSettingsUpdated handles settings update events by updating the account projection
+func (h *AccountEventHandler) HandleAccountSettingsUpdated(ctx context.Context, event *domain.AccountSettingsUpdatedEventData) error {
+	// Update settings in database
+	if err := h.accountRepo.Update(ctx, event.Account); err != nil {
+		return fmt.Errorf("failed to update account settings: %w", err)
+	}
+
+	return nil
+}
+
 // HandleAccountMemberAdded handles member addition events by creating membership projection
 func (h *AccountEventHandler) HandleAccountMemberAdded(ctx context.Context, event *domain.AccountMemberAddedEventData) error {
 	// Create membership projection in database
@@ -90,6 +100,15 @@ func (h *AccountEventHandler) HandleInvitationCreated(ctx context.Context, event
 	return nil
 }
 
+// HandleMemberInvited handles invitation resend events by storing the new expiry and token
+func (h *AccountEventHandler) HandleMemberInvited(ctx context.Context, event *domain.MemberInvitedEventData) error {
+	if err := h.invitationRepo.Update(ctx, event.Invitation); err != nil {
+		return fmt.Errorf("failed to update resent invitation: %w", err)
+	}
+
+	return nil
+}
+
 // HandleInvitationAccepted handles invitation acceptance events by updating invitation and creating membership
 func (h *AccountEventHandler) HandleInvitationAccepted(ctx context.Context, event *domain.InvitationAcceptedEventData) error {
 	// Update invitation status in database
@@ -124,3 +143,13 @@ func (h *AccountEventHandler) HandleInvitationExpired(ctx context.Context, event
 
 	return nil
 }
+
+// HandleAccountOwnershipTransferred handles ownership transfer events by updating the account projection
+func (h *AccountEventHandler) HandleAccountOwnershipTransferred(ctx context.Context, event *domain.AccountOwnershipTransferredEventData) error {
+	// Update owner in database
+	if err := h.accountRepo.Update(ctx, event.Account); err != nil {
+		return fmt.Errorf("failed to update account owner: %w", err)
+	}
+
+	return nil
+}
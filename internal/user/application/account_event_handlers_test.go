@@ -148,3 +148,19 @@ func TestAccountEventHandler_HandleMemberAdded_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockMemberRepo.AssertExpectations(t)
 }
+
+func TestAccountEventHandler_HandleMemberInvited_UpdatesInvitation(t *testing.T) {
+	mockInvitationRepo := &MockInvitationWriteRepository{}
+	handler := NewAccountEventHandler(&MockAccountWriteRepository{}, &MockAccountMemberWriteRepository{}, mockInvitationRepo, &MockFileStorage{})
+
+	invitation := createTestInvitation("invitation-123", "account-123", "invitee@example.com", "member", "owner-123")
+	mockInvitationRepo.On("Update", mock.Anything, invitation).Return(nil)
+
+	err := handler.HandleMemberInvited(context.Background(), &domain.MemberInvitedEventData{
+		BaseEventData: domain.BaseEventData{OccurredAt: time.Now()},
+		Invitation:    invitation,
+	})
+
+	assert.NoError(t, err)
+	mockInvitationRepo.AssertExpectations(t)
+}
@@ -23,8 +23,35 @@ type AccountService interface {
 	InviteUser(ctx context.Context, accountID, inviterID, email string, roleID string) (*domain.Invitation, error)
 	AcceptInvitation(ctx context.Context, token string, userID string) error
 	UpdateMemberRole(ctx context.Context, accountID, userID string, roleID string) error
+	RemoveMember(ctx context.Context, accountID, userID, removedByID, reason string) error
+	TransferOwnership(ctx context.Context, accountID, currentOwnerID, newOwnerID string) error
+	LeaveAccount(ctx context.Context, accountID, userID, successorID string) error
+	PatchAccountSettings(ctx context.Context, accountID, requesterID string, patch domain.AccountSettingsPatch) (*domain.Account, error)
+	DeliverInvitation(ctx context.Context, invitationID, requesterID string) error
+	ResendInvitation(ctx context.Context, req ResendInvitationRequest) (*domain.Invitation, error)
+	ListMembers(ctx context.Context, accountID string, opts domain.PaginationOptions, roleFilter string) ([]*domain.AccountMember, int, error)
 }
 
+// ResendInvitationRequest identifies a pending invitation to extend and send again
+type ResendInvitationRequest struct {
+	AccountID    string `json:"account_id"`
+	InvitationID string `json:"invitation_id"`
+	// ResentByID is the user resending, taken from the caller's verified identity and never
+	// from request bodies
+	ResentByID string `json:"-"`
+	// RegenerateToken replaces the token, so links from earlier emails stop working
+	RegenerateToken bool `json:"regenerate_token"`
+}
+
+const (
+	// ownerRoleID is the role held by the account owner's membership
+	ownerRoleID = "owner"
+	// formerOwnerRoleID is the role a previous owner keeps after handing over ownership
+	formerOwnerRoleID = "admin"
+	// invitationTTL is how long a sent or resent invitation can be accepted
+	invitationTTL = 7 * 24 * time.Hour
+)
+
 // accountService implements the AccountService interface
 type accountService struct {
 	unitOfWorkFactory func() pericarpdomain.UnitOfWork
@@ -34,6 +61,7 @@ type accountService struct {
 	roleRepo          domain.RoleRepository
 	invitationRepo    domain.InvitationRepository
 	memberRepo        domain.AccountMemberRepository
+	notifier          InvitationNotifier
 }
 
 // NewAccountService creates a new AccountService instance
@@ -54,6 +82,7 @@ func NewAccountService(
 		roleRepo:          roleRepo,
 		invitationRepo:    invitationRepo,
 		memberRepo:        memberRepo,
+		notifier:          NoopInvitationNotifier{},
 	}
 }
 
@@ -104,7 +133,9 @@ func (s *accountService) CreateAccount(ctx context.Context, ownerID string, name
 	return account, nil
 }
 
-// InviteUser invites a user to join an account with a specific role
+// InviteUser invites a user to join an account with a specific role and sends the invitation.
+// When only the delivery fails the stored invitation is returned with an error wrapping
+// ErrInvitationDeliveryFailed, and DeliverInvitation can retry it.
 func (s *accountService) InviteUser(ctx context.Context, accountID, inviterID, email string, roleID string) (*domain.Invitation, error) {
 	// Get account
 	account, err := s.accountRepo.GetByID(ctx, accountID)
@@ -112,6 +143,11 @@ func (s *accountService) InviteUser(ctx context.Context, accountID, inviterID, e
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 
+	// Bound the invitations the account has outstanding
+	if err := s.checkPendingInvitationLimit(ctx, account, 1); err != nil {
+		return nil, err
+	}
+
 	// Get inviter user
 	inviter, err := s.userRepo.GetByID(ctx, inviterID)
 	if err != nil {
@@ -127,7 +163,7 @@ func (s *accountService) InviteUser(ctx context.Context, accountID, inviterID, e
 	// Generate invitation details
 	invitationID := s.inviteGen.GenerateInvitationID()
 	token := s.inviteGen.GenerateToken()
-	expiresAt := time.Now().Add(7 * 24 * time.Hour) // 7 days from now
+	expiresAt := time.Now().Add(invitationTTL)
 
 	// Create invitation entity
 	invitation, err := domain.NewInvitation(ctx, invitationID, token, account, email, role, inviter, expiresAt)
@@ -157,9 +193,93 @@ func (s *accountService) InviteUser(ctx context.Context, accountID, inviterID, e
 	// Mark events as committed
 	invitation.MarkEventsAsCommitted()
 
+	// Send the invitation; a failed delivery keeps the invitation and is reported with it
+	if err := s.notifyInvitation(ctx, invitation); err != nil {
+		return invitation, err
+	}
+
 	return invitation, nil
 }
 
+// ResendInvitation extends a pending invitation by a full validity period, optionally with a new
+// token, and sends it again. Accepted and revoked invitations are refused with
+// ErrInvitationNotPending. As with InviteUser, a failed delivery returns the updated invitation
+// with an error wrapping ErrInvitationDeliveryFailed.
+func (s *accountService) ResendInvitation(ctx context.Context, req ResendInvitationRequest) (*domain.Invitation, error) {
+	invitation, err := s.invitationRepo.GetByID(ctx, req.InvitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation.AccountID != req.AccountID {
+		return nil, fmt.Errorf("invitation not found in account")
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, invitation.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	resentBy, err := s.userRepo.GetByID(ctx, req.ResentByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resending user: %w", err)
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, invitation.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	token := ""
+	if req.RegenerateToken {
+		token = s.inviteGen.GenerateToken()
+	}
+	if err := invitation.Resend(ctx, account, role, resentBy, token, time.Now().Add(invitationTTL)); err != nil {
+		return nil, err
+	}
+
+	unitOfWork := s.unitOfWorkFactory()
+	if events := invitation.UncommittedEvents(); len(events) > 0 {
+		unitOfWork.RegisterEvents(events)
+	}
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		unitOfWork.Rollback()
+		return nil, fmt.Errorf("failed to commit invitation resend: %w", err)
+	}
+	invitation.MarkEventsAsCommitted()
+
+	if err := s.notifyInvitation(ctx, invitation); err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// checkPendingInvitationLimit returns ErrTooManyPendingInvitations when sending the given
+// number of new invitations would take the account past its MaxPendingInvitations. Accepted,
+// revoked and expired invitations do not count.
+func (s *accountService) checkPendingInvitationLimit(ctx context.Context, account *domain.Account, additional int) error {
+	limit := account.Settings.MaxPendingInvitations
+	if limit <= 0 {
+		return nil
+	}
+
+	invitations, err := s.invitationRepo.ListByAccount(ctx, account.ID())
+	if err != nil {
+		return fmt.Errorf("failed to list pending invitations: %w", err)
+	}
+
+	pending := 0
+	for _, invitation := range invitations {
+		if invitation.CanAccept() {
+			pending++
+		}
+	}
+	if pending+additional > limit {
+		return domain.ErrTooManyPendingInvitations
+	}
+	return nil
+}
+
 // AcceptInvitation accepts an invitation and creates account membership
 func (s *accountService) AcceptInvitation(ctx context.Context, token string, userID string) error {
 	// Get invitation by token
@@ -292,6 +412,263 @@ func (s *accountService) UpdateMemberRole(ctx context.Context, accountID, userID
 	return nil
 }
 
+// RemoveMember removes a member from an account. The owner cannot be removed until
+// ownership has been transferred to another member.
+func (s *accountService) RemoveMember(ctx context.Context, accountID, userID, removedByID, reason string) error {
+	// Get account
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// Get user being removed
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Get user performing the removal
+	removedBy, err := s.userRepo.GetByID(ctx, removedByID)
+	if err != nil {
+		return fmt.Errorf("failed to get removing user: %w", err)
+	}
+
+	// Get account member
+	member, err := s.memberRepo.GetByAccountAndUser(ctx, accountID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get account member: %w", err)
+	}
+
+	// Remove member
+	if err := account.RemoveMember(ctx, user, member, removedBy, reason); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return s.commitAccountChanges(ctx, account, nil, "member removal")
+}
+
+// TransferOwnership hands ownership of an account to another existing member. The new
+// owner takes the owner role and the previous owner stays on as an admin.
+func (s *accountService) TransferOwnership(ctx context.Context, accountID, currentOwnerID, newOwnerID string) error {
+	// Get account
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	members, err := s.transferOwnership(ctx, account, currentOwnerID, newOwnerID)
+	if err != nil {
+		return err
+	}
+
+	return s.commitAccountChanges(ctx, account, members, "ownership transfer")
+}
+
+// LeaveAccount removes a user from an account at their own request. An owner must name a
+// successor; ownership is transferred to the successor and the owner removed atomically.
+func (s *accountService) LeaveAccount(ctx context.Context, accountID, userID, successorID string) error {
+	// Get account
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// Get leaving user
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var members []*domain.AccountMember
+	var member *domain.AccountMember
+	if account.OwnerID == userID {
+		if successorID == "" {
+			return fmt.Errorf("failed to leave account: a successor owner is required: %w", domain.ErrCannotRemoveOwner)
+		}
+
+		members, err = s.transferOwnership(ctx, account, userID, successorID)
+		if err != nil {
+			return err
+		}
+		member = members[1]
+	} else {
+		// Get leaving user's membership
+		member, err = s.memberRepo.GetByAccountAndUser(ctx, accountID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get account member: %w", err)
+		}
+	}
+
+	if err := account.RemoveMember(ctx, user, member, user, "left account"); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return s.commitAccountChanges(ctx, account, members, "account departure")
+}
+
+// transferOwnership applies an ownership transfer to the account and both memberships
+// without committing, so callers can combine it with further changes in one unit of work.
+// The returned memberships are the new owner's followed by the previous owner's.
+func (s *accountService) transferOwnership(ctx context.Context, account *domain.Account, currentOwnerID, newOwnerID string) ([]*domain.AccountMember, error) {
+	accountID := account.ID()
+
+	// Get current owner
+	currentOwner, err := s.userRepo.GetByID(ctx, currentOwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current owner: %w", err)
+	}
+
+	// Get new owner
+	newOwner, err := s.userRepo.GetByID(ctx, newOwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get new owner: %w", err)
+	}
+
+	// Get memberships of both users
+	currentOwnerMember, err := s.memberRepo.GetByAccountAndUser(ctx, accountID, currentOwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current owner membership: %w", err)
+	}
+	newOwnerMember, err := s.memberRepo.GetByAccountAndUser(ctx, accountID, newOwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("new owner must be a member of the account: %w", err)
+	}
+
+	// Get roles involved in the swap
+	ownerRole, err := s.roleRepo.GetByID(ctx, ownerRoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner role: %w", err)
+	}
+	formerOwnerRole, err := s.roleRepo.GetByID(ctx, formerOwnerRoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get former owner role: %w", err)
+	}
+	newOwnerOldRole, err := s.roleRepo.GetByID(ctx, newOwnerMember.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get new owner role: %w", err)
+	}
+
+	if err := account.TransferOwnership(ctx, newOwner, newOwnerMember, currentOwner); err != nil {
+		return nil, fmt.Errorf("failed to transfer ownership: %w", err)
+	}
+
+	if err := newOwnerMember.UpdateRole(ctx, account, newOwner, newOwnerOldRole, ownerRole); err != nil {
+		return nil, fmt.Errorf("failed to update new owner role: %w", err)
+	}
+	if err := currentOwnerMember.UpdateRole(ctx, account, currentOwner, ownerRole, formerOwnerRole); err != nil {
+		return nil, fmt.Errorf("failed to update former owner role: %w", err)
+	}
+
+	return []*domain.AccountMember{newOwnerMember, currentOwnerMember}, nil
+}
+
+// PatchAccountSettings merges the provided settings fields into the account's existing
+// settings, leaving unspecified fields untouched. The requester needs a role in the account
+// that may update it; others are refused with ErrInsufficientPermissions.
+func (s *accountService) PatchAccountSettings(ctx context.Context, accountID, requesterID string, patch domain.AccountSettingsPatch) (*domain.Account, error) {
+	if patch.IsEmpty() {
+		return nil, fmt.Errorf("invalid settings patch: no fields provided")
+	}
+
+	// Get account
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := s.requireAccountPermission(ctx, account, requesterID, "account", "update"); err != nil {
+		return nil, err
+	}
+
+	if _, err := account.PatchSettings(ctx, patch); err != nil {
+		return nil, fmt.Errorf("failed to patch account settings: %w", err)
+	}
+
+	if err := s.commitAccountChanges(ctx, account, nil, "account settings update"); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// requireAccountPermission refuses users whose role in an account lacks an account-wide
+// permission. The owner holds every permission.
+func (s *accountService) requireAccountPermission(ctx context.Context, account *domain.Account, userID, resource, action string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: no user on account %s", domain.ErrInsufficientPermissions, account.ID())
+	}
+	if userID == account.OwnerID {
+		return nil
+	}
+
+	member, err := s.memberRepo.GetByAccountAndUser(ctx, account.ID(), userID)
+	if err != nil || member == nil {
+		return fmt.Errorf("%w: user %s is not a member of account %s", domain.ErrInsufficientPermissions, userID, account.ID())
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, member.RoleID)
+	if err != nil {
+		return fmt.Errorf("failed to get role: %w", err)
+	}
+	if role == nil || !role.HasPermission(resource, action, "account") {
+		return fmt.Errorf("%w: %s %s on account %s", domain.ErrInsufficientPermissions, resource, action, account.ID())
+	}
+
+	return nil
+}
+
+// ListMembers returns one page of an account's members, oldest first, optionally only those
+// holding roleFilter, and how many members match in all so callers can page through them
+func (s *accountService) ListMembers(ctx context.Context, accountID string, opts domain.PaginationOptions, roleFilter string) ([]*domain.AccountMember, int, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, 0, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	members, total, err := s.memberRepo.ListByAccountPaged(ctx, accountID, opts, roleFilter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list account members: %w", err)
+	}
+
+	return members, total, nil
+}
+
+// commitAccountChanges registers the uncommitted events of an account and any touched
+// memberships with a single unit of work and commits them together
+func (s *accountService) commitAccountChanges(ctx context.Context, account *domain.Account, members []*domain.AccountMember, operation string) error {
+	// Create unit of work for event processing
+	unitOfWork := s.unitOfWorkFactory()
+
+	allEvents := append([]domain.Event{}, account.UncommittedEvents()...)
+	for _, member := range members {
+		allEvents = append(allEvents, member.UncommittedEvents()...)
+	}
+
+	if len(allEvents) > 0 {
+		unitOfWork.RegisterEvents(allEvents)
+	}
+
+	// Commit unit of work - this persists events and dispatches them
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		// Rollback on failure
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			// Log rollback error but return original error
+		}
+		return fmt.Errorf("failed to commit %s: %w", operation, err)
+	}
+
+	// Mark events as committed
+	account.MarkEventsAsCommitted()
+	for _, member := range members {
+		member.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
 // Helper functions
 func generateAccountID() string {
 	return uuid.New().String()
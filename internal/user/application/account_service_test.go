@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -131,6 +132,14 @@ func (m *MockAccountMemberRepository) ListByAccount(ctx context.Context, account
 	return args.Get(0).([]*domain.AccountMember), args.Error(1)
 }
 
+func (m *MockAccountMemberRepository) ListByAccountPaged(ctx context.Context, accountID string, opts domain.PaginationOptions, roleFilter string) ([]*domain.AccountMember, int, error) {
+	args := m.Called(ctx, accountID, opts, roleFilter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.AccountMember), args.Int(1), args.Error(2)
+}
+
 func (m *MockAccountMemberRepository) ListByUser(ctx context.Context, userID string) ([]*domain.AccountMember, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -439,6 +448,47 @@ func TestAccountService_InviteUser_InvalidRole(t *testing.T) {
 	mockRoleRepo.AssertExpectations(t)
 }
 
+func TestAccountService_InviteUser_TooManyPendingInvitations(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	account.Settings.MaxPendingInvitations = 2
+
+	pending := createTestInvitation("pending-1", accountID, "a@example.com", "member", "owner-id")
+	accepted := createTestInvitation("accepted-1", accountID, "b@example.com", "member", "owner-id")
+	accepted.Status = domain.InvitationStatusAccepted
+	expired := createTestInvitation("expired-1", accountID, "c@example.com", "member", "owner-id")
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	stillPending := createTestInvitation("pending-2", accountID, "d@example.com", "member", "owner-id")
+
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockInvitationRepo.On("ListByAccount", ctx, accountID).Return([]*domain.Invitation{pending, accepted, expired, stillPending}, nil)
+
+	// Act
+	invitation, err := service.InviteUser(ctx, accountID, "inviter-user-id", "invitee@example.com", "member")
+
+	// Assert: two pending invitations already reach the limit; accepted and expired ones do not count
+	assert.ErrorIs(t, err, domain.ErrTooManyPendingInvitations)
+	assert.Nil(t, invitation)
+	mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
 // Test AcceptInvitation method
 
 func TestAccountService_AcceptInvitation_Success(t *testing.T) {
@@ -723,3 +773,367 @@ func TestAccountService_InviteUser_EmitsCorrectEvents(t *testing.T) {
 	mockInviteGen.AssertExpectations(t)
 	mockUnitOfWork.AssertExpectations(t)
 }
+
+// Test owner removal and LeaveAccount
+
+func TestAccountService_RemoveMember_OwnerRequiresTransfer(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	owner := createTestUser("owner-id", "owner@example.com", "Owner User")
+	ownerMember := createTestAccountMember("owner-member-id", accountID, "owner-id", "owner")
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockUserRepo.On("GetByID", ctx, "owner-id").Return(owner, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "owner-id").Return(ownerMember, nil)
+
+	// Act
+	err := service.RemoveMember(ctx, accountID, "owner-id", "owner-id", "cleanup")
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrCannotRemoveOwner)
+	assert.Contains(t, err.Error(), "transfer ownership first")
+	assert.Equal(t, "owner-id", account.OwnerID)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestAccountService_LeaveAccount_OwnerWithoutSuccessor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	owner := createTestUser("owner-id", "owner@example.com", "Owner User")
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockUserRepo.On("GetByID", ctx, "owner-id").Return(owner, nil)
+
+	// Act
+	err := service.LeaveAccount(ctx, accountID, "owner-id", "")
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrCannotRemoveOwner)
+	assert.Equal(t, "owner-id", account.OwnerID)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestAccountService_LeaveAccount_OwnerWithSuccessor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	owner := createTestUser("owner-id", "owner@example.com", "Owner User")
+	successor := createTestUser("successor-id", "successor@example.com", "Successor User")
+	ownerMember := createTestAccountMember("owner-member-id", accountID, "owner-id", "owner")
+	successorMember := createTestAccountMember("successor-member-id", accountID, "successor-id", "member")
+
+	var registered []domain.Event
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockUserRepo.On("GetByID", ctx, "owner-id").Return(owner, nil)
+	mockUserRepo.On("GetByID", ctx, "successor-id").Return(successor, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "owner-id").Return(ownerMember, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "successor-id").Return(successorMember, nil)
+	mockRoleRepo.On("GetByID", ctx, "owner").Return(createTestRole("owner", "Owner"), nil)
+	mockRoleRepo.On("GetByID", ctx, "admin").Return(createTestRole("admin", "Admin"), nil)
+	mockRoleRepo.On("GetByID", ctx, "member").Return(createTestRole("member", "Member"), nil)
+	mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Run(func(args mock.Arguments) {
+		registered = args.Get(0).([]domain.Event)
+	}).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	// Act
+	err := service.LeaveAccount(ctx, accountID, "owner-id", "successor-id")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "successor-id", account.OwnerID)
+	assert.Equal(t, "owner", successorMember.RoleID)
+
+	// Transfer and removal are committed together in one unit of work
+	eventTypes := make([]string, 0, len(registered))
+	for _, event := range registered {
+		eventTypes = append(eventTypes, event.EventType())
+	}
+	assert.Contains(t, eventTypes, "account."+domain.EventTypeAccountOwnershipTransferred)
+	assert.Contains(t, eventTypes, "account."+domain.EventTypeAccountMemberRemoved)
+	mockUnitOfWork.AssertNumberOfCalls(t, "Commit", 1)
+	assert.Empty(t, account.UncommittedEvents())
+}
+
+func TestAccountService_LeaveAccount_SuccessorMustBeMember(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	owner := createTestUser("owner-id", "owner@example.com", "Owner User")
+	outsider := createTestUser("outsider-id", "outsider@example.com", "Outsider User")
+	ownerMember := createTestAccountMember("owner-member-id", accountID, "owner-id", "owner")
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockUserRepo.On("GetByID", ctx, "owner-id").Return(owner, nil)
+	mockUserRepo.On("GetByID", ctx, "outsider-id").Return(outsider, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "owner-id").Return(ownerMember, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "outsider-id").Return(nil, errors.New("member not found"))
+
+	// Act
+	err := service.LeaveAccount(ctx, accountID, "owner-id", "outsider-id")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "new owner must be a member")
+	assert.Equal(t, "owner-id", account.OwnerID)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestAccountService_PatchAccountSettings_MergesProvidedFields(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	allowInvitations := false
+
+	var registered []domain.Event
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Run(func(args mock.Arguments) {
+		registered = args.Get(0).([]domain.Event)
+	}).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	// Act
+	updated, err := service.PatchAccountSettings(ctx, accountID, "owner-id", domain.AccountSettingsPatch{AllowInvitations: &allowInvitations})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, updated.Settings.AllowInvitations)
+	assert.Equal(t, 100, updated.Settings.MaxMembers)
+	assert.Equal(t, "member", updated.Settings.DefaultRoleID)
+
+	require.Len(t, registered, 1)
+	assert.Equal(t, "account."+domain.EventTypeAccountSettingsUpdated, registered[0].EventType())
+	var data domain.AccountSettingsUpdatedEventData
+	require.NoError(t, json.Unmarshal(registered[0].Payload(), &data))
+	assert.Equal(t, []string{"allow_invitations"}, data.ChangedFields)
+}
+
+func TestAccountService_PatchAccountSettings_RejectsInvalidResult(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	maxMembers := -1
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+
+	// Act
+	_, err := service.PatchAccountSettings(ctx, accountID, "owner-id", domain.AccountSettingsPatch{MaxMembers: &maxMembers})
+	_, emptyErr := service.PatchAccountSettings(ctx, accountID, "owner-id", domain.AccountSettingsPatch{})
+
+	// Assert
+	require.Error(t, err)
+	require.Error(t, emptyErr)
+	assert.Equal(t, 100, account.Settings.MaxMembers)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestAccountService_PatchAccountSettings_RequiresUpdatePermission(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewAccountService(unitOfWorkFactory, &MockInvitationGenerator{}, mockAccountRepo, &MockUserRepository{}, mockRoleRepo, &MockInvitationRepository{}, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	allowInvitations := false
+
+	viewer, err := domain.NewRole(ctx, "viewer", "Viewer", "Read-only access", []domain.Permission{
+		{Resource: "resource", Action: "read", Scope: "account"},
+	})
+	require.NoError(t, err)
+	admin, err := domain.NewRole(ctx, "admin", "Administrator", "Administrative access", []domain.Permission{
+		{Resource: "account", Action: "update", Scope: "account"},
+	})
+	require.NoError(t, err)
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "viewer-id").Return(&domain.AccountMember{AccountID: accountID, UserID: "viewer-id", RoleID: "viewer"}, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "admin-id").Return(&domain.AccountMember{AccountID: accountID, UserID: "admin-id", RoleID: "admin"}, nil)
+	mockMemberRepo.On("GetByAccountAndUser", ctx, accountID, "stranger-id").Return(nil, errors.New("account member not found"))
+	mockRoleRepo.On("GetByID", ctx, "viewer").Return(viewer, nil)
+	mockRoleRepo.On("GetByID", ctx, "admin").Return(admin, nil)
+	mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	// Act
+	patch := domain.AccountSettingsPatch{AllowInvitations: &allowInvitations}
+	_, viewerErr := service.PatchAccountSettings(ctx, accountID, "viewer-id", patch)
+	_, strangerErr := service.PatchAccountSettings(ctx, accountID, "stranger-id", patch)
+	_, anonymousErr := service.PatchAccountSettings(ctx, accountID, "", patch)
+	assert.True(t, account.Settings.AllowInvitations)
+	updated, adminErr := service.PatchAccountSettings(ctx, accountID, "admin-id", patch)
+
+	// Assert
+	assert.ErrorIs(t, viewerErr, domain.ErrInsufficientPermissions)
+	assert.ErrorIs(t, strangerErr, domain.ErrInsufficientPermissions)
+	assert.ErrorIs(t, anonymousErr, domain.ErrInsufficientPermissions)
+	require.NoError(t, adminErr)
+	assert.False(t, updated.Settings.AllowInvitations)
+	mockUnitOfWork.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestAccountService_ListMembers_ReturnsPageAndTotal(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockAccountRepo := &MockAccountRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return &MockUnitOfWork{}
+	}
+
+	service := NewAccountService(unitOfWorkFactory, &MockInvitationGenerator{}, mockAccountRepo, &MockUserRepository{}, &MockRoleRepository{}, &MockInvitationRepository{}, mockMemberRepo)
+
+	accountID := "test-account-id"
+	account := createTestAccount(accountID, "owner-id", "Test Account")
+	opts := domain.PaginationOptions{Limit: 2, Offset: 2}
+	page := []*domain.AccountMember{
+		{AccountID: accountID, UserID: "user-3", RoleID: "admin"},
+		{AccountID: accountID, UserID: "user-4", RoleID: "admin"},
+	}
+
+	// Mock expectations
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+	mockMemberRepo.On("ListByAccountPaged", ctx, accountID, opts, "admin").Return(page, 7, nil)
+
+	// Act
+	members, total, err := service.ListMembers(ctx, accountID, opts, "admin")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, page, members)
+	assert.Equal(t, 7, total)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func TestAccountService_ListMembers_RejectsInvalidPagination(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockAccountRepo := &MockAccountRepository{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return &MockUnitOfWork{}
+	}
+
+	service := NewAccountService(unitOfWorkFactory, &MockInvitationGenerator{}, mockAccountRepo, &MockUserRepository{}, &MockRoleRepository{}, &MockInvitationRepository{}, mockMemberRepo)
+
+	// Act
+	_, _, err := service.ListMembers(ctx, "test-account-id", domain.PaginationOptions{Limit: -1}, "")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid pagination")
+	mockMemberRepo.AssertNotCalled(t, "ListByAccountPaged", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+
+	ldpdomain "github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// AccountDeletionStatusPolicy resolves whether an account's resources may be served from its
+// deletion status
+type AccountDeletionStatusPolicy struct {
+	accountRepo domain.AccountRepository
+}
+
+// NewAccountDeletionStatusPolicy creates a new AccountDeletionStatusPolicy instance
+func NewAccountDeletionStatusPolicy(accountRepo domain.AccountRepository) *AccountDeletionStatusPolicy {
+	return &AccountDeletionStatusPolicy{
+		accountRepo: accountRepo,
+	}
+}
+
+// AccountStatus returns where the account stands in its deletion. Unknown accounts are active,
+// so a lookup failure never hides resources.
+func (p *AccountDeletionStatusPolicy) AccountStatus(ctx context.Context, accountID string) ldpdomain.AccountStatus {
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil || account == nil {
+		return ldpdomain.AccountActive
+	}
+	switch account.DeletionStatus {
+	case domain.AccountDeletionPurging:
+		return ldpdomain.AccountDeleted
+	case domain.AccountDeletionPurged:
+		return ldpdomain.AccountPurged
+	}
+	return ldpdomain.AccountActive
+}
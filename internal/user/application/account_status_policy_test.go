@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ldpdomain "github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDeletionStatusPolicy(t *testing.T) {
+	ctx := context.Background()
+	owner, member, account, _ := newAuditTestFixture(t)
+	accountRepo := &MockAccountRepository{}
+	accountRepo.On("GetByID", mock.Anything, "account-1").Return(account, nil)
+	accountRepo.On("GetByID", mock.Anything, "unknown").Return(nil, fmt.Errorf("account not found"))
+	policy := NewAccountDeletionStatusPolicy(accountRepo)
+
+	assert.Equal(t, ldpdomain.AccountActive, policy.AccountStatus(ctx, "account-1"))
+	assert.Equal(t, ldpdomain.AccountActive, policy.AccountStatus(ctx, "unknown"))
+
+	require.Error(t, account.MarkPurged(ctx), "only a deleted account can be purged")
+	require.Error(t, account.MarkDeleted(ctx, member), "only the owner deletes the account")
+	require.NoError(t, account.MarkDeleted(ctx, owner))
+	assert.Equal(t, ldpdomain.AccountDeleted, policy.AccountStatus(ctx, "account-1"))
+
+	require.NoError(t, account.MarkPurged(ctx))
+	assert.Equal(t, ldpdomain.AccountPurged, policy.AccountStatus(ctx, "account-1"))
+}
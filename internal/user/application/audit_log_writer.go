@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// auditedEventTypes are the account and invitation events recorded in the audit trail
+var auditedEventTypes = []string{
+	"account." + domain.EventTypeAccountCreated,
+	"account." + domain.EventTypeAccountSettingsUpdated,
+	"account." + domain.EventTypeAccountMemberAdded,
+	"account." + domain.EventTypeAccountMemberRemoved,
+	"account." + domain.EventTypeAccountMemberRoleUpdated,
+	"account." + domain.EventTypeAccountOwnershipTransferred,
+	"account." + domain.EventTypeAccountDeleted,
+	"invitation." + domain.EventTypeInvitationCreated,
+	"invitation." + domain.EventTypeInvitationAccepted,
+	"invitation." + domain.EventTypeInvitationRevoked,
+	"invitation." + domain.EventTypeInvitationExpired,
+	"invitation." + domain.EventTypeMemberInvited,
+}
+
+// auditUser is the part of a user in an event payload the audit trail keeps. Entity IDs are
+// not serialized with events, so users are identified by their WebID.
+type auditUser struct {
+	WebID string `json:"webid"`
+}
+
+// auditPayload holds the fields of the account and invitation event payloads that name the
+// actor, the target and the account
+type auditPayload struct {
+	Owner         *auditUser `json:"owner"`
+	User          *auditUser `json:"user"`
+	NewOwner      *auditUser `json:"new_owner"`
+	AddedBy       *auditUser `json:"added_by"`
+	RemovedBy     *auditUser `json:"removed_by"`
+	UpdatedBy     *auditUser `json:"updated_by"`
+	TransferredBy *auditUser `json:"transferred_by"`
+	InvitedBy     *auditUser `json:"invited_by"`
+	RevokedBy     *auditUser `json:"revoked_by"`
+	DeletedBy     *auditUser `json:"deleted_by"`
+	Invitation    *struct {
+		AccountID string `json:"account_id"`
+		Email     string `json:"email"`
+	} `json:"invitation"`
+}
+
+// AuditLogWriter records account management events in the append-only audit trail.
+// Unlike the notification projection it writes synchronously and reports failures, so a
+// dispatcher that retries failed handlers does not lose audit entries.
+type AuditLogWriter struct {
+	auditRepo domain.AuditLogRepository
+}
+
+// NewAuditLogWriter creates an audit log writer appending to auditRepo
+func NewAuditLogWriter(auditRepo domain.AuditLogRepository) *AuditLogWriter {
+	return &AuditLogWriter{auditRepo: auditRepo}
+}
+
+// EventTypes returns the event types this writer handles
+func (w *AuditLogWriter) EventTypes() []string {
+	return append([]string(nil), auditedEventTypes...)
+}
+
+// Handle records the event carried by the envelope. The envelope's event ID keys the entry,
+// so an event delivered twice is recorded once.
+func (w *AuditLogWriter) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	return w.record(ctx, envelope.EventID(), envelope.Event())
+}
+
+// Record records a bare event, for dispatchers that hand over events rather than envelopes
+func (w *AuditLogWriter) Record(ctx context.Context, event pericarpdomain.Event) error {
+	return w.record(ctx, "", event)
+}
+
+// record appends the audit entry for an event. Events that are not audited are ignored, and
+// malformed ones are logged and skipped rather than retried.
+func (w *AuditLogWriter) record(ctx context.Context, entryID string, event pericarpdomain.Event) error {
+	entry, ok := auditEntryFor(event)
+	if !ok {
+		return nil
+	}
+	if entry.AccountID == "" {
+		log.Context(ctx).Warnf("Skipping audit entry for %s event on %s without an account", event.EventType(), event.AggregateID())
+		return nil
+	}
+
+	if entryID == "" {
+		entryID = uuid.New().String()
+	}
+	entry.ID = entryID
+
+	if err := w.auditRepo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record %s event in audit log: %w", event.EventType(), err)
+	}
+	return nil
+}
+
+// auditEntryFor builds the audit entry for an audited event, without its ID
+func auditEntryFor(event pericarpdomain.Event) (*domain.AuditEntry, bool) {
+	action := event.EventType()
+	if !isAuditedEventType(action) {
+		return nil, false
+	}
+
+	// An unreadable payload still records the action against the account raising it, only
+	// without actor and target
+	var payload auditPayload
+	if err := json.Unmarshal(event.Payload(), &payload); err != nil {
+		payload = auditPayload{}
+	}
+
+	entry := &domain.AuditEntry{
+		AccountID:  event.AggregateID(),
+		Action:     action,
+		TargetType: domain.AuditTargetAccount,
+		Target:     event.AggregateID(),
+		OccurredAt: event.CreatedAt(),
+	}
+
+	switch action {
+	case "account." + domain.EventTypeAccountCreated:
+		entry.Actor = webIDOf(payload.Owner)
+	case "account." + domain.EventTypeAccountSettingsUpdated:
+		// Settings changes carry no actor
+	case "account." + domain.EventTypeAccountMemberAdded:
+		entry.Actor = webIDOf(payload.AddedBy)
+		entry.TargetType, entry.Target = domain.AuditTargetUser, webIDOf(payload.User)
+	case "account." + domain.EventTypeAccountMemberRemoved:
+		entry.Actor = webIDOf(payload.RemovedBy)
+		entry.TargetType, entry.Target = domain.AuditTargetUser, webIDOf(payload.User)
+	case "account." + domain.EventTypeAccountMemberRoleUpdated:
+		entry.Actor = webIDOf(payload.UpdatedBy)
+		entry.TargetType, entry.Target = domain.AuditTargetUser, webIDOf(payload.User)
+	case "account." + domain.EventTypeAccountOwnershipTransferred:
+		entry.Actor = webIDOf(payload.TransferredBy)
+		entry.TargetType, entry.Target = domain.AuditTargetUser, webIDOf(payload.NewOwner)
+	case "account." + domain.EventTypeAccountDeleted:
+		entry.Actor = webIDOf(payload.DeletedBy)
+	default:
+		// Invitation events are raised by the invitation, which names its account
+		if payload.Invitation == nil {
+			entry.AccountID = ""
+			return entry, true
+		}
+		entry.AccountID = payload.Invitation.AccountID
+		entry.TargetType, entry.Target = domain.AuditTargetInvitation, payload.Invitation.Email
+
+		switch action {
+		case "invitation." + domain.EventTypeInvitationCreated, "invitation." + domain.EventTypeMemberInvited:
+			entry.Actor = webIDOf(payload.InvitedBy)
+		case "invitation." + domain.EventTypeInvitationAccepted:
+			entry.Actor = webIDOf(payload.User)
+		case "invitation." + domain.EventTypeInvitationRevoked:
+			entry.Actor = webIDOf(payload.RevokedBy)
+		}
+	}
+
+	return entry, true
+}
+
+// isAuditedEventType reports whether events of the type belong in the audit trail
+func isAuditedEventType(eventType string) bool {
+	for _, audited := range auditedEventTypes {
+		if eventType == audited {
+			return true
+		}
+	}
+	return false
+}
+
+// webIDOf returns the WebID of a user named in a payload, or "" when it names none
+func webIDOf(user *auditUser) string {
+	if user == nil {
+		return ""
+	}
+	return user.WebID
+}
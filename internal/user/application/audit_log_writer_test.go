@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditLogRepository records appended audit entries, keeping the first entry per ID
+type memoryAuditLogRepository struct {
+	mu      sync.Mutex
+	entries []*domain.AuditEntry
+}
+
+func (r *memoryAuditLogRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.entries {
+		if existing.ID == entry.ID {
+			return nil
+		}
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *memoryAuditLogRepository) ListAuditEntries(ctx context.Context, accountID string, timeRange domain.AuditTimeRange, opts domain.PaginationOptions) ([]*domain.AuditEntry, int, error) {
+	return nil, 0, nil
+}
+
+// idEnvelope wraps an event with its own envelope ID
+type idEnvelope struct {
+	id    string
+	event pericarpdomain.Event
+}
+
+func (e idEnvelope) Event() pericarpdomain.Event      { return e.event }
+func (e idEnvelope) Metadata() map[string]interface{} { return map[string]interface{}{} }
+func (e idEnvelope) EventID() string                  { return e.id }
+func (e idEnvelope) Timestamp() time.Time             { return e.event.CreatedAt() }
+
+func newAuditTestFixture(t *testing.T) (*domain.User, *domain.User, *domain.Account, *domain.Role) {
+	ctx := context.Background()
+	owner, err := domain.NewUser(ctx, "owner-1", "https://pod.example.com/owner#me", "owner@example.com", domain.UserProfile{Name: "Owner"})
+	require.NoError(t, err)
+	member, err := domain.NewUser(ctx, "member-1", "https://pod.example.com/member#me", "member@example.com", domain.UserProfile{Name: "Member"})
+	require.NoError(t, err)
+	account, err := domain.NewAccount(ctx, "account-1", owner, "Team", "")
+	require.NoError(t, err)
+	role, err := domain.NewRole(ctx, "member", "Member", "Account member", []domain.Permission{{Resource: "resource", Action: "read", Scope: "account"}})
+	require.NoError(t, err)
+	return owner, member, account, role
+}
+
+func TestAuditLogWriter_RecordsMemberRemoval(t *testing.T) {
+	owner, member, account, role := newAuditTestFixture(t)
+	membership, err := domain.NewAccountMember(context.Background(), "membership-1", account, member, role, owner, time.Now())
+	require.NoError(t, err)
+
+	repo := &memoryAuditLogRepository{}
+	writer := NewAuditLogWriter(repo)
+	event := domain.NewAccountMemberRemovedEvent(account, member, membership, owner, "left the team")
+
+	require.NoError(t, writer.Handle(context.Background(), idEnvelope{id: "event-1", event: event}))
+	// A redelivered event is recorded once
+	require.NoError(t, writer.Handle(context.Background(), idEnvelope{id: "event-1", event: event}))
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, "event-1", entry.ID)
+	assert.Equal(t, "account-1", entry.AccountID)
+	assert.Equal(t, "account.member_removed", entry.Action)
+	assert.Equal(t, "https://pod.example.com/owner#me", entry.Actor)
+	assert.Equal(t, domain.AuditTargetUser, entry.TargetType)
+	assert.Equal(t, "https://pod.example.com/member#me", entry.Target)
+	assert.Equal(t, event.CreatedAt(), entry.OccurredAt)
+}
+
+func TestAuditLogWriter_RecordsInvitationUnderItsAccount(t *testing.T) {
+	owner, _, account, role := newAuditTestFixture(t)
+	invitation, err := domain.NewInvitation(context.Background(), "invitation-1", "token", account, "new@example.com", role, owner, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	repo := &memoryAuditLogRepository{}
+	writer := NewAuditLogWriter(repo)
+
+	require.NoError(t, writer.Record(context.Background(), domain.NewInvitationCreatedEvent(invitation, account, role, owner)))
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, "account-1", entry.AccountID)
+	assert.Equal(t, "invitation.created", entry.Action)
+	assert.Equal(t, "https://pod.example.com/owner#me", entry.Actor)
+	assert.Equal(t, domain.AuditTargetInvitation, entry.TargetType)
+	assert.Equal(t, "new@example.com", entry.Target)
+}
+
+func TestAuditLogWriter_RecordsAccountDeletion(t *testing.T) {
+	owner, _, account, _ := newAuditTestFixture(t)
+	require.NoError(t, account.MarkDeleted(context.Background(), owner))
+
+	repo := &memoryAuditLogRepository{}
+	writer := NewAuditLogWriter(repo)
+	events := account.UncommittedEvents()
+	require.NoError(t, writer.Record(context.Background(), events[len(events)-1]))
+
+	require.Len(t, repo.entries, 1)
+	assert.Equal(t, "account.deleted", repo.entries[0].Action)
+	assert.Equal(t, "https://pod.example.com/owner#me", repo.entries[0].Actor)
+	assert.Equal(t, domain.AuditTargetAccount, repo.entries[0].TargetType)
+}
+
+func TestAuditLogWriter_IgnoresEventsOutsideAccountManagement(t *testing.T) {
+	_, member, _, _ := newAuditTestFixture(t)
+
+	repo := &memoryAuditLogRepository{}
+	writer := NewAuditLogWriter(repo)
+
+	require.NoError(t, writer.Record(context.Background(), domain.NewUserActivatedEvent(member)))
+	assert.Empty(t, repo.entries)
+}
+
+// subscriptionRecorder records the event types handlers subscribe to
+type subscriptionRecorder struct {
+	SimpleMockEventDispatcher
+	subscribed []string
+}
+
+func (d *subscriptionRecorder) Subscribe(eventType string, handler pericarpdomain.EventHandler) error {
+	d.subscribed = append(d.subscribed, eventType)
+	return nil
+}
+
+func TestEventHandlerRegistrar_RegisterAuditLogWriter(t *testing.T) {
+	dispatcher := &subscriptionRecorder{}
+	writer := NewAuditLogWriter(&memoryAuditLogRepository{})
+
+	require.NoError(t, NewEventHandlerRegistrar(dispatcher).RegisterAuditLogWriter(writer))
+	assert.Equal(t, writer.EventTypes(), dispatcher.subscribed)
+	assert.Contains(t, dispatcher.subscribed, "account.member_role_updated")
+	assert.Contains(t, dispatcher.subscribed, "invitation.revoked")
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+
+	ldpdomain "github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// RoleContainerAccess resolves the access account members hold on the containers of the
+// account's pod from the account-wide permissions of their role. Roles limited to their own
+// resources grant no access to containers, which have no owner of their own.
+type RoleContainerAccess struct {
+	memberRepo domain.AccountMemberRepository
+	roleRepo   domain.RoleRepository
+}
+
+// NewRoleContainerAccess creates a new RoleContainerAccess instance
+func NewRoleContainerAccess(memberRepo domain.AccountMemberRepository, roleRepo domain.RoleRepository) *RoleContainerAccess {
+	return &RoleContainerAccess{
+		memberRepo: memberRepo,
+		roleRepo:   roleRepo,
+	}
+}
+
+// ContainerAccess returns the access a user holds on a container in an account's pod. Users
+// who are not members of the account hold none.
+func (a *RoleContainerAccess) ContainerAccess(ctx context.Context, userID, accountID, containerID string) (ldpdomain.AccessMode, error) {
+	member, err := a.memberRepo.GetByAccountAndUser(ctx, accountID, userID)
+	if err != nil || member == nil {
+		return ldpdomain.AccessMode{}, nil
+	}
+
+	role, err := a.roleRepo.GetByID(ctx, member.RoleID)
+	if err != nil {
+		return ldpdomain.AccessMode{}, err
+	}
+	if role == nil {
+		return ldpdomain.AccessMode{}, nil
+	}
+
+	access := ldpdomain.AccessMode{
+		Read:    role.HasPermission("resource", "read", "account"),
+		Append:  role.HasPermission("resource", "create", "account"),
+		Write:   role.HasPermission("resource", "update", "account") && role.HasPermission("resource", "delete", "account"),
+		Control: role.HasPermission("resource", "control", "account"),
+	}
+	// Write covers adding members as well as changing them
+	access.Append = access.Append || access.Write
+	return access, nil
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	ldpdomain "github.com/akeemphilbert/goro/internal/ldp/domain"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleContainerAccess_ContainerAccess(t *testing.T) {
+	ctx := context.Background()
+	accountID := "acct"
+
+	ownerRole, err := domain.NewRole(ctx, "owner", "Owner", "Full access", []domain.Permission{
+		{Resource: "*", Action: "*", Scope: "account"},
+	})
+	require.NoError(t, err)
+	viewerRole, err := domain.NewRole(ctx, "viewer", "Viewer", "Read-only access", []domain.Permission{
+		{Resource: "resource", Action: "read", Scope: "account"},
+	})
+	require.NoError(t, err)
+	memberRole, err := domain.NewRole(ctx, "member", "Member", "Standard member access", []domain.Permission{
+		{Resource: "resource", Action: "read", Scope: "own"},
+	})
+	require.NoError(t, err)
+
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockMemberRepo.On("GetByAccountAndUser", mock.Anything, accountID, "owner-id").Return(createTestAccountMember("m-1", accountID, "owner-id", "owner"), nil)
+	mockMemberRepo.On("GetByAccountAndUser", mock.Anything, accountID, "viewer-id").Return(createTestAccountMember("m-2", accountID, "viewer-id", "viewer"), nil)
+	mockMemberRepo.On("GetByAccountAndUser", mock.Anything, accountID, "member-id").Return(createTestAccountMember("m-3", accountID, "member-id", "member"), nil)
+	mockMemberRepo.On("GetByAccountAndUser", mock.Anything, accountID, "stranger-id").Return(nil, assert.AnError)
+	mockRoleRepo.On("GetByID", mock.Anything, "owner").Return(ownerRole, nil)
+	mockRoleRepo.On("GetByID", mock.Anything, "viewer").Return(viewerRole, nil)
+	mockRoleRepo.On("GetByID", mock.Anything, "member").Return(memberRole, nil)
+
+	resolver := NewRoleContainerAccess(mockMemberRepo, mockRoleRepo)
+
+	tests := []struct {
+		userID   string
+		expected ldpdomain.AccessMode
+	}{
+		{"owner-id", ldpdomain.AccessMode{Read: true, Append: true, Write: true, Control: true}},
+		{"viewer-id", ldpdomain.AccessMode{Read: true}},
+		{"member-id", ldpdomain.AccessMode{}},
+		{"stranger-id", ldpdomain.AccessMode{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			access, err := resolver.ContainerAccess(ctx, tt.userID, accountID, "acct/docs")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, access)
+		})
+	}
+}
@@ -24,9 +24,11 @@ func (r *EventHandlerRegistrar) RegisterUserEventHandlers(handler *UserEventHand
 		return fmt.Errorf("user event handler cannot be nil")
 	}
 
-	// For now, we'll just store the handler reference
-	// In a full implementation, we would register with the event dispatcher
-	// The actual event registration would depend on the pericarp event system implementation
+	for _, eventType := range handler.EventTypes() {
+		if err := r.eventDispatcher.Subscribe(eventType, handler); err != nil {
+			return fmt.Errorf("failed to subscribe user event handler to event type %s: %w", eventType, err)
+		}
+	}
 
 	return nil
 }
@@ -44,6 +46,52 @@ func (r *EventHandlerRegistrar) RegisterAccountEventHandlers(handler *AccountEve
 	return nil
 }
 
+// RegisterNotificationProjection subscribes the notification projection to container
+// membership events
+func (r *EventHandlerRegistrar) RegisterNotificationProjection(projection *NotificationProjection) error {
+	if projection == nil {
+		return fmt.Errorf("notification projection cannot be nil")
+	}
+
+	for _, eventType := range projection.EventTypes() {
+		if err := r.eventDispatcher.Subscribe(eventType, projection); err != nil {
+			return fmt.Errorf("failed to subscribe notification projection to event type %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// RegisterWebIDProfileSync subscribes the WebID profile sync to user profile updates
+func (r *EventHandlerRegistrar) RegisterWebIDProfileSync(sync *WebIDProfileSync) error {
+	if sync == nil {
+		return fmt.Errorf("WebID profile sync cannot be nil")
+	}
+
+	for _, eventType := range sync.EventTypes() {
+		if err := r.eventDispatcher.Subscribe(eventType, sync); err != nil {
+			return fmt.Errorf("failed to subscribe WebID profile sync to event type %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// RegisterAuditLogWriter subscribes the audit log writer to account and invitation events
+func (r *EventHandlerRegistrar) RegisterAuditLogWriter(writer *AuditLogWriter) error {
+	if writer == nil {
+		return fmt.Errorf("audit log writer cannot be nil")
+	}
+
+	for _, eventType := range writer.EventTypes() {
+		if err := r.eventDispatcher.Subscribe(eventType, writer); err != nil {
+			return fmt.Errorf("failed to subscribe audit log writer to event type %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
 // RegisterAllHandlers registers both user and account event handlers
 func (r *EventHandlerRegistrar) RegisterAllHandlers(
 	userHandler *UserEventHandler,
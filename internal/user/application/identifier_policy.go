@@ -0,0 +1,30 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// AccountIdentifierPolicy reports from its AccountSettings whether an account requires unique
+// Dublin Core identifiers
+type AccountIdentifierPolicy struct {
+	accountRepo domain.AccountRepository
+}
+
+// NewAccountIdentifierPolicy creates a new AccountIdentifierPolicy instance
+func NewAccountIdentifierPolicy(accountRepo domain.AccountRepository) *AccountIdentifierPolicy {
+	return &AccountIdentifierPolicy{
+		accountRepo: accountRepo,
+	}
+}
+
+// UniqueIdentifiers reports whether the account opted in to unique identifiers. Unknown
+// accounts have not.
+func (p *AccountIdentifierPolicy) UniqueIdentifiers(ctx context.Context, accountID string) bool {
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil || account == nil {
+		return false
+	}
+	return account.Settings.UniqueIdentifiers
+}
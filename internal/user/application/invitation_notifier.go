@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// InvitationNotifier delivers an invitation, with its token, to the invited address
+type InvitationNotifier interface {
+	SendInvitation(ctx context.Context, invitation *domain.Invitation) error
+}
+
+// NoopInvitationNotifier drops every invitation; it is the notifier used when none is set
+type NoopInvitationNotifier struct{}
+
+// SendInvitation does nothing
+func (NoopInvitationNotifier) SendInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	return nil
+}
+
+// SetInvitationNotifier sets how invitations are delivered; nil stops delivering them
+func (s *accountService) SetInvitationNotifier(notifier InvitationNotifier) {
+	if notifier == nil {
+		notifier = NoopInvitationNotifier{}
+	}
+	s.notifier = notifier
+}
+
+// DeliverInvitation sends a pending invitation again, for retrying a delivery that failed. The
+// requester needs a role in the invitation's account that may invite users; others are refused
+// with ErrInsufficientPermissions.
+func (s *accountService) DeliverInvitation(ctx context.Context, invitationID, requesterID string) error {
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, invitation.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	if err := s.requireAccountPermission(ctx, account, requesterID, "user", "invite"); err != nil {
+		return err
+	}
+
+	if !invitation.CanAccept() {
+		return domain.ErrInvitationNotPending
+	}
+	return s.notifyInvitation(ctx, invitation)
+}
+
+// notifyInvitation sends a stored invitation. A failure is logged and returned wrapping
+// ErrInvitationDeliveryFailed; the invitation itself is kept.
+func (s *accountService) notifyInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	if s.notifier == nil {
+		return nil
+	}
+	if err := s.notifier.SendInvitation(ctx, invitation); err != nil {
+		log.Context(ctx).Warnf("Failed to deliver invitation %s for account %s: %v", invitation.ID(), invitation.AccountID, err)
+		return fmt.Errorf("%w: %v", domain.ErrInvitationDeliveryFailed, err)
+	}
+	return nil
+}
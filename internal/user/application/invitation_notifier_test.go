@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier records the invitations it is asked to send and fails while err is set
+type recordingNotifier struct {
+	sent []*domain.Invitation
+	err  error
+}
+
+func (n *recordingNotifier) SendInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, invitation)
+	return nil
+}
+
+// newInvitingAccountService returns an account service whose InviteUser succeeds up to delivery
+func newInvitingAccountService(ctx context.Context, notifier InvitationNotifier) (AccountService, *MockInvitationRepository, *MockAccountMemberRepository) {
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	mockInvitationRepo := &MockInvitationRepository{}
+	mockInviteGen := &MockInvitationGenerator{}
+	mockMemberRepo := &MockAccountMemberRepository{}
+
+	mockAccountRepo.On("GetByID", ctx, "account-id").Return(createTestAccount("account-id", "owner-id", "Test Account"), nil)
+	mockUserRepo.On("GetByID", ctx, "inviter-id").Return(createTestUser("inviter-id", "inviter@example.com", "Inviter"), nil)
+	mockRoleRepo.On("GetByID", ctx, "member").Return(createTestRole("member", "Member"), nil)
+	mockInviteGen.On("GenerateInvitationID").Return("invitation-id")
+	mockInviteGen.On("GenerateToken").Return("invitation-token")
+	mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	service := NewAccountService(func() pericarpdomain.UnitOfWork { return mockUnitOfWork },
+		mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, mockMemberRepo)
+	service.(*accountService).SetInvitationNotifier(notifier)
+	return service, mockInvitationRepo, mockMemberRepo
+}
+
+func TestAccountService_InviteUser_SendsInvitation(t *testing.T) {
+	ctx := context.Background()
+	notifier := &recordingNotifier{}
+	service, _, _ := newInvitingAccountService(ctx, notifier)
+
+	invitation, err := service.InviteUser(ctx, "account-id", "inviter-id", "invitee@example.com", "member")
+
+	require.NoError(t, err)
+	require.Len(t, notifier.sent, 1)
+	assert.Same(t, invitation, notifier.sent[0])
+	assert.Equal(t, "invitation-token", notifier.sent[0].Token)
+}
+
+func TestAccountService_InviteUser_DeliveryFailure(t *testing.T) {
+	ctx := context.Background()
+	notifier := &recordingNotifier{err: errors.New("connection refused")}
+	service, mockInvitationRepo, mockMemberRepo := newInvitingAccountService(ctx, notifier)
+
+	invitation, err := service.InviteUser(ctx, "account-id", "inviter-id", "invitee@example.com", "member")
+
+	// The invitation is kept and returned alongside the delivery error
+	assert.ErrorIs(t, err, domain.ErrInvitationDeliveryFailed)
+	assert.Contains(t, err.Error(), "connection refused")
+	require.NotNil(t, invitation)
+	assert.Equal(t, domain.InvitationStatusPending, invitation.Status)
+
+	t.Run("retry delivers the stored invitation", func(t *testing.T) {
+		notifier.err = nil
+		mockInvitationRepo.On("GetByID", ctx, "invitation-id").Return(invitation, nil).Once()
+
+		require.NoError(t, service.DeliverInvitation(ctx, "invitation-id", "owner-id"))
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "invitation-token", notifier.sent[0].Token)
+	})
+
+	t.Run("users outside the account cannot have it delivered", func(t *testing.T) {
+		mockInvitationRepo.On("GetByID", ctx, "invitation-id").Return(invitation, nil).Once()
+		mockMemberRepo.On("GetByAccountAndUser", ctx, "account-id", "mallory").Return(nil, errors.New("not found")).Once()
+
+		assert.ErrorIs(t, service.DeliverInvitation(ctx, "invitation-id", "mallory"), domain.ErrInsufficientPermissions)
+		assert.Len(t, notifier.sent, 1)
+	})
+
+	t.Run("answered invitations are not delivered", func(t *testing.T) {
+		accepted := createTestInvitation("accepted-id", "account-id", "invitee@example.com", "member", "inviter-id")
+		accepted.Status = domain.InvitationStatusAccepted
+		mockInvitationRepo.On("GetByID", ctx, "accepted-id").Return(accepted, nil).Once()
+
+		assert.ErrorIs(t, service.DeliverInvitation(ctx, "accepted-id", "owner-id"), domain.ErrInvitationNotPending)
+		assert.Len(t, notifier.sent, 1)
+	})
+}
+
+func TestAccountService_ResendInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(invitation *domain.Invitation) (AccountService, *recordingNotifier, *MockUnitOfWork) {
+		mockUnitOfWork := &MockUnitOfWork{}
+		mockAccountRepo := &MockAccountRepository{}
+		mockUserRepo := &MockUserRepository{}
+		mockRoleRepo := &MockRoleRepository{}
+		mockInvitationRepo := &MockInvitationRepository{}
+		mockInviteGen := &MockInvitationGenerator{}
+
+		mockInvitationRepo.On("GetByID", ctx, invitation.ID()).Return(invitation, nil)
+		mockAccountRepo.On("GetByID", ctx, "account-id").Return(createTestAccount("account-id", "owner-id", "Test Account"), nil)
+		mockUserRepo.On("GetByID", ctx, "owner-id").Return(createTestUser("owner-id", "owner@example.com", "Owner"), nil)
+		mockRoleRepo.On("GetByID", ctx, "member").Return(createTestRole("member", "Member"), nil)
+		mockInviteGen.On("GenerateToken").Return("fresh-token")
+		mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Return()
+		mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+		notifier := &recordingNotifier{}
+		service := NewAccountService(func() pericarpdomain.UnitOfWork { return mockUnitOfWork },
+			mockInviteGen, mockAccountRepo, mockUserRepo, mockRoleRepo, mockInvitationRepo, &MockAccountMemberRepository{})
+		service.(*accountService).SetInvitationNotifier(notifier)
+		return service, notifier, mockUnitOfWork
+	}
+
+	t.Run("extends an expired invitation and sends it again", func(t *testing.T) {
+		invitation := createTestInvitation("invitation-id", "account-id", "invitee@example.com", "member", "owner-id")
+		invitation.ExpiresAt = time.Now().Add(-time.Hour)
+		service, notifier, mockUnitOfWork := setup(invitation)
+
+		resent, err := service.ResendInvitation(ctx, ResendInvitationRequest{
+			AccountID: "account-id", InvitationID: "invitation-id", ResentByID: "owner-id",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resent.CanAccept())
+		assert.WithinDuration(t, time.Now().Add(invitationTTL), resent.ExpiresAt, time.Minute)
+		assert.Equal(t, "test-token", resent.Token, "the token is kept unless regeneration is asked for")
+		require.Len(t, notifier.sent, 1)
+
+		events := mockUnitOfWork.Calls[0].Arguments.Get(0).([]domain.Event)
+		require.Len(t, events, 1)
+		assert.Equal(t, "invitation."+domain.EventTypeMemberInvited, events[0].EventType())
+	})
+
+	t.Run("regenerates the token on request", func(t *testing.T) {
+		invitation := createTestInvitation("invitation-id", "account-id", "invitee@example.com", "member", "owner-id")
+		service, _, _ := setup(invitation)
+
+		resent, err := service.ResendInvitation(ctx, ResendInvitationRequest{
+			AccountID: "account-id", InvitationID: "invitation-id", ResentByID: "owner-id", RegenerateToken: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "fresh-token", resent.Token)
+	})
+
+	t.Run("refuses answered invitations", func(t *testing.T) {
+		for _, status := range []domain.InvitationStatus{domain.InvitationStatusAccepted, domain.InvitationStatusRevoked} {
+			invitation := createTestInvitation("invitation-id", "account-id", "invitee@example.com", "member", "owner-id")
+			invitation.Status = status
+			service, notifier, mockUnitOfWork := setup(invitation)
+
+			_, err := service.ResendInvitation(ctx, ResendInvitationRequest{
+				AccountID: "account-id", InvitationID: "invitation-id", ResentByID: "owner-id",
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvitationNotPending, string(status))
+			assert.Empty(t, notifier.sent)
+			mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+		}
+	})
+
+	t.Run("refuses invitations of another account", func(t *testing.T) {
+		invitation := createTestInvitation("invitation-id", "other-account", "invitee@example.com", "member", "owner-id")
+		service, _, _ := setup(invitation)
+
+		_, err := service.ResendInvitation(ctx, ResendInvitationRequest{
+			AccountID: "account-id", InvitationID: "invitation-id", ResentByID: "owner-id",
+		})
+		assert.Error(t, err)
+	})
+}
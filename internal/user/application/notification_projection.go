@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// Container membership event types the notification projection subscribes to
+const (
+	containerMemberAddedEventType   = "container.member_added"
+	containerMemberRemovedEventType = "container.member_removed"
+)
+
+// notificationQueueSize bounds the membership changes waiting to be fanned out
+const notificationQueueSize = 1024
+
+// PodAccountResolver maps a container to the account whose pod holds it
+type PodAccountResolver interface {
+	AccountForContainer(ctx context.Context, containerID string) (string, error)
+}
+
+// ContainerPathLookup returns the container IDs from the pod root down to a container
+type ContainerPathLookup interface {
+	GetPath(ctx context.Context, containerID string) ([]string, error)
+}
+
+// RootContainerAccountResolver resolves a container's account from the root of its
+// hierarchy: an account's pod is the top-level container named after the account.
+type RootContainerAccountResolver struct {
+	paths       ContainerPathLookup
+	accountRepo domain.AccountRepository
+}
+
+// NewRootContainerAccountResolver creates a new RootContainerAccountResolver instance
+func NewRootContainerAccountResolver(paths ContainerPathLookup, accountRepo domain.AccountRepository) *RootContainerAccountResolver {
+	return &RootContainerAccountResolver{
+		paths:       paths,
+		accountRepo: accountRepo,
+	}
+}
+
+// AccountForContainer returns the account owning the container's pod. Containers
+// outside an account pod resolve to an empty account ID.
+func (r *RootContainerAccountResolver) AccountForContainer(ctx context.Context, containerID string) (string, error) {
+	path, err := r.paths.GetPath(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if len(path) == 0 {
+		return "", nil
+	}
+
+	account, err := r.accountRepo.GetByID(ctx, path[0])
+	if err != nil || account == nil {
+		return "", nil
+	}
+	return account.ID(), nil
+}
+
+// membershipChange is a container membership event waiting to be fanned out
+type membershipChange struct {
+	accountID   string
+	actorID     string
+	containerID string
+	memberID    string
+	kind        domain.NotificationType
+	occurredAt  time.Time
+}
+
+// NotificationProjection turns container membership events in an account's pod into
+// per-member notifications. Handle only enqueues the change; a background worker
+// resolves recipients and writes the notifications, so delivery never slows down or
+// fails the commit that produced the event.
+type NotificationProjection struct {
+	resolver         PodAccountResolver
+	memberRepo       domain.AccountMemberRepository
+	roleRepo         domain.RoleRepository
+	notificationRepo domain.NotificationRepository
+	queue            chan membershipChange
+	done             chan struct{}
+	closeOnce        sync.Once
+}
+
+// NewNotificationProjection creates a notification projection and starts its worker
+func NewNotificationProjection(
+	resolver PodAccountResolver,
+	memberRepo domain.AccountMemberRepository,
+	roleRepo domain.RoleRepository,
+	notificationRepo domain.NotificationRepository,
+) *NotificationProjection {
+	projection := &NotificationProjection{
+		resolver:         resolver,
+		memberRepo:       memberRepo,
+		roleRepo:         roleRepo,
+		notificationRepo: notificationRepo,
+		queue:            make(chan membershipChange, notificationQueueSize),
+		done:             make(chan struct{}),
+	}
+
+	go projection.run()
+
+	return projection
+}
+
+// EventTypes returns the event types this projection handles
+func (p *NotificationProjection) EventTypes() []string {
+	return []string{containerMemberAddedEventType, containerMemberRemovedEventType}
+}
+
+// Handle queues a membership change for notification. It never returns an error for
+// a well-formed event; when the queue is full the change is dropped and logged.
+func (p *NotificationProjection) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event := envelope.Event()
+
+	var kind domain.NotificationType
+	switch event.EventType() {
+	case containerMemberAddedEventType:
+		kind = domain.NotificationTypeMemberAdded
+	case containerMemberRemovedEventType:
+		kind = domain.NotificationTypeMemberRemoved
+	default:
+		return nil
+	}
+
+	var payload struct {
+		MemberID string `json:"memberID"`
+	}
+	if err := json.Unmarshal(event.Payload(), &payload); err != nil || payload.MemberID == "" {
+		log.Context(ctx).Warnf("Skipping notification for malformed %s event on container %s", event.EventType(), event.AggregateID())
+		return nil
+	}
+
+	change := membershipChange{
+		accountID:   event.Account(),
+		actorID:     event.User(),
+		containerID: event.AggregateID(),
+		memberID:    payload.MemberID,
+		kind:        kind,
+		occurredAt:  event.CreatedAt(),
+	}
+
+	select {
+	case p.queue <- change:
+	default:
+		log.Context(ctx).Warnf("Notification queue full, dropping %s notification for container %s", kind, change.containerID)
+	}
+	return nil
+}
+
+// Close stops accepting work, delivers any queued changes and waits for the worker
+func (p *NotificationProjection) Close() {
+	p.closeOnce.Do(func() {
+		close(p.queue)
+	})
+	<-p.done
+}
+
+// run delivers queued membership changes until the projection is closed
+func (p *NotificationProjection) run() {
+	defer close(p.done)
+
+	for change := range p.queue {
+		if err := p.deliver(context.Background(), change); err != nil {
+			log.Warnf("Failed to deliver %s notifications for container %s: %v", change.kind, change.containerID, err)
+		}
+	}
+}
+
+// deliver writes one notification per account member allowed to see the change
+func (p *NotificationProjection) deliver(ctx context.Context, change membershipChange) error {
+	accountID := change.accountID
+	if accountID == "" {
+		resolved, err := p.resolver.AccountForContainer(ctx, change.containerID)
+		if err != nil {
+			return err
+		}
+		accountID = resolved
+	}
+	if accountID == "" {
+		return nil
+	}
+
+	members, err := p.memberRepo.ListByAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	canRead := make(map[string]bool)
+	notifications := make([]*domain.Notification, 0, len(members))
+	for _, member := range members {
+		if member.UserID == change.actorID {
+			continue
+		}
+
+		allowed, checked := canRead[member.RoleID]
+		if !checked {
+			allowed = p.roleCanReadPod(ctx, member.RoleID)
+			canRead[member.RoleID] = allowed
+		}
+		if !allowed {
+			continue
+		}
+
+		notifications = append(notifications, &domain.Notification{
+			ID:          uuid.New().String(),
+			AccountID:   accountID,
+			UserID:      member.UserID,
+			Type:        change.kind,
+			ContainerID: change.containerID,
+			MemberID:    change.memberID,
+			CreatedAt:   change.occurredAt,
+		})
+	}
+
+	return p.notificationRepo.Create(ctx, notifications)
+}
+
+// roleCanReadPod reports whether a role can read every resource in the account's pod.
+// Roles limited to their own resources are not told about other members' changes.
+func (p *NotificationProjection) roleCanReadPod(ctx context.Context, roleID string) bool {
+	role, err := p.roleRepo.GetByID(ctx, roleID)
+	if err != nil || role == nil {
+		return false
+	}
+	return role.HasPermission("resource", "read", "account")
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testEnvelope wraps an event for handlers under test
+type testEnvelope struct {
+	event pericarpdomain.Event
+}
+
+func (e testEnvelope) Event() pericarpdomain.Event      { return e.event }
+func (e testEnvelope) Metadata() map[string]interface{} { return map[string]interface{}{} }
+func (e testEnvelope) EventID() string                  { return "test-event-id" }
+func (e testEnvelope) Timestamp() time.Time             { return e.event.CreatedAt() }
+
+// memoryNotificationRepository records created notifications
+type memoryNotificationRepository struct {
+	mu            sync.Mutex
+	notifications []*domain.Notification
+}
+
+func (r *memoryNotificationRepository) Create(ctx context.Context, notifications []*domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, notifications...)
+	return nil
+}
+
+func (r *memoryNotificationRepository) ListByUser(ctx context.Context, userID string, filter domain.NotificationFilter) ([]*domain.Notification, error) {
+	return nil, nil
+}
+
+func (r *memoryNotificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryNotificationRepository) MarkRead(ctx context.Context, userID, id string, readAt time.Time) error {
+	return nil
+}
+
+// staticPodAccountResolver resolves every container to one account
+type staticPodAccountResolver struct {
+	accountID string
+}
+
+func (r staticPodAccountResolver) AccountForContainer(ctx context.Context, containerID string) (string, error) {
+	return r.accountID, nil
+}
+
+func TestNotificationProjection_NotifiesMembersWhoCanReadPod(t *testing.T) {
+	ctx := context.Background()
+	accountID := "test-account-id"
+
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	notificationRepo := &memoryNotificationRepository{}
+
+	viewerRole, err := domain.NewRole(ctx, "viewer", "Viewer", "Read-only access", []domain.Permission{
+		{Resource: "resource", Action: "read", Scope: "account"},
+	})
+	require.NoError(t, err)
+	memberRole, err := domain.NewRole(ctx, "member", "Member", "Standard member access", []domain.Permission{
+		{Resource: "resource", Action: "read", Scope: "own"},
+	})
+	require.NoError(t, err)
+
+	members := []*domain.AccountMember{
+		createTestAccountMember("m-1", accountID, "actor-id", "viewer"),
+		createTestAccountMember("m-2", accountID, "viewer-id", "viewer"),
+		createTestAccountMember("m-3", accountID, "member-id", "member"),
+	}
+
+	mockMemberRepo.On("ListByAccount", mock.Anything, accountID).Return(members, nil)
+	mockRoleRepo.On("GetByID", mock.Anything, "viewer").Return(viewerRole, nil)
+	mockRoleRepo.On("GetByID", mock.Anything, "member").Return(memberRole, nil)
+
+	projection := NewNotificationProjection(staticPodAccountResolver{accountID: accountID}, mockMemberRepo, mockRoleRepo, notificationRepo)
+
+	added := pericarpdomain.NewEntityEvent("container", "member_added", "docs", "actor-id", "", map[string]interface{}{"memberID": "report.ttl"})
+	removed := pericarpdomain.NewEntityEvent("container", "member_removed", "docs", "actor-id", "", map[string]interface{}{"memberID": "old.ttl"})
+	require.NoError(t, projection.Handle(ctx, testEnvelope{event: added}))
+	require.NoError(t, projection.Handle(ctx, testEnvelope{event: removed}))
+
+	// Close drains the queue, so every queued change has been delivered afterwards
+	projection.Close()
+
+	require.Len(t, notificationRepo.notifications, 2)
+	for _, notification := range notificationRepo.notifications {
+		assert.Equal(t, "viewer-id", notification.UserID)
+		assert.Equal(t, accountID, notification.AccountID)
+		assert.Equal(t, "docs", notification.ContainerID)
+		assert.False(t, notification.IsRead())
+	}
+	assert.Equal(t, domain.NotificationTypeMemberAdded, notificationRepo.notifications[0].Type)
+	assert.Equal(t, "report.ttl", notificationRepo.notifications[0].MemberID)
+	assert.Equal(t, domain.NotificationTypeMemberRemoved, notificationRepo.notifications[1].Type)
+
+	// Role permissions are looked up once per role for each change
+	mockRoleRepo.AssertNumberOfCalls(t, "GetByID", 4)
+}
+
+func TestNotificationProjection_IgnoresContainersOutsideAccountPods(t *testing.T) {
+	ctx := context.Background()
+
+	mockMemberRepo := &MockAccountMemberRepository{}
+	mockRoleRepo := &MockRoleRepository{}
+	notificationRepo := &memoryNotificationRepository{}
+
+	projection := NewNotificationProjection(staticPodAccountResolver{}, mockMemberRepo, mockRoleRepo, notificationRepo)
+
+	added := pericarpdomain.NewEntityEvent("container", "member_added", "shared", "", "", map[string]interface{}{"memberID": "file.txt"})
+	require.NoError(t, projection.Handle(ctx, testEnvelope{event: added}))
+	projection.Close()
+
+	assert.Empty(t, notificationRepo.notifications)
+	mockMemberRepo.AssertNotCalled(t, "ListByAccount", mock.Anything, mock.Anything)
+}
+
+func TestRootContainerAccountResolver_UsesPodRoot(t *testing.T) {
+	ctx := context.Background()
+	mockAccountRepo := &MockAccountRepository{}
+	account := createTestAccount("acct-root", "owner-id", "Test Account")
+
+	mockAccountRepo.On("GetByID", ctx, "acct-root").Return(account, nil)
+	mockAccountRepo.On("GetByID", ctx, "public").Return(nil, assert.AnError)
+
+	resolver := NewRootContainerAccountResolver(staticPathLookup{
+		"photos": {"acct-root", "photos"},
+		"public": {"public"},
+	}, mockAccountRepo)
+
+	accountID, err := resolver.AccountForContainer(ctx, "photos")
+	require.NoError(t, err)
+	assert.Equal(t, "acct-root", accountID)
+
+	accountID, err = resolver.AccountForContainer(ctx, "public")
+	require.NoError(t, err)
+	assert.Empty(t, accountID)
+}
+
+// staticPathLookup returns fixed container paths
+type staticPathLookup map[string][]string
+
+func (l staticPathLookup) GetPath(ctx context.Context, containerID string) ([]string, error) {
+	return l[containerID], nil
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// NotificationService defines the interface for reading a user's notifications
+type NotificationService interface {
+	ListNotifications(ctx context.Context, userID string, filter domain.NotificationFilter) ([]*domain.Notification, int64, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID string) error
+}
+
+// notificationService implements the NotificationService interface
+type notificationService struct {
+	notificationRepo domain.NotificationRepository
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(notificationRepo domain.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// ListNotifications returns a page of the user's notifications together with their unread count
+func (s *notificationService) ListNotifications(ctx context.Context, userID string, filter domain.NotificationFilter) ([]*domain.Notification, int64, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, 0, fmt.Errorf("user ID is required")
+	}
+
+	notifications, err := s.notificationRepo.ListByUser(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return notifications, unread, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read
+func (s *notificationService) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if err := s.notificationRepo.MarkRead(ctx, userID, notificationID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// AccountOperationLimitPolicy resolves an account's cap on concurrent heavy operations from
+// its AccountSettings
+type AccountOperationLimitPolicy struct {
+	accountRepo domain.AccountRepository
+}
+
+// NewAccountOperationLimitPolicy creates a new AccountOperationLimitPolicy instance
+func NewAccountOperationLimitPolicy(accountRepo domain.AccountRepository) *AccountOperationLimitPolicy {
+	return &AccountOperationLimitPolicy{
+		accountRepo: accountRepo,
+	}
+}
+
+// MaxConcurrentOperations returns the account's override of the heavy operation limit.
+// Unknown accounts and accounts without an override get zero, the server-wide limit.
+func (p *AccountOperationLimitPolicy) MaxConcurrentOperations(ctx context.Context, accountID string) int {
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil || account == nil {
+		return 0
+	}
+	return account.Settings.MaxConcurrentOperations
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// AccountReadAuditPolicy reports whether an account has opted in to read auditing
+// through its AccountSettings
+type AccountReadAuditPolicy struct {
+	accountRepo domain.AccountRepository
+}
+
+// NewAccountReadAuditPolicy creates a new AccountReadAuditPolicy instance
+func NewAccountReadAuditPolicy(accountRepo domain.AccountRepository) *AccountReadAuditPolicy {
+	return &AccountReadAuditPolicy{
+		accountRepo: accountRepo,
+	}
+}
+
+// ReadAuditEnabled returns true when the account's settings enable read auditing.
+// Unknown accounts are not audited.
+func (p *AccountReadAuditPolicy) ReadAuditEnabled(ctx context.Context, accountID string) bool {
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil || account == nil {
+		return false
+	}
+	return account.Settings.AuditReads
+}
+
+// AnyReadAuditEnabled reports whether any account has opted in to read auditing. It answers
+// true when the repository cannot tell or the check fails, so that no opted-in read goes
+// unaudited.
+func (p *AccountReadAuditPolicy) AnyReadAuditEnabled(ctx context.Context) bool {
+	source, ok := p.accountRepo.(domain.ReadAuditingAccountSource)
+	if !ok {
+		return true
+	}
+	enabled, err := source.AnyAccountAuditsReads(ctx)
+	return err != nil || enabled
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/google/uuid"
+)
+
+// maxSessionUserAgentLength caps the user agent kept with a session
+const maxSessionUserAgentLength = 512
+
+// SessionService defines the interface for managing a user's sign-in sessions
+type SessionService interface {
+	// StartSession records a new session signed in from the given user agent and IP address
+	StartSession(ctx context.Context, userID, userAgent, ipAddress string) (*domain.Session, error)
+	ListSessions(ctx context.Context, userID string) ([]*domain.Session, error)
+	TouchSession(ctx context.Context, userID, sessionID string) error
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+}
+
+// sessionService implements the SessionService interface
+type sessionService struct {
+	sessionRepo domain.SessionRepository
+	idleTimeout time.Duration
+}
+
+// NewSessionService creates a new SessionService instance. Sessions idle for longer than
+// idleTimeout are no longer listed; zero keeps them until they are revoked.
+func NewSessionService(sessionRepo domain.SessionRepository, idleTimeout time.Duration) SessionService {
+	return newSessionService(sessionRepo, idleTimeout)
+}
+
+// newSessionService creates a sessionService, for callers that need more than the interface
+func newSessionService(sessionRepo domain.SessionRepository, idleTimeout time.Duration) *sessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		idleTimeout: idleTimeout,
+	}
+}
+
+// activeSince returns the last activity a session needs to still be active, or the zero time
+// when sessions never go idle
+func (s *sessionService) activeSince() time.Time {
+	if s.idleTimeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-s.idleTimeout)
+}
+
+// refreshActiveSessions recounts the active sessions into the server stats. Sessions going
+// idle change nothing stored, so they drop out of the count at the next refresh.
+func (s *sessionService) refreshActiveSessions(ctx context.Context) {
+	count, err := s.sessionRepo.CountActive(ctx, s.activeSince())
+	if err != nil {
+		return
+	}
+	metrics.ActiveSessions.Set(count)
+}
+
+// StartSession records a new session for the user
+func (s *sessionService) StartSession(ctx context.Context, userID, userAgent, ipAddress string) (*domain.Session, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if len(userAgent) > maxSessionUserAgentLength {
+		userAgent = userAgent[:maxSessionUserAgentLength]
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		UserAgent:      userAgent,
+		IPAddress:      ipAddress,
+		CreatedAt:      now,
+		LastActivityAt: now,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	s.refreshActiveSessions(ctx)
+	return session, nil
+}
+
+// ListSessions returns the user's active sessions, most recently active first
+func (s *sessionService) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	sessions, err := s.sessionRepo.ListActiveByUser(ctx, userID, s.activeSince())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// TouchSession records activity on one of the user's sessions
+func (s *sessionService) TouchSession(ctx context.Context, userID, sessionID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if err := s.sessionRepo.Touch(ctx, userID, sessionID, time.Now()); err != nil {
+		return fmt.Errorf("failed to record session activity: %w", err)
+	}
+	s.refreshActiveSessions(ctx)
+	return nil
+}
+
+// RevokeSession revokes one of the user's sessions. Another user's session is reported as
+// domain.ErrSessionNotFound.
+func (s *sessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, userID, sessionID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	s.refreshActiveSessions(ctx)
+	return nil
+}
@@ -0,0 +1,137 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/infrastructure/metrics"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySessionRepository keeps sessions in memory
+type memorySessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]*domain.Session
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = session
+	return nil
+}
+
+func (r *memorySessionRepository) ListActiveByUser(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []*domain.Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && !session.IsRevoked() && !session.LastActivityAt.Before(activeSince) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) CountActive(ctx context.Context, activeSince time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, session := range r.sessions {
+		if !session.IsRevoked() && !session.LastActivityAt.Before(activeSince) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memorySessionRepository) Touch(ctx context.Context, userID, id string, at time.Time) error {
+	session, err := r.unrevoked(userID, id)
+	if err == nil {
+		session.LastActivityAt = at
+	}
+	return err
+}
+
+func (r *memorySessionRepository) Revoke(ctx context.Context, userID, id string, at time.Time) error {
+	session, err := r.unrevoked(userID, id)
+	if err == nil {
+		session.RevokedAt = &at
+	}
+	return err
+}
+
+func (r *memorySessionRepository) unrevoked(userID, id string) (*domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[id]
+	if !ok || session.UserID != userID || session.IsRevoked() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSessionNotFound, id)
+	}
+	return session, nil
+}
+
+func TestSessionService(t *testing.T) {
+	ctx := context.Background()
+	repo := &memorySessionRepository{sessions: map[string]*domain.Session{}}
+	service := NewSessionService(repo, time.Hour)
+
+	session, err := service.StartSession(ctx, "user-1", strings.Repeat("x", 1000), "192.0.2.1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, session.ID)
+	assert.Len(t, session.UserAgent, maxSessionUserAgentLength)
+	assert.Equal(t, "192.0.2.1", session.IPAddress)
+
+	idle, err := service.StartSession(ctx, "user-1", "curl", "192.0.2.2")
+	require.NoError(t, err)
+	idle.LastActivityAt = time.Now().Add(-2 * time.Hour)
+
+	t.Run("lists only sessions active within the idle timeout", func(t *testing.T) {
+		sessions, err := service.ListSessions(ctx, "user-1")
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, session.ID, sessions[0].ID)
+	})
+
+	t.Run("users cannot revoke other users' sessions", func(t *testing.T) {
+		err := service.RevokeSession(ctx, "user-2", session.ID)
+		assert.True(t, errors.Is(err, domain.ErrSessionNotFound))
+
+		require.NoError(t, service.RevokeSession(ctx, "user-1", session.ID))
+		sessions, err := service.ListSessions(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
+
+func TestSessionService_CountsActiveSessions(t *testing.T) {
+	previous := metrics.ActiveSessions.Value()
+	t.Cleanup(func() { metrics.ActiveSessions.Set(previous) })
+
+	ctx := context.Background()
+	repo := &memorySessionRepository{sessions: map[string]*domain.Session{
+		"earlier": {ID: "earlier", UserID: "user-2", LastActivityAt: time.Now()},
+		"idle":    {ID: "idle", UserID: "user-2", LastActivityAt: time.Now().Add(-2 * time.Hour)},
+	}}
+
+	// Sessions stored before a restart are counted when the service is provided
+	metrics.ActiveSessions.Set(0)
+	service, err := ProvideSessionService(repo, &conf.Auth{SessionIdleTimeout: conf.Duration(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), metrics.ActiveSessions.Value())
+
+	session, err := service.StartSession(ctx, "user-1", "curl", "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), metrics.ActiveSessions.Value())
+
+	require.NoError(t, service.RevokeSession(ctx, "user-1", session.ID))
+	assert.Equal(t, int64(1), metrics.ActiveSessions.Value())
+}
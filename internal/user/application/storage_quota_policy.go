@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// AccountStorageQuotaPolicy resolves an account's storage quota from its AccountSettings
+type AccountStorageQuotaPolicy struct {
+	accountRepo domain.AccountRepository
+}
+
+// NewAccountStorageQuotaPolicy creates a new AccountStorageQuotaPolicy instance
+func NewAccountStorageQuotaPolicy(accountRepo domain.AccountRepository) *AccountStorageQuotaPolicy {
+	return &AccountStorageQuotaPolicy{
+		accountRepo: accountRepo,
+	}
+}
+
+// MaxStorageBytes returns the account's storage quota in bytes. Unknown accounts and accounts
+// without a quota get zero, which means unlimited.
+func (p *AccountStorageQuotaPolicy) MaxStorageBytes(ctx context.Context, accountID string) int64 {
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil || account == nil {
+		return 0
+	}
+	return account.Settings.MaxStorageBytes
+}
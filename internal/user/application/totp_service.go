@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+// TOTPEnrollment is what a user needs to add their TOTP secret to an authenticator app
+type TOTPEnrollment struct {
+	// ProvisioningURI is the otpauth:// URI of the secret; it is also the QR code payload
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TOTPService defines the interface for enrolling users in the TOTP second factor and checking
+// their codes
+type TOTPService interface {
+	// EnrollTOTP gives the user a new TOTP secret, pending until a code from it is verified
+	EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error)
+	// VerifyTOTP checks a code from the user's authenticator app, confirming a pending enrollment
+	VerifyTOTP(ctx context.Context, userID, code string) error
+}
+
+// totpService implements the TOTPService interface
+type totpService struct {
+	unitOfWorkFactory func() pericarpdomain.UnitOfWork
+	userRepo          domain.UserRepository
+	sealer            domain.TOTPSecretSealer
+	issuer            string
+
+	// mu guards users, the per-user locks that keep two requests from accepting the same code
+	// before the first one's period is stored
+	mu    sync.Mutex
+	users map[string]*sync.Mutex
+}
+
+// NewTOTPService creates a new TOTPService instance. Without a sealer users cannot enroll or
+// verify codes, and both answer domain.ErrMFAUnavailable.
+func NewTOTPService(
+	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
+	userRepo domain.UserRepository,
+	sealer domain.TOTPSecretSealer,
+	issuer string,
+) TOTPService {
+	return &totpService{
+		unitOfWorkFactory: unitOfWorkFactory,
+		userRepo:          userRepo,
+		sealer:            sealer,
+		issuer:            issuer,
+		users:             make(map[string]*sync.Mutex),
+	}
+}
+
+// EnrollTOTP generates a secret, stores it sealed to the user and returns its provisioning URI
+func (s *totpService) EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("invalid user ID: cannot be empty")
+	}
+	if s.sealer == nil {
+		return nil, domain.ErrMFAUnavailable
+	}
+
+	lock := s.lock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secret, err := domain.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.sealer.Seal(user.ID(), secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal TOTP secret: %w", err)
+	}
+	if err := user.EnrollTOTP(ctx, sealed); err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to commit TOTP enrollment: %w", err)
+	}
+
+	return &TOTPEnrollment{ProvisioningURI: domain.TOTPProvisioningURI(s.issuer, user.Email, secret)}, nil
+}
+
+// VerifyTOTP checks a code against the user's stored secret and stores the period it was
+// accepted for, so it cannot be used again
+func (s *totpService) VerifyTOTP(ctx context.Context, userID, code string) error {
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("invalid user ID: cannot be empty")
+	}
+	if s.sealer == nil {
+		return domain.ErrMFAUnavailable
+	}
+
+	lock := s.lock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.TOTP == nil {
+		return domain.ErrMFANotEnrolled
+	}
+
+	secret, err := s.sealer.Open(user.ID(), user.TOTP.SealedSecret)
+	if err != nil {
+		return err
+	}
+	if err := user.VerifyTOTP(ctx, secret, code, time.Now()); err != nil {
+		return err
+	}
+	if err := s.commit(ctx, user); err != nil {
+		return fmt.Errorf("failed to commit TOTP verification: %w", err)
+	}
+	return nil
+}
+
+// commit persists and dispatches the user's uncommitted events
+func (s *totpService) commit(ctx context.Context, user *domain.User) error {
+	unitOfWork := s.unitOfWorkFactory()
+	if events := user.UncommittedEvents(); len(events) > 0 {
+		unitOfWork.RegisterEvents(events)
+	}
+	if _, err := unitOfWork.Commit(ctx); err != nil {
+		_ = unitOfWork.Rollback()
+		return err
+	}
+	user.MarkEventsAsCommitted()
+	return nil
+}
+
+// lock returns the lock serializing TOTP changes to a user
+func (s *totpService) lock(userID string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.users[userID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.users[userID] = lock
+	}
+	return lock
+}
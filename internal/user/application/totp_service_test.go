@@ -0,0 +1,65 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/base32"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/akeemphilbert/goro/internal/user/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+)
+
+func TestTOTPService_WithoutKey(t *testing.T) {
+	ctx := context.Background()
+	service := NewTOTPService(func() pericarpdomain.UnitOfWork { return &MockUnitOfWork{} }, &MockUserRepository{}, nil, "Goro")
+
+	_, err := service.EnrollTOTP(ctx, "user-123")
+	assert.True(t, errors.Is(err, domain.ErrMFAUnavailable), "got %v", err)
+	assert.True(t, errors.Is(service.VerifyTOTP(ctx, "user-123", "123456"), domain.ErrMFAUnavailable))
+}
+
+func TestTOTPService_EnrollAndVerify(t *testing.T) {
+	ctx := context.Background()
+	user, err := domain.NewUser(ctx, "user-123", "https://example.com/users/user-123#me", "john@example.com", domain.UserProfile{
+		Name: "John Doe",
+	})
+	require.NoError(t, err)
+	user.MarkEventsAsCommitted()
+
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockUnitOfWork.On("RegisterEvents", mock.Anything).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+	mockUserRepo := &MockUserRepository{}
+	mockUserRepo.On("GetByID", ctx, "user-123").Return(user, nil)
+	sealer, err := infrastructure.NewAESSecretSealer(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+
+	service := NewTOTPService(func() pericarpdomain.UnitOfWork { return mockUnitOfWork }, mockUserRepo, sealer, "Goro")
+
+	enrollment, err := service.EnrollTOTP(ctx, "user-123")
+	require.NoError(t, err)
+	uri, err := url.Parse(enrollment.ProvisioningURI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(uri.Query().Get("secret"))
+	require.NoError(t, err)
+	require.NotNil(t, user.TOTP)
+	assert.NotContains(t, user.TOTP.SealedSecret, uri.Query().Get("secret"))
+	assert.False(t, user.MFAEnabled())
+
+	code := domain.TOTPCode(secret, time.Now())
+	require.NoError(t, service.VerifyTOTP(ctx, "user-123", code))
+	assert.True(t, user.MFAEnabled())
+	assert.True(t, errors.Is(service.VerifyTOTP(ctx, "user-123", code), domain.ErrTOTPCodeReused))
+
+	mockUnitOfWork.AssertNumberOfCalls(t, "Commit", 2)
+}
@@ -2,9 +2,20 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/akeemphilbert/goro/internal/user/domain"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Full types of the user events this handler persists
+const (
+	// oauthUnlinkedEventType is the full type of the event an OAuth unlink commits
+	oauthUnlinkedEventType = "user." + domain.EventTypeOAuthUnlinked
+	// totpUpdatedEventType is the full type of the event a TOTP enrollment or accepted code commits
+	totpUpdatedEventType = "user." + domain.EventTypeTOTPUpdated
 )
 
 // FileStorage interface for user file operations
@@ -89,6 +100,61 @@ func (h *UserEventHandler) HandleWebIDGenerated(ctx context.Context, event *doma
 	return nil
 }
 
+// EventTypes returns the event types this handler is subscribed to
+func (h *UserEventHandler) EventTypes() []string {
+	return []string{oauthUnlinkedEventType, totpUpdatedEventType}
+}
+
+// Handle decodes a dispatched user event and passes it to its typed handler
+func (h *UserEventHandler) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event := envelope.Event()
+	switch event.EventType() {
+	case oauthUnlinkedEventType:
+		var data domain.OAuthUnlinkedEventData
+		if err := json.Unmarshal(event.Payload(), &data); err != nil {
+			return fmt.Errorf("failed to unmarshal OAuth unlinked event: %w", err)
+		}
+		if data.UserID == "" || data.User == nil {
+			log.Context(ctx).Warnf("Skipping OAuth unlink for malformed %s event on user %s", event.EventType(), event.AggregateID())
+			return nil
+		}
+		// The entity ID is not part of the user's JSON, so it is restored from the event
+		data.User.BasicEntity = pericarpdomain.NewEntity(data.UserID)
+		return h.HandleOAuthUnlinked(ctx, &data)
+	case totpUpdatedEventType:
+		var data domain.TOTPUpdatedEventData
+		if err := json.Unmarshal(event.Payload(), &data); err != nil {
+			return fmt.Errorf("failed to unmarshal TOTP updated event: %w", err)
+		}
+		if data.UserID == "" || data.User == nil {
+			log.Context(ctx).Warnf("Skipping TOTP update for malformed %s event on user %s", event.EventType(), event.AggregateID())
+			return nil
+		}
+		data.User.BasicEntity = pericarpdomain.NewEntity(data.UserID)
+		return h.HandleTOTPUpdated(ctx, &data)
+	}
+	return nil
+}
+
+// HandleOAuthUnlinked handles OAuth unlink events by updating the stored authentication methods
+func (h *UserEventHandler) HandleOAuthUnlinked(ctx context.Context, event *domain.OAuthUnlinkedEventData) error {
+	if err := h.userRepo.Update(ctx, event.User); err != nil {
+		return fmt.Errorf("failed to persist OAuth unlink: %w", err)
+	}
+
+	return nil
+}
+
+// HandleTOTPUpdated handles TOTP update events by storing the user's TOTP factor, including
+// the period of the last accepted code
+func (h *UserEventHandler) HandleTOTPUpdated(ctx context.Context, event *domain.TOTPUpdatedEventData) error {
+	if err := h.userRepo.Update(ctx, event.User); err != nil {
+		return fmt.Errorf("failed to persist TOTP update: %w", err)
+	}
+
+	return nil
+}
+
 // generateWebIDDocument generates a Turtle format WebID document
 func (h *UserEventHandler) generateWebIDDocument(user *domain.User, webID string) string {
 	// This is a simplified WebID document generation
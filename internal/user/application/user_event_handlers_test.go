@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/akeemphilbert/goro/internal/user/domain"
 )
@@ -160,3 +161,60 @@ func TestUserEventHandler_HandleWebIDGenerated_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockFileStorage.AssertExpectations(t)
 }
+
+func TestUserEventHandler_Handle_OAuthUnlinked(t *testing.T) {
+	ctx := context.Background()
+	user, err := domain.NewUser(ctx, "user-123", "https://example.com/users/user-123#me", "john@example.com", domain.UserProfile{
+		Name: "John Doe",
+	})
+	require.NoError(t, err)
+	user.ExternalIdentities = []domain.ExternalIdentity{
+		{Provider: "google", Subject: "google-subject"},
+		{Provider: "github", Subject: "github-subject"},
+	}
+	require.NoError(t, user.UnlinkOAuthProvider(ctx, "google"))
+	events := user.UncommittedEvents()
+
+	mockUserRepo := &MockUserWriteRepository{}
+	mockUserRepo.On("Update", ctx, mock.MatchedBy(func(stored *domain.User) bool {
+		return stored.ID() == "user-123" &&
+			!stored.IsOAuthProviderLinked("google") &&
+			stored.IsOAuthProviderLinked("github")
+	})).Return(nil)
+
+	handler := NewUserEventHandler(mockUserRepo, &MockFileStorage{})
+	require.NoError(t, handler.Handle(ctx, testEnvelope{event: events[len(events)-1]}))
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserEventHandler_Handle_TOTPUpdated(t *testing.T) {
+	ctx := context.Background()
+	user, err := domain.NewUser(ctx, "user-123", "https://example.com/users/user-123#me", "john@example.com", domain.UserProfile{
+		Name: "John Doe",
+	})
+	require.NoError(t, err)
+	secret := []byte("12345678901234567890")
+	require.NoError(t, user.EnrollTOTP(ctx, "sealed-secret"))
+	now := time.Now()
+	require.NoError(t, user.VerifyTOTP(ctx, secret, domain.TOTPCode(secret, now), now))
+	events := user.UncommittedEvents()
+
+	mockUserRepo := &MockUserWriteRepository{}
+	mockUserRepo.On("Update", ctx, mock.MatchedBy(func(stored *domain.User) bool {
+		return stored.ID() == "user-123" && stored.MFAEnabled() && stored.TOTP.LastUsedStep == user.TOTP.LastUsedStep
+	})).Return(nil)
+
+	handler := NewUserEventHandler(mockUserRepo, &MockFileStorage{})
+	require.NoError(t, handler.Handle(ctx, testEnvelope{event: events[len(events)-1]}))
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestEventHandlerRegistrar_RegisterUserEventHandlers(t *testing.T) {
+	dispatcher := &subscriptionRecorder{}
+	handler := NewUserEventHandler(&MockUserWriteRepository{}, &MockFileStorage{})
+
+	require.NoError(t, NewEventHandlerRegistrar(dispatcher).RegisterUserEventHandlers(handler))
+	assert.Equal(t, []string{"user.oauth_unlinked", "user.totp_updated"}, dispatcher.subscribed)
+}
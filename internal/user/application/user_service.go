@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/akeemphilbert/goro/internal/user/domain"
 	"github.com/akeemphilbert/goro/internal/user/infrastructure"
@@ -23,6 +24,8 @@ type UserService interface {
 	DeleteAccount(ctx context.Context, userID string) error
 	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
 	GetUserByWebID(ctx context.Context, webID string) (*domain.User, error)
+	UnlinkOAuthProvider(ctx context.Context, userID, provider string) error
+	GetOwnedAccounts(ctx context.Context, userID string) ([]*domain.Account, error)
 }
 
 // userService implements the UserService interface
@@ -30,6 +33,7 @@ type userService struct {
 	unitOfWorkFactory func() pericarpdomain.UnitOfWork
 	webidGen          infrastructure.WebIDGenerator
 	userRepo          domain.UserRepository
+	accountRepo       domain.AccountRepository
 }
 
 // NewUserService creates a new UserService instance
@@ -37,11 +41,13 @@ func NewUserService(
 	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
 	webidGen infrastructure.WebIDGenerator,
 	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
 ) UserService {
 	return &userService{
 		unitOfWorkFactory: unitOfWorkFactory,
 		webidGen:          webidGen,
 		userRepo:          userRepo,
+		accountRepo:       accountRepo,
 	}
 }
 
@@ -215,6 +221,45 @@ func (s *userService) DeleteAccount(ctx context.Context, userID string) error {
 	return nil
 }
 
+// UnlinkOAuthProvider removes a user's linkage with an external OAuth provider.
+// Returns domain.ErrLastAuthMethod if the provider is the user's only way to sign in.
+func (s *userService) UnlinkOAuthProvider(ctx context.Context, userID, provider string) error {
+	// Get existing user
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Unlink provider
+	if err := user.UnlinkOAuthProvider(ctx, provider); err != nil {
+		return fmt.Errorf("failed to unlink OAuth provider: %w", err)
+	}
+
+	// Create unit of work for event processing
+	unitOfWork := s.unitOfWorkFactory()
+
+	// Register events with unit of work
+	events := user.UncommittedEvents()
+	if len(events) > 0 {
+		unitOfWork.RegisterEvents(events)
+	}
+
+	// Commit unit of work - this persists events and dispatches them
+	_, err = unitOfWork.Commit(ctx)
+	if err != nil {
+		// Rollback on failure
+		if rollbackErr := unitOfWork.Rollback(); rollbackErr != nil {
+			// Log rollback error but return original error
+		}
+		return fmt.Errorf("failed to commit OAuth unlink: %w", err)
+	}
+
+	// Mark events as committed
+	user.MarkEventsAsCommitted()
+
+	return nil
+}
+
 // GetUserByID retrieves a user by their ID
 func (s *userService) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
 	return s.userRepo.GetByID(ctx, userID)
@@ -225,6 +270,23 @@ func (s *userService) GetUserByWebID(ctx context.Context, webID string) (*domain
 	return s.userRepo.GetByWebID(ctx, webID)
 }
 
+// GetOwnedAccounts retrieves the accounts a user owns, excluding accounts they only belong to as a member
+func (s *userService) GetOwnedAccounts(ctx context.Context, userID string) ([]*domain.Account, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("invalid user ID: cannot be empty")
+	}
+
+	accounts, err := s.accountRepo.GetByOwner(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owned accounts: %w", err)
+	}
+	if accounts == nil {
+		accounts = []*domain.Account{}
+	}
+
+	return accounts, nil
+}
+
 // generateUserID generates a new unique user ID
 func generateUserID() string {
 	return uuid.New().String()
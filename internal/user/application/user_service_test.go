@@ -152,7 +152,7 @@ func TestUserService_RegisterUser_Success(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	req := createRegisterUserRequest()
 	expectedWebID := "https://example.com/users/test-user#me"
@@ -191,7 +191,7 @@ func TestUserService_RegisterUser_InvalidEmail(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	req := createRegisterUserRequest()
 	req.Email = "invalid-email"
@@ -216,7 +216,7 @@ func TestUserService_RegisterUser_WebIDGenerationFails(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	req := createRegisterUserRequest()
 
@@ -246,7 +246,7 @@ func TestUserService_RegisterUser_WebIDNotUnique(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	req := createRegisterUserRequest()
 	originalWebID := "https://example.com/users/test-user#me"
@@ -286,7 +286,7 @@ func TestUserService_RegisterUser_CommitFails(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	req := createRegisterUserRequest()
 	expectedWebID := "https://example.com/users/test-user#me"
@@ -325,7 +325,7 @@ func TestUserService_UpdateProfile_Success(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "test-user-id"
 	existingUser := createTestUser(userID, "test@example.com", "Old Name")
@@ -365,7 +365,7 @@ func TestUserService_UpdateProfile_UserNotFound(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "non-existent-user"
 	newProfile := domain.UserProfile{Name: "New Name"}
@@ -396,7 +396,7 @@ func TestUserService_DeleteAccount_Success(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "test-user-id"
 	existingUser := createTestUser(userID, "test@example.com", "Test User")
@@ -428,7 +428,7 @@ func TestUserService_DeleteAccount_UserNotFound(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "non-existent-user"
 
@@ -445,6 +445,169 @@ func TestUserService_DeleteAccount_UserNotFound(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestUserService_GetOwnedAccounts(t *testing.T) {
+	ctx := context.Background()
+	mockAccountRepo := &MockAccountRepository{}
+	service := NewUserService(nil, &MockWebIDGenerator{}, &MockUserRepository{}, mockAccountRepo)
+
+	owner := createTestUser("owner-id", "owner@example.com", "Owner")
+	owned, err := domain.NewAccount(ctx, "account-1", owner, "Owned", "")
+	require.NoError(t, err)
+
+	mockAccountRepo.On("GetByOwner", ctx, "owner-id").Return([]*domain.Account{owned}, nil)
+	mockAccountRepo.On("GetByOwner", ctx, "member-id").Return(nil, nil)
+
+	accounts, err := service.GetOwnedAccounts(ctx, "owner-id")
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "account-1", accounts[0].ID())
+
+	accounts, err = service.GetOwnedAccounts(ctx, "member-id")
+	require.NoError(t, err)
+	assert.NotNil(t, accounts)
+	assert.Empty(t, accounts)
+
+	_, err = service.GetOwnedAccounts(ctx, " ")
+	assert.Error(t, err)
+
+	mockAccountRepo.AssertExpectations(t)
+}
+
+func TestUserService_UnlinkOAuthProvider_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockWebIDGen := &MockWebIDGenerator{}
+	mockUserRepo := &MockUserRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
+
+	userID := "test-user-id"
+	existingUser := createTestUser(userID, "test@example.com", "Test User")
+	existingUser.ExternalIdentities = []domain.ExternalIdentity{
+		{Provider: "google", Subject: "google-subject"},
+		{Provider: "github", Subject: "github-subject"},
+	}
+
+	// Mock expectations
+	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
+	mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	// Act
+	err := service.UnlinkOAuthProvider(ctx, userID, "Google")
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, existingUser.IsOAuthProviderLinked("google"))
+	assert.True(t, existingUser.IsOAuthProviderLinked("github"))
+
+	mockUserRepo.AssertExpectations(t)
+	mockUnitOfWork.AssertExpectations(t)
+}
+
+func TestUserService_UnlinkOAuthProvider_LastAuthMethod(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockWebIDGen := &MockWebIDGenerator{}
+	mockUserRepo := &MockUserRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
+
+	userID := "test-user-id"
+	existingUser := createTestUser(userID, "test@example.com", "Test User")
+	existingUser.ExternalIdentities = []domain.ExternalIdentity{
+		{Provider: "google", Subject: "google-subject"},
+	}
+
+	// Mock expectations
+	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
+
+	// Act
+	err := service.UnlinkOAuthProvider(ctx, userID, "google")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrLastAuthMethod)
+	assert.True(t, existingUser.IsOAuthProviderLinked("google"))
+
+	mockUserRepo.AssertExpectations(t)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestUserService_UnlinkOAuthProvider_PasswordRetained(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockWebIDGen := &MockWebIDGenerator{}
+	mockUserRepo := &MockUserRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
+
+	userID := "test-user-id"
+	existingUser := createTestUser(userID, "test@example.com", "Test User")
+	existingUser.HasPassword = true
+	existingUser.ExternalIdentities = []domain.ExternalIdentity{
+		{Provider: "github", Subject: "github-subject"},
+	}
+
+	// Mock expectations
+	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
+	mockUnitOfWork.On("RegisterEvents", mock.AnythingOfType("[]domain.Event")).Return()
+	mockUnitOfWork.On("Commit", ctx).Return([]pericarpdomain.Envelope{}, nil)
+
+	// Act
+	err := service.UnlinkOAuthProvider(ctx, userID, "github")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, existingUser.ExternalIdentities)
+
+	mockUserRepo.AssertExpectations(t)
+	mockUnitOfWork.AssertExpectations(t)
+}
+
+func TestUserService_UnlinkOAuthProvider_NotLinked(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockUnitOfWork := &MockUnitOfWork{}
+	mockWebIDGen := &MockWebIDGenerator{}
+	mockUserRepo := &MockUserRepository{}
+
+	unitOfWorkFactory := func() pericarpdomain.UnitOfWork {
+		return mockUnitOfWork
+	}
+
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
+
+	userID := "test-user-id"
+	existingUser := createTestUser(userID, "test@example.com", "Test User")
+	existingUser.HasPassword = true
+
+	// Mock expectations
+	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
+
+	// Act
+	err := service.UnlinkOAuthProvider(ctx, userID, "google")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrOAuthProviderNotLinked)
+
+	mockUserRepo.AssertExpectations(t)
+}
+
 // Test query methods
 
 func TestUserService_GetUserByID_Success(t *testing.T) {
@@ -458,7 +621,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "test-user-id"
 	expectedUser := createTestUser(userID, "test@example.com", "Test User")
@@ -487,7 +650,7 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "non-existent-user"
 
@@ -515,7 +678,7 @@ func TestUserService_GetUserByWebID_Success(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	webID := "https://example.com/users/test-user#me"
 	expectedUser := createTestUser("test-user-id", "test@example.com", "Test User")
@@ -544,7 +707,7 @@ func TestUserService_GetUserByWebID_NotFound(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	webID := "https://example.com/users/non-existent#me"
 
@@ -574,7 +737,7 @@ func TestUserService_RegisterUser_EmitsCorrectEvents(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	req := createRegisterUserRequest()
 	expectedWebID := "https://example.com/users/test-user#me"
@@ -618,7 +781,7 @@ func TestUserService_UpdateProfile_EmitsCorrectEvents(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "test-user-id"
 	existingUser := createTestUser(userID, "test@example.com", "Old Name")
@@ -659,7 +822,7 @@ func TestUserService_DeleteAccount_EmitsCorrectEvents(t *testing.T) {
 		return mockUnitOfWork
 	}
 
-	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo)
+	service := NewUserService(unitOfWorkFactory, mockWebIDGen, mockUserRepo, nil)
 
 	userID := "test-user-id"
 	existingUser := createTestUser(userID, "test@example.com", "Test User")
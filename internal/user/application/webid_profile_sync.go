@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/akeemphilbert/goro/internal/user/infrastructure"
+	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// userProfileUpdatedEventType is the full type of the event a profile update commits
+const userProfileUpdatedEventType = "user." + domain.EventTypeUserProfileUpdated
+
+// WebIDProfileSync keeps users' WebID profile documents in step with their accounts. When a
+// user's profile changes it merges their current name and email into the stored document,
+// keeping any statements the user added, and stores it again. Users without a document get a
+// freshly generated one.
+type WebIDProfileSync struct {
+	fileStorage FileStorage
+	webidGen    infrastructure.WebIDGenerator
+}
+
+// NewWebIDProfileSync creates a new WebIDProfileSync instance
+func NewWebIDProfileSync(fileStorage FileStorage, webidGen infrastructure.WebIDGenerator) *WebIDProfileSync {
+	return &WebIDProfileSync{
+		fileStorage: fileStorage,
+		webidGen:    webidGen,
+	}
+}
+
+// EventTypes returns the event types this handler handles
+func (s *WebIDProfileSync) EventTypes() []string {
+	return []string{userProfileUpdatedEventType}
+}
+
+// Handle regenerates the WebID profile document of the user whose profile was updated
+func (s *WebIDProfileSync) Handle(ctx context.Context, envelope pericarpdomain.Envelope) error {
+	event := envelope.Event()
+	if event.EventType() != userProfileUpdatedEventType {
+		return nil
+	}
+
+	var payload struct {
+		UserID string `json:"user_id"`
+		User   struct {
+			WebID string `json:"webid"`
+			Email string `json:"email"`
+		} `json:"user"`
+		NewProfile domain.UserProfile `json:"new_profile"`
+	}
+	if err := json.Unmarshal(event.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal profile updated event: %w", err)
+	}
+	if payload.UserID == "" || payload.User.WebID == "" {
+		log.Context(ctx).Warnf("Skipping WebID profile update for malformed %s event on user %s", event.EventType(), event.AggregateID())
+		return nil
+	}
+
+	return s.SyncProfile(ctx, payload.UserID, payload.User.WebID, payload.User.Email, payload.NewProfile.Name)
+}
+
+// SyncProfile stores the user's WebID profile document with their current name and email.
+// The document is only written when its content changes.
+func (s *WebIDProfileSync) SyncProfile(ctx context.Context, userID, webID, email, name string) error {
+	current, err := s.fileStorage.ReadWebIDDocument(ctx, userID)
+	if err != nil && !errors.Is(err, infrastructure.ErrUserNotFound) {
+		return fmt.Errorf("failed to read WebID document: %w", err)
+	}
+
+	var document string
+	if err != nil {
+		document, err = s.webidGen.GenerateWebIDDocument(ctx, webID, email, name)
+		if err != nil {
+			return fmt.Errorf("failed to generate WebID document: %w", err)
+		}
+	} else {
+		document = infrastructure.MergeWebIDProfile(current, webID, email, name)
+		if document == current {
+			return nil
+		}
+	}
+
+	if err := s.fileStorage.WriteWebIDDocument(ctx, userID, webID, document); err != nil {
+		return fmt.Errorf("failed to write WebID document: %w", err)
+	}
+
+	return nil
+}
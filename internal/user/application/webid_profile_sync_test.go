@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/akeemphilbert/goro/internal/user/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebIDProfileSync_Handle(t *testing.T) {
+	ctx := context.Background()
+	webID := "https://example.com/users/user-123#me"
+	profileUpdated := func(t *testing.T, name string) testEnvelope {
+		user, err := domain.NewUser(ctx, "user-123", webID, "john@example.com", domain.UserProfile{Name: "John Doe"})
+		require.NoError(t, err)
+		require.NoError(t, user.UpdateProfile(ctx, domain.UserProfile{Name: name}))
+		events := user.UncommittedEvents()
+		return testEnvelope{event: events[len(events)-1]}
+	}
+
+	t.Run("merges the new name into the stored document", func(t *testing.T) {
+		fileStorage := &MockFileStorage{}
+		stored := "<" + webID + "> <http://xmlns.com/foaf/0.1/name> \"John Doe\" ;\n    <http://xmlns.com/foaf/0.1/mbox> <mailto:john@example.com> ;\n    <http://example.com/hobby> \"chess\" .\n"
+		fileStorage.On("ReadWebIDDocument", ctx, "user-123").Return(stored, nil)
+		fileStorage.On("WriteWebIDDocument", ctx, "user-123", webID, mock.Anything).Return(nil)
+
+		sync := NewWebIDProfileSync(fileStorage, infrastructure.NewWebIDGenerator("https://example.com"))
+		require.NoError(t, sync.Handle(ctx, profileUpdated(t, "John Smith")))
+
+		document := fileStorage.Calls[1].Arguments.String(3)
+		assert.Contains(t, document, `<http://xmlns.com/foaf/0.1/name> "John Smith"`)
+		assert.Contains(t, document, `<http://example.com/hobby> "chess"`)
+	})
+
+	t.Run("does not rewrite an up-to-date document", func(t *testing.T) {
+		fileStorage := &MockFileStorage{}
+		stored := "<" + webID + "> <http://xmlns.com/foaf/0.1/name> \"John Smith\" ;\n    <http://xmlns.com/foaf/0.1/mbox> <mailto:john@example.com> .\n"
+		fileStorage.On("ReadWebIDDocument", ctx, "user-123").Return(stored, nil)
+
+		sync := NewWebIDProfileSync(fileStorage, infrastructure.NewWebIDGenerator("https://example.com"))
+		require.NoError(t, sync.Handle(ctx, profileUpdated(t, "John Smith")))
+
+		fileStorage.AssertNotCalled(t, "WriteWebIDDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("generates a document for users without one", func(t *testing.T) {
+		fileStorage := &MockFileStorage{}
+		fileStorage.On("ReadWebIDDocument", ctx, "user-123").Return("", fmt.Errorf("%w: WebID document not found for user user-123", infrastructure.ErrUserNotFound))
+		fileStorage.On("WriteWebIDDocument", ctx, "user-123", webID, mock.MatchedBy(func(document string) bool {
+			return assert.Contains(t, document, `foaf:name "John Smith"`)
+		})).Return(nil)
+
+		sync := NewWebIDProfileSync(fileStorage, infrastructure.NewWebIDGenerator("https://example.com"))
+		require.NoError(t, sync.Handle(ctx, profileUpdated(t, "John Smith")))
+
+		fileStorage.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// UserWebIDResolver finds the WebID a user acts as in Web Access Control decisions
+type UserWebIDResolver struct {
+	userRepo domain.UserRepository
+}
+
+// NewUserWebIDResolver creates a new UserWebIDResolver instance
+func NewUserWebIDResolver(userRepo domain.UserRepository) *UserWebIDResolver {
+	return &UserWebIDResolver{userRepo: userRepo}
+}
+
+// WebIDForUser returns the user's WebID. Unknown users have none, so their requests are
+// treated as unauthenticated.
+func (r *UserWebIDResolver) WebIDForUser(ctx context.Context, userID string) (string, error) {
+	exists, err := r.userRepo.Exists(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", nil
+	}
+
+	user, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.WebID, nil
+}
@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserWebIDResolver_WebIDForUser(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &MockUserRepository{}
+	mockUserRepo.On("Exists", ctx, "alice").Return(true, nil)
+	mockUserRepo.On("GetByID", ctx, "alice").Return(createTestUser("alice", "alice@example.com", "Alice"), nil)
+	mockUserRepo.On("Exists", ctx, "ghost").Return(false, nil)
+	mockUserRepo.On("Exists", ctx, "broken").Return(false, assert.AnError)
+
+	resolver := NewUserWebIDResolver(mockUserRepo)
+
+	webID, err := resolver.WebIDForUser(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/users/alice#me", webID)
+
+	webID, err = resolver.WebIDForUser(ctx, "ghost")
+	require.NoError(t, err)
+	assert.Empty(t, webID)
+
+	_, err = resolver.WebIDForUser(ctx, "broken")
+	assert.Error(t, err)
+}
@@ -2,8 +2,11 @@ package application
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"time"
 
+	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/user/domain"
 	"github.com/akeemphilbert/goro/internal/user/infrastructure"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
@@ -15,6 +18,7 @@ func ProvideUserService(
 	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
 	webidGen infrastructure.WebIDGenerator,
 	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
 ) (UserService, error) {
 	if unitOfWorkFactory == nil {
 		return nil, fmt.Errorf("unit of work factory cannot be nil")
@@ -25,8 +29,11 @@ func ProvideUserService(
 	if userRepo == nil {
 		return nil, fmt.Errorf("user repository cannot be nil")
 	}
+	if accountRepo == nil {
+		return nil, fmt.Errorf("account repository cannot be nil")
+	}
 
-	return NewUserService(unitOfWorkFactory, webidGen, userRepo), nil
+	return NewUserService(unitOfWorkFactory, webidGen, userRepo, accountRepo), nil
 }
 
 func ProvideAccountService(
@@ -37,6 +44,7 @@ func ProvideAccountService(
 	roleRepo domain.RoleRepository,
 	invitationRepo domain.InvitationRepository,
 	memberRepo domain.AccountMemberRepository,
+	notifier InvitationNotifier,
 ) (AccountService, error) {
 	if unitOfWorkFactory == nil {
 		return nil, fmt.Errorf("unit of work factory cannot be nil")
@@ -60,7 +68,69 @@ func ProvideAccountService(
 		return nil, fmt.Errorf("member repository cannot be nil")
 	}
 
-	return NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo), nil
+	service := NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo)
+	service.(*accountService).SetInvitationNotifier(notifier)
+	return service, nil
+}
+
+func ProvideNotificationService(notificationRepo domain.NotificationRepository) (NotificationService, error) {
+	if notificationRepo == nil {
+		return nil, fmt.Errorf("notification repository cannot be nil")
+	}
+
+	return NewNotificationService(notificationRepo), nil
+}
+
+// ProvideSessionService provides the session service, listing sessions idle for no longer than
+// the configured session idle timeout. The active sessions statistic is counted as it starts,
+// so it does not read zero after a restart.
+func ProvideSessionService(sessionRepo domain.SessionRepository, config *conf.Auth) (SessionService, error) {
+	if sessionRepo == nil {
+		return nil, fmt.Errorf("session repository cannot be nil")
+	}
+
+	var idleTimeout time.Duration
+	if config != nil {
+		idleTimeout = time.Duration(config.SessionIdleTimeout)
+	}
+	service := newSessionService(sessionRepo, idleTimeout)
+	service.refreshActiveSessions(context.Background())
+	return service, nil
+}
+
+// ProvideTOTPService provides the TOTP second factor service, sealing secrets under the
+// configured key. Without a key users cannot enroll.
+func ProvideTOTPService(
+	unitOfWorkFactory func() pericarpdomain.UnitOfWork,
+	userRepo domain.UserRepository,
+	config *conf.Auth,
+) (TOTPService, error) {
+	if unitOfWorkFactory == nil {
+		return nil, fmt.Errorf("unit of work factory cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+
+	var sealer domain.TOTPSecretSealer
+	issuer := "Goro"
+	if config != nil {
+		if config.TOTP.Key != "" {
+			key, err := base64.StdEncoding.DecodeString(config.TOTP.Key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TOTP key: %w", err)
+			}
+			aesSealer, err := infrastructure.NewAESSecretSealer(key)
+			if err != nil {
+				return nil, err
+			}
+			sealer = aesSealer
+		}
+		if config.TOTP.Issuer != "" {
+			issuer = config.TOTP.Issuer
+		}
+	}
+	return NewTOTPService(unitOfWorkFactory, userRepo, sealer, issuer), nil
 }
 
 // Event Handler Providers
@@ -100,6 +170,144 @@ func ProvideAccountEventHandler(
 	return NewAccountEventHandler(accountWriteRepo, accountMemberWriteRepo, invitationWriteRepo, fileStorage), nil
 }
 
+// ProvidePodAccountResolver provides the resolver mapping pod containers to accounts
+func ProvidePodAccountResolver(paths ContainerPathLookup, accountRepo domain.AccountRepository) (PodAccountResolver, error) {
+	if paths == nil {
+		return nil, fmt.Errorf("container path lookup cannot be nil")
+	}
+	if accountRepo == nil {
+		return nil, fmt.Errorf("account repository cannot be nil")
+	}
+
+	return NewRootContainerAccountResolver(paths, accountRepo), nil
+}
+
+// ProvideRoleContainerAccess provides the role-based access resolver for account pod containers
+func ProvideRoleContainerAccess(memberRepo domain.AccountMemberRepository, roleRepo domain.RoleRepository) (*RoleContainerAccess, error) {
+	if memberRepo == nil {
+		return nil, fmt.Errorf("member repository cannot be nil")
+	}
+	if roleRepo == nil {
+		return nil, fmt.Errorf("role repository cannot be nil")
+	}
+
+	return NewRoleContainerAccess(memberRepo, roleRepo), nil
+}
+
+// ProvideUserWebIDResolver provides the lookup from users to the WebIDs Web Access Control
+// decides access for
+func ProvideUserWebIDResolver(userRepo domain.UserRepository) (*UserWebIDResolver, error) {
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+
+	return NewUserWebIDResolver(userRepo), nil
+}
+
+// ProvideNotificationProjection provides the notification projection subscribed to
+// container membership events
+func ProvideNotificationProjection(
+	eventDispatcher pericarpdomain.EventDispatcher,
+	resolver PodAccountResolver,
+	memberRepo domain.AccountMemberRepository,
+	roleRepo domain.RoleRepository,
+	notificationRepo domain.NotificationRepository,
+) (*NotificationProjection, func(), error) {
+	if eventDispatcher == nil {
+		return nil, nil, fmt.Errorf("event dispatcher cannot be nil")
+	}
+	if resolver == nil {
+		return nil, nil, fmt.Errorf("pod account resolver cannot be nil")
+	}
+	if memberRepo == nil {
+		return nil, nil, fmt.Errorf("member repository cannot be nil")
+	}
+	if roleRepo == nil {
+		return nil, nil, fmt.Errorf("role repository cannot be nil")
+	}
+	if notificationRepo == nil {
+		return nil, nil, fmt.Errorf("notification repository cannot be nil")
+	}
+
+	projection := NewNotificationProjection(resolver, memberRepo, roleRepo, notificationRepo)
+	if err := NewEventHandlerRegistrar(eventDispatcher).RegisterNotificationProjection(projection); err != nil {
+		projection.Close()
+		return nil, nil, err
+	}
+
+	return projection, projection.Close, nil
+}
+
+// ProvideWebIDProfileSync provides the WebID profile sync subscribed to user profile updates.
+// It is nil when automatic profile updates are turned off.
+func ProvideWebIDProfileSync(
+	config *conf.Auth,
+	eventDispatcher pericarpdomain.EventDispatcher,
+	fileStorage FileStorage,
+	webidGen infrastructure.WebIDGenerator,
+) (*WebIDProfileSync, error) {
+	if config == nil || !config.WebIDProfileSync {
+		return nil, nil
+	}
+	if eventDispatcher == nil {
+		return nil, fmt.Errorf("event dispatcher cannot be nil")
+	}
+	if fileStorage == nil {
+		return nil, fmt.Errorf("file storage cannot be nil")
+	}
+	if webidGen == nil {
+		return nil, fmt.Errorf("WebID generator cannot be nil")
+	}
+
+	sync := NewWebIDProfileSync(fileStorage, webidGen)
+	if err := NewEventHandlerRegistrar(eventDispatcher).RegisterWebIDProfileSync(sync); err != nil {
+		return nil, err
+	}
+
+	return sync, nil
+}
+
+// ProvideAuditLogWriter provides the audit log writer subscribed to account and invitation
+// events
+func ProvideAuditLogWriter(
+	eventDispatcher pericarpdomain.EventDispatcher,
+	auditRepo domain.AuditLogRepository,
+) (*AuditLogWriter, error) {
+	if eventDispatcher == nil {
+		return nil, fmt.Errorf("event dispatcher cannot be nil")
+	}
+	if auditRepo == nil {
+		return nil, fmt.Errorf("audit log repository cannot be nil")
+	}
+
+	writer := NewAuditLogWriter(auditRepo)
+	if err := NewEventHandlerRegistrar(eventDispatcher).RegisterAuditLogWriter(writer); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// ProvidePasswordPolicy provides the password policy configured for auth, or the default
+// policy when none is configured
+func ProvidePasswordPolicy(config *conf.Auth) (*domain.PasswordPolicy, error) {
+	if config == nil {
+		return domain.DefaultPasswordPolicy(), nil
+	}
+
+	settings := config.PasswordPolicy
+	classes := make([]domain.CharacterClass, len(settings.RequiredClasses))
+	for i, class := range settings.RequiredClasses {
+		classes[i] = domain.CharacterClass(class)
+	}
+
+	policy, err := domain.NewPasswordPolicy(settings.MinLength, settings.MaxLength, classes, settings.Denylist, settings.MinStrength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid password policy: %w", err)
+	}
+	return policy, nil
+}
+
 // Event Handler Registration Provider
 func ProvideEventHandlerRegistrar(
 	eventDispatcher pericarpdomain.EventDispatcher,
@@ -135,10 +343,21 @@ func ProvideEventHandlerRegistrar(
 var UserApplicationProviderSet = wire.NewSet(
 	ProvideUserService,
 	ProvideAccountService,
+	ProvideNotificationService,
+	ProvideSessionService,
+	ProvideTOTPService,
 	ProvideUserEventHandler,
 	ProvideAccountEventHandler,
 	ProvideEventHandlerRegistrar,
+	ProvidePodAccountResolver,
+	ProvideNotificationProjection,
+	ProvideRoleContainerAccess,
+	ProvideUserWebIDResolver,
+	ProvideWebIDProfileSync,
+	ProvideAuditLogWriter,
+	ProvidePasswordPolicy,
 	ProvideInvitationGenerator,
+	ProvideInvitationNotifier,
 	ProvideFileStorageAdapter,
 	ProvideUnitOfWorkFactory,
 )
@@ -150,6 +369,12 @@ func ProvideInvitationGenerator() InvitationGenerator {
 	return &simpleInvitationGenerator{}
 }
 
+// ProvideInvitationNotifier provides the notifier invitations are sent with. It sends nothing;
+// deployments that email invitations bind an SMTP notifier in its place.
+func ProvideInvitationNotifier() InvitationNotifier {
+	return NoopInvitationNotifier{}
+}
+
 // ProvideFileStorageAdapter provides a file storage adapter for the application layer
 func ProvideFileStorageAdapter(domainFileStorage domain.FileStorage) FileStorage {
 	return &fileStorageAdapter{
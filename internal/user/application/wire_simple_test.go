@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/user/infrastructure"
 	pericarpdomain "github.com/akeemphilbert/pericarp/pkg/domain"
 )
@@ -53,6 +54,23 @@ func TestSimpleWireProviders(t *testing.T) {
 		}
 	})
 
+	t.Run("ProvidePasswordPolicy", func(t *testing.T) {
+		config := &conf.Auth{}
+		config.SetDefaults()
+		config.PasswordPolicy.RequiredClasses = []string{"digit"}
+
+		policy, err := ProvidePasswordPolicy(config)
+		if err != nil {
+			t.Fatalf("ProvidePasswordPolicy returned error: %v", err)
+		}
+		if err := policy.Check("no digits here"); err == nil {
+			t.Error("Expected a password without a digit to be refused")
+		}
+		if err := policy.Check("one digit: 7"); err != nil {
+			t.Errorf("Expected a password with a digit to pass, got %v", err)
+		}
+	})
+
 	t.Run("ProvideUnitOfWorkFactory", func(t *testing.T) {
 		// Create mock dependencies
 		eventDispatcher := &SimpleMockEventDispatcher{}
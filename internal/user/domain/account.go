@@ -15,6 +15,18 @@ type AccountSettings struct {
 	AllowInvitations bool   `json:"allow_invitations"`
 	DefaultRoleID    string `json:"default_role_id"`
 	MaxMembers       int    `json:"max_members"`
+	AuditReads       bool   `json:"audit_reads"`
+	// MaxPendingInvitations caps the invitations awaiting an answer; zero means unlimited
+	MaxPendingInvitations int `json:"max_pending_invitations"`
+	// MaxConcurrentOperations caps the heavy pod operations, such as batch creates and
+	// exports, the account runs at once; zero means the server-wide limit
+	MaxConcurrentOperations int `json:"max_concurrent_operations"`
+	// MaxStorageBytes caps the bytes of resource content the account stores; writes past it
+	// are refused with 507 Insufficient Storage. Zero means unlimited
+	MaxStorageBytes int64 `json:"max_storage_bytes"`
+	// UniqueIdentifiers refuses a Dublin Core identifier already used by another resource or
+	// container of the account with 409 Conflict
+	UniqueIdentifiers bool `json:"unique_identifiers"`
 }
 
 // Validate validates the account settings
@@ -25,9 +37,111 @@ func (s AccountSettings) Validate() error {
 	if s.MaxMembers < 0 {
 		return fmt.Errorf("max members cannot be negative")
 	}
+	if s.MaxPendingInvitations < 0 {
+		return fmt.Errorf("max pending invitations cannot be negative")
+	}
+	if s.MaxConcurrentOperations < 0 {
+		return fmt.Errorf("max concurrent operations cannot be negative")
+	}
+	if s.MaxStorageBytes < 0 {
+		return fmt.Errorf("max storage bytes cannot be negative")
+	}
 	return nil
 }
 
+// AccountSettingsPatch holds a partial settings update; nil fields are left unchanged
+type AccountSettingsPatch struct {
+	AllowInvitations *bool   `json:"allow_invitations,omitempty"`
+	DefaultRoleID    *string `json:"default_role_id,omitempty"`
+	MaxMembers       *int    `json:"max_members,omitempty"`
+	AuditReads       *bool   `json:"audit_reads,omitempty"`
+	// MaxPendingInvitations caps the invitations awaiting an answer; zero means unlimited
+	MaxPendingInvitations *int `json:"max_pending_invitations,omitempty"`
+	// MaxConcurrentOperations caps the account's concurrent heavy operations; zero means the
+	// server-wide limit
+	MaxConcurrentOperations *int `json:"max_concurrent_operations,omitempty"`
+	// MaxStorageBytes caps the bytes of resource content the account stores; zero means unlimited
+	MaxStorageBytes *int64 `json:"max_storage_bytes,omitempty"`
+	// UniqueIdentifiers refuses Dublin Core identifiers already used within the account
+	UniqueIdentifiers *bool `json:"unique_identifiers,omitempty"`
+}
+
+// IsEmpty reports whether the patch sets no fields
+func (p AccountSettingsPatch) IsEmpty() bool {
+	return p.AllowInvitations == nil && p.DefaultRoleID == nil && p.MaxMembers == nil && p.AuditReads == nil &&
+		p.MaxPendingInvitations == nil && p.MaxConcurrentOperations == nil && p.MaxStorageBytes == nil &&
+		p.UniqueIdentifiers == nil
+}
+
+// ApplyTo merges the provided fields into the given settings
+func (p AccountSettingsPatch) ApplyTo(settings AccountSettings) AccountSettings {
+	if p.AllowInvitations != nil {
+		settings.AllowInvitations = *p.AllowInvitations
+	}
+	if p.DefaultRoleID != nil {
+		settings.DefaultRoleID = *p.DefaultRoleID
+	}
+	if p.MaxMembers != nil {
+		settings.MaxMembers = *p.MaxMembers
+	}
+	if p.AuditReads != nil {
+		settings.AuditReads = *p.AuditReads
+	}
+	if p.MaxPendingInvitations != nil {
+		settings.MaxPendingInvitations = *p.MaxPendingInvitations
+	}
+	if p.MaxConcurrentOperations != nil {
+		settings.MaxConcurrentOperations = *p.MaxConcurrentOperations
+	}
+	if p.MaxStorageBytes != nil {
+		settings.MaxStorageBytes = *p.MaxStorageBytes
+	}
+	if p.UniqueIdentifiers != nil {
+		settings.UniqueIdentifiers = *p.UniqueIdentifiers
+	}
+	return settings
+}
+
+// ChangedSettingsFields returns the JSON names of the settings that differ between old and new
+func ChangedSettingsFields(oldSettings, newSettings AccountSettings) []string {
+	var changed []string
+	if oldSettings.AllowInvitations != newSettings.AllowInvitations {
+		changed = append(changed, "allow_invitations")
+	}
+	if oldSettings.DefaultRoleID != newSettings.DefaultRoleID {
+		changed = append(changed, "default_role_id")
+	}
+	if oldSettings.MaxMembers != newSettings.MaxMembers {
+		changed = append(changed, "max_members")
+	}
+	if oldSettings.AuditReads != newSettings.AuditReads {
+		changed = append(changed, "audit_reads")
+	}
+	if oldSettings.MaxPendingInvitations != newSettings.MaxPendingInvitations {
+		changed = append(changed, "max_pending_invitations")
+	}
+	if oldSettings.MaxConcurrentOperations != newSettings.MaxConcurrentOperations {
+		changed = append(changed, "max_concurrent_operations")
+	}
+	if oldSettings.MaxStorageBytes != newSettings.MaxStorageBytes {
+		changed = append(changed, "max_storage_bytes")
+	}
+	if oldSettings.UniqueIdentifiers != newSettings.UniqueIdentifiers {
+		changed = append(changed, "unique_identifiers")
+	}
+	return changed
+}
+
+// AccountDeletionStatus tracks a deleted account until its resources are purged
+type AccountDeletionStatus string
+
+const (
+	// AccountDeletionPurging accounts were deleted and their resources are being purged
+	AccountDeletionPurging AccountDeletionStatus = "purging"
+	// AccountDeletionPurged accounts were deleted and their resources purged
+	AccountDeletionPurged AccountDeletionStatus = "purged"
+)
+
 // Account represents an account in the system
 type Account struct {
 	*pericarpdomain.BasicEntity
@@ -35,8 +149,10 @@ type Account struct {
 	Name        string          `json:"name"`
 	Description string          `json:"description"`
 	Settings    AccountSettings `json:"settings"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	// DeletionStatus is empty until the account is deleted
+	DeletionStatus AccountDeletionStatus `json:"deletion_status,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
 }
 
 // NewAccount creates a new account with validation
@@ -199,6 +315,14 @@ func (a *Account) RemoveMember(ctx context.Context, user *User, accountMember *A
 	}
 	log.Context(ctx).Debugf("[RemoveMember] RemovedBy validation passed: removedByID=%s", removedBy.ID())
 
+	if user.ID() == a.OwnerID {
+		log.Context(ctx).Debugf("[RemoveMember] Validation failed: user %s still owns the account", user.ID())
+		err := fmt.Errorf("user %s owns account %s: %w", user.ID(), a.ID(), ErrCannotRemoveOwner)
+		a.AddError(err)
+		return err
+	}
+	log.Context(ctx).Debug("[RemoveMember] Owner check passed")
+
 	log.Context(ctx).Debug("[RemoveMember] All validations passed, processing member removal")
 
 	// Business logic for removing member would go here
@@ -216,6 +340,62 @@ func (a *Account) RemoveMember(ctx context.Context, user *User, accountMember *A
 	return nil
 }
 
+// TransferOwnership hands ownership of the account to another member
+func (a *Account) TransferOwnership(ctx context.Context, newOwner *User, newOwnerMember *AccountMember, transferredBy *User) error {
+	log.Context(ctx).Debugf("[TransferOwnership] Starting ownership transfer: account=%s", a.ID())
+
+	if newOwner == nil {
+		log.Context(ctx).Debug("[TransferOwnership] Validation failed: new owner is required")
+		err := fmt.Errorf("new owner is required")
+		a.AddError(err)
+		return err
+	}
+	log.Context(ctx).Debugf("[TransferOwnership] NewOwner validation passed: newOwnerID=%s", newOwner.ID())
+
+	if newOwnerMember == nil || newOwnerMember.AccountID != a.ID() || newOwnerMember.UserID != newOwner.ID() {
+		log.Context(ctx).Debug("[TransferOwnership] Validation failed: new owner must be a member of the account")
+		err := fmt.Errorf("new owner must be a member of the account")
+		a.AddError(err)
+		return err
+	}
+	log.Context(ctx).Debugf("[TransferOwnership] Membership validation passed: memberID=%s", newOwnerMember.ID())
+
+	if transferredBy == nil {
+		log.Context(ctx).Debug("[TransferOwnership] Validation failed: transferred by user is required")
+		err := fmt.Errorf("transferred by user is required")
+		a.AddError(err)
+		return err
+	}
+	log.Context(ctx).Debugf("[TransferOwnership] TransferredBy validation passed: transferredByID=%s", transferredBy.ID())
+
+	if transferredBy.ID() != a.OwnerID {
+		log.Context(ctx).Debug("[TransferOwnership] Validation failed: only the current owner can transfer ownership")
+		err := fmt.Errorf("only the current owner can transfer ownership")
+		a.AddError(err)
+		return err
+	}
+
+	if newOwner.ID() == a.OwnerID {
+		log.Context(ctx).Debug("[TransferOwnership] Validation failed: user already owns the account")
+		err := fmt.Errorf("user %s already owns account %s", newOwner.ID(), a.ID())
+		a.AddError(err)
+		return err
+	}
+
+	previousOwnerID := a.OwnerID
+	a.OwnerID = newOwner.ID()
+	a.UpdatedAt = time.Now()
+	log.Context(ctx).Debug("[TransferOwnership] Account owner and timestamp updated")
+
+	// Emit ownership transferred event
+	event := NewAccountOwnershipTransferredEvent(a, previousOwnerID, newOwner, transferredBy)
+	a.AddEvent(event)
+	log.Context(ctx).Debug("[TransferOwnership] Ownership transferred event created and added to entity")
+
+	log.Context(ctx).Infof("Account ownership transferred: account=%s, previousOwner=%s, newOwner=%s", a.ID(), previousOwnerID, newOwner.ID())
+	return nil
+}
+
 // UpdateMemberRole updates a member's role in the account
 func (a *Account) UpdateMemberRole(ctx context.Context, user *User, accountMember *AccountMember, oldRole, newRole *Role, updatedBy *User) error {
 	log.Context(ctx).Debugf("[UpdateMemberRole] Starting member role update in account: account=%s", a.ID())
@@ -277,6 +457,56 @@ func (a *Account) UpdateMemberRole(ctx context.Context, user *User, accountMembe
 	return nil
 }
 
+// IsDeleted reports whether the account was deleted, whether or not its purge completed
+func (a *Account) IsDeleted() bool {
+	return a.DeletionStatus != ""
+}
+
+// MarkDeleted deletes the account on behalf of its owner. Its resources stay behind until
+// MarkPurged records that they were purged.
+func (a *Account) MarkDeleted(ctx context.Context, deletedBy *User) error {
+	log.Context(ctx).Debugf("[MarkDeleted] Starting account deletion: account=%s", a.ID())
+
+	if deletedBy == nil {
+		err := fmt.Errorf("deleted by user is required")
+		a.AddError(err)
+		return err
+	}
+	if deletedBy.ID() != a.OwnerID {
+		err := fmt.Errorf("only the account owner can delete the account")
+		a.AddError(err)
+		return err
+	}
+	if a.IsDeleted() {
+		err := fmt.Errorf("account %s is already deleted", a.ID())
+		a.AddError(err)
+		return err
+	}
+
+	a.DeletionStatus = AccountDeletionPurging
+	a.UpdatedAt = time.Now()
+	a.AddEvent(NewAccountDeletedEvent(a, deletedBy))
+
+	log.Context(ctx).Infof("Account deleted, purge pending: account=%s, deletedBy=%s", a.ID(), deletedBy.ID())
+	return nil
+}
+
+// MarkPurged records that the resources of the deleted account were purged
+func (a *Account) MarkPurged(ctx context.Context) error {
+	if a.DeletionStatus != AccountDeletionPurging {
+		err := fmt.Errorf("account %s is not awaiting a purge", a.ID())
+		a.AddError(err)
+		return err
+	}
+
+	a.DeletionStatus = AccountDeletionPurged
+	a.UpdatedAt = time.Now()
+	a.AddEvent(NewAccountPurgedEvent(a))
+
+	log.Context(ctx).Infof("Account purged: account=%s", a.ID())
+	return nil
+}
+
 // UpdateSettings updates the account settings
 func (a *Account) UpdateSettings(ctx context.Context, settings AccountSettings) error {
 	log.Context(ctx).Debugf("[UpdateSettings] Starting account settings update: account=%s", a.ID())
@@ -312,3 +542,32 @@ func (a *Account) UpdateSettings(ctx context.Context, settings AccountSettings)
 	log.Context(ctx).Debug("[UpdateSettings] Account settings update completed successfully")
 	return nil
 }
+
+// PatchSettings merges a partial update into the current settings. The merged settings are
+// validated as a whole, and an update that changes nothing emits no event.
+func (a *Account) PatchSettings(ctx context.Context, patch AccountSettingsPatch) ([]string, error) {
+	log.Context(ctx).Debugf("[PatchSettings] Starting account settings patch: account=%s", a.ID())
+
+	settings := patch.ApplyTo(a.Settings)
+	if err := settings.Validate(); err != nil {
+		log.Context(ctx).Debugf("[PatchSettings] Settings validation failed: %v", err)
+		validationErr := fmt.Errorf("invalid settings: %w", err)
+		a.AddError(validationErr)
+		return nil, validationErr
+	}
+
+	changed := ChangedSettingsFields(a.Settings, settings)
+	if len(changed) == 0 {
+		log.Context(ctx).Debug("[PatchSettings] Patch leaves settings unchanged, skipping event")
+		return nil, nil
+	}
+
+	oldSettings := a.Settings
+	a.Settings = settings
+	a.UpdatedAt = time.Now()
+
+	a.AddEvent(NewAccountSettingsUpdatedEvent(a, oldSettings, settings))
+
+	log.Context(ctx).Infof("Account settings patched: account=%s, changed=%v", a.ID(), changed)
+	return changed, nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AuditTargetType identifies what an audited action was done to
+type AuditTargetType string
+
+const (
+	AuditTargetAccount    AuditTargetType = "account"
+	AuditTargetUser       AuditTargetType = "user"
+	AuditTargetInvitation AuditTargetType = "invitation"
+)
+
+// AuditEntry records one account management action: who did what to whom, and when.
+// Entries are a read-side projection of account and invitation events and are never
+// changed once written.
+type AuditEntry struct {
+	ID         string          `json:"id"`
+	AccountID  string          `json:"account_id"`
+	Actor      string          `json:"actor"`  // WebID of the acting user; empty for system actions
+	Action     string          `json:"action"` // event type, e.g. "account.member_removed"
+	TargetType AuditTargetType `json:"target_type"`
+	Target     string          `json:"target"` // account ID, member WebID or invited email
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// AuditTimeRange bounds audit queries to entries that occurred in [From, To). A zero
+// bound leaves that side of the range open.
+type AuditTimeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Validate validates the time range
+func (r AuditTimeRange) Validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && !r.To.After(r.From) {
+		return fmt.Errorf("time range end must be after its start")
+	}
+	return nil
+}
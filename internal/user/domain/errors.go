@@ -0,0 +1,68 @@
+package domain
+
+import "errors"
+
+// ErrExternalAuthFailed is returned when a call to an external identity provider
+// fails or times out
+var ErrExternalAuthFailed = errors.New("external authentication failed")
+
+// ErrLastAuthMethod is returned when removing an authentication method would leave
+// the user without any way to sign in
+var ErrLastAuthMethod = errors.New("cannot remove the last authentication method")
+
+// ErrOAuthProviderNotLinked is returned when the user has no linkage with the provider
+var ErrOAuthProviderNotLinked = errors.New("oauth provider is not linked")
+
+// ErrOAuthProviderAlreadyLinked is returned when the user is already linked with the provider
+var ErrOAuthProviderAlreadyLinked = errors.New("oauth provider is already linked")
+
+// ErrCannotRemoveOwner is returned when the account owner would be removed while still
+// holding ownership; ownership must be transferred to another member first
+var ErrCannotRemoveOwner = errors.New("cannot remove the account owner; transfer ownership first")
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs to another user
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// ErrSessionNotFound is returned when a session does not exist, has been revoked or belongs to
+// another user
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrOAuthRedirectNotAllowed is returned when an OAuth redirect URI is not on the
+// provider's configured allowlist
+var ErrOAuthRedirectNotAllowed = errors.New("oauth redirect uri is not allowed")
+
+// ErrOAuthStateMismatch is returned when an OAuth callback's state is unknown, expired or
+// already used, or does not match the provider and redirect the flow started with
+var ErrOAuthStateMismatch = errors.New("oauth state does not match a pending flow")
+
+// ErrTooManyPendingInvitations is returned when inviting would exceed the account's limit on
+// invitations awaiting an answer
+var ErrTooManyPendingInvitations = errors.New("account has too many pending invitations")
+
+// ErrMFANotEnrolled is returned when a TOTP code is checked for a user without a TOTP factor
+var ErrMFANotEnrolled = errors.New("user is not enrolled in multi-factor authentication")
+
+// ErrMFAAlreadyEnabled is returned when enrolling a user whose TOTP factor is already confirmed
+var ErrMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+
+// ErrMFAUnavailable is returned when enrolling in multi-factor authentication on a server that
+// has no key to seal TOTP secrets with
+var ErrMFAUnavailable = errors.New("multi-factor authentication is not configured")
+
+// ErrInvalidTOTPCode is returned when a TOTP code does not match the user's secret
+var ErrInvalidTOTPCode = errors.New("invalid TOTP code")
+
+// ErrTOTPCodeReused is returned when a TOTP code from an already used period is presented again
+var ErrTOTPCodeReused = errors.New("TOTP code has already been used")
+
+// ErrInvitationDeliveryFailed is returned alongside a stored invitation whose email could not
+// be sent; the invitation stands and its delivery can be retried
+var ErrInvitationDeliveryFailed = errors.New("invitation was created but could not be delivered")
+
+// ErrInvitationNotPending is returned when delivering or resending an invitation that was
+// already accepted or revoked, or delivering one that has expired
+var ErrInvitationNotPending = errors.New("invitation is no longer pending")
+
+// ErrInsufficientPermissions is returned when a user's role in an account does not grant the
+// permission an operation needs
+var ErrInsufficientPermissions = errors.New("insufficient permissions")
@@ -112,6 +112,34 @@ func (u *EventDataUnmarshaler) UnmarshalWebIDGeneratedEvent(event *EntityEvent)
 	return &data, nil
 }
 
+// UnmarshalOAuthLinkedEvent unmarshals an OAuth linked event from EntityEvent payload
+func (u *EventDataUnmarshaler) UnmarshalOAuthLinkedEvent(event *EntityEvent) (*OAuthLinkedEventData, error) {
+	if event.EventType() != EventTypeOAuthLinked {
+		return nil, fmt.Errorf("expected event type %s, got %s", EventTypeOAuthLinked, event.EventType())
+	}
+
+	var data OAuthLinkedEventData
+	if err := json.Unmarshal(event.Payload(), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OAuth linked event: %w", err)
+	}
+
+	return &data, nil
+}
+
+// UnmarshalOAuthUnlinkedEvent unmarshals an OAuth unlinked event from EntityEvent payload
+func (u *EventDataUnmarshaler) UnmarshalOAuthUnlinkedEvent(event *EntityEvent) (*OAuthUnlinkedEventData, error) {
+	if event.EventType() != EventTypeOAuthUnlinked {
+		return nil, fmt.Errorf("expected event type %s, got %s", EventTypeOAuthUnlinked, event.EventType())
+	}
+
+	var data OAuthUnlinkedEventData
+	if err := json.Unmarshal(event.Payload(), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OAuth unlinked event: %w", err)
+	}
+
+	return &data, nil
+}
+
 // UnmarshalAccountCreatedEvent unmarshals an account created event from EntityEvent payload
 func (u *EventDataUnmarshaler) UnmarshalAccountCreatedEvent(event *EntityEvent) (*AccountCreatedEventData, error) {
 	if event.EventType() != EventTypeAccountCreated {
@@ -182,6 +210,20 @@ func (u *EventDataUnmarshaler) UnmarshalAccountMemberRemovedEvent(event *EntityE
 	return &data, nil
 }
 
+// UnmarshalAccountOwnershipTransferredEvent unmarshals an account ownership transferred event from EntityEvent payload
+func (u *EventDataUnmarshaler) UnmarshalAccountOwnershipTransferredEvent(event *EntityEvent) (*AccountOwnershipTransferredEventData, error) {
+	if event.EventType() != EventTypeAccountOwnershipTransferred {
+		return nil, fmt.Errorf("expected event type %s, got %s", EventTypeAccountOwnershipTransferred, event.EventType())
+	}
+
+	var data AccountOwnershipTransferredEventData
+	if err := json.Unmarshal(event.Payload(), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal account ownership transferred event: %w", err)
+	}
+
+	return &data, nil
+}
+
 // UnmarshalAccountMemberRoleUpdatedEvent unmarshals an account member role updated event from EntityEvent payload
 func (u *EventDataUnmarshaler) UnmarshalAccountMemberRoleUpdatedEvent(event *EntityEvent) (*AccountMemberRoleUpdatedEventData, error) {
 	if event.EventType() != EventTypeAccountMemberRoleUpdated {
@@ -414,6 +456,22 @@ func (d *EventTypeDispatcher) dispatchUserEvent(event *EntityEvent, handlers Eve
 			}
 			return handlers.WebIDGenerated(data)
 		}
+	case EventTypeOAuthLinked:
+		if handlers.OAuthLinked != nil {
+			data, err := d.unmarshaler.UnmarshalOAuthLinkedEvent(event)
+			if err != nil {
+				return err
+			}
+			return handlers.OAuthLinked(data)
+		}
+	case EventTypeOAuthUnlinked:
+		if handlers.OAuthUnlinked != nil {
+			data, err := d.unmarshaler.UnmarshalOAuthUnlinkedEvent(event)
+			if err != nil {
+				return err
+			}
+			return handlers.OAuthUnlinked(data)
+		}
 	}
 	return nil
 }
@@ -469,6 +527,14 @@ func (d *EventTypeDispatcher) dispatchAccountEvent(event *EntityEvent, handlers
 			}
 			return handlers.AccountMemberRoleUpdated(data)
 		}
+	case EventTypeAccountOwnershipTransferred:
+		if handlers.AccountOwnershipTransferred != nil {
+			data, err := d.unmarshaler.UnmarshalAccountOwnershipTransferredEvent(event)
+			if err != nil {
+				return err
+			}
+			return handlers.AccountOwnershipTransferred(data)
+		}
 	}
 	return nil
 }
@@ -584,14 +650,17 @@ type EventHandlers struct {
 	UserActivated      func(*UserActivatedEventData) error
 	UserDeleted        func(*UserDeletedEventData) error
 	WebIDGenerated     func(*WebIDGeneratedEventData) error
+	OAuthLinked        func(*OAuthLinkedEventData) error
+	OAuthUnlinked      func(*OAuthUnlinkedEventData) error
 
 	// Account event handlers
-	AccountCreated           func(*AccountCreatedEventData) error
-	AccountUpdated           func(*AccountUpdatedEventData) error
-	AccountSettingsUpdated   func(*AccountSettingsUpdatedEventData) error
-	AccountMemberAdded       func(*AccountMemberAddedEventData) error
-	AccountMemberRemoved     func(*AccountMemberRemovedEventData) error
-	AccountMemberRoleUpdated func(*AccountMemberRoleUpdatedEventData) error
+	AccountCreated              func(*AccountCreatedEventData) error
+	AccountUpdated              func(*AccountUpdatedEventData) error
+	AccountSettingsUpdated      func(*AccountSettingsUpdatedEventData) error
+	AccountMemberAdded          func(*AccountMemberAddedEventData) error
+	AccountMemberRemoved        func(*AccountMemberRemovedEventData) error
+	AccountMemberRoleUpdated    func(*AccountMemberRoleUpdatedEventData) error
+	AccountOwnershipTransferred func(*AccountOwnershipTransferredEventData) error
 
 	// Invitation event handlers
 	InvitationCreated  func(*InvitationCreatedEventData) error
@@ -21,16 +21,22 @@ const (
 	EventTypeUserActivated      = "activated"
 	EventTypeUserDeleted        = "deleted"
 	EventTypeWebIDGenerated     = "webid_generated"
+	EventTypeOAuthLinked        = "oauth_linked"
+	EventTypeOAuthUnlinked      = "oauth_unlinked"
+	EventTypeTOTPUpdated        = "totp_updated"
 )
 
 // Event types for account operations
 const (
-	EventTypeAccountCreated           = "created"
-	EventTypeAccountUpdated           = "updated"
-	EventTypeAccountSettingsUpdated   = "settings_updated"
-	EventTypeAccountMemberAdded       = "member_added"
-	EventTypeAccountMemberRemoved     = "member_removed"
-	EventTypeAccountMemberRoleUpdated = "member_role_updated"
+	EventTypeAccountCreated              = "created"
+	EventTypeAccountUpdated              = "updated"
+	EventTypeAccountSettingsUpdated      = "settings_updated"
+	EventTypeAccountMemberAdded          = "member_added"
+	EventTypeAccountMemberRemoved        = "member_removed"
+	EventTypeAccountMemberRoleUpdated    = "member_role_updated"
+	EventTypeAccountOwnershipTransferred = "ownership_transferred"
+	EventTypeAccountDeleted              = "deleted"
+	EventTypeAccountPurged               = "purged"
 )
 
 // Event types for invitation operations
@@ -128,6 +134,37 @@ func NewWebIDGeneratedEvent(user *User, webID string) *EntityEvent {
 	return pericarpdomain.NewEntityEvent("user", EventTypeWebIDGenerated, user.ID(), "", "", data)
 }
 
+func NewOAuthLinkedEvent(user *User, identity ExternalIdentity) *EntityEvent {
+	data := OAuthLinkedEventData{
+		BaseEventData: BaseEventData{OccurredAt: time.Now()},
+		UserID:        user.ID(),
+		User:          user,
+		Provider:      identity.Provider,
+		Subject:       identity.Subject,
+	}
+	return pericarpdomain.NewEntityEvent("user", EventTypeOAuthLinked, user.ID(), "", "", data)
+}
+
+func NewOAuthUnlinkedEvent(user *User, identity ExternalIdentity) *EntityEvent {
+	data := OAuthUnlinkedEventData{
+		BaseEventData: BaseEventData{OccurredAt: time.Now()},
+		UserID:        user.ID(),
+		User:          user,
+		Provider:      identity.Provider,
+		Subject:       identity.Subject,
+	}
+	return pericarpdomain.NewEntityEvent("user", EventTypeOAuthUnlinked, user.ID(), "", "", data)
+}
+
+func NewTOTPUpdatedEvent(user *User) *EntityEvent {
+	data := TOTPUpdatedEventData{
+		BaseEventData: BaseEventData{OccurredAt: time.Now()},
+		UserID:        user.ID(),
+		User:          user,
+	}
+	return pericarpdomain.NewEntityEvent("user", EventTypeTOTPUpdated, user.ID(), "", "", data)
+}
+
 // Account event constructors
 func NewAccountCreatedEvent(account *Account, owner *User) *EntityEvent {
 	data := AccountCreatedEventData{
@@ -152,6 +189,7 @@ func NewAccountSettingsUpdatedEvent(account *Account, oldSettings, newSettings A
 		Account:       account,
 		OldSettings:   oldSettings,
 		NewSettings:   newSettings,
+		ChangedFields: ChangedSettingsFields(oldSettings, newSettings),
 	}
 	return pericarpdomain.NewEntityEvent("account", EventTypeAccountSettingsUpdated, account.ID(), "", "", data)
 }
@@ -181,6 +219,34 @@ func NewAccountMemberRemovedEvent(account *Account, user *User, accountMember *A
 	return pericarpdomain.NewEntityEvent("account", EventTypeAccountMemberRemoved, account.ID(), "", "", data)
 }
 
+func NewAccountOwnershipTransferredEvent(account *Account, previousOwnerID string, newOwner *User, transferredBy *User) *EntityEvent {
+	data := AccountOwnershipTransferredEventData{
+		BaseEventData:   BaseEventData{OccurredAt: time.Now()},
+		Account:         account,
+		PreviousOwnerID: previousOwnerID,
+		NewOwner:        newOwner,
+		TransferredBy:   transferredBy,
+	}
+	return pericarpdomain.NewEntityEvent("account", EventTypeAccountOwnershipTransferred, account.ID(), "", "", data)
+}
+
+func NewAccountDeletedEvent(account *Account, deletedBy *User) *EntityEvent {
+	data := AccountDeletedEventData{
+		BaseEventData: BaseEventData{OccurredAt: time.Now()},
+		Account:       account,
+		DeletedBy:     deletedBy,
+	}
+	return pericarpdomain.NewEntityEvent("account", EventTypeAccountDeleted, account.ID(), "", "", data)
+}
+
+func NewAccountPurgedEvent(account *Account) *EntityEvent {
+	data := AccountPurgedEventData{
+		BaseEventData: BaseEventData{OccurredAt: time.Now()},
+		Account:       account,
+	}
+	return pericarpdomain.NewEntityEvent("account", EventTypeAccountPurged, account.ID(), "", "", data)
+}
+
 func NewAccountMemberRoleUpdatedEvent(account *Account, user *User, accountMember *AccountMember, oldRole, newRole *Role, updatedBy *User) *EntityEvent {
 	data := AccountMemberRoleUpdatedEventData{
 		BaseEventData: BaseEventData{OccurredAt: time.Now()},
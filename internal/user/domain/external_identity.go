@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ExternalIdentity links a user to an identity at an external OAuth/OIDC provider
+type ExternalIdentity struct {
+	Provider string    `json:"provider"`
+	Subject  string    `json:"subject"`
+	Email    string    `json:"email,omitempty"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+// Validate validates the external identity
+func (i ExternalIdentity) Validate() error {
+	if strings.TrimSpace(i.Provider) == "" {
+		return fmt.Errorf("provider is required")
+	}
+	if strings.TrimSpace(i.Subject) == "" {
+		return fmt.Errorf("subject is required")
+	}
+	return nil
+}
+
+// LinkOAuthProvider links an external provider identity so the user can sign in with it
+func (u *User) LinkOAuthProvider(ctx context.Context, identity ExternalIdentity) error {
+	if u.Status == UserStatusDeleted {
+		err := fmt.Errorf("cannot link provider to deleted user")
+		u.AddError(err)
+		return err
+	}
+
+	if err := identity.Validate(); err != nil {
+		validationErr := fmt.Errorf("invalid external identity: %w", err)
+		u.AddError(validationErr)
+		return validationErr
+	}
+
+	identity.Provider = normalizeProvider(identity.Provider)
+	if u.IsOAuthProviderLinked(identity.Provider) {
+		return fmt.Errorf("%w: %s", ErrOAuthProviderAlreadyLinked, identity.Provider)
+	}
+
+	if identity.LinkedAt.IsZero() {
+		identity.LinkedAt = time.Now()
+	}
+
+	u.ExternalIdentities = append(u.ExternalIdentities, identity)
+	u.UpdatedAt = time.Now()
+
+	log.Context(ctx).Infof("OAuth provider linked: id=%s, provider=%s", u.ID(), identity.Provider)
+
+	// Emit OAuth linked event
+	event := NewOAuthLinkedEvent(u, identity)
+	u.AddEvent(event)
+	return nil
+}
+
+// UnlinkOAuthProvider removes the linkage with an external provider, preventing future sign-ins
+// through it. The user must keep at least one usable authentication method.
+func (u *User) UnlinkOAuthProvider(ctx context.Context, provider string) error {
+	provider = normalizeProvider(provider)
+
+	index := -1
+	for i, identity := range u.ExternalIdentities {
+		if identity.Provider == provider {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrOAuthProviderNotLinked, provider)
+	}
+
+	if u.AuthMethodCount() <= 1 {
+		log.Context(ctx).Warnf("Refusing to unlink last authentication method: id=%s, provider=%s", u.ID(), provider)
+		return ErrLastAuthMethod
+	}
+
+	removed := u.ExternalIdentities[index]
+	u.ExternalIdentities = append(u.ExternalIdentities[:index:index], u.ExternalIdentities[index+1:]...)
+	u.UpdatedAt = time.Now()
+
+	log.Context(ctx).Infof("OAuth provider unlinked: id=%s, provider=%s", u.ID(), provider)
+
+	// Emit OAuth unlinked event
+	event := NewOAuthUnlinkedEvent(u, removed)
+	u.AddEvent(event)
+	return nil
+}
+
+// IsOAuthProviderLinked reports whether the user has a linkage with the provider
+func (u *User) IsOAuthProviderLinked(provider string) bool {
+	provider = normalizeProvider(provider)
+	for _, identity := range u.ExternalIdentities {
+		if identity.Provider == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthMethodCount returns the number of usable authentication methods (password and linked providers)
+func (u *User) AuthMethodCount() int {
+	count := len(u.ExternalIdentities)
+	if u.HasPassword {
+		count++
+	}
+	return count
+}
+
+// normalizeProvider normalizes a provider name such as "Google" to "google"
+func normalizeProvider(provider string) string {
+	return strings.ToLower(strings.TrimSpace(provider))
+}
@@ -213,6 +213,32 @@ func (i *Invitation) Revoke(ctx context.Context, account *Account, revokedBy *Us
 	return nil
 }
 
+// Resend extends a pending invitation to expiresAt, replacing its token when token is set, so
+// the invitee can still accept it without being invited again. Expired invitations may be
+// resent; accepted and revoked ones may not.
+func (i *Invitation) Resend(ctx context.Context, account *Account, role *Role, resentBy *User, token string, expiresAt time.Time) error {
+	if i.Status != InvitationStatusPending {
+		log.Context(ctx).Warnf("Attempt to resend %s invitation: id=%s", i.Status, i.ID())
+		err := fmt.Errorf("cannot resend %s invitation: %w", i.Status, ErrInvitationNotPending)
+		i.AddError(err)
+		return err
+	}
+	if !expiresAt.After(time.Now()) {
+		return fmt.Errorf("expiration time must be in the future")
+	}
+
+	if token != "" {
+		i.Token = token
+	}
+	i.ExpiresAt = expiresAt
+	i.UpdatedAt = time.Now()
+
+	i.AddEvent(NewMemberInvitedEvent(i, account, role, resentBy, i.Email))
+
+	log.Context(ctx).Infof("Invitation resent: id=%s, resentBy=%s, expiresAt=%s", i.ID(), resentBy.ID(), expiresAt.Format(time.RFC3339))
+	return nil
+}
+
 // IsExpired checks if the invitation has expired
 func (i *Invitation) IsExpired() bool {
 	return time.Now().After(i.ExpiresAt)
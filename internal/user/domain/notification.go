@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationType identifies what a notification reports
+type NotificationType string
+
+const (
+	NotificationTypeMemberAdded   NotificationType = "member_added"
+	NotificationTypeMemberRemoved NotificationType = "member_removed"
+)
+
+// Notification is an in-app notice to one account member. Notifications are a
+// read-side projection of pod events rather than an event-sourced entity.
+type Notification struct {
+	ID          string           `json:"id"`
+	AccountID   string           `json:"account_id"`
+	UserID      string           `json:"user_id"`
+	Type        NotificationType `json:"type"`
+	ContainerID string           `json:"container_id"`
+	MemberID    string           `json:"member_id"`
+	CreatedAt   time.Time        `json:"created_at"`
+	ReadAt      *time.Time       `json:"read_at,omitempty"`
+}
+
+// IsRead reports whether the notification has been marked read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationFilter represents filtering options for notification queries
+type NotificationFilter struct {
+	UnreadOnly bool `json:"unread_only"`
+	Limit      int  `json:"limit"`
+	Offset     int  `json:"offset"`
+}
+
+// Validate validates the notification filter
+func (f NotificationFilter) Validate() error {
+	if f.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	if f.Offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrPasswordPolicyViolation is returned, wrapped in a PasswordPolicyError, when a password
+// breaks one or more rules of the password policy
+var ErrPasswordPolicyViolation = errors.New("password does not satisfy the password policy")
+
+// PasswordRule names a rule of the password policy
+type PasswordRule string
+
+const (
+	PasswordRuleMinLength  PasswordRule = "min_length"
+	PasswordRuleMaxLength  PasswordRule = "max_length"
+	PasswordRuleLowercase  PasswordRule = "lowercase"
+	PasswordRuleUppercase  PasswordRule = "uppercase"
+	PasswordRuleDigit      PasswordRule = "digit"
+	PasswordRuleSymbol     PasswordRule = "symbol"
+	PasswordRuleDenylisted PasswordRule = "denylisted"
+	PasswordRuleStrength   PasswordRule = "strength"
+)
+
+// CharacterClass is a class of characters a password can be required to contain
+type CharacterClass string
+
+const (
+	CharacterClassLowercase CharacterClass = "lowercase"
+	CharacterClassUppercase CharacterClass = "uppercase"
+	CharacterClassDigit     CharacterClass = "digit"
+	CharacterClassSymbol    CharacterClass = "symbol"
+)
+
+// characterClassRules maps each character class to the rule requiring it
+var characterClassRules = map[CharacterClass]PasswordRule{
+	CharacterClassLowercase: PasswordRuleLowercase,
+	CharacterClassUppercase: PasswordRuleUppercase,
+	CharacterClassDigit:     PasswordRuleDigit,
+	CharacterClassSymbol:    PasswordRuleSymbol,
+}
+
+// commonPasswords are refused by every policy, on top of its configured denylist
+var commonPasswords = []string{
+	"123456", "123456789", "12345678", "password", "qwerty", "qwerty123", "1q2w3e4r",
+	"111111", "123123", "abc123", "password1", "password123", "1234567890", "iloveyou",
+	"admin", "admin123", "welcome", "welcome1", "letmein", "monkey", "dragon", "sunshine",
+	"football", "baseball", "princess", "master", "shadow", "superman", "trustno1",
+	"passw0rd", "p@ssw0rd", "changeme", "secret", "login", "starwars", "whatever",
+}
+
+// PasswordViolation describes one rule a password breaks
+type PasswordViolation struct {
+	Rule    PasswordRule `json:"rule"`
+	Message string       `json:"message"`
+}
+
+// PasswordPolicyError lists every rule a password breaks, so clients can show each of them
+// rather than a generic weak password message
+type PasswordPolicyError struct {
+	Violations []PasswordViolation `json:"violations"`
+}
+
+// Error implements the error interface
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return fmt.Sprintf("%s: %s", ErrPasswordPolicyViolation, strings.Join(messages, "; "))
+}
+
+// Unwrap returns ErrPasswordPolicyViolation, so errors.Is recognises policy failures
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicyViolation
+}
+
+// Rules returns the rules the password breaks
+func (e *PasswordPolicyError) Rules() []PasswordRule {
+	rules := make([]PasswordRule, len(e.Violations))
+	for i, violation := range e.Violations {
+		rules[i] = violation.Rule
+	}
+	return rules
+}
+
+// PasswordPolicy decides which passwords users may set. A zero length bound or strength
+// score leaves that rule out.
+type PasswordPolicy struct {
+	MinLength       int
+	MaxLength       int
+	RequiredClasses []CharacterClass
+	// MinStrength is the lowest estimated strength score, from 0 (guessed at once) to 4
+	// (very unlikely to be guessed), a password may have
+	MinStrength int
+
+	denylist map[string]bool
+}
+
+// NewPasswordPolicy creates a password policy refusing the common passwords and those in
+// denylist, compared case-insensitively
+func NewPasswordPolicy(minLength, maxLength int, requiredClasses []CharacterClass, denylist []string, minStrength int) (*PasswordPolicy, error) {
+	if minLength < 0 || maxLength < 0 {
+		return nil, fmt.Errorf("password length bounds cannot be negative")
+	}
+	if maxLength > 0 && maxLength < minLength {
+		return nil, fmt.Errorf("maximum password length %d is below the minimum %d", maxLength, minLength)
+	}
+	for _, class := range requiredClasses {
+		if _, ok := characterClassRules[class]; !ok {
+			return nil, fmt.Errorf("unknown character class %q", class)
+		}
+	}
+	if minStrength < 0 || minStrength > 4 {
+		return nil, fmt.Errorf("minimum password strength must be between 0 and 4, got %d", minStrength)
+	}
+
+	policy := &PasswordPolicy{
+		MinLength:       minLength,
+		MaxLength:       maxLength,
+		RequiredClasses: requiredClasses,
+		MinStrength:     minStrength,
+		denylist:        make(map[string]bool, len(commonPasswords)+len(denylist)),
+	}
+	for _, password := range append(append([]string{}, commonPasswords...), denylist...) {
+		if password = strings.ToLower(strings.TrimSpace(password)); password != "" {
+			policy.denylist[password] = true
+		}
+	}
+	return policy, nil
+}
+
+// DefaultPasswordPolicy returns the policy used when none is configured: at least 8 and at
+// most 128 characters, and not a common password
+func DefaultPasswordPolicy() *PasswordPolicy {
+	policy, _ := NewPasswordPolicy(8, 128, nil, nil, 0)
+	return policy
+}
+
+// Check returns a PasswordPolicyError listing every rule the password breaks, or nil when it
+// satisfies the policy. A nil policy applies DefaultPasswordPolicy.
+func (p *PasswordPolicy) Check(password string) error {
+	if p == nil {
+		p = DefaultPasswordPolicy()
+	}
+
+	var violations []PasswordViolation
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleMinLength,
+			Message: fmt.Sprintf("password must be at least %d characters long", p.MinLength),
+		})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleMaxLength,
+			Message: fmt.Sprintf("password must be at most %d characters long", p.MaxLength),
+		})
+	}
+
+	present := characterClassesOf(password)
+	for _, class := range p.RequiredClasses {
+		if !present[class] {
+			violations = append(violations, PasswordViolation{
+				Rule:    characterClassRules[class],
+				Message: fmt.Sprintf("password must contain a %s character", class),
+			})
+		}
+	}
+
+	if p.denylist[strings.ToLower(password)] {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleDenylisted,
+			Message: "password is too common",
+		})
+	}
+
+	if p.MinStrength > 0 {
+		if score := p.StrengthScore(password); score < p.MinStrength {
+			violations = append(violations, PasswordViolation{
+				Rule:    PasswordRuleStrength,
+				Message: fmt.Sprintf("password is too easy to guess (strength %d of 4, at least %d required)", score, p.MinStrength),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// StrengthScore estimates how hard a password is to guess on zxcvbn's scale: 0 for fewer
+// than 10^3 guesses, then 1, 2 and 3 below 10^6, 10^8 and 10^10 guesses, and 4 beyond.
+// Guesses are estimated from the characters used, with repeated characters, runs such as
+// "abcd" or "4321", keyboard rows, years and embedded denylisted words counting as a single guess
+// each rather than one guess per character.
+func (p *PasswordPolicy) StrengthScore(password string) int {
+	log10Guesses := p.estimateGuessBits(password) * math.Log10(2)
+	switch {
+	case log10Guesses < 3:
+		return 0
+	case log10Guesses < 6:
+		return 1
+	case log10Guesses < 8:
+		return 2
+	case log10Guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// keyboardRows are the rows of a QWERTY keyboard, for spotting keyboard walks
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// minPatternLength is the shortest repeat or run treated as a single guess; embedded
+// denylisted words must be longer than it
+const minPatternLength = 3
+
+// estimateGuessBits returns log2 of the estimated number of guesses for a password
+func (p *PasswordPolicy) estimateGuessBits(password string) float64 {
+	runes := []rune(password)
+	lower := []rune(strings.ToLower(password))
+	perCharacter := math.Log2(float64(characterPoolSize(characterClassesOf(password))))
+
+	bits := 0.0
+	for i := 0; i < len(runes); {
+		if length := p.denylistedWordAt(lower, i); length > 0 {
+			// A known word costs a lookup in the denylist, plus its capitalisation
+			bits += math.Log2(float64(len(p.denylist))) + 1
+			i += length
+			continue
+		}
+		if yearAt(lower, i) {
+			bits += math.Log2(yearSpan)
+			i += 4
+			continue
+		}
+		if length := patternRunAt(lower, i); length >= minPatternLength {
+			bits += perCharacter + math.Log2(float64(length))
+			i += length
+			continue
+		}
+		bits += perCharacter
+		i++
+	}
+	return bits
+}
+
+// denylistedWordAt returns the length of the longest denylisted word starting at i, or 0
+func (p *PasswordPolicy) denylistedWordAt(lower []rune, i int) int {
+	for end := len(lower); end-i > minPatternLength; end-- {
+		if p.denylist[string(lower[i:end])] {
+			return end - i
+		}
+	}
+	return 0
+}
+
+// yearSpan is how many years a password's year is guessed among
+const yearSpan = 200
+
+// yearAt reports whether a year from 1900 to 2099 starts at i
+func yearAt(lower []rune, i int) bool {
+	if i+4 > len(lower) {
+		return false
+	}
+	for _, r := range lower[i : i+4] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	century := string(lower[i : i+2])
+	return century == "19" || century == "20"
+}
+
+// patternRunAt returns the length of the repeat, sequence or keyboard walk starting at i
+func patternRunAt(lower []rune, i int) int {
+	step := func(a, b rune) bool {
+		return a == b || b-a == 1 || a-b == 1 || adjacentOnKeyboard(a, b)
+	}
+	if i+1 >= len(lower) || !step(lower[i], lower[i+1]) {
+		return 1
+	}
+
+	// A run keeps the kind of step it started with: repeats, ascending or descending
+	delta := lower[i+1] - lower[i]
+	end := i + 1
+	for end+1 < len(lower) {
+		next := lower[end+1] - lower[end]
+		if next != delta && !(delta != 0 && adjacentOnKeyboard(lower[end], lower[end+1])) {
+			break
+		}
+		end++
+	}
+	return end - i + 1
+}
+
+// adjacentOnKeyboard reports whether b follows a on a keyboard row
+func adjacentOnKeyboard(a, b rune) bool {
+	for _, row := range keyboardRows {
+		if index := strings.IndexRune(row, a); index >= 0 && index+1 < len(row) && rune(row[index+1]) == b {
+			return true
+		}
+	}
+	return false
+}
+
+// characterClassesOf returns the character classes the password contains
+func characterClassesOf(password string) map[CharacterClass]bool {
+	classes := make(map[CharacterClass]bool)
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			classes[CharacterClassLowercase] = true
+		case unicode.IsUpper(r):
+			classes[CharacterClassUppercase] = true
+		case unicode.IsDigit(r):
+			classes[CharacterClassDigit] = true
+		default:
+			classes[CharacterClassSymbol] = true
+		}
+	}
+	return classes
+}
+
+// characterPoolSize returns how many characters a password drawing on the classes picks from
+func characterPoolSize(classes map[CharacterClass]bool) int {
+	size := 0
+	if classes[CharacterClassLowercase] {
+		size += 26
+	}
+	if classes[CharacterClassUppercase] {
+		size += 26
+	}
+	if classes[CharacterClassDigit] {
+		size += 10
+	}
+	if classes[CharacterClassSymbol] {
+		size += 33
+	}
+	if size == 0 {
+		size = 1
+	}
+	return size
+}
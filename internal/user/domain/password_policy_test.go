@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPasswordPolicy_ReportsEveryBrokenRule(t *testing.T) {
+	policy, err := NewPasswordPolicy(10, 20, []CharacterClass{CharacterClassUppercase, CharacterClassDigit, CharacterClassSymbol}, []string{"hunter2"}, 0)
+	if err != nil {
+		t.Fatalf("NewPasswordPolicy returned error: %v", err)
+	}
+
+	err = policy.Check("Hunter2")
+	if !errors.Is(err, ErrPasswordPolicyViolation) {
+		t.Fatalf("expected ErrPasswordPolicyViolation, got %v", err)
+	}
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected a PasswordPolicyError, got %T", err)
+	}
+	want := []PasswordRule{PasswordRuleMinLength, PasswordRuleSymbol, PasswordRuleDenylisted}
+	if got := policyErr.Rules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected rules %v, got %v", want, got)
+	}
+
+	if err := policy.Check("Sufficient-Passw0rd"); err != nil {
+		t.Errorf("expected a compliant password to pass, got %v", err)
+	}
+	if err := policy.Check("Much-Too-Long-Passw0rd-For-This-Policy"); err == nil {
+		t.Error("expected a password over the maximum length to be refused")
+	}
+}
+
+func TestPasswordPolicy_DefaultRefusesShortAndCommonPasswords(t *testing.T) {
+	var policy *PasswordPolicy
+
+	for _, password := range []string{"short", "Password123", "qwerty123"} {
+		if err := policy.Check(password); !errors.Is(err, ErrPasswordPolicyViolation) {
+			t.Errorf("expected %q to be refused, got %v", password, err)
+		}
+	}
+	if err := policy.Check("gravel lantern orbit"); err != nil {
+		t.Errorf("expected an uncommon passphrase to pass, got %v", err)
+	}
+}
+
+func TestPasswordPolicy_StrengthScore(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	tests := []struct {
+		password string
+		minScore int
+		maxScore int
+	}{
+		{password: "password", minScore: 0, maxScore: 0},
+		{password: "aaaaaaaaaaaa", minScore: 0, maxScore: 1},
+		{password: "abcdefgh1234", minScore: 0, maxScore: 1},
+		{password: "qwertyuiop", minScore: 0, maxScore: 1},
+		{password: "Password2024", minScore: 0, maxScore: 2},
+		{password: "x7#Kp2!mQz9&", minScore: 4, maxScore: 4},
+		{password: "correct horse battery staple", minScore: 4, maxScore: 4},
+	}
+
+	for _, tt := range tests {
+		if score := policy.StrengthScore(tt.password); score < tt.minScore || score > tt.maxScore {
+			t.Errorf("expected %q to score between %d and %d, got %d", tt.password, tt.minScore, tt.maxScore, score)
+		}
+	}
+}
+
+func TestPasswordPolicy_MinimumStrength(t *testing.T) {
+	policy, err := NewPasswordPolicy(8, 0, nil, nil, 3)
+	if err != nil {
+		t.Fatalf("NewPasswordPolicy returned error: %v", err)
+	}
+
+	var policyErr *PasswordPolicyError
+	if err := policy.Check("abcdefgh1234"); !errors.As(err, &policyErr) || !reflect.DeepEqual(policyErr.Rules(), []PasswordRule{PasswordRuleStrength}) {
+		t.Errorf("expected only the strength rule to fail, got %v", err)
+	}
+	if err := policy.Check("x7#Kp2!mQz9&"); err != nil {
+		t.Errorf("expected a strong password to pass, got %v", err)
+	}
+}
+
+func TestNewPasswordPolicy_RefusesInvalidSettings(t *testing.T) {
+	if _, err := NewPasswordPolicy(12, 8, nil, nil, 0); err == nil {
+		t.Error("expected a maximum below the minimum to be refused")
+	}
+	if _, err := NewPasswordPolicy(8, 0, []CharacterClass{"emoji"}, nil, 0); err == nil {
+		t.Error("expected an unknown character class to be refused")
+	}
+	if _, err := NewPasswordPolicy(8, 0, nil, nil, 5); err == nil {
+		t.Error("expected a strength above 4 to be refused")
+	}
+}
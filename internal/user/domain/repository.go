@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // UserFilter represents filtering options for user queries
@@ -24,6 +25,23 @@ func (f UserFilter) Validate() error {
 	return nil
 }
 
+// PaginationOptions selects one page of a listing
+type PaginationOptions struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// Validate validates the pagination options; a zero limit leaves the page unbounded
+func (p PaginationOptions) Validate() error {
+	if p.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	if p.Offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	return nil
+}
+
 // Read-only repository interfaces (for queries)
 
 // UserRepository provides read-only access to users
@@ -41,11 +59,20 @@ type AccountRepository interface {
 	GetByOwner(ctx context.Context, ownerID string) ([]*Account, error)
 }
 
+// ReadAuditingAccountSource is implemented by account repositories that can tell whether any
+// account has opted in to read auditing without loading each account
+type ReadAuditingAccountSource interface {
+	AnyAccountAuditsReads(ctx context.Context) (bool, error)
+}
+
 // AccountMemberRepository provides read-only access to account members (projection)
 type AccountMemberRepository interface {
 	GetByID(ctx context.Context, id string) (*AccountMember, error)
 	GetByAccountAndUser(ctx context.Context, accountID, userID string) (*AccountMember, error)
 	ListByAccount(ctx context.Context, accountID string) ([]*AccountMember, error)
+	// ListByAccountPaged returns one page of an account's members, oldest first, holding
+	// roleFilter when it is set, and how many members match in all
+	ListByAccountPaged(ctx context.Context, accountID string, opts PaginationOptions, roleFilter string) ([]*AccountMember, int, error)
 	ListByUser(ctx context.Context, userID string) ([]*AccountMember, error)
 }
 
@@ -57,6 +84,35 @@ type InvitationRepository interface {
 	ListByEmail(ctx context.Context, email string) ([]*Invitation, error)
 }
 
+// NotificationRepository stores the per-member notification projection
+type NotificationRepository interface {
+	Create(ctx context.Context, notifications []*Notification) error
+	ListByUser(ctx context.Context, userID string, filter NotificationFilter) ([]*Notification, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID, id string, readAt time.Time) error
+}
+
+// SessionRepository stores users' sign-in sessions
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	// ListActiveByUser returns the user's unrevoked sessions active since activeSince, most
+	// recently active first
+	ListActiveByUser(ctx context.Context, userID string, activeSince time.Time) ([]*Session, error)
+	// CountActive counts every user's unrevoked sessions active since activeSince
+	CountActive(ctx context.Context, activeSince time.Time) (int64, error)
+	// Touch records activity on one of the user's unrevoked sessions
+	Touch(ctx context.Context, userID, id string, at time.Time) error
+	// Revoke revokes one of the user's unrevoked sessions
+	Revoke(ctx context.Context, userID, id string, at time.Time) error
+}
+
+// AuditLogRepository stores the account audit trail. It is append-only: entries can be
+// added and listed but never updated or deleted.
+type AuditLogRepository interface {
+	Append(ctx context.Context, entry *AuditEntry) error
+	ListAuditEntries(ctx context.Context, accountID string, timeRange AuditTimeRange, opts PaginationOptions) ([]*AuditEntry, int, error)
+}
+
 // RoleRepository provides read-only access to roles
 type RoleRepository interface {
 	GetByID(ctx context.Context, id string) (*Role, error)
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Session is a user's sign-in session, with the user agent and address it was started from so
+// users can recognise their sessions. Sessions are plain records rather than event-sourced
+// entities.
+type Session struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	UserAgent      string     `json:"user_agent"`
+	IPAddress      string     `json:"ip_address"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastActivityAt time.Time  `json:"last_activity_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IsRevoked reports whether the session has been revoked
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
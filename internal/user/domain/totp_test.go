@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 seed of the RFC 6238 test vectors
+var rfc6238Secret = []byte("12345678901234567890")
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; 6-digit codes are their last six digits
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		if got := TOTPCode(rfc6238Secret, time.Unix(unix, 0)); got != want {
+			t.Errorf("TOTPCode at %d = %s, want %s", unix, got, want)
+		}
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(TOTPProvisioningURI("Goro", "alice@example.com", rfc6238Secret))
+	if err != nil {
+		t.Fatalf("Provisioning URI should parse: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Goro:alice@example.com" {
+		t.Errorf("Unexpected provisioning URI %s", uri)
+	}
+	query := uri.Query()
+	if query.Get("secret") != "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" || query.Get("issuer") != "Goro" || query.Get("digits") != "6" {
+		t.Errorf("Unexpected provisioning parameters %v", query)
+	}
+}
+
+func TestUser_VerifyTOTP(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	user, err := NewUser(ctx, "user-1", "https://example.com/user-1#me", "alice@example.com", UserProfile{Name: "Alice"})
+	if err != nil {
+		t.Fatalf("NewUser failed: %v", err)
+	}
+
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, TOTPCode(rfc6238Secret, now), now); !errors.Is(err, ErrMFANotEnrolled) {
+		t.Fatalf("Expected ErrMFANotEnrolled, got %v", err)
+	}
+	if err := user.EnrollTOTP(ctx, "sealed"); err != nil {
+		t.Fatalf("EnrollTOTP failed: %v", err)
+	}
+	if user.MFAEnabled() {
+		t.Fatal("MFA should not be enabled before a code is verified")
+	}
+
+	// A code from the previous period is accepted while clocks drift, and confirms enrollment
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, TOTPCode(rfc6238Secret, now.Add(-TOTPPeriod)), now); err != nil {
+		t.Fatalf("Code within the skew window should be accepted: %v", err)
+	}
+	if !user.MFAEnabled() {
+		t.Fatal("MFA should be enabled once a code is verified")
+	}
+	if err := user.EnrollTOTP(ctx, "other"); !errors.Is(err, ErrMFAAlreadyEnabled) {
+		t.Errorf("Expected ErrMFAAlreadyEnabled, got %v", err)
+	}
+
+	code := TOTPCode(rfc6238Secret, now)
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, code, now); err != nil {
+		t.Fatalf("Current code should be accepted: %v", err)
+	}
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, code, now); !errors.Is(err, ErrTOTPCodeReused) {
+		t.Errorf("Expected ErrTOTPCodeReused for a replayed code, got %v", err)
+	}
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, TOTPCode(rfc6238Secret, now.Add(-TOTPPeriod)), now); !errors.Is(err, ErrTOTPCodeReused) {
+		t.Errorf("Expected ErrTOTPCodeReused for a code older than the last used one, got %v", err)
+	}
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, TOTPCode(rfc6238Secret, now.Add(3*TOTPPeriod)), now); !errors.Is(err, ErrInvalidTOTPCode) {
+		t.Errorf("Expected ErrInvalidTOTPCode outside the skew window, got %v", err)
+	}
+	if err := user.VerifyTOTP(ctx, rfc6238Secret, strings.Repeat("1", TOTPDigits+1), now); !errors.Is(err, ErrInvalidTOTPCode) {
+		t.Errorf("Expected ErrInvalidTOTPCode for a malformed code, got %v", err)
+	}
+}
@@ -65,6 +65,32 @@ type WebIDGeneratedEventData struct {
 	WebID  string `json:"webid"`
 }
 
+// OAuthLinkedEventData represents data for when an external OAuth provider is linked to a user
+type OAuthLinkedEventData struct {
+	BaseEventData
+	UserID   string `json:"user_id"`
+	User     *User  `json:"user"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+// OAuthUnlinkedEventData represents data for when an external OAuth provider is unlinked from a user
+type OAuthUnlinkedEventData struct {
+	BaseEventData
+	UserID   string `json:"user_id"`
+	User     *User  `json:"user"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+// TOTPUpdatedEventData represents data for when a user's TOTP factor is enrolled or a code
+// from it is accepted
+type TOTPUpdatedEventData struct {
+	BaseEventData
+	UserID string `json:"user_id"`
+	User   *User  `json:"user"`
+}
+
 // AccountCreatedEventData represents data for when an account is created
 type AccountCreatedEventData struct {
 	BaseEventData
@@ -81,9 +107,10 @@ type AccountUpdatedEventData struct {
 // AccountSettingsUpdatedEventData represents data for when account settings are updated
 type AccountSettingsUpdatedEventData struct {
 	BaseEventData
-	Account     *Account        `json:"account"`
-	OldSettings AccountSettings `json:"old_settings"`
-	NewSettings AccountSettings `json:"new_settings"`
+	Account       *Account        `json:"account"`
+	OldSettings   AccountSettings `json:"old_settings"`
+	NewSettings   AccountSettings `json:"new_settings"`
+	ChangedFields []string        `json:"changed_fields"`
 }
 
 // AccountMemberAddedEventData represents data for when a member is added to an account
@@ -107,6 +134,29 @@ type AccountMemberRemovedEventData struct {
 	Reason        string         `json:"reason"`
 }
 
+// AccountOwnershipTransferredEventData represents data for when account ownership changes hands
+type AccountOwnershipTransferredEventData struct {
+	BaseEventData
+	Account         *Account `json:"account"`
+	PreviousOwnerID string   `json:"previous_owner_id"`
+	NewOwner        *User    `json:"new_owner"`
+	TransferredBy   *User    `json:"transferred_by"`
+}
+
+// AccountDeletedEventData represents data for when an account is deleted, ahead of the purge
+// of its resources
+type AccountDeletedEventData struct {
+	BaseEventData
+	Account   *Account `json:"account"`
+	DeletedBy *User    `json:"deleted_by"`
+}
+
+// AccountPurgedEventData represents data for when a deleted account's resources are purged
+type AccountPurgedEventData struct {
+	BaseEventData
+	Account *Account `json:"account"`
+}
+
 // AccountMemberRoleUpdatedEventData represents data for when a member's role is updated
 type AccountMemberRoleUpdatedEventData struct {
 	BaseEventData
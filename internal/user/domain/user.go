@@ -48,6 +48,12 @@ type User struct {
 	Status    UserStatus  `json:"status"`
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
+
+	// Authentication methods
+	HasPassword        bool               `json:"has_password"`
+	ExternalIdentities []ExternalIdentity `json:"external_identities,omitempty"`
+	// TOTP is the user's second factor; nil when the user has not enrolled
+	TOTP *TOTPFactor `json:"totp,omitempty"`
 }
 
 // NewUser creates a new user with validation
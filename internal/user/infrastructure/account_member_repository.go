@@ -80,6 +80,22 @@ func (r *GormAccountMemberRepository) ListByAccount(ctx context.Context, account
 	return members, nil
 }
 
+// ListByAccountPaged retrieves one page of an account's members, optionally only those holding
+// roleFilter, together with the number of matching members
+func (r *GormAccountMemberRepository) ListByAccountPaged(ctx context.Context, accountID string, opts domain.PaginationOptions, roleFilter string) ([]*domain.AccountMember, int, error) {
+	memberModels, total, err := listAccountMembersPage(r.db.WithContext(ctx), accountID, opts, roleFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members := make([]*domain.AccountMember, len(memberModels))
+	for i, model := range memberModels {
+		members[i] = r.modelToDomain(&model)
+	}
+
+	return members, total, nil
+}
+
 // ListByUser retrieves all account memberships for a user
 func (r *GormAccountMemberRepository) ListByUser(ctx context.Context, userID string) ([]*domain.AccountMember, error) {
 	if strings.TrimSpace(userID) == "" {
@@ -100,6 +116,46 @@ func (r *GormAccountMemberRepository) ListByUser(ctx context.Context, userID str
 	return members, nil
 }
 
+// listAccountMembersPage counts an account's members holding roleFilter, when set, and loads the
+// requested page of them ordered by when they joined, so pages stay stable between requests
+func listAccountMembersPage(db *gorm.DB, accountID string, opts domain.PaginationOptions, roleFilter string) ([]AccountMemberModel, int, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, 0, fmt.Errorf("account ID cannot be empty")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	// Each query starts from a fresh statement, as counting would otherwise leak into the page
+	members := func() *gorm.DB {
+		query := db.Model(&AccountMemberModel{}).Where("account_id = ?", accountID)
+		if roleFilter != "" {
+			query = query.Where("role_id = ?", roleFilter)
+		}
+		return query
+	}
+
+	var total int64
+	if err := members().Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count account members for account %s: %w", accountID, err)
+	}
+
+	page := members().Order("joined_at ASC, id ASC")
+	if opts.Limit > 0 {
+		page = page.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		page = page.Offset(opts.Offset)
+	}
+
+	var memberModels []AccountMemberModel
+	if err := page.Find(&memberModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list account members for account %s: %w", accountID, err)
+	}
+
+	return memberModels, int(total), nil
+}
+
 // modelToDomain converts an AccountMemberModel to a domain.AccountMember
 func (r *GormAccountMemberRepository) modelToDomain(model *AccountMemberModel) *domain.AccountMember {
 	member := &domain.AccountMember{
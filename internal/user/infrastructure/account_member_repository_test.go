@@ -179,6 +179,80 @@ func TestGormAccountMemberRepository_ListByAccount(t *testing.T) {
 	})
 }
 
+func TestGormAccountMemberRepository_ListByAccountPaged(t *testing.T) {
+	db := setupTestDBWithMigration(t)
+	repo := NewGormAccountMemberRepository(db)
+	ctx := context.Background()
+
+	ownerID := "owner-user-member-paged"
+	accountID := "account-member-paged"
+	joinedAt := time.Now().Add(-72 * time.Hour)
+
+	createTestUser(t, db, ownerID, "https://example.com/users/owner-member-paged", "owner-member-paged@example.com", "Owner Member Paged", string(domain.UserStatusActive))
+	createTestAccount(t, db, accountID, ownerID, "Paged Member Account", "Account for member paging")
+
+	// Members join an hour apart, so the listing order is known
+	members := []struct {
+		id, userID, roleID string
+	}{
+		{"member-paged-1", "user-paged-1", "member"},
+		{"member-paged-2", "user-paged-2", "admin"},
+		{"member-paged-3", "user-paged-3", "member"},
+		{"member-paged-4", "user-paged-4", "viewer"},
+		{"member-paged-5", "user-paged-5", "member"},
+	}
+	for i, m := range members {
+		createTestUser(t, db, m.userID, "https://example.com/users/"+m.userID, m.userID+"@example.com", "User "+m.userID, string(domain.UserStatusActive))
+		createTestAccountMember(t, db, m.id, accountID, m.userID, m.roleID, ownerID, joinedAt.Add(time.Duration(i)*time.Hour))
+	}
+
+	t.Run("should return the requested page and the total", func(t *testing.T) {
+		page, total, err := repo.ListByAccountPaged(ctx, accountID, domain.PaginationOptions{Limit: 2, Offset: 2}, "")
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		require.Len(t, page, 2)
+		assert.Equal(t, "member-paged-3", page[0].ID())
+		assert.Equal(t, "member-paged-4", page[1].ID())
+	})
+
+	t.Run("should return a short last page", func(t *testing.T) {
+		page, total, err := repo.ListByAccountPaged(ctx, accountID, domain.PaginationOptions{Limit: 2, Offset: 4}, "")
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		require.Len(t, page, 1)
+		assert.Equal(t, "member-paged-5", page[0].ID())
+	})
+
+	t.Run("should filter by role before paging", func(t *testing.T) {
+		page, total, err := repo.ListByAccountPaged(ctx, accountID, domain.PaginationOptions{Limit: 2, Offset: 1}, "member")
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, page, 2)
+		assert.Equal(t, "member-paged-3", page[0].ID())
+		assert.Equal(t, "member-paged-5", page[1].ID())
+	})
+
+	t.Run("should return every member without a limit", func(t *testing.T) {
+		page, total, err := repo.ListByAccountPaged(ctx, accountID, domain.PaginationOptions{}, "")
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Len(t, page, 5)
+	})
+
+	t.Run("should return error for negative pagination", func(t *testing.T) {
+		_, _, err := repo.ListByAccountPaged(ctx, accountID, domain.PaginationOptions{Limit: -1}, "")
+		assert.Error(t, err)
+
+		_, _, err = repo.ListByAccountPaged(ctx, accountID, domain.PaginationOptions{Offset: -1}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error for empty account ID", func(t *testing.T) {
+		_, _, err := repo.ListByAccountPaged(ctx, "", domain.PaginationOptions{Limit: 10}, "")
+		assert.Error(t, err)
+	})
+}
+
 func TestGormAccountMemberRepository_ListByUser(t *testing.T) {
 	db := setupTestDBWithMigration(t)
 	repo := NewGormAccountMemberRepository(db)
@@ -64,6 +64,19 @@ func (r *GormAccountRepository) GetByOwner(ctx context.Context, ownerID string)
 	return accounts, nil
 }
 
+// AnyAccountAuditsReads reports whether any account's settings enable read auditing. Settings
+// are stored as the JSON encoding of domain.AccountSettings, so an opted-in account's settings
+// contain "audit_reads":true.
+func (r *GormAccountRepository) AnyAccountAuditsReads(ctx context.Context) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&AccountModel{}).
+		Where("settings LIKE ?", `%"audit_reads":true%`).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check accounts for read auditing: %w", err)
+	}
+	return count > 0, nil
+}
+
 // modelToDomain converts an AccountModel to a domain.Account
 func (r *GormAccountRepository) modelToDomain(model *AccountModel) (*domain.Account, error) {
 	// Deserialize settings from JSON
@@ -83,13 +96,14 @@ func (r *GormAccountRepository) modelToDomain(model *AccountModel) (*domain.Acco
 	}
 
 	account := &domain.Account{
-		BasicEntity: pericarpdomain.NewEntity(model.ID),
-		OwnerID:     model.OwnerID,
-		Name:        model.Name,
-		Description: model.Description,
-		Settings:    settings,
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
+		BasicEntity:    pericarpdomain.NewEntity(model.ID),
+		OwnerID:        model.OwnerID,
+		Name:           model.Name,
+		Description:    model.Description,
+		Settings:       settings,
+		DeletionStatus: domain.AccountDeletionStatus(model.DeletionStatus),
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
 	}
 
 	return account, nil
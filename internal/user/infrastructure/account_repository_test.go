@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -136,3 +137,26 @@ func TestGormAccountRepository_GetByOwner(t *testing.T) {
 		assert.Len(t, accountList, 0)
 	})
 }
+
+func TestGormAccountRepository_AnyAccountAuditsReads(t *testing.T) {
+	db := setupTestDBWithMigration(t)
+	repo := NewGormAccountRepository(db).(domain.ReadAuditingAccountSource)
+	ctx := context.Background()
+
+	ownerID := "owner-user-1"
+	createTestUser(t, db, ownerID, "https://example.com/users/owner-1", "owner1@example.com", "Owner 1", string(domain.UserStatusActive))
+	createTestAccount(t, db, "quiet-account", ownerID, "Quiet", "")
+
+	audits, err := repo.AnyAccountAuditsReads(ctx)
+	require.NoError(t, err)
+	assert.False(t, audits)
+
+	// Settings are written as the account write repository encodes them
+	settings, err := json.Marshal(domain.AccountSettings{AuditReads: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&AccountModel{}).Where("id = ?", "quiet-account").Update("settings", string(settings)).Error)
+
+	audits, err = repo.AnyAccountAuditsReads(ctx)
+	require.NoError(t, err)
+	assert.True(t, audits)
+}
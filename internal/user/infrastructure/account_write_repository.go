@@ -47,13 +47,14 @@ func (r *GormAccountWriteRepository) Create(ctx context.Context, account *domain
 
 	// Convert domain account to GORM model
 	accountModel := &AccountModel{
-		ID:          account.ID(),
-		OwnerID:     account.OwnerID,
-		Name:        account.Name,
-		Description: account.Description,
-		Settings:    string(settingsJSON),
-		CreatedAt:   account.CreatedAt,
-		UpdatedAt:   account.UpdatedAt,
+		ID:             account.ID(),
+		OwnerID:        account.OwnerID,
+		Name:           account.Name,
+		Description:    account.Description,
+		Settings:       string(settingsJSON),
+		DeletionStatus: string(account.DeletionStatus),
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
 	}
 
 	err = r.db.WithContext(ctx).Create(accountModel).Error
@@ -87,8 +88,11 @@ func (r *GormAccountWriteRepository) Update(ctx context.Context, account *domain
 		Name:        account.Name,
 		Description: account.Description,
 		Settings:    string(settingsJSON),
-		CreatedAt:   account.CreatedAt,
-		UpdatedAt:   account.UpdatedAt,
+		// The deletion status only ever moves forward, so the zero value Updates skips is never
+		// a change
+		DeletionStatus: string(account.DeletionStatus),
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
 	}
 
 	result := r.db.WithContext(ctx).Model(&AccountModel{}).Where("id = ?", account.ID()).Updates(accountModel)
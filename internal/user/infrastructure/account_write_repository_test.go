@@ -128,6 +128,24 @@ func TestGormAccountWriteRepository_Update(t *testing.T) {
 		assert.Contains(t, accountModel.Settings, "false")
 	})
 
+	t.Run("should persist the deletion status", func(t *testing.T) {
+		createTestAccount(t, db, "account-update-deleted", "owner-update-deleted", "Deleted Account", "")
+
+		account := &domain.Account{
+			BasicEntity:    pericarpdomain.NewEntity("account-update-deleted"),
+			OwnerID:        "owner-update-deleted",
+			Name:           "Deleted Account",
+			Settings:       domain.AccountSettings{DefaultRoleID: "member"},
+			DeletionStatus: domain.AccountDeletionPurging,
+			UpdatedAt:      time.Now(),
+		}
+		require.NoError(t, repo.Update(ctx, account))
+
+		stored, err := NewGormAccountRepository(db).GetByID(ctx, "account-update-deleted")
+		require.NoError(t, err)
+		assert.Equal(t, domain.AccountDeletionPurging, stored.DeletionStatus)
+	})
+
 	t.Run("should return error for nil account", func(t *testing.T) {
 		err := repo.Update(ctx, nil)
 		assert.Error(t, err)
@@ -0,0 +1,109 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// GormAuditLogRepository implements domain.AuditLogRepository using GORM. It only ever
+// inserts and selects audit_entry_models rows.
+type GormAuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditLogRepository creates a new GORM-based audit log repository
+func NewGormAuditLogRepository(db *gorm.DB) domain.AuditLogRepository {
+	return &GormAuditLogRepository{db: db}
+}
+
+// Append adds an entry to the audit trail. Appending an entry whose ID is already recorded,
+// as happens when an event is delivered again, leaves the recorded entry as it is.
+func (r *GormAuditLogRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	if entry == nil {
+		return fmt.Errorf("audit entry cannot be nil")
+	}
+	if strings.TrimSpace(entry.ID) == "" {
+		return fmt.Errorf("audit entry ID cannot be empty")
+	}
+	if strings.TrimSpace(entry.AccountID) == "" {
+		return fmt.Errorf("audit entry account ID cannot be empty")
+	}
+
+	model := AuditEntryModel{
+		ID:         entry.ID,
+		AccountID:  entry.AccountID,
+		Actor:      entry.Actor,
+		Action:     entry.Action,
+		TargetType: string(entry.TargetType),
+		Target:     entry.Target,
+		OccurredAt: entry.OccurredAt,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to append audit entry for account %s: %w", entry.AccountID, err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns one page of an account's audit trail within the time range,
+// oldest first, and how many entries fall in the range in all
+func (r *GormAuditLogRepository) ListAuditEntries(ctx context.Context, accountID string, timeRange domain.AuditTimeRange, opts domain.PaginationOptions) ([]*domain.AuditEntry, int, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, 0, fmt.Errorf("account ID cannot be empty")
+	}
+	if err := timeRange.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("invalid time range: %w", err)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	// Each query starts from a fresh statement, as counting would otherwise leak into the page
+	entries := func() *gorm.DB {
+		query := r.db.WithContext(ctx).Model(&AuditEntryModel{}).Where("account_id = ?", accountID)
+		if !timeRange.From.IsZero() {
+			query = query.Where("occurred_at >= ?", timeRange.From)
+		}
+		if !timeRange.To.IsZero() {
+			query = query.Where("occurred_at < ?", timeRange.To)
+		}
+		return query
+	}
+
+	var total int64
+	if err := entries().Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries for account %s: %w", accountID, err)
+	}
+
+	page := entries().Order("occurred_at ASC, id ASC")
+	if opts.Limit > 0 {
+		page = page.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		page = page.Offset(opts.Offset)
+	}
+
+	var models []AuditEntryModel
+	if err := page.Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit entries for account %s: %w", accountID, err)
+	}
+
+	result := make([]*domain.AuditEntry, len(models))
+	for i, model := range models {
+		result[i] = &domain.AuditEntry{
+			ID:         model.ID,
+			AccountID:  model.AccountID,
+			Actor:      model.Actor,
+			Action:     model.Action,
+			TargetType: domain.AuditTargetType(model.TargetType),
+			Target:     model.Target,
+			OccurredAt: model.OccurredAt,
+		}
+	}
+	return result, int(total), nil
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+func TestGormAuditLogRepository_AppendAndList(t *testing.T) {
+	db := setupTestDBWithMigration(t)
+	repo := NewGormAuditLogRepository(db)
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, action := range []string{"invitation.created", "account.member_added", "account.member_role_updated", "account.member_removed"} {
+		require.NoError(t, repo.Append(ctx, &domain.AuditEntry{
+			ID:         action,
+			AccountID:  "acct",
+			Actor:      "https://pod.example.com/owner#me",
+			Action:     action,
+			TargetType: domain.AuditTargetUser,
+			Target:     "https://pod.example.com/member#me",
+			OccurredAt: start.Add(time.Duration(i) * time.Hour),
+		}))
+	}
+	require.NoError(t, repo.Append(ctx, &domain.AuditEntry{
+		ID: "other", AccountID: "other-acct", Action: "account.created", TargetType: domain.AuditTargetAccount, Target: "other-acct", OccurredAt: start,
+	}))
+
+	t.Run("should list an account's entries oldest first", func(t *testing.T) {
+		entries, total, err := repo.ListAuditEntries(ctx, "acct", domain.AuditTimeRange{}, domain.PaginationOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		require.Len(t, entries, 4)
+		assert.Equal(t, "invitation.created", entries[0].Action)
+		assert.Equal(t, "account.member_removed", entries[3].Action)
+		assert.Equal(t, domain.AuditTargetUser, entries[3].TargetType)
+		assert.Equal(t, "https://pod.example.com/owner#me", entries[3].Actor)
+	})
+
+	t.Run("should page through entries within a time range", func(t *testing.T) {
+		timeRange := domain.AuditTimeRange{From: start.Add(time.Hour), To: start.Add(3 * time.Hour)}
+		entries, total, err := repo.ListAuditEntries(ctx, "acct", timeRange, domain.PaginationOptions{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "account.member_role_updated", entries[0].Action)
+	})
+
+	t.Run("should keep the first entry when an ID is appended again", func(t *testing.T) {
+		require.NoError(t, repo.Append(ctx, &domain.AuditEntry{
+			ID: "account.member_added", AccountID: "acct", Action: "account.member_added", TargetType: domain.AuditTargetUser, Target: "someone-else", OccurredAt: start,
+		}))
+
+		entries, total, err := repo.ListAuditEntries(ctx, "acct", domain.AuditTimeRange{}, domain.PaginationOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, "https://pod.example.com/member#me", entries[1].Target)
+	})
+
+	t.Run("should refuse an inverted time range", func(t *testing.T) {
+		_, _, err := repo.ListAuditEntries(ctx, "acct", domain.AuditTimeRange{From: start, To: start.Add(-time.Hour)}, domain.PaginationOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,174 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// ExternalAuthClientConfig configures the shared HTTP client used for outbound
+// OAuth/OIDC calls (code exchange, user profile, JWKS fetch)
+type ExternalAuthClientConfig struct {
+	ConnectTimeout      time.Duration
+	ReadTimeout         time.Duration
+	WriteTimeout        time.Duration
+	RequestTimeout      time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+}
+
+// DefaultExternalAuthClientConfig returns the default outbound auth client configuration
+func DefaultExternalAuthClientConfig() ExternalAuthClientConfig {
+	return ExternalAuthClientConfig{
+		ConnectTimeout:      5 * time.Second,
+		ReadTimeout:         10 * time.Second,
+		WriteTimeout:        10 * time.Second,
+		RequestTimeout:      30 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+	}
+}
+
+// ExternalAuthClient is a pooled HTTP client with bounded timeouts for identity provider calls.
+// Waiting for a response is bounded by ReadTimeout, through the transport's response header
+// timeout, and the whole call by RequestTimeout. A request whose connection could not be made
+// is retried once; one whose connection was reset before a response arrived is retried once
+// only when its method is idempotent, so a POST such as a code exchange, which may already have
+// reached the provider, is never sent twice. Timeouts are not retried, since the provider may
+// still be working on the first attempt, and neither are HTTP responses, including auth
+// rejections.
+// Outbound identity provider calls (code exchange, userinfo, JWKS) go through Do; the server
+// does not make any yet.
+type ExternalAuthClient struct {
+	client *http.Client
+}
+
+// NewExternalAuthClient creates a new external auth client
+func NewExternalAuthClient(config ExternalAuthClientConfig) *ExternalAuthClient {
+	dialer := &net.Dialer{
+		Timeout:   config.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &deadlineConn{Conn: conn, writeTimeout: config.WriteTimeout}, nil
+		},
+		TLSHandshakeTimeout:   config.ConnectTimeout,
+		ResponseHeaderTimeout: config.ReadTimeout,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	return &ExternalAuthClient{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.RequestTimeout,
+		},
+	}
+}
+
+// HTTPClient returns the underlying pooled HTTP client for libraries that need one
+func (c *ExternalAuthClient) HTTPClient() *http.Client {
+	return c.client
+}
+
+// Do sends the request, retrying once when the connection could not be made, or was reset and
+// the request is idempotent. Timeouts and network failures are reported as
+// domain.ErrExternalAuthFailed.
+func (c *ExternalAuthClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil && isRetryableConnectionError(req.Method, err) && req.Context().Err() == nil {
+		retryReq, retryErr := cloneRequestForRetry(req)
+		if retryErr == nil {
+			resp, err = c.client.Do(retryReq)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s: %v", domain.ErrExternalAuthFailed, req.Method, req.URL.Host, err)
+	}
+
+	return resp, nil
+}
+
+// isRetryableConnectionError reports whether a request with the given method can be sent again
+// after failing with err. A dial failure never reached the provider, so any request is retried.
+// A reset or the connection closing before any response may have come after the provider
+// received the request, so only idempotent methods are retried. Timeouts, including the
+// client's own, and cancellations are not.
+func isRetryableConnectionError(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if !isIdempotentMethod(method) {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isIdempotentMethod reports whether sending a request with the method twice has the same
+// effect as sending it once, per RFC 9110
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// cloneRequestForRetry clones a request, rewinding the body when possible
+func cloneRequestForRetry(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("request body cannot be replayed")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+	return retryReq, nil
+}
+
+// deadlineConn applies a deadline to each write to a connection. Reads are left without one:
+// an idle pooled connection is read in the background while it waits for reuse, and a response
+// is bounded by the transport's header timeout and the client's request timeout instead.
+type deadlineConn struct {
+	net.Conn
+	writeTimeout time.Duration
+}
+
+// Write writes to the connection with the configured write timeout
+func (c *deadlineConn) Write(b []byte) (int, error) {
+	if c.writeTimeout > 0 {
+		if err := c.Conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	return c.Conn.Write(b)
+}
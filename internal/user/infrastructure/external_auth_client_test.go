@@ -0,0 +1,229 @@
+package infrastructure
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalAuthClient_TimeoutReturnsExternalAuthFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultExternalAuthClientConfig()
+	config.RequestTimeout = 50 * time.Millisecond
+	client := NewExternalAuthClient(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrExternalAuthFailed), "timeout should map to ErrExternalAuthFailed")
+}
+
+func TestExternalAuthClient_DoesNotRetryTimeouts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultExternalAuthClientConfig()
+	config.RequestTimeout = 50 * time.Millisecond
+	client := NewExternalAuthClient(config)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "a provider that timed out may still be handling the request")
+}
+
+func TestIsRetryableConnectionError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	_, refused := http.Get("http://" + addr)
+	require.Error(t, refused)
+	assert.True(t, isRetryableConnectionError(http.MethodGet, refused), "a refused connection is retried")
+	assert.True(t, isRetryableConnectionError(http.MethodPost, refused), "a request that never reached the provider is retried whatever its method")
+
+	reset := &url.Error{Op: "Post", URL: "http://" + addr, Err: io.EOF}
+	assert.True(t, isRetryableConnectionError(http.MethodGet, reset), "an idempotent request on a dropped connection is retried")
+	assert.False(t, isRetryableConnectionError(http.MethodPost, reset), "a POST on a dropped connection may have reached the provider")
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+	_, timedOut := (&http.Client{Timeout: 50 * time.Millisecond}).Get(slow.URL)
+	require.Error(t, timedOut)
+	assert.False(t, isRetryableConnectionError(http.MethodGet, timedOut), "client timeouts are not retried")
+}
+
+func TestProvideExternalAuthClient_LeavesConfigUntouched(t *testing.T) {
+	config := &conf.Auth{}
+
+	ProvideExternalAuthClient(config)
+
+	assert.Zero(t, config.Outbound.RequestTimeout, "defaults are not written back to the shared config")
+}
+
+func TestExternalAuthClient_DoesNotRetryAuthRejection(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewExternalAuthClient(DefaultExternalAuthClientConfig())
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "auth rejections must not be retried")
+}
+
+func TestExternalAuthClient_RetriesTransientNetworkErrorOnce(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// Drop the first connection without responding, then serve normally
+	var accepted int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if atomic.AddInt32(&accepted, 1) == 1 {
+				conn.Close()
+				continue
+			}
+			go http.Serve(&singleConnListener{conn: conn, addr: listener.Addr()}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+		}
+	}()
+
+	client := NewExternalAuthClient(DefaultExternalAuthClientConfig())
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+listener.Addr().String(), nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&accepted))
+}
+
+func TestExternalAuthClient_DoesNotRetryDroppedPost(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// Read the request, then drop the connection without responding
+	var accepted int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&accepted, 1)
+			go func() {
+				_, _ = http.ReadRequest(bufio.NewReader(conn))
+				conn.Close()
+			}()
+		}
+	}()
+
+	client := NewExternalAuthClient(DefaultExternalAuthClientConfig())
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+listener.Addr().String(), strings.NewReader("code=abc"))
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrExternalAuthFailed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&accepted), "an authorization code must not be exchanged twice")
+}
+
+func TestExternalAuthClient_KeepsIdleConnectionsPastReadTimeout(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	config := DefaultExternalAuthClientConfig()
+	config.ReadTimeout = 50 * time.Millisecond
+	client := NewExternalAuthClient(config)
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		time.Sleep(150 * time.Millisecond)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&connections), "an idle pooled connection is reused after the read timeout")
+}
+
+// singleConnListener serves exactly one pre-accepted connection
+type singleConnListener struct {
+	conn net.Conn
+	addr net.Addr
+	done bool
+}
+
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	if l.done {
+		return nil, net.ErrClosed
+	}
+	l.done = true
+	return l.conn, nil
+}
+
+func (l *singleConnListener) Close() error   { return nil }
+func (l *singleConnListener) Addr() net.Addr { return l.addr }
@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/email"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+const (
+	// DefaultInvitationSubject is the subject template used when none is configured
+	DefaultInvitationSubject = "You have been invited to join an account"
+	// DefaultInvitationBody is the body template used when none is configured
+	DefaultInvitationBody = `Hello,
+
+You have been invited to join an account as {{.RoleID}}.
+
+Accept the invitation by opening this link:
+
+{{.InviteURL}}
+
+The invitation expires on {{.ExpiresAt.Format "2 January 2006 15:04 MST"}}.
+`
+	// defaultSMTPTimeout bounds connecting to the SMTP server when no timeout is configured
+	defaultSMTPTimeout = 10 * time.Second
+)
+
+// InvitationMailConfig holds how invitation emails are sent and what they say
+type InvitationMailConfig struct {
+	SMTP        email.SMTPConfig `yaml:"smtp"`
+	FromAddress string           `yaml:"from_address"`
+	// AcceptURL is the page invitations are accepted on; the token is added as its token
+	// query parameter
+	AcceptURL string `yaml:"accept_url"`
+	// Subject and Body are text/template templates executed with InvitationMailData
+	Subject string        `yaml:"subject"`
+	Body    string        `yaml:"body"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// InvitationMailData is what the subject and body templates are executed with
+type InvitationMailData struct {
+	Email     string
+	AccountID string
+	RoleID    string
+	InvitedBy string
+	InviteURL string
+	ExpiresAt time.Time
+}
+
+// SMTPInvitationNotifier emails invitations through an SMTP server
+type SMTPInvitationNotifier struct {
+	config  InvitationMailConfig
+	subject *template.Template
+	body    *template.Template
+}
+
+// NewSMTPInvitationNotifier creates an SMTP notifier, parsing its templates up front so a bad
+// template is reported at startup rather than on the first invitation
+func NewSMTPInvitationNotifier(config InvitationMailConfig) (*SMTPInvitationNotifier, error) {
+	if config.SMTP.Host == "" {
+		return nil, fmt.Errorf("SMTP host cannot be empty")
+	}
+	if config.FromAddress == "" {
+		return nil, fmt.Errorf("from address cannot be empty")
+	}
+	if strings.ContainsAny(config.FromAddress, "\r\n") {
+		return nil, fmt.Errorf("from address cannot contain line breaks")
+	}
+	acceptURL, err := url.Parse(config.AcceptURL)
+	if err != nil || !acceptURL.IsAbs() {
+		return nil, fmt.Errorf("accept URL must be an absolute URL")
+	}
+	if config.SMTP.Port == 0 {
+		config.SMTP.Port = 587
+	}
+	if config.Subject == "" {
+		config.Subject = DefaultInvitationSubject
+	}
+	if config.Body == "" {
+		config.Body = DefaultInvitationBody
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultSMTPTimeout
+	}
+
+	subject, err := template.New("subject").Parse(config.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invitation subject template: %w", err)
+	}
+	body, err := template.New("body").Parse(config.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invitation body template: %w", err)
+	}
+
+	return &SMTPInvitationNotifier{config: config, subject: subject, body: body}, nil
+}
+
+// SendInvitation emails the invitation's accept link to the invited address
+func (n *SMTPInvitationNotifier) SendInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	if invitation == nil {
+		return fmt.Errorf("invitation cannot be nil")
+	}
+	if strings.ContainsAny(invitation.Email, "\r\n") {
+		return fmt.Errorf("invalid recipient address")
+	}
+
+	message, err := n.render(invitation)
+	if err != nil {
+		return err
+	}
+	return n.send(ctx, invitation.Email, message)
+}
+
+// InviteURL returns the link an invitation is accepted through
+func (n *SMTPInvitationNotifier) InviteURL(token string) string {
+	acceptURL, _ := url.Parse(n.config.AcceptURL)
+	query := acceptURL.Query()
+	query.Set("token", token)
+	acceptURL.RawQuery = query.Encode()
+	return acceptURL.String()
+}
+
+// render builds the invitation message with its headers
+func (n *SMTPInvitationNotifier) render(invitation *domain.Invitation) ([]byte, error) {
+	data := InvitationMailData{
+		Email:     invitation.Email,
+		AccountID: invitation.AccountID,
+		RoleID:    invitation.RoleID,
+		InvitedBy: invitation.InvitedBy,
+		InviteURL: n.InviteURL(invitation.Token),
+		ExpiresAt: invitation.ExpiresAt,
+	}
+
+	var subject, body bytes.Buffer
+	if err := n.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render invitation subject: %w", err)
+	}
+	if err := n.body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render invitation body: %w", err)
+	}
+	subjectLine := strings.Join(strings.Fields(subject.String()), " ")
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", n.config.FromAddress)
+	fmt.Fprintf(&message, "To: %s\r\n", invitation.Email)
+	fmt.Fprintf(&message, "Subject: %s\r\n", subjectLine)
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(strings.ReplaceAll(body.String(), "\r\n", "\n"), "\n", "\r\n"))
+	return message.Bytes(), nil
+}
+
+// send delivers one message, over implicit TLS when configured and otherwise upgrading with
+// STARTTLS when the server offers it
+func (n *SMTPInvitationNotifier) send(ctx context.Context, recipient string, message []byte) error {
+	smtpConfig := n.config.SMTP
+	address := net.JoinHostPort(smtpConfig.Host, strconv.Itoa(smtpConfig.Port))
+	dialer := &net.Dialer{Timeout: n.config.Timeout}
+
+	var conn net.Conn
+	var err error
+	if smtpConfig.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: smtpConfig.Host})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	deadline := time.Now().Add(n.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, smtpConfig.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if !smtpConfig.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: smtpConfig.Host}); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+	if smtpConfig.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(n.config.FromAddress); err != nil {
+		return fmt.Errorf("SMTP server refused sender: %w", err)
+	}
+	if err := client.Rcpt(recipient); err != nil {
+		return fmt.Errorf("SMTP server refused recipient: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP server refused message: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP server refused message: %w", err)
+	}
+	return client.Quit()
+}
@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/infrastructure/email"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts mail for any recipient except those at refused.example
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []string
+	rcpts    []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeSMTPServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "220 fake ESMTP\r\n")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			fmt.Fprint(conn, "250 fake\r\n")
+		case strings.HasPrefix(command, "MAIL FROM"):
+			fmt.Fprint(conn, "250 OK\r\n")
+		case strings.HasPrefix(command, "RCPT TO"):
+			if strings.Contains(command, "REFUSED.EXAMPLE") {
+				fmt.Fprint(conn, "550 No such user\r\n")
+				continue
+			}
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, strings.TrimSpace(line))
+			s.mu.Unlock()
+			fmt.Fprint(conn, "250 OK\r\n")
+		case command == "DATA":
+			fmt.Fprint(conn, "354 Go ahead\r\n")
+			var message strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				message.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message.String())
+			s.mu.Unlock()
+			fmt.Fprint(conn, "250 Queued\r\n")
+		case command == "QUIT":
+			fmt.Fprint(conn, "221 Bye\r\n")
+			return
+		default:
+			fmt.Fprint(conn, "250 OK\r\n")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) config(t *testing.T) email.SMTPConfig {
+	host, port, err := net.SplitHostPort(s.listener.Addr().String())
+	require.NoError(t, err)
+	var portNumber int
+	fmt.Sscanf(port, "%d", &portNumber)
+	return email.SMTPConfig{Host: host, Port: portNumber}
+}
+
+func testMailInvitation(recipient string) *domain.Invitation {
+	return &domain.Invitation{
+		AccountID: "account-1",
+		Email:     recipient,
+		RoleID:    "member",
+		Token:     "tok en/1",
+		InvitedBy: "owner-1",
+		Status:    domain.InvitationStatusPending,
+		ExpiresAt: time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC),
+	}
+}
+
+func TestSMTPInvitationNotifier_SendInvitation(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier, err := NewSMTPInvitationNotifier(InvitationMailConfig{
+		SMTP:        server.config(t),
+		FromAddress: "pods@example.com",
+		AcceptURL:   "https://pod.example/invitations/accept?lang=en",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.SendInvitation(context.Background(), testMailInvitation("invitee@example.com")))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.messages, 1)
+	assert.Equal(t, []string{"RCPT TO:<invitee@example.com>"}, server.rcpts)
+	message := server.messages[0]
+	assert.Contains(t, message, "From: pods@example.com\r\n")
+	assert.Contains(t, message, "To: invitee@example.com\r\n")
+	assert.Contains(t, message, "Subject: "+DefaultInvitationSubject+"\r\n")
+	assert.Contains(t, message, "https://pod.example/invitations/accept?lang=en&token=tok+en%2F1")
+	assert.Contains(t, message, "2 January 2030")
+}
+
+func TestSMTPInvitationNotifier_Templates(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier, err := NewSMTPInvitationNotifier(InvitationMailConfig{
+		SMTP:        server.config(t),
+		FromAddress: "pods@example.com",
+		AcceptURL:   "https://pod.example/accept",
+		Subject:     "Join {{.AccountID}}\nnow",
+		Body:        "Role {{.RoleID}}: {{.InviteURL}}",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.SendInvitation(context.Background(), testMailInvitation("invitee@example.com")))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.messages, 1)
+	assert.Contains(t, server.messages[0], "Subject: Join account-1 now\r\n", "the subject stays on one header line")
+	assert.Contains(t, server.messages[0], "Role member: https://pod.example/accept?token=tok+en%2F1")
+}
+
+func TestSMTPInvitationNotifier_Failures(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	config := InvitationMailConfig{
+		SMTP:        server.config(t),
+		FromAddress: "pods@example.com",
+		AcceptURL:   "https://pod.example/accept",
+	}
+
+	t.Run("refused recipient", func(t *testing.T) {
+		notifier, err := NewSMTPInvitationNotifier(config)
+		require.NoError(t, err)
+		err = notifier.SendInvitation(context.Background(), testMailInvitation("someone@refused.example"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "refused recipient")
+	})
+
+	t.Run("header injection", func(t *testing.T) {
+		notifier, err := NewSMTPInvitationNotifier(config)
+		require.NoError(t, err)
+		assert.Error(t, notifier.SendInvitation(context.Background(), testMailInvitation("a@example.com\r\nBcc: b@example.com")))
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().(*net.TCPAddr)
+		listener.Close()
+
+		unreachable := config
+		unreachable.SMTP = email.SMTPConfig{Host: "127.0.0.1", Port: address.Port}
+		notifier, err := NewSMTPInvitationNotifier(unreachable)
+		require.NoError(t, err)
+		assert.Error(t, notifier.SendInvitation(context.Background(), testMailInvitation("invitee@example.com")))
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		invalid := config
+		invalid.Body = "{{.InviteURL"
+		_, err := NewSMTPInvitationNotifier(invalid)
+		assert.Error(t, err)
+
+		invalid = config
+		invalid.AcceptURL = "/accept"
+		_, err = NewSMTPInvitationNotifier(invalid)
+		assert.Error(t, err)
+
+		invalid = config
+		invalid.SMTP.Host = ""
+		_, err = NewSMTPInvitationNotifier(invalid)
+		assert.Error(t, err)
+	})
+}
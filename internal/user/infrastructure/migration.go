@@ -17,6 +17,9 @@ func MigrateUserModels(db *gorm.DB) error {
 		&AccountModel{},
 		&AccountMemberModel{},
 		&InvitationModel{},
+		&NotificationModel{},
+		&SessionModel{},
+		&AuditEntryModel{},
 	)
 }
 
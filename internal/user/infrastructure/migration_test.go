@@ -21,7 +21,7 @@ func TestMigrateUserModels(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify that all tables were created
-	tables := []string{"user_models", "role_models", "account_models", "account_member_models", "invitation_models"}
+	tables := []string{"user_models", "role_models", "account_models", "account_member_models", "invitation_models", "notification_models", "session_models", "audit_entry_models"}
 
 	for _, table := range tables {
 		var count int64
@@ -2,21 +2,68 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/user/domain"
 	"github.com/go-kratos/kratos/v2/log"
 )
 
 // UserModel represents the GORM model for users table
 type UserModel struct {
-	ID        string    `gorm:"primaryKey;type:varchar(255)"`
-	WebID     string    `gorm:"uniqueIndex:idx_user_webid;not null;type:varchar(500)"`
-	Email     string    `gorm:"uniqueIndex:idx_user_email;not null;type:varchar(255)"`
-	Name      string    `gorm:"index:idx_user_name;type:varchar(255)"`
-	Status    string    `gorm:"index:idx_user_status;not null;type:varchar(50)"`
-	CreatedAt time.Time `gorm:"index:idx_user_created;not null"`
-	UpdatedAt time.Time `gorm:"not null"`
+	ID          string    `gorm:"primaryKey;type:varchar(255)"`
+	WebID       string    `gorm:"uniqueIndex:idx_user_webid;not null;type:varchar(500)"`
+	Email       string    `gorm:"uniqueIndex:idx_user_email;not null;type:varchar(255)"`
+	Name        string    `gorm:"index:idx_user_name;type:varchar(255)"`
+	Status      string    `gorm:"index:idx_user_status;not null;type:varchar(50)"`
+	AuthMethods string    `gorm:"type:text"` // JSON serialized UserAuthMethods
+	CreatedAt   time.Time `gorm:"index:idx_user_created;not null"`
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+// UserAuthMethods is the stored form of a user's authentication methods
+type UserAuthMethods struct {
+	HasPassword        bool                      `json:"has_password"`
+	ExternalIdentities []domain.ExternalIdentity `json:"external_identities"`
+	TOTP               *domain.TOTPFactor        `json:"totp,omitempty"`
+}
+
+// marshalUserAuthMethods serializes a user's authentication methods for storage
+func marshalUserAuthMethods(user *domain.User) (string, error) {
+	identities := user.ExternalIdentities
+	if identities == nil {
+		identities = []domain.ExternalIdentity{}
+	}
+
+	data, err := json.Marshal(UserAuthMethods{
+		HasPassword:        user.HasPassword,
+		ExternalIdentities: identities,
+		TOTP:               user.TOTP,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize user auth methods: %w", err)
+	}
+	return string(data), nil
+}
+
+// applyUserAuthMethods deserializes stored authentication methods onto a user
+func applyUserAuthMethods(user *domain.User, stored string) error {
+	if stored == "" {
+		return nil
+	}
+
+	var methods UserAuthMethods
+	if err := json.Unmarshal([]byte(stored), &methods); err != nil {
+		return fmt.Errorf("failed to deserialize user auth methods: %w", err)
+	}
+
+	user.HasPassword = methods.HasPassword
+	if len(methods.ExternalIdentities) > 0 {
+		user.ExternalIdentities = methods.ExternalIdentities
+	}
+	user.TOTP = methods.TOTP
+	return nil
 }
 
 // TableName specifies the table name for UserModel
@@ -104,8 +151,10 @@ type AccountModel struct {
 	Name        string    `gorm:"not null;type:varchar(255);index:idx_account_name"`
 	Description string    `gorm:"type:text"`
 	Settings    string    `gorm:"type:text"` // JSON serialized AccountSettings
-	CreatedAt   time.Time `gorm:"index:idx_account_created;not null"`
-	UpdatedAt   time.Time `gorm:"not null"`
+	// DeletionStatus is empty for accounts that were not deleted
+	DeletionStatus string    `gorm:"type:varchar(20);index:idx_account_deletion_status"`
+	CreatedAt      time.Time `gorm:"index:idx_account_created;not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
 }
 
 // TableName specifies the table name for AccountModel
@@ -296,3 +345,52 @@ func (i *InvitationModel) MarkExpired(ctx context.Context) error {
 	log.Context(ctx).Debugf("[InvitationModel.MarkExpired] Marking invitation as expired: invitationID=%s", i.ID)
 	return i.UpdateStatus(ctx, "expired")
 }
+
+// NotificationModel represents the GORM model for the member notification projection
+type NotificationModel struct {
+	ID          string     `gorm:"primaryKey;type:varchar(255)"`
+	AccountID   string     `gorm:"not null;type:varchar(255);index:idx_notification_account"`
+	UserID      string     `gorm:"not null;type:varchar(255);index:idx_notification_user_read"`
+	Type        string     `gorm:"not null;type:varchar(50)"`
+	ContainerID string     `gorm:"not null;type:varchar(255)"`
+	MemberID    string     `gorm:"not null;type:varchar(255)"`
+	ReadAt      *time.Time `gorm:"index:idx_notification_user_read"`
+	CreatedAt   time.Time  `gorm:"index:idx_notification_created;not null"`
+}
+
+// TableName specifies the table name for NotificationModel
+func (NotificationModel) TableName() string {
+	return "notification_models"
+}
+
+// SessionModel represents the GORM model for a user's sign-in session
+type SessionModel struct {
+	ID             string     `gorm:"primaryKey;type:varchar(255)"`
+	UserID         string     `gorm:"not null;type:varchar(255);index:idx_session_user_activity"`
+	UserAgent      string     `gorm:"type:text"`
+	IPAddress      string     `gorm:"type:varchar(45)"`
+	CreatedAt      time.Time  `gorm:"not null"`
+	LastActivityAt time.Time  `gorm:"not null;index:idx_session_user_activity"`
+	RevokedAt      *time.Time `gorm:"index"`
+}
+
+// TableName specifies the table name for SessionModel
+func (SessionModel) TableName() string {
+	return "session_models"
+}
+
+// AuditEntryModel represents the GORM model for the append-only account audit trail
+type AuditEntryModel struct {
+	ID         string    `gorm:"primaryKey;type:varchar(255)"`
+	AccountID  string    `gorm:"not null;type:varchar(255);index:idx_audit_account_time"`
+	Actor      string    `gorm:"type:varchar(500)"`
+	Action     string    `gorm:"not null;type:varchar(100)"`
+	TargetType string    `gorm:"not null;type:varchar(50)"`
+	Target     string    `gorm:"type:varchar(500)"`
+	OccurredAt time.Time `gorm:"not null;index:idx_audit_account_time"`
+}
+
+// TableName specifies the table name for AuditEntryModel
+func (AuditEntryModel) TableName() string {
+	return "audit_entry_models"
+}
@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// GormNotificationRepository implements domain.NotificationRepository using GORM
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationRepository creates a new GORM-based notification repository
+func NewGormNotificationRepository(db *gorm.DB) domain.NotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+// Create stores a batch of notifications in a single insert
+func (r *GormNotificationRepository) Create(ctx context.Context, notifications []*domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	models := make([]NotificationModel, len(notifications))
+	for i, notification := range notifications {
+		models[i] = r.domainToModel(notification)
+	}
+
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+	return nil
+}
+
+// ListByUser retrieves a user's notifications, newest first
+func (r *GormNotificationRepository) ListByUser(ctx context.Context, userID string, filter domain.NotificationFilter) ([]*domain.Notification, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var models []NotificationModel
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notifications for user %s: %w", userID, err)
+	}
+
+	notifications := make([]*domain.Notification, len(models))
+	for i := range models {
+		notifications[i] = r.modelToDomain(&models[i])
+	}
+	return notifications, nil
+}
+
+// CountUnread counts a user's unread notifications
+func (r *GormNotificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	if strings.TrimSpace(userID) == "" {
+		return 0, fmt.Errorf("user ID cannot be empty")
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&NotificationModel{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications for user %s: %w", userID, err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one of the user's notifications as read. Marking an already read
+// notification keeps its original read time.
+func (r *GormNotificationRepository) MarkRead(ctx context.Context, userID, id string, readAt time.Time) error {
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("notification ID cannot be empty")
+	}
+
+	var model NotificationModel
+	err := r.db.WithContext(ctx).First(&model, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("%w: %s", domain.ErrNotificationNotFound, id)
+		}
+		return fmt.Errorf("failed to get notification %s: %w", id, err)
+	}
+	if model.ReadAt != nil {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&model).Update("read_at", readAt).Error; err != nil {
+		return fmt.Errorf("failed to mark notification %s as read: %w", id, err)
+	}
+	return nil
+}
+
+// domainToModel converts a domain notification to its GORM model
+func (r *GormNotificationRepository) domainToModel(notification *domain.Notification) NotificationModel {
+	return NotificationModel{
+		ID:          notification.ID,
+		AccountID:   notification.AccountID,
+		UserID:      notification.UserID,
+		Type:        string(notification.Type),
+		ContainerID: notification.ContainerID,
+		MemberID:    notification.MemberID,
+		ReadAt:      notification.ReadAt,
+		CreatedAt:   notification.CreatedAt,
+	}
+}
+
+// modelToDomain converts a GORM model to a domain notification
+func (r *GormNotificationRepository) modelToDomain(model *NotificationModel) *domain.Notification {
+	return &domain.Notification{
+		ID:          model.ID,
+		AccountID:   model.AccountID,
+		UserID:      model.UserID,
+		Type:        domain.NotificationType(model.Type),
+		ContainerID: model.ContainerID,
+		MemberID:    model.MemberID,
+		CreatedAt:   model.CreatedAt,
+		ReadAt:      model.ReadAt,
+	}
+}
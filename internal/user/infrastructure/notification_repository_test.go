@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+func TestGormNotificationRepository_ListAndMarkRead(t *testing.T) {
+	db := setupTestDBWithMigration(t)
+	repo := NewGormNotificationRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	notifications := []*domain.Notification{
+		{ID: "n-1", AccountID: "acct", UserID: "user-1", Type: domain.NotificationTypeMemberAdded, ContainerID: "docs", MemberID: "a.ttl", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "n-2", AccountID: "acct", UserID: "user-1", Type: domain.NotificationTypeMemberRemoved, ContainerID: "docs", MemberID: "a.ttl", CreatedAt: now.Add(-time.Minute)},
+		{ID: "n-3", AccountID: "acct", UserID: "user-2", Type: domain.NotificationTypeMemberAdded, ContainerID: "docs", MemberID: "a.ttl", CreatedAt: now},
+	}
+	require.NoError(t, repo.Create(ctx, notifications))
+
+	t.Run("should list a user's notifications newest first", func(t *testing.T) {
+		listed, err := repo.ListByUser(ctx, "user-1", domain.NotificationFilter{})
+		require.NoError(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, "n-2", listed[0].ID)
+		assert.Equal(t, "n-1", listed[1].ID)
+		assert.False(t, listed[0].IsRead())
+	})
+
+	t.Run("should track unread notifications", func(t *testing.T) {
+		count, err := repo.CountUnread(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		require.NoError(t, repo.MarkRead(ctx, "user-1", "n-1", now))
+
+		count, err = repo.CountUnread(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		unread, err := repo.ListByUser(ctx, "user-1", domain.NotificationFilter{UnreadOnly: true})
+		require.NoError(t, err)
+		require.Len(t, unread, 1)
+		assert.Equal(t, "n-2", unread[0].ID)
+	})
+
+	t.Run("should not mark another user's notification", func(t *testing.T) {
+		err := repo.MarkRead(ctx, "user-1", "n-3", now)
+		assert.ErrorIs(t, err, domain.ErrNotificationNotFound)
+
+		count, err := repo.CountUnread(ctx, "user-2")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
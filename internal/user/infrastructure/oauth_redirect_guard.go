@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// oauthStateBytes is the amount of randomness in an OAuth state value
+const oauthStateBytes = 32
+
+// OAuthRedirectGuard keeps OAuth sign-in flows from redirecting anywhere but an allowlisted URI.
+// Redirect URIs are matched exactly against the provider's allowlist, and the redirect a flow
+// starts with is bound to its state, so the callback can only return to that same URI. The
+// server has no OAuth sign-in endpoints yet; the flow that builds authorization URLs must call
+// BeginFlow, and its callback CompleteFlow, when they are added.
+type OAuthRedirectGuard struct {
+	allowed  map[string]map[string]bool
+	stateTTL time.Duration
+	flows    map[string]oauthFlow
+	mu       sync.Mutex
+	now      func() time.Time
+}
+
+// oauthFlow is a started sign-in flow awaiting its callback
+type oauthFlow struct {
+	provider    string
+	redirectURI string
+	expiresAt   time.Time
+}
+
+// NewOAuthRedirectGuard creates a guard accepting the given redirect URIs per provider; flow
+// state expires after stateTTL
+func NewOAuthRedirectGuard(redirectURIs map[string][]string, stateTTL time.Duration) *OAuthRedirectGuard {
+	allowed := make(map[string]map[string]bool, len(redirectURIs))
+	for provider, uris := range redirectURIs {
+		allowed[provider] = make(map[string]bool, len(uris))
+		for _, uri := range uris {
+			allowed[provider][uri] = true
+		}
+	}
+
+	return &OAuthRedirectGuard{
+		allowed:  allowed,
+		stateTTL: stateTTL,
+		flows:    make(map[string]oauthFlow),
+		now:      time.Now,
+	}
+}
+
+// ValidateRedirect checks a redirect URI against the provider's allowlist
+func (g *OAuthRedirectGuard) ValidateRedirect(provider, redirectURI string) error {
+	if !g.allowed[provider][redirectURI] {
+		return fmt.Errorf("%w: %s for provider %s", domain.ErrOAuthRedirectNotAllowed, redirectURI, provider)
+	}
+	return nil
+}
+
+// BeginFlow validates the redirect URI an authorization URL is being built for and returns a
+// fresh state value bound to it
+func (g *OAuthRedirectGuard) BeginFlow(provider, redirectURI string) (string, error) {
+	if err := g.ValidateRedirect(provider, redirectURI); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, oauthStateBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+	state := base64.RawURLEncoding.EncodeToString(buf)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for key, flow := range g.flows {
+		if !now.Before(flow.expiresAt) {
+			delete(g.flows, key)
+		}
+	}
+	g.flows[state] = oauthFlow{
+		provider:    provider,
+		redirectURI: redirectURI,
+		expiresAt:   now.Add(g.stateTTL),
+	}
+	return state, nil
+}
+
+// CompleteFlow consumes the state returned to the callback and returns the redirect URI the
+// flow started with. A redirect URI reported by the callback must match it; an empty one
+// means the bound redirect is used. State is single-use, so a replayed callback fails.
+func (g *OAuthRedirectGuard) CompleteFlow(provider, state, redirectURI string) (string, error) {
+	g.mu.Lock()
+	flow, ok := g.flows[state]
+	delete(g.flows, state)
+	g.mu.Unlock()
+
+	if !ok || !g.now().Before(flow.expiresAt) {
+		return "", fmt.Errorf("%w: unknown or expired state", domain.ErrOAuthStateMismatch)
+	}
+	if flow.provider != provider {
+		return "", fmt.Errorf("%w: state was issued for provider %s", domain.ErrOAuthStateMismatch, flow.provider)
+	}
+	if redirectURI != "" && redirectURI != flow.redirectURI {
+		return "", fmt.Errorf("%w: redirect %s differs from the one the flow started with", domain.ErrOAuthStateMismatch, redirectURI)
+	}
+
+	// The allowlist may have changed since the flow started
+	if err := g.ValidateRedirect(provider, flow.redirectURI); err != nil {
+		return "", err
+	}
+	return flow.redirectURI, nil
+}
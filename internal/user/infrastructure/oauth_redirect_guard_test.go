@@ -0,0 +1,100 @@
+package infrastructure
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akeemphilbert/goro/internal/conf"
+	"github.com/akeemphilbert/goro/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOAuthRedirectGuard() *OAuthRedirectGuard {
+	return NewOAuthRedirectGuard(map[string][]string{
+		"github": {"https://app.example.com/auth/callback"},
+		"google": {"https://app.example.com/auth/google"},
+	}, 10*time.Minute)
+}
+
+func TestOAuthRedirectGuard_ValidateRedirect(t *testing.T) {
+	guard := newTestOAuthRedirectGuard()
+
+	assert.NoError(t, guard.ValidateRedirect("github", "https://app.example.com/auth/callback"))
+
+	for _, tt := range []struct{ provider, redirectURI string }{
+		{"github", "https://evil.example.com/auth/callback"},
+		{"github", "https://app.example.com/auth/callback/../../evil"},
+		{"github", "https://app.example.com/auth/callback?next=https://evil.example.com"},
+		{"github", "https://app.example.com/auth/google"},
+		{"gitlab", "https://app.example.com/auth/callback"},
+	} {
+		err := guard.ValidateRedirect(tt.provider, tt.redirectURI)
+		assert.True(t, errors.Is(err, domain.ErrOAuthRedirectNotAllowed), "%s %s should be rejected", tt.provider, tt.redirectURI)
+	}
+}
+
+func TestOAuthRedirectGuard_FlowReturnsBoundRedirect(t *testing.T) {
+	guard := newTestOAuthRedirectGuard()
+
+	state, err := guard.BeginFlow("github", "https://app.example.com/auth/callback")
+	require.NoError(t, err)
+	assert.NotEmpty(t, state)
+
+	redirectURI, err := guard.CompleteFlow("github", state, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://app.example.com/auth/callback", redirectURI)
+
+	// State is single-use
+	_, err = guard.CompleteFlow("github", state, "")
+	assert.True(t, errors.Is(err, domain.ErrOAuthStateMismatch))
+}
+
+func TestOAuthRedirectGuard_BeginFlowRejectsUnlistedRedirect(t *testing.T) {
+	guard := newTestOAuthRedirectGuard()
+
+	_, err := guard.BeginFlow("github", "https://evil.example.com/")
+	assert.True(t, errors.Is(err, domain.ErrOAuthRedirectNotAllowed))
+}
+
+func TestOAuthRedirectGuard_CompleteFlowRejectsMismatches(t *testing.T) {
+	guard := newTestOAuthRedirectGuard()
+
+	state, err := guard.BeginFlow("github", "https://app.example.com/auth/callback")
+	require.NoError(t, err)
+	_, err = guard.CompleteFlow("github", state, "https://app.example.com/auth/google")
+	assert.True(t, errors.Is(err, domain.ErrOAuthStateMismatch), "redirect differing from the bound one")
+
+	state, err = guard.BeginFlow("github", "https://app.example.com/auth/callback")
+	require.NoError(t, err)
+	_, err = guard.CompleteFlow("google", state, "")
+	assert.True(t, errors.Is(err, domain.ErrOAuthStateMismatch), "state issued for another provider")
+
+	_, err = guard.CompleteFlow("github", "forged-state", "")
+	assert.True(t, errors.Is(err, domain.ErrOAuthStateMismatch), "unknown state")
+}
+
+func TestOAuthRedirectGuard_StateExpires(t *testing.T) {
+	guard := newTestOAuthRedirectGuard()
+	now := time.Now()
+	guard.now = func() time.Time { return now }
+
+	state, err := guard.BeginFlow("github", "https://app.example.com/auth/callback")
+	require.NoError(t, err)
+
+	now = now.Add(11 * time.Minute)
+	_, err = guard.CompleteFlow("github", state, "")
+	assert.True(t, errors.Is(err, domain.ErrOAuthStateMismatch))
+}
+
+func TestProvideOAuthRedirectGuard_LeavesConfigUntouched(t *testing.T) {
+	config := &conf.Auth{OAuthRedirectURIs: map[string][]string{"github": {"https://app.example.com/auth/callback"}}}
+
+	guard := ProvideOAuthRedirectGuard(config)
+
+	assert.NoError(t, guard.ValidateRedirect("github", "https://app.example.com/auth/callback"))
+	assert.Equal(t, 10*time.Minute, guard.stateTTL)
+	assert.Zero(t, config.OAuthStateTTL, "defaults are not written back to the shared config")
+	assert.Nil(t, config.HTTPSOnlyPaths)
+}
@@ -225,6 +225,10 @@ func (r *OptimizedGormUserRepository) modelToDomain(model *UserModel) (*domain.U
 		UpdatedAt:   model.UpdatedAt,
 	}
 
+	if err := applyUserAuthMethods(user, model.AuthMethods); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -314,6 +318,21 @@ func (r *OptimizedGormAccountMemberRepository) ListByAccount(ctx context.Context
 	return members, nil
 }
 
+// ListByAccountPaged retrieves one page of an account's members with the account and role indexes
+func (r *OptimizedGormAccountMemberRepository) ListByAccountPaged(ctx context.Context, accountID string, opts domain.PaginationOptions, roleFilter string) ([]*domain.AccountMember, int, error) {
+	memberModels, total, err := listAccountMembersPage(r.db.WithContext(ctx), accountID, opts, roleFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members := make([]*domain.AccountMember, len(memberModels))
+	for i, model := range memberModels {
+		members[i] = r.modelToDomain(&model)
+	}
+
+	return members, total, nil
+}
+
 // ListByUser retrieves all memberships for a user with optimized indexing
 func (r *OptimizedGormAccountMemberRepository) ListByUser(ctx context.Context, userID string) ([]*domain.AccountMember, error) {
 	if strings.TrimSpace(userID) == "" {
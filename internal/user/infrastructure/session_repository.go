@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+// GormSessionRepository implements domain.SessionRepository using GORM
+type GormSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSessionRepository creates a new GORM-based session repository
+func NewGormSessionRepository(db *gorm.DB) domain.SessionRepository {
+	return &GormSessionRepository{db: db}
+}
+
+// Create stores a new session
+func (r *GormSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if session == nil {
+		return fmt.Errorf("session cannot be nil")
+	}
+	if strings.TrimSpace(session.ID) == "" {
+		return fmt.Errorf("session ID cannot be empty")
+	}
+	if strings.TrimSpace(session.UserID) == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+
+	model := SessionModel{
+		ID:             session.ID,
+		UserID:         session.UserID,
+		UserAgent:      session.UserAgent,
+		IPAddress:      session.IPAddress,
+		CreatedAt:      session.CreatedAt,
+		LastActivityAt: session.LastActivityAt,
+		RevokedAt:      session.RevokedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// ListActiveByUser retrieves the user's unrevoked sessions active since activeSince, most
+// recently active first. A zero activeSince lists every unrevoked session.
+func (r *GormSessionRepository) ListActiveByUser(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	query := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if !activeSince.IsZero() {
+		query = query.Where("last_activity_at >= ?", activeSince)
+	}
+
+	var models []SessionModel
+	if err := query.Order("last_activity_at DESC, id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list sessions for user %s: %w", userID, err)
+	}
+
+	sessions := make([]*domain.Session, len(models))
+	for i, model := range models {
+		sessions[i] = &domain.Session{
+			ID:             model.ID,
+			UserID:         model.UserID,
+			UserAgent:      model.UserAgent,
+			IPAddress:      model.IPAddress,
+			CreatedAt:      model.CreatedAt,
+			LastActivityAt: model.LastActivityAt,
+			RevokedAt:      model.RevokedAt,
+		}
+	}
+	return sessions, nil
+}
+
+// CountActive counts every user's unrevoked sessions active since activeSince. A zero
+// activeSince counts every unrevoked session.
+func (r *GormSessionRepository) CountActive(ctx context.Context, activeSince time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&SessionModel{}).Where("revoked_at IS NULL")
+	if !activeSince.IsZero() {
+		query = query.Where("last_activity_at >= ?", activeSince)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+	return count, nil
+}
+
+// Touch records activity on one of the user's unrevoked sessions
+func (r *GormSessionRepository) Touch(ctx context.Context, userID, id string, at time.Time) error {
+	return r.updateUnrevoked(ctx, userID, id, "last_activity_at", at)
+}
+
+// Revoke revokes one of the user's unrevoked sessions. Sessions of other users are reported as
+// not found, so their IDs cannot be probed.
+func (r *GormSessionRepository) Revoke(ctx context.Context, userID, id string, at time.Time) error {
+	return r.updateUnrevoked(ctx, userID, id, "revoked_at", at)
+}
+
+// updateUnrevoked sets a column of one of the user's unrevoked sessions
+func (r *GormSessionRepository) updateUnrevoked(ctx context.Context, userID, id, column string, value time.Time) error {
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("session ID cannot be empty")
+	}
+
+	result := r.db.WithContext(ctx).Model(&SessionModel{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update(column, value)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update session %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrSessionNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akeemphilbert/goro/internal/user/domain"
+)
+
+func TestGormSessionRepository(t *testing.T) {
+	db := setupTestDBWithMigration(t)
+	repo := NewGormSessionRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	sessions := []*domain.Session{
+		{ID: "s-1", UserID: "user-1", UserAgent: "Firefox", IPAddress: "192.0.2.1", CreatedAt: now.Add(-2 * time.Hour), LastActivityAt: now.Add(-time.Hour)},
+		{ID: "s-2", UserID: "user-1", UserAgent: "curl", IPAddress: "192.0.2.2", CreatedAt: now.Add(-time.Hour), LastActivityAt: now.Add(-time.Minute)},
+		{ID: "s-3", UserID: "user-1", UserAgent: "Safari", IPAddress: "192.0.2.3", CreatedAt: now.Add(-72 * time.Hour), LastActivityAt: now.Add(-48 * time.Hour)},
+		{ID: "s-4", UserID: "user-2", UserAgent: "Chrome", IPAddress: "192.0.2.4", CreatedAt: now, LastActivityAt: now},
+	}
+	for _, session := range sessions {
+		require.NoError(t, repo.Create(ctx, session))
+	}
+
+	t.Run("should list a user's active sessions most recently active first", func(t *testing.T) {
+		listed, err := repo.ListActiveByUser(ctx, "user-1", now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, "s-2", listed[0].ID)
+		assert.Equal(t, "curl", listed[0].UserAgent)
+		assert.Equal(t, "192.0.2.2", listed[0].IPAddress)
+		assert.Equal(t, "s-1", listed[1].ID)
+	})
+
+	t.Run("should count every user's active sessions", func(t *testing.T) {
+		count, err := repo.CountActive(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		count, err = repo.CountActive(ctx, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("should record activity", func(t *testing.T) {
+		require.NoError(t, repo.Touch(ctx, "user-1", "s-3", now))
+
+		listed, err := repo.ListActiveByUser(ctx, "user-1", now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, listed, 3)
+		assert.Equal(t, "s-3", listed[0].ID)
+	})
+
+	t.Run("should revoke only the user's own sessions", func(t *testing.T) {
+		err := repo.Revoke(ctx, "user-1", "s-4", now)
+		assert.True(t, errors.Is(err, domain.ErrSessionNotFound))
+
+		require.NoError(t, repo.Revoke(ctx, "user-1", "s-1", now))
+		err = repo.Revoke(ctx, "user-1", "s-1", now)
+		assert.True(t, errors.Is(err, domain.ErrSessionNotFound), "a revoked session cannot be revoked again")
+
+		listed, err := repo.ListActiveByUser(ctx, "user-1", time.Time{})
+		require.NoError(t, err)
+		for _, session := range listed {
+			assert.NotEqual(t, "s-1", session.ID)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// AESSecretSealer seals TOTP secrets with AES-256-GCM under a server key, so the user store
+// holds only ciphertext. Each sealed secret carries its own random nonce and is authenticated
+// with the owning user's ID, so it opens only for that user.
+type AESSecretSealer struct {
+	aead cipher.AEAD
+}
+
+// NewAESSecretSealer creates a sealer from a 32-byte key
+func NewAESSecretSealer(key []byte) (*AESSecretSealer, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("TOTP sealing key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TOTP sealing cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TOTP sealing cipher: %w", err)
+	}
+	return &AESSecretSealer{aead: aead}, nil
+}
+
+// Seal encrypts a user's secret, returning the nonce and ciphertext base64 encoded
+func (s *AESSecretSealer) Seal(userID string, secret []byte) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, secret, []byte(userID))
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a secret sealed by Seal, failing if it was sealed under another key, for
+// another user, or altered
+func (s *AESSecretSealer) Open(userID, sealed string) ([]byte, error) {
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed TOTP secret: %w", err)
+	}
+	if len(data) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("invalid sealed TOTP secret: too short")
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	secret, err := s.aead.Open(nil, nonce, ciphertext, []byte(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sealed TOTP secret: %w", err)
+	}
+	return secret, nil
+}
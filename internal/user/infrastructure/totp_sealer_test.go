@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAESSecretSealer(t *testing.T) {
+	sealer, err := NewAESSecretSealer(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("NewAESSecretSealer failed: %v", err)
+	}
+
+	secret := []byte("12345678901234567890")
+	sealed, err := sealer.Seal("user-1", secret)
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if bytes.Contains([]byte(sealed), secret) {
+		t.Error("Sealed secret should not contain the plaintext")
+	}
+	opened, err := sealer.Open("user-1", sealed)
+	if err != nil || !bytes.Equal(opened, secret) {
+		t.Fatalf("Open = %q, %v; want the original secret", opened, err)
+	}
+	if _, err := sealer.Open("user-2", sealed); err == nil {
+		t.Error("A secret sealed for one user should not open for another")
+	}
+
+	other, _ := NewAESSecretSealer(bytes.Repeat([]byte{8}, 32))
+	if _, err := other.Open("user-1", sealed); err == nil {
+		t.Error("A secret sealed under another key should not open")
+	}
+	if _, err := NewAESSecretSealer([]byte("short")); err == nil {
+		t.Error("A key that is not 32 bytes should be rejected")
+	}
+}
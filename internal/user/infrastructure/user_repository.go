@@ -154,5 +154,9 @@ func (r *GormUserRepository) modelToDomain(model *UserModel) (*domain.User, erro
 		UpdatedAt:   model.UpdatedAt,
 	}
 
+	if err := applyUserAuthMethods(user, model.AuthMethods); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
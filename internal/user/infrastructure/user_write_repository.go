@@ -30,18 +30,24 @@ func (r *GormUserWriteRepository) Create(ctx context.Context, user *domain.User)
 		return fmt.Errorf("user ID cannot be empty")
 	}
 
+	authMethods, err := marshalUserAuthMethods(user)
+	if err != nil {
+		return err
+	}
+
 	// Convert domain user to GORM model
 	userModel := &UserModel{
-		ID:        user.ID(),
-		WebID:     user.WebID,
-		Email:     user.Email,
-		Name:      user.Profile.Name,
-		Status:    string(user.Status),
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:          user.ID(),
+		WebID:       user.WebID,
+		Email:       user.Email,
+		Name:        user.Profile.Name,
+		Status:      string(user.Status),
+		AuthMethods: authMethods,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
 	}
 
-	err := r.db.WithContext(ctx).Create(userModel).Error
+	err = r.db.WithContext(ctx).Create(userModel).Error
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -59,15 +65,21 @@ func (r *GormUserWriteRepository) Update(ctx context.Context, user *domain.User)
 		return fmt.Errorf("user ID cannot be empty")
 	}
 
+	authMethods, err := marshalUserAuthMethods(user)
+	if err != nil {
+		return err
+	}
+
 	// Convert domain user to GORM model
 	userModel := &UserModel{
-		ID:        user.ID(),
-		WebID:     user.WebID,
-		Email:     user.Email,
-		Name:      user.Profile.Name,
-		Status:    string(user.Status),
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:          user.ID(),
+		WebID:       user.WebID,
+		Email:       user.Email,
+		Name:        user.Profile.Name,
+		Status:      string(user.Status),
+		AuthMethods: authMethods,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
 	}
 
 	result := r.db.WithContext(ctx).Model(&UserModel{}).Where("id = ?", user.ID()).Updates(userModel)
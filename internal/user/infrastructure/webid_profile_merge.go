@@ -0,0 +1,294 @@
+package infrastructure
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// foafNamespace is the FOAF vocabulary namespace
+const foafNamespace = "http://xmlns.com/foaf/0.1/"
+
+// foafPrefixPattern matches a Turtle or SPARQL-style declaration of the foaf prefix
+var foafPrefixPattern = regexp.MustCompile(`(?i)(@prefix|prefix)\s+foaf:\s*<` + regexp.QuoteMeta(foafNamespace) + `>`)
+
+// MergeWebIDProfile updates the name and email the server manages in a Turtle WebID profile
+// document: the foaf:name and foaf:mbox of the WebID. Every other statement is kept as
+// written, so additions the user made to their profile survive. Merging the same values
+// twice returns the document unchanged.
+func MergeWebIDProfile(document, webID, email, userName string) string {
+	namePredicate, mboxPredicate := "foaf:name", "foaf:mbox"
+	if !foafPrefixPattern.MatchString(document) {
+		namePredicate, mboxPredicate = "<"+foafNamespace+"name>", "<"+foafNamespace+"mbox>"
+	}
+	managed := []struct {
+		predicates []string
+		statement  string
+	}{
+		{[]string{"foaf:name", "<" + foafNamespace + "name>"}, namePredicate + ` "` + escapeTurtleString(userName) + `"`},
+		{[]string{"foaf:mbox", "<" + foafNamespace + "mbox>"}, mboxPredicate + " <mailto:" + email + ">"},
+	}
+
+	var edits []profileEdit
+	var first *turtleStatement
+	found := make([]bool, len(managed))
+	statements := scanTurtleStatements(document)
+	for i := range statements {
+		statement := &statements[i]
+		if !isWebIDSubject(statement.subject, webID) {
+			continue
+		}
+		if first == nil {
+			first = statement
+		}
+		for _, part := range statement.parts {
+			predicate, start, end := turtlePart(document, part)
+			for j, property := range managed {
+				if containsString(property.predicates, predicate) {
+					found[j] = true
+					edits = append(edits, profileEdit{start: start, end: end, text: property.statement})
+				}
+			}
+		}
+	}
+
+	if first == nil {
+		var added []string
+		for _, property := range managed {
+			added = append(added, property.statement)
+		}
+		return strings.TrimRight(document, "\n") + "\n\n<" + webID + "> " + strings.Join(added, " ;\n    ") + " .\n"
+	}
+
+	// Add the missing properties after the last predicate-object list of the WebID's statement
+	insertAt := first.parts[0].end
+	for _, part := range first.parts {
+		if _, _, end := turtlePart(document, part); end > part.start {
+			insertAt = end
+		}
+	}
+	for j, property := range managed {
+		if !found[j] {
+			edits = append(edits, profileEdit{start: insertAt, end: insertAt, text: " ;\n    " + property.statement})
+		}
+	}
+
+	// Apply edits back to front so earlier offsets stay valid
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, edit := range edits {
+		document = document[:edit.start] + edit.text + document[edit.end:]
+	}
+	return document
+}
+
+// profileEdit replaces document[start:end] with text
+type profileEdit struct {
+	start, end int
+	text       string
+}
+
+// turtleStatement is one statement of a Turtle document: its subject as written and its
+// predicate-object lists, split at top-level semicolons
+type turtleStatement struct {
+	subject string
+	parts   []textSpan
+}
+
+// textSpan is a range of offsets in a document
+type textSpan struct {
+	start, end int
+}
+
+// isWebIDSubject reports whether a subject as written names the WebID, absolutely or by its
+// fragment relative to the profile document
+func isWebIDSubject(subject, webID string) bool {
+	if subject == "<"+webID+">" {
+		return true
+	}
+	if _, fragment, ok := strings.Cut(webID, "#"); ok {
+		return subject == "<#"+fragment+">"
+	}
+	return false
+}
+
+// turtlePart returns the predicate of a predicate-object list and the offsets of its terms,
+// without surrounding whitespace and comments; an empty list has an empty predicate
+func turtlePart(document string, part textSpan) (string, int, int) {
+	start := skipTurtleSpace(document, part.start, part.end)
+	if start >= part.end {
+		return "", start, start
+	}
+
+	end := start
+	for i := start; i < part.end; {
+		next := scanTurtleTerm(document, i, part.end)
+		if next == i {
+			// Object list commas
+			next++
+		}
+		end = next
+		i = skipTurtleSpace(document, next, part.end)
+	}
+	return document[start:scanTurtleTerm(document, start, end)], start, end
+}
+
+// scanTurtleStatements splits a Turtle document into statements. Strings, IRIs, comments and
+// nested blank nodes and collections are skipped, so only top-level punctuation splits.
+func scanTurtleStatements(document string) []turtleStatement {
+	var statements []turtleStatement
+	i := 0
+	for {
+		i = skipTurtleSpace(document, i, len(document))
+		if i >= len(document) {
+			return statements
+		}
+
+		subjectEnd := scanTurtleTerm(document, i, len(document))
+		subject := document[i:subjectEnd]
+
+		// SPARQL-style directives have no terminating period
+		switch strings.ToUpper(subject) {
+		case "PREFIX":
+			i = skipTurtleSpace(document, subjectEnd, len(document))
+			i = scanTurtleTerm(document, i, len(document))
+			i = skipTurtleSpace(document, i, len(document))
+			i = scanTurtleTerm(document, i, len(document))
+			continue
+		case "BASE":
+			i = skipTurtleSpace(document, subjectEnd, len(document))
+			i = scanTurtleTerm(document, i, len(document))
+			continue
+		}
+
+		statement := turtleStatement{subject: subject}
+		depth, partStart, j := 0, subjectEnd, subjectEnd
+	scan:
+		for j < len(document) {
+			switch c := document[j]; {
+			case c == '"' || c == '\'' || c == '<':
+				j = scanTurtleTerm(document, j, len(document))
+				continue
+			case c == '#':
+				j = skipTurtleComment(document, j)
+				continue
+			case c == '[' || c == '(':
+				depth++
+			case c == ']' || c == ')':
+				depth--
+			case c == ';' && depth == 0:
+				statement.parts = append(statement.parts, textSpan{partStart, j})
+				partStart = j + 1
+			case c == '.' && depth == 0 && (j+1 == len(document) || isTurtleSpace(document[j+1]) || document[j+1] == '#'):
+				break scan
+			}
+			j++
+		}
+		statement.parts = append(statement.parts, textSpan{partStart, j})
+		statements = append(statements, statement)
+		i = j + 1
+	}
+}
+
+// scanTurtleTerm returns the end of the term starting at i: an IRI, a quoted string with any
+// language tag or datatype, a bracketed blank node or collection, or a bare token
+func scanTurtleTerm(document string, i, limit int) int {
+	if i >= limit {
+		return i
+	}
+	switch c := document[i]; c {
+	case '<':
+		if end := strings.IndexByte(document[i:limit], '>'); end >= 0 {
+			return i + end + 1
+		}
+		return limit
+	case '"', '\'':
+		quote := string(c)
+		if strings.HasPrefix(document[i:limit], strings.Repeat(quote, 3)) {
+			quote = strings.Repeat(quote, 3)
+		}
+		j := i + len(quote)
+		for j < limit && !strings.HasPrefix(document[j:limit], quote) {
+			if document[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		j += len(quote)
+		// Language tags and datatypes belong to the literal
+		if j < limit && document[j] == '@' {
+			for j < limit && !isTurtleSpace(document[j]) && !strings.ContainsRune(";,.)]", rune(document[j])) {
+				j++
+			}
+		} else if strings.HasPrefix(document[min(j, limit):limit], "^^") {
+			j = scanTurtleTerm(document, j+2, limit)
+		}
+		return min(j, limit)
+	case '[', '(':
+		depth, j := 0, i
+		for j < limit {
+			switch document[j] {
+			case '"', '\'', '<':
+				j = scanTurtleTerm(document, j, limit)
+				continue
+			case '[', '(':
+				depth++
+			case ']', ')':
+				depth--
+				if depth == 0 {
+					return j + 1
+				}
+			}
+			j++
+		}
+		return limit
+	}
+
+	j := i
+	for j < limit && !isTurtleSpace(document[j]) && !strings.ContainsRune(";,<\"[(", rune(document[j])) {
+		// A period ends a bare token only when nothing but whitespace follows it
+		if document[j] == '.' && (j+1 == limit || isTurtleSpace(document[j+1])) {
+			break
+		}
+		j++
+	}
+	return j
+}
+
+// skipTurtleSpace returns the offset of the first character at or after i that is neither
+// whitespace nor part of a comment
+func skipTurtleSpace(document string, i, limit int) int {
+	for i < limit {
+		switch {
+		case isTurtleSpace(document[i]):
+			i++
+		case document[i] == '#':
+			i = skipTurtleComment(document, i)
+		default:
+			return i
+		}
+	}
+	return limit
+}
+
+// skipTurtleComment returns the offset just past the comment starting at i
+func skipTurtleComment(document string, i int) int {
+	if end := strings.IndexByte(document[i:], '\n'); end >= 0 {
+		return i + end + 1
+	}
+	return len(document)
+}
+
+// isTurtleSpace reports whether c is Turtle whitespace
+func isTurtleSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeWebIDProfile(t *testing.T) {
+	webID := "https://example.com/users/alice#me"
+
+	t.Run("updates a generated document", func(t *testing.T) {
+		document, err := NewWebIDGenerator("https://example.com").GenerateWebIDDocument(context.Background(), webID, "alice@example.com", "Alice")
+		require.NoError(t, err)
+
+		merged := MergeWebIDProfile(document, webID, "alice@example.org", "Alice Smith")
+
+		assert.Contains(t, merged, `foaf:name "Alice Smith" ;`)
+		assert.Contains(t, merged, `foaf:mbox <mailto:alice@example.org> ;`)
+		assert.Contains(t, merged, "solid:publicTypeIndex <https://example.com/users/alice/public/index.ttl> .")
+		assert.NotContains(t, merged, `"Alice" `)
+	})
+
+	t.Run("keeps statements the user added", func(t *testing.T) {
+		document := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+<https://example.com/users/alice#me> a foaf:Person ;
+    foaf:name "Alice" ; # set at sign-up
+    foaf:knows [ foaf:name "Bob" ] ;
+    foaf:nick "ali; the \"first\"" .
+
+<https://example.com/users/carol#me> foaf:name "Carol" .
+`
+		merged := MergeWebIDProfile(document, webID, "alice@example.org", "Alice Smith")
+
+		assert.Equal(t, `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+<https://example.com/users/alice#me> a foaf:Person ;
+    foaf:name "Alice Smith" ; # set at sign-up
+    foaf:knows [ foaf:name "Bob" ] ;
+    foaf:nick "ali; the \"first\"" ;
+    foaf:mbox <mailto:alice@example.org> .
+
+<https://example.com/users/carol#me> foaf:name "Carol" .
+`, merged)
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		document := "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n"
+
+		merged := MergeWebIDProfile(document, webID, "alice@example.org", "Alice Smith")
+		assert.Equal(t, "<#me> <http://xmlns.com/foaf/0.1/name> \"Alice Smith\" ;\n    <http://xmlns.com/foaf/0.1/mbox> <mailto:alice@example.org> .\n", merged)
+		assert.Equal(t, merged, MergeWebIDProfile(merged, webID, "alice@example.org", "Alice Smith"))
+	})
+
+	t.Run("describes the WebID when the document does not", func(t *testing.T) {
+		document := "PREFIX foaf: <http://xmlns.com/foaf/0.1/>\n<https://example.com/users/carol#me> foaf:name \"Carol\" .\n"
+
+		merged := MergeWebIDProfile(document, webID, "alice@example.org", "Alice")
+		assert.Equal(t, document+"\n<https://example.com/users/alice#me> foaf:name \"Alice\" ;\n    foaf:mbox <mailto:alice@example.org> .\n", merged)
+	})
+}
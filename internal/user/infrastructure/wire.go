@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/akeemphilbert/goro/internal/conf"
 	"github.com/akeemphilbert/goro/internal/user/domain"
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -71,6 +72,27 @@ func ProvideInvitationRepository(db *gorm.DB) (domain.InvitationRepository, erro
 	return NewGormInvitationRepository(db), nil
 }
 
+func ProvideNotificationRepository(db *gorm.DB) (domain.NotificationRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	return NewGormNotificationRepository(db), nil
+}
+
+func ProvideSessionRepository(db *gorm.DB) (domain.SessionRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	return NewGormSessionRepository(db), nil
+}
+
+func ProvideAuditLogRepository(db *gorm.DB) (domain.AuditLogRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	return NewGormAuditLogRepository(db), nil
+}
+
 // Write Repository Providers
 func ProvideUserWriteRepository(db *gorm.DB) (domain.UserWriteRepository, error) {
 	if db == nil {
@@ -114,12 +136,45 @@ func ProvideFileStorage(baseDir string) (domain.FileStorage, error) {
 	return NewFileStorageAdapter(baseDir)
 }
 
+// ProvideExternalAuthClient provides the shared HTTP client for outbound OAuth/OIDC calls.
+// Defaults are applied to a copy, so the shared auth configuration is left as loaded.
+func ProvideExternalAuthClient(config *conf.Auth) *ExternalAuthClient {
+	var settings conf.Auth
+	if config != nil {
+		settings = *config
+	}
+	settings.SetDefaults()
+
+	return NewExternalAuthClient(ExternalAuthClientConfig{
+		ConnectTimeout:      time.Duration(settings.Outbound.ConnectTimeout),
+		ReadTimeout:         time.Duration(settings.Outbound.ReadTimeout),
+		WriteTimeout:        time.Duration(settings.Outbound.WriteTimeout),
+		RequestTimeout:      time.Duration(settings.Outbound.RequestTimeout),
+		MaxIdleConns:        settings.Outbound.MaxIdleConns,
+		MaxIdleConnsPerHost: settings.Outbound.MaxIdleConnsPerHost,
+	})
+}
+
+// ProvideOAuthRedirectGuard provides the allowlist and state binding for OAuth redirect URIs.
+// Defaults are applied to a copy, so the shared auth configuration is left as loaded.
+func ProvideOAuthRedirectGuard(config *conf.Auth) *OAuthRedirectGuard {
+	var settings conf.Auth
+	if config != nil {
+		settings = *config
+	}
+	settings.SetDefaults()
+
+	return NewOAuthRedirectGuard(settings.OAuthRedirectURIs, time.Duration(settings.OAuthStateTTL))
+}
+
 // Provider Sets
 var UserInfrastructureProviderSet = wire.NewSet(
 	ProvideUserDatabase,
 	ProvideWebIDGenerator,
 	ProvideFileStorage,
 	ProvideCache,
+	ProvideExternalAuthClient,
+	ProvideOAuthRedirectGuard,
 )
 
 var UserRepositoryProviderSet = wire.NewSet(
@@ -128,6 +183,9 @@ var UserRepositoryProviderSet = wire.NewSet(
 	ProvideRoleRepository,
 	ProvideAccountMemberRepository,
 	ProvideInvitationRepository,
+	ProvideNotificationRepository,
+	ProvideSessionRepository,
+	ProvideAuditLogRepository,
 )
 
 var UserWriteRepositoryProviderSet = wire.NewSet(
@@ -46,7 +46,7 @@ func TestAccountCreationWorkflow(t *testing.T) {
 	unitOfWorkFactory := setupTestUnitOfWorkFactory(t, eventDispatcher)
 
 	// Setup services
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, accountRepo)
 	accountService := application.NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo)
 
 	// Create owner user first
@@ -123,7 +123,7 @@ func TestInvitationWorkflow(t *testing.T) {
 	unitOfWorkFactory := setupTestUnitOfWorkFactory(t, eventDispatcher)
 
 	// Setup services
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, accountRepo)
 	accountService := application.NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo)
 
 	// Create owner and account
@@ -220,7 +220,7 @@ func TestMembershipManagementWorkflow(t *testing.T) {
 	unitOfWorkFactory := setupTestUnitOfWorkFactory(t, eventDispatcher)
 
 	// Setup services
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, accountRepo)
 	accountService := application.NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo)
 
 	// Create owner and account
@@ -289,7 +289,7 @@ func TestAtomicEntityRelationships(t *testing.T) {
 	unitOfWorkFactory := setupTestUnitOfWorkFactory(t, eventDispatcher)
 
 	// Setup services
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, accountRepo)
 	accountService := application.NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo)
 
 	// Create owner and account
@@ -493,7 +493,7 @@ func setupTestHTTPServer(t *testing.T) (*mux.Router, func()) {
 	unitOfWorkFactory := setupTestUnitOfWorkFactory(t, eventDispatcher)
 
 	// Setup services
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, accountRepo)
 	accountService := application.NewAccountService(unitOfWorkFactory, inviteGen, accountRepo, userRepo, roleRepo, invitationRepo, memberRepo)
 
 	// Setup logger
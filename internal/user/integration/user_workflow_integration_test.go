@@ -39,7 +39,7 @@ func TestUserRegistrationWorkflow(t *testing.T) {
 	unitOfWorkFactory := setupTestUnitOfWorkFactory(t, eventDispatcher)
 
 	// Setup user service
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, nil)
 
 	// Test data
 	profile := domain.UserProfile{
@@ -118,7 +118,7 @@ func TestUserProfileUpdateWorkflow(t *testing.T) {
 	webidGen := infrastructure.NewWebIDGenerator("https://example.com")
 
 	// Setup user service
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, nil)
 
 	// Create initial user
 	initialProfile := domain.UserProfile{
@@ -186,7 +186,7 @@ func TestUserDeletionWorkflow(t *testing.T) {
 	webidGen := infrastructure.NewWebIDGenerator("https://example.com")
 
 	// Setup user service
-	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo)
+	userService := application.NewUserService(unitOfWorkFactory, webidGen, userRepo, nil)
 
 	// Create user
 	profile := domain.UserProfile{